@@ -6,6 +6,7 @@ import (
 	database "go-backend/database/ent"
 	"go-backend/database/events"
 	"go-backend/internal/funcs"
+	"go-backend/internal/middleware"
 	"go-backend/internal/routes"
 	"go-backend/pkg/caching"
 	"go-backend/pkg/configs"
@@ -186,7 +187,19 @@ func initializeComponents(config *configs.AppConfig) (*InitResults, error) {
 
 // createGinEngine 创建和配置Gin引擎
 func createGinEngine(config *configs.AppConfig) *gin.Engine {
-	engine := gin.Default()
+	// 访问日志由 AccessLog 中间件统一记录，不使用 gin 默认的日志中间件
+	engine := gin.New()
+	// 只采用受信任代理传入的 X-Forwarded-For，否则客户端可以伪造来源IP绕过登录IP防护
+	if err := engine.SetTrustedProxies(config.Server.TrustedProxies); err != nil {
+		logging.Warn("Invalid trusted proxies %v, trusting no proxy: %v", config.Server.TrustedProxies, err)
+		_ = engine.SetTrustedProxies(nil)
+	}
+	engine.Use(gin.Recovery())
+	if config.Server.Middleware.AccessLog.Enabled {
+		engine.Use(middleware.AccessLog(config.Server.Middleware.AccessLog))
+	} else {
+		logging.Info("Access log middleware is disabled")
+	}
 
 	// 配置CORS跨域中间件
 	if config.Server.CORS.Enabled {
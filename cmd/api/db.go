@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	database "go-backend/database/ent"
 	"go-backend/pkg/configs"
 	pkgdatabase "go-backend/pkg/database"
 	"go-backend/pkg/logging"
@@ -21,6 +22,7 @@ var (
 	timeout       time.Duration
 	showResult    bool
 	excludeFields string
+	exportFilters []string
 )
 
 // import命令的参数变量
@@ -134,6 +136,84 @@ var checkDbCmd = &cobra.Command{
 	},
 }
 
+// statusDbCmd 查看数据库迁移状态
+var statusDbCmd = &cobra.Command{
+	Use:   "status",
+	Short: "查看数据库迁移状态",
+	Long:  "检查数据库结构是否为最新，并列出待执行的迁移语句（不会执行）",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbClient, err := openDatabaseWithoutMigration()
+		if err != nil {
+			return err
+		}
+		defer dbClient.Close()
+
+		status, err := pkgdatabase.MigrationStatus(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("检查迁移状态失败: %w", err)
+		}
+
+		if status.UpToDate {
+			fmt.Println("数据库结构已是最新，无需迁移")
+			return nil
+		}
+
+		fmt.Printf("数据库结构需要迁移，共 %d 条待执行语句:\n\n", len(status.Statements))
+		for _, stmt := range status.Statements {
+			fmt.Println(stmt)
+		}
+		fmt.Println("\n确认无误后执行 `db migrate` 应用迁移")
+		return nil
+	},
+}
+
+// diffDbCmd 输出待执行的迁移SQL
+var diffDbCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "输出待执行的迁移SQL",
+	Long:  "输出将数据库结构更新到最新所需执行的DDL语句（不会执行），可重定向到文件后审阅或手动执行",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dbClient, err := openDatabaseWithoutMigration()
+		if err != nil {
+			return err
+		}
+		defer dbClient.Close()
+
+		statements, err := pkgdatabase.MigrationDiff(cmd.Context())
+		if err != nil {
+			return fmt.Errorf("生成迁移SQL失败: %w", err)
+		}
+		for _, stmt := range statements {
+			fmt.Println(stmt)
+		}
+		return nil
+	},
+}
+
+// openDatabaseWithoutMigration 加载配置并连接数据库，不执行迁移也不检查迁移
+func openDatabaseWithoutMigration() (*database.Client, error) {
+	resolvedConfigPath, err := configs.ResolveConfigPath(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件路径失败: %w", err)
+	}
+
+	config, err := configs.LoadConfig(resolvedConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("加载配置失败: %w", err)
+	}
+
+	config.Database.SkipMigrateCheck = true
+	config.Database.AutoMigrate = false
+	config.Database.ConnectionCheckInterval = 0 // 禁用连接检查间隔
+
+	// 设置日志
+	logging.SetLevel(logging.ParseLogLevel(config.Logging.Level))
+	logging.SetPrefix(config.Logging.Prefix)
+	pkgdatabase.SetLogger(logging.WithName("Database"))
+
+	return pkgdatabase.InitInstance(&config.Database), nil
+}
+
 var exportDbCmd = &cobra.Command{
 	Use:   "export",
 	Short: "导出数据库表数据到JSON文件",
@@ -195,6 +275,17 @@ var exportDbCmd = &cobra.Command{
 			}
 		}
 
+		for _, expr := range exportFilters {
+			entityName, filter, err := pkgdatabase.ParseExportFilter(expr)
+			if err != nil {
+				return err
+			}
+			if exportConfig.Filters == nil {
+				exportConfig.Filters = make(map[string][]pkgdatabase.ExportFilter)
+			}
+			exportConfig.Filters[entityName] = append(exportConfig.Filters[entityName], filter)
+		}
+
 		// 执行导出
 		fmt.Println("开始导出数据库表...")
 		result, err := pkgdatabase.ExportAllTables(client, exportConfig)
@@ -264,6 +355,7 @@ var importDbCmd = &cobra.Command{
 			BatchSize:         batchSize,
 			SkipExisting:      skipExisting,
 			ClearBeforeImport: clearBeforeImport,
+			Driver:            config.Database.Driver,
 		}
 
 		// 处理包含和排除列表
@@ -296,6 +388,9 @@ var importDbCmd = &cobra.Command{
 		fmt.Printf("总记录数: %d\n", result.TotalRecords)
 		fmt.Printf("导入记录数: %d\n", result.ImportedRecords)
 		fmt.Printf("跳过记录数: %d\n", result.SkippedRecords)
+		for _, adjustment := range result.SequenceAdjustments {
+			fmt.Printf("已调整自增序列: %s (%s) -> %d\n", adjustment.Table, adjustment.Sequence, adjustment.NextValue)
+		}
 
 		if importShowResult {
 			fmt.Println("\n详细结果:")
@@ -321,6 +416,8 @@ func init() {
 	// 添加子命令到db
 	dbCmd.AddCommand(migrateDbCmd)
 	dbCmd.AddCommand(checkDbCmd)
+	dbCmd.AddCommand(statusDbCmd)
+	dbCmd.AddCommand(diffDbCmd)
 	dbCmd.AddCommand(exportDbCmd)
 	dbCmd.AddCommand(importDbCmd)
 
@@ -332,6 +429,7 @@ func init() {
 	exportDbCmd.Flags().DurationVarP(&timeout, "timeout", "t", time.Minute*10, "导出超时时间")
 	exportDbCmd.Flags().BoolVarP(&showResult, "result", "r", false, "是否显示详细导出结果")
 	exportDbCmd.Flags().StringVarP(&excludeFields, "exclude-fields", "f", "", "导出时排除指定的字段，多个字段用逗号分隔")
+	exportDbCmd.Flags().StringArrayVar(&exportFilters, "filter", nil, "按条件过滤导出的记录，格式为 实体.字段<操作符>值（如 WorkflowApplication.status=published），可重复指定")
 
 	// 为import命令添加参数
 	importDbCmd.Flags().StringVarP(&inputDir, "input", "d", "./exports", "输入目录")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go-backend/internal/funcs"
 	"go-backend/internal/subscription"
+	"go-backend/pkg/configs"
 	"go-backend/pkg/logging"
 	"go-backend/pkg/messaging"
 	"go-backend/pkg/utils"
@@ -27,6 +28,16 @@ func setupHandlers(ctx context.Context) {
 	cleaner := messaging.NewStreamCleaner(messaging.ChannelOpenCheck)
 	cleaner.StartCleanup(ctx)
 
+	// 启动延迟消息投递器
+	messaging.StartDelayedDispatcher(ctx)
+
+	// 启动 Redis 频道到 WebSocket 主题的桥接
+	for _, bridge := range configs.GetConfig().Server.Components.Messaging.Bridges {
+		if err := messaging.BridgeToWebSocket(ctx, bridge.Channel, bridge.Topic); err != nil {
+			logging.Error("Failed to start websocket bridge %s -> %s: %v", bridge.Channel, bridge.Topic, err)
+		}
+	}
+
 	// 注册处理器来处理创建channel的请求
 	logging.Info("Register channel open check handler")
 	messaging.RegisterHandler(messaging.ChannelOpenCheck, func(message messaging.MessageStruct) error {
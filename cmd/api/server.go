@@ -11,6 +11,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/redis/go-redis/v9"
@@ -60,6 +61,9 @@ func runServer(cmd *cobra.Command, args []string) error {
 	return startServer(config, engine, results.dbClient, results.redisClient)
 }
 
+// shutdownTimeout 优雅关闭时等待进行中请求完成的最长时间
+const shutdownTimeout = 15 * time.Second
+
 // startServer 启动HTTP服务器并处理优雅关闭
 func startServer(config *configs.AppConfig, engine *gin.Engine, dbClient *database.Client, redisClient *redis.Client) error {
 	srv := &http.Server{
@@ -67,20 +71,46 @@ func startServer(config *configs.AppConfig, engine *gin.Engine, dbClient *databa
 		Handler: engine,
 	}
 
+	var redirectSrv *http.Server
+	if config.Server.TLS.Enabled {
+		var err error
+		redirectSrv, err = configureTLS(srv, config.Server.TLS)
+		if err != nil {
+			return fmt.Errorf("failed to configure tls: %w", err)
+		}
+	}
+
 	// 在goroutine中启动服务器
 	go func() {
 		// 尝试从banner.txt读取并显示字符图
 		if bannerContent, err := os.ReadFile("banner.txt"); err == nil {
 			bannerStr := string(bannerContent)
 			toShow := configs.ResolveConfigVariables(bannerStr)
-			logging.Info(toShow)
+			logging.Info("%s", toShow)
+		}
+		var err error
+		if config.Server.TLS.Enabled {
+			logging.Info("Server is starting on %s (https)", config.Server.Port)
+			// 证书已在 TLSConfig 中配置
+			err = srv.ListenAndServeTLS("", "")
+		} else {
+			logging.Info("Server is starting on %s", config.Server.Port)
+			err = srv.ListenAndServe()
 		}
-		logging.Info("Server is starting on %s", config.Server.Port)
-		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err != nil && err != http.ErrServerClosed {
 			logging.Fatal("Server failed to start: %v", err)
 		}
 	}()
 
+	if redirectSrv != nil {
+		go func() {
+			logging.Info("HTTPS redirect server is starting on %s", redirectSrv.Addr)
+			if err := redirectSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				logging.Error("HTTPS redirect server failed: %v", err)
+			}
+		}()
+	}
+
 	// 优雅关闭服务器
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -96,8 +126,15 @@ func startServer(config *configs.AppConfig, engine *gin.Engine, dbClient *databa
 
 	funcs.Cleanup()
 
-	// 关闭HTTP服务器
-	if err := srv.Shutdown(ctx); err != nil {
+	// 关闭HTTP服务器，等待进行中的请求完成
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer shutdownCancel()
+	if redirectSrv != nil {
+		if err := redirectSrv.Shutdown(shutdownCtx); err != nil {
+			logging.Error("HTTPS redirect server shutdown failed: %v", err)
+		}
+	}
+	if err := srv.Shutdown(shutdownCtx); err != nil {
 		logging.Fatal("Server shutdown failed: %v", err)
 	}
 
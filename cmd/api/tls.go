@@ -0,0 +1,87 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+
+	"go-backend/pkg/configs"
+
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// configureTLS 按配置为服务器设置HTTPS，返回HTTP重定向服务器（未启用重定向时为nil）
+// 启用自动证书时，重定向服务器同时负责响应 ACME HTTP-01 验证请求
+func configureTLS(srv *http.Server, cfg configs.TLSConfig) (*http.Server, error) {
+	var redirectHandler http.Handler = httpsRedirectHandler(srv.Addr)
+
+	if cfg.AutoCert.Enabled {
+		if len(cfg.AutoCert.Domains) == 0 {
+			return nil, fmt.Errorf("server.tls.auto_cert.domains must not be empty when auto cert is enabled")
+		}
+		manager := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			HostPolicy: autocert.HostWhitelist(cfg.AutoCert.Domains...),
+			Cache:      autocert.DirCache(cfg.AutoCert.CacheDir),
+			Email:      cfg.AutoCert.Email,
+		}
+		if cfg.AutoCert.DirectoryURL != "" {
+			manager.Client = &acme.Client{DirectoryURL: cfg.AutoCert.DirectoryURL}
+		}
+		srv.TLSConfig = manager.TLSConfig()
+		redirectHandler = manager.HTTPHandler(redirectHandler)
+	} else {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("server.tls.cert_file and server.tls.key_file are required when tls is enabled")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls certificate: %w", err)
+		}
+		srv.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	if !cfg.HTTP2 {
+		// 非nil的空映射会关闭 net/http 自动启用的 HTTP/2
+		srv.TLSNextProto = map[string]func(*http.Server, *tls.Conn, http.Handler){}
+		srv.TLSConfig.NextProtos = removeProto(srv.TLSConfig.NextProtos, "h2")
+	}
+
+	if !cfg.Redirect {
+		return nil, nil
+	}
+	return &http.Server{
+		Addr:    cfg.RedirectPort,
+		Handler: redirectHandler,
+	}, nil
+}
+
+// httpsRedirectHandler 将HTTP请求永久重定向到HTTPS地址
+func httpsRedirectHandler(httpsAddr string) http.Handler {
+	_, port, _ := net.SplitHostPort(httpsAddr)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if port != "" && port != "443" {
+			host = net.JoinHostPort(host, port)
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
+
+func removeProto(protos []string, target string) []string {
+	result := make([]string, 0, len(protos))
+	for _, p := range protos {
+		if p != target {
+			result = append(result, p)
+		}
+	}
+	return result
+}
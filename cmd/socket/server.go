@@ -76,7 +76,7 @@ func startServer(config *configs.AppConfig, redisClient *redis.Client) error {
 		if bannerContent, err := os.ReadFile("banner.txt"); err == nil {
 			bannerStr := string(bannerContent)
 			toShow := configs.ResolveConfigVariables(bannerStr)
-			logging.Info(toShow)
+			logging.Info("%s", toShow)
 		}
 		logging.Info("WsServer is starting on %s", config.Socket.Port)
 		if err := wsServer.Start(config.Socket.Port); err != nil {
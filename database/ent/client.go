@@ -17,6 +17,7 @@ import (
 	"go-backend/database/ent/attachment"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
+	"go-backend/database/ent/databasebackup"
 	"go-backend/database/ent/logging"
 	"go-backend/database/ent/loginrecord"
 	"go-backend/database/ent/oauthapplication"
@@ -39,11 +40,14 @@ import (
 	"go-backend/database/ent/userrole"
 	"go-backend/database/ent/verifycode"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflowexecutionlog"
 	"go-backend/database/ent/workflownode"
 	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
 
 	"entgo.io/ent"
@@ -71,6 +75,8 @@ type Client struct {
 	ClientDevice *ClientDeviceClient
 	// Credential is the client for interacting with the Credential builders.
 	Credential *CredentialClient
+	// DatabaseBackup is the client for interacting with the DatabaseBackup builders.
+	DatabaseBackup *DatabaseBackupClient
 	// Logging is the client for interacting with the Logging builders.
 	Logging *LoggingClient
 	// LoginRecord is the client for interacting with the LoginRecord builders.
@@ -115,6 +121,8 @@ type Client struct {
 	VerifyCode *VerifyCodeClient
 	// WorkflowApplication is the client for interacting with the WorkflowApplication builders.
 	WorkflowApplication *WorkflowApplicationClient
+	// WorkflowApplicationShare is the client for interacting with the WorkflowApplicationShare builders.
+	WorkflowApplicationShare *WorkflowApplicationShareClient
 	// WorkflowEdge is the client for interacting with the WorkflowEdge builders.
 	WorkflowEdge *WorkflowEdgeClient
 	// WorkflowExecution is the client for interacting with the WorkflowExecution builders.
@@ -125,6 +133,10 @@ type Client struct {
 	WorkflowNode *WorkflowNodeClient
 	// WorkflowNodeExecution is the client for interacting with the WorkflowNodeExecution builders.
 	WorkflowNodeExecution *WorkflowNodeExecutionClient
+	// WorkflowSchedule is the client for interacting with the WorkflowSchedule builders.
+	WorkflowSchedule *WorkflowScheduleClient
+	// WorkflowSecret is the client for interacting with the WorkflowSecret builders.
+	WorkflowSecret *WorkflowSecretClient
 	// WorkflowVersion is the client for interacting with the WorkflowVersion builders.
 	WorkflowVersion *WorkflowVersionClient
 }
@@ -144,6 +156,7 @@ func (c *Client) init() {
 	c.Attachment = NewAttachmentClient(c.config)
 	c.ClientDevice = NewClientDeviceClient(c.config)
 	c.Credential = NewCredentialClient(c.config)
+	c.DatabaseBackup = NewDatabaseBackupClient(c.config)
 	c.Logging = NewLoggingClient(c.config)
 	c.LoginRecord = NewLoginRecordClient(c.config)
 	c.OauthApplication = NewOauthApplicationClient(c.config)
@@ -166,11 +179,14 @@ func (c *Client) init() {
 	c.UserRole = NewUserRoleClient(c.config)
 	c.VerifyCode = NewVerifyCodeClient(c.config)
 	c.WorkflowApplication = NewWorkflowApplicationClient(c.config)
+	c.WorkflowApplicationShare = NewWorkflowApplicationShareClient(c.config)
 	c.WorkflowEdge = NewWorkflowEdgeClient(c.config)
 	c.WorkflowExecution = NewWorkflowExecutionClient(c.config)
 	c.WorkflowExecutionLog = NewWorkflowExecutionLogClient(c.config)
 	c.WorkflowNode = NewWorkflowNodeClient(c.config)
 	c.WorkflowNodeExecution = NewWorkflowNodeExecutionClient(c.config)
+	c.WorkflowSchedule = NewWorkflowScheduleClient(c.config)
+	c.WorkflowSecret = NewWorkflowSecretClient(c.config)
 	c.WorkflowVersion = NewWorkflowVersionClient(c.config)
 }
 
@@ -262,42 +278,46 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 	cfg := c.config
 	cfg.driver = tx
 	return &Tx{
-		ctx:                    ctx,
-		config:                 cfg,
-		APIAuth:                NewAPIAuthClient(cfg),
-		Address:                NewAddressClient(cfg),
-		Area:                   NewAreaClient(cfg),
-		Attachment:             NewAttachmentClient(cfg),
-		ClientDevice:           NewClientDeviceClient(cfg),
-		Credential:             NewCredentialClient(cfg),
-		Logging:                NewLoggingClient(cfg),
-		LoginRecord:            NewLoginRecordClient(cfg),
-		OauthApplication:       NewOauthApplicationClient(cfg),
-		OauthAuthorizationCode: NewOauthAuthorizationCodeClient(cfg),
-		OauthProvider:          NewOauthProviderClient(cfg),
-		OauthState:             NewOauthStateClient(cfg),
-		OauthToken:             NewOauthTokenClient(cfg),
-		OauthUser:              NewOauthUserClient(cfg),
-		OauthUserAuthorization: NewOauthUserAuthorizationClient(cfg),
-		Permission:             NewPermissionClient(cfg),
-		Role:                   NewRoleClient(cfg),
-		RolePermission:         NewRolePermissionClient(cfg),
-		Scan:                   NewScanClient(cfg),
-		Scope:                  NewScopeClient(cfg),
-		Station:                NewStationClient(cfg),
-		Subway:                 NewSubwayClient(cfg),
-		SubwayStation:          NewSubwayStationClient(cfg),
-		SystemMonitor:          NewSystemMonitorClient(cfg),
-		User:                   NewUserClient(cfg),
-		UserRole:               NewUserRoleClient(cfg),
-		VerifyCode:             NewVerifyCodeClient(cfg),
-		WorkflowApplication:    NewWorkflowApplicationClient(cfg),
-		WorkflowEdge:           NewWorkflowEdgeClient(cfg),
-		WorkflowExecution:      NewWorkflowExecutionClient(cfg),
-		WorkflowExecutionLog:   NewWorkflowExecutionLogClient(cfg),
-		WorkflowNode:           NewWorkflowNodeClient(cfg),
-		WorkflowNodeExecution:  NewWorkflowNodeExecutionClient(cfg),
-		WorkflowVersion:        NewWorkflowVersionClient(cfg),
+		ctx:                      ctx,
+		config:                   cfg,
+		APIAuth:                  NewAPIAuthClient(cfg),
+		Address:                  NewAddressClient(cfg),
+		Area:                     NewAreaClient(cfg),
+		Attachment:               NewAttachmentClient(cfg),
+		ClientDevice:             NewClientDeviceClient(cfg),
+		Credential:               NewCredentialClient(cfg),
+		DatabaseBackup:           NewDatabaseBackupClient(cfg),
+		Logging:                  NewLoggingClient(cfg),
+		LoginRecord:              NewLoginRecordClient(cfg),
+		OauthApplication:         NewOauthApplicationClient(cfg),
+		OauthAuthorizationCode:   NewOauthAuthorizationCodeClient(cfg),
+		OauthProvider:            NewOauthProviderClient(cfg),
+		OauthState:               NewOauthStateClient(cfg),
+		OauthToken:               NewOauthTokenClient(cfg),
+		OauthUser:                NewOauthUserClient(cfg),
+		OauthUserAuthorization:   NewOauthUserAuthorizationClient(cfg),
+		Permission:               NewPermissionClient(cfg),
+		Role:                     NewRoleClient(cfg),
+		RolePermission:           NewRolePermissionClient(cfg),
+		Scan:                     NewScanClient(cfg),
+		Scope:                    NewScopeClient(cfg),
+		Station:                  NewStationClient(cfg),
+		Subway:                   NewSubwayClient(cfg),
+		SubwayStation:            NewSubwayStationClient(cfg),
+		SystemMonitor:            NewSystemMonitorClient(cfg),
+		User:                     NewUserClient(cfg),
+		UserRole:                 NewUserRoleClient(cfg),
+		VerifyCode:               NewVerifyCodeClient(cfg),
+		WorkflowApplication:      NewWorkflowApplicationClient(cfg),
+		WorkflowApplicationShare: NewWorkflowApplicationShareClient(cfg),
+		WorkflowEdge:             NewWorkflowEdgeClient(cfg),
+		WorkflowExecution:        NewWorkflowExecutionClient(cfg),
+		WorkflowExecutionLog:     NewWorkflowExecutionLogClient(cfg),
+		WorkflowNode:             NewWorkflowNodeClient(cfg),
+		WorkflowNodeExecution:    NewWorkflowNodeExecutionClient(cfg),
+		WorkflowSchedule:         NewWorkflowScheduleClient(cfg),
+		WorkflowSecret:           NewWorkflowSecretClient(cfg),
+		WorkflowVersion:          NewWorkflowVersionClient(cfg),
 	}, nil
 }
 
@@ -315,42 +335,46 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 	cfg := c.config
 	cfg.driver = &txDriver{tx: tx, drv: c.driver}
 	return &Tx{
-		ctx:                    ctx,
-		config:                 cfg,
-		APIAuth:                NewAPIAuthClient(cfg),
-		Address:                NewAddressClient(cfg),
-		Area:                   NewAreaClient(cfg),
-		Attachment:             NewAttachmentClient(cfg),
-		ClientDevice:           NewClientDeviceClient(cfg),
-		Credential:             NewCredentialClient(cfg),
-		Logging:                NewLoggingClient(cfg),
-		LoginRecord:            NewLoginRecordClient(cfg),
-		OauthApplication:       NewOauthApplicationClient(cfg),
-		OauthAuthorizationCode: NewOauthAuthorizationCodeClient(cfg),
-		OauthProvider:          NewOauthProviderClient(cfg),
-		OauthState:             NewOauthStateClient(cfg),
-		OauthToken:             NewOauthTokenClient(cfg),
-		OauthUser:              NewOauthUserClient(cfg),
-		OauthUserAuthorization: NewOauthUserAuthorizationClient(cfg),
-		Permission:             NewPermissionClient(cfg),
-		Role:                   NewRoleClient(cfg),
-		RolePermission:         NewRolePermissionClient(cfg),
-		Scan:                   NewScanClient(cfg),
-		Scope:                  NewScopeClient(cfg),
-		Station:                NewStationClient(cfg),
-		Subway:                 NewSubwayClient(cfg),
-		SubwayStation:          NewSubwayStationClient(cfg),
-		SystemMonitor:          NewSystemMonitorClient(cfg),
-		User:                   NewUserClient(cfg),
-		UserRole:               NewUserRoleClient(cfg),
-		VerifyCode:             NewVerifyCodeClient(cfg),
-		WorkflowApplication:    NewWorkflowApplicationClient(cfg),
-		WorkflowEdge:           NewWorkflowEdgeClient(cfg),
-		WorkflowExecution:      NewWorkflowExecutionClient(cfg),
-		WorkflowExecutionLog:   NewWorkflowExecutionLogClient(cfg),
-		WorkflowNode:           NewWorkflowNodeClient(cfg),
-		WorkflowNodeExecution:  NewWorkflowNodeExecutionClient(cfg),
-		WorkflowVersion:        NewWorkflowVersionClient(cfg),
+		ctx:                      ctx,
+		config:                   cfg,
+		APIAuth:                  NewAPIAuthClient(cfg),
+		Address:                  NewAddressClient(cfg),
+		Area:                     NewAreaClient(cfg),
+		Attachment:               NewAttachmentClient(cfg),
+		ClientDevice:             NewClientDeviceClient(cfg),
+		Credential:               NewCredentialClient(cfg),
+		DatabaseBackup:           NewDatabaseBackupClient(cfg),
+		Logging:                  NewLoggingClient(cfg),
+		LoginRecord:              NewLoginRecordClient(cfg),
+		OauthApplication:         NewOauthApplicationClient(cfg),
+		OauthAuthorizationCode:   NewOauthAuthorizationCodeClient(cfg),
+		OauthProvider:            NewOauthProviderClient(cfg),
+		OauthState:               NewOauthStateClient(cfg),
+		OauthToken:               NewOauthTokenClient(cfg),
+		OauthUser:                NewOauthUserClient(cfg),
+		OauthUserAuthorization:   NewOauthUserAuthorizationClient(cfg),
+		Permission:               NewPermissionClient(cfg),
+		Role:                     NewRoleClient(cfg),
+		RolePermission:           NewRolePermissionClient(cfg),
+		Scan:                     NewScanClient(cfg),
+		Scope:                    NewScopeClient(cfg),
+		Station:                  NewStationClient(cfg),
+		Subway:                   NewSubwayClient(cfg),
+		SubwayStation:            NewSubwayStationClient(cfg),
+		SystemMonitor:            NewSystemMonitorClient(cfg),
+		User:                     NewUserClient(cfg),
+		UserRole:                 NewUserRoleClient(cfg),
+		VerifyCode:               NewVerifyCodeClient(cfg),
+		WorkflowApplication:      NewWorkflowApplicationClient(cfg),
+		WorkflowApplicationShare: NewWorkflowApplicationShareClient(cfg),
+		WorkflowEdge:             NewWorkflowEdgeClient(cfg),
+		WorkflowExecution:        NewWorkflowExecutionClient(cfg),
+		WorkflowExecutionLog:     NewWorkflowExecutionLogClient(cfg),
+		WorkflowNode:             NewWorkflowNodeClient(cfg),
+		WorkflowNodeExecution:    NewWorkflowNodeExecutionClient(cfg),
+		WorkflowSchedule:         NewWorkflowScheduleClient(cfg),
+		WorkflowSecret:           NewWorkflowSecretClient(cfg),
+		WorkflowVersion:          NewWorkflowVersionClient(cfg),
 	}, nil
 }
 
@@ -381,13 +405,14 @@ func (c *Client) Close() error {
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
 		c.APIAuth, c.Address, c.Area, c.Attachment, c.ClientDevice, c.Credential,
-		c.Logging, c.LoginRecord, c.OauthApplication, c.OauthAuthorizationCode,
-		c.OauthProvider, c.OauthState, c.OauthToken, c.OauthUser,
-		c.OauthUserAuthorization, c.Permission, c.Role, c.RolePermission, c.Scan,
-		c.Scope, c.Station, c.Subway, c.SubwayStation, c.SystemMonitor, c.User,
-		c.UserRole, c.VerifyCode, c.WorkflowApplication, c.WorkflowEdge,
-		c.WorkflowExecution, c.WorkflowExecutionLog, c.WorkflowNode,
-		c.WorkflowNodeExecution, c.WorkflowVersion,
+		c.DatabaseBackup, c.Logging, c.LoginRecord, c.OauthApplication,
+		c.OauthAuthorizationCode, c.OauthProvider, c.OauthState, c.OauthToken,
+		c.OauthUser, c.OauthUserAuthorization, c.Permission, c.Role, c.RolePermission,
+		c.Scan, c.Scope, c.Station, c.Subway, c.SubwayStation, c.SystemMonitor, c.User,
+		c.UserRole, c.VerifyCode, c.WorkflowApplication, c.WorkflowApplicationShare,
+		c.WorkflowEdge, c.WorkflowExecution, c.WorkflowExecutionLog, c.WorkflowNode,
+		c.WorkflowNodeExecution, c.WorkflowSchedule, c.WorkflowSecret,
+		c.WorkflowVersion,
 	} {
 		n.Use(hooks...)
 	}
@@ -398,13 +423,14 @@ func (c *Client) Use(hooks ...Hook) {
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
 		c.APIAuth, c.Address, c.Area, c.Attachment, c.ClientDevice, c.Credential,
-		c.Logging, c.LoginRecord, c.OauthApplication, c.OauthAuthorizationCode,
-		c.OauthProvider, c.OauthState, c.OauthToken, c.OauthUser,
-		c.OauthUserAuthorization, c.Permission, c.Role, c.RolePermission, c.Scan,
-		c.Scope, c.Station, c.Subway, c.SubwayStation, c.SystemMonitor, c.User,
-		c.UserRole, c.VerifyCode, c.WorkflowApplication, c.WorkflowEdge,
-		c.WorkflowExecution, c.WorkflowExecutionLog, c.WorkflowNode,
-		c.WorkflowNodeExecution, c.WorkflowVersion,
+		c.DatabaseBackup, c.Logging, c.LoginRecord, c.OauthApplication,
+		c.OauthAuthorizationCode, c.OauthProvider, c.OauthState, c.OauthToken,
+		c.OauthUser, c.OauthUserAuthorization, c.Permission, c.Role, c.RolePermission,
+		c.Scan, c.Scope, c.Station, c.Subway, c.SubwayStation, c.SystemMonitor, c.User,
+		c.UserRole, c.VerifyCode, c.WorkflowApplication, c.WorkflowApplicationShare,
+		c.WorkflowEdge, c.WorkflowExecution, c.WorkflowExecutionLog, c.WorkflowNode,
+		c.WorkflowNodeExecution, c.WorkflowSchedule, c.WorkflowSecret,
+		c.WorkflowVersion,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -425,6 +451,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.ClientDevice.mutate(ctx, m)
 	case *CredentialMutation:
 		return c.Credential.mutate(ctx, m)
+	case *DatabaseBackupMutation:
+		return c.DatabaseBackup.mutate(ctx, m)
 	case *LoggingMutation:
 		return c.Logging.mutate(ctx, m)
 	case *LoginRecordMutation:
@@ -469,6 +497,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.VerifyCode.mutate(ctx, m)
 	case *WorkflowApplicationMutation:
 		return c.WorkflowApplication.mutate(ctx, m)
+	case *WorkflowApplicationShareMutation:
+		return c.WorkflowApplicationShare.mutate(ctx, m)
 	case *WorkflowEdgeMutation:
 		return c.WorkflowEdge.mutate(ctx, m)
 	case *WorkflowExecutionMutation:
@@ -479,6 +509,10 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.WorkflowNode.mutate(ctx, m)
 	case *WorkflowNodeExecutionMutation:
 		return c.WorkflowNodeExecution.mutate(ctx, m)
+	case *WorkflowScheduleMutation:
+		return c.WorkflowSchedule.mutate(ctx, m)
+	case *WorkflowSecretMutation:
+		return c.WorkflowSecret.mutate(ctx, m)
 	case *WorkflowVersionMutation:
 		return c.WorkflowVersion.mutate(ctx, m)
 	default:
@@ -1440,6 +1474,140 @@ func (c *CredentialClient) mutate(ctx context.Context, m *CredentialMutation) (V
 	}
 }
 
+// DatabaseBackupClient is a client for the DatabaseBackup schema.
+type DatabaseBackupClient struct {
+	config
+}
+
+// NewDatabaseBackupClient returns a client for the DatabaseBackup from the given config.
+func NewDatabaseBackupClient(c config) *DatabaseBackupClient {
+	return &DatabaseBackupClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `databasebackup.Hooks(f(g(h())))`.
+func (c *DatabaseBackupClient) Use(hooks ...Hook) {
+	c.hooks.DatabaseBackup = append(c.hooks.DatabaseBackup, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `databasebackup.Intercept(f(g(h())))`.
+func (c *DatabaseBackupClient) Intercept(interceptors ...Interceptor) {
+	c.inters.DatabaseBackup = append(c.inters.DatabaseBackup, interceptors...)
+}
+
+// Create returns a builder for creating a DatabaseBackup entity.
+func (c *DatabaseBackupClient) Create() *DatabaseBackupCreate {
+	mutation := newDatabaseBackupMutation(c.config, OpCreate)
+	return &DatabaseBackupCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of DatabaseBackup entities.
+func (c *DatabaseBackupClient) CreateBulk(builders ...*DatabaseBackupCreate) *DatabaseBackupCreateBulk {
+	return &DatabaseBackupCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *DatabaseBackupClient) MapCreateBulk(slice any, setFunc func(*DatabaseBackupCreate, int)) *DatabaseBackupCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &DatabaseBackupCreateBulk{err: fmt.Errorf("calling to DatabaseBackupClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*DatabaseBackupCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &DatabaseBackupCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for DatabaseBackup.
+func (c *DatabaseBackupClient) Update() *DatabaseBackupUpdate {
+	mutation := newDatabaseBackupMutation(c.config, OpUpdate)
+	return &DatabaseBackupUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *DatabaseBackupClient) UpdateOne(_m *DatabaseBackup) *DatabaseBackupUpdateOne {
+	mutation := newDatabaseBackupMutation(c.config, OpUpdateOne, withDatabaseBackup(_m))
+	return &DatabaseBackupUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *DatabaseBackupClient) UpdateOneID(id uint64) *DatabaseBackupUpdateOne {
+	mutation := newDatabaseBackupMutation(c.config, OpUpdateOne, withDatabaseBackupID(id))
+	return &DatabaseBackupUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for DatabaseBackup.
+func (c *DatabaseBackupClient) Delete() *DatabaseBackupDelete {
+	mutation := newDatabaseBackupMutation(c.config, OpDelete)
+	return &DatabaseBackupDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *DatabaseBackupClient) DeleteOne(_m *DatabaseBackup) *DatabaseBackupDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *DatabaseBackupClient) DeleteOneID(id uint64) *DatabaseBackupDeleteOne {
+	builder := c.Delete().Where(databasebackup.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &DatabaseBackupDeleteOne{builder}
+}
+
+// Query returns a query builder for DatabaseBackup.
+func (c *DatabaseBackupClient) Query() *DatabaseBackupQuery {
+	return &DatabaseBackupQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeDatabaseBackup},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a DatabaseBackup entity by its id.
+func (c *DatabaseBackupClient) Get(ctx context.Context, id uint64) (*DatabaseBackup, error) {
+	return c.Query().Where(databasebackup.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *DatabaseBackupClient) GetX(ctx context.Context, id uint64) *DatabaseBackup {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *DatabaseBackupClient) Hooks() []Hook {
+	hooks := c.hooks.DatabaseBackup
+	return append(hooks[:len(hooks):len(hooks)], databasebackup.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *DatabaseBackupClient) Interceptors() []Interceptor {
+	return c.inters.DatabaseBackup
+}
+
+func (c *DatabaseBackupClient) mutate(ctx context.Context, m *DatabaseBackupMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&DatabaseBackupCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&DatabaseBackupUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&DatabaseBackupUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&DatabaseBackupDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown DatabaseBackup mutation op: %q", m.Op())
+	}
+}
+
 // LoggingClient is a client for the Logging schema.
 type LoggingClient struct {
 	config
@@ -5229,6 +5397,54 @@ func (c *WorkflowApplicationClient) QueryExecutions(_m *WorkflowApplication) *Wo
 	return query
 }
 
+// QuerySchedules queries the schedules edge of a WorkflowApplication.
+func (c *WorkflowApplicationClient) QuerySchedules(_m *WorkflowApplication) *WorkflowScheduleQuery {
+	query := (&WorkflowScheduleClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, id),
+			sqlgraph.To(workflowschedule.Table, workflowschedule.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SchedulesTable, workflowapplication.SchedulesColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QuerySecrets queries the secrets edge of a WorkflowApplication.
+func (c *WorkflowApplicationClient) QuerySecrets(_m *WorkflowApplication) *WorkflowSecretQuery {
+	query := (&WorkflowSecretClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, id),
+			sqlgraph.To(workflowsecret.Table, workflowsecret.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SecretsTable, workflowapplication.SecretsColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// QueryShares queries the shares edge of a WorkflowApplication.
+func (c *WorkflowApplicationClient) QueryShares(_m *WorkflowApplication) *WorkflowApplicationShareQuery {
+	query := (&WorkflowApplicationShareClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, id),
+			sqlgraph.To(workflowapplicationshare.Table, workflowapplicationshare.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SharesTable, workflowapplication.SharesColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
 // Hooks returns the client hooks.
 func (c *WorkflowApplicationClient) Hooks() []Hook {
 	hooks := c.hooks.WorkflowApplication
@@ -5256,6 +5472,156 @@ func (c *WorkflowApplicationClient) mutate(ctx context.Context, m *WorkflowAppli
 	}
 }
 
+// WorkflowApplicationShareClient is a client for the WorkflowApplicationShare schema.
+type WorkflowApplicationShareClient struct {
+	config
+}
+
+// NewWorkflowApplicationShareClient returns a client for the WorkflowApplicationShare from the given config.
+func NewWorkflowApplicationShareClient(c config) *WorkflowApplicationShareClient {
+	return &WorkflowApplicationShareClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `workflowapplicationshare.Hooks(f(g(h())))`.
+func (c *WorkflowApplicationShareClient) Use(hooks ...Hook) {
+	c.hooks.WorkflowApplicationShare = append(c.hooks.WorkflowApplicationShare, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `workflowapplicationshare.Intercept(f(g(h())))`.
+func (c *WorkflowApplicationShareClient) Intercept(interceptors ...Interceptor) {
+	c.inters.WorkflowApplicationShare = append(c.inters.WorkflowApplicationShare, interceptors...)
+}
+
+// Create returns a builder for creating a WorkflowApplicationShare entity.
+func (c *WorkflowApplicationShareClient) Create() *WorkflowApplicationShareCreate {
+	mutation := newWorkflowApplicationShareMutation(c.config, OpCreate)
+	return &WorkflowApplicationShareCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of WorkflowApplicationShare entities.
+func (c *WorkflowApplicationShareClient) CreateBulk(builders ...*WorkflowApplicationShareCreate) *WorkflowApplicationShareCreateBulk {
+	return &WorkflowApplicationShareCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *WorkflowApplicationShareClient) MapCreateBulk(slice any, setFunc func(*WorkflowApplicationShareCreate, int)) *WorkflowApplicationShareCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &WorkflowApplicationShareCreateBulk{err: fmt.Errorf("calling to WorkflowApplicationShareClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*WorkflowApplicationShareCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &WorkflowApplicationShareCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for WorkflowApplicationShare.
+func (c *WorkflowApplicationShareClient) Update() *WorkflowApplicationShareUpdate {
+	mutation := newWorkflowApplicationShareMutation(c.config, OpUpdate)
+	return &WorkflowApplicationShareUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *WorkflowApplicationShareClient) UpdateOne(_m *WorkflowApplicationShare) *WorkflowApplicationShareUpdateOne {
+	mutation := newWorkflowApplicationShareMutation(c.config, OpUpdateOne, withWorkflowApplicationShare(_m))
+	return &WorkflowApplicationShareUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *WorkflowApplicationShareClient) UpdateOneID(id uint64) *WorkflowApplicationShareUpdateOne {
+	mutation := newWorkflowApplicationShareMutation(c.config, OpUpdateOne, withWorkflowApplicationShareID(id))
+	return &WorkflowApplicationShareUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for WorkflowApplicationShare.
+func (c *WorkflowApplicationShareClient) Delete() *WorkflowApplicationShareDelete {
+	mutation := newWorkflowApplicationShareMutation(c.config, OpDelete)
+	return &WorkflowApplicationShareDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *WorkflowApplicationShareClient) DeleteOne(_m *WorkflowApplicationShare) *WorkflowApplicationShareDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *WorkflowApplicationShareClient) DeleteOneID(id uint64) *WorkflowApplicationShareDeleteOne {
+	builder := c.Delete().Where(workflowapplicationshare.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &WorkflowApplicationShareDeleteOne{builder}
+}
+
+// Query returns a query builder for WorkflowApplicationShare.
+func (c *WorkflowApplicationShareClient) Query() *WorkflowApplicationShareQuery {
+	return &WorkflowApplicationShareQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeWorkflowApplicationShare},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a WorkflowApplicationShare entity by its id.
+func (c *WorkflowApplicationShareClient) Get(ctx context.Context, id uint64) (*WorkflowApplicationShare, error) {
+	return c.Query().Where(workflowapplicationshare.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *WorkflowApplicationShareClient) GetX(ctx context.Context, id uint64) *WorkflowApplicationShare {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryApplication queries the application edge of a WorkflowApplicationShare.
+func (c *WorkflowApplicationShareClient) QueryApplication(_m *WorkflowApplicationShare) *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplicationshare.Table, workflowapplicationshare.FieldID, id),
+			sqlgraph.To(workflowapplication.Table, workflowapplication.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, workflowapplicationshare.ApplicationTable, workflowapplicationshare.ApplicationColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *WorkflowApplicationShareClient) Hooks() []Hook {
+	hooks := c.hooks.WorkflowApplicationShare
+	return append(hooks[:len(hooks):len(hooks)], workflowapplicationshare.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *WorkflowApplicationShareClient) Interceptors() []Interceptor {
+	return c.inters.WorkflowApplicationShare
+}
+
+func (c *WorkflowApplicationShareClient) mutate(ctx context.Context, m *WorkflowApplicationShareMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&WorkflowApplicationShareCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&WorkflowApplicationShareUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&WorkflowApplicationShareUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&WorkflowApplicationShareDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown WorkflowApplicationShare mutation op: %q", m.Op())
+	}
+}
+
 // WorkflowEdgeClient is a client for the WorkflowEdge schema.
 type WorkflowEdgeClient struct {
 	config
@@ -6104,6 +6470,307 @@ func (c *WorkflowNodeExecutionClient) mutate(ctx context.Context, m *WorkflowNod
 	}
 }
 
+// WorkflowScheduleClient is a client for the WorkflowSchedule schema.
+type WorkflowScheduleClient struct {
+	config
+}
+
+// NewWorkflowScheduleClient returns a client for the WorkflowSchedule from the given config.
+func NewWorkflowScheduleClient(c config) *WorkflowScheduleClient {
+	return &WorkflowScheduleClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `workflowschedule.Hooks(f(g(h())))`.
+func (c *WorkflowScheduleClient) Use(hooks ...Hook) {
+	c.hooks.WorkflowSchedule = append(c.hooks.WorkflowSchedule, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `workflowschedule.Intercept(f(g(h())))`.
+func (c *WorkflowScheduleClient) Intercept(interceptors ...Interceptor) {
+	c.inters.WorkflowSchedule = append(c.inters.WorkflowSchedule, interceptors...)
+}
+
+// Create returns a builder for creating a WorkflowSchedule entity.
+func (c *WorkflowScheduleClient) Create() *WorkflowScheduleCreate {
+	mutation := newWorkflowScheduleMutation(c.config, OpCreate)
+	return &WorkflowScheduleCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of WorkflowSchedule entities.
+func (c *WorkflowScheduleClient) CreateBulk(builders ...*WorkflowScheduleCreate) *WorkflowScheduleCreateBulk {
+	return &WorkflowScheduleCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *WorkflowScheduleClient) MapCreateBulk(slice any, setFunc func(*WorkflowScheduleCreate, int)) *WorkflowScheduleCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &WorkflowScheduleCreateBulk{err: fmt.Errorf("calling to WorkflowScheduleClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*WorkflowScheduleCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &WorkflowScheduleCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for WorkflowSchedule.
+func (c *WorkflowScheduleClient) Update() *WorkflowScheduleUpdate {
+	mutation := newWorkflowScheduleMutation(c.config, OpUpdate)
+	return &WorkflowScheduleUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *WorkflowScheduleClient) UpdateOne(_m *WorkflowSchedule) *WorkflowScheduleUpdateOne {
+	mutation := newWorkflowScheduleMutation(c.config, OpUpdateOne, withWorkflowSchedule(_m))
+	return &WorkflowScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *WorkflowScheduleClient) UpdateOneID(id uint64) *WorkflowScheduleUpdateOne {
+	mutation := newWorkflowScheduleMutation(c.config, OpUpdateOne, withWorkflowScheduleID(id))
+	return &WorkflowScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for WorkflowSchedule.
+func (c *WorkflowScheduleClient) Delete() *WorkflowScheduleDelete {
+	mutation := newWorkflowScheduleMutation(c.config, OpDelete)
+	return &WorkflowScheduleDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *WorkflowScheduleClient) DeleteOne(_m *WorkflowSchedule) *WorkflowScheduleDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *WorkflowScheduleClient) DeleteOneID(id uint64) *WorkflowScheduleDeleteOne {
+	builder := c.Delete().Where(workflowschedule.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &WorkflowScheduleDeleteOne{builder}
+}
+
+// Query returns a query builder for WorkflowSchedule.
+func (c *WorkflowScheduleClient) Query() *WorkflowScheduleQuery {
+	return &WorkflowScheduleQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeWorkflowSchedule},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a WorkflowSchedule entity by its id.
+func (c *WorkflowScheduleClient) Get(ctx context.Context, id uint64) (*WorkflowSchedule, error) {
+	return c.Query().Where(workflowschedule.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *WorkflowScheduleClient) GetX(ctx context.Context, id uint64) *WorkflowSchedule {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryApplication queries the application edge of a WorkflowSchedule.
+func (c *WorkflowScheduleClient) QueryApplication(_m *WorkflowSchedule) *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowschedule.Table, workflowschedule.FieldID, id),
+			sqlgraph.To(workflowapplication.Table, workflowapplication.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, workflowschedule.ApplicationTable, workflowschedule.ApplicationColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *WorkflowScheduleClient) Hooks() []Hook {
+	hooks := c.hooks.WorkflowSchedule
+	return append(hooks[:len(hooks):len(hooks)], workflowschedule.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *WorkflowScheduleClient) Interceptors() []Interceptor {
+	inters := c.inters.WorkflowSchedule
+	return append(inters[:len(inters):len(inters)], workflowschedule.Interceptors[:]...)
+}
+
+func (c *WorkflowScheduleClient) mutate(ctx context.Context, m *WorkflowScheduleMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&WorkflowScheduleCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&WorkflowScheduleUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&WorkflowScheduleUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&WorkflowScheduleDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown WorkflowSchedule mutation op: %q", m.Op())
+	}
+}
+
+// WorkflowSecretClient is a client for the WorkflowSecret schema.
+type WorkflowSecretClient struct {
+	config
+}
+
+// NewWorkflowSecretClient returns a client for the WorkflowSecret from the given config.
+func NewWorkflowSecretClient(c config) *WorkflowSecretClient {
+	return &WorkflowSecretClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `workflowsecret.Hooks(f(g(h())))`.
+func (c *WorkflowSecretClient) Use(hooks ...Hook) {
+	c.hooks.WorkflowSecret = append(c.hooks.WorkflowSecret, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `workflowsecret.Intercept(f(g(h())))`.
+func (c *WorkflowSecretClient) Intercept(interceptors ...Interceptor) {
+	c.inters.WorkflowSecret = append(c.inters.WorkflowSecret, interceptors...)
+}
+
+// Create returns a builder for creating a WorkflowSecret entity.
+func (c *WorkflowSecretClient) Create() *WorkflowSecretCreate {
+	mutation := newWorkflowSecretMutation(c.config, OpCreate)
+	return &WorkflowSecretCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of WorkflowSecret entities.
+func (c *WorkflowSecretClient) CreateBulk(builders ...*WorkflowSecretCreate) *WorkflowSecretCreateBulk {
+	return &WorkflowSecretCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *WorkflowSecretClient) MapCreateBulk(slice any, setFunc func(*WorkflowSecretCreate, int)) *WorkflowSecretCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &WorkflowSecretCreateBulk{err: fmt.Errorf("calling to WorkflowSecretClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*WorkflowSecretCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &WorkflowSecretCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for WorkflowSecret.
+func (c *WorkflowSecretClient) Update() *WorkflowSecretUpdate {
+	mutation := newWorkflowSecretMutation(c.config, OpUpdate)
+	return &WorkflowSecretUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *WorkflowSecretClient) UpdateOne(_m *WorkflowSecret) *WorkflowSecretUpdateOne {
+	mutation := newWorkflowSecretMutation(c.config, OpUpdateOne, withWorkflowSecret(_m))
+	return &WorkflowSecretUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *WorkflowSecretClient) UpdateOneID(id uint64) *WorkflowSecretUpdateOne {
+	mutation := newWorkflowSecretMutation(c.config, OpUpdateOne, withWorkflowSecretID(id))
+	return &WorkflowSecretUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for WorkflowSecret.
+func (c *WorkflowSecretClient) Delete() *WorkflowSecretDelete {
+	mutation := newWorkflowSecretMutation(c.config, OpDelete)
+	return &WorkflowSecretDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *WorkflowSecretClient) DeleteOne(_m *WorkflowSecret) *WorkflowSecretDeleteOne {
+	return c.DeleteOneID(_m.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *WorkflowSecretClient) DeleteOneID(id uint64) *WorkflowSecretDeleteOne {
+	builder := c.Delete().Where(workflowsecret.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &WorkflowSecretDeleteOne{builder}
+}
+
+// Query returns a query builder for WorkflowSecret.
+func (c *WorkflowSecretClient) Query() *WorkflowSecretQuery {
+	return &WorkflowSecretQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeWorkflowSecret},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a WorkflowSecret entity by its id.
+func (c *WorkflowSecretClient) Get(ctx context.Context, id uint64) (*WorkflowSecret, error) {
+	return c.Query().Where(workflowsecret.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *WorkflowSecretClient) GetX(ctx context.Context, id uint64) *WorkflowSecret {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// QueryApplication queries the application edge of a WorkflowSecret.
+func (c *WorkflowSecretClient) QueryApplication(_m *WorkflowSecret) *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationClient{config: c.config}).Query()
+	query.path = func(context.Context) (fromV *sql.Selector, _ error) {
+		id := _m.ID
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowsecret.Table, workflowsecret.FieldID, id),
+			sqlgraph.To(workflowapplication.Table, workflowapplication.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, workflowsecret.ApplicationTable, workflowsecret.ApplicationColumn),
+		)
+		fromV = sqlgraph.Neighbors(_m.driver.Dialect(), step)
+		return fromV, nil
+	}
+	return query
+}
+
+// Hooks returns the client hooks.
+func (c *WorkflowSecretClient) Hooks() []Hook {
+	hooks := c.hooks.WorkflowSecret
+	return append(hooks[:len(hooks):len(hooks)], workflowsecret.Hooks[:]...)
+}
+
+// Interceptors returns the client interceptors.
+func (c *WorkflowSecretClient) Interceptors() []Interceptor {
+	return c.inters.WorkflowSecret
+}
+
+func (c *WorkflowSecretClient) mutate(ctx context.Context, m *WorkflowSecretMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&WorkflowSecretCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&WorkflowSecretUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&WorkflowSecretUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&WorkflowSecretDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown WorkflowSecret mutation op: %q", m.Op())
+	}
+}
+
 // WorkflowVersionClient is a client for the WorkflowVersion schema.
 type WorkflowVersionClient struct {
 	config
@@ -6241,21 +6908,23 @@ func (c *WorkflowVersionClient) mutate(ctx context.Context, m *WorkflowVersionMu
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		APIAuth, Address, Area, Attachment, ClientDevice, Credential, Logging,
-		LoginRecord, OauthApplication, OauthAuthorizationCode, OauthProvider,
+		APIAuth, Address, Area, Attachment, ClientDevice, Credential, DatabaseBackup,
+		Logging, LoginRecord, OauthApplication, OauthAuthorizationCode, OauthProvider,
 		OauthState, OauthToken, OauthUser, OauthUserAuthorization, Permission, Role,
 		RolePermission, Scan, Scope, Station, Subway, SubwayStation, SystemMonitor,
-		User, UserRole, VerifyCode, WorkflowApplication, WorkflowEdge,
-		WorkflowExecution, WorkflowExecutionLog, WorkflowNode, WorkflowNodeExecution,
+		User, UserRole, VerifyCode, WorkflowApplication, WorkflowApplicationShare,
+		WorkflowEdge, WorkflowExecution, WorkflowExecutionLog, WorkflowNode,
+		WorkflowNodeExecution, WorkflowSchedule, WorkflowSecret,
 		WorkflowVersion []ent.Hook
 	}
 	inters struct {
-		APIAuth, Address, Area, Attachment, ClientDevice, Credential, Logging,
-		LoginRecord, OauthApplication, OauthAuthorizationCode, OauthProvider,
+		APIAuth, Address, Area, Attachment, ClientDevice, Credential, DatabaseBackup,
+		Logging, LoginRecord, OauthApplication, OauthAuthorizationCode, OauthProvider,
 		OauthState, OauthToken, OauthUser, OauthUserAuthorization, Permission, Role,
 		RolePermission, Scan, Scope, Station, Subway, SubwayStation, SystemMonitor,
-		User, UserRole, VerifyCode, WorkflowApplication, WorkflowEdge,
-		WorkflowExecution, WorkflowExecutionLog, WorkflowNode, WorkflowNodeExecution,
+		User, UserRole, VerifyCode, WorkflowApplication, WorkflowApplicationShare,
+		WorkflowEdge, WorkflowExecution, WorkflowExecutionLog, WorkflowNode,
+		WorkflowNodeExecution, WorkflowSchedule, WorkflowSecret,
 		WorkflowVersion []ent.Interceptor
 	}
 )
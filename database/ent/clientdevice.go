@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"go-backend/database/ent/clientdevice"
 	"strings"
@@ -44,6 +45,10 @@ type ClientDevice struct {
 	RefreshTokenExpiry uint64 `json:"refresh_token_expiry,omitempty"`
 	// 允许所有角色登录
 	Anonymous bool `json:"anonymous,omitempty"`
+	// 令牌受众，为空时只使用服务受众
+	Audience string `json:"audience,omitempty"`
+	// 令牌授权范围，为空表示不限制
+	Scopes []string `json:"scopes,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the ClientDeviceQuery when eager-loading is set.
 	Edges        ClientDeviceEdges `json:"edges"`
@@ -74,11 +79,13 @@ func (*ClientDevice) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case clientdevice.FieldScopes:
+			values[i] = new([]byte)
 		case clientdevice.FieldEnabled, clientdevice.FieldAnonymous:
 			values[i] = new(sql.NullBool)
 		case clientdevice.FieldID, clientdevice.FieldCreateBy, clientdevice.FieldUpdateBy, clientdevice.FieldDeleteBy, clientdevice.FieldAccessTokenExpiry, clientdevice.FieldRefreshTokenExpiry:
 			values[i] = new(sql.NullInt64)
-		case clientdevice.FieldName, clientdevice.FieldCode, clientdevice.FieldDescription:
+		case clientdevice.FieldName, clientdevice.FieldCode, clientdevice.FieldDescription, clientdevice.FieldAudience:
 			values[i] = new(sql.NullString)
 		case clientdevice.FieldCreateTime, clientdevice.FieldUpdateTime, clientdevice.FieldDeleteTime:
 			values[i] = new(sql.NullTime)
@@ -181,6 +188,20 @@ func (_m *ClientDevice) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Anonymous = value.Bool
 			}
+		case clientdevice.FieldAudience:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field audience", values[i])
+			} else if value.Valid {
+				_m.Audience = value.String
+			}
+		case clientdevice.FieldScopes:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field scopes", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Scopes); err != nil {
+					return fmt.Errorf("unmarshal field scopes: %w", err)
+				}
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -260,6 +281,12 @@ func (_m *ClientDevice) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("anonymous=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Anonymous))
+	builder.WriteString(", ")
+	builder.WriteString("audience=")
+	builder.WriteString(_m.Audience)
+	builder.WriteString(", ")
+	builder.WriteString("scopes=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Scopes))
 	builder.WriteByte(')')
 	return builder.String()
 }
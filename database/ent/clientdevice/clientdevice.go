@@ -41,6 +41,10 @@ const (
 	FieldRefreshTokenExpiry = "refresh_token_expiry"
 	// FieldAnonymous holds the string denoting the anonymous field in the database.
 	FieldAnonymous = "anonymous"
+	// FieldAudience holds the string denoting the audience field in the database.
+	FieldAudience = "audience"
+	// FieldScopes holds the string denoting the scopes field in the database.
+	FieldScopes = "scopes"
 	// EdgeRoles holds the string denoting the roles edge name in mutations.
 	EdgeRoles = "roles"
 	// Table holds the table name of the clientdevice in the database.
@@ -68,6 +72,8 @@ var Columns = []string{
 	FieldAccessTokenExpiry,
 	FieldRefreshTokenExpiry,
 	FieldAnonymous,
+	FieldAudience,
+	FieldScopes,
 }
 
 var (
@@ -114,6 +120,8 @@ var (
 	RefreshTokenExpiryValidator func(uint64) error
 	// DefaultAnonymous holds the default value on creation for the "anonymous" field.
 	DefaultAnonymous bool
+	// AudienceValidator is a validator for the "audience" field. It is called by the builders before save.
+	AudienceValidator func(string) error
 )
 
 // OrderOption defines the ordering options for the ClientDevice queries.
@@ -189,6 +197,11 @@ func ByAnonymous(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAnonymous, opts...).ToFunc()
 }
 
+// ByAudience orders the results by the audience field.
+func ByAudience(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAudience, opts...).ToFunc()
+}
+
 // ByRolesCount orders the results by roles count.
 func ByRolesCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
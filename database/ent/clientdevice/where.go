@@ -120,6 +120,11 @@ func Anonymous(v bool) predicate.ClientDevice {
 	return predicate.ClientDevice(sql.FieldEQ(FieldAnonymous, v))
 }
 
+// Audience applies equality check predicate on the "audience" field. It's identical to AudienceEQ.
+func Audience(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldEQ(FieldAudience, v))
+}
+
 // CreateTimeEQ applies the EQ predicate on the "create_time" field.
 func CreateTimeEQ(v time.Time) predicate.ClientDevice {
 	return predicate.ClientDevice(sql.FieldEQ(FieldCreateTime, v))
@@ -705,6 +710,91 @@ func AnonymousNEQ(v bool) predicate.ClientDevice {
 	return predicate.ClientDevice(sql.FieldNEQ(FieldAnonymous, v))
 }
 
+// AudienceEQ applies the EQ predicate on the "audience" field.
+func AudienceEQ(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldEQ(FieldAudience, v))
+}
+
+// AudienceNEQ applies the NEQ predicate on the "audience" field.
+func AudienceNEQ(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldNEQ(FieldAudience, v))
+}
+
+// AudienceIn applies the In predicate on the "audience" field.
+func AudienceIn(vs ...string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldIn(FieldAudience, vs...))
+}
+
+// AudienceNotIn applies the NotIn predicate on the "audience" field.
+func AudienceNotIn(vs ...string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldNotIn(FieldAudience, vs...))
+}
+
+// AudienceGT applies the GT predicate on the "audience" field.
+func AudienceGT(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldGT(FieldAudience, v))
+}
+
+// AudienceGTE applies the GTE predicate on the "audience" field.
+func AudienceGTE(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldGTE(FieldAudience, v))
+}
+
+// AudienceLT applies the LT predicate on the "audience" field.
+func AudienceLT(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldLT(FieldAudience, v))
+}
+
+// AudienceLTE applies the LTE predicate on the "audience" field.
+func AudienceLTE(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldLTE(FieldAudience, v))
+}
+
+// AudienceContains applies the Contains predicate on the "audience" field.
+func AudienceContains(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldContains(FieldAudience, v))
+}
+
+// AudienceHasPrefix applies the HasPrefix predicate on the "audience" field.
+func AudienceHasPrefix(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldHasPrefix(FieldAudience, v))
+}
+
+// AudienceHasSuffix applies the HasSuffix predicate on the "audience" field.
+func AudienceHasSuffix(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldHasSuffix(FieldAudience, v))
+}
+
+// AudienceIsNil applies the IsNil predicate on the "audience" field.
+func AudienceIsNil() predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldIsNull(FieldAudience))
+}
+
+// AudienceNotNil applies the NotNil predicate on the "audience" field.
+func AudienceNotNil() predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldNotNull(FieldAudience))
+}
+
+// AudienceEqualFold applies the EqualFold predicate on the "audience" field.
+func AudienceEqualFold(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldEqualFold(FieldAudience, v))
+}
+
+// AudienceContainsFold applies the ContainsFold predicate on the "audience" field.
+func AudienceContainsFold(v string) predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldContainsFold(FieldAudience, v))
+}
+
+// ScopesIsNil applies the IsNil predicate on the "scopes" field.
+func ScopesIsNil() predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldIsNull(FieldScopes))
+}
+
+// ScopesNotNil applies the NotNil predicate on the "scopes" field.
+func ScopesNotNil() predicate.ClientDevice {
+	return predicate.ClientDevice(sql.FieldNotNull(FieldScopes))
+}
+
 // HasRoles applies the HasEdge predicate on the "roles" edge.
 func HasRoles() predicate.ClientDevice {
 	return predicate.ClientDevice(func(s *sql.Selector) {
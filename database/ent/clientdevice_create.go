@@ -171,6 +171,26 @@ func (_c *ClientDeviceCreate) SetNillableAnonymous(v *bool) *ClientDeviceCreate
 	return _c
 }
 
+// SetAudience sets the "audience" field.
+func (_c *ClientDeviceCreate) SetAudience(v string) *ClientDeviceCreate {
+	_c.mutation.SetAudience(v)
+	return _c
+}
+
+// SetNillableAudience sets the "audience" field if the given value is not nil.
+func (_c *ClientDeviceCreate) SetNillableAudience(v *string) *ClientDeviceCreate {
+	if v != nil {
+		_c.SetAudience(*v)
+	}
+	return _c
+}
+
+// SetScopes sets the "scopes" field.
+func (_c *ClientDeviceCreate) SetScopes(v []string) *ClientDeviceCreate {
+	_c.mutation.SetScopes(v)
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *ClientDeviceCreate) SetID(v uint64) *ClientDeviceCreate {
 	_c.mutation.SetID(v)
@@ -305,6 +325,11 @@ func (_c *ClientDeviceCreate) check() error {
 	if _, ok := _c.mutation.Anonymous(); !ok {
 		return &ValidationError{Name: "anonymous", err: errors.New(`ent: missing required field "ClientDevice.anonymous"`)}
 	}
+	if v, ok := _c.mutation.Audience(); ok {
+		if err := clientdevice.AudienceValidator(v); err != nil {
+			return &ValidationError{Name: "audience", err: fmt.Errorf(`ent: validator failed for field "ClientDevice.audience": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -389,6 +414,14 @@ func (_c *ClientDeviceCreate) createSpec() (*ClientDevice, *sqlgraph.CreateSpec)
 		_spec.SetField(clientdevice.FieldAnonymous, field.TypeBool, value)
 		_node.Anonymous = value
 	}
+	if value, ok := _c.mutation.Audience(); ok {
+		_spec.SetField(clientdevice.FieldAudience, field.TypeString, value)
+		_node.Audience = value
+	}
+	if value, ok := _c.mutation.Scopes(); ok {
+		_spec.SetField(clientdevice.FieldScopes, field.TypeJSON, value)
+		_node.Scopes = value
+	}
 	if nodes := _c.mutation.RolesIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -13,6 +13,7 @@ import (
 
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
 	"entgo.io/ent/schema/field"
 )
 
@@ -254,6 +255,44 @@ func (_u *ClientDeviceUpdate) SetNillableAnonymous(v *bool) *ClientDeviceUpdate
 	return _u
 }
 
+// SetAudience sets the "audience" field.
+func (_u *ClientDeviceUpdate) SetAudience(v string) *ClientDeviceUpdate {
+	_u.mutation.SetAudience(v)
+	return _u
+}
+
+// SetNillableAudience sets the "audience" field if the given value is not nil.
+func (_u *ClientDeviceUpdate) SetNillableAudience(v *string) *ClientDeviceUpdate {
+	if v != nil {
+		_u.SetAudience(*v)
+	}
+	return _u
+}
+
+// ClearAudience clears the value of the "audience" field.
+func (_u *ClientDeviceUpdate) ClearAudience() *ClientDeviceUpdate {
+	_u.mutation.ClearAudience()
+	return _u
+}
+
+// SetScopes sets the "scopes" field.
+func (_u *ClientDeviceUpdate) SetScopes(v []string) *ClientDeviceUpdate {
+	_u.mutation.SetScopes(v)
+	return _u
+}
+
+// AppendScopes appends value to the "scopes" field.
+func (_u *ClientDeviceUpdate) AppendScopes(v []string) *ClientDeviceUpdate {
+	_u.mutation.AppendScopes(v)
+	return _u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (_u *ClientDeviceUpdate) ClearScopes() *ClientDeviceUpdate {
+	_u.mutation.ClearScopes()
+	return _u
+}
+
 // AddRoleIDs adds the "roles" edge to the Role entity by IDs.
 func (_u *ClientDeviceUpdate) AddRoleIDs(ids ...uint64) *ClientDeviceUpdate {
 	_u.mutation.AddRoleIDs(ids...)
@@ -364,6 +403,11 @@ func (_u *ClientDeviceUpdate) check() error {
 			return &ValidationError{Name: "refresh_token_expiry", err: fmt.Errorf(`ent: validator failed for field "ClientDevice.refresh_token_expiry": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Audience(); ok {
+		if err := clientdevice.AudienceValidator(v); err != nil {
+			return &ValidationError{Name: "audience", err: fmt.Errorf(`ent: validator failed for field "ClientDevice.audience": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -445,6 +489,23 @@ func (_u *ClientDeviceUpdate) sqlSave(ctx context.Context) (_node int, err error
 	if value, ok := _u.mutation.Anonymous(); ok {
 		_spec.SetField(clientdevice.FieldAnonymous, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.Audience(); ok {
+		_spec.SetField(clientdevice.FieldAudience, field.TypeString, value)
+	}
+	if _u.mutation.AudienceCleared() {
+		_spec.ClearField(clientdevice.FieldAudience, field.TypeString)
+	}
+	if value, ok := _u.mutation.Scopes(); ok {
+		_spec.SetField(clientdevice.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, clientdevice.FieldScopes, value)
+		})
+	}
+	if _u.mutation.ScopesCleared() {
+		_spec.ClearField(clientdevice.FieldScopes, field.TypeJSON)
+	}
 	if _u.mutation.RolesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -735,6 +796,44 @@ func (_u *ClientDeviceUpdateOne) SetNillableAnonymous(v *bool) *ClientDeviceUpda
 	return _u
 }
 
+// SetAudience sets the "audience" field.
+func (_u *ClientDeviceUpdateOne) SetAudience(v string) *ClientDeviceUpdateOne {
+	_u.mutation.SetAudience(v)
+	return _u
+}
+
+// SetNillableAudience sets the "audience" field if the given value is not nil.
+func (_u *ClientDeviceUpdateOne) SetNillableAudience(v *string) *ClientDeviceUpdateOne {
+	if v != nil {
+		_u.SetAudience(*v)
+	}
+	return _u
+}
+
+// ClearAudience clears the value of the "audience" field.
+func (_u *ClientDeviceUpdateOne) ClearAudience() *ClientDeviceUpdateOne {
+	_u.mutation.ClearAudience()
+	return _u
+}
+
+// SetScopes sets the "scopes" field.
+func (_u *ClientDeviceUpdateOne) SetScopes(v []string) *ClientDeviceUpdateOne {
+	_u.mutation.SetScopes(v)
+	return _u
+}
+
+// AppendScopes appends value to the "scopes" field.
+func (_u *ClientDeviceUpdateOne) AppendScopes(v []string) *ClientDeviceUpdateOne {
+	_u.mutation.AppendScopes(v)
+	return _u
+}
+
+// ClearScopes clears the value of the "scopes" field.
+func (_u *ClientDeviceUpdateOne) ClearScopes() *ClientDeviceUpdateOne {
+	_u.mutation.ClearScopes()
+	return _u
+}
+
 // AddRoleIDs adds the "roles" edge to the Role entity by IDs.
 func (_u *ClientDeviceUpdateOne) AddRoleIDs(ids ...uint64) *ClientDeviceUpdateOne {
 	_u.mutation.AddRoleIDs(ids...)
@@ -858,6 +957,11 @@ func (_u *ClientDeviceUpdateOne) check() error {
 			return &ValidationError{Name: "refresh_token_expiry", err: fmt.Errorf(`ent: validator failed for field "ClientDevice.refresh_token_expiry": %w`, err)}
 		}
 	}
+	if v, ok := _u.mutation.Audience(); ok {
+		if err := clientdevice.AudienceValidator(v); err != nil {
+			return &ValidationError{Name: "audience", err: fmt.Errorf(`ent: validator failed for field "ClientDevice.audience": %w`, err)}
+		}
+	}
 	return nil
 }
 
@@ -956,6 +1060,23 @@ func (_u *ClientDeviceUpdateOne) sqlSave(ctx context.Context) (_node *ClientDevi
 	if value, ok := _u.mutation.Anonymous(); ok {
 		_spec.SetField(clientdevice.FieldAnonymous, field.TypeBool, value)
 	}
+	if value, ok := _u.mutation.Audience(); ok {
+		_spec.SetField(clientdevice.FieldAudience, field.TypeString, value)
+	}
+	if _u.mutation.AudienceCleared() {
+		_spec.ClearField(clientdevice.FieldAudience, field.TypeString)
+	}
+	if value, ok := _u.mutation.Scopes(); ok {
+		_spec.SetField(clientdevice.FieldScopes, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedScopes(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, clientdevice.FieldScopes, value)
+		})
+	}
+	if _u.mutation.ScopesCleared() {
+		_spec.ClearField(clientdevice.FieldScopes, field.TypeJSON)
+	}
 	if _u.mutation.RolesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2M,
@@ -36,9 +36,9 @@ type Credential struct {
 	UserID uint64 `json:"user_id,omitempty"`
 	// 认证类型
 	CredentialType credential.CredentialType `json:"credential_type,omitempty"`
-	// 认证标识符(用户名/邮箱/手机号/OAuth Provider ID等)
+	// 认证标识符(用户名/邮箱/手机号/OAuth Provider ID/通行密钥凭据ID等)
 	Identifier string `json:"identifier,omitempty"`
-	// 认证密钥(密码hash/token等)
+	// 认证密钥(密码hash/token/通行密钥公钥等)
 	Secret string `json:"-"`
 	// 密码盐值
 	Salt string `json:"-"`
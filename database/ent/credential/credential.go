@@ -139,6 +139,7 @@ const (
 	CredentialTypeOauth    CredentialType = "oauth"
 	CredentialTypePhone    CredentialType = "phone"
 	CredentialTypeTotp     CredentialType = "totp"
+	CredentialTypeWebauthn CredentialType = "webauthn"
 )
 
 func (ct CredentialType) String() string {
@@ -148,7 +149,7 @@ func (ct CredentialType) String() string {
 // CredentialTypeValidator is a validator for the "credential_type" field enum values. It is called by the builders before save.
 func CredentialTypeValidator(ct CredentialType) error {
 	switch ct {
-	case CredentialTypePassword, CredentialTypeEmail, CredentialTypeOauth, CredentialTypePhone, CredentialTypeTotp:
+	case CredentialTypePassword, CredentialTypeEmail, CredentialTypeOauth, CredentialTypePhone, CredentialTypeTotp, CredentialTypeWebauthn:
 		return nil
 	default:
 		return fmt.Errorf("credential: invalid enum value for credential_type field: %q", ct)
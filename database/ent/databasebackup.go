@@ -0,0 +1,224 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-backend/database/ent/databasebackup"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// DatabaseBackup is the model entity for the DatabaseBackup schema.
+type DatabaseBackup struct {
+	config `json:"-"`
+	// ID of the ent.
+	// 主键ID
+	ID uint64 `json:"id,omitempty"`
+	// 创建时间
+	CreateTime time.Time `json:"create_time,omitempty"`
+	// 创建人ID
+	CreateBy uint64 `json:"create_by,omitempty"`
+	// 更新时间
+	UpdateTime time.Time `json:"update_time,omitempty"`
+	// 更新人ID
+	UpdateBy uint64 `json:"update_by,omitempty"`
+	// 存储桶
+	Bucket string `json:"bucket,omitempty"`
+	// 归档在存储桶中的键
+	Key string `json:"key,omitempty"`
+	// 归档大小(字节)
+	Size int64 `json:"size,omitempty"`
+	// 导出的记录总数
+	TotalRecords int `json:"total_records,omitempty"`
+	// 各实体导出的记录数
+	EntityCounts map[string]int `json:"entity_counts,omitempty"`
+	// 导出失败的实体
+	FailedEntities []string `json:"failed_entities,omitempty"`
+	// 备份时间点
+	BackupTime   time.Time `json:"backup_time,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*DatabaseBackup) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case databasebackup.FieldEntityCounts, databasebackup.FieldFailedEntities:
+			values[i] = new([]byte)
+		case databasebackup.FieldID, databasebackup.FieldCreateBy, databasebackup.FieldUpdateBy, databasebackup.FieldSize, databasebackup.FieldTotalRecords:
+			values[i] = new(sql.NullInt64)
+		case databasebackup.FieldBucket, databasebackup.FieldKey:
+			values[i] = new(sql.NullString)
+		case databasebackup.FieldCreateTime, databasebackup.FieldUpdateTime, databasebackup.FieldBackupTime:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the DatabaseBackup fields.
+func (_m *DatabaseBackup) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case databasebackup.FieldID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = uint64(value.Int64)
+			}
+		case databasebackup.FieldCreateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field create_time", values[i])
+			} else if value.Valid {
+				_m.CreateTime = value.Time
+			}
+		case databasebackup.FieldCreateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field create_by", values[i])
+			} else if value.Valid {
+				_m.CreateBy = uint64(value.Int64)
+			}
+		case databasebackup.FieldUpdateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field update_time", values[i])
+			} else if value.Valid {
+				_m.UpdateTime = value.Time
+			}
+		case databasebackup.FieldUpdateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field update_by", values[i])
+			} else if value.Valid {
+				_m.UpdateBy = uint64(value.Int64)
+			}
+		case databasebackup.FieldBucket:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field bucket", values[i])
+			} else if value.Valid {
+				_m.Bucket = value.String
+			}
+		case databasebackup.FieldKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field key", values[i])
+			} else if value.Valid {
+				_m.Key = value.String
+			}
+		case databasebackup.FieldSize:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field size", values[i])
+			} else if value.Valid {
+				_m.Size = value.Int64
+			}
+		case databasebackup.FieldTotalRecords:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field total_records", values[i])
+			} else if value.Valid {
+				_m.TotalRecords = int(value.Int64)
+			}
+		case databasebackup.FieldEntityCounts:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field entity_counts", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.EntityCounts); err != nil {
+					return fmt.Errorf("unmarshal field entity_counts: %w", err)
+				}
+			}
+		case databasebackup.FieldFailedEntities:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field failed_entities", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.FailedEntities); err != nil {
+					return fmt.Errorf("unmarshal field failed_entities: %w", err)
+				}
+			}
+		case databasebackup.FieldBackupTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field backup_time", values[i])
+			} else if value.Valid {
+				_m.BackupTime = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the DatabaseBackup.
+// This includes values selected through modifiers, order, etc.
+func (_m *DatabaseBackup) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this DatabaseBackup.
+// Note that you need to call DatabaseBackup.Unwrap() before calling this method if this DatabaseBackup
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *DatabaseBackup) Update() *DatabaseBackupUpdateOne {
+	return NewDatabaseBackupClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the DatabaseBackup entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *DatabaseBackup) Unwrap() *DatabaseBackup {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: DatabaseBackup is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *DatabaseBackup) String() string {
+	var builder strings.Builder
+	builder.WriteString("DatabaseBackup(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("create_time=")
+	builder.WriteString(_m.CreateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("create_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreateBy))
+	builder.WriteString(", ")
+	builder.WriteString("update_time=")
+	builder.WriteString(_m.UpdateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("update_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdateBy))
+	builder.WriteString(", ")
+	builder.WriteString("bucket=")
+	builder.WriteString(_m.Bucket)
+	builder.WriteString(", ")
+	builder.WriteString("key=")
+	builder.WriteString(_m.Key)
+	builder.WriteString(", ")
+	builder.WriteString("size=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Size))
+	builder.WriteString(", ")
+	builder.WriteString("total_records=")
+	builder.WriteString(fmt.Sprintf("%v", _m.TotalRecords))
+	builder.WriteString(", ")
+	builder.WriteString("entity_counts=")
+	builder.WriteString(fmt.Sprintf("%v", _m.EntityCounts))
+	builder.WriteString(", ")
+	builder.WriteString("failed_entities=")
+	builder.WriteString(fmt.Sprintf("%v", _m.FailedEntities))
+	builder.WriteString(", ")
+	builder.WriteString("backup_time=")
+	builder.WriteString(_m.BackupTime.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// DatabaseBackups is a parsable slice of DatabaseBackup.
+type DatabaseBackups []*DatabaseBackup
@@ -0,0 +1,145 @@
+// Code generated by ent, DO NOT EDIT.
+
+package databasebackup
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+const (
+	// Label holds the string label denoting the databasebackup type in the database.
+	Label = "database_backup"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldCreateTime holds the string denoting the create_time field in the database.
+	FieldCreateTime = "create_time"
+	// FieldCreateBy holds the string denoting the create_by field in the database.
+	FieldCreateBy = "create_by"
+	// FieldUpdateTime holds the string denoting the update_time field in the database.
+	FieldUpdateTime = "update_time"
+	// FieldUpdateBy holds the string denoting the update_by field in the database.
+	FieldUpdateBy = "update_by"
+	// FieldBucket holds the string denoting the bucket field in the database.
+	FieldBucket = "bucket"
+	// FieldKey holds the string denoting the key field in the database.
+	FieldKey = "key"
+	// FieldSize holds the string denoting the size field in the database.
+	FieldSize = "size"
+	// FieldTotalRecords holds the string denoting the total_records field in the database.
+	FieldTotalRecords = "total_records"
+	// FieldEntityCounts holds the string denoting the entity_counts field in the database.
+	FieldEntityCounts = "entity_counts"
+	// FieldFailedEntities holds the string denoting the failed_entities field in the database.
+	FieldFailedEntities = "failed_entities"
+	// FieldBackupTime holds the string denoting the backup_time field in the database.
+	FieldBackupTime = "backup_time"
+	// Table holds the table name of the databasebackup in the database.
+	Table = "sys_database_backups"
+)
+
+// Columns holds all SQL columns for databasebackup fields.
+var Columns = []string{
+	FieldID,
+	FieldCreateTime,
+	FieldCreateBy,
+	FieldUpdateTime,
+	FieldUpdateBy,
+	FieldBucket,
+	FieldKey,
+	FieldSize,
+	FieldTotalRecords,
+	FieldEntityCounts,
+	FieldFailedEntities,
+	FieldBackupTime,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-backend/database/ent/runtime"
+var (
+	Hooks [2]ent.Hook
+	// DefaultCreateTime holds the default value on creation for the "create_time" field.
+	DefaultCreateTime func() time.Time
+	// DefaultUpdateTime holds the default value on creation for the "update_time" field.
+	DefaultUpdateTime func() time.Time
+	// UpdateDefaultUpdateTime holds the default value on update for the "update_time" field.
+	UpdateDefaultUpdateTime func() time.Time
+	// BucketValidator is a validator for the "bucket" field. It is called by the builders before save.
+	BucketValidator func(string) error
+	// KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	KeyValidator func(string) error
+	// SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	SizeValidator func(int64) error
+	// DefaultTotalRecords holds the default value on creation for the "total_records" field.
+	DefaultTotalRecords int
+	// TotalRecordsValidator is a validator for the "total_records" field. It is called by the builders before save.
+	TotalRecordsValidator func(int) error
+)
+
+// OrderOption defines the ordering options for the DatabaseBackup queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByCreateTime orders the results by the create_time field.
+func ByCreateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateTime, opts...).ToFunc()
+}
+
+// ByCreateBy orders the results by the create_by field.
+func ByCreateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateBy, opts...).ToFunc()
+}
+
+// ByUpdateTime orders the results by the update_time field.
+func ByUpdateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateTime, opts...).ToFunc()
+}
+
+// ByUpdateBy orders the results by the update_by field.
+func ByUpdateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateBy, opts...).ToFunc()
+}
+
+// ByBucket orders the results by the bucket field.
+func ByBucket(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBucket, opts...).ToFunc()
+}
+
+// ByKey orders the results by the key field.
+func ByKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldKey, opts...).ToFunc()
+}
+
+// BySize orders the results by the size field.
+func BySize(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldSize, opts...).ToFunc()
+}
+
+// ByTotalRecords orders the results by the total_records field.
+func ByTotalRecords(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTotalRecords, opts...).ToFunc()
+}
+
+// ByBackupTime orders the results by the backup_time field.
+func ByBackupTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBackupTime, opts...).ToFunc()
+}
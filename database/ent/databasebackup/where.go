@@ -0,0 +1,565 @@
+// Code generated by ent, DO NOT EDIT.
+
+package databasebackup
+
+import (
+	"go-backend/database/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldID, id))
+}
+
+// CreateTime applies equality check predicate on the "create_time" field. It's identical to CreateTimeEQ.
+func CreateTime(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateBy applies equality check predicate on the "create_by" field. It's identical to CreateByEQ.
+func CreateBy(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// UpdateTime applies equality check predicate on the "update_time" field. It's identical to UpdateTimeEQ.
+func UpdateTime(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateBy applies equality check predicate on the "update_by" field. It's identical to UpdateByEQ.
+func UpdateBy(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// Bucket applies equality check predicate on the "bucket" field. It's identical to BucketEQ.
+func Bucket(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldBucket, v))
+}
+
+// Key applies equality check predicate on the "key" field. It's identical to KeyEQ.
+func Key(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldKey, v))
+}
+
+// Size applies equality check predicate on the "size" field. It's identical to SizeEQ.
+func Size(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldSize, v))
+}
+
+// TotalRecords applies equality check predicate on the "total_records" field. It's identical to TotalRecordsEQ.
+func TotalRecords(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldTotalRecords, v))
+}
+
+// BackupTime applies equality check predicate on the "backup_time" field. It's identical to BackupTimeEQ.
+func BackupTime(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldBackupTime, v))
+}
+
+// CreateTimeEQ applies the EQ predicate on the "create_time" field.
+func CreateTimeEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateTimeNEQ applies the NEQ predicate on the "create_time" field.
+func CreateTimeNEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldCreateTime, v))
+}
+
+// CreateTimeIn applies the In predicate on the "create_time" field.
+func CreateTimeIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeNotIn applies the NotIn predicate on the "create_time" field.
+func CreateTimeNotIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeGT applies the GT predicate on the "create_time" field.
+func CreateTimeGT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldCreateTime, v))
+}
+
+// CreateTimeGTE applies the GTE predicate on the "create_time" field.
+func CreateTimeGTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldCreateTime, v))
+}
+
+// CreateTimeLT applies the LT predicate on the "create_time" field.
+func CreateTimeLT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldCreateTime, v))
+}
+
+// CreateTimeLTE applies the LTE predicate on the "create_time" field.
+func CreateTimeLTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldCreateTime, v))
+}
+
+// CreateByEQ applies the EQ predicate on the "create_by" field.
+func CreateByEQ(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// CreateByNEQ applies the NEQ predicate on the "create_by" field.
+func CreateByNEQ(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldCreateBy, v))
+}
+
+// CreateByIn applies the In predicate on the "create_by" field.
+func CreateByIn(vs ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldCreateBy, vs...))
+}
+
+// CreateByNotIn applies the NotIn predicate on the "create_by" field.
+func CreateByNotIn(vs ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldCreateBy, vs...))
+}
+
+// CreateByGT applies the GT predicate on the "create_by" field.
+func CreateByGT(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldCreateBy, v))
+}
+
+// CreateByGTE applies the GTE predicate on the "create_by" field.
+func CreateByGTE(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldCreateBy, v))
+}
+
+// CreateByLT applies the LT predicate on the "create_by" field.
+func CreateByLT(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldCreateBy, v))
+}
+
+// CreateByLTE applies the LTE predicate on the "create_by" field.
+func CreateByLTE(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldCreateBy, v))
+}
+
+// CreateByIsNil applies the IsNil predicate on the "create_by" field.
+func CreateByIsNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIsNull(FieldCreateBy))
+}
+
+// CreateByNotNil applies the NotNil predicate on the "create_by" field.
+func CreateByNotNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotNull(FieldCreateBy))
+}
+
+// UpdateTimeEQ applies the EQ predicate on the "update_time" field.
+func UpdateTimeEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeNEQ applies the NEQ predicate on the "update_time" field.
+func UpdateTimeNEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeIn applies the In predicate on the "update_time" field.
+func UpdateTimeIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeNotIn applies the NotIn predicate on the "update_time" field.
+func UpdateTimeNotIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeGT applies the GT predicate on the "update_time" field.
+func UpdateTimeGT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldUpdateTime, v))
+}
+
+// UpdateTimeGTE applies the GTE predicate on the "update_time" field.
+func UpdateTimeGTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldUpdateTime, v))
+}
+
+// UpdateTimeLT applies the LT predicate on the "update_time" field.
+func UpdateTimeLT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldUpdateTime, v))
+}
+
+// UpdateTimeLTE applies the LTE predicate on the "update_time" field.
+func UpdateTimeLTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldUpdateTime, v))
+}
+
+// UpdateByEQ applies the EQ predicate on the "update_by" field.
+func UpdateByEQ(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// UpdateByNEQ applies the NEQ predicate on the "update_by" field.
+func UpdateByNEQ(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldUpdateBy, v))
+}
+
+// UpdateByIn applies the In predicate on the "update_by" field.
+func UpdateByIn(vs ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByNotIn applies the NotIn predicate on the "update_by" field.
+func UpdateByNotIn(vs ...uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByGT applies the GT predicate on the "update_by" field.
+func UpdateByGT(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldUpdateBy, v))
+}
+
+// UpdateByGTE applies the GTE predicate on the "update_by" field.
+func UpdateByGTE(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldUpdateBy, v))
+}
+
+// UpdateByLT applies the LT predicate on the "update_by" field.
+func UpdateByLT(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldUpdateBy, v))
+}
+
+// UpdateByLTE applies the LTE predicate on the "update_by" field.
+func UpdateByLTE(v uint64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldUpdateBy, v))
+}
+
+// UpdateByIsNil applies the IsNil predicate on the "update_by" field.
+func UpdateByIsNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIsNull(FieldUpdateBy))
+}
+
+// UpdateByNotNil applies the NotNil predicate on the "update_by" field.
+func UpdateByNotNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotNull(FieldUpdateBy))
+}
+
+// BucketEQ applies the EQ predicate on the "bucket" field.
+func BucketEQ(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldBucket, v))
+}
+
+// BucketNEQ applies the NEQ predicate on the "bucket" field.
+func BucketNEQ(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldBucket, v))
+}
+
+// BucketIn applies the In predicate on the "bucket" field.
+func BucketIn(vs ...string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldBucket, vs...))
+}
+
+// BucketNotIn applies the NotIn predicate on the "bucket" field.
+func BucketNotIn(vs ...string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldBucket, vs...))
+}
+
+// BucketGT applies the GT predicate on the "bucket" field.
+func BucketGT(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldBucket, v))
+}
+
+// BucketGTE applies the GTE predicate on the "bucket" field.
+func BucketGTE(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldBucket, v))
+}
+
+// BucketLT applies the LT predicate on the "bucket" field.
+func BucketLT(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldBucket, v))
+}
+
+// BucketLTE applies the LTE predicate on the "bucket" field.
+func BucketLTE(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldBucket, v))
+}
+
+// BucketContains applies the Contains predicate on the "bucket" field.
+func BucketContains(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldContains(FieldBucket, v))
+}
+
+// BucketHasPrefix applies the HasPrefix predicate on the "bucket" field.
+func BucketHasPrefix(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldHasPrefix(FieldBucket, v))
+}
+
+// BucketHasSuffix applies the HasSuffix predicate on the "bucket" field.
+func BucketHasSuffix(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldHasSuffix(FieldBucket, v))
+}
+
+// BucketEqualFold applies the EqualFold predicate on the "bucket" field.
+func BucketEqualFold(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEqualFold(FieldBucket, v))
+}
+
+// BucketContainsFold applies the ContainsFold predicate on the "bucket" field.
+func BucketContainsFold(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldContainsFold(FieldBucket, v))
+}
+
+// KeyEQ applies the EQ predicate on the "key" field.
+func KeyEQ(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldKey, v))
+}
+
+// KeyNEQ applies the NEQ predicate on the "key" field.
+func KeyNEQ(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldKey, v))
+}
+
+// KeyIn applies the In predicate on the "key" field.
+func KeyIn(vs ...string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldKey, vs...))
+}
+
+// KeyNotIn applies the NotIn predicate on the "key" field.
+func KeyNotIn(vs ...string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldKey, vs...))
+}
+
+// KeyGT applies the GT predicate on the "key" field.
+func KeyGT(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldKey, v))
+}
+
+// KeyGTE applies the GTE predicate on the "key" field.
+func KeyGTE(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldKey, v))
+}
+
+// KeyLT applies the LT predicate on the "key" field.
+func KeyLT(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldKey, v))
+}
+
+// KeyLTE applies the LTE predicate on the "key" field.
+func KeyLTE(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldKey, v))
+}
+
+// KeyContains applies the Contains predicate on the "key" field.
+func KeyContains(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldContains(FieldKey, v))
+}
+
+// KeyHasPrefix applies the HasPrefix predicate on the "key" field.
+func KeyHasPrefix(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldHasPrefix(FieldKey, v))
+}
+
+// KeyHasSuffix applies the HasSuffix predicate on the "key" field.
+func KeyHasSuffix(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldHasSuffix(FieldKey, v))
+}
+
+// KeyEqualFold applies the EqualFold predicate on the "key" field.
+func KeyEqualFold(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEqualFold(FieldKey, v))
+}
+
+// KeyContainsFold applies the ContainsFold predicate on the "key" field.
+func KeyContainsFold(v string) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldContainsFold(FieldKey, v))
+}
+
+// SizeEQ applies the EQ predicate on the "size" field.
+func SizeEQ(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldSize, v))
+}
+
+// SizeNEQ applies the NEQ predicate on the "size" field.
+func SizeNEQ(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldSize, v))
+}
+
+// SizeIn applies the In predicate on the "size" field.
+func SizeIn(vs ...int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldSize, vs...))
+}
+
+// SizeNotIn applies the NotIn predicate on the "size" field.
+func SizeNotIn(vs ...int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldSize, vs...))
+}
+
+// SizeGT applies the GT predicate on the "size" field.
+func SizeGT(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldSize, v))
+}
+
+// SizeGTE applies the GTE predicate on the "size" field.
+func SizeGTE(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldSize, v))
+}
+
+// SizeLT applies the LT predicate on the "size" field.
+func SizeLT(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldSize, v))
+}
+
+// SizeLTE applies the LTE predicate on the "size" field.
+func SizeLTE(v int64) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldSize, v))
+}
+
+// TotalRecordsEQ applies the EQ predicate on the "total_records" field.
+func TotalRecordsEQ(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldTotalRecords, v))
+}
+
+// TotalRecordsNEQ applies the NEQ predicate on the "total_records" field.
+func TotalRecordsNEQ(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldTotalRecords, v))
+}
+
+// TotalRecordsIn applies the In predicate on the "total_records" field.
+func TotalRecordsIn(vs ...int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldTotalRecords, vs...))
+}
+
+// TotalRecordsNotIn applies the NotIn predicate on the "total_records" field.
+func TotalRecordsNotIn(vs ...int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldTotalRecords, vs...))
+}
+
+// TotalRecordsGT applies the GT predicate on the "total_records" field.
+func TotalRecordsGT(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldTotalRecords, v))
+}
+
+// TotalRecordsGTE applies the GTE predicate on the "total_records" field.
+func TotalRecordsGTE(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldTotalRecords, v))
+}
+
+// TotalRecordsLT applies the LT predicate on the "total_records" field.
+func TotalRecordsLT(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldTotalRecords, v))
+}
+
+// TotalRecordsLTE applies the LTE predicate on the "total_records" field.
+func TotalRecordsLTE(v int) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldTotalRecords, v))
+}
+
+// EntityCountsIsNil applies the IsNil predicate on the "entity_counts" field.
+func EntityCountsIsNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIsNull(FieldEntityCounts))
+}
+
+// EntityCountsNotNil applies the NotNil predicate on the "entity_counts" field.
+func EntityCountsNotNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotNull(FieldEntityCounts))
+}
+
+// FailedEntitiesIsNil applies the IsNil predicate on the "failed_entities" field.
+func FailedEntitiesIsNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIsNull(FieldFailedEntities))
+}
+
+// FailedEntitiesNotNil applies the NotNil predicate on the "failed_entities" field.
+func FailedEntitiesNotNil() predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotNull(FieldFailedEntities))
+}
+
+// BackupTimeEQ applies the EQ predicate on the "backup_time" field.
+func BackupTimeEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldEQ(FieldBackupTime, v))
+}
+
+// BackupTimeNEQ applies the NEQ predicate on the "backup_time" field.
+func BackupTimeNEQ(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNEQ(FieldBackupTime, v))
+}
+
+// BackupTimeIn applies the In predicate on the "backup_time" field.
+func BackupTimeIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldIn(FieldBackupTime, vs...))
+}
+
+// BackupTimeNotIn applies the NotIn predicate on the "backup_time" field.
+func BackupTimeNotIn(vs ...time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldNotIn(FieldBackupTime, vs...))
+}
+
+// BackupTimeGT applies the GT predicate on the "backup_time" field.
+func BackupTimeGT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGT(FieldBackupTime, v))
+}
+
+// BackupTimeGTE applies the GTE predicate on the "backup_time" field.
+func BackupTimeGTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldGTE(FieldBackupTime, v))
+}
+
+// BackupTimeLT applies the LT predicate on the "backup_time" field.
+func BackupTimeLT(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLT(FieldBackupTime, v))
+}
+
+// BackupTimeLTE applies the LTE predicate on the "backup_time" field.
+func BackupTimeLTE(v time.Time) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.FieldLTE(FieldBackupTime, v))
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.DatabaseBackup) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.DatabaseBackup) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.DatabaseBackup) predicate.DatabaseBackup {
+	return predicate.DatabaseBackup(sql.NotPredicates(p))
+}
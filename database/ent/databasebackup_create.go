@@ -0,0 +1,401 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/databasebackup"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// DatabaseBackupCreate is the builder for creating a DatabaseBackup entity.
+type DatabaseBackupCreate struct {
+	config
+	mutation *DatabaseBackupMutation
+	hooks    []Hook
+}
+
+// SetCreateTime sets the "create_time" field.
+func (_c *DatabaseBackupCreate) SetCreateTime(v time.Time) *DatabaseBackupCreate {
+	_c.mutation.SetCreateTime(v)
+	return _c
+}
+
+// SetNillableCreateTime sets the "create_time" field if the given value is not nil.
+func (_c *DatabaseBackupCreate) SetNillableCreateTime(v *time.Time) *DatabaseBackupCreate {
+	if v != nil {
+		_c.SetCreateTime(*v)
+	}
+	return _c
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_c *DatabaseBackupCreate) SetCreateBy(v uint64) *DatabaseBackupCreate {
+	_c.mutation.SetCreateBy(v)
+	return _c
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_c *DatabaseBackupCreate) SetNillableCreateBy(v *uint64) *DatabaseBackupCreate {
+	if v != nil {
+		_c.SetCreateBy(*v)
+	}
+	return _c
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_c *DatabaseBackupCreate) SetUpdateTime(v time.Time) *DatabaseBackupCreate {
+	_c.mutation.SetUpdateTime(v)
+	return _c
+}
+
+// SetNillableUpdateTime sets the "update_time" field if the given value is not nil.
+func (_c *DatabaseBackupCreate) SetNillableUpdateTime(v *time.Time) *DatabaseBackupCreate {
+	if v != nil {
+		_c.SetUpdateTime(*v)
+	}
+	return _c
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_c *DatabaseBackupCreate) SetUpdateBy(v uint64) *DatabaseBackupCreate {
+	_c.mutation.SetUpdateBy(v)
+	return _c
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_c *DatabaseBackupCreate) SetNillableUpdateBy(v *uint64) *DatabaseBackupCreate {
+	if v != nil {
+		_c.SetUpdateBy(*v)
+	}
+	return _c
+}
+
+// SetBucket sets the "bucket" field.
+func (_c *DatabaseBackupCreate) SetBucket(v string) *DatabaseBackupCreate {
+	_c.mutation.SetBucket(v)
+	return _c
+}
+
+// SetKey sets the "key" field.
+func (_c *DatabaseBackupCreate) SetKey(v string) *DatabaseBackupCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetSize sets the "size" field.
+func (_c *DatabaseBackupCreate) SetSize(v int64) *DatabaseBackupCreate {
+	_c.mutation.SetSize(v)
+	return _c
+}
+
+// SetTotalRecords sets the "total_records" field.
+func (_c *DatabaseBackupCreate) SetTotalRecords(v int) *DatabaseBackupCreate {
+	_c.mutation.SetTotalRecords(v)
+	return _c
+}
+
+// SetNillableTotalRecords sets the "total_records" field if the given value is not nil.
+func (_c *DatabaseBackupCreate) SetNillableTotalRecords(v *int) *DatabaseBackupCreate {
+	if v != nil {
+		_c.SetTotalRecords(*v)
+	}
+	return _c
+}
+
+// SetEntityCounts sets the "entity_counts" field.
+func (_c *DatabaseBackupCreate) SetEntityCounts(v map[string]int) *DatabaseBackupCreate {
+	_c.mutation.SetEntityCounts(v)
+	return _c
+}
+
+// SetFailedEntities sets the "failed_entities" field.
+func (_c *DatabaseBackupCreate) SetFailedEntities(v []string) *DatabaseBackupCreate {
+	_c.mutation.SetFailedEntities(v)
+	return _c
+}
+
+// SetBackupTime sets the "backup_time" field.
+func (_c *DatabaseBackupCreate) SetBackupTime(v time.Time) *DatabaseBackupCreate {
+	_c.mutation.SetBackupTime(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *DatabaseBackupCreate) SetID(v uint64) *DatabaseBackupCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// Mutation returns the DatabaseBackupMutation object of the builder.
+func (_c *DatabaseBackupCreate) Mutation() *DatabaseBackupMutation {
+	return _c.mutation
+}
+
+// Save creates the DatabaseBackup in the database.
+func (_c *DatabaseBackupCreate) Save(ctx context.Context) (*DatabaseBackup, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *DatabaseBackupCreate) SaveX(ctx context.Context) *DatabaseBackup {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DatabaseBackupCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DatabaseBackupCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *DatabaseBackupCreate) defaults() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		if databasebackup.DefaultCreateTime == nil {
+			return fmt.Errorf("ent: uninitialized databasebackup.DefaultCreateTime (forgotten import ent/runtime?)")
+		}
+		v := databasebackup.DefaultCreateTime()
+		_c.mutation.SetCreateTime(v)
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		if databasebackup.DefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized databasebackup.DefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := databasebackup.DefaultUpdateTime()
+		_c.mutation.SetUpdateTime(v)
+	}
+	if _, ok := _c.mutation.TotalRecords(); !ok {
+		v := databasebackup.DefaultTotalRecords
+		_c.mutation.SetTotalRecords(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *DatabaseBackupCreate) check() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		return &ValidationError{Name: "create_time", err: errors.New(`ent: missing required field "DatabaseBackup.create_time"`)}
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		return &ValidationError{Name: "update_time", err: errors.New(`ent: missing required field "DatabaseBackup.update_time"`)}
+	}
+	if _, ok := _c.mutation.Bucket(); !ok {
+		return &ValidationError{Name: "bucket", err: errors.New(`ent: missing required field "DatabaseBackup.bucket"`)}
+	}
+	if v, ok := _c.mutation.Bucket(); ok {
+		if err := databasebackup.BucketValidator(v); err != nil {
+			return &ValidationError{Name: "bucket", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.bucket": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "DatabaseBackup.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := databasebackup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Size(); !ok {
+		return &ValidationError{Name: "size", err: errors.New(`ent: missing required field "DatabaseBackup.size"`)}
+	}
+	if v, ok := _c.mutation.Size(); ok {
+		if err := databasebackup.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.size": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.TotalRecords(); !ok {
+		return &ValidationError{Name: "total_records", err: errors.New(`ent: missing required field "DatabaseBackup.total_records"`)}
+	}
+	if v, ok := _c.mutation.TotalRecords(); ok {
+		if err := databasebackup.TotalRecordsValidator(v); err != nil {
+			return &ValidationError{Name: "total_records", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.total_records": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.BackupTime(); !ok {
+		return &ValidationError{Name: "backup_time", err: errors.New(`ent: missing required field "DatabaseBackup.backup_time"`)}
+	}
+	return nil
+}
+
+func (_c *DatabaseBackupCreate) sqlSave(ctx context.Context) (*DatabaseBackup, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *DatabaseBackupCreate) createSpec() (*DatabaseBackup, *sqlgraph.CreateSpec) {
+	var (
+		_node = &DatabaseBackup{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(databasebackup.Table, sqlgraph.NewFieldSpec(databasebackup.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreateTime(); ok {
+		_spec.SetField(databasebackup.FieldCreateTime, field.TypeTime, value)
+		_node.CreateTime = value
+	}
+	if value, ok := _c.mutation.CreateBy(); ok {
+		_spec.SetField(databasebackup.FieldCreateBy, field.TypeUint64, value)
+		_node.CreateBy = value
+	}
+	if value, ok := _c.mutation.UpdateTime(); ok {
+		_spec.SetField(databasebackup.FieldUpdateTime, field.TypeTime, value)
+		_node.UpdateTime = value
+	}
+	if value, ok := _c.mutation.UpdateBy(); ok {
+		_spec.SetField(databasebackup.FieldUpdateBy, field.TypeUint64, value)
+		_node.UpdateBy = value
+	}
+	if value, ok := _c.mutation.Bucket(); ok {
+		_spec.SetField(databasebackup.FieldBucket, field.TypeString, value)
+		_node.Bucket = value
+	}
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(databasebackup.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.Size(); ok {
+		_spec.SetField(databasebackup.FieldSize, field.TypeInt64, value)
+		_node.Size = value
+	}
+	if value, ok := _c.mutation.TotalRecords(); ok {
+		_spec.SetField(databasebackup.FieldTotalRecords, field.TypeInt, value)
+		_node.TotalRecords = value
+	}
+	if value, ok := _c.mutation.EntityCounts(); ok {
+		_spec.SetField(databasebackup.FieldEntityCounts, field.TypeJSON, value)
+		_node.EntityCounts = value
+	}
+	if value, ok := _c.mutation.FailedEntities(); ok {
+		_spec.SetField(databasebackup.FieldFailedEntities, field.TypeJSON, value)
+		_node.FailedEntities = value
+	}
+	if value, ok := _c.mutation.BackupTime(); ok {
+		_spec.SetField(databasebackup.FieldBackupTime, field.TypeTime, value)
+		_node.BackupTime = value
+	}
+	return _node, _spec
+}
+
+// DatabaseBackupCreateBulk is the builder for creating many DatabaseBackup entities in bulk.
+type DatabaseBackupCreateBulk struct {
+	config
+	err      error
+	builders []*DatabaseBackupCreate
+}
+
+// Save creates the DatabaseBackup entities in the database.
+func (_c *DatabaseBackupCreateBulk) Save(ctx context.Context) ([]*DatabaseBackup, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*DatabaseBackup, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*DatabaseBackupMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *DatabaseBackupCreateBulk) SaveX(ctx context.Context) []*DatabaseBackup {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *DatabaseBackupCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *DatabaseBackupCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,729 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/databasebackup"
+	"go-backend/database/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"entgo.io/ent/schema/field"
+)
+
+// DatabaseBackupUpdate is the builder for updating DatabaseBackup entities.
+type DatabaseBackupUpdate struct {
+	config
+	hooks    []Hook
+	mutation *DatabaseBackupMutation
+}
+
+// Where appends a list predicates to the DatabaseBackupUpdate builder.
+func (_u *DatabaseBackupUpdate) Where(ps ...predicate.DatabaseBackup) *DatabaseBackupUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *DatabaseBackupUpdate) SetCreateBy(v uint64) *DatabaseBackupUpdate {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableCreateBy(v *uint64) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *DatabaseBackupUpdate) AddCreateBy(v int64) *DatabaseBackupUpdate {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *DatabaseBackupUpdate) ClearCreateBy() *DatabaseBackupUpdate {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *DatabaseBackupUpdate) SetUpdateTime(v time.Time) *DatabaseBackupUpdate {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *DatabaseBackupUpdate) SetUpdateBy(v uint64) *DatabaseBackupUpdate {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableUpdateBy(v *uint64) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *DatabaseBackupUpdate) AddUpdateBy(v int64) *DatabaseBackupUpdate {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *DatabaseBackupUpdate) ClearUpdateBy() *DatabaseBackupUpdate {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetBucket sets the "bucket" field.
+func (_u *DatabaseBackupUpdate) SetBucket(v string) *DatabaseBackupUpdate {
+	_u.mutation.SetBucket(v)
+	return _u
+}
+
+// SetNillableBucket sets the "bucket" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableBucket(v *string) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetBucket(*v)
+	}
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *DatabaseBackupUpdate) SetKey(v string) *DatabaseBackupUpdate {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableKey(v *string) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *DatabaseBackupUpdate) SetSize(v int64) *DatabaseBackupUpdate {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableSize(v *int64) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *DatabaseBackupUpdate) AddSize(v int64) *DatabaseBackupUpdate {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// SetTotalRecords sets the "total_records" field.
+func (_u *DatabaseBackupUpdate) SetTotalRecords(v int) *DatabaseBackupUpdate {
+	_u.mutation.ResetTotalRecords()
+	_u.mutation.SetTotalRecords(v)
+	return _u
+}
+
+// SetNillableTotalRecords sets the "total_records" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableTotalRecords(v *int) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetTotalRecords(*v)
+	}
+	return _u
+}
+
+// AddTotalRecords adds value to the "total_records" field.
+func (_u *DatabaseBackupUpdate) AddTotalRecords(v int) *DatabaseBackupUpdate {
+	_u.mutation.AddTotalRecords(v)
+	return _u
+}
+
+// SetEntityCounts sets the "entity_counts" field.
+func (_u *DatabaseBackupUpdate) SetEntityCounts(v map[string]int) *DatabaseBackupUpdate {
+	_u.mutation.SetEntityCounts(v)
+	return _u
+}
+
+// ClearEntityCounts clears the value of the "entity_counts" field.
+func (_u *DatabaseBackupUpdate) ClearEntityCounts() *DatabaseBackupUpdate {
+	_u.mutation.ClearEntityCounts()
+	return _u
+}
+
+// SetFailedEntities sets the "failed_entities" field.
+func (_u *DatabaseBackupUpdate) SetFailedEntities(v []string) *DatabaseBackupUpdate {
+	_u.mutation.SetFailedEntities(v)
+	return _u
+}
+
+// AppendFailedEntities appends value to the "failed_entities" field.
+func (_u *DatabaseBackupUpdate) AppendFailedEntities(v []string) *DatabaseBackupUpdate {
+	_u.mutation.AppendFailedEntities(v)
+	return _u
+}
+
+// ClearFailedEntities clears the value of the "failed_entities" field.
+func (_u *DatabaseBackupUpdate) ClearFailedEntities() *DatabaseBackupUpdate {
+	_u.mutation.ClearFailedEntities()
+	return _u
+}
+
+// SetBackupTime sets the "backup_time" field.
+func (_u *DatabaseBackupUpdate) SetBackupTime(v time.Time) *DatabaseBackupUpdate {
+	_u.mutation.SetBackupTime(v)
+	return _u
+}
+
+// SetNillableBackupTime sets the "backup_time" field if the given value is not nil.
+func (_u *DatabaseBackupUpdate) SetNillableBackupTime(v *time.Time) *DatabaseBackupUpdate {
+	if v != nil {
+		_u.SetBackupTime(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DatabaseBackupMutation object of the builder.
+func (_u *DatabaseBackupUpdate) Mutation() *DatabaseBackupMutation {
+	return _u.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *DatabaseBackupUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DatabaseBackupUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *DatabaseBackupUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DatabaseBackupUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DatabaseBackupUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if databasebackup.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized databasebackup.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := databasebackup.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *DatabaseBackupUpdate) check() error {
+	if v, ok := _u.mutation.Bucket(); ok {
+		if err := databasebackup.BucketValidator(v); err != nil {
+			return &ValidationError{Name: "bucket", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.bucket": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Key(); ok {
+		if err := databasebackup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.key": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Size(); ok {
+		if err := databasebackup.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.size": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TotalRecords(); ok {
+		if err := databasebackup.TotalRecordsValidator(v); err != nil {
+			return &ValidationError{Name: "total_records", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.total_records": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *DatabaseBackupUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(databasebackup.Table, databasebackup.Columns, sqlgraph.NewFieldSpec(databasebackup.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(databasebackup.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(databasebackup.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(databasebackup.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(databasebackup.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(databasebackup.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(databasebackup.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(databasebackup.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Bucket(); ok {
+		_spec.SetField(databasebackup.FieldBucket, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(databasebackup.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(databasebackup.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(databasebackup.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.TotalRecords(); ok {
+		_spec.SetField(databasebackup.FieldTotalRecords, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTotalRecords(); ok {
+		_spec.AddField(databasebackup.FieldTotalRecords, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.EntityCounts(); ok {
+		_spec.SetField(databasebackup.FieldEntityCounts, field.TypeJSON, value)
+	}
+	if _u.mutation.EntityCountsCleared() {
+		_spec.ClearField(databasebackup.FieldEntityCounts, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.FailedEntities(); ok {
+		_spec.SetField(databasebackup.FieldFailedEntities, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedFailedEntities(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, databasebackup.FieldFailedEntities, value)
+		})
+	}
+	if _u.mutation.FailedEntitiesCleared() {
+		_spec.ClearField(databasebackup.FieldFailedEntities, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.BackupTime(); ok {
+		_spec.SetField(databasebackup.FieldBackupTime, field.TypeTime, value)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{databasebackup.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// DatabaseBackupUpdateOne is the builder for updating a single DatabaseBackup entity.
+type DatabaseBackupUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *DatabaseBackupMutation
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *DatabaseBackupUpdateOne) SetCreateBy(v uint64) *DatabaseBackupUpdateOne {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableCreateBy(v *uint64) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *DatabaseBackupUpdateOne) AddCreateBy(v int64) *DatabaseBackupUpdateOne {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *DatabaseBackupUpdateOne) ClearCreateBy() *DatabaseBackupUpdateOne {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *DatabaseBackupUpdateOne) SetUpdateTime(v time.Time) *DatabaseBackupUpdateOne {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *DatabaseBackupUpdateOne) SetUpdateBy(v uint64) *DatabaseBackupUpdateOne {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableUpdateBy(v *uint64) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *DatabaseBackupUpdateOne) AddUpdateBy(v int64) *DatabaseBackupUpdateOne {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *DatabaseBackupUpdateOne) ClearUpdateBy() *DatabaseBackupUpdateOne {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetBucket sets the "bucket" field.
+func (_u *DatabaseBackupUpdateOne) SetBucket(v string) *DatabaseBackupUpdateOne {
+	_u.mutation.SetBucket(v)
+	return _u
+}
+
+// SetNillableBucket sets the "bucket" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableBucket(v *string) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetBucket(*v)
+	}
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *DatabaseBackupUpdateOne) SetKey(v string) *DatabaseBackupUpdateOne {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableKey(v *string) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetSize sets the "size" field.
+func (_u *DatabaseBackupUpdateOne) SetSize(v int64) *DatabaseBackupUpdateOne {
+	_u.mutation.ResetSize()
+	_u.mutation.SetSize(v)
+	return _u
+}
+
+// SetNillableSize sets the "size" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableSize(v *int64) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetSize(*v)
+	}
+	return _u
+}
+
+// AddSize adds value to the "size" field.
+func (_u *DatabaseBackupUpdateOne) AddSize(v int64) *DatabaseBackupUpdateOne {
+	_u.mutation.AddSize(v)
+	return _u
+}
+
+// SetTotalRecords sets the "total_records" field.
+func (_u *DatabaseBackupUpdateOne) SetTotalRecords(v int) *DatabaseBackupUpdateOne {
+	_u.mutation.ResetTotalRecords()
+	_u.mutation.SetTotalRecords(v)
+	return _u
+}
+
+// SetNillableTotalRecords sets the "total_records" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableTotalRecords(v *int) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetTotalRecords(*v)
+	}
+	return _u
+}
+
+// AddTotalRecords adds value to the "total_records" field.
+func (_u *DatabaseBackupUpdateOne) AddTotalRecords(v int) *DatabaseBackupUpdateOne {
+	_u.mutation.AddTotalRecords(v)
+	return _u
+}
+
+// SetEntityCounts sets the "entity_counts" field.
+func (_u *DatabaseBackupUpdateOne) SetEntityCounts(v map[string]int) *DatabaseBackupUpdateOne {
+	_u.mutation.SetEntityCounts(v)
+	return _u
+}
+
+// ClearEntityCounts clears the value of the "entity_counts" field.
+func (_u *DatabaseBackupUpdateOne) ClearEntityCounts() *DatabaseBackupUpdateOne {
+	_u.mutation.ClearEntityCounts()
+	return _u
+}
+
+// SetFailedEntities sets the "failed_entities" field.
+func (_u *DatabaseBackupUpdateOne) SetFailedEntities(v []string) *DatabaseBackupUpdateOne {
+	_u.mutation.SetFailedEntities(v)
+	return _u
+}
+
+// AppendFailedEntities appends value to the "failed_entities" field.
+func (_u *DatabaseBackupUpdateOne) AppendFailedEntities(v []string) *DatabaseBackupUpdateOne {
+	_u.mutation.AppendFailedEntities(v)
+	return _u
+}
+
+// ClearFailedEntities clears the value of the "failed_entities" field.
+func (_u *DatabaseBackupUpdateOne) ClearFailedEntities() *DatabaseBackupUpdateOne {
+	_u.mutation.ClearFailedEntities()
+	return _u
+}
+
+// SetBackupTime sets the "backup_time" field.
+func (_u *DatabaseBackupUpdateOne) SetBackupTime(v time.Time) *DatabaseBackupUpdateOne {
+	_u.mutation.SetBackupTime(v)
+	return _u
+}
+
+// SetNillableBackupTime sets the "backup_time" field if the given value is not nil.
+func (_u *DatabaseBackupUpdateOne) SetNillableBackupTime(v *time.Time) *DatabaseBackupUpdateOne {
+	if v != nil {
+		_u.SetBackupTime(*v)
+	}
+	return _u
+}
+
+// Mutation returns the DatabaseBackupMutation object of the builder.
+func (_u *DatabaseBackupUpdateOne) Mutation() *DatabaseBackupMutation {
+	return _u.mutation
+}
+
+// Where appends a list predicates to the DatabaseBackupUpdate builder.
+func (_u *DatabaseBackupUpdateOne) Where(ps ...predicate.DatabaseBackup) *DatabaseBackupUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *DatabaseBackupUpdateOne) Select(field string, fields ...string) *DatabaseBackupUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated DatabaseBackup entity.
+func (_u *DatabaseBackupUpdateOne) Save(ctx context.Context) (*DatabaseBackup, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *DatabaseBackupUpdateOne) SaveX(ctx context.Context) *DatabaseBackup {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *DatabaseBackupUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *DatabaseBackupUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *DatabaseBackupUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if databasebackup.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized databasebackup.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := databasebackup.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *DatabaseBackupUpdateOne) check() error {
+	if v, ok := _u.mutation.Bucket(); ok {
+		if err := databasebackup.BucketValidator(v); err != nil {
+			return &ValidationError{Name: "bucket", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.bucket": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Key(); ok {
+		if err := databasebackup.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.key": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.Size(); ok {
+		if err := databasebackup.SizeValidator(v); err != nil {
+			return &ValidationError{Name: "size", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.size": %w`, err)}
+		}
+	}
+	if v, ok := _u.mutation.TotalRecords(); ok {
+		if err := databasebackup.TotalRecordsValidator(v); err != nil {
+			return &ValidationError{Name: "total_records", err: fmt.Errorf(`ent: validator failed for field "DatabaseBackup.total_records": %w`, err)}
+		}
+	}
+	return nil
+}
+
+func (_u *DatabaseBackupUpdateOne) sqlSave(ctx context.Context) (_node *DatabaseBackup, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(databasebackup.Table, databasebackup.Columns, sqlgraph.NewFieldSpec(databasebackup.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "DatabaseBackup.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, databasebackup.FieldID)
+		for _, f := range fields {
+			if !databasebackup.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != databasebackup.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(databasebackup.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(databasebackup.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(databasebackup.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(databasebackup.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(databasebackup.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(databasebackup.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(databasebackup.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Bucket(); ok {
+		_spec.SetField(databasebackup.FieldBucket, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(databasebackup.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Size(); ok {
+		_spec.SetField(databasebackup.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.AddedSize(); ok {
+		_spec.AddField(databasebackup.FieldSize, field.TypeInt64, value)
+	}
+	if value, ok := _u.mutation.TotalRecords(); ok {
+		_spec.SetField(databasebackup.FieldTotalRecords, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.AddedTotalRecords(); ok {
+		_spec.AddField(databasebackup.FieldTotalRecords, field.TypeInt, value)
+	}
+	if value, ok := _u.mutation.EntityCounts(); ok {
+		_spec.SetField(databasebackup.FieldEntityCounts, field.TypeJSON, value)
+	}
+	if _u.mutation.EntityCountsCleared() {
+		_spec.ClearField(databasebackup.FieldEntityCounts, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.FailedEntities(); ok {
+		_spec.SetField(databasebackup.FieldFailedEntities, field.TypeJSON, value)
+	}
+	if value, ok := _u.mutation.AppendedFailedEntities(); ok {
+		_spec.AddModifier(func(u *sql.UpdateBuilder) {
+			sqljson.Append(u, databasebackup.FieldFailedEntities, value)
+		})
+	}
+	if _u.mutation.FailedEntitiesCleared() {
+		_spec.ClearField(databasebackup.FieldFailedEntities, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.BackupTime(); ok {
+		_spec.SetField(databasebackup.FieldBackupTime, field.TypeTime, value)
+	}
+	_node = &DatabaseBackup{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{databasebackup.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -12,6 +12,7 @@ import (
 	"go-backend/database/ent/attachment"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
+	"go-backend/database/ent/databasebackup"
 	"go-backend/database/ent/logging"
 	"go-backend/database/ent/loginrecord"
 	"go-backend/database/ent/oauthapplication"
@@ -34,11 +35,14 @@ import (
 	"go-backend/database/ent/userrole"
 	"go-backend/database/ent/verifycode"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflowexecutionlog"
 	"go-backend/database/ent/workflownode"
 	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
 	"reflect"
 	"sync"
@@ -106,40 +110,44 @@ var (
 func checkColumn(t, c string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			apiauth.Table:                apiauth.ValidColumn,
-			address.Table:                address.ValidColumn,
-			area.Table:                   area.ValidColumn,
-			attachment.Table:             attachment.ValidColumn,
-			clientdevice.Table:           clientdevice.ValidColumn,
-			credential.Table:             credential.ValidColumn,
-			logging.Table:                logging.ValidColumn,
-			loginrecord.Table:            loginrecord.ValidColumn,
-			oauthapplication.Table:       oauthapplication.ValidColumn,
-			oauthauthorizationcode.Table: oauthauthorizationcode.ValidColumn,
-			oauthprovider.Table:          oauthprovider.ValidColumn,
-			oauthstate.Table:             oauthstate.ValidColumn,
-			oauthtoken.Table:             oauthtoken.ValidColumn,
-			oauthuser.Table:              oauthuser.ValidColumn,
-			oauthuserauthorization.Table: oauthuserauthorization.ValidColumn,
-			permission.Table:             permission.ValidColumn,
-			role.Table:                   role.ValidColumn,
-			rolepermission.Table:         rolepermission.ValidColumn,
-			scan.Table:                   scan.ValidColumn,
-			scope.Table:                  scope.ValidColumn,
-			station.Table:                station.ValidColumn,
-			subway.Table:                 subway.ValidColumn,
-			subwaystation.Table:          subwaystation.ValidColumn,
-			systemmonitor.Table:          systemmonitor.ValidColumn,
-			user.Table:                   user.ValidColumn,
-			userrole.Table:               userrole.ValidColumn,
-			verifycode.Table:             verifycode.ValidColumn,
-			workflowapplication.Table:    workflowapplication.ValidColumn,
-			workflowedge.Table:           workflowedge.ValidColumn,
-			workflowexecution.Table:      workflowexecution.ValidColumn,
-			workflowexecutionlog.Table:   workflowexecutionlog.ValidColumn,
-			workflownode.Table:           workflownode.ValidColumn,
-			workflownodeexecution.Table:  workflownodeexecution.ValidColumn,
-			workflowversion.Table:        workflowversion.ValidColumn,
+			apiauth.Table:                  apiauth.ValidColumn,
+			address.Table:                  address.ValidColumn,
+			area.Table:                     area.ValidColumn,
+			attachment.Table:               attachment.ValidColumn,
+			clientdevice.Table:             clientdevice.ValidColumn,
+			credential.Table:               credential.ValidColumn,
+			databasebackup.Table:           databasebackup.ValidColumn,
+			logging.Table:                  logging.ValidColumn,
+			loginrecord.Table:              loginrecord.ValidColumn,
+			oauthapplication.Table:         oauthapplication.ValidColumn,
+			oauthauthorizationcode.Table:   oauthauthorizationcode.ValidColumn,
+			oauthprovider.Table:            oauthprovider.ValidColumn,
+			oauthstate.Table:               oauthstate.ValidColumn,
+			oauthtoken.Table:               oauthtoken.ValidColumn,
+			oauthuser.Table:                oauthuser.ValidColumn,
+			oauthuserauthorization.Table:   oauthuserauthorization.ValidColumn,
+			permission.Table:               permission.ValidColumn,
+			role.Table:                     role.ValidColumn,
+			rolepermission.Table:           rolepermission.ValidColumn,
+			scan.Table:                     scan.ValidColumn,
+			scope.Table:                    scope.ValidColumn,
+			station.Table:                  station.ValidColumn,
+			subway.Table:                   subway.ValidColumn,
+			subwaystation.Table:            subwaystation.ValidColumn,
+			systemmonitor.Table:            systemmonitor.ValidColumn,
+			user.Table:                     user.ValidColumn,
+			userrole.Table:                 userrole.ValidColumn,
+			verifycode.Table:               verifycode.ValidColumn,
+			workflowapplication.Table:      workflowapplication.ValidColumn,
+			workflowapplicationshare.Table: workflowapplicationshare.ValidColumn,
+			workflowedge.Table:             workflowedge.ValidColumn,
+			workflowexecution.Table:        workflowexecution.ValidColumn,
+			workflowexecutionlog.Table:     workflowexecutionlog.ValidColumn,
+			workflownode.Table:             workflownode.ValidColumn,
+			workflownodeexecution.Table:    workflownodeexecution.ValidColumn,
+			workflowschedule.Table:         workflowschedule.ValidColumn,
+			workflowsecret.Table:           workflowsecret.ValidColumn,
+			workflowversion.Table:          workflowversion.ValidColumn,
 		})
 	})
 	return columnCheck(t, c)
@@ -9,6 +9,7 @@ import (
 	"go-backend/database/ent/attachment"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
+	"go-backend/database/ent/databasebackup"
 	"go-backend/database/ent/logging"
 	"go-backend/database/ent/loginrecord"
 	"go-backend/database/ent/oauthapplication"
@@ -32,11 +33,14 @@ import (
 	"go-backend/database/ent/userrole"
 	"go-backend/database/ent/verifycode"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflowexecutionlog"
 	"go-backend/database/ent/workflownode"
 	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
 
 	"entgo.io/ent/dialect/sql"
@@ -47,7 +51,7 @@ import (
 
 // schemaGraph holds a representation of ent/schema at runtime.
 var schemaGraph = func() *sqlgraph.Schema {
-	graph := &sqlgraph.Schema{Nodes: make([]*sqlgraph.Node, 34)}
+	graph := &sqlgraph.Schema{Nodes: make([]*sqlgraph.Node, 38)}
 	graph.Nodes[0] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   apiauth.Table,
@@ -187,6 +191,8 @@ var schemaGraph = func() *sqlgraph.Schema {
 			clientdevice.FieldAccessTokenExpiry:  {Type: field.TypeUint64, Column: clientdevice.FieldAccessTokenExpiry},
 			clientdevice.FieldRefreshTokenExpiry: {Type: field.TypeUint64, Column: clientdevice.FieldRefreshTokenExpiry},
 			clientdevice.FieldAnonymous:          {Type: field.TypeBool, Column: clientdevice.FieldAnonymous},
+			clientdevice.FieldAudience:           {Type: field.TypeString, Column: clientdevice.FieldAudience},
+			clientdevice.FieldScopes:             {Type: field.TypeJSON, Column: clientdevice.FieldScopes},
 		},
 	}
 	graph.Nodes[5] = &sqlgraph.Node{
@@ -222,6 +228,30 @@ var schemaGraph = func() *sqlgraph.Schema {
 		},
 	}
 	graph.Nodes[6] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   databasebackup.Table,
+			Columns: databasebackup.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeUint64,
+				Column: databasebackup.FieldID,
+			},
+		},
+		Type: "DatabaseBackup",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			databasebackup.FieldCreateTime:     {Type: field.TypeTime, Column: databasebackup.FieldCreateTime},
+			databasebackup.FieldCreateBy:       {Type: field.TypeUint64, Column: databasebackup.FieldCreateBy},
+			databasebackup.FieldUpdateTime:     {Type: field.TypeTime, Column: databasebackup.FieldUpdateTime},
+			databasebackup.FieldUpdateBy:       {Type: field.TypeUint64, Column: databasebackup.FieldUpdateBy},
+			databasebackup.FieldBucket:         {Type: field.TypeString, Column: databasebackup.FieldBucket},
+			databasebackup.FieldKey:            {Type: field.TypeString, Column: databasebackup.FieldKey},
+			databasebackup.FieldSize:           {Type: field.TypeInt64, Column: databasebackup.FieldSize},
+			databasebackup.FieldTotalRecords:   {Type: field.TypeInt, Column: databasebackup.FieldTotalRecords},
+			databasebackup.FieldEntityCounts:   {Type: field.TypeJSON, Column: databasebackup.FieldEntityCounts},
+			databasebackup.FieldFailedEntities: {Type: field.TypeJSON, Column: databasebackup.FieldFailedEntities},
+			databasebackup.FieldBackupTime:     {Type: field.TypeTime, Column: databasebackup.FieldBackupTime},
+		},
+	}
+	graph.Nodes[7] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   logging.Table,
 			Columns: logging.Columns,
@@ -251,7 +281,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			logging.FieldStack:      {Type: field.TypeString, Column: logging.FieldStack},
 		},
 	}
-	graph.Nodes[7] = &sqlgraph.Node{
+	graph.Nodes[8] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   loginrecord.Table,
 			Columns: loginrecord.Columns,
@@ -282,7 +312,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			loginrecord.FieldClientID:       {Type: field.TypeUint64, Column: loginrecord.FieldClientID},
 		},
 	}
-	graph.Nodes[8] = &sqlgraph.Node{
+	graph.Nodes[9] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthapplication.Table,
 			Columns: oauthapplication.Columns,
@@ -309,7 +339,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthapplication.FieldSystemID:       {Type: field.TypeUint64, Column: oauthapplication.FieldSystemID},
 		},
 	}
-	graph.Nodes[9] = &sqlgraph.Node{
+	graph.Nodes[10] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthauthorizationcode.Table,
 			Columns: oauthauthorizationcode.Columns,
@@ -337,7 +367,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthauthorizationcode.FieldCodeChallengeMethod: {Type: field.TypeString, Column: oauthauthorizationcode.FieldCodeChallengeMethod},
 		},
 	}
-	graph.Nodes[10] = &sqlgraph.Node{
+	graph.Nodes[11] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthprovider.Table,
 			Columns: oauthprovider.Columns,
@@ -370,7 +400,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthprovider.FieldMetadata:              {Type: field.TypeJSON, Column: oauthprovider.FieldMetadata},
 		},
 	}
-	graph.Nodes[11] = &sqlgraph.Node{
+	graph.Nodes[12] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthstate.Table,
 			Columns: oauthstate.Columns,
@@ -395,7 +425,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthstate.FieldUsedAt:     {Type: field.TypeTime, Column: oauthstate.FieldUsedAt},
 		},
 	}
-	graph.Nodes[12] = &sqlgraph.Node{
+	graph.Nodes[13] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthtoken.Table,
 			Columns: oauthtoken.Columns,
@@ -423,7 +453,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthtoken.FieldLastUsedAt:       {Type: field.TypeTime, Column: oauthtoken.FieldLastUsedAt},
 		},
 	}
-	graph.Nodes[13] = &sqlgraph.Node{
+	graph.Nodes[14] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthuser.Table,
 			Columns: oauthuser.Columns,
@@ -452,7 +482,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthuser.FieldLoadState:        {Type: field.TypeEnum, Column: oauthuser.FieldLoadState},
 		},
 	}
-	graph.Nodes[14] = &sqlgraph.Node{
+	graph.Nodes[15] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   oauthuserauthorization.Table,
 			Columns: oauthuserauthorization.Columns,
@@ -476,7 +506,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			oauthuserauthorization.FieldScope:         {Type: field.TypeJSON, Column: oauthuserauthorization.FieldScope},
 		},
 	}
-	graph.Nodes[15] = &sqlgraph.Node{
+	graph.Nodes[16] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   permission.Table,
 			Columns: permission.Columns,
@@ -499,7 +529,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			permission.FieldIsPublic:    {Type: field.TypeBool, Column: permission.FieldIsPublic},
 		},
 	}
-	graph.Nodes[16] = &sqlgraph.Node{
+	graph.Nodes[17] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   role.Table,
 			Columns: role.Columns,
@@ -520,7 +550,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			role.FieldDescription: {Type: field.TypeString, Column: role.FieldDescription},
 		},
 	}
-	graph.Nodes[17] = &sqlgraph.Node{
+	graph.Nodes[18] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   rolepermission.Table,
 			Columns: rolepermission.Columns,
@@ -541,7 +571,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			rolepermission.FieldPermissionID: {Type: field.TypeUint64, Column: rolepermission.FieldPermissionID},
 		},
 	}
-	graph.Nodes[18] = &sqlgraph.Node{
+	graph.Nodes[19] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   scan.Table,
 			Columns: scan.Columns,
@@ -563,7 +593,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			scan.FieldSuccess:    {Type: field.TypeBool, Column: scan.FieldSuccess},
 		},
 	}
-	graph.Nodes[19] = &sqlgraph.Node{
+	graph.Nodes[20] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   scope.Table,
 			Columns: scope.Columns,
@@ -594,7 +624,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			scope.FieldParentID:    {Type: field.TypeUint64, Column: scope.FieldParentID},
 		},
 	}
-	graph.Nodes[20] = &sqlgraph.Node{
+	graph.Nodes[21] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   station.Table,
 			Columns: station.Columns,
@@ -615,7 +645,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			station.FieldAreaID:     {Type: field.TypeUint64, Column: station.FieldAreaID},
 		},
 	}
-	graph.Nodes[21] = &sqlgraph.Node{
+	graph.Nodes[22] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   subway.Table,
 			Columns: subway.Columns,
@@ -637,7 +667,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			subway.FieldColor:      {Type: field.TypeString, Column: subway.FieldColor},
 		},
 	}
-	graph.Nodes[22] = &sqlgraph.Node{
+	graph.Nodes[23] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   subwaystation.Table,
 			Columns: subwaystation.Columns,
@@ -659,7 +689,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			subwaystation.FieldSequence:   {Type: field.TypeInt, Column: subwaystation.FieldSequence},
 		},
 	}
-	graph.Nodes[23] = &sqlgraph.Node{
+	graph.Nodes[24] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   systemmonitor.Table,
 			Columns: systemmonitor.Columns,
@@ -701,7 +731,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			systemmonitor.FieldRecordedAt:         {Type: field.TypeTime, Column: systemmonitor.FieldRecordedAt},
 		},
 	}
-	graph.Nodes[24] = &sqlgraph.Node{
+	graph.Nodes[25] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   user.Table,
 			Columns: user.Columns,
@@ -725,7 +755,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			user.FieldAvatarID:   {Type: field.TypeUint64, Column: user.FieldAvatarID},
 		},
 	}
-	graph.Nodes[25] = &sqlgraph.Node{
+	graph.Nodes[26] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   userrole.Table,
 			Columns: userrole.Columns,
@@ -744,9 +774,10 @@ var schemaGraph = func() *sqlgraph.Schema {
 			userrole.FieldDeleteBy:   {Type: field.TypeUint64, Column: userrole.FieldDeleteBy},
 			userrole.FieldUserID:     {Type: field.TypeUint64, Column: userrole.FieldUserID},
 			userrole.FieldRoleID:     {Type: field.TypeUint64, Column: userrole.FieldRoleID},
+			userrole.FieldExpiresAt:  {Type: field.TypeTime, Column: userrole.FieldExpiresAt},
 		},
 	}
-	graph.Nodes[26] = &sqlgraph.Node{
+	graph.Nodes[27] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   verifycode.Table,
 			Columns: verifycode.Columns,
@@ -774,7 +805,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			verifycode.FieldClientID:    {Type: field.TypeUint64, Column: verifycode.FieldClientID},
 		},
 	}
-	graph.Nodes[27] = &sqlgraph.Node{
+	graph.Nodes[28] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflowapplication.Table,
 			Columns: workflowapplication.Columns,
@@ -799,9 +830,32 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflowapplication.FieldVersion:        {Type: field.TypeUint, Column: workflowapplication.FieldVersion},
 			workflowapplication.FieldStatus:         {Type: field.TypeEnum, Column: workflowapplication.FieldStatus},
 			workflowapplication.FieldViewportConfig: {Type: field.TypeJSON, Column: workflowapplication.FieldViewportConfig},
+			workflowapplication.FieldInputSchema:    {Type: field.TypeJSON, Column: workflowapplication.FieldInputSchema},
+			workflowapplication.FieldIsTemplate:     {Type: field.TypeBool, Column: workflowapplication.FieldIsTemplate},
+			workflowapplication.FieldOwnerID:        {Type: field.TypeUint64, Column: workflowapplication.FieldOwnerID},
 		},
 	}
-	graph.Nodes[28] = &sqlgraph.Node{
+	graph.Nodes[29] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   workflowapplicationshare.Table,
+			Columns: workflowapplicationshare.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeUint64,
+				Column: workflowapplicationshare.FieldID,
+			},
+		},
+		Type: "WorkflowApplicationShare",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			workflowapplicationshare.FieldCreateTime:    {Type: field.TypeTime, Column: workflowapplicationshare.FieldCreateTime},
+			workflowapplicationshare.FieldCreateBy:      {Type: field.TypeUint64, Column: workflowapplicationshare.FieldCreateBy},
+			workflowapplicationshare.FieldUpdateTime:    {Type: field.TypeTime, Column: workflowapplicationshare.FieldUpdateTime},
+			workflowapplicationshare.FieldUpdateBy:      {Type: field.TypeUint64, Column: workflowapplicationshare.FieldUpdateBy},
+			workflowapplicationshare.FieldApplicationID: {Type: field.TypeUint64, Column: workflowapplicationshare.FieldApplicationID},
+			workflowapplicationshare.FieldUserID:        {Type: field.TypeUint64, Column: workflowapplicationshare.FieldUserID},
+			workflowapplicationshare.FieldAccess:        {Type: field.TypeEnum, Column: workflowapplicationshare.FieldAccess},
+		},
+	}
+	graph.Nodes[30] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflowedge.Table,
 			Columns: workflowedge.Columns,
@@ -831,7 +885,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflowedge.FieldData:          {Type: field.TypeJSON, Column: workflowedge.FieldData},
 		},
 	}
-	graph.Nodes[29] = &sqlgraph.Node{
+	graph.Nodes[31] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflowexecution.Table,
 			Columns: workflowexecution.Columns,
@@ -842,28 +896,31 @@ var schemaGraph = func() *sqlgraph.Schema {
 		},
 		Type: "WorkflowExecution",
 		Fields: map[string]*sqlgraph.FieldSpec{
-			workflowexecution.FieldCreateTime:    {Type: field.TypeTime, Column: workflowexecution.FieldCreateTime},
-			workflowexecution.FieldCreateBy:      {Type: field.TypeUint64, Column: workflowexecution.FieldCreateBy},
-			workflowexecution.FieldUpdateTime:    {Type: field.TypeTime, Column: workflowexecution.FieldUpdateTime},
-			workflowexecution.FieldUpdateBy:      {Type: field.TypeUint64, Column: workflowexecution.FieldUpdateBy},
-			workflowexecution.FieldExecutionID:   {Type: field.TypeString, Column: workflowexecution.FieldExecutionID},
-			workflowexecution.FieldApplicationID: {Type: field.TypeUint64, Column: workflowexecution.FieldApplicationID},
-			workflowexecution.FieldStatus:        {Type: field.TypeEnum, Column: workflowexecution.FieldStatus},
-			workflowexecution.FieldInput:         {Type: field.TypeJSON, Column: workflowexecution.FieldInput},
-			workflowexecution.FieldOutput:        {Type: field.TypeJSON, Column: workflowexecution.FieldOutput},
-			workflowexecution.FieldContext:       {Type: field.TypeJSON, Column: workflowexecution.FieldContext},
-			workflowexecution.FieldStartedAt:     {Type: field.TypeTime, Column: workflowexecution.FieldStartedAt},
-			workflowexecution.FieldFinishedAt:    {Type: field.TypeTime, Column: workflowexecution.FieldFinishedAt},
-			workflowexecution.FieldDurationMs:    {Type: field.TypeInt, Column: workflowexecution.FieldDurationMs},
-			workflowexecution.FieldTotalTokens:   {Type: field.TypeInt, Column: workflowexecution.FieldTotalTokens},
-			workflowexecution.FieldTotalCost:     {Type: field.TypeFloat64, Column: workflowexecution.FieldTotalCost},
-			workflowexecution.FieldErrorMessage:  {Type: field.TypeString, Column: workflowexecution.FieldErrorMessage},
-			workflowexecution.FieldErrorStack:    {Type: field.TypeString, Column: workflowexecution.FieldErrorStack},
-			workflowexecution.FieldTriggeredBy:   {Type: field.TypeString, Column: workflowexecution.FieldTriggeredBy},
-			workflowexecution.FieldTriggerSource: {Type: field.TypeString, Column: workflowexecution.FieldTriggerSource},
+			workflowexecution.FieldCreateTime:        {Type: field.TypeTime, Column: workflowexecution.FieldCreateTime},
+			workflowexecution.FieldCreateBy:          {Type: field.TypeUint64, Column: workflowexecution.FieldCreateBy},
+			workflowexecution.FieldUpdateTime:        {Type: field.TypeTime, Column: workflowexecution.FieldUpdateTime},
+			workflowexecution.FieldUpdateBy:          {Type: field.TypeUint64, Column: workflowexecution.FieldUpdateBy},
+			workflowexecution.FieldExecutionID:       {Type: field.TypeString, Column: workflowexecution.FieldExecutionID},
+			workflowexecution.FieldApplicationID:     {Type: field.TypeUint64, Column: workflowexecution.FieldApplicationID},
+			workflowexecution.FieldStatus:            {Type: field.TypeEnum, Column: workflowexecution.FieldStatus},
+			workflowexecution.FieldInput:             {Type: field.TypeJSON, Column: workflowexecution.FieldInput},
+			workflowexecution.FieldOutput:            {Type: field.TypeJSON, Column: workflowexecution.FieldOutput},
+			workflowexecution.FieldContext:           {Type: field.TypeJSON, Column: workflowexecution.FieldContext},
+			workflowexecution.FieldVariables:         {Type: field.TypeJSON, Column: workflowexecution.FieldVariables},
+			workflowexecution.FieldGraphSnapshot:     {Type: field.TypeString, Column: workflowexecution.FieldGraphSnapshot},
+			workflowexecution.FieldStartedAt:         {Type: field.TypeTime, Column: workflowexecution.FieldStartedAt},
+			workflowexecution.FieldFinishedAt:        {Type: field.TypeTime, Column: workflowexecution.FieldFinishedAt},
+			workflowexecution.FieldDurationMs:        {Type: field.TypeInt, Column: workflowexecution.FieldDurationMs},
+			workflowexecution.FieldTotalTokens:       {Type: field.TypeInt, Column: workflowexecution.FieldTotalTokens},
+			workflowexecution.FieldTotalCost:         {Type: field.TypeFloat64, Column: workflowexecution.FieldTotalCost},
+			workflowexecution.FieldErrorMessage:      {Type: field.TypeString, Column: workflowexecution.FieldErrorMessage},
+			workflowexecution.FieldErrorStack:        {Type: field.TypeString, Column: workflowexecution.FieldErrorStack},
+			workflowexecution.FieldTriggeredBy:       {Type: field.TypeString, Column: workflowexecution.FieldTriggeredBy},
+			workflowexecution.FieldTriggerSource:     {Type: field.TypeString, Column: workflowexecution.FieldTriggerSource},
+			workflowexecution.FieldParentExecutionID: {Type: field.TypeUint64, Column: workflowexecution.FieldParentExecutionID},
 		},
 	}
-	graph.Nodes[30] = &sqlgraph.Node{
+	graph.Nodes[32] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflowexecutionlog.Table,
 			Columns: workflowexecutionlog.Columns,
@@ -886,7 +943,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflowexecutionlog.FieldLoggedAt:        {Type: field.TypeTime, Column: workflowexecutionlog.FieldLoggedAt},
 		},
 	}
-	graph.Nodes[31] = &sqlgraph.Node{
+	graph.Nodes[33] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflownode.Table,
 			Columns: workflownode.Columns,
@@ -921,9 +978,10 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflownode.FieldPositionX:             {Type: field.TypeFloat64, Column: workflownode.FieldPositionX},
 			workflownode.FieldPositionY:             {Type: field.TypeFloat64, Column: workflownode.FieldPositionY},
 			workflownode.FieldColor:                 {Type: field.TypeString, Column: workflownode.FieldColor},
+			workflownode.FieldEnabled:               {Type: field.TypeBool, Column: workflownode.FieldEnabled},
 		},
 	}
-	graph.Nodes[32] = &sqlgraph.Node{
+	graph.Nodes[34] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflownodeexecution.Table,
 			Columns: workflownodeexecution.Columns,
@@ -961,7 +1019,53 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflownodeexecution.FieldParentExecutionID: {Type: field.TypeUint64, Column: workflownodeexecution.FieldParentExecutionID},
 		},
 	}
-	graph.Nodes[33] = &sqlgraph.Node{
+	graph.Nodes[35] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   workflowschedule.Table,
+			Columns: workflowschedule.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeUint64,
+				Column: workflowschedule.FieldID,
+			},
+		},
+		Type: "WorkflowSchedule",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			workflowschedule.FieldCreateTime:     {Type: field.TypeTime, Column: workflowschedule.FieldCreateTime},
+			workflowschedule.FieldCreateBy:       {Type: field.TypeUint64, Column: workflowschedule.FieldCreateBy},
+			workflowschedule.FieldUpdateTime:     {Type: field.TypeTime, Column: workflowschedule.FieldUpdateTime},
+			workflowschedule.FieldUpdateBy:       {Type: field.TypeUint64, Column: workflowschedule.FieldUpdateBy},
+			workflowschedule.FieldDeleteTime:     {Type: field.TypeTime, Column: workflowschedule.FieldDeleteTime},
+			workflowschedule.FieldDeleteBy:       {Type: field.TypeUint64, Column: workflowschedule.FieldDeleteBy},
+			workflowschedule.FieldApplicationID:  {Type: field.TypeUint64, Column: workflowschedule.FieldApplicationID},
+			workflowschedule.FieldName:           {Type: field.TypeString, Column: workflowschedule.FieldName},
+			workflowschedule.FieldCronExpression: {Type: field.TypeString, Column: workflowschedule.FieldCronExpression},
+			workflowschedule.FieldInput:          {Type: field.TypeJSON, Column: workflowschedule.FieldInput},
+			workflowschedule.FieldEnabled:        {Type: field.TypeBool, Column: workflowschedule.FieldEnabled},
+			workflowschedule.FieldLastRunAt:      {Type: field.TypeTime, Column: workflowschedule.FieldLastRunAt},
+			workflowschedule.FieldNextRunAt:      {Type: field.TypeTime, Column: workflowschedule.FieldNextRunAt},
+		},
+	}
+	graph.Nodes[36] = &sqlgraph.Node{
+		NodeSpec: sqlgraph.NodeSpec{
+			Table:   workflowsecret.Table,
+			Columns: workflowsecret.Columns,
+			ID: &sqlgraph.FieldSpec{
+				Type:   field.TypeUint64,
+				Column: workflowsecret.FieldID,
+			},
+		},
+		Type: "WorkflowSecret",
+		Fields: map[string]*sqlgraph.FieldSpec{
+			workflowsecret.FieldCreateTime:    {Type: field.TypeTime, Column: workflowsecret.FieldCreateTime},
+			workflowsecret.FieldCreateBy:      {Type: field.TypeUint64, Column: workflowsecret.FieldCreateBy},
+			workflowsecret.FieldUpdateTime:    {Type: field.TypeTime, Column: workflowsecret.FieldUpdateTime},
+			workflowsecret.FieldUpdateBy:      {Type: field.TypeUint64, Column: workflowsecret.FieldUpdateBy},
+			workflowsecret.FieldApplicationID: {Type: field.TypeUint64, Column: workflowsecret.FieldApplicationID},
+			workflowsecret.FieldKey:           {Type: field.TypeString, Column: workflowsecret.FieldKey},
+			workflowsecret.FieldValue:         {Type: field.TypeString, Column: workflowsecret.FieldValue},
+		},
+	}
+	graph.Nodes[37] = &sqlgraph.Node{
 		NodeSpec: sqlgraph.NodeSpec{
 			Table:   workflowversion.Table,
 			Columns: workflowversion.Columns,
@@ -980,6 +1084,7 @@ var schemaGraph = func() *sqlgraph.Schema {
 			workflowversion.FieldVersion:       {Type: field.TypeUint, Column: workflowversion.FieldVersion},
 			workflowversion.FieldSnapshot:      {Type: field.TypeJSON, Column: workflowversion.FieldSnapshot},
 			workflowversion.FieldChangeLog:     {Type: field.TypeString, Column: workflowversion.FieldChangeLog},
+			workflowversion.FieldPinned:        {Type: field.TypeBool, Column: workflowversion.FieldPinned},
 		},
 	}
 	graph.MustAddE(
@@ -1726,6 +1831,54 @@ var schemaGraph = func() *sqlgraph.Schema {
 		"WorkflowApplication",
 		"WorkflowExecution",
 	)
+	graph.MustAddE(
+		"schedules",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+		},
+		"WorkflowApplication",
+		"WorkflowSchedule",
+	)
+	graph.MustAddE(
+		"secrets",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+		},
+		"WorkflowApplication",
+		"WorkflowSecret",
+	)
+	graph.MustAddE(
+		"shares",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+		},
+		"WorkflowApplication",
+		"WorkflowApplicationShare",
+	)
+	graph.MustAddE(
+		"application",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+		},
+		"WorkflowApplicationShare",
+		"WorkflowApplication",
+	)
 	graph.MustAddE(
 		"application",
 		&sqlgraph.EdgeSpec{
@@ -1858,6 +2011,30 @@ var schemaGraph = func() *sqlgraph.Schema {
 		"WorkflowNodeExecution",
 		"WorkflowNode",
 	)
+	graph.MustAddE(
+		"application",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+		},
+		"WorkflowSchedule",
+		"WorkflowApplication",
+	)
+	graph.MustAddE(
+		"application",
+		&sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+		},
+		"WorkflowSecret",
+		"WorkflowApplication",
+	)
 	return graph
 }()
 
@@ -2545,6 +2722,16 @@ func (f *ClientDeviceFilter) WhereAnonymous(p entql.BoolP) {
 	f.Where(p.Field(clientdevice.FieldAnonymous))
 }
 
+// WhereAudience applies the entql string predicate on the audience field.
+func (f *ClientDeviceFilter) WhereAudience(p entql.StringP) {
+	f.Where(p.Field(clientdevice.FieldAudience))
+}
+
+// WhereScopes applies the entql json.RawMessage predicate on the scopes field.
+func (f *ClientDeviceFilter) WhereScopes(p entql.BytesP) {
+	f.Where(p.Field(clientdevice.FieldScopes))
+}
+
 // WhereHasRoles applies a predicate to check if query has an edge roles.
 func (f *ClientDeviceFilter) WhereHasRoles() {
 	f.Where(entql.HasEdge("roles"))
@@ -2708,6 +2895,101 @@ func (f *CredentialFilter) WhereHasUserWith(preds ...predicate.User) {
 	})))
 }
 
+// addPredicate implements the predicateAdder interface.
+func (_q *DatabaseBackupQuery) addPredicate(pred func(s *sql.Selector)) {
+	_q.predicates = append(_q.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the DatabaseBackupQuery builder.
+func (_q *DatabaseBackupQuery) Filter() *DatabaseBackupFilter {
+	return &DatabaseBackupFilter{config: _q.config, predicateAdder: _q}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *DatabaseBackupMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the DatabaseBackupMutation builder.
+func (m *DatabaseBackupMutation) Filter() *DatabaseBackupFilter {
+	return &DatabaseBackupFilter{config: m.config, predicateAdder: m}
+}
+
+// DatabaseBackupFilter provides a generic filtering capability at runtime for DatabaseBackupQuery.
+type DatabaseBackupFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *DatabaseBackupFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[6].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql uint64 predicate on the id field.
+func (f *DatabaseBackupFilter) WhereID(p entql.Uint64P) {
+	f.Where(p.Field(databasebackup.FieldID))
+}
+
+// WhereCreateTime applies the entql time.Time predicate on the create_time field.
+func (f *DatabaseBackupFilter) WhereCreateTime(p entql.TimeP) {
+	f.Where(p.Field(databasebackup.FieldCreateTime))
+}
+
+// WhereCreateBy applies the entql uint64 predicate on the create_by field.
+func (f *DatabaseBackupFilter) WhereCreateBy(p entql.Uint64P) {
+	f.Where(p.Field(databasebackup.FieldCreateBy))
+}
+
+// WhereUpdateTime applies the entql time.Time predicate on the update_time field.
+func (f *DatabaseBackupFilter) WhereUpdateTime(p entql.TimeP) {
+	f.Where(p.Field(databasebackup.FieldUpdateTime))
+}
+
+// WhereUpdateBy applies the entql uint64 predicate on the update_by field.
+func (f *DatabaseBackupFilter) WhereUpdateBy(p entql.Uint64P) {
+	f.Where(p.Field(databasebackup.FieldUpdateBy))
+}
+
+// WhereBucket applies the entql string predicate on the bucket field.
+func (f *DatabaseBackupFilter) WhereBucket(p entql.StringP) {
+	f.Where(p.Field(databasebackup.FieldBucket))
+}
+
+// WhereKey applies the entql string predicate on the key field.
+func (f *DatabaseBackupFilter) WhereKey(p entql.StringP) {
+	f.Where(p.Field(databasebackup.FieldKey))
+}
+
+// WhereSize applies the entql int64 predicate on the size field.
+func (f *DatabaseBackupFilter) WhereSize(p entql.Int64P) {
+	f.Where(p.Field(databasebackup.FieldSize))
+}
+
+// WhereTotalRecords applies the entql int predicate on the total_records field.
+func (f *DatabaseBackupFilter) WhereTotalRecords(p entql.IntP) {
+	f.Where(p.Field(databasebackup.FieldTotalRecords))
+}
+
+// WhereEntityCounts applies the entql json.RawMessage predicate on the entity_counts field.
+func (f *DatabaseBackupFilter) WhereEntityCounts(p entql.BytesP) {
+	f.Where(p.Field(databasebackup.FieldEntityCounts))
+}
+
+// WhereFailedEntities applies the entql json.RawMessage predicate on the failed_entities field.
+func (f *DatabaseBackupFilter) WhereFailedEntities(p entql.BytesP) {
+	f.Where(p.Field(databasebackup.FieldFailedEntities))
+}
+
+// WhereBackupTime applies the entql time.Time predicate on the backup_time field.
+func (f *DatabaseBackupFilter) WhereBackupTime(p entql.TimeP) {
+	f.Where(p.Field(databasebackup.FieldBackupTime))
+}
+
 // addPredicate implements the predicateAdder interface.
 func (_q *LoggingQuery) addPredicate(pred func(s *sql.Selector)) {
 	_q.predicates = append(_q.predicates, pred)
@@ -2737,7 +3019,7 @@ type LoggingFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *LoggingFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[6].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[7].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -2862,7 +3144,7 @@ type LoginRecordFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *LoginRecordFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[7].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[8].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3006,7 +3288,7 @@ type OauthApplicationFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthApplicationFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[8].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[9].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3158,7 +3440,7 @@ type OauthAuthorizationCodeFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthAuthorizationCodeFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[9].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[10].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3329,7 +3611,7 @@ type OauthProviderFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthProviderFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[10].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[11].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3497,7 +3779,7 @@ type OauthStateFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthStateFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[11].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[12].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3639,7 +3921,7 @@ type OauthTokenFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthTokenFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[12].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[13].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3810,7 +4092,7 @@ type OauthUserFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthUserFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[13].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[14].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -3972,7 +4254,7 @@ type OauthUserAuthorizationFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *OauthUserAuthorizationFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[14].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[15].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4123,7 +4405,7 @@ type PermissionFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *PermissionFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[15].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[16].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4255,7 +4537,7 @@ type RoleFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *RoleFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[16].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[17].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4405,7 +4687,7 @@ type RolePermissionFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *RolePermissionFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[17].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[18].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4513,7 +4795,7 @@ type ScanFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *ScanFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[18].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[19].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4612,7 +4894,7 @@ type ScopeFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *ScopeFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[19].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[20].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4784,7 +5066,7 @@ type StationFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *StationFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[20].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[21].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -4892,7 +5174,7 @@ type SubwayFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *SubwayFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[21].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[22].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5005,7 +5287,7 @@ type SubwayStationFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *SubwayStationFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[22].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[23].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5118,7 +5400,7 @@ type SystemMonitorFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *SystemMonitorFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[23].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[24].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5303,7 +5585,7 @@ type UserFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *UserFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[24].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[25].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5454,7 +5736,7 @@ type UserRoleFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *UserRoleFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[25].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[26].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5505,6 +5787,11 @@ func (f *UserRoleFilter) WhereRoleID(p entql.Uint64P) {
 	f.Where(p.Field(userrole.FieldRoleID))
 }
 
+// WhereExpiresAt applies the entql time.Time predicate on the expires_at field.
+func (f *UserRoleFilter) WhereExpiresAt(p entql.TimeP) {
+	f.Where(p.Field(userrole.FieldExpiresAt))
+}
+
 // WhereHasUser applies a predicate to check if query has an edge user.
 func (f *UserRoleFilter) WhereHasUser() {
 	f.Where(entql.HasEdge("user"))
@@ -5562,7 +5849,7 @@ type VerifyCodeFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *VerifyCodeFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[26].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[27].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5677,7 +5964,7 @@ type WorkflowApplicationFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowApplicationFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[27].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[28].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5758,6 +6045,21 @@ func (f *WorkflowApplicationFilter) WhereViewportConfig(p entql.BytesP) {
 	f.Where(p.Field(workflowapplication.FieldViewportConfig))
 }
 
+// WhereInputSchema applies the entql json.RawMessage predicate on the input_schema field.
+func (f *WorkflowApplicationFilter) WhereInputSchema(p entql.BytesP) {
+	f.Where(p.Field(workflowapplication.FieldInputSchema))
+}
+
+// WhereIsTemplate applies the entql bool predicate on the is_template field.
+func (f *WorkflowApplicationFilter) WhereIsTemplate(p entql.BoolP) {
+	f.Where(p.Field(workflowapplication.FieldIsTemplate))
+}
+
+// WhereOwnerID applies the entql uint64 predicate on the owner_id field.
+func (f *WorkflowApplicationFilter) WhereOwnerID(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplication.FieldOwnerID))
+}
+
 // WhereHasNodes applies a predicate to check if query has an edge nodes.
 func (f *WorkflowApplicationFilter) WhereHasNodes() {
 	f.Where(entql.HasEdge("nodes"))
@@ -5800,6 +6102,137 @@ func (f *WorkflowApplicationFilter) WhereHasExecutionsWith(preds ...predicate.Wo
 	})))
 }
 
+// WhereHasSchedules applies a predicate to check if query has an edge schedules.
+func (f *WorkflowApplicationFilter) WhereHasSchedules() {
+	f.Where(entql.HasEdge("schedules"))
+}
+
+// WhereHasSchedulesWith applies a predicate to check if query has an edge schedules with a given conditions (other predicates).
+func (f *WorkflowApplicationFilter) WhereHasSchedulesWith(preds ...predicate.WorkflowSchedule) {
+	f.Where(entql.HasEdgeWith("schedules", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// WhereHasSecrets applies a predicate to check if query has an edge secrets.
+func (f *WorkflowApplicationFilter) WhereHasSecrets() {
+	f.Where(entql.HasEdge("secrets"))
+}
+
+// WhereHasSecretsWith applies a predicate to check if query has an edge secrets with a given conditions (other predicates).
+func (f *WorkflowApplicationFilter) WhereHasSecretsWith(preds ...predicate.WorkflowSecret) {
+	f.Where(entql.HasEdgeWith("secrets", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// WhereHasShares applies a predicate to check if query has an edge shares.
+func (f *WorkflowApplicationFilter) WhereHasShares() {
+	f.Where(entql.HasEdge("shares"))
+}
+
+// WhereHasSharesWith applies a predicate to check if query has an edge shares with a given conditions (other predicates).
+func (f *WorkflowApplicationFilter) WhereHasSharesWith(preds ...predicate.WorkflowApplicationShare) {
+	f.Where(entql.HasEdgeWith("shares", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (_q *WorkflowApplicationShareQuery) addPredicate(pred func(s *sql.Selector)) {
+	_q.predicates = append(_q.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the WorkflowApplicationShareQuery builder.
+func (_q *WorkflowApplicationShareQuery) Filter() *WorkflowApplicationShareFilter {
+	return &WorkflowApplicationShareFilter{config: _q.config, predicateAdder: _q}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *WorkflowApplicationShareMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the WorkflowApplicationShareMutation builder.
+func (m *WorkflowApplicationShareMutation) Filter() *WorkflowApplicationShareFilter {
+	return &WorkflowApplicationShareFilter{config: m.config, predicateAdder: m}
+}
+
+// WorkflowApplicationShareFilter provides a generic filtering capability at runtime for WorkflowApplicationShareQuery.
+type WorkflowApplicationShareFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *WorkflowApplicationShareFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[29].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql uint64 predicate on the id field.
+func (f *WorkflowApplicationShareFilter) WhereID(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplicationshare.FieldID))
+}
+
+// WhereCreateTime applies the entql time.Time predicate on the create_time field.
+func (f *WorkflowApplicationShareFilter) WhereCreateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowapplicationshare.FieldCreateTime))
+}
+
+// WhereCreateBy applies the entql uint64 predicate on the create_by field.
+func (f *WorkflowApplicationShareFilter) WhereCreateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplicationshare.FieldCreateBy))
+}
+
+// WhereUpdateTime applies the entql time.Time predicate on the update_time field.
+func (f *WorkflowApplicationShareFilter) WhereUpdateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowapplicationshare.FieldUpdateTime))
+}
+
+// WhereUpdateBy applies the entql uint64 predicate on the update_by field.
+func (f *WorkflowApplicationShareFilter) WhereUpdateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplicationshare.FieldUpdateBy))
+}
+
+// WhereApplicationID applies the entql uint64 predicate on the application_id field.
+func (f *WorkflowApplicationShareFilter) WhereApplicationID(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplicationshare.FieldApplicationID))
+}
+
+// WhereUserID applies the entql uint64 predicate on the user_id field.
+func (f *WorkflowApplicationShareFilter) WhereUserID(p entql.Uint64P) {
+	f.Where(p.Field(workflowapplicationshare.FieldUserID))
+}
+
+// WhereAccess applies the entql string predicate on the access field.
+func (f *WorkflowApplicationShareFilter) WhereAccess(p entql.StringP) {
+	f.Where(p.Field(workflowapplicationshare.FieldAccess))
+}
+
+// WhereHasApplication applies a predicate to check if query has an edge application.
+func (f *WorkflowApplicationShareFilter) WhereHasApplication() {
+	f.Where(entql.HasEdge("application"))
+}
+
+// WhereHasApplicationWith applies a predicate to check if query has an edge application with a given conditions (other predicates).
+func (f *WorkflowApplicationShareFilter) WhereHasApplicationWith(preds ...predicate.WorkflowApplication) {
+	f.Where(entql.HasEdgeWith("application", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
 // addPredicate implements the predicateAdder interface.
 func (_q *WorkflowEdgeQuery) addPredicate(pred func(s *sql.Selector)) {
 	_q.predicates = append(_q.predicates, pred)
@@ -5829,7 +6262,7 @@ type WorkflowEdgeFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowEdgeFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[28].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[30].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -5996,7 +6429,7 @@ type WorkflowExecutionFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowExecutionFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[29].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[31].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -6057,6 +6490,16 @@ func (f *WorkflowExecutionFilter) WhereContext(p entql.BytesP) {
 	f.Where(p.Field(workflowexecution.FieldContext))
 }
 
+// WhereVariables applies the entql json.RawMessage predicate on the variables field.
+func (f *WorkflowExecutionFilter) WhereVariables(p entql.BytesP) {
+	f.Where(p.Field(workflowexecution.FieldVariables))
+}
+
+// WhereGraphSnapshot applies the entql string predicate on the graph_snapshot field.
+func (f *WorkflowExecutionFilter) WhereGraphSnapshot(p entql.StringP) {
+	f.Where(p.Field(workflowexecution.FieldGraphSnapshot))
+}
+
 // WhereStartedAt applies the entql time.Time predicate on the started_at field.
 func (f *WorkflowExecutionFilter) WhereStartedAt(p entql.TimeP) {
 	f.Where(p.Field(workflowexecution.FieldStartedAt))
@@ -6102,6 +6545,11 @@ func (f *WorkflowExecutionFilter) WhereTriggerSource(p entql.StringP) {
 	f.Where(p.Field(workflowexecution.FieldTriggerSource))
 }
 
+// WhereParentExecutionID applies the entql uint64 predicate on the parent_execution_id field.
+func (f *WorkflowExecutionFilter) WhereParentExecutionID(p entql.Uint64P) {
+	f.Where(p.Field(workflowexecution.FieldParentExecutionID))
+}
+
 // WhereHasApplication applies a predicate to check if query has an edge application.
 func (f *WorkflowExecutionFilter) WhereHasApplication() {
 	f.Where(entql.HasEdge("application"))
@@ -6159,7 +6607,7 @@ type WorkflowExecutionLogFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowExecutionLogFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[30].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[32].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -6249,7 +6697,7 @@ type WorkflowNodeFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowNodeFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[31].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[33].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -6380,6 +6828,11 @@ func (f *WorkflowNodeFilter) WhereColor(p entql.StringP) {
 	f.Where(p.Field(workflownode.FieldColor))
 }
 
+// WhereEnabled applies the entql bool predicate on the enabled field.
+func (f *WorkflowNodeFilter) WhereEnabled(p entql.BoolP) {
+	f.Where(p.Field(workflownode.FieldEnabled))
+}
+
 // WhereHasApplication applies a predicate to check if query has an edge application.
 func (f *WorkflowNodeFilter) WhereHasApplication() {
 	f.Where(entql.HasEdge("application"))
@@ -6465,7 +6918,7 @@ type WorkflowNodeExecutionFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowNodeExecutionFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[32].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[34].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -6629,6 +7082,214 @@ func (f *WorkflowNodeExecutionFilter) WhereHasNodeWith(preds ...predicate.Workfl
 	})))
 }
 
+// addPredicate implements the predicateAdder interface.
+func (_q *WorkflowScheduleQuery) addPredicate(pred func(s *sql.Selector)) {
+	_q.predicates = append(_q.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the WorkflowScheduleQuery builder.
+func (_q *WorkflowScheduleQuery) Filter() *WorkflowScheduleFilter {
+	return &WorkflowScheduleFilter{config: _q.config, predicateAdder: _q}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *WorkflowScheduleMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the WorkflowScheduleMutation builder.
+func (m *WorkflowScheduleMutation) Filter() *WorkflowScheduleFilter {
+	return &WorkflowScheduleFilter{config: m.config, predicateAdder: m}
+}
+
+// WorkflowScheduleFilter provides a generic filtering capability at runtime for WorkflowScheduleQuery.
+type WorkflowScheduleFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *WorkflowScheduleFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[35].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql uint64 predicate on the id field.
+func (f *WorkflowScheduleFilter) WhereID(p entql.Uint64P) {
+	f.Where(p.Field(workflowschedule.FieldID))
+}
+
+// WhereCreateTime applies the entql time.Time predicate on the create_time field.
+func (f *WorkflowScheduleFilter) WhereCreateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowschedule.FieldCreateTime))
+}
+
+// WhereCreateBy applies the entql uint64 predicate on the create_by field.
+func (f *WorkflowScheduleFilter) WhereCreateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowschedule.FieldCreateBy))
+}
+
+// WhereUpdateTime applies the entql time.Time predicate on the update_time field.
+func (f *WorkflowScheduleFilter) WhereUpdateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowschedule.FieldUpdateTime))
+}
+
+// WhereUpdateBy applies the entql uint64 predicate on the update_by field.
+func (f *WorkflowScheduleFilter) WhereUpdateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowschedule.FieldUpdateBy))
+}
+
+// WhereDeleteTime applies the entql time.Time predicate on the delete_time field.
+func (f *WorkflowScheduleFilter) WhereDeleteTime(p entql.TimeP) {
+	f.Where(p.Field(workflowschedule.FieldDeleteTime))
+}
+
+// WhereDeleteBy applies the entql uint64 predicate on the delete_by field.
+func (f *WorkflowScheduleFilter) WhereDeleteBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowschedule.FieldDeleteBy))
+}
+
+// WhereApplicationID applies the entql uint64 predicate on the application_id field.
+func (f *WorkflowScheduleFilter) WhereApplicationID(p entql.Uint64P) {
+	f.Where(p.Field(workflowschedule.FieldApplicationID))
+}
+
+// WhereName applies the entql string predicate on the name field.
+func (f *WorkflowScheduleFilter) WhereName(p entql.StringP) {
+	f.Where(p.Field(workflowschedule.FieldName))
+}
+
+// WhereCronExpression applies the entql string predicate on the cron_expression field.
+func (f *WorkflowScheduleFilter) WhereCronExpression(p entql.StringP) {
+	f.Where(p.Field(workflowschedule.FieldCronExpression))
+}
+
+// WhereInput applies the entql json.RawMessage predicate on the input field.
+func (f *WorkflowScheduleFilter) WhereInput(p entql.BytesP) {
+	f.Where(p.Field(workflowschedule.FieldInput))
+}
+
+// WhereEnabled applies the entql bool predicate on the enabled field.
+func (f *WorkflowScheduleFilter) WhereEnabled(p entql.BoolP) {
+	f.Where(p.Field(workflowschedule.FieldEnabled))
+}
+
+// WhereLastRunAt applies the entql time.Time predicate on the last_run_at field.
+func (f *WorkflowScheduleFilter) WhereLastRunAt(p entql.TimeP) {
+	f.Where(p.Field(workflowschedule.FieldLastRunAt))
+}
+
+// WhereNextRunAt applies the entql time.Time predicate on the next_run_at field.
+func (f *WorkflowScheduleFilter) WhereNextRunAt(p entql.TimeP) {
+	f.Where(p.Field(workflowschedule.FieldNextRunAt))
+}
+
+// WhereHasApplication applies a predicate to check if query has an edge application.
+func (f *WorkflowScheduleFilter) WhereHasApplication() {
+	f.Where(entql.HasEdge("application"))
+}
+
+// WhereHasApplicationWith applies a predicate to check if query has an edge application with a given conditions (other predicates).
+func (f *WorkflowScheduleFilter) WhereHasApplicationWith(preds ...predicate.WorkflowApplication) {
+	f.Where(entql.HasEdgeWith("application", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
+// addPredicate implements the predicateAdder interface.
+func (_q *WorkflowSecretQuery) addPredicate(pred func(s *sql.Selector)) {
+	_q.predicates = append(_q.predicates, pred)
+}
+
+// Filter returns a Filter implementation to apply filters on the WorkflowSecretQuery builder.
+func (_q *WorkflowSecretQuery) Filter() *WorkflowSecretFilter {
+	return &WorkflowSecretFilter{config: _q.config, predicateAdder: _q}
+}
+
+// addPredicate implements the predicateAdder interface.
+func (m *WorkflowSecretMutation) addPredicate(pred func(s *sql.Selector)) {
+	m.predicates = append(m.predicates, pred)
+}
+
+// Filter returns an entql.Where implementation to apply filters on the WorkflowSecretMutation builder.
+func (m *WorkflowSecretMutation) Filter() *WorkflowSecretFilter {
+	return &WorkflowSecretFilter{config: m.config, predicateAdder: m}
+}
+
+// WorkflowSecretFilter provides a generic filtering capability at runtime for WorkflowSecretQuery.
+type WorkflowSecretFilter struct {
+	predicateAdder
+	config
+}
+
+// Where applies the entql predicate on the query filter.
+func (f *WorkflowSecretFilter) Where(p entql.P) {
+	f.addPredicate(func(s *sql.Selector) {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[36].Type, p, s); err != nil {
+			s.AddError(err)
+		}
+	})
+}
+
+// WhereID applies the entql uint64 predicate on the id field.
+func (f *WorkflowSecretFilter) WhereID(p entql.Uint64P) {
+	f.Where(p.Field(workflowsecret.FieldID))
+}
+
+// WhereCreateTime applies the entql time.Time predicate on the create_time field.
+func (f *WorkflowSecretFilter) WhereCreateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowsecret.FieldCreateTime))
+}
+
+// WhereCreateBy applies the entql uint64 predicate on the create_by field.
+func (f *WorkflowSecretFilter) WhereCreateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowsecret.FieldCreateBy))
+}
+
+// WhereUpdateTime applies the entql time.Time predicate on the update_time field.
+func (f *WorkflowSecretFilter) WhereUpdateTime(p entql.TimeP) {
+	f.Where(p.Field(workflowsecret.FieldUpdateTime))
+}
+
+// WhereUpdateBy applies the entql uint64 predicate on the update_by field.
+func (f *WorkflowSecretFilter) WhereUpdateBy(p entql.Uint64P) {
+	f.Where(p.Field(workflowsecret.FieldUpdateBy))
+}
+
+// WhereApplicationID applies the entql uint64 predicate on the application_id field.
+func (f *WorkflowSecretFilter) WhereApplicationID(p entql.Uint64P) {
+	f.Where(p.Field(workflowsecret.FieldApplicationID))
+}
+
+// WhereKey applies the entql string predicate on the key field.
+func (f *WorkflowSecretFilter) WhereKey(p entql.StringP) {
+	f.Where(p.Field(workflowsecret.FieldKey))
+}
+
+// WhereValue applies the entql string predicate on the value field.
+func (f *WorkflowSecretFilter) WhereValue(p entql.StringP) {
+	f.Where(p.Field(workflowsecret.FieldValue))
+}
+
+// WhereHasApplication applies a predicate to check if query has an edge application.
+func (f *WorkflowSecretFilter) WhereHasApplication() {
+	f.Where(entql.HasEdge("application"))
+}
+
+// WhereHasApplicationWith applies a predicate to check if query has an edge application with a given conditions (other predicates).
+func (f *WorkflowSecretFilter) WhereHasApplicationWith(preds ...predicate.WorkflowApplication) {
+	f.Where(entql.HasEdgeWith("application", sqlgraph.WrapFunc(func(s *sql.Selector) {
+		for _, p := range preds {
+			p(s)
+		}
+	})))
+}
+
 // addPredicate implements the predicateAdder interface.
 func (_q *WorkflowVersionQuery) addPredicate(pred func(s *sql.Selector)) {
 	_q.predicates = append(_q.predicates, pred)
@@ -6658,7 +7319,7 @@ type WorkflowVersionFilter struct {
 // Where applies the entql predicate on the query filter.
 func (f *WorkflowVersionFilter) Where(p entql.P) {
 	f.addPredicate(func(s *sql.Selector) {
-		if err := schemaGraph.EvalP(schemaGraph.Nodes[33].Type, p, s); err != nil {
+		if err := schemaGraph.EvalP(schemaGraph.Nodes[37].Type, p, s); err != nil {
 			s.AddError(err)
 		}
 	})
@@ -6708,3 +7369,8 @@ func (f *WorkflowVersionFilter) WhereSnapshot(p entql.BytesP) {
 func (f *WorkflowVersionFilter) WhereChangeLog(p entql.StringP) {
 	f.Where(p.Field(workflowversion.FieldChangeLog))
 }
+
+// WherePinned applies the entql bool predicate on the pinned field.
+func (f *WorkflowVersionFilter) WherePinned(p entql.BoolP) {
+	f.Where(p.Field(workflowversion.FieldPinned))
+}
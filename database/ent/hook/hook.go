@@ -80,6 +80,18 @@ func (f CredentialFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value,
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.CredentialMutation", m)
 }
 
+// The DatabaseBackupFunc type is an adapter to allow the use of ordinary
+// function as DatabaseBackup mutator.
+type DatabaseBackupFunc func(context.Context, *ent.DatabaseBackupMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f DatabaseBackupFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.DatabaseBackupMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.DatabaseBackupMutation", m)
+}
+
 // The LoggingFunc type is an adapter to allow the use of ordinary
 // function as Logging mutator.
 type LoggingFunc func(context.Context, *ent.LoggingMutation) (ent.Value, error)
@@ -344,6 +356,18 @@ func (f WorkflowApplicationFunc) Mutate(ctx context.Context, m ent.Mutation) (en
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.WorkflowApplicationMutation", m)
 }
 
+// The WorkflowApplicationShareFunc type is an adapter to allow the use of ordinary
+// function as WorkflowApplicationShare mutator.
+type WorkflowApplicationShareFunc func(context.Context, *ent.WorkflowApplicationShareMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f WorkflowApplicationShareFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.WorkflowApplicationShareMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.WorkflowApplicationShareMutation", m)
+}
+
 // The WorkflowEdgeFunc type is an adapter to allow the use of ordinary
 // function as WorkflowEdge mutator.
 type WorkflowEdgeFunc func(context.Context, *ent.WorkflowEdgeMutation) (ent.Value, error)
@@ -404,6 +428,30 @@ func (f WorkflowNodeExecutionFunc) Mutate(ctx context.Context, m ent.Mutation) (
 	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.WorkflowNodeExecutionMutation", m)
 }
 
+// The WorkflowScheduleFunc type is an adapter to allow the use of ordinary
+// function as WorkflowSchedule mutator.
+type WorkflowScheduleFunc func(context.Context, *ent.WorkflowScheduleMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f WorkflowScheduleFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.WorkflowScheduleMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.WorkflowScheduleMutation", m)
+}
+
+// The WorkflowSecretFunc type is an adapter to allow the use of ordinary
+// function as WorkflowSecret mutator.
+type WorkflowSecretFunc func(context.Context, *ent.WorkflowSecretMutation) (ent.Value, error)
+
+// Mutate calls f(ctx, m).
+func (f WorkflowSecretFunc) Mutate(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+	if mv, ok := m.(*ent.WorkflowSecretMutation); ok {
+		return f(ctx, mv)
+	}
+	return nil, fmt.Errorf("unexpected mutation type %T. expect *ent.WorkflowSecretMutation", m)
+}
+
 // The WorkflowVersionFunc type is an adapter to allow the use of ordinary
 // function as WorkflowVersion mutator.
 type WorkflowVersionFunc func(context.Context, *ent.WorkflowVersionMutation) (ent.Value, error)
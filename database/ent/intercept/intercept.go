@@ -13,6 +13,7 @@ import (
 	"go-backend/database/ent/attachment"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
+	"go-backend/database/ent/databasebackup"
 	"go-backend/database/ent/logging"
 	"go-backend/database/ent/loginrecord"
 	"go-backend/database/ent/oauthapplication"
@@ -36,11 +37,14 @@ import (
 	"go-backend/database/ent/userrole"
 	"go-backend/database/ent/verifycode"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflowexecutionlog"
 	"go-backend/database/ent/workflownode"
 	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
 
 	"entgo.io/ent/dialect/sql"
@@ -264,6 +268,33 @@ func (f TraverseCredential) Traverse(ctx context.Context, q ent.Query) error {
 	return fmt.Errorf("unexpected query type %T. expect *ent.CredentialQuery", q)
 }
 
+// The DatabaseBackupFunc type is an adapter to allow the use of ordinary function as a Querier.
+type DatabaseBackupFunc func(context.Context, *ent.DatabaseBackupQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f DatabaseBackupFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.DatabaseBackupQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.DatabaseBackupQuery", q)
+}
+
+// The TraverseDatabaseBackup type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseDatabaseBackup func(context.Context, *ent.DatabaseBackupQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseDatabaseBackup) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseDatabaseBackup) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.DatabaseBackupQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.DatabaseBackupQuery", q)
+}
+
 // The LoggingFunc type is an adapter to allow the use of ordinary function as a Querier.
 type LoggingFunc func(context.Context, *ent.LoggingQuery) (ent.Value, error)
 
@@ -858,6 +889,33 @@ func (f TraverseWorkflowApplication) Traverse(ctx context.Context, q ent.Query)
 	return fmt.Errorf("unexpected query type %T. expect *ent.WorkflowApplicationQuery", q)
 }
 
+// The WorkflowApplicationShareFunc type is an adapter to allow the use of ordinary function as a Querier.
+type WorkflowApplicationShareFunc func(context.Context, *ent.WorkflowApplicationShareQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f WorkflowApplicationShareFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.WorkflowApplicationShareQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.WorkflowApplicationShareQuery", q)
+}
+
+// The TraverseWorkflowApplicationShare type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseWorkflowApplicationShare func(context.Context, *ent.WorkflowApplicationShareQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseWorkflowApplicationShare) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseWorkflowApplicationShare) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowApplicationShareQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.WorkflowApplicationShareQuery", q)
+}
+
 // The WorkflowEdgeFunc type is an adapter to allow the use of ordinary function as a Querier.
 type WorkflowEdgeFunc func(context.Context, *ent.WorkflowEdgeQuery) (ent.Value, error)
 
@@ -993,6 +1051,60 @@ func (f TraverseWorkflowNodeExecution) Traverse(ctx context.Context, q ent.Query
 	return fmt.Errorf("unexpected query type %T. expect *ent.WorkflowNodeExecutionQuery", q)
 }
 
+// The WorkflowScheduleFunc type is an adapter to allow the use of ordinary function as a Querier.
+type WorkflowScheduleFunc func(context.Context, *ent.WorkflowScheduleQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f WorkflowScheduleFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.WorkflowScheduleQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.WorkflowScheduleQuery", q)
+}
+
+// The TraverseWorkflowSchedule type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseWorkflowSchedule func(context.Context, *ent.WorkflowScheduleQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseWorkflowSchedule) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseWorkflowSchedule) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowScheduleQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.WorkflowScheduleQuery", q)
+}
+
+// The WorkflowSecretFunc type is an adapter to allow the use of ordinary function as a Querier.
+type WorkflowSecretFunc func(context.Context, *ent.WorkflowSecretQuery) (ent.Value, error)
+
+// Query calls f(ctx, q).
+func (f WorkflowSecretFunc) Query(ctx context.Context, q ent.Query) (ent.Value, error) {
+	if q, ok := q.(*ent.WorkflowSecretQuery); ok {
+		return f(ctx, q)
+	}
+	return nil, fmt.Errorf("unexpected query type %T. expect *ent.WorkflowSecretQuery", q)
+}
+
+// The TraverseWorkflowSecret type is an adapter to allow the use of ordinary function as Traverser.
+type TraverseWorkflowSecret func(context.Context, *ent.WorkflowSecretQuery) error
+
+// Intercept is a dummy implementation of Intercept that returns the next Querier in the pipeline.
+func (f TraverseWorkflowSecret) Intercept(next ent.Querier) ent.Querier {
+	return next
+}
+
+// Traverse calls f(ctx, q).
+func (f TraverseWorkflowSecret) Traverse(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowSecretQuery); ok {
+		return f(ctx, q)
+	}
+	return fmt.Errorf("unexpected query type %T. expect *ent.WorkflowSecretQuery", q)
+}
+
 // The WorkflowVersionFunc type is an adapter to allow the use of ordinary function as a Querier.
 type WorkflowVersionFunc func(context.Context, *ent.WorkflowVersionQuery) (ent.Value, error)
 
@@ -1035,6 +1147,8 @@ func NewQuery(q ent.Query) (Query, error) {
 		return &query[*ent.ClientDeviceQuery, predicate.ClientDevice, clientdevice.OrderOption]{typ: ent.TypeClientDevice, tq: q}, nil
 	case *ent.CredentialQuery:
 		return &query[*ent.CredentialQuery, predicate.Credential, credential.OrderOption]{typ: ent.TypeCredential, tq: q}, nil
+	case *ent.DatabaseBackupQuery:
+		return &query[*ent.DatabaseBackupQuery, predicate.DatabaseBackup, databasebackup.OrderOption]{typ: ent.TypeDatabaseBackup, tq: q}, nil
 	case *ent.LoggingQuery:
 		return &query[*ent.LoggingQuery, predicate.Logging, logging.OrderOption]{typ: ent.TypeLogging, tq: q}, nil
 	case *ent.LoginRecordQuery:
@@ -1079,6 +1193,8 @@ func NewQuery(q ent.Query) (Query, error) {
 		return &query[*ent.VerifyCodeQuery, predicate.VerifyCode, verifycode.OrderOption]{typ: ent.TypeVerifyCode, tq: q}, nil
 	case *ent.WorkflowApplicationQuery:
 		return &query[*ent.WorkflowApplicationQuery, predicate.WorkflowApplication, workflowapplication.OrderOption]{typ: ent.TypeWorkflowApplication, tq: q}, nil
+	case *ent.WorkflowApplicationShareQuery:
+		return &query[*ent.WorkflowApplicationShareQuery, predicate.WorkflowApplicationShare, workflowapplicationshare.OrderOption]{typ: ent.TypeWorkflowApplicationShare, tq: q}, nil
 	case *ent.WorkflowEdgeQuery:
 		return &query[*ent.WorkflowEdgeQuery, predicate.WorkflowEdge, workflowedge.OrderOption]{typ: ent.TypeWorkflowEdge, tq: q}, nil
 	case *ent.WorkflowExecutionQuery:
@@ -1089,6 +1205,10 @@ func NewQuery(q ent.Query) (Query, error) {
 		return &query[*ent.WorkflowNodeQuery, predicate.WorkflowNode, workflownode.OrderOption]{typ: ent.TypeWorkflowNode, tq: q}, nil
 	case *ent.WorkflowNodeExecutionQuery:
 		return &query[*ent.WorkflowNodeExecutionQuery, predicate.WorkflowNodeExecution, workflownodeexecution.OrderOption]{typ: ent.TypeWorkflowNodeExecution, tq: q}, nil
+	case *ent.WorkflowScheduleQuery:
+		return &query[*ent.WorkflowScheduleQuery, predicate.WorkflowSchedule, workflowschedule.OrderOption]{typ: ent.TypeWorkflowSchedule, tq: q}, nil
+	case *ent.WorkflowSecretQuery:
+		return &query[*ent.WorkflowSecretQuery, predicate.WorkflowSecret, workflowsecret.OrderOption]{typ: ent.TypeWorkflowSecret, tq: q}, nil
 	case *ent.WorkflowVersionQuery:
 		return &query[*ent.WorkflowVersionQuery, predicate.WorkflowVersion, workflowversion.OrderOption]{typ: ent.TypeWorkflowVersion, tq: q}, nil
 	default:
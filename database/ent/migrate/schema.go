@@ -312,6 +312,8 @@ var (
 		{Name: "access_token_expiry", Type: field.TypeUint64},
 		{Name: "refresh_token_expiry", Type: field.TypeUint64},
 		{Name: "anonymous", Type: field.TypeBool, Default: true},
+		{Name: "audience", Type: field.TypeString, Nullable: true, Size: 255},
+		{Name: "scopes", Type: field.TypeJSON, Nullable: true},
 	}
 	// SysClientsTable holds the schema information for the "sys_clients" table.
 	SysClientsTable = &schema.Table{
@@ -345,7 +347,7 @@ var (
 		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
 		{Name: "delete_time", Type: field.TypeTime, Nullable: true},
 		{Name: "delete_by", Type: field.TypeUint64, Nullable: true},
-		{Name: "credential_type", Type: field.TypeEnum, Enums: []string{"password", "email", "oauth", "phone", "totp"}},
+		{Name: "credential_type", Type: field.TypeEnum, Enums: []string{"password", "email", "oauth", "phone", "totp", "webauthn"}},
 		{Name: "identifier", Type: field.TypeString, Size: 255},
 		{Name: "secret", Type: field.TypeString, Nullable: true, Size: 500},
 		{Name: "salt", Type: field.TypeString, Nullable: true, Size: 100},
@@ -410,6 +412,34 @@ var (
 			},
 		},
 	}
+	// SysDatabaseBackupsColumns holds the columns for the "sys_database_backups" table.
+	SysDatabaseBackupsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "create_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "update_time", Type: field.TypeTime},
+		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "bucket", Type: field.TypeString, Size: 255},
+		{Name: "key", Type: field.TypeString, Unique: true, Size: 512},
+		{Name: "size", Type: field.TypeInt64},
+		{Name: "total_records", Type: field.TypeInt, Default: 0},
+		{Name: "entity_counts", Type: field.TypeJSON, Nullable: true},
+		{Name: "failed_entities", Type: field.TypeJSON, Nullable: true},
+		{Name: "backup_time", Type: field.TypeTime},
+	}
+	// SysDatabaseBackupsTable holds the schema information for the "sys_database_backups" table.
+	SysDatabaseBackupsTable = &schema.Table{
+		Name:       "sys_database_backups",
+		Columns:    SysDatabaseBackupsColumns,
+		PrimaryKey: []*schema.Column{SysDatabaseBackupsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "databasebackup_backup_time",
+				Unique:  false,
+				Columns: []*schema.Column{SysDatabaseBackupsColumns[11]},
+			},
+		},
+	}
 	// LoggingsColumns holds the columns for the "loggings" table.
 	LoggingsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUint64, Increment: true},
@@ -457,7 +487,7 @@ var (
 		{Name: "update_time", Type: field.TypeTime},
 		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
 		{Name: "identifier", Type: field.TypeString, Size: 255},
-		{Name: "credential_type", Type: field.TypeEnum, Enums: []string{"password", "email", "oauth", "phone", "totp"}},
+		{Name: "credential_type", Type: field.TypeEnum, Enums: []string{"password", "email", "oauth", "phone", "totp", "webauthn"}},
 		{Name: "ip_address", Type: field.TypeString, Size: 45},
 		{Name: "user_agent", Type: field.TypeString, Nullable: true, Size: 512},
 		{Name: "device_info", Type: field.TypeString, Nullable: true, Size: 255},
@@ -1492,6 +1522,7 @@ var (
 		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
 		{Name: "delete_time", Type: field.TypeTime, Nullable: true},
 		{Name: "delete_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "expires_at", Type: field.TypeTime, Nullable: true},
 		{Name: "role_id", Type: field.TypeUint64},
 		{Name: "user_id", Type: field.TypeUint64},
 	}
@@ -1503,13 +1534,13 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "sys_user_role_sys_roles_user_roles",
-				Columns:    []*schema.Column{SysUserRoleColumns[7]},
+				Columns:    []*schema.Column{SysUserRoleColumns[8]},
 				RefColumns: []*schema.Column{SysRolesColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
 			{
 				Symbol:     "sys_user_role_sys_users_user_roles",
-				Columns:    []*schema.Column{SysUserRoleColumns[8]},
+				Columns:    []*schema.Column{SysUserRoleColumns[9]},
 				RefColumns: []*schema.Column{SysUsersColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -1528,16 +1559,21 @@ var (
 			{
 				Name:    "userrole_user_id_role_id_delete_time",
 				Unique:  true,
-				Columns: []*schema.Column{SysUserRoleColumns[8], SysUserRoleColumns[7], SysUserRoleColumns[5]},
+				Columns: []*schema.Column{SysUserRoleColumns[9], SysUserRoleColumns[8], SysUserRoleColumns[5]},
 			},
 			{
 				Name:    "userrole_user_id",
 				Unique:  false,
-				Columns: []*schema.Column{SysUserRoleColumns[8]},
+				Columns: []*schema.Column{SysUserRoleColumns[9]},
 			},
 			{
 				Name:    "userrole_role_id",
 				Unique:  false,
+				Columns: []*schema.Column{SysUserRoleColumns[8]},
+			},
+			{
+				Name:    "userrole_expires_at",
+				Unique:  false,
 				Columns: []*schema.Column{SysUserRoleColumns[7]},
 			},
 		},
@@ -1601,6 +1637,9 @@ var (
 		{Name: "version", Type: field.TypeUint, Default: 1},
 		{Name: "status", Type: field.TypeEnum, Enums: []string{"draft", "published", "archived"}, Default: "draft"},
 		{Name: "viewport_config", Type: field.TypeJSON, Nullable: true},
+		{Name: "input_schema", Type: field.TypeJSON, Nullable: true},
+		{Name: "is_template", Type: field.TypeBool, Default: false},
+		{Name: "owner_id", Type: field.TypeUint64, Nullable: true},
 	}
 	// WorkflowApplicationsTable holds the schema information for the "workflow_applications" table.
 	WorkflowApplicationsTable = &schema.Table{
@@ -1628,6 +1667,48 @@ var (
 				Unique:  false,
 				Columns: []*schema.Column{WorkflowApplicationsColumns[13]},
 			},
+			{
+				Name:    "workflowapplication_owner_id",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowApplicationsColumns[17]},
+			},
+		},
+	}
+	// WorkflowApplicationSharesColumns holds the columns for the "workflow_application_shares" table.
+	WorkflowApplicationSharesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "create_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "update_time", Type: field.TypeTime},
+		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "user_id", Type: field.TypeUint64},
+		{Name: "access", Type: field.TypeEnum, Enums: []string{"read", "edit"}, Default: "read"},
+		{Name: "application_id", Type: field.TypeUint64},
+	}
+	// WorkflowApplicationSharesTable holds the schema information for the "workflow_application_shares" table.
+	WorkflowApplicationSharesTable = &schema.Table{
+		Name:       "workflow_application_shares",
+		Columns:    WorkflowApplicationSharesColumns,
+		PrimaryKey: []*schema.Column{WorkflowApplicationSharesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "workflow_application_shares_workflow_applications_shares",
+				Columns:    []*schema.Column{WorkflowApplicationSharesColumns[7]},
+				RefColumns: []*schema.Column{WorkflowApplicationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "workflowapplicationshare_application_id_user_id",
+				Unique:  true,
+				Columns: []*schema.Column{WorkflowApplicationSharesColumns[7], WorkflowApplicationSharesColumns[5]},
+			},
+			{
+				Name:    "workflowapplicationshare_user_id",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowApplicationSharesColumns[5]},
+			},
 		},
 	}
 	// WorkflowEdgesColumns holds the columns for the "workflow_edges" table.
@@ -1641,7 +1722,7 @@ var (
 		{Name: "delete_by", Type: field.TypeUint64, Nullable: true},
 		{Name: "source_handle", Type: field.TypeString, Nullable: true},
 		{Name: "target_handle", Type: field.TypeString, Nullable: true},
-		{Name: "type", Type: field.TypeEnum, Enums: []string{"default", "branch", "parallel"}, Default: "default"},
+		{Name: "type", Type: field.TypeEnum, Enums: []string{"default", "branch", "parallel", "conditional"}, Default: "default"},
 		{Name: "label", Type: field.TypeString, Nullable: true},
 		{Name: "branch_name", Type: field.TypeString, Nullable: true},
 		{Name: "animated", Type: field.TypeBool, Default: false},
@@ -1721,6 +1802,8 @@ var (
 		{Name: "input", Type: field.TypeJSON, Nullable: true},
 		{Name: "output", Type: field.TypeJSON, Nullable: true},
 		{Name: "context", Type: field.TypeJSON, Nullable: true},
+		{Name: "variables", Type: field.TypeJSON, Nullable: true},
+		{Name: "graph_snapshot", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "started_at", Type: field.TypeTime, Nullable: true},
 		{Name: "finished_at", Type: field.TypeTime, Nullable: true},
 		{Name: "duration_ms", Type: field.TypeInt, Default: 0},
@@ -1730,6 +1813,7 @@ var (
 		{Name: "error_stack", Type: field.TypeString, Nullable: true, Size: 2147483647},
 		{Name: "triggered_by", Type: field.TypeString, Nullable: true},
 		{Name: "trigger_source", Type: field.TypeString, Nullable: true},
+		{Name: "parent_execution_id", Type: field.TypeUint64, Nullable: true},
 		{Name: "application_id", Type: field.TypeUint64},
 	}
 	// WorkflowExecutionsTable holds the schema information for the "workflow_executions" table.
@@ -1740,7 +1824,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "workflow_executions_workflow_applications_executions",
-				Columns:    []*schema.Column{WorkflowExecutionsColumns[19]},
+				Columns:    []*schema.Column{WorkflowExecutionsColumns[22]},
 				RefColumns: []*schema.Column{WorkflowApplicationsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -1754,17 +1838,22 @@ var (
 			{
 				Name:    "workflowexecution_application_id_status",
 				Unique:  false,
-				Columns: []*schema.Column{WorkflowExecutionsColumns[19], WorkflowExecutionsColumns[6]},
+				Columns: []*schema.Column{WorkflowExecutionsColumns[22], WorkflowExecutionsColumns[6]},
 			},
 			{
 				Name:    "workflowexecution_started_at",
 				Unique:  false,
-				Columns: []*schema.Column{WorkflowExecutionsColumns[10]},
+				Columns: []*schema.Column{WorkflowExecutionsColumns[12]},
 			},
 			{
 				Name:    "workflowexecution_triggered_by",
 				Unique:  false,
-				Columns: []*schema.Column{WorkflowExecutionsColumns[17]},
+				Columns: []*schema.Column{WorkflowExecutionsColumns[19]},
+			},
+			{
+				Name:    "workflowexecution_parent_execution_id",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowExecutionsColumns[21]},
 			},
 		},
 	}
@@ -1831,6 +1920,7 @@ var (
 		{Name: "position_x", Type: field.TypeFloat64, Default: 0},
 		{Name: "position_y", Type: field.TypeFloat64, Default: 0},
 		{Name: "color", Type: field.TypeString, Nullable: true},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
 		{Name: "application_id", Type: field.TypeUint64},
 	}
 	// WorkflowNodesTable holds the schema information for the "workflow_nodes" table.
@@ -1841,7 +1931,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "workflow_nodes_workflow_applications_nodes",
-				Columns:    []*schema.Column{WorkflowNodesColumns[24]},
+				Columns:    []*schema.Column{WorkflowNodesColumns[25]},
 				RefColumns: []*schema.Column{WorkflowApplicationsColumns[0]},
 				OnDelete:   schema.NoAction,
 			},
@@ -1860,7 +1950,7 @@ var (
 			{
 				Name:    "workflownode_application_id",
 				Unique:  false,
-				Columns: []*schema.Column{WorkflowNodesColumns[24]},
+				Columns: []*schema.Column{WorkflowNodesColumns[25]},
 			},
 			{
 				Name:    "workflownode_type",
@@ -1940,6 +2030,91 @@ var (
 			},
 		},
 	}
+	// WorkflowSchedulesColumns holds the columns for the "workflow_schedules" table.
+	WorkflowSchedulesColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "create_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "update_time", Type: field.TypeTime},
+		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "delete_time", Type: field.TypeTime, Nullable: true},
+		{Name: "delete_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "name", Type: field.TypeString, Nullable: true},
+		{Name: "cron_expression", Type: field.TypeString},
+		{Name: "input", Type: field.TypeJSON, Nullable: true},
+		{Name: "enabled", Type: field.TypeBool, Default: true},
+		{Name: "last_run_at", Type: field.TypeTime, Nullable: true},
+		{Name: "next_run_at", Type: field.TypeTime, Nullable: true},
+		{Name: "application_id", Type: field.TypeUint64},
+	}
+	// WorkflowSchedulesTable holds the schema information for the "workflow_schedules" table.
+	WorkflowSchedulesTable = &schema.Table{
+		Name:       "workflow_schedules",
+		Columns:    WorkflowSchedulesColumns,
+		PrimaryKey: []*schema.Column{WorkflowSchedulesColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "workflow_schedules_workflow_applications_schedules",
+				Columns:    []*schema.Column{WorkflowSchedulesColumns[13]},
+				RefColumns: []*schema.Column{WorkflowApplicationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "workflowschedule_delete_time",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowSchedulesColumns[5]},
+			},
+			{
+				Name:    "workflowschedule_delete_by",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowSchedulesColumns[6]},
+			},
+			{
+				Name:    "workflowschedule_application_id",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowSchedulesColumns[13]},
+			},
+			{
+				Name:    "workflowschedule_enabled",
+				Unique:  false,
+				Columns: []*schema.Column{WorkflowSchedulesColumns[10]},
+			},
+		},
+	}
+	// WorkflowSecretsColumns holds the columns for the "workflow_secrets" table.
+	WorkflowSecretsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeUint64, Increment: true},
+		{Name: "create_time", Type: field.TypeTime},
+		{Name: "create_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "update_time", Type: field.TypeTime},
+		{Name: "update_by", Type: field.TypeUint64, Nullable: true},
+		{Name: "key", Type: field.TypeString},
+		{Name: "value", Type: field.TypeString, Size: 2147483647},
+		{Name: "application_id", Type: field.TypeUint64},
+	}
+	// WorkflowSecretsTable holds the schema information for the "workflow_secrets" table.
+	WorkflowSecretsTable = &schema.Table{
+		Name:       "workflow_secrets",
+		Columns:    WorkflowSecretsColumns,
+		PrimaryKey: []*schema.Column{WorkflowSecretsColumns[0]},
+		ForeignKeys: []*schema.ForeignKey{
+			{
+				Symbol:     "workflow_secrets_workflow_applications_secrets",
+				Columns:    []*schema.Column{WorkflowSecretsColumns[7]},
+				RefColumns: []*schema.Column{WorkflowApplicationsColumns[0]},
+				OnDelete:   schema.NoAction,
+			},
+		},
+		Indexes: []*schema.Index{
+			{
+				Name:    "workflowsecret_application_id_key",
+				Unique:  true,
+				Columns: []*schema.Column{WorkflowSecretsColumns[7], WorkflowSecretsColumns[5]},
+			},
+		},
+	}
 	// WorkflowVersionsColumns holds the columns for the "workflow_versions" table.
 	WorkflowVersionsColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeUint64, Increment: true},
@@ -1951,6 +2126,7 @@ var (
 		{Name: "version", Type: field.TypeUint},
 		{Name: "snapshot", Type: field.TypeJSON},
 		{Name: "change_log", Type: field.TypeString, Nullable: true},
+		{Name: "pinned", Type: field.TypeBool, Default: false},
 	}
 	// WorkflowVersionsTable holds the schema information for the "workflow_versions" table.
 	WorkflowVersionsTable = &schema.Table{
@@ -2048,6 +2224,7 @@ var (
 		SysAttachmentsTable,
 		SysClientsTable,
 		SysCredentialsTable,
+		SysDatabaseBackupsTable,
 		LoggingsTable,
 		SysLoginRecordsTable,
 		OauthApplicationsTable,
@@ -2070,11 +2247,14 @@ var (
 		SysUserRoleTable,
 		SysVerifyCodesTable,
 		WorkflowApplicationsTable,
+		WorkflowApplicationSharesTable,
 		WorkflowEdgesTable,
 		WorkflowExecutionsTable,
 		WorkflowExecutionLogsTable,
 		WorkflowNodesTable,
 		WorkflowNodeExecutionsTable,
+		WorkflowSchedulesTable,
+		WorkflowSecretsTable,
 		WorkflowVersionsTable,
 		APIAuthPermissionsTable,
 		ClientDeviceRolesTable,
@@ -2104,6 +2284,9 @@ func init() {
 	SysCredentialsTable.Annotation = &entsql.Annotation{
 		Table: "sys_credentials",
 	}
+	SysDatabaseBackupsTable.Annotation = &entsql.Annotation{
+		Table: "sys_database_backups",
+	}
 	SysLoginRecordsTable.ForeignKeys[0].RefTable = SysUsersTable
 	SysLoginRecordsTable.Annotation = &entsql.Annotation{
 		Table: "sys_login_records",
@@ -2194,6 +2377,10 @@ func init() {
 	WorkflowApplicationsTable.Annotation = &entsql.Annotation{
 		Table: "workflow_applications",
 	}
+	WorkflowApplicationSharesTable.ForeignKeys[0].RefTable = WorkflowApplicationsTable
+	WorkflowApplicationSharesTable.Annotation = &entsql.Annotation{
+		Table: "workflow_application_shares",
+	}
 	WorkflowEdgesTable.ForeignKeys[0].RefTable = WorkflowApplicationsTable
 	WorkflowEdgesTable.ForeignKeys[1].RefTable = WorkflowNodesTable
 	WorkflowEdgesTable.ForeignKeys[2].RefTable = WorkflowNodesTable
@@ -2216,6 +2403,14 @@ func init() {
 	WorkflowNodeExecutionsTable.Annotation = &entsql.Annotation{
 		Table: "workflow_node_executions",
 	}
+	WorkflowSchedulesTable.ForeignKeys[0].RefTable = WorkflowApplicationsTable
+	WorkflowSchedulesTable.Annotation = &entsql.Annotation{
+		Table: "workflow_schedules",
+	}
+	WorkflowSecretsTable.ForeignKeys[0].RefTable = WorkflowApplicationsTable
+	WorkflowSecretsTable.Annotation = &entsql.Annotation{
+		Table: "workflow_secrets",
+	}
 	WorkflowVersionsTable.Annotation = &entsql.Annotation{
 		Table: "workflow_versions",
 	}
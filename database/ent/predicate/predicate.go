@@ -24,6 +24,9 @@ type ClientDevice func(*sql.Selector)
 // Credential is the predicate function for credential builders.
 type Credential func(*sql.Selector)
 
+// DatabaseBackup is the predicate function for databasebackup builders.
+type DatabaseBackup func(*sql.Selector)
+
 // Logging is the predicate function for logging builders.
 type Logging func(*sql.Selector)
 
@@ -90,6 +93,9 @@ type VerifyCode func(*sql.Selector)
 // WorkflowApplication is the predicate function for workflowapplication builders.
 type WorkflowApplication func(*sql.Selector)
 
+// WorkflowApplicationShare is the predicate function for workflowapplicationshare builders.
+type WorkflowApplicationShare func(*sql.Selector)
+
 // WorkflowEdge is the predicate function for workflowedge builders.
 type WorkflowEdge func(*sql.Selector)
 
@@ -105,5 +111,11 @@ type WorkflowNode func(*sql.Selector)
 // WorkflowNodeExecution is the predicate function for workflownodeexecution builders.
 type WorkflowNodeExecution func(*sql.Selector)
 
+// WorkflowSchedule is the predicate function for workflowschedule builders.
+type WorkflowSchedule func(*sql.Selector)
+
+// WorkflowSecret is the predicate function for workflowsecret builders.
+type WorkflowSecret func(*sql.Selector)
+
 // WorkflowVersion is the predicate function for workflowversion builders.
 type WorkflowVersion func(*sql.Selector)
@@ -255,6 +255,30 @@ func (f CredentialMutationRuleFunc) EvalMutation(ctx context.Context, m ent.Muta
 	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.CredentialMutation", m)
 }
 
+// The DatabaseBackupQueryRuleFunc type is an adapter to allow the use of ordinary
+// functions as a query rule.
+type DatabaseBackupQueryRuleFunc func(context.Context, *ent.DatabaseBackupQuery) error
+
+// EvalQuery return f(ctx, q).
+func (f DatabaseBackupQueryRuleFunc) EvalQuery(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.DatabaseBackupQuery); ok {
+		return f(ctx, q)
+	}
+	return Denyf("ent/privacy: unexpected query type %T, expect *ent.DatabaseBackupQuery", q)
+}
+
+// The DatabaseBackupMutationRuleFunc type is an adapter to allow the use of ordinary
+// functions as a mutation rule.
+type DatabaseBackupMutationRuleFunc func(context.Context, *ent.DatabaseBackupMutation) error
+
+// EvalMutation calls f(ctx, m).
+func (f DatabaseBackupMutationRuleFunc) EvalMutation(ctx context.Context, m ent.Mutation) error {
+	if m, ok := m.(*ent.DatabaseBackupMutation); ok {
+		return f(ctx, m)
+	}
+	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.DatabaseBackupMutation", m)
+}
+
 // The LoggingQueryRuleFunc type is an adapter to allow the use of ordinary
 // functions as a query rule.
 type LoggingQueryRuleFunc func(context.Context, *ent.LoggingQuery) error
@@ -783,6 +807,30 @@ func (f WorkflowApplicationMutationRuleFunc) EvalMutation(ctx context.Context, m
 	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.WorkflowApplicationMutation", m)
 }
 
+// The WorkflowApplicationShareQueryRuleFunc type is an adapter to allow the use of ordinary
+// functions as a query rule.
+type WorkflowApplicationShareQueryRuleFunc func(context.Context, *ent.WorkflowApplicationShareQuery) error
+
+// EvalQuery return f(ctx, q).
+func (f WorkflowApplicationShareQueryRuleFunc) EvalQuery(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowApplicationShareQuery); ok {
+		return f(ctx, q)
+	}
+	return Denyf("ent/privacy: unexpected query type %T, expect *ent.WorkflowApplicationShareQuery", q)
+}
+
+// The WorkflowApplicationShareMutationRuleFunc type is an adapter to allow the use of ordinary
+// functions as a mutation rule.
+type WorkflowApplicationShareMutationRuleFunc func(context.Context, *ent.WorkflowApplicationShareMutation) error
+
+// EvalMutation calls f(ctx, m).
+func (f WorkflowApplicationShareMutationRuleFunc) EvalMutation(ctx context.Context, m ent.Mutation) error {
+	if m, ok := m.(*ent.WorkflowApplicationShareMutation); ok {
+		return f(ctx, m)
+	}
+	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.WorkflowApplicationShareMutation", m)
+}
+
 // The WorkflowEdgeQueryRuleFunc type is an adapter to allow the use of ordinary
 // functions as a query rule.
 type WorkflowEdgeQueryRuleFunc func(context.Context, *ent.WorkflowEdgeQuery) error
@@ -903,6 +951,54 @@ func (f WorkflowNodeExecutionMutationRuleFunc) EvalMutation(ctx context.Context,
 	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.WorkflowNodeExecutionMutation", m)
 }
 
+// The WorkflowScheduleQueryRuleFunc type is an adapter to allow the use of ordinary
+// functions as a query rule.
+type WorkflowScheduleQueryRuleFunc func(context.Context, *ent.WorkflowScheduleQuery) error
+
+// EvalQuery return f(ctx, q).
+func (f WorkflowScheduleQueryRuleFunc) EvalQuery(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowScheduleQuery); ok {
+		return f(ctx, q)
+	}
+	return Denyf("ent/privacy: unexpected query type %T, expect *ent.WorkflowScheduleQuery", q)
+}
+
+// The WorkflowScheduleMutationRuleFunc type is an adapter to allow the use of ordinary
+// functions as a mutation rule.
+type WorkflowScheduleMutationRuleFunc func(context.Context, *ent.WorkflowScheduleMutation) error
+
+// EvalMutation calls f(ctx, m).
+func (f WorkflowScheduleMutationRuleFunc) EvalMutation(ctx context.Context, m ent.Mutation) error {
+	if m, ok := m.(*ent.WorkflowScheduleMutation); ok {
+		return f(ctx, m)
+	}
+	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.WorkflowScheduleMutation", m)
+}
+
+// The WorkflowSecretQueryRuleFunc type is an adapter to allow the use of ordinary
+// functions as a query rule.
+type WorkflowSecretQueryRuleFunc func(context.Context, *ent.WorkflowSecretQuery) error
+
+// EvalQuery return f(ctx, q).
+func (f WorkflowSecretQueryRuleFunc) EvalQuery(ctx context.Context, q ent.Query) error {
+	if q, ok := q.(*ent.WorkflowSecretQuery); ok {
+		return f(ctx, q)
+	}
+	return Denyf("ent/privacy: unexpected query type %T, expect *ent.WorkflowSecretQuery", q)
+}
+
+// The WorkflowSecretMutationRuleFunc type is an adapter to allow the use of ordinary
+// functions as a mutation rule.
+type WorkflowSecretMutationRuleFunc func(context.Context, *ent.WorkflowSecretMutation) error
+
+// EvalMutation calls f(ctx, m).
+func (f WorkflowSecretMutationRuleFunc) EvalMutation(ctx context.Context, m ent.Mutation) error {
+	if m, ok := m.(*ent.WorkflowSecretMutation); ok {
+		return f(ctx, m)
+	}
+	return Denyf("ent/privacy: unexpected mutation type %T, expect *ent.WorkflowSecretMutation", m)
+}
+
 // The WorkflowVersionQueryRuleFunc type is an adapter to allow the use of ordinary
 // functions as a query rule.
 type WorkflowVersionQueryRuleFunc func(context.Context, *ent.WorkflowVersionQuery) error
@@ -974,6 +1070,8 @@ func queryFilter(q ent.Query) (Filter, error) {
 		return q.Filter(), nil
 	case *ent.CredentialQuery:
 		return q.Filter(), nil
+	case *ent.DatabaseBackupQuery:
+		return q.Filter(), nil
 	case *ent.LoggingQuery:
 		return q.Filter(), nil
 	case *ent.LoginRecordQuery:
@@ -1018,6 +1116,8 @@ func queryFilter(q ent.Query) (Filter, error) {
 		return q.Filter(), nil
 	case *ent.WorkflowApplicationQuery:
 		return q.Filter(), nil
+	case *ent.WorkflowApplicationShareQuery:
+		return q.Filter(), nil
 	case *ent.WorkflowEdgeQuery:
 		return q.Filter(), nil
 	case *ent.WorkflowExecutionQuery:
@@ -1028,6 +1128,10 @@ func queryFilter(q ent.Query) (Filter, error) {
 		return q.Filter(), nil
 	case *ent.WorkflowNodeExecutionQuery:
 		return q.Filter(), nil
+	case *ent.WorkflowScheduleQuery:
+		return q.Filter(), nil
+	case *ent.WorkflowSecretQuery:
+		return q.Filter(), nil
 	case *ent.WorkflowVersionQuery:
 		return q.Filter(), nil
 	default:
@@ -1049,6 +1153,8 @@ func mutationFilter(m ent.Mutation) (Filter, error) {
 		return m.Filter(), nil
 	case *ent.CredentialMutation:
 		return m.Filter(), nil
+	case *ent.DatabaseBackupMutation:
+		return m.Filter(), nil
 	case *ent.LoggingMutation:
 		return m.Filter(), nil
 	case *ent.LoginRecordMutation:
@@ -1093,6 +1199,8 @@ func mutationFilter(m ent.Mutation) (Filter, error) {
 		return m.Filter(), nil
 	case *ent.WorkflowApplicationMutation:
 		return m.Filter(), nil
+	case *ent.WorkflowApplicationShareMutation:
+		return m.Filter(), nil
 	case *ent.WorkflowEdgeMutation:
 		return m.Filter(), nil
 	case *ent.WorkflowExecutionMutation:
@@ -1103,6 +1211,10 @@ func mutationFilter(m ent.Mutation) (Filter, error) {
 		return m.Filter(), nil
 	case *ent.WorkflowNodeExecutionMutation:
 		return m.Filter(), nil
+	case *ent.WorkflowScheduleMutation:
+		return m.Filter(), nil
+	case *ent.WorkflowSecretMutation:
+		return m.Filter(), nil
 	case *ent.WorkflowVersionMutation:
 		return m.Filter(), nil
 	default:
@@ -9,6 +9,7 @@ import (
 	"go-backend/database/ent/attachment"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
+	"go-backend/database/ent/databasebackup"
 	"go-backend/database/ent/logging"
 	"go-backend/database/ent/loginrecord"
 	"go-backend/database/ent/oauthapplication"
@@ -31,11 +32,14 @@ import (
 	"go-backend/database/ent/userrole"
 	"go-backend/database/ent/verifycode"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflowexecutionlog"
 	"go-backend/database/ent/workflownode"
 	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
 	"go-backend/database/schema"
 	"time"
@@ -450,6 +454,10 @@ func init() {
 	clientdeviceDescAnonymous := clientdeviceFields[6].Descriptor()
 	// clientdevice.DefaultAnonymous holds the default value on creation for the anonymous field.
 	clientdevice.DefaultAnonymous = clientdeviceDescAnonymous.Default.(bool)
+	// clientdeviceDescAudience is the schema descriptor for audience field.
+	clientdeviceDescAudience := clientdeviceFields[7].Descriptor()
+	// clientdevice.AudienceValidator is a validator for the "audience" field. It is called by the builders before save.
+	clientdevice.AudienceValidator = clientdeviceDescAudience.Validators[0].(func(string) error)
 	credentialMixin := schema.Credential{}.Mixin()
 	credentialMixinHooks0 := credentialMixin[0].Hooks()
 	credentialMixinHooks1 := credentialMixin[1].Hooks()
@@ -496,6 +504,42 @@ func init() {
 	credentialDescFailedAttempts := credentialFields[10].Descriptor()
 	// credential.DefaultFailedAttempts holds the default value on creation for the failed_attempts field.
 	credential.DefaultFailedAttempts = credentialDescFailedAttempts.Default.(int)
+	databasebackupMixin := schema.DatabaseBackup{}.Mixin()
+	databasebackupMixinHooks0 := databasebackupMixin[0].Hooks()
+	databasebackup.Hooks[0] = databasebackupMixinHooks0[0]
+	databasebackup.Hooks[1] = databasebackupMixinHooks0[1]
+	databasebackupMixinFields0 := databasebackupMixin[0].Fields()
+	_ = databasebackupMixinFields0
+	databasebackupFields := schema.DatabaseBackup{}.Fields()
+	_ = databasebackupFields
+	// databasebackupDescCreateTime is the schema descriptor for create_time field.
+	databasebackupDescCreateTime := databasebackupMixinFields0[1].Descriptor()
+	// databasebackup.DefaultCreateTime holds the default value on creation for the create_time field.
+	databasebackup.DefaultCreateTime = databasebackupDescCreateTime.Default.(func() time.Time)
+	// databasebackupDescUpdateTime is the schema descriptor for update_time field.
+	databasebackupDescUpdateTime := databasebackupMixinFields0[3].Descriptor()
+	// databasebackup.DefaultUpdateTime holds the default value on creation for the update_time field.
+	databasebackup.DefaultUpdateTime = databasebackupDescUpdateTime.Default.(func() time.Time)
+	// databasebackup.UpdateDefaultUpdateTime holds the default value on update for the update_time field.
+	databasebackup.UpdateDefaultUpdateTime = databasebackupDescUpdateTime.UpdateDefault.(func() time.Time)
+	// databasebackupDescBucket is the schema descriptor for bucket field.
+	databasebackupDescBucket := databasebackupFields[0].Descriptor()
+	// databasebackup.BucketValidator is a validator for the "bucket" field. It is called by the builders before save.
+	databasebackup.BucketValidator = databasebackupDescBucket.Validators[0].(func(string) error)
+	// databasebackupDescKey is the schema descriptor for key field.
+	databasebackupDescKey := databasebackupFields[1].Descriptor()
+	// databasebackup.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	databasebackup.KeyValidator = databasebackupDescKey.Validators[0].(func(string) error)
+	// databasebackupDescSize is the schema descriptor for size field.
+	databasebackupDescSize := databasebackupFields[2].Descriptor()
+	// databasebackup.SizeValidator is a validator for the "size" field. It is called by the builders before save.
+	databasebackup.SizeValidator = databasebackupDescSize.Validators[0].(func(int64) error)
+	// databasebackupDescTotalRecords is the schema descriptor for total_records field.
+	databasebackupDescTotalRecords := databasebackupFields[3].Descriptor()
+	// databasebackup.DefaultTotalRecords holds the default value on creation for the total_records field.
+	databasebackup.DefaultTotalRecords = databasebackupDescTotalRecords.Default.(int)
+	// databasebackup.TotalRecordsValidator is a validator for the "total_records" field. It is called by the builders before save.
+	databasebackup.TotalRecordsValidator = databasebackupDescTotalRecords.Validators[0].(func(int) error)
 	loggingMixin := schema.Logging{}.Mixin()
 	loggingMixinHooks0 := loggingMixin[0].Hooks()
 	loggingMixinHooks1 := loggingMixin[1].Hooks()
@@ -1406,6 +1450,28 @@ func init() {
 	workflowapplicationDescVersion := workflowapplicationFields[5].Descriptor()
 	// workflowapplication.DefaultVersion holds the default value on creation for the version field.
 	workflowapplication.DefaultVersion = workflowapplicationDescVersion.Default.(uint)
+	// workflowapplicationDescIsTemplate is the schema descriptor for is_template field.
+	workflowapplicationDescIsTemplate := workflowapplicationFields[9].Descriptor()
+	// workflowapplication.DefaultIsTemplate holds the default value on creation for the is_template field.
+	workflowapplication.DefaultIsTemplate = workflowapplicationDescIsTemplate.Default.(bool)
+	workflowapplicationshareMixin := schema.WorkflowApplicationShare{}.Mixin()
+	workflowapplicationshareMixinHooks0 := workflowapplicationshareMixin[0].Hooks()
+	workflowapplicationshare.Hooks[0] = workflowapplicationshareMixinHooks0[0]
+	workflowapplicationshare.Hooks[1] = workflowapplicationshareMixinHooks0[1]
+	workflowapplicationshareMixinFields0 := workflowapplicationshareMixin[0].Fields()
+	_ = workflowapplicationshareMixinFields0
+	workflowapplicationshareFields := schema.WorkflowApplicationShare{}.Fields()
+	_ = workflowapplicationshareFields
+	// workflowapplicationshareDescCreateTime is the schema descriptor for create_time field.
+	workflowapplicationshareDescCreateTime := workflowapplicationshareMixinFields0[1].Descriptor()
+	// workflowapplicationshare.DefaultCreateTime holds the default value on creation for the create_time field.
+	workflowapplicationshare.DefaultCreateTime = workflowapplicationshareDescCreateTime.Default.(func() time.Time)
+	// workflowapplicationshareDescUpdateTime is the schema descriptor for update_time field.
+	workflowapplicationshareDescUpdateTime := workflowapplicationshareMixinFields0[3].Descriptor()
+	// workflowapplicationshare.DefaultUpdateTime holds the default value on creation for the update_time field.
+	workflowapplicationshare.DefaultUpdateTime = workflowapplicationshareDescUpdateTime.Default.(func() time.Time)
+	// workflowapplicationshare.UpdateDefaultUpdateTime holds the default value on update for the update_time field.
+	workflowapplicationshare.UpdateDefaultUpdateTime = workflowapplicationshareDescUpdateTime.UpdateDefault.(func() time.Time)
 	workflowedgeMixin := schema.WorkflowEdge{}.Mixin()
 	workflowedgeMixinHooks0 := workflowedgeMixin[0].Hooks()
 	workflowedgeMixinHooks1 := workflowedgeMixin[1].Hooks()
@@ -1455,15 +1521,15 @@ func init() {
 	// workflowexecution.ExecutionIDValidator is a validator for the "execution_id" field. It is called by the builders before save.
 	workflowexecution.ExecutionIDValidator = workflowexecutionDescExecutionID.Validators[0].(func(string) error)
 	// workflowexecutionDescDurationMs is the schema descriptor for duration_ms field.
-	workflowexecutionDescDurationMs := workflowexecutionFields[8].Descriptor()
+	workflowexecutionDescDurationMs := workflowexecutionFields[10].Descriptor()
 	// workflowexecution.DefaultDurationMs holds the default value on creation for the duration_ms field.
 	workflowexecution.DefaultDurationMs = workflowexecutionDescDurationMs.Default.(int)
 	// workflowexecutionDescTotalTokens is the schema descriptor for total_tokens field.
-	workflowexecutionDescTotalTokens := workflowexecutionFields[9].Descriptor()
+	workflowexecutionDescTotalTokens := workflowexecutionFields[11].Descriptor()
 	// workflowexecution.DefaultTotalTokens holds the default value on creation for the total_tokens field.
 	workflowexecution.DefaultTotalTokens = workflowexecutionDescTotalTokens.Default.(int)
 	// workflowexecutionDescTotalCost is the schema descriptor for total_cost field.
-	workflowexecutionDescTotalCost := workflowexecutionFields[10].Descriptor()
+	workflowexecutionDescTotalCost := workflowexecutionFields[12].Descriptor()
 	// workflowexecution.DefaultTotalCost holds the default value on creation for the total_cost field.
 	workflowexecution.DefaultTotalCost = workflowexecutionDescTotalCost.Default.(float64)
 	workflowexecutionlogMixin := schema.WorkflowExecutionLog{}.Mixin()
@@ -1534,6 +1600,10 @@ func init() {
 	workflownodeDescPositionY := workflownodeFields[16].Descriptor()
 	// workflownode.DefaultPositionY holds the default value on creation for the position_y field.
 	workflownode.DefaultPositionY = workflownodeDescPositionY.Default.(float64)
+	// workflownodeDescEnabled is the schema descriptor for enabled field.
+	workflownodeDescEnabled := workflownodeFields[18].Descriptor()
+	// workflownode.DefaultEnabled holds the default value on creation for the enabled field.
+	workflownode.DefaultEnabled = workflownodeDescEnabled.Default.(bool)
 	workflownodeexecutionMixin := schema.WorkflowNodeExecution{}.Mixin()
 	workflownodeexecutionMixinHooks0 := workflownodeexecutionMixin[0].Hooks()
 	workflownodeexecution.Hooks[0] = workflownodeexecutionMixinHooks0[0]
@@ -1580,6 +1650,58 @@ func init() {
 	workflownodeexecutionDescIsAsync := workflownodeexecutionFields[19].Descriptor()
 	// workflownodeexecution.DefaultIsAsync holds the default value on creation for the is_async field.
 	workflownodeexecution.DefaultIsAsync = workflownodeexecutionDescIsAsync.Default.(bool)
+	workflowscheduleMixin := schema.WorkflowSchedule{}.Mixin()
+	workflowscheduleMixinHooks0 := workflowscheduleMixin[0].Hooks()
+	workflowscheduleMixinHooks1 := workflowscheduleMixin[1].Hooks()
+	workflowschedule.Hooks[0] = workflowscheduleMixinHooks0[0]
+	workflowschedule.Hooks[1] = workflowscheduleMixinHooks0[1]
+	workflowschedule.Hooks[2] = workflowscheduleMixinHooks1[0]
+	workflowscheduleMixinInters1 := workflowscheduleMixin[1].Interceptors()
+	workflowschedule.Interceptors[0] = workflowscheduleMixinInters1[0]
+	workflowscheduleMixinFields0 := workflowscheduleMixin[0].Fields()
+	_ = workflowscheduleMixinFields0
+	workflowscheduleFields := schema.WorkflowSchedule{}.Fields()
+	_ = workflowscheduleFields
+	// workflowscheduleDescCreateTime is the schema descriptor for create_time field.
+	workflowscheduleDescCreateTime := workflowscheduleMixinFields0[1].Descriptor()
+	// workflowschedule.DefaultCreateTime holds the default value on creation for the create_time field.
+	workflowschedule.DefaultCreateTime = workflowscheduleDescCreateTime.Default.(func() time.Time)
+	// workflowscheduleDescUpdateTime is the schema descriptor for update_time field.
+	workflowscheduleDescUpdateTime := workflowscheduleMixinFields0[3].Descriptor()
+	// workflowschedule.DefaultUpdateTime holds the default value on creation for the update_time field.
+	workflowschedule.DefaultUpdateTime = workflowscheduleDescUpdateTime.Default.(func() time.Time)
+	// workflowschedule.UpdateDefaultUpdateTime holds the default value on update for the update_time field.
+	workflowschedule.UpdateDefaultUpdateTime = workflowscheduleDescUpdateTime.UpdateDefault.(func() time.Time)
+	// workflowscheduleDescCronExpression is the schema descriptor for cron_expression field.
+	workflowscheduleDescCronExpression := workflowscheduleFields[2].Descriptor()
+	// workflowschedule.CronExpressionValidator is a validator for the "cron_expression" field. It is called by the builders before save.
+	workflowschedule.CronExpressionValidator = workflowscheduleDescCronExpression.Validators[0].(func(string) error)
+	// workflowscheduleDescEnabled is the schema descriptor for enabled field.
+	workflowscheduleDescEnabled := workflowscheduleFields[4].Descriptor()
+	// workflowschedule.DefaultEnabled holds the default value on creation for the enabled field.
+	workflowschedule.DefaultEnabled = workflowscheduleDescEnabled.Default.(bool)
+	workflowsecretMixin := schema.WorkflowSecret{}.Mixin()
+	workflowsecretMixinHooks0 := workflowsecretMixin[0].Hooks()
+	workflowsecret.Hooks[0] = workflowsecretMixinHooks0[0]
+	workflowsecret.Hooks[1] = workflowsecretMixinHooks0[1]
+	workflowsecretMixinFields0 := workflowsecretMixin[0].Fields()
+	_ = workflowsecretMixinFields0
+	workflowsecretFields := schema.WorkflowSecret{}.Fields()
+	_ = workflowsecretFields
+	// workflowsecretDescCreateTime is the schema descriptor for create_time field.
+	workflowsecretDescCreateTime := workflowsecretMixinFields0[1].Descriptor()
+	// workflowsecret.DefaultCreateTime holds the default value on creation for the create_time field.
+	workflowsecret.DefaultCreateTime = workflowsecretDescCreateTime.Default.(func() time.Time)
+	// workflowsecretDescUpdateTime is the schema descriptor for update_time field.
+	workflowsecretDescUpdateTime := workflowsecretMixinFields0[3].Descriptor()
+	// workflowsecret.DefaultUpdateTime holds the default value on creation for the update_time field.
+	workflowsecret.DefaultUpdateTime = workflowsecretDescUpdateTime.Default.(func() time.Time)
+	// workflowsecret.UpdateDefaultUpdateTime holds the default value on update for the update_time field.
+	workflowsecret.UpdateDefaultUpdateTime = workflowsecretDescUpdateTime.UpdateDefault.(func() time.Time)
+	// workflowsecretDescKey is the schema descriptor for key field.
+	workflowsecretDescKey := workflowsecretFields[1].Descriptor()
+	// workflowsecret.KeyValidator is a validator for the "key" field. It is called by the builders before save.
+	workflowsecret.KeyValidator = workflowsecretDescKey.Validators[0].(func(string) error)
 	workflowversionMixin := schema.WorkflowVersion{}.Mixin()
 	workflowversionMixinHooks0 := workflowversionMixin[0].Hooks()
 	workflowversion.Hooks[0] = workflowversionMixinHooks0[0]
@@ -1598,6 +1720,10 @@ func init() {
 	workflowversion.DefaultUpdateTime = workflowversionDescUpdateTime.Default.(func() time.Time)
 	// workflowversion.UpdateDefaultUpdateTime holds the default value on update for the update_time field.
 	workflowversion.UpdateDefaultUpdateTime = workflowversionDescUpdateTime.UpdateDefault.(func() time.Time)
+	// workflowversionDescPinned is the schema descriptor for pinned field.
+	workflowversionDescPinned := workflowversionFields[4].Descriptor()
+	// workflowversion.DefaultPinned holds the default value on creation for the pinned field.
+	workflowversion.DefaultPinned = workflowversionDescPinned.Default.(bool)
 }
 
 const (
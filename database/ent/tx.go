@@ -26,6 +26,8 @@ type Tx struct {
 	ClientDevice *ClientDeviceClient
 	// Credential is the client for interacting with the Credential builders.
 	Credential *CredentialClient
+	// DatabaseBackup is the client for interacting with the DatabaseBackup builders.
+	DatabaseBackup *DatabaseBackupClient
 	// Logging is the client for interacting with the Logging builders.
 	Logging *LoggingClient
 	// LoginRecord is the client for interacting with the LoginRecord builders.
@@ -70,6 +72,8 @@ type Tx struct {
 	VerifyCode *VerifyCodeClient
 	// WorkflowApplication is the client for interacting with the WorkflowApplication builders.
 	WorkflowApplication *WorkflowApplicationClient
+	// WorkflowApplicationShare is the client for interacting with the WorkflowApplicationShare builders.
+	WorkflowApplicationShare *WorkflowApplicationShareClient
 	// WorkflowEdge is the client for interacting with the WorkflowEdge builders.
 	WorkflowEdge *WorkflowEdgeClient
 	// WorkflowExecution is the client for interacting with the WorkflowExecution builders.
@@ -80,6 +84,10 @@ type Tx struct {
 	WorkflowNode *WorkflowNodeClient
 	// WorkflowNodeExecution is the client for interacting with the WorkflowNodeExecution builders.
 	WorkflowNodeExecution *WorkflowNodeExecutionClient
+	// WorkflowSchedule is the client for interacting with the WorkflowSchedule builders.
+	WorkflowSchedule *WorkflowScheduleClient
+	// WorkflowSecret is the client for interacting with the WorkflowSecret builders.
+	WorkflowSecret *WorkflowSecretClient
 	// WorkflowVersion is the client for interacting with the WorkflowVersion builders.
 	WorkflowVersion *WorkflowVersionClient
 
@@ -219,6 +227,7 @@ func (tx *Tx) init() {
 	tx.Attachment = NewAttachmentClient(tx.config)
 	tx.ClientDevice = NewClientDeviceClient(tx.config)
 	tx.Credential = NewCredentialClient(tx.config)
+	tx.DatabaseBackup = NewDatabaseBackupClient(tx.config)
 	tx.Logging = NewLoggingClient(tx.config)
 	tx.LoginRecord = NewLoginRecordClient(tx.config)
 	tx.OauthApplication = NewOauthApplicationClient(tx.config)
@@ -241,11 +250,14 @@ func (tx *Tx) init() {
 	tx.UserRole = NewUserRoleClient(tx.config)
 	tx.VerifyCode = NewVerifyCodeClient(tx.config)
 	tx.WorkflowApplication = NewWorkflowApplicationClient(tx.config)
+	tx.WorkflowApplicationShare = NewWorkflowApplicationShareClient(tx.config)
 	tx.WorkflowEdge = NewWorkflowEdgeClient(tx.config)
 	tx.WorkflowExecution = NewWorkflowExecutionClient(tx.config)
 	tx.WorkflowExecutionLog = NewWorkflowExecutionLogClient(tx.config)
 	tx.WorkflowNode = NewWorkflowNodeClient(tx.config)
 	tx.WorkflowNodeExecution = NewWorkflowNodeExecutionClient(tx.config)
+	tx.WorkflowSchedule = NewWorkflowScheduleClient(tx.config)
+	tx.WorkflowSecret = NewWorkflowSecretClient(tx.config)
 	tx.WorkflowVersion = NewWorkflowVersionClient(tx.config)
 }
 
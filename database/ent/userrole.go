@@ -36,6 +36,8 @@ type UserRole struct {
 	UserID uint64 `json:"user_id,omitempty"`
 	// RoleID holds the value of the "role_id" field.
 	RoleID uint64 `json:"role_id,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the UserRoleQuery when eager-loading is set.
 	Edges        UserRoleEdges `json:"edges"`
@@ -82,7 +84,7 @@ func (*UserRole) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case userrole.FieldID, userrole.FieldCreateBy, userrole.FieldUpdateBy, userrole.FieldDeleteBy, userrole.FieldUserID, userrole.FieldRoleID:
 			values[i] = new(sql.NullInt64)
-		case userrole.FieldCreateTime, userrole.FieldUpdateTime, userrole.FieldDeleteTime:
+		case userrole.FieldCreateTime, userrole.FieldUpdateTime, userrole.FieldDeleteTime, userrole.FieldExpiresAt:
 			values[i] = new(sql.NullTime)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -153,6 +155,13 @@ func (_m *UserRole) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.RoleID = uint64(value.Int64)
 			}
+		case userrole.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				_m.ExpiresAt = new(time.Time)
+				*_m.ExpiresAt = value.Time
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -222,6 +231,11 @@ func (_m *UserRole) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("role_id=")
 	builder.WriteString(fmt.Sprintf("%v", _m.RoleID))
+	builder.WriteString(", ")
+	if v := _m.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
 	builder.WriteByte(')')
 	return builder.String()
 }
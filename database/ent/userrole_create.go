@@ -118,6 +118,20 @@ func (_c *UserRoleCreate) SetRoleID(v uint64) *UserRoleCreate {
 	return _c
 }
 
+// SetExpiresAt sets the "expires_at" field.
+func (_c *UserRoleCreate) SetExpiresAt(v time.Time) *UserRoleCreate {
+	_c.mutation.SetExpiresAt(v)
+	return _c
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_c *UserRoleCreate) SetNillableExpiresAt(v *time.Time) *UserRoleCreate {
+	if v != nil {
+		_c.SetExpiresAt(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *UserRoleCreate) SetID(v uint64) *UserRoleCreate {
 	_c.mutation.SetID(v)
@@ -264,6 +278,10 @@ func (_c *UserRoleCreate) createSpec() (*UserRole, *sqlgraph.CreateSpec) {
 		_spec.SetField(userrole.FieldDeleteBy, field.TypeUint64, value)
 		_node.DeleteBy = value
 	}
+	if value, ok := _c.mutation.ExpiresAt(); ok {
+		_spec.SetField(userrole.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = &value
+	}
 	if nodes := _c.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
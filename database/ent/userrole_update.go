@@ -165,6 +165,26 @@ func (_u *UserRoleUpdate) SetNillableRoleID(v *uint64) *UserRoleUpdate {
 	return _u
 }
 
+// SetExpiresAt sets the "expires_at" field.
+func (_u *UserRoleUpdate) SetExpiresAt(v time.Time) *UserRoleUpdate {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *UserRoleUpdate) SetNillableExpiresAt(v *time.Time) *UserRoleUpdate {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *UserRoleUpdate) ClearExpiresAt() *UserRoleUpdate {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UserRoleUpdate) SetUser(v *User) *UserRoleUpdate {
 	return _u.SetUserID(v.ID)
@@ -293,6 +313,12 @@ func (_u *UserRoleUpdate) sqlSave(ctx context.Context) (_node int, err error) {
 	if _u.mutation.DeleteByCleared() {
 		_spec.ClearField(userrole.FieldDeleteBy, field.TypeUint64)
 	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(userrole.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(userrole.FieldExpiresAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -506,6 +532,26 @@ func (_u *UserRoleUpdateOne) SetNillableRoleID(v *uint64) *UserRoleUpdateOne {
 	return _u
 }
 
+// SetExpiresAt sets the "expires_at" field.
+func (_u *UserRoleUpdateOne) SetExpiresAt(v time.Time) *UserRoleUpdateOne {
+	_u.mutation.SetExpiresAt(v)
+	return _u
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (_u *UserRoleUpdateOne) SetNillableExpiresAt(v *time.Time) *UserRoleUpdateOne {
+	if v != nil {
+		_u.SetExpiresAt(*v)
+	}
+	return _u
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (_u *UserRoleUpdateOne) ClearExpiresAt() *UserRoleUpdateOne {
+	_u.mutation.ClearExpiresAt()
+	return _u
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (_u *UserRoleUpdateOne) SetUser(v *User) *UserRoleUpdateOne {
 	return _u.SetUserID(v.ID)
@@ -664,6 +710,12 @@ func (_u *UserRoleUpdateOne) sqlSave(ctx context.Context) (_node *UserRole, err
 	if _u.mutation.DeleteByCleared() {
 		_spec.ClearField(userrole.FieldDeleteBy, field.TypeUint64)
 	}
+	if value, ok := _u.mutation.ExpiresAt(); ok {
+		_spec.SetField(userrole.FieldExpiresAt, field.TypeTime, value)
+	}
+	if _u.mutation.ExpiresAtCleared() {
+		_spec.ClearField(userrole.FieldExpiresAt, field.TypeTime)
+	}
 	if _u.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
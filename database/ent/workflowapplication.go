@@ -47,6 +47,12 @@ type WorkflowApplication struct {
 	Status workflowapplication.Status `json:"status,omitempty"`
 	// 画布视口配置（zoom, x, y）
 	ViewportConfig map[string]interface{} `json:"viewport_config,omitempty"`
+	// 执行输入的JSON Schema（支持 required、type、enum），为空时不校验
+	InputSchema map[string]interface{} `json:"input_schema,omitempty"`
+	// 是否为模板，模板不能直接执行，需要实例化为普通应用后使用
+	IsTemplate bool `json:"is_template,omitempty"`
+	// 所有者用户ID，为空表示引入所有权之前创建的应用，对所有用户可见
+	OwnerID uint64 `json:"owner_id,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the WorkflowApplicationQuery when eager-loading is set.
 	Edges        WorkflowApplicationEdges `json:"edges"`
@@ -61,12 +67,21 @@ type WorkflowApplicationEdges struct {
 	Edges []*WorkflowEdge `json:"edges,omitempty"`
 	// Executions holds the value of the executions edge.
 	Executions []*WorkflowExecution `json:"executions,omitempty"`
+	// Schedules holds the value of the schedules edge.
+	Schedules []*WorkflowSchedule `json:"schedules,omitempty"`
+	// Secrets holds the value of the secrets edge.
+	Secrets []*WorkflowSecret `json:"secrets,omitempty"`
+	// Shares holds the value of the shares edge.
+	Shares []*WorkflowApplicationShare `json:"shares,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes     [3]bool
+	loadedTypes     [6]bool
 	namedNodes      map[string][]*WorkflowNode
 	namedEdges      map[string][]*WorkflowEdge
 	namedExecutions map[string][]*WorkflowExecution
+	namedSchedules  map[string][]*WorkflowSchedule
+	namedSecrets    map[string][]*WorkflowSecret
+	namedShares     map[string][]*WorkflowApplicationShare
 }
 
 // NodesOrErr returns the Nodes value or an error if the edge
@@ -96,14 +111,43 @@ func (e WorkflowApplicationEdges) ExecutionsOrErr() ([]*WorkflowExecution, error
 	return nil, &NotLoadedError{edge: "executions"}
 }
 
+// SchedulesOrErr returns the Schedules value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkflowApplicationEdges) SchedulesOrErr() ([]*WorkflowSchedule, error) {
+	if e.loadedTypes[3] {
+		return e.Schedules, nil
+	}
+	return nil, &NotLoadedError{edge: "schedules"}
+}
+
+// SecretsOrErr returns the Secrets value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkflowApplicationEdges) SecretsOrErr() ([]*WorkflowSecret, error) {
+	if e.loadedTypes[4] {
+		return e.Secrets, nil
+	}
+	return nil, &NotLoadedError{edge: "secrets"}
+}
+
+// SharesOrErr returns the Shares value or an error if the edge
+// was not loaded in eager-loading.
+func (e WorkflowApplicationEdges) SharesOrErr() ([]*WorkflowApplicationShare, error) {
+	if e.loadedTypes[5] {
+		return e.Shares, nil
+	}
+	return nil, &NotLoadedError{edge: "shares"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*WorkflowApplication) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case workflowapplication.FieldVariables, workflowapplication.FieldViewportConfig:
+		case workflowapplication.FieldVariables, workflowapplication.FieldViewportConfig, workflowapplication.FieldInputSchema:
 			values[i] = new([]byte)
-		case workflowapplication.FieldID, workflowapplication.FieldCreateBy, workflowapplication.FieldUpdateBy, workflowapplication.FieldDeleteBy, workflowapplication.FieldStartNodeID, workflowapplication.FieldVersion:
+		case workflowapplication.FieldIsTemplate:
+			values[i] = new(sql.NullBool)
+		case workflowapplication.FieldID, workflowapplication.FieldCreateBy, workflowapplication.FieldUpdateBy, workflowapplication.FieldDeleteBy, workflowapplication.FieldStartNodeID, workflowapplication.FieldVersion, workflowapplication.FieldOwnerID:
 			values[i] = new(sql.NullInt64)
 		case workflowapplication.FieldName, workflowapplication.FieldDescription, workflowapplication.FieldClientSecret, workflowapplication.FieldStatus:
 			values[i] = new(sql.NullString)
@@ -218,6 +262,26 @@ func (_m *WorkflowApplication) assignValues(columns []string, values []any) erro
 					return fmt.Errorf("unmarshal field viewport_config: %w", err)
 				}
 			}
+		case workflowapplication.FieldInputSchema:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field input_schema", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.InputSchema); err != nil {
+					return fmt.Errorf("unmarshal field input_schema: %w", err)
+				}
+			}
+		case workflowapplication.FieldIsTemplate:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field is_template", values[i])
+			} else if value.Valid {
+				_m.IsTemplate = value.Bool
+			}
+		case workflowapplication.FieldOwnerID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field owner_id", values[i])
+			} else if value.Valid {
+				_m.OwnerID = uint64(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -246,6 +310,21 @@ func (_m *WorkflowApplication) QueryExecutions() *WorkflowExecutionQuery {
 	return NewWorkflowApplicationClient(_m.config).QueryExecutions(_m)
 }
 
+// QuerySchedules queries the "schedules" edge of the WorkflowApplication entity.
+func (_m *WorkflowApplication) QuerySchedules() *WorkflowScheduleQuery {
+	return NewWorkflowApplicationClient(_m.config).QuerySchedules(_m)
+}
+
+// QuerySecrets queries the "secrets" edge of the WorkflowApplication entity.
+func (_m *WorkflowApplication) QuerySecrets() *WorkflowSecretQuery {
+	return NewWorkflowApplicationClient(_m.config).QuerySecrets(_m)
+}
+
+// QueryShares queries the "shares" edge of the WorkflowApplication entity.
+func (_m *WorkflowApplication) QueryShares() *WorkflowApplicationShareQuery {
+	return NewWorkflowApplicationClient(_m.config).QueryShares(_m)
+}
+
 // Update returns a builder for updating this WorkflowApplication.
 // Note that you need to call WorkflowApplication.Unwrap() before calling this method if this WorkflowApplication
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -310,6 +389,15 @@ func (_m *WorkflowApplication) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("viewport_config=")
 	builder.WriteString(fmt.Sprintf("%v", _m.ViewportConfig))
+	builder.WriteString(", ")
+	builder.WriteString("input_schema=")
+	builder.WriteString(fmt.Sprintf("%v", _m.InputSchema))
+	builder.WriteString(", ")
+	builder.WriteString("is_template=")
+	builder.WriteString(fmt.Sprintf("%v", _m.IsTemplate))
+	builder.WriteString(", ")
+	builder.WriteString("owner_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.OwnerID))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -386,5 +474,77 @@ func (_m *WorkflowApplication) appendNamedExecutions(name string, edges ...*Work
 	}
 }
 
+// NamedSchedules returns the Schedules named value or an error if the edge was not
+// loaded in eager-loading with this name.
+func (_m *WorkflowApplication) NamedSchedules(name string) ([]*WorkflowSchedule, error) {
+	if _m.Edges.namedSchedules == nil {
+		return nil, &NotLoadedError{edge: name}
+	}
+	nodes, ok := _m.Edges.namedSchedules[name]
+	if !ok {
+		return nil, &NotLoadedError{edge: name}
+	}
+	return nodes, nil
+}
+
+func (_m *WorkflowApplication) appendNamedSchedules(name string, edges ...*WorkflowSchedule) {
+	if _m.Edges.namedSchedules == nil {
+		_m.Edges.namedSchedules = make(map[string][]*WorkflowSchedule)
+	}
+	if len(edges) == 0 {
+		_m.Edges.namedSchedules[name] = []*WorkflowSchedule{}
+	} else {
+		_m.Edges.namedSchedules[name] = append(_m.Edges.namedSchedules[name], edges...)
+	}
+}
+
+// NamedSecrets returns the Secrets named value or an error if the edge was not
+// loaded in eager-loading with this name.
+func (_m *WorkflowApplication) NamedSecrets(name string) ([]*WorkflowSecret, error) {
+	if _m.Edges.namedSecrets == nil {
+		return nil, &NotLoadedError{edge: name}
+	}
+	nodes, ok := _m.Edges.namedSecrets[name]
+	if !ok {
+		return nil, &NotLoadedError{edge: name}
+	}
+	return nodes, nil
+}
+
+func (_m *WorkflowApplication) appendNamedSecrets(name string, edges ...*WorkflowSecret) {
+	if _m.Edges.namedSecrets == nil {
+		_m.Edges.namedSecrets = make(map[string][]*WorkflowSecret)
+	}
+	if len(edges) == 0 {
+		_m.Edges.namedSecrets[name] = []*WorkflowSecret{}
+	} else {
+		_m.Edges.namedSecrets[name] = append(_m.Edges.namedSecrets[name], edges...)
+	}
+}
+
+// NamedShares returns the Shares named value or an error if the edge was not
+// loaded in eager-loading with this name.
+func (_m *WorkflowApplication) NamedShares(name string) ([]*WorkflowApplicationShare, error) {
+	if _m.Edges.namedShares == nil {
+		return nil, &NotLoadedError{edge: name}
+	}
+	nodes, ok := _m.Edges.namedShares[name]
+	if !ok {
+		return nil, &NotLoadedError{edge: name}
+	}
+	return nodes, nil
+}
+
+func (_m *WorkflowApplication) appendNamedShares(name string, edges ...*WorkflowApplicationShare) {
+	if _m.Edges.namedShares == nil {
+		_m.Edges.namedShares = make(map[string][]*WorkflowApplicationShare)
+	}
+	if len(edges) == 0 {
+		_m.Edges.namedShares[name] = []*WorkflowApplicationShare{}
+	} else {
+		_m.Edges.namedShares[name] = append(_m.Edges.namedShares[name], edges...)
+	}
+}
+
 // WorkflowApplications is a parsable slice of WorkflowApplication.
 type WorkflowApplications []*WorkflowApplication
@@ -110,6 +110,16 @@ func Version(v uint) predicate.WorkflowApplication {
 	return predicate.WorkflowApplication(sql.FieldEQ(FieldVersion, v))
 }
 
+// IsTemplate applies equality check predicate on the "is_template" field. It's identical to IsTemplateEQ.
+func IsTemplate(v bool) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldEQ(FieldIsTemplate, v))
+}
+
+// OwnerID applies equality check predicate on the "owner_id" field. It's identical to OwnerIDEQ.
+func OwnerID(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldEQ(FieldOwnerID, v))
+}
+
 // CreateTimeEQ applies the EQ predicate on the "create_time" field.
 func CreateTimeEQ(v time.Time) predicate.WorkflowApplication {
 	return predicate.WorkflowApplication(sql.FieldEQ(FieldCreateTime, v))
@@ -725,6 +735,76 @@ func ViewportConfigNotNil() predicate.WorkflowApplication {
 	return predicate.WorkflowApplication(sql.FieldNotNull(FieldViewportConfig))
 }
 
+// InputSchemaIsNil applies the IsNil predicate on the "input_schema" field.
+func InputSchemaIsNil() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldIsNull(FieldInputSchema))
+}
+
+// InputSchemaNotNil applies the NotNil predicate on the "input_schema" field.
+func InputSchemaNotNil() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldNotNull(FieldInputSchema))
+}
+
+// IsTemplateEQ applies the EQ predicate on the "is_template" field.
+func IsTemplateEQ(v bool) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldEQ(FieldIsTemplate, v))
+}
+
+// IsTemplateNEQ applies the NEQ predicate on the "is_template" field.
+func IsTemplateNEQ(v bool) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldNEQ(FieldIsTemplate, v))
+}
+
+// OwnerIDEQ applies the EQ predicate on the "owner_id" field.
+func OwnerIDEQ(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldEQ(FieldOwnerID, v))
+}
+
+// OwnerIDNEQ applies the NEQ predicate on the "owner_id" field.
+func OwnerIDNEQ(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldNEQ(FieldOwnerID, v))
+}
+
+// OwnerIDIn applies the In predicate on the "owner_id" field.
+func OwnerIDIn(vs ...uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDNotIn applies the NotIn predicate on the "owner_id" field.
+func OwnerIDNotIn(vs ...uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldNotIn(FieldOwnerID, vs...))
+}
+
+// OwnerIDGT applies the GT predicate on the "owner_id" field.
+func OwnerIDGT(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldGT(FieldOwnerID, v))
+}
+
+// OwnerIDGTE applies the GTE predicate on the "owner_id" field.
+func OwnerIDGTE(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldGTE(FieldOwnerID, v))
+}
+
+// OwnerIDLT applies the LT predicate on the "owner_id" field.
+func OwnerIDLT(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldLT(FieldOwnerID, v))
+}
+
+// OwnerIDLTE applies the LTE predicate on the "owner_id" field.
+func OwnerIDLTE(v uint64) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldLTE(FieldOwnerID, v))
+}
+
+// OwnerIDIsNil applies the IsNil predicate on the "owner_id" field.
+func OwnerIDIsNil() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldIsNull(FieldOwnerID))
+}
+
+// OwnerIDNotNil applies the NotNil predicate on the "owner_id" field.
+func OwnerIDNotNil() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(sql.FieldNotNull(FieldOwnerID))
+}
+
 // HasNodes applies the HasEdge predicate on the "nodes" edge.
 func HasNodes() predicate.WorkflowApplication {
 	return predicate.WorkflowApplication(func(s *sql.Selector) {
@@ -794,6 +874,75 @@ func HasExecutionsWith(preds ...predicate.WorkflowExecution) predicate.WorkflowA
 	})
 }
 
+// HasSchedules applies the HasEdge predicate on the "schedules" edge.
+func HasSchedules() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SchedulesTable, SchedulesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSchedulesWith applies the HasEdge predicate on the "schedules" edge with a given conditions (other predicates).
+func HasSchedulesWith(preds ...predicate.WorkflowSchedule) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := newSchedulesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasSecrets applies the HasEdge predicate on the "secrets" edge.
+func HasSecrets() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SecretsTable, SecretsColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSecretsWith applies the HasEdge predicate on the "secrets" edge with a given conditions (other predicates).
+func HasSecretsWith(preds ...predicate.WorkflowSecret) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := newSecretsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasShares applies the HasEdge predicate on the "shares" edge.
+func HasShares() predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, SharesTable, SharesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasSharesWith applies the HasEdge predicate on the "shares" edge with a given conditions (other predicates).
+func HasSharesWith(preds ...predicate.WorkflowApplicationShare) predicate.WorkflowApplication {
+	return predicate.WorkflowApplication(func(s *sql.Selector) {
+		step := newSharesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.WorkflowApplication) predicate.WorkflowApplication {
 	return predicate.WorkflowApplication(sql.AndPredicates(predicates...))
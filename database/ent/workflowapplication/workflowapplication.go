@@ -44,12 +44,24 @@ const (
 	FieldStatus = "status"
 	// FieldViewportConfig holds the string denoting the viewport_config field in the database.
 	FieldViewportConfig = "viewport_config"
+	// FieldInputSchema holds the string denoting the input_schema field in the database.
+	FieldInputSchema = "input_schema"
+	// FieldIsTemplate holds the string denoting the is_template field in the database.
+	FieldIsTemplate = "is_template"
+	// FieldOwnerID holds the string denoting the owner_id field in the database.
+	FieldOwnerID = "owner_id"
 	// EdgeNodes holds the string denoting the nodes edge name in mutations.
 	EdgeNodes = "nodes"
 	// EdgeEdges holds the string denoting the edges edge name in mutations.
 	EdgeEdges = "edges"
 	// EdgeExecutions holds the string denoting the executions edge name in mutations.
 	EdgeExecutions = "executions"
+	// EdgeSchedules holds the string denoting the schedules edge name in mutations.
+	EdgeSchedules = "schedules"
+	// EdgeSecrets holds the string denoting the secrets edge name in mutations.
+	EdgeSecrets = "secrets"
+	// EdgeShares holds the string denoting the shares edge name in mutations.
+	EdgeShares = "shares"
 	// Table holds the table name of the workflowapplication in the database.
 	Table = "workflow_applications"
 	// NodesTable is the table that holds the nodes relation/edge.
@@ -73,6 +85,27 @@ const (
 	ExecutionsInverseTable = "workflow_executions"
 	// ExecutionsColumn is the table column denoting the executions relation/edge.
 	ExecutionsColumn = "application_id"
+	// SchedulesTable is the table that holds the schedules relation/edge.
+	SchedulesTable = "workflow_schedules"
+	// SchedulesInverseTable is the table name for the WorkflowSchedule entity.
+	// It exists in this package in order to avoid circular dependency with the "workflowschedule" package.
+	SchedulesInverseTable = "workflow_schedules"
+	// SchedulesColumn is the table column denoting the schedules relation/edge.
+	SchedulesColumn = "application_id"
+	// SecretsTable is the table that holds the secrets relation/edge.
+	SecretsTable = "workflow_secrets"
+	// SecretsInverseTable is the table name for the WorkflowSecret entity.
+	// It exists in this package in order to avoid circular dependency with the "workflowsecret" package.
+	SecretsInverseTable = "workflow_secrets"
+	// SecretsColumn is the table column denoting the secrets relation/edge.
+	SecretsColumn = "application_id"
+	// SharesTable is the table that holds the shares relation/edge.
+	SharesTable = "workflow_application_shares"
+	// SharesInverseTable is the table name for the WorkflowApplicationShare entity.
+	// It exists in this package in order to avoid circular dependency with the "workflowapplicationshare" package.
+	SharesInverseTable = "workflow_application_shares"
+	// SharesColumn is the table column denoting the shares relation/edge.
+	SharesColumn = "application_id"
 )
 
 // Columns holds all SQL columns for workflowapplication fields.
@@ -92,6 +125,9 @@ var Columns = []string{
 	FieldVersion,
 	FieldStatus,
 	FieldViewportConfig,
+	FieldInputSchema,
+	FieldIsTemplate,
+	FieldOwnerID,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -122,6 +158,8 @@ var (
 	NameValidator func(string) error
 	// DefaultVersion holds the default value on creation for the "version" field.
 	DefaultVersion uint
+	// DefaultIsTemplate holds the default value on creation for the "is_template" field.
+	DefaultIsTemplate bool
 )
 
 // Status defines the type for the "status" enum field.
@@ -219,6 +257,16 @@ func ByStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStatus, opts...).ToFunc()
 }
 
+// ByIsTemplate orders the results by the is_template field.
+func ByIsTemplate(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldIsTemplate, opts...).ToFunc()
+}
+
+// ByOwnerID orders the results by the owner_id field.
+func ByOwnerID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOwnerID, opts...).ToFunc()
+}
+
 // ByNodesCount orders the results by nodes count.
 func ByNodesCount(opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -260,6 +308,48 @@ func ByExecutions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newExecutionsStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// BySchedulesCount orders the results by schedules count.
+func BySchedulesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSchedulesStep(), opts...)
+	}
+}
+
+// BySchedules orders the results by schedules terms.
+func BySchedules(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSchedulesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// BySecretsCount orders the results by secrets count.
+func BySecretsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSecretsStep(), opts...)
+	}
+}
+
+// BySecrets orders the results by secrets terms.
+func BySecrets(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSecretsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// BySharesCount orders the results by shares count.
+func BySharesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newSharesStep(), opts...)
+	}
+}
+
+// ByShares orders the results by shares terms.
+func ByShares(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newSharesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
 func newNodesStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -281,3 +371,24 @@ func newExecutionsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, ExecutionsTable, ExecutionsColumn),
 	)
 }
+func newSchedulesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SchedulesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SchedulesTable, SchedulesColumn),
+	)
+}
+func newSecretsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SecretsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SecretsTable, SecretsColumn),
+	)
+}
+func newSharesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(SharesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, SharesTable, SharesColumn),
+	)
+}
@@ -7,9 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"time"
 
 	"entgo.io/ent/dialect/sql/sqlgraph"
@@ -187,6 +190,40 @@ func (_c *WorkflowApplicationCreate) SetViewportConfig(v map[string]interface{})
 	return _c
 }
 
+// SetInputSchema sets the "input_schema" field.
+func (_c *WorkflowApplicationCreate) SetInputSchema(v map[string]interface{}) *WorkflowApplicationCreate {
+	_c.mutation.SetInputSchema(v)
+	return _c
+}
+
+// SetIsTemplate sets the "is_template" field.
+func (_c *WorkflowApplicationCreate) SetIsTemplate(v bool) *WorkflowApplicationCreate {
+	_c.mutation.SetIsTemplate(v)
+	return _c
+}
+
+// SetNillableIsTemplate sets the "is_template" field if the given value is not nil.
+func (_c *WorkflowApplicationCreate) SetNillableIsTemplate(v *bool) *WorkflowApplicationCreate {
+	if v != nil {
+		_c.SetIsTemplate(*v)
+	}
+	return _c
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (_c *WorkflowApplicationCreate) SetOwnerID(v uint64) *WorkflowApplicationCreate {
+	_c.mutation.SetOwnerID(v)
+	return _c
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_c *WorkflowApplicationCreate) SetNillableOwnerID(v *uint64) *WorkflowApplicationCreate {
+	if v != nil {
+		_c.SetOwnerID(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *WorkflowApplicationCreate) SetID(v uint64) *WorkflowApplicationCreate {
 	_c.mutation.SetID(v)
@@ -238,6 +275,51 @@ func (_c *WorkflowApplicationCreate) AddExecutions(v ...*WorkflowExecution) *Wor
 	return _c.AddExecutionIDs(ids...)
 }
 
+// AddScheduleIDs adds the "schedules" edge to the WorkflowSchedule entity by IDs.
+func (_c *WorkflowApplicationCreate) AddScheduleIDs(ids ...uint64) *WorkflowApplicationCreate {
+	_c.mutation.AddScheduleIDs(ids...)
+	return _c
+}
+
+// AddSchedules adds the "schedules" edges to the WorkflowSchedule entity.
+func (_c *WorkflowApplicationCreate) AddSchedules(v ...*WorkflowSchedule) *WorkflowApplicationCreate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddScheduleIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the WorkflowSecret entity by IDs.
+func (_c *WorkflowApplicationCreate) AddSecretIDs(ids ...uint64) *WorkflowApplicationCreate {
+	_c.mutation.AddSecretIDs(ids...)
+	return _c
+}
+
+// AddSecrets adds the "secrets" edges to the WorkflowSecret entity.
+func (_c *WorkflowApplicationCreate) AddSecrets(v ...*WorkflowSecret) *WorkflowApplicationCreate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddSecretIDs(ids...)
+}
+
+// AddShareIDs adds the "shares" edge to the WorkflowApplicationShare entity by IDs.
+func (_c *WorkflowApplicationCreate) AddShareIDs(ids ...uint64) *WorkflowApplicationCreate {
+	_c.mutation.AddShareIDs(ids...)
+	return _c
+}
+
+// AddShares adds the "shares" edges to the WorkflowApplicationShare entity.
+func (_c *WorkflowApplicationCreate) AddShares(v ...*WorkflowApplicationShare) *WorkflowApplicationCreate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _c.AddShareIDs(ids...)
+}
+
 // Mutation returns the WorkflowApplicationMutation object of the builder.
 func (_c *WorkflowApplicationCreate) Mutation() *WorkflowApplicationMutation {
 	return _c.mutation
@@ -297,6 +379,10 @@ func (_c *WorkflowApplicationCreate) defaults() error {
 		v := workflowapplication.DefaultStatus
 		_c.mutation.SetStatus(v)
 	}
+	if _, ok := _c.mutation.IsTemplate(); !ok {
+		v := workflowapplication.DefaultIsTemplate
+		_c.mutation.SetIsTemplate(v)
+	}
 	return nil
 }
 
@@ -330,6 +416,9 @@ func (_c *WorkflowApplicationCreate) check() error {
 			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "WorkflowApplication.status": %w`, err)}
 		}
 	}
+	if _, ok := _c.mutation.IsTemplate(); !ok {
+		return &ValidationError{Name: "is_template", err: errors.New(`ent: missing required field "WorkflowApplication.is_template"`)}
+	}
 	return nil
 }
 
@@ -418,6 +507,18 @@ func (_c *WorkflowApplicationCreate) createSpec() (*WorkflowApplication, *sqlgra
 		_spec.SetField(workflowapplication.FieldViewportConfig, field.TypeJSON, value)
 		_node.ViewportConfig = value
 	}
+	if value, ok := _c.mutation.InputSchema(); ok {
+		_spec.SetField(workflowapplication.FieldInputSchema, field.TypeJSON, value)
+		_node.InputSchema = value
+	}
+	if value, ok := _c.mutation.IsTemplate(); ok {
+		_spec.SetField(workflowapplication.FieldIsTemplate, field.TypeBool, value)
+		_node.IsTemplate = value
+	}
+	if value, ok := _c.mutation.OwnerID(); ok {
+		_spec.SetField(workflowapplication.FieldOwnerID, field.TypeUint64, value)
+		_node.OwnerID = value
+	}
 	if nodes := _c.mutation.NodesIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -466,6 +567,54 @@ func (_c *WorkflowApplicationCreate) createSpec() (*WorkflowApplication, *sqlgra
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := _c.mutation.SchedulesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := _c.mutation.SharesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"go-backend/database/ent/predicate"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"math"
 
 	"entgo.io/ent"
@@ -30,10 +33,16 @@ type WorkflowApplicationQuery struct {
 	withNodes           *WorkflowNodeQuery
 	withEdges           *WorkflowEdgeQuery
 	withExecutions      *WorkflowExecutionQuery
+	withSchedules       *WorkflowScheduleQuery
+	withSecrets         *WorkflowSecretQuery
+	withShares          *WorkflowApplicationShareQuery
 	modifiers           []func(*sql.Selector)
 	withNamedNodes      map[string]*WorkflowNodeQuery
 	withNamedEdges      map[string]*WorkflowEdgeQuery
 	withNamedExecutions map[string]*WorkflowExecutionQuery
+	withNamedSchedules  map[string]*WorkflowScheduleQuery
+	withNamedSecrets    map[string]*WorkflowSecretQuery
+	withNamedShares     map[string]*WorkflowApplicationShareQuery
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -136,6 +145,72 @@ func (_q *WorkflowApplicationQuery) QueryExecutions() *WorkflowExecutionQuery {
 	return query
 }
 
+// QuerySchedules chains the current query on the "schedules" edge.
+func (_q *WorkflowApplicationQuery) QuerySchedules() *WorkflowScheduleQuery {
+	query := (&WorkflowScheduleClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, selector),
+			sqlgraph.To(workflowschedule.Table, workflowschedule.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SchedulesTable, workflowapplication.SchedulesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QuerySecrets chains the current query on the "secrets" edge.
+func (_q *WorkflowApplicationQuery) QuerySecrets() *WorkflowSecretQuery {
+	query := (&WorkflowSecretClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, selector),
+			sqlgraph.To(workflowsecret.Table, workflowsecret.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SecretsTable, workflowapplication.SecretsColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryShares chains the current query on the "shares" edge.
+func (_q *WorkflowApplicationQuery) QueryShares() *WorkflowApplicationShareQuery {
+	query := (&WorkflowApplicationShareClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplication.Table, workflowapplication.FieldID, selector),
+			sqlgraph.To(workflowapplicationshare.Table, workflowapplicationshare.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, workflowapplication.SharesTable, workflowapplication.SharesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first WorkflowApplication entity from the query.
 // Returns a *NotFoundError when no WorkflowApplication was found.
 func (_q *WorkflowApplicationQuery) First(ctx context.Context) (*WorkflowApplication, error) {
@@ -331,6 +406,9 @@ func (_q *WorkflowApplicationQuery) Clone() *WorkflowApplicationQuery {
 		withNodes:      _q.withNodes.Clone(),
 		withEdges:      _q.withEdges.Clone(),
 		withExecutions: _q.withExecutions.Clone(),
+		withSchedules:  _q.withSchedules.Clone(),
+		withSecrets:    _q.withSecrets.Clone(),
+		withShares:     _q.withShares.Clone(),
 		// clone intermediate query.
 		sql:  _q.sql.Clone(),
 		path: _q.path,
@@ -370,6 +448,39 @@ func (_q *WorkflowApplicationQuery) WithExecutions(opts ...func(*WorkflowExecuti
 	return _q
 }
 
+// WithSchedules tells the query-builder to eager-load the nodes that are connected to
+// the "schedules" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithSchedules(opts ...func(*WorkflowScheduleQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowScheduleClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withSchedules = query
+	return _q
+}
+
+// WithSecrets tells the query-builder to eager-load the nodes that are connected to
+// the "secrets" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithSecrets(opts ...func(*WorkflowSecretQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowSecretClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withSecrets = query
+	return _q
+}
+
+// WithShares tells the query-builder to eager-load the nodes that are connected to
+// the "shares" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithShares(opts ...func(*WorkflowApplicationShareQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationShareClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withShares = query
+	return _q
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -448,10 +559,13 @@ func (_q *WorkflowApplicationQuery) sqlAll(ctx context.Context, hooks ...queryHo
 	var (
 		nodes       = []*WorkflowApplication{}
 		_spec       = _q.querySpec()
-		loadedTypes = [3]bool{
+		loadedTypes = [6]bool{
 			_q.withNodes != nil,
 			_q.withEdges != nil,
 			_q.withExecutions != nil,
+			_q.withSchedules != nil,
+			_q.withSecrets != nil,
+			_q.withShares != nil,
 		}
 	)
 	_spec.ScanValues = func(columns []string) ([]any, error) {
@@ -496,6 +610,27 @@ func (_q *WorkflowApplicationQuery) sqlAll(ctx context.Context, hooks ...queryHo
 			return nil, err
 		}
 	}
+	if query := _q.withSchedules; query != nil {
+		if err := _q.loadSchedules(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.Edges.Schedules = []*WorkflowSchedule{} },
+			func(n *WorkflowApplication, e *WorkflowSchedule) { n.Edges.Schedules = append(n.Edges.Schedules, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withSecrets; query != nil {
+		if err := _q.loadSecrets(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.Edges.Secrets = []*WorkflowSecret{} },
+			func(n *WorkflowApplication, e *WorkflowSecret) { n.Edges.Secrets = append(n.Edges.Secrets, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := _q.withShares; query != nil {
+		if err := _q.loadShares(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.Edges.Shares = []*WorkflowApplicationShare{} },
+			func(n *WorkflowApplication, e *WorkflowApplicationShare) { n.Edges.Shares = append(n.Edges.Shares, e) }); err != nil {
+			return nil, err
+		}
+	}
 	for name, query := range _q.withNamedNodes {
 		if err := _q.loadNodes(ctx, query, nodes,
 			func(n *WorkflowApplication) { n.appendNamedNodes(name) },
@@ -517,6 +652,27 @@ func (_q *WorkflowApplicationQuery) sqlAll(ctx context.Context, hooks ...queryHo
 			return nil, err
 		}
 	}
+	for name, query := range _q.withNamedSchedules {
+		if err := _q.loadSchedules(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.appendNamedSchedules(name) },
+			func(n *WorkflowApplication, e *WorkflowSchedule) { n.appendNamedSchedules(name, e) }); err != nil {
+			return nil, err
+		}
+	}
+	for name, query := range _q.withNamedSecrets {
+		if err := _q.loadSecrets(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.appendNamedSecrets(name) },
+			func(n *WorkflowApplication, e *WorkflowSecret) { n.appendNamedSecrets(name, e) }); err != nil {
+			return nil, err
+		}
+	}
+	for name, query := range _q.withNamedShares {
+		if err := _q.loadShares(ctx, query, nodes,
+			func(n *WorkflowApplication) { n.appendNamedShares(name) },
+			func(n *WorkflowApplication, e *WorkflowApplicationShare) { n.appendNamedShares(name, e) }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -610,6 +766,96 @@ func (_q *WorkflowApplicationQuery) loadExecutions(ctx context.Context, query *W
 	}
 	return nil
 }
+func (_q *WorkflowApplicationQuery) loadSchedules(ctx context.Context, query *WorkflowScheduleQuery, nodes []*WorkflowApplication, init func(*WorkflowApplication), assign func(*WorkflowApplication, *WorkflowSchedule)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uint64]*WorkflowApplication)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(workflowschedule.FieldApplicationID)
+	}
+	query.Where(predicate.WorkflowSchedule(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(workflowapplication.SchedulesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.ApplicationID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "application_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *WorkflowApplicationQuery) loadSecrets(ctx context.Context, query *WorkflowSecretQuery, nodes []*WorkflowApplication, init func(*WorkflowApplication), assign func(*WorkflowApplication, *WorkflowSecret)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uint64]*WorkflowApplication)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(workflowsecret.FieldApplicationID)
+	}
+	query.Where(predicate.WorkflowSecret(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(workflowapplication.SecretsColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.ApplicationID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "application_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (_q *WorkflowApplicationQuery) loadShares(ctx context.Context, query *WorkflowApplicationShareQuery, nodes []*WorkflowApplication, init func(*WorkflowApplication), assign func(*WorkflowApplication, *WorkflowApplicationShare)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[uint64]*WorkflowApplication)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(workflowapplicationshare.FieldApplicationID)
+	}
+	query.Where(predicate.WorkflowApplicationShare(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(workflowapplication.SharesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.ApplicationID
+		node, ok := nodeids[fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "application_id" returned %v for node %v`, fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
 
 func (_q *WorkflowApplicationQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := _q.querySpec()
@@ -766,6 +1012,48 @@ func (_q *WorkflowApplicationQuery) WithNamedExecutions(name string, opts ...fun
 	return _q
 }
 
+// WithNamedSchedules tells the query-builder to eager-load the nodes that are connected to the "schedules"
+// edge with the given name. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithNamedSchedules(name string, opts ...func(*WorkflowScheduleQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowScheduleClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	if _q.withNamedSchedules == nil {
+		_q.withNamedSchedules = make(map[string]*WorkflowScheduleQuery)
+	}
+	_q.withNamedSchedules[name] = query
+	return _q
+}
+
+// WithNamedSecrets tells the query-builder to eager-load the nodes that are connected to the "secrets"
+// edge with the given name. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithNamedSecrets(name string, opts ...func(*WorkflowSecretQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowSecretClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	if _q.withNamedSecrets == nil {
+		_q.withNamedSecrets = make(map[string]*WorkflowSecretQuery)
+	}
+	_q.withNamedSecrets[name] = query
+	return _q
+}
+
+// WithNamedShares tells the query-builder to eager-load the nodes that are connected to the "shares"
+// edge with the given name. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationQuery) WithNamedShares(name string, opts ...func(*WorkflowApplicationShareQuery)) *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationShareClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	if _q.withNamedShares == nil {
+		_q.withNamedShares = make(map[string]*WorkflowApplicationShareQuery)
+	}
+	_q.withNamedShares[name] = query
+	return _q
+}
+
 // WorkflowApplicationGroupBy is the group-by builder for WorkflowApplication entities.
 type WorkflowApplicationGroupBy struct {
 	selector
@@ -8,9 +8,12 @@ import (
 	"fmt"
 	"go-backend/database/ent/predicate"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
 	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"time"
 
 	"entgo.io/ent/dialect/sql"
@@ -272,6 +275,59 @@ func (_u *WorkflowApplicationUpdate) ClearViewportConfig() *WorkflowApplicationU
 	return _u
 }
 
+// SetInputSchema sets the "input_schema" field.
+func (_u *WorkflowApplicationUpdate) SetInputSchema(v map[string]interface{}) *WorkflowApplicationUpdate {
+	_u.mutation.SetInputSchema(v)
+	return _u
+}
+
+// ClearInputSchema clears the value of the "input_schema" field.
+func (_u *WorkflowApplicationUpdate) ClearInputSchema() *WorkflowApplicationUpdate {
+	_u.mutation.ClearInputSchema()
+	return _u
+}
+
+// SetIsTemplate sets the "is_template" field.
+func (_u *WorkflowApplicationUpdate) SetIsTemplate(v bool) *WorkflowApplicationUpdate {
+	_u.mutation.SetIsTemplate(v)
+	return _u
+}
+
+// SetNillableIsTemplate sets the "is_template" field if the given value is not nil.
+func (_u *WorkflowApplicationUpdate) SetNillableIsTemplate(v *bool) *WorkflowApplicationUpdate {
+	if v != nil {
+		_u.SetIsTemplate(*v)
+	}
+	return _u
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (_u *WorkflowApplicationUpdate) SetOwnerID(v uint64) *WorkflowApplicationUpdate {
+	_u.mutation.ResetOwnerID()
+	_u.mutation.SetOwnerID(v)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_u *WorkflowApplicationUpdate) SetNillableOwnerID(v *uint64) *WorkflowApplicationUpdate {
+	if v != nil {
+		_u.SetOwnerID(*v)
+	}
+	return _u
+}
+
+// AddOwnerID adds value to the "owner_id" field.
+func (_u *WorkflowApplicationUpdate) AddOwnerID(v int64) *WorkflowApplicationUpdate {
+	_u.mutation.AddOwnerID(v)
+	return _u
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (_u *WorkflowApplicationUpdate) ClearOwnerID() *WorkflowApplicationUpdate {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
 // AddNodeIDs adds the "nodes" edge to the WorkflowNode entity by IDs.
 func (_u *WorkflowApplicationUpdate) AddNodeIDs(ids ...uint64) *WorkflowApplicationUpdate {
 	_u.mutation.AddNodeIDs(ids...)
@@ -317,6 +373,51 @@ func (_u *WorkflowApplicationUpdate) AddExecutions(v ...*WorkflowExecution) *Wor
 	return _u.AddExecutionIDs(ids...)
 }
 
+// AddScheduleIDs adds the "schedules" edge to the WorkflowSchedule entity by IDs.
+func (_u *WorkflowApplicationUpdate) AddScheduleIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.AddScheduleIDs(ids...)
+	return _u
+}
+
+// AddSchedules adds the "schedules" edges to the WorkflowSchedule entity.
+func (_u *WorkflowApplicationUpdate) AddSchedules(v ...*WorkflowSchedule) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddScheduleIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the WorkflowSecret entity by IDs.
+func (_u *WorkflowApplicationUpdate) AddSecretIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.AddSecretIDs(ids...)
+	return _u
+}
+
+// AddSecrets adds the "secrets" edges to the WorkflowSecret entity.
+func (_u *WorkflowApplicationUpdate) AddSecrets(v ...*WorkflowSecret) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSecretIDs(ids...)
+}
+
+// AddShareIDs adds the "shares" edge to the WorkflowApplicationShare entity by IDs.
+func (_u *WorkflowApplicationUpdate) AddShareIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.AddShareIDs(ids...)
+	return _u
+}
+
+// AddShares adds the "shares" edges to the WorkflowApplicationShare entity.
+func (_u *WorkflowApplicationUpdate) AddShares(v ...*WorkflowApplicationShare) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddShareIDs(ids...)
+}
+
 // Mutation returns the WorkflowApplicationMutation object of the builder.
 func (_u *WorkflowApplicationUpdate) Mutation() *WorkflowApplicationMutation {
 	return _u.mutation
@@ -385,6 +486,69 @@ func (_u *WorkflowApplicationUpdate) RemoveExecutions(v ...*WorkflowExecution) *
 	return _u.RemoveExecutionIDs(ids...)
 }
 
+// ClearSchedules clears all "schedules" edges to the WorkflowSchedule entity.
+func (_u *WorkflowApplicationUpdate) ClearSchedules() *WorkflowApplicationUpdate {
+	_u.mutation.ClearSchedules()
+	return _u
+}
+
+// RemoveScheduleIDs removes the "schedules" edge to WorkflowSchedule entities by IDs.
+func (_u *WorkflowApplicationUpdate) RemoveScheduleIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.RemoveScheduleIDs(ids...)
+	return _u
+}
+
+// RemoveSchedules removes "schedules" edges to WorkflowSchedule entities.
+func (_u *WorkflowApplicationUpdate) RemoveSchedules(v ...*WorkflowSchedule) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveScheduleIDs(ids...)
+}
+
+// ClearSecrets clears all "secrets" edges to the WorkflowSecret entity.
+func (_u *WorkflowApplicationUpdate) ClearSecrets() *WorkflowApplicationUpdate {
+	_u.mutation.ClearSecrets()
+	return _u
+}
+
+// RemoveSecretIDs removes the "secrets" edge to WorkflowSecret entities by IDs.
+func (_u *WorkflowApplicationUpdate) RemoveSecretIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.RemoveSecretIDs(ids...)
+	return _u
+}
+
+// RemoveSecrets removes "secrets" edges to WorkflowSecret entities.
+func (_u *WorkflowApplicationUpdate) RemoveSecrets(v ...*WorkflowSecret) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSecretIDs(ids...)
+}
+
+// ClearShares clears all "shares" edges to the WorkflowApplicationShare entity.
+func (_u *WorkflowApplicationUpdate) ClearShares() *WorkflowApplicationUpdate {
+	_u.mutation.ClearShares()
+	return _u
+}
+
+// RemoveShareIDs removes the "shares" edge to WorkflowApplicationShare entities by IDs.
+func (_u *WorkflowApplicationUpdate) RemoveShareIDs(ids ...uint64) *WorkflowApplicationUpdate {
+	_u.mutation.RemoveShareIDs(ids...)
+	return _u
+}
+
+// RemoveShares removes "shares" edges to WorkflowApplicationShare entities.
+func (_u *WorkflowApplicationUpdate) RemoveShares(v ...*WorkflowApplicationShare) *WorkflowApplicationUpdate {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveShareIDs(ids...)
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (_u *WorkflowApplicationUpdate) Save(ctx context.Context) (int, error) {
 	if err := _u.defaults(); err != nil {
@@ -532,6 +696,24 @@ func (_u *WorkflowApplicationUpdate) sqlSave(ctx context.Context) (_node int, er
 	if _u.mutation.ViewportConfigCleared() {
 		_spec.ClearField(workflowapplication.FieldViewportConfig, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.InputSchema(); ok {
+		_spec.SetField(workflowapplication.FieldInputSchema, field.TypeJSON, value)
+	}
+	if _u.mutation.InputSchemaCleared() {
+		_spec.ClearField(workflowapplication.FieldInputSchema, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IsTemplate(); ok {
+		_spec.SetField(workflowapplication.FieldIsTemplate, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.OwnerID(); ok {
+		_spec.SetField(workflowapplication.FieldOwnerID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedOwnerID(); ok {
+		_spec.AddField(workflowapplication.FieldOwnerID, field.TypeUint64, value)
+	}
+	if _u.mutation.OwnerIDCleared() {
+		_spec.ClearField(workflowapplication.FieldOwnerID, field.TypeUint64)
+	}
 	if _u.mutation.NodesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -667,6 +849,141 @@ func (_u *WorkflowApplicationUpdate) sqlSave(ctx context.Context) (_node int, er
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.SchedulesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSchedulesIDs(); len(nodes) > 0 && !_u.mutation.SchedulesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SchedulesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretsIDs(); len(nodes) > 0 && !_u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSharesIDs(); len(nodes) > 0 && !_u.mutation.SharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SharesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{workflowapplication.Label}
@@ -928,6 +1245,59 @@ func (_u *WorkflowApplicationUpdateOne) ClearViewportConfig() *WorkflowApplicati
 	return _u
 }
 
+// SetInputSchema sets the "input_schema" field.
+func (_u *WorkflowApplicationUpdateOne) SetInputSchema(v map[string]interface{}) *WorkflowApplicationUpdateOne {
+	_u.mutation.SetInputSchema(v)
+	return _u
+}
+
+// ClearInputSchema clears the value of the "input_schema" field.
+func (_u *WorkflowApplicationUpdateOne) ClearInputSchema() *WorkflowApplicationUpdateOne {
+	_u.mutation.ClearInputSchema()
+	return _u
+}
+
+// SetIsTemplate sets the "is_template" field.
+func (_u *WorkflowApplicationUpdateOne) SetIsTemplate(v bool) *WorkflowApplicationUpdateOne {
+	_u.mutation.SetIsTemplate(v)
+	return _u
+}
+
+// SetNillableIsTemplate sets the "is_template" field if the given value is not nil.
+func (_u *WorkflowApplicationUpdateOne) SetNillableIsTemplate(v *bool) *WorkflowApplicationUpdateOne {
+	if v != nil {
+		_u.SetIsTemplate(*v)
+	}
+	return _u
+}
+
+// SetOwnerID sets the "owner_id" field.
+func (_u *WorkflowApplicationUpdateOne) SetOwnerID(v uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.ResetOwnerID()
+	_u.mutation.SetOwnerID(v)
+	return _u
+}
+
+// SetNillableOwnerID sets the "owner_id" field if the given value is not nil.
+func (_u *WorkflowApplicationUpdateOne) SetNillableOwnerID(v *uint64) *WorkflowApplicationUpdateOne {
+	if v != nil {
+		_u.SetOwnerID(*v)
+	}
+	return _u
+}
+
+// AddOwnerID adds value to the "owner_id" field.
+func (_u *WorkflowApplicationUpdateOne) AddOwnerID(v int64) *WorkflowApplicationUpdateOne {
+	_u.mutation.AddOwnerID(v)
+	return _u
+}
+
+// ClearOwnerID clears the value of the "owner_id" field.
+func (_u *WorkflowApplicationUpdateOne) ClearOwnerID() *WorkflowApplicationUpdateOne {
+	_u.mutation.ClearOwnerID()
+	return _u
+}
+
 // AddNodeIDs adds the "nodes" edge to the WorkflowNode entity by IDs.
 func (_u *WorkflowApplicationUpdateOne) AddNodeIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
 	_u.mutation.AddNodeIDs(ids...)
@@ -973,6 +1343,51 @@ func (_u *WorkflowApplicationUpdateOne) AddExecutions(v ...*WorkflowExecution) *
 	return _u.AddExecutionIDs(ids...)
 }
 
+// AddScheduleIDs adds the "schedules" edge to the WorkflowSchedule entity by IDs.
+func (_u *WorkflowApplicationUpdateOne) AddScheduleIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.AddScheduleIDs(ids...)
+	return _u
+}
+
+// AddSchedules adds the "schedules" edges to the WorkflowSchedule entity.
+func (_u *WorkflowApplicationUpdateOne) AddSchedules(v ...*WorkflowSchedule) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddScheduleIDs(ids...)
+}
+
+// AddSecretIDs adds the "secrets" edge to the WorkflowSecret entity by IDs.
+func (_u *WorkflowApplicationUpdateOne) AddSecretIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.AddSecretIDs(ids...)
+	return _u
+}
+
+// AddSecrets adds the "secrets" edges to the WorkflowSecret entity.
+func (_u *WorkflowApplicationUpdateOne) AddSecrets(v ...*WorkflowSecret) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddSecretIDs(ids...)
+}
+
+// AddShareIDs adds the "shares" edge to the WorkflowApplicationShare entity by IDs.
+func (_u *WorkflowApplicationUpdateOne) AddShareIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.AddShareIDs(ids...)
+	return _u
+}
+
+// AddShares adds the "shares" edges to the WorkflowApplicationShare entity.
+func (_u *WorkflowApplicationUpdateOne) AddShares(v ...*WorkflowApplicationShare) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.AddShareIDs(ids...)
+}
+
 // Mutation returns the WorkflowApplicationMutation object of the builder.
 func (_u *WorkflowApplicationUpdateOne) Mutation() *WorkflowApplicationMutation {
 	return _u.mutation
@@ -1041,6 +1456,69 @@ func (_u *WorkflowApplicationUpdateOne) RemoveExecutions(v ...*WorkflowExecution
 	return _u.RemoveExecutionIDs(ids...)
 }
 
+// ClearSchedules clears all "schedules" edges to the WorkflowSchedule entity.
+func (_u *WorkflowApplicationUpdateOne) ClearSchedules() *WorkflowApplicationUpdateOne {
+	_u.mutation.ClearSchedules()
+	return _u
+}
+
+// RemoveScheduleIDs removes the "schedules" edge to WorkflowSchedule entities by IDs.
+func (_u *WorkflowApplicationUpdateOne) RemoveScheduleIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.RemoveScheduleIDs(ids...)
+	return _u
+}
+
+// RemoveSchedules removes "schedules" edges to WorkflowSchedule entities.
+func (_u *WorkflowApplicationUpdateOne) RemoveSchedules(v ...*WorkflowSchedule) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveScheduleIDs(ids...)
+}
+
+// ClearSecrets clears all "secrets" edges to the WorkflowSecret entity.
+func (_u *WorkflowApplicationUpdateOne) ClearSecrets() *WorkflowApplicationUpdateOne {
+	_u.mutation.ClearSecrets()
+	return _u
+}
+
+// RemoveSecretIDs removes the "secrets" edge to WorkflowSecret entities by IDs.
+func (_u *WorkflowApplicationUpdateOne) RemoveSecretIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.RemoveSecretIDs(ids...)
+	return _u
+}
+
+// RemoveSecrets removes "secrets" edges to WorkflowSecret entities.
+func (_u *WorkflowApplicationUpdateOne) RemoveSecrets(v ...*WorkflowSecret) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveSecretIDs(ids...)
+}
+
+// ClearShares clears all "shares" edges to the WorkflowApplicationShare entity.
+func (_u *WorkflowApplicationUpdateOne) ClearShares() *WorkflowApplicationUpdateOne {
+	_u.mutation.ClearShares()
+	return _u
+}
+
+// RemoveShareIDs removes the "shares" edge to WorkflowApplicationShare entities by IDs.
+func (_u *WorkflowApplicationUpdateOne) RemoveShareIDs(ids ...uint64) *WorkflowApplicationUpdateOne {
+	_u.mutation.RemoveShareIDs(ids...)
+	return _u
+}
+
+// RemoveShares removes "shares" edges to WorkflowApplicationShare entities.
+func (_u *WorkflowApplicationUpdateOne) RemoveShares(v ...*WorkflowApplicationShare) *WorkflowApplicationUpdateOne {
+	ids := make([]uint64, len(v))
+	for i := range v {
+		ids[i] = v[i].ID
+	}
+	return _u.RemoveShareIDs(ids...)
+}
+
 // Where appends a list predicates to the WorkflowApplicationUpdate builder.
 func (_u *WorkflowApplicationUpdateOne) Where(ps ...predicate.WorkflowApplication) *WorkflowApplicationUpdateOne {
 	_u.mutation.Where(ps...)
@@ -1218,6 +1696,24 @@ func (_u *WorkflowApplicationUpdateOne) sqlSave(ctx context.Context) (_node *Wor
 	if _u.mutation.ViewportConfigCleared() {
 		_spec.ClearField(workflowapplication.FieldViewportConfig, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.InputSchema(); ok {
+		_spec.SetField(workflowapplication.FieldInputSchema, field.TypeJSON, value)
+	}
+	if _u.mutation.InputSchemaCleared() {
+		_spec.ClearField(workflowapplication.FieldInputSchema, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.IsTemplate(); ok {
+		_spec.SetField(workflowapplication.FieldIsTemplate, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.OwnerID(); ok {
+		_spec.SetField(workflowapplication.FieldOwnerID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedOwnerID(); ok {
+		_spec.AddField(workflowapplication.FieldOwnerID, field.TypeUint64, value)
+	}
+	if _u.mutation.OwnerIDCleared() {
+		_spec.ClearField(workflowapplication.FieldOwnerID, field.TypeUint64)
+	}
 	if _u.mutation.NodesCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.O2M,
@@ -1353,6 +1849,141 @@ func (_u *WorkflowApplicationUpdateOne) sqlSave(ctx context.Context) (_node *Wor
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if _u.mutation.SchedulesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSchedulesIDs(); len(nodes) > 0 && !_u.mutation.SchedulesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SchedulesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SchedulesTable,
+			Columns: []string{workflowapplication.SchedulesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSecretsIDs(); len(nodes) > 0 && !_u.mutation.SecretsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SecretsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SecretsTable,
+			Columns: []string{workflowapplication.SecretsColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _u.mutation.SharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.RemovedSharesIDs(); len(nodes) > 0 && !_u.mutation.SharesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.SharesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   workflowapplication.SharesTable,
+			Columns: []string{workflowapplication.SharesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &WorkflowApplication{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
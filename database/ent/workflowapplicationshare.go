@@ -0,0 +1,202 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// WorkflowApplicationShare is the model entity for the WorkflowApplicationShare schema.
+type WorkflowApplicationShare struct {
+	config `json:"-"`
+	// ID of the ent.
+	// 主键ID
+	ID uint64 `json:"id,omitempty"`
+	// 创建时间
+	CreateTime time.Time `json:"create_time,omitempty"`
+	// 创建人ID
+	CreateBy uint64 `json:"create_by,omitempty"`
+	// 更新时间
+	UpdateTime time.Time `json:"update_time,omitempty"`
+	// 更新人ID
+	UpdateBy uint64 `json:"update_by,omitempty"`
+	// 工作流应用ID
+	ApplicationID uint64 `json:"application_id,omitempty"`
+	// 被授权的用户ID
+	UserID uint64 `json:"user_id,omitempty"`
+	// 访问级别：read 只读，edit 可编辑
+	Access workflowapplicationshare.Access `json:"access,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WorkflowApplicationShareQuery when eager-loading is set.
+	Edges        WorkflowApplicationShareEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// WorkflowApplicationShareEdges holds the relations/edges for other nodes in the graph.
+type WorkflowApplicationShareEdges struct {
+	// Application holds the value of the application edge.
+	Application *WorkflowApplication `json:"application,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ApplicationOrErr returns the Application value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e WorkflowApplicationShareEdges) ApplicationOrErr() (*WorkflowApplication, error) {
+	if e.Application != nil {
+		return e.Application, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: workflowapplication.Label}
+	}
+	return nil, &NotLoadedError{edge: "application"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WorkflowApplicationShare) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case workflowapplicationshare.FieldID, workflowapplicationshare.FieldCreateBy, workflowapplicationshare.FieldUpdateBy, workflowapplicationshare.FieldApplicationID, workflowapplicationshare.FieldUserID:
+			values[i] = new(sql.NullInt64)
+		case workflowapplicationshare.FieldAccess:
+			values[i] = new(sql.NullString)
+		case workflowapplicationshare.FieldCreateTime, workflowapplicationshare.FieldUpdateTime:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WorkflowApplicationShare fields.
+func (_m *WorkflowApplicationShare) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case workflowapplicationshare.FieldID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = uint64(value.Int64)
+			}
+		case workflowapplicationshare.FieldCreateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field create_time", values[i])
+			} else if value.Valid {
+				_m.CreateTime = value.Time
+			}
+		case workflowapplicationshare.FieldCreateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field create_by", values[i])
+			} else if value.Valid {
+				_m.CreateBy = uint64(value.Int64)
+			}
+		case workflowapplicationshare.FieldUpdateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field update_time", values[i])
+			} else if value.Valid {
+				_m.UpdateTime = value.Time
+			}
+		case workflowapplicationshare.FieldUpdateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field update_by", values[i])
+			} else if value.Valid {
+				_m.UpdateBy = uint64(value.Int64)
+			}
+		case workflowapplicationshare.FieldApplicationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field application_id", values[i])
+			} else if value.Valid {
+				_m.ApplicationID = uint64(value.Int64)
+			}
+		case workflowapplicationshare.FieldUserID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field user_id", values[i])
+			} else if value.Valid {
+				_m.UserID = uint64(value.Int64)
+			}
+		case workflowapplicationshare.FieldAccess:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field access", values[i])
+			} else if value.Valid {
+				_m.Access = workflowapplicationshare.Access(value.String)
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the WorkflowApplicationShare.
+// This includes values selected through modifiers, order, etc.
+func (_m *WorkflowApplicationShare) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryApplication queries the "application" edge of the WorkflowApplicationShare entity.
+func (_m *WorkflowApplicationShare) QueryApplication() *WorkflowApplicationQuery {
+	return NewWorkflowApplicationShareClient(_m.config).QueryApplication(_m)
+}
+
+// Update returns a builder for updating this WorkflowApplicationShare.
+// Note that you need to call WorkflowApplicationShare.Unwrap() before calling this method if this WorkflowApplicationShare
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *WorkflowApplicationShare) Update() *WorkflowApplicationShareUpdateOne {
+	return NewWorkflowApplicationShareClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the WorkflowApplicationShare entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *WorkflowApplicationShare) Unwrap() *WorkflowApplicationShare {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WorkflowApplicationShare is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *WorkflowApplicationShare) String() string {
+	var builder strings.Builder
+	builder.WriteString("WorkflowApplicationShare(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("create_time=")
+	builder.WriteString(_m.CreateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("create_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreateBy))
+	builder.WriteString(", ")
+	builder.WriteString("update_time=")
+	builder.WriteString(_m.UpdateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("update_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdateBy))
+	builder.WriteString(", ")
+	builder.WriteString("application_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ApplicationID))
+	builder.WriteString(", ")
+	builder.WriteString("user_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UserID))
+	builder.WriteString(", ")
+	builder.WriteString("access=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Access))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WorkflowApplicationShares is a parsable slice of WorkflowApplicationShare.
+type WorkflowApplicationShares []*WorkflowApplicationShare
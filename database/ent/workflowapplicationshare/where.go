@@ -0,0 +1,384 @@
+// Code generated by ent, DO NOT EDIT.
+
+package workflowapplicationshare
+
+import (
+	"go-backend/database/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldID, id))
+}
+
+// CreateTime applies equality check predicate on the "create_time" field. It's identical to CreateTimeEQ.
+func CreateTime(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateBy applies equality check predicate on the "create_by" field. It's identical to CreateByEQ.
+func CreateBy(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// UpdateTime applies equality check predicate on the "update_time" field. It's identical to UpdateTimeEQ.
+func UpdateTime(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateBy applies equality check predicate on the "update_by" field. It's identical to UpdateByEQ.
+func UpdateBy(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// ApplicationID applies equality check predicate on the "application_id" field. It's identical to ApplicationIDEQ.
+func ApplicationID(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldApplicationID, v))
+}
+
+// UserID applies equality check predicate on the "user_id" field. It's identical to UserIDEQ.
+func UserID(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUserID, v))
+}
+
+// CreateTimeEQ applies the EQ predicate on the "create_time" field.
+func CreateTimeEQ(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateTimeNEQ applies the NEQ predicate on the "create_time" field.
+func CreateTimeNEQ(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldCreateTime, v))
+}
+
+// CreateTimeIn applies the In predicate on the "create_time" field.
+func CreateTimeIn(vs ...time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeNotIn applies the NotIn predicate on the "create_time" field.
+func CreateTimeNotIn(vs ...time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeGT applies the GT predicate on the "create_time" field.
+func CreateTimeGT(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldCreateTime, v))
+}
+
+// CreateTimeGTE applies the GTE predicate on the "create_time" field.
+func CreateTimeGTE(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldCreateTime, v))
+}
+
+// CreateTimeLT applies the LT predicate on the "create_time" field.
+func CreateTimeLT(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldCreateTime, v))
+}
+
+// CreateTimeLTE applies the LTE predicate on the "create_time" field.
+func CreateTimeLTE(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldCreateTime, v))
+}
+
+// CreateByEQ applies the EQ predicate on the "create_by" field.
+func CreateByEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// CreateByNEQ applies the NEQ predicate on the "create_by" field.
+func CreateByNEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldCreateBy, v))
+}
+
+// CreateByIn applies the In predicate on the "create_by" field.
+func CreateByIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldCreateBy, vs...))
+}
+
+// CreateByNotIn applies the NotIn predicate on the "create_by" field.
+func CreateByNotIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldCreateBy, vs...))
+}
+
+// CreateByGT applies the GT predicate on the "create_by" field.
+func CreateByGT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldCreateBy, v))
+}
+
+// CreateByGTE applies the GTE predicate on the "create_by" field.
+func CreateByGTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldCreateBy, v))
+}
+
+// CreateByLT applies the LT predicate on the "create_by" field.
+func CreateByLT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldCreateBy, v))
+}
+
+// CreateByLTE applies the LTE predicate on the "create_by" field.
+func CreateByLTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldCreateBy, v))
+}
+
+// CreateByIsNil applies the IsNil predicate on the "create_by" field.
+func CreateByIsNil() predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIsNull(FieldCreateBy))
+}
+
+// CreateByNotNil applies the NotNil predicate on the "create_by" field.
+func CreateByNotNil() predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotNull(FieldCreateBy))
+}
+
+// UpdateTimeEQ applies the EQ predicate on the "update_time" field.
+func UpdateTimeEQ(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeNEQ applies the NEQ predicate on the "update_time" field.
+func UpdateTimeNEQ(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeIn applies the In predicate on the "update_time" field.
+func UpdateTimeIn(vs ...time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeNotIn applies the NotIn predicate on the "update_time" field.
+func UpdateTimeNotIn(vs ...time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeGT applies the GT predicate on the "update_time" field.
+func UpdateTimeGT(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldUpdateTime, v))
+}
+
+// UpdateTimeGTE applies the GTE predicate on the "update_time" field.
+func UpdateTimeGTE(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldUpdateTime, v))
+}
+
+// UpdateTimeLT applies the LT predicate on the "update_time" field.
+func UpdateTimeLT(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldUpdateTime, v))
+}
+
+// UpdateTimeLTE applies the LTE predicate on the "update_time" field.
+func UpdateTimeLTE(v time.Time) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldUpdateTime, v))
+}
+
+// UpdateByEQ applies the EQ predicate on the "update_by" field.
+func UpdateByEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// UpdateByNEQ applies the NEQ predicate on the "update_by" field.
+func UpdateByNEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldUpdateBy, v))
+}
+
+// UpdateByIn applies the In predicate on the "update_by" field.
+func UpdateByIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByNotIn applies the NotIn predicate on the "update_by" field.
+func UpdateByNotIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByGT applies the GT predicate on the "update_by" field.
+func UpdateByGT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldUpdateBy, v))
+}
+
+// UpdateByGTE applies the GTE predicate on the "update_by" field.
+func UpdateByGTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldUpdateBy, v))
+}
+
+// UpdateByLT applies the LT predicate on the "update_by" field.
+func UpdateByLT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldUpdateBy, v))
+}
+
+// UpdateByLTE applies the LTE predicate on the "update_by" field.
+func UpdateByLTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldUpdateBy, v))
+}
+
+// UpdateByIsNil applies the IsNil predicate on the "update_by" field.
+func UpdateByIsNil() predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIsNull(FieldUpdateBy))
+}
+
+// UpdateByNotNil applies the NotNil predicate on the "update_by" field.
+func UpdateByNotNil() predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotNull(FieldUpdateBy))
+}
+
+// ApplicationIDEQ applies the EQ predicate on the "application_id" field.
+func ApplicationIDEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldApplicationID, v))
+}
+
+// ApplicationIDNEQ applies the NEQ predicate on the "application_id" field.
+func ApplicationIDNEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldApplicationID, v))
+}
+
+// ApplicationIDIn applies the In predicate on the "application_id" field.
+func ApplicationIDIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldApplicationID, vs...))
+}
+
+// ApplicationIDNotIn applies the NotIn predicate on the "application_id" field.
+func ApplicationIDNotIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldApplicationID, vs...))
+}
+
+// UserIDEQ applies the EQ predicate on the "user_id" field.
+func UserIDEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldUserID, v))
+}
+
+// UserIDNEQ applies the NEQ predicate on the "user_id" field.
+func UserIDNEQ(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldUserID, v))
+}
+
+// UserIDIn applies the In predicate on the "user_id" field.
+func UserIDIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldUserID, vs...))
+}
+
+// UserIDNotIn applies the NotIn predicate on the "user_id" field.
+func UserIDNotIn(vs ...uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldUserID, vs...))
+}
+
+// UserIDGT applies the GT predicate on the "user_id" field.
+func UserIDGT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGT(FieldUserID, v))
+}
+
+// UserIDGTE applies the GTE predicate on the "user_id" field.
+func UserIDGTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldGTE(FieldUserID, v))
+}
+
+// UserIDLT applies the LT predicate on the "user_id" field.
+func UserIDLT(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLT(FieldUserID, v))
+}
+
+// UserIDLTE applies the LTE predicate on the "user_id" field.
+func UserIDLTE(v uint64) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldLTE(FieldUserID, v))
+}
+
+// AccessEQ applies the EQ predicate on the "access" field.
+func AccessEQ(v Access) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldEQ(FieldAccess, v))
+}
+
+// AccessNEQ applies the NEQ predicate on the "access" field.
+func AccessNEQ(v Access) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNEQ(FieldAccess, v))
+}
+
+// AccessIn applies the In predicate on the "access" field.
+func AccessIn(vs ...Access) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldIn(FieldAccess, vs...))
+}
+
+// AccessNotIn applies the NotIn predicate on the "access" field.
+func AccessNotIn(vs ...Access) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.FieldNotIn(FieldAccess, vs...))
+}
+
+// HasApplication applies the HasEdge predicate on the "application" edge.
+func HasApplication() predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, ApplicationTable, ApplicationColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasApplicationWith applies the HasEdge predicate on the "application" edge with a given conditions (other predicates).
+func HasApplicationWith(preds ...predicate.WorkflowApplication) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(func(s *sql.Selector) {
+		step := newApplicationStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.WorkflowApplicationShare) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.WorkflowApplicationShare) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.WorkflowApplicationShare) predicate.WorkflowApplicationShare {
+	return predicate.WorkflowApplicationShare(sql.NotPredicates(p))
+}
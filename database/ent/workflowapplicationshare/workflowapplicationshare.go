@@ -0,0 +1,164 @@
+// Code generated by ent, DO NOT EDIT.
+
+package workflowapplicationshare
+
+import (
+	"fmt"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the workflowapplicationshare type in the database.
+	Label = "workflow_application_share"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldCreateTime holds the string denoting the create_time field in the database.
+	FieldCreateTime = "create_time"
+	// FieldCreateBy holds the string denoting the create_by field in the database.
+	FieldCreateBy = "create_by"
+	// FieldUpdateTime holds the string denoting the update_time field in the database.
+	FieldUpdateTime = "update_time"
+	// FieldUpdateBy holds the string denoting the update_by field in the database.
+	FieldUpdateBy = "update_by"
+	// FieldApplicationID holds the string denoting the application_id field in the database.
+	FieldApplicationID = "application_id"
+	// FieldUserID holds the string denoting the user_id field in the database.
+	FieldUserID = "user_id"
+	// FieldAccess holds the string denoting the access field in the database.
+	FieldAccess = "access"
+	// EdgeApplication holds the string denoting the application edge name in mutations.
+	EdgeApplication = "application"
+	// Table holds the table name of the workflowapplicationshare in the database.
+	Table = "workflow_application_shares"
+	// ApplicationTable is the table that holds the application relation/edge.
+	ApplicationTable = "workflow_application_shares"
+	// ApplicationInverseTable is the table name for the WorkflowApplication entity.
+	// It exists in this package in order to avoid circular dependency with the "workflowapplication" package.
+	ApplicationInverseTable = "workflow_applications"
+	// ApplicationColumn is the table column denoting the application relation/edge.
+	ApplicationColumn = "application_id"
+)
+
+// Columns holds all SQL columns for workflowapplicationshare fields.
+var Columns = []string{
+	FieldID,
+	FieldCreateTime,
+	FieldCreateBy,
+	FieldUpdateTime,
+	FieldUpdateBy,
+	FieldApplicationID,
+	FieldUserID,
+	FieldAccess,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-backend/database/ent/runtime"
+var (
+	Hooks [2]ent.Hook
+	// DefaultCreateTime holds the default value on creation for the "create_time" field.
+	DefaultCreateTime func() time.Time
+	// DefaultUpdateTime holds the default value on creation for the "update_time" field.
+	DefaultUpdateTime func() time.Time
+	// UpdateDefaultUpdateTime holds the default value on update for the "update_time" field.
+	UpdateDefaultUpdateTime func() time.Time
+)
+
+// Access defines the type for the "access" enum field.
+type Access string
+
+// AccessRead is the default value of the Access enum.
+const DefaultAccess = AccessRead
+
+// Access values.
+const (
+	AccessRead Access = "read"
+	AccessEdit Access = "edit"
+)
+
+func (a Access) String() string {
+	return string(a)
+}
+
+// AccessValidator is a validator for the "access" field enum values. It is called by the builders before save.
+func AccessValidator(a Access) error {
+	switch a {
+	case AccessRead, AccessEdit:
+		return nil
+	default:
+		return fmt.Errorf("workflowapplicationshare: invalid enum value for access field: %q", a)
+	}
+}
+
+// OrderOption defines the ordering options for the WorkflowApplicationShare queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByCreateTime orders the results by the create_time field.
+func ByCreateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateTime, opts...).ToFunc()
+}
+
+// ByCreateBy orders the results by the create_by field.
+func ByCreateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateBy, opts...).ToFunc()
+}
+
+// ByUpdateTime orders the results by the update_time field.
+func ByUpdateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateTime, opts...).ToFunc()
+}
+
+// ByUpdateBy orders the results by the update_by field.
+func ByUpdateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateBy, opts...).ToFunc()
+}
+
+// ByApplicationID orders the results by the application_id field.
+func ByApplicationID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldApplicationID, opts...).ToFunc()
+}
+
+// ByUserID orders the results by the user_id field.
+func ByUserID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUserID, opts...).ToFunc()
+}
+
+// ByAccess orders the results by the access field.
+func ByAccess(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAccess, opts...).ToFunc()
+}
+
+// ByApplicationField orders the results by application field.
+func ByApplicationField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newApplicationStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newApplicationStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ApplicationInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, ApplicationTable, ApplicationColumn),
+	)
+}
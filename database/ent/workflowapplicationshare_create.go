@@ -0,0 +1,362 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowApplicationShareCreate is the builder for creating a WorkflowApplicationShare entity.
+type WorkflowApplicationShareCreate struct {
+	config
+	mutation *WorkflowApplicationShareMutation
+	hooks    []Hook
+}
+
+// SetCreateTime sets the "create_time" field.
+func (_c *WorkflowApplicationShareCreate) SetCreateTime(v time.Time) *WorkflowApplicationShareCreate {
+	_c.mutation.SetCreateTime(v)
+	return _c
+}
+
+// SetNillableCreateTime sets the "create_time" field if the given value is not nil.
+func (_c *WorkflowApplicationShareCreate) SetNillableCreateTime(v *time.Time) *WorkflowApplicationShareCreate {
+	if v != nil {
+		_c.SetCreateTime(*v)
+	}
+	return _c
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_c *WorkflowApplicationShareCreate) SetCreateBy(v uint64) *WorkflowApplicationShareCreate {
+	_c.mutation.SetCreateBy(v)
+	return _c
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_c *WorkflowApplicationShareCreate) SetNillableCreateBy(v *uint64) *WorkflowApplicationShareCreate {
+	if v != nil {
+		_c.SetCreateBy(*v)
+	}
+	return _c
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_c *WorkflowApplicationShareCreate) SetUpdateTime(v time.Time) *WorkflowApplicationShareCreate {
+	_c.mutation.SetUpdateTime(v)
+	return _c
+}
+
+// SetNillableUpdateTime sets the "update_time" field if the given value is not nil.
+func (_c *WorkflowApplicationShareCreate) SetNillableUpdateTime(v *time.Time) *WorkflowApplicationShareCreate {
+	if v != nil {
+		_c.SetUpdateTime(*v)
+	}
+	return _c
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_c *WorkflowApplicationShareCreate) SetUpdateBy(v uint64) *WorkflowApplicationShareCreate {
+	_c.mutation.SetUpdateBy(v)
+	return _c
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_c *WorkflowApplicationShareCreate) SetNillableUpdateBy(v *uint64) *WorkflowApplicationShareCreate {
+	if v != nil {
+		_c.SetUpdateBy(*v)
+	}
+	return _c
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_c *WorkflowApplicationShareCreate) SetApplicationID(v uint64) *WorkflowApplicationShareCreate {
+	_c.mutation.SetApplicationID(v)
+	return _c
+}
+
+// SetUserID sets the "user_id" field.
+func (_c *WorkflowApplicationShareCreate) SetUserID(v uint64) *WorkflowApplicationShareCreate {
+	_c.mutation.SetUserID(v)
+	return _c
+}
+
+// SetAccess sets the "access" field.
+func (_c *WorkflowApplicationShareCreate) SetAccess(v workflowapplicationshare.Access) *WorkflowApplicationShareCreate {
+	_c.mutation.SetAccess(v)
+	return _c
+}
+
+// SetNillableAccess sets the "access" field if the given value is not nil.
+func (_c *WorkflowApplicationShareCreate) SetNillableAccess(v *workflowapplicationshare.Access) *WorkflowApplicationShareCreate {
+	if v != nil {
+		_c.SetAccess(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *WorkflowApplicationShareCreate) SetID(v uint64) *WorkflowApplicationShareCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_c *WorkflowApplicationShareCreate) SetApplication(v *WorkflowApplication) *WorkflowApplicationShareCreate {
+	return _c.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowApplicationShareMutation object of the builder.
+func (_c *WorkflowApplicationShareCreate) Mutation() *WorkflowApplicationShareMutation {
+	return _c.mutation
+}
+
+// Save creates the WorkflowApplicationShare in the database.
+func (_c *WorkflowApplicationShareCreate) Save(ctx context.Context) (*WorkflowApplicationShare, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WorkflowApplicationShareCreate) SaveX(ctx context.Context) *WorkflowApplicationShare {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowApplicationShareCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowApplicationShareCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WorkflowApplicationShareCreate) defaults() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		if workflowapplicationshare.DefaultCreateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowapplicationshare.DefaultCreateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowapplicationshare.DefaultCreateTime()
+		_c.mutation.SetCreateTime(v)
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		if workflowapplicationshare.DefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowapplicationshare.DefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowapplicationshare.DefaultUpdateTime()
+		_c.mutation.SetUpdateTime(v)
+	}
+	if _, ok := _c.mutation.Access(); !ok {
+		v := workflowapplicationshare.DefaultAccess
+		_c.mutation.SetAccess(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WorkflowApplicationShareCreate) check() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		return &ValidationError{Name: "create_time", err: errors.New(`ent: missing required field "WorkflowApplicationShare.create_time"`)}
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		return &ValidationError{Name: "update_time", err: errors.New(`ent: missing required field "WorkflowApplicationShare.update_time"`)}
+	}
+	if _, ok := _c.mutation.ApplicationID(); !ok {
+		return &ValidationError{Name: "application_id", err: errors.New(`ent: missing required field "WorkflowApplicationShare.application_id"`)}
+	}
+	if _, ok := _c.mutation.UserID(); !ok {
+		return &ValidationError{Name: "user_id", err: errors.New(`ent: missing required field "WorkflowApplicationShare.user_id"`)}
+	}
+	if _, ok := _c.mutation.Access(); !ok {
+		return &ValidationError{Name: "access", err: errors.New(`ent: missing required field "WorkflowApplicationShare.access"`)}
+	}
+	if v, ok := _c.mutation.Access(); ok {
+		if err := workflowapplicationshare.AccessValidator(v); err != nil {
+			return &ValidationError{Name: "access", err: fmt.Errorf(`ent: validator failed for field "WorkflowApplicationShare.access": %w`, err)}
+		}
+	}
+	if len(_c.mutation.ApplicationIDs()) == 0 {
+		return &ValidationError{Name: "application", err: errors.New(`ent: missing required edge "WorkflowApplicationShare.application"`)}
+	}
+	return nil
+}
+
+func (_c *WorkflowApplicationShareCreate) sqlSave(ctx context.Context) (*WorkflowApplicationShare, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WorkflowApplicationShareCreate) createSpec() (*WorkflowApplicationShare, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WorkflowApplicationShare{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(workflowapplicationshare.Table, sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreateTime(); ok {
+		_spec.SetField(workflowapplicationshare.FieldCreateTime, field.TypeTime, value)
+		_node.CreateTime = value
+	}
+	if value, ok := _c.mutation.CreateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldCreateBy, field.TypeUint64, value)
+		_node.CreateBy = value
+	}
+	if value, ok := _c.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateTime, field.TypeTime, value)
+		_node.UpdateTime = value
+	}
+	if value, ok := _c.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64, value)
+		_node.UpdateBy = value
+	}
+	if value, ok := _c.mutation.UserID(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUserID, field.TypeUint64, value)
+		_node.UserID = value
+	}
+	if value, ok := _c.mutation.Access(); ok {
+		_spec.SetField(workflowapplicationshare.FieldAccess, field.TypeEnum, value)
+		_node.Access = value
+	}
+	if nodes := _c.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ApplicationID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WorkflowApplicationShareCreateBulk is the builder for creating many WorkflowApplicationShare entities in bulk.
+type WorkflowApplicationShareCreateBulk struct {
+	config
+	err      error
+	builders []*WorkflowApplicationShareCreate
+}
+
+// Save creates the WorkflowApplicationShare entities in the database.
+func (_c *WorkflowApplicationShareCreateBulk) Save(ctx context.Context) ([]*WorkflowApplicationShare, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*WorkflowApplicationShare, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WorkflowApplicationShareMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WorkflowApplicationShareCreateBulk) SaveX(ctx context.Context) []*WorkflowApplicationShare {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowApplicationShareCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowApplicationShareCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
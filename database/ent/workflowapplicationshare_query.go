@@ -0,0 +1,643 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowApplicationShareQuery is the builder for querying WorkflowApplicationShare entities.
+type WorkflowApplicationShareQuery struct {
+	config
+	ctx             *QueryContext
+	order           []workflowapplicationshare.OrderOption
+	inters          []Interceptor
+	predicates      []predicate.WorkflowApplicationShare
+	withApplication *WorkflowApplicationQuery
+	modifiers       []func(*sql.Selector)
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the WorkflowApplicationShareQuery builder.
+func (_q *WorkflowApplicationShareQuery) Where(ps ...predicate.WorkflowApplicationShare) *WorkflowApplicationShareQuery {
+	_q.predicates = append(_q.predicates, ps...)
+	return _q
+}
+
+// Limit the number of records to be returned by this query.
+func (_q *WorkflowApplicationShareQuery) Limit(limit int) *WorkflowApplicationShareQuery {
+	_q.ctx.Limit = &limit
+	return _q
+}
+
+// Offset to start from.
+func (_q *WorkflowApplicationShareQuery) Offset(offset int) *WorkflowApplicationShareQuery {
+	_q.ctx.Offset = &offset
+	return _q
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (_q *WorkflowApplicationShareQuery) Unique(unique bool) *WorkflowApplicationShareQuery {
+	_q.ctx.Unique = &unique
+	return _q
+}
+
+// Order specifies how the records should be ordered.
+func (_q *WorkflowApplicationShareQuery) Order(o ...workflowapplicationshare.OrderOption) *WorkflowApplicationShareQuery {
+	_q.order = append(_q.order, o...)
+	return _q
+}
+
+// QueryApplication chains the current query on the "application" edge.
+func (_q *WorkflowApplicationShareQuery) QueryApplication() *WorkflowApplicationQuery {
+	query := (&WorkflowApplicationClient{config: _q.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := _q.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := _q.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(workflowapplicationshare.Table, workflowapplicationshare.FieldID, selector),
+			sqlgraph.To(workflowapplication.Table, workflowapplication.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, workflowapplicationshare.ApplicationTable, workflowapplicationshare.ApplicationColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(_q.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// First returns the first WorkflowApplicationShare entity from the query.
+// Returns a *NotFoundError when no WorkflowApplicationShare was found.
+func (_q *WorkflowApplicationShareQuery) First(ctx context.Context) (*WorkflowApplicationShare, error) {
+	nodes, err := _q.Limit(1).All(setContextOp(ctx, _q.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{workflowapplicationshare.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) FirstX(ctx context.Context) *WorkflowApplicationShare {
+	node, err := _q.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first WorkflowApplicationShare ID from the query.
+// Returns a *NotFoundError when no WorkflowApplicationShare ID was found.
+func (_q *WorkflowApplicationShareQuery) FirstID(ctx context.Context) (id uint64, err error) {
+	var ids []uint64
+	if ids, err = _q.Limit(1).IDs(setContextOp(ctx, _q.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{workflowapplicationshare.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) FirstIDX(ctx context.Context) uint64 {
+	id, err := _q.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single WorkflowApplicationShare entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one WorkflowApplicationShare entity is found.
+// Returns a *NotFoundError when no WorkflowApplicationShare entities are found.
+func (_q *WorkflowApplicationShareQuery) Only(ctx context.Context) (*WorkflowApplicationShare, error) {
+	nodes, err := _q.Limit(2).All(setContextOp(ctx, _q.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{workflowapplicationshare.Label}
+	default:
+		return nil, &NotSingularError{workflowapplicationshare.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) OnlyX(ctx context.Context) *WorkflowApplicationShare {
+	node, err := _q.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only WorkflowApplicationShare ID in the query.
+// Returns a *NotSingularError when more than one WorkflowApplicationShare ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (_q *WorkflowApplicationShareQuery) OnlyID(ctx context.Context) (id uint64, err error) {
+	var ids []uint64
+	if ids, err = _q.Limit(2).IDs(setContextOp(ctx, _q.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{workflowapplicationshare.Label}
+	default:
+		err = &NotSingularError{workflowapplicationshare.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) OnlyIDX(ctx context.Context) uint64 {
+	id, err := _q.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of WorkflowApplicationShares.
+func (_q *WorkflowApplicationShareQuery) All(ctx context.Context) ([]*WorkflowApplicationShare, error) {
+	ctx = setContextOp(ctx, _q.ctx, ent.OpQueryAll)
+	if err := _q.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*WorkflowApplicationShare, *WorkflowApplicationShareQuery]()
+	return withInterceptors[[]*WorkflowApplicationShare](ctx, _q, qr, _q.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) AllX(ctx context.Context) []*WorkflowApplicationShare {
+	nodes, err := _q.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of WorkflowApplicationShare IDs.
+func (_q *WorkflowApplicationShareQuery) IDs(ctx context.Context) (ids []uint64, err error) {
+	if _q.ctx.Unique == nil && _q.path != nil {
+		_q.Unique(true)
+	}
+	ctx = setContextOp(ctx, _q.ctx, ent.OpQueryIDs)
+	if err = _q.Select(workflowapplicationshare.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) IDsX(ctx context.Context) []uint64 {
+	ids, err := _q.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (_q *WorkflowApplicationShareQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, _q.ctx, ent.OpQueryCount)
+	if err := _q.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, _q, querierCount[*WorkflowApplicationShareQuery](), _q.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) CountX(ctx context.Context) int {
+	count, err := _q.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (_q *WorkflowApplicationShareQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, _q.ctx, ent.OpQueryExist)
+	switch _, err := _q.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (_q *WorkflowApplicationShareQuery) ExistX(ctx context.Context) bool {
+	exist, err := _q.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the WorkflowApplicationShareQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (_q *WorkflowApplicationShareQuery) Clone() *WorkflowApplicationShareQuery {
+	if _q == nil {
+		return nil
+	}
+	return &WorkflowApplicationShareQuery{
+		config:          _q.config,
+		ctx:             _q.ctx.Clone(),
+		order:           append([]workflowapplicationshare.OrderOption{}, _q.order...),
+		inters:          append([]Interceptor{}, _q.inters...),
+		predicates:      append([]predicate.WorkflowApplicationShare{}, _q.predicates...),
+		withApplication: _q.withApplication.Clone(),
+		// clone intermediate query.
+		sql:  _q.sql.Clone(),
+		path: _q.path,
+	}
+}
+
+// WithApplication tells the query-builder to eager-load the nodes that are connected to
+// the "application" edge. The optional arguments are used to configure the query builder of the edge.
+func (_q *WorkflowApplicationShareQuery) WithApplication(opts ...func(*WorkflowApplicationQuery)) *WorkflowApplicationShareQuery {
+	query := (&WorkflowApplicationClient{config: _q.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	_q.withApplication = query
+	return _q
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CreateTime time.Time `json:"create_time,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.WorkflowApplicationShare.Query().
+//		GroupBy(workflowapplicationshare.FieldCreateTime).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (_q *WorkflowApplicationShareQuery) GroupBy(field string, fields ...string) *WorkflowApplicationShareGroupBy {
+	_q.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &WorkflowApplicationShareGroupBy{build: _q}
+	grbuild.flds = &_q.ctx.Fields
+	grbuild.label = workflowapplicationshare.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CreateTime time.Time `json:"create_time,omitempty"`
+//	}
+//
+//	client.WorkflowApplicationShare.Query().
+//		Select(workflowapplicationshare.FieldCreateTime).
+//		Scan(ctx, &v)
+func (_q *WorkflowApplicationShareQuery) Select(fields ...string) *WorkflowApplicationShareSelect {
+	_q.ctx.Fields = append(_q.ctx.Fields, fields...)
+	sbuild := &WorkflowApplicationShareSelect{WorkflowApplicationShareQuery: _q}
+	sbuild.label = workflowapplicationshare.Label
+	sbuild.flds, sbuild.scan = &_q.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a WorkflowApplicationShareSelect configured with the given aggregations.
+func (_q *WorkflowApplicationShareQuery) Aggregate(fns ...AggregateFunc) *WorkflowApplicationShareSelect {
+	return _q.Select().Aggregate(fns...)
+}
+
+func (_q *WorkflowApplicationShareQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range _q.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, _q); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range _q.ctx.Fields {
+		if !workflowapplicationshare.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if _q.path != nil {
+		prev, err := _q.path(ctx)
+		if err != nil {
+			return err
+		}
+		_q.sql = prev
+	}
+	return nil
+}
+
+func (_q *WorkflowApplicationShareQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*WorkflowApplicationShare, error) {
+	var (
+		nodes       = []*WorkflowApplicationShare{}
+		_spec       = _q.querySpec()
+		loadedTypes = [1]bool{
+			_q.withApplication != nil,
+		}
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*WorkflowApplicationShare).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &WorkflowApplicationShare{config: _q.config}
+		nodes = append(nodes, node)
+		node.Edges.loadedTypes = loadedTypes
+		return node.assignValues(columns, values)
+	}
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, _q.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	if query := _q.withApplication; query != nil {
+		if err := _q.loadApplication(ctx, query, nodes, nil,
+			func(n *WorkflowApplicationShare, e *WorkflowApplication) { n.Edges.Application = e }); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func (_q *WorkflowApplicationShareQuery) loadApplication(ctx context.Context, query *WorkflowApplicationQuery, nodes []*WorkflowApplicationShare, init func(*WorkflowApplicationShare), assign func(*WorkflowApplicationShare, *WorkflowApplication)) error {
+	ids := make([]uint64, 0, len(nodes))
+	nodeids := make(map[uint64][]*WorkflowApplicationShare)
+	for i := range nodes {
+		fk := nodes[i].ApplicationID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(workflowapplication.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "application_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
+
+func (_q *WorkflowApplicationShareQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := _q.querySpec()
+	if len(_q.modifiers) > 0 {
+		_spec.Modifiers = _q.modifiers
+	}
+	_spec.Node.Columns = _q.ctx.Fields
+	if len(_q.ctx.Fields) > 0 {
+		_spec.Unique = _q.ctx.Unique != nil && *_q.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, _q.driver, _spec)
+}
+
+func (_q *WorkflowApplicationShareQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(workflowapplicationshare.Table, workflowapplicationshare.Columns, sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64))
+	_spec.From = _q.sql
+	if unique := _q.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if _q.path != nil {
+		_spec.Unique = true
+	}
+	if fields := _q.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, workflowapplicationshare.FieldID)
+		for i := range fields {
+			if fields[i] != workflowapplicationshare.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+		if _q.withApplication != nil {
+			_spec.Node.AddColumnOnce(workflowapplicationshare.FieldApplicationID)
+		}
+	}
+	if ps := _q.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := _q.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := _q.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := _q.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (_q *WorkflowApplicationShareQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(_q.driver.Dialect())
+	t1 := builder.Table(workflowapplicationshare.Table)
+	columns := _q.ctx.Fields
+	if len(columns) == 0 {
+		columns = workflowapplicationshare.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if _q.sql != nil {
+		selector = _q.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if _q.ctx.Unique != nil && *_q.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, m := range _q.modifiers {
+		m(selector)
+	}
+	for _, p := range _q.predicates {
+		p(selector)
+	}
+	for _, p := range _q.order {
+		p(selector)
+	}
+	if offset := _q.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := _q.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// ForUpdate locks the selected rows against concurrent updates, and prevent them from being
+// updated, deleted or "selected ... for update" by other sessions, until the transaction is
+// either committed or rolled-back.
+func (_q *WorkflowApplicationShareQuery) ForUpdate(opts ...sql.LockOption) *WorkflowApplicationShareQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForUpdate(opts...)
+	})
+	return _q
+}
+
+// ForShare behaves similarly to ForUpdate, except that it acquires a shared mode lock
+// on any rows that are read. Other sessions can read the rows, but cannot modify them
+// until your transaction commits.
+func (_q *WorkflowApplicationShareQuery) ForShare(opts ...sql.LockOption) *WorkflowApplicationShareQuery {
+	if _q.driver.Dialect() == dialect.Postgres {
+		_q.Unique(false)
+	}
+	_q.modifiers = append(_q.modifiers, func(s *sql.Selector) {
+		s.ForShare(opts...)
+	})
+	return _q
+}
+
+// WorkflowApplicationShareGroupBy is the group-by builder for WorkflowApplicationShare entities.
+type WorkflowApplicationShareGroupBy struct {
+	selector
+	build *WorkflowApplicationShareQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (_g *WorkflowApplicationShareGroupBy) Aggregate(fns ...AggregateFunc) *WorkflowApplicationShareGroupBy {
+	_g.fns = append(_g.fns, fns...)
+	return _g
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (_g *WorkflowApplicationShareGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, _g.build.ctx, ent.OpQueryGroupBy)
+	if err := _g.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*WorkflowApplicationShareQuery, *WorkflowApplicationShareGroupBy](ctx, _g.build, _g, _g.build.inters, v)
+}
+
+func (_g *WorkflowApplicationShareGroupBy) sqlScan(ctx context.Context, root *WorkflowApplicationShareQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(_g.fns))
+	for _, fn := range _g.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*_g.flds)+len(_g.fns))
+		for _, f := range *_g.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*_g.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := _g.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// WorkflowApplicationShareSelect is the builder for selecting fields of WorkflowApplicationShare entities.
+type WorkflowApplicationShareSelect struct {
+	*WorkflowApplicationShareQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (_s *WorkflowApplicationShareSelect) Aggregate(fns ...AggregateFunc) *WorkflowApplicationShareSelect {
+	_s.fns = append(_s.fns, fns...)
+	return _s
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (_s *WorkflowApplicationShareSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, _s.ctx, ent.OpQuerySelect)
+	if err := _s.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*WorkflowApplicationShareQuery, *WorkflowApplicationShareSelect](ctx, _s.WorkflowApplicationShareQuery, _s, _s.inters, v)
+}
+
+func (_s *WorkflowApplicationShareSelect) sqlScan(ctx context.Context, root *WorkflowApplicationShareQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(_s.fns))
+	for _, fn := range _s.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*_s.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := _s.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
@@ -0,0 +1,597 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowApplicationShareUpdate is the builder for updating WorkflowApplicationShare entities.
+type WorkflowApplicationShareUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WorkflowApplicationShareMutation
+}
+
+// Where appends a list predicates to the WorkflowApplicationShareUpdate builder.
+func (_u *WorkflowApplicationShareUpdate) Where(ps ...predicate.WorkflowApplicationShare) *WorkflowApplicationShareUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowApplicationShareUpdate) SetCreateBy(v uint64) *WorkflowApplicationShareUpdate {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdate) SetNillableCreateBy(v *uint64) *WorkflowApplicationShareUpdate {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowApplicationShareUpdate) AddCreateBy(v int64) *WorkflowApplicationShareUpdate {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowApplicationShareUpdate) ClearCreateBy() *WorkflowApplicationShareUpdate {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowApplicationShareUpdate) SetUpdateTime(v time.Time) *WorkflowApplicationShareUpdate {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowApplicationShareUpdate) SetUpdateBy(v uint64) *WorkflowApplicationShareUpdate {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdate) SetNillableUpdateBy(v *uint64) *WorkflowApplicationShareUpdate {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowApplicationShareUpdate) AddUpdateBy(v int64) *WorkflowApplicationShareUpdate {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowApplicationShareUpdate) ClearUpdateBy() *WorkflowApplicationShareUpdate {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowApplicationShareUpdate) SetApplicationID(v uint64) *WorkflowApplicationShareUpdate {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdate) SetNillableApplicationID(v *uint64) *WorkflowApplicationShareUpdate {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *WorkflowApplicationShareUpdate) SetUserID(v uint64) *WorkflowApplicationShareUpdate {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdate) SetNillableUserID(v *uint64) *WorkflowApplicationShareUpdate {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *WorkflowApplicationShareUpdate) AddUserID(v int64) *WorkflowApplicationShareUpdate {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetAccess sets the "access" field.
+func (_u *WorkflowApplicationShareUpdate) SetAccess(v workflowapplicationshare.Access) *WorkflowApplicationShareUpdate {
+	_u.mutation.SetAccess(v)
+	return _u
+}
+
+// SetNillableAccess sets the "access" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdate) SetNillableAccess(v *workflowapplicationshare.Access) *WorkflowApplicationShareUpdate {
+	if v != nil {
+		_u.SetAccess(*v)
+	}
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowApplicationShareUpdate) SetApplication(v *WorkflowApplication) *WorkflowApplicationShareUpdate {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowApplicationShareMutation object of the builder.
+func (_u *WorkflowApplicationShareUpdate) Mutation() *WorkflowApplicationShareMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowApplicationShareUpdate) ClearApplication() *WorkflowApplicationShareUpdate {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *WorkflowApplicationShareUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowApplicationShareUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *WorkflowApplicationShareUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowApplicationShareUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowApplicationShareUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowapplicationshare.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowapplicationshare.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowapplicationshare.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowApplicationShareUpdate) check() error {
+	if v, ok := _u.mutation.Access(); ok {
+		if err := workflowapplicationshare.AccessValidator(v); err != nil {
+			return &ValidationError{Name: "access", err: fmt.Errorf(`ent: validator failed for field "WorkflowApplicationShare.access": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowApplicationShare.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowApplicationShareUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowapplicationshare.Table, workflowapplicationshare.Columns, sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowapplicationshare.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowapplicationshare.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUserID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(workflowapplicationshare.FieldUserID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.Access(); ok {
+		_spec.SetField(workflowapplicationshare.FieldAccess, field.TypeEnum, value)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowapplicationshare.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// WorkflowApplicationShareUpdateOne is the builder for updating a single WorkflowApplicationShare entity.
+type WorkflowApplicationShareUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WorkflowApplicationShareMutation
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetCreateBy(v uint64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdateOne) SetNillableCreateBy(v *uint64) *WorkflowApplicationShareUpdateOne {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) AddCreateBy(v int64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) ClearCreateBy() *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetUpdateTime(v time.Time) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetUpdateBy(v uint64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdateOne) SetNillableUpdateBy(v *uint64) *WorkflowApplicationShareUpdateOne {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) AddUpdateBy(v int64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowApplicationShareUpdateOne) ClearUpdateBy() *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetApplicationID(v uint64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdateOne) SetNillableApplicationID(v *uint64) *WorkflowApplicationShareUpdateOne {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetUserID sets the "user_id" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetUserID(v uint64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ResetUserID()
+	_u.mutation.SetUserID(v)
+	return _u
+}
+
+// SetNillableUserID sets the "user_id" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdateOne) SetNillableUserID(v *uint64) *WorkflowApplicationShareUpdateOne {
+	if v != nil {
+		_u.SetUserID(*v)
+	}
+	return _u
+}
+
+// AddUserID adds value to the "user_id" field.
+func (_u *WorkflowApplicationShareUpdateOne) AddUserID(v int64) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.AddUserID(v)
+	return _u
+}
+
+// SetAccess sets the "access" field.
+func (_u *WorkflowApplicationShareUpdateOne) SetAccess(v workflowapplicationshare.Access) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.SetAccess(v)
+	return _u
+}
+
+// SetNillableAccess sets the "access" field if the given value is not nil.
+func (_u *WorkflowApplicationShareUpdateOne) SetNillableAccess(v *workflowapplicationshare.Access) *WorkflowApplicationShareUpdateOne {
+	if v != nil {
+		_u.SetAccess(*v)
+	}
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowApplicationShareUpdateOne) SetApplication(v *WorkflowApplication) *WorkflowApplicationShareUpdateOne {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowApplicationShareMutation object of the builder.
+func (_u *WorkflowApplicationShareUpdateOne) Mutation() *WorkflowApplicationShareMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowApplicationShareUpdateOne) ClearApplication() *WorkflowApplicationShareUpdateOne {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Where appends a list predicates to the WorkflowApplicationShareUpdate builder.
+func (_u *WorkflowApplicationShareUpdateOne) Where(ps ...predicate.WorkflowApplicationShare) *WorkflowApplicationShareUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *WorkflowApplicationShareUpdateOne) Select(field string, fields ...string) *WorkflowApplicationShareUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated WorkflowApplicationShare entity.
+func (_u *WorkflowApplicationShareUpdateOne) Save(ctx context.Context) (*WorkflowApplicationShare, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowApplicationShareUpdateOne) SaveX(ctx context.Context) *WorkflowApplicationShare {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *WorkflowApplicationShareUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowApplicationShareUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowApplicationShareUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowapplicationshare.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowapplicationshare.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowapplicationshare.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowApplicationShareUpdateOne) check() error {
+	if v, ok := _u.mutation.Access(); ok {
+		if err := workflowapplicationshare.AccessValidator(v); err != nil {
+			return &ValidationError{Name: "access", err: fmt.Errorf(`ent: validator failed for field "WorkflowApplicationShare.access": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowApplicationShare.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowApplicationShareUpdateOne) sqlSave(ctx context.Context) (_node *WorkflowApplicationShare, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowapplicationshare.Table, workflowapplicationshare.Columns, sqlgraph.NewFieldSpec(workflowapplicationshare.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "WorkflowApplicationShare.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, workflowapplicationshare.FieldID)
+		for _, f := range fields {
+			if !workflowapplicationshare.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != workflowapplicationshare.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowapplicationshare.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowapplicationshare.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowapplicationshare.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UserID(); ok {
+		_spec.SetField(workflowapplicationshare.FieldUserID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUserID(); ok {
+		_spec.AddField(workflowapplicationshare.FieldUserID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.Access(); ok {
+		_spec.SetField(workflowapplicationshare.FieldAccess, field.TypeEnum, value)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowapplicationshare.ApplicationTable,
+			Columns: []string{workflowapplicationshare.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &WorkflowApplicationShare{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowapplicationshare.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
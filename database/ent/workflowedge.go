@@ -47,7 +47,7 @@ type WorkflowEdge struct {
 	Type workflowedge.Type `json:"type,omitempty"`
 	// 边标签
 	Label string `json:"label,omitempty"`
-	// 分支名称（用于 condition_checker）
+	// 分支名称（用于 condition_checker，default/else 为没有条件成立时的默认分支）
 	BranchName string `json:"branch_name,omitempty"`
 	// 是否动画
 	Animated bool `json:"animated,omitempty"`
@@ -139,9 +139,10 @@ const DefaultType = TypeDefault
 
 // Type values.
 const (
-	TypeDefault  Type = "default"
-	TypeBranch   Type = "branch"
-	TypeParallel Type = "parallel"
+	TypeDefault     Type = "default"
+	TypeBranch      Type = "branch"
+	TypeParallel    Type = "parallel"
+	TypeConditional Type = "conditional"
 )
 
 func (_type Type) String() string {
@@ -151,7 +152,7 @@ func (_type Type) String() string {
 // TypeValidator is a validator for the "type" field enum values. It is called by the builders before save.
 func TypeValidator(_type Type) error {
 	switch _type {
-	case TypeDefault, TypeBranch, TypeParallel:
+	case TypeDefault, TypeBranch, TypeParallel, TypeConditional:
 		return nil
 	default:
 		return fmt.Errorf("workflowedge: invalid enum value for type field: %q", _type)
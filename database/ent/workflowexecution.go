@@ -40,6 +40,10 @@ type WorkflowExecution struct {
 	Output map[string]interface{} `json:"output,omitempty"`
 	// 执行上下文
 	Context map[string]interface{} `json:"context,omitempty"`
+	// 本次执行生效的变量：应用变量与执行上下文深度合并的结果，同名键以执行上下文为准
+	Variables map[string]interface{} `json:"variables,omitempty"`
+	// 开始执行时的工作流图快照JSON（应用、节点和边），执行期间按快照遍历，不受之后的编辑影响
+	GraphSnapshot string `json:"graph_snapshot,omitempty"`
 	// 开始时间
 	StartedAt time.Time `json:"started_at,omitempty"`
 	// 结束时间
@@ -58,6 +62,8 @@ type WorkflowExecution struct {
 	TriggeredBy string `json:"triggered_by,omitempty"`
 	// 触发源
 	TriggerSource string `json:"trigger_source,omitempty"`
+	// 父执行ID，由子工作流节点启动的执行指向调用方的执行
+	ParentExecutionID uint64 `json:"parent_execution_id,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the WorkflowExecutionQuery when eager-loading is set.
 	Edges        WorkflowExecutionEdges `json:"edges"`
@@ -101,13 +107,13 @@ func (*WorkflowExecution) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case workflowexecution.FieldInput, workflowexecution.FieldOutput, workflowexecution.FieldContext:
+		case workflowexecution.FieldInput, workflowexecution.FieldOutput, workflowexecution.FieldContext, workflowexecution.FieldVariables:
 			values[i] = new([]byte)
 		case workflowexecution.FieldTotalCost:
 			values[i] = new(sql.NullFloat64)
-		case workflowexecution.FieldID, workflowexecution.FieldCreateBy, workflowexecution.FieldUpdateBy, workflowexecution.FieldApplicationID, workflowexecution.FieldDurationMs, workflowexecution.FieldTotalTokens:
+		case workflowexecution.FieldID, workflowexecution.FieldCreateBy, workflowexecution.FieldUpdateBy, workflowexecution.FieldApplicationID, workflowexecution.FieldDurationMs, workflowexecution.FieldTotalTokens, workflowexecution.FieldParentExecutionID:
 			values[i] = new(sql.NullInt64)
-		case workflowexecution.FieldExecutionID, workflowexecution.FieldStatus, workflowexecution.FieldErrorMessage, workflowexecution.FieldErrorStack, workflowexecution.FieldTriggeredBy, workflowexecution.FieldTriggerSource:
+		case workflowexecution.FieldExecutionID, workflowexecution.FieldStatus, workflowexecution.FieldGraphSnapshot, workflowexecution.FieldErrorMessage, workflowexecution.FieldErrorStack, workflowexecution.FieldTriggeredBy, workflowexecution.FieldTriggerSource:
 			values[i] = new(sql.NullString)
 		case workflowexecution.FieldCreateTime, workflowexecution.FieldUpdateTime, workflowexecution.FieldStartedAt, workflowexecution.FieldFinishedAt:
 			values[i] = new(sql.NullTime)
@@ -198,6 +204,20 @@ func (_m *WorkflowExecution) assignValues(columns []string, values []any) error
 					return fmt.Errorf("unmarshal field context: %w", err)
 				}
 			}
+		case workflowexecution.FieldVariables:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field variables", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Variables); err != nil {
+					return fmt.Errorf("unmarshal field variables: %w", err)
+				}
+			}
+		case workflowexecution.FieldGraphSnapshot:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field graph_snapshot", values[i])
+			} else if value.Valid {
+				_m.GraphSnapshot = value.String
+			}
 		case workflowexecution.FieldStartedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field started_at", values[i])
@@ -252,6 +272,12 @@ func (_m *WorkflowExecution) assignValues(columns []string, values []any) error
 			} else if value.Valid {
 				_m.TriggerSource = value.String
 			}
+		case workflowexecution.FieldParentExecutionID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field parent_execution_id", values[i])
+			} else if value.Valid {
+				_m.ParentExecutionID = uint64(value.Int64)
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -328,6 +354,12 @@ func (_m *WorkflowExecution) String() string {
 	builder.WriteString("context=")
 	builder.WriteString(fmt.Sprintf("%v", _m.Context))
 	builder.WriteString(", ")
+	builder.WriteString("variables=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Variables))
+	builder.WriteString(", ")
+	builder.WriteString("graph_snapshot=")
+	builder.WriteString(_m.GraphSnapshot)
+	builder.WriteString(", ")
 	builder.WriteString("started_at=")
 	builder.WriteString(_m.StartedAt.Format(time.ANSIC))
 	builder.WriteString(", ")
@@ -354,6 +386,9 @@ func (_m *WorkflowExecution) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("trigger_source=")
 	builder.WriteString(_m.TriggerSource)
+	builder.WriteString(", ")
+	builder.WriteString("parent_execution_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ParentExecutionID))
 	builder.WriteByte(')')
 	return builder.String()
 }
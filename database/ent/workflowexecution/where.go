@@ -85,6 +85,11 @@ func ApplicationID(v uint64) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldEQ(FieldApplicationID, v))
 }
 
+// GraphSnapshot applies equality check predicate on the "graph_snapshot" field. It's identical to GraphSnapshotEQ.
+func GraphSnapshot(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldEQ(FieldGraphSnapshot, v))
+}
+
 // StartedAt applies equality check predicate on the "started_at" field. It's identical to StartedAtEQ.
 func StartedAt(v time.Time) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldEQ(FieldStartedAt, v))
@@ -130,6 +135,11 @@ func TriggerSource(v string) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldEQ(FieldTriggerSource, v))
 }
 
+// ParentExecutionID applies equality check predicate on the "parent_execution_id" field. It's identical to ParentExecutionIDEQ.
+func ParentExecutionID(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldEQ(FieldParentExecutionID, v))
+}
+
 // CreateTimeEQ applies the EQ predicate on the "create_time" field.
 func CreateTimeEQ(v time.Time) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldEQ(FieldCreateTime, v))
@@ -445,6 +455,91 @@ func ContextNotNil() predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldNotNull(FieldContext))
 }
 
+// VariablesIsNil applies the IsNil predicate on the "variables" field.
+func VariablesIsNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldIsNull(FieldVariables))
+}
+
+// VariablesNotNil applies the NotNil predicate on the "variables" field.
+func VariablesNotNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNotNull(FieldVariables))
+}
+
+// GraphSnapshotEQ applies the EQ predicate on the "graph_snapshot" field.
+func GraphSnapshotEQ(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldEQ(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotNEQ applies the NEQ predicate on the "graph_snapshot" field.
+func GraphSnapshotNEQ(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNEQ(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotIn applies the In predicate on the "graph_snapshot" field.
+func GraphSnapshotIn(vs ...string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldIn(FieldGraphSnapshot, vs...))
+}
+
+// GraphSnapshotNotIn applies the NotIn predicate on the "graph_snapshot" field.
+func GraphSnapshotNotIn(vs ...string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNotIn(FieldGraphSnapshot, vs...))
+}
+
+// GraphSnapshotGT applies the GT predicate on the "graph_snapshot" field.
+func GraphSnapshotGT(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldGT(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotGTE applies the GTE predicate on the "graph_snapshot" field.
+func GraphSnapshotGTE(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldGTE(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotLT applies the LT predicate on the "graph_snapshot" field.
+func GraphSnapshotLT(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldLT(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotLTE applies the LTE predicate on the "graph_snapshot" field.
+func GraphSnapshotLTE(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldLTE(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotContains applies the Contains predicate on the "graph_snapshot" field.
+func GraphSnapshotContains(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldContains(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotHasPrefix applies the HasPrefix predicate on the "graph_snapshot" field.
+func GraphSnapshotHasPrefix(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldHasPrefix(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotHasSuffix applies the HasSuffix predicate on the "graph_snapshot" field.
+func GraphSnapshotHasSuffix(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldHasSuffix(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotIsNil applies the IsNil predicate on the "graph_snapshot" field.
+func GraphSnapshotIsNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldIsNull(FieldGraphSnapshot))
+}
+
+// GraphSnapshotNotNil applies the NotNil predicate on the "graph_snapshot" field.
+func GraphSnapshotNotNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNotNull(FieldGraphSnapshot))
+}
+
+// GraphSnapshotEqualFold applies the EqualFold predicate on the "graph_snapshot" field.
+func GraphSnapshotEqualFold(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldEqualFold(FieldGraphSnapshot, v))
+}
+
+// GraphSnapshotContainsFold applies the ContainsFold predicate on the "graph_snapshot" field.
+func GraphSnapshotContainsFold(v string) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldContainsFold(FieldGraphSnapshot, v))
+}
+
 // StartedAtEQ applies the EQ predicate on the "started_at" field.
 func StartedAtEQ(v time.Time) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldEQ(FieldStartedAt, v))
@@ -965,6 +1060,56 @@ func TriggerSourceContainsFold(v string) predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(sql.FieldContainsFold(FieldTriggerSource, v))
 }
 
+// ParentExecutionIDEQ applies the EQ predicate on the "parent_execution_id" field.
+func ParentExecutionIDEQ(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldEQ(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDNEQ applies the NEQ predicate on the "parent_execution_id" field.
+func ParentExecutionIDNEQ(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNEQ(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDIn applies the In predicate on the "parent_execution_id" field.
+func ParentExecutionIDIn(vs ...uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldIn(FieldParentExecutionID, vs...))
+}
+
+// ParentExecutionIDNotIn applies the NotIn predicate on the "parent_execution_id" field.
+func ParentExecutionIDNotIn(vs ...uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNotIn(FieldParentExecutionID, vs...))
+}
+
+// ParentExecutionIDGT applies the GT predicate on the "parent_execution_id" field.
+func ParentExecutionIDGT(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldGT(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDGTE applies the GTE predicate on the "parent_execution_id" field.
+func ParentExecutionIDGTE(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldGTE(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDLT applies the LT predicate on the "parent_execution_id" field.
+func ParentExecutionIDLT(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldLT(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDLTE applies the LTE predicate on the "parent_execution_id" field.
+func ParentExecutionIDLTE(v uint64) predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldLTE(FieldParentExecutionID, v))
+}
+
+// ParentExecutionIDIsNil applies the IsNil predicate on the "parent_execution_id" field.
+func ParentExecutionIDIsNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldIsNull(FieldParentExecutionID))
+}
+
+// ParentExecutionIDNotNil applies the NotNil predicate on the "parent_execution_id" field.
+func ParentExecutionIDNotNil() predicate.WorkflowExecution {
+	return predicate.WorkflowExecution(sql.FieldNotNull(FieldParentExecutionID))
+}
+
 // HasApplication applies the HasEdge predicate on the "application" edge.
 func HasApplication() predicate.WorkflowExecution {
 	return predicate.WorkflowExecution(func(s *sql.Selector) {
@@ -36,6 +36,10 @@ const (
 	FieldOutput = "output"
 	// FieldContext holds the string denoting the context field in the database.
 	FieldContext = "context"
+	// FieldVariables holds the string denoting the variables field in the database.
+	FieldVariables = "variables"
+	// FieldGraphSnapshot holds the string denoting the graph_snapshot field in the database.
+	FieldGraphSnapshot = "graph_snapshot"
 	// FieldStartedAt holds the string denoting the started_at field in the database.
 	FieldStartedAt = "started_at"
 	// FieldFinishedAt holds the string denoting the finished_at field in the database.
@@ -54,6 +58,8 @@ const (
 	FieldTriggeredBy = "triggered_by"
 	// FieldTriggerSource holds the string denoting the trigger_source field in the database.
 	FieldTriggerSource = "trigger_source"
+	// FieldParentExecutionID holds the string denoting the parent_execution_id field in the database.
+	FieldParentExecutionID = "parent_execution_id"
 	// EdgeApplication holds the string denoting the application edge name in mutations.
 	EdgeApplication = "application"
 	// EdgeNodeExecutions holds the string denoting the node_executions edge name in mutations.
@@ -89,6 +95,8 @@ var Columns = []string{
 	FieldInput,
 	FieldOutput,
 	FieldContext,
+	FieldVariables,
+	FieldGraphSnapshot,
 	FieldStartedAt,
 	FieldFinishedAt,
 	FieldDurationMs,
@@ -98,6 +106,7 @@ var Columns = []string{
 	FieldErrorStack,
 	FieldTriggeredBy,
 	FieldTriggerSource,
+	FieldParentExecutionID,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -206,6 +215,11 @@ func ByStatus(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStatus, opts...).ToFunc()
 }
 
+// ByGraphSnapshot orders the results by the graph_snapshot field.
+func ByGraphSnapshot(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldGraphSnapshot, opts...).ToFunc()
+}
+
 // ByStartedAt orders the results by the started_at field.
 func ByStartedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldStartedAt, opts...).ToFunc()
@@ -251,6 +265,11 @@ func ByTriggerSource(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldTriggerSource, opts...).ToFunc()
 }
 
+// ByParentExecutionID orders the results by the parent_execution_id field.
+func ByParentExecutionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldParentExecutionID, opts...).ToFunc()
+}
+
 // ByApplicationField orders the results by application field.
 func ByApplicationField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
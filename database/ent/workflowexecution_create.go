@@ -122,6 +122,26 @@ func (_c *WorkflowExecutionCreate) SetContext(v map[string]interface{}) *Workflo
 	return _c
 }
 
+// SetVariables sets the "variables" field.
+func (_c *WorkflowExecutionCreate) SetVariables(v map[string]interface{}) *WorkflowExecutionCreate {
+	_c.mutation.SetVariables(v)
+	return _c
+}
+
+// SetGraphSnapshot sets the "graph_snapshot" field.
+func (_c *WorkflowExecutionCreate) SetGraphSnapshot(v string) *WorkflowExecutionCreate {
+	_c.mutation.SetGraphSnapshot(v)
+	return _c
+}
+
+// SetNillableGraphSnapshot sets the "graph_snapshot" field if the given value is not nil.
+func (_c *WorkflowExecutionCreate) SetNillableGraphSnapshot(v *string) *WorkflowExecutionCreate {
+	if v != nil {
+		_c.SetGraphSnapshot(*v)
+	}
+	return _c
+}
+
 // SetStartedAt sets the "started_at" field.
 func (_c *WorkflowExecutionCreate) SetStartedAt(v time.Time) *WorkflowExecutionCreate {
 	_c.mutation.SetStartedAt(v)
@@ -248,6 +268,20 @@ func (_c *WorkflowExecutionCreate) SetNillableTriggerSource(v *string) *Workflow
 	return _c
 }
 
+// SetParentExecutionID sets the "parent_execution_id" field.
+func (_c *WorkflowExecutionCreate) SetParentExecutionID(v uint64) *WorkflowExecutionCreate {
+	_c.mutation.SetParentExecutionID(v)
+	return _c
+}
+
+// SetNillableParentExecutionID sets the "parent_execution_id" field if the given value is not nil.
+func (_c *WorkflowExecutionCreate) SetNillableParentExecutionID(v *uint64) *WorkflowExecutionCreate {
+	if v != nil {
+		_c.SetParentExecutionID(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *WorkflowExecutionCreate) SetID(v uint64) *WorkflowExecutionCreate {
 	_c.mutation.SetID(v)
@@ -451,6 +485,14 @@ func (_c *WorkflowExecutionCreate) createSpec() (*WorkflowExecution, *sqlgraph.C
 		_spec.SetField(workflowexecution.FieldContext, field.TypeJSON, value)
 		_node.Context = value
 	}
+	if value, ok := _c.mutation.Variables(); ok {
+		_spec.SetField(workflowexecution.FieldVariables, field.TypeJSON, value)
+		_node.Variables = value
+	}
+	if value, ok := _c.mutation.GraphSnapshot(); ok {
+		_spec.SetField(workflowexecution.FieldGraphSnapshot, field.TypeString, value)
+		_node.GraphSnapshot = value
+	}
 	if value, ok := _c.mutation.StartedAt(); ok {
 		_spec.SetField(workflowexecution.FieldStartedAt, field.TypeTime, value)
 		_node.StartedAt = value
@@ -487,6 +529,10 @@ func (_c *WorkflowExecutionCreate) createSpec() (*WorkflowExecution, *sqlgraph.C
 		_spec.SetField(workflowexecution.FieldTriggerSource, field.TypeString, value)
 		_node.TriggerSource = value
 	}
+	if value, ok := _c.mutation.ParentExecutionID(); ok {
+		_spec.SetField(workflowexecution.FieldParentExecutionID, field.TypeUint64, value)
+		_node.ParentExecutionID = value
+	}
 	if nodes := _c.mutation.ApplicationIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
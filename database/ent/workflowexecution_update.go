@@ -168,6 +168,38 @@ func (_u *WorkflowExecutionUpdate) ClearContext() *WorkflowExecutionUpdate {
 	return _u
 }
 
+// SetVariables sets the "variables" field.
+func (_u *WorkflowExecutionUpdate) SetVariables(v map[string]interface{}) *WorkflowExecutionUpdate {
+	_u.mutation.SetVariables(v)
+	return _u
+}
+
+// ClearVariables clears the value of the "variables" field.
+func (_u *WorkflowExecutionUpdate) ClearVariables() *WorkflowExecutionUpdate {
+	_u.mutation.ClearVariables()
+	return _u
+}
+
+// SetGraphSnapshot sets the "graph_snapshot" field.
+func (_u *WorkflowExecutionUpdate) SetGraphSnapshot(v string) *WorkflowExecutionUpdate {
+	_u.mutation.SetGraphSnapshot(v)
+	return _u
+}
+
+// SetNillableGraphSnapshot sets the "graph_snapshot" field if the given value is not nil.
+func (_u *WorkflowExecutionUpdate) SetNillableGraphSnapshot(v *string) *WorkflowExecutionUpdate {
+	if v != nil {
+		_u.SetGraphSnapshot(*v)
+	}
+	return _u
+}
+
+// ClearGraphSnapshot clears the value of the "graph_snapshot" field.
+func (_u *WorkflowExecutionUpdate) ClearGraphSnapshot() *WorkflowExecutionUpdate {
+	_u.mutation.ClearGraphSnapshot()
+	return _u
+}
+
 // SetStartedAt sets the "started_at" field.
 func (_u *WorkflowExecutionUpdate) SetStartedAt(v time.Time) *WorkflowExecutionUpdate {
 	_u.mutation.SetStartedAt(v)
@@ -351,6 +383,33 @@ func (_u *WorkflowExecutionUpdate) ClearTriggerSource() *WorkflowExecutionUpdate
 	return _u
 }
 
+// SetParentExecutionID sets the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdate) SetParentExecutionID(v uint64) *WorkflowExecutionUpdate {
+	_u.mutation.ResetParentExecutionID()
+	_u.mutation.SetParentExecutionID(v)
+	return _u
+}
+
+// SetNillableParentExecutionID sets the "parent_execution_id" field if the given value is not nil.
+func (_u *WorkflowExecutionUpdate) SetNillableParentExecutionID(v *uint64) *WorkflowExecutionUpdate {
+	if v != nil {
+		_u.SetParentExecutionID(*v)
+	}
+	return _u
+}
+
+// AddParentExecutionID adds value to the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdate) AddParentExecutionID(v int64) *WorkflowExecutionUpdate {
+	_u.mutation.AddParentExecutionID(v)
+	return _u
+}
+
+// ClearParentExecutionID clears the value of the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdate) ClearParentExecutionID() *WorkflowExecutionUpdate {
+	_u.mutation.ClearParentExecutionID()
+	return _u
+}
+
 // SetApplication sets the "application" edge to the WorkflowApplication entity.
 func (_u *WorkflowExecutionUpdate) SetApplication(v *WorkflowApplication) *WorkflowExecutionUpdate {
 	return _u.SetApplicationID(v.ID)
@@ -520,6 +579,18 @@ func (_u *WorkflowExecutionUpdate) sqlSave(ctx context.Context) (_node int, err
 	if _u.mutation.ContextCleared() {
 		_spec.ClearField(workflowexecution.FieldContext, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.Variables(); ok {
+		_spec.SetField(workflowexecution.FieldVariables, field.TypeJSON, value)
+	}
+	if _u.mutation.VariablesCleared() {
+		_spec.ClearField(workflowexecution.FieldVariables, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.GraphSnapshot(); ok {
+		_spec.SetField(workflowexecution.FieldGraphSnapshot, field.TypeString, value)
+	}
+	if _u.mutation.GraphSnapshotCleared() {
+		_spec.ClearField(workflowexecution.FieldGraphSnapshot, field.TypeString)
+	}
 	if value, ok := _u.mutation.StartedAt(); ok {
 		_spec.SetField(workflowexecution.FieldStartedAt, field.TypeTime, value)
 	}
@@ -574,6 +645,15 @@ func (_u *WorkflowExecutionUpdate) sqlSave(ctx context.Context) (_node int, err
 	if _u.mutation.TriggerSourceCleared() {
 		_spec.ClearField(workflowexecution.FieldTriggerSource, field.TypeString)
 	}
+	if value, ok := _u.mutation.ParentExecutionID(); ok {
+		_spec.SetField(workflowexecution.FieldParentExecutionID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedParentExecutionID(); ok {
+		_spec.AddField(workflowexecution.FieldParentExecutionID, field.TypeUint64, value)
+	}
+	if _u.mutation.ParentExecutionIDCleared() {
+		_spec.ClearField(workflowexecution.FieldParentExecutionID, field.TypeUint64)
+	}
 	if _u.mutation.ApplicationCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -806,6 +886,38 @@ func (_u *WorkflowExecutionUpdateOne) ClearContext() *WorkflowExecutionUpdateOne
 	return _u
 }
 
+// SetVariables sets the "variables" field.
+func (_u *WorkflowExecutionUpdateOne) SetVariables(v map[string]interface{}) *WorkflowExecutionUpdateOne {
+	_u.mutation.SetVariables(v)
+	return _u
+}
+
+// ClearVariables clears the value of the "variables" field.
+func (_u *WorkflowExecutionUpdateOne) ClearVariables() *WorkflowExecutionUpdateOne {
+	_u.mutation.ClearVariables()
+	return _u
+}
+
+// SetGraphSnapshot sets the "graph_snapshot" field.
+func (_u *WorkflowExecutionUpdateOne) SetGraphSnapshot(v string) *WorkflowExecutionUpdateOne {
+	_u.mutation.SetGraphSnapshot(v)
+	return _u
+}
+
+// SetNillableGraphSnapshot sets the "graph_snapshot" field if the given value is not nil.
+func (_u *WorkflowExecutionUpdateOne) SetNillableGraphSnapshot(v *string) *WorkflowExecutionUpdateOne {
+	if v != nil {
+		_u.SetGraphSnapshot(*v)
+	}
+	return _u
+}
+
+// ClearGraphSnapshot clears the value of the "graph_snapshot" field.
+func (_u *WorkflowExecutionUpdateOne) ClearGraphSnapshot() *WorkflowExecutionUpdateOne {
+	_u.mutation.ClearGraphSnapshot()
+	return _u
+}
+
 // SetStartedAt sets the "started_at" field.
 func (_u *WorkflowExecutionUpdateOne) SetStartedAt(v time.Time) *WorkflowExecutionUpdateOne {
 	_u.mutation.SetStartedAt(v)
@@ -989,6 +1101,33 @@ func (_u *WorkflowExecutionUpdateOne) ClearTriggerSource() *WorkflowExecutionUpd
 	return _u
 }
 
+// SetParentExecutionID sets the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdateOne) SetParentExecutionID(v uint64) *WorkflowExecutionUpdateOne {
+	_u.mutation.ResetParentExecutionID()
+	_u.mutation.SetParentExecutionID(v)
+	return _u
+}
+
+// SetNillableParentExecutionID sets the "parent_execution_id" field if the given value is not nil.
+func (_u *WorkflowExecutionUpdateOne) SetNillableParentExecutionID(v *uint64) *WorkflowExecutionUpdateOne {
+	if v != nil {
+		_u.SetParentExecutionID(*v)
+	}
+	return _u
+}
+
+// AddParentExecutionID adds value to the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdateOne) AddParentExecutionID(v int64) *WorkflowExecutionUpdateOne {
+	_u.mutation.AddParentExecutionID(v)
+	return _u
+}
+
+// ClearParentExecutionID clears the value of the "parent_execution_id" field.
+func (_u *WorkflowExecutionUpdateOne) ClearParentExecutionID() *WorkflowExecutionUpdateOne {
+	_u.mutation.ClearParentExecutionID()
+	return _u
+}
+
 // SetApplication sets the "application" edge to the WorkflowApplication entity.
 func (_u *WorkflowExecutionUpdateOne) SetApplication(v *WorkflowApplication) *WorkflowExecutionUpdateOne {
 	return _u.SetApplicationID(v.ID)
@@ -1188,6 +1327,18 @@ func (_u *WorkflowExecutionUpdateOne) sqlSave(ctx context.Context) (_node *Workf
 	if _u.mutation.ContextCleared() {
 		_spec.ClearField(workflowexecution.FieldContext, field.TypeJSON)
 	}
+	if value, ok := _u.mutation.Variables(); ok {
+		_spec.SetField(workflowexecution.FieldVariables, field.TypeJSON, value)
+	}
+	if _u.mutation.VariablesCleared() {
+		_spec.ClearField(workflowexecution.FieldVariables, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.GraphSnapshot(); ok {
+		_spec.SetField(workflowexecution.FieldGraphSnapshot, field.TypeString, value)
+	}
+	if _u.mutation.GraphSnapshotCleared() {
+		_spec.ClearField(workflowexecution.FieldGraphSnapshot, field.TypeString)
+	}
 	if value, ok := _u.mutation.StartedAt(); ok {
 		_spec.SetField(workflowexecution.FieldStartedAt, field.TypeTime, value)
 	}
@@ -1242,6 +1393,15 @@ func (_u *WorkflowExecutionUpdateOne) sqlSave(ctx context.Context) (_node *Workf
 	if _u.mutation.TriggerSourceCleared() {
 		_spec.ClearField(workflowexecution.FieldTriggerSource, field.TypeString)
 	}
+	if value, ok := _u.mutation.ParentExecutionID(); ok {
+		_spec.SetField(workflowexecution.FieldParentExecutionID, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedParentExecutionID(); ok {
+		_spec.AddField(workflowexecution.FieldParentExecutionID, field.TypeUint64, value)
+	}
+	if _u.mutation.ParentExecutionIDCleared() {
+		_spec.ClearField(workflowexecution.FieldParentExecutionID, field.TypeUint64)
+	}
 	if _u.mutation.ApplicationCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
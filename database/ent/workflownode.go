@@ -68,6 +68,8 @@ type WorkflowNode struct {
 	PositionY float64 `json:"position_y,omitempty"`
 	// 节点颜色
 	Color string `json:"color,omitempty"`
+	// 是否启用，禁用的节点执行时被跳过并透传到下游节点
+	Enabled bool `json:"enabled,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the WorkflowNodeQuery when eager-loading is set.
 	Edges        WorkflowNodeEdges `json:"edges"`
@@ -137,7 +139,7 @@ func (*WorkflowNode) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case workflownode.FieldConfig, workflownode.FieldBranchNodes, workflownode.FieldParallelConfig, workflownode.FieldAPIConfig:
 			values[i] = new([]byte)
-		case workflownode.FieldAsync:
+		case workflownode.FieldAsync, workflownode.FieldEnabled:
 			values[i] = new(sql.NullBool)
 		case workflownode.FieldPositionX, workflownode.FieldPositionY:
 			values[i] = new(sql.NullFloat64)
@@ -320,6 +322,12 @@ func (_m *WorkflowNode) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.Color = value.String
 			}
+		case workflownode.FieldEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field enabled", values[i])
+			} else if value.Valid {
+				_m.Enabled = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -447,6 +455,9 @@ func (_m *WorkflowNode) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("color=")
 	builder.WriteString(_m.Color)
+	builder.WriteString(", ")
+	builder.WriteString("enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Enabled))
 	builder.WriteByte(')')
 	return builder.String()
 }
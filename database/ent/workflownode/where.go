@@ -150,6 +150,11 @@ func Color(v string) predicate.WorkflowNode {
 	return predicate.WorkflowNode(sql.FieldEQ(FieldColor, v))
 }
 
+// Enabled applies equality check predicate on the "enabled" field. It's identical to EnabledEQ.
+func Enabled(v bool) predicate.WorkflowNode {
+	return predicate.WorkflowNode(sql.FieldEQ(FieldEnabled, v))
+}
+
 // CreateTimeEQ applies the EQ predicate on the "create_time" field.
 func CreateTimeEQ(v time.Time) predicate.WorkflowNode {
 	return predicate.WorkflowNode(sql.FieldEQ(FieldCreateTime, v))
@@ -1160,6 +1165,16 @@ func ColorContainsFold(v string) predicate.WorkflowNode {
 	return predicate.WorkflowNode(sql.FieldContainsFold(FieldColor, v))
 }
 
+// EnabledEQ applies the EQ predicate on the "enabled" field.
+func EnabledEQ(v bool) predicate.WorkflowNode {
+	return predicate.WorkflowNode(sql.FieldEQ(FieldEnabled, v))
+}
+
+// EnabledNEQ applies the NEQ predicate on the "enabled" field.
+func EnabledNEQ(v bool) predicate.WorkflowNode {
+	return predicate.WorkflowNode(sql.FieldNEQ(FieldEnabled, v))
+}
+
 // HasApplication applies the HasEdge predicate on the "application" edge.
 func HasApplication() predicate.WorkflowNode {
 	return predicate.WorkflowNode(func(s *sql.Selector) {
@@ -64,6 +64,8 @@ const (
 	FieldPositionY = "position_y"
 	// FieldColor holds the string denoting the color field in the database.
 	FieldColor = "color"
+	// FieldEnabled holds the string denoting the enabled field in the database.
+	FieldEnabled = "enabled"
 	// EdgeApplication holds the string denoting the application edge name in mutations.
 	EdgeApplication = "application"
 	// EdgeExecutions holds the string denoting the executions edge name in mutations.
@@ -131,6 +133,7 @@ var Columns = []string{
 	FieldPositionX,
 	FieldPositionY,
 	FieldColor,
+	FieldEnabled,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -169,6 +172,8 @@ var (
 	DefaultPositionX float64
 	// DefaultPositionY holds the default value on creation for the "position_y" field.
 	DefaultPositionY float64
+	// DefaultEnabled holds the default value on creation for the "enabled" field.
+	DefaultEnabled bool
 )
 
 // Type defines the type for the "type" enum field.
@@ -310,6 +315,11 @@ func ByColor(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldColor, opts...).ToFunc()
 }
 
+// ByEnabled orders the results by the enabled field.
+func ByEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEnabled, opts...).ToFunc()
+}
+
 // ByApplicationField orders the results by application field.
 func ByApplicationField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
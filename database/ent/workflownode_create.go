@@ -303,6 +303,20 @@ func (_c *WorkflowNodeCreate) SetNillableColor(v *string) *WorkflowNodeCreate {
 	return _c
 }
 
+// SetEnabled sets the "enabled" field.
+func (_c *WorkflowNodeCreate) SetEnabled(v bool) *WorkflowNodeCreate {
+	_c.mutation.SetEnabled(v)
+	return _c
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_c *WorkflowNodeCreate) SetNillableEnabled(v *bool) *WorkflowNodeCreate {
+	if v != nil {
+		_c.SetEnabled(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *WorkflowNodeCreate) SetID(v uint64) *WorkflowNodeCreate {
 	_c.mutation.SetID(v)
@@ -430,6 +444,10 @@ func (_c *WorkflowNodeCreate) defaults() error {
 		v := workflownode.DefaultPositionY
 		_c.mutation.SetPositionY(v)
 	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		v := workflownode.DefaultEnabled
+		_c.mutation.SetEnabled(v)
+	}
 	return nil
 }
 
@@ -478,6 +496,9 @@ func (_c *WorkflowNodeCreate) check() error {
 	if _, ok := _c.mutation.PositionY(); !ok {
 		return &ValidationError{Name: "position_y", err: errors.New(`ent: missing required field "WorkflowNode.position_y"`)}
 	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "WorkflowNode.enabled"`)}
+	}
 	if len(_c.mutation.ApplicationIDs()) == 0 {
 		return &ValidationError{Name: "application", err: errors.New(`ent: missing required edge "WorkflowNode.application"`)}
 	}
@@ -605,6 +626,10 @@ func (_c *WorkflowNodeCreate) createSpec() (*WorkflowNode, *sqlgraph.CreateSpec)
 		_spec.SetField(workflownode.FieldColor, field.TypeString, value)
 		_node.Color = value
 	}
+	if value, ok := _c.mutation.Enabled(); ok {
+		_spec.SetField(workflownode.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
 	if nodes := _c.mutation.ApplicationIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -447,6 +447,20 @@ func (_u *WorkflowNodeUpdate) ClearColor() *WorkflowNodeUpdate {
 	return _u
 }
 
+// SetEnabled sets the "enabled" field.
+func (_u *WorkflowNodeUpdate) SetEnabled(v bool) *WorkflowNodeUpdate {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *WorkflowNodeUpdate) SetNillableEnabled(v *bool) *WorkflowNodeUpdate {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
 // SetApplication sets the "application" edge to the WorkflowApplication entity.
 func (_u *WorkflowNodeUpdate) SetApplication(v *WorkflowApplication) *WorkflowNodeUpdate {
 	return _u.SetApplicationID(v.ID)
@@ -772,6 +786,9 @@ func (_u *WorkflowNodeUpdate) sqlSave(ctx context.Context) (_node int, err error
 	if _u.mutation.ColorCleared() {
 		_spec.ClearField(workflownode.FieldColor, field.TypeString)
 	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(workflownode.FieldEnabled, field.TypeBool, value)
+	}
 	if _u.mutation.ApplicationCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -1372,6 +1389,20 @@ func (_u *WorkflowNodeUpdateOne) ClearColor() *WorkflowNodeUpdateOne {
 	return _u
 }
 
+// SetEnabled sets the "enabled" field.
+func (_u *WorkflowNodeUpdateOne) SetEnabled(v bool) *WorkflowNodeUpdateOne {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *WorkflowNodeUpdateOne) SetNillableEnabled(v *bool) *WorkflowNodeUpdateOne {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
 // SetApplication sets the "application" edge to the WorkflowApplication entity.
 func (_u *WorkflowNodeUpdateOne) SetApplication(v *WorkflowApplication) *WorkflowNodeUpdateOne {
 	return _u.SetApplicationID(v.ID)
@@ -1727,6 +1758,9 @@ func (_u *WorkflowNodeUpdateOne) sqlSave(ctx context.Context) (_node *WorkflowNo
 	if _u.mutation.ColorCleared() {
 		_spec.ClearField(workflownode.FieldColor, field.TypeString)
 	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(workflownode.FieldEnabled, field.TypeBool, value)
+	}
 	if _u.mutation.ApplicationCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -0,0 +1,281 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// WorkflowSchedule is the model entity for the WorkflowSchedule schema.
+type WorkflowSchedule struct {
+	config `json:"-"`
+	// ID of the ent.
+	// 主键ID
+	ID uint64 `json:"id,omitempty"`
+	// 创建时间
+	CreateTime time.Time `json:"create_time,omitempty"`
+	// 创建人ID
+	CreateBy uint64 `json:"create_by,omitempty"`
+	// 更新时间
+	UpdateTime time.Time `json:"update_time,omitempty"`
+	// 更新人ID
+	UpdateBy uint64 `json:"update_by,omitempty"`
+	// 删除时间
+	DeleteTime time.Time `json:"delete_time,omitempty"`
+	// 删除人ID
+	DeleteBy uint64 `json:"delete_by,omitempty"`
+	// 工作流应用ID
+	ApplicationID uint64 `json:"application_id,omitempty"`
+	// 调度名称
+	Name string `json:"name,omitempty"`
+	// Cron表达式
+	CronExpression string `json:"cron_expression,omitempty"`
+	// 执行输入
+	Input map[string]interface{} `json:"input,omitempty"`
+	// 是否启用
+	Enabled bool `json:"enabled,omitempty"`
+	// 上次触发时间
+	LastRunAt *time.Time `json:"last_run_at,omitempty"`
+	// 下次触发时间
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the WorkflowScheduleQuery when eager-loading is set.
+	Edges        WorkflowScheduleEdges `json:"edges"`
+	selectValues sql.SelectValues
+}
+
+// WorkflowScheduleEdges holds the relations/edges for other nodes in the graph.
+type WorkflowScheduleEdges struct {
+	// Application holds the value of the application edge.
+	Application *WorkflowApplication `json:"application,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// ApplicationOrErr returns the Application value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e WorkflowScheduleEdges) ApplicationOrErr() (*WorkflowApplication, error) {
+	if e.Application != nil {
+		return e.Application, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: workflowapplication.Label}
+	}
+	return nil, &NotLoadedError{edge: "application"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*WorkflowSchedule) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case workflowschedule.FieldInput:
+			values[i] = new([]byte)
+		case workflowschedule.FieldEnabled:
+			values[i] = new(sql.NullBool)
+		case workflowschedule.FieldID, workflowschedule.FieldCreateBy, workflowschedule.FieldUpdateBy, workflowschedule.FieldDeleteBy, workflowschedule.FieldApplicationID:
+			values[i] = new(sql.NullInt64)
+		case workflowschedule.FieldName, workflowschedule.FieldCronExpression:
+			values[i] = new(sql.NullString)
+		case workflowschedule.FieldCreateTime, workflowschedule.FieldUpdateTime, workflowschedule.FieldDeleteTime, workflowschedule.FieldLastRunAt, workflowschedule.FieldNextRunAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the WorkflowSchedule fields.
+func (_m *WorkflowSchedule) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case workflowschedule.FieldID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field id", values[i])
+			} else if value.Valid {
+				_m.ID = uint64(value.Int64)
+			}
+		case workflowschedule.FieldCreateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field create_time", values[i])
+			} else if value.Valid {
+				_m.CreateTime = value.Time
+			}
+		case workflowschedule.FieldCreateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field create_by", values[i])
+			} else if value.Valid {
+				_m.CreateBy = uint64(value.Int64)
+			}
+		case workflowschedule.FieldUpdateTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field update_time", values[i])
+			} else if value.Valid {
+				_m.UpdateTime = value.Time
+			}
+		case workflowschedule.FieldUpdateBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field update_by", values[i])
+			} else if value.Valid {
+				_m.UpdateBy = uint64(value.Int64)
+			}
+		case workflowschedule.FieldDeleteTime:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field delete_time", values[i])
+			} else if value.Valid {
+				_m.DeleteTime = value.Time
+			}
+		case workflowschedule.FieldDeleteBy:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field delete_by", values[i])
+			} else if value.Valid {
+				_m.DeleteBy = uint64(value.Int64)
+			}
+		case workflowschedule.FieldApplicationID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field application_id", values[i])
+			} else if value.Valid {
+				_m.ApplicationID = uint64(value.Int64)
+			}
+		case workflowschedule.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				_m.Name = value.String
+			}
+		case workflowschedule.FieldCronExpression:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field cron_expression", values[i])
+			} else if value.Valid {
+				_m.CronExpression = value.String
+			}
+		case workflowschedule.FieldInput:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field input", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &_m.Input); err != nil {
+					return fmt.Errorf("unmarshal field input: %w", err)
+				}
+			}
+		case workflowschedule.FieldEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field enabled", values[i])
+			} else if value.Valid {
+				_m.Enabled = value.Bool
+			}
+		case workflowschedule.FieldLastRunAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_run_at", values[i])
+			} else if value.Valid {
+				_m.LastRunAt = new(time.Time)
+				*_m.LastRunAt = value.Time
+			}
+		case workflowschedule.FieldNextRunAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field next_run_at", values[i])
+			} else if value.Valid {
+				_m.NextRunAt = new(time.Time)
+				*_m.NextRunAt = value.Time
+			}
+		default:
+			_m.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the WorkflowSchedule.
+// This includes values selected through modifiers, order, etc.
+func (_m *WorkflowSchedule) Value(name string) (ent.Value, error) {
+	return _m.selectValues.Get(name)
+}
+
+// QueryApplication queries the "application" edge of the WorkflowSchedule entity.
+func (_m *WorkflowSchedule) QueryApplication() *WorkflowApplicationQuery {
+	return NewWorkflowScheduleClient(_m.config).QueryApplication(_m)
+}
+
+// Update returns a builder for updating this WorkflowSchedule.
+// Note that you need to call WorkflowSchedule.Unwrap() before calling this method if this WorkflowSchedule
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (_m *WorkflowSchedule) Update() *WorkflowScheduleUpdateOne {
+	return NewWorkflowScheduleClient(_m.config).UpdateOne(_m)
+}
+
+// Unwrap unwraps the WorkflowSchedule entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (_m *WorkflowSchedule) Unwrap() *WorkflowSchedule {
+	_tx, ok := _m.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: WorkflowSchedule is not a transactional entity")
+	}
+	_m.config.driver = _tx.drv
+	return _m
+}
+
+// String implements the fmt.Stringer.
+func (_m *WorkflowSchedule) String() string {
+	var builder strings.Builder
+	builder.WriteString("WorkflowSchedule(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", _m.ID))
+	builder.WriteString("create_time=")
+	builder.WriteString(_m.CreateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("create_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.CreateBy))
+	builder.WriteString(", ")
+	builder.WriteString("update_time=")
+	builder.WriteString(_m.UpdateTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("update_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.UpdateBy))
+	builder.WriteString(", ")
+	builder.WriteString("delete_time=")
+	builder.WriteString(_m.DeleteTime.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("delete_by=")
+	builder.WriteString(fmt.Sprintf("%v", _m.DeleteBy))
+	builder.WriteString(", ")
+	builder.WriteString("application_id=")
+	builder.WriteString(fmt.Sprintf("%v", _m.ApplicationID))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(_m.Name)
+	builder.WriteString(", ")
+	builder.WriteString("cron_expression=")
+	builder.WriteString(_m.CronExpression)
+	builder.WriteString(", ")
+	builder.WriteString("input=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Input))
+	builder.WriteString(", ")
+	builder.WriteString("enabled=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Enabled))
+	builder.WriteString(", ")
+	if v := _m.LastRunAt; v != nil {
+		builder.WriteString("last_run_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := _m.NextRunAt; v != nil {
+		builder.WriteString("next_run_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// WorkflowSchedules is a parsable slice of WorkflowSchedule.
+type WorkflowSchedules []*WorkflowSchedule
@@ -0,0 +1,714 @@
+// Code generated by ent, DO NOT EDIT.
+
+package workflowschedule
+
+import (
+	"go-backend/database/ent/predicate"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+// ID filters vertices based on their ID field.
+func ID(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldID, id))
+}
+
+// IDEQ applies the EQ predicate on the ID field.
+func IDEQ(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldID, id))
+}
+
+// IDNEQ applies the NEQ predicate on the ID field.
+func IDNEQ(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldID, id))
+}
+
+// IDIn applies the In predicate on the ID field.
+func IDIn(ids ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldID, ids...))
+}
+
+// IDNotIn applies the NotIn predicate on the ID field.
+func IDNotIn(ids ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldID, ids...))
+}
+
+// IDGT applies the GT predicate on the ID field.
+func IDGT(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldID, id))
+}
+
+// IDGTE applies the GTE predicate on the ID field.
+func IDGTE(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldID, id))
+}
+
+// IDLT applies the LT predicate on the ID field.
+func IDLT(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldID, id))
+}
+
+// IDLTE applies the LTE predicate on the ID field.
+func IDLTE(id uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldID, id))
+}
+
+// CreateTime applies equality check predicate on the "create_time" field. It's identical to CreateTimeEQ.
+func CreateTime(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateBy applies equality check predicate on the "create_by" field. It's identical to CreateByEQ.
+func CreateBy(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// UpdateTime applies equality check predicate on the "update_time" field. It's identical to UpdateTimeEQ.
+func UpdateTime(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateBy applies equality check predicate on the "update_by" field. It's identical to UpdateByEQ.
+func UpdateBy(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// DeleteTime applies equality check predicate on the "delete_time" field. It's identical to DeleteTimeEQ.
+func DeleteTime(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldDeleteTime, v))
+}
+
+// DeleteBy applies equality check predicate on the "delete_by" field. It's identical to DeleteByEQ.
+func DeleteBy(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldDeleteBy, v))
+}
+
+// ApplicationID applies equality check predicate on the "application_id" field. It's identical to ApplicationIDEQ.
+func ApplicationID(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldApplicationID, v))
+}
+
+// Name applies equality check predicate on the "name" field. It's identical to NameEQ.
+func Name(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldName, v))
+}
+
+// CronExpression applies equality check predicate on the "cron_expression" field. It's identical to CronExpressionEQ.
+func CronExpression(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCronExpression, v))
+}
+
+// Enabled applies equality check predicate on the "enabled" field. It's identical to EnabledEQ.
+func Enabled(v bool) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldEnabled, v))
+}
+
+// LastRunAt applies equality check predicate on the "last_run_at" field. It's identical to LastRunAtEQ.
+func LastRunAt(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldLastRunAt, v))
+}
+
+// NextRunAt applies equality check predicate on the "next_run_at" field. It's identical to NextRunAtEQ.
+func NextRunAt(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldNextRunAt, v))
+}
+
+// CreateTimeEQ applies the EQ predicate on the "create_time" field.
+func CreateTimeEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCreateTime, v))
+}
+
+// CreateTimeNEQ applies the NEQ predicate on the "create_time" field.
+func CreateTimeNEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldCreateTime, v))
+}
+
+// CreateTimeIn applies the In predicate on the "create_time" field.
+func CreateTimeIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeNotIn applies the NotIn predicate on the "create_time" field.
+func CreateTimeNotIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldCreateTime, vs...))
+}
+
+// CreateTimeGT applies the GT predicate on the "create_time" field.
+func CreateTimeGT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldCreateTime, v))
+}
+
+// CreateTimeGTE applies the GTE predicate on the "create_time" field.
+func CreateTimeGTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldCreateTime, v))
+}
+
+// CreateTimeLT applies the LT predicate on the "create_time" field.
+func CreateTimeLT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldCreateTime, v))
+}
+
+// CreateTimeLTE applies the LTE predicate on the "create_time" field.
+func CreateTimeLTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldCreateTime, v))
+}
+
+// CreateByEQ applies the EQ predicate on the "create_by" field.
+func CreateByEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCreateBy, v))
+}
+
+// CreateByNEQ applies the NEQ predicate on the "create_by" field.
+func CreateByNEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldCreateBy, v))
+}
+
+// CreateByIn applies the In predicate on the "create_by" field.
+func CreateByIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldCreateBy, vs...))
+}
+
+// CreateByNotIn applies the NotIn predicate on the "create_by" field.
+func CreateByNotIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldCreateBy, vs...))
+}
+
+// CreateByGT applies the GT predicate on the "create_by" field.
+func CreateByGT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldCreateBy, v))
+}
+
+// CreateByGTE applies the GTE predicate on the "create_by" field.
+func CreateByGTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldCreateBy, v))
+}
+
+// CreateByLT applies the LT predicate on the "create_by" field.
+func CreateByLT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldCreateBy, v))
+}
+
+// CreateByLTE applies the LTE predicate on the "create_by" field.
+func CreateByLTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldCreateBy, v))
+}
+
+// CreateByIsNil applies the IsNil predicate on the "create_by" field.
+func CreateByIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldCreateBy))
+}
+
+// CreateByNotNil applies the NotNil predicate on the "create_by" field.
+func CreateByNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldCreateBy))
+}
+
+// UpdateTimeEQ applies the EQ predicate on the "update_time" field.
+func UpdateTimeEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeNEQ applies the NEQ predicate on the "update_time" field.
+func UpdateTimeNEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldUpdateTime, v))
+}
+
+// UpdateTimeIn applies the In predicate on the "update_time" field.
+func UpdateTimeIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeNotIn applies the NotIn predicate on the "update_time" field.
+func UpdateTimeNotIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldUpdateTime, vs...))
+}
+
+// UpdateTimeGT applies the GT predicate on the "update_time" field.
+func UpdateTimeGT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldUpdateTime, v))
+}
+
+// UpdateTimeGTE applies the GTE predicate on the "update_time" field.
+func UpdateTimeGTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldUpdateTime, v))
+}
+
+// UpdateTimeLT applies the LT predicate on the "update_time" field.
+func UpdateTimeLT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldUpdateTime, v))
+}
+
+// UpdateTimeLTE applies the LTE predicate on the "update_time" field.
+func UpdateTimeLTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldUpdateTime, v))
+}
+
+// UpdateByEQ applies the EQ predicate on the "update_by" field.
+func UpdateByEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldUpdateBy, v))
+}
+
+// UpdateByNEQ applies the NEQ predicate on the "update_by" field.
+func UpdateByNEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldUpdateBy, v))
+}
+
+// UpdateByIn applies the In predicate on the "update_by" field.
+func UpdateByIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByNotIn applies the NotIn predicate on the "update_by" field.
+func UpdateByNotIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldUpdateBy, vs...))
+}
+
+// UpdateByGT applies the GT predicate on the "update_by" field.
+func UpdateByGT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldUpdateBy, v))
+}
+
+// UpdateByGTE applies the GTE predicate on the "update_by" field.
+func UpdateByGTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldUpdateBy, v))
+}
+
+// UpdateByLT applies the LT predicate on the "update_by" field.
+func UpdateByLT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldUpdateBy, v))
+}
+
+// UpdateByLTE applies the LTE predicate on the "update_by" field.
+func UpdateByLTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldUpdateBy, v))
+}
+
+// UpdateByIsNil applies the IsNil predicate on the "update_by" field.
+func UpdateByIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldUpdateBy))
+}
+
+// UpdateByNotNil applies the NotNil predicate on the "update_by" field.
+func UpdateByNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldUpdateBy))
+}
+
+// DeleteTimeEQ applies the EQ predicate on the "delete_time" field.
+func DeleteTimeEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldDeleteTime, v))
+}
+
+// DeleteTimeNEQ applies the NEQ predicate on the "delete_time" field.
+func DeleteTimeNEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldDeleteTime, v))
+}
+
+// DeleteTimeIn applies the In predicate on the "delete_time" field.
+func DeleteTimeIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldDeleteTime, vs...))
+}
+
+// DeleteTimeNotIn applies the NotIn predicate on the "delete_time" field.
+func DeleteTimeNotIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldDeleteTime, vs...))
+}
+
+// DeleteTimeGT applies the GT predicate on the "delete_time" field.
+func DeleteTimeGT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldDeleteTime, v))
+}
+
+// DeleteTimeGTE applies the GTE predicate on the "delete_time" field.
+func DeleteTimeGTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldDeleteTime, v))
+}
+
+// DeleteTimeLT applies the LT predicate on the "delete_time" field.
+func DeleteTimeLT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldDeleteTime, v))
+}
+
+// DeleteTimeLTE applies the LTE predicate on the "delete_time" field.
+func DeleteTimeLTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldDeleteTime, v))
+}
+
+// DeleteTimeIsNil applies the IsNil predicate on the "delete_time" field.
+func DeleteTimeIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldDeleteTime))
+}
+
+// DeleteTimeNotNil applies the NotNil predicate on the "delete_time" field.
+func DeleteTimeNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldDeleteTime))
+}
+
+// DeleteByEQ applies the EQ predicate on the "delete_by" field.
+func DeleteByEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldDeleteBy, v))
+}
+
+// DeleteByNEQ applies the NEQ predicate on the "delete_by" field.
+func DeleteByNEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldDeleteBy, v))
+}
+
+// DeleteByIn applies the In predicate on the "delete_by" field.
+func DeleteByIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldDeleteBy, vs...))
+}
+
+// DeleteByNotIn applies the NotIn predicate on the "delete_by" field.
+func DeleteByNotIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldDeleteBy, vs...))
+}
+
+// DeleteByGT applies the GT predicate on the "delete_by" field.
+func DeleteByGT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldDeleteBy, v))
+}
+
+// DeleteByGTE applies the GTE predicate on the "delete_by" field.
+func DeleteByGTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldDeleteBy, v))
+}
+
+// DeleteByLT applies the LT predicate on the "delete_by" field.
+func DeleteByLT(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldDeleteBy, v))
+}
+
+// DeleteByLTE applies the LTE predicate on the "delete_by" field.
+func DeleteByLTE(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldDeleteBy, v))
+}
+
+// DeleteByIsNil applies the IsNil predicate on the "delete_by" field.
+func DeleteByIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldDeleteBy))
+}
+
+// DeleteByNotNil applies the NotNil predicate on the "delete_by" field.
+func DeleteByNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldDeleteBy))
+}
+
+// ApplicationIDEQ applies the EQ predicate on the "application_id" field.
+func ApplicationIDEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldApplicationID, v))
+}
+
+// ApplicationIDNEQ applies the NEQ predicate on the "application_id" field.
+func ApplicationIDNEQ(v uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldApplicationID, v))
+}
+
+// ApplicationIDIn applies the In predicate on the "application_id" field.
+func ApplicationIDIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldApplicationID, vs...))
+}
+
+// ApplicationIDNotIn applies the NotIn predicate on the "application_id" field.
+func ApplicationIDNotIn(vs ...uint64) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldApplicationID, vs...))
+}
+
+// NameEQ applies the EQ predicate on the "name" field.
+func NameEQ(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldName, v))
+}
+
+// NameNEQ applies the NEQ predicate on the "name" field.
+func NameNEQ(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldName, v))
+}
+
+// NameIn applies the In predicate on the "name" field.
+func NameIn(vs ...string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldName, vs...))
+}
+
+// NameNotIn applies the NotIn predicate on the "name" field.
+func NameNotIn(vs ...string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldName, vs...))
+}
+
+// NameGT applies the GT predicate on the "name" field.
+func NameGT(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldName, v))
+}
+
+// NameGTE applies the GTE predicate on the "name" field.
+func NameGTE(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldName, v))
+}
+
+// NameLT applies the LT predicate on the "name" field.
+func NameLT(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldName, v))
+}
+
+// NameLTE applies the LTE predicate on the "name" field.
+func NameLTE(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldName, v))
+}
+
+// NameContains applies the Contains predicate on the "name" field.
+func NameContains(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldContains(FieldName, v))
+}
+
+// NameHasPrefix applies the HasPrefix predicate on the "name" field.
+func NameHasPrefix(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldHasPrefix(FieldName, v))
+}
+
+// NameHasSuffix applies the HasSuffix predicate on the "name" field.
+func NameHasSuffix(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldHasSuffix(FieldName, v))
+}
+
+// NameIsNil applies the IsNil predicate on the "name" field.
+func NameIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldName))
+}
+
+// NameNotNil applies the NotNil predicate on the "name" field.
+func NameNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldName))
+}
+
+// NameEqualFold applies the EqualFold predicate on the "name" field.
+func NameEqualFold(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEqualFold(FieldName, v))
+}
+
+// NameContainsFold applies the ContainsFold predicate on the "name" field.
+func NameContainsFold(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldContainsFold(FieldName, v))
+}
+
+// CronExpressionEQ applies the EQ predicate on the "cron_expression" field.
+func CronExpressionEQ(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldCronExpression, v))
+}
+
+// CronExpressionNEQ applies the NEQ predicate on the "cron_expression" field.
+func CronExpressionNEQ(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldCronExpression, v))
+}
+
+// CronExpressionIn applies the In predicate on the "cron_expression" field.
+func CronExpressionIn(vs ...string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldCronExpression, vs...))
+}
+
+// CronExpressionNotIn applies the NotIn predicate on the "cron_expression" field.
+func CronExpressionNotIn(vs ...string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldCronExpression, vs...))
+}
+
+// CronExpressionGT applies the GT predicate on the "cron_expression" field.
+func CronExpressionGT(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldCronExpression, v))
+}
+
+// CronExpressionGTE applies the GTE predicate on the "cron_expression" field.
+func CronExpressionGTE(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldCronExpression, v))
+}
+
+// CronExpressionLT applies the LT predicate on the "cron_expression" field.
+func CronExpressionLT(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldCronExpression, v))
+}
+
+// CronExpressionLTE applies the LTE predicate on the "cron_expression" field.
+func CronExpressionLTE(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldCronExpression, v))
+}
+
+// CronExpressionContains applies the Contains predicate on the "cron_expression" field.
+func CronExpressionContains(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldContains(FieldCronExpression, v))
+}
+
+// CronExpressionHasPrefix applies the HasPrefix predicate on the "cron_expression" field.
+func CronExpressionHasPrefix(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldHasPrefix(FieldCronExpression, v))
+}
+
+// CronExpressionHasSuffix applies the HasSuffix predicate on the "cron_expression" field.
+func CronExpressionHasSuffix(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldHasSuffix(FieldCronExpression, v))
+}
+
+// CronExpressionEqualFold applies the EqualFold predicate on the "cron_expression" field.
+func CronExpressionEqualFold(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEqualFold(FieldCronExpression, v))
+}
+
+// CronExpressionContainsFold applies the ContainsFold predicate on the "cron_expression" field.
+func CronExpressionContainsFold(v string) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldContainsFold(FieldCronExpression, v))
+}
+
+// InputIsNil applies the IsNil predicate on the "input" field.
+func InputIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldInput))
+}
+
+// InputNotNil applies the NotNil predicate on the "input" field.
+func InputNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldInput))
+}
+
+// EnabledEQ applies the EQ predicate on the "enabled" field.
+func EnabledEQ(v bool) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldEnabled, v))
+}
+
+// EnabledNEQ applies the NEQ predicate on the "enabled" field.
+func EnabledNEQ(v bool) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldEnabled, v))
+}
+
+// LastRunAtEQ applies the EQ predicate on the "last_run_at" field.
+func LastRunAtEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldLastRunAt, v))
+}
+
+// LastRunAtNEQ applies the NEQ predicate on the "last_run_at" field.
+func LastRunAtNEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldLastRunAt, v))
+}
+
+// LastRunAtIn applies the In predicate on the "last_run_at" field.
+func LastRunAtIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldLastRunAt, vs...))
+}
+
+// LastRunAtNotIn applies the NotIn predicate on the "last_run_at" field.
+func LastRunAtNotIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldLastRunAt, vs...))
+}
+
+// LastRunAtGT applies the GT predicate on the "last_run_at" field.
+func LastRunAtGT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldLastRunAt, v))
+}
+
+// LastRunAtGTE applies the GTE predicate on the "last_run_at" field.
+func LastRunAtGTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldLastRunAt, v))
+}
+
+// LastRunAtLT applies the LT predicate on the "last_run_at" field.
+func LastRunAtLT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldLastRunAt, v))
+}
+
+// LastRunAtLTE applies the LTE predicate on the "last_run_at" field.
+func LastRunAtLTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldLastRunAt, v))
+}
+
+// LastRunAtIsNil applies the IsNil predicate on the "last_run_at" field.
+func LastRunAtIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldLastRunAt))
+}
+
+// LastRunAtNotNil applies the NotNil predicate on the "last_run_at" field.
+func LastRunAtNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldLastRunAt))
+}
+
+// NextRunAtEQ applies the EQ predicate on the "next_run_at" field.
+func NextRunAtEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldEQ(FieldNextRunAt, v))
+}
+
+// NextRunAtNEQ applies the NEQ predicate on the "next_run_at" field.
+func NextRunAtNEQ(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNEQ(FieldNextRunAt, v))
+}
+
+// NextRunAtIn applies the In predicate on the "next_run_at" field.
+func NextRunAtIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIn(FieldNextRunAt, vs...))
+}
+
+// NextRunAtNotIn applies the NotIn predicate on the "next_run_at" field.
+func NextRunAtNotIn(vs ...time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotIn(FieldNextRunAt, vs...))
+}
+
+// NextRunAtGT applies the GT predicate on the "next_run_at" field.
+func NextRunAtGT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGT(FieldNextRunAt, v))
+}
+
+// NextRunAtGTE applies the GTE predicate on the "next_run_at" field.
+func NextRunAtGTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldGTE(FieldNextRunAt, v))
+}
+
+// NextRunAtLT applies the LT predicate on the "next_run_at" field.
+func NextRunAtLT(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLT(FieldNextRunAt, v))
+}
+
+// NextRunAtLTE applies the LTE predicate on the "next_run_at" field.
+func NextRunAtLTE(v time.Time) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldLTE(FieldNextRunAt, v))
+}
+
+// NextRunAtIsNil applies the IsNil predicate on the "next_run_at" field.
+func NextRunAtIsNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldIsNull(FieldNextRunAt))
+}
+
+// NextRunAtNotNil applies the NotNil predicate on the "next_run_at" field.
+func NextRunAtNotNil() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.FieldNotNull(FieldNextRunAt))
+}
+
+// HasApplication applies the HasEdge predicate on the "application" edge.
+func HasApplication() predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, ApplicationTable, ApplicationColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasApplicationWith applies the HasEdge predicate on the "application" edge with a given conditions (other predicates).
+func HasApplicationWith(preds ...predicate.WorkflowApplication) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(func(s *sql.Selector) {
+		step := newApplicationStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// And groups predicates with the AND operator between them.
+func And(predicates ...predicate.WorkflowSchedule) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.AndPredicates(predicates...))
+}
+
+// Or groups predicates with the OR operator between them.
+func Or(predicates ...predicate.WorkflowSchedule) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.OrPredicates(predicates...))
+}
+
+// Not applies the not operator on the given predicate.
+func Not(p predicate.WorkflowSchedule) predicate.WorkflowSchedule {
+	return predicate.WorkflowSchedule(sql.NotPredicates(p))
+}
@@ -0,0 +1,185 @@
+// Code generated by ent, DO NOT EDIT.
+
+package workflowschedule
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+)
+
+const (
+	// Label holds the string label denoting the workflowschedule type in the database.
+	Label = "workflow_schedule"
+	// FieldID holds the string denoting the id field in the database.
+	FieldID = "id"
+	// FieldCreateTime holds the string denoting the create_time field in the database.
+	FieldCreateTime = "create_time"
+	// FieldCreateBy holds the string denoting the create_by field in the database.
+	FieldCreateBy = "create_by"
+	// FieldUpdateTime holds the string denoting the update_time field in the database.
+	FieldUpdateTime = "update_time"
+	// FieldUpdateBy holds the string denoting the update_by field in the database.
+	FieldUpdateBy = "update_by"
+	// FieldDeleteTime holds the string denoting the delete_time field in the database.
+	FieldDeleteTime = "delete_time"
+	// FieldDeleteBy holds the string denoting the delete_by field in the database.
+	FieldDeleteBy = "delete_by"
+	// FieldApplicationID holds the string denoting the application_id field in the database.
+	FieldApplicationID = "application_id"
+	// FieldName holds the string denoting the name field in the database.
+	FieldName = "name"
+	// FieldCronExpression holds the string denoting the cron_expression field in the database.
+	FieldCronExpression = "cron_expression"
+	// FieldInput holds the string denoting the input field in the database.
+	FieldInput = "input"
+	// FieldEnabled holds the string denoting the enabled field in the database.
+	FieldEnabled = "enabled"
+	// FieldLastRunAt holds the string denoting the last_run_at field in the database.
+	FieldLastRunAt = "last_run_at"
+	// FieldNextRunAt holds the string denoting the next_run_at field in the database.
+	FieldNextRunAt = "next_run_at"
+	// EdgeApplication holds the string denoting the application edge name in mutations.
+	EdgeApplication = "application"
+	// Table holds the table name of the workflowschedule in the database.
+	Table = "workflow_schedules"
+	// ApplicationTable is the table that holds the application relation/edge.
+	ApplicationTable = "workflow_schedules"
+	// ApplicationInverseTable is the table name for the WorkflowApplication entity.
+	// It exists in this package in order to avoid circular dependency with the "workflowapplication" package.
+	ApplicationInverseTable = "workflow_applications"
+	// ApplicationColumn is the table column denoting the application relation/edge.
+	ApplicationColumn = "application_id"
+)
+
+// Columns holds all SQL columns for workflowschedule fields.
+var Columns = []string{
+	FieldID,
+	FieldCreateTime,
+	FieldCreateBy,
+	FieldUpdateTime,
+	FieldUpdateBy,
+	FieldDeleteTime,
+	FieldDeleteBy,
+	FieldApplicationID,
+	FieldName,
+	FieldCronExpression,
+	FieldInput,
+	FieldEnabled,
+	FieldLastRunAt,
+	FieldNextRunAt,
+}
+
+// ValidColumn reports if the column name is valid (part of the table columns).
+func ValidColumn(column string) bool {
+	for i := range Columns {
+		if column == Columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "go-backend/database/ent/runtime"
+var (
+	Hooks        [3]ent.Hook
+	Interceptors [1]ent.Interceptor
+	// DefaultCreateTime holds the default value on creation for the "create_time" field.
+	DefaultCreateTime func() time.Time
+	// DefaultUpdateTime holds the default value on creation for the "update_time" field.
+	DefaultUpdateTime func() time.Time
+	// UpdateDefaultUpdateTime holds the default value on update for the "update_time" field.
+	UpdateDefaultUpdateTime func() time.Time
+	// CronExpressionValidator is a validator for the "cron_expression" field. It is called by the builders before save.
+	CronExpressionValidator func(string) error
+	// DefaultEnabled holds the default value on creation for the "enabled" field.
+	DefaultEnabled bool
+)
+
+// OrderOption defines the ordering options for the WorkflowSchedule queries.
+type OrderOption func(*sql.Selector)
+
+// ByID orders the results by the id field.
+func ByID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldID, opts...).ToFunc()
+}
+
+// ByCreateTime orders the results by the create_time field.
+func ByCreateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateTime, opts...).ToFunc()
+}
+
+// ByCreateBy orders the results by the create_by field.
+func ByCreateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCreateBy, opts...).ToFunc()
+}
+
+// ByUpdateTime orders the results by the update_time field.
+func ByUpdateTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateTime, opts...).ToFunc()
+}
+
+// ByUpdateBy orders the results by the update_by field.
+func ByUpdateBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldUpdateBy, opts...).ToFunc()
+}
+
+// ByDeleteTime orders the results by the delete_time field.
+func ByDeleteTime(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeleteTime, opts...).ToFunc()
+}
+
+// ByDeleteBy orders the results by the delete_by field.
+func ByDeleteBy(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldDeleteBy, opts...).ToFunc()
+}
+
+// ByApplicationID orders the results by the application_id field.
+func ByApplicationID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldApplicationID, opts...).ToFunc()
+}
+
+// ByName orders the results by the name field.
+func ByName(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldName, opts...).ToFunc()
+}
+
+// ByCronExpression orders the results by the cron_expression field.
+func ByCronExpression(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldCronExpression, opts...).ToFunc()
+}
+
+// ByEnabled orders the results by the enabled field.
+func ByEnabled(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEnabled, opts...).ToFunc()
+}
+
+// ByLastRunAt orders the results by the last_run_at field.
+func ByLastRunAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLastRunAt, opts...).ToFunc()
+}
+
+// ByNextRunAt orders the results by the next_run_at field.
+func ByNextRunAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldNextRunAt, opts...).ToFunc()
+}
+
+// ByApplicationField orders the results by application field.
+func ByApplicationField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newApplicationStep(), sql.OrderByField(field, opts...))
+	}
+}
+func newApplicationStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ApplicationInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, ApplicationTable, ApplicationColumn),
+	)
+}
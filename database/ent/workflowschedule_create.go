@@ -0,0 +1,462 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowScheduleCreate is the builder for creating a WorkflowSchedule entity.
+type WorkflowScheduleCreate struct {
+	config
+	mutation *WorkflowScheduleMutation
+	hooks    []Hook
+}
+
+// SetCreateTime sets the "create_time" field.
+func (_c *WorkflowScheduleCreate) SetCreateTime(v time.Time) *WorkflowScheduleCreate {
+	_c.mutation.SetCreateTime(v)
+	return _c
+}
+
+// SetNillableCreateTime sets the "create_time" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableCreateTime(v *time.Time) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetCreateTime(*v)
+	}
+	return _c
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_c *WorkflowScheduleCreate) SetCreateBy(v uint64) *WorkflowScheduleCreate {
+	_c.mutation.SetCreateBy(v)
+	return _c
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableCreateBy(v *uint64) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetCreateBy(*v)
+	}
+	return _c
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_c *WorkflowScheduleCreate) SetUpdateTime(v time.Time) *WorkflowScheduleCreate {
+	_c.mutation.SetUpdateTime(v)
+	return _c
+}
+
+// SetNillableUpdateTime sets the "update_time" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableUpdateTime(v *time.Time) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetUpdateTime(*v)
+	}
+	return _c
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_c *WorkflowScheduleCreate) SetUpdateBy(v uint64) *WorkflowScheduleCreate {
+	_c.mutation.SetUpdateBy(v)
+	return _c
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableUpdateBy(v *uint64) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetUpdateBy(*v)
+	}
+	return _c
+}
+
+// SetDeleteTime sets the "delete_time" field.
+func (_c *WorkflowScheduleCreate) SetDeleteTime(v time.Time) *WorkflowScheduleCreate {
+	_c.mutation.SetDeleteTime(v)
+	return _c
+}
+
+// SetNillableDeleteTime sets the "delete_time" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableDeleteTime(v *time.Time) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetDeleteTime(*v)
+	}
+	return _c
+}
+
+// SetDeleteBy sets the "delete_by" field.
+func (_c *WorkflowScheduleCreate) SetDeleteBy(v uint64) *WorkflowScheduleCreate {
+	_c.mutation.SetDeleteBy(v)
+	return _c
+}
+
+// SetNillableDeleteBy sets the "delete_by" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableDeleteBy(v *uint64) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetDeleteBy(*v)
+	}
+	return _c
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_c *WorkflowScheduleCreate) SetApplicationID(v uint64) *WorkflowScheduleCreate {
+	_c.mutation.SetApplicationID(v)
+	return _c
+}
+
+// SetName sets the "name" field.
+func (_c *WorkflowScheduleCreate) SetName(v string) *WorkflowScheduleCreate {
+	_c.mutation.SetName(v)
+	return _c
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableName(v *string) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetName(*v)
+	}
+	return _c
+}
+
+// SetCronExpression sets the "cron_expression" field.
+func (_c *WorkflowScheduleCreate) SetCronExpression(v string) *WorkflowScheduleCreate {
+	_c.mutation.SetCronExpression(v)
+	return _c
+}
+
+// SetInput sets the "input" field.
+func (_c *WorkflowScheduleCreate) SetInput(v map[string]interface{}) *WorkflowScheduleCreate {
+	_c.mutation.SetInput(v)
+	return _c
+}
+
+// SetEnabled sets the "enabled" field.
+func (_c *WorkflowScheduleCreate) SetEnabled(v bool) *WorkflowScheduleCreate {
+	_c.mutation.SetEnabled(v)
+	return _c
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableEnabled(v *bool) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetEnabled(*v)
+	}
+	return _c
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (_c *WorkflowScheduleCreate) SetLastRunAt(v time.Time) *WorkflowScheduleCreate {
+	_c.mutation.SetLastRunAt(v)
+	return _c
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableLastRunAt(v *time.Time) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetLastRunAt(*v)
+	}
+	return _c
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (_c *WorkflowScheduleCreate) SetNextRunAt(v time.Time) *WorkflowScheduleCreate {
+	_c.mutation.SetNextRunAt(v)
+	return _c
+}
+
+// SetNillableNextRunAt sets the "next_run_at" field if the given value is not nil.
+func (_c *WorkflowScheduleCreate) SetNillableNextRunAt(v *time.Time) *WorkflowScheduleCreate {
+	if v != nil {
+		_c.SetNextRunAt(*v)
+	}
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *WorkflowScheduleCreate) SetID(v uint64) *WorkflowScheduleCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_c *WorkflowScheduleCreate) SetApplication(v *WorkflowApplication) *WorkflowScheduleCreate {
+	return _c.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowScheduleMutation object of the builder.
+func (_c *WorkflowScheduleCreate) Mutation() *WorkflowScheduleMutation {
+	return _c.mutation
+}
+
+// Save creates the WorkflowSchedule in the database.
+func (_c *WorkflowScheduleCreate) Save(ctx context.Context) (*WorkflowSchedule, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WorkflowScheduleCreate) SaveX(ctx context.Context) *WorkflowSchedule {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowScheduleCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowScheduleCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WorkflowScheduleCreate) defaults() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		if workflowschedule.DefaultCreateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowschedule.DefaultCreateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowschedule.DefaultCreateTime()
+		_c.mutation.SetCreateTime(v)
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		if workflowschedule.DefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowschedule.DefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowschedule.DefaultUpdateTime()
+		_c.mutation.SetUpdateTime(v)
+	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		v := workflowschedule.DefaultEnabled
+		_c.mutation.SetEnabled(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WorkflowScheduleCreate) check() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		return &ValidationError{Name: "create_time", err: errors.New(`ent: missing required field "WorkflowSchedule.create_time"`)}
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		return &ValidationError{Name: "update_time", err: errors.New(`ent: missing required field "WorkflowSchedule.update_time"`)}
+	}
+	if _, ok := _c.mutation.ApplicationID(); !ok {
+		return &ValidationError{Name: "application_id", err: errors.New(`ent: missing required field "WorkflowSchedule.application_id"`)}
+	}
+	if _, ok := _c.mutation.CronExpression(); !ok {
+		return &ValidationError{Name: "cron_expression", err: errors.New(`ent: missing required field "WorkflowSchedule.cron_expression"`)}
+	}
+	if v, ok := _c.mutation.CronExpression(); ok {
+		if err := workflowschedule.CronExpressionValidator(v); err != nil {
+			return &ValidationError{Name: "cron_expression", err: fmt.Errorf(`ent: validator failed for field "WorkflowSchedule.cron_expression": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Enabled(); !ok {
+		return &ValidationError{Name: "enabled", err: errors.New(`ent: missing required field "WorkflowSchedule.enabled"`)}
+	}
+	if len(_c.mutation.ApplicationIDs()) == 0 {
+		return &ValidationError{Name: "application", err: errors.New(`ent: missing required edge "WorkflowSchedule.application"`)}
+	}
+	return nil
+}
+
+func (_c *WorkflowScheduleCreate) sqlSave(ctx context.Context) (*WorkflowSchedule, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WorkflowScheduleCreate) createSpec() (*WorkflowSchedule, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WorkflowSchedule{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(workflowschedule.Table, sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreateTime(); ok {
+		_spec.SetField(workflowschedule.FieldCreateTime, field.TypeTime, value)
+		_node.CreateTime = value
+	}
+	if value, ok := _c.mutation.CreateBy(); ok {
+		_spec.SetField(workflowschedule.FieldCreateBy, field.TypeUint64, value)
+		_node.CreateBy = value
+	}
+	if value, ok := _c.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateTime, field.TypeTime, value)
+		_node.UpdateTime = value
+	}
+	if value, ok := _c.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateBy, field.TypeUint64, value)
+		_node.UpdateBy = value
+	}
+	if value, ok := _c.mutation.DeleteTime(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteTime, field.TypeTime, value)
+		_node.DeleteTime = value
+	}
+	if value, ok := _c.mutation.DeleteBy(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteBy, field.TypeUint64, value)
+		_node.DeleteBy = value
+	}
+	if value, ok := _c.mutation.Name(); ok {
+		_spec.SetField(workflowschedule.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := _c.mutation.CronExpression(); ok {
+		_spec.SetField(workflowschedule.FieldCronExpression, field.TypeString, value)
+		_node.CronExpression = value
+	}
+	if value, ok := _c.mutation.Input(); ok {
+		_spec.SetField(workflowschedule.FieldInput, field.TypeJSON, value)
+		_node.Input = value
+	}
+	if value, ok := _c.mutation.Enabled(); ok {
+		_spec.SetField(workflowschedule.FieldEnabled, field.TypeBool, value)
+		_node.Enabled = value
+	}
+	if value, ok := _c.mutation.LastRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldLastRunAt, field.TypeTime, value)
+		_node.LastRunAt = &value
+	}
+	if value, ok := _c.mutation.NextRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldNextRunAt, field.TypeTime, value)
+		_node.NextRunAt = &value
+	}
+	if nodes := _c.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ApplicationID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WorkflowScheduleCreateBulk is the builder for creating many WorkflowSchedule entities in bulk.
+type WorkflowScheduleCreateBulk struct {
+	config
+	err      error
+	builders []*WorkflowScheduleCreate
+}
+
+// Save creates the WorkflowSchedule entities in the database.
+func (_c *WorkflowScheduleCreateBulk) Save(ctx context.Context) ([]*WorkflowSchedule, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*WorkflowSchedule, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WorkflowScheduleMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WorkflowScheduleCreateBulk) SaveX(ctx context.Context) []*WorkflowSchedule {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowScheduleCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowScheduleCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
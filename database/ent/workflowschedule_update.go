@@ -0,0 +1,893 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowScheduleUpdate is the builder for updating WorkflowSchedule entities.
+type WorkflowScheduleUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WorkflowScheduleMutation
+}
+
+// Where appends a list predicates to the WorkflowScheduleUpdate builder.
+func (_u *WorkflowScheduleUpdate) Where(ps ...predicate.WorkflowSchedule) *WorkflowScheduleUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowScheduleUpdate) SetCreateBy(v uint64) *WorkflowScheduleUpdate {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableCreateBy(v *uint64) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowScheduleUpdate) AddCreateBy(v int64) *WorkflowScheduleUpdate {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowScheduleUpdate) ClearCreateBy() *WorkflowScheduleUpdate {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowScheduleUpdate) SetUpdateTime(v time.Time) *WorkflowScheduleUpdate {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowScheduleUpdate) SetUpdateBy(v uint64) *WorkflowScheduleUpdate {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableUpdateBy(v *uint64) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowScheduleUpdate) AddUpdateBy(v int64) *WorkflowScheduleUpdate {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowScheduleUpdate) ClearUpdateBy() *WorkflowScheduleUpdate {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetDeleteTime sets the "delete_time" field.
+func (_u *WorkflowScheduleUpdate) SetDeleteTime(v time.Time) *WorkflowScheduleUpdate {
+	_u.mutation.SetDeleteTime(v)
+	return _u
+}
+
+// SetNillableDeleteTime sets the "delete_time" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableDeleteTime(v *time.Time) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetDeleteTime(*v)
+	}
+	return _u
+}
+
+// ClearDeleteTime clears the value of the "delete_time" field.
+func (_u *WorkflowScheduleUpdate) ClearDeleteTime() *WorkflowScheduleUpdate {
+	_u.mutation.ClearDeleteTime()
+	return _u
+}
+
+// SetDeleteBy sets the "delete_by" field.
+func (_u *WorkflowScheduleUpdate) SetDeleteBy(v uint64) *WorkflowScheduleUpdate {
+	_u.mutation.ResetDeleteBy()
+	_u.mutation.SetDeleteBy(v)
+	return _u
+}
+
+// SetNillableDeleteBy sets the "delete_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableDeleteBy(v *uint64) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetDeleteBy(*v)
+	}
+	return _u
+}
+
+// AddDeleteBy adds value to the "delete_by" field.
+func (_u *WorkflowScheduleUpdate) AddDeleteBy(v int64) *WorkflowScheduleUpdate {
+	_u.mutation.AddDeleteBy(v)
+	return _u
+}
+
+// ClearDeleteBy clears the value of the "delete_by" field.
+func (_u *WorkflowScheduleUpdate) ClearDeleteBy() *WorkflowScheduleUpdate {
+	_u.mutation.ClearDeleteBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowScheduleUpdate) SetApplicationID(v uint64) *WorkflowScheduleUpdate {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableApplicationID(v *uint64) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *WorkflowScheduleUpdate) SetName(v string) *WorkflowScheduleUpdate {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableName(v *string) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// ClearName clears the value of the "name" field.
+func (_u *WorkflowScheduleUpdate) ClearName() *WorkflowScheduleUpdate {
+	_u.mutation.ClearName()
+	return _u
+}
+
+// SetCronExpression sets the "cron_expression" field.
+func (_u *WorkflowScheduleUpdate) SetCronExpression(v string) *WorkflowScheduleUpdate {
+	_u.mutation.SetCronExpression(v)
+	return _u
+}
+
+// SetNillableCronExpression sets the "cron_expression" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableCronExpression(v *string) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetCronExpression(*v)
+	}
+	return _u
+}
+
+// SetInput sets the "input" field.
+func (_u *WorkflowScheduleUpdate) SetInput(v map[string]interface{}) *WorkflowScheduleUpdate {
+	_u.mutation.SetInput(v)
+	return _u
+}
+
+// ClearInput clears the value of the "input" field.
+func (_u *WorkflowScheduleUpdate) ClearInput() *WorkflowScheduleUpdate {
+	_u.mutation.ClearInput()
+	return _u
+}
+
+// SetEnabled sets the "enabled" field.
+func (_u *WorkflowScheduleUpdate) SetEnabled(v bool) *WorkflowScheduleUpdate {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableEnabled(v *bool) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (_u *WorkflowScheduleUpdate) SetLastRunAt(v time.Time) *WorkflowScheduleUpdate {
+	_u.mutation.SetLastRunAt(v)
+	return _u
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableLastRunAt(v *time.Time) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetLastRunAt(*v)
+	}
+	return _u
+}
+
+// ClearLastRunAt clears the value of the "last_run_at" field.
+func (_u *WorkflowScheduleUpdate) ClearLastRunAt() *WorkflowScheduleUpdate {
+	_u.mutation.ClearLastRunAt()
+	return _u
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (_u *WorkflowScheduleUpdate) SetNextRunAt(v time.Time) *WorkflowScheduleUpdate {
+	_u.mutation.SetNextRunAt(v)
+	return _u
+}
+
+// SetNillableNextRunAt sets the "next_run_at" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdate) SetNillableNextRunAt(v *time.Time) *WorkflowScheduleUpdate {
+	if v != nil {
+		_u.SetNextRunAt(*v)
+	}
+	return _u
+}
+
+// ClearNextRunAt clears the value of the "next_run_at" field.
+func (_u *WorkflowScheduleUpdate) ClearNextRunAt() *WorkflowScheduleUpdate {
+	_u.mutation.ClearNextRunAt()
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowScheduleUpdate) SetApplication(v *WorkflowApplication) *WorkflowScheduleUpdate {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowScheduleMutation object of the builder.
+func (_u *WorkflowScheduleUpdate) Mutation() *WorkflowScheduleMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowScheduleUpdate) ClearApplication() *WorkflowScheduleUpdate {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *WorkflowScheduleUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowScheduleUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *WorkflowScheduleUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowScheduleUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowScheduleUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowschedule.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowschedule.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowschedule.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowScheduleUpdate) check() error {
+	if v, ok := _u.mutation.CronExpression(); ok {
+		if err := workflowschedule.CronExpressionValidator(v); err != nil {
+			return &ValidationError{Name: "cron_expression", err: fmt.Errorf(`ent: validator failed for field "WorkflowSchedule.cron_expression": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowSchedule.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowScheduleUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowschedule.Table, workflowschedule.Columns, sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowschedule.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowschedule.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowschedule.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowschedule.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowschedule.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.DeleteTime(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteTime, field.TypeTime, value)
+	}
+	if _u.mutation.DeleteTimeCleared() {
+		_spec.ClearField(workflowschedule.FieldDeleteTime, field.TypeTime)
+	}
+	if value, ok := _u.mutation.DeleteBy(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDeleteBy(); ok {
+		_spec.AddField(workflowschedule.FieldDeleteBy, field.TypeUint64, value)
+	}
+	if _u.mutation.DeleteByCleared() {
+		_spec.ClearField(workflowschedule.FieldDeleteBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(workflowschedule.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.NameCleared() {
+		_spec.ClearField(workflowschedule.FieldName, field.TypeString)
+	}
+	if value, ok := _u.mutation.CronExpression(); ok {
+		_spec.SetField(workflowschedule.FieldCronExpression, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Input(); ok {
+		_spec.SetField(workflowschedule.FieldInput, field.TypeJSON, value)
+	}
+	if _u.mutation.InputCleared() {
+		_spec.ClearField(workflowschedule.FieldInput, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(workflowschedule.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.LastRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldLastRunAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastRunAtCleared() {
+		_spec.ClearField(workflowschedule.FieldLastRunAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.NextRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	if _u.mutation.NextRunAtCleared() {
+		_spec.ClearField(workflowschedule.FieldNextRunAt, field.TypeTime)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowschedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// WorkflowScheduleUpdateOne is the builder for updating a single WorkflowSchedule entity.
+type WorkflowScheduleUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WorkflowScheduleMutation
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowScheduleUpdateOne) SetCreateBy(v uint64) *WorkflowScheduleUpdateOne {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableCreateBy(v *uint64) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowScheduleUpdateOne) AddCreateBy(v int64) *WorkflowScheduleUpdateOne {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowScheduleUpdateOne) ClearCreateBy() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowScheduleUpdateOne) SetUpdateTime(v time.Time) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowScheduleUpdateOne) SetUpdateBy(v uint64) *WorkflowScheduleUpdateOne {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableUpdateBy(v *uint64) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowScheduleUpdateOne) AddUpdateBy(v int64) *WorkflowScheduleUpdateOne {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowScheduleUpdateOne) ClearUpdateBy() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetDeleteTime sets the "delete_time" field.
+func (_u *WorkflowScheduleUpdateOne) SetDeleteTime(v time.Time) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetDeleteTime(v)
+	return _u
+}
+
+// SetNillableDeleteTime sets the "delete_time" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableDeleteTime(v *time.Time) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetDeleteTime(*v)
+	}
+	return _u
+}
+
+// ClearDeleteTime clears the value of the "delete_time" field.
+func (_u *WorkflowScheduleUpdateOne) ClearDeleteTime() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearDeleteTime()
+	return _u
+}
+
+// SetDeleteBy sets the "delete_by" field.
+func (_u *WorkflowScheduleUpdateOne) SetDeleteBy(v uint64) *WorkflowScheduleUpdateOne {
+	_u.mutation.ResetDeleteBy()
+	_u.mutation.SetDeleteBy(v)
+	return _u
+}
+
+// SetNillableDeleteBy sets the "delete_by" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableDeleteBy(v *uint64) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetDeleteBy(*v)
+	}
+	return _u
+}
+
+// AddDeleteBy adds value to the "delete_by" field.
+func (_u *WorkflowScheduleUpdateOne) AddDeleteBy(v int64) *WorkflowScheduleUpdateOne {
+	_u.mutation.AddDeleteBy(v)
+	return _u
+}
+
+// ClearDeleteBy clears the value of the "delete_by" field.
+func (_u *WorkflowScheduleUpdateOne) ClearDeleteBy() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearDeleteBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowScheduleUpdateOne) SetApplicationID(v uint64) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableApplicationID(v *uint64) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetName sets the "name" field.
+func (_u *WorkflowScheduleUpdateOne) SetName(v string) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetName(v)
+	return _u
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableName(v *string) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetName(*v)
+	}
+	return _u
+}
+
+// ClearName clears the value of the "name" field.
+func (_u *WorkflowScheduleUpdateOne) ClearName() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearName()
+	return _u
+}
+
+// SetCronExpression sets the "cron_expression" field.
+func (_u *WorkflowScheduleUpdateOne) SetCronExpression(v string) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetCronExpression(v)
+	return _u
+}
+
+// SetNillableCronExpression sets the "cron_expression" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableCronExpression(v *string) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetCronExpression(*v)
+	}
+	return _u
+}
+
+// SetInput sets the "input" field.
+func (_u *WorkflowScheduleUpdateOne) SetInput(v map[string]interface{}) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetInput(v)
+	return _u
+}
+
+// ClearInput clears the value of the "input" field.
+func (_u *WorkflowScheduleUpdateOne) ClearInput() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearInput()
+	return _u
+}
+
+// SetEnabled sets the "enabled" field.
+func (_u *WorkflowScheduleUpdateOne) SetEnabled(v bool) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetEnabled(v)
+	return _u
+}
+
+// SetNillableEnabled sets the "enabled" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableEnabled(v *bool) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetEnabled(*v)
+	}
+	return _u
+}
+
+// SetLastRunAt sets the "last_run_at" field.
+func (_u *WorkflowScheduleUpdateOne) SetLastRunAt(v time.Time) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetLastRunAt(v)
+	return _u
+}
+
+// SetNillableLastRunAt sets the "last_run_at" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableLastRunAt(v *time.Time) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetLastRunAt(*v)
+	}
+	return _u
+}
+
+// ClearLastRunAt clears the value of the "last_run_at" field.
+func (_u *WorkflowScheduleUpdateOne) ClearLastRunAt() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearLastRunAt()
+	return _u
+}
+
+// SetNextRunAt sets the "next_run_at" field.
+func (_u *WorkflowScheduleUpdateOne) SetNextRunAt(v time.Time) *WorkflowScheduleUpdateOne {
+	_u.mutation.SetNextRunAt(v)
+	return _u
+}
+
+// SetNillableNextRunAt sets the "next_run_at" field if the given value is not nil.
+func (_u *WorkflowScheduleUpdateOne) SetNillableNextRunAt(v *time.Time) *WorkflowScheduleUpdateOne {
+	if v != nil {
+		_u.SetNextRunAt(*v)
+	}
+	return _u
+}
+
+// ClearNextRunAt clears the value of the "next_run_at" field.
+func (_u *WorkflowScheduleUpdateOne) ClearNextRunAt() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearNextRunAt()
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowScheduleUpdateOne) SetApplication(v *WorkflowApplication) *WorkflowScheduleUpdateOne {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowScheduleMutation object of the builder.
+func (_u *WorkflowScheduleUpdateOne) Mutation() *WorkflowScheduleMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowScheduleUpdateOne) ClearApplication() *WorkflowScheduleUpdateOne {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Where appends a list predicates to the WorkflowScheduleUpdate builder.
+func (_u *WorkflowScheduleUpdateOne) Where(ps ...predicate.WorkflowSchedule) *WorkflowScheduleUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *WorkflowScheduleUpdateOne) Select(field string, fields ...string) *WorkflowScheduleUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated WorkflowSchedule entity.
+func (_u *WorkflowScheduleUpdateOne) Save(ctx context.Context) (*WorkflowSchedule, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowScheduleUpdateOne) SaveX(ctx context.Context) *WorkflowSchedule {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *WorkflowScheduleUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowScheduleUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowScheduleUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowschedule.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowschedule.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowschedule.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowScheduleUpdateOne) check() error {
+	if v, ok := _u.mutation.CronExpression(); ok {
+		if err := workflowschedule.CronExpressionValidator(v); err != nil {
+			return &ValidationError{Name: "cron_expression", err: fmt.Errorf(`ent: validator failed for field "WorkflowSchedule.cron_expression": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowSchedule.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowScheduleUpdateOne) sqlSave(ctx context.Context) (_node *WorkflowSchedule, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowschedule.Table, workflowschedule.Columns, sqlgraph.NewFieldSpec(workflowschedule.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "WorkflowSchedule.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, workflowschedule.FieldID)
+		for _, f := range fields {
+			if !workflowschedule.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != workflowschedule.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowschedule.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowschedule.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowschedule.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowschedule.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowschedule.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowschedule.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.DeleteTime(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteTime, field.TypeTime, value)
+	}
+	if _u.mutation.DeleteTimeCleared() {
+		_spec.ClearField(workflowschedule.FieldDeleteTime, field.TypeTime)
+	}
+	if value, ok := _u.mutation.DeleteBy(); ok {
+		_spec.SetField(workflowschedule.FieldDeleteBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedDeleteBy(); ok {
+		_spec.AddField(workflowschedule.FieldDeleteBy, field.TypeUint64, value)
+	}
+	if _u.mutation.DeleteByCleared() {
+		_spec.ClearField(workflowschedule.FieldDeleteBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Name(); ok {
+		_spec.SetField(workflowschedule.FieldName, field.TypeString, value)
+	}
+	if _u.mutation.NameCleared() {
+		_spec.ClearField(workflowschedule.FieldName, field.TypeString)
+	}
+	if value, ok := _u.mutation.CronExpression(); ok {
+		_spec.SetField(workflowschedule.FieldCronExpression, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Input(); ok {
+		_spec.SetField(workflowschedule.FieldInput, field.TypeJSON, value)
+	}
+	if _u.mutation.InputCleared() {
+		_spec.ClearField(workflowschedule.FieldInput, field.TypeJSON)
+	}
+	if value, ok := _u.mutation.Enabled(); ok {
+		_spec.SetField(workflowschedule.FieldEnabled, field.TypeBool, value)
+	}
+	if value, ok := _u.mutation.LastRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldLastRunAt, field.TypeTime, value)
+	}
+	if _u.mutation.LastRunAtCleared() {
+		_spec.ClearField(workflowschedule.FieldLastRunAt, field.TypeTime)
+	}
+	if value, ok := _u.mutation.NextRunAt(); ok {
+		_spec.SetField(workflowschedule.FieldNextRunAt, field.TypeTime, value)
+	}
+	if _u.mutation.NextRunAtCleared() {
+		_spec.ClearField(workflowschedule.FieldNextRunAt, field.TypeTime)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowschedule.ApplicationTable,
+			Columns: []string{workflowschedule.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &WorkflowSchedule{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowschedule.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,350 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowsecret"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowSecretCreate is the builder for creating a WorkflowSecret entity.
+type WorkflowSecretCreate struct {
+	config
+	mutation *WorkflowSecretMutation
+	hooks    []Hook
+}
+
+// SetCreateTime sets the "create_time" field.
+func (_c *WorkflowSecretCreate) SetCreateTime(v time.Time) *WorkflowSecretCreate {
+	_c.mutation.SetCreateTime(v)
+	return _c
+}
+
+// SetNillableCreateTime sets the "create_time" field if the given value is not nil.
+func (_c *WorkflowSecretCreate) SetNillableCreateTime(v *time.Time) *WorkflowSecretCreate {
+	if v != nil {
+		_c.SetCreateTime(*v)
+	}
+	return _c
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_c *WorkflowSecretCreate) SetCreateBy(v uint64) *WorkflowSecretCreate {
+	_c.mutation.SetCreateBy(v)
+	return _c
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_c *WorkflowSecretCreate) SetNillableCreateBy(v *uint64) *WorkflowSecretCreate {
+	if v != nil {
+		_c.SetCreateBy(*v)
+	}
+	return _c
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_c *WorkflowSecretCreate) SetUpdateTime(v time.Time) *WorkflowSecretCreate {
+	_c.mutation.SetUpdateTime(v)
+	return _c
+}
+
+// SetNillableUpdateTime sets the "update_time" field if the given value is not nil.
+func (_c *WorkflowSecretCreate) SetNillableUpdateTime(v *time.Time) *WorkflowSecretCreate {
+	if v != nil {
+		_c.SetUpdateTime(*v)
+	}
+	return _c
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_c *WorkflowSecretCreate) SetUpdateBy(v uint64) *WorkflowSecretCreate {
+	_c.mutation.SetUpdateBy(v)
+	return _c
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_c *WorkflowSecretCreate) SetNillableUpdateBy(v *uint64) *WorkflowSecretCreate {
+	if v != nil {
+		_c.SetUpdateBy(*v)
+	}
+	return _c
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_c *WorkflowSecretCreate) SetApplicationID(v uint64) *WorkflowSecretCreate {
+	_c.mutation.SetApplicationID(v)
+	return _c
+}
+
+// SetKey sets the "key" field.
+func (_c *WorkflowSecretCreate) SetKey(v string) *WorkflowSecretCreate {
+	_c.mutation.SetKey(v)
+	return _c
+}
+
+// SetValue sets the "value" field.
+func (_c *WorkflowSecretCreate) SetValue(v string) *WorkflowSecretCreate {
+	_c.mutation.SetValue(v)
+	return _c
+}
+
+// SetID sets the "id" field.
+func (_c *WorkflowSecretCreate) SetID(v uint64) *WorkflowSecretCreate {
+	_c.mutation.SetID(v)
+	return _c
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_c *WorkflowSecretCreate) SetApplication(v *WorkflowApplication) *WorkflowSecretCreate {
+	return _c.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowSecretMutation object of the builder.
+func (_c *WorkflowSecretCreate) Mutation() *WorkflowSecretMutation {
+	return _c.mutation
+}
+
+// Save creates the WorkflowSecret in the database.
+func (_c *WorkflowSecretCreate) Save(ctx context.Context) (*WorkflowSecret, error) {
+	if err := _c.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _c.sqlSave, _c.mutation, _c.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (_c *WorkflowSecretCreate) SaveX(ctx context.Context) *WorkflowSecret {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowSecretCreate) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowSecretCreate) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_c *WorkflowSecretCreate) defaults() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		if workflowsecret.DefaultCreateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowsecret.DefaultCreateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowsecret.DefaultCreateTime()
+		_c.mutation.SetCreateTime(v)
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		if workflowsecret.DefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowsecret.DefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowsecret.DefaultUpdateTime()
+		_c.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_c *WorkflowSecretCreate) check() error {
+	if _, ok := _c.mutation.CreateTime(); !ok {
+		return &ValidationError{Name: "create_time", err: errors.New(`ent: missing required field "WorkflowSecret.create_time"`)}
+	}
+	if _, ok := _c.mutation.UpdateTime(); !ok {
+		return &ValidationError{Name: "update_time", err: errors.New(`ent: missing required field "WorkflowSecret.update_time"`)}
+	}
+	if _, ok := _c.mutation.ApplicationID(); !ok {
+		return &ValidationError{Name: "application_id", err: errors.New(`ent: missing required field "WorkflowSecret.application_id"`)}
+	}
+	if _, ok := _c.mutation.Key(); !ok {
+		return &ValidationError{Name: "key", err: errors.New(`ent: missing required field "WorkflowSecret.key"`)}
+	}
+	if v, ok := _c.mutation.Key(); ok {
+		if err := workflowsecret.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "WorkflowSecret.key": %w`, err)}
+		}
+	}
+	if _, ok := _c.mutation.Value(); !ok {
+		return &ValidationError{Name: "value", err: errors.New(`ent: missing required field "WorkflowSecret.value"`)}
+	}
+	if len(_c.mutation.ApplicationIDs()) == 0 {
+		return &ValidationError{Name: "application", err: errors.New(`ent: missing required edge "WorkflowSecret.application"`)}
+	}
+	return nil
+}
+
+func (_c *WorkflowSecretCreate) sqlSave(ctx context.Context) (*WorkflowSecret, error) {
+	if err := _c.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := _c.createSpec()
+	if err := sqlgraph.CreateNode(ctx, _c.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	if _spec.ID.Value != _node.ID {
+		id := _spec.ID.Value.(int64)
+		_node.ID = uint64(id)
+	}
+	_c.mutation.id = &_node.ID
+	_c.mutation.done = true
+	return _node, nil
+}
+
+func (_c *WorkflowSecretCreate) createSpec() (*WorkflowSecret, *sqlgraph.CreateSpec) {
+	var (
+		_node = &WorkflowSecret{config: _c.config}
+		_spec = sqlgraph.NewCreateSpec(workflowsecret.Table, sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64))
+	)
+	if id, ok := _c.mutation.ID(); ok {
+		_node.ID = id
+		_spec.ID.Value = id
+	}
+	if value, ok := _c.mutation.CreateTime(); ok {
+		_spec.SetField(workflowsecret.FieldCreateTime, field.TypeTime, value)
+		_node.CreateTime = value
+	}
+	if value, ok := _c.mutation.CreateBy(); ok {
+		_spec.SetField(workflowsecret.FieldCreateBy, field.TypeUint64, value)
+		_node.CreateBy = value
+	}
+	if value, ok := _c.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateTime, field.TypeTime, value)
+		_node.UpdateTime = value
+	}
+	if value, ok := _c.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateBy, field.TypeUint64, value)
+		_node.UpdateBy = value
+	}
+	if value, ok := _c.mutation.Key(); ok {
+		_spec.SetField(workflowsecret.FieldKey, field.TypeString, value)
+		_node.Key = value
+	}
+	if value, ok := _c.mutation.Value(); ok {
+		_spec.SetField(workflowsecret.FieldValue, field.TypeString, value)
+		_node.Value = value
+	}
+	if nodes := _c.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.ApplicationID = nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// WorkflowSecretCreateBulk is the builder for creating many WorkflowSecret entities in bulk.
+type WorkflowSecretCreateBulk struct {
+	config
+	err      error
+	builders []*WorkflowSecretCreate
+}
+
+// Save creates the WorkflowSecret entities in the database.
+func (_c *WorkflowSecretCreateBulk) Save(ctx context.Context) ([]*WorkflowSecret, error) {
+	if _c.err != nil {
+		return nil, _c.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(_c.builders))
+	nodes := make([]*WorkflowSecret, len(_c.builders))
+	mutators := make([]Mutator, len(_c.builders))
+	for i := range _c.builders {
+		func(i int, root context.Context) {
+			builder := _c.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*WorkflowSecretMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, _c.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, _c.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil && nodes[i].ID == 0 {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = uint64(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, _c.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_c *WorkflowSecretCreateBulk) SaveX(ctx context.Context) []*WorkflowSecret {
+	v, err := _c.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (_c *WorkflowSecretCreateBulk) Exec(ctx context.Context) error {
+	_, err := _c.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_c *WorkflowSecretCreateBulk) ExecX(ctx context.Context) {
+	if err := _c.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,577 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowsecret"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// WorkflowSecretUpdate is the builder for updating WorkflowSecret entities.
+type WorkflowSecretUpdate struct {
+	config
+	hooks    []Hook
+	mutation *WorkflowSecretMutation
+}
+
+// Where appends a list predicates to the WorkflowSecretUpdate builder.
+func (_u *WorkflowSecretUpdate) Where(ps ...predicate.WorkflowSecret) *WorkflowSecretUpdate {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowSecretUpdate) SetCreateBy(v uint64) *WorkflowSecretUpdate {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowSecretUpdate) SetNillableCreateBy(v *uint64) *WorkflowSecretUpdate {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowSecretUpdate) AddCreateBy(v int64) *WorkflowSecretUpdate {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowSecretUpdate) ClearCreateBy() *WorkflowSecretUpdate {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowSecretUpdate) SetUpdateTime(v time.Time) *WorkflowSecretUpdate {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowSecretUpdate) SetUpdateBy(v uint64) *WorkflowSecretUpdate {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowSecretUpdate) SetNillableUpdateBy(v *uint64) *WorkflowSecretUpdate {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowSecretUpdate) AddUpdateBy(v int64) *WorkflowSecretUpdate {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowSecretUpdate) ClearUpdateBy() *WorkflowSecretUpdate {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowSecretUpdate) SetApplicationID(v uint64) *WorkflowSecretUpdate {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowSecretUpdate) SetNillableApplicationID(v *uint64) *WorkflowSecretUpdate {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *WorkflowSecretUpdate) SetKey(v string) *WorkflowSecretUpdate {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *WorkflowSecretUpdate) SetNillableKey(v *string) *WorkflowSecretUpdate {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetValue sets the "value" field.
+func (_u *WorkflowSecretUpdate) SetValue(v string) *WorkflowSecretUpdate {
+	_u.mutation.SetValue(v)
+	return _u
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (_u *WorkflowSecretUpdate) SetNillableValue(v *string) *WorkflowSecretUpdate {
+	if v != nil {
+		_u.SetValue(*v)
+	}
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowSecretUpdate) SetApplication(v *WorkflowApplication) *WorkflowSecretUpdate {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowSecretMutation object of the builder.
+func (_u *WorkflowSecretUpdate) Mutation() *WorkflowSecretMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowSecretUpdate) ClearApplication() *WorkflowSecretUpdate {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (_u *WorkflowSecretUpdate) Save(ctx context.Context) (int, error) {
+	if err := _u.defaults(); err != nil {
+		return 0, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowSecretUpdate) SaveX(ctx context.Context) int {
+	affected, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (_u *WorkflowSecretUpdate) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowSecretUpdate) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowSecretUpdate) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowsecret.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowsecret.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowsecret.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowSecretUpdate) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := workflowsecret.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "WorkflowSecret.key": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowSecret.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowSecretUpdate) sqlSave(ctx context.Context) (_node int, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowsecret.Table, workflowsecret.Columns, sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64))
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowsecret.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowsecret.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowsecret.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowsecret.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowsecret.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(workflowsecret.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Value(); ok {
+		_spec.SetField(workflowsecret.FieldValue, field.TypeString, value)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowsecret.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
+
+// WorkflowSecretUpdateOne is the builder for updating a single WorkflowSecret entity.
+type WorkflowSecretUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *WorkflowSecretMutation
+}
+
+// SetCreateBy sets the "create_by" field.
+func (_u *WorkflowSecretUpdateOne) SetCreateBy(v uint64) *WorkflowSecretUpdateOne {
+	_u.mutation.ResetCreateBy()
+	_u.mutation.SetCreateBy(v)
+	return _u
+}
+
+// SetNillableCreateBy sets the "create_by" field if the given value is not nil.
+func (_u *WorkflowSecretUpdateOne) SetNillableCreateBy(v *uint64) *WorkflowSecretUpdateOne {
+	if v != nil {
+		_u.SetCreateBy(*v)
+	}
+	return _u
+}
+
+// AddCreateBy adds value to the "create_by" field.
+func (_u *WorkflowSecretUpdateOne) AddCreateBy(v int64) *WorkflowSecretUpdateOne {
+	_u.mutation.AddCreateBy(v)
+	return _u
+}
+
+// ClearCreateBy clears the value of the "create_by" field.
+func (_u *WorkflowSecretUpdateOne) ClearCreateBy() *WorkflowSecretUpdateOne {
+	_u.mutation.ClearCreateBy()
+	return _u
+}
+
+// SetUpdateTime sets the "update_time" field.
+func (_u *WorkflowSecretUpdateOne) SetUpdateTime(v time.Time) *WorkflowSecretUpdateOne {
+	_u.mutation.SetUpdateTime(v)
+	return _u
+}
+
+// SetUpdateBy sets the "update_by" field.
+func (_u *WorkflowSecretUpdateOne) SetUpdateBy(v uint64) *WorkflowSecretUpdateOne {
+	_u.mutation.ResetUpdateBy()
+	_u.mutation.SetUpdateBy(v)
+	return _u
+}
+
+// SetNillableUpdateBy sets the "update_by" field if the given value is not nil.
+func (_u *WorkflowSecretUpdateOne) SetNillableUpdateBy(v *uint64) *WorkflowSecretUpdateOne {
+	if v != nil {
+		_u.SetUpdateBy(*v)
+	}
+	return _u
+}
+
+// AddUpdateBy adds value to the "update_by" field.
+func (_u *WorkflowSecretUpdateOne) AddUpdateBy(v int64) *WorkflowSecretUpdateOne {
+	_u.mutation.AddUpdateBy(v)
+	return _u
+}
+
+// ClearUpdateBy clears the value of the "update_by" field.
+func (_u *WorkflowSecretUpdateOne) ClearUpdateBy() *WorkflowSecretUpdateOne {
+	_u.mutation.ClearUpdateBy()
+	return _u
+}
+
+// SetApplicationID sets the "application_id" field.
+func (_u *WorkflowSecretUpdateOne) SetApplicationID(v uint64) *WorkflowSecretUpdateOne {
+	_u.mutation.SetApplicationID(v)
+	return _u
+}
+
+// SetNillableApplicationID sets the "application_id" field if the given value is not nil.
+func (_u *WorkflowSecretUpdateOne) SetNillableApplicationID(v *uint64) *WorkflowSecretUpdateOne {
+	if v != nil {
+		_u.SetApplicationID(*v)
+	}
+	return _u
+}
+
+// SetKey sets the "key" field.
+func (_u *WorkflowSecretUpdateOne) SetKey(v string) *WorkflowSecretUpdateOne {
+	_u.mutation.SetKey(v)
+	return _u
+}
+
+// SetNillableKey sets the "key" field if the given value is not nil.
+func (_u *WorkflowSecretUpdateOne) SetNillableKey(v *string) *WorkflowSecretUpdateOne {
+	if v != nil {
+		_u.SetKey(*v)
+	}
+	return _u
+}
+
+// SetValue sets the "value" field.
+func (_u *WorkflowSecretUpdateOne) SetValue(v string) *WorkflowSecretUpdateOne {
+	_u.mutation.SetValue(v)
+	return _u
+}
+
+// SetNillableValue sets the "value" field if the given value is not nil.
+func (_u *WorkflowSecretUpdateOne) SetNillableValue(v *string) *WorkflowSecretUpdateOne {
+	if v != nil {
+		_u.SetValue(*v)
+	}
+	return _u
+}
+
+// SetApplication sets the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowSecretUpdateOne) SetApplication(v *WorkflowApplication) *WorkflowSecretUpdateOne {
+	return _u.SetApplicationID(v.ID)
+}
+
+// Mutation returns the WorkflowSecretMutation object of the builder.
+func (_u *WorkflowSecretUpdateOne) Mutation() *WorkflowSecretMutation {
+	return _u.mutation
+}
+
+// ClearApplication clears the "application" edge to the WorkflowApplication entity.
+func (_u *WorkflowSecretUpdateOne) ClearApplication() *WorkflowSecretUpdateOne {
+	_u.mutation.ClearApplication()
+	return _u
+}
+
+// Where appends a list predicates to the WorkflowSecretUpdate builder.
+func (_u *WorkflowSecretUpdateOne) Where(ps ...predicate.WorkflowSecret) *WorkflowSecretUpdateOne {
+	_u.mutation.Where(ps...)
+	return _u
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (_u *WorkflowSecretUpdateOne) Select(field string, fields ...string) *WorkflowSecretUpdateOne {
+	_u.fields = append([]string{field}, fields...)
+	return _u
+}
+
+// Save executes the query and returns the updated WorkflowSecret entity.
+func (_u *WorkflowSecretUpdateOne) Save(ctx context.Context) (*WorkflowSecret, error) {
+	if err := _u.defaults(); err != nil {
+		return nil, err
+	}
+	return withHooks(ctx, _u.sqlSave, _u.mutation, _u.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (_u *WorkflowSecretUpdateOne) SaveX(ctx context.Context) *WorkflowSecret {
+	node, err := _u.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (_u *WorkflowSecretUpdateOne) Exec(ctx context.Context) error {
+	_, err := _u.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (_u *WorkflowSecretUpdateOne) ExecX(ctx context.Context) {
+	if err := _u.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (_u *WorkflowSecretUpdateOne) defaults() error {
+	if _, ok := _u.mutation.UpdateTime(); !ok {
+		if workflowsecret.UpdateDefaultUpdateTime == nil {
+			return fmt.Errorf("ent: uninitialized workflowsecret.UpdateDefaultUpdateTime (forgotten import ent/runtime?)")
+		}
+		v := workflowsecret.UpdateDefaultUpdateTime()
+		_u.mutation.SetUpdateTime(v)
+	}
+	return nil
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (_u *WorkflowSecretUpdateOne) check() error {
+	if v, ok := _u.mutation.Key(); ok {
+		if err := workflowsecret.KeyValidator(v); err != nil {
+			return &ValidationError{Name: "key", err: fmt.Errorf(`ent: validator failed for field "WorkflowSecret.key": %w`, err)}
+		}
+	}
+	if _u.mutation.ApplicationCleared() && len(_u.mutation.ApplicationIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "WorkflowSecret.application"`)
+	}
+	return nil
+}
+
+func (_u *WorkflowSecretUpdateOne) sqlSave(ctx context.Context) (_node *WorkflowSecret, err error) {
+	if err := _u.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(workflowsecret.Table, workflowsecret.Columns, sqlgraph.NewFieldSpec(workflowsecret.FieldID, field.TypeUint64))
+	id, ok := _u.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "WorkflowSecret.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := _u.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, workflowsecret.FieldID)
+		for _, f := range fields {
+			if !workflowsecret.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != workflowsecret.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := _u.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := _u.mutation.CreateBy(); ok {
+		_spec.SetField(workflowsecret.FieldCreateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedCreateBy(); ok {
+		_spec.AddField(workflowsecret.FieldCreateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.CreateByCleared() {
+		_spec.ClearField(workflowsecret.FieldCreateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.UpdateTime(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateTime, field.TypeTime, value)
+	}
+	if value, ok := _u.mutation.UpdateBy(); ok {
+		_spec.SetField(workflowsecret.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if value, ok := _u.mutation.AddedUpdateBy(); ok {
+		_spec.AddField(workflowsecret.FieldUpdateBy, field.TypeUint64, value)
+	}
+	if _u.mutation.UpdateByCleared() {
+		_spec.ClearField(workflowsecret.FieldUpdateBy, field.TypeUint64)
+	}
+	if value, ok := _u.mutation.Key(); ok {
+		_spec.SetField(workflowsecret.FieldKey, field.TypeString, value)
+	}
+	if value, ok := _u.mutation.Value(); ok {
+		_spec.SetField(workflowsecret.FieldValue, field.TypeString, value)
+	}
+	if _u.mutation.ApplicationCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := _u.mutation.ApplicationIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   workflowsecret.ApplicationTable,
+			Columns: []string{workflowsecret.ApplicationColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(workflowapplication.FieldID, field.TypeUint64),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &WorkflowSecret{config: _u.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, _u.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{workflowsecret.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	_u.mutation.done = true
+	return _node, nil
+}
@@ -34,7 +34,9 @@ type WorkflowVersion struct {
 	// 版本快照
 	Snapshot map[string]interface{} `json:"snapshot,omitempty"`
 	// 变更日志
-	ChangeLog    string `json:"change_log,omitempty"`
+	ChangeLog string `json:"change_log,omitempty"`
+	// 是否置顶
+	Pinned       bool `json:"pinned,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -45,6 +47,8 @@ func (*WorkflowVersion) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case workflowversion.FieldSnapshot:
 			values[i] = new([]byte)
+		case workflowversion.FieldPinned:
+			values[i] = new(sql.NullBool)
 		case workflowversion.FieldID, workflowversion.FieldCreateBy, workflowversion.FieldUpdateBy, workflowversion.FieldApplicationID, workflowversion.FieldVersion:
 			values[i] = new(sql.NullInt64)
 		case workflowversion.FieldChangeLog:
@@ -122,6 +126,12 @@ func (_m *WorkflowVersion) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				_m.ChangeLog = value.String
 			}
+		case workflowversion.FieldPinned:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field pinned", values[i])
+			} else if value.Valid {
+				_m.Pinned = value.Bool
+			}
 		default:
 			_m.selectValues.Set(columns[i], values[i])
 		}
@@ -181,6 +191,9 @@ func (_m *WorkflowVersion) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("change_log=")
 	builder.WriteString(_m.ChangeLog)
+	builder.WriteString(", ")
+	builder.WriteString("pinned=")
+	builder.WriteString(fmt.Sprintf("%v", _m.Pinned))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -89,6 +89,11 @@ func ChangeLog(v string) predicate.WorkflowVersion {
 	return predicate.WorkflowVersion(sql.FieldEQ(FieldChangeLog, v))
 }
 
+// Pinned applies equality check predicate on the "pinned" field. It's identical to PinnedEQ.
+func Pinned(v bool) predicate.WorkflowVersion {
+	return predicate.WorkflowVersion(sql.FieldEQ(FieldPinned, v))
+}
+
 // CreateTimeEQ applies the EQ predicate on the "create_time" field.
 func CreateTimeEQ(v time.Time) predicate.WorkflowVersion {
 	return predicate.WorkflowVersion(sql.FieldEQ(FieldCreateTime, v))
@@ -424,6 +429,16 @@ func ChangeLogContainsFold(v string) predicate.WorkflowVersion {
 	return predicate.WorkflowVersion(sql.FieldContainsFold(FieldChangeLog, v))
 }
 
+// PinnedEQ applies the EQ predicate on the "pinned" field.
+func PinnedEQ(v bool) predicate.WorkflowVersion {
+	return predicate.WorkflowVersion(sql.FieldEQ(FieldPinned, v))
+}
+
+// PinnedNEQ applies the NEQ predicate on the "pinned" field.
+func PinnedNEQ(v bool) predicate.WorkflowVersion {
+	return predicate.WorkflowVersion(sql.FieldNEQ(FieldPinned, v))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.WorkflowVersion) predicate.WorkflowVersion {
 	return predicate.WorkflowVersion(sql.AndPredicates(predicates...))
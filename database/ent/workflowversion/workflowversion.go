@@ -30,6 +30,8 @@ const (
 	FieldSnapshot = "snapshot"
 	// FieldChangeLog holds the string denoting the change_log field in the database.
 	FieldChangeLog = "change_log"
+	// FieldPinned holds the string denoting the pinned field in the database.
+	FieldPinned = "pinned"
 	// Table holds the table name of the workflowversion in the database.
 	Table = "workflow_versions"
 )
@@ -45,6 +47,7 @@ var Columns = []string{
 	FieldVersion,
 	FieldSnapshot,
 	FieldChangeLog,
+	FieldPinned,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -70,6 +73,8 @@ var (
 	DefaultUpdateTime func() time.Time
 	// UpdateDefaultUpdateTime holds the default value on update for the "update_time" field.
 	UpdateDefaultUpdateTime func() time.Time
+	// DefaultPinned holds the default value on creation for the "pinned" field.
+	DefaultPinned bool
 )
 
 // OrderOption defines the ordering options for the WorkflowVersion queries.
@@ -114,3 +119,8 @@ func ByVersion(opts ...sql.OrderTermOption) OrderOption {
 func ByChangeLog(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldChangeLog, opts...).ToFunc()
 }
+
+// ByPinned orders the results by the pinned field.
+func ByPinned(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldPinned, opts...).ToFunc()
+}
@@ -108,6 +108,20 @@ func (_c *WorkflowVersionCreate) SetNillableChangeLog(v *string) *WorkflowVersio
 	return _c
 }
 
+// SetPinned sets the "pinned" field.
+func (_c *WorkflowVersionCreate) SetPinned(v bool) *WorkflowVersionCreate {
+	_c.mutation.SetPinned(v)
+	return _c
+}
+
+// SetNillablePinned sets the "pinned" field if the given value is not nil.
+func (_c *WorkflowVersionCreate) SetNillablePinned(v *bool) *WorkflowVersionCreate {
+	if v != nil {
+		_c.SetPinned(*v)
+	}
+	return _c
+}
+
 // SetID sets the "id" field.
 func (_c *WorkflowVersionCreate) SetID(v uint64) *WorkflowVersionCreate {
 	_c.mutation.SetID(v)
@@ -165,6 +179,10 @@ func (_c *WorkflowVersionCreate) defaults() error {
 		v := workflowversion.DefaultUpdateTime()
 		_c.mutation.SetUpdateTime(v)
 	}
+	if _, ok := _c.mutation.Pinned(); !ok {
+		v := workflowversion.DefaultPinned
+		_c.mutation.SetPinned(v)
+	}
 	return nil
 }
 
@@ -185,6 +203,9 @@ func (_c *WorkflowVersionCreate) check() error {
 	if _, ok := _c.mutation.Snapshot(); !ok {
 		return &ValidationError{Name: "snapshot", err: errors.New(`ent: missing required field "WorkflowVersion.snapshot"`)}
 	}
+	if _, ok := _c.mutation.Pinned(); !ok {
+		return &ValidationError{Name: "pinned", err: errors.New(`ent: missing required field "WorkflowVersion.pinned"`)}
+	}
 	return nil
 }
 
@@ -249,6 +270,10 @@ func (_c *WorkflowVersionCreate) createSpec() (*WorkflowVersion, *sqlgraph.Creat
 		_spec.SetField(workflowversion.FieldChangeLog, field.TypeString, value)
 		_node.ChangeLog = value
 	}
+	if value, ok := _c.mutation.Pinned(); ok {
+		_spec.SetField(workflowversion.FieldPinned, field.TypeBool, value)
+		_node.Pinned = value
+	}
 	return _node, _spec
 }
 
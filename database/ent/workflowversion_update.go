@@ -156,6 +156,20 @@ func (_u *WorkflowVersionUpdate) ClearChangeLog() *WorkflowVersionUpdate {
 	return _u
 }
 
+// SetPinned sets the "pinned" field.
+func (_u *WorkflowVersionUpdate) SetPinned(v bool) *WorkflowVersionUpdate {
+	_u.mutation.SetPinned(v)
+	return _u
+}
+
+// SetNillablePinned sets the "pinned" field if the given value is not nil.
+func (_u *WorkflowVersionUpdate) SetNillablePinned(v *bool) *WorkflowVersionUpdate {
+	if v != nil {
+		_u.SetPinned(*v)
+	}
+	return _u
+}
+
 // Mutation returns the WorkflowVersionMutation object of the builder.
 func (_u *WorkflowVersionUpdate) Mutation() *WorkflowVersionMutation {
 	return _u.mutation
@@ -254,6 +268,9 @@ func (_u *WorkflowVersionUpdate) sqlSave(ctx context.Context) (_node int, err er
 	if _u.mutation.ChangeLogCleared() {
 		_spec.ClearField(workflowversion.FieldChangeLog, field.TypeString)
 	}
+	if value, ok := _u.mutation.Pinned(); ok {
+		_spec.SetField(workflowversion.FieldPinned, field.TypeBool, value)
+	}
 	if _node, err = sqlgraph.UpdateNodes(ctx, _u.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{workflowversion.Label}
@@ -402,6 +419,20 @@ func (_u *WorkflowVersionUpdateOne) ClearChangeLog() *WorkflowVersionUpdateOne {
 	return _u
 }
 
+// SetPinned sets the "pinned" field.
+func (_u *WorkflowVersionUpdateOne) SetPinned(v bool) *WorkflowVersionUpdateOne {
+	_u.mutation.SetPinned(v)
+	return _u
+}
+
+// SetNillablePinned sets the "pinned" field if the given value is not nil.
+func (_u *WorkflowVersionUpdateOne) SetNillablePinned(v *bool) *WorkflowVersionUpdateOne {
+	if v != nil {
+		_u.SetPinned(*v)
+	}
+	return _u
+}
+
 // Mutation returns the WorkflowVersionMutation object of the builder.
 func (_u *WorkflowVersionUpdateOne) Mutation() *WorkflowVersionMutation {
 	return _u.mutation
@@ -530,6 +561,9 @@ func (_u *WorkflowVersionUpdateOne) sqlSave(ctx context.Context) (_node *Workflo
 	if _u.mutation.ChangeLogCleared() {
 		_spec.ClearField(workflowversion.FieldChangeLog, field.TypeString)
 	}
+	if value, ok := _u.mutation.Pinned(); ok {
+		_spec.SetField(workflowversion.FieldPinned, field.TypeBool, value)
+	}
 	_node = &WorkflowVersion{config: _u.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -8,13 +8,13 @@ import (
 	pkgent "go-backend/database/ent"
 	"go-backend/database/ent/hook"
 	"go-backend/database/ent/intercept"
+	"go-backend/pkg/utils"
 
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/schema/field"
 	"entgo.io/ent/schema/index"
 	"entgo.io/ent/schema/mixin"
-	"github.com/sony/sonyflake"
 )
 
 // BaseMixin 包含所有基础字段的mixin
@@ -22,8 +22,8 @@ type BaseMixin struct {
 	mixin.Schema
 }
 
+// IDHook 创建时使用全局雪花ID生成器（utils.GenerateID）设置ID，节点ID和纪元由 database.id_generator 配置
 func IDHook() ent.Hook {
-	sf := sonyflake.NewSonyflake(sonyflake.Settings{})
 	type IDSetter interface {
 		SetID(uint64)
 	}
@@ -38,11 +38,7 @@ func IDHook() ent.Hook {
 			if !ok {
 				return nil, fmt.Errorf("unexpected mutation %T", m)
 			}
-			id, err := sf.NextID()
-			if err != nil {
-				return nil, err
-			}
-			is.SetID(id)
+			is.SetID(utils.GenerateID())
 			return next.Mutate(ctx, m)
 		})
 	}
@@ -57,6 +57,13 @@ func (ClientDevice) Fields() []ent.Field {
 		field.Bool("anonymous").
 			Default(true).
 			Comment("允许所有角色登录"),
+		field.String("audience").
+			Optional().
+			MaxLen(255).
+			Comment("令牌受众，为空时只使用服务受众"),
+		field.Strings("scopes").
+			Optional().
+			Comment("令牌授权范围，为空表示不限制"),
 	}
 }
 
@@ -36,16 +36,16 @@ func (Credential) Fields() []ent.Field {
 		field.Uint64("user_id").
 			Comment("用户ID"),
 		field.Enum("credential_type").
-			Values("password", "email", "oauth", "phone", "totp").
+			Values("password", "email", "oauth", "phone", "totp", "webauthn").
 			Comment("认证类型"),
 		field.String("identifier").
 			MaxLen(255).
-			Comment("认证标识符(用户名/邮箱/手机号/OAuth Provider ID等)"),
+			Comment("认证标识符(用户名/邮箱/手机号/OAuth Provider ID/通行密钥凭据ID等)"),
 		field.String("secret").
 			MaxLen(500).
 			Optional().
 			Sensitive().
-			Comment("认证密钥(密码hash/token等)"),
+			Comment("认证密钥(密码hash/token/通行密钥公钥等)"),
 		field.String("salt").
 			MaxLen(100).
 			Optional().
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"go-backend/database/mixins"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// DatabaseBackup holds the schema definition for the DatabaseBackup entity.
+// 每条记录对应一次上传到S3的数据库备份归档（备份清单）
+type DatabaseBackup struct {
+	ent.Schema
+}
+
+func (DatabaseBackup) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "sys_database_backups"},
+	}
+}
+
+// Mixin returns DatabaseBackup mixed-in fields.
+func (DatabaseBackup) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.BaseMixin{},
+	}
+}
+
+// Fields of the DatabaseBackup.
+func (DatabaseBackup) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("bucket").
+			MaxLen(255).
+			Comment("存储桶"),
+		field.String("key").
+			MaxLen(512).
+			Unique().
+			Comment("归档在存储桶中的键"),
+		field.Int64("size").
+			NonNegative().
+			Comment("归档大小(字节)"),
+		field.Int("total_records").
+			NonNegative().
+			Default(0).
+			Comment("导出的记录总数"),
+		field.JSON("entity_counts", map[string]int{}).
+			Optional().
+			Comment("各实体导出的记录数"),
+		field.Strings("failed_entities").
+			Optional().
+			Comment("导出失败的实体"),
+		field.Time("backup_time").
+			Comment("备份时间点"),
+	}
+}
+
+// Indexes of the DatabaseBackup.
+func (DatabaseBackup) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("backup_time"),
+	}
+}
@@ -38,7 +38,7 @@ func (LoginRecord) Fields() []ent.Field {
 			MaxLen(255).
 			Comment("登录标识符(用户名/邮箱/手机号等)"),
 		field.Enum("credential_type").
-			Values("password", "email", "oauth", "phone", "totp").
+			Values("password", "email", "oauth", "phone", "totp", "webauthn").
 			Comment("登录方式"),
 		field.String("ip_address").
 			MaxLen(45).
@@ -129,14 +129,18 @@ func (UserRole) Fields() []ent.Field {
 	return []ent.Field{
 		field.Uint64("user_id"),
 		field.Uint64("role_id"),
+		field.Time("expires_at").
+			Optional().
+			Nillable(), // 过期时间，为空表示永久有效；过期后不再授予权限，由清理任务定期删除
 	}
 }
 
 func (UserRole) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("user_id", "role_id", "delete_time").Unique(), // 防重复用户角色（核心索引）
-		index.Fields("user_id"), // 查询用户的所有角色
-		index.Fields("role_id"), // 查询角色下的所有用户
+		index.Fields("user_id"),    // 查询用户的所有角色
+		index.Fields("role_id"),    // 查询角色下的所有用户
+		index.Fields("expires_at"), // 清理过期的分配
 	}
 }
 
@@ -40,6 +40,9 @@ func (WorkflowApplication) Fields() []ent.Field {
 		field.Uint("version").Default(1).Comment("版本号"),
 		field.Enum("status").Values("draft", "published", "archived").Default("draft").Comment("状态"),
 		field.JSON("viewport_config", map[string]interface{}{}).Optional().Comment("画布视口配置（zoom, x, y）"),
+		field.JSON("input_schema", map[string]interface{}{}).Optional().Comment("执行输入的JSON Schema（支持 required、type、enum），为空时不校验"),
+		field.Bool("is_template").Default(false).Comment("是否为模板，模板不能直接执行，需要实例化为普通应用后使用"),
+		field.Uint64("owner_id").Optional().Comment("所有者用户ID，为空表示引入所有权之前创建的应用，对所有用户可见"),
 	}
 }
 
@@ -47,6 +50,7 @@ func (WorkflowApplication) Indexes() []ent.Index {
 	return []ent.Index{
 		index.Fields("client_secret").Unique(),
 		index.Fields("status"),
+		index.Fields("owner_id"),
 	}
 }
 
@@ -55,6 +59,9 @@ func (WorkflowApplication) Edges() []ent.Edge {
 		edge.To("nodes", WorkflowNode.Type),
 		edge.To("edges", WorkflowEdge.Type),
 		edge.To("executions", WorkflowExecution.Type),
+		edge.To("schedules", WorkflowSchedule.Type),
+		edge.To("secrets", WorkflowSecret.Type),
+		edge.To("shares", WorkflowApplicationShare.Type),
 	}
 }
 
@@ -137,6 +144,7 @@ func (WorkflowNode) Fields() []ent.Field {
 		field.Float("position_x").Default(0).Comment("画布X坐标"),
 		field.Float("position_y").Default(0).Comment("画布Y坐标"),
 		field.String("color").Optional().Comment("节点颜色"),
+		field.Bool("enabled").Default(true).Comment("是否启用，禁用的节点执行时被跳过并透传到下游节点"),
 	}
 }
 
@@ -185,12 +193,13 @@ func (WorkflowEdge) Fields() []ent.Field {
 		field.String("source_handle").Optional().Comment("源节点连接点ID"),
 		field.String("target_handle").Optional().Comment("目标节点连接点ID"),
 		field.Enum("type").Values(
-			"default",  // 默认连接
-			"branch",   // 分支连接
-			"parallel", // 并行连接
+			"default",     // 默认连接
+			"branch",      // 分支连接
+			"parallel",    // 并行连接
+			"conditional", // 条件连接，data.condition 中的表达式成立时才沿该边继续
 		).Default("default").Comment("边类型"),
 		field.String("label").Optional().Comment("边标签"),
-		field.String("branch_name").Optional().Comment("分支名称（用于 condition_checker）"),
+		field.String("branch_name").Optional().Comment("分支名称（用于 condition_checker，default/else 为没有条件成立时的默认分支）"),
 		field.Bool("animated").Default(false).Comment("是否动画"),
 		field.JSON("style", map[string]interface{}{}).Optional().Comment("边样式"),
 		field.JSON("data", map[string]interface{}{}).Optional().Comment("边数据"),
@@ -255,6 +264,8 @@ func (WorkflowExecution) Fields() []ent.Field {
 		field.JSON("input", map[string]interface{}{}).Optional().Comment("执行输入"),
 		field.JSON("output", map[string]interface{}{}).Optional().Comment("执行输出"),
 		field.JSON("context", map[string]interface{}{}).Optional().Comment("执行上下文"),
+		field.JSON("variables", map[string]interface{}{}).Optional().Comment("本次执行生效的变量：应用变量与执行上下文深度合并的结果，同名键以执行上下文为准"),
+		field.Text("graph_snapshot").Optional().Comment("开始执行时的工作流图快照JSON（应用、节点和边），执行期间按快照遍历，不受之后的编辑影响"),
 		field.Time("started_at").Optional().Comment("开始时间"),
 		field.Time("finished_at").Optional().Comment("结束时间"),
 		field.Int("duration_ms").Default(0).Comment("执行时长(毫秒)"),
@@ -264,6 +275,7 @@ func (WorkflowExecution) Fields() []ent.Field {
 		field.Text("error_stack").Optional().Comment("错误堆栈"),
 		field.String("triggered_by").Optional().Comment("触发者"),
 		field.String("trigger_source").Optional().Comment("触发源"),
+		field.Uint64("parent_execution_id").Optional().Comment("父执行ID，由子工作流节点启动的执行指向调用方的执行"),
 	}
 }
 
@@ -273,6 +285,7 @@ func (WorkflowExecution) Indexes() []ent.Index {
 		index.Fields("application_id", "status"),
 		index.Fields("started_at"),
 		index.Fields("triggered_by"),
+		index.Fields("parent_execution_id"),
 	}
 }
 
@@ -381,6 +394,7 @@ func (WorkflowVersion) Fields() []ent.Field {
 		field.Uint("version").Comment("版本号"), // 在保存时自动增加
 		field.JSON("snapshot", map[string]interface{}{}).Comment("版本快照"),
 		field.String("change_log").Optional().Comment("变更日志"), // autosave或者手动保存时填写
+		field.Bool("pinned").Default(false).Comment("是否置顶"),   // 置顶的版本不会被自动清理
 	}
 }
 
@@ -425,3 +439,130 @@ func (WorkflowExecutionLog) Indexes() []ent.Index {
 		index.Fields("level"),
 	}
 }
+
+// WorkflowSchedule 工作流定时调度
+type WorkflowSchedule struct {
+	ent.Schema
+}
+
+func (WorkflowSchedule) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "workflow_schedules"},
+	}
+}
+
+func (WorkflowSchedule) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.BaseMixin{},
+		mixins.SoftDeleteMixin{},
+	}
+}
+
+func (WorkflowSchedule) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint64("application_id").Comment("工作流应用ID"),
+		field.String("name").Optional().Comment("调度名称"),
+		field.String("cron_expression").NotEmpty().Comment("Cron表达式"),
+		field.JSON("input", map[string]interface{}{}).Optional().Comment("执行输入"),
+		field.Bool("enabled").Default(true).Comment("是否启用"),
+		field.Time("last_run_at").Optional().Nillable().Comment("上次触发时间"),
+		field.Time("next_run_at").Optional().Nillable().Comment("下次触发时间"),
+	}
+}
+
+func (WorkflowSchedule) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("application_id"),
+		index.Fields("enabled"),
+	}
+}
+
+func (WorkflowSchedule) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("application", WorkflowApplication.Type).
+			Ref("schedules").Unique().
+			Field("application_id").
+			Required(),
+	}
+}
+
+// WorkflowSecret 工作流应用的加密密钥（如 API Key），节点配置通过 ${secrets.KEY} 按名称引用
+type WorkflowSecret struct {
+	ent.Schema
+}
+
+func (WorkflowSecret) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "workflow_secrets"},
+	}
+}
+
+func (WorkflowSecret) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.BaseMixin{},
+	}
+}
+
+func (WorkflowSecret) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint64("application_id").Comment("工作流应用ID"),
+		field.String("key").NotEmpty().Comment("密钥名称"),
+		field.Text("value").Sensitive().Comment("加密后的密钥值"),
+	}
+}
+
+func (WorkflowSecret) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("application_id", "key").Unique(),
+	}
+}
+
+func (WorkflowSecret) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("application", WorkflowApplication.Type).
+			Ref("secrets").Unique().
+			Field("application_id").
+			Required(),
+	}
+}
+
+// WorkflowApplicationShare 工作流应用共享，所有者将应用的只读或编辑权限授予其他用户
+type WorkflowApplicationShare struct {
+	ent.Schema
+}
+
+func (WorkflowApplicationShare) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "workflow_application_shares"},
+	}
+}
+
+func (WorkflowApplicationShare) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		mixins.BaseMixin{},
+	}
+}
+
+func (WorkflowApplicationShare) Fields() []ent.Field {
+	return []ent.Field{
+		field.Uint64("application_id").Comment("工作流应用ID"),
+		field.Uint64("user_id").Comment("被授权的用户ID"),
+		field.Enum("access").Values("read", "edit").Default("read").Comment("访问级别：read 只读，edit 可编辑"),
+	}
+}
+
+func (WorkflowApplicationShare) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("application_id", "user_id").Unique(),
+		index.Fields("user_id"),
+	}
+}
+
+func (WorkflowApplicationShare) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("application", WorkflowApplication.Type).
+			Ref("shares").Unique().
+			Field("application_id").
+			Required(),
+	}
+}
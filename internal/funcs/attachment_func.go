@@ -350,13 +350,18 @@ func (AttachmentFuncs) PrepareUpload(ctx context.Context, req *models.PrepareUpl
 
 	// 使用S3客户端生成预签名URL
 	s3Client := s3.GetClient()
-	uploadURL, err := s3Client.GetPresignedPutURL(bucket, filePath, time.Hour, req.ContentType)
+	upload, err := s3Client.PresignPutObject(bucket, filePath, time.Hour, &s3.UploadOptions{
+		ContentType:        req.ContentType,
+		ContentDisposition: req.ContentDisposition,
+		Metadata:           req.Metadata,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate presigned URL: %w", err)
 	}
 
 	return &models.PrepareUploadResponse{
-		UploadURL:       uploadURL,
+		UploadURL:       upload.URL,
+		Headers:         upload.Headers,
 		UploadSessionID: uploadSessionID,
 		ExpiresAt:       time.Now().Add(time.Hour).Unix(),
 		AttachmentID:    utils.ToString(attachmentRecord.ID),
@@ -0,0 +1,105 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/jwt"
+)
+
+// tokenClaimsPolicy accessToken声明扩展策略
+// 声明是签发时的快照，角色或权限变更后最多滞后一个accessToken有效期，
+// 因此携带声明的accessToken有效期被限制为 maxAccessLifetime
+type tokenClaimsPolicy struct {
+	enabled            bool
+	devices            map[string]bool // 生效的终端名称或编码，为空表示所有终端
+	includePermissions bool
+	maxRoles           int
+	maxPermissions     int
+	maxAccessLifetime  time.Duration // 0表示不限制
+}
+
+// claimsPolicy 全局声明扩展策略，启动时由配置覆盖
+var claimsPolicy = &tokenClaimsPolicy{}
+
+// InitTokenClaimsPolicy 根据配置初始化accessToken声明扩展策略
+func InitTokenClaimsPolicy(config *configs.TokenClaimsConfig) {
+	claimsPolicy = newTokenClaimsPolicy(config)
+}
+
+// newTokenClaimsPolicy 根据配置创建声明扩展策略
+func newTokenClaimsPolicy(config *configs.TokenClaimsConfig) *tokenClaimsPolicy {
+	devices := make(map[string]bool, len(config.Devices))
+	for _, device := range config.Devices {
+		if device = strings.TrimSpace(device); device != "" {
+			devices[device] = true
+		}
+	}
+	return &tokenClaimsPolicy{
+		enabled:            config.Enabled,
+		devices:            devices,
+		includePermissions: config.IncludePermissions,
+		maxRoles:           config.MaxRoles,
+		maxPermissions:     config.MaxPermissions,
+		maxAccessLifetime:  time.Duration(config.MaxAccessTokenExpiry) * time.Millisecond,
+	}
+}
+
+// applies 判断策略是否对该终端生效
+func (p *tokenClaimsPolicy) applies(device *ent.ClientDevice) bool {
+	if !p.enabled || device == nil {
+		return false
+	}
+	if len(p.devices) == 0 {
+		return true
+	}
+	return p.devices[device.Name] || p.devices[device.Code]
+}
+
+// accessLifetime 返回携带声明的accessToken有效期，不超过策略上限
+func (p *tokenClaimsPolicy) accessLifetime(lifetime time.Duration) time.Duration {
+	if p.maxAccessLifetime > 0 && lifetime > p.maxAccessLifetime {
+		return p.maxAccessLifetime
+	}
+	return lifetime
+}
+
+// build 根据角色和权限构建有数量上限的声明，未开启权限声明时只携带角色
+func (p *tokenClaimsPolicy) build(roles []*ent.Role, permissions []*ent.Permission) *jwt.AccessClaims {
+	roleNames := make([]string, 0, len(roles))
+	for _, role := range roles {
+		roleNames = append(roleNames, role.Name)
+	}
+
+	// 权限检查同时匹配 name 和 action，两者都写入哈希
+	var permissionValues []string
+	maxPermissions := 0
+	if p.includePermissions {
+		maxPermissions = p.maxPermissions
+		for _, permission := range permissions {
+			permissionValues = append(permissionValues, permission.Name, permission.Action)
+		}
+	}
+	return jwt.NewAccessClaims(roleNames, permissionValues, p.maxRoles, maxPermissions)
+}
+
+// loadAccessClaims 查询用户当前的角色和权限并构建声明，策略对终端不生效时返回nil
+func (p *tokenClaimsPolicy) loadAccessClaims(ctx context.Context, userID uint64, device *ent.ClientDevice) (*jwt.AccessClaims, error) {
+	if !p.applies(device) {
+		return nil, nil
+	}
+	roles, err := UserFuncs{}.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var permissions []*ent.Permission
+	if p.includePermissions {
+		if permissions, err = (UserFuncs{}).GetUserPermissions(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return p.build(roles, permissions), nil
+}
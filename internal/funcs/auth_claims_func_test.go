@@ -0,0 +1,54 @@
+package funcs
+
+import (
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/jwt"
+)
+
+func TestTokenClaimsPolicy(t *testing.T) {
+	web := &ent.ClientDevice{Name: "web", Code: "web-code"}
+	api := &ent.ClientDevice{Name: "api", Code: "api-code"}
+
+	disabled := newTokenClaimsPolicy(&configs.TokenClaimsConfig{Devices: []string{"web"}})
+	if disabled.applies(web) {
+		t.Fatal("disabled policy should not apply")
+	}
+
+	policy := newTokenClaimsPolicy(&configs.TokenClaimsConfig{
+		Enabled:              true,
+		Devices:              []string{" web "},
+		MaxRoles:             1,
+		MaxPermissions:       4,
+		MaxAccessTokenExpiry: 60000,
+	})
+	if !policy.applies(web) || policy.applies(api) || policy.applies(nil) {
+		t.Fatal("policy should only apply to configured devices")
+	}
+	if got := policy.accessLifetime(time.Hour); got != time.Minute {
+		t.Fatalf("access lifetime should be capped, got %v", got)
+	}
+	if got := policy.accessLifetime(30 * time.Second); got != 30*time.Second {
+		t.Fatalf("shorter access lifetime should be kept, got %v", got)
+	}
+
+	roles := []*ent.Role{{Name: "editor"}, {Name: "admin"}}
+	permissions := []*ent.Permission{{Name: "workflow-delete", Action: "workflow:delete"}}
+	claims := policy.build(roles, permissions)
+	if len(claims.Roles) != 1 || claims.Roles[0] != "admin" || !claims.Truncated {
+		t.Fatalf("roles should be bounded: %+v", claims)
+	}
+	if claims.PermHashes != nil {
+		t.Fatalf("permissions should be omitted unless include_permissions is set: %v", claims.PermHashes)
+	}
+
+	policy.includePermissions = true
+	claims = policy.build(roles, permissions)
+	token := &jwt.Claims{PermHashes: claims.PermHashes}
+	if !token.HasPermission("workflow:delete") || !token.HasPermission("workflow-delete") {
+		t.Fatalf("permission name and action should both be hashed: %v", claims.PermHashes)
+	}
+}
@@ -0,0 +1,167 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// ErrLastLoginCredential 删除后用户将没有可用的登录方式
+var ErrLastLoginCredential = errors.New("不能删除最后一个可用的登录方式")
+
+// ErrCredentialNotFound 认证方式不存在或不属于当前用户
+var ErrCredentialNotFound = errors.New("认证方式不存在")
+
+// GetUserCredentials 获取用户的所有认证方式，按创建时间排序；邮箱和手机号脱敏，不返回密钥
+func (AuthFuncs) GetUserCredentials(ctx context.Context, userID uint64) ([]*models.CredentialResponse, error) {
+	credentials, err := database.Client.Credential.Query().
+		Where(credential.UserIDEQ(userID)).
+		Order(ent.Asc(credential.FieldCreateTime), ent.Asc(credential.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询用户认证信息失败: %w", err)
+	}
+
+	now := time.Now()
+	result := make([]*models.CredentialResponse, 0, len(credentials))
+	for _, record := range credentials {
+		result = append(result, convertCredentialToResponse(record, now))
+	}
+	return result, nil
+}
+
+// UnlinkCredential 删除用户的一个认证方式，删除后用户必须仍有至少一个可用的登录方式。
+// 检查和删除在同一事务内完成并锁定用户的认证记录，防止并发删除不同的认证方式后用户没有可用的登录方式
+func (AuthFuncs) UnlinkCredential(ctx context.Context, userID, credentialID uint64) error {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	credentials, err := tx.Credential.Query().
+		Where(credential.UserIDEQ(userID), lockCredentialRows).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("查询用户认证信息失败: %w", err)
+	}
+
+	found := false
+	remaining := 0
+	now := time.Now()
+	for _, record := range credentials {
+		if record.ID == credentialID {
+			found = true
+			continue
+		}
+		if isLoginCredential(record, now) {
+			remaining++
+		}
+	}
+	if !found {
+		return ErrCredentialNotFound
+	}
+	if remaining == 0 {
+		return ErrLastLoginCredential
+	}
+
+	if err := tx.Credential.DeleteOneID(credentialID).Exec(ctx); err != nil {
+		return fmt.Errorf("删除认证方式失败: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// lockCredentialRows 以 SELECT ... FOR UPDATE 锁定查询到的认证记录直到事务结束；
+// SQLite 不支持行锁，其写事务本身互斥，不加锁
+func lockCredentialRows(s *sql.Selector) {
+	if s.Dialect() != dialect.SQLite {
+		s.ForUpdate()
+	}
+}
+
+// isLoginCredential 判断认证方式能否单独用于登录：密码需已设置，邮箱和手机号需已验证（验证码登录），
+// OAuth 和通行密钥直接可用；TOTP 只能作为第二因素。已过期的认证方式不可用
+func isLoginCredential(record *ent.Credential, now time.Time) bool {
+	if record.ExpiresAt != nil && !record.ExpiresAt.After(now) {
+		return false
+	}
+	switch record.CredentialType {
+	case credential.CredentialTypePassword:
+		return record.Secret != ""
+	case credential.CredentialTypeEmail, credential.CredentialTypePhone:
+		return record.IsVerified
+	case credential.CredentialTypeOauth, credential.CredentialTypeWebauthn:
+		return true
+	}
+	return false
+}
+
+// convertCredentialToResponse 转换认证方式为响应格式
+func convertCredentialToResponse(record *ent.Credential, now time.Time) *models.CredentialResponse {
+	resp := &models.CredentialResponse{
+		ID:             utils.Uint64ToString(record.ID),
+		CredentialType: string(record.CredentialType),
+		Identifier:     maskCredentialIdentifier(string(record.CredentialType), record.Identifier),
+		Provider:       record.Provider,
+		IsVerified:     record.IsVerified,
+		LoginMethod:    isLoginCredential(record, now),
+		CreateTime:     utils.FormatDateTime(record.CreateTime),
+	}
+	if name, ok := record.Metadata["name"].(string); ok {
+		resp.Name = name
+	}
+	if record.VerifiedAt != nil {
+		verifiedAt := utils.FormatDateTime(*record.VerifiedAt)
+		resp.VerifiedAt = &verifiedAt
+	}
+	if record.LastUsedAt != nil {
+		lastUsedAt := utils.FormatDateTime(*record.LastUsedAt)
+		resp.LastUsedAt = &lastUsedAt
+	}
+	if record.ExpiresAt != nil {
+		expiresAt := utils.FormatDateTime(*record.ExpiresAt)
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+// maskCredentialIdentifier 脱敏邮箱和手机号，其他类型的标识符原样返回
+func maskCredentialIdentifier(credentialType, identifier string) string {
+	switch credentialType {
+	case CredentialTypeEmail:
+		local, domain, ok := strings.Cut(identifier, "@")
+		if !ok {
+			return maskMiddle(identifier, 1, 0)
+		}
+		return maskMiddle(local, 1, 0) + "@" + domain
+	case CredentialTypePhone:
+		return maskMiddle(identifier, 3, 4)
+	}
+	return identifier
+}
+
+// maskMiddle 保留开头 head 个和结尾 tail 个字符，中间替换为星号；过短时只保留第一个字符
+func maskMiddle(s string, head, tail int) string {
+	runes := []rune(s)
+	if len(runes) <= head+tail {
+		if len(runes) <= 1 {
+			return strings.Repeat("*", len(runes))
+		}
+		return string(runes[:1]) + strings.Repeat("*", len(runes)-1)
+	}
+	return string(runes[:head]) + strings.Repeat("*", len(runes)-head-tail) + string(runes[len(runes)-tail:])
+}
@@ -0,0 +1,143 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/database"
+)
+
+func TestUnlinkCredentialKeepsLastLoginMethod(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 2, "name": "bob", "status": "active"})
+	ctx := context.Background()
+
+	password := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypePassword).
+		SetIdentifier("alice").
+		SetSecret("hashed").
+		SetIsVerified(true).
+		SaveX(ctx)
+	email := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypeEmail).
+		SetIdentifier("alice@example.com").
+		SetIsVerified(false).
+		SaveX(ctx)
+	totp := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypeTotp).
+		SetIdentifier("alice").
+		SetSecret("totp-secret").
+		SetLastUsedAt(time.Now()).
+		SaveX(ctx)
+	phone := client.Credential.Create().
+		SetUserID(2).
+		SetCredentialType(credential.CredentialTypePhone).
+		SetIdentifier("13812345678").
+		SetIsVerified(true).
+		SaveX(ctx)
+
+	credentials, err := AuthFuncs{}.GetUserCredentials(ctx, 1)
+	if err != nil {
+		t.Fatalf("list credentials failed: %v", err)
+	}
+	if len(credentials) != 3 {
+		t.Fatalf("expected 3 credentials, got %+v", credentials)
+	}
+	byType := make(map[string]bool)
+	for _, c := range credentials {
+		byType[c.CredentialType] = c.LoginMethod
+		if c.CredentialType == CredentialTypeEmail && c.Identifier != "a****@example.com" {
+			t.Fatalf("email not masked: %s", c.Identifier)
+		}
+		if c.CredentialType == CredentialTypeTotp && c.LastUsedAt == nil {
+			t.Fatal("expected last used time for totp")
+		}
+	}
+	if !byType[CredentialTypePassword] || byType[CredentialTypeEmail] || byType[CredentialTypeTotp] {
+		t.Fatalf("unexpected login methods: %v", byType)
+	}
+
+	// 未验证的邮箱和 TOTP 都不能单独登录，密码是唯一的登录方式
+	if err := (AuthFuncs{}).UnlinkCredential(ctx, 1, password.ID); !errors.Is(err, ErrLastLoginCredential) {
+		t.Fatalf("expected last credential guard, got %v", err)
+	}
+	// 不能解绑其他用户的认证方式
+	if err := (AuthFuncs{}).UnlinkCredential(ctx, 1, phone.ID); !errors.Is(err, ErrCredentialNotFound) {
+		t.Fatalf("expected not found for another user's credential, got %v", err)
+	}
+	if err := (AuthFuncs{}).UnlinkCredential(ctx, 1, totp.ID); err != nil {
+		t.Fatalf("unlink totp failed: %v", err)
+	}
+
+	// 邮箱验证后成为可用的登录方式，密码可以解绑，此后邮箱成为最后一个登录方式
+	client.Credential.UpdateOneID(email.ID).SetIsVerified(true).ExecX(ctx)
+	if err := (AuthFuncs{}).UnlinkCredential(ctx, 1, password.ID); err != nil {
+		t.Fatalf("unlink password failed: %v", err)
+	}
+	if err := (AuthFuncs{}).UnlinkCredential(ctx, 1, email.ID); !errors.Is(err, ErrLastLoginCredential) {
+		t.Fatalf("expected last credential guard, got %v", err)
+	}
+
+	credentials, err = AuthFuncs{}.GetUserCredentials(ctx, 2)
+	if err != nil || len(credentials) != 1 || credentials[0].Identifier != "138****5678" {
+		t.Fatalf("unexpected phone credential: %+v (%v)", credentials, err)
+	}
+}
+
+func TestUnlinkCredentialConcurrent(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	// 单连接下事务互斥，并发删除按事务串行执行
+	db.SetMaxOpenConns(1)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	ctx := context.Background()
+	password := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypePassword).
+		SetIdentifier("alice").
+		SetSecret("hashed").
+		SetIsVerified(true).
+		SaveX(ctx)
+	email := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypeEmail).
+		SetIdentifier("alice@example.com").
+		SetIsVerified(true).
+		SaveX(ctx)
+
+	// 同时删除仅有的两个登录方式，只能有一个成功
+	errs := make(chan error, 2)
+	for _, id := range []uint64{password.ID, email.ID} {
+		go func(id uint64) { errs <- (AuthFuncs{}).UnlinkCredential(ctx, 1, id) }(id)
+	}
+	succeeded := 0
+	for i := 0; i < 2; i++ {
+		err := <-errs
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrLastLoginCredential):
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("expected exactly one unlink to succeed, got %d", succeeded)
+	}
+	if count := client.Credential.Query().Where(credential.UserIDEQ(1)).CountX(ctx); count != 1 {
+		t.Fatalf("expected one login method left, got %d", count)
+	}
+}
@@ -0,0 +1,142 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdentifierKindUsername 用户名标识符，对应 password 类型的认证信息（其标识符即用户名）
+const IdentifierKindUsername = "username"
+
+// loginIdentifierPriority 灵活登录时标识符类型的判定顺序，启动时由配置覆盖
+var loginIdentifierPriority = []string{CredentialTypeEmail, CredentialTypePhone, IdentifierKindUsername}
+
+// InitLoginIdentifierPriority 根据配置初始化灵活登录的标识符判定顺序，配置为空时保持默认顺序，包含无效值时返回错误且不做修改
+func InitLoginIdentifierPriority(priority []string) error {
+	if len(priority) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(priority))
+	result := make([]string, 0, len(priority))
+	for _, kind := range priority {
+		kind = strings.ToLower(strings.TrimSpace(kind))
+		switch kind {
+		case CredentialTypeEmail, CredentialTypePhone, IdentifierKindUsername:
+		default:
+			return fmt.Errorf("invalid login identifier kind %q", kind)
+		}
+		if _, ok := seen[kind]; ok {
+			continue
+		}
+		seen[kind] = struct{}{}
+		result = append(result, kind)
+	}
+	loginIdentifierPriority = result
+	return nil
+}
+
+// loginCredentialCandidates 按判定顺序返回标识符可能对应的认证类型，格式不符的类型会被排除
+func loginCredentialCandidates(identifier string, priority []string) []string {
+	candidates := make([]string, 0, len(priority))
+	for _, kind := range priority {
+		switch kind {
+		case CredentialTypeEmail:
+			if utils.IsValidEmail(identifier) {
+				candidates = append(candidates, CredentialTypeEmail)
+			}
+		case CredentialTypePhone:
+			if utils.IsValidPhone(identifier) {
+				candidates = append(candidates, CredentialTypePhone)
+			}
+		case IdentifierKindUsername:
+			candidates = append(candidates, CredentialTypePassword)
+		}
+	}
+	return candidates
+}
+
+// resolveLoginCredential 按候选顺序查找第一个存在的认证信息，都不存在时返回 nil
+func resolveLoginCredential(ctx context.Context, identifier string, candidates []string) (*ent.Credential, error) {
+	for _, credentialType := range candidates {
+		record, err := database.Client.Credential.Query().
+			Where(
+				credential.CredentialTypeEQ(credential.CredentialType(credentialType)),
+				credential.Identifier(identifier),
+			).
+			First(ctx)
+		if err == nil {
+			return record, nil
+		}
+		if !ent.IsNotFound(err) {
+			return nil, fmt.Errorf("查询用户认证信息失败: %w", err)
+		}
+	}
+	return nil, nil
+}
+
+// UserLoginFlexible 灵活登录，客户端无需指定认证类型
+// 根据标识符格式按配置的顺序判定是邮箱、手机号还是用户名，找到对应认证信息后：
+// 提供验证码时走验证码登录（仅邮箱、手机号），否则使用该用户的密码登录
+func (AuthFuncs) UserLoginFlexible(ctx context.Context, ginCtx *gin.Context, identifier, secret, verifyCodeStr, deviceCode string) (*ent.User, error) {
+	identifier = strings.TrimSpace(identifier)
+	if identifier == "" {
+		return nil, fmt.Errorf("请提供登录标识")
+	}
+	if secret == "" && verifyCodeStr == "" {
+		return nil, fmt.Errorf("请提供密码或验证码")
+	}
+
+	if err := checkLoginIP(ctx, ginCtx); err != nil {
+		return nil, err
+	}
+
+	candidates := loginCredentialCandidates(identifier, loginIdentifierPriority)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("无法识别的登录标识")
+	}
+
+	record, err := resolveLoginCredential(ctx, identifier, candidates)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		// 交由常规登录流程记录失败尝试并返回统一的错误信息
+		return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, candidates[0], identifier, secret, verifyCodeStr, deviceCode)
+	}
+
+	credentialType := string(record.CredentialType)
+	if credentialType == CredentialTypePassword {
+		if secret == "" {
+			return nil, fmt.Errorf("用户名登录必须提供密码")
+		}
+		return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, credentialType, identifier, secret, "", deviceCode)
+	}
+
+	if verifyCodeStr != "" {
+		return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, credentialType, identifier, "", verifyCodeStr, deviceCode)
+	}
+
+	// 邮箱/手机号 + 密码：使用同一用户的密码认证信息校验
+	passwordRecord, err := database.Client.Credential.Query().
+		Where(
+			credential.UserID(record.UserID),
+			credential.CredentialTypeEQ(credential.CredentialTypePassword),
+		).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("该账号未设置密码，请使用验证码登录")
+		}
+		return nil, fmt.Errorf("查询用户认证信息失败: %w", err)
+	}
+	return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, CredentialTypePassword, passwordRecord.Identifier, secret, "", deviceCode)
+}
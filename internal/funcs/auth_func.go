@@ -5,13 +5,16 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"go-backend/database/ent"
 	"go-backend/database/ent/clientdevice"
 	"go-backend/database/ent/credential"
 	"go-backend/database/ent/user"
+	"go-backend/pkg/configs"
 	"go-backend/pkg/database"
 	"go-backend/pkg/jwt"
 	"go-backend/pkg/logging"
@@ -23,19 +26,48 @@ import (
 )
 
 const (
-	// 密码哈希参数
-	argonTime    = 1
-	argonMemory  = 64 * 1024
-	argonThreads = 4
-	argonKeyLen  = 32
-	argonSaltLen = 16
+	// 旧版密码哈希参数（未内嵌参数的历史哈希使用该组参数校验）
+	legacyArgonTime    = 1
+	legacyArgonMemory  = 64 * 1024
+	legacyArgonThreads = 4
+	legacyArgonKeyLen  = 32
+	legacyArgonSaltLen = 16
+
+	// argonPHCPrefix PHC 格式哈希串前缀
+	argonPHCPrefix = "$argon2id$"
+
+	// 哈希参数的合法范围，存储的哈希串和配置都需满足，避免被篡改的哈希串以极端参数耗尽资源或降低强度
+	argonMinSaltLen = 16
+	argonMinKeyLen  = 16
+	argonMaxMemory  = 4 * 1024 * 1024 // KiB，即 4 GiB
+	argonMaxTime    = 64
 )
 
+// argonParams Argon2id 哈希参数
+type argonParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+	keyLen  uint32
+	saltLen uint32
+}
+
+// passwordHashParams 当前用于生成新哈希的参数，启动时由配置覆盖
+var passwordHashParams = argonParams{
+	time:    legacyArgonTime,
+	memory:  legacyArgonMemory,
+	threads: legacyArgonThreads,
+	keyLen:  legacyArgonKeyLen,
+	saltLen: legacyArgonSaltLen,
+}
+
 // 认证用途常量
 const (
 	PurposeLogin         = "login"          // 登录
 	PurposeRegister      = "register"       // 注册
 	PurposeResetPassword = "reset_password" // 重置密码
+	PurposeChangeContact = "change_contact" // 更换邮箱/手机号
+	PurposeSetPassword   = "set_password"   // 首次设置密码
 )
 
 // 认证方式常量
@@ -45,27 +77,160 @@ const (
 	CredentialTypePhone    = "phone"
 	CredentialTypeOauth    = "oauth"
 	CredentialTypeTotp     = "totp"
+	CredentialTypeWebAuthn = "webauthn"
 )
 
 type AuthFuncs struct{}
 
+// validateArgonParams 校验哈希参数是否在合法范围内：并行度至少为1，迭代次数在1到 argonMaxTime 之间，
+// 内存不低于 8*并行度（Argon2 的要求）且不超过 argonMaxMemory，盐值和哈希值不短于16字节
+func validateArgonParams(params argonParams) error {
+	if params.threads < 1 {
+		return fmt.Errorf("Argon2并行度必须至少为1")
+	}
+	if params.time < 1 || params.time > argonMaxTime {
+		return fmt.Errorf("Argon2迭代次数超出范围: %d", params.time)
+	}
+	if params.memory < 8*uint32(params.threads) || params.memory > argonMaxMemory {
+		return fmt.Errorf("Argon2内存开销超出范围: %d KiB", params.memory)
+	}
+	if params.saltLen < argonMinSaltLen {
+		return fmt.Errorf("Argon2盐值长度不能小于%d字节", argonMinSaltLen)
+	}
+	if params.keyLen < argonMinKeyLen {
+		return fmt.Errorf("Argon2哈希长度不能小于%d字节", argonMinKeyLen)
+	}
+	return nil
+}
+
+// InitPasswordHasher 根据配置初始化密码哈希参数，配置的参数超出合法范围时保留原有参数
+func InitPasswordHasher(cfg *configs.Argon2Config) {
+	params := passwordHashParams
+	if cfg.Time > 0 {
+		params.time = cfg.Time
+	}
+	if cfg.Memory > 0 {
+		params.memory = cfg.Memory
+	}
+	if cfg.Threads > 0 {
+		params.threads = cfg.Threads
+	}
+	if cfg.KeyLen > 0 {
+		params.keyLen = cfg.KeyLen
+	}
+	if cfg.SaltLen > 0 {
+		params.saltLen = cfg.SaltLen
+	}
+	if err := validateArgonParams(params); err != nil {
+		logging.Error("Invalid auth.argon2 configuration, keeping current parameters: %v", err)
+		params = passwordHashParams
+	}
+	passwordHashParams = params
+
+	logging.Info("Argon2 parameters: m=%d, t=%d, p=%d, keyLen=%d, saltLen=%d",
+		params.memory, params.time, params.threads, params.keyLen, params.saltLen)
+
+	if cfg.BenchmarkOnStart {
+		BenchmarkPasswordHash(time.Duration(cfg.MinDuration) * time.Millisecond)
+	}
+}
+
+// BenchmarkPasswordHash 测量当前参数下单次哈希的耗时，低于目标耗时时输出告警
+func BenchmarkPasswordHash(target time.Duration) time.Duration {
+	params := passwordHashParams
+	salt := make([]byte, params.saltLen)
+
+	start := time.Now()
+	argon2.IDKey([]byte("benchmark-password"), salt, params.time, params.memory, params.threads, params.keyLen)
+	elapsed := time.Since(start)
+
+	if target > 0 && elapsed < target {
+		logging.Warn("Argon2 hashing took %v, below target %v; consider increasing auth.argon2.time or auth.argon2.memory", elapsed, target)
+	} else {
+		logging.Info("Argon2 hashing took %v", elapsed)
+	}
+
+	return elapsed
+}
+
+// encodeArgonHash 编码为 PHC 格式: $argon2id$v=19$m=...,t=...,p=...$salt$hash
+func encodeArgonHash(params argonParams, salt, hash []byte) string {
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argonPHCPrefix, argon2.Version, params.memory, params.time, params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(hash),
+	)
+}
+
+// decodeArgonHash 解析 PHC 格式的哈希串，返回哈希参数、盐值和哈希值
+func decodeArgonHash(encoded string) (argonParams, []byte, []byte, error) {
+	var params argonParams
+
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, fmt.Errorf("哈希格式错误")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return params, nil, nil, fmt.Errorf("解析哈希版本失败: %w", err)
+	}
+	if version != argon2.Version {
+		return params, nil, nil, fmt.Errorf("不支持的Argon2版本: %d", version)
+	}
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return params, nil, nil, fmt.Errorf("解析哈希参数失败: %w", err)
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("解码盐值失败: %w", err)
+	}
+
+	hash, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, fmt.Errorf("解码哈希值失败: %w", err)
+	}
+
+	params.saltLen = uint32(len(salt))
+	params.keyLen = uint32(len(hash))
+	if err := validateArgonParams(params); err != nil {
+		return params, nil, nil, err
+	}
+	return params, salt, hash, nil
+}
+
 // hashPassword 哈希密码
+// 返回 PHC 格式的哈希串（内嵌参数和盐值）以及单独编码的盐值
 func (AuthFuncs) hashPassword(password string) (string, string, error) {
-	salt := make([]byte, argonSaltLen)
+	params := passwordHashParams
+
+	salt := make([]byte, params.saltLen)
 	if _, err := rand.Read(salt); err != nil {
 		return "", "", fmt.Errorf("生成盐值失败: %w", err)
 	}
 
-	hash := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	hash := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
 
 	// 返回哈希值和盐值
 	saltStr := base64.StdEncoding.EncodeToString(salt)
-	hashStr := base64.StdEncoding.EncodeToString(hash)
-	return hashStr, saltStr, nil
+	return encodeArgonHash(params, salt, hash), saltStr, nil
 }
 
 // verifyPassword 验证密码
 func (AuthFuncs) verifyPassword(password, hashedPassword, saltStr string) (bool, error) {
+	// PHC 格式，使用哈希创建时的参数进行校验
+	if strings.HasPrefix(hashedPassword, argonPHCPrefix) {
+		params, salt, hash, err := decodeArgonHash(hashedPassword)
+		if err != nil {
+			return false, err
+		}
+
+		newHash := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLen)
+		return subtle.ConstantTimeCompare(hash, newHash) == 1, nil
+	}
+
 	// 如果没有盐值，尝试使用旧格式（向后兼容）
 	if saltStr == "" {
 		return AuthFuncs{}.verifyPasswordLegacy(password, hashedPassword)
@@ -83,7 +248,7 @@ func (AuthFuncs) verifyPassword(password, hashedPassword, saltStr string) (bool,
 	}
 
 	// 计算新的哈希值进行比较
-	newHash := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	newHash := argon2.IDKey([]byte(password), salt, legacyArgonTime, legacyArgonMemory, legacyArgonThreads, legacyArgonKeyLen)
 
 	return subtle.ConstantTimeCompare(hash, newHash) == 1, nil
 }
@@ -95,14 +260,14 @@ func (AuthFuncs) verifyPasswordLegacy(password, hashedPassword string) (bool, er
 		return false, fmt.Errorf("解码哈希密码失败: %w", err)
 	}
 
-	if len(decoded) != argonSaltLen+argonKeyLen {
+	if len(decoded) != legacyArgonSaltLen+legacyArgonKeyLen {
 		return false, fmt.Errorf("哈希密码格式错误")
 	}
 
-	salt := decoded[:argonSaltLen]
-	hash := decoded[argonSaltLen:]
+	salt := decoded[:legacyArgonSaltLen]
+	hash := decoded[legacyArgonSaltLen:]
 
-	newHash := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	newHash := argon2.IDKey([]byte(password), salt, legacyArgonTime, legacyArgonMemory, legacyArgonThreads, legacyArgonKeyLen)
 
 	return subtle.ConstantTimeCompare(hash, newHash) == 1, nil
 }
@@ -124,13 +289,18 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 		sessionID = AuthFuncs{}.generateSessionID()
 	}
 
+	// 来源IP被临时封禁时直接拒绝，不再查询凭据
+	if err := checkLoginIP(ctx, ginCtx); err != nil {
+		return nil, err
+	}
+
 	// 找设备信息
 	clientDevice, err := ClientDeviceFuncs{}.GetClientDeviceByCodeInner(ctx, deviceCode)
 
 	if err != nil {
 		if ent.IsNotFound(err) {
 			failureReason = "终端信息无效"
-			return nil, fmt.Errorf(failureReason)
+			return nil, errors.New(failureReason)
 		}
 		failureReason = "查询终端类型失败"
 		return nil, fmt.Errorf("%s: %w", failureReason, err)
@@ -171,7 +341,8 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 	if err != nil {
 		if ent.IsNotFound(err) {
 			failureReason = "用户不存在或认证信息无效"
-			return nil, fmt.Errorf(failureReason)
+			recordLoginIPFailure(ctx, ginCtx, credentialType, identifier)
+			return nil, errors.New(failureReason)
 		}
 		failureReason = "查询用户认证信息失败"
 		return nil, fmt.Errorf("%s: %w", failureReason, err)
@@ -181,12 +352,12 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 	userRecord = credentialRecord.Edges.User
 	if userRecord == nil {
 		failureReason = "用户信息异常"
-		return nil, fmt.Errorf(failureReason)
+		return nil, errors.New(failureReason)
 	}
 
-	if userRecord.Status == user.StatusInactive {
-		failureReason = "用户账号已禁用"
-		return nil, fmt.Errorf(failureReason)
+	if err := checkUserActive(userRecord); err != nil {
+		failureReason = err.Error()
+		return nil, err
 	}
 
 	var needRoleIds []uint64
@@ -220,7 +391,7 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 		remainingTime := credentialRecord.LockedUntil.Sub(now)
 		loginStatus = LoginStatusLocked
 		failureReason = fmt.Sprintf("账号已锁定，剩余时间: %v", remainingTime.Round(time.Minute))
-		return nil, fmt.Errorf(failureReason)
+		return nil, errors.New(failureReason)
 	}
 
 	// 如果锁定时间已过期，自动解锁并重置失败次数
@@ -256,7 +427,7 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 		// 密码登录直接校验密码
 		if credentialRecord.Secret == "" {
 			failureReason = "未设置密码"
-			return nil, fmt.Errorf(failureReason)
+			return nil, errors.New(failureReason)
 		}
 
 		match, err := AuthFuncs{}.verifyPassword(secret, credentialRecord.Secret, credentialRecord.Salt)
@@ -268,17 +439,34 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 		if !authSuccess {
 			failureReason = "用户名或密码错误"
 		}
+	} else if credentialType == CredentialTypeWebAuthn {
+		// 通行密钥登录，secret 为认证器返回的断言
+		err = verifyWebAuthnAssertion(ctx, credentialRecord, secret)
+		authSuccess = err == nil
+		if err != nil {
+			failureReason = err.Error()
+		}
+	} else if credentialType == CredentialTypeEmail && verifyCodeStr == "" && secret != "" {
+		// 魔法链接登录，secret 为链接中的一次性令牌
+		err = consumeMagicLinkToken(ctx, secret, identifier, deviceCode)
+		authSuccess = err == nil
+		if err != nil {
+			failureReason = err.Error()
+		}
 	} else {
 		// 其他认证方式需要验证码
 		if verifyCodeStr == "" {
 			failureReason = "请提供验证码"
-			return nil, fmt.Errorf(failureReason)
+			return nil, errors.New(failureReason)
 		}
 
 		err = VerifyCodeFuncs{}.VerifyCode(ctx, credentialType, PurposeLogin, identifier, verifyCodeStr)
 		if err != nil {
 			authSuccess = false
 			failureReason = "验证码错误或已过期"
+			if IsVerifyCodeRejected(err) {
+				failureReason = err.Error()
+			}
 		} else {
 			authSuccess = true
 		}
@@ -296,7 +484,8 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 		loginStatus = LoginStatusSuccess
 		failureReason = "" // 清空失败原因
 	} else {
-		// 认证失败，增加失败次数
+		// 认证失败，增加失败次数，同时计入来源IP的失败统计
+		recordLoginIPFailure(ctx, ginCtx, credentialType, identifier)
 		failedAttempts := credentialRecord.FailedAttempts + 1
 		updateBuilder = updateBuilder.SetFailedAttempts(failedAttempts)
 
@@ -323,7 +512,12 @@ func (AuthFuncs) UserLoginWithContext(ctx context.Context, ginCtx *gin.Context,
 				failureReason = "验证码错误或已过期"
 			}
 		}
-		return nil, fmt.Errorf(failureReason)
+		return nil, errors.New(failureReason)
+	}
+
+	// 单终端单会话：在签发新令牌前使同一终端的旧会话失效，失败时不影响本次登录
+	if err := displaceDeviceSessions(ctx, userRecord.ID, clientDevice); err != nil {
+		logging.Warn("顶替用户 %d 在终端 %d 的旧会话失败: %v", userRecord.ID, clientDevice.ID, err)
 	}
 
 	// 如果有gin上下文，将sessionID存储到上下文中，后续可以用于退出登录时更新记录
@@ -363,6 +557,12 @@ func (AuthFuncs) UserRegister(ctx context.Context, credentialType, identifier, s
 		return nil, fmt.Errorf("用户已存在")
 	}
 
+	if secret != "" {
+		if err := ValidatePasswordPolicy(secret); err != nil {
+			return nil, err
+		}
+	}
+
 	// 如果不是密码注册，需要验证验证码
 	if credentialType != CredentialTypePassword {
 		if verifyCodeStr == "" {
@@ -448,6 +648,10 @@ func (AuthFuncs) UserRegister(ctx context.Context, credentialType, identifier, s
 
 // ResetPassword 重置密码
 func (AuthFuncs) ResetPassword(ctx context.Context, credentialType, identifier, newPassword, verifyCodeStr, oldPassword string) error {
+	if err := ValidatePasswordPolicy(newPassword); err != nil {
+		return err
+	}
+
 	tx, err := database.Client.Tx(ctx)
 	if err != nil {
 		return fmt.Errorf("开始事务失败: %w", err)
@@ -558,6 +762,232 @@ func (AuthFuncs) ResetPassword(ctx context.Context, credentialType, identifier,
 	return nil
 }
 
+// SetInitialPassword 为没有密码认证方式的用户（如通过OAuth、手机号注册）设置密码
+// 验证码需以 set_password 用途发送到用户任一已验证的邮箱或手机号；已设置过密码的用户应使用重置密码
+func (AuthFuncs) SetInitialPassword(ctx context.Context, userID uint64, newPassword, verifyCode string) error {
+	if err := ValidatePasswordPolicy(newPassword); err != nil {
+		return err
+	}
+	if verifyCode == "" {
+		return fmt.Errorf("请提供验证码")
+	}
+
+	hasPassword, err := database.Client.Credential.Query().
+		Where(
+			credential.UserIDEQ(userID),
+			credential.CredentialTypeEQ(credential.CredentialTypePassword),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("查询密码认证记录失败: %w", err)
+	}
+	if hasPassword {
+		return fmt.Errorf("已设置密码")
+	}
+
+	contacts, err := database.Client.Credential.Query().
+		Where(
+			credential.UserIDEQ(userID),
+			credential.CredentialTypeIn(credential.CredentialTypeEmail, credential.CredentialTypePhone),
+			credential.IsVerified(true),
+		).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("查询用户认证信息失败: %w", err)
+	}
+	if len(contacts) == 0 {
+		return fmt.Errorf("没有已验证的邮箱或手机号")
+	}
+
+	// 验证码可能发送到任意一个联系方式，优先返回实际存在验证码的联系方式的错误（过期或次数过多）
+	verified := false
+	verifyErr := ErrVerifyCodeInvalid
+	for _, contact := range contacts {
+		err := (VerifyCodeFuncs{}).VerifyCode(ctx, string(contact.CredentialType), PurposeSetPassword, contact.Identifier, verifyCode)
+		if err == nil {
+			verified = true
+			break
+		}
+		if !errors.Is(err, ErrVerifyCodeInvalid) {
+			verifyErr = err
+		}
+	}
+	if !verified {
+		return fmt.Errorf("验证码验证失败: %w", verifyErr)
+	}
+
+	hashedPassword, saltStr, err := AuthFuncs{}.hashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("密码哈希失败: %w", err)
+	}
+
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 事务内再次检查，防止并发设置
+	hasPassword, err = tx.Credential.Query().
+		Where(
+			credential.UserIDEQ(userID),
+			credential.CredentialTypeEQ(credential.CredentialTypePassword),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("查询密码认证记录失败: %w", err)
+	}
+	if hasPassword {
+		return fmt.Errorf("已设置密码")
+	}
+
+	// 与重置密码一致，密码登录的标识符为用户名
+	userRecord, err := tx.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("用户不存在")
+		}
+		return fmt.Errorf("查询用户信息失败: %w", err)
+	}
+	_, err = tx.Credential.Create().
+		SetUserID(userID).
+		SetCredentialType(credential.CredentialTypePassword).
+		SetIdentifier(userRecord.Name).
+		SetSecret(hashedPassword).
+		SetSalt(saltStr).
+		SetIsVerified(true).
+		SetVerifiedAt(time.Now()).
+		SetFailedAttempts(0).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("创建密码认证记录失败: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// contactChangePurpose 更换联系方式的验证码用途，绑定到用户，防止验证码被其他用户使用
+func contactChangePurpose(userID uint64) string {
+	return fmt.Sprintf("%s:%d", PurposeChangeContact, userID)
+}
+
+// checkContactCredentialType 检查是否为可更换的联系方式类型
+func checkContactCredentialType(credentialType string) error {
+	if credentialType != CredentialTypeEmail && credentialType != CredentialTypePhone {
+		return fmt.Errorf("不支持更换该类型的认证方式")
+	}
+	return nil
+}
+
+// isIdentifierInUse 检查标识符是否已被其他认证记录使用
+func isIdentifierInUse(ctx context.Context, client *ent.Client, credentialType, identifier string) (bool, error) {
+	return client.Credential.Query().
+		Where(
+			credential.CredentialTypeEQ(credential.CredentialType(credentialType)),
+			credential.Identifier(identifier),
+		).
+		Exist(ctx)
+}
+
+// RequestContactChange 申请更换邮箱/手机号，向新的标识符发送验证码，确认前原认证方式保持可用
+func (AuthFuncs) RequestContactChange(ctx context.Context, userID uint64, credentialType, newIdentifier string) error {
+	if err := checkContactCredentialType(credentialType); err != nil {
+		return err
+	}
+
+	inUse, err := isIdentifierInUse(ctx, database.Client, credentialType, newIdentifier)
+	if err != nil {
+		return fmt.Errorf("检查标识符是否已被使用失败: %w", err)
+	}
+	if inUse {
+		return fmt.Errorf("该标识已被使用")
+	}
+
+	return VerifyCodeFuncs{}.sendVerificationCode(ctx, credentialType, contactChangePurpose(userID), newIdentifier, nil)
+}
+
+// ConfirmContactChange 校验验证码并更换邮箱/手机号，用户没有该类型的认证方式时新增
+func (AuthFuncs) ConfirmContactChange(ctx context.Context, userID uint64, credentialType, newIdentifier, code string) error {
+	if err := checkContactCredentialType(credentialType); err != nil {
+		return err
+	}
+
+	// 先检查冲突，避免在标识符已被占用时消耗验证码
+	inUse, err := isIdentifierInUse(ctx, database.Client, credentialType, newIdentifier)
+	if err != nil {
+		return fmt.Errorf("检查标识符是否已被使用失败: %w", err)
+	}
+	if inUse {
+		return fmt.Errorf("该标识已被使用")
+	}
+
+	err = VerifyCodeFuncs{}.VerifyCode(ctx, credentialType, contactChangePurpose(userID), newIdentifier, code)
+	if err != nil {
+		return fmt.Errorf("验证码验证失败: %w", err)
+	}
+
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	// 事务内再次检查，防止并发申请同一标识符
+	inUse, err = isIdentifierInUse(ctx, tx.Client(), credentialType, newIdentifier)
+	if err != nil {
+		return fmt.Errorf("检查标识符是否已被使用失败: %w", err)
+	}
+	if inUse {
+		return fmt.Errorf("该标识已被使用")
+	}
+
+	now := time.Now()
+	credentialRecord, err := tx.Credential.Query().
+		Where(
+			credential.UserIDEQ(userID),
+			credential.CredentialTypeEQ(credential.CredentialType(credentialType)),
+		).
+		First(ctx)
+
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			return fmt.Errorf("查询用户认证信息失败: %w", err)
+		}
+
+		_, err = tx.Credential.Create().
+			SetUserID(userID).
+			SetCredentialType(credential.CredentialType(credentialType)).
+			SetIdentifier(newIdentifier).
+			SetIsVerified(true).
+			SetVerifiedAt(now).
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("创建认证记录失败: %w", err)
+		}
+	} else {
+		_, err = credentialRecord.Update().
+			SetIdentifier(newIdentifier).
+			SetIsVerified(true).
+			SetVerifiedAt(now).
+			SetFailedAttempts(0).
+			ClearLockedUntil().
+			Save(ctx)
+		if err != nil {
+			return fmt.Errorf("更新认证记录失败: %w", err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+
+	return nil
+}
+
 // BuildUserInfoWithToken 构建包含Token和角色权限的用户信息
 func (AuthFuncs) BuildUserInfoWithToken(ctx context.Context, user *ent.User, clientId *uint64, rememberMe bool) (*models.UserInfo, *models.TokenInfo, error) {
 	userInfo := &models.UserInfo{
@@ -618,17 +1048,35 @@ func (AuthFuncs) BuildUserInfoWithToken(ctx context.Context, user *ent.User, cli
 			return nil, nil, fmt.Errorf("查找终端类型失败")
 		}
 
-		tokenInfo.RefreshExpiredIn = uint64(time.Now().Add(time.Duration(client.RefreshTokenExpiry) * time.Millisecond).UnixMilli())
-		tokenInfo.AccessExpiredIn = uint64(time.Now().Add(time.Duration(client.AccessTokenExpiry) * time.Millisecond).UnixMilli())
-		timeoutAccess := time.Duration(client.AccessTokenExpiry) * time.Millisecond
-		timeoutRefresh := time.Duration(client.RefreshTokenExpiry) * time.Millisecond
+		// 未勾选"记住我"的会话使用更短的refreshToken有效期，accessToken也不能晚于refreshToken过期
+		now := time.Now()
+		timeoutRefresh := refreshTokenLifetime(client.RefreshTokenExpiry, rememberMe, sessionRefreshTokenCap())
+		refreshExpiresAt := now.Add(timeoutRefresh)
+		accessLifetime := time.Duration(client.AccessTokenExpiry) * time.Millisecond
+		var accessClaims *jwt.AccessClaims
+		if claimsPolicy.applies(client) {
+			// 携带角色/权限声明的accessToken使用较短的有效期，限制权限变更后的滞后时间
+			accessLifetime = claimsPolicy.accessLifetime(accessLifetime)
+			accessClaims = claimsPolicy.build(roles, permissions)
+		}
+		// 终端配置的受众和授权范围同时写入accessToken和refreshToken
+		accessClaims = withDeviceTokenScope(accessClaims, client)
+		accessExpiresAt := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, rememberMe)
+		timeoutAccess := accessExpiresAt.Sub(now)
 
-		tokenInfo.AccessToken, err = jwt.GenerateAccessToken(user.ID, client.ID, timeoutAccess)
+		tokenInfo.RefreshExpiredIn = uint64(refreshExpiresAt.UnixMilli())
+		tokenInfo.AccessExpiredIn = uint64(accessExpiresAt.UnixMilli())
+
+		if accessClaims != nil {
+			tokenInfo.AccessToken, err = jwt.GenerateEnrichedAccessToken(user.ID, client.ID, timeoutAccess, accessClaims)
+		} else {
+			tokenInfo.AccessToken, err = jwt.GenerateAccessToken(user.ID, client.ID, timeoutAccess)
+		}
 		if err != nil {
 			return nil, nil, fmt.Errorf("生成JWT Token失败: %w", err)
 		}
 
-		tokenInfo.RefreshToken, err = jwt.GenerateRefreshToken(user.ID, client.ID, timeoutRefresh, rememberMe)
+		tokenInfo.RefreshToken, err = jwt.GenerateRefreshTokenWithClaims(user.ID, client.ID, timeoutRefresh, rememberMe, deviceTokenScope(client))
 
 		if err != nil {
 			return nil, nil, fmt.Errorf("生成JWT Token失败: %w", err)
@@ -637,6 +1085,33 @@ func (AuthFuncs) BuildUserInfoWithToken(ctx context.Context, user *ent.User, cli
 
 	return userInfo, &tokenInfo, nil
 }
+
+// sessionRefreshTokenCap 获取未勾选"记住我"时refreshToken的绝对有效期上限（毫秒）
+func sessionRefreshTokenCap() uint64 {
+	return configs.GetConfig().Auth.Device.SessionRefreshTokenExpiry
+}
+
+// refreshTokenLifetime 计算refreshToken的有效期
+// 勾选"记住我"时使用设备配置的有效期；否则取设备配置与会话上限中的较小者，上限为0表示不限制
+func refreshTokenLifetime(deviceRefreshExpiry uint64, rememberMe bool, sessionCap uint64) time.Duration {
+	expiry := deviceRefreshExpiry
+	if !rememberMe && sessionCap > 0 && sessionCap < expiry {
+		expiry = sessionCap
+	}
+	return time.Duration(expiry) * time.Millisecond
+}
+
+// accessTokenExpiresAt 计算accessToken的过期时间
+// 未勾选"记住我"的会话refreshToken不会续期，accessToken的过期时间不能超过refreshToken的过期时间
+func accessTokenExpiresAt(now time.Time, accessLifetime time.Duration, refreshExpiresAt time.Time, rememberMe bool) time.Time {
+	expiresAt := now.Add(accessLifetime)
+	if !rememberMe && expiresAt.After(refreshExpiresAt) {
+		return refreshExpiresAt
+	}
+	return expiresAt
+}
+
+// RefreshToken 使用refreshToken换取新的accessToken，勾选"记住我"的会话在refreshToken过半后轮换
 func (AuthFuncs) RefreshToken(ctx context.Context, accessToken, refreshToken string) (*models.TokenInfo, error) {
 	// 如果accessToken没有过期，则不允许刷新
 	if accessToken != "" {
@@ -652,6 +1127,21 @@ func (AuthFuncs) RefreshToken(ctx context.Context, accessToken, refreshToken str
 		logging.Error("验证refresh Token失败: %v", err)
 		return nil, fmt.Errorf("验证Token失败")
 	}
+	if err := CheckSessionRevoked(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	// 停用或封禁的用户不能刷新令牌
+	tokenUser, err := database.Client.User.Get(ctx, claims.UserID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("用户不存在")
+		}
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+	if err := checkUserActive(tokenUser); err != nil {
+		return nil, err
+	}
 
 	// 获取客户端设备配置信息
 	client, err := ClientDeviceFuncs{}.GetClientDeviceByIdInner(ctx, claims.ClientDeviceId)
@@ -660,31 +1150,44 @@ func (AuthFuncs) RefreshToken(ctx context.Context, accessToken, refreshToken str
 	}
 
 	// 生成新的access token
-	timeoutAccess := time.Duration(client.AccessTokenExpiry) * time.Millisecond
-	newToken, err := jwt.RefreshToken(refreshToken, client.ID, timeoutAccess)
+	// 未勾选"记住我"的会话不会续期refresh token，因此accessToken也不能超出refresh token的过期时间
+	now := time.Now()
+	refreshExpiresAt := time.UnixMilli(int64(claims.Expiry))
+	// 开启声明扩展的终端重新查询角色和权限，不沿用旧令牌中的声明
+	accessLifetime := time.Duration(client.AccessTokenExpiry) * time.Millisecond
+	accessClaims, err := claimsPolicy.loadAccessClaims(ctx, claims.UserID, client)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户角色权限失败: %w", err)
+	}
+	if accessClaims != nil {
+		accessLifetime = claimsPolicy.accessLifetime(accessLifetime)
+	}
+	// 授权范围按终端当前的配置重新写入
+	accessClaims = withDeviceTokenScope(accessClaims, client)
+	accessExpiresAt := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, claims.RememberMe)
+	timeoutAccess := accessExpiresAt.Sub(now)
+	newToken, err := jwt.RefreshTokenWithClaims(refreshToken, client.ID, timeoutAccess, accessClaims)
 	if err != nil {
 		return nil, fmt.Errorf("token刷新失败: %w", err)
 	}
 
 	tokenInfo := models.TokenInfo{
 		AccessToken:     newToken,
-		AccessExpiredIn: uint64(time.Now().Add(timeoutAccess).UnixMilli()),
+		AccessExpiredIn: uint64(accessExpiresAt.UnixMilli()),
 	}
 
 	// 如果勾选了"记住我"功能，需要判断是否刷新refresh token
 	if claims.RememberMe {
-		timeoutRefresh := time.Duration(client.RefreshTokenExpiry) * time.Millisecond
+		timeoutRefresh := refreshTokenLifetime(client.RefreshTokenExpiry, true, 0)
 
 		// 计算refresh token的剩余有效时间
-		now := time.Now()
-		refreshExpiresAt := time.UnixMilli(int64(claims.Expiry))
 		remainingTime := refreshExpiresAt.Sub(now)
 		totalTime := timeoutRefresh
 
 		// 只有在refresh token过期时间过半时才重新生成，避免退化成单token模式
 		if remainingTime < totalTime/2 {
 			// 重新生成refresh token
-			newRefreshToken, err := jwt.GenerateRefreshToken(claims.UserID, client.ID, timeoutRefresh, claims.RememberMe)
+			newRefreshToken, err := jwt.GenerateRefreshTokenWithClaims(claims.UserID, client.ID, timeoutRefresh, claims.RememberMe, deviceTokenScope(client))
 			if err != nil {
 				return nil, fmt.Errorf("refresh Token刷新失败: %w", err)
 			}
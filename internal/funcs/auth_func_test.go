@@ -0,0 +1,326 @@
+package funcs
+
+import (
+	"context"
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/credential"
+	vcpkg "go-backend/internal/funcs/verifycode"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	testDeviceRefreshExpiry = uint64(30 * 24 * 3600 * 1000) // 30天
+	testSessionCap          = uint64(12 * 3600 * 1000)      // 12小时
+)
+
+func TestRefreshTokenLifetimeRememberMe(t *testing.T) {
+	got := refreshTokenLifetime(testDeviceRefreshExpiry, true, testSessionCap)
+	if got != 30*24*time.Hour {
+		t.Fatalf("expected remember-me lifetime 30d, got %s", got)
+	}
+}
+
+func TestRefreshTokenLifetimeSession(t *testing.T) {
+	got := refreshTokenLifetime(testDeviceRefreshExpiry, false, testSessionCap)
+	if got != 12*time.Hour {
+		t.Fatalf("expected session lifetime 12h, got %s", got)
+	}
+
+	// 设备配置比会话上限更短时使用设备配置
+	got = refreshTokenLifetime(uint64(time.Hour/time.Millisecond), false, testSessionCap)
+	if got != time.Hour {
+		t.Fatalf("expected device lifetime 1h, got %s", got)
+	}
+
+	// 上限为0表示不限制
+	got = refreshTokenLifetime(testDeviceRefreshExpiry, false, 0)
+	if got != 30*24*time.Hour {
+		t.Fatalf("expected uncapped lifetime 30d, got %s", got)
+	}
+}
+
+func TestAccessTokenExpiresAtBoundary(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	accessLifetime := 2 * time.Hour
+
+	// 会话剩余时间充足时不受影响
+	refreshExpiresAt := now.Add(12 * time.Hour)
+	if got := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, false); !got.Equal(now.Add(accessLifetime)) {
+		t.Fatalf("unexpected access expiry: %s", got)
+	}
+
+	// 恰好在边界上
+	refreshExpiresAt = now.Add(accessLifetime)
+	if got := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, false); !got.Equal(refreshExpiresAt) {
+		t.Fatalf("unexpected access expiry at boundary: %s", got)
+	}
+
+	// 未勾选"记住我"时不能超过refreshToken的过期时间
+	refreshExpiresAt = now.Add(30 * time.Minute)
+	if got := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, false); !got.Equal(refreshExpiresAt) {
+		t.Fatalf("expected access expiry capped at %s, got %s", refreshExpiresAt, got)
+	}
+
+	// 勾选"记住我"时refreshToken会轮换，不做截断
+	if got := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, true); !got.Equal(now.Add(accessLifetime)) {
+		t.Fatalf("unexpected remember-me access expiry: %s", got)
+	}
+}
+
+func TestLoginCredentialCandidates(t *testing.T) {
+	priority := []string{CredentialTypeEmail, CredentialTypePhone, IdentifierKindUsername}
+
+	cases := map[string][]string{
+		"alice@example.com": {CredentialTypeEmail, CredentialTypePassword},
+		"13800138000":       {CredentialTypePhone, CredentialTypePassword},
+		"alice":             {CredentialTypePassword},
+	}
+	for identifier, want := range cases {
+		if got := loginCredentialCandidates(identifier, priority); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: expected %v, got %v", identifier, want, got)
+		}
+	}
+
+	// 用户名优先时，纯数字标识符先按用户名查找
+	got := loginCredentialCandidates("13800138000", []string{IdentifierKindUsername, CredentialTypePhone})
+	if want := []string{CredentialTypePassword, CredentialTypePhone}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestInitLoginIdentifierPriority(t *testing.T) {
+	original := loginIdentifierPriority
+	defer func() { loginIdentifierPriority = original }()
+
+	if err := InitLoginIdentifierPriority([]string{" Username ", "phone", "username"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{IdentifierKindUsername, CredentialTypePhone}; !reflect.DeepEqual(loginIdentifierPriority, want) {
+		t.Fatalf("expected %v, got %v", want, loginIdentifierPriority)
+	}
+
+	if err := InitLoginIdentifierPriority([]string{"oauth"}); err == nil {
+		t.Fatal("expected error for invalid identifier kind")
+	}
+	if want := []string{IdentifierKindUsername, CredentialTypePhone}; !reflect.DeepEqual(loginIdentifierPriority, want) {
+		t.Fatalf("invalid config should keep previous priority, got %v", loginIdentifierPriority)
+	}
+}
+
+// withPasswordHashParams 在测试期间替换生成新哈希的参数
+func withPasswordHashParams(t *testing.T, params argonParams) {
+	t.Helper()
+	previous := passwordHashParams
+	passwordHashParams = params
+	t.Cleanup(func() { passwordHashParams = previous })
+}
+
+func TestPasswordHashPHCRoundTrip(t *testing.T) {
+	params := argonParams{time: 2, memory: 1024, threads: 2, keyLen: 24, saltLen: 20}
+	withPasswordHashParams(t, params)
+
+	hashed, saltStr, err := AuthFuncs{}.hashPassword("s3cret")
+	if err != nil {
+		t.Fatalf("hash failed: %v", err)
+	}
+	if !strings.HasPrefix(hashed, "$argon2id$v=19$m=1024,t=2,p=2$") {
+		t.Fatalf("unexpected PHC string: %s", hashed)
+	}
+
+	decoded, salt, hash, err := decodeArgonHash(hashed)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if decoded != params || len(hash) != 24 || base64.StdEncoding.EncodeToString(salt) != saltStr {
+		t.Fatalf("decoded params = %+v, salt %d bytes, hash %d bytes", decoded, len(salt), len(hash))
+	}
+
+	// 生成参数改变后，旧哈希仍按内嵌的参数校验
+	withPasswordHashParams(t, argonParams{time: 1, memory: 2048, threads: 1, keyLen: 32, saltLen: 16})
+	if ok, err := (AuthFuncs{}).verifyPassword("s3cret", hashed, saltStr); err != nil || !ok {
+		t.Fatalf("correct password should verify: ok = %v, err = %v", ok, err)
+	}
+	if ok, _ := (AuthFuncs{}).verifyPassword("wrong", hashed, saltStr); ok {
+		t.Fatal("wrong password should not verify")
+	}
+}
+
+func TestDecodeArgonHashRejectsUnsafeParams(t *testing.T) {
+	salt := base64.RawStdEncoding.EncodeToString(make([]byte, 16))
+	key := base64.RawStdEncoding.EncodeToString(make([]byte, 32))
+	shortSalt := base64.RawStdEncoding.EncodeToString(make([]byte, 8))
+	shortKey := base64.RawStdEncoding.EncodeToString(make([]byte, 4))
+
+	cases := map[string]string{
+		"valid":                "$argon2id$v=19$m=65536,t=1,p=4$" + salt + "$" + key,
+		"zero parallelism":     "$argon2id$v=19$m=65536,t=1,p=0$" + salt + "$" + key,
+		"zero iterations":      "$argon2id$v=19$m=65536,t=0,p=4$" + salt + "$" + key,
+		"memory below 8*p":     "$argon2id$v=19$m=16,t=1,p=4$" + salt + "$" + key,
+		"memory above maximum": "$argon2id$v=19$m=4294967295,t=1,p=4$" + salt + "$" + key,
+		"time above maximum":   "$argon2id$v=19$m=65536,t=4294967295,p=4$" + salt + "$" + key,
+		"short salt":           "$argon2id$v=19$m=65536,t=1,p=4$" + shortSalt + "$" + key,
+		"short key":            "$argon2id$v=19$m=65536,t=1,p=4$" + salt + "$" + shortKey,
+		"wrong version":        "$argon2id$v=16$m=65536,t=1,p=4$" + salt + "$" + key,
+		"wrong algorithm":      "$argon2i$v=19$m=65536,t=1,p=4$" + salt + "$" + key,
+	}
+	for name, encoded := range cases {
+		_, _, _, err := decodeArgonHash(encoded)
+		if (err == nil) != (name == "valid") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+}
+
+func TestVerifyLegacyPasswordHashes(t *testing.T) {
+	salt := []byte("0123456789abcdef")
+	hash := argon2.IDKey([]byte("s3cret"), salt, legacyArgonTime, legacyArgonMemory, legacyArgonThreads, legacyArgonKeyLen)
+
+	// 盐值单独保存的旧格式
+	hashed, saltStr := base64.StdEncoding.EncodeToString(hash), base64.StdEncoding.EncodeToString(salt)
+	if ok, err := (AuthFuncs{}).verifyPassword("s3cret", hashed, saltStr); err != nil || !ok {
+		t.Fatalf("separate-salt legacy hash should verify: ok = %v, err = %v", ok, err)
+	}
+	if ok, _ := (AuthFuncs{}).verifyPassword("wrong", hashed, saltStr); ok {
+		t.Fatal("wrong password should not verify against separate-salt legacy hash")
+	}
+
+	// 盐值拼接在哈希之前、没有单独盐值的更早格式
+	combined := base64.StdEncoding.EncodeToString(append(append([]byte{}, salt...), hash...))
+	if ok, err := (AuthFuncs{}).verifyPassword("s3cret", combined, ""); err != nil || !ok {
+		t.Fatalf("combined legacy hash should verify: ok = %v, err = %v", ok, err)
+	}
+	if ok, _ := (AuthFuncs{}).verifyPassword("wrong", combined, ""); ok {
+		t.Fatal("wrong password should not verify against combined legacy hash")
+	}
+	if _, err := (AuthFuncs{}).verifyPassword("s3cret", base64.StdEncoding.EncodeToString(hash), ""); err == nil {
+		t.Fatal("combined legacy hash with the wrong length should be rejected")
+	}
+}
+
+func TestInitPasswordHasher(t *testing.T) {
+	defaults := argonParams{time: legacyArgonTime, memory: legacyArgonMemory, threads: legacyArgonThreads, keyLen: legacyArgonKeyLen, saltLen: legacyArgonSaltLen}
+
+	withPasswordHashParams(t, defaults)
+	InitPasswordHasher(&configs.Argon2Config{Time: 3, Memory: 32 * 1024, Threads: 2, KeyLen: 48, SaltLen: 24})
+	if want := (argonParams{time: 3, memory: 32 * 1024, threads: 2, keyLen: 48, saltLen: 24}); passwordHashParams != want {
+		t.Fatalf("configured params = %+v, want %+v", passwordHashParams, want)
+	}
+
+	// 未配置的参数保留默认值
+	withPasswordHashParams(t, defaults)
+	InitPasswordHasher(&configs.Argon2Config{Time: 2})
+	want := defaults
+	want.time = 2
+	if passwordHashParams != want {
+		t.Fatalf("partially configured params = %+v, want %+v", passwordHashParams, want)
+	}
+
+	// 超出合法范围的配置不生效
+	for _, cfg := range []configs.Argon2Config{
+		{SaltLen: 8},
+		{KeyLen: 8},
+		{Memory: 8},
+		{Memory: argonMaxMemory + 1},
+		{Time: argonMaxTime + 1},
+	} {
+		withPasswordHashParams(t, defaults)
+		InitPasswordHasher(&cfg)
+		if passwordHashParams != defaults {
+			t.Errorf("invalid config %+v should keep defaults, got %+v", cfg, passwordHashParams)
+		}
+	}
+}
+
+// recordingCodeSender 记录发送的验证码，不实际发送
+type recordingCodeSender struct {
+	codes map[string]string
+}
+
+func (s *recordingCodeSender) Send(ctx context.Context, identifier, code, purpose string) error {
+	s.codes[purpose+"/"+identifier] = code
+	return nil
+}
+
+func (s *recordingCodeSender) GetType() vcpkg.SenderType {
+	return vcpkg.EmailSender
+}
+
+func TestContactChangeIdentifierInUse(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	previousSender, _ := vcpkg.DefaultSenderFactory.GetSender(vcpkg.EmailSender)
+	sender := &recordingCodeSender{codes: map[string]string{}}
+	vcpkg.DefaultSenderFactory.RegisterSender(vcpkg.EmailSender, sender)
+	t.Cleanup(func() {
+		database.Client = previous
+		vcpkg.DefaultSenderFactory.RegisterSender(vcpkg.EmailSender, previousSender)
+	})
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 2, "name": "bob", "status": "active"})
+	ctx := context.Background()
+	alice := client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypeEmail).
+		SetIdentifier("alice@example.com").
+		SetIsVerified(true).
+		SaveX(ctx)
+	client.Credential.Create().
+		SetUserID(2).
+		SetCredentialType(credential.CredentialTypeEmail).
+		SetIdentifier("bob@example.com").
+		SetIsVerified(true).
+		SaveX(ctx)
+	codeFor := func(userID uint64, identifier string) string {
+		t.Helper()
+		code, ok := sender.codes[contactChangePurpose(userID)+"/"+identifier]
+		if !ok {
+			t.Fatalf("no code sent to %s for user %d", identifier, userID)
+		}
+		return code
+	}
+	expectInUse := func(err error) {
+		t.Helper()
+		if err == nil || !strings.Contains(err.Error(), "该标识已被使用") {
+			t.Fatalf("expected identifier in use error, got %v", err)
+		}
+	}
+
+	// 已绑定到其他用户的邮箱不发送验证码，也不能确认
+	expectInUse(AuthFuncs{}.RequestContactChange(ctx, 1, CredentialTypeEmail, "bob@example.com"))
+	if len(sender.codes) != 0 {
+		t.Fatalf("no code should be sent for an identifier in use: %v", sender.codes)
+	}
+	expectInUse(AuthFuncs{}.ConfirmContactChange(ctx, 1, CredentialTypeEmail, "bob@example.com", "123456"))
+
+	// 申请后、确认前邮箱被其他用户绑定，确认时拒绝且不修改原邮箱
+	if err := (AuthFuncs{}).RequestContactChange(ctx, 1, CredentialTypeEmail, "new@example.com"); err != nil {
+		t.Fatalf("request contact change failed: %v", err)
+	}
+	aliceCode := codeFor(1, "new@example.com")
+	if err := (AuthFuncs{}).RequestContactChange(ctx, 2, CredentialTypeEmail, "new@example.com"); err != nil {
+		t.Fatalf("request contact change failed: %v", err)
+	}
+	if err := (AuthFuncs{}).ConfirmContactChange(ctx, 2, CredentialTypeEmail, "new@example.com", codeFor(2, "new@example.com")); err != nil {
+		t.Fatalf("confirm contact change failed: %v", err)
+	}
+	expectInUse(AuthFuncs{}.ConfirmContactChange(ctx, 1, CredentialTypeEmail, "new@example.com", aliceCode))
+	if record := client.Credential.GetX(ctx, alice.ID); record.Identifier != "alice@example.com" {
+		t.Fatalf("email should stay unchanged, got %s", record.Identifier)
+	}
+	owners := client.Credential.Query().
+		Where(credential.CredentialTypeEQ(credential.CredentialTypeEmail), credential.Identifier("new@example.com")).
+		AllX(ctx)
+	if len(owners) != 1 || owners[0].UserID != 2 {
+		t.Fatalf("new email should only be bound to bob: %+v", owners)
+	}
+}
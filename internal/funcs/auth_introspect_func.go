@@ -0,0 +1,47 @@
+package funcs
+
+import (
+	"context"
+
+	"go-backend/pkg/jwt"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// IntrospectToken 校验访问令牌并返回其有效期和关键声明，供前端判断何时主动刷新、供网关校验令牌
+// 令牌无效、已过期、为刷新令牌或所属会话已被撤销（被顶替、账号停用等）时返回 active=false
+func (AuthFuncs) IntrospectToken(ctx context.Context, tokenString string) *models.TokenIntrospectionResponse {
+	if tokenString == "" {
+		return &models.TokenIntrospectionResponse{Active: false}
+	}
+	claims, err := jwt.ValidateToken(tokenString)
+	return introspectClaims(ctx, claims, err)
+}
+
+// introspectClaims 在令牌校验结果的基础上检查会话撤销状态，与认证中间件对令牌的判断保持一致
+func introspectClaims(ctx context.Context, claims *jwt.Claims, err error) *models.TokenIntrospectionResponse {
+	resp := buildTokenIntrospection(claims, err)
+	if resp.Active && CheckSessionRevoked(ctx, claims) != nil {
+		return &models.TokenIntrospectionResponse{Active: false}
+	}
+	return resp
+}
+
+// buildTokenIntrospection 根据令牌校验结果构建自省响应
+func buildTokenIntrospection(claims *jwt.Claims, err error) *models.TokenIntrospectionResponse {
+	if err != nil || claims == nil || claims.IsRefresh {
+		return &models.TokenIntrospectionResponse{Active: false}
+	}
+
+	resp := &models.TokenIntrospectionResponse{
+		Active:         true,
+		UserID:         utils.Uint64ToString(claims.UserID),
+		ClientDeviceID: utils.Uint64ToString(claims.ClientDeviceId),
+		RememberMe:     &claims.RememberMe,
+	}
+	if claims.ExpiresAt != nil {
+		expiresAt := claims.ExpiresAt.Time
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
@@ -0,0 +1,81 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/jwt"
+	"go-backend/shared/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestBuildTokenIntrospection(t *testing.T) {
+	service := jwt.NewJWTService("test-secret", "test", "test")
+
+	token, err := service.GenerateToken(42, 7, time.Hour, false, true)
+	if err != nil {
+		t.Fatalf("failed to generate token: %v", err)
+	}
+	resp := buildTokenIntrospection(service.ValidateToken(token))
+	if !resp.Active || resp.UserID != "42" || resp.ClientDeviceID != "7" {
+		t.Fatalf("unexpected introspection: %+v", resp)
+	}
+	if resp.RememberMe == nil || !*resp.RememberMe {
+		t.Fatal("expected rememberMe to be true")
+	}
+	if resp.ExpiresAt == nil || time.Until(*resp.ExpiresAt) <= 50*time.Minute {
+		t.Fatalf("unexpected expiresAt: %v", resp.ExpiresAt)
+	}
+
+	expired, _ := service.GenerateToken(42, 7, -time.Minute, false, false)
+	if resp := buildTokenIntrospection(service.ValidateToken(expired)); resp.Active || resp.UserID != "" {
+		t.Fatalf("expired token should be inactive without claims: %+v", resp)
+	}
+
+	refresh, _ := service.GenerateToken(42, 7, time.Hour, true, false)
+	if resp := buildTokenIntrospection(service.ValidateToken(refresh)); resp.Active {
+		t.Fatal("refresh token should not be reported as an active access token")
+	}
+
+	if resp := buildTokenIntrospection(jwt.NewJWTService("other", "test", "test").ValidateToken(token)); resp.Active {
+		t.Fatal("token signed with another key should be inactive")
+	}
+}
+
+func TestIntrospectRevokedSession(t *testing.T) {
+	_, client := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = client.(*redis.Client)
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	service := jwt.NewJWTService("test-secret", "test", "test")
+	ctx := context.Background()
+	token, _ := service.GenerateToken(42, 7, time.Hour, false, false)
+	introspect := func() *models.TokenIntrospectionResponse {
+		claims, err := service.ValidateToken(token)
+		return introspectClaims(ctx, claims, err)
+	}
+	if resp := introspect(); !resp.Active {
+		t.Fatalf("token should be active before revocation: %+v", resp)
+	}
+
+	// 同一终端的新登录顶替旧会话
+	if err := sessionPolicy.revoke(ctx, client, 42, 7, time.Now().Add(time.Second), time.Hour); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	if resp := introspect(); resp.Active || resp.UserID != "" {
+		t.Fatalf("displaced session should be inactive without claims: %+v", resp)
+	}
+
+	// 用户的所有会话被撤销
+	client.(*redis.Client).FlushAll(ctx)
+	if err := sessionPolicy.revokeUser(ctx, client, 42, time.Now().Add(time.Second), time.Hour); err != nil {
+		t.Fatalf("revoke user failed: %v", err)
+	}
+	if resp := introspect(); resp.Active {
+		t.Fatalf("revoked user session should be inactive: %+v", resp)
+	}
+}
@@ -0,0 +1,197 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLoginIPBlocked 来源IP因短时间内对多个账号登录失败而被临时封禁
+var ErrLoginIPBlocked = errors.New("登录尝试过于频繁，当前IP已被临时限制")
+
+// loginIPKeys 登录IP防护的缓存键构建器
+var loginIPKeys = caching.NewKeyBuilder("login_ip")
+
+// loginIPGuard 基于来源IP的登录防护
+// 按凭据的锁定无法阻止同一IP对大量不同账号各尝试少量密码（撞库/密码喷洒），
+// 因此额外统计每个IP在时间窗口内登录失败涉及的不同标识符数量，达到阈值后临时拒绝该IP的登录请求
+type loginIPGuard struct {
+	enabled       bool
+	threshold     int64         // 窗口内失败涉及的不同标识符数量上限
+	window        time.Duration // 失败统计窗口，从最近一次失败开始计算
+	blockDuration time.Duration // 封禁时长
+	allowlist     []*net.IPNet  // 豁免的IP或网段
+}
+
+// ipGuard 全局登录IP防护，启动时由配置覆盖
+var ipGuard = &loginIPGuard{
+	enabled:       true,
+	threshold:     10,
+	window:        15 * time.Minute,
+	blockDuration: 30 * time.Minute,
+}
+
+// InitLoginIPGuard 根据配置初始化登录IP防护，配置无效时返回错误且不做修改
+func InitLoginIPGuard(config *configs.LoginIPGuardConfig) error {
+	guard, err := newLoginIPGuard(config)
+	if err != nil {
+		return err
+	}
+	ipGuard = guard
+	return nil
+}
+
+// newLoginIPGuard 根据配置创建登录IP防护
+func newLoginIPGuard(config *configs.LoginIPGuardConfig) (*loginIPGuard, error) {
+	if config.MaxDistinctIdentifiers <= 0 {
+		return nil, fmt.Errorf("max_distinct_identifiers must be greater than 0")
+	}
+	if config.Window <= 0 || config.BlockDuration <= 0 {
+		return nil, fmt.Errorf("window and block_duration must be greater than 0")
+	}
+
+	allowlist := make([]*net.IPNet, 0, len(config.Allowlist))
+	for _, entry := range config.Allowlist {
+		network, err := parseIPOrCIDR(entry)
+		if err != nil {
+			return nil, err
+		}
+		allowlist = append(allowlist, network)
+	}
+
+	return &loginIPGuard{
+		enabled:       config.Enabled,
+		threshold:     int64(config.MaxDistinctIdentifiers),
+		window:        config.Window,
+		blockDuration: config.BlockDuration,
+		allowlist:     allowlist,
+	}, nil
+}
+
+// parseIPOrCIDR 解析单个IP或CIDR网段，单个IP视为仅包含自身的网段
+func parseIPOrCIDR(entry string) (*net.IPNet, error) {
+	entry = strings.TrimSpace(entry)
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowlist entry %q: %w", entry, err)
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid allowlist entry %q", entry)
+	}
+	bits := 8 * net.IPv6len
+	if ip.To4() != nil {
+		ip = ip.To4()
+		bits = 8 * net.IPv4len
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// applies 判断该IP是否需要防护，未启用、IP无法识别或在白名单中时不做限制
+func (g *loginIPGuard) applies(ip string) bool {
+	if !g.enabled {
+		return false
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range g.allowlist {
+		if network.Contains(parsed) {
+			return false
+		}
+	}
+	return true
+}
+
+// check 检查IP是否处于封禁期，封禁中返回包装了 ErrLoginIPBlocked 的错误
+func (g *loginIPGuard) check(ctx context.Context, client redis.Cmdable, ip string) error {
+	if !g.applies(ip) {
+		return nil
+	}
+
+	remaining, err := client.TTL(ctx, loginIPKeys.Key("blocked", ip)).Result()
+	if err != nil {
+		return err
+	}
+	if remaining > 0 {
+		return fmt.Errorf("%w，请在%v后重试", ErrLoginIPBlocked, remaining.Round(time.Minute))
+	}
+	return nil
+}
+
+// recordFailure 记录一次登录失败，窗口内失败涉及的不同标识符达到阈值时封禁该IP并返回 true
+func (g *loginIPGuard) recordFailure(ctx context.Context, client redis.Cmdable, ip, credentialType, identifier string) (bool, error) {
+	if !g.applies(ip) {
+		return false, nil
+	}
+
+	failuresKey := loginIPKeys.Key("failures", ip)
+	member := credentialType + ":" + strings.ToLower(strings.TrimSpace(identifier))
+
+	pipe := client.TxPipeline()
+	pipe.SAdd(ctx, failuresKey, member)
+	pipe.Expire(ctx, failuresKey, g.window)
+	distinctCmd := pipe.SCard(ctx, failuresKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+
+	if distinctCmd.Val() < g.threshold {
+		return false, nil
+	}
+
+	// 达到阈值：封禁IP并清空失败记录，封禁结束后重新计数
+	pipe = client.TxPipeline()
+	pipe.Set(ctx, loginIPKeys.Key("blocked", ip), distinctCmd.Val(), g.blockDuration)
+	pipe.Del(ctx, failuresKey)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// checkLoginIP 登录前检查来源IP是否被封禁，Redis 不可用时放行，仅依赖按凭据的锁定
+func checkLoginIP(ctx context.Context, ginCtx *gin.Context) error {
+	if ginCtx == nil || caching.Client == nil {
+		return nil
+	}
+
+	err := ipGuard.check(ctx, caching.Client, ginCtx.ClientIP())
+	if err != nil && !errors.Is(err, ErrLoginIPBlocked) {
+		logging.Warn("检查登录IP封禁状态失败: %v", err)
+		return nil
+	}
+	return err
+}
+
+// recordLoginIPFailure 记录来源IP的一次登录失败
+func recordLoginIPFailure(ctx context.Context, ginCtx *gin.Context, credentialType, identifier string) {
+	if ginCtx == nil || caching.Client == nil {
+		return
+	}
+
+	ip := ginCtx.ClientIP()
+	blocked, err := ipGuard.recordFailure(ctx, caching.Client, ip, credentialType, identifier)
+	if err != nil {
+		logging.Warn("记录登录IP失败次数失败: %v", err)
+		return
+	}
+	if blocked {
+		logging.Warn("IP %s 短时间内对多个账号登录失败，已临时封禁 %v", ip, ipGuard.blockDuration)
+	}
+}
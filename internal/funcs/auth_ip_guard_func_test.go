@@ -0,0 +1,212 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+
+	"github.com/alicebob/miniredis"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func newIPGuardTestClient(t *testing.T) (*miniredis.Miniredis, redis.Cmdable) {
+	t.Helper()
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start fake redis: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func newTestIPGuard(t *testing.T, allowlist ...string) *loginIPGuard {
+	t.Helper()
+	guard, err := newLoginIPGuard(&configs.LoginIPGuardConfig{
+		Enabled:                true,
+		MaxDistinctIdentifiers: 3,
+		Window:                 10 * time.Minute,
+		BlockDuration:          30 * time.Minute,
+		Allowlist:              allowlist,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return guard
+}
+
+func TestLoginIPGuardBlocksSprayAcrossIdentifiers(t *testing.T) {
+	_, client := newIPGuardTestClient(t)
+	guard := newTestIPGuard(t)
+	ctx := context.Background()
+	ip := "198.51.100.7"
+
+	for i := 0; i < 3; i++ {
+		if err := guard.check(ctx, client, ip); err != nil {
+			t.Fatalf("attempt %d should not be blocked yet: %v", i, err)
+		}
+		blocked, err := guard.recordFailure(ctx, client, ip, CredentialTypePassword, fmt.Sprintf("user%d", i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if blocked != (i == 2) {
+			t.Fatalf("attempt %d: blocked = %v", i, blocked)
+		}
+	}
+
+	if err := guard.check(ctx, client, ip); !errors.Is(err, ErrLoginIPBlocked) {
+		t.Fatalf("expected ip to be blocked, got %v", err)
+	}
+	if err := guard.check(ctx, client, "198.51.100.8"); err != nil {
+		t.Fatalf("other ips must not be blocked: %v", err)
+	}
+}
+
+func TestLoginIPGuardCountsDistinctIdentifiersOnly(t *testing.T) {
+	_, client := newIPGuardTestClient(t)
+	guard := newTestIPGuard(t)
+	ctx := context.Background()
+	ip := "198.51.100.7"
+
+	// 同一账号的重复失败交给按凭据的锁定处理，不计入IP的不同标识符数量
+	for i := 0; i < 10; i++ {
+		if blocked, err := guard.recordFailure(ctx, client, ip, CredentialTypePassword, "Alice"); err != nil || blocked {
+			t.Fatalf("repeated failures for one identifier should not block ip: blocked=%v err=%v", blocked, err)
+		}
+	}
+	if err := guard.check(ctx, client, ip); err != nil {
+		t.Fatalf("unexpected block: %v", err)
+	}
+}
+
+func TestLoginIPGuardBlockExpires(t *testing.T) {
+	server, client := newIPGuardTestClient(t)
+	guard := newTestIPGuard(t)
+	ctx := context.Background()
+	ip := "198.51.100.7"
+
+	for i := 0; i < 3; i++ {
+		if _, err := guard.recordFailure(ctx, client, ip, CredentialTypeEmail, fmt.Sprintf("u%d@example.com", i)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	server.FastForward(31 * time.Minute)
+
+	if err := guard.check(ctx, client, ip); err != nil {
+		t.Fatalf("block should have expired: %v", err)
+	}
+}
+
+func TestLoginIPGuardAllowlist(t *testing.T) {
+	_, client := newIPGuardTestClient(t)
+	guard := newTestIPGuard(t, "203.0.113.10", "10.0.0.0/8")
+	ctx := context.Background()
+
+	for _, ip := range []string{"203.0.113.10", "10.1.2.3"} {
+		for i := 0; i < 5; i++ {
+			blocked, err := guard.recordFailure(ctx, client, ip, CredentialTypePassword, fmt.Sprintf("user%d", i))
+			if err != nil || blocked {
+				t.Fatalf("allowlisted ip %s must not be blocked: blocked=%v err=%v", ip, blocked, err)
+			}
+		}
+		if err := guard.check(ctx, client, ip); err != nil {
+			t.Fatalf("allowlisted ip %s must not be blocked: %v", ip, err)
+		}
+	}
+}
+
+func TestNewLoginIPGuardRejectsInvalidConfig(t *testing.T) {
+	valid := configs.LoginIPGuardConfig{MaxDistinctIdentifiers: 1, Window: time.Minute, BlockDuration: time.Minute}
+
+	invalid := valid
+	invalid.Allowlist = []string{"not-an-ip"}
+	if _, err := newLoginIPGuard(&invalid); err == nil {
+		t.Fatal("expected invalid allowlist entry to be rejected")
+	}
+
+	invalid = valid
+	invalid.MaxDistinctIdentifiers = 0
+	if _, err := newLoginIPGuard(&invalid); err == nil {
+		t.Fatal("expected zero threshold to be rejected")
+	}
+}
+
+func TestLoginIPGuardIgnoresSpoofedForwardedFor(t *testing.T) {
+	_, client := newIPGuardTestClient(t)
+	previousCache, previousGuard := caching.Client, ipGuard
+	caching.Client = client.(*redis.Client)
+	ipGuard = newTestIPGuard(t, "10.0.0.1")
+	t.Cleanup(func() { caching.Client, ipGuard = previousCache, previousGuard })
+	gin.SetMode(gin.TestMode)
+
+	// 与 createGinEngine 一致：只信任配置的代理
+	newEngine := func(trustedProxies []string) *gin.Engine {
+		engine := gin.New()
+		if err := engine.SetTrustedProxies(trustedProxies); err != nil {
+			t.Fatal(err)
+		}
+		engine.POST("/login", func(c *gin.Context) {
+			if err := checkLoginIP(c.Request.Context(), c); err != nil {
+				c.Status(http.StatusTooManyRequests)
+				return
+			}
+			recordLoginIPFailure(c.Request.Context(), c, CredentialTypePassword, c.Query("user"))
+			c.Status(http.StatusUnauthorized)
+		})
+		return engine
+	}
+	login := func(engine *gin.Engine, remoteAddr, forwardedFor, user string) int {
+		req := httptest.NewRequest(http.MethodPost, "/login?user="+user, nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, req)
+		return w.Code
+	}
+	blocked := func(ip string) bool {
+		exists, err := client.Exists(context.Background(), loginIPKeys.Key("blocked", ip)).Result()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return exists == 1
+	}
+
+	// 默认不信任代理：伪造的 X-Forwarded-For（轮换地址、白名单地址、受害者地址）都按连接地址计数
+	engine := newEngine(nil)
+	spoofed := []string{"198.51.100.1", "10.0.0.1", "198.51.100.9"}
+	for i, forwardedFor := range spoofed {
+		if code := login(engine, "203.0.113.5:40000", forwardedFor, fmt.Sprintf("user%d", i)); code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: unexpected status %d", i, code)
+		}
+	}
+	if code := login(engine, "203.0.113.5:40000", "198.51.100.2", "user9"); code != http.StatusTooManyRequests {
+		t.Fatalf("spoofed header should not bypass the block, got status %d", code)
+	}
+	if !blocked("203.0.113.5") {
+		t.Fatal("connection address should be blocked")
+	}
+	for _, ip := range spoofed {
+		if blocked(ip) {
+			t.Fatalf("spoofed address %s should not be blocked", ip)
+		}
+	}
+
+	// 来自受信任代理的请求采用代理传入的客户端IP
+	engine = newEngine([]string{"192.0.2.10"})
+	for i := 0; i < 3; i++ {
+		login(engine, "192.0.2.10:40000", "198.51.100.20", fmt.Sprintf("user%d", i))
+	}
+	if !blocked("198.51.100.20") || blocked("192.0.2.10") {
+		t.Fatal("client address forwarded by a trusted proxy should be counted")
+	}
+}
@@ -0,0 +1,236 @@
+package funcs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/email"
+	"go-backend/pkg/utils"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+// 魔法链接登录：
+//
+// RequestMagicLink 为已绑定的邮箱生成随机的一次性令牌保存到 Redis（短时有效，与邮箱和终端绑定），并发送带令牌的登录链接；
+// 同一邮箱在同一终端上只有最近一次发送的链接有效。ConsumeMagicLink 读取令牌对应的邮箱后复用 UserLoginWithContext，
+// 与验证码登录一样经过终端角色校验、失败锁定和登录记录，令牌在校验时取出并删除，只能使用一次。
+
+// errMagicLinkDisabled 未配置链接地址时不启用魔法链接
+var errMagicLinkDisabled = errors.New("魔法链接登录未启用")
+
+// errMagicLinkInvalid 令牌不存在、已过期、已使用或不属于当前邮箱和终端
+var errMagicLinkInvalid = errors.New("登录链接无效或已过期")
+
+// IsMagicLinkDisabled 判断错误是否为魔法链接未启用
+func IsMagicLinkDisabled(err error) bool {
+	return errors.Is(err, errMagicLinkDisabled)
+}
+
+const magicLinkTokenSize = 32
+
+var magicLinkKeys = caching.NewKeyBuilder("magiclink")
+
+// sendMagicLinkEmail 发送登录链接邮件，测试中可替换
+var sendMagicLinkEmail = email.SendMessage
+
+// magicLinkSettings 生效的魔法链接配置
+type magicLinkSettings struct {
+	url     *url.URL
+	ttl     time.Duration
+	subject string
+}
+
+// magicLink 当前的魔法链接配置，为 nil 表示未启用
+var magicLink *magicLinkSettings
+
+// InitMagicLink 根据配置初始化魔法链接登录，链接地址为空时不启用，配置无效时返回错误并保持未启用
+func InitMagicLink(config *configs.MagicLinkConfig) error {
+	magicLink = nil
+	rawURL := strings.TrimSpace(config.URL)
+	if rawURL == "" {
+		return nil
+	}
+
+	link, err := url.Parse(rawURL)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return fmt.Errorf("invalid magic link url %q", config.URL)
+	}
+	if config.TTL <= 0 {
+		return fmt.Errorf("magic link ttl must be positive, got %d", config.TTL)
+	}
+	subject := config.Subject
+	if subject == "" {
+		subject = "登录链接"
+	}
+
+	magicLink = &magicLinkSettings{
+		url:     link,
+		ttl:     time.Duration(config.TTL) * time.Second,
+		subject: subject,
+	}
+	return nil
+}
+
+// magicLinkToken 保存在 Redis 中的令牌信息
+type magicLinkToken struct {
+	Email      string `json:"email"`
+	DeviceCode string `json:"deviceCode"`
+}
+
+// link 返回带令牌的登录链接
+func (s *magicLinkSettings) link(token string) string {
+	link := *s.url
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
+
+// RequestMagicLink 向邮箱发送一次性登录链接。邮箱未绑定任何用户时不发送邮件也不返回错误，避免暴露邮箱是否已注册
+func (AuthFuncs) RequestMagicLink(ctx context.Context, emailAddr, deviceCode string) error {
+	settings := magicLink
+	if settings == nil {
+		return errMagicLinkDisabled
+	}
+	if caching.Client == nil {
+		return fmt.Errorf("缓存服务不可用，无法使用魔法链接")
+	}
+
+	emailAddr = strings.TrimSpace(emailAddr)
+	if !utils.IsValidEmail(emailAddr) {
+		return fmt.Errorf("邮箱格式无效")
+	}
+	if _, err := (ClientDeviceFuncs{}).GetClientDeviceByCodeInner(ctx, deviceCode); err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("终端信息无效")
+		}
+		return fmt.Errorf("查询终端类型失败: %w", err)
+	}
+
+	exists, err := database.Client.Credential.Query().
+		Where(
+			credential.CredentialTypeEQ(credential.CredentialTypeEmail),
+			credential.Identifier(emailAddr),
+		).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("查询用户认证信息失败: %w", err)
+	}
+	if !exists {
+		return nil
+	}
+
+	raw := make([]byte, magicLinkTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		return fmt.Errorf("生成登录令牌失败: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(raw)
+	data, err := json.Marshal(magicLinkToken{Email: emailAddr, DeviceCode: deviceCode})
+	if err != nil {
+		return err
+	}
+
+	// 记录邮箱和终端当前有效的令牌，重新发送时作废之前的链接
+	tokenKey := magicLinkKeys.Key("token", token)
+	pendingKey := magicLinkKeys.Key("pending", emailAddr, deviceCode)
+	previous, err := caching.Client.Get(ctx, pendingKey).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("保存登录令牌失败: %w", err)
+	}
+	_, err = caching.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		if previous != "" {
+			pipe.Del(ctx, magicLinkKeys.Key("token", previous))
+		}
+		pipe.Set(ctx, tokenKey, data, settings.ttl)
+		pipe.Set(ctx, pendingKey, token, settings.ttl)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("保存登录令牌失败: %w", err)
+	}
+
+	body := fmt.Sprintf("请在 %d 分钟内打开以下链接完成登录，链接只能使用一次：\n\n%s\n\n如果不是您本人操作，请忽略本邮件。",
+		int(settings.ttl.Minutes()), settings.link(token))
+	if err := sendMagicLinkEmail(emailAddr, settings.subject, body); err != nil {
+		caching.Client.Del(ctx, tokenKey, pendingKey)
+		return fmt.Errorf("发送登录链接失败: %w", err)
+	}
+	return nil
+}
+
+// ConsumeMagicLink 使用魔法链接登录
+func (AuthFuncs) ConsumeMagicLink(ctx context.Context, token, deviceCode string) (*ent.User, error) {
+	return AuthFuncs{}.ConsumeMagicLinkWithContext(ctx, nil, token, deviceCode)
+}
+
+// ConsumeMagicLinkWithContext 使用魔法链接登录（带上下文记录），令牌必须在请求链接的同一终端上使用
+func (AuthFuncs) ConsumeMagicLinkWithContext(ctx context.Context, ginCtx *gin.Context, token, deviceCode string) (*ent.User, error) {
+	if magicLink == nil {
+		return nil, errMagicLinkDisabled
+	}
+	if caching.Client == nil {
+		return nil, fmt.Errorf("缓存服务不可用，无法使用魔法链接")
+	}
+
+	// 先读取令牌对应的邮箱，令牌在登录流程中校验时才删除，以便失败时计入该邮箱的失败次数
+	token = strings.TrimSpace(token)
+	data, err := caching.Client.Get(ctx, magicLinkKeys.Key("token", token)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, errMagicLinkInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取登录令牌失败: %w", err)
+	}
+	var record magicLinkToken
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, errMagicLinkInvalid
+	}
+	// 在其他终端上打开链接时直接拒绝，不作废令牌，请求链接的终端仍可使用
+	if record.DeviceCode != deviceCode {
+		return nil, errMagicLinkInvalid
+	}
+
+	return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, CredentialTypeEmail, record.Email, token, "", deviceCode)
+}
+
+// consumeMagicLinkToken 取出并删除令牌，校验令牌属于该邮箱和终端，保证每个令牌只能使用一次
+func consumeMagicLinkToken(ctx context.Context, token, emailAddr, deviceCode string) error {
+	if caching.Client == nil {
+		return fmt.Errorf("缓存服务不可用，无法使用魔法链接")
+	}
+
+	key := magicLinkKeys.Key("token", token)
+	var get *redis.StringCmd
+	_, err := caching.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return errMagicLinkInvalid
+	}
+	if err != nil {
+		return fmt.Errorf("读取登录令牌失败: %w", err)
+	}
+
+	var record magicLinkToken
+	if err := json.Unmarshal([]byte(get.Val()), &record); err != nil ||
+		record.Email != emailAddr || record.DeviceCode != deviceCode {
+		return errMagicLinkInvalid
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// magicLinkPattern 从邮件正文中提取登录链接
+var magicLinkPattern = regexp.MustCompile(`https://\S+`)
+
+func TestMagicLinkLogin(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previousClient := database.Client
+	database.Client = client
+	server, redisClient := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = redisClient.(*redis.Client)
+	previousSettings, previousSend := magicLink, sendMagicLinkEmail
+	t.Cleanup(func() {
+		database.Client = previousClient
+		caching.Client = previousCache
+		magicLink, sendMagicLinkEmail = previousSettings, previousSend
+	})
+
+	// 记录发送的邮件，返回最近一封邮件中的令牌
+	sent := map[string]string{}
+	sendMagicLinkEmail = func(to, subject, body string) error {
+		sent[to] = body
+		return nil
+	}
+	lastToken := func(to string) string {
+		t.Helper()
+		link, err := url.Parse(magicLinkPattern.FindString(sent[to]))
+		if err != nil || link.Query().Get("token") == "" {
+			t.Fatalf("no magic link sent to %s: %q", to, sent[to])
+		}
+		if link.Query().Get("from") != "mail" {
+			t.Fatalf("existing query parameters should be kept: %s", link)
+		}
+		return link.Query().Get("token")
+	}
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 5, "name": "web", "code": "web-code", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 6, "name": "admin", "code": "admin-code", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "admin"})
+	client.ClientDevice.UpdateOneID(6).AddRoleIDs(21).ExecX(context.Background())
+	client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypeEmail).
+		SetIdentifier("alice@example.com").
+		SetIsVerified(true).
+		SaveX(context.Background())
+	ctx := context.Background()
+
+	if err := (AuthFuncs{}).RequestMagicLink(ctx, "alice@example.com", "web-code"); !IsMagicLinkDisabled(err) {
+		t.Fatalf("expected disabled error, got %v", err)
+	}
+	if err := InitMagicLink(&configs.MagicLinkConfig{URL: "ftp://admin.example.com", TTL: 60}); err == nil {
+		t.Fatal("expected non-http link to be rejected")
+	}
+	if err := InitMagicLink(&configs.MagicLinkConfig{URL: "https://admin.example.com/login/magic-link?from=mail", TTL: 60}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 未绑定的邮箱不发送邮件也不报错
+	if err := (AuthFuncs{}).RequestMagicLink(ctx, "nobody@example.com", "web-code"); err != nil || len(sent) != 0 {
+		t.Fatalf("unknown email should be silently ignored: %v, %v", err, sent)
+	}
+	if err := (AuthFuncs{}).RequestMagicLink(ctx, "alice@example.com", "missing-code"); err == nil {
+		t.Fatal("expected unknown device to be rejected")
+	}
+
+	// 登录成功，令牌只能使用一次
+	if err := (AuthFuncs{}).RequestMagicLink(ctx, "alice@example.com", "web-code"); err != nil {
+		t.Fatalf("request magic link failed: %v", err)
+	}
+	token := lastToken("alice@example.com")
+	user, err := AuthFuncs{}.ConsumeMagicLink(ctx, token, "web-code")
+	if err != nil {
+		t.Fatalf("consume failed: %v", err)
+	}
+	if user.ID != 1 {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, token, "web-code"); !errors.Is(err, errMagicLinkInvalid) {
+		t.Fatalf("expected reused token to be rejected, got %v", err)
+	}
+
+	// 重新发送后之前的链接作废
+	AuthFuncs{}.RequestMagicLink(ctx, "alice@example.com", "web-code")
+	first := lastToken("alice@example.com")
+	AuthFuncs{}.RequestMagicLink(ctx, "alice@example.com", "web-code")
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, first, "web-code"); !errors.Is(err, errMagicLinkInvalid) {
+		t.Fatalf("expected superseded token to be rejected, got %v", err)
+	}
+
+	// 令牌过期后不能使用
+	expired := lastToken("alice@example.com")
+	server.FastForward(61 * time.Second)
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, expired, "web-code"); !errors.Is(err, errMagicLinkInvalid) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+
+	// 令牌与终端绑定，在其他终端上使用时失败，请求链接的终端仍可使用
+	AuthFuncs{}.RequestMagicLink(ctx, "alice@example.com", "web-code")
+	token = lastToken("alice@example.com")
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, token, "admin-code"); !errors.Is(err, errMagicLinkInvalid) {
+		t.Fatalf("expected token from another device to be rejected, got %v", err)
+	}
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, token, "web-code"); err != nil {
+		t.Fatalf("token should still work on the requesting device, got %v", err)
+	}
+
+	// 与其他登录方式一样校验终端角色：用户没有 admin 终端要求的角色
+	AuthFuncs{}.RequestMagicLink(ctx, "alice@example.com", "admin-code")
+	if _, err := (AuthFuncs{}).ConsumeMagicLink(ctx, lastToken("alice@example.com"), "admin-code"); err == nil || err.Error() != "用户没有权限使用这个终端进行登录" {
+		t.Fatalf("expected device role check to reject the login, got %v", err)
+	}
+}
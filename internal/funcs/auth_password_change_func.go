@@ -0,0 +1,162 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/credential"
+	entlogging "go-backend/database/ent/logging"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/jwt"
+	"go-backend/pkg/logging"
+	"go-backend/shared/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// passwordChangeRevokesSessions 修改密码后是否撤销其他会话，启动时由配置覆盖
+var passwordChangeRevokesSessions = true
+
+// InitPasswordChangePolicy 根据配置初始化修改密码后的会话处理
+func InitPasswordChangePolicy(config *configs.PasswordChangeConfig) {
+	passwordChangeRevokesSessions = config.RevokeOtherSessions
+}
+
+// ChangePassword 登录状态下修改密码：校验原密码后更新密码哈希并写入审计日志。
+// 开启撤销其他会话时，该用户此前签发的所有令牌失效，refreshToken 所属的当前会话换发新令牌并返回；
+// 未提供 refreshToken 时当前会话同样失效。未撤销会话时返回的令牌为nil
+func (AuthFuncs) ChangePassword(ctx context.Context, userID uint64, oldPassword, newPassword, refreshToken string) (*models.TokenInfo, error) {
+	if err := ValidatePasswordPolicy(newPassword); err != nil {
+		return nil, err
+	}
+
+	// 当前会话需要在撤销前确认，撤销之后无法再与其他会话区分
+	var current *jwt.Claims
+	if passwordChangeRevokesSessions && refreshToken != "" {
+		claims, err := jwt.ValidateToken(refreshToken)
+		if err != nil || !claims.IsRefresh || claims.UserID != userID {
+			return nil, fmt.Errorf("当前会话的refreshToken无效")
+		}
+		if err := CheckSessionRevoked(ctx, claims); err != nil {
+			return nil, err
+		}
+		current = claims
+	}
+
+	passwordCredential, err := database.Client.Credential.Query().
+		Where(
+			credential.UserIDEQ(userID),
+			credential.CredentialTypeEQ(credential.CredentialTypePassword),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("未设置密码")
+		}
+		return nil, fmt.Errorf("查询密码认证记录失败: %w", err)
+	}
+	if passwordCredential.Secret == "" {
+		return nil, fmt.Errorf("未设置密码")
+	}
+
+	match, err := AuthFuncs{}.verifyPassword(oldPassword, passwordCredential.Secret, passwordCredential.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("原密码验证失败: %w", err)
+	}
+	if !match {
+		return nil, fmt.Errorf("原密码错误")
+	}
+
+	hashedPassword, saltStr, err := AuthFuncs{}.hashPassword(newPassword)
+	if err != nil {
+		return nil, fmt.Errorf("新密码哈希失败: %w", err)
+	}
+	_, err = passwordCredential.Update().
+		SetSecret(hashedPassword).
+		SetSalt(saltStr).
+		SetFailedAttempts(0).
+		ClearLockedUntil().
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("更新密码失败: %w", err)
+	}
+
+	var tokenInfo *models.TokenInfo
+	if passwordChangeRevokesSessions {
+		var keepDeviceID uint64
+		if current != nil {
+			keepDeviceID = current.ClientDeviceId
+		}
+		var client redis.Cmdable
+		if caching.Client != nil {
+			client = caching.Client
+		} else {
+			logging.Warn("缓存服务不可用，用户 %d 修改密码前签发的令牌在过期前仍然有效", userID)
+		}
+		if err := revokeUserSessions(ctx, client, userID, time.Now(), keepDeviceID); err != nil {
+			return nil, err
+		}
+		if current != nil {
+			if tokenInfo, err = reissueSessionTokens(ctx, current); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// 审计日志写入失败不回滚密码变更
+	err = database.Client.Logging.Create().
+		SetLevel(entlogging.LevelInfo).
+		SetType(entlogging.TypeManul).
+		SetMessage(fmt.Sprintf("用户 %d 修改了密码", userID)).
+		SetData(map[string]any{
+			"userId":                userID,
+			"revokeOtherSessions":   passwordChangeRevokesSessions,
+			"currentSessionRenewed": tokenInfo != nil,
+		}).
+		Exec(ctx)
+	if err != nil {
+		logging.Warn("记录修改密码审计日志失败: %v", err)
+	}
+	return tokenInfo, nil
+}
+
+// reissueSessionTokens 为会话撤销后仍需保留的当前会话换发令牌，refreshToken 沿用原有的过期时间和"记住我"状态
+func reissueSessionTokens(ctx context.Context, claims *jwt.Claims) (*models.TokenInfo, error) {
+	client, err := ClientDeviceFuncs{}.GetClientDeviceByIdInner(ctx, claims.ClientDeviceId)
+	if err != nil {
+		return nil, fmt.Errorf("获取设备类型失败")
+	}
+
+	now := time.Now()
+	refreshExpiresAt := time.UnixMilli(int64(claims.Expiry))
+	newRefreshToken, err := jwt.GenerateRefreshTokenWithClaims(claims.UserID, client.ID, refreshExpiresAt.Sub(now), claims.RememberMe, deviceTokenScope(client))
+	if err != nil {
+		return nil, fmt.Errorf("生成refresh Token失败: %w", err)
+	}
+
+	accessLifetime := time.Duration(client.AccessTokenExpiry) * time.Millisecond
+	accessClaims, err := claimsPolicy.loadAccessClaims(ctx, claims.UserID, client)
+	if err != nil {
+		return nil, fmt.Errorf("获取用户角色权限失败: %w", err)
+	}
+	if accessClaims != nil {
+		accessLifetime = claimsPolicy.accessLifetime(accessLifetime)
+	}
+	accessClaims = withDeviceTokenScope(accessClaims, client)
+	accessExpiresAt := accessTokenExpiresAt(now, accessLifetime, refreshExpiresAt, claims.RememberMe)
+	accessToken, err := jwt.GenerateEnrichedAccessToken(claims.UserID, client.ID, accessExpiresAt.Sub(now), accessClaims)
+	if err != nil {
+		return nil, fmt.Errorf("生成access Token失败: %w", err)
+	}
+
+	return &models.TokenInfo{
+		AccessToken:      accessToken,
+		RefreshToken:     newRefreshToken,
+		AccessExpiredIn:  uint64(accessExpiresAt.UnixMilli()),
+		RefreshExpiredIn: uint64(refreshExpiresAt.UnixMilli()),
+	}, nil
+}
@@ -0,0 +1,166 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/jwt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestChangePasswordRevokesOtherSessions(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	_, cmdable := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = cmdable.(*redis.Client)
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	previousPolicy := passwordChangeRevokesSessions
+	InitPasswordChangePolicy(&configs.PasswordChangeConfig{RevokeOtherSessions: true})
+	t.Cleanup(func() { passwordChangeRevokesSessions = previousPolicy })
+
+	if err := jwt.InitializeService(&configs.JWTConfig{SecretKey: "test-secret"}); err != nil {
+		t.Fatalf("init jwt failed: %v", err)
+	}
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 5, "name": "web", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 6, "name": "app", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	ctx := context.Background()
+
+	secret, salt, err := AuthFuncs{}.hashPassword("Old-Passw0rd!")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypePassword).
+		SetIdentifier("alice").
+		SetSecret(secret).
+		SetSalt(salt).
+		SetIsVerified(true).
+		SaveX(ctx)
+
+	currentToken, err := jwt.GenerateRefreshToken(1, 5, time.Hour, false)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	sameDeviceToken, err := jwt.GenerateRefreshToken(1, 5, time.Hour, false)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	otherDeviceToken, err := jwt.GenerateRefreshToken(1, 6, time.Hour, true)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	if _, err := (AuthFuncs{}).ChangePassword(ctx, 1, "wrong-password", "N3w-Passw0rd!", currentToken); err == nil || err.Error() != "原密码错误" {
+		t.Fatalf("expected wrong old password error, got %v", err)
+	}
+	if _, err := (AuthFuncs{}).ChangePassword(ctx, 1, "Old-Passw0rd!", "N3w-Passw0rd!", otherDeviceToken[:len(otherDeviceToken)-2]); err == nil {
+		t.Fatal("a malformed refresh token should be rejected")
+	}
+
+	token, err := (AuthFuncs{}).ChangePassword(ctx, 1, "Old-Passw0rd!", "N3w-Passw0rd!", currentToken)
+	if err != nil {
+		t.Fatalf("change password failed: %v", err)
+	}
+	if token == nil || token.RefreshToken == "" || token.AccessToken == "" {
+		t.Fatalf("current session should receive new tokens, got %+v", token)
+	}
+
+	// 新密码生效
+	updated := client.Credential.Query().Where(credential.UserIDEQ(1)).OnlyX(ctx)
+	if match, _ := (AuthFuncs{}).verifyPassword("N3w-Passw0rd!", updated.Secret, updated.Salt); !match {
+		t.Fatal("new password should verify")
+	}
+
+	// 其他会话（包括同一终端上的其他会话）和当前会话的旧令牌都被撤销
+	for name, refreshToken := range map[string]string{"current": currentToken, "same device": sameDeviceToken, "other device": otherDeviceToken} {
+		if _, err := (AuthFuncs{}).RefreshToken(ctx, "", refreshToken); !errors.Is(err, ErrSessionRevoked) {
+			t.Fatalf("%s session should be revoked, got %v", name, err)
+		}
+	}
+
+	// 换发的令牌可以继续刷新，且沿用原refreshToken的过期时间
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", token.RefreshToken); err != nil {
+		t.Fatalf("reissued refresh token should be usable: %v", err)
+	}
+	claims, err := jwt.ValidateToken(currentToken)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if token.RefreshExpiredIn != claims.Expiry {
+		t.Fatalf("reissued refresh token should keep the original expiry, got %d want %d", token.RefreshExpiredIn, claims.Expiry)
+	}
+
+	// 修改密码写入审计日志
+	if n := client.Logging.Query().CountX(ctx); n != 1 {
+		t.Fatalf("expected one audit log entry, got %d", n)
+	}
+}
+
+func TestChangePasswordKeepsSessionsWhenDisabled(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	_, cmdable := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = cmdable.(*redis.Client)
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	previousPolicy := passwordChangeRevokesSessions
+	InitPasswordChangePolicy(&configs.PasswordChangeConfig{RevokeOtherSessions: false})
+	t.Cleanup(func() { passwordChangeRevokesSessions = previousPolicy })
+
+	if err := jwt.InitializeService(&configs.JWTConfig{SecretKey: "test-secret"}); err != nil {
+		t.Fatalf("init jwt failed: %v", err)
+	}
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 6, "name": "app", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	ctx := context.Background()
+
+	secret, salt, err := AuthFuncs{}.hashPassword("Old-Passw0rd!")
+	if err != nil {
+		t.Fatalf("hash password failed: %v", err)
+	}
+	client.Credential.Create().
+		SetUserID(1).
+		SetCredentialType(credential.CredentialTypePassword).
+		SetIdentifier("alice").
+		SetSecret(secret).
+		SetSalt(salt).
+		SaveX(ctx)
+
+	otherDeviceToken, err := jwt.GenerateRefreshToken(1, 6, time.Hour, false)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	token, err := (AuthFuncs{}).ChangePassword(ctx, 1, "Old-Passw0rd!", "N3w-Passw0rd!", "")
+	if err != nil {
+		t.Fatalf("change password failed: %v", err)
+	}
+	if token != nil {
+		t.Fatalf("no tokens should be reissued when sessions are kept, got %+v", token)
+	}
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", otherDeviceToken); err != nil {
+		t.Fatalf("other sessions should stay valid: %v", err)
+	}
+}
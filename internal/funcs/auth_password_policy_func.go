@@ -0,0 +1,74 @@
+package funcs
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"go-backend/pkg/configs"
+)
+
+// errPasswordPolicyPrefix 密码不满足策略时错误信息的前缀，处理器据此返回400
+const errPasswordPolicyPrefix = "密码不符合安全策略"
+
+// passwordPolicy 全局密码策略，启动时由配置覆盖
+var passwordPolicy = configs.PasswordPolicyConfig{
+	MinLength:     8,
+	MaxLength:     128,
+	RequireLetter: true,
+	RequireDigit:  true,
+}
+
+// InitPasswordPolicy 根据配置初始化密码策略
+func InitPasswordPolicy(cfg *configs.PasswordPolicyConfig) {
+	policy := *cfg
+	if policy.MinLength <= 0 {
+		policy.MinLength = 1
+	}
+	passwordPolicy = policy
+}
+
+// ValidatePasswordPolicy 检查新密码是否满足密码策略，不满足时返回列出所有未满足规则的错误
+func ValidatePasswordPolicy(password string) error {
+	return checkPasswordPolicy(password, &passwordPolicy)
+}
+
+// checkPasswordPolicy 按给定策略检查密码
+func checkPasswordPolicy(password string, policy *configs.PasswordPolicyConfig) error {
+	var problems []string
+
+	length := utf8.RuneCountInString(password)
+	if length < policy.MinLength {
+		problems = append(problems, fmt.Sprintf("长度不能少于%d个字符", policy.MinLength))
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		problems = append(problems, fmt.Sprintf("长度不能超过%d个字符", policy.MaxLength))
+	}
+
+	var hasLetter, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case !unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if policy.RequireLetter && !hasLetter {
+		problems = append(problems, "必须包含字母")
+	}
+	if policy.RequireDigit && !hasDigit {
+		problems = append(problems, "必须包含数字")
+	}
+	if policy.RequireSymbol && !hasSymbol {
+		problems = append(problems, "必须包含符号")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%s: %s", errPasswordPolicyPrefix, strings.Join(problems, "，"))
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package funcs
+
+import (
+	"strings"
+	"testing"
+
+	"go-backend/pkg/configs"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	policy := &configs.PasswordPolicyConfig{MinLength: 8, MaxLength: 16, RequireLetter: true, RequireDigit: true}
+
+	if err := checkPasswordPolicy("abcd1234", policy); err != nil {
+		t.Fatalf("expected valid password, got %v", err)
+	}
+
+	cases := []struct {
+		password string
+		want     []string
+	}{
+		{"abc1", []string{"少于8"}},
+		{"abcdefgh", []string{"数字"}},
+		{"12345678", []string{"字母"}},
+		{"!!!", []string{"少于8", "字母", "数字"}},
+		{strings.Repeat("a1", 9), []string{"超过16"}},
+	}
+	for _, tc := range cases {
+		err := checkPasswordPolicy(tc.password, policy)
+		if err == nil || !strings.HasPrefix(err.Error(), errPasswordPolicyPrefix) {
+			t.Fatalf("%q: expected policy error, got %v", tc.password, err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Fatalf("%q: error %q should mention %s", tc.password, err, want)
+			}
+		}
+	}
+
+	policy.RequireSymbol = true
+	if err := checkPasswordPolicy("abcd1234", policy); err == nil || !strings.Contains(err.Error(), "符号") {
+		t.Fatalf("expected symbol requirement, got %v", err)
+	}
+	if err := checkPasswordPolicy("abcd-1234", policy); err != nil {
+		t.Fatalf("expected valid password, got %v", err)
+	}
+}
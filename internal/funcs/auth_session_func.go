@@ -0,0 +1,204 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/loginrecord"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/jwt"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/messaging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrSessionDisplaced 令牌所属会话已被同一终端上的新登录顶替
+var ErrSessionDisplaced = errors.New("当前会话已在同一终端的其他位置登录，请重新登录")
+
+// ErrSessionRevoked 用户在所有终端上的会话已被撤销（如账号被停用）
+var ErrSessionRevoked = errors.New("会话已失效，请重新登录")
+
+// SessionDisplacedTopic 会话被顶替时推送给用户的WebSocket主题，
+// 连接使用的令牌签发时间早于 revokedBefore 且终端一致的客户端应断开连接
+const SessionDisplacedTopic = "auth/session/displaced"
+
+// sessionKeys 会话撤销的缓存键构建器
+var sessionKeys = caching.NewKeyBuilder("auth_session")
+
+// singleSessionPolicy 单终端单会话策略
+// 令牌本身无状态，无法逐个吊销，因此按用户+终端记录一个撤销时间点，
+// 签发时间早于该时间点的accessToken和refreshToken都视为失效
+type singleSessionPolicy struct {
+	enabled bool
+	devices map[string]bool // 生效的终端名称或编码，为空表示所有终端
+}
+
+// sessionPolicy 全局单终端单会话策略，启动时由配置覆盖
+var sessionPolicy = &singleSessionPolicy{}
+
+// InitSessionPolicy 根据配置初始化单终端单会话策略
+func InitSessionPolicy(config *configs.SinglePerDeviceConfig) {
+	sessionPolicy = newSingleSessionPolicy(config)
+}
+
+// newSingleSessionPolicy 根据配置创建单终端单会话策略
+func newSingleSessionPolicy(config *configs.SinglePerDeviceConfig) *singleSessionPolicy {
+	devices := make(map[string]bool, len(config.Devices))
+	for _, device := range config.Devices {
+		if device = strings.TrimSpace(device); device != "" {
+			devices[device] = true
+		}
+	}
+	return &singleSessionPolicy{enabled: config.Enabled, devices: devices}
+}
+
+// applies 判断策略是否对该终端生效
+func (p *singleSessionPolicy) applies(device *ent.ClientDevice) bool {
+	if !p.enabled || device == nil {
+		return false
+	}
+	if len(p.devices) == 0 {
+		return true
+	}
+	return p.devices[device.Name] || p.devices[device.Code]
+}
+
+// revoke 撤销用户在该终端上签发于 before 之前的所有令牌，记录保留 ttl（终端refreshToken的最长有效期）
+func (p *singleSessionPolicy) revoke(ctx context.Context, client redis.Cmdable, userID, deviceID uint64, before time.Time, ttl time.Duration) error {
+	return client.Set(ctx, sessionKeys.Key("revoked", userID, deviceID), before.UnixMilli(), ttl).Err()
+}
+
+// revokeUser 撤销用户在所有终端上签发于 before 之前的令牌（如账号被停用），记录保留 ttl
+func (p *singleSessionPolicy) revokeUser(ctx context.Context, client redis.Cmdable, userID uint64, before time.Time, ttl time.Duration) error {
+	return client.Set(ctx, sessionKeys.Key("revoked", userID), before.UnixMilli(), ttl).Err()
+}
+
+// isRevoked 判断令牌是否签发于所属用户+终端的撤销时间点之前
+func (p *singleSessionPolicy) isRevoked(ctx context.Context, client redis.Cmdable, claims *jwt.Claims) (bool, error) {
+	return issuedBeforeWatermark(ctx, client, sessionKeys.Key("revoked", claims.UserID, claims.ClientDeviceId), claims)
+}
+
+// isUserRevoked 判断令牌是否签发于用户级（所有终端）撤销时间点之前
+func (p *singleSessionPolicy) isUserRevoked(ctx context.Context, client redis.Cmdable, claims *jwt.Claims) (bool, error) {
+	return issuedBeforeWatermark(ctx, client, sessionKeys.Key("revoked", claims.UserID), claims)
+}
+
+// issuedBeforeWatermark 判断令牌的签发时间是否早于 key 中记录的撤销时间点，没有记录时返回false
+func issuedBeforeWatermark(ctx context.Context, client redis.Cmdable, key string, claims *jwt.Claims) (bool, error) {
+	value, err := client.Get(ctx, key).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return false, nil
+		}
+		return false, err
+	}
+	revokedBefore, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return claims.IssuedAtMs < revokedBefore, nil
+}
+
+// CheckSessionRevoked 校验令牌所属会话是否已被撤销：用户的所有会话被撤销时返回 ErrSessionRevoked，
+// 被同一终端的新登录顶替时返回 ErrSessionDisplaced。
+// 撤销记录只在需要时写入，因此不检查策略是否启用，关闭策略后已撤销的令牌仍然无效；Redis 不可用时放行
+func CheckSessionRevoked(ctx context.Context, claims *jwt.Claims) error {
+	if claims == nil || caching.Client == nil {
+		return nil
+	}
+	revoked, err := sessionPolicy.isUserRevoked(ctx, caching.Client, claims)
+	if err != nil {
+		logging.Warn("检查会话撤销状态失败: %v", err)
+		return nil
+	}
+	if revoked {
+		return ErrSessionRevoked
+	}
+	revoked, err = sessionPolicy.isRevoked(ctx, caching.Client, claims)
+	if err != nil {
+		logging.Warn("检查会话撤销状态失败: %v", err)
+		return nil
+	}
+	if revoked {
+		return ErrSessionDisplaced
+	}
+	return nil
+}
+
+// displaceDeviceSessions 登录成功后、签发新令牌前调用：策略对该终端生效时撤销该用户在同一终端的旧会话，
+// 关闭对应的登录记录，并通知WebSocket层断开被顶替的连接
+func displaceDeviceSessions(ctx context.Context, userID uint64, device *ent.ClientDevice) error {
+	if !sessionPolicy.applies(device) {
+		return nil
+	}
+	if caching.Client == nil {
+		return fmt.Errorf("缓存服务不可用，无法撤销旧会话")
+	}
+
+	now := time.Now()
+	ttl := time.Duration(device.RefreshTokenExpiry) * time.Millisecond
+	if err := sessionPolicy.revoke(ctx, caching.Client, userID, device.ID, now, ttl); err != nil {
+		return fmt.Errorf("撤销旧会话失败: %w", err)
+	}
+
+	sessionIDs, err := closeDeviceLoginRecords(ctx, userID, device.ID, now)
+	if err != nil {
+		// 令牌已经失效，登录记录未关闭只影响统计
+		logging.Warn("关闭被顶替会话的登录记录失败: %v", err)
+	}
+
+	_, err = messaging.Publish(ctx, messaging.MessageStruct{
+		Type: messaging.ServerToUserSocket,
+		Payload: messaging.SocketMessagePayload{
+			UserId: &userID,
+			Topic:  SessionDisplacedTopic,
+			Data: map[string]interface{}{
+				"clientDeviceId": device.ID,
+				"revokedBefore":  now.UnixMilli(),
+				"sessionIds":     sessionIDs,
+			},
+		},
+	})
+	if err != nil {
+		logging.Warn("发布会话顶替事件失败: %v", err)
+	}
+	return nil
+}
+
+// closeDeviceLoginRecords 将用户在该终端上尚未退出的成功登录记录标记为已退出，返回被关闭的会话ID
+func closeDeviceLoginRecords(ctx context.Context, userID, deviceID uint64, logoutTime time.Time) ([]string, error) {
+	records, err := database.Client.LoginRecord.Query().
+		Where(
+			loginrecord.UserIDEQ(userID),
+			loginrecord.ClientIDEQ(deviceID),
+			loginrecord.StatusEQ(loginrecord.StatusSuccess),
+			loginrecord.LogoutTimeIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询登录记录失败: %w", err)
+	}
+
+	sessionIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		_, err := record.Update().
+			SetLogoutTime(logoutTime).
+			SetDuration(int(logoutTime.Sub(record.CreateTime).Seconds())).
+			Save(ctx)
+		if err != nil {
+			return sessionIDs, fmt.Errorf("更新登录记录失败: %w", err)
+		}
+		if record.SessionID != "" {
+			sessionIDs = append(sessionIDs, record.SessionID)
+		}
+	}
+	return sessionIDs, nil
+}
@@ -0,0 +1,130 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/jwt"
+)
+
+func TestSingleSessionPolicyAppliesPerDevice(t *testing.T) {
+	web := &ent.ClientDevice{ID: 1, Name: "web", Code: "web-code"}
+	api := &ent.ClientDevice{ID: 2, Name: "api", Code: "api-code"}
+
+	all := newSingleSessionPolicy(&configs.SinglePerDeviceConfig{Enabled: true})
+	if !all.applies(web) || !all.applies(api) {
+		t.Fatal("policy without device list should apply to all devices")
+	}
+
+	webOnly := newSingleSessionPolicy(&configs.SinglePerDeviceConfig{Enabled: true, Devices: []string{"web", " "}})
+	if !webOnly.applies(web) || webOnly.applies(api) {
+		t.Fatal("policy should only apply to configured devices")
+	}
+	byCode := newSingleSessionPolicy(&configs.SinglePerDeviceConfig{Enabled: true, Devices: []string{"api-code"}})
+	if byCode.applies(web) || !byCode.applies(api) {
+		t.Fatal("policy should match devices by code")
+	}
+
+	disabled := newSingleSessionPolicy(&configs.SinglePerDeviceConfig{Devices: []string{"web"}})
+	if disabled.applies(web) {
+		t.Fatal("disabled policy should not apply")
+	}
+}
+
+func TestSingleSessionPolicyRevokesEarlierTokens(t *testing.T) {
+	server, client := newIPGuardTestClient(t)
+	policy := newSingleSessionPolicy(&configs.SinglePerDeviceConfig{Enabled: true})
+	ctx := context.Background()
+
+	loginAt := time.Now()
+	displaced := &jwt.Claims{UserID: 7, ClientDeviceId: 1, IssuedAtMs: uint64(loginAt.Add(-time.Minute).UnixMilli())}
+	current := &jwt.Claims{UserID: 7, ClientDeviceId: 1, IssuedAtMs: uint64(loginAt.UnixMilli())}
+	otherDevice := &jwt.Claims{UserID: 7, ClientDeviceId: 2, IssuedAtMs: displaced.IssuedAtMs}
+	otherUser := &jwt.Claims{UserID: 8, ClientDeviceId: 1, IssuedAtMs: displaced.IssuedAtMs}
+
+	if revoked, err := policy.isRevoked(ctx, client, displaced); err != nil || revoked {
+		t.Fatalf("no revocation recorded yet: revoked = %v, err = %v", revoked, err)
+	}
+
+	if err := policy.revoke(ctx, client, 7, 1, loginAt, time.Hour); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		claims *jwt.Claims
+		want   bool
+	}{
+		{"earlier session on same device", displaced, true},
+		{"session issued by the new login", current, false},
+		{"same user on another device", otherDevice, false},
+		{"another user on same device", otherUser, false},
+	}
+	for _, c := range cases {
+		revoked, err := policy.isRevoked(ctx, client, c.claims)
+		if err != nil || revoked != c.want {
+			t.Errorf("%s: revoked = %v, err = %v, want %v", c.name, revoked, err, c.want)
+		}
+	}
+
+	// 撤销记录在终端refreshToken的最长有效期后过期，此时旧令牌本身也已过期
+	server.FastForward(time.Hour + time.Second)
+	if revoked, _ := policy.isRevoked(ctx, client, displaced); revoked {
+		t.Fatal("revocation should expire with the device refresh token lifetime")
+	}
+}
+
+func TestCloseDeviceLoginRecords(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	for _, userID := range []int{7, 8} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	for _, deviceID := range []int{1, 2} {
+		insertTestRow(t, db, "sys_clients", map[string]any{"id": deviceID, "name": "device", "access_token_expiry": 60000, "refresh_token_expiry": 60000})
+	}
+
+	loginAt := time.Now().Add(-time.Hour)
+	rows := []map[string]any{
+		{"id": 1, "user_id": 7, "client_id": 1, "status": "success", "session_id": "web-old"},
+		{"id": 2, "user_id": 7, "client_id": 1, "status": "failed", "session_id": ""},
+		{"id": 3, "user_id": 7, "client_id": 2, "status": "success", "session_id": "api-session"},
+		{"id": 4, "user_id": 8, "client_id": 1, "status": "success", "session_id": "other-user"},
+		{"id": 5, "user_id": 7, "client_id": 1, "status": "success", "session_id": "web-closed", "logout_time": loginAt},
+	}
+	for _, row := range rows {
+		row["create_time"] = loginAt
+		row["update_time"] = loginAt
+		row["identifier"] = "alice"
+		row["credential_type"] = "password"
+		row["ip_address"] = "127.0.0.1"
+		insertTestRow(t, db, "sys_login_records", row)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	sessionIDs, err := closeDeviceLoginRecords(ctx, 7, 1, now)
+	if err != nil {
+		t.Fatalf("close login records failed: %v", err)
+	}
+	if len(sessionIDs) != 1 || sessionIDs[0] != "web-old" {
+		t.Fatalf("unexpected displaced sessions: %v", sessionIDs)
+	}
+
+	closed := client.LoginRecord.GetX(ctx, 1)
+	if closed.LogoutTime == nil || closed.Duration < 3599 {
+		t.Fatalf("displaced record not closed: %+v", closed)
+	}
+	for _, id := range []uint64{3, 4} {
+		if record := client.LoginRecord.GetX(ctx, id); record.LogoutTime != nil {
+			t.Fatalf("record %d should stay active", id)
+		}
+	}
+}
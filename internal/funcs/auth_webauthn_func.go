@@ -0,0 +1,546 @@
+package funcs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/redis/go-redis/v9"
+)
+
+// 通行密钥（WebAuthn）：
+//
+// 注册和登录的协议部分（生成参数、解析 clientDataJSON 和 attestationObject、校验挑战、来源、依赖方ID哈希、
+// 用户在场/验证标志、认证器证明格式和签名）由 go-webauthn/webauthn 完成，这里只负责：
+// Begin 时把库生成的会话数据以挑战为键保存到 Redis（短时有效，只能使用一次），Finish 时按浏览器返回的挑战取出；
+// 以及 webauthn 类型 Credential 的读写：base64url 编码的凭据ID作为标识符，COSE 格式的公钥保存在 secret 中，
+// 签名计数、证明格式、AAGUID 等保存在 metadata 中。
+// 登录复用 UserLoginWithContext，与其他认证方式一样经过终端角色校验、失败锁定和登录记录。
+//
+// 注册时请求 attestation=none，库会校验认证器实际返回的证明格式，但不配置认证器元数据（FIDO MDS），不限制认证器型号；
+// 因此 metadata.aaguid 只是认证器自报的信息，仅用于展示，不能作为授权依据。
+//
+// 签名计数只增不减（不支持计数的认证器始终为0），保存新计数时在事务中锁定凭据记录并重新比较，
+// 防止同一计数的两个并发断言都通过校验。
+
+// errWebAuthnDisabled 未配置依赖方ID时不启用通行密钥
+var errWebAuthnDisabled = errors.New("通行密钥登录未启用")
+
+// errWebAuthnChallengeInvalid 挑战不存在、已过期、已使用或不属于当前操作
+var errWebAuthnChallengeInvalid = errors.New("通行密钥挑战无效或已过期")
+
+// errWebAuthnSignCount 签名计数没有增加，凭据可能被复制
+var errWebAuthnSignCount = errors.New("通行密钥签名计数异常")
+
+// IsWebAuthnDisabled 判断错误是否为通行密钥未启用
+func IsWebAuthnDisabled(err error) bool {
+	return errors.Is(err, errWebAuthnDisabled)
+}
+
+const (
+	webAuthnPurposeRegister = "register"
+	webAuthnPurposeLogin    = "login"
+)
+
+var webAuthnKeys = caching.NewKeyBuilder("webauthn")
+
+// webAuthnSettings 生效的通行密钥配置
+type webAuthnSettings struct {
+	*webauthn.WebAuthn
+	challengeTTL time.Duration
+}
+
+// webAuthn 当前的通行密钥配置，为 nil 表示未启用
+var webAuthn *webAuthnSettings
+
+// InitWebAuthn 根据配置初始化通行密钥，依赖方ID为空时不启用，配置无效时返回错误并保持未启用
+func InitWebAuthn(config *configs.WebAuthnConfig) error {
+	webAuthn = nil
+	rpID := strings.TrimSpace(config.RPID)
+	if rpID == "" {
+		return nil
+	}
+
+	userVerification := protocol.UserVerificationRequirement(config.UserVerification)
+	if userVerification == "" {
+		userVerification = protocol.VerificationPreferred
+	}
+	switch userVerification {
+	case protocol.VerificationRequired, protocol.VerificationPreferred, protocol.VerificationDiscouraged:
+	default:
+		return fmt.Errorf("invalid user_verification: %s", userVerification)
+	}
+
+	origins := make([]string, 0, len(config.Origins))
+	for _, origin := range config.Origins {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	challengeTTL := config.ChallengeTTL
+	if challengeTTL <= 0 {
+		challengeTTL = 300
+	}
+	rpName := config.RPName
+	if rpName == "" {
+		rpName = rpID
+	}
+	timeout := webauthn.TimeoutConfig{
+		Timeout:    time.Duration(config.Timeout) * time.Millisecond,
+		TimeoutUVD: time.Duration(config.Timeout) * time.Millisecond,
+	}
+
+	instance, err := webauthn.New(&webauthn.Config{
+		RPID:                  rpID,
+		RPDisplayName:         rpName,
+		RPOrigins:             origins,
+		AttestationPreference: protocol.PreferNoAttestation,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementPreferred,
+			UserVerification: userVerification,
+		},
+		Timeouts: webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+	if err != nil {
+		return err
+	}
+
+	webAuthn = &webAuthnSettings{
+		WebAuthn:     instance,
+		challengeTTL: time.Duration(challengeTTL) * time.Second,
+	}
+	return nil
+}
+
+// BeginWebAuthnRegistration 为已登录用户生成注册通行密钥的参数（navigator.credentials.create 的 publicKey）
+func (AuthFuncs) BeginWebAuthnRegistration(ctx context.Context, userID uint64) (*protocol.PublicKeyCredentialCreationOptions, error) {
+	settings := webAuthn
+	if settings == nil {
+		return nil, errWebAuthnDisabled
+	}
+
+	user, err := loadWebAuthnUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	creation, session, err := settings.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.credentials).CredentialDescriptors()))
+	if err != nil {
+		return nil, fmt.Errorf("生成注册参数失败: %w", err)
+	}
+	if err := settings.saveSession(ctx, webAuthnPurposeRegister, session); err != nil {
+		return nil, err
+	}
+	return &creation.Response, nil
+}
+
+// FinishWebAuthnRegistration 校验认证器创建的凭据并保存为用户的通行密钥
+func (AuthFuncs) FinishWebAuthnRegistration(ctx context.Context, userID uint64, req *models.WebAuthnRegistrationRequest) (*models.WebAuthnCredentialResponse, error) {
+	settings := webAuthn
+	if settings == nil {
+		return nil, errWebAuthnDisabled
+	}
+
+	credentialID, err := canonicalWebAuthnID(req.ID)
+	if err != nil {
+		return nil, fmt.Errorf("凭据ID格式错误")
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("凭据数据格式错误")
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("凭据数据解析失败: %w", err)
+	}
+
+	session, err := consumeWebAuthnSession(ctx, parsed.Response.CollectedClientData.Challenge, webAuthnPurposeRegister)
+	if err != nil {
+		return nil, err
+	}
+	// 会话中的用户句柄与当前用户不一致时由库拒绝
+	created, err := settings.CreateCredential(&webAuthnUser{id: userID}, *session, parsed)
+	if err != nil {
+		return nil, fmt.Errorf("通行密钥校验失败: %w", err)
+	}
+
+	if base64.RawURLEncoding.EncodeToString(created.ID) != credentialID {
+		return nil, fmt.Errorf("凭据ID与认证器数据不一致")
+	}
+	publicKey := base64.RawURLEncoding.EncodeToString(created.PublicKey)
+	if len(credentialID) > 255 || len(publicKey) > 500 {
+		return nil, fmt.Errorf("凭据ID或公钥过长")
+	}
+
+	exists, err := database.Client.Credential.Query().
+		Where(
+			credential.CredentialTypeEQ(credential.CredentialTypeWebauthn),
+			credential.Identifier(credentialID),
+		).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询认证信息失败: %w", err)
+	}
+	if exists {
+		return nil, fmt.Errorf("该通行密钥已注册")
+	}
+
+	metadata := map[string]interface{}{
+		"sign_count":       created.Authenticator.SignCount,
+		"alg":              created.Attestation.PublicKeyAlgorithm,
+		"attestation_type": created.AttestationType,
+		"aaguid":           hex.EncodeToString(created.Authenticator.AAGUID), // 认证器自报，未经元数据校验，仅用于展示
+		"backup_eligible":  created.Flags.BackupEligible,
+	}
+	if req.Name != "" {
+		metadata["name"] = req.Name
+	}
+	if len(req.Response.Transports) > 0 {
+		metadata["transports"] = req.Response.Transports
+	}
+
+	now := time.Now()
+	record, err := database.Client.Credential.Create().
+		SetUserID(userID).
+		SetCredentialType(credential.CredentialTypeWebauthn).
+		SetIdentifier(credentialID).
+		SetSecret(publicKey).
+		SetIsVerified(true).
+		SetVerifiedAt(now).
+		SetMetadata(metadata).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("保存通行密钥失败: %w", err)
+	}
+
+	return &models.WebAuthnCredentialResponse{
+		ID:           utils.Uint64ToString(record.ID),
+		CredentialID: record.Identifier,
+		Name:         req.Name,
+		CreateTime:   utils.FormatDateTime(record.CreateTime),
+	}, nil
+}
+
+// BeginWebAuthnLogin 生成通行密钥登录的参数（navigator.credentials.get 的 publicKey）。
+// 提供标识符且对应用户已注册通行密钥时只允许这些通行密钥，否则使用可发现凭据登录，不暴露用户是否存在
+func (AuthFuncs) BeginWebAuthnLogin(ctx context.Context, identifier string) (*protocol.PublicKeyCredentialRequestOptions, error) {
+	settings := webAuthn
+	if settings == nil {
+		return nil, errWebAuthnDisabled
+	}
+
+	var user *webAuthnUser
+	if identifier = strings.TrimSpace(identifier); identifier != "" {
+		if candidates := loginCredentialCandidates(identifier, loginIdentifierPriority); len(candidates) > 0 {
+			record, err := resolveLoginCredential(ctx, identifier, candidates)
+			if err != nil {
+				return nil, err
+			}
+			if record != nil {
+				if user, err = loadWebAuthnUser(ctx, record.UserID); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		session   *webauthn.SessionData
+		err       error
+	)
+	if user != nil && len(user.credentials) > 0 {
+		assertion, session, err = settings.BeginLogin(user)
+	} else {
+		assertion, session, err = settings.BeginDiscoverableLogin()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("生成登录参数失败: %w", err)
+	}
+	if err := settings.saveSession(ctx, webAuthnPurposeLogin, session); err != nil {
+		return nil, err
+	}
+	return &assertion.Response, nil
+}
+
+// FinishWebAuthnLogin 使用认证器的签名登录，断言通过 UserLoginWithContext 的 secret 传递并在其中校验
+func (AuthFuncs) FinishWebAuthnLogin(ctx context.Context, ginCtx *gin.Context, req *models.WebAuthnLoginRequest) (*ent.User, error) {
+	if webAuthn == nil {
+		return nil, errWebAuthnDisabled
+	}
+
+	credentialID, assertion, err := encodeWebAuthnAssertion(req)
+	if err != nil {
+		return nil, err
+	}
+	return AuthFuncs{}.UserLoginWithContext(ctx, ginCtx, CredentialTypeWebAuthn, credentialID, assertion, "", req.ClientCode)
+}
+
+// encodeWebAuthnAssertion 规范凭据ID并将断言编码为库可以解析的 JSON。
+// 库按 rawId 查找凭据，rawId 必须与用于查找认证记录的 id 一致
+func encodeWebAuthnAssertion(req *models.WebAuthnLoginRequest) (string, string, error) {
+	credentialID, err := canonicalWebAuthnID(req.ID)
+	if err != nil {
+		return "", "", fmt.Errorf("凭据ID格式错误")
+	}
+	if req.RawID != "" {
+		if rawID, err := canonicalWebAuthnID(req.RawID); err != nil || rawID != credentialID {
+			return "", "", fmt.Errorf("凭据ID格式错误")
+		}
+	}
+	assertion, err := json.Marshal(struct {
+		ID       string                           `json:"id"`
+		RawID    string                           `json:"rawId"`
+		Type     string                           `json:"type"`
+		Response models.WebAuthnAssertionResponse `json:"response"`
+	}{credentialID, credentialID, req.Type, req.Response})
+	if err != nil {
+		return "", "", fmt.Errorf("断言数据格式错误")
+	}
+	return credentialID, string(assertion), nil
+}
+
+// verifyWebAuthnAssertion 校验通行密钥登录的断言，成功时保存新的签名计数
+func verifyWebAuthnAssertion(ctx context.Context, record *ent.Credential, secret string) error {
+	settings := webAuthn
+	if settings == nil {
+		return errWebAuthnDisabled
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes([]byte(secret))
+	if err != nil {
+		return fmt.Errorf("断言数据解析失败: %w", err)
+	}
+	session, err := consumeWebAuthnSession(ctx, parsed.Response.CollectedClientData.Challenge, webAuthnPurposeLogin)
+	if err != nil {
+		return err
+	}
+
+	user, err := loadWebAuthnUser(ctx, record.UserID)
+	if err != nil {
+		return err
+	}
+	var validated *webauthn.Credential
+	if len(session.UserID) > 0 {
+		// 按标识符发起的登录，库校验凭据在允许列表中且属于该用户
+		validated, err = settings.ValidateLogin(user, *session, parsed)
+	} else {
+		// 可发现凭据登录，库校验断言中的用户句柄属于凭据记录的用户
+		_, validated, err = settings.ValidatePasskeyLogin(func(_, _ []byte) (webauthn.User, error) {
+			return user, nil
+		}, *session, parsed)
+	}
+	if err != nil {
+		return fmt.Errorf("通行密钥校验失败: %w", err)
+	}
+	if validated.Authenticator.CloneWarning {
+		return errWebAuthnSignCount
+	}
+	return saveWebAuthnSignCount(ctx, record.ID, validated.Authenticator.SignCount)
+}
+
+// saveWebAuthnSignCount 锁定凭据记录后重新比较并保存签名计数。
+// 库只和登录开始时读到的计数比较，两个并发的断言可能都通过，加锁后只有先提交的一个能保存
+func saveWebAuthnSignCount(ctx context.Context, credentialID uint64, signCount uint32) error {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("开始事务失败: %w", err)
+	}
+	defer tx.Rollback()
+
+	record, err := tx.Credential.Query().
+		Where(credential.ID(credentialID), lockCredentialRows).
+		Only(ctx)
+	if err != nil {
+		return fmt.Errorf("查询通行密钥失败: %w", err)
+	}
+	storedCount, _ := record.Metadata["sign_count"].(float64)
+	if storedCount == 0 && signCount == 0 {
+		return nil // 认证器不支持签名计数
+	}
+	if float64(signCount) <= storedCount {
+		return errWebAuthnSignCount
+	}
+
+	metadata := make(map[string]interface{}, len(record.Metadata)+1)
+	for key, value := range record.Metadata {
+		metadata[key] = value
+	}
+	metadata["sign_count"] = signCount
+	if err := tx.Credential.UpdateOneID(credentialID).SetMetadata(metadata).Exec(ctx); err != nil {
+		return fmt.Errorf("保存签名计数失败: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("提交事务失败: %w", err)
+	}
+	return nil
+}
+
+// webAuthnChallenge 保存在 Redis 中的挑战信息
+type webAuthnChallenge struct {
+	Purpose string               `json:"purpose"`
+	Session webauthn.SessionData `json:"session"`
+}
+
+// saveSession 以挑战为键保存库生成的会话数据
+func (s *webAuthnSettings) saveSession(ctx context.Context, purpose string, session *webauthn.SessionData) error {
+	if caching.Client == nil {
+		return fmt.Errorf("缓存服务不可用，无法使用通行密钥")
+	}
+
+	data, err := json.Marshal(webAuthnChallenge{Purpose: purpose, Session: *session})
+	if err != nil {
+		return err
+	}
+	if err := caching.Client.Set(ctx, webAuthnKeys.Key("challenge", session.Challenge), data, s.challengeTTL).Err(); err != nil {
+		return fmt.Errorf("保存挑战失败: %w", err)
+	}
+	return nil
+}
+
+// consumeWebAuthnSession 取出并删除挑战对应的会话数据，保证每个挑战只能使用一次
+func consumeWebAuthnSession(ctx context.Context, challenge, purpose string) (*webauthn.SessionData, error) {
+	if caching.Client == nil {
+		return nil, fmt.Errorf("缓存服务不可用，无法使用通行密钥")
+	}
+	if challenge == "" {
+		return nil, errWebAuthnChallengeInvalid
+	}
+
+	key := webAuthnKeys.Key("challenge", challenge)
+	var get *redis.StringCmd
+	_, err := caching.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil, errWebAuthnChallengeInvalid
+	}
+	if err != nil {
+		return nil, fmt.Errorf("读取挑战失败: %w", err)
+	}
+
+	var stored webAuthnChallenge
+	if err := json.Unmarshal([]byte(get.Val()), &stored); err != nil || stored.Purpose != purpose {
+		return nil, errWebAuthnChallengeInvalid
+	}
+	return &stored.Session, nil
+}
+
+// webAuthnUser 实现 webauthn.User，凭据来自用户的 webauthn 类型认证记录
+type webAuthnUser struct {
+	id          uint64
+	name        string
+	credentials []webauthn.Credential
+}
+
+// WebAuthnID 用户句柄：用户ID的8字节大端序编码
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return binary.BigEndian.AppendUint64(nil, u.id)
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.name
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.name
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+// loadWebAuthnUser 查询用户和已注册的通行密钥
+func loadWebAuthnUser(ctx context.Context, userID uint64) (*webAuthnUser, error) {
+	record, err := database.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("用户不存在")
+		}
+		return nil, fmt.Errorf("查询用户失败: %w", err)
+	}
+
+	records, err := database.Client.Credential.Query().
+		Where(
+			credential.UserID(userID),
+			credential.CredentialTypeEQ(credential.CredentialTypeWebauthn),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询通行密钥失败: %w", err)
+	}
+
+	user := &webAuthnUser{id: userID, name: record.Name, credentials: make([]webauthn.Credential, 0, len(records))}
+	for _, r := range records {
+		c, err := webAuthnCredentialFromRecord(r)
+		if err != nil {
+			return nil, fmt.Errorf("通行密钥 %d 数据损坏: %w", r.ID, err)
+		}
+		user.credentials = append(user.credentials, c)
+	}
+	return user, nil
+}
+
+// webAuthnCredentialFromRecord 将认证记录还原为库的凭据
+func webAuthnCredentialFromRecord(record *ent.Credential) (webauthn.Credential, error) {
+	id, err := decodeWebAuthnBase64(record.Identifier)
+	if err != nil {
+		return webauthn.Credential{}, err
+	}
+	publicKey, err := decodeWebAuthnBase64(record.Secret)
+	if err != nil {
+		return webauthn.Credential{}, err
+	}
+
+	c := webauthn.Credential{ID: id, PublicKey: publicKey}
+	c.AttestationType, _ = record.Metadata["attestation_type"].(string)
+	c.Flags.BackupEligible, _ = record.Metadata["backup_eligible"].(bool)
+	signCount, _ := record.Metadata["sign_count"].(float64)
+	c.Authenticator.SignCount = uint32(signCount)
+	if aaguid, ok := record.Metadata["aaguid"].(string); ok {
+		c.Authenticator.AAGUID, _ = hex.DecodeString(aaguid)
+	}
+	if transports, ok := record.Metadata["transports"].([]interface{}); ok {
+		for _, transport := range transports {
+			if s, ok := transport.(string); ok {
+				c.Transport = append(c.Transport, protocol.AuthenticatorTransport(s))
+			}
+		}
+	}
+	return c, nil
+}
+
+// canonicalWebAuthnID 将 base64url（允许带填充）编码的ID规范为不带填充的形式
+func canonicalWebAuthnID(encoded string) (string, error) {
+	raw, err := decodeWebAuthnBase64(encoded)
+	if err != nil || len(raw) == 0 {
+		return "", fmt.Errorf("invalid id")
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeWebAuthnBase64 解码 base64url，兼容带填充的写法
+func decodeWebAuthnBase64(encoded string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+}
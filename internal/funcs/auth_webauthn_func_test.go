@@ -0,0 +1,297 @@
+package funcs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	stdsql "database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent/credential"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+	"github.com/redis/go-redis/v9"
+)
+
+const testWebAuthnOrigin = "https://admin.example.com"
+
+// testAuthenticator 模拟使用 ES256 密钥的认证器
+type testAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	signCount    uint32
+}
+
+func newTestAuthenticator(t *testing.T) *testAuthenticator {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testAuthenticator{key: key, credentialID: []byte("test-credential-1")}
+}
+
+func (a *testAuthenticator) clientData(t *testing.T, typ, challenge string) string {
+	raw, err := json.Marshal(map[string]string{"type": typ, "challenge": challenge, "origin": testWebAuthnOrigin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func (a *testAuthenticator) authData(flags byte, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte("admin.example.com"))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+func (a *testAuthenticator) register(t *testing.T, challenge string) *models.WebAuthnRegistrationRequest {
+	coseKey, err := webauthncbor.Marshal(map[int64]interface{}{
+		1: int64(2), 3: int64(-7), -1: int64(1), // EC2、ES256、P-256
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	attested := make([]byte, 16)
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, coseKey...)
+
+	attestation, err := webauthncbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": a.authData(byte(protocol.FlagUserPresent|protocol.FlagAttestedCredentialData), attested),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(a.credentialID)
+	return &models.WebAuthnRegistrationRequest{
+		ID:    id,
+		RawID: id,
+		Type:  "public-key",
+		Response: models.WebAuthnAttestationResponse{
+			ClientDataJSON:    a.clientData(t, "webauthn.create", challenge),
+			AttestationObject: base64.RawURLEncoding.EncodeToString(attestation),
+		},
+		Name: "laptop",
+	}
+}
+
+func (a *testAuthenticator) login(t *testing.T, challenge, clientCode string) *models.WebAuthnLoginRequest {
+	a.signCount++
+	authData := a.authData(byte(protocol.FlagUserPresent|protocol.FlagUserVerified), nil)
+	clientData := a.clientData(t, "webauthn.get", challenge)
+	clientDataRaw, _ := base64.RawURLEncoding.DecodeString(clientData)
+	clientDataHash := sha256.Sum256(clientDataRaw)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(a.credentialID)
+	return &models.WebAuthnLoginRequest{
+		ID:   id,
+		Type: "public-key",
+		Response: models.WebAuthnAssertionResponse{
+			ClientDataJSON:    clientData,
+			AuthenticatorData: base64.RawURLEncoding.EncodeToString(authData),
+			Signature:         base64.RawURLEncoding.EncodeToString(signature),
+			UserHandle:        base64.RawURLEncoding.EncodeToString((&webAuthnUser{id: 1}).WebAuthnID()),
+		},
+		ClientCode: clientCode,
+	}
+}
+
+func TestInitWebAuthnValidatesConfig(t *testing.T) {
+	previous := webAuthn
+	t.Cleanup(func() { webAuthn = previous })
+
+	if err := InitWebAuthn(&configs.WebAuthnConfig{}); err != nil || webAuthn != nil {
+		t.Fatalf("empty rp_id should disable webauthn, got %v (%v)", webAuthn, err)
+	}
+	if err := InitWebAuthn(&configs.WebAuthnConfig{RPID: "example.com"}); err == nil {
+		t.Fatal("expected error without origins")
+	}
+	if err := InitWebAuthn(&configs.WebAuthnConfig{RPID: "example.com", Origins: []string{"https://example.com"}, UserVerification: "always"}); err == nil {
+		t.Fatal("expected error for invalid user_verification")
+	}
+	if err := InitWebAuthn(&configs.WebAuthnConfig{RPID: "example.com", Origins: []string{"https://example.com/"}}); err != nil {
+		t.Fatalf("valid config rejected: %v", err)
+	}
+	config := webAuthn.Config
+	if len(config.RPOrigins) != 1 || config.RPOrigins[0] != "https://example.com" ||
+		config.AuthenticatorSelection.UserVerification != protocol.VerificationPreferred || config.RPDisplayName != "example.com" {
+		t.Fatalf("unexpected settings: %+v", config)
+	}
+}
+
+// setupWebAuthnTest 使用内存数据库和 miniredis，创建用户 alice(1) 和终端 web-code
+func setupWebAuthnTest(t *testing.T) *stdsql.DB {
+	client, db := newWorkflowDeleteTestClient(t)
+	previousClient := database.Client
+	database.Client = client
+	_, redisClient := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = redisClient.(*redis.Client)
+	previousSettings := webAuthn
+	t.Cleanup(func() {
+		database.Client = previousClient
+		caching.Client = previousCache
+		webAuthn = previousSettings
+	})
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 5, "name": "web", "code": "web-code", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	return db
+}
+
+func TestWebAuthnRegisterAndLogin(t *testing.T) {
+	setupWebAuthnTest(t)
+
+	ctx := context.Background()
+	if _, err := (AuthFuncs{}).BeginWebAuthnLogin(ctx, ""); !IsWebAuthnDisabled(err) {
+		t.Fatalf("expected disabled error, got %v", err)
+	}
+	if err := InitWebAuthn(&configs.WebAuthnConfig{RPID: "admin.example.com", Origins: []string{testWebAuthnOrigin}}); err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator := newTestAuthenticator(t)
+	creation, err := AuthFuncs{}.BeginWebAuthnRegistration(ctx, 1)
+	if err != nil {
+		t.Fatalf("begin registration failed: %v", err)
+	}
+	if userID, _ := creation.User.ID.(protocol.URLEncodedBase64); userID.String() != "AAAAAAAAAAE" || len(creation.CredentialExcludeList) != 0 {
+		t.Fatalf("unexpected creation options: %+v", creation)
+	}
+	registered, err := AuthFuncs{}.FinishWebAuthnRegistration(ctx, 1, authenticator.register(t, creation.Challenge.String()))
+	if err != nil {
+		t.Fatalf("finish registration failed: %v", err)
+	}
+	if registered.CredentialID != base64.RawURLEncoding.EncodeToString(authenticator.credentialID) {
+		t.Fatalf("unexpected registered credential: %+v", registered)
+	}
+
+	// 挑战只能使用一次
+	if _, err := (AuthFuncs{}).FinishWebAuthnRegistration(ctx, 1, authenticator.register(t, creation.Challenge.String())); err == nil {
+		t.Fatal("expected reused registration challenge to be rejected")
+	}
+
+	request, err := AuthFuncs{}.BeginWebAuthnLogin(ctx, "alice")
+	if err != nil {
+		t.Fatalf("begin login failed: %v", err)
+	}
+	// 用户名没有对应的密码认证信息时不限制可用的通行密钥
+	if len(request.AllowedCredentials) != 0 || request.RelyingPartyID != "admin.example.com" {
+		t.Fatalf("unexpected request options: %+v", request)
+	}
+
+	user, err := AuthFuncs{}.FinishWebAuthnLogin(ctx, nil, authenticator.login(t, request.Challenge.String(), "web-code"))
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if user.ID != 1 {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	// 同一挑战不能再次登录
+	if _, err := (AuthFuncs{}).FinishWebAuthnLogin(ctx, nil, authenticator.login(t, request.Challenge.String(), "web-code")); err == nil || !strings.Contains(err.Error(), "挑战") {
+		t.Fatalf("expected replayed challenge to be rejected, got %v", err)
+	}
+
+	// 签名计数回退时拒绝登录
+	request, _ = AuthFuncs{}.BeginWebAuthnLogin(ctx, "")
+	authenticator.signCount = 0
+	if _, err := (AuthFuncs{}).FinishWebAuthnLogin(ctx, nil, authenticator.login(t, request.Challenge.String(), "web-code")); err == nil || !strings.Contains(err.Error(), "计数") {
+		t.Fatalf("expected sign count rollback to be rejected, got %v", err)
+	}
+
+	// 篡改签名时拒绝登录
+	request, _ = AuthFuncs{}.BeginWebAuthnLogin(ctx, "")
+	authenticator.signCount = 10
+	tampered := authenticator.login(t, request.Challenge.String(), "web-code")
+	signature, _ := base64.RawURLEncoding.DecodeString(tampered.Response.Signature)
+	signature[len(signature)-1] ^= 0xff
+	tampered.Response.Signature = base64.RawURLEncoding.EncodeToString(signature)
+	if _, err := (AuthFuncs{}).FinishWebAuthnLogin(ctx, nil, tampered); err == nil || !strings.Contains(err.Error(), "校验失败") {
+		t.Fatalf("expected bad signature to be rejected, got %v", err)
+	}
+
+	// 注册后再次开始注册时排除已注册的通行密钥
+	creation, err = AuthFuncs{}.BeginWebAuthnRegistration(ctx, 1)
+	if err != nil || len(creation.CredentialExcludeList) != 1 {
+		t.Fatalf("expected registered credential to be excluded, got %+v (%v)", creation, err)
+	}
+}
+
+func TestWebAuthnSignCountUpdateIsAtomic(t *testing.T) {
+	db := setupWebAuthnTest(t)
+	// 用户名有密码认证信息时只允许该用户的通行密钥
+	insertTestRow(t, db, "sys_credentials", map[string]any{"id": 10, "user_id": 1, "credential_type": "password", "identifier": "alice"})
+
+	ctx := context.Background()
+	if err := InitWebAuthn(&configs.WebAuthnConfig{RPID: "admin.example.com", Origins: []string{testWebAuthnOrigin}}); err != nil {
+		t.Fatal(err)
+	}
+	authenticator := newTestAuthenticator(t)
+	creation, err := AuthFuncs{}.BeginWebAuthnRegistration(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (AuthFuncs{}).FinishWebAuthnRegistration(ctx, 1, authenticator.register(t, creation.Challenge.String())); err != nil {
+		t.Fatalf("finish registration failed: %v", err)
+	}
+
+	first, err := AuthFuncs{}.BeginWebAuthnLogin(ctx, "alice")
+	if err != nil || len(first.AllowedCredentials) != 1 {
+		t.Fatalf("expected alice's passkey to be allowed, got %+v (%v)", first, err)
+	}
+	second, _ := AuthFuncs{}.BeginWebAuthnLogin(ctx, "alice")
+
+	// 两个断言使用相同的签名计数，第二个在第一个保存计数之前已读取凭据记录
+	stale, err := database.Client.Credential.Query().
+		Where(credential.Identifier(base64.RawURLEncoding.EncodeToString(authenticator.credentialID))).
+		Only(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstAssertion := authenticator.login(t, first.Challenge.String(), "web-code")
+	authenticator.signCount--
+	_, secondAssertion, err := encodeWebAuthnAssertion(authenticator.login(t, second.Challenge.String(), "web-code"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := (AuthFuncs{}).FinishWebAuthnLogin(ctx, nil, firstAssertion); err != nil {
+		t.Fatalf("first login failed: %v", err)
+	}
+	if err := verifyWebAuthnAssertion(ctx, stale, secondAssertion); !errors.Is(err, errWebAuthnSignCount) {
+		t.Fatalf("expected concurrent assertion with the same sign count to be rejected, got %v", err)
+	}
+
+	record, err := database.Client.Credential.Get(ctx, stale.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := record.Metadata["sign_count"].(float64); count != 1 {
+		t.Fatalf("expected stored sign count 1, got %v", record.Metadata["sign_count"])
+	}
+}
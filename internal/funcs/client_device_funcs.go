@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"go-backend/database/ent"
 	"go-backend/database/ent/clientdevice"
+	"go-backend/pkg/configs"
 	"go-backend/pkg/database"
 	"go-backend/pkg/utils"
 	"go-backend/shared/models"
+	"strings"
 )
 
 type ClientDeviceFuncs struct{}
@@ -23,6 +25,39 @@ func (ClientDeviceFuncs) generateClientCode() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// schemaMinTokenExpiry 数据库约束的令牌最短有效期（毫秒）
+const schemaMinTokenExpiry uint64 = 1000
+
+// ValidateTokenExpiry 校验令牌有效期是否在配置允许的范围内，且refreshToken不短于accessToken
+func (ClientDeviceFuncs) ValidateTokenExpiry(accessTokenExpiry, refreshTokenExpiry uint64) error {
+	bounds := configs.GetConfig().Auth.Device
+
+	if err := checkTokenExpiryBounds("accessToken", accessTokenExpiry, bounds.MinAccessTokenExpiry, bounds.MaxAccessTokenExpiry); err != nil {
+		return err
+	}
+	if err := checkTokenExpiryBounds("refreshToken", refreshTokenExpiry, bounds.MinRefreshTokenExpiry, bounds.MaxRefreshTokenExpiry); err != nil {
+		return err
+	}
+	if refreshTokenExpiry < accessTokenExpiry {
+		return fmt.Errorf("invalid token expiry: refreshToken expiry must not be shorter than accessToken expiry")
+	}
+	return nil
+}
+
+// checkTokenExpiryBounds 校验单个令牌有效期，max为0表示不限制上限
+func checkTokenExpiryBounds(name string, value, min, max uint64) error {
+	if min < schemaMinTokenExpiry {
+		min = schemaMinTokenExpiry
+	}
+	if value < min {
+		return fmt.Errorf("invalid token expiry: %s expiry must be at least %d ms", name, min)
+	}
+	if max > 0 && value > max {
+		return fmt.Errorf("invalid token expiry: %s expiry must be at most %d ms", name, max)
+	}
+	return nil
+}
+
 // GetAllClientDevices 获取所有客户端设备
 func (ClientDeviceFuncs) GetAllClientDevices(ctx context.Context) ([]*models.ClientDeviceResponse, error) {
 	records, err := database.Client.ClientDevice.Query().
@@ -45,6 +80,8 @@ func (ClientDeviceFuncs) GetAllClientDevices(ctx context.Context) ([]*models.Cli
 			AccessTokenExpiry:  record.AccessTokenExpiry,
 			RefreshTokenExpiry: record.RefreshTokenExpiry,
 			Anonymous:          record.Anonymous,
+			Audience:           record.Audience,
+			Scopes:             record.Scopes,
 		}
 
 		// 处理关联的角色
@@ -96,6 +133,8 @@ func (ClientDeviceFuncs) GetClientDeviceById(ctx context.Context, id uint64) (*m
 		AccessTokenExpiry:  device.AccessTokenExpiry,
 		RefreshTokenExpiry: device.RefreshTokenExpiry,
 		Anonymous:          device.Anonymous,
+		Audience:           device.Audience,
+		Scopes:             device.Scopes,
 	}
 
 	// 处理关联的角色
@@ -162,6 +201,14 @@ func (ClientDeviceFuncs) GetClientDeviceByCode(ctx context.Context, code string)
 
 // CreateClientDevice 创建客户端设备
 func (ClientDeviceFuncs) CreateClientDevice(ctx context.Context, req *models.CreateClientDeviceRequest) (*ent.ClientDevice, error) {
+	if err := (ClientDeviceFuncs{}).ValidateTokenExpiry(req.AccessTokenExpiry, req.RefreshTokenExpiry); err != nil {
+		return nil, err
+	}
+	scopes, err := NormalizeTokenScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成唯一的code
 	code, err := ClientDeviceFuncs{}.generateClientCode()
 	if err != nil {
@@ -220,7 +267,11 @@ func (ClientDeviceFuncs) CreateClientDevice(ctx context.Context, req *models.Cre
 		SetDescription(desc).
 		SetAccessTokenExpiry(req.AccessTokenExpiry).
 		SetRefreshTokenExpiry(req.RefreshTokenExpiry).
-		SetAnonymous(anonymous)
+		SetAnonymous(anonymous).
+		SetAudience(strings.TrimSpace(req.Audience))
+	if len(scopes) > 0 {
+		builder = builder.SetScopes(scopes)
+	}
 
 	// 如果指定了角色ID，添加角色关联
 	if len(req.RoleIds) > 0 {
@@ -255,12 +306,30 @@ func (ClientDeviceFuncs) UpdateClientDevice(ctx context.Context, id uint64, req
 	}()
 
 	// 检查设备是否存在
-	exists, err := tx.ClientDevice.Query().Where(clientdevice.ID(id)).Exist(ctx)
+	current, err := tx.ClientDevice.Get(ctx, id)
 	if err != nil {
+		if ent.IsNotFound(err) {
+			err = fmt.Errorf("client device with id %d not found", id)
+		}
 		return nil, err
 	}
-	if !exists {
-		return nil, fmt.Errorf("client device with id %d not found", id)
+
+	// 校验更新后的令牌有效期
+	accessTokenExpiry, refreshTokenExpiry := current.AccessTokenExpiry, current.RefreshTokenExpiry
+	if req.AccessTokenExpiry != nil {
+		accessTokenExpiry = *req.AccessTokenExpiry
+	}
+	if req.RefreshTokenExpiry != nil {
+		refreshTokenExpiry = *req.RefreshTokenExpiry
+	}
+	if err = (ClientDeviceFuncs{}).ValidateTokenExpiry(accessTokenExpiry, refreshTokenExpiry); err != nil {
+		return nil, err
+	}
+	var scopes []string
+	if req.Scopes != nil {
+		if scopes, err = NormalizeTokenScopes(req.Scopes); err != nil {
+			return nil, err
+		}
 	}
 
 	// 更新设备基本信息
@@ -297,6 +366,20 @@ func (ClientDeviceFuncs) UpdateClientDevice(ctx context.Context, id uint64, req
 		builder = builder.SetAnonymous(*req.Anonymous)
 	}
 
+	// 只有非 nil 指针才设置 Audience，空字符串表示清除
+	if req.Audience != nil {
+		builder = builder.SetAudience(strings.TrimSpace(*req.Audience))
+	}
+
+	// 只有传入 scopes 时才更新，空数组表示不限制授权范围
+	if req.Scopes != nil {
+		if len(scopes) > 0 {
+			builder = builder.SetScopes(scopes)
+		} else {
+			builder = builder.ClearScopes()
+		}
+	}
+
 	oldRoles, err := tx.ClientDevice.Query().Where(clientdevice.IDEQ(id)).QueryRoles().All(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -334,6 +417,42 @@ func (ClientDeviceFuncs) DeleteClientDevice(ctx context.Context, id uint64) erro
 	return database.Client.ClientDevice.DeleteOneID(id).Exec(ctx)
 }
 
+// RegenerateClientDeviceCode 重新生成客户端设备的标识code，旧code立即失效
+func (ClientDeviceFuncs) RegenerateClientDeviceCode(ctx context.Context, id uint64) (*models.ClientDeviceResponse, error) {
+	exists, err := database.Client.ClientDevice.Query().Where(clientdevice.ID(id)).Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("client device with id %d not found", id)
+	}
+
+	var code string
+	for {
+		code, err = ClientDeviceFuncs{}.generateClientCode()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate client code: %w", err)
+		}
+
+		// 检查code是否已存在（虽然概率很小，但还是要检查）
+		taken, err := database.Client.ClientDevice.Query().
+			Where(clientdevice.Code(code)).
+			Exist(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check code existence: %w", err)
+		}
+		if !taken {
+			break
+		}
+	}
+
+	if err := database.Client.ClientDevice.UpdateOneID(id).SetCode(code).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return ClientDeviceFuncs{}.GetClientDeviceById(ctx, id)
+}
+
 // GetClientDevicesWithPagination 分页获取客户端设备
 func (ClientDeviceFuncs) GetClientDevicesWithPagination(ctx context.Context, req *models.PageClientDevicesRequest) (*models.PageClientDevicesResponse, error) {
 	// 构建查询
@@ -440,6 +559,8 @@ func (ClientDeviceFuncs) GetClientDevicesWithPagination(ctx context.Context, req
 			AccessTokenExpiry:  device.AccessTokenExpiry,
 			RefreshTokenExpiry: device.RefreshTokenExpiry,
 			Anonymous:          device.Anonymous,
+			Audience:           device.Audience,
+			Scopes:             device.Scopes,
 		}
 
 		// 处理关联的角色
@@ -0,0 +1,250 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/databasebackup"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/s3"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 数据库备份：将所有表导出为 tar.gz 归档，边导出边上传到S3，上传完成后记录备份清单。
+// 同一时间只允许一个备份运行，多实例部署时通过 Redis 分布式锁互斥，未配置 Redis 时退化为进程内互斥。
+
+// DatabaseBackupPermission 触发数据库备份和查看备份清单所需的权限
+const DatabaseBackupPermission = "system:backup"
+
+const (
+	// databaseBackupLockTTL 备份锁的过期时间，防止持有者异常退出后无法再次备份
+	databaseBackupLockTTL = 30 * time.Minute
+	// databaseBackupURLExpiration 备份下载链接的有效期
+	databaseBackupURLExpiration = time.Hour
+	// databaseBackupKeyPrefix 备份归档在存储桶中的键前缀
+	databaseBackupKeyPrefix = "backups/database-"
+)
+
+var (
+	// errDatabaseBackupInProgress 已有备份正在运行
+	errDatabaseBackupInProgress = errors.New("database backup already in progress")
+	// errDatabaseBackupStorageUnavailable 未配置S3存储
+	errDatabaseBackupStorageUnavailable = errors.New("database backup storage not configured")
+)
+
+// IsDatabaseBackupInProgress 判断错误是否为已有备份正在运行
+func IsDatabaseBackupInProgress(err error) bool {
+	return errors.Is(err, errDatabaseBackupInProgress)
+}
+
+// IsDatabaseBackupStorageUnavailable 判断错误是否为未配置备份存储
+func IsDatabaseBackupStorageUnavailable(err error) bool {
+	return errors.Is(err, errDatabaseBackupStorageUnavailable)
+}
+
+// backupStorage 备份归档的存储，生产环境为S3，测试中可替换为内存实现
+type backupStorage interface {
+	Upload(ctx context.Context, bucket, key string, body io.Reader) error
+	PresignDownload(bucket, key string, expiration time.Duration) (string, error)
+}
+
+// s3BackupStorage 基于S3客户端的备份存储
+type s3BackupStorage struct {
+	client *s3.S3Client
+}
+
+func (s s3BackupStorage) Upload(_ context.Context, bucket, key string, body io.Reader) error {
+	_, err := s.client.UploadFileWithOptions(bucket, key, body, &s3.UploadOptions{ContentType: "application/gzip"})
+	return err
+}
+
+func (s s3BackupStorage) PresignDownload(bucket, key string, expiration time.Duration) (string, error) {
+	return s.client.GetFileURL(bucket, key, expiration)
+}
+
+// localBackupMutex 未配置 Redis 时的进程内备份锁
+var localBackupMutex sync.Mutex
+
+// databaseBackupLockKey 备份锁的键名
+func databaseBackupLockKey() string {
+	return caching.NewKeyBuilder("database").Key("backup_lock")
+}
+
+// acquireDatabaseBackupLock 获取备份锁，锁被占用时立即返回 errDatabaseBackupInProgress
+func acquireDatabaseBackupLock(ctx context.Context, client redis.Cmdable) (func(), error) {
+	if client == nil {
+		if !localBackupMutex.TryLock() {
+			return nil, errDatabaseBackupInProgress
+		}
+		return localBackupMutex.Unlock, nil
+	}
+
+	key := databaseBackupLockKey()
+	token := utils.UUIDString()
+	acquired, err := client.SetNX(ctx, key, token, databaseBackupLockTTL).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire backup lock: %w", err)
+	}
+	if !acquired {
+		return nil, errDatabaseBackupInProgress
+	}
+	return func() {
+		// 与编辑锁相同，只释放自己持有的锁
+		releaseEditLockScript.Run(context.Background(), client, []string{key}, token)
+	}, nil
+}
+
+// countingWriter 统计写入的字节数
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// DatabaseBackupFuncs 数据库备份相关函数
+type DatabaseBackupFuncs struct{}
+
+// BackupDatabase 导出数据库并上传到配置的S3存储桶，返回备份清单和预签名下载链接
+func (DatabaseBackupFuncs) BackupDatabase(ctx context.Context, userID uint64) (*models.DatabaseBackupResponse, error) {
+	client := s3.GetClient()
+	bucket := configs.GetConfig().S3.Bucket
+	if client == nil || bucket == "" {
+		return nil, errDatabaseBackupStorageUnavailable
+	}
+
+	var redisClient redis.Cmdable
+	if caching.Client != nil {
+		redisClient = caching.Client
+	}
+	return runDatabaseBackup(ctx, database.Client, redisClient, s3BackupStorage{client: client}, bucket, userID)
+}
+
+// runDatabaseBackup 持有备份锁执行一次备份：导出归档通过管道直接流式上传，不在本地落盘整个归档
+func runDatabaseBackup(ctx context.Context, client *ent.Client, redisClient redis.Cmdable, storage backupStorage, bucket string, userID uint64) (*models.DatabaseBackupResponse, error) {
+	release, err := acquireDatabaseBackupLock(ctx, redisClient)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	backupTime := time.Now()
+	key := databaseBackupKeyPrefix + backupTime.UTC().Format("20060102T150405Z") + ".tar.gz"
+
+	reader, writer := io.Pipe()
+	counter := &countingWriter{w: writer}
+	var exportResult *database.ExportResult
+	exportDone := make(chan error, 1)
+	go func() {
+		result, err := database.ExportToArchive(client, &database.ExportConfig{Context: ctx}, counter)
+		exportResult = result
+		writer.CloseWithError(err)
+		exportDone <- err
+	}()
+
+	uploadErr := storage.Upload(ctx, bucket, key, reader)
+	// 上传提前失败时关闭读端，避免导出协程阻塞在写入上
+	reader.CloseWithError(uploadErr)
+	if exportErr := <-exportDone; exportErr != nil {
+		return nil, fmt.Errorf("failed to export database: %w", exportErr)
+	}
+	if uploadErr != nil {
+		return nil, fmt.Errorf("failed to upload database backup: %w", uploadErr)
+	}
+
+	entityCounts := make(map[string]int, len(exportResult.Results))
+	failedEntities := make([]string, 0)
+	totalRecords := 0
+	for _, entity := range exportResult.Results {
+		if !entity.Success {
+			failedEntities = append(failedEntities, entity.EntityName)
+			continue
+		}
+		entityCounts[entity.EntityName] = entity.RecordCount
+		totalRecords += entity.RecordCount
+	}
+	sort.Strings(failedEntities)
+
+	create := client.DatabaseBackup.Create().
+		SetBucket(bucket).
+		SetKey(key).
+		SetSize(counter.n).
+		SetTotalRecords(totalRecords).
+		SetEntityCounts(entityCounts).
+		SetFailedEntities(failedEntities).
+		SetBackupTime(backupTime)
+	if userID > 0 {
+		create = create.SetCreateBy(userID)
+	}
+	backup, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record database backup: %w", err)
+	}
+
+	response := convertDatabaseBackupToResponse(backup)
+	response.DownloadURL, err = storage.PresignDownload(bucket, key, databaseBackupURLExpiration)
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign backup download url: %w", err)
+	}
+	return response, nil
+}
+
+// ListDatabaseBackups 按备份时间倒序分页列出备份清单
+func (DatabaseBackupFuncs) ListDatabaseBackups(ctx context.Context, req *models.DatabaseBackupListRequest) (*models.PaginationResponse, error) {
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	query := database.Client.DatabaseBackup.Query().
+		Order(ent.Desc(databasebackup.FieldBackupTime), ent.Desc(databasebackup.FieldID))
+	backups, pagination, err := database.Paginate(ctx, query, req.Page, req.PageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query database backups: %w", err)
+	}
+
+	data := make([]*models.DatabaseBackupResponse, 0, len(backups))
+	for _, backup := range backups {
+		data = append(data, convertDatabaseBackupToResponse(backup))
+	}
+
+	return &models.PaginationResponse{
+		Data:       data,
+		Pagination: pagination,
+	}, nil
+}
+
+// convertDatabaseBackupToResponse 转换备份清单为响应格式
+func convertDatabaseBackupToResponse(backup *ent.DatabaseBackup) *models.DatabaseBackupResponse {
+	response := &models.DatabaseBackupResponse{
+		ID:             utils.Uint64ToString(backup.ID),
+		Bucket:         backup.Bucket,
+		Key:            backup.Key,
+		Size:           backup.Size,
+		TotalRecords:   backup.TotalRecords,
+		EntityCounts:   backup.EntityCounts,
+		FailedEntities: backup.FailedEntities,
+		BackupTime:     utils.FormatDateTime(backup.BackupTime),
+	}
+	if backup.CreateBy > 0 {
+		response.CreateBy = utils.Uint64ToString(backup.CreateBy)
+	}
+	return response
+}
@@ -0,0 +1,147 @@
+package funcs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// fakeBackupStorage 内存中的备份存储
+type fakeBackupStorage struct {
+	objects   map[string][]byte
+	uploadErr error
+}
+
+func (f *fakeBackupStorage) Upload(_ context.Context, bucket, key string, body io.Reader) error {
+	if f.uploadErr != nil {
+		return f.uploadErr
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	f.objects[bucket+"/"+key] = data
+	return nil
+}
+
+func (f *fakeBackupStorage) PresignDownload(bucket, key string, expiration time.Duration) (string, error) {
+	return "https://s3.test/" + bucket + "/" + key + "?expires=" + expiration.String(), nil
+}
+
+// readBackupArchive 解压归档，返回文件名到内容的映射
+func readBackupArchive(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("open gzip: %v", err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("read tar: %v", err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("read tar entry %s: %v", header.Name, err)
+		}
+		files[header.Name] = content
+	}
+	return files
+}
+
+func TestRunDatabaseBackup(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+
+	_, redisClient := newIPGuardTestClient(t)
+	storage := &fakeBackupStorage{objects: make(map[string][]byte)}
+	ctx := context.Background()
+
+	backup, err := runDatabaseBackup(ctx, client, redisClient, storage, "backups", 7)
+	if err != nil {
+		t.Fatalf("backup failed: %v", err)
+	}
+	if !strings.HasPrefix(backup.Key, databaseBackupKeyPrefix) || !strings.HasSuffix(backup.Key, ".tar.gz") {
+		t.Fatalf("unexpected backup key: %s", backup.Key)
+	}
+	if !strings.Contains(backup.DownloadURL, "backups/"+backup.Key) {
+		t.Fatalf("unexpected download url: %s", backup.DownloadURL)
+	}
+	if backup.CreateBy != "7" || backup.EntityCounts["WorkflowApplication"] != 2 || backup.EntityCounts["WorkflowNode"] != 4 {
+		t.Fatalf("unexpected manifest: %+v", backup)
+	}
+
+	data, ok := storage.objects["backups/"+backup.Key]
+	if !ok {
+		t.Fatalf("archive was not uploaded: %v", storage.objects)
+	}
+	if backup.Size != int64(len(data)) {
+		t.Fatalf("manifest size %d, uploaded %d bytes", backup.Size, len(data))
+	}
+
+	// 归档中包含各实体的完整数据和导出清单
+	files := readBackupArchive(t, data)
+	var apps []map[string]any
+	if err := json.Unmarshal(files["WorkflowApplication.json"], &apps); err != nil || len(apps) != 2 || apps[0]["id"] == nil {
+		t.Fatalf("unexpected archived applications: %v (%v)", apps, err)
+	}
+	var manifest database.ExportResult
+	if err := json.Unmarshal(files[database.ArchiveManifestName], &manifest); err != nil || manifest.SuccessCount == 0 {
+		t.Fatalf("unexpected archive manifest: %+v (%v)", manifest, err)
+	}
+
+	// 锁被占用时拒绝并发备份，也不会上传或记录清单
+	release, err := acquireDatabaseBackupLock(ctx, redisClient)
+	if err != nil {
+		t.Fatalf("acquire lock: %v", err)
+	}
+	if _, err := runDatabaseBackup(ctx, client, redisClient, storage, "backups", 7); !IsDatabaseBackupInProgress(err) {
+		t.Fatalf("expected backup in progress, got %v", err)
+	}
+	release()
+
+	// 上传失败时不记录清单，且导出协程不会阻塞
+	storage.uploadErr = errors.New("bucket unavailable")
+	if _, err := runDatabaseBackup(ctx, client, redisClient, storage, "backups", 7); err == nil || !strings.Contains(err.Error(), "bucket unavailable") {
+		t.Fatalf("expected upload error, got %v", err)
+	}
+
+	page, err := DatabaseBackupFuncs{}.ListDatabaseBackups(ctx, &models.DatabaseBackupListRequest{})
+	if err != nil {
+		t.Fatalf("list backups failed: %v", err)
+	}
+	listed := page.Data.([]*models.DatabaseBackupResponse)
+	if page.Pagination.Total != 1 || len(listed) != 1 || listed[0].Key != backup.Key || listed[0].DownloadURL != "" {
+		t.Fatalf("unexpected backup list: %+v", page)
+	}
+	if page.Pagination.Page != 1 || page.Pagination.PageSize != 20 {
+		t.Fatalf("unexpected default pagination: %+v", page.Pagination)
+	}
+
+	page, err = DatabaseBackupFuncs{}.ListDatabaseBackups(ctx, &models.DatabaseBackupListRequest{PageSize: 1000})
+	if err != nil {
+		t.Fatalf("list backups failed: %v", err)
+	}
+	if page.Pagination.PageSize != 100 {
+		t.Fatalf("page size should be capped, got %d", page.Pagination.PageSize)
+	}
+}
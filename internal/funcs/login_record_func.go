@@ -3,7 +3,9 @@ package funcs
 import (
 	"context"
 	"fmt"
+	"math"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"go-backend/database/ent/loginrecord"
 	"go-backend/pkg/database"
 	"go-backend/pkg/logging"
+	"go-backend/shared/models"
 
 	"github.com/gin-gonic/gin"
 )
@@ -24,6 +27,9 @@ const (
 	LoginStatusLocked  = "locked"
 )
 
+// LoginRecordAuditPermission 查看所有用户登录记录（审计）所需的权限
+const LoginRecordAuditPermission = "login_record.audit"
+
 // LoginRecordParams 登录记录参数
 type LoginRecordParams struct {
 	UserID         uint64
@@ -194,6 +200,136 @@ func (LoginRecordFuncs) GetLoginRecordsByStatus(ctx context.Context, status stri
 	return records, nil
 }
 
+// GetLoginRecordsWithPagination 按条件分页查询登录记录，按登录时间倒序
+func (LoginRecordFuncs) GetLoginRecordsWithPagination(ctx context.Context, req *models.GetLoginRecordsRequest) (*models.LoginRecordsListResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+	if req.PageSize < 1 {
+		req.PageSize = 20
+	}
+	if req.PageSize > 100 {
+		req.PageSize = 100
+	}
+
+	query := database.Client.LoginRecord.Query()
+
+	if req.UserID != "" {
+		userID, err := strconv.ParseUint(req.UserID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user id: %s", req.UserID)
+		}
+		query = query.Where(loginrecord.UserIDEQ(userID))
+	}
+	if req.Identifier != "" {
+		query = query.Where(loginrecord.IdentifierContains(req.Identifier))
+	}
+	if req.Status != "" {
+		status := loginrecord.Status(req.Status)
+		if err := loginrecord.StatusValidator(status); err != nil {
+			return nil, fmt.Errorf("invalid status: %s", req.Status)
+		}
+		query = query.Where(loginrecord.StatusEQ(status))
+	}
+	if req.CredentialType != "" {
+		credentialType := loginrecord.CredentialType(req.CredentialType)
+		if err := loginrecord.CredentialTypeValidator(credentialType); err != nil {
+			return nil, fmt.Errorf("invalid credential type: %s", req.CredentialType)
+		}
+		query = query.Where(loginrecord.CredentialTypeEQ(credentialType))
+	}
+	if req.ClientID != "" {
+		clientID, err := strconv.ParseUint(req.ClientID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client id: %s", req.ClientID)
+		}
+		query = query.Where(loginrecord.ClientIDEQ(clientID))
+	}
+	if req.Device != "" {
+		query = query.Where(loginrecord.DeviceInfoContains(req.Device))
+	}
+	if req.IPAddress != "" {
+		query = query.Where(loginrecord.IPAddressEQ(req.IPAddress))
+	}
+	if req.BeginTime != "" {
+		beginTime, err := time.Parse(time.RFC3339, req.BeginTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid begin time: %s", req.BeginTime)
+		}
+		query = query.Where(loginrecord.CreateTimeGTE(beginTime))
+	}
+	if req.EndTime != "" {
+		endTime, err := time.Parse(time.RFC3339, req.EndTime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end time: %s", req.EndTime)
+		}
+		query = query.Where(loginrecord.CreateTimeLTE(endTime))
+	}
+
+	total, err := query.Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询记录总数失败: %w", err)
+	}
+
+	offset := (req.Page - 1) * req.PageSize
+	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
+
+	records, err := query.
+		Order(ent.Desc(loginrecord.FieldCreateTime), ent.Desc(loginrecord.FieldID)).
+		Limit(req.PageSize).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("查询登录记录失败: %w", err)
+	}
+
+	data := make([]*models.LoginRecordResponse, len(records))
+	for i, record := range records {
+		data[i] = LoginRecordFuncs{}.ConvertLoginRecordToResponse(record)
+	}
+
+	return &models.LoginRecordsListResponse{
+		Data: data,
+		Pagination: models.Pagination{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      int64(total),
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
+
+// ConvertLoginRecordToResponse 转换登录记录为响应格式
+func (LoginRecordFuncs) ConvertLoginRecordToResponse(record *ent.LoginRecord) *models.LoginRecordResponse {
+	response := &models.LoginRecordResponse{
+		ID:             record.ID,
+		UserID:         record.UserID,
+		Identifier:     record.Identifier,
+		CredentialType: string(record.CredentialType),
+		IPAddress:      record.IPAddress,
+		UserAgent:      record.UserAgent,
+		DeviceInfo:     record.DeviceInfo,
+		Location:       record.Location,
+		Status:         string(record.Status),
+		FailureReason:  record.FailureReason,
+		SessionID:      record.SessionID,
+		LoginTime:      record.CreateTime.Format("2006-01-02 15:04:05"),
+		Duration:       record.Duration,
+		Metadata:       record.Metadata,
+	}
+
+	if record.LogoutTime != nil {
+		response.LogoutTime = record.LogoutTime.Format("2006-01-02 15:04:05")
+	}
+	if record.ClientID != nil {
+		response.ClientID = *record.ClientID
+	}
+
+	return response
+}
+
 // GetRecentFailedLoginAttempts 获取最近的失败登录尝试
 func (LoginRecordFuncs) GetRecentFailedLoginAttempts(ctx context.Context, identifier string, minutes int) (int, error) {
 	since := time.Now().Add(-time.Duration(minutes) * time.Minute)
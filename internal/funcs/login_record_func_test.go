@@ -0,0 +1,62 @@
+package funcs
+
+import (
+	"testing"
+	"time"
+
+	"go-backend/shared/models"
+)
+
+func TestGetLoginRecordsWithPagination(t *testing.T) {
+	drv, ctx := setupRoleTestDB(t)
+
+	now := time.Now()
+	execSQL(t, drv, "INSERT INTO sys_users (id, create_time, update_time, name) VALUES (10, ?, ?, 'alice'), (11, ?, ?, 'bob')", now, now, now, now)
+	records := []struct {
+		id     int
+		userID int
+		status string
+		ip     string
+		at     time.Time
+	}{
+		{1, 10, "success", "10.0.0.1", now.Add(-3 * time.Hour)},
+		{2, 10, "failed", "10.0.0.2", now.Add(-2 * time.Hour)},
+		{3, 11, "failed", "10.0.0.2", now.Add(-1 * time.Hour)},
+		{4, 11, "locked", "10.0.0.2", now},
+	}
+	for _, r := range records {
+		execSQL(t, drv, `INSERT INTO sys_login_records (id, create_time, update_time, identifier, credential_type, ip_address, status, user_id, device_info)
+			VALUES (?, ?, ?, ?, 'password', ?, ?, ?, 'Chrome on Windows')`, r.id, r.at, r.at, "user", r.ip, r.status, r.userID)
+	}
+
+	result, err := LoginRecordFuncs{}.GetLoginRecordsWithPagination(ctx, &models.GetLoginRecordsRequest{
+		IPAddress: "10.0.0.2",
+		BeginTime: now.Add(-150 * time.Minute).Format(time.RFC3339),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Pagination.Total != 3 || len(result.Data) != 3 {
+		t.Fatalf("expected 3 records, got %+v", result.Pagination)
+	}
+	// 按时间倒序
+	if result.Data[0].ID != 4 || result.Data[2].ID != 2 {
+		t.Fatalf("unexpected order: %d, %d", result.Data[0].ID, result.Data[2].ID)
+	}
+
+	result, err = LoginRecordFuncs{}.GetLoginRecordsWithPagination(ctx, &models.GetLoginRecordsRequest{
+		UserID: "10",
+		Status: "failed",
+		Device: "Chrome",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Data) != 1 || result.Data[0].ID != 2 {
+		t.Fatalf("expected record 2, got %+v", result.Data)
+	}
+
+	if _, err := (LoginRecordFuncs{}).GetLoginRecordsWithPagination(ctx, &models.GetLoginRecordsRequest{Status: "unknown"}); err == nil {
+		t.Fatal("expected error for invalid status")
+	}
+}
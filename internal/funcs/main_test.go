@@ -0,0 +1,15 @@
+package funcs
+
+import (
+	"os"
+	"testing"
+
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+)
+
+func TestMain(m *testing.M) {
+	// 部分流程会写日志，测试中使用不输出的默认logger
+	logging.NewLogger(&configs.LoggingConfig{Level: "fatal"})
+	os.Exit(m.Run())
+}
@@ -100,6 +100,7 @@ func (PermissionFuncs) UpdatePermission(ctx context.Context, id uint64, req *mod
 		}
 		return nil, err
 	}
+	invalidateRBACPermissionCache(ctx)
 
 	return PermissionFuncs{}.GetPermissionByID(ctx, id)
 }
@@ -113,6 +114,7 @@ func (PermissionFuncs) DeletePermission(ctx context.Context, id uint64) error {
 		}
 		return err
 	}
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -140,16 +142,6 @@ func (PermissionFuncs) GetPermissionsWithPagination(ctx context.Context, req *mo
 		query = query.Where(permission.IsPublicEQ(*req.IsPublic))
 	}
 
-	// 获取总数
-	total, err := query.Count(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// 计算分页
-	offset := (req.Page - 1) * req.PageSize
-	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
-
 	// 设置排序
 	if req.OrderBy != "" {
 		switch req.OrderBy {
@@ -183,8 +175,8 @@ func (PermissionFuncs) GetPermissionsWithPagination(ctx context.Context, req *mo
 		query = query.Order(ent.Desc(permission.FieldCreateTime))
 	}
 
-	// 执行查询
-	permissions, err := query.Offset(offset).Limit(req.PageSize).All(ctx)
+	// 分页查询
+	permissions, pagination, err := database.Paginate(ctx, query, req.Page, req.PageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -196,15 +188,8 @@ func (PermissionFuncs) GetPermissionsWithPagination(ctx context.Context, req *mo
 	}
 
 	return &models.PermissionsListResponse{
-		Data: permissionResponses,
-		Pagination: models.Pagination{
-			Page:       req.Page,
-			PageSize:   req.PageSize,
-			Total:      int64(total),
-			TotalPages: totalPages,
-			HasNext:    req.Page < totalPages,
-			HasPrev:    req.Page > 1,
-		},
+		Data:       permissionResponses,
+		Pagination: pagination,
 	}, nil
 }
 
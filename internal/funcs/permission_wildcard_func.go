@@ -0,0 +1,91 @@
+package funcs
+
+import (
+	"strings"
+
+	"go-backend/database/ent"
+)
+
+// 权限通配符语法：
+//
+// 权限的 name 或 action 按分隔符 ':' 或 '.' 拆分为段（两种分隔符等价，workflow:* 同样匹配 workflow.delete）。
+// 用户持有的权限中值为 "*" 的段是通配符：
+//   - 位于中间的 "*" 匹配恰好一个段，例如 *:read 匹配 workflow:read，不匹配 workflow:node:read
+//   - 位于末尾的 "*" 匹配剩余的一个或多个段，例如 workflow:* 匹配 workflow:delete 和 workflow:node:delete，不匹配 workflow 本身
+//   - 只由 "*" 组成的权限（*、*:*、*.*）匹配任何权限
+//
+// 通配符只在用户持有的权限中生效，检查时要求的权限按字面值处理；段内的 "*"（例如 work*）不是通配符。
+// 不含通配符的权限仍然要求完全相等。
+
+// permissionWildcard 通配符段
+const permissionWildcard = "*"
+
+// splitPermission 按 ':' 或 '.' 拆分权限
+func splitPermission(value string) []string {
+	return strings.FieldsFunc(value, func(r rune) bool {
+		return r == ':' || r == '.'
+	})
+}
+
+// isWildcardPermission 判断权限值是否包含通配符段
+func isWildcardPermission(value string) bool {
+	for _, segment := range splitPermission(value) {
+		if segment == permissionWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPermission 判断持有的权限 granted 是否满足要求的权限 required
+func matchPermission(granted, required string) bool {
+	if granted == required {
+		return true
+	}
+	if !isWildcardPermission(granted) {
+		return false
+	}
+
+	pattern := splitPermission(granted)
+	target := splitPermission(required)
+	if len(target) == 0 {
+		return false
+	}
+
+	allWildcard := true
+	for _, segment := range pattern {
+		if segment != permissionWildcard {
+			allWildcard = false
+			break
+		}
+	}
+	if allWildcard {
+		return true
+	}
+
+	for i, segment := range pattern {
+		if i >= len(target) {
+			return false
+		}
+		if segment == permissionWildcard {
+			if i == len(pattern)-1 {
+				return true
+			}
+			continue
+		}
+		if segment != target[i] {
+			return false
+		}
+	}
+	return len(pattern) == len(target)
+}
+
+// permissionSatisfies 判断持有的权限（name 或 action）是否满足任意一个要求的权限
+func permissionSatisfies(perm *ent.Permission, required []string) bool {
+	for _, requiredPerm := range required {
+		if matchPermission(perm.Name, requiredPerm) || matchPermission(perm.Action, requiredPerm) {
+			return true
+		}
+	}
+	return false
+}
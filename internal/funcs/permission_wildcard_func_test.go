@@ -0,0 +1,102 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/pkg/database"
+)
+
+func TestMatchPermission(t *testing.T) {
+	cases := []struct {
+		granted, required string
+		want              bool
+	}{
+		// 精确匹配
+		{"workflow:delete", "workflow:delete", true},
+		{"workflow:delete", "workflow:read", false},
+		{"workflow:delete", "workflow.delete", false},
+		// 末尾通配符匹配剩余的一个或多个段
+		{"workflow:*", "workflow:delete", true},
+		{"workflow:*", "workflow.delete", true},
+		{"workflow:*", "workflow:node:delete", true},
+		{"workflow:*", "workflow", false},
+		{"workflow:*", "workflows:delete", false},
+		{"login_record.*", "login_record.audit", true},
+		// 中间的通配符只匹配一个段
+		{"*:read", "workflow:read", true},
+		{"*:read", "workflow:node:read", false},
+		{"workflow:*:read", "workflow:node:read", true},
+		{"workflow:*:read", "workflow:node:write", false},
+		// 全通配
+		{"*:*", "workflow:delete", true},
+		{"*:*", "admin", true},
+		{"*", "login_record.audit", true},
+		// 段内的 * 不是通配符，要求的权限中的 * 按字面值处理
+		{"work*:delete", "workflow:delete", false},
+		{"workflow:delete", "workflow:*", false},
+	}
+	for _, c := range cases {
+		if got := matchPermission(c.granted, c.required); got != c.want {
+			t.Errorf("matchPermission(%q, %q) = %v, want %v", c.granted, c.required, got, c.want)
+		}
+	}
+}
+
+func TestHasAnyPermissionsWithWildcards(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	// 用户1：精确权限；用户2：继承 workflow:* 的角色；用户3：*:*；用户4：无角色
+	for _, userID := range []int{1, 2, 3, 4} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	permissions := map[int][2]string{
+		11: {"workflow-delete", "workflow:delete"},
+		12: {"workflow-all", "workflow:*"},
+		13: {"super-admin", "*:*"},
+	}
+	for id, perm := range permissions {
+		insertTestRow(t, db, "sys_permissions", map[string]any{"id": id, "name": perm[0], "action": perm[1]})
+	}
+	for roleID, permissionID := range map[int]int{21: 11, 22: 12, 23: 13} {
+		insertTestRow(t, db, "sys_roles", map[string]any{"id": roleID, "name": "role"})
+		insertTestRow(t, db, "sys_role_permission", map[string]any{"id": roleID * 10, "role_id": roleID, "permission_id": permissionID})
+	}
+	// 角色24继承 workflow:* 的角色22
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 24, "name": "child"})
+	insertTestRow(t, db, "role_inherits_from", map[string]any{"role_id": 24, "inherited_by_id": 22})
+	for userID, roleID := range map[int]int{1: 21, 2: 24, 3: 23} {
+		insertTestRow(t, db, "sys_user_role", map[string]any{"id": userID * 100, "user_id": userID, "role_id": roleID})
+	}
+
+	ctx := context.Background()
+	cases := []struct {
+		userID   uint64
+		required []string
+		want     bool
+	}{
+		{1, []string{"workflow:delete"}, true},
+		{1, []string{"workflow:read"}, false},
+		{2, []string{"workflow:delete"}, true},
+		{2, []string{"user:delete"}, false},
+		{3, []string{"user:delete"}, true},
+		{4, []string{"workflow:delete"}, false},
+	}
+	for _, c := range cases {
+		for name, check := range map[string]func(context.Context, uint64, []string) (bool, error){
+			"HasAnyPermissions":          HasAnyPermissions,
+			"HasAnyPermissionsOptimized": HasAnyPermissionsOptimized,
+		} {
+			got, err := check(ctx, c.userID, c.required)
+			if err != nil {
+				t.Fatalf("%s(%d, %v) failed: %v", name, c.userID, c.required, err)
+			}
+			if got != c.want {
+				t.Errorf("%s(%d, %v) = %v, want %v", name, c.userID, c.required, got, c.want)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	"go-backend/database/ent"
 	"go-backend/database/ent/permission"
@@ -11,7 +12,9 @@ import (
 	"go-backend/database/ent/rolepermission"
 	"go-backend/database/ent/user"
 	"go-backend/database/ent/userrole"
+	"go-backend/pkg/caching"
 	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
 	"go-backend/pkg/utils"
 	"go-backend/shared/models"
 
@@ -332,6 +335,7 @@ func RemoveParentRole(ctx context.Context, roleID, parentID uint64) error {
 		return fmt.Errorf("failed to remove parent role: %v", err)
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -372,6 +376,7 @@ func AddParentRole(ctx context.Context, roleID, parentID uint64) error {
 		return fmt.Errorf("failed to add parent role: %v", err)
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -419,7 +424,7 @@ func GetRoleUsersWithPagination(ctx context.Context, roleID uint64, req *models.
 		return nil, fmt.Errorf("role not found")
 	}
 
-	// 第一步：查询用户角色关联表，获取所有属于该角色的用户ID
+	// 第一步：查询用户角色关联表，获取所有属于该角色的用户ID（包含已过期但尚未清理的分配，便于续期）
 	userRoles, err := database.Client.UserRole.Query().
 		Where(userrole.RoleID(roleID)).
 		WithRole(func(rq *ent.RoleQuery) {
@@ -530,6 +535,10 @@ func GetRoleUsersWithPagination(ctx context.Context, roleID uint64, req *models.
 			Roles:      roles,
 			OtherRoles: roleList,
 		}
+		if userRoleList := userRoleMap[u.ID]; len(userRoleList) > 0 && userRoleList[0].ExpiresAt != nil {
+			userResponses[i].ExpiresAt = utils.FormatDateTime(*userRoleList[0].ExpiresAt)
+			userResponses[i].Expired = !userRoleList[0].ExpiresAt.After(time.Now())
+		}
 	}
 
 	return &models.RoleUsersResponse{
@@ -557,6 +566,9 @@ func BatchAssignUsersToRole(ctx context.Context, roleID uint64, req *models.Batc
 	if !exists {
 		return fmt.Errorf("role not found")
 	}
+	if err := checkUserRoleExpiry(req.ExpiresAt); err != nil {
+		return err
+	}
 
 	// 转换用户ID
 	userIDs := make([]uint64, 0, len(req.UserIds))
@@ -576,23 +588,28 @@ func BatchAssignUsersToRole(ctx context.Context, roleID uint64, req *models.Batc
 		return fmt.Errorf("some users not found")
 	}
 
-	// 批量创建用户角色关联
+	// 批量创建用户角色关联，已存在的关联按本次请求更新过期时间
 	bulk := make([]*ent.UserRoleCreate, 0, len(userIDs))
 	for _, userID := range userIDs {
 		// 检查是否已经存在关联
-		exists, err := database.Client.UserRole.Query().
+		existing, err := database.Client.UserRole.Query().
 			Where(
 				userrole.UserID(userID),
 				userrole.RoleID(roleID),
-			).Exist(ctx)
-		if err != nil {
+			).Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
 			return err
 		}
-		if !exists {
-			bulk = append(bulk, database.Client.UserRole.Create().
-				SetUserID(userID).
-				SetRoleID(roleID))
+		if existing != nil {
+			if err := updateUserRoleExpiry(ctx, existing, req.ExpiresAt); err != nil {
+				return fmt.Errorf("failed to update role assignment expiry: %v", err)
+			}
+			continue
 		}
+		bulk = append(bulk, database.Client.UserRole.Create().
+			SetUserID(userID).
+			SetRoleID(roleID).
+			SetNillableExpiresAt(req.ExpiresAt))
 	}
 
 	if len(bulk) > 0 {
@@ -602,6 +619,7 @@ func BatchAssignUsersToRole(ctx context.Context, roleID uint64, req *models.Batc
 		}
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -635,6 +653,7 @@ func BatchRemoveUsersFromRole(ctx context.Context, roleID uint64, req *models.Ba
 		return fmt.Errorf("failed to remove users from role: %v", err)
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -680,7 +699,7 @@ func collectRolePermissions(ctx context.Context, roleID uint64, visited map[uint
 	return nil
 }
 
-// HasAnyPermissions 检查用户是否拥有指定权限列表中的任何一个权限（支持角色继承）
+// HasAnyPermissions 检查用户是否拥有指定权限列表中的任何一个权限（支持角色继承和通配符权限）
 func HasAnyPermissions(ctx context.Context, userID uint64, permissions []string) (bool, error) {
 	if len(permissions) == 0 {
 		return false, nil
@@ -692,12 +711,10 @@ func HasAnyPermissions(ctx context.Context, userID uint64, permissions []string)
 		return false, err
 	}
 
-	// 检查是否有任何匹配的权限
+	// 检查是否有任何匹配的权限（支持通配符，见 matchPermission）
 	for _, userPerm := range userPermissions {
-		for _, requiredPerm := range permissions {
-			if userPerm.Name == requiredPerm || userPerm.Action == requiredPerm {
-				return true, nil
-			}
+		if permissionSatisfies(userPerm, permissions) {
+			return true, nil
 		}
 	}
 
@@ -724,6 +741,7 @@ func HasAnyRoleId(ctx context.Context, userId uint64, roleIds []uint64) (bool, e
 		Where(
 			userrole.UserID(userId),
 			userrole.RoleIDIn(roleIds...),
+			activeUserRole(),
 		).
 		Count(ctx)
 
@@ -734,7 +752,8 @@ func HasAnyRoleId(ctx context.Context, userId uint64, roleIds []uint64) (bool, e
 	return count > 0, nil
 }
 
-// HasAnyPermissionsOptimized 更高效的版本：直接通过数据库查询检查权限
+// HasAnyPermissionsOptimized 更高效的版本：公开权限直接查库，用户通过角色（含继承）持有的权限集合
+// 经 caching.GetOrSet 缓存（见 userPermissionGrants），在内存中按 matchPermission 匹配（支持通配符）
 func HasAnyPermissionsOptimized(ctx context.Context, userID uint64, permissions []string) (bool, error) {
 	if len(permissions) == 0 {
 		return true, nil
@@ -765,34 +784,133 @@ func HasAnyPermissionsOptimized(ctx context.Context, userID uint64, permissions
 		return true, nil
 	}
 
-	// 获取用户的所有角色ID（包括继承的角色）
-	roleIDs, err := getAllUserRoleIDs(ctx, userID)
+	grants, err := getUserPermissionGrants(ctx, userID)
 	if err != nil {
 		return false, err
 	}
-
-	if len(roleIDs) == 0 {
-		return false, nil
+	for _, granted := range grants.Grants {
+		for _, required := range permissions {
+			if matchPermission(granted, required) {
+				return true, nil
+			}
+		}
 	}
 
-	// 检查这些角色是否有任何所需的权限
-	count, err := database.Client.Permission.Query().
+	// 公开的通配符权限无法用 IN 查询命中，取出后逐个匹配
+	wildcards, err := database.Client.Permission.Query().
 		Where(
+			permission.IsPublicEQ(true),
 			permission.Or(
-				permission.NameIn(permissions...),
-				permission.ActionIn(permissions...),
+				permission.NameContains(permissionWildcard),
+				permission.ActionContains(permissionWildcard),
 			),
 		).
-		Where(permission.HasRolePermissionsWith(
-			rolepermission.RoleIDIn(roleIDs...),
-		)).
-		Count(ctx)
-
+		All(ctx)
 	if err != nil {
 		return false, err
 	}
+	for _, perm := range wildcards {
+		if permissionSatisfies(perm, permissions) {
+			return true, nil
+		}
+	}
 
-	return count > 0, nil
+	return false, nil
+}
+
+// userPermissionCacheTTL 用户权限集合的缓存时间。角色、权限及用户角色分配变更时会主动清除（见 invalidateRBACPermissionCache），
+// 该时间只是多实例下失效通知丢失时的兜底
+const userPermissionCacheTTL = 10 * time.Minute
+
+// userPermissionGrants 用户通过角色（含继承）持有的权限 name 和 action，以及集合的有效期限
+type userPermissionGrants struct {
+	Grants []string `json:"grants"`
+	// ValidUntil 用户最早到期的角色分配的过期时间，到期后集合可能已包含失效的权限，需要重新加载
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+}
+
+// getUserPermissionGrants 从缓存读取用户的权限集合，未命中时从数据库加载；
+// 缓存的集合中有角色分配已经到期时清除该条缓存并重新加载
+func getUserPermissionGrants(ctx context.Context, userID uint64) (*userPermissionGrants, error) {
+	key := caching.RBACKeys.Key("perms", userID)
+	load := func(ctx context.Context) (*userPermissionGrants, error) {
+		return loadUserPermissionGrants(ctx, userID)
+	}
+
+	grants, err := caching.GetOrSet(ctx, key, userPermissionCacheTTL, load)
+	if err != nil {
+		return nil, err
+	}
+	if grants.ValidUntil == nil || time.Now().Before(*grants.ValidUntil) {
+		return grants, nil
+	}
+
+	if err := caching.Invalidate(ctx, key); err != nil {
+		logging.Warn("Failed to invalidate expired permission cache for user %d: %v", userID, err)
+		return loadUserPermissionGrants(ctx, userID)
+	}
+	return caching.GetOrSet(ctx, key, userPermissionCacheTTL, load)
+}
+
+// loadUserPermissionGrants 从数据库加载用户通过角色（含继承）持有的权限集合
+func loadUserPermissionGrants(ctx context.Context, userID uint64) (*userPermissionGrants, error) {
+	grants := &userPermissionGrants{Grants: []string{}}
+
+	roleIDs, err := getAllUserRoleIDs(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleIDs) == 0 {
+		return grants, nil
+	}
+
+	// 直接查询角色权限关联，使软删除拦截器生效（HasRolePermissionsWith 的子查询不会过滤已撤销的关联）
+	rolePermissions, err := database.Client.RolePermission.Query().
+		Where(rolepermission.RoleIDIn(roleIDs...)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(rolePermissions) == 0 {
+		return grants, nil
+	}
+	permissionIDs := make([]uint64, 0, len(rolePermissions))
+	for _, rp := range rolePermissions {
+		permissionIDs = append(permissionIDs, rp.PermissionID)
+	}
+	perms, err := database.Client.Permission.Query().
+		Where(permission.IDIn(uniqueUint64s(permissionIDs)...)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(perms)*2)
+	for _, perm := range perms {
+		for _, value := range []string{perm.Name, perm.Action} {
+			if value != "" && !seen[value] {
+				seen[value] = true
+				grants.Grants = append(grants.Grants, value)
+			}
+		}
+	}
+
+	// 记录最早到期的角色分配，到期后缓存的集合不再可信
+	expiring, err := database.Client.UserRole.Query().
+		Where(
+			userrole.UserID(userID),
+			userrole.ExpiresAtNotNil(),
+			activeUserRole(),
+		).
+		Order(ent.Asc(userrole.FieldExpiresAt)).
+		First(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+	if expiring != nil {
+		grants.ValidUntil = expiring.ExpiresAt
+	}
+
+	return grants, nil
 }
 
 // getAllUserRoleIDs 获取用户所有角色ID（包括继承的角色）
@@ -800,7 +918,8 @@ func getAllUserRoleIDs(ctx context.Context, userID uint64) ([]uint64, error) {
 	// 获取用户的直接角色
 	directRoles, err := database.Client.Role.Query().
 		Where(entRole.HasUserRolesWith(func(s *sql.Selector) {
-			s.Where(sql.EQ("user_id", userID)).Where(sql.IsNull("delete_time"))
+			s.Where(sql.EQ("user_id", userID))
+			activeUserRoleSelector(s)
 		})).All(ctx)
 	if err != nil {
 		return nil, err
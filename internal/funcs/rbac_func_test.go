@@ -0,0 +1,91 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestUserPermissionGrantsAreCached(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	server, redisClient := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = redisClient.(*redis.Client)
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "user", "status": "active"})
+	insertTestRow(t, db, "sys_permissions", map[string]any{"id": 11, "name": "workflow-delete", "action": "workflow:delete"})
+	insertTestRow(t, db, "sys_permissions", map[string]any{"id": 12, "name": "workflow-read", "action": "workflow:read"})
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "operator"})
+	insertTestRow(t, db, "sys_role_permission", map[string]any{"id": 210, "role_id": 21, "permission_id": 11})
+	insertTestRow(t, db, "sys_role_permission", map[string]any{"id": 211, "role_id": 21, "permission_id": 12})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 100, "user_id": 1, "role_id": 21})
+
+	ctx := context.Background()
+	check := func(required string, want bool) {
+		t.Helper()
+		granted, err := HasAnyPermissionsOptimized(ctx, 1, []string{required})
+		if err != nil {
+			t.Fatalf("permission check failed: %v", err)
+		}
+		if granted != want {
+			t.Fatalf("permission %s = %v, want %v", required, granted, want)
+		}
+	}
+
+	check("workflow:delete", true)
+	if !server.Exists(caching.RBACKeys.Key("perms", 1)) {
+		t.Fatal("permission set should be written to the cache")
+	}
+
+	// 绕过业务函数直接删除的权限仍从缓存命中
+	if _, err := db.Exec("DELETE FROM sys_role_permission WHERE id = 211"); err != nil {
+		t.Fatalf("failed to delete role permission: %v", err)
+	}
+	check("workflow:read", true)
+
+	// 通过业务函数撤销权限会清除缓存，随后重新加载
+	if err := (RoleFuncs{}).RevokeRolePermission(ctx, 21, 11); err != nil {
+		t.Fatalf("revoke failed: %v", err)
+	}
+	check("workflow:delete", false)
+	check("workflow:read", false)
+}
+
+func TestUserPermissionGrantsReloadAfterRoleExpiry(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	_, redisClient := newIPGuardTestClient(t)
+	previousCache := caching.Client
+	caching.Client = redisClient.(*redis.Client)
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "user", "status": "active"})
+	insertTestRow(t, db, "sys_permissions", map[string]any{"id": 11, "name": "workflow-delete", "action": "workflow:delete"})
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "operator"})
+	insertTestRow(t, db, "sys_role_permission", map[string]any{"id": 210, "role_id": 21, "permission_id": 11})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 100, "user_id": 1, "role_id": 21, "expires_at": time.Now().Add(300 * time.Millisecond)})
+
+	ctx := context.Background()
+	if granted, err := HasAnyPermissionsOptimized(ctx, 1, []string{"workflow:delete"}); err != nil || !granted {
+		t.Fatalf("permission before expiry = %v, %v", granted, err)
+	}
+
+	// 缓存尚未过期，但角色分配已经到期，不能继续使用缓存的权限
+	time.Sleep(400 * time.Millisecond)
+	if granted, err := HasAnyPermissionsOptimized(ctx, 1, []string{"workflow:delete"}); err != nil || granted {
+		t.Fatalf("permission after expiry = %v, %v", granted, err)
+	}
+}
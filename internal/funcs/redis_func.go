@@ -43,9 +43,9 @@ func (RedisFuncs) Exists(ctx context.Context, key string) bool {
 	return err == nil && count > 0
 }
 
-// Delete 删除键
+// Delete 删除键，同时通知各实例删除一级缓存中的条目
 func (RedisFuncs) Delete(ctx context.Context, keys ...string) error {
-	return caching.Client.Del(ctx, keys...).Err()
+	return caching.Invalidate(ctx, keys...)
 }
 
 // SetExpire 设置键的过期时间
@@ -267,3 +267,9 @@ func (RedisFuncs) Keys(ctx context.Context, pattern string) ([]string, error) {
 func (RedisFuncs) Scan(ctx context.Context, cursor uint64, match string, count int64) ([]string, uint64, error) {
 	return caching.Client.Scan(ctx, cursor, match, count).Result()
 }
+
+// InvalidatePrefix 删除所有以指定前缀开头的键（基于SCAN分批删除，不阻塞Redis）
+// 前缀通常由 caching.KeyBuilder 构建，例如 caching.RBACKeys.Prefix("perms")
+func (RedisFuncs) InvalidatePrefix(ctx context.Context, prefix string) error {
+	return caching.InvalidatePrefix(ctx, prefix)
+}
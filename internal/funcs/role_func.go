@@ -3,13 +3,15 @@ package funcs
 import (
 	"context"
 	"fmt"
-	"math"
 
 	"go-backend/database/ent"
+	"go-backend/database/ent/permission"
 	"go-backend/database/ent/role"
 	"go-backend/database/ent/rolepermission"
 	"go-backend/database/ent/userrole"
+	"go-backend/pkg/caching"
 	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
 	"go-backend/pkg/utils"
 	"go-backend/shared/models"
 )
@@ -113,48 +115,159 @@ func (RoleFuncs) UpdateRole(ctx context.Context, id uint64, req *models.UpdateRo
 		}
 		return nil, err
 	}
+	if len(req.InheritsFrom) > 0 {
+		invalidateRBACPermissionCache(ctx)
+	}
 
 	return RoleFuncs{}.GetRoleByID(ctx, id)
 }
 
+// roleDeleteBlockerSampleSize 删除被阻止时每类依赖最多返回的样例数量
+const roleDeleteBlockerSampleSize = 20
+
+// GetRoleDeleteBlockers 获取阻止删除角色的依赖：已分配的用户和继承该角色的子角色
+func (RoleFuncs) GetRoleDeleteBlockers(ctx context.Context, id uint64) (*models.RoleDeleteBlockers, error) {
+	return getRoleDeleteBlockers(ctx, database.Client, id)
+}
+
+func getRoleDeleteBlockers(ctx context.Context, client *ent.Client, id uint64) (*models.RoleDeleteBlockers, error) {
+	blockers := &models.RoleDeleteBlockers{
+		Users:      make([]models.RoleDeleteBlocker, 0),
+		ChildRoles: make([]models.RoleDeleteBlocker, 0),
+	}
+
+	userRoleQuery := client.UserRole.Query().Where(userrole.RoleID(id))
+	userCount, err := userRoleQuery.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count role users: %w", err)
+	}
+	blockers.UserCount = userCount
+	if userCount > 0 {
+		userRoles, err := userRoleQuery.WithUser().Limit(roleDeleteBlockerSampleSize).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query role users: %w", err)
+		}
+		for _, ur := range userRoles {
+			blocker := models.RoleDeleteBlocker{ID: utils.Uint64ToString(ur.UserID)}
+			if ur.Edges.User != nil {
+				blocker.Name = ur.Edges.User.Name
+			}
+			blockers.Users = append(blockers.Users, blocker)
+		}
+	}
+
+	childQuery := client.Role.Query().Where(role.HasInheritsFromWith(role.ID(id)))
+	childCount, err := childQuery.Clone().Count(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count child roles: %w", err)
+	}
+	blockers.ChildRoleCount = childCount
+	if childCount > 0 {
+		children, err := childQuery.Limit(roleDeleteBlockerSampleSize).All(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query child roles: %w", err)
+		}
+		for _, child := range children {
+			blockers.ChildRoles = append(blockers.ChildRoles, models.RoleDeleteBlocker{
+				ID:   utils.Uint64ToString(child.ID),
+				Name: child.Name,
+			})
+		}
+	}
+
+	return blockers, nil
+}
+
 // DeleteRole 删除角色
-func (RoleFuncs) DeleteRole(ctx context.Context, id uint64) error {
-	// 删除的时候先删除管理的sys_role_permission和sys_user_role关联
+// 默认情况下，如果仍有用户分配了该角色或有子角色继承该角色，则拒绝删除并返回依赖信息（错误为 "role in use"）；
+// force 为 true 时在事务中移除用户角色关联，并将子角色改为继承被删除角色的父角色，然后删除角色
+func (RoleFuncs) DeleteRole(ctx context.Context, id uint64, force bool) (*models.RoleDeleteBlockers, error) {
 	tx, err := database.Client.Tx(ctx)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	target, err := tx.Role.Query().Where(role.ID(id)).WithInheritsFrom().Only(ctx)
+	if err != nil {
+		tx.Rollback()
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("role not found")
+		}
+		return nil, err
+	}
+
+	if !force {
+		blockers, err := getRoleDeleteBlockers(ctx, tx.Client(), id)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if blockers.HasBlockers() {
+			tx.Rollback()
+			return blockers, fmt.Errorf("role in use")
+		}
 	}
+
+	// 删除的时候先删除管理的sys_role_permission和sys_user_role关联
 	_, err = tx.RolePermission.Delete().Where(rolepermission.RoleID(id)).Exec(ctx)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
 	}
 	_, err = tx.UserRole.Delete().Where(userrole.RoleID(id)).Exec(ctx)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
 	}
-	// 还要删除子角色的继承关系
-	err = tx.Role.Update().Where(role.HasInheritsFromWith(role.ID(id))).RemoveInheritsFromIDs(id).Exec(ctx)
+
+	// 子角色改为继承被删除角色的父角色，保持其原有的继承权限
+	children, err := tx.Role.Query().
+		Where(role.HasInheritsFromWith(role.ID(id))).
+		WithInheritsFrom().
+		All(ctx)
 	if err != nil {
 		tx.Rollback()
-		return err
+		return nil, err
+	}
+	for _, child := range children {
+		existing := make(map[uint64]struct{}, len(child.Edges.InheritsFrom))
+		for _, parent := range child.Edges.InheritsFrom {
+			existing[parent.ID] = struct{}{}
+		}
+		newParents := make([]uint64, 0, len(target.Edges.InheritsFrom))
+		for _, parent := range target.Edges.InheritsFrom {
+			if _, ok := existing[parent.ID]; ok || parent.ID == child.ID {
+				continue
+			}
+			newParents = append(newParents, parent.ID)
+		}
+
+		err = tx.Role.UpdateOneID(child.ID).
+			RemoveInheritsFromIDs(id).
+			AddInheritsFromIDs(newParents...).
+			Exec(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
 	}
+
 	err = tx.Role.DeleteOneID(id).Exec(ctx)
 	if err != nil {
 		tx.Rollback()
 		if ent.IsNotFound(err) {
-			return fmt.Errorf("role not found")
+			return nil, fmt.Errorf("role not found")
 		}
-		return err
+		return nil, err
 	}
 
 	// 提交事务，检查提交错误
 	err = tx.Commit()
 	if err != nil {
-		return fmt.Errorf("failed to commit transaction: %v", err)
+		return nil, fmt.Errorf("failed to commit transaction: %v", err)
 	}
-	return nil
+	invalidateRBACPermissionCache(ctx)
+	return nil, nil
 }
 
 // GetRolesWithPagination 分页获取角色列表
@@ -175,16 +288,6 @@ func (RoleFuncs) GetRolesWithPagination(ctx context.Context, req *models.GetRole
 		query = query.Where(role.DescriptionContains(req.Description))
 	}
 
-	// 获取总数
-	total, err := query.Count(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	// 计算分页
-	offset := (req.Page - 1) * req.PageSize
-	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
-
 	// 设置排序
 	if req.OrderBy != "" {
 		switch req.OrderBy {
@@ -212,8 +315,8 @@ func (RoleFuncs) GetRolesWithPagination(ctx context.Context, req *models.GetRole
 		query = query.Order(ent.Desc(role.FieldCreateTime))
 	}
 
-	// 执行查询
-	roles, err := query.Offset(offset).Limit(req.PageSize).All(ctx)
+	// 分页查询
+	roles, pagination, err := database.Paginate(ctx, query, req.Page, req.PageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -225,15 +328,8 @@ func (RoleFuncs) GetRolesWithPagination(ctx context.Context, req *models.GetRole
 	}
 
 	return &models.RolesListResponse{
-		Data: roleResponses,
-		Pagination: models.Pagination{
-			Page:       req.Page,
-			PageSize:   req.PageSize,
-			Total:      int64(total),
-			TotalPages: totalPages,
-			HasNext:    req.Page < totalPages,
-			HasPrev:    req.Page > 1,
-		},
+		Data:       roleResponses,
+		Pagination: pagination,
 	}, nil
 }
 
@@ -284,9 +380,142 @@ func (RoleFuncs) AssignRolePermissions(ctx context.Context, roleID uint64, req *
 		return fmt.Errorf("failed to assign permissions: %v", err)
 	}
 
+	invalidateRBACPermissionCache(ctx)
+	return nil
+}
+
+// SetRolePermissions 将角色的直接权限设置为给定列表：在一个事务中与当前直接权限比较，
+// 新增缺少的、移除多余的，使角色最终恰好拥有这些权限（继承自父角色的权限不受影响）
+func (RoleFuncs) SetRolePermissions(ctx context.Context, roleID uint64, permissionIDs []uint64) error {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := setRolePermissionsTx(ctx, tx, roleID, permissionIDs); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
+// setRolePermissionsTx 在事务中计算并应用角色直接权限的增删差异
+func setRolePermissionsTx(ctx context.Context, tx *ent.Tx, roleID uint64, permissionIDs []uint64) error {
+	exists, err := tx.Role.Query().Where(role.ID(roleID)).Exist(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("role not found")
+	}
+
+	desired := uniqueUint64s(permissionIDs)
+	if len(desired) > 0 {
+		found, err := tx.Permission.Query().Where(permission.IDIn(desired...)).IDs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check permissions: %w", err)
+		}
+		if missing := missingUint64s(desired, found); len(missing) > 0 {
+			return fmt.Errorf("permission not found: %v", missing)
+		}
+	}
+
+	current, err := tx.RolePermission.Query().
+		Where(rolepermission.RoleID(roleID)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get existing permissions: %w", err)
+	}
+	currentIDs := make([]uint64, 0, len(current))
+	for _, rp := range current {
+		currentIDs = append(currentIDs, rp.PermissionID)
+	}
+
+	toAdd, toRemove := diffRolePermissions(currentIDs, desired)
+	if len(toRemove) > 0 {
+		_, err := tx.RolePermission.Delete().
+			Where(rolepermission.RoleID(roleID), rolepermission.PermissionIDIn(toRemove...)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to remove permissions: %w", err)
+		}
+	}
+	if len(toAdd) > 0 {
+		bulk := make([]*ent.RolePermissionCreate, 0, len(toAdd))
+		for _, permissionID := range toAdd {
+			bulk = append(bulk, tx.RolePermission.Create().
+				SetRoleID(roleID).
+				SetPermissionID(permissionID))
+		}
+		if _, err := tx.RolePermission.CreateBulk(bulk...).Save(ctx); err != nil {
+			return fmt.Errorf("failed to assign permissions: %w", err)
+		}
+	}
+	return nil
+}
+
+// diffRolePermissions 计算从当前权限变为目标权限需要新增和移除的权限ID，结果保持输入顺序
+func diffRolePermissions(current, desired []uint64) (toAdd, toRemove []uint64) {
+	currentSet := make(map[uint64]bool, len(current))
+	for _, id := range current {
+		currentSet[id] = true
+	}
+	desiredSet := make(map[uint64]bool, len(desired))
+	for _, id := range desired {
+		desiredSet[id] = true
+		if !currentSet[id] {
+			toAdd = append(toAdd, id)
+		}
+	}
+	for _, id := range uniqueUint64s(current) {
+		if !desiredSet[id] {
+			toRemove = append(toRemove, id)
+		}
+	}
+	return toAdd, toRemove
+}
+
+// uniqueUint64s 去重并保持首次出现的顺序
+func uniqueUint64s(ids []uint64) []uint64 {
+	seen := make(map[uint64]bool, len(ids))
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+// missingUint64s 返回 want 中不在 have 里的ID
+func missingUint64s(want, have []uint64) []uint64 {
+	haveSet := make(map[uint64]bool, len(have))
+	for _, id := range have {
+		haveSet[id] = true
+	}
+	var missing []uint64
+	for _, id := range want {
+		if !haveSet[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// invalidateRBACPermissionCache 角色、权限或用户角色分配变更后清除所有用户的权限集合缓存，失败只记录日志（缓存会在过期后自然失效）
+func invalidateRBACPermissionCache(ctx context.Context) {
+	if err := caching.InvalidatePrefix(ctx, caching.RBACKeys.Prefix("perms")); err != nil {
+		logging.Warn("Failed to invalidate rbac permission cache: %v", err)
+	}
+}
+
 // GetRolePermissions 获取角色的权限列表
 func (RoleFuncs) GetRolePermissions(ctx context.Context, roleId uint64) ([]*ent.Permission, error) {
 	role, err := database.Client.Role.Query().
@@ -454,5 +683,6 @@ func (RoleFuncs) RevokeRolePermission(ctx context.Context, roleID, permissionID
 		return err
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
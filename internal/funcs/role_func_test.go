@@ -0,0 +1,174 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/role"
+	_ "go-backend/database/ent/runtime"
+	"go-backend/database/ent/userrole"
+	"go-backend/pkg/database"
+
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// setupRoleTestDB 创建内存 SQLite 数据库并替换全局客户端
+// 测试数据通过 SQL 直接写入，以使用固定的ID
+func setupRoleTestDB(t *testing.T) (*entsql.Driver, context.Context) {
+	t.Helper()
+
+	drv, err := entsql.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	client := ent.NewClient(ent.Driver(drv))
+	ctx := context.Background()
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() {
+		database.Client = previous
+		client.Close()
+	})
+	return drv, ctx
+}
+
+func execSQL(t *testing.T, drv *entsql.Driver, query string, args ...any) {
+	t.Helper()
+	if _, err := drv.DB().Exec(query, args...); err != nil {
+		t.Fatalf("failed to exec %q: %v", query, err)
+	}
+}
+
+// seedRoleHierarchy 创建角色 parent(1) <- target(2) <- child(3)，并为用户(10)分配 target
+func seedRoleHierarchy(t *testing.T, drv *entsql.Driver) {
+	now := time.Now()
+	for id, name := range map[int]string{1: "parent", 2: "target", 3: "child"} {
+		execSQL(t, drv, "INSERT INTO sys_roles (id, create_time, update_time, name) VALUES (?, ?, ?, ?)", id, now, now, name)
+	}
+	// role_id 为子角色，inherited_by_id 为其继承的父角色
+	execSQL(t, drv, "INSERT INTO role_inherits_from (role_id, inherited_by_id) VALUES (2, 1), (3, 2)")
+	execSQL(t, drv, "INSERT INTO sys_users (id, create_time, update_time, name) VALUES (10, ?, ?, 'alice')", now, now)
+	execSQL(t, drv, "INSERT INTO sys_user_role (id, create_time, update_time, role_id, user_id) VALUES (100, ?, ?, 2, 10)", now, now)
+}
+
+func TestDeleteRoleBlocked(t *testing.T) {
+	drv, ctx := setupRoleTestDB(t)
+	seedRoleHierarchy(t, drv)
+
+	blockers, err := RoleFuncs{}.DeleteRole(ctx, 2, false)
+	if err == nil || err.Error() != "role in use" {
+		t.Fatalf("expected role in use error, got %v", err)
+	}
+	if blockers == nil || blockers.UserCount != 1 || blockers.ChildRoleCount != 1 {
+		t.Fatalf("unexpected blockers: %+v", blockers)
+	}
+	if blockers.Users[0].ID != "10" || blockers.Users[0].Name != "alice" {
+		t.Fatalf("unexpected user blocker: %+v", blockers.Users[0])
+	}
+	if blockers.ChildRoles[0].ID != "3" || blockers.ChildRoles[0].Name != "child" {
+		t.Fatalf("unexpected child role blocker: %+v", blockers.ChildRoles[0])
+	}
+
+	if exists, _ := database.Client.Role.Query().Where(role.ID(2)).Exist(ctx); !exists {
+		t.Fatal("role should not be deleted")
+	}
+}
+
+func TestDeleteRoleForce(t *testing.T) {
+	drv, ctx := setupRoleTestDB(t)
+	seedRoleHierarchy(t, drv)
+
+	if _, err := (RoleFuncs{}).DeleteRole(ctx, 2, true); err != nil {
+		t.Fatalf("force delete failed: %v", err)
+	}
+
+	if exists, _ := database.Client.Role.Query().Where(role.ID(2)).Exist(ctx); exists {
+		t.Fatal("role should be deleted")
+	}
+	if count, _ := database.Client.UserRole.Query().Where(userrole.RoleID(2)).Count(ctx); count != 0 {
+		t.Fatalf("expected user role assignments to be removed, got %d", count)
+	}
+
+	// 子角色改为继承被删除角色的父角色
+	parents, err := database.Client.Role.Query().Where(role.ID(3)).QueryInheritsFrom().IDs(ctx)
+	if err != nil {
+		t.Fatalf("failed to query child parents: %v", err)
+	}
+	if len(parents) != 1 || parents[0] != 1 {
+		t.Fatalf("expected child role to be re-parented to role 1, got %v", parents)
+	}
+}
+
+func TestDeleteRoleUnused(t *testing.T) {
+	drv, ctx := setupRoleTestDB(t)
+	seedRoleHierarchy(t, drv)
+
+	if _, err := (RoleFuncs{}).DeleteRole(ctx, 3, false); err != nil {
+		t.Fatalf("delete unused role failed: %v", err)
+	}
+	if _, err := (RoleFuncs{}).DeleteRole(ctx, 99, false); err == nil || err.Error() != "role not found" {
+		t.Fatalf("expected role not found, got %v", err)
+	}
+}
+
+func TestDiffRolePermissions(t *testing.T) {
+	toAdd, toRemove := diffRolePermissions([]uint64{1, 2, 3, 3}, []uint64{3, 4, 1, 5})
+	if fmt.Sprint(toAdd) != "[4 5]" || fmt.Sprint(toRemove) != "[2]" {
+		t.Fatalf("unexpected delta: add=%v remove=%v", toAdd, toRemove)
+	}
+
+	toAdd, toRemove = diffRolePermissions([]uint64{1, 2}, nil)
+	if len(toAdd) != 0 || fmt.Sprint(toRemove) != "[1 2]" {
+		t.Fatalf("empty target should remove everything: add=%v remove=%v", toAdd, toRemove)
+	}
+}
+
+func TestSetRolePermissionsRemovesExtras(t *testing.T) {
+	drv, ctx := setupRoleTestDB(t)
+	seedRoleHierarchy(t, drv)
+	now := time.Now()
+	for id := 50; id <= 52; id++ {
+		execSQL(t, drv, "INSERT INTO sys_permissions (id, create_time, update_time, name, action) VALUES (?, ?, ?, ?, ?)", id, now, now, fmt.Sprintf("perm-%d", id), fmt.Sprintf("action:%d", id))
+		execSQL(t, drv, "INSERT INTO sys_role_permission (id, create_time, update_time, role_id, permission_id) VALUES (?, ?, ?, 2, ?)", id+100, now, now, id)
+	}
+	// 父角色的权限不应受影响
+	execSQL(t, drv, "INSERT INTO sys_role_permission (id, create_time, update_time, role_id, permission_id) VALUES (200, ?, ?, 1, 50)", now, now)
+
+	if err := (RoleFuncs{}).SetRolePermissions(ctx, 2, []uint64{52, 50, 52}); err != nil {
+		t.Fatalf("SetRolePermissions failed: %v", err)
+	}
+
+	permissions, err := RoleFuncs{}.GetRolePermissions(ctx, 2)
+	if err != nil {
+		t.Fatalf("GetRolePermissions failed: %v", err)
+	}
+	got := make(map[uint64]bool)
+	for _, p := range permissions {
+		got[p.ID] = true
+	}
+	if len(got) != 2 || !got[50] || !got[52] {
+		t.Fatalf("expected role to have exactly permissions 50 and 52, got %v", got)
+	}
+	if parent, _ := (RoleFuncs{}).GetRolePermissions(ctx, 1); len(parent) != 1 {
+		t.Fatalf("parent role permissions should be untouched, got %d", len(parent))
+	}
+
+	if err := (RoleFuncs{}).SetRolePermissions(ctx, 2, []uint64{50, 999}); err == nil || !strings.HasPrefix(err.Error(), "permission not found") {
+		t.Fatalf("expected unknown permission to be rejected, got %v", err)
+	}
+	if permissions, _ := (RoleFuncs{}).GetRolePermissions(ctx, 2); len(permissions) != 2 {
+		t.Fatalf("rejected update must not change permissions, got %d", len(permissions))
+	}
+	if err := (RoleFuncs{}).SetRolePermissions(ctx, 404, nil); err == nil || err.Error() != "role not found" {
+		t.Fatalf("expected role not found, got %v", err)
+	}
+}
@@ -2,6 +2,8 @@ package funcs
 
 import (
 	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
 	"sync"
 	"time"
 )
@@ -9,6 +11,52 @@ import (
 func Setup() {
 	config := configs.GetConfig()
 
+	// 初始化密码哈希参数
+	InitPasswordHasher(&config.Auth.Argon2)
+
+	// 初始化密码策略
+	InitPasswordPolicy(&config.Auth.PasswordPolicy)
+
+	// 日志和报告脱敏使用的敏感键名
+	utils.SetSensitiveKeys(config.Logging.RedactedKeys)
+
+	// 初始化灵活登录的标识符判定顺序
+	if err := InitLoginIdentifierPriority(config.Auth.Login.IdentifierPriority); err != nil {
+		logging.Warn("登录标识符判定顺序配置无效，使用默认顺序: %v", err)
+	}
+
+	// 初始化按来源IP的登录防护
+	if err := InitLoginIPGuard(&config.Auth.Login.IPGuard); err != nil {
+		logging.Warn("登录IP防护配置无效，使用默认配置: %v", err)
+	}
+
+	// 初始化验证码尝试次数限制
+	if err := InitVerifyCodePolicy(&config.Auth.VerifyCode); err != nil {
+		logging.Warn("验证码校验配置无效，使用默认配置: %v", err)
+	}
+
+	// 初始化修改密码后的会话处理
+	InitPasswordChangePolicy(&config.Auth.PasswordChange)
+
+	// 初始化单终端单会话策略
+	InitSessionPolicy(&config.Auth.Session.SinglePerDevice)
+
+	// 初始化accessToken声明扩展策略
+	InitTokenClaimsPolicy(&config.Auth.TokenClaims)
+
+	// 启动过期角色分配的清理任务
+	InitUserRoleSweeper(&config.Auth.RoleExpiry)
+
+	// 初始化通行密钥（WebAuthn）
+	if err := InitWebAuthn(&config.Auth.WebAuthn); err != nil {
+		logging.Warn("通行密钥配置无效，不启用通行密钥登录: %v", err)
+	}
+
+	// 初始化魔法链接登录
+	if err := InitMagicLink(&config.Auth.MagicLink); err != nil {
+		logging.Warn("魔法链接配置无效，不启用魔法链接登录: %v", err)
+	}
+
 	monitorConfig := config.Server.Components.Monitor
 	if monitorConfig.Enabled {
 		interval := time.Duration(monitorConfig.Interval) * time.Second
@@ -22,9 +70,41 @@ func Setup() {
 	wsCache = make(map[uint64]*WsCache)
 	records := queryWsList()
 	wsCache = makeCache(records)
+
+	// 初始化工作流执行数据大小限制
+	InitWorkflowPayloadLimits(&config.Workflow.PayloadLimits)
+
+	// 初始化工作流名称唯一性检查
+	InitWorkflowNameUniqueness(config.Workflow.UniqueNames)
+
+	// 初始化子工作流的嵌套深度限制
+	InitSubWorkflowPolicy(&config.Workflow.SubWorkflow)
+
+	// 注册工作流执行事件的订阅监听
+	InitWorkflowExecutionEvents()
+
+	// 启动工作流执行队列，没有注册外部执行引擎时使用进程内执行引擎
+	if workflowExecutionRunner == nil {
+		RegisterWorkflowExecutionRunner(newWorkflowExecutionRunner(time.Duration(config.Workflow.DefaultNodeTimeout) * time.Second))
+	}
+	InitWorkflowExecutionQueue(&config.Workflow.Queue)
+
+	// 启动工作流定时调度
+	if err := InitWorkflowScheduler(); err != nil {
+		logging.Error("Failed to start workflow scheduler: %v", err)
+	}
 }
 
 func Cleanup() {
 	// 清理系统监控
 	StopSystemMonitor()
+
+	// 停止过期角色分配的清理任务
+	StopUserRoleSweeper()
+
+	// 停止工作流定时调度
+	StopWorkflowScheduler()
+
+	// 停止接受新的执行并等待进行中的执行完成
+	StopWorkflowExecutionQueue(time.Duration(configs.GetConfig().Workflow.Queue.DrainTimeout) * time.Second)
 }
@@ -0,0 +1,72 @@
+package funcs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/jwt"
+)
+
+// 终端授权范围：终端可以配置令牌受众（audience）和授权范围（scopes），登录和刷新时写入该终端签发的令牌。
+// 配置了授权范围的令牌只能访问声明了对应范围的接口（见 middleware.RequireScopes），
+// 未配置授权范围的终端签发的令牌不受范围限制，保持原有行为。授权范围不替代权限检查，接口仍按用户权限鉴权。
+
+const (
+	// TokenScopeWorkflow 访问工作流管理接口
+	TokenScopeWorkflow = "workflow"
+	// TokenScopeWorkflowExecute 触发工作流执行
+	TokenScopeWorkflowExecute = "workflow:execute"
+	// TokenScopeAttachment 上传和管理附件
+	TokenScopeAttachment = "attachment"
+)
+
+// KnownTokenScopes 终端可以配置的授权范围
+var KnownTokenScopes = map[string]string{
+	TokenScopeWorkflow:        "访问工作流管理接口",
+	TokenScopeWorkflowExecute: "触发工作流执行",
+	TokenScopeAttachment:      "上传和管理附件",
+}
+
+// NormalizeTokenScopes 去除空白、去重并排序授权范围，存在未知的授权范围时返回错误
+func NormalizeTokenScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool, len(scopes))
+	result := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if scope == "" || seen[scope] {
+			continue
+		}
+		if _, ok := KnownTokenScopes[scope]; !ok {
+			return nil, fmt.Errorf("invalid token scope: unknown scope %q", scope)
+		}
+		seen[scope] = true
+		result = append(result, scope)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// deviceTokenScope 返回终端配置的受众和授权范围声明，都未配置时返回 nil
+func deviceTokenScope(device *ent.ClientDevice) *jwt.AccessClaims {
+	if device == nil || (device.Audience == "" && len(device.Scopes) == 0) {
+		return nil
+	}
+	return &jwt.AccessClaims{Audience: device.Audience, Scopes: device.Scopes}
+}
+
+// withDeviceTokenScope 将终端的受众和授权范围合并到accessToken声明中
+func withDeviceTokenScope(claims *jwt.AccessClaims, device *ent.ClientDevice) *jwt.AccessClaims {
+	scope := deviceTokenScope(device)
+	if scope == nil {
+		return claims
+	}
+	if claims == nil {
+		return scope
+	}
+	merged := *claims
+	merged.Audience = scope.Audience
+	merged.Scopes = scope.Scopes
+	return &merged
+}
@@ -0,0 +1,42 @@
+package funcs
+
+import (
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/jwt"
+)
+
+func TestNormalizeTokenScopes(t *testing.T) {
+	scopes, err := NormalizeTokenScopes([]string{" workflow:execute", "workflow", "workflow", ""})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scopes) != 2 || scopes[0] != "workflow" || scopes[1] != "workflow:execute" {
+		t.Fatalf("scopes should be trimmed, deduplicated and sorted, got %v", scopes)
+	}
+
+	if _, err := NormalizeTokenScopes([]string{"workflow", "admin"}); err == nil || !strings.HasPrefix(err.Error(), "invalid token scope") {
+		t.Fatalf("expected unknown scope to be rejected, got %v", err)
+	}
+}
+
+func TestWithDeviceTokenScope(t *testing.T) {
+	if claims := withDeviceTokenScope(nil, &ent.ClientDevice{}); claims != nil {
+		t.Fatalf("device without audience or scopes should not add claims, got %+v", claims)
+	}
+
+	device := &ent.ClientDevice{Audience: "integration-api", Scopes: []string{"workflow"}}
+	claims := withDeviceTokenScope(nil, device)
+	if claims == nil || claims.Audience != "integration-api" || len(claims.Scopes) != 1 {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	// 与角色声明合并时不修改原声明
+	roles := &jwt.AccessClaims{Roles: []string{"admin"}}
+	merged := withDeviceTokenScope(roles, device)
+	if len(merged.Roles) != 1 || merged.Audience != "integration-api" || roles.Audience != "" {
+		t.Fatalf("unexpected merged claims: %+v (original %+v)", merged, roles)
+	}
+}
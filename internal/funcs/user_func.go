@@ -6,6 +6,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"time"
 
 	"go-backend/database/ent"
 	"go-backend/database/ent/attachment"
@@ -351,27 +352,41 @@ func (UserFuncs) AssignUserRole(ctx context.Context, req *models.AssignUserRoleR
 		return nil, fmt.Errorf("role not found")
 	}
 
-	// 检查是否已经分配了该角色
-	exists, err = database.Client.UserRole.Query().
+	if err := checkUserRoleExpiry(req.ExpiresAt); err != nil {
+		return nil, err
+	}
+
+	// 检查是否已经分配了该角色，已过期的分配按本次请求续期
+	existing, err := database.Client.UserRole.Query().
 		Where(
 			userrole.UserID(utils.StringToUint64(req.UserID)),
 			userrole.RoleID(utils.StringToUint64(req.RoleID)),
-		).Exist(ctx)
-	if err != nil {
+		).Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
 		return nil, err
 	}
-	if exists {
-		return nil, fmt.Errorf("user role already exists")
-	}
 
-	// 创建用户角色关联
-	userRole, err := database.Client.UserRole.Create().
-		SetUserID(utils.StringToUint64(req.UserID)).
-		SetRoleID(utils.StringToUint64(req.RoleID)).
-		Save(ctx)
-	if err != nil {
-		return nil, err
+	var userRole *ent.UserRole
+	if existing != nil {
+		if existing.ExpiresAt == nil || existing.ExpiresAt.After(time.Now()) {
+			return nil, fmt.Errorf("user role already exists")
+		}
+		if err := updateUserRoleExpiry(ctx, existing, req.ExpiresAt); err != nil {
+			return nil, err
+		}
+		userRole = existing
+	} else {
+		// 创建用户角色关联
+		userRole, err = database.Client.UserRole.Create().
+			SetUserID(utils.StringToUint64(req.UserID)).
+			SetRoleID(utils.StringToUint64(req.RoleID)).
+			SetNillableExpiresAt(req.ExpiresAt).
+			Save(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
+	invalidateRBACPermissionCache(ctx)
 
 	// 加载关联数据
 	userRole, err = database.Client.UserRole.Query().
@@ -407,6 +422,7 @@ func (UserFuncs) RevokeUserRole(ctx context.Context, userID, roleID uint64) erro
 		return err
 	}
 
+	invalidateRBACPermissionCache(ctx)
 	return nil
 }
 
@@ -424,8 +440,8 @@ func (UserFuncs) GetUserRoles(ctx context.Context, userID uint64) ([]*ent.Role,
 	// 获取用户的所有角色
 	roles, err := database.Client.Role.Query().
 		Where(role.HasUserRolesWith(func(s *sql.Selector) {
-			// userrole.UserID(userID)
-			s.Where(sql.EQ("user_id", userID)).Where(sql.IsNull("delete_time"))
+			s.Where(sql.EQ("user_id", userID))
+			activeUserRoleSelector(s)
 		})).
 		All(ctx)
 	if err != nil {
@@ -449,7 +465,8 @@ func (UserFuncs) GetRoleUsers(ctx context.Context, roleID uint64) ([]*ent.User,
 	// 获取拥有该角色的所有用户
 	users, err := database.Client.User.Query().
 		Where(user.HasUserRolesWith(func(s *sql.Selector) {
-			s.Where(sql.EQ("role_id", roleID)).Where(sql.IsNull("delete_time"))
+			s.Where(sql.EQ("role_id", roleID))
+			activeUserRoleSelector(s)
 		})).
 		All(ctx)
 	if err != nil {
@@ -473,7 +490,8 @@ func (UserFuncs) GetUserPermissions(ctx context.Context, userID uint64) ([]*ent.
 	// 获取用户的所有角色
 	userRoles, err := database.Client.Role.Query().
 		Where(role.HasUserRolesWith(func(s *sql.Selector) {
-			s.Where(sql.EQ("user_id", userID)).Where(sql.IsNull("delete_time"))
+			s.Where(sql.EQ("user_id", userID))
+			activeUserRoleSelector(s)
 		})).
 		All(ctx)
 	if err != nil {
@@ -554,6 +572,10 @@ func (UserFuncs) ConvertUserRoleToResponse(userRole *ent.UserRole) *models.UserR
 		CreateTime: utils.FormatDateTime(userRole.CreateTime),
 		UpdateTime: utils.FormatDateTime(userRole.UpdateTime),
 	}
+	if userRole.ExpiresAt != nil {
+		response.ExpiresAt = utils.FormatDateTime(*userRole.ExpiresAt)
+		response.Expired = !userRole.ExpiresAt.After(time.Now())
+	}
 
 	// 如果加载了用户关联数据
 	if userRole.Edges.User != nil {
@@ -697,7 +719,7 @@ func (UserFuncs) ConvertUserToResponse(user *ent.User) *models.UserResponse {
 func (UserFuncs) GetUserMenuTree(ctx context.Context, userID uint64) ([]*models.ScopeResponse, error) {
 	// 1. 获取用户的所有角色
 	userRoles, err := database.Client.UserRole.Query().
-		Where(userrole.UserID(userID)).
+		Where(userrole.UserID(userID), activeUserRole()).
 		WithRole().
 		All(ctx)
 	if err != nil {
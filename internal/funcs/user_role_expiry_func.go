@@ -0,0 +1,113 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/userrole"
+	"go-backend/database/mixins"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+var (
+	// 过期角色分配清理定时器
+	userRoleSweepTicker *time.Ticker
+	// 清理任务停止信号
+	userRoleSweepStop chan struct{}
+)
+
+// errUserRoleExpiryInPast 分配角色时指定的过期时间早于当前时间
+var errUserRoleExpiryInPast = errors.New("role assignment expiry must be in the future")
+
+// IsUserRoleExpiryInPast 判断错误是否为过期时间早于当前时间
+func IsUserRoleExpiryInPast(err error) bool {
+	return errors.Is(err, errUserRoleExpiryInPast)
+}
+
+// checkUserRoleExpiry 校验分配角色时指定的过期时间，为空表示永久有效
+func checkUserRoleExpiry(expiresAt *time.Time) error {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return errUserRoleExpiryInPast
+	}
+	return nil
+}
+
+// updateUserRoleExpiry 重新分配已存在的角色时更新过期时间，为空时改为永久有效
+func updateUserRoleExpiry(ctx context.Context, userRole *ent.UserRole, expiresAt *time.Time) error {
+	update := userRole.Update()
+	if expiresAt != nil {
+		update = update.SetExpiresAt(*expiresAt)
+	} else {
+		update = update.ClearExpiresAt()
+	}
+	return update.Exec(ctx)
+}
+
+// activeUserRole 未过期的用户角色分配（未设置过期时间或过期时间晚于当前时间）
+func activeUserRole() predicate.UserRole {
+	return userrole.Or(userrole.ExpiresAtIsNil(), userrole.ExpiresAtGT(time.Now()))
+}
+
+// activeUserRoleSelector 在 HasUserRolesWith 等基于 sql.Selector 的条件中过滤已删除和已过期的用户角色分配
+func activeUserRoleSelector(s *sql.Selector) {
+	s.Where(sql.IsNull(userrole.FieldDeleteTime)).
+		Where(sql.Or(
+			sql.IsNull(userrole.FieldExpiresAt),
+			sql.GT(userrole.FieldExpiresAt, time.Now()),
+		))
+}
+
+// InitUserRoleSweeper 启动过期角色分配的清理任务
+func InitUserRoleSweeper(config *configs.RoleExpiryConfig) {
+	if userRoleSweepTicker != nil {
+		StopUserRoleSweeper()
+	}
+	if config.SweepInterval <= 0 {
+		return
+	}
+
+	retention := time.Duration(config.RetentionDays) * 24 * time.Hour
+	userRoleSweepTicker = time.NewTicker(time.Duration(config.SweepInterval) * time.Second)
+	userRoleSweepStop = make(chan struct{})
+	ticker, stop := userRoleSweepTicker, userRoleSweepStop
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				removed, err := sweepExpiredUserRoles(context.Background(), time.Now().Add(-retention))
+				if err != nil {
+					logging.Error("Failed to sweep expired user roles: %v", err)
+				} else if removed > 0 {
+					logging.Info("Removed %d expired user role assignments", removed)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopUserRoleSweeper 停止过期角色分配的清理任务
+func StopUserRoleSweeper() {
+	if userRoleSweepTicker != nil {
+		userRoleSweepTicker.Stop()
+		close(userRoleSweepStop)
+		userRoleSweepTicker = nil
+		userRoleSweepStop = nil
+	}
+}
+
+// sweepExpiredUserRoles 物理删除在 before 之前过期的用户角色分配，返回删除的数量
+func sweepExpiredUserRoles(ctx context.Context, before time.Time) (int, error) {
+	return database.Client.UserRole.Delete().
+		Where(userrole.ExpiresAtNotNil(), userrole.ExpiresAtLT(before)).
+		Exec(mixins.SkipSoftDelete(ctx))
+}
@@ -0,0 +1,130 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/userrole"
+	"go-backend/database/mixins"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func TestExpiredUserRoleGrantsNoPermissions(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	// 用户1：角色已过期；用户2：角色尚未过期；用户3：永久角色
+	for _, userID := range []int{1, 2, 3} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	insertTestRow(t, db, "sys_permissions", map[string]any{"id": 11, "name": "workflow-delete", "action": "workflow:delete"})
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "operator"})
+	insertTestRow(t, db, "sys_role_permission", map[string]any{"id": 210, "role_id": 21, "permission_id": 11})
+	now := time.Now()
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 100, "user_id": 1, "role_id": 21, "expires_at": now.Add(-time.Minute)})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 200, "user_id": 2, "role_id": 21, "expires_at": now.Add(time.Hour)})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 300, "user_id": 3, "role_id": 21})
+
+	ctx := context.Background()
+	for userID, want := range map[uint64]bool{1: false, 2: true, 3: true} {
+		granted, err := HasAnyPermissionsOptimized(ctx, userID, []string{"workflow:delete"})
+		if err != nil {
+			t.Fatalf("permission check for user %d failed: %v", userID, err)
+		}
+		if granted != want {
+			t.Fatalf("user %d permission = %v, want %v", userID, granted, want)
+		}
+
+		roleIDs, err := getAllUserRoleIDs(ctx, userID)
+		if err != nil {
+			t.Fatalf("get role ids failed: %v", err)
+		}
+		if (len(roleIDs) == 1) != want {
+			t.Fatalf("user %d role ids = %v", userID, roleIDs)
+		}
+
+		permissions, err := UserFuncs{}.GetUserPermissions(ctx, userID)
+		if err != nil {
+			t.Fatalf("get permissions failed: %v", err)
+		}
+		if (len(permissions) == 1) != want {
+			t.Fatalf("user %d permissions = %d", userID, len(permissions))
+		}
+
+		hasRole, err := HasAnyRoleId(ctx, userID, []uint64{21})
+		if err != nil {
+			t.Fatalf("role check failed: %v", err)
+		}
+		if hasRole != want {
+			t.Fatalf("user %d has role = %v, want %v", userID, hasRole, want)
+		}
+	}
+
+	// 角色用户列表包含已过期的分配并标记过期
+	users, err := GetRoleUsersWithPagination(ctx, 21, &models.GetRoleUsersRequest{PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 10}})
+	if err != nil {
+		t.Fatalf("list role users failed: %v", err)
+	}
+	expired := map[string]bool{}
+	for _, u := range users.Users {
+		expired[u.ID] = u.Expired
+		if u.ID != "3" && u.ExpiresAt == "" {
+			t.Fatalf("user %s should expose the expiry", u.ID)
+		}
+	}
+	if len(expired) != 3 || !expired["1"] || expired["2"] || expired["3"] {
+		t.Fatalf("unexpected expiry flags: %v", expired)
+	}
+
+	// 重新分配已过期的角色时续期
+	expiresAt := now.Add(2 * time.Hour)
+	if _, err := (UserFuncs{}).AssignUserRole(ctx, &models.AssignUserRoleRequest{UserID: "1", RoleID: "21", ExpiresAt: &expiresAt}); err != nil {
+		t.Fatalf("renew expired role failed: %v", err)
+	}
+	if granted, _ := HasAnyPermissionsOptimized(ctx, 1, []string{"workflow:delete"}); !granted {
+		t.Fatal("renewed role should grant permissions again")
+	}
+	if _, err := (UserFuncs{}).AssignUserRole(ctx, &models.AssignUserRoleRequest{UserID: "2", RoleID: "21"}); err == nil || err.Error() != "user role already exists" {
+		t.Fatalf("active assignment should conflict, got %v", err)
+	}
+	past := now.Add(-time.Hour)
+	if err := BatchAssignUsersToRole(ctx, 21, &models.BatchAssignUsersToRoleRequest{UserIds: []string{"3"}, ExpiresAt: &past}); !IsUserRoleExpiryInPast(err) {
+		t.Fatalf("expiry in the past should be rejected, got %v", err)
+	}
+}
+
+func TestSweepExpiredUserRoles(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	for _, userID := range []int{1, 2, 3} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "operator"})
+	now := time.Now()
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 100, "user_id": 1, "role_id": 21, "expires_at": now.Add(-48 * time.Hour)})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 200, "user_id": 2, "role_id": 21, "expires_at": now.Add(-time.Hour)})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 300, "user_id": 3, "role_id": 21})
+
+	ctx := context.Background()
+	removed, err := sweepExpiredUserRoles(ctx, now.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("sweep failed: %v", err)
+	}
+	if removed != 1 {
+		t.Fatalf("expected 1 removed assignment, got %d", removed)
+	}
+	// 物理删除，跳过软删除也查询不到
+	if n := client.UserRole.Query().Where(userrole.ID(100)).CountX(mixins.SkipSoftDelete(ctx)); n != 0 {
+		t.Fatal("long-expired assignment should be hard-deleted")
+	}
+	if n := client.UserRole.Query().CountX(ctx); n != 2 {
+		t.Fatalf("recently expired and permanent assignments should be kept, got %d", n)
+	}
+}
@@ -0,0 +1,156 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	entlogging "go-backend/database/ent/logging"
+	"go-backend/database/ent/user"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/messaging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// UserStatusManagePermission 修改用户账号状态（停用/启用）所需的权限
+const UserStatusManagePermission = "user.status.manage"
+
+// AccountSuspendedTopic 账号被停用时推送给用户的WebSocket主题，
+// 连接使用的令牌签发时间早于 revokedBefore 的客户端应断开连接
+const AccountSuspendedTopic = "auth/account/suspended"
+
+// errInvalidUserStatus 用户状态不合法
+const errInvalidUserStatus = "invalid user status"
+
+// IsInvalidUserStatus 判断错误是否为用户状态不合法
+func IsInvalidUserStatus(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errInvalidUserStatus)
+}
+
+// checkUserActive 检查用户是否可以登录或刷新令牌，inactive（停用）和 banned（封禁）的用户都不允许
+func checkUserActive(u *ent.User) error {
+	switch u.Status {
+	case user.StatusActive:
+		return nil
+	case user.StatusBanned:
+		return fmt.Errorf("用户账号已封禁")
+	default:
+		return fmt.Errorf("用户账号已禁用")
+	}
+}
+
+// SetUserStatus 修改用户账号状态并记录审计日志。
+// 停用（inactive）或封禁（banned）时撤销该用户在所有终端上已签发的令牌，并通知WebSocket层断开该用户的连接；
+// 重新启用后用户需要重新登录
+func (UserFuncs) SetUserStatus(ctx context.Context, userID uint64, status string, reason string) error {
+	var client redis.Cmdable
+	if caching.Client != nil {
+		client = caching.Client
+	}
+	revokedBefore, err := setUserStatus(ctx, client, userID, status, reason)
+	if err != nil {
+		return err
+	}
+	if revokedBefore.IsZero() || caching.Client == nil {
+		return nil
+	}
+
+	_, err = messaging.Publish(ctx, messaging.MessageStruct{
+		Type: messaging.ServerToUserSocket,
+		Payload: messaging.SocketMessagePayload{
+			UserId: &userID,
+			Topic:  AccountSuspendedTopic,
+			Data: map[string]interface{}{
+				"status":        status,
+				"revokedBefore": revokedBefore.UnixMilli(),
+			},
+		},
+	})
+	if err != nil {
+		logging.Warn("发布账号停用事件失败: %v", err)
+	}
+	return nil
+}
+
+// setUserStatus 更新用户状态、撤销会话并写入审计日志，返回会话的撤销时间点（未撤销时为零值）
+func setUserStatus(ctx context.Context, client redis.Cmdable, userID uint64, status string, reason string) (time.Time, error) {
+	target := user.Status(status)
+	if err := user.StatusValidator(target); err != nil {
+		return time.Time{}, fmt.Errorf("%s: %s", errInvalidUserStatus, status)
+	}
+
+	current, err := database.Client.User.Get(ctx, userID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return time.Time{}, fmt.Errorf("user not found")
+		}
+		return time.Time{}, err
+	}
+
+	if current.Status != target {
+		if err := current.Update().SetStatus(target).Exec(ctx); err != nil {
+			return time.Time{}, fmt.Errorf("更新用户状态失败: %w", err)
+		}
+	}
+
+	var revokedBefore time.Time
+	if target != user.StatusActive {
+		revokedBefore = time.Now()
+		if err := revokeUserSessions(ctx, client, userID, revokedBefore, 0); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	// 审计日志写入失败不回滚状态变更
+	err = database.Client.Logging.Create().
+		SetLevel(entlogging.LevelInfo).
+		SetType(entlogging.TypeManul).
+		SetMessage(fmt.Sprintf("用户 %d 的账号状态由 %s 修改为 %s", userID, current.Status, target)).
+		SetData(map[string]any{
+			"userId": userID,
+			"from":   string(current.Status),
+			"to":     string(target),
+			"reason": reason,
+		}).
+		Exec(ctx)
+	if err != nil {
+		logging.Warn("记录用户状态变更审计日志失败: %v", err)
+	}
+	return revokedBefore, nil
+}
+
+// revokeUserSessions 撤销用户在所有终端上签发于 before 之前的令牌，并关闭其未退出的登录记录。
+// 撤销记录保留所有终端中最长的refreshToken有效期；未启用 Redis 时只依赖登录和刷新时的状态检查。
+// keepDeviceID 非0时不关闭该终端的登录记录（当前会话仍在使用）
+func revokeUserSessions(ctx context.Context, client redis.Cmdable, userID uint64, before time.Time, keepDeviceID uint64) error {
+	devices, err := database.Client.ClientDevice.Query().All(ctx)
+	if err != nil {
+		return fmt.Errorf("查询终端失败: %w", err)
+	}
+
+	var ttl time.Duration
+	for _, device := range devices {
+		if expiry := time.Duration(device.RefreshTokenExpiry) * time.Millisecond; expiry > ttl {
+			ttl = expiry
+		}
+		if device.ID == keepDeviceID {
+			continue
+		}
+		if _, err := closeDeviceLoginRecords(ctx, userID, device.ID, before); err != nil {
+			logging.Warn("关闭被停用用户的登录记录失败: %v", err)
+		}
+	}
+
+	if client == nil {
+		return nil
+	}
+	if err := sessionPolicy.revokeUser(ctx, client, userID, before, ttl); err != nil {
+		return fmt.Errorf("撤销用户会话失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/user"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/jwt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCheckUserActive(t *testing.T) {
+	if err := checkUserActive(&ent.User{Status: user.StatusActive}); err != nil {
+		t.Fatalf("active user should pass: %v", err)
+	}
+	for _, status := range []user.Status{user.StatusInactive, user.StatusBanned} {
+		if err := checkUserActive(&ent.User{Status: status}); err == nil {
+			t.Fatalf("%s user should not be able to log in", status)
+		}
+	}
+}
+
+func TestSuspendedUserCannotRefresh(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	_, cmdable := newIPGuardTestClient(t)
+	redisClient := cmdable.(*redis.Client)
+	previousCache := caching.Client
+	caching.Client = redisClient
+	t.Cleanup(func() { caching.Client = previousCache })
+
+	if err := jwt.InitializeService(&configs.JWTConfig{SecretKey: "test-secret"}); err != nil {
+		t.Fatalf("init jwt failed: %v", err)
+	}
+
+	insertTestRow(t, db, "sys_users", map[string]any{"id": 1, "name": "alice", "status": "active"})
+	insertTestRow(t, db, "sys_clients", map[string]any{"id": 5, "name": "web", "access_token_expiry": 60000, "refresh_token_expiry": 3600000})
+	ctx := context.Background()
+
+	refreshToken, err := jwt.GenerateRefreshToken(1, 5, time.Hour, false)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	time.Sleep(2 * time.Millisecond)
+
+	if _, err := setUserStatus(ctx, redisClient, 1, "disabled", "typo"); !IsInvalidUserStatus(err) {
+		t.Fatalf("expected invalid status error, got %v", err)
+	}
+	if _, err := setUserStatus(ctx, redisClient, 2, "inactive", ""); err == nil || err.Error() != "user not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	revokedBefore, err := setUserStatus(ctx, redisClient, 1, "inactive", "abuse report")
+	if err != nil {
+		t.Fatalf("suspend failed: %v", err)
+	}
+	if revokedBefore.IsZero() || client.User.GetX(ctx, 1).Status != user.StatusInactive {
+		t.Fatal("user should be suspended and sessions revoked")
+	}
+
+	// 已签发的令牌被撤销
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", refreshToken); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("refresh with a revoked token should fail, got %v", err)
+	}
+	claims, err := jwt.ValidateToken(refreshToken)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if err := CheckSessionRevoked(ctx, claims); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("access check should reject tokens issued before suspension, got %v", err)
+	}
+
+	// 停用后签发的令牌（例如在撤销记录写入前登录）也因用户状态无法刷新
+	time.Sleep(2 * time.Millisecond)
+	lateToken, err := jwt.GenerateRefreshToken(1, 5, time.Hour, false)
+	if err != nil {
+		t.Fatalf("generate refresh token failed: %v", err)
+	}
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", lateToken); err == nil || err.Error() != "用户账号已禁用" {
+		t.Fatalf("suspended user should not refresh, got %v", err)
+	}
+
+	// 状态变更写入审计日志
+	if n := client.Logging.Query().CountX(ctx); n != 1 {
+		t.Fatalf("expected one audit log entry, got %d", n)
+	}
+	if entry := client.Logging.Query().OnlyX(ctx); entry.Data["reason"] != "abuse report" || entry.Data["to"] != "inactive" {
+		t.Fatalf("unexpected audit log: %+v", entry.Data)
+	}
+
+	// 重新启用后新签发的令牌可以刷新，停用前的令牌仍然无效
+	if _, err := setUserStatus(ctx, redisClient, 1, "active", "appeal accepted"); err != nil {
+		t.Fatalf("activate failed: %v", err)
+	}
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", lateToken); err != nil {
+		t.Fatalf("reactivated user should refresh new tokens: %v", err)
+	}
+	if _, err := (AuthFuncs{}).RefreshToken(ctx, "", refreshToken); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("tokens issued before suspension should stay revoked, got %v", err)
+	}
+}
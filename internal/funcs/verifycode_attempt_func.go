@@ -0,0 +1,149 @@
+package funcs
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/verifycode"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrVerifyCodeInvalid 验证码错误，或该用途下没有可用的验证码（未发送、已使用）
+	ErrVerifyCodeInvalid = errors.New("验证码错误")
+	// ErrVerifyCodeExpired 验证码已过期
+	ErrVerifyCodeExpired = errors.New("验证码已过期，请重新获取")
+	// ErrVerifyCodeTooManyAttempts 验证码错误次数过多，已作废
+	ErrVerifyCodeTooManyAttempts = errors.New("验证码错误次数过多，请重新获取")
+)
+
+// IsVerifyCodeRejected 判断错误是否为验证码校验未通过（错误、过期或次数过多），而非查询失败等内部错误
+func IsVerifyCodeRejected(err error) bool {
+	return errors.Is(err, ErrVerifyCodeInvalid) ||
+		errors.Is(err, ErrVerifyCodeExpired) ||
+		errors.Is(err, ErrVerifyCodeTooManyAttempts)
+}
+
+// verifyCodeKeys 验证码的缓存键构建器
+var verifyCodeKeys = caching.NewKeyBuilder("verify_code")
+
+// verifyCodeAttemptPolicy 验证码尝试次数限制
+// 错误次数记录在 Redis 中，过期时间与验证码一致；Redis 不可用时不限制次数，仅保证单次使用
+type verifyCodeAttemptPolicy struct {
+	maxAttempts int64 // 同一验证码允许的错误次数，达到后验证码作废
+}
+
+// verifyCodePolicy 全局验证码尝试次数限制，启动时由配置覆盖
+var verifyCodePolicy = &verifyCodeAttemptPolicy{maxAttempts: 5}
+
+// InitVerifyCodePolicy 根据配置初始化验证码尝试次数限制，配置无效时返回错误且不做修改
+func InitVerifyCodePolicy(config *configs.VerifyCodeConfig) error {
+	if config.MaxAttempts <= 0 {
+		return fmt.Errorf("max_attempts must be greater than 0")
+	}
+	verifyCodePolicy = &verifyCodeAttemptPolicy{maxAttempts: int64(config.MaxAttempts)}
+	return nil
+}
+
+// verify 校验验证码，attempts 为nil时不统计错误次数
+func (p *verifyCodeAttemptPolicy) verify(ctx context.Context, client *ent.Client, attempts redis.Cmdable, senderType, purpose, identifier, code string) error {
+	// 只取该组合下最近一次发送成功的验证码，不按验证码值匹配，以便区分错误、过期和已作废
+	record, err := client.VerifyCode.Query().
+		Where(
+			verifycode.Identifier(identifier),
+			verifycode.SenderTypeEQ(verifycode.SenderType(senderType)),
+			verifycode.SendFor(purpose),
+			verifycode.SendSuccess(true),
+		).
+		Order(ent.Desc(verifycode.FieldCreateTime), ent.Desc(verifycode.FieldID)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return ErrVerifyCodeInvalid
+		}
+		return fmt.Errorf("验证验证码失败: %w", err)
+	}
+
+	now := time.Now()
+	if !now.Before(record.ExpiresAt) {
+		return ErrVerifyCodeExpired
+	}
+
+	attemptsKey := verifyCodeKeys.Key("attempts", record.ID)
+	if record.UsedAt != nil {
+		// 已使用或已因错误次数过多作废
+		if p.exhausted(ctx, attempts, attemptsKey) {
+			return ErrVerifyCodeTooManyAttempts
+		}
+		return ErrVerifyCodeInvalid
+	}
+
+	if subtle.ConstantTimeCompare([]byte(record.Code), []byte(code)) != 1 {
+		if p.recordFailure(ctx, attempts, attemptsKey, record.ExpiresAt) {
+			if err := markVerifyCodeUsed(ctx, client, record.ID, now); err != nil {
+				logging.Warn("作废验证码 %d 失败: %v", record.ID, err)
+			}
+			return ErrVerifyCodeTooManyAttempts
+		}
+		return ErrVerifyCodeInvalid
+	}
+
+	// 条件更新保证并发请求中只有一个能使用该验证码
+	affected, err := client.VerifyCode.Update().
+		Where(verifycode.ID(record.ID), verifycode.UsedAtIsNil()).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("更新验证码使用状态失败: %w", err)
+	}
+	if affected == 0 {
+		return ErrVerifyCodeInvalid
+	}
+	return nil
+}
+
+// exhausted 判断验证码的错误次数是否已达到上限
+func (p *verifyCodeAttemptPolicy) exhausted(ctx context.Context, attempts redis.Cmdable, key string) bool {
+	if attempts == nil {
+		return false
+	}
+	count, err := attempts.Get(ctx, key).Int64()
+	if err != nil {
+		if err != redis.Nil {
+			logging.Warn("读取验证码错误次数失败: %v", err)
+		}
+		return false
+	}
+	return count >= p.maxAttempts
+}
+
+// recordFailure 记录一次错误，计数与验证码同时过期，达到上限时返回 true
+func (p *verifyCodeAttemptPolicy) recordFailure(ctx context.Context, attempts redis.Cmdable, key string, expiresAt time.Time) bool {
+	if attempts == nil {
+		return false
+	}
+	pipe := attempts.TxPipeline()
+	countCmd := pipe.Incr(ctx, key)
+	pipe.ExpireAt(ctx, key, expiresAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logging.Warn("记录验证码错误次数失败: %v", err)
+		return false
+	}
+	return countCmd.Val() >= p.maxAttempts
+}
+
+// markVerifyCodeUsed 将验证码标记为已使用，使其不能再通过校验
+func markVerifyCodeUsed(ctx context.Context, client *ent.Client, id uint64, now time.Time) error {
+	return client.VerifyCode.Update().
+		Where(verifycode.ID(id), verifycode.UsedAtIsNil()).
+		SetUsedAt(now).
+		Exec(ctx)
+}
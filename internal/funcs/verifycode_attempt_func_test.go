@@ -0,0 +1,101 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/verifycode"
+)
+
+// createTestVerifyCode 创建一条已发送成功的验证码记录
+func createTestVerifyCode(t *testing.T, client *ent.Client, purpose, identifier, code string, expiresAt time.Time) *ent.VerifyCode {
+	t.Helper()
+	record, err := client.VerifyCode.Create().
+		SetCode(code).
+		SetIdentifier(identifier).
+		SetSenderType(verifycode.SenderTypeEmail).
+		SetSendFor(purpose).
+		SetExpiresAt(expiresAt).
+		SetSendSuccess(true).
+		SetSendAt(time.Now()).
+		Save(context.Background())
+	if err != nil {
+		t.Fatalf("failed to create verify code: %v", err)
+	}
+	return record
+}
+
+func TestVerifyCodeSingleUse(t *testing.T) {
+	client, _ := newWorkflowDeleteTestClient(t)
+	_, attempts := newIPGuardTestClient(t)
+	policy := &verifyCodeAttemptPolicy{maxAttempts: 3}
+	ctx := context.Background()
+	createTestVerifyCode(t, client, PurposeLogin, "a@example.com", "123456", time.Now().Add(time.Minute))
+
+	if err := policy.verify(ctx, client, attempts, "email", PurposeLogin, "a@example.com", "123456"); err != nil {
+		t.Fatalf("first use should succeed: %v", err)
+	}
+	if err := policy.verify(ctx, client, attempts, "email", PurposeLogin, "a@example.com", "123456"); !errors.Is(err, ErrVerifyCodeInvalid) {
+		t.Fatalf("reusing a code should be rejected, got %v", err)
+	}
+
+	createTestVerifyCode(t, client, PurposeLogin, "b@example.com", "654321", time.Now().Add(-time.Second))
+	if err := policy.verify(ctx, client, attempts, "email", PurposeLogin, "b@example.com", "654321"); !errors.Is(err, ErrVerifyCodeExpired) {
+		t.Fatalf("expected expired error, got %v", err)
+	}
+}
+
+func TestVerifyCodeTooManyAttempts(t *testing.T) {
+	client, _ := newWorkflowDeleteTestClient(t)
+	server, attempts := newIPGuardTestClient(t)
+	policy := &verifyCodeAttemptPolicy{maxAttempts: 3}
+	ctx := context.Background()
+	record := createTestVerifyCode(t, client, PurposeRegister, "a@example.com", "123456", time.Now().Add(time.Minute))
+
+	for i := 0; i < 2; i++ {
+		if err := policy.verify(ctx, client, attempts, "email", PurposeRegister, "a@example.com", "000000"); !errors.Is(err, ErrVerifyCodeInvalid) {
+			t.Fatalf("attempt %d: expected wrong code error, got %v", i, err)
+		}
+	}
+	if ttl := server.TTL(verifyCodeKeys.Key("attempts", record.ID)); ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("attempt counter should expire with the code, got ttl %v", ttl)
+	}
+	if err := policy.verify(ctx, client, attempts, "email", PurposeRegister, "a@example.com", "000000"); !errors.Is(err, ErrVerifyCodeTooManyAttempts) {
+		t.Fatalf("expected too many attempts error, got %v", err)
+	}
+	// 作废后正确的验证码也不能通过
+	if err := policy.verify(ctx, client, attempts, "email", PurposeRegister, "a@example.com", "123456"); !errors.Is(err, ErrVerifyCodeTooManyAttempts) {
+		t.Fatalf("burned code should stay rejected, got %v", err)
+	}
+
+	// 重新获取的验证码重新计数
+	createTestVerifyCode(t, client, PurposeRegister, "a@example.com", "222222", time.Now().Add(time.Minute))
+	if err := policy.verify(ctx, client, attempts, "email", PurposeRegister, "a@example.com", "222222"); err != nil {
+		t.Fatalf("a new code should be usable: %v", err)
+	}
+}
+
+func TestVerifyCodeBoundToPurpose(t *testing.T) {
+	client, _ := newWorkflowDeleteTestClient(t)
+	_, attempts := newIPGuardTestClient(t)
+	policy := &verifyCodeAttemptPolicy{maxAttempts: 3}
+	ctx := context.Background()
+	createTestVerifyCode(t, client, PurposeLogin, "a@example.com", "123456", time.Now().Add(time.Minute))
+
+	if err := policy.verify(ctx, client, attempts, "email", PurposeResetPassword, "a@example.com", "123456"); !errors.Is(err, ErrVerifyCodeInvalid) {
+		t.Fatalf("a login code should not reset the password, got %v", err)
+	}
+	if err := policy.verify(ctx, client, attempts, "phone", PurposeLogin, "a@example.com", "123456"); !errors.Is(err, ErrVerifyCodeInvalid) {
+		t.Fatalf("a code should be bound to its sender type, got %v", err)
+	}
+	if err := policy.verify(ctx, client, attempts, "email", PurposeLogin, "b@example.com", "123456"); !errors.Is(err, ErrVerifyCodeInvalid) {
+		t.Fatalf("a code should be bound to its identifier, got %v", err)
+	}
+	// 误用不消耗原用途的验证码
+	if err := policy.verify(ctx, client, attempts, "email", PurposeLogin, "a@example.com", "123456"); err != nil {
+		t.Fatalf("the code should still be valid for its own purpose: %v", err)
+	}
+}
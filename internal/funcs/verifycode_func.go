@@ -5,16 +5,28 @@ import (
 	"fmt"
 	"time"
 
-	"go-backend/database/ent"
 	"go-backend/database/ent/verifycode"
 	vcpkg "go-backend/internal/funcs/verifycode"
+	"go-backend/pkg/caching"
 	"go-backend/pkg/database"
+
+	"github.com/redis/go-redis/v9"
 )
 
 type VerifyCodeFuncs struct{}
 
 // SendVerificationCode 发送验证码通用接口
 func (VerifyCodeFuncs) SendVerificationCode(ctx context.Context, senderType, purpose, identifier, deviceCode string) error {
+	client, err := ClientDeviceFuncs{}.GetClientDeviceByCodeInner(ctx, deviceCode)
+	if err != nil {
+		return fmt.Errorf("创建验证码记录失败: %w", err)
+	}
+
+	return VerifyCodeFuncs{}.sendVerificationCode(ctx, senderType, purpose, identifier, &client.ID)
+}
+
+// sendVerificationCode 发送验证码，clientID为空时不关联请求设备
+func (VerifyCodeFuncs) sendVerificationCode(ctx context.Context, senderType, purpose, identifier string, clientID *uint64) error {
 	// 检查30秒内是否已发送过验证码
 	thirtySecondsAgo := time.Now().Add(-30 * time.Second)
 	exists, err := database.Client.VerifyCode.Query().
@@ -48,19 +60,13 @@ func (VerifyCodeFuncs) SendVerificationCode(ctx context.Context, senderType, pur
 	now := time.Now()
 	expiresAt := now.Add(15 * time.Minute) // 15分钟过期
 
-	client, err := ClientDeviceFuncs{}.GetClientDeviceByCodeInner(ctx, deviceCode)
-
-	if err != nil {
-		return fmt.Errorf("创建验证码记录失败: %w", err)
-	}
-
 	verifyCodeRecord, err := database.Client.VerifyCode.Create().
 		SetCode(code).
 		SetIdentifier(identifier).
 		SetSenderType(verifycode.SenderType(senderType)).
 		SetSendFor(purpose).
 		SetExpiresAt(expiresAt).
-		SetClientID(client.ID).
+		SetNillableClientID(clientID).
 		SetSendSuccess(false).
 		Save(ctx)
 
@@ -89,39 +95,12 @@ func (VerifyCodeFuncs) SendVerificationCode(ctx context.Context, senderType, pur
 }
 
 // VerifyCode 验证验证码通用接口
+// 验证码与 (发送方式, 用途, 标识符) 绑定，只校验该组合下最近一次发送成功的验证码；
+// 验证成功后立即失效，错误次数达到上限后作废，需要重新获取
 func (VerifyCodeFuncs) VerifyCode(ctx context.Context, senderType, purpose, identifier, code string) error {
-	// 查询15分钟内有效的验证码
-	fifteenMinutesAgo := time.Now().Add(-15 * time.Minute)
-	now := time.Now()
-
-	verifyCodeRecord, err := database.Client.VerifyCode.Query().
-		Where(
-			verifycode.Identifier(identifier),
-			verifycode.SenderTypeEQ(verifycode.SenderType(senderType)),
-			verifycode.SendFor(purpose),
-			verifycode.Code(code),
-			verifycode.SendSuccess(true),
-			verifycode.ExpiresAtGTE(now),
-			verifycode.CreateTimeGTE(fifteenMinutesAgo),
-			verifycode.UsedAtIsNil(),
-		).
-		First(ctx)
-
-	if err != nil {
-		if ent.IsNotFound(err) {
-			return fmt.Errorf("验证码无效或已过期")
-		}
-		return fmt.Errorf("验证验证码失败: %w", err)
+	var attempts redis.Cmdable
+	if caching.Client != nil {
+		attempts = caching.Client
 	}
-
-	// 标记验证码已使用
-	_, err = verifyCodeRecord.Update().
-		SetUsedAt(now).
-		Save(ctx)
-
-	if err != nil {
-		return fmt.Errorf("更新验证码使用状态失败: %w", err)
-	}
-
-	return nil
+	return verifyCodePolicy.verify(ctx, database.Client, attempts, senderType, purpose, identifier, code)
 }
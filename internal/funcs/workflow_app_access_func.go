@@ -0,0 +1,362 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/predicate"
+	"go-backend/database/ent/user"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowversion"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// 工作流应用的所有权与共享：
+//
+// 应用创建（包括克隆和从模板实例化）时记录创建者为所有者。普通用户只能看到自己拥有的、被共享给自己的，
+// 以及引入所有权之前创建的（没有所有者的）应用；拥有 workflow:admin 权限的用户不受限制。
+// 所有者可以将应用的只读（read）或编辑（edit）权限共享给其他用户，共享和取消共享只能由所有者或管理员操作。
+
+// WorkflowAdminPermission 可以查看和管理所有工作流应用的权限
+const WorkflowAdminPermission = "workflow:admin"
+
+const (
+	WorkflowAccessRead  = "read"
+	WorkflowAccessEdit  = "edit"
+	WorkflowAccessOwner = "owner"
+)
+
+// workflowAccessRank 访问级别的高低，用于判断是否满足要求
+var workflowAccessRank = map[string]int{
+	WorkflowAccessRead:  1,
+	WorkflowAccessEdit:  2,
+	WorkflowAccessOwner: 3,
+}
+
+// errWorkflowAccessDenied 当前用户对应用没有所需的访问权限
+var errWorkflowAccessDenied = errors.New("workflow application access denied")
+
+// IsWorkflowAccessDenied 判断错误是否为没有应用访问权限
+func IsWorkflowAccessDenied(err error) bool {
+	return errors.Is(err, errWorkflowAccessDenied)
+}
+
+// WorkflowAccessScope 当前用户对工作流应用的可见范围
+type WorkflowAccessScope struct {
+	UserID uint64
+	Admin  bool // 管理员可以访问所有应用
+}
+
+// ResolveWorkflowAccessScope 根据用户权限确定可见范围
+func (WorkflowFuncs) ResolveWorkflowAccessScope(ctx context.Context, userID uint64) (WorkflowAccessScope, error) {
+	admin, err := HasAnyPermissions(ctx, userID, []string{WorkflowAdminPermission})
+	if err != nil {
+		return WorkflowAccessScope{}, fmt.Errorf("failed to check workflow permissions: %w", err)
+	}
+	return WorkflowAccessScope{UserID: userID, Admin: admin}, nil
+}
+
+// visibleApplications 返回限制应用可见范围的查询条件，管理员返回 nil
+func (s WorkflowAccessScope) visibleApplications() predicate.WorkflowApplication {
+	if s.Admin {
+		return nil
+	}
+	return workflowapplication.Or(
+		workflowapplication.OwnerIDIsNil(),
+		workflowapplication.OwnerID(s.UserID),
+		workflowapplication.HasSharesWith(workflowapplicationshare.UserID(s.UserID)),
+	)
+}
+
+// applicationAccess 计算用户对应用的访问级别，没有访问权限时返回空字符串
+func (s WorkflowAccessScope) applicationAccess(ctx context.Context, app *ent.WorkflowApplication) (string, error) {
+	if s.Admin || app.OwnerID == 0 || app.OwnerID == s.UserID {
+		return WorkflowAccessOwner, nil
+	}
+
+	share, err := database.Client.WorkflowApplicationShare.Query().
+		Where(
+			workflowapplicationshare.ApplicationID(app.ID),
+			workflowapplicationshare.UserID(s.UserID),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	return share.Access.String(), nil
+}
+
+// CheckWorkflowApplicationAccess 检查用户对应用至少拥有 required 级别的访问权限
+// 对用户不可见的应用与不存在的应用一样返回 not found，避免暴露应用是否存在
+func (WorkflowFuncs) CheckWorkflowApplicationAccess(ctx context.Context, scope WorkflowAccessScope, applicationID uint64, required string) error {
+	app, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Select(workflowapplication.FieldOwnerID).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("workflow application not found")
+		}
+		return err
+	}
+
+	access, err := scope.applicationAccess(ctx, app)
+	if err != nil {
+		return err
+	}
+	if access == "" {
+		return fmt.Errorf("workflow application not found")
+	}
+	if workflowAccessRank[access] < workflowAccessRank[required] {
+		return fmt.Errorf("%w: %s access required", errWorkflowAccessDenied, required)
+	}
+	return nil
+}
+
+// ShareWorkflowApplication 将应用共享给其他用户，已共享时更新访问级别；只有所有者和管理员可以共享
+func (WorkflowFuncs) ShareWorkflowApplication(ctx context.Context, scope WorkflowAccessScope, applicationID, userID uint64, access string) ([]*models.WorkflowApplicationShareResponse, error) {
+	if access != WorkflowAccessRead && access != WorkflowAccessEdit {
+		return nil, fmt.Errorf("%s: unknown access %q", errInvalidWorkflowShare, access)
+	}
+	app, err := checkWorkflowShareOwner(ctx, scope, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == app.OwnerID {
+		return nil, fmt.Errorf("%s: user %d already owns the application", errInvalidWorkflowShare, userID)
+	}
+	exists, err := database.Client.User.Query().Where(user.ID(userID)).Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: user %d not found", errInvalidWorkflowShare, userID)
+	}
+
+	updated, err := database.Client.WorkflowApplicationShare.Update().
+		Where(
+			workflowapplicationshare.ApplicationID(applicationID),
+			workflowapplicationshare.UserID(userID),
+		).
+		SetAccess(workflowapplicationshare.Access(access)).
+		Save(ctx)
+	if err == nil && updated == 0 {
+		err = database.Client.WorkflowApplicationShare.Create().
+			SetApplicationID(applicationID).
+			SetUserID(userID).
+			SetAccess(workflowapplicationshare.Access(access)).
+			Exec(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to share workflow application: %w", err)
+	}
+
+	return listWorkflowApplicationShares(ctx, applicationID)
+}
+
+// UnshareWorkflowApplication 取消用户对应用的共享；只有所有者和管理员可以取消
+func (WorkflowFuncs) UnshareWorkflowApplication(ctx context.Context, scope WorkflowAccessScope, applicationID, userID uint64) ([]*models.WorkflowApplicationShareResponse, error) {
+	if _, err := checkWorkflowShareOwner(ctx, scope, applicationID); err != nil {
+		return nil, err
+	}
+
+	_, err := database.Client.WorkflowApplicationShare.Delete().
+		Where(
+			workflowapplicationshare.ApplicationID(applicationID),
+			workflowapplicationshare.UserID(userID),
+		).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unshare workflow application: %w", err)
+	}
+
+	return listWorkflowApplicationShares(ctx, applicationID)
+}
+
+// errInvalidWorkflowShare 共享请求不合法时的错误前缀
+const errInvalidWorkflowShare = "invalid workflow share"
+
+// checkWorkflowShareOwner 确认当前用户是应用的所有者或管理员
+func checkWorkflowShareOwner(ctx context.Context, scope WorkflowAccessScope, applicationID uint64) (*ent.WorkflowApplication, error) {
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scope, applicationID, WorkflowAccessOwner); err != nil {
+		return nil, err
+	}
+	app, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Select(workflowapplication.FieldOwnerID).
+		Only(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 没有所有者的应用不能共享，否则共享记录无法体现访问限制
+	if app.OwnerID == 0 {
+		return nil, fmt.Errorf("%s: application %d has no owner", errInvalidWorkflowShare, applicationID)
+	}
+	return app, nil
+}
+
+// listWorkflowApplicationShares 按用户ID列出应用的共享记录
+func listWorkflowApplicationShares(ctx context.Context, applicationID uint64) ([]*models.WorkflowApplicationShareResponse, error) {
+	shares, err := database.Client.WorkflowApplicationShare.Query().
+		Where(workflowapplicationshare.ApplicationID(applicationID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(shares, func(i, j int) bool { return shares[i].UserID < shares[j].UserID })
+
+	result := make([]*models.WorkflowApplicationShareResponse, 0, len(shares))
+	for _, share := range shares {
+		result = append(result, &models.WorkflowApplicationShareResponse{
+			UserID:     utils.Uint64ToString(share.UserID),
+			Access:     share.Access.String(),
+			UpdateTime: utils.FormatDateTime(share.UpdateTime),
+		})
+	}
+	return result, nil
+}
+
+// 通过节点、边等资源ID操作时，按资源所属的应用检查访问权限
+const (
+	WorkflowResourceNode          = "node"
+	WorkflowResourceEdge          = "edge"
+	WorkflowResourceVersion       = "version"
+	WorkflowResourceSchedule      = "schedule"
+	WorkflowResourceNodeExecution = "node execution"
+)
+
+// CheckWorkflowResourceAccess 检查用户对资源所属的应用至少拥有 required 级别的访问权限
+// 资源不存在或所属应用对用户不可见时都返回 "workflow <resource> not found"，避免暴露其他用户的资源
+func (WorkflowFuncs) CheckWorkflowResourceAccess(ctx context.Context, scope WorkflowAccessScope, resource string, required string, ids ...uint64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	applicationIDs, err := workflowResourceApplicationIDs(ctx, resource, ids)
+	if err != nil {
+		return err
+	}
+	return checkResolvedApplicationsAccess(ctx, scope, resource, required, applicationIDs)
+}
+
+// CheckWorkflowExecutionAccess 检查用户对执行所属的应用至少拥有 required 级别的访问权限
+func (WorkflowFuncs) CheckWorkflowExecutionAccess(ctx context.Context, scope WorkflowAccessScope, executionID string, required string) error {
+	execution, err := database.Client.WorkflowExecution.Query().
+		Where(workflowexecution.ExecutionIDEQ(executionID)).
+		Select(workflowexecution.FieldApplicationID).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("workflow execution not found")
+		}
+		return err
+	}
+	return checkResolvedApplicationsAccess(ctx, scope, "execution", required, []uint64{execution.ApplicationID})
+}
+
+// checkResolvedApplicationsAccess 逐个检查资源所属的应用，应用不可见时按资源不存在处理
+func checkResolvedApplicationsAccess(ctx context.Context, scope WorkflowAccessScope, resource string, required string, applicationIDs []uint64) error {
+	for _, applicationID := range applicationIDs {
+		err := WorkflowFuncs{}.CheckWorkflowApplicationAccess(ctx, scope, applicationID, required)
+		if err != nil {
+			if err.Error() == "workflow application not found" {
+				return fmt.Errorf("workflow %s not found", resource)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// workflowResourceApplicationIDs 返回资源所属的应用ID（去重），任一资源不存在时返回 not found
+func workflowResourceApplicationIDs(ctx context.Context, resource string, ids []uint64) ([]uint64, error) {
+	ids = uniqueUint64s(ids)
+	var applicationIDs []uint64
+	var found int
+	var err error
+	switch resource {
+	case WorkflowResourceNode:
+		var nodes []*ent.WorkflowNode
+		nodes, err = database.Client.WorkflowNode.Query().
+			Where(workflownode.IDIn(ids...)).
+			Select(workflownode.FieldApplicationID).
+			All(ctx)
+		for _, node := range nodes {
+			applicationIDs = append(applicationIDs, node.ApplicationID)
+		}
+		found = len(nodes)
+	case WorkflowResourceEdge:
+		var edges []*ent.WorkflowEdge
+		edges, err = database.Client.WorkflowEdge.Query().
+			Where(workflowedge.IDIn(ids...)).
+			Select(workflowedge.FieldApplicationID).
+			All(ctx)
+		for _, edge := range edges {
+			applicationIDs = append(applicationIDs, edge.ApplicationID)
+		}
+		found = len(edges)
+	case WorkflowResourceVersion:
+		var versions []*ent.WorkflowVersion
+		versions, err = database.Client.WorkflowVersion.Query().
+			Where(workflowversion.IDIn(ids...)).
+			Select(workflowversion.FieldApplicationID).
+			All(ctx)
+		for _, version := range versions {
+			applicationIDs = append(applicationIDs, version.ApplicationID)
+		}
+		found = len(versions)
+	case WorkflowResourceSchedule:
+		var schedules []*ent.WorkflowSchedule
+		schedules, err = database.Client.WorkflowSchedule.Query().
+			Where(workflowschedule.IDIn(ids...)).
+			Select(workflowschedule.FieldApplicationID).
+			All(ctx)
+		for _, schedule := range schedules {
+			applicationIDs = append(applicationIDs, schedule.ApplicationID)
+		}
+		found = len(schedules)
+	case WorkflowResourceNodeExecution:
+		var nodeExecutions []*ent.WorkflowNodeExecution
+		nodeExecutions, err = database.Client.WorkflowNodeExecution.Query().
+			Where(workflownodeexecution.IDIn(ids...)).
+			Select(workflownodeexecution.FieldExecutionID).
+			All(ctx)
+		found = len(nodeExecutions)
+		if err == nil && found > 0 {
+			executionIDs := make([]uint64, 0, len(nodeExecutions))
+			for _, nodeExecution := range nodeExecutions {
+				executionIDs = append(executionIDs, nodeExecution.ExecutionID)
+			}
+			var executions []*ent.WorkflowExecution
+			executions, err = database.Client.WorkflowExecution.Query().
+				Where(workflowexecution.IDIn(uniqueUint64s(executionIDs)...)).
+				Select(workflowexecution.FieldApplicationID).
+				All(ctx)
+			for _, execution := range executions {
+				applicationIDs = append(applicationIDs, execution.ApplicationID)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown workflow resource %q", resource)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if found != len(ids) {
+		return nil, fmt.Errorf("workflow %s not found", resource)
+	}
+	return uniqueUint64s(applicationIDs), nil
+}
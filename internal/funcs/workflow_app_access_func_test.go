@@ -0,0 +1,236 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func listVisibleApplicationNames(t *testing.T, scope WorkflowAccessScope) []string {
+	t.Helper()
+	page, err := WorkflowFuncs{}.GetWorkflowApplicationsWithPagination(context.Background(), scope, &models.PageWorkflowApplicationRequest{
+		PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 10, OrderBy: "name", Order: "asc"},
+	})
+	if err != nil {
+		t.Fatalf("list applications failed: %v", err)
+	}
+	names := make([]string, 0, len(page.Data))
+	for _, app := range page.Data {
+		names = append(names, app.Name)
+	}
+	return names
+}
+
+func TestWorkflowApplicationOwnershipAndSharing(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	// 用户1、2为普通用户，用户3拥有 workflow:admin 权限
+	for _, userID := range []int{1, 2, 3} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	insertTestRow(t, db, "sys_permissions", map[string]any{"id": 11, "name": "workflow-admin", "action": WorkflowAdminPermission})
+	insertTestRow(t, db, "sys_roles", map[string]any{"id": 21, "name": "admin"})
+	insertTestRow(t, db, "sys_role_permission", map[string]any{"id": 210, "role_id": 21, "permission_id": 11})
+	insertTestRow(t, db, "sys_user_role", map[string]any{"id": 300, "user_id": 3, "role_id": 21})
+
+	// 应用1、2属于用户1，应用3属于用户2，应用4没有所有者
+	for appID, owner := range map[uint64]any{1: 1, 2: 1, 3: 2, 4: nil} {
+		seedWorkflowApplication(t, db, appID)
+		if owner != nil {
+			if _, err := db.Exec("UPDATE workflow_applications SET owner_id = ? WHERE id = ?", owner, appID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	ctx := context.Background()
+	scopes := make(map[uint64]WorkflowAccessScope)
+	for _, userID := range []uint64{1, 2, 3} {
+		scope, err := WorkflowFuncs{}.ResolveWorkflowAccessScope(ctx, userID)
+		if err != nil {
+			t.Fatalf("resolve scope for user %d failed: %v", userID, err)
+		}
+		scopes[userID] = scope
+	}
+	if scopes[1].Admin || !scopes[3].Admin {
+		t.Fatalf("unexpected scopes: %+v", scopes)
+	}
+
+	assertNames := func(scope WorkflowAccessScope, want ...string) {
+		t.Helper()
+		got := listVisibleApplicationNames(t, scope)
+		if len(got) != len(want) {
+			t.Fatalf("user %d sees %v, want %v", scope.UserID, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("user %d sees %v, want %v", scope.UserID, got, want)
+			}
+		}
+	}
+	assertNames(scopes[1], "app-1", "app-2", "app-4")
+	assertNames(scopes[2], "app-3", "app-4")
+	assertNames(scopes[3], "app-1", "app-2", "app-3", "app-4")
+
+	// 其他用户的应用与不存在的应用一样不可见
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scopes[2], 1, WorkflowAccessRead); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found for unshared application, got %v", err)
+	}
+
+	// 只有所有者或管理员可以共享
+	if _, err := (WorkflowFuncs{}).ShareWorkflowApplication(ctx, scopes[2], 1, 2, WorkflowAccessEdit); err == nil {
+		t.Fatal("expected non-owner share to be rejected")
+	}
+	if _, err := (WorkflowFuncs{}).ShareWorkflowApplication(ctx, scopes[1], 1, 1, WorkflowAccessRead); err == nil {
+		t.Fatal("expected sharing with the owner to be rejected")
+	}
+	if _, err := (WorkflowFuncs{}).ShareWorkflowApplication(ctx, scopes[1], 4, 2, WorkflowAccessRead); err == nil {
+		t.Fatal("expected sharing an ownerless application to be rejected")
+	}
+
+	shares, err := WorkflowFuncs{}.ShareWorkflowApplication(ctx, scopes[1], 1, 2, WorkflowAccessRead)
+	if err != nil {
+		t.Fatalf("share failed: %v", err)
+	}
+	if len(shares) != 1 || shares[0].UserID != "2" || shares[0].Access != WorkflowAccessRead {
+		t.Fatalf("unexpected shares: %+v", shares)
+	}
+	assertNames(scopes[2], "app-1", "app-3", "app-4")
+
+	// 只读共享不能编辑，也不能删除或再次共享
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scopes[2], 1, WorkflowAccessRead); err != nil {
+		t.Fatalf("expected read access, got %v", err)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scopes[2], 1, WorkflowAccessEdit); !IsWorkflowAccessDenied(err) {
+		t.Fatalf("expected edit to be denied, got %v", err)
+	}
+
+	// 再次共享时更新权限而不是新增记录
+	shares, err = WorkflowFuncs{}.ShareWorkflowApplication(ctx, scopes[3], 1, 2, WorkflowAccessEdit)
+	if err != nil {
+		t.Fatalf("admin share failed: %v", err)
+	}
+	if len(shares) != 1 || shares[0].Access != WorkflowAccessEdit {
+		t.Fatalf("unexpected shares after upgrade: %+v", shares)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scopes[2], 1, WorkflowAccessEdit); err != nil {
+		t.Fatalf("expected edit access, got %v", err)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scopes[2], 1, WorkflowAccessOwner); !IsWorkflowAccessDenied(err) {
+		t.Fatalf("expected owner access to be denied, got %v", err)
+	}
+
+	// 取消共享后不再可见
+	shares, err = WorkflowFuncs{}.UnshareWorkflowApplication(ctx, scopes[1], 1, 2)
+	if err != nil || len(shares) != 0 {
+		t.Fatalf("unshare failed: %+v (%v)", shares, err)
+	}
+	assertNames(scopes[2], "app-3", "app-4")
+
+	// 克隆的应用归克隆者所有
+	cloned, err := WorkflowFuncs{}.CloneWorkflowApplication(ctx, 2, 4, "mine")
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if cloned.OwnerID != "2" {
+		t.Fatalf("unexpected clone owner: %q", cloned.OwnerID)
+	}
+	assertNames(scopes[1], "app-1", "app-2", "app-4")
+}
+
+func TestWorkflowResourceAccessFollowsApplication(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	for _, userID := range []int{1, 2} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	// 应用1属于用户1，应用2属于用户2
+	for _, appID := range []uint64{1, 2} {
+		seedWorkflowApplication(t, db, appID)
+		if _, err := db.Exec("UPDATE workflow_applications SET owner_id = ? WHERE id = ?", appID, appID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	insertTestRow(t, db, "workflow_secrets", map[string]any{"id": 16, "application_id": 1, "key": "API_KEY", "value": "encrypted"})
+
+	ctx := context.Background()
+	owner := WorkflowAccessScope{UserID: 1}
+	other := WorkflowAccessScope{UserID: 2}
+
+	// 其他用户的节点、边、版本、调度与不存在的一样
+	for _, tt := range []struct {
+		resource string
+		id       uint64
+	}{
+		{WorkflowResourceNode, 11},
+		{WorkflowResourceEdge, 13},
+		{WorkflowResourceSchedule, 14},
+		{WorkflowResourceVersion, 15},
+	} {
+		if err := (WorkflowFuncs{}).CheckWorkflowResourceAccess(ctx, owner, tt.resource, WorkflowAccessEdit, tt.id); err != nil {
+			t.Fatalf("owner access to %s %d failed: %v", tt.resource, tt.id, err)
+		}
+		err := WorkflowFuncs{}.CheckWorkflowResourceAccess(ctx, other, tt.resource, WorkflowAccessRead, tt.id)
+		if err == nil || err.Error() != "workflow "+tt.resource+" not found" {
+			t.Fatalf("expected non-owner %s access to look not found, got %v", tt.resource, err)
+		}
+	}
+
+	// 批量操作中混入其他用户的节点时整体拒绝
+	if err := (WorkflowFuncs{}).CheckWorkflowResourceAccess(ctx, other, WorkflowResourceNode, WorkflowAccessEdit, 21, 11); err == nil {
+		t.Fatal("expected batch containing another user's node to be rejected")
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowResourceAccess(ctx, other, WorkflowResourceNode, WorkflowAccessEdit, 21, 99); err == nil || err.Error() != "workflow node not found" {
+		t.Fatalf("expected missing node to be not found, got %v", err)
+	}
+
+	// 节点列表只包含可见应用的节点
+	nodes, err := WorkflowFuncs{}.GetAllWorkflowNodes(ctx, other)
+	if err != nil {
+		t.Fatalf("list nodes failed: %v", err)
+	}
+	for _, node := range nodes {
+		if node.ApplicationID != "2" {
+			t.Fatalf("non-owner sees node %s of application %s", node.ID, node.ApplicationID)
+		}
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 visible nodes, got %d", len(nodes))
+	}
+	page, err := WorkflowFuncs{}.GetWorkflowNodesWithPagination(ctx, other, &models.PageWorkflowNodeRequest{
+		PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 10},
+		ApplicationID:     "1",
+	})
+	if err != nil || len(page.Data) != 0 {
+		t.Fatalf("expected no nodes of another user's application, got %+v (%v)", page, err)
+	}
+
+	// 密钥按所属应用检查：未共享时不可见，只读共享可以列出但不能修改
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, other, 1, WorkflowAccessRead); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected secrets of unshared application to be hidden, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).ShareWorkflowApplication(ctx, owner, 1, 2, WorkflowAccessRead); err != nil {
+		t.Fatalf("share failed: %v", err)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, other, 1, WorkflowAccessRead); err != nil {
+		t.Fatalf("expected read access to shared secrets, got %v", err)
+	}
+	secrets, err := WorkflowFuncs{}.ListApplicationSecrets(ctx, 1)
+	if err != nil || len(secrets) != 1 {
+		t.Fatalf("unexpected secrets: %+v (%v)", secrets, err)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, other, 1, WorkflowAccessEdit); !IsWorkflowAccessDenied(err) {
+		t.Fatalf("expected secret changes to be denied for read share, got %v", err)
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowResourceAccess(ctx, other, WorkflowResourceNode, WorkflowAccessEdit, 11); !IsWorkflowAccessDenied(err) {
+		t.Fatalf("expected node edit to be denied for read share, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// diffNodeSide 参与对比的一侧节点及其匹配键
+type diffNodeSide struct {
+	keys  map[uint64]string // 节点ID -> 匹配键
+	byKey map[string]*ent.WorkflowNode
+}
+
+// DiffWorkflowApplications 对比两个工作流应用
+// 两个应用的节点ID不同，节点按名称和类型匹配，同名同类型的多个节点按ID顺序依次配对；
+// 边按两端匹配后的节点和分支名称对应。节点位置、颜色和边样式只影响画布展示，不参与对比
+func (WorkflowFuncs) DiffWorkflowApplications(ctx context.Context, leftID, rightID uint64) (*models.WorkflowApplicationDiff, error) {
+	left, err := loadWorkflowGraph(ctx, leftID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := loadWorkflowGraph(ctx, rightID)
+	if err != nil {
+		return nil, err
+	}
+
+	leftSide := newDiffNodeSide(left.nodes)
+	rightSide := newDiffNodeSide(right.nodes)
+
+	result := &models.WorkflowApplicationDiff{
+		Left:           models.WorkflowDiffApplication{ID: utils.Uint64ToString(left.application.ID), Name: left.application.Name},
+		Right:          models.WorkflowDiffApplication{ID: utils.Uint64ToString(right.application.ID), Name: right.application.Name},
+		Nodes:          []models.WorkflowNodeDiff{},
+		UnmatchedNodes: []models.WorkflowUnmatchedNode{},
+		Edges:          []models.WorkflowEdgeDiff{},
+	}
+
+	for _, key := range sortedDiffKeys(leftSide.byKey, rightSide.byKey) {
+		l, r := leftSide.byKey[key], rightSide.byKey[key]
+		switch {
+		case r == nil:
+			result.UnmatchedNodes = append(result.UnmatchedNodes, unmatchedDiffNode("left", l))
+		case l == nil:
+			result.UnmatchedNodes = append(result.UnmatchedNodes, unmatchedDiffNode("right", r))
+		default:
+			if changes := diffWorkflowNodes(l, r); len(changes) > 0 {
+				result.Nodes = append(result.Nodes, models.WorkflowNodeDiff{
+					Name:        l.Name,
+					Type:        string(l.Type),
+					LeftNodeID:  utils.Uint64ToString(l.ID),
+					RightNodeID: utils.Uint64ToString(r.ID),
+					Changes:     changes,
+				})
+			}
+		}
+	}
+
+	leftEdges := leftSide.indexEdges(left)
+	rightEdges := rightSide.indexEdges(right)
+	for _, key := range sortedDiffKeys(leftEdges, rightEdges) {
+		l, r := leftEdges[key], rightEdges[key]
+		var item models.WorkflowEdgeDiff
+		switch {
+		case r == nil:
+			item = edgeDiffEndpoints(models.WorkflowDiffLeftOnly, l, left)
+			item.LeftEdgeID = utils.Uint64ToString(l.ID)
+		case l == nil:
+			item = edgeDiffEndpoints(models.WorkflowDiffRightOnly, r, right)
+			item.RightEdgeID = utils.Uint64ToString(r.ID)
+		default:
+			changes := diffWorkflowEdges(l, r)
+			if len(changes) == 0 {
+				continue
+			}
+			item = edgeDiffEndpoints(models.WorkflowDiffChanged, l, left)
+			item.LeftEdgeID = utils.Uint64ToString(l.ID)
+			item.RightEdgeID = utils.Uint64ToString(r.ID)
+			item.Changes = changes
+		}
+		result.Edges = append(result.Edges, item)
+	}
+
+	result.Identical = len(result.Nodes) == 0 && len(result.UnmatchedNodes) == 0 && len(result.Edges) == 0
+	return result, nil
+}
+
+// newDiffNodeSide 为一侧的节点生成匹配键：类型、名称以及同名同类型节点中按ID排序的序号
+func newDiffNodeSide(nodes map[uint64]*ent.WorkflowNode) *diffNodeSide {
+	groups := make(map[string][]*ent.WorkflowNode)
+	for _, node := range nodes {
+		group := string(node.Type) + "\x00" + node.Name
+		groups[group] = append(groups[group], node)
+	}
+
+	side := &diffNodeSide{
+		keys:  make(map[uint64]string, len(nodes)),
+		byKey: make(map[string]*ent.WorkflowNode, len(nodes)),
+	}
+	for group, members := range groups {
+		sort.Slice(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+		for i, node := range members {
+			key := fmt.Sprintf("%s\x00%d", group, i)
+			side.keys[node.ID] = key
+			side.byKey[key] = node
+		}
+	}
+	return side
+}
+
+// indexEdges 按两端节点的匹配键和分支名称为边生成匹配键，相同端点的多条边按ID排序编号
+func (s *diffNodeSide) indexEdges(graph *workflowGraph) map[string]*ent.WorkflowEdge {
+	var edges []*ent.WorkflowEdge
+	for _, outgoing := range graph.outgoingEdges {
+		edges = append(edges, outgoing...)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].ID < edges[j].ID })
+
+	indexed := make(map[string]*ent.WorkflowEdge, len(edges))
+	counts := make(map[string]int, len(edges))
+	for _, edge := range edges {
+		base := s.keys[edge.SourceNodeID] + "\x01" + s.keys[edge.TargetNodeID] + "\x01" + edge.BranchName
+		indexed[fmt.Sprintf("%s\x01%d", base, counts[base])] = edge
+		counts[base]++
+	}
+	return indexed
+}
+
+// diffWorkflowNodes 对比两个已匹配节点的内容
+func diffWorkflowNodes(left, right *ent.WorkflowNode) []models.WorkflowFieldChange {
+	changes := []models.WorkflowFieldChange{}
+	changes = appendFieldChange(changes, "description", left.Description, right.Description)
+	changes = appendFieldChange(changes, "prompt", left.Prompt, right.Prompt)
+	changes = appendJSONFieldChanges(changes, "config", left.Config, right.Config)
+	changes = appendFieldChange(changes, "processor_language", left.ProcessorLanguage, right.ProcessorLanguage)
+	changes = appendFieldChange(changes, "processor_code", left.ProcessorCode, right.ProcessorCode)
+	changes = appendJSONFieldChanges(changes, "parallel_config", left.ParallelConfig, right.ParallelConfig)
+	changes = appendJSONFieldChanges(changes, "api_config", left.APIConfig, right.APIConfig)
+	changes = appendFieldChange(changes, "workflow_application_id", left.WorkflowApplicationID, right.WorkflowApplicationID)
+	changes = appendFieldChange(changes, "async", left.Async, right.Async)
+	changes = appendFieldChange(changes, "timeout", left.Timeout, right.Timeout)
+	changes = appendFieldChange(changes, "retry_count", left.RetryCount, right.RetryCount)
+	changes = appendFieldChange(changes, "enabled", left.Enabled, right.Enabled)
+	// branch_nodes 中保存的是节点ID，两个应用之间不可比较，分支的差异通过边体现
+	return changes
+}
+
+// diffWorkflowEdges 对比两条已匹配边的内容，连接点ID与节点ID相关，不参与对比
+func diffWorkflowEdges(left, right *ent.WorkflowEdge) []models.WorkflowFieldChange {
+	changes := []models.WorkflowFieldChange{}
+	changes = appendFieldChange(changes, "type", string(left.Type), string(right.Type))
+	changes = appendFieldChange(changes, "label", left.Label, right.Label)
+	changes = appendJSONFieldChanges(changes, "data", left.Data, right.Data)
+	return changes
+}
+
+// appendFieldChange 字段值不同时记录差异
+func appendFieldChange(changes []models.WorkflowFieldChange, field string, left, right any) []models.WorkflowFieldChange {
+	if reflect.DeepEqual(left, right) {
+		return changes
+	}
+	return append(changes, models.WorkflowFieldChange{Field: field, Left: left, Right: right})
+}
+
+// appendJSONFieldChanges 将JSON配置按层级展开后逐项对比，数组作为整体对比
+func appendJSONFieldChanges(changes []models.WorkflowFieldChange, field string, left, right map[string]interface{}) []models.WorkflowFieldChange {
+	leftValues := make(map[string]any)
+	rightValues := make(map[string]any)
+	flattenDiffValues(field, left, leftValues)
+	flattenDiffValues(field, right, rightValues)
+
+	for _, path := range sortedDiffKeys(leftValues, rightValues) {
+		changes = appendFieldChange(changes, path, leftValues[path], rightValues[path])
+	}
+	return changes
+}
+
+// flattenDiffValues 将嵌套的对象展开为 a.b.c 形式的路径
+func flattenDiffValues(prefix string, values map[string]interface{}, out map[string]any) {
+	for key, value := range values {
+		path := prefix + "." + key
+		if nested, ok := value.(map[string]interface{}); ok && len(nested) > 0 {
+			flattenDiffValues(path, nested, out)
+			continue
+		}
+		out[path] = value
+	}
+}
+
+// sortedDiffKeys 返回两个映射中所有键的有序并集
+func sortedDiffKeys[V any](left, right map[string]V) []string {
+	keys := make([]string, 0, len(left)+len(right))
+	for key := range left {
+		keys = append(keys, key)
+	}
+	for key := range right {
+		if _, ok := left[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// unmatchedDiffNode 构建只存在于一侧的节点
+func unmatchedDiffNode(side string, node *ent.WorkflowNode) models.WorkflowUnmatchedNode {
+	return models.WorkflowUnmatchedNode{
+		Side:   side,
+		NodeID: utils.Uint64ToString(node.ID),
+		Name:   node.Name,
+		Type:   string(node.Type),
+	}
+}
+
+// edgeDiffEndpoints 使用节点名称描述边的两端
+func edgeDiffEndpoints(status string, edge *ent.WorkflowEdge, graph *workflowGraph) models.WorkflowEdgeDiff {
+	item := models.WorkflowEdgeDiff{Status: status, BranchName: edge.BranchName}
+	if source, ok := graph.nodes[edge.SourceNodeID]; ok {
+		item.SourceName = source.Name
+	}
+	if target, ok := graph.nodes[edge.TargetNodeID]; ok {
+		item.TargetName = target.Name
+	}
+	return item
+}
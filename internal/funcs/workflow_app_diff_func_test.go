@@ -0,0 +1,78 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func TestDiffWorkflowApplications(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	// 两侧都有的 LLM 节点：提示词和嵌套配置不同，位置不同不算差异
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 16, "application_id": 1, "name": "answer", "node_key": "answer", "type": "llm_caller",
+		"prompt": "hello", "config": `{"model":"gpt-4","options":{"temperature":0.2,"top_p":1}}`, "position_x": 10})
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 26, "application_id": 2, "name": "answer", "node_key": "answer", "type": "llm_caller",
+		"prompt": "hi", "config": `{"model":"gpt-4","options":{"temperature":0.7,"top_p":1}}`, "position_x": 300})
+	// 只存在于右侧的节点，以及连接它的边
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 27, "application_id": 2, "name": "audit", "node_key": "audit", "type": "data_processor"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 16, "application_id": 1, "source_node_id": 11, "target_node_id": 16, "edge_key": "e2", "type": "default", "label": "ask"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 26, "application_id": 2, "source_node_id": 21, "target_node_id": 26, "edge_key": "e2", "type": "default", "label": "question"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 27, "application_id": 2, "source_node_id": 26, "target_node_id": 27, "edge_key": "e3", "type": "default"})
+
+	ctx := context.Background()
+	diff, err := WorkflowFuncs{}.DiffWorkflowApplications(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("diff failed: %v", err)
+	}
+	if diff.Identical || diff.Left.Name != "app-1" || diff.Right.Name != "app-2" {
+		t.Fatalf("unexpected diff header: %+v", diff)
+	}
+
+	if len(diff.Nodes) != 1 || diff.Nodes[0].Name != "answer" || diff.Nodes[0].LeftNodeID != "16" || diff.Nodes[0].RightNodeID != "26" {
+		t.Fatalf("expected only the answer node to differ, got %+v", diff.Nodes)
+	}
+	changes := map[string]models.WorkflowFieldChange{}
+	for _, change := range diff.Nodes[0].Changes {
+		changes[change.Field] = change
+	}
+	if len(changes) != 2 || changes["prompt"].Right != "hi" || changes["config.options.temperature"].Left != 0.2 {
+		t.Fatalf("unexpected node changes: %+v", diff.Nodes[0].Changes)
+	}
+
+	if len(diff.UnmatchedNodes) != 1 || diff.UnmatchedNodes[0].Side != "right" || diff.UnmatchedNodes[0].Name != "audit" {
+		t.Fatalf("unexpected unmatched nodes: %+v", diff.UnmatchedNodes)
+	}
+
+	// start->end 两侧一致；start->answer 标签不同；answer->audit 只存在于右侧
+	statuses := map[string]models.WorkflowEdgeDiff{}
+	for _, edge := range diff.Edges {
+		statuses[edge.SourceName+"->"+edge.TargetName] = edge
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("unexpected edge diffs: %+v", diff.Edges)
+	}
+	if changed := statuses["start->answer"]; changed.Status != models.WorkflowDiffChanged || len(changed.Changes) != 1 || changed.Changes[0].Field != "label" {
+		t.Fatalf("unexpected changed edge: %+v", changed)
+	}
+	if added := statuses["answer->audit"]; added.Status != models.WorkflowDiffRightOnly || added.RightEdgeID != "27" {
+		t.Fatalf("unexpected right-only edge: %+v", added)
+	}
+
+	// 应用与自身对比没有差异
+	same, err := WorkflowFuncs{}.DiffWorkflowApplications(ctx, 1, 1)
+	if err != nil || !same.Identical {
+		t.Fatalf("an application should be identical to itself, got %+v (%v)", same, err)
+	}
+
+	if _, err := (WorkflowFuncs{}).DiffWorkflowApplications(ctx, 1, 99); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// batchSaveSize 统计批量保存请求中的节点操作数和边操作数（新增、更新、删除之和）
+func batchSaveSize(req *models.BatchSaveWorkflowRequest) (nodeOps, edgeOps int) {
+	nodeOps = len(req.NodesToCreate) + len(req.NodesToUpdate) + len(req.NodeIDsToDelete)
+	edgeOps = len(req.EdgesToCreate) + len(req.EdgesToUpdate) + len(req.EdgeIDsToDelete)
+	return nodeOps, edgeOps
+}
+
+// checkBatchSaveLimits 校验批量保存规模，超出配置上限时返回 invalid batch save request 错误
+// 上限不大于0表示不限制
+func checkBatchSaveLimits(req *models.BatchSaveWorkflowRequest, limits configs.BatchSaveConfig) error {
+	maxNodes, maxEdges := limits.MaxNodes, limits.MaxEdges
+	if req.LargeBatch {
+		maxNodes, maxEdges = limits.LargeBatch.MaxNodes, limits.LargeBatch.MaxEdges
+	}
+
+	nodeOps, edgeOps := batchSaveSize(req)
+	if maxNodes > 0 && nodeOps > maxNodes {
+		return fmt.Errorf("%s: %d node operations exceed the limit of %d", errInvalidBatchSaveRequest, nodeOps, maxNodes)
+	}
+	if maxEdges > 0 && edgeOps > maxEdges {
+		return fmt.Errorf("%s: %d edge operations exceed the limit of %d", errInvalidBatchSaveRequest, edgeOps, maxEdges)
+	}
+	return nil
+}
+
+// chunkRanges 将长度为 n 的数组按 size 切分为 [start, end) 区间
+func chunkRanges(n, size int) [][2]int {
+	ranges := make([][2]int, 0, (n+size-1)/size)
+	for start := 0; start < n; start += size {
+		ranges = append(ranges, [2]int{start, min(start+size, n)})
+	}
+	return ranges
+}
+
+// splitBatchSaveRequest 将批量保存请求拆分为按执行顺序排列的子请求，每个子请求只包含一类操作且不超过 chunkSize 项
+// 顺序与单事务保存一致：新增节点、更新节点、删除节点、新增边、更新边、删除边
+func splitBatchSaveRequest(req *models.BatchSaveWorkflowRequest, chunkSize int) []*models.BatchSaveWorkflowRequest {
+	if chunkSize <= 0 {
+		chunkSize = 200
+	}
+
+	chunks := make([]*models.BatchSaveWorkflowRequest, 0)
+	newChunk := func() *models.BatchSaveWorkflowRequest {
+		chunk := &models.BatchSaveWorkflowRequest{ApplicationID: req.ApplicationID}
+		chunks = append(chunks, chunk)
+		return chunk
+	}
+
+	for _, r := range chunkRanges(len(req.NodesToCreate), chunkSize) {
+		chunk := newChunk()
+		chunk.NodesToCreate = req.NodesToCreate[r[0]:r[1]]
+		chunk.NodeTempIDs = req.NodeTempIDs[r[0]:r[1]]
+	}
+	for _, r := range chunkRanges(len(req.NodesToUpdate), chunkSize) {
+		newChunk().NodesToUpdate = req.NodesToUpdate[r[0]:r[1]]
+	}
+	for _, r := range chunkRanges(len(req.NodeIDsToDelete), chunkSize) {
+		newChunk().NodeIDsToDelete = req.NodeIDsToDelete[r[0]:r[1]]
+	}
+	for _, r := range chunkRanges(len(req.EdgesToCreate), chunkSize) {
+		chunk := newChunk()
+		chunk.EdgesToCreate = req.EdgesToCreate[r[0]:r[1]]
+		chunk.EdgeTempIDs = req.EdgeTempIDs[r[0]:r[1]]
+	}
+	for _, r := range chunkRanges(len(req.EdgesToUpdate), chunkSize) {
+		newChunk().EdgesToUpdate = req.EdgesToUpdate[r[0]:r[1]]
+	}
+	for _, r := range chunkRanges(len(req.EdgeIDsToDelete), chunkSize) {
+		newChunk().EdgeIDsToDelete = req.EdgeIDsToDelete[r[0]:r[1]]
+	}
+	return chunks
+}
+
+// resolveChunkEdgeRefs 将子请求中边引用的节点临时ID替换为之前块中已创建节点的数据库ID
+func resolveChunkEdgeRefs(chunk *models.BatchSaveWorkflowRequest, nodeIDMapping map[string]string) {
+	if len(chunk.EdgesToCreate) == 0 {
+		return
+	}
+	edges := make([]models.CreateWorkflowEdgeRequest, len(chunk.EdgesToCreate))
+	for i, edge := range chunk.EdgesToCreate {
+		if dbID, ok := nodeIDMapping[edge.SourceNodeID]; ok {
+			edge.SourceNodeID = dbID
+		}
+		if dbID, ok := nodeIDMapping[edge.TargetNodeID]; ok {
+			edge.TargetNodeID = dbID
+		}
+		edges[i] = edge
+	}
+	chunk.EdgesToCreate = edges
+}
+
+// newBatchSaveWorkflowData 创建空的批量保存结果
+func newBatchSaveWorkflowData() *models.BatchSaveWorkflowData {
+	return &models.BatchSaveWorkflowData{
+		NodeIDMapping:  make(map[string]string),
+		EdgeIDMapping:  make(map[string]string),
+		CreatedNodes:   make([]*models.WorkflowNodeResponse, 0),
+		UpdatedNodes:   make([]*models.WorkflowNodeResponse, 0),
+		DeletedNodeIDs: make([]string, 0),
+		CreatedEdges:   make([]*models.WorkflowEdgeResponse, 0),
+		UpdatedEdges:   make([]*models.WorkflowEdgeResponse, 0),
+		DeletedEdgeIDs: make([]string, 0),
+
+		SkippedEdgeTempIDs: make([]string, 0),
+	}
+}
+
+// mergeBatchSaveData 将子请求的保存结果合并到总结果中
+func mergeBatchSaveData(dst, src *models.BatchSaveWorkflowData) {
+	for tempID, dbID := range src.NodeIDMapping {
+		dst.NodeIDMapping[tempID] = dbID
+	}
+	for tempID, dbID := range src.EdgeIDMapping {
+		dst.EdgeIDMapping[tempID] = dbID
+	}
+	dst.CreatedNodes = append(dst.CreatedNodes, src.CreatedNodes...)
+	dst.UpdatedNodes = append(dst.UpdatedNodes, src.UpdatedNodes...)
+	dst.DeletedNodeIDs = append(dst.DeletedNodeIDs, src.DeletedNodeIDs...)
+	dst.CreatedEdges = append(dst.CreatedEdges, src.CreatedEdges...)
+	dst.UpdatedEdges = append(dst.UpdatedEdges, src.UpdatedEdges...)
+	dst.DeletedEdgeIDs = append(dst.DeletedEdgeIDs, src.DeletedEdgeIDs...)
+
+	dst.Stats.NodesCreated += src.Stats.NodesCreated
+	dst.Stats.NodesUpdated += src.Stats.NodesUpdated
+	dst.Stats.NodesDeleted += src.Stats.NodesDeleted
+	dst.Stats.EdgesCreated += src.Stats.EdgesCreated
+	dst.Stats.EdgesUpdated += src.Stats.EdgesUpdated
+	dst.Stats.EdgesDeleted += src.Stats.EdgesDeleted
+}
+
+// batchSaveWorkflowChunked 大批量模式：先整体校验，再按块在独立事务中依次提交，缩短单个事务持有锁的时间
+// 中途失败时之前的块已提交，返回的错误中包含失败的块序号
+func batchSaveWorkflowChunked(ctx context.Context, req *models.BatchSaveWorkflowRequest, chunkSize int) (*models.BatchSaveWorkflowData, error) {
+	if err := prepareBatchSave(ctx, utils.StringToUint64(req.ApplicationID), req); err != nil {
+		return nil, err
+	}
+
+	result := newBatchSaveWorkflowData()
+	chunks := splitBatchSaveRequest(req, chunkSize)
+	for i, chunk := range chunks {
+		resolveChunkEdgeRefs(chunk, result.NodeIDMapping)
+
+		chunkResult, err := batchSaveWorkflowTx(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("large batch save failed at chunk %d/%d, earlier chunks were committed: %w", i+1, len(chunks), err)
+		}
+		mergeBatchSaveData(result, chunkResult)
+	}
+	return result, nil
+}
+
+// edgeIdentity 判断两条边是否重复的字段：源节点、目标节点、源/目标连接点和分支名称
+type edgeIdentity struct {
+	source, target             string
+	sourceHandle, targetHandle string
+	branchName                 string
+}
+
+// existingEdgeIdentity 已保存的边的标识
+func existingEdgeIdentity(edge *ent.WorkflowEdge) edgeIdentity {
+	return edgeIdentity{
+		source:       utils.Uint64ToString(edge.SourceNodeID),
+		target:       utils.Uint64ToString(edge.TargetNodeID),
+		sourceHandle: edge.SourceHandle,
+		targetHandle: edge.TargetHandle,
+		branchName:   edge.BranchName,
+	}
+}
+
+// duplicateEdge 批量保存中被跳过的重复边：duplicateOf 为已存在的边的数据库ID，或本次请求中首次出现的同一条边的临时ID
+type duplicateEdge struct {
+	tempID      string
+	duplicateOf string
+	existing    bool
+}
+
+// filterDuplicateEdges 检查待新增的边是否与已有边或请求中的其他边重复
+// 本次请求中要删除的边不参与比较；节点引用按请求中的原始字符串（临时ID或数据库ID）比较。
+// skip 为 false 时遇到重复返回 invalid batch save request 错误；为 true 时返回去掉重复边的请求副本和被跳过的边
+func filterDuplicateEdges(req *models.BatchSaveWorkflowRequest, existingEdges []*ent.WorkflowEdge, skip bool) (*models.BatchSaveWorkflowRequest, []duplicateEdge, error) {
+	if len(req.EdgesToCreate) == 0 || len(req.EdgeTempIDs) != len(req.EdgesToCreate) {
+		// 临时ID错位由 validateBatchSaveRequest 报错
+		return req, nil, nil
+	}
+
+	deleting := make(map[string]struct{}, len(req.EdgeIDsToDelete))
+	for _, id := range req.EdgeIDsToDelete {
+		deleting[id] = struct{}{}
+	}
+
+	seen := make(map[edgeIdentity]duplicateEdge, len(existingEdges)+len(req.EdgesToCreate))
+	for _, edge := range existingEdges {
+		id := utils.Uint64ToString(edge.ID)
+		if _, ok := deleting[id]; ok {
+			continue
+		}
+		seen[existingEdgeIdentity(edge)] = duplicateEdge{duplicateOf: id, existing: true}
+	}
+
+	filtered := *req
+	filtered.EdgesToCreate = make([]models.CreateWorkflowEdgeRequest, 0, len(req.EdgesToCreate))
+	filtered.EdgeTempIDs = make([]string, 0, len(req.EdgeTempIDs))
+	skipped := make([]duplicateEdge, 0)
+
+	for i, edge := range req.EdgesToCreate {
+		tempID := req.EdgeTempIDs[i]
+		identity := edgeIdentity{
+			source:       edge.SourceNodeID,
+			target:       edge.TargetNodeID,
+			sourceHandle: edge.SourceHandle,
+			targetHandle: edge.TargetHandle,
+			branchName:   edge.BranchName,
+		}
+		if first, ok := seen[identity]; ok {
+			if !skip {
+				return nil, nil, fmt.Errorf("%s: edge %s duplicates edge %s with the same source, target, handles and branch",
+					errInvalidBatchSaveRequest, tempID, first.duplicateOf)
+			}
+			skipped = append(skipped, duplicateEdge{tempID: tempID, duplicateOf: first.duplicateOf, existing: first.existing})
+			continue
+		}
+		seen[identity] = duplicateEdge{duplicateOf: tempID}
+		filtered.EdgesToCreate = append(filtered.EdgesToCreate, edge)
+		filtered.EdgeTempIDs = append(filtered.EdgeTempIDs, tempID)
+	}
+	return &filtered, skipped, nil
+}
+
+// applySkippedEdges 将被跳过的重复边的临时ID映射到已存在或本次新建的边
+func applySkippedEdges(result *models.BatchSaveWorkflowData, skipped []duplicateEdge) {
+	for _, edge := range skipped {
+		if edge.existing {
+			result.EdgeIDMapping[edge.tempID] = edge.duplicateOf
+		} else if dbID, ok := result.EdgeIDMapping[edge.duplicateOf]; ok {
+			result.EdgeIDMapping[edge.tempID] = dbID
+		}
+		result.SkippedEdgeTempIDs = append(result.SkippedEdgeTempIDs, edge.tempID)
+		result.Stats.EdgesSkipped++
+	}
+}
+
+// dedupeBatchSaveEdges 加载应用已有的边并过滤批量保存请求中的重复边
+func dedupeBatchSaveEdges(ctx context.Context, req *models.BatchSaveWorkflowRequest) (*models.BatchSaveWorkflowRequest, []duplicateEdge, error) {
+	if len(req.EdgesToCreate) == 0 {
+		return req, nil, nil
+	}
+	applicationID, err := strconv.ParseUint(req.ApplicationID, 10, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: invalid application id %q", errInvalidBatchSaveRequest, req.ApplicationID)
+	}
+
+	existingEdges, err := database.Client.WorkflowEdge.Query().
+		Where(workflowedge.ApplicationIDEQ(applicationID)).
+		All(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load existing edges: %w", err)
+	}
+	return filterDuplicateEdges(req, existingEdges, req.SkipDuplicateEdges)
+}
+
+// duplicateEdgeIDs 返回重复边中需要删除的ID，每组重复边保留最早创建的一条
+// edges 需按创建时间和ID升序排列
+func duplicateEdgeIDs(edges []*ent.WorkflowEdge) []uint64 {
+	kept := make(map[edgeIdentity]struct{}, len(edges))
+	duplicates := make([]uint64, 0)
+	for _, edge := range edges {
+		identity := existingEdgeIdentity(edge)
+		if _, ok := kept[identity]; ok {
+			duplicates = append(duplicates, edge.ID)
+			continue
+		}
+		kept[identity] = struct{}{}
+	}
+	return duplicates
+}
+
+// DedupeApplicationEdges 删除应用中重复的边（源节点、目标节点、连接点和分支均相同），每组保留最早创建的一条，返回删除的数量
+func (WorkflowFuncs) DedupeApplicationEdges(ctx context.Context, applicationID uint64) (int, error) {
+	edges, err := database.Client.WorkflowEdge.Query().
+		Where(workflowedge.ApplicationIDEQ(applicationID)).
+		Order(ent.Asc(workflowedge.FieldCreateTime), ent.Asc(workflowedge.FieldID)).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	duplicates := duplicateEdgeIDs(edges)
+	if len(duplicates) == 0 {
+		return 0, nil
+	}
+
+	removed, err := database.Client.WorkflowEdge.Delete().
+		Where(workflowedge.IDIn(duplicates...)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete duplicate edges: %w", err)
+	}
+	return removed, nil
+}
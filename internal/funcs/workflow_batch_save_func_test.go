@@ -0,0 +1,180 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func TestCheckBatchSaveLimits(t *testing.T) {
+	limits := configs.BatchSaveConfig{
+		MaxNodes:   2,
+		MaxEdges:   1,
+		LargeBatch: configs.LargeBatchSaveConfig{MaxNodes: 10, MaxEdges: 10},
+	}
+	req := &models.BatchSaveWorkflowRequest{
+		NodesToCreate:   []models.CreateWorkflowNodeRequest{{Name: "a"}},
+		NodeIDsToDelete: []string{"1", "2"},
+	}
+
+	err := checkBatchSaveLimits(req, limits)
+	if err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected node limit error, got %v", err)
+	}
+
+	req.LargeBatch = true
+	if err := checkBatchSaveLimits(req, limits); err != nil {
+		t.Fatalf("large batch limits should apply: %v", err)
+	}
+
+	req.LargeBatch = false
+	req.NodeIDsToDelete = nil
+	req.EdgeIDsToDelete = []string{"1", "2"}
+	if err := checkBatchSaveLimits(req, limits); err == nil {
+		t.Fatal("expected edge limit error")
+	}
+
+	if err := checkBatchSaveLimits(req, configs.BatchSaveConfig{}); err != nil {
+		t.Fatalf("zero limits should disable the check: %v", err)
+	}
+}
+
+func TestSplitBatchSaveRequest(t *testing.T) {
+	req := &models.BatchSaveWorkflowRequest{ApplicationID: "1"}
+	for i := 0; i < 5; i++ {
+		req.NodeTempIDs = append(req.NodeTempIDs, fmt.Sprintf("tmp-%d", i))
+		req.NodesToCreate = append(req.NodesToCreate, models.CreateWorkflowNodeRequest{Name: fmt.Sprint(i)})
+	}
+	req.NodeIDsToDelete = []string{"100"}
+	req.EdgeTempIDs = []string{"e-1", "e-2", "e-3"}
+	req.EdgesToCreate = []models.CreateWorkflowEdgeRequest{
+		{SourceNodeID: "tmp-0", TargetNodeID: "tmp-4"},
+		{SourceNodeID: "tmp-1", TargetNodeID: "200"},
+		{SourceNodeID: "200", TargetNodeID: "tmp-2"},
+	}
+
+	chunks := splitBatchSaveRequest(req, 2)
+	// 节点新增 3 块、节点删除 1 块、边新增 2 块
+	if len(chunks) != 6 {
+		t.Fatalf("expected 6 chunks, got %d", len(chunks))
+	}
+	for i, chunk := range chunks[:3] {
+		if chunk.ApplicationID != "1" || len(chunk.NodesToCreate) != len(chunk.NodeTempIDs) {
+			t.Fatalf("chunk %d has mismatched node temp IDs", i)
+		}
+	}
+	if len(chunks[2].NodesToCreate) != 1 || chunks[2].NodeTempIDs[0] != "tmp-4" {
+		t.Fatalf("unexpected last node chunk: %+v", chunks[2])
+	}
+	if len(chunks[3].NodeIDsToDelete) != 1 {
+		t.Fatalf("expected node delete chunk, got %+v", chunks[3])
+	}
+	if len(chunks[4].EdgesToCreate) != 2 || len(chunks[5].EdgesToCreate) != 1 || chunks[5].EdgeTempIDs[0] != "e-3" {
+		t.Fatalf("unexpected edge chunks: %+v %+v", chunks[4], chunks[5])
+	}
+
+	mapping := map[string]string{"tmp-0": "10", "tmp-1": "11", "tmp-2": "12", "tmp-3": "13", "tmp-4": "14"}
+	resolveChunkEdgeRefs(chunks[4], mapping)
+	if chunks[4].EdgesToCreate[0].SourceNodeID != "10" || chunks[4].EdgesToCreate[0].TargetNodeID != "14" ||
+		chunks[4].EdgesToCreate[1].TargetNodeID != "200" {
+		t.Fatalf("unexpected resolved edges: %+v", chunks[4].EdgesToCreate)
+	}
+	if req.EdgesToCreate[0].SourceNodeID != "tmp-0" {
+		t.Fatal("resolving chunk references must not modify the original request")
+	}
+}
+
+func TestMergeBatchSaveData(t *testing.T) {
+	dst := newBatchSaveWorkflowData()
+	src := newBatchSaveWorkflowData()
+	src.NodeIDMapping["tmp-1"] = "10"
+	src.DeletedNodeIDs = []string{"5"}
+	src.Stats.NodesCreated = 1
+	src.Stats.NodesDeleted = 1
+
+	mergeBatchSaveData(dst, src)
+	mergeBatchSaveData(dst, src)
+	if dst.NodeIDMapping["tmp-1"] != "10" || len(dst.DeletedNodeIDs) != 2 || dst.Stats.NodesCreated != 2 || dst.Stats.NodesDeleted != 2 {
+		t.Fatalf("unexpected merged result: %+v", dst)
+	}
+}
+
+func TestFilterDuplicateEdges(t *testing.T) {
+	existing := []*ent.WorkflowEdge{
+		{ID: 100, SourceNodeID: 1, TargetNodeID: 2, SourceHandle: "out"},
+		{ID: 101, SourceNodeID: 2, TargetNodeID: 3, BranchName: "yes"},
+	}
+	req := &models.BatchSaveWorkflowRequest{
+		ApplicationID: "1",
+		EdgeTempIDs:   []string{"e1", "e2", "e3", "e4", "e5"},
+		EdgesToCreate: []models.CreateWorkflowEdgeRequest{
+			{SourceNodeID: "1", TargetNodeID: "2", SourceHandle: "out"}, // 与 100 重复
+			{SourceNodeID: "2", TargetNodeID: "3", BranchName: "no"},    // 分支不同，不重复
+			{SourceNodeID: "tmp-a", TargetNodeID: "3"},                  // 新节点
+			{SourceNodeID: "tmp-a", TargetNodeID: "3"},                  // 与 e3 重复
+			{SourceNodeID: "2", TargetNodeID: "3", BranchName: "yes"},   // 101 在本次请求中删除，不算重复
+		},
+		EdgeIDsToDelete: []string{"101"},
+	}
+
+	if _, _, err := filterDuplicateEdges(req, existing, false); err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected duplicate to be rejected, got %v", err)
+	}
+
+	filtered, skipped, err := filterDuplicateEdges(req, existing, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := strings.Join(filtered.EdgeTempIDs, ","); got != "e2,e3,e5" || len(filtered.EdgesToCreate) != 3 {
+		t.Fatalf("unexpected remaining edges: %s", got)
+	}
+	if len(req.EdgesToCreate) != 5 {
+		t.Fatal("original request must not be modified")
+	}
+
+	result := newBatchSaveWorkflowData()
+	result.EdgeIDMapping = map[string]string{"e2": "200", "e3": "201", "e5": "202"}
+	applySkippedEdges(result, skipped)
+	if result.EdgeIDMapping["e1"] != "100" || result.EdgeIDMapping["e4"] != "201" {
+		t.Fatalf("skipped edges should map to the surviving edge, got %v", result.EdgeIDMapping)
+	}
+	if result.Stats.EdgesSkipped != 2 || strings.Join(result.SkippedEdgeTempIDs, ",") != "e1,e4" {
+		t.Fatalf("unexpected skip report: %+v %v", result.Stats, result.SkippedEdgeTempIDs)
+	}
+}
+
+func TestDedupeApplicationEdges(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	ctx := context.Background()
+
+	seedWorkflowApplication(t, db, 1)
+	// 与种子边 13（11 -> 12）重复的两条边，以及一条连接点不同的边
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 16, "application_id": 1, "source_node_id": 11, "target_node_id": 12, "edge_key": "dup1", "type": "default"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 17, "application_id": 1, "source_node_id": 11, "target_node_id": 12, "edge_key": "dup2", "type": "default"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 18, "application_id": 1, "source_node_id": 11, "target_node_id": 12, "source_handle": "alt", "edge_key": "alt", "type": "default"})
+
+	removed, err := WorkflowFuncs{}.DedupeApplicationEdges(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if removed != 2 {
+		t.Fatalf("expected 2 duplicates removed, got %d", removed)
+	}
+	ids := client.WorkflowEdge.Query().IDsX(ctx)
+	if len(ids) != 2 || ids[0] != 13 || ids[1] != 18 {
+		t.Fatalf("expected edges 13 and 18 to remain, got %v", ids)
+	}
+
+	if removed, err := (WorkflowFuncs{}).DedupeApplicationEdges(ctx, 1); err != nil || removed != 0 {
+		t.Fatalf("second run should remove nothing, got %d, %v", removed, err)
+	}
+}
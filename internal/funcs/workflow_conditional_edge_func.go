@@ -0,0 +1,159 @@
+package funcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+)
+
+// 条件边：类型为 conditional 的边在 data.condition 中携带表达式（如 `score > 0.8`），
+// 执行引擎从源节点继续时按执行上下文对表达式求值，只沿条件成立的边继续，不需要单独的条件节点。
+// 多条条件边同时成立时全部沿用；表达式求值出错时视为不成立。
+// 求值结果记录在源节点执行的 Extra.conditionalEdges 中，执行路径报告据此列出未成立的条件。
+
+// ConditionalEdgeConditionKey 条件边在 data 中保存表达式的键
+const ConditionalEdgeConditionKey = "condition"
+
+// conditionalEdgesExtraKey 节点执行 Extra 中记录条件边求值结果的键
+const conditionalEdgesExtraKey = "conditionalEdges"
+
+// conditionPathPattern 条件表达式中变量路径操作数的格式
+var conditionPathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*$`)
+
+// edgeCondition 读取边 data 中的条件表达式
+func edgeCondition(data map[string]interface{}) string {
+	condition, _ := data[ConditionalEdgeConditionKey].(string)
+	return strings.TrimSpace(condition)
+}
+
+// validateEdgeCondition 校验条件边的表达式，非条件边不做检查
+func validateEdgeCondition(edgeType string, data map[string]interface{}) error {
+	if edgeType != workflowedge.TypeConditional.String() {
+		return nil
+	}
+	if err := validateConditionExpression(edgeCondition(data)); err != nil {
+		return fmt.Errorf("conditional edge data.%s: %w", ConditionalEdgeConditionKey, err)
+	}
+	return nil
+}
+
+// validateConditionExpression 保存时按条件求值器支持的语法校验表达式：
+// 单个比较（或单个操作数的真假判断），操作数为JSON字面量或变量路径
+func validateConditionExpression(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return fmt.Errorf("condition is empty")
+	}
+	left, op, right := splitCondition(expr)
+	if op == "" {
+		return validateConditionOperand(left)
+	}
+	if left == "" || right == "" {
+		return fmt.Errorf("operator %s in %q is missing an operand", op, expr)
+	}
+	if err := validateConditionOperand(left); err != nil {
+		return err
+	}
+	return validateConditionOperand(right)
+}
+
+// validateConditionOperand 校验操作数为JSON字面量或变量路径，且不包含多余的运算符
+func validateConditionOperand(operand string) error {
+	var literal interface{}
+	if json.Unmarshal([]byte(operand), &literal) == nil {
+		return nil
+	}
+	if _, op, _ := splitCondition(operand); op != "" {
+		return fmt.Errorf("only a single comparison is supported, got %q", operand)
+	}
+	if !conditionPathPattern.MatchString(operand) {
+		return fmt.Errorf("operand %q is neither a JSON literal nor a variable path (quote string literals)", operand)
+	}
+	return nil
+}
+
+// conditionalEdgeEvaluation 一条条件边的求值结果
+type conditionalEdgeEvaluation struct {
+	edge      *ent.WorkflowEdge
+	condition string
+	met       bool
+	err       error
+}
+
+// record 转换为写入节点执行 Extra 的记录
+func (e conditionalEdgeEvaluation) record() map[string]interface{} {
+	record := map[string]interface{}{
+		"edgeId":       utils.Uint64ToString(e.edge.ID),
+		"targetNodeId": utils.Uint64ToString(e.edge.TargetNodeID),
+		"condition":    e.condition,
+		"met":          e.met,
+	}
+	if e.err != nil {
+		record["error"] = e.err.Error()
+	}
+	return record
+}
+
+// selectConditionalEdges 对条件边求值，返回要继续的出边（非条件边和条件成立的条件边，保持原顺序）以及各条件边的求值结果
+func selectConditionalEdges(edges []*ent.WorkflowEdge, vars map[string]interface{}) ([]*ent.WorkflowEdge, []conditionalEdgeEvaluation) {
+	followed := make([]*ent.WorkflowEdge, 0, len(edges))
+	evaluations := make([]conditionalEdgeEvaluation, 0)
+	for _, edge := range edges {
+		if edge.Type != workflowedge.TypeConditional {
+			followed = append(followed, edge)
+			continue
+		}
+
+		evaluation := conditionalEdgeEvaluation{edge: edge, condition: edgeCondition(edge.Data)}
+		if err := validateConditionExpression(evaluation.condition); err != nil {
+			evaluation.err = err
+		} else {
+			evaluation.met, evaluation.err = evaluateTestCondition(evaluation.condition, vars)
+		}
+		evaluations = append(evaluations, evaluation)
+		if evaluation.met {
+			followed = append(followed, edge)
+		}
+	}
+	return followed, evaluations
+}
+
+// SelectConditionalEdges 对出边中的条件边按执行上下文 vars 求值，返回要继续执行的出边。
+// 非条件边总是保留；条件边只在表达式成立时保留。
+// nodeExecutionID 为源节点的执行记录ID，不为0时求值结果写入该执行的 Extra，供执行路径报告展示未成立的条件
+func (WorkflowFuncs) SelectConditionalEdges(ctx context.Context, nodeExecutionID uint64, edges []*ent.WorkflowEdge, vars map[string]interface{}) ([]*ent.WorkflowEdge, error) {
+	followed, evaluations := selectConditionalEdges(edges, vars)
+	if nodeExecutionID == 0 || len(evaluations) == 0 {
+		return followed, nil
+	}
+
+	nodeExecution, err := database.Client.WorkflowNodeExecution.Get(ctx, nodeExecutionID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node execution not found")
+		}
+		return nil, err
+	}
+
+	records := make([]interface{}, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		records = append(records, evaluation.record())
+	}
+	extra := make(map[string]interface{}, len(nodeExecution.Extra)+1)
+	for key, value := range nodeExecution.Extra {
+		extra[key] = value
+	}
+	extra[conditionalEdgesExtraKey] = records
+
+	if err := nodeExecution.Update().SetExtra(extra).Exec(ctx); err != nil {
+		return nil, fmt.Errorf("failed to record conditional edge results: %w", err)
+	}
+	return followed, nil
+}
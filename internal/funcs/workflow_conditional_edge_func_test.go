@@ -0,0 +1,228 @@
+package funcs
+
+import (
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/shared/models"
+)
+
+func conditionalEdge(id, target uint64, condition string) *ent.WorkflowEdge {
+	return &ent.WorkflowEdge{
+		ID:           id,
+		SourceNodeID: 1,
+		TargetNodeID: target,
+		Type:         workflowedge.TypeConditional,
+		Data:         map[string]interface{}{ConditionalEdgeConditionKey: condition},
+	}
+}
+
+func competingConditionalEdges() []*ent.WorkflowEdge {
+	return []*ent.WorkflowEdge{
+		conditionalEdge(21, 2, "score > 0.8"),
+		conditionalEdge(22, 3, "score > 0.5"),
+		conditionalEdge(23, 4, `level == "gold"`),
+		{ID: 24, SourceNodeID: 1, TargetNodeID: 5, Type: workflowedge.TypeDefault},
+	}
+}
+
+func edgeTargets(edges []*ent.WorkflowEdge) []uint64 {
+	targets := make([]uint64, 0, len(edges))
+	for _, edge := range edges {
+		targets = append(targets, edge.TargetNodeID)
+	}
+	return targets
+}
+
+func TestSelectConditionalEdgesCompeting(t *testing.T) {
+	cases := []struct {
+		name    string
+		vars    map[string]interface{}
+		targets []uint64
+	}{
+		{"all conditions met", map[string]interface{}{"score": 0.9, "level": "gold"}, []uint64{2, 3, 4, 5}},
+		{"only lower threshold met", map[string]interface{}{"score": 0.7, "level": "silver"}, []uint64{3, 5}},
+		{"no condition met", map[string]interface{}{"score": 0.1}, []uint64{5}},
+		{"numeric string", map[string]interface{}{"score": "0.85"}, []uint64{2, 3, 5}},
+	}
+	for _, tc := range cases {
+		followed, evaluations := selectConditionalEdges(competingConditionalEdges(), tc.vars)
+		got := edgeTargets(followed)
+		if len(got) != len(tc.targets) {
+			t.Fatalf("%s: expected targets %v, got %v", tc.name, tc.targets, got)
+		}
+		for i := range got {
+			if got[i] != tc.targets[i] {
+				t.Fatalf("%s: expected targets %v, got %v", tc.name, tc.targets, got)
+			}
+		}
+		if len(evaluations) != 3 {
+			t.Fatalf("%s: expected 3 evaluations, got %d", tc.name, len(evaluations))
+		}
+	}
+}
+
+func TestSelectConditionalEdgesEvaluationError(t *testing.T) {
+	edges := []*ent.WorkflowEdge{
+		conditionalEdge(21, 2, "name > 3"),
+		conditionalEdge(22, 3, "score >"),
+	}
+	followed, evaluations := selectConditionalEdges(edges, map[string]interface{}{"name": "alice"})
+	if len(followed) != 0 {
+		t.Fatalf("expected no edge to be followed, got %v", edgeTargets(followed))
+	}
+	for _, evaluation := range evaluations {
+		if evaluation.met || evaluation.err == nil {
+			t.Fatalf("expected edge %d to be unmet with an error, got %+v", evaluation.edge.ID, evaluation)
+		}
+		if record := evaluation.record(); record["error"] == nil || record["met"] != false {
+			t.Fatalf("unexpected record: %v", record)
+		}
+	}
+}
+
+func TestValidateConditionExpression(t *testing.T) {
+	valid := []string{
+		"score > 0.8",
+		"input.score >= 60",
+		`level == "gold"`,
+		"status != done",
+		"approved",
+		"true",
+	}
+	for _, expr := range valid {
+		if err := validateConditionExpression(expr); err != nil {
+			t.Fatalf("expected %q to be valid, got %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"   ",
+		"score >",
+		"> 0.8",
+		"score > 0.5 > 0.1",
+		"a > b == c",
+		"score = 1",
+		"name == hello world",
+	}
+	for _, expr := range invalid {
+		if err := validateConditionExpression(expr); err == nil {
+			t.Fatalf("expected %q to be rejected", expr)
+		}
+	}
+}
+
+func TestValidateEdgeConditionOnSave(t *testing.T) {
+	if err := validateEdgeCondition("default", nil); err != nil {
+		t.Fatalf("non-conditional edges should not need a condition, got %v", err)
+	}
+	if err := validateEdgeCondition("conditional", nil); err == nil {
+		t.Fatalf("expected conditional edge without condition to be rejected")
+	}
+
+	req := &models.CreateWorkflowEdgeRequest{
+		ApplicationID: "1", SourceNodeID: "2", TargetNodeID: "3", Type: "conditional",
+		Data: map[string]interface{}{ConditionalEdgeConditionKey: "score >"},
+	}
+	if _, _, _, err := parseCreateWorkflowEdgeRequest(req); err == nil || !strings.HasPrefix(err.Error(), errInvalidEdgeRequest) {
+		t.Fatalf("expected invalid condition to be rejected, got %v", err)
+	}
+	req.Data[ConditionalEdgeConditionKey] = "score > 0.8"
+	if _, _, _, err := parseCreateWorkflowEdgeRequest(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := &models.BatchSaveWorkflowRequest{
+		NodeTempIDs:   []string{"tmp-1", "tmp-2"},
+		NodesToCreate: []models.CreateWorkflowNodeRequest{{Name: "a"}, {Name: "b"}},
+		EdgeTempIDs:   []string{"edge-1"},
+		EdgesToCreate: []models.CreateWorkflowEdgeRequest{
+			{SourceNodeID: "tmp-1", TargetNodeID: "tmp-2", Type: "conditional", Data: map[string]interface{}{}},
+		},
+	}
+	if _, err := validateBatchSaveRequest(batch); err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected batch save with invalid condition to be rejected, got %v", err)
+	}
+}
+
+func TestValidationIssuesInvalidEdgeCondition(t *testing.T) {
+	nodes := []*ent.WorkflowNode{
+		{ID: 1, Name: "start", Type: workflownode.TypeUserInput, Enabled: true, Config: map[string]interface{}{}},
+		{ID: 2, Name: "end", Type: workflownode.TypeEndNode, Enabled: true, Config: map[string]interface{}{}},
+	}
+	edge := conditionalEdge(21, 2, "score >")
+	graph := buildWorkflowGraph(&ent.WorkflowApplication{ID: 100, StartNodeID: 1}, nodes, []*ent.WorkflowEdge{edge})
+
+	found := false
+	for _, issue := range graph.validationIssues() {
+		if issue.Code == models.ValidationCodeInvalidEdgeCondition && issue.NodeID == "1" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an invalid edge condition issue")
+	}
+}
+
+func conditionalPathTestGraph() *workflowGraph {
+	nodes := make([]*ent.WorkflowNode, 0, 5)
+	for id := uint64(1); id <= 5; id++ {
+		nodes = append(nodes, &ent.WorkflowNode{ID: id, Name: "node", Type: workflownode.TypeLlmCaller, Enabled: true})
+	}
+	return buildWorkflowGraph(&ent.WorkflowApplication{ID: 100}, nodes, competingConditionalEdges())
+}
+
+func TestBuildExecutionPathRecordedUnmetConditions(t *testing.T) {
+	graph := conditionalPathTestGraph()
+	source := &ent.WorkflowNodeExecution{ID: 1000, NodeID: 1, Status: "completed"}
+	_, evaluations := selectConditionalEdges(graph.outgoingEdges[1], map[string]interface{}{"score": 0.7})
+	records := make([]interface{}, 0, len(evaluations))
+	for _, evaluation := range evaluations {
+		records = append(records, evaluation.record())
+	}
+	source.Extra = map[string]interface{}{conditionalEdgesExtraKey: records}
+	executions := []*ent.WorkflowNodeExecution{source,
+		{ID: 1001, NodeID: 3, Status: "completed"},
+		{ID: 1002, NodeID: 5, Status: "completed"},
+	}
+
+	execution := &ent.WorkflowExecution{ExecutionID: "exec-1", ApplicationID: 100, Status: workflowexecution.StatusCompleted}
+	path := buildExecutionPath(execution, graph, executions)
+	if len(path.UnmetConditions) != 2 {
+		t.Fatalf("expected 2 unmet conditions, got %+v", path.UnmetConditions)
+	}
+	if path.UnmetConditions[0].EdgeID != "21" || path.UnmetConditions[0].Condition != "score > 0.8" || path.UnmetConditions[0].Inferred {
+		t.Fatalf("unexpected unmet condition: %+v", path.UnmetConditions[0])
+	}
+	if path.UnmetConditions[1].EdgeID != "23" || path.UnmetConditions[1].TargetNodeID != "4" {
+		t.Fatalf("unexpected unmet condition: %+v", path.UnmetConditions[1])
+	}
+}
+
+func TestBuildExecutionPathInfersUnmetConditions(t *testing.T) {
+	executions := []*ent.WorkflowNodeExecution{
+		{ID: 1000, NodeID: 1, Status: "completed"},
+		{ID: 1001, NodeID: 2, Status: "completed"},
+		{ID: 1002, NodeID: 3, Status: "completed"},
+		{ID: 1003, NodeID: 5, Status: "completed"},
+	}
+	execution := &ent.WorkflowExecution{ExecutionID: "exec-1", ApplicationID: 100, Status: workflowexecution.StatusCompleted}
+	path := buildExecutionPath(execution, conditionalPathTestGraph(), executions)
+	if len(path.UnmetConditions) != 1 {
+		t.Fatalf("expected 1 unmet condition, got %+v", path.UnmetConditions)
+	}
+	if unmet := path.UnmetConditions[0]; unmet.EdgeID != "23" || !unmet.Inferred || unmet.Condition != `level == "gold"` {
+		t.Fatalf("unexpected unmet condition: %+v", unmet)
+	}
+
+	// 失败的节点不会继续沿出边执行，不报告未成立的条件
+	executions[0].Status = "failed"
+	if path := buildExecutionPath(execution, conditionalPathTestGraph(), executions[:1]); len(path.UnmetConditions) != 0 {
+		t.Fatalf("expected no unmet conditions for a failed node, got %+v", path.UnmetConditions)
+	}
+}
@@ -0,0 +1,418 @@
+package funcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// promptPlaceholderPattern 提示词中的占位符，如 {{name}}、{{ input.user.name }}
+var promptPlaceholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.\-]+)\s*\}\}`)
+
+// EstimateWorkflowCost 预估执行工作流的 token 用量和成本
+// 从起始节点遍历图：条件节点取成本最高的分支（最坏路径），循环体按循环上限计次，禁用节点不计成本；
+// 每个 llm_caller 节点按其模型价格和解析占位符后的提示词长度估算，价格表来自 workflow.cost_estimate 配置
+func (WorkflowFuncs) EstimateWorkflowCost(ctx context.Context, applicationID uint64, input map[string]interface{}) (*models.CostEstimate, error) {
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := configs.GetConfig().Workflow.CostEstimate
+	return estimateWorkflowCost(graph, input, &settings), nil
+}
+
+// costEstimator 单次成本预估的计算状态
+type costEstimator struct {
+	graph     *workflowGraph
+	vars      map[string]interface{}
+	settings  *configs.CostEstimateConfig
+	backEdges map[*ent.WorkflowEdge]struct{}
+	loopCount map[uint64]int // 节点ID -> 预估执行次数
+	estimates map[uint64]*models.NodeCostEstimate
+	reached   map[uint64]map[uint64]struct{}
+	warnings  []string
+}
+
+// estimateWorkflowCost 基于已加载的图计算成本预估
+func estimateWorkflowCost(graph *workflowGraph, input map[string]interface{}, settings *configs.CostEstimateConfig) *models.CostEstimate {
+	vars := make(map[string]interface{})
+	if graph.application != nil {
+		for k, v := range graph.application.Variables {
+			vars[k] = v
+		}
+	}
+	for k, v := range input {
+		vars[k] = v
+	}
+
+	e := &costEstimator{
+		graph:     graph,
+		vars:      vars,
+		settings:  settings,
+		backEdges: make(map[*ent.WorkflowEdge]struct{}),
+		loopCount: make(map[uint64]int),
+		estimates: make(map[uint64]*models.NodeCostEstimate),
+		reached:   make(map[uint64]map[uint64]struct{}),
+	}
+
+	roots := e.roots()
+	e.detectLoops(roots)
+
+	path := make(map[uint64]struct{})
+	for _, root := range roots {
+		for id := range e.reach(root) {
+			path[id] = struct{}{}
+		}
+	}
+
+	result := &models.CostEstimate{
+		Currency: settings.Currency,
+		Nodes:    make([]*models.NodeCostEstimate, 0),
+	}
+	if graph.application != nil {
+		result.ApplicationID = utils.Uint64ToString(graph.application.ID)
+	}
+
+	ids := make([]uint64, 0, len(path))
+	for id := range path {
+		ids = append(ids, id)
+	}
+	for _, id := range graph.sortedNodeIDs(ids) {
+		estimate := e.nodeEstimate(id)
+		if estimate == nil {
+			continue
+		}
+		result.Nodes = append(result.Nodes, estimate)
+		result.InputTokens += estimate.InputTokens
+		result.OutputTokens += estimate.OutputTokens
+		result.EstimatedCost += estimate.EstimatedCost
+	}
+	result.TotalTokens = result.InputTokens + result.OutputTokens
+	result.EstimatedCost = roundCost(result.EstimatedCost)
+	result.Warnings = e.warnings
+	return result
+}
+
+// roots 遍历起点：有效的起始节点，否则为所有没有入边的节点
+func (e *costEstimator) roots() []uint64 {
+	if e.graph.application != nil {
+		if _, ok := e.graph.nodes[e.graph.application.StartNodeID]; ok {
+			return []uint64{e.graph.application.StartNodeID}
+		}
+	}
+	roots := make([]uint64, 0)
+	for id := range e.graph.nodes {
+		if len(e.graph.incoming[id]) == 0 {
+			roots = append(roots, id)
+		}
+	}
+	return e.graph.sortedNodeIDs(roots)
+}
+
+// followEdges 执行时会经过的出边：禁用节点透传，启用节点沿所有出边
+func (e *costEstimator) followEdges(id uint64) []*ent.WorkflowEdge {
+	if node := e.graph.nodes[id]; node != nil && !node.Enabled {
+		return e.graph.passThroughEdges(id)
+	}
+	return e.graph.outgoingEdges[id]
+}
+
+// detectLoops 深度优先遍历识别回边，回边两端之间的节点构成循环体，按循环上限累乘执行次数
+func (e *costEstimator) detectLoops(roots []uint64) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[uint64]int, len(e.graph.nodes))
+	stack := make([]uint64, 0)
+	bodies := make(map[uint64]map[uint64]struct{}) // 循环入口 -> 循环体节点
+
+	var visit func(id uint64)
+	visit = func(id uint64) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, edge := range e.followEdges(id) {
+			target := edge.TargetNodeID
+			switch state[target] {
+			case unvisited:
+				visit(target)
+			case visiting:
+				e.backEdges[edge] = struct{}{}
+				body := bodies[target]
+				if body == nil {
+					body = make(map[uint64]struct{})
+					bodies[target] = body
+				}
+				for i := len(stack) - 1; i >= 0; i-- {
+					body[stack[i]] = struct{}{}
+					if stack[i] == target {
+						break
+					}
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+	for _, root := range roots {
+		if state[root] == unvisited {
+			visit(root)
+		}
+	}
+
+	for _, body := range bodies {
+		iterations := e.loopIterations(body)
+		for id := range body {
+			count := e.loopCount[id]
+			if count == 0 {
+				count = 1
+			}
+			e.loopCount[id] = count * iterations
+		}
+	}
+}
+
+// loopIterations 循环体的预估迭代次数：取循环体内 while_loop 节点的 config.max_iterations，未配置时使用默认值
+func (e *costEstimator) loopIterations(body map[uint64]struct{}) int {
+	iterations := 0
+	for id := range body {
+		node := e.graph.nodes[id]
+		if node == nil || node.Type != workflownode.TypeWhileLoop {
+			continue
+		}
+		if n, ok := configInt(node.Config, "max_iterations"); ok && n > iterations {
+			iterations = n
+		}
+	}
+	if iterations <= 0 {
+		iterations = e.settings.DefaultLoopIterations
+	}
+	if iterations <= 0 {
+		iterations = 1
+	}
+	return iterations
+}
+
+// reach 返回从节点出发按最坏路径会执行的节点集合（含自身），条件节点只取成本最高的分支
+func (e *costEstimator) reach(id uint64) map[uint64]struct{} {
+	if set, ok := e.reached[id]; ok {
+		return set
+	}
+	set := map[uint64]struct{}{id: {}}
+	// 先占位，防止异常数据导致无限递归
+	e.reached[id] = set
+
+	node := e.graph.nodes[id]
+	edges := make([]*ent.WorkflowEdge, 0)
+	for _, edge := range e.followEdges(id) {
+		if _, back := e.backEdges[edge]; !back {
+			edges = append(edges, edge)
+		}
+	}
+
+	if node != nil && node.Enabled && node.Type == workflownode.TypeConditionChecker {
+		branches := make(map[string]map[uint64]struct{})
+		names := make([]string, 0)
+		for _, edge := range edges {
+			name := edge.BranchName
+			if IsDefaultConditionBranch(name) {
+				name = ConditionBranchDefault
+			}
+			if _, ok := branches[name]; !ok {
+				branches[name] = make(map[uint64]struct{})
+				names = append(names, name)
+			}
+			for member := range e.reach(edge.TargetNodeID) {
+				branches[name][member] = struct{}{}
+			}
+		}
+
+		var worst map[uint64]struct{}
+		worstCost, worstTokens := -1.0, -1
+		for _, name := range names {
+			cost, tokens := e.setCost(branches[name])
+			if cost > worstCost || (cost == worstCost && tokens > worstTokens) {
+				worst, worstCost, worstTokens = branches[name], cost, tokens
+			}
+		}
+		for member := range worst {
+			set[member] = struct{}{}
+		}
+		return set
+	}
+
+	for _, edge := range edges {
+		for member := range e.reach(edge.TargetNodeID) {
+			set[member] = struct{}{}
+		}
+	}
+	return set
+}
+
+// setCost 节点集合的总成本和总token数
+func (e *costEstimator) setCost(set map[uint64]struct{}) (float64, int) {
+	cost, tokens := 0.0, 0
+	for id := range set {
+		if estimate := e.nodeEstimate(id); estimate != nil {
+			cost += estimate.EstimatedCost
+			tokens += estimate.InputTokens + estimate.OutputTokens
+		}
+	}
+	return cost, tokens
+}
+
+// nodeEstimate 单个节点的成本预估，非 LLM 节点或禁用节点返回 nil
+func (e *costEstimator) nodeEstimate(id uint64) *models.NodeCostEstimate {
+	if estimate, ok := e.estimates[id]; ok {
+		return estimate
+	}
+
+	node := e.graph.nodes[id]
+	if node == nil || !node.Enabled || node.Type != workflownode.TypeLlmCaller {
+		e.estimates[id] = nil
+		return nil
+	}
+
+	model, _ := node.Config["model"].(string)
+	if strings.TrimSpace(model) == "" {
+		model = e.settings.DefaultModel
+	}
+
+	prompt := resolvePromptTemplate(node.Prompt, e.vars)
+	if systemPrompt, ok := node.Config["system_prompt"].(string); ok {
+		prompt += resolvePromptTemplate(systemPrompt, e.vars)
+	}
+
+	outputTokens, ok := configInt(node.Config, "max_tokens")
+	if !ok || outputTokens <= 0 {
+		outputTokens = e.settings.DefaultOutputTokens
+	}
+
+	iterations := e.loopCount[id]
+	if iterations == 0 {
+		iterations = 1
+	}
+
+	estimate := &models.NodeCostEstimate{
+		NodeID:       utils.Uint64ToString(id),
+		NodeName:     node.Name,
+		Model:        model,
+		Iterations:   iterations,
+		InputTokens:  estimateTokenCount(prompt) * iterations,
+		OutputTokens: outputTokens * iterations,
+	}
+
+	pricing, found := e.settings.Pricing[strings.ToLower(model)]
+	estimate.PricingFound = found
+	if found {
+		estimate.EstimatedCost = roundCost((float64(estimate.InputTokens)*pricing.Input + float64(estimate.OutputTokens)*pricing.Output) / 1000)
+	} else {
+		e.warnings = appendUniqueWarning(e.warnings, fmt.Sprintf("模型 %s 未配置价格，成本按0计算", model))
+	}
+
+	e.estimates[id] = estimate
+	return estimate
+}
+
+// estimateTokenCount 粗略估算文本的token数：ASCII字符约4个一个token，其他字符（如中文）按每字一个token计
+func estimateTokenCount(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < 128 {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// resolvePromptTemplate 将提示词中的 {{key}} 占位符替换为变量值，支持点号路径及 input./variables. 前缀，无法解析的占位符保持原样
+func resolvePromptTemplate(prompt string, vars map[string]interface{}) string {
+	return promptPlaceholderPattern.ReplaceAllStringFunc(prompt, func(match string) string {
+		path := promptPlaceholderPattern.FindStringSubmatch(match)[1]
+		value, ok := lookupTemplateValue(vars, strings.Split(path, "."))
+		if !ok {
+			return match
+		}
+		if s, isString := value.(string); isString {
+			return s
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return match
+		}
+		return string(encoded)
+	})
+}
+
+// lookupTemplateValue 按路径查找变量值，路径以 input/variables 开头时同时尝试去掉该前缀
+func lookupTemplateValue(vars map[string]interface{}, path []string) (interface{}, bool) {
+	if value, ok := lookupPath(vars, path); ok {
+		return value, true
+	}
+	if len(path) > 1 && (path[0] == "input" || path[0] == "variables") {
+		return lookupPath(vars, path[1:])
+	}
+	return nil, false
+}
+
+func lookupPath(vars map[string]interface{}, path []string) (interface{}, bool) {
+	var current interface{} = vars
+	for _, segment := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// configInt 从节点配置中读取整数，兼容JSON反序列化出的 float64 和字符串
+func configInt(config map[string]interface{}, key string) (int, bool) {
+	switch v := config[key].(type) {
+	case float64:
+		return int(v), true
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case json.Number:
+		n, err := v.Int64()
+		return int(n), err == nil
+	case string:
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// roundCost 成本保留6位小数
+func roundCost(cost float64) float64 {
+	return math.Round(cost*1e6) / 1e6
+}
+
+func appendUniqueWarning(warnings []string, warning string) []string {
+	for _, w := range warnings {
+		if w == warning {
+			return warnings
+		}
+	}
+	return append(warnings, warning)
+}
@@ -0,0 +1,73 @@
+package funcs
+
+import (
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+)
+
+func TestEstimateWorkflowCostWorstCaseAndLoops(t *testing.T) {
+	settings := &configs.CostEstimateConfig{
+		Currency:              "USD",
+		DefaultModel:          "cheap",
+		DefaultOutputTokens:   100,
+		DefaultLoopIterations: 10,
+		Pricing: map[string]configs.LLMPricing{
+			"cheap":     {Input: 1, Output: 1},
+			"expensive": {Input: 10, Output: 10},
+		},
+	}
+
+	// 1(起始) -> 2(条件) -yes-> 3(cheap LLM)；-default-> 4(expensive LLM)
+	// 4 -> 5(循环，最多3次) -> 6(LLM，未配置价格的模型) -> 5（回边）
+	nodes := []*ent.WorkflowNode{
+		{ID: 1, Enabled: true},
+		{ID: 2, Enabled: true, Type: workflownode.TypeConditionChecker},
+		{ID: 3, Enabled: true, Type: workflownode.TypeLlmCaller, Prompt: "hi {{name}}"},
+		{ID: 4, Enabled: true, Type: workflownode.TypeLlmCaller, Prompt: "hello", Config: map[string]interface{}{"model": "Expensive", "max_tokens": float64(200)}},
+		{ID: 5, Enabled: true, Type: workflownode.TypeWhileLoop, Config: map[string]interface{}{"max_iterations": float64(3)}},
+		{ID: 6, Enabled: true, Type: workflownode.TypeLlmCaller, Prompt: "loop", Config: map[string]interface{}{"model": "unknown"}},
+	}
+	edges := []*ent.WorkflowEdge{
+		{SourceNodeID: 1, TargetNodeID: 2},
+		{SourceNodeID: 2, TargetNodeID: 3, BranchName: "yes"},
+		{SourceNodeID: 2, TargetNodeID: 4, BranchName: ConditionBranchDefault},
+		{SourceNodeID: 4, TargetNodeID: 5},
+		{SourceNodeID: 5, TargetNodeID: 6},
+		{SourceNodeID: 6, TargetNodeID: 5},
+	}
+	graph := buildWorkflowGraph(&ent.WorkflowApplication{ID: 9, StartNodeID: 1}, nodes, edges)
+
+	estimate := estimateWorkflowCost(graph, map[string]interface{}{"name": "bob"}, settings)
+
+	if len(estimate.Nodes) != 2 || estimate.Nodes[0].NodeID != "4" || estimate.Nodes[1].NodeID != "6" {
+		t.Fatalf("expected worst-case path through nodes 4 and 6, got %+v", estimate.Nodes)
+	}
+	if got := estimate.Nodes[1].Iterations; got != 3 {
+		t.Fatalf("expected loop body to run 3 times, got %d", got)
+	}
+	if estimate.Nodes[1].PricingFound || len(estimate.Warnings) != 1 {
+		t.Fatalf("expected missing pricing warning, got %+v", estimate.Warnings)
+	}
+
+	// 节点4: "hello" -> 2 token 输入，200 输出，单价10/1k
+	if want := (2.0*10 + 200.0*10) / 1000; estimate.EstimatedCost != want {
+		t.Fatalf("expected cost %v, got %v", want, estimate.EstimatedCost)
+	}
+	if want := 2 + 200 + 3*(1+100); estimate.TotalTokens != want {
+		t.Fatalf("expected %d total tokens, got %d", want, estimate.TotalTokens)
+	}
+}
+
+func TestResolvePromptTemplate(t *testing.T) {
+	vars := map[string]interface{}{
+		"user": map[string]interface{}{"name": "alice"},
+		"tags": []interface{}{"a", "b"},
+	}
+	got := resolvePromptTemplate("{{ input.user.name }} {{tags}} {{missing}}", vars)
+	if want := `alice ["a","b"] {{missing}}`; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
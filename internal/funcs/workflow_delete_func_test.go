@@ -0,0 +1,158 @@
+package funcs
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/database/mixins"
+	"go-backend/pkg/configs"
+
+	"entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newWorkflowDeleteTestClient 创建基于内存 sqlite 的客户端并建表
+func newWorkflowDeleteTestClient(t *testing.T) (*ent.Client, *stdsql.DB) {
+	t.Helper()
+	drv, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	client := ent.NewClient(ent.Driver(drv))
+	t.Cleanup(func() { client.Close() })
+
+	if err := client.Schema.Create(context.Background()); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	return client, drv.DB()
+}
+
+// insertTestRow 直接插入一行数据（绕过 ID 生成钩子），未指定的非空列按类型填充零值
+func insertTestRow(t *testing.T, db *stdsql.DB, table string, values map[string]any) {
+	t.Helper()
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(`%s`)", table))
+	if err != nil {
+		t.Fatalf("failed to inspect %s: %v", table, err)
+	}
+	defer rows.Close()
+
+	columns := make([]string, 0)
+	args := make([]any, 0)
+	for rows.Next() {
+		var (
+			cid, notNull, pk int
+			name, colType    string
+			defaultValue     any
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &defaultValue, &pk); err != nil {
+			t.Fatalf("failed to scan column: %v", err)
+		}
+		value, ok := values[name]
+		if !ok {
+			if notNull == 0 || defaultValue != nil {
+				continue
+			}
+			switch colType = strings.ToLower(colType); {
+			case strings.Contains(colType, "int"), strings.Contains(colType, "bool"), strings.Contains(colType, "real"):
+				value = 0
+			case strings.Contains(colType, "json"):
+				value = "{}"
+			case strings.Contains(colType, "datetime"):
+				value = "2024-01-01 00:00:00"
+			default:
+				value = ""
+			}
+		}
+		columns = append(columns, "`"+name+"`")
+		args = append(args, value)
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	query := fmt.Sprintf("INSERT INTO `%s` (%s) VALUES (%s)", table, strings.Join(columns, ","), placeholders)
+	if _, err := db.Exec(query, args...); err != nil {
+		t.Fatalf("failed to insert into %s: %v", table, err)
+	}
+}
+
+// seedWorkflowApplication 插入一个带两个节点、一条边、一个调度和一个版本的应用
+func seedWorkflowApplication(t *testing.T, db *stdsql.DB, appID uint64) {
+	t.Helper()
+	insertTestRow(t, db, "workflow_applications", map[string]any{"id": appID, "name": fmt.Sprintf("app-%d", appID), "client_secret": fmt.Sprintf("secret-%d", appID), "status": "draft"})
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": appID*10 + 1, "application_id": appID, "name": "start", "node_key": "start", "type": "user_input"})
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": appID*10 + 2, "application_id": appID, "name": "end", "node_key": "end", "type": "end_node"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": appID*10 + 3, "application_id": appID, "source_node_id": appID*10 + 1, "target_node_id": appID*10 + 2, "edge_key": "e1", "type": "default"})
+	insertTestRow(t, db, "workflow_schedules", map[string]any{"id": appID*10 + 4, "application_id": appID, "name": "nightly", "cron_expr": "0 0 * * *", "timezone": "UTC"})
+	insertTestRow(t, db, "workflow_versions", map[string]any{"id": appID*10 + 5, "application_id": appID, "version": 1})
+}
+
+func TestDeleteWorkflowApplicationCascadesOwnedRows(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+
+	scheduleIDs, err := deleteWorkflowApplication(ctx, client, 1, configs.ApplicationDeleteModeCascade)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scheduleIDs) != 1 || scheduleIDs[0] != 14 {
+		t.Fatalf("unexpected deleted schedules: %v", scheduleIDs)
+	}
+
+	// 默认查询不再返回被删除应用的节点、边和调度
+	if n := client.WorkflowNode.Query().CountX(ctx); n != 2 {
+		t.Fatalf("expected only the other application's 2 nodes, got %d", n)
+	}
+	if n := client.WorkflowEdge.Query().CountX(ctx); n != 1 {
+		t.Fatalf("expected only the other application's edge, got %d", n)
+	}
+	if n := client.WorkflowSchedule.Query().CountX(ctx); n != 1 {
+		t.Fatalf("expected only the other application's schedule, got %d", n)
+	}
+
+	// 行仍然存在，只是被标记为软删除
+	skipCtx := mixins.SkipSoftDelete(ctx)
+	for table, total := range map[string]int{"workflow_nodes": 4, "workflow_edges": 2, "workflow_schedules": 2, "workflow_applications": 2} {
+		var rowCount, deletedCount int
+		if err := db.QueryRow(fmt.Sprintf("SELECT COUNT(*), COUNT(delete_time) FROM `%s`", table)).Scan(&rowCount, &deletedCount); err != nil {
+			t.Fatalf("failed to count %s: %v", table, err)
+		}
+		if rowCount != total || deletedCount != total/2 {
+			t.Fatalf("%s: expected %d rows with %d soft-deleted, got %d and %d", table, total, total/2, rowCount, deletedCount)
+		}
+	}
+	if n := client.WorkflowNode.Query().CountX(skipCtx); n != 4 {
+		t.Fatalf("expected soft-deleted nodes to remain queryable when skipping soft delete, got %d", n)
+	}
+
+	// 版本保留用于审计
+	if n := client.WorkflowVersion.Query().CountX(ctx); n != 2 {
+		t.Fatalf("expected versions to be retained, got %d", n)
+	}
+}
+
+func TestDeleteWorkflowApplicationBlockMode(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_applications", map[string]any{"id": uint64(3), "name": "empty", "client_secret": "secret-3", "status": "draft"})
+
+	_, err := deleteWorkflowApplication(ctx, client, 1, configs.ApplicationDeleteModeBlock)
+	if err == nil || !strings.HasPrefix(err.Error(), errWorkflowApplicationNotEmpty) {
+		t.Fatalf("expected non-empty application to be blocked, got %v", err)
+	}
+	if n := client.WorkflowNode.Query().CountX(ctx); n != 2 {
+		t.Fatalf("blocked delete must not touch nodes, got %d", n)
+	}
+
+	if _, err := deleteWorkflowApplication(ctx, client, 3, configs.ApplicationDeleteModeBlock); err != nil {
+		t.Fatalf("empty application should be deletable: %v", err)
+	}
+	if _, err := deleteWorkflowApplication(ctx, client, 3, configs.ApplicationDeleteModeBlock); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found for deleted application, got %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+)
+
+// 工作流图导出格式
+const (
+	DiagramFormatMermaid = "mermaid" // Mermaid flowchart
+	DiagramFormatDOT     = "dot"     // Graphviz DOT
+)
+
+// errUnsupportedDiagramFormat 不支持的导出格式
+const errUnsupportedDiagramFormat = "unsupported diagram format"
+
+// IsUnsupportedDiagramFormat 判断错误是否为不支持的导出格式
+func IsUnsupportedDiagramFormat(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errUnsupportedDiagramFormat)
+}
+
+// diagramColorPattern 允许输出到图中的节点颜色：#RGB、#RRGGBB、#RRGGBBAA 或颜色名，其他值忽略，避免注入图语法
+var diagramColorPattern = regexp.MustCompile(`^(#[0-9A-Fa-f]{3}|#[0-9A-Fa-f]{6}|#[0-9A-Fa-f]{8}|[A-Za-z]+)$`)
+
+// RenderWorkflowDiagram 将应用的节点和边导出为 Mermaid flowchart 或 Graphviz DOT 文本。
+// 节点标签为“名称 (类型)”，边标签为分支名称（没有分支名称时使用边标签），节点按其颜色填充
+func (WorkflowFuncs) RenderWorkflowDiagram(ctx context.Context, applicationID uint64, format string) (string, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = DiagramFormatMermaid
+	}
+	if format != DiagramFormatMermaid && format != DiagramFormatDOT {
+		return "", fmt.Errorf("%s: %q", errUnsupportedDiagramFormat, format)
+	}
+
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return "", err
+	}
+	if format == DiagramFormatDOT {
+		return renderDOT(graph), nil
+	}
+	return renderMermaid(graph), nil
+}
+
+// diagramNodes 返回排序后的节点（起始节点优先）
+func (g *workflowGraph) diagramNodes() []*ent.WorkflowNode {
+	ids := make([]uint64, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+	nodes := make([]*ent.WorkflowNode, 0, len(ids))
+	for _, id := range g.sortedNodeIDs(ids) {
+		nodes = append(nodes, g.nodes[id])
+	}
+	return nodes
+}
+
+// diagramEdges 返回按ID排序的边
+func (g *workflowGraph) diagramEdges() []*ent.WorkflowEdge {
+	edges := make([]*ent.WorkflowEdge, 0)
+	for _, outgoing := range g.outgoingEdges {
+		edges = append(edges, outgoing...)
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].ID < edges[j].ID })
+	return edges
+}
+
+// diagramNodeID 图中节点的标识符，只包含字母和数字
+func diagramNodeID(id uint64) string {
+	return fmt.Sprintf("n%d", id)
+}
+
+// diagramEdgeLabel 边的标签：分支名称优先，其次为边标签
+func diagramEdgeLabel(edge *ent.WorkflowEdge) string {
+	if edge.BranchName != "" {
+		return edge.BranchName
+	}
+	return edge.Label
+}
+
+// diagramNodeColor 返回可安全输出的节点颜色，不合法时返回空
+func diagramNodeColor(node *ent.WorkflowNode) string {
+	color := strings.TrimSpace(node.Color)
+	if !diagramColorPattern.MatchString(color) {
+		return ""
+	}
+	return color
+}
+
+// renderMermaid 输出 Mermaid flowchart，开始/结束节点为圆角，条件节点为菱形
+func renderMermaid(g *workflowGraph) string {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+
+	var styles []string
+	for _, node := range g.diagramNodes() {
+		id := diagramNodeID(node.ID)
+		label := escapeMermaidLabel(node.Name) + "<br/>(" + escapeMermaidLabel(string(node.Type)) + ")"
+
+		left, right := "[", "]"
+		switch {
+		case node.Type == workflownode.TypeConditionChecker:
+			left, right = "{", "}"
+		case node.Type == workflownode.TypeEndNode || (g.application != nil && node.ID == g.application.StartNodeID):
+			left, right = "([", "])"
+		}
+		fmt.Fprintf(&b, "    %s%s\"%s\"%s\n", id, left, label, right)
+
+		if color := diagramNodeColor(node); color != "" {
+			styles = append(styles, fmt.Sprintf("    style %s fill:%s", id, color))
+		}
+	}
+
+	for _, edge := range g.diagramEdges() {
+		source, target := diagramNodeID(edge.SourceNodeID), diagramNodeID(edge.TargetNodeID)
+		if label := diagramEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, "    %s -->|\"%s\"| %s\n", source, escapeMermaidLabel(label), target)
+		} else {
+			fmt.Fprintf(&b, "    %s --> %s\n", source, target)
+		}
+	}
+
+	for _, style := range styles {
+		b.WriteString(style)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// mermaidEscaper 将 Mermaid 标签中的特殊字符替换为实体编码（单次替换，生成的实体编码不会被再次转义）
+var mermaidEscaper = strings.NewReplacer(
+	"#", "#35;",
+	"\"", "#quot;",
+	"<", "#lt;",
+	">", "#gt;",
+	"|", "#124;",
+	"\r\n", "<br/>",
+	"\n", "<br/>",
+	"\r", "<br/>",
+)
+
+// escapeMermaidLabel 转义 Mermaid 带引号标签中的特殊字符
+func escapeMermaidLabel(value string) string {
+	return mermaidEscaper.Replace(value)
+}
+
+// renderDOT 输出 Graphviz DOT，开始/结束节点为椭圆，条件节点为菱形
+func renderDOT(g *workflowGraph) string {
+	var b strings.Builder
+	name := "workflow"
+	if g.application != nil && g.application.Name != "" {
+		name = g.application.Name
+	}
+	fmt.Fprintf(&b, "digraph \"%s\" {\n", escapeDOTString(name))
+	b.WriteString("    rankdir=TB;\n")
+	b.WriteString("    node [shape=box, style=\"rounded\"];\n")
+
+	for _, node := range g.diagramNodes() {
+		attrs := []string{fmt.Sprintf("label=\"%s\\n(%s)\"", escapeDOTString(node.Name), escapeDOTString(string(node.Type)))}
+		switch {
+		case node.Type == workflownode.TypeConditionChecker:
+			attrs = append(attrs, "shape=diamond")
+		case node.Type == workflownode.TypeEndNode || (g.application != nil && node.ID == g.application.StartNodeID):
+			attrs = append(attrs, "shape=ellipse")
+		}
+		if color := diagramNodeColor(node); color != "" {
+			attrs = append(attrs, "style=\"rounded,filled\"", fmt.Sprintf("fillcolor=\"%s\"", color))
+		}
+		fmt.Fprintf(&b, "    %s [%s];\n", diagramNodeID(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range g.diagramEdges() {
+		source, target := diagramNodeID(edge.SourceNodeID), diagramNodeID(edge.TargetNodeID)
+		if label := diagramEdgeLabel(edge); label != "" {
+			fmt.Fprintf(&b, "    %s -> %s [label=\"%s\"];\n", source, target, escapeDOTString(label))
+		} else {
+			fmt.Fprintf(&b, "    %s -> %s;\n", source, target)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// dotEscaper 转义 DOT 双引号字符串中的反斜杠、引号和换行
+var dotEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"\"", "\\\"",
+	"\r\n", "\\n",
+	"\n", "\\n",
+	"\r", "\\n",
+)
+
+// escapeDOTString 转义 DOT 双引号字符串
+func escapeDOTString(value string) string {
+	return dotEscaper.Replace(value)
+}
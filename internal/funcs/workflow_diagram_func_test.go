@@ -0,0 +1,99 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-backend/pkg/database"
+)
+
+// setDiagramTestClient 写入一个包含条件分支和特殊字符标签的应用
+func setDiagramTestClient(t *testing.T) {
+	t.Helper()
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	insertTestRow(t, db, "workflow_applications", map[string]any{"id": 1, "name": `My "flow"`, "client_secret": "s", "status": "draft", "start_node_id": 11})
+	nodes := []map[string]any{
+		{"id": 11, "name": "Start", "node_key": "start", "type": "user_input", "color": "#ff0000"},
+		{"id": 12, "name": `Is "big" <#1>?`, "node_key": "check", "type": "condition_checker", "color": "red; stroke:#000"},
+		{"id": 13, "name": "Line1\nLine2 | a\\b", "node_key": "end", "type": "end_node"},
+	}
+	for _, node := range nodes {
+		node["application_id"] = 1
+		insertTestRow(t, db, "workflow_nodes", node)
+	}
+	edges := []map[string]any{
+		{"id": 21, "source_node_id": 11, "target_node_id": 12, "edge_key": "e1"},
+		{"id": 22, "source_node_id": 12, "target_node_id": 13, "edge_key": "e2", "branch_name": `yes "x"`},
+		{"id": 23, "source_node_id": 12, "target_node_id": 13, "edge_key": "e3", "label": "fallback"},
+	}
+	for _, edge := range edges {
+		edge["application_id"] = 1
+		edge["type"] = "default"
+		insertTestRow(t, db, "workflow_edges", edge)
+	}
+}
+
+func TestRenderWorkflowDiagramMermaid(t *testing.T) {
+	setDiagramTestClient(t)
+
+	got, err := WorkflowFuncs{}.RenderWorkflowDiagram(context.Background(), 1, "")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := strings.Join([]string{
+		"flowchart TD",
+		`    n11(["Start<br/>(user_input)"])`,
+		`    n12{"Is #quot;big#quot; #lt;#35;1#gt;?<br/>(condition_checker)"}`,
+		`    n13(["Line1<br/>Line2 #124; a\b<br/>(end_node)"])`,
+		"    n11 --> n12",
+		`    n12 -->|"yes #quot;x#quot;"| n13`,
+		`    n12 -->|"fallback"| n13`,
+		"    style n11 fill:#ff0000",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected mermaid output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderWorkflowDiagramDOT(t *testing.T) {
+	setDiagramTestClient(t)
+
+	got, err := WorkflowFuncs{}.RenderWorkflowDiagram(context.Background(), 1, "DOT")
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	want := strings.Join([]string{
+		`digraph "My \"flow\"" {`,
+		"    rankdir=TB;",
+		`    node [shape=box, style="rounded"];`,
+		`    n11 [label="Start\n(user_input)", shape=ellipse, style="rounded,filled", fillcolor="#ff0000"];`,
+		`    n12 [label="Is \"big\" <#1>?\n(condition_checker)", shape=diamond];`,
+		`    n13 [label="Line1\nLine2 | a\\b\n(end_node)", shape=ellipse];`,
+		"    n11 -> n12;",
+		`    n12 -> n13 [label="yes \"x\""];`,
+		`    n12 -> n13 [label="fallback"];`,
+		"}",
+		"",
+	}, "\n")
+	if got != want {
+		t.Fatalf("unexpected dot output:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderWorkflowDiagramErrors(t *testing.T) {
+	setDiagramTestClient(t)
+	ctx := context.Background()
+
+	if _, err := (WorkflowFuncs{}).RenderWorkflowDiagram(ctx, 1, "svg"); !IsUnsupportedDiagramFormat(err) {
+		t.Fatalf("expected unsupported format error, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).RenderWorkflowDiagram(ctx, 99, "mermaid"); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// ReconnectEdge 将边的源端和/或目标端移动到其他节点，原地更新以保留边ID、样式和数据（编辑器的撤销历史依赖边ID）
+// newSourceNodeID、newTargetNodeID 为 nil 表示该端保持不变；移动后的连接按与创建边相同的规则重新校验
+func (WorkflowFuncs) ReconnectEdge(ctx context.Context, edgeID uint64, newSourceNodeID, newTargetNodeID *uint64) (*models.WorkflowEdgeResponse, error) {
+	if newSourceNodeID == nil && newTargetNodeID == nil {
+		return nil, fmt.Errorf("%s: source or target is required", errInvalidEdgeRequest)
+	}
+
+	edge, err := database.Client.WorkflowEdge.Get(ctx, edgeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow edge not found")
+		}
+		return nil, err
+	}
+
+	sourceNodeID, targetNodeID := edge.SourceNodeID, edge.TargetNodeID
+	if newSourceNodeID != nil {
+		sourceNodeID = *newSourceNodeID
+	}
+	if newTargetNodeID != nil {
+		targetNodeID = *newTargetNodeID
+	}
+	if sourceNodeID == edge.SourceNodeID && targetNodeID == edge.TargetNodeID {
+		return WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge), nil
+	}
+
+	sourceNode, err := database.Client.WorkflowNode.Get(ctx, sourceNodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%s: source node %d not found", errInvalidEdgeEndpoints, sourceNodeID)
+		}
+		return nil, err
+	}
+	targetNode, err := database.Client.WorkflowNode.Get(ctx, targetNodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%s: target node %d not found", errInvalidEdgeEndpoints, targetNodeID)
+		}
+		return nil, err
+	}
+	if err := validateEdgeEndpoints(edge.ApplicationID, sourceNode, targetNode); err != nil {
+		return nil, err
+	}
+	if err := validateEdgeReconnection(edge, sourceNode); err != nil {
+		return nil, err
+	}
+
+	err = database.Client.WorkflowEdge.UpdateOneID(edgeID).
+		SetSourceNodeID(sourceNodeID).
+		SetTargetNodeID(targetNodeID).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow edge not found")
+		}
+		return nil, err
+	}
+
+	return WorkflowFuncs{}.GetWorkflowEdgeByID(ctx, edgeID)
+}
+
+// validateEdgeReconnection 校验移动后的源节点能否承载这条边：
+// 结束节点没有出边；分支边（超时分支除外）只能从条件节点出发，条件节点的出边必须带分支名称，否则执行时永远不会被选中
+func validateEdgeReconnection(edge *ent.WorkflowEdge, source *ent.WorkflowNode) error {
+	if source.Type == workflownode.TypeEndNode {
+		return fmt.Errorf("%s: end node %d cannot have outgoing edges", errInvalidEdgeEndpoints, source.ID)
+	}
+
+	isConditionSource := source.Type == workflownode.TypeConditionChecker
+	switch {
+	case edge.BranchName == NodeTimeoutBranch:
+		return nil
+	case edge.BranchName != "" && !isConditionSource:
+		return fmt.Errorf("%s: branch %q can only leave a condition_checker node, node %d is %s",
+			errInvalidEdgeEndpoints, edge.BranchName, source.ID, source.Type)
+	case edge.BranchName == "" && isConditionSource:
+		return fmt.Errorf("%s: edges leaving condition_checker node %d must have a branch name",
+			errInvalidEdgeEndpoints, source.ID)
+	}
+	return nil
+}
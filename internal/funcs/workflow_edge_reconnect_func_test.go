@@ -0,0 +1,75 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-backend/pkg/database"
+)
+
+func TestReconnectEdge(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 14, "application_id": 1, "name": "process", "node_key": "process", "type": "data_processor"})
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 15, "application_id": 1, "name": "check", "node_key": "check", "type": "condition_checker"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 16, "application_id": 1, "source_node_id": 11, "target_node_id": 14, "edge_key": "e16",
+		"type": "default", "style": `{"stroke":"red"}`, "data": `{"note":"keep"}`})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 17, "application_id": 1, "source_node_id": 15, "target_node_id": 14, "edge_key": "e17",
+		"type": "branch", "branch_name": "yes"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 18, "application_id": 1, "source_node_id": 14, "target_node_id": 12, "edge_key": "e18",
+		"type": "default", "branch_name": NodeTimeoutBranch})
+
+	ctx := context.Background()
+	ptr := func(id uint64) *uint64 { return &id }
+
+	// 只移动目标端：边ID、样式和数据保持不变
+	edge, err := WorkflowFuncs{}.ReconnectEdge(ctx, 16, nil, ptr(12))
+	if err != nil {
+		t.Fatalf("reconnect failed: %v", err)
+	}
+	if edge.ID != "16" || edge.SourceNodeID != "11" || edge.TargetNodeID != "12" || edge.Style["stroke"] != "red" || edge.Data["note"] != "keep" {
+		t.Fatalf("unexpected reconnected edge: %+v", edge)
+	}
+
+	// 同时移动两端
+	edge, err = WorkflowFuncs{}.ReconnectEdge(ctx, 16, ptr(14), ptr(15))
+	if err != nil || edge.SourceNodeID != "14" || edge.TargetNodeID != "15" {
+		t.Fatalf("unexpected reconnect result: %+v (%v)", edge, err)
+	}
+
+	// 超时分支可以从任意节点出发
+	if _, err := (WorkflowFuncs{}).ReconnectEdge(ctx, 18, ptr(11), nil); err != nil {
+		t.Fatalf("timeout branch should be movable: %v", err)
+	}
+
+	for name, tc := range map[string]struct {
+		edgeID         uint64
+		source, target *uint64
+		wantPrefix     string
+	}{
+		"no endpoints":           {16, nil, nil, errInvalidEdgeRequest},
+		"end node as source":     {16, ptr(12), nil, errInvalidEdgeEndpoints},
+		"cross application":      {16, nil, ptr(21), errInvalidEdgeEndpoints},
+		"missing node":           {16, nil, ptr(999), errInvalidEdgeEndpoints},
+		"self loop":              {16, ptr(14), ptr(14), errInvalidEdgeEndpoints},
+		"branch off condition":   {17, ptr(11), nil, errInvalidEdgeEndpoints},
+		"unnamed from condition": {16, ptr(15), ptr(14), errInvalidEdgeEndpoints},
+		"edge not found":         {999, ptr(11), nil, "workflow edge not found"},
+	} {
+		if _, err := (WorkflowFuncs{}).ReconnectEdge(ctx, tc.edgeID, tc.source, tc.target); err == nil || !strings.HasPrefix(err.Error(), tc.wantPrefix) {
+			t.Fatalf("%s: expected error with prefix %q, got %v", name, tc.wantPrefix, err)
+		}
+	}
+
+	// 校验失败时边保持原样
+	current, err := WorkflowFuncs{}.GetWorkflowEdgeByID(ctx, 17)
+	if err != nil || current.SourceNodeID != "15" || current.TargetNodeID != "14" {
+		t.Fatalf("rejected reconnect should not modify the edge: %+v (%v)", current, err)
+	}
+}
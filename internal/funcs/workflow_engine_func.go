@@ -0,0 +1,135 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/shared/models"
+)
+
+// 进程内执行引擎：
+//
+// newWorkflowExecutionRunner 返回的进程内执行器作为执行队列的默认执行器，按执行的快照从应用的开始节点依次运行节点：
+// 普通节点沿所有非 timeout 出边继续，条件边按当前节点输出求值；条件节点沿命中的分支（或默认分支）继续；
+// 节点超时时沿 timeout 分支继续，没有 timeout 分支时 RunNode 已将整个执行标记为失败。
+// 禁用的节点透传并记录为 skipped。节点失败时整个执行失败，所有节点运行完成后以最后一个结束节点的输出完成执行。
+
+// workflowEngineMaxSteps 一次执行最多运行的节点次数，防止环路导致执行无法结束
+const workflowEngineMaxSteps = 1000
+
+// workflowEngineStep 待运行的节点及其输入
+type workflowEngineStep struct {
+	nodeID uint64
+	input  map[string]interface{}
+}
+
+// newWorkflowExecutionRunner 创建进程内执行引擎，节点未配置超时时间时使用 defaultTimeout
+func newWorkflowExecutionRunner(defaultTimeout time.Duration) WorkflowExecutionRunner {
+	return func(ctx context.Context, executionID uint64) error {
+		return runWorkflowExecution(ctx, executionID, defaultTimeout)
+	}
+}
+
+// runWorkflowExecution 运行一次排队的执行直到结束，返回错误时由执行队列将执行标记为失败
+func runWorkflowExecution(ctx context.Context, executionID uint64, defaultTimeout time.Duration) error {
+	execution, err := WorkflowFuncs{}.StartWorkflowExecution(ctx, executionID)
+	if err != nil {
+		return err
+	}
+	graph, err := loadExecutionGraph(ctx, execution)
+	if err != nil {
+		return err
+	}
+
+	startNodeID := graph.application.StartNodeID
+	if _, ok := graph.nodes[startNodeID]; !ok {
+		return fmt.Errorf("workflow application has no start node")
+	}
+
+	queue := []workflowEngineStep{{nodeID: startNodeID, input: execution.Input}}
+	var output map[string]interface{}
+	for steps := 0; len(queue) > 0; steps++ {
+		if steps >= workflowEngineMaxSteps {
+			return fmt.Errorf("workflow execution exceeded %d node runs", workflowEngineMaxSteps)
+		}
+		step := queue[0]
+		queue = queue[1:]
+		node := graph.nodes[step.nodeID]
+
+		next, nodeOutput, finished, err := runWorkflowEngineStep(ctx, execution.ID, graph, node, step.input, defaultTimeout)
+		if err != nil || finished {
+			return err
+		}
+		if node.Type == workflownode.TypeEndNode {
+			output = nodeOutput
+		}
+		queue = append(queue, next...)
+	}
+
+	_, err = WorkflowFuncs{}.FinishWorkflowExecution(ctx, execution.ID, &models.UpdateWorkflowExecutionRequest{
+		Status: string(workflowexecution.StatusCompleted),
+		Output: output,
+	})
+	return err
+}
+
+// runWorkflowEngineStep 运行一个节点并解析之后要运行的节点；finished 为 true 表示执行已经结束（节点超时且没有 timeout 分支）
+func runWorkflowEngineStep(ctx context.Context, executionID uint64, graph *workflowGraph, node *ent.WorkflowNode, input map[string]interface{}, defaultTimeout time.Duration) (next []workflowEngineStep, output map[string]interface{}, finished bool, err error) {
+	result, err := runNodeExecution(ctx, executionID, node, input, nil, nodeTimeout(node, defaultTimeout))
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var edges []*ent.WorkflowEdge
+	switch {
+	case result.TimedOut:
+		if len(result.TimeoutEdges) == 0 {
+			return nil, nil, true, nil
+		}
+		edges = result.TimeoutEdges
+		output = input
+	case result.NodeExecution.Status != workflownodeexecution.StatusCompleted:
+		return nil, nil, false, fmt.Errorf("node %s (%d) failed: %s", node.Name, node.ID, result.NodeExecution.ErrorMessage)
+	case node.Type == workflownode.TypeConditionChecker:
+		// 条件节点的输出只记录命中的分支，下游节点继续使用条件节点的输入
+		branch, _ := result.Output["branch"].(string)
+		edges, err = SelectConditionBranchEdges(normalWorkflowEdges(graph.outgoingEdges[node.ID]), branch)
+		if err != nil {
+			return nil, nil, false, fmt.Errorf("node %s (%d): %w", node.Name, node.ID, err)
+		}
+		output = input
+	default:
+		output = result.Output
+		edges, err = WorkflowFuncs{}.SelectConditionalEdges(ctx, result.NodeExecution.ID, normalWorkflowEdges(graph.outgoingEdges[node.ID]), output)
+		if err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	targets, skipped := graph.resolveEnabledTargets(edges)
+	for _, id := range skipped {
+		if _, err := (WorkflowFuncs{}).SkipNodeExecution(ctx, executionID, graph.nodes[id], output, "node is disabled"); err != nil {
+			return nil, nil, false, err
+		}
+	}
+	for _, id := range targets {
+		next = append(next, workflowEngineStep{nodeID: id, input: output})
+	}
+	return next, output, false, nil
+}
+
+// normalWorkflowEdges 过滤掉只在节点超时时才走的 timeout 分支出边
+func normalWorkflowEdges(edges []*ent.WorkflowEdge) []*ent.WorkflowEdge {
+	normal := make([]*ent.WorkflowEdge, 0, len(edges))
+	for _, edge := range edges {
+		if edge.BranchName != NodeTimeoutBranch {
+			normal = append(normal, edge)
+		}
+	}
+	return normal
+}
@@ -0,0 +1,62 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/database"
+)
+
+func TestWorkflowExecutionRunnerRunsToEndNode(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	ctx := context.Background()
+
+	// 应用1：start -> end；应用2：start -> middle(禁用) -> end
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	client.WorkflowApplication.UpdateOneID(1).SetStartNodeID(11).ExecX(ctx)
+	client.WorkflowApplication.UpdateOneID(2).SetStartNodeID(21).ExecX(ctx)
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 26, "application_id": 2, "name": "middle", "node_key": "middle", "type": "end_node", "enabled": false})
+	client.WorkflowEdge.UpdateOneID(23).SetTargetNodeID(26).ExecX(ctx)
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 27, "application_id": 2, "source_node_id": 26, "target_node_id": 22, "edge_key": "e2", "type": "default"})
+
+	runner := newWorkflowExecutionRunner(time.Second)
+	for _, appID := range []uint64{1, 2} {
+		executionID := 100 + appID
+		insertTestRow(t, db, "workflow_executions", map[string]any{"id": executionID, "execution_id": fmt.Sprintf("exec-%d", appID), "application_id": appID, "status": "pending"})
+		snapshot, err := snapshotWorkflowGraph(ctx, appID)
+		if err != nil {
+			t.Fatalf("app %d: snapshot failed: %v", appID, err)
+		}
+		client.WorkflowExecution.UpdateOneID(executionID).SetInput(map[string]interface{}{"q": "hi"}).SetGraphSnapshot(snapshot).ExecX(ctx)
+
+		if err := runner(ctx, executionID); err != nil {
+			t.Fatalf("app %d: runner failed: %v", appID, err)
+		}
+		execution := client.WorkflowExecution.GetX(ctx, executionID)
+		if execution.Status != workflowexecution.StatusCompleted || execution.Output["q"] != "hi" {
+			t.Fatalf("app %d: execution should complete with the end node output: %+v", appID, execution)
+		}
+
+		nodeExecutions := client.WorkflowNodeExecution.Query().AllX(ctx)
+		statuses := make(map[uint64]workflownodeexecution.Status)
+		for _, nodeExecution := range nodeExecutions {
+			if nodeExecution.ExecutionID == executionID {
+				statuses[nodeExecution.NodeID] = nodeExecution.Status
+			}
+		}
+		if statuses[appID*10+1] != workflownodeexecution.StatusCompleted || statuses[appID*10+2] != workflownodeexecution.StatusCompleted {
+			t.Fatalf("app %d: start and end nodes should run: %v", appID, statuses)
+		}
+		if appID == 2 && statuses[26] != workflownodeexecution.StatusSkipped {
+			t.Fatalf("disabled node should be recorded as skipped: %v", statuses)
+		}
+	}
+}
@@ -0,0 +1,471 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflowexecutionlog"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/internal/subscription"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/messaging"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// WorkflowExecutionTopicPrefix 执行事件的WebSocket主题前缀，完整主题为 workflow/execution/{executionId}
+const WorkflowExecutionTopicPrefix = "workflow/execution/"
+
+// WorkflowExecutionTopic 获取执行事件的WebSocket主题
+func WorkflowExecutionTopic(executionID string) string {
+	return WorkflowExecutionTopicPrefix + executionID
+}
+
+// isTerminalExecutionStatus 判断执行状态是否为终态
+func isTerminalExecutionStatus(status workflowexecution.Status) bool {
+	switch status {
+	case workflowexecution.StatusCompleted, workflowexecution.StatusFailed,
+		workflowexecution.StatusCancelled, workflowexecution.StatusTimeout:
+		return true
+	}
+	return false
+}
+
+// InitWorkflowExecutionEvents 注册执行事件主题的订阅监听，客户端订阅后立即收到执行的当前状态，
+// 用于补齐订阅之前已经发生的进度（例如执行已经结束时，客户端可以直接停止等待）
+func InitWorkflowExecutionEvents() {
+	subscription.RegisterSubscribeSuccessListener(WorkflowExecutionTopicPrefix+"+", func(payload subscription.SubscribeSuccessPayload) (subscription.MessageToClient, error) {
+		executionID := strings.TrimPrefix(payload.Topic, WorkflowExecutionTopicPrefix)
+		execution, err := database.Client.WorkflowExecution.Query().
+			Where(workflowexecution.ExecutionID(executionID)).
+			Only(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow execution %s: %w", executionID, err)
+		}
+
+		event, err := utils.StructToMap(newExecutionEvent("", execution))
+		if err != nil {
+			return nil, err
+		}
+		return subscription.MessageToClient{
+			"status":  "success",
+			"message": "Subscribed to workflow execution events",
+			"data":    event,
+		}, nil
+	})
+}
+
+// newExecutionEvent 根据执行记录构建执行级别的事件，eventType为空时表示当前状态快照
+func newExecutionEvent(eventType string, execution *ent.WorkflowExecution) *models.WorkflowExecutionEvent {
+	event := &models.WorkflowExecutionEvent{
+		Event:         eventType,
+		ExecutionID:   execution.ExecutionID,
+		ApplicationID: utils.Uint64ToString(execution.ApplicationID),
+		Status:        string(execution.Status),
+		DurationMs:    execution.DurationMs,
+		TotalTokens:   execution.TotalTokens,
+		ErrorMessage:  execution.ErrorMessage,
+		Timestamp:     time.Now().UnixMilli(),
+	}
+	if !execution.StartedAt.IsZero() {
+		startedAt := execution.StartedAt
+		event.StartedAt = &startedAt
+	}
+	if !execution.FinishedAt.IsZero() {
+		finishedAt := execution.FinishedAt
+		event.FinishedAt = &finishedAt
+	}
+	return event
+}
+
+// newNodeExecutionEvent 根据节点执行记录构建节点级别的事件
+func newNodeExecutionEvent(eventType string, execution *ent.WorkflowExecution, nodeExecution *ent.WorkflowNodeExecution) *models.WorkflowExecutionEvent {
+	event := &models.WorkflowExecutionEvent{
+		Event:            eventType,
+		ExecutionID:      execution.ExecutionID,
+		ApplicationID:    utils.Uint64ToString(execution.ApplicationID),
+		NodeExecutionID:  utils.Uint64ToString(nodeExecution.ID),
+		NodeID:           utils.Uint64ToString(nodeExecution.NodeID),
+		NodeName:         nodeExecution.NodeName,
+		NodeType:         nodeExecution.NodeType,
+		Status:           string(nodeExecution.Status),
+		DurationMs:       nodeExecution.DurationMs,
+		PromptTokens:     nodeExecution.PromptTokens,
+		CompletionTokens: nodeExecution.CompletionTokens,
+		TotalTokens:      nodeExecution.TotalTokens,
+		ErrorMessage:     nodeExecution.ErrorMessage,
+		Timestamp:        time.Now().UnixMilli(),
+	}
+	if !nodeExecution.StartedAt.IsZero() {
+		startedAt := nodeExecution.StartedAt
+		event.StartedAt = &startedAt
+	}
+	if !nodeExecution.FinishedAt.IsZero() {
+		finishedAt := nodeExecution.FinishedAt
+		event.FinishedAt = &finishedAt
+	}
+	return event
+}
+
+// publishWorkflowExecutionEvent 通过消息系统将执行事件推送到WebSocket订阅者，推送失败不影响执行本身
+func publishWorkflowExecutionEvent(ctx context.Context, event *models.WorkflowExecutionEvent) {
+	if caching.Client == nil {
+		return
+	}
+
+	// 跨服务传递后json标签会丢失，需要先转换为map
+	data, err := utils.StructToMap(event)
+	if err != nil {
+		logging.Warn("Failed to convert workflow execution event: %v", err)
+		return
+	}
+
+	_, err = messaging.Publish(ctx, messaging.MessageStruct{
+		Type: messaging.ServerToUserSocket,
+		Payload: messaging.SocketMessagePayload{
+			UserId: nil,
+			Topic:  WorkflowExecutionTopic(event.ExecutionID),
+			Data:   data,
+		},
+	})
+	if err != nil {
+		logging.Warn("Failed to publish workflow execution event %s for %s: %v", event.Event, event.ExecutionID, err)
+	}
+
+	if err := publishExecutionEventToWaiters(ctx, event); err != nil {
+		logging.Warn("Failed to notify waiters of workflow execution event %s for %s: %v", event.Event, event.ExecutionID, err)
+	}
+}
+
+// getActiveWorkflowExecution 获取尚未进入终态的执行记录
+func getActiveWorkflowExecution(ctx context.Context, id uint64) (*ent.WorkflowExecution, error) {
+	execution, err := database.Client.WorkflowExecution.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+	if isTerminalExecutionStatus(execution.Status) {
+		return nil, fmt.Errorf("workflow execution already finished")
+	}
+	return execution, nil
+}
+
+// StartWorkflowExecution 将执行标记为运行中并推送 execution_started 事件
+func (WorkflowFuncs) StartWorkflowExecution(ctx context.Context, id uint64) (*ent.WorkflowExecution, error) {
+	active, err := getActiveWorkflowExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	update := database.Client.WorkflowExecution.UpdateOneID(id).
+		SetStatus(workflowexecution.StatusRunning).
+		SetStartedAt(time.Now())
+	// 创建时没有保存快照的执行在开始时补拍
+	if active.GraphSnapshot == "" {
+		graphSnapshot, err := takeExecutionSnapshot(ctx, active.ApplicationID)
+		if err != nil {
+			return nil, err
+		}
+		update = update.SetGraphSnapshot(graphSnapshot)
+	}
+
+	execution, err := update.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publishWorkflowExecutionEvent(ctx, newExecutionEvent(models.WorkflowEventExecutionStarted, execution))
+	return execution, nil
+}
+
+// StartNodeExecution 创建运行中的节点执行记录并推送 node_started 事件
+func (WorkflowFuncs) StartNodeExecution(ctx context.Context, executionID uint64, node *ent.WorkflowNode, input map[string]interface{}) (*ent.WorkflowNodeExecution, error) {
+	execution, err := getActiveWorkflowExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := database.Client.WorkflowNodeExecution.Create().
+		SetExecutionID(executionID).
+		SetNodeID(node.ID).
+		SetNodeName(node.Name).
+		SetNodeType(string(node.Type)).
+		SetStatus(workflownodeexecution.StatusRunning).
+		SetIsAsync(node.Async).
+		SetStartedAt(time.Now())
+
+	// 输入超出大小限制时截断，或在失败策略下直接记录为失败的节点执行
+	inputCheck := payloadLimits.check("node input", input, payloadLimits.maxNodeInput)
+	inputCheck.warnTruncated("node input", node.ID)
+	if inputCheck.payload != nil {
+		builder = builder.SetInput(inputCheck.payload)
+	}
+	if extra := recordPayloadSize(nil, "input", inputCheck); extra != nil {
+		builder = builder.SetExtra(extra)
+	}
+	if inputCheck.err != nil {
+		builder = builder.
+			SetStatus(workflownodeexecution.StatusFailed).
+			SetFinishedAt(time.Now()).
+			SetErrorMessage(inputCheck.err.Error())
+	}
+
+	nodeExecution, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if inputCheck.err != nil {
+		publishWorkflowExecutionEvent(ctx, newNodeExecutionEvent(models.WorkflowEventNodeFailed, execution, nodeExecution))
+		return nodeExecution, inputCheck.err
+	}
+	publishWorkflowExecutionEvent(ctx, newNodeExecutionEvent(models.WorkflowEventNodeStarted, execution, nodeExecution))
+	return nodeExecution, nil
+}
+
+// SkipNodeExecution 为被跳过的节点（如已禁用的节点）记录 skipped 执行并推送 node_finished 事件
+// 节点的输入原样作为输出透传给下游
+func (WorkflowFuncs) SkipNodeExecution(ctx context.Context, executionID uint64, node *ent.WorkflowNode, input map[string]interface{}, reason string) (*ent.WorkflowNodeExecution, error) {
+	execution, err := getActiveWorkflowExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	builder := database.Client.WorkflowNodeExecution.Create().
+		SetExecutionID(executionID).
+		SetNodeID(node.ID).
+		SetNodeName(node.Name).
+		SetNodeType(string(node.Type)).
+		SetStatus(workflownodeexecution.StatusSkipped).
+		SetIsAsync(node.Async).
+		SetStartedAt(now).
+		SetFinishedAt(now)
+
+	// 跳过的节点不会失败，超出大小限制的输入总是截断
+	inputCheck := (&payloadLimitPolicy{}).check("node input", input, payloadLimits.maxNodeInput)
+	inputCheck.warnTruncated("node input", node.ID)
+	builder = builder.SetExtra(recordPayloadSize(map[string]interface{}{"skipReason": reason}, "input", inputCheck))
+	if inputCheck.payload != nil {
+		builder = builder.SetInput(inputCheck.payload).SetOutput(inputCheck.payload)
+	}
+
+	nodeExecution, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publishWorkflowExecutionEvent(ctx, newNodeExecutionEvent(models.WorkflowEventNodeFinished, execution, nodeExecution))
+	return nodeExecution, nil
+}
+
+// FinishNodeExecution 记录节点执行结果并推送 node_finished 或 node_failed 事件
+// 未指定状态时视为完成；执行已进入终态时不再记录和推送
+func (WorkflowFuncs) FinishNodeExecution(ctx context.Context, nodeExecutionID uint64, req *models.UpdateWorkflowNodeExecutionRequest) (*ent.WorkflowNodeExecution, error) {
+	current, err := database.Client.WorkflowNodeExecution.Get(ctx, nodeExecutionID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node execution not found")
+		}
+		return nil, err
+	}
+
+	execution, err := getActiveWorkflowExecution(ctx, current.ExecutionID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := workflownodeexecution.StatusCompleted
+	if req.Status != "" {
+		status = workflownodeexecution.Status(req.Status)
+		if err := workflownodeexecution.StatusValidator(status); err != nil {
+			return nil, err
+		}
+	}
+
+	// 输出超出大小限制时截断，或在失败策略下丢弃输出并将节点标记为失败
+	outputCheck := payloadLimits.check("node output", req.Output, payloadLimits.maxNodeOutput)
+	outputCheck.warnTruncated("node output", current.NodeID)
+	errorMessage := req.ErrorMessage
+	if outputCheck.err != nil {
+		status = workflownodeexecution.StatusFailed
+		errorMessage = outputCheck.err.Error()
+	}
+
+	finishedAt := time.Now()
+	builder := database.Client.WorkflowNodeExecution.UpdateOneID(nodeExecutionID).
+		SetStatus(status).
+		SetFinishedAt(finishedAt)
+	if !current.StartedAt.IsZero() {
+		builder = builder.SetDurationMs(int(finishedAt.Sub(current.StartedAt).Milliseconds()))
+	}
+	if outputCheck.payload != nil {
+		builder = builder.SetOutput(outputCheck.payload)
+	}
+	extra := req.Extra
+	if extra == nil && outputCheck.exceeded {
+		// 保留开始时记录的输入大小信息
+		extra = current.Extra
+	}
+	if extra = recordPayloadSize(extra, "output", outputCheck); extra != nil {
+		builder = builder.SetExtra(extra)
+	}
+	if req.PromptTokens != nil {
+		builder = builder.SetPromptTokens(*req.PromptTokens)
+	}
+	if req.CompletionTokens != nil {
+		builder = builder.SetCompletionTokens(*req.CompletionTokens)
+	}
+	if req.TotalTokens != nil {
+		builder = builder.SetTotalTokens(*req.TotalTokens)
+	}
+	if req.Cost != nil {
+		builder = builder.SetCost(*req.Cost)
+	}
+	if req.Model != "" {
+		builder = builder.SetModel(req.Model)
+	}
+	if errorMessage != "" {
+		builder = builder.SetErrorMessage(errorMessage)
+	}
+	if req.ErrorStack != "" {
+		builder = builder.SetErrorStack(req.ErrorStack)
+	}
+	if req.RetryCount != nil {
+		builder = builder.SetRetryCount(*req.RetryCount)
+	}
+
+	nodeExecution, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventType := models.WorkflowEventNodeFinished
+	if status == workflownodeexecution.StatusFailed || status == workflownodeexecution.StatusTimeout {
+		eventType = models.WorkflowEventNodeFailed
+	}
+	publishWorkflowExecutionEvent(ctx, newNodeExecutionEvent(eventType, execution, nodeExecution))
+	return nodeExecution, nil
+}
+
+// FinishWorkflowExecution 将执行标记为终态，汇总节点的Token和成本，并推送最后一个 execution_finished 事件
+func (WorkflowFuncs) FinishWorkflowExecution(ctx context.Context, id uint64, req *models.UpdateWorkflowExecutionRequest) (*ent.WorkflowExecution, error) {
+	current, err := getActiveWorkflowExecution(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	status := workflowexecution.StatusCompleted
+	if req.Status != "" {
+		status = workflowexecution.Status(req.Status)
+		if err := workflowexecution.StatusValidator(status); err != nil {
+			return nil, err
+		}
+	}
+	if !isTerminalExecutionStatus(status) {
+		return nil, fmt.Errorf("status %s is not a terminal status", status)
+	}
+
+	// 执行输出和上下文超出大小限制时截断，或在失败策略下丢弃并将执行标记为失败
+	errorMessage := req.ErrorMessage
+	outputCheck := payloadLimits.check("execution output", req.Output, payloadLimits.maxExecutionContext)
+	contextCheck := payloadLimits.check("execution context", req.Context, payloadLimits.maxExecutionContext)
+	outputCheck.warnTruncated("execution output", id)
+	contextCheck.warnTruncated("execution context", id)
+	for _, check := range []payloadCheck{outputCheck, contextCheck} {
+		if check.err != nil {
+			status = workflowexecution.StatusFailed
+			errorMessage = check.err.Error()
+		}
+	}
+
+	nodeExecutions, err := database.Client.WorkflowNodeExecution.Query().
+		Where(workflownodeexecution.ExecutionID(id)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	totalTokens, totalCost := 0, 0.0
+	for _, nodeExecution := range nodeExecutions {
+		totalTokens += nodeExecution.TotalTokens
+		totalCost += nodeExecution.Cost
+	}
+
+	finishedAt := time.Now()
+	builder := database.Client.WorkflowExecution.UpdateOneID(id).
+		SetStatus(status).
+		SetFinishedAt(finishedAt).
+		SetTotalTokens(totalTokens).
+		SetTotalCost(totalCost)
+	if !current.StartedAt.IsZero() {
+		builder = builder.SetDurationMs(int(finishedAt.Sub(current.StartedAt).Milliseconds()))
+	}
+	if outputCheck.payload != nil {
+		builder = builder.SetOutput(outputCheck.payload)
+	}
+	if contextCheck.payload != nil {
+		builder = builder.SetContext(contextCheck.payload)
+	}
+	if errorMessage != "" {
+		builder = builder.SetErrorMessage(errorMessage)
+	}
+	if req.ErrorStack != "" {
+		builder = builder.SetErrorStack(req.ErrorStack)
+	}
+
+	execution, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	publishWorkflowExecutionEvent(ctx, newExecutionEvent(models.WorkflowEventExecutionFinished, execution))
+	return execution, nil
+}
+
+// GetNodeExecution 获取单个节点执行的详情（输入、输出、附加信息、错误）及其关联的日志，
+// withContext 为 true 时同时返回直接上游节点的输出和直接下游节点的输入，用于追踪错误值的来源
+func (WorkflowFuncs) GetNodeExecution(ctx context.Context, nodeExecutionID string, withContext bool) (*models.WorkflowNodeExecutionResponse, error) {
+	id, err := strconv.ParseUint(nodeExecutionID, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid node execution id %s", nodeExecutionID)
+	}
+
+	nodeExecution, err := database.Client.WorkflowNodeExecution.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node execution not found")
+		}
+		return nil, err
+	}
+
+	logs, err := database.Client.WorkflowExecutionLog.Query().
+		Where(workflowexecutionlog.NodeExecutionIDEQ(id)).
+		Order(ent.Asc(workflowexecutionlog.FieldLoggedAt), ent.Asc(workflowexecutionlog.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution logs: %w", err)
+	}
+
+	resp := WorkflowFuncs{}.ConvertWorkflowNodeExecutionToResponse(nodeExecution)
+	resp.Logs = make([]*models.WorkflowExecutionLogResponse, 0, len(logs))
+	for _, log := range logs {
+		resp.Logs = append(resp.Logs, WorkflowFuncs{}.ConvertWorkflowExecutionLogToResponse(log))
+	}
+
+	if withContext {
+		resp.Upstream, resp.Downstream, err = loadNodeExecutionContext(ctx, nodeExecution)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load node execution context: %w", err)
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,92 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+)
+
+// errSourceExecutionMismatch 作为输入模板的执行不属于当前应用
+const errSourceExecutionMismatch = "source execution belongs to another application"
+
+// IsSourceExecutionMismatch 判断错误是否为模板执行与应用不匹配
+func IsSourceExecutionMismatch(err error) bool {
+	return err != nil && err.Error() == errSourceExecutionMismatch
+}
+
+// getExecutionByExecutionID 根据执行ID（UUID）获取执行记录
+func getExecutionByExecutionID(ctx context.Context, executionID string) (*ent.WorkflowExecution, error) {
+	execution, err := database.Client.WorkflowExecution.Query().
+		Where(workflowexecution.ExecutionIDEQ(executionID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+	return execution, nil
+}
+
+// GetExecutionInput 获取执行的输入，用于预填新的执行。
+// 敏感键名的值和应用密钥的明文会被替换为掩码，不会原样返回
+func (WorkflowFuncs) GetExecutionInput(ctx context.Context, executionID string) (map[string]interface{}, error) {
+	execution, err := getExecutionByExecutionID(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	return WorkflowFuncs{}.RedactExecutionInput(ctx, execution.ApplicationID, execution.Input), nil
+}
+
+// CopyExecutionInput 以应用下某次执行的输入为模板生成新的执行输入，overrides 中的顶层键覆盖模板中的同名键。
+// 模板使用原始输入（不脱敏），执行必须属于同一应用
+func (WorkflowFuncs) CopyExecutionInput(ctx context.Context, applicationID uint64, fromExecutionID string, overrides map[string]interface{}) (map[string]interface{}, error) {
+	execution, err := getExecutionByExecutionID(ctx, fromExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	if execution.ApplicationID != applicationID {
+		return nil, fmt.Errorf(errSourceExecutionMismatch)
+	}
+	return mergeExecutionInput(execution.Input, overrides), nil
+}
+
+// mergeExecutionInput 浅合并执行输入，返回新的 map，不修改参数
+func mergeExecutionInput(base, overrides map[string]interface{}) map[string]interface{} {
+	if base == nil && overrides == nil {
+		return nil
+	}
+	merged := make(map[string]interface{}, len(base)+len(overrides))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range overrides {
+		merged[key] = value
+	}
+	return merged
+}
+
+// RedactExecutionInput 返回脱敏后的执行输入副本：敏感键名（全局和 workflow.redacted_keys）的值被替换，
+// 值中出现的应用密钥明文被掩码。密钥无法加载时只按键名脱敏
+func (WorkflowFuncs) RedactExecutionInput(ctx context.Context, applicationID uint64, input map[string]interface{}) map[string]interface{} {
+	redactedKeys := append(utils.SensitiveKeys(), configs.GetConfig().Workflow.RedactedKeys...)
+	secrets, err := loadApplicationSecrets(ctx, applicationID)
+	if err != nil {
+		secrets = nil
+	}
+	return redactExecutionInput(input, redactedKeys, secrets)
+}
+
+// redactExecutionInput 按键名脱敏后再掩码密钥明文
+func redactExecutionInput(input map[string]interface{}, redactedKeys []string, secrets map[string]string) map[string]interface{} {
+	redacted := utils.RedactJSON(input, redactedKeys)
+	if masked, ok := maskSecretValues(redacted, secrets).(map[string]interface{}); ok {
+		return masked
+	}
+	return redacted
+}
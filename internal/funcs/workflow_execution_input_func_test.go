@@ -0,0 +1,65 @@
+package funcs
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go-backend/pkg/database"
+)
+
+func TestCopyExecutionInput(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	insertTestRow(t, db, "workflow_executions", map[string]any{
+		"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "completed",
+		"input": `{"query":"hello","options":{"limit":3},"api_key":"sk-live"}`,
+	})
+	ctx := context.Background()
+
+	input, err := WorkflowFuncs{}.CopyExecutionInput(ctx, 1, "exec-1", map[string]interface{}{"query": "again"})
+	if err != nil {
+		t.Fatalf("copy failed: %v", err)
+	}
+	want := map[string]interface{}{
+		"query":   "again",
+		"options": map[string]interface{}{"limit": float64(3)},
+		"api_key": "sk-live",
+	}
+	if !reflect.DeepEqual(input, want) {
+		t.Fatalf("unexpected merged input: %v", input)
+	}
+
+	if _, err := (WorkflowFuncs{}).CopyExecutionInput(ctx, 2, "exec-1", nil); !IsSourceExecutionMismatch(err) {
+		t.Fatalf("execution of another application should be rejected, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).CopyExecutionInput(ctx, 1, "missing", nil); err == nil || err.Error() != "workflow execution not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestRedactExecutionInput(t *testing.T) {
+	input := map[string]interface{}{
+		"query":   "use sk-live-123 please",
+		"api_key": "sk-other",
+		"nested":  map[string]interface{}{"token": "abc", "name": "n"},
+	}
+	redacted := redactExecutionInput(input, []string{"api_key", "token"}, map[string]string{"OPENAI_API_KEY": "sk-live-123"})
+
+	if redacted["api_key"] == "sk-other" || redacted["nested"].(map[string]interface{})["token"] == "abc" {
+		t.Fatalf("sensitive keys should be redacted: %v", redacted)
+	}
+	if redacted["query"] == input["query"] {
+		t.Fatalf("secret plaintext should be masked: %v", redacted["query"])
+	}
+	if redacted["nested"].(map[string]interface{})["name"] != "n" {
+		t.Fatalf("other values should be kept: %v", redacted)
+	}
+	if input["api_key"] != "sk-other" {
+		t.Fatal("original input should not be modified")
+	}
+}
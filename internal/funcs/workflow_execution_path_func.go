@@ -0,0 +1,234 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// GetExecutionPath 根据节点执行记录和工作流图重建单次执行的路径
+// 路径包含按开始时间排序的节点执行、每个条件节点选择的分支以及从未执行到的节点。
+// 图使用执行开始时保存的快照，没有快照的旧执行记录使用工作流当前的节点和边
+func (WorkflowFuncs) GetExecutionPath(ctx context.Context, executionID string) (*models.ExecutionPath, error) {
+	execution, err := database.Client.WorkflowExecution.Query().
+		Where(workflowexecution.ExecutionIDEQ(executionID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+
+	nodeExecutions, err := database.Client.WorkflowNodeExecution.Query().
+		Where(workflownodeexecution.ExecutionIDEQ(execution.ID)).
+		Order(ent.Asc(workflownodeexecution.FieldStartedAt), ent.Asc(workflownodeexecution.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node executions: %w", err)
+	}
+
+	graph, err := loadExecutionGraph(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildExecutionPath(execution, graph, nodeExecutions), nil
+}
+
+// buildExecutionPath 根据已按开始时间排序的节点执行记录构建执行路径
+func buildExecutionPath(execution *ent.WorkflowExecution, graph *workflowGraph, nodeExecutions []*ent.WorkflowNodeExecution) *models.ExecutionPath {
+	path := &models.ExecutionPath{
+		ExecutionID:     execution.ExecutionID,
+		ApplicationID:   utils.Uint64ToString(execution.ApplicationID),
+		Status:          string(execution.Status),
+		Steps:           make([]*models.ExecutionPathStep, 0, len(nodeExecutions)),
+		Branches:        make([]*models.ExecutionBranchDecision, 0),
+		UnmetConditions: make([]*models.ExecutionUnmetCondition, 0),
+		UnreachedNodes:  make([]*models.ExecutionPathNode, 0),
+		TotalNodes:      len(graph.nodes),
+	}
+
+	visited := make(map[uint64]struct{}, len(nodeExecutions))
+	for i, nodeExecution := range nodeExecutions {
+		visited[nodeExecution.NodeID] = struct{}{}
+
+		step := &models.ExecutionPathStep{
+			NodeExecutionID: utils.Uint64ToString(nodeExecution.ID),
+			NodeID:          utils.Uint64ToString(nodeExecution.NodeID),
+			NodeName:        nodeExecution.NodeName,
+			NodeType:        nodeExecution.NodeType,
+			Status:          string(nodeExecution.Status),
+			ErrorMessage:    nodeExecution.ErrorMessage,
+		}
+		if !nodeExecution.StartedAt.IsZero() {
+			step.StartedAt = utils.FormatDateTime(nodeExecution.StartedAt)
+		}
+		if !nodeExecution.FinishedAt.IsZero() {
+			step.FinishedAt = utils.FormatDateTime(nodeExecution.FinishedAt)
+		}
+		path.Steps = append(path.Steps, step)
+
+		if nodeExecution.NodeType == workflownode.TypeConditionChecker.String() &&
+			nodeExecution.Status != workflownodeexecution.StatusSkipped {
+			path.Branches = append(path.Branches, graph.branchDecision(nodeExecution, nodeExecutions[i+1:]))
+		}
+		if nodeExecution.Status == workflownodeexecution.StatusCompleted {
+			path.UnmetConditions = append(path.UnmetConditions, graph.unmetConditions(nodeExecution, nodeExecutions[i+1:])...)
+		}
+	}
+
+	nodeIDs := make([]uint64, 0, len(graph.nodes))
+	for id := range graph.nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Slice(nodeIDs, func(i, j int) bool { return nodeIDs[i] < nodeIDs[j] })
+	for _, id := range nodeIDs {
+		if _, ok := visited[id]; ok {
+			path.VisitedCount++
+			continue
+		}
+		node := graph.nodes[id]
+		path.UnreachedNodes = append(path.UnreachedNodes, &models.ExecutionPathNode{
+			NodeID:   utils.Uint64ToString(id),
+			NodeName: node.Name,
+			NodeType: node.Type.String(),
+		})
+	}
+
+	if path.TotalNodes > 0 {
+		path.Coverage = float64(path.VisitedCount) / float64(path.TotalNodes)
+	}
+	return path
+}
+
+// branchDecision 确定条件节点一次执行选择的分支
+// 优先使用节点输出（或 extra）中记录的 branch，没有记录时根据之后执行的节点属于哪个分支推断
+func (g *workflowGraph) branchDecision(nodeExecution *ent.WorkflowNodeExecution, later []*ent.WorkflowNodeExecution) *models.ExecutionBranchDecision {
+	decision := &models.ExecutionBranchDecision{
+		NodeExecutionID:  utils.Uint64ToString(nodeExecution.ID),
+		NodeID:           utils.Uint64ToString(nodeExecution.NodeID),
+		NodeName:         nodeExecution.NodeName,
+		NotTakenBranches: make([]string, 0),
+	}
+
+	targetBranches := make(map[uint64][]string)
+	branchSet := make(map[string]struct{})
+	for _, edge := range g.outgoingEdges[nodeExecution.NodeID] {
+		name := edge.BranchName
+		if IsDefaultConditionBranch(name) {
+			name = ConditionBranchDefault
+		}
+		branchSet[name] = struct{}{}
+		targetBranches[edge.TargetNodeID] = append(targetBranches[edge.TargetNodeID], name)
+	}
+
+	decision.BranchName = recordedBranch(nodeExecution)
+	if decision.BranchName == "" {
+		taken := make(map[string]struct{})
+		for _, next := range later {
+			for _, name := range targetBranches[next.NodeID] {
+				taken[name] = struct{}{}
+			}
+		}
+		if len(taken) == 1 {
+			for name := range taken {
+				decision.BranchName = name
+			}
+			decision.Inferred = true
+		}
+	}
+
+	if decision.BranchName == "" {
+		return decision
+	}
+	for name := range branchSet {
+		if name != decision.BranchName && !(IsDefaultConditionBranch(name) && IsDefaultConditionBranch(decision.BranchName)) {
+			decision.NotTakenBranches = append(decision.NotTakenBranches, name)
+		}
+	}
+	sort.Strings(decision.NotTakenBranches)
+	return decision
+}
+
+// unmetConditions 列出节点一次执行后条件未成立的条件边
+// 优先使用引擎记录在 extra 中的求值结果，没有记录时将目标节点在之后没有执行记录的条件边推断为未成立
+func (g *workflowGraph) unmetConditions(nodeExecution *ent.WorkflowNodeExecution, later []*ent.WorkflowNodeExecution) []*models.ExecutionUnmetCondition {
+	edges := make(map[string]*ent.WorkflowEdge)
+	for _, edge := range g.outgoingEdges[nodeExecution.NodeID] {
+		if edge.Type == workflowedge.TypeConditional {
+			edges[utils.Uint64ToString(edge.ID)] = edge
+		}
+	}
+	if len(edges) == 0 {
+		return nil
+	}
+
+	unmet := make([]*models.ExecutionUnmetCondition, 0)
+	if records, ok := nodeExecution.Extra[conditionalEdgesExtraKey].([]interface{}); ok {
+		for _, item := range records {
+			record, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if met, _ := record["met"].(bool); met {
+				continue
+			}
+			condition := &models.ExecutionUnmetCondition{
+				NodeExecutionID: utils.Uint64ToString(nodeExecution.ID),
+				SourceNodeID:    utils.Uint64ToString(nodeExecution.NodeID),
+			}
+			condition.EdgeID, _ = record["edgeId"].(string)
+			condition.TargetNodeID, _ = record["targetNodeId"].(string)
+			condition.Condition, _ = record["condition"].(string)
+			condition.Error, _ = record["error"].(string)
+			unmet = append(unmet, condition)
+		}
+		return unmet
+	}
+
+	executed := make(map[uint64]struct{}, len(later))
+	for _, next := range later {
+		executed[next.NodeID] = struct{}{}
+	}
+	ids := make([]string, 0, len(edges))
+	for id := range edges {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		edge := edges[id]
+		if _, ok := executed[edge.TargetNodeID]; ok {
+			continue
+		}
+		unmet = append(unmet, &models.ExecutionUnmetCondition{
+			NodeExecutionID: utils.Uint64ToString(nodeExecution.ID),
+			EdgeID:          id,
+			SourceNodeID:    utils.Uint64ToString(edge.SourceNodeID),
+			TargetNodeID:    utils.Uint64ToString(edge.TargetNodeID),
+			Condition:       edgeCondition(edge.Data),
+			Inferred:        true,
+		})
+	}
+	return unmet
+}
+
+// recordedBranch 读取条件节点执行记录中的分支名称
+func recordedBranch(nodeExecution *ent.WorkflowNodeExecution) string {
+	for _, data := range []map[string]interface{}{nodeExecution.Output, nodeExecution.Extra} {
+		if branch, ok := data["branch"].(string); ok && strings.TrimSpace(branch) != "" {
+			return branch
+		}
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package funcs
+
+import (
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+)
+
+func executionPathTestGraph() *workflowGraph {
+	node := func(id uint64, nodeType workflownode.Type) *ent.WorkflowNode {
+		return &ent.WorkflowNode{ID: id, Name: nodeType.String(), Type: nodeType, Enabled: true}
+	}
+	nodes := []*ent.WorkflowNode{
+		node(1, workflownode.TypeUserInput),
+		node(2, workflownode.TypeConditionChecker),
+		node(3, workflownode.TypeLlmCaller),
+		node(4, workflownode.TypeAPICaller),
+		node(5, workflownode.TypeEndNode),
+	}
+	edges := []*ent.WorkflowEdge{
+		{ID: 11, SourceNodeID: 1, TargetNodeID: 2},
+		{ID: 12, SourceNodeID: 2, TargetNodeID: 3, BranchName: "high"},
+		{ID: 13, SourceNodeID: 2, TargetNodeID: 4, BranchName: "else"},
+		{ID: 14, SourceNodeID: 3, TargetNodeID: 5},
+		{ID: 15, SourceNodeID: 4, TargetNodeID: 5},
+	}
+	return buildWorkflowGraph(&ent.WorkflowApplication{ID: 100}, nodes, edges)
+}
+
+func pathTestNodeExecutions(conditionOutput map[string]interface{}, nodeIDs ...uint64) []*ent.WorkflowNodeExecution {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	graph := executionPathTestGraph()
+	executions := make([]*ent.WorkflowNodeExecution, 0, len(nodeIDs))
+	for i, id := range nodeIDs {
+		ne := &ent.WorkflowNodeExecution{
+			ID:        uint64(1000 + i),
+			NodeID:    id,
+			NodeName:  graph.nodes[id].Name,
+			NodeType:  graph.nodes[id].Type.String(),
+			Status:    workflownodeexecution.StatusCompleted,
+			StartedAt: start.Add(time.Duration(i) * time.Second),
+		}
+		if graph.nodes[id].Type == workflownode.TypeConditionChecker {
+			ne.Output = conditionOutput
+		}
+		executions = append(executions, ne)
+	}
+	return executions
+}
+
+func TestBuildExecutionPathInfersBranch(t *testing.T) {
+	execution := &ent.WorkflowExecution{ExecutionID: "exec-1", ApplicationID: 100, Status: workflowexecution.StatusCompleted}
+	path := buildExecutionPath(execution, executionPathTestGraph(), pathTestNodeExecutions(nil, 1, 2, 3, 5))
+
+	if len(path.Steps) != 4 || path.Steps[2].NodeID != "3" {
+		t.Fatalf("unexpected steps: %+v", path.Steps)
+	}
+	if len(path.Branches) != 1 {
+		t.Fatalf("expected one branch decision, got %d", len(path.Branches))
+	}
+	decision := path.Branches[0]
+	if decision.BranchName != "high" || !decision.Inferred {
+		t.Fatalf("unexpected decision: %+v", decision)
+	}
+	if len(decision.NotTakenBranches) != 1 || decision.NotTakenBranches[0] != ConditionBranchDefault {
+		t.Fatalf("unexpected not taken branches: %v", decision.NotTakenBranches)
+	}
+
+	if len(path.UnreachedNodes) != 1 || path.UnreachedNodes[0].NodeID != "4" {
+		t.Fatalf("unexpected unreached nodes: %+v", path.UnreachedNodes)
+	}
+	if path.VisitedCount != 4 || path.TotalNodes != 5 || path.Coverage != 0.8 {
+		t.Fatalf("unexpected coverage: %d/%d = %v", path.VisitedCount, path.TotalNodes, path.Coverage)
+	}
+}
+
+func TestBuildExecutionPathRecordedBranch(t *testing.T) {
+	execution := &ent.WorkflowExecution{ExecutionID: "exec-2", ApplicationID: 100, Status: workflowexecution.StatusFailed}
+	// 条件节点记录了分支，但下游节点还没开始执行就失败了
+	path := buildExecutionPath(execution, executionPathTestGraph(),
+		pathTestNodeExecutions(map[string]interface{}{"branch": "default"}, 1, 2))
+
+	decision := path.Branches[0]
+	if decision.BranchName != "default" || decision.Inferred {
+		t.Fatalf("unexpected decision: %+v", decision)
+	}
+	if len(decision.NotTakenBranches) != 1 || decision.NotTakenBranches[0] != "high" {
+		t.Fatalf("unexpected not taken branches: %v", decision.NotTakenBranches)
+	}
+	if len(path.UnreachedNodes) != 3 {
+		t.Fatalf("expected 3 unreached nodes, got %+v", path.UnreachedNodes)
+	}
+}
@@ -0,0 +1,645 @@
+package funcs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/shared/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// errExecutionQueueClosed 队列正在关闭，不再接受新的执行
+	errExecutionQueueClosed = errors.New("workflow execution queue is closed")
+	// errExecutionQueueFull 排队中的执行数达到上限
+	errExecutionQueueFull = errors.New("workflow execution queue is full")
+)
+
+// IsExecutionQueueUnavailable 判断错误是否为执行队列已关闭或已满
+func IsExecutionQueueUnavailable(err error) bool {
+	return errors.Is(err, errExecutionQueueClosed) || errors.Is(err, errExecutionQueueFull)
+}
+
+const (
+	// localExecutionQueueCapacity 未配置容量时进程内队列的缓冲大小
+	localExecutionQueueCapacity = 1000
+	// executionQueuePollWait 工作协程每次等待新任务的最长时间，决定关闭时的响应速度
+	executionQueuePollWait = time.Second
+	// executionWorkerHeartbeatTTL 实例心跳的有效期，超过该时间未续期的实例视为已退出
+	executionWorkerHeartbeatTTL = 30 * time.Second
+	// executionWorkerHeartbeatInterval 实例续期心跳的间隔
+	executionWorkerHeartbeatInterval = 10 * time.Second
+	// executionQueueRecoverInterval 检查已退出实例遗留执行的间隔
+	executionQueueRecoverInterval = time.Minute
+	// executionRecoveryGrace 最近更新过的待执行或运行中的执行不参与恢复，避免与刚创建尚未入队、
+	// 或刚取出尚未开始的执行冲突
+	executionRecoveryGrace = time.Minute
+)
+
+// executionQueueKey 保存待执行ID的Redis列表
+var executionQueueKey = caching.WorkflowKeys.Key("queue", "pending")
+
+// WorkflowExecutionRunner 执行引擎运行一次执行的入口，返回时执行应已进入终态；
+// 返回 error 且执行仍未结束时，队列将执行标记为失败
+type WorkflowExecutionRunner func(ctx context.Context, executionID uint64) error
+
+// workflowExecutionRunner 已注册的执行引擎，Setup 时为空则注册进程内执行引擎
+var workflowExecutionRunner WorkflowExecutionRunner
+
+// RegisterWorkflowExecutionRunner 注册执行引擎替换进程内执行引擎，需要在 Setup 之前调用
+func RegisterWorkflowExecutionRunner(runner WorkflowExecutionRunner) {
+	workflowExecutionRunner = runner
+}
+
+// executionQueueBackend 执行队列的存储
+type executionQueueBackend interface {
+	// push 将执行ID加入队尾
+	push(ctx context.Context, executionID uint64) error
+	// pop 取出队首的执行ID，队列为空时最多等待 wait，仍为空时返回 false
+	pop(ctx context.Context, wait time.Duration) (uint64, bool, error)
+	// ack 取出的执行运行结束后调用，之后不再需要恢复
+	ack(ctx context.Context, executionID uint64) error
+	// depth 排队中的执行数
+	depth(ctx context.Context) (int64, error)
+	// name 存储名称，用于状态展示
+	name() string
+}
+
+// localExecutionQueue 进程内队列，服务重启后未开始的执行会丢失（执行记录保持 pending）
+type localExecutionQueue struct {
+	jobs chan uint64
+}
+
+func newLocalExecutionQueue(capacity int) *localExecutionQueue {
+	if capacity <= 0 {
+		capacity = localExecutionQueueCapacity
+	}
+	return &localExecutionQueue{jobs: make(chan uint64, capacity)}
+}
+
+func (q *localExecutionQueue) push(_ context.Context, executionID uint64) error {
+	select {
+	case q.jobs <- executionID:
+		return nil
+	default:
+		return errExecutionQueueFull
+	}
+}
+
+func (q *localExecutionQueue) pop(ctx context.Context, wait time.Duration) (uint64, bool, error) {
+	if wait <= 0 {
+		select {
+		case executionID := <-q.jobs:
+			return executionID, true, nil
+		default:
+			return 0, false, nil
+		}
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case executionID := <-q.jobs:
+		return executionID, true, nil
+	case <-timer.C:
+		return 0, false, nil
+	case <-ctx.Done():
+		return 0, false, ctx.Err()
+	}
+}
+
+func (q *localExecutionQueue) ack(context.Context, uint64) error {
+	return nil
+}
+
+func (q *localExecutionQueue) depth(context.Context) (int64, error) {
+	return int64(len(q.jobs)), nil
+}
+
+func (q *localExecutionQueue) name() string {
+	return "local"
+}
+
+// redisExecutionQueue 基于Redis列表的队列，多个实例共享同一队列。
+// 工作协程取出执行时原子地将其移入本实例的处理中列表，运行结束后再移除；实例通过心跳键表明存活，
+// 实例崩溃或关闭超时后处理中列表里的执行由其他实例（或重启后的实例）放回队列，因此执行不会因重启而丢失
+type redisExecutionQueue struct {
+	client        redis.Cmdable
+	key           string
+	capacity      int64
+	workerID      string
+	processingKey string
+	heartbeatKey  string
+}
+
+// newRedisExecutionQueue 创建 workerID 实例使用的Redis队列
+func newRedisExecutionQueue(client redis.Cmdable, capacity int64, workerID string) *redisExecutionQueue {
+	return &redisExecutionQueue{
+		client:        client,
+		key:           executionQueueKey,
+		capacity:      capacity,
+		workerID:      workerID,
+		processingKey: executionProcessingKey(workerID),
+		heartbeatKey:  executionHeartbeatKey(workerID),
+	}
+}
+
+// executionProcessingKey 实例的处理中列表
+func executionProcessingKey(workerID string) string {
+	return caching.WorkflowKeys.Key("queue", "processing", workerID)
+}
+
+// executionHeartbeatKey 实例的心跳键
+func executionHeartbeatKey(workerID string) string {
+	return caching.WorkflowKeys.Key("queue", "worker", workerID)
+}
+
+// newExecutionWorkerID 生成实例ID，每次启动不同，重启后的实例按已退出的实例恢复之前遗留的执行
+func newExecutionWorkerID() string {
+	raw := make([]byte, 8)
+	if _, err := rand.Read(raw); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(raw)
+}
+
+func (q *redisExecutionQueue) push(ctx context.Context, executionID uint64) error {
+	if q.capacity > 0 {
+		depth, err := q.client.LLen(ctx, q.key).Result()
+		if err != nil {
+			return err
+		}
+		if depth >= q.capacity {
+			return errExecutionQueueFull
+		}
+	}
+	return q.client.LPush(ctx, q.key, executionID).Err()
+}
+
+func (q *redisExecutionQueue) pop(ctx context.Context, wait time.Duration) (uint64, bool, error) {
+	var (
+		value string
+		err   error
+	)
+	if wait <= 0 {
+		value, err = q.client.RPopLPush(ctx, q.key, q.processingKey).Result()
+	} else {
+		value, err = q.client.BRPopLPush(ctx, q.key, q.processingKey, wait).Result()
+	}
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	executionID, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		q.client.LRem(ctx, q.processingKey, 1, value)
+		return 0, false, fmt.Errorf("invalid execution id in queue: %q", value)
+	}
+	return executionID, true, nil
+}
+
+func (q *redisExecutionQueue) ack(ctx context.Context, executionID uint64) error {
+	return q.client.LRem(ctx, q.processingKey, 1, executionID).Err()
+}
+
+// heartbeat 续期本实例的心跳
+func (q *redisExecutionQueue) heartbeat(ctx context.Context) error {
+	return q.client.Set(ctx, q.heartbeatKey, "1", executionWorkerHeartbeatTTL).Err()
+}
+
+// release 本实例的执行都已结束，删除心跳
+func (q *redisExecutionQueue) release(ctx context.Context) error {
+	return q.client.Del(ctx, q.heartbeatKey).Err()
+}
+
+// processingLists 返回所有实例的处理中列表，按实例是否存活分组
+func (q *redisExecutionQueue) processingLists(ctx context.Context) (alive, dead []string, err error) {
+	prefix := caching.WorkflowKeys.Prefix("queue", "processing")
+	var cursor uint64
+	for {
+		keys, next, err := q.client.Scan(ctx, cursor, prefix+"*", 100).Result()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range keys {
+			workerID := strings.TrimPrefix(key, prefix)
+			if workerID == q.workerID {
+				alive = append(alive, key)
+				continue
+			}
+			exists, err := q.client.Exists(ctx, executionHeartbeatKey(workerID)).Result()
+			if err != nil {
+				return nil, nil, err
+			}
+			if exists > 0 {
+				alive = append(alive, key)
+			} else {
+				dead = append(dead, key)
+			}
+		}
+		cursor = next
+		if cursor == 0 {
+			return alive, dead, nil
+		}
+	}
+}
+
+// requeueOrphaned 将已退出实例处理中列表里的执行放回队列，返回放回的数量
+func (q *redisExecutionQueue) requeueOrphaned(ctx context.Context) (int, error) {
+	_, dead, err := q.processingLists(ctx)
+	if err != nil {
+		return 0, err
+	}
+	requeued := 0
+	for _, key := range dead {
+		for {
+			err := q.client.RPopLPush(ctx, key, q.key).Err()
+			if errors.Is(err, redis.Nil) {
+				break
+			}
+			if err != nil {
+				return requeued, err
+			}
+			requeued++
+		}
+	}
+	return requeued, nil
+}
+
+// queued 返回排队中以及存活实例正在处理的执行ID，在一个事务中读取所有列表，避免遗漏正在被取出的执行
+func (q *redisExecutionQueue) queued(ctx context.Context) (map[uint64]bool, error) {
+	alive, _, err := q.processingLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var lists []*redis.StringSliceCmd
+	_, err = q.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range append([]string{q.key}, alive...) {
+			lists = append(lists, pipe.LRange(ctx, key, 0, -1))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[uint64]bool)
+	for _, list := range lists {
+		for _, value := range list.Val() {
+			if id, err := strconv.ParseUint(value, 10, 64); err == nil {
+				ids[id] = true
+			}
+		}
+	}
+	return ids, nil
+}
+
+// recoverExecutions 恢复已退出实例遗留的执行：先将其处理中列表里的执行放回队列，
+// 再将既不在队列中、也不在存活实例处理中列表里的待执行和运行中的执行（例如在入队前或旧版本出队后实例退出）重新入队。
+// 只处理在 staleBefore 之前最后更新的执行，返回重新入队的数量
+func (q *redisExecutionQueue) recoverExecutions(ctx context.Context, staleBefore time.Time) (int, error) {
+	requeued, err := q.requeueOrphaned(ctx)
+	if err != nil {
+		return requeued, fmt.Errorf("failed to requeue orphaned executions: %w", err)
+	}
+
+	queued, err := q.queued(ctx)
+	if err != nil {
+		return requeued, fmt.Errorf("failed to read queued executions: %w", err)
+	}
+	ids, err := database.Client.WorkflowExecution.Query().
+		Where(
+			workflowexecution.StatusIn(workflowexecution.StatusPending, workflowexecution.StatusRunning),
+			workflowexecution.UpdateTimeLT(staleBefore),
+		).
+		IDs(ctx)
+	if err != nil {
+		return requeued, fmt.Errorf("failed to query unfinished executions: %w", err)
+	}
+	for _, id := range ids {
+		if queued[id] {
+			continue
+		}
+		// 恢复的执行不受队列容量限制，避免在队列已满时丢失
+		if err := q.client.LPush(ctx, q.key, id).Err(); err != nil {
+			return requeued, err
+		}
+		requeued++
+	}
+	return requeued, nil
+}
+
+func (q *redisExecutionQueue) depth(ctx context.Context) (int64, error) {
+	return q.client.LLen(ctx, q.key).Result()
+}
+
+func (q *redisExecutionQueue) name() string {
+	return "redis"
+}
+
+// fallbackExecutionQueue Redis 队列写入失败时退回进程内队列，工作协程优先处理进程内队列中的执行
+type fallbackExecutionQueue struct {
+	primary executionQueueBackend
+	local   *localExecutionQueue
+}
+
+func (q *fallbackExecutionQueue) push(ctx context.Context, executionID uint64) error {
+	err := q.primary.push(ctx, executionID)
+	if err == nil || errors.Is(err, errExecutionQueueFull) {
+		return err
+	}
+	logging.Warn("Failed to enqueue workflow execution %d to %s queue, falling back to local queue: %v", executionID, q.primary.name(), err)
+	return q.local.push(ctx, executionID)
+}
+
+func (q *fallbackExecutionQueue) pop(ctx context.Context, wait time.Duration) (uint64, bool, error) {
+	if executionID, ok, _ := q.local.pop(ctx, 0); ok {
+		return executionID, true, nil
+	}
+	return q.primary.pop(ctx, wait)
+}
+
+func (q *fallbackExecutionQueue) ack(ctx context.Context, executionID uint64) error {
+	return q.primary.ack(ctx, executionID)
+}
+
+func (q *fallbackExecutionQueue) depth(ctx context.Context) (int64, error) {
+	local, _ := q.local.depth(ctx)
+	primary, err := q.primary.depth(ctx)
+	return primary + local, err
+}
+
+func (q *fallbackExecutionQueue) name() string {
+	return q.primary.name()
+}
+
+// workflowExecutionQueue 执行队列和工作池
+type workflowExecutionQueue struct {
+	backend  executionQueueBackend
+	runner   WorkflowExecutionRunner
+	workers  int
+	pollWait time.Duration
+	// recovery 使用Redis队列时负责心跳和恢复已退出实例遗留的执行，进程内队列为空
+	recovery *redisExecutionQueue
+
+	accepting atomic.Bool
+	active    atomic.Int64
+	stop      chan struct{}
+	wg        sync.WaitGroup
+}
+
+// executionQueue 当前运行的执行队列，未启用或未注册执行引擎时为空
+var executionQueue *workflowExecutionQueue
+
+// InitWorkflowExecutionQueue 根据配置启动执行队列和工作池，Redis 可用时使用Redis队列
+func InitWorkflowExecutionQueue(config *configs.ExecutionQueueConfig) {
+	if !config.Enabled {
+		return
+	}
+	if workflowExecutionRunner == nil {
+		logging.Info("No workflow execution runner registered, executions stay pending for external executors")
+		return
+	}
+
+	local := newLocalExecutionQueue(config.Capacity)
+	var backend executionQueueBackend = local
+	var recovery *redisExecutionQueue
+	if caching.Client != nil {
+		recovery = newRedisExecutionQueue(caching.Client, int64(config.Capacity), newExecutionWorkerID())
+		backend = &fallbackExecutionQueue{primary: recovery, local: local}
+	}
+
+	executionQueue = newWorkflowExecutionQueue(backend, workflowExecutionRunner, config.Workers)
+	executionQueue.recovery = recovery
+	executionQueue.start()
+	logging.Info("Workflow execution queue started with %d workers (%s)", executionQueue.workers, backend.name())
+}
+
+// StopWorkflowExecutionQueue 停止接受新的执行，并在 timeout 内等待进行中的执行完成；
+// Redis 队列中尚未开始的执行保留到下次启动处理，超时仍未结束的执行在心跳过期后由其他实例或重启后的实例重新入队。停止后队列仍保留，健康检查据此报告正在关闭
+func StopWorkflowExecutionQueue(timeout time.Duration) {
+	if executionQueue == nil {
+		return
+	}
+	if err := executionQueue.drain(timeout); err != nil {
+		logging.Warn("Workflow execution queue drain incomplete: %v", err)
+	} else {
+		logging.Info("Workflow execution queue drained")
+	}
+}
+
+func newWorkflowExecutionQueue(backend executionQueueBackend, runner WorkflowExecutionRunner, workers int) *workflowExecutionQueue {
+	if workers <= 0 {
+		workers = 1
+	}
+	q := &workflowExecutionQueue{
+		backend:  backend,
+		runner:   runner,
+		workers:  workers,
+		pollWait: executionQueuePollWait,
+		stop:     make(chan struct{}),
+	}
+	q.accepting.Store(true)
+	return q
+}
+
+// start 启动工作协程，使用Redis队列时先恢复已退出实例遗留的执行并启动心跳
+func (q *workflowExecutionQueue) start() {
+	for i := 0; i < q.workers; i++ {
+		q.wg.Add(1)
+		go q.work()
+	}
+
+	if q.recovery == nil {
+		return
+	}
+	ctx := context.Background()
+	if err := q.recovery.heartbeat(ctx); err != nil {
+		logging.Warn("Failed to register workflow execution worker: %v", err)
+	}
+	q.recoverExecutions(ctx)
+
+	workersDone := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(workersDone)
+	}()
+	go q.maintain(workersDone)
+}
+
+// maintain 定期续期心跳并恢复已退出实例遗留的执行。心跳持续到所有工作协程退出，
+// 关闭时仍在运行的执行不会被其他实例提前恢复；工作协程全部退出后删除心跳
+func (q *workflowExecutionQueue) maintain(workersDone <-chan struct{}) {
+	ctx := context.Background()
+	heartbeat := time.NewTicker(executionWorkerHeartbeatInterval)
+	defer heartbeat.Stop()
+	recoverTicker := time.NewTicker(executionQueueRecoverInterval)
+	defer recoverTicker.Stop()
+	for {
+		select {
+		case <-workersDone:
+			if err := q.recovery.release(ctx); err != nil {
+				logging.Warn("Failed to unregister workflow execution worker: %v", err)
+			}
+			return
+		case <-heartbeat.C:
+			if err := q.recovery.heartbeat(ctx); err != nil {
+				logging.Warn("Failed to renew workflow execution worker heartbeat: %v", err)
+			}
+		case <-recoverTicker.C:
+			if q.accepting.Load() {
+				q.recoverExecutions(ctx)
+			}
+		}
+	}
+}
+
+// recoverExecutions 将已退出实例遗留的执行重新入队
+func (q *workflowExecutionQueue) recoverExecutions(ctx context.Context) {
+	requeued, err := q.recovery.recoverExecutions(ctx, time.Now().Add(-executionRecoveryGrace))
+	if err != nil {
+		logging.Error("Failed to recover workflow executions: %v", err)
+	}
+	if requeued > 0 {
+		logging.Warn("Requeued %d workflow executions left by stopped workers", requeued)
+	}
+}
+
+// enqueue 将执行加入队列
+func (q *workflowExecutionQueue) enqueue(ctx context.Context, executionID uint64) error {
+	if !q.accepting.Load() {
+		return errExecutionQueueClosed
+	}
+	return q.backend.push(ctx, executionID)
+}
+
+// work 工作协程循环取出执行并运行，收到停止信号后完成当前执行再退出
+func (q *workflowExecutionQueue) work() {
+	defer q.wg.Done()
+	ctx := context.Background()
+	for {
+		select {
+		case <-q.stop:
+			return
+		default:
+		}
+
+		executionID, ok, err := q.backend.pop(ctx, q.pollWait)
+		if err != nil {
+			logging.Error("Failed to dequeue workflow execution: %v", err)
+			select {
+			case <-q.stop:
+				return
+			case <-time.After(q.pollWait):
+			}
+			continue
+		}
+		if ok {
+			q.run(ctx, executionID)
+			if err := q.backend.ack(ctx, executionID); err != nil {
+				logging.Warn("Failed to acknowledge workflow execution %d: %v", executionID, err)
+			}
+		}
+	}
+}
+
+// run 运行一次执行，引擎返回错误或panic且执行未结束时将其标记为失败
+func (q *workflowExecutionQueue) run(ctx context.Context, executionID uint64) {
+	q.active.Add(1)
+	defer q.active.Add(-1)
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("workflow execution runner panicked: %v", r)
+			}
+		}()
+		return q.runner(ctx, executionID)
+	}()
+	if err == nil {
+		return
+	}
+
+	_, finishErr := WorkflowFuncs{}.FinishWorkflowExecution(ctx, executionID, &models.UpdateWorkflowExecutionRequest{
+		Status:       string(workflowexecution.StatusFailed),
+		ErrorMessage: err.Error(),
+	})
+	if finishErr != nil && finishErr.Error() != "workflow execution already finished" {
+		logging.Error("Failed to mark workflow execution %d as failed: %v", executionID, finishErr)
+	}
+}
+
+// drain 停止接受新的执行并通知工作协程退出，等待进行中的执行完成
+func (q *workflowExecutionQueue) drain(timeout time.Duration) error {
+	if !q.accepting.CompareAndSwap(true, false) {
+		return nil
+	}
+	close(q.stop)
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-time.After(timeout):
+		return fmt.Errorf("%d executions still running after %s", q.active.Load(), timeout)
+	}
+}
+
+// stats 队列的当前状态
+func (q *workflowExecutionQueue) stats(ctx context.Context) *models.WorkflowExecutionQueueStats {
+	stats := &models.WorkflowExecutionQueueStats{
+		Enabled:       true,
+		Accepting:     q.accepting.Load(),
+		Backend:       q.backend.name(),
+		Workers:       q.workers,
+		ActiveWorkers: q.active.Load(),
+	}
+	if depth, err := q.backend.depth(ctx); err == nil {
+		stats.Depth = depth
+	} else {
+		stats.Error = err.Error()
+	}
+	return stats
+}
+
+// enqueueWorkflowExecution 执行队列启用时将执行加入队列，未启用时不做处理
+func enqueueWorkflowExecution(ctx context.Context, executionID uint64) error {
+	if executionQueue == nil {
+		return nil
+	}
+	return executionQueue.enqueue(ctx, executionID)
+}
+
+// checkExecutionQueueAccepting 创建执行前检查队列是否仍在接受新的执行，避免留下无法处理的执行记录
+func checkExecutionQueueAccepting() error {
+	if executionQueue != nil && !executionQueue.accepting.Load() {
+		return errExecutionQueueClosed
+	}
+	return nil
+}
+
+// GetExecutionQueueStats 获取执行队列的深度和工作协程状态
+func (WorkflowFuncs) GetExecutionQueueStats(ctx context.Context) *models.WorkflowExecutionQueueStats {
+	if executionQueue == nil {
+		return &models.WorkflowExecutionQueueStats{}
+	}
+	return executionQueue.stats(ctx)
+}
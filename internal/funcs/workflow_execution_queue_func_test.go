@@ -0,0 +1,267 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/pkg/database"
+)
+
+func TestLocalExecutionQueue(t *testing.T) {
+	ctx := context.Background()
+	q := newLocalExecutionQueue(2)
+
+	if _, ok, err := q.pop(ctx, 0); ok || err != nil {
+		t.Fatalf("empty queue should return nothing, got ok=%v err=%v", ok, err)
+	}
+	for _, id := range []uint64{1, 2} {
+		if err := q.push(ctx, id); err != nil {
+			t.Fatalf("push %d failed: %v", id, err)
+		}
+	}
+	if err := q.push(ctx, 3); !errors.Is(err, errExecutionQueueFull) || !IsExecutionQueueUnavailable(err) {
+		t.Fatalf("expected full queue error, got %v", err)
+	}
+	if depth, _ := q.depth(ctx); depth != 2 {
+		t.Fatalf("expected depth 2, got %d", depth)
+	}
+	for _, want := range []uint64{1, 2} {
+		if got, ok, err := q.pop(ctx, time.Millisecond); !ok || err != nil || got != want {
+			t.Fatalf("expected %d, got %d (ok=%v err=%v)", want, got, ok, err)
+		}
+	}
+	if _, ok, _ := q.pop(ctx, 10*time.Millisecond); ok {
+		t.Fatal("drained queue should time out")
+	}
+}
+
+func TestRedisExecutionQueue(t *testing.T) {
+	ctx := context.Background()
+	_, client := newIPGuardTestClient(t)
+	q := newRedisExecutionQueue(client, 2, "worker-a")
+
+	for _, id := range []uint64{7, 8} {
+		if err := q.push(ctx, id); err != nil {
+			t.Fatalf("push %d failed: %v", id, err)
+		}
+	}
+	if err := q.push(ctx, 9); !errors.Is(err, errExecutionQueueFull) {
+		t.Fatalf("expected full queue error, got %v", err)
+	}
+	if depth, _ := q.depth(ctx); depth != 2 {
+		t.Fatalf("expected depth 2, got %d", depth)
+	}
+
+	// 入队的执行保存在Redis中，新的队列实例（重启后）可以继续取出
+	restarted := newRedisExecutionQueue(client, 0, "worker-b")
+	if got, ok, err := restarted.pop(ctx, 0); !ok || err != nil || got != 7 {
+		t.Fatalf("expected 7, got %d (ok=%v err=%v)", got, ok, err)
+	}
+	if got, ok, err := restarted.pop(ctx, 0); !ok || err != nil || got != 8 {
+		t.Fatalf("expected 8, got %d (ok=%v err=%v)", got, ok, err)
+	}
+	if _, ok, err := restarted.pop(ctx, 0); ok || err != nil {
+		t.Fatalf("empty queue should return nothing, got ok=%v err=%v", ok, err)
+	}
+
+	// 取出的执行在运行结束前保留在实例的处理中列表里
+	if processing, _ := client.LRange(ctx, restarted.processingKey, 0, -1).Result(); len(processing) != 2 {
+		t.Fatalf("popped executions should be tracked as processing, got %v", processing)
+	}
+	for _, id := range []uint64{7, 8} {
+		if err := restarted.ack(ctx, id); err != nil {
+			t.Fatalf("ack %d failed: %v", id, err)
+		}
+	}
+	if n, _ := client.LLen(ctx, restarted.processingKey).Result(); n != 0 {
+		t.Fatalf("acknowledged executions should leave the processing list, got %d", n)
+	}
+
+	// 退回进程内队列的执行优先被取出，深度包含两个队列
+	fallback := &fallbackExecutionQueue{primary: q, local: newLocalExecutionQueue(1)}
+	if err := fallback.push(ctx, 10); err != nil {
+		t.Fatalf("push failed: %v", err)
+	}
+	if err := fallback.local.push(ctx, 11); err != nil {
+		t.Fatalf("local push failed: %v", err)
+	}
+	if depth, _ := fallback.depth(ctx); depth != 2 {
+		t.Fatalf("expected depth 2, got %d", depth)
+	}
+	for _, want := range []uint64{11, 10} {
+		if got, ok, err := fallback.pop(ctx, 0); !ok || err != nil || got != want {
+			t.Fatalf("expected %d, got %d (ok=%v err=%v)", want, got, ok, err)
+		}
+	}
+}
+
+func TestExecutionQueueRunsAndDrains(t *testing.T) {
+	ctx := context.Background()
+	started := make(chan uint64, 4)
+	release := make(chan struct{})
+	var mu sync.Mutex
+	var finished []uint64
+
+	q := newWorkflowExecutionQueue(newLocalExecutionQueue(10), func(ctx context.Context, executionID uint64) error {
+		started <- executionID
+		<-release
+		mu.Lock()
+		finished = append(finished, executionID)
+		mu.Unlock()
+		return nil
+	}, 2)
+	q.pollWait = 10 * time.Millisecond
+	q.start()
+
+	for _, id := range []uint64{1, 2} {
+		if err := q.enqueue(ctx, id); err != nil {
+			t.Fatalf("enqueue %d failed: %v", id, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatal("workers did not pick up the executions")
+		}
+	}
+	if stats := q.stats(ctx); stats.ActiveWorkers != 2 || stats.Depth != 0 || !stats.Accepting {
+		t.Fatalf("unexpected stats while running: %+v", stats)
+	}
+
+	// 关闭时等待进行中的执行完成，超时前返回错误
+	if err := q.drain(20 * time.Millisecond); err == nil {
+		t.Fatal("drain should time out while executions are running")
+	}
+	if err := q.enqueue(ctx, 3); !errors.Is(err, errExecutionQueueClosed) {
+		t.Fatalf("draining queue should reject new executions, got %v", err)
+	}
+	if stats := q.stats(ctx); stats.Accepting {
+		t.Fatal("draining queue should report not accepting")
+	}
+
+	close(release)
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("workers did not exit after finishing in-flight executions")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(finished) != 2 {
+		t.Fatalf("in-flight executions should finish, got %v", finished)
+	}
+}
+
+func TestExecutionQueueMarksFailedRuns(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running", "started_at": time.Now()})
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 101, "execution_id": "exec-2", "application_id": 1, "status": "running", "started_at": time.Now()})
+	ctx := context.Background()
+
+	q := newWorkflowExecutionQueue(newLocalExecutionQueue(10), func(ctx context.Context, executionID uint64) error {
+		if executionID == 100 {
+			return errors.New("engine crashed")
+		}
+		panic("unexpected node type")
+	}, 1)
+	q.run(ctx, 100)
+	q.run(ctx, 101)
+
+	for id, message := range map[uint64]string{100: "engine crashed", 101: "workflow execution runner panicked: unexpected node type"} {
+		execution := client.WorkflowExecution.GetX(ctx, id)
+		if execution.Status != workflowexecution.StatusFailed || execution.ErrorMessage != message {
+			t.Fatalf("execution %d should be failed with %q, got %s %q", id, message, execution.Status, execution.ErrorMessage)
+		}
+	}
+	if q.active.Load() != 0 {
+		t.Fatalf("active workers should return to 0, got %d", q.active.Load())
+	}
+}
+
+func TestRedisExecutionQueueRecoversStoppedWorkers(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	_, redisClient := newIPGuardTestClient(t)
+	ctx := context.Background()
+
+	seedWorkflowApplication(t, db, 1)
+	stale := time.Now().Add(-time.Hour)
+	for id, status := range map[uint64]string{
+		100: "pending",   // 入队前实例退出
+		101: "running",   // 在已退出实例的处理中列表里
+		102: "running",   // 出队后实例退出，不在任何列表里
+		103: "running",   // 存活实例正在处理
+		104: "pending",   // 仍在队列中
+		105: "completed", // 已结束
+	} {
+		insertTestRow(t, db, "workflow_executions", map[string]any{
+			"id": id, "execution_id": fmt.Sprintf("exec-%d", id), "application_id": 1, "status": status, "update_time": stale,
+		})
+	}
+	// 最近更新的执行可能刚创建尚未入队，不参与恢复
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 106, "execution_id": "exec-106", "application_id": 1, "status": "pending", "update_time": time.Now()})
+
+	crashed := newRedisExecutionQueue(redisClient, 0, "crashed")
+	alive := newRedisExecutionQueue(redisClient, 0, "alive")
+	for _, id := range []uint64{101, 103, 104} {
+		if err := crashed.push(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// crashed 取出101后退出，没有心跳；alive 取出103并保持心跳
+	if got, _, _ := crashed.pop(ctx, 0); got != 101 {
+		t.Fatalf("expected 101, got %d", got)
+	}
+	if got, _, _ := alive.pop(ctx, 0); got != 103 {
+		t.Fatalf("expected 103, got %d", got)
+	}
+	if err := alive.heartbeat(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	restarted := newRedisExecutionQueue(redisClient, 0, "restarted")
+	requeued, err := restarted.recoverExecutions(ctx, time.Now().Add(-executionRecoveryGrace))
+	if err != nil {
+		t.Fatalf("recover failed: %v", err)
+	}
+	if requeued != 3 {
+		t.Fatalf("expected 3 executions to be requeued, got %d", requeued)
+	}
+
+	pending, _ := redisClient.LRange(ctx, executionQueueKey, 0, -1).Result()
+	sort.Strings(pending)
+	if want := []string{"100", "101", "102", "104"}; !reflect.DeepEqual(pending, want) {
+		t.Fatalf("expected queue %v, got %v", want, pending)
+	}
+	if n, _ := redisClient.LLen(ctx, crashed.processingKey).Result(); n != 0 {
+		t.Fatalf("stopped worker's processing list should be emptied, got %d", n)
+	}
+	if processing, _ := redisClient.LRange(ctx, alive.processingKey, 0, -1).Result(); len(processing) != 1 || processing[0] != "103" {
+		t.Fatalf("live worker's processing list should be untouched, got %v", processing)
+	}
+
+	// 再次恢复不会重复入队
+	if requeued, err := restarted.recoverExecutions(ctx, time.Now().Add(-executionRecoveryGrace)); err != nil || requeued != 0 {
+		t.Fatalf("second recovery should be a no-op, got %d (%v)", requeued, err)
+	}
+}
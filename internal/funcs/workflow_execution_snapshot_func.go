@@ -0,0 +1,225 @@
+package funcs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 执行期间的编辑隔离：
+//
+// 创建执行记录时把应用、节点和边序列化后保存到执行的 graph_snapshot 字段，执行器通过
+// GetExecutionGraph 按快照遍历，执行路径等查询也使用快照，因此执行开始后对工作流的编辑只影响之后创建的执行。
+// 批量保存和拍摄快照都需要持有应用的编辑锁（Redis 咨询锁），保证快照不会读到保存到一半的图，
+// 大批量模式分块提交时也一样。未启用 Redis 时不加锁。
+// 配置 workflow.edit_lock.block_while_running 为 true 时，应用存在待执行或运行中的执行时拒绝批量保存。
+
+// errWorkflowBeingEdited 编辑锁被其他保存或快照占用
+const errWorkflowBeingEdited = "workflow application is being edited"
+
+// errWorkflowExecutionRunning 应用存在未结束的执行，按配置拒绝编辑
+const errWorkflowExecutionRunning = "workflow application has running executions"
+
+// editLockRetryInterval 等待编辑锁时的重试间隔
+const editLockRetryInterval = 50 * time.Millisecond
+
+// releaseEditLockScript 只有锁仍属于自己时才删除，避免超时后误删其他请求持有的锁
+var releaseEditLockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+// IsWorkflowEditConflict 判断错误是否为编辑冲突（编辑锁被占用或应用正在执行）
+func IsWorkflowEditConflict(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.HasPrefix(message, errWorkflowBeingEdited) || strings.HasPrefix(message, errWorkflowExecutionRunning)
+}
+
+// executionGraphSnapshot 执行开始时的工作流图，ID 以数字保存，反序列化时不会丢失精度
+type executionGraphSnapshot struct {
+	Application *ent.WorkflowApplication `json:"application"`
+	Nodes       []*ent.WorkflowNode      `json:"nodes"`
+	Edges       []*ent.WorkflowEdge      `json:"edges"`
+}
+
+// editLockKey 应用编辑锁的键名
+func editLockKey(applicationID uint64) string {
+	return caching.WorkflowKeys.Key("edit_lock", applicationID)
+}
+
+// acquireApplicationEditLock 获取应用编辑锁，锁被占用时在 wait 内重试，超时返回编辑冲突错误。
+// 锁在 ttl 后自动过期，防止持有者异常退出后应用无法编辑；返回的 release 只会释放自己持有的锁
+func acquireApplicationEditLock(ctx context.Context, client redis.Cmdable, applicationID uint64, ttl, wait time.Duration) (func(), error) {
+	if client == nil {
+		return func() {}, nil
+	}
+
+	key := editLockKey(applicationID)
+	token := utils.UUIDString()
+	deadline := time.Now().Add(wait)
+	for {
+		acquired, err := client.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire edit lock: %w", err)
+		}
+		if acquired {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return nil, fmt.Errorf("%s: %d", errWorkflowBeingEdited, applicationID)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(editLockRetryInterval):
+		}
+	}
+
+	return func() {
+		// 使用独立的上下文，请求被取消时仍然释放锁
+		releaseEditLockScript.Run(context.Background(), client, []string{key}, token)
+	}, nil
+}
+
+// lockApplicationForEdit 按配置获取全局 Redis 客户端上的应用编辑锁
+func lockApplicationForEdit(ctx context.Context, applicationID uint64) (func(), error) {
+	lockConfig := configs.GetConfig().Workflow.EditLock
+	var client redis.Cmdable
+	if caching.Client != nil {
+		client = caching.Client
+	}
+	return acquireApplicationEditLock(ctx, client, applicationID,
+		time.Duration(lockConfig.TTL)*time.Second, time.Duration(lockConfig.WaitTimeout)*time.Second)
+}
+
+// checkNoRunningExecutions 应用存在待执行或运行中的执行时返回错误
+func checkNoRunningExecutions(ctx context.Context, applicationID uint64) error {
+	running, err := database.Client.WorkflowExecution.Query().
+		Where(
+			workflowexecution.ApplicationIDEQ(applicationID),
+			workflowexecution.StatusIn(workflowexecution.StatusPending, workflowexecution.StatusRunning),
+		).
+		Count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check running executions: %w", err)
+	}
+	if running > 0 {
+		return fmt.Errorf("%s: %d", errWorkflowExecutionRunning, running)
+	}
+	return nil
+}
+
+// snapshotWorkflowGraph 读取应用当前的节点和边并序列化为执行快照，调用方负责持有编辑锁
+func snapshotWorkflowGraph(ctx context.Context, applicationID uint64) (string, error) {
+	app, err := database.Client.WorkflowApplication.Get(ctx, applicationID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", fmt.Errorf("workflow application not found")
+		}
+		return "", err
+	}
+	nodes, err := database.Client.WorkflowNode.Query().
+		Where(workflownode.ApplicationID(applicationID)).
+		Order(ent.Asc(workflownode.FieldID)).
+		All(ctx)
+	if err != nil {
+		return "", err
+	}
+	edges, err := database.Client.WorkflowEdge.Query().
+		Where(workflowedge.ApplicationID(applicationID)).
+		Order(ent.Asc(workflowedge.FieldID)).
+		All(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(executionGraphSnapshot{Application: app, Nodes: nodes, Edges: edges})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal graph snapshot: %w", err)
+	}
+	return string(data), nil
+}
+
+// takeExecutionSnapshot 持有编辑锁拍摄应用的图快照
+func takeExecutionSnapshot(ctx context.Context, applicationID uint64) (string, error) {
+	release, err := lockApplicationForEdit(ctx, applicationID)
+	if err != nil {
+		return "", err
+	}
+	defer release()
+	return snapshotWorkflowGraph(ctx, applicationID)
+}
+
+// loadExecutionSnapshot 加载执行使用的工作流图：优先使用执行开始时的快照，
+// 没有快照的执行（功能上线前创建的记录）退回到应用当前的节点和边
+func loadExecutionSnapshot(ctx context.Context, execution *ent.WorkflowExecution) (*executionGraphSnapshot, error) {
+	data := execution.GraphSnapshot
+	if data == "" {
+		var err error
+		if data, err = snapshotWorkflowGraph(ctx, execution.ApplicationID); err != nil {
+			return nil, err
+		}
+	}
+
+	var snapshot executionGraphSnapshot
+	if err := json.Unmarshal([]byte(data), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse graph snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
+
+// loadExecutionGraph 按执行使用的快照构建邻接表
+func loadExecutionGraph(ctx context.Context, execution *ent.WorkflowExecution) (*workflowGraph, error) {
+	snapshot, err := loadExecutionSnapshot(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+	return buildWorkflowGraph(snapshot.Application, snapshot.Nodes, snapshot.Edges), nil
+}
+
+// GetExecutionGraph 获取执行使用的节点和边，执行器应按此遍历而不是读取应用当前的图
+func (WorkflowFuncs) GetExecutionGraph(ctx context.Context, id uint64) (*models.WorkflowVersionSnapshot, error) {
+	execution, err := database.Client.WorkflowExecution.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+
+	snapshot, err := loadExecutionSnapshot(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.WorkflowVersionSnapshot{
+		Nodes: make([]*models.WorkflowNodeResponse, 0, len(snapshot.Nodes)),
+		Edges: make([]*models.WorkflowEdgeResponse, 0, len(snapshot.Edges)),
+	}
+	for _, node := range snapshot.Nodes {
+		result.Nodes = append(result.Nodes, WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node))
+	}
+	for _, edge := range snapshot.Edges {
+		result.Edges = append(result.Edges, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
+	}
+	return result, nil
+}
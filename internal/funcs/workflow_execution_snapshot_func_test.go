@@ -0,0 +1,130 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+)
+
+func TestExecutionGraphIsolatedFromEditsDuringRun(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	ctx := context.Background()
+
+	snapshot, err := snapshotWorkflowGraph(ctx, 1)
+	if err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	insertTestRow(t, db, "workflow_executions", map[string]any{
+		"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running", "graph_snapshot": snapshot,
+	})
+	insertTestRow(t, db, "workflow_executions", map[string]any{
+		"id": 101, "execution_id": "exec-legacy", "application_id": 1, "status": "running",
+	})
+
+	// 执行期间编辑工作流：重命名开始节点、删除原有的边、新增节点和边
+	client.WorkflowNode.UpdateOneID(11).SetName("renamed").ExecX(ctx)
+	client.WorkflowEdge.DeleteOneID(13).ExecX(ctx)
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 16, "application_id": 1, "name": "llm", "node_key": "llm", "type": "llm_call"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 17, "application_id": 1, "source_node_id": 11, "target_node_id": 16, "edge_key": "e2", "type": "default"})
+
+	execution := client.WorkflowExecution.GetX(ctx, 100)
+	graph, err := loadExecutionGraph(ctx, execution)
+	if err != nil {
+		t.Fatalf("load execution graph failed: %v", err)
+	}
+	if len(graph.nodes) != 2 || graph.nodes[11].Name != "start" || graph.nodes[11].Type != workflownode.TypeUserInput {
+		t.Fatalf("execution should see the nodes at start: %+v", graph.nodes)
+	}
+	if targets := graph.outgoing[11]; len(targets) != 1 || targets[0] != 12 {
+		t.Fatalf("execution should follow the original edge, got %v", targets)
+	}
+	if graph.application == nil || graph.application.ID != 1 {
+		t.Fatalf("snapshot should include the application: %+v", graph.application)
+	}
+
+	// 新的执行和没有快照的旧执行看到编辑后的图
+	current, err := loadWorkflowGraph(ctx, 1)
+	if err != nil {
+		t.Fatalf("load current graph failed: %v", err)
+	}
+	if len(current.nodes) != 3 || current.nodes[11].Name != "renamed" {
+		t.Fatalf("current graph should reflect edits: %+v", current.nodes)
+	}
+	legacy, err := loadExecutionGraph(ctx, client.WorkflowExecution.GetX(ctx, 101))
+	if err != nil {
+		t.Fatalf("load legacy execution graph failed: %v", err)
+	}
+	if targets := legacy.outgoing[11]; len(targets) != 1 || targets[0] != 16 {
+		t.Fatalf("execution without snapshot should use the current graph, got %v", targets)
+	}
+
+	result, err := WorkflowFuncs{}.GetExecutionGraph(ctx, 100)
+	if err != nil {
+		t.Fatalf("get execution graph failed: %v", err)
+	}
+	if len(result.Nodes) != 2 || len(result.Edges) != 1 || result.Edges[0].ID != "13" {
+		t.Fatalf("unexpected execution graph: %+v", result)
+	}
+	if _, err := (WorkflowFuncs{}).GetExecutionGraph(ctx, 999); err == nil || err.Error() != "workflow execution not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
+
+func TestApplicationEditLock(t *testing.T) {
+	server, client := newIPGuardTestClient(t)
+	ctx := context.Background()
+
+	release, err := acquireApplicationEditLock(ctx, client, 1, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("acquire failed: %v", err)
+	}
+
+	// 锁被占用时等待超时后返回编辑冲突，其他应用不受影响
+	if _, err := acquireApplicationEditLock(ctx, client, 1, time.Minute, 100*time.Millisecond); !IsWorkflowEditConflict(err) {
+		t.Fatalf("expected edit conflict, got %v", err)
+	}
+	releaseOther, err := acquireApplicationEditLock(ctx, client, 2, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("lock of another application should be independent: %v", err)
+	}
+	releaseOther()
+
+	// 等待期间锁被释放时获取成功
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		release()
+	}()
+	releaseWaiter, err := acquireApplicationEditLock(ctx, client, 1, time.Minute, 5*time.Second)
+	if err != nil {
+		t.Fatalf("waiter should acquire the released lock: %v", err)
+	}
+
+	// 过期后被他人获取的锁不会被原持有者释放
+	server.FastForward(time.Minute + time.Second)
+	releaseNext, err := acquireApplicationEditLock(ctx, client, 1, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("expired lock should be acquirable: %v", err)
+	}
+	releaseWaiter()
+	if !server.Exists(editLockKey(1)) {
+		t.Fatal("stale release should not delete the lock held by another request")
+	}
+	releaseNext()
+	if server.Exists(editLockKey(1)) {
+		t.Fatal("lock should be released")
+	}
+
+	// 未启用 Redis 时不加锁
+	noop, err := acquireApplicationEditLock(ctx, nil, 1, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("nil client should not fail: %v", err)
+	}
+	noop()
+}
@@ -0,0 +1,37 @@
+package funcs
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go-backend/pkg/utils"
+)
+
+// 执行级变量覆盖：
+//
+// 同一个工作流在不同环境（如 staging 和 prod 的接口地址）下执行时，可以通过执行请求的 context 覆盖应用变量，而不必修改应用。
+// 创建执行时，以图快照中的应用变量为基础，按 JSON Merge Patch 规则合并执行上下文，结果保存到执行记录的 variables 字段，
+// 执行器应使用该字段而不是应用当前的变量。优先级：执行上下文 > 应用变量；两边都是对象时递归合并，
+// 上下文中值为 null 的键表示本次执行不使用该变量。返回给客户端时按敏感键名脱敏。
+
+// resolveExecutionVariables 计算执行生效的变量：图快照中的应用变量合并执行上下文，执行上下文优先
+func resolveExecutionVariables(graphSnapshot string, executionContext map[string]interface{}) (map[string]interface{}, error) {
+	var snapshot executionGraphSnapshot
+	if err := json.Unmarshal([]byte(graphSnapshot), &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse graph snapshot: %w", err)
+	}
+
+	var appVariables map[string]interface{}
+	if snapshot.Application != nil {
+		appVariables = snapshot.Application.Variables
+	}
+	if appVariables == nil && executionContext == nil {
+		return nil, nil
+	}
+	return utils.MergeJSON(appVariables, executionContext), nil
+}
+
+// redactExecutionVariables 返回脱敏后的执行变量副本，敏感键名的值被替换
+func redactExecutionVariables(variables map[string]interface{}) map[string]interface{} {
+	return utils.RedactJSON(variables, utils.SensitiveKeys())
+}
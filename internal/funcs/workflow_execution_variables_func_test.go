@@ -0,0 +1,76 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+)
+
+func TestExecutionContextOverridesApplicationVariables(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	ctx := context.Background()
+	client.WorkflowApplication.UpdateOneID(1).SetVariables(map[string]interface{}{
+		"env":    "prod",
+		"apiKey": "prod-key",
+		"api":    map[string]interface{}{"baseUrl": "https://api.example.com", "timeout": float64(30)},
+		"debug":  true,
+	}).ExecX(ctx)
+
+	snapshot, err := snapshotWorkflowGraph(ctx, 1)
+	if err != nil {
+		t.Fatalf("snapshot failed: %v", err)
+	}
+	variables, err := resolveExecutionVariables(snapshot, map[string]interface{}{
+		"env":    "staging",
+		"apiKey": "staging-key",
+		"api":    map[string]interface{}{"baseUrl": "https://staging.example.com"},
+		"debug":  nil,
+		"extra":  "only-in-context",
+	})
+	if err != nil {
+		t.Fatalf("resolve variables failed: %v", err)
+	}
+
+	// 执行上下文优先，嵌套对象递归合并，null 表示不使用该变量
+	api := variables["api"].(map[string]interface{})
+	if variables["env"] != "staging" || variables["apiKey"] != "staging-key" || api["baseUrl"] != "https://staging.example.com" {
+		t.Fatalf("execution context should win, got %v", variables)
+	}
+	if api["timeout"] != float64(30) {
+		t.Fatalf("unset nested keys should keep the application default, got %v", api)
+	}
+	if _, ok := variables["debug"]; ok {
+		t.Fatalf("null override should remove the variable, got %v", variables)
+	}
+	if variables["extra"] != "only-in-context" {
+		t.Fatalf("context-only keys should be included, got %v", variables)
+	}
+
+	// 没有覆盖时沿用应用变量
+	defaults, err := resolveExecutionVariables(snapshot, nil)
+	if err != nil || defaults["env"] != "prod" {
+		t.Fatalf("application variables should apply without overrides, got %v (%v)", defaults, err)
+	}
+
+	// 执行记录保存生效的变量，响应中敏感键被脱敏
+	execution := client.WorkflowExecution.Create().
+		SetExecutionID("exec-1").
+		SetApplicationID(1).
+		SetVariables(variables).
+		SaveX(ctx)
+	stored := client.WorkflowExecution.GetX(ctx, execution.ID)
+	if stored.Variables["apiKey"] != "staging-key" {
+		t.Fatalf("stored variables should keep the real value for the engine, got %v", stored.Variables)
+	}
+	resp := WorkflowFuncs{}.ConvertWorkflowExecutionToResponse(stored)
+	if resp.Variables["apiKey"] != utils.RedactedValue || resp.Variables["env"] != "staging" {
+		t.Fatalf("response variables should be redacted, got %v", resp.Variables)
+	}
+}
@@ -0,0 +1,145 @@
+package funcs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// errExecutionWaitTimeout 等待执行结束超时，执行仍在进行，调用方应改为轮询
+const errExecutionWaitTimeout = "workflow execution wait timed out"
+
+// executionWaitPollInterval 等待执行结束时重新读取执行状态的间隔，用于弥补Redis不可用或事件丢失
+const executionWaitPollInterval = 2 * time.Second
+
+// IsExecutionWaitTimeout 判断错误是否为等待执行结束超时
+func IsExecutionWaitTimeout(err error) bool {
+	return err != nil && err.Error() == errExecutionWaitTimeout
+}
+
+// executionEventChannel 执行事件的Redis发布/订阅频道，与WebSocket主题同名，供同步等待执行结果的请求订阅
+func executionEventChannel(executionID string) string {
+	return caching.GetKeyPrefix() + caching.KeySeparator + WorkflowExecutionTopic(executionID)
+}
+
+// publishExecutionEventToWaiters 将执行事件发布到Redis频道，通知正在同步等待该执行的请求
+func publishExecutionEventToWaiters(ctx context.Context, event *models.WorkflowExecutionEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return caching.Client.Publish(ctx, executionEventChannel(event.ExecutionID), payload).Err()
+}
+
+// subscribeExecutionEvents 订阅执行事件，Redis不可用时返回 nil 频道，调用方只能依靠轮询
+func subscribeExecutionEvents(ctx context.Context, executionID string) (<-chan *models.WorkflowExecutionEvent, func()) {
+	client := caching.GetInstanceUnsafe()
+	if client == nil {
+		return nil, func() {}
+	}
+
+	pubsub := client.Subscribe(ctx, executionEventChannel(executionID))
+	// 等待订阅确认，保证之后读取的执行状态不会漏掉订阅之前发生的事件
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, func() {}
+	}
+
+	events := make(chan *models.WorkflowExecutionEvent, 16)
+	go func() {
+		defer close(events)
+		for message := range pubsub.Channel() {
+			var event models.WorkflowExecutionEvent
+			if err := json.Unmarshal([]byte(message.Payload), &event); err != nil {
+				continue
+			}
+			select {
+			case events <- &event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events, func() { pubsub.Close() }
+}
+
+// WaitWorkflowExecution 等待执行进入终态并返回最终结果（含输出），等待期间的执行事件依次交给 onEvent（可为 nil）。
+// 超过 timeout 仍未结束时返回 errExecutionWaitTimeout，执行本身不受影响
+func (WorkflowFuncs) WaitWorkflowExecution(ctx context.Context, executionID string, timeout time.Duration, onEvent func(*models.WorkflowExecutionEvent)) (*models.WorkflowExecutionResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	events, unsubscribe := subscribeExecutionEvents(ctx, executionID)
+	defer unsubscribe()
+
+	execution, err := waitForExecution(ctx, events, executionWaitPollInterval, func(ctx context.Context) (*ent.WorkflowExecution, error) {
+		return database.Client.WorkflowExecution.Query().
+			Where(workflowexecution.ExecutionIDEQ(executionID)).
+			Only(ctx)
+	}, onEvent)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+	return WorkflowFuncs{}.ConvertWorkflowExecutionToResponse(execution), nil
+}
+
+// waitForExecution 在收到 execution_finished 事件或轮询发现执行进入终态时返回执行记录
+func waitForExecution(ctx context.Context, events <-chan *models.WorkflowExecutionEvent, interval time.Duration,
+	load func(context.Context) (*ent.WorkflowExecution, error), onEvent func(*models.WorkflowExecutionEvent)) (*ent.WorkflowExecution, error) {
+	execution, err := load(ctx)
+	if err != nil {
+		return nil, waitError(ctx, err)
+	}
+	if isTerminalExecutionStatus(execution.Status) {
+		return execution, nil
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, waitError(ctx, ctx.Err())
+		case event, ok := <-events:
+			if !ok {
+				// 订阅中断，之后只依靠轮询
+				events = nil
+				continue
+			}
+			if onEvent != nil {
+				onEvent(event)
+			}
+			if event.Event != models.WorkflowEventExecutionFinished {
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		execution, err = load(ctx)
+		if err != nil {
+			return nil, waitError(ctx, err)
+		}
+		if isTerminalExecutionStatus(execution.Status) {
+			return execution, nil
+		}
+	}
+}
+
+// waitError 将等待期间因超时产生的错误统一为 errExecutionWaitTimeout
+func waitError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf(errExecutionWaitTimeout)
+	}
+	return err
+}
@@ -0,0 +1,74 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/shared/models"
+)
+
+// executionLoader 依次返回给定状态的执行记录，用完后保持最后一个状态
+func executionLoader(statuses ...workflowexecution.Status) (func(context.Context) (*ent.WorkflowExecution, error), *int) {
+	calls := 0
+	return func(ctx context.Context) (*ent.WorkflowExecution, error) {
+		index := calls
+		if index >= len(statuses) {
+			index = len(statuses) - 1
+		}
+		calls++
+		return &ent.WorkflowExecution{ExecutionID: "exec-1", Status: statuses[index]}, nil
+	}, &calls
+}
+
+func TestWaitForExecutionAlreadyFinished(t *testing.T) {
+	load, calls := executionLoader(workflowexecution.StatusCompleted)
+	execution, err := waitForExecution(context.Background(), nil, time.Hour, load, nil)
+	if err != nil || execution.Status != workflowexecution.StatusCompleted || *calls != 1 {
+		t.Fatalf("execution = %+v, err = %v, calls = %d", execution, err, *calls)
+	}
+}
+
+func TestWaitForExecutionStreamsEventsUntilFinished(t *testing.T) {
+	events := make(chan *models.WorkflowExecutionEvent, 3)
+	events <- &models.WorkflowExecutionEvent{Event: models.WorkflowEventNodeStarted, ExecutionID: "exec-1"}
+	events <- &models.WorkflowExecutionEvent{Event: models.WorkflowEventNodeFinished, ExecutionID: "exec-1"}
+	events <- &models.WorkflowExecutionEvent{Event: models.WorkflowEventExecutionFinished, ExecutionID: "exec-1"}
+
+	load, calls := executionLoader(workflowexecution.StatusRunning, workflowexecution.StatusFailed)
+	var received []string
+	execution, err := waitForExecution(context.Background(), events, time.Hour, load, func(event *models.WorkflowExecutionEvent) {
+		received = append(received, event.Event)
+	})
+	if err != nil || execution.Status != workflowexecution.StatusFailed {
+		t.Fatalf("execution = %+v, err = %v", execution, err)
+	}
+	if len(received) != 3 || received[2] != models.WorkflowEventExecutionFinished {
+		t.Fatalf("unexpected events: %v", received)
+	}
+	// 初始读取一次，收到 execution_finished 后再读取一次，中间的节点事件不触发读取
+	if *calls != 2 {
+		t.Fatalf("expected 2 loads, got %d", *calls)
+	}
+}
+
+func TestWaitForExecutionPollsWithoutEvents(t *testing.T) {
+	load, calls := executionLoader(workflowexecution.StatusPending, workflowexecution.StatusRunning, workflowexecution.StatusCompleted)
+	execution, err := waitForExecution(context.Background(), nil, 5*time.Millisecond, load, nil)
+	if err != nil || execution.Status != workflowexecution.StatusCompleted || *calls != 3 {
+		t.Fatalf("execution = %+v, err = %v, calls = %d", execution, err, *calls)
+	}
+}
+
+func TestWaitForExecutionTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	load, _ := executionLoader(workflowexecution.StatusRunning)
+	execution, err := waitForExecution(ctx, make(chan *models.WorkflowExecutionEvent), 5*time.Millisecond, load, nil)
+	if execution != nil || !IsExecutionWaitTimeout(err) {
+		t.Fatalf("expected wait timeout, got %+v, %v", execution, err)
+	}
+}
@@ -7,14 +7,21 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"time"
 
 	"go-backend/database/ent"
 	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowapplicationshare"
 	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflowexecution"
 	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/database/ent/workflowsecret"
 	"go-backend/database/ent/workflowversion"
+	"go-backend/pkg/configs"
 	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
 	"go-backend/pkg/utils"
 	"go-backend/shared/models"
 )
@@ -24,11 +31,14 @@ type WorkflowFuncs struct{}
 
 // ============ WorkflowApplication CRUD ============
 
-// GetAllWorkflowApplications 获取所有工作流应用
-func (WorkflowFuncs) GetAllWorkflowApplications(ctx context.Context) ([]*models.WorkflowApplicationResponse, error) {
-	apps, err := database.Client.WorkflowApplication.Query().
-		WithNodes().
-		All(ctx)
+// GetAllWorkflowApplications 获取用户可见的所有工作流应用
+func (WorkflowFuncs) GetAllWorkflowApplications(ctx context.Context, scope WorkflowAccessScope) ([]*models.WorkflowApplicationResponse, error) {
+	query := database.Client.WorkflowApplication.Query().
+		WithNodes()
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(visible)
+	}
+	apps, err := query.All(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -57,14 +67,27 @@ func (WorkflowFuncs) GetWorkflowApplicationByID(ctx context.Context, id uint64)
 	return WorkflowFuncs{}.ConvertWorkflowApplicationToResponse(app), nil
 }
 
-// CreateWorkflowApplication 创建工作流应用
-func (WorkflowFuncs) CreateWorkflowApplication(ctx context.Context, req *models.CreateWorkflowApplicationRequest) (*models.WorkflowApplicationResponse, error) {
+// CreateWorkflowApplication 创建工作流应用，ownerID 为创建者，为0时不设置所有者
+func (WorkflowFuncs) CreateWorkflowApplication(ctx context.Context, ownerID uint64, req *models.CreateWorkflowApplicationRequest) (*models.WorkflowApplicationResponse, error) {
+	if err := ValidateInputSchema(req.InputSchema); err != nil {
+		return nil, err
+	}
+	if err := checkApplicationName(ctx, database.Client, req.Name, 0); err != nil {
+		return nil, err
+	}
+
 	// 生成客户端密钥
 	clientSecret, err := generateClientSecret()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate client secret: %w", err)
 	}
 
+	// 未提供 startNodeId 时默认创建开始节点，调用方可以显式关闭
+	createStartNode := req.StartNodeID == "" && (req.CreateDefaultStartNode == nil || *req.CreateDefaultStartNode)
+	if req.Status == string(workflowapplication.StatusPublished) && req.StartNodeID == "" && !createStartNode {
+		return nil, fmt.Errorf("%s: start node is required", errWorkflowNotPublishable)
+	}
+
 	// 使用事务创建应用和默认开始节点
 	tx, err := database.Client.Tx(ctx)
 	if err != nil {
@@ -95,6 +118,18 @@ func (WorkflowFuncs) CreateWorkflowApplication(ctx context.Context, req *models.
 		appBuilder = appBuilder.SetStatus(workflowapplication.Status(req.Status))
 	}
 
+	if len(req.InputSchema) > 0 {
+		appBuilder = appBuilder.SetInputSchema(req.InputSchema)
+	}
+
+	if req.IsTemplate {
+		appBuilder = appBuilder.SetIsTemplate(true)
+	}
+
+	if ownerID != 0 {
+		appBuilder = appBuilder.SetOwnerID(ownerID)
+	}
+
 	app, err := appBuilder.Save(ctx)
 	if err != nil {
 		tx.Rollback()
@@ -102,7 +137,7 @@ func (WorkflowFuncs) CreateWorkflowApplication(ctx context.Context, req *models.
 	}
 
 	// 如果没有提供 startNodeId，创建默认的开始节点
-	if req.StartNodeID == "" {
+	if createStartNode {
 		startNode, err := tx.WorkflowNode.Create().
 			SetName("用户输入").
 			SetType(workflownode.TypeUserInput).
@@ -142,6 +177,24 @@ func (WorkflowFuncs) CreateWorkflowApplication(ctx context.Context, req *models.
 
 // UpdateWorkflowApplication 更新工作流应用
 func (WorkflowFuncs) UpdateWorkflowApplication(ctx context.Context, id uint64, req *models.UpdateWorkflowApplicationRequest) (*models.WorkflowApplicationResponse, error) {
+	if err := ValidateInputSchema(req.InputSchema); err != nil {
+		return nil, err
+	}
+	if req.ViewportConfig != nil {
+		if err := ValidateViewportConfig(req.ViewportConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkApplicationName(ctx, database.Client, req.Name, id); err != nil {
+		return nil, err
+	}
+
+	if req.Status == string(workflowapplication.StatusPublished) {
+		if err := checkWorkflowPublishable(ctx, database.Client, id, utils.StringToUint64(req.StartNodeID)); err != nil {
+			return nil, err
+		}
+	}
+
 	builder := database.Client.WorkflowApplication.UpdateOneID(id)
 
 	if req.Name != "" {
@@ -173,6 +226,18 @@ func (WorkflowFuncs) UpdateWorkflowApplication(ctx context.Context, id uint64, r
 		builder = builder.SetViewportConfig(req.ViewportConfig)
 	}
 
+	if req.InputSchema != nil {
+		if len(req.InputSchema) == 0 {
+			builder = builder.ClearInputSchema()
+		} else {
+			builder = builder.SetInputSchema(req.InputSchema)
+		}
+	}
+
+	if req.IsTemplate != nil {
+		builder = builder.SetIsTemplate(*req.IsTemplate)
+	}
+
 	err := builder.Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -184,9 +249,104 @@ func (WorkflowFuncs) UpdateWorkflowApplication(ctx context.Context, id uint64, r
 	return WorkflowFuncs{}.GetWorkflowApplicationByID(ctx, id)
 }
 
-// DeleteWorkflowApplication 删除工作流应用(软删除)
-func (WorkflowFuncs) DeleteWorkflowApplication(ctx context.Context, id uint64) error {
-	err := database.Client.WorkflowApplication.DeleteOneID(id).Exec(ctx)
+// errWorkflowNotPublishable 工作流应用不满足发布条件
+const errWorkflowNotPublishable = "workflow application cannot be published"
+
+// checkWorkflowPublishable 发布前检查应用存在开始节点：startNodeID 为0时使用应用当前的开始节点，
+// 该节点必须存在且属于该应用（创建时关闭了默认开始节点的应用需要先设置开始节点才能发布）
+func checkWorkflowPublishable(ctx context.Context, client *ent.Client, appID, startNodeID uint64) error {
+	if startNodeID == 0 {
+		app, err := client.WorkflowApplication.Get(ctx, appID)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("workflow application not found")
+			}
+			return err
+		}
+		startNodeID = app.StartNodeID
+	}
+	if startNodeID == 0 {
+		return fmt.Errorf("%s: start node is not set", errWorkflowNotPublishable)
+	}
+
+	exists, err := client.WorkflowNode.Query().
+		Where(workflownode.ID(startNodeID), workflownode.ApplicationIDEQ(appID)).
+		Exist(ctx)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%s: start node %d does not exist in this application", errWorkflowNotPublishable, startNodeID)
+	}
+	return nil
+}
+
+// PatchWorkflowApplicationVariables 以合并方式更新工作流应用的变量
+// patch 中的键覆盖原值、嵌套对象递归合并、值为 null 的键被删除，未出现的键保持不变
+func (WorkflowFuncs) PatchWorkflowApplicationVariables(ctx context.Context, id uint64, patch map[string]interface{}) (*models.WorkflowApplicationResponse, error) {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	app, err := tx.WorkflowApplication.Get(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow application not found")
+		}
+		return nil, err
+	}
+
+	err = tx.WorkflowApplication.UpdateOneID(id).
+		SetVariables(utils.MergeJSON(app.Variables, patch)).
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return WorkflowFuncs{}.GetWorkflowApplicationByID(ctx, id)
+}
+
+// errWorkflowApplicationNotEmpty block 模式下删除仍有节点或边的应用时的错误前缀
+const errWorkflowApplicationNotEmpty = "workflow application is not empty"
+
+// ValidateViewportConfig 校验画布视口配置：x、y 为数字，zoom 为正数，允许携带其他前端自定义字段
+func ValidateViewportConfig(viewport map[string]interface{}) error {
+	if viewport == nil {
+		return fmt.Errorf("invalid viewport: viewport is required")
+	}
+	for _, key := range []string{"x", "y", "zoom"} {
+		value, exists := viewport[key]
+		if !exists {
+			return fmt.Errorf("invalid viewport: %s is required", key)
+		}
+		number, ok := value.(float64)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			return fmt.Errorf("invalid viewport: %s must be a number", key)
+		}
+		if key == "zoom" && number <= 0 {
+			return fmt.Errorf("invalid viewport: zoom must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// UpdateViewportConfig 只更新应用的画布视口配置，不影响名称、状态、变量等其他字段
+// 前端会高频保存平移/缩放，单独更新可避免旧的完整数据覆盖其他字段
+func (WorkflowFuncs) UpdateViewportConfig(ctx context.Context, applicationID uint64, viewport map[string]interface{}) error {
+	if err := ValidateViewportConfig(viewport); err != nil {
+		return err
+	}
+
+	err := database.Client.WorkflowApplication.UpdateOneID(applicationID).
+		SetViewportConfig(viewport).
+		Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return fmt.Errorf("workflow application not found")
@@ -196,11 +356,112 @@ func (WorkflowFuncs) DeleteWorkflowApplication(ctx context.Context, id uint64) e
 	return nil
 }
 
-// GetWorkflowApplicationsWithPagination 分页获取工作流应用列表
-func (WorkflowFuncs) GetWorkflowApplicationsWithPagination(ctx context.Context, req *models.PageWorkflowApplicationRequest) (*models.PageWorkflowApplicationResponse, error) {
+// DeleteWorkflowApplication 删除工作流应用(软删除)
+// 根据 workflow.application_delete_mode 配置：cascade 时在同一事务中一并软删除应用下的节点、边和调度；
+// block 时若应用下仍有节点或边则拒绝删除。版本和执行记录不会被删除，保留用于审计
+func (WorkflowFuncs) DeleteWorkflowApplication(ctx context.Context, id uint64) error {
+	mode := configs.GetConfig().Workflow.ApplicationDeleteMode
+
+	scheduleIDs, err := deleteWorkflowApplication(ctx, database.Client, id, mode)
+	if err != nil {
+		return err
+	}
+
+	// 事务提交后再从调度器中移除，避免回滚后调度丢失
+	for _, scheduleID := range scheduleIDs {
+		unregisterWorkflowSchedule(scheduleID)
+	}
+	publishWorkflowScheduleChanged(ctx, scheduleIDs...)
+	return nil
+}
+
+// deleteWorkflowApplication 在事务中删除应用及其拥有的节点、边、调度、密钥和共享记录，返回被删除的调度ID
+func deleteWorkflowApplication(ctx context.Context, client *ent.Client, id uint64, mode string) ([]uint64, error) {
+	tx, err := client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	exists, err := tx.WorkflowApplication.Query().Where(workflowapplication.ID(id)).Exist(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !exists {
+		tx.Rollback()
+		return nil, fmt.Errorf("workflow application not found")
+	}
+
+	if mode == configs.ApplicationDeleteModeBlock {
+		nodeCount, err := tx.WorkflowNode.Query().Where(workflownode.ApplicationIDEQ(id)).Count(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		edgeCount, err := tx.WorkflowEdge.Query().Where(workflowedge.ApplicationIDEQ(id)).Count(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+		if nodeCount > 0 || edgeCount > 0 {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: application still has %d nodes and %d edges",
+				errWorkflowApplicationNotEmpty, nodeCount, edgeCount)
+		}
+	}
+
+	scheduleIDs, err := tx.WorkflowSchedule.Query().
+		Where(workflowschedule.ApplicationIDEQ(id)).
+		IDs(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	// 先删边再删节点，软删除不会触发外键约束，但顺序与物理删除保持一致
+	if _, err := tx.WorkflowEdge.Delete().Where(workflowedge.ApplicationIDEQ(id)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to delete edges: %w", err)
+	}
+	if _, err := tx.WorkflowNode.Delete().Where(workflownode.ApplicationIDEQ(id)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to delete nodes: %w", err)
+	}
+	if _, err := tx.WorkflowSchedule.Delete().Where(workflowschedule.ApplicationIDEQ(id)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to delete schedules: %w", err)
+	}
+	if _, err := tx.WorkflowSecret.Delete().Where(workflowsecret.ApplicationIDEQ(id)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to delete secrets: %w", err)
+	}
+	if _, err := tx.WorkflowApplicationShare.Delete().Where(workflowapplicationshare.ApplicationIDEQ(id)).Exec(ctx); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("failed to delete shares: %w", err)
+	}
+	if err := tx.WorkflowApplication.DeleteOneID(id).Exec(ctx); err != nil {
+		tx.Rollback()
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow application not found")
+		}
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return scheduleIDs, nil
+}
+
+// GetWorkflowApplicationsWithPagination 分页获取用户可见的工作流应用列表
+func (WorkflowFuncs) GetWorkflowApplicationsWithPagination(ctx context.Context, scope WorkflowAccessScope, req *models.PageWorkflowApplicationRequest) (*models.PageWorkflowApplicationResponse, error) {
 	query := database.Client.WorkflowApplication.Query().
 		WithNodes()
 
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(visible)
+	}
+
 	// 添加搜索条件
 	if req.Name != "" {
 		query = query.Where(workflowapplication.NameContains(req.Name))
@@ -210,6 +471,10 @@ func (WorkflowFuncs) GetWorkflowApplicationsWithPagination(ctx context.Context,
 		query = query.Where(workflowapplication.StatusEQ(workflowapplication.Status(req.Status)))
 	}
 
+	if req.IsTemplate != nil {
+		query = query.Where(workflowapplication.IsTemplateEQ(*req.IsTemplate))
+	}
+
 	if req.BeginTime != "" {
 		beginTime, err := time.Parse(time.RFC3339, req.BeginTime)
 		if err == nil {
@@ -300,6 +565,11 @@ func (WorkflowFuncs) ConvertWorkflowApplicationToResponse(app *ent.WorkflowAppli
 		Version:        app.Version,
 		Status:         string(app.Status),
 		ViewportConfig: app.ViewportConfig,
+		InputSchema:    app.InputSchema,
+		IsTemplate:     app.IsTemplate,
+	}
+	if app.OwnerID != 0 {
+		resp.OwnerID = utils.Uint64ToString(app.OwnerID)
 	}
 
 	// // 转换节点列表（旧架构，保留兼容）
@@ -324,11 +594,14 @@ func generateClientSecret() (string, error) {
 
 // ============ WorkflowNode CRUD ============
 
-// GetAllWorkflowNodes 获取所有工作流节点
-func (WorkflowFuncs) GetAllWorkflowNodes(ctx context.Context) ([]*models.WorkflowNodeResponse, error) {
-	nodes, err := database.Client.WorkflowNode.Query().
-		WithApplication().
-		All(ctx)
+// GetAllWorkflowNodes 获取用户可见应用中的所有工作流节点
+func (WorkflowFuncs) GetAllWorkflowNodes(ctx context.Context, scope WorkflowAccessScope) ([]*models.WorkflowNodeResponse, error) {
+	query := database.Client.WorkflowNode.Query().
+		WithApplication()
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(workflownode.HasApplicationWith(visible))
+	}
+	nodes, err := query.All(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -376,9 +649,95 @@ func (WorkflowFuncs) GetWorkflowNodesByApplicationID(ctx context.Context, applic
 	return nodeResponses, nil
 }
 
+// GetWorkflowNodesWithPagination 分页获取用户可见应用中的工作流节点列表，默认按创建时间升序
+func (WorkflowFuncs) GetWorkflowNodesWithPagination(ctx context.Context, scope WorkflowAccessScope, req *models.PageWorkflowNodeRequest) (*models.PageWorkflowNodeResponse, error) {
+	query := database.Client.WorkflowNode.Query().
+		WithApplication()
+
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(workflownode.HasApplicationWith(visible))
+	}
+
+	// 添加搜索条件
+	if req.ApplicationID != "" {
+		applicationID, err := strconv.ParseUint(req.ApplicationID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application id: %s", req.ApplicationID)
+		}
+		query = query.Where(workflownode.ApplicationIDEQ(applicationID))
+	}
+
+	if req.Name != "" {
+		query = query.Where(workflownode.NameContains(req.Name))
+	}
+
+	if req.Type != "" {
+		query = query.Where(workflownode.TypeEQ(workflownode.Type(req.Type)))
+	}
+
+	if req.BeginTime != "" {
+		beginTime, err := time.Parse(time.RFC3339, req.BeginTime)
+		if err == nil {
+			query = query.Where(workflownode.CreateTimeGTE(beginTime))
+		}
+	}
+
+	if req.EndTime != "" {
+		endTime, err := time.Parse(time.RFC3339, req.EndTime)
+		if err == nil {
+			query = query.Where(workflownode.CreateTimeLTE(endTime))
+		}
+	}
+
+	// 设置排序，最后按ID排序保证分页稳定
+	direction := ent.Asc
+	if req.Order == "desc" {
+		direction = ent.Desc
+	}
+	switch req.OrderBy {
+	case "name":
+		query = query.Order(direction(workflownode.FieldName))
+	case "type":
+		query = query.Order(direction(workflownode.FieldType))
+	case "updateTime":
+		query = query.Order(direction(workflownode.FieldUpdateTime))
+	default:
+		query = query.Order(direction(workflownode.FieldCreateTime))
+	}
+	query = query.Order(direction(workflownode.FieldID))
+
+	// 分页查询
+	nodes, pagination, err := database.Paginate(ctx, query, req.Page, req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为响应格式
+	nodeResponses := make([]*models.WorkflowNodeResponse, 0, len(nodes))
+	for _, node := range nodes {
+		nodeResponses = append(nodeResponses, WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node))
+	}
+
+	return &models.PageWorkflowNodeResponse{
+		Data:       nodeResponses,
+		Pagination: pagination,
+	}, nil
+}
+
 // CreateWorkflowNode 创建工作流节点
 func (WorkflowFuncs) CreateWorkflowNode(ctx context.Context, req *models.CreateWorkflowNodeRequest) (*models.WorkflowNodeResponse, error) {
+	if err := validateNodeRetryPolicy(req.Config); err != nil {
+		return nil, err
+	}
 	applicationID := utils.StringToUint64(req.ApplicationID)
+	if err := checkNodeName(ctx, database.Client, applicationID, req.Name, 0); err != nil {
+		return nil, err
+	}
+	if !req.SkipConfigValidation {
+		if err := validateNodeConfig(nodeFromCreateRequest(req)); err != nil {
+			return nil, err
+		}
+	}
 
 	builder := database.Client.WorkflowNode.Create().
 		SetName(req.Name).
@@ -443,6 +802,10 @@ func (WorkflowFuncs) CreateWorkflowNode(ctx context.Context, req *models.CreateW
 		builder = builder.SetColor(req.Color)
 	}
 
+	if req.Enabled != nil {
+		builder = builder.SetEnabled(*req.Enabled)
+	}
+
 	node, err := builder.Save(ctx)
 	if err != nil {
 		return nil, err
@@ -453,6 +816,29 @@ func (WorkflowFuncs) CreateWorkflowNode(ctx context.Context, req *models.CreateW
 
 // UpdateWorkflowNode 更新工作流节点
 func (WorkflowFuncs) UpdateWorkflowNode(ctx context.Context, id uint64, req *models.UpdateWorkflowNodeRequest) (*models.WorkflowNodeResponse, error) {
+	if err := validateNodeRetryPolicy(req.Config); err != nil {
+		return nil, err
+	}
+	if (req.Name != "" && uniqueWorkflowNames) || !req.SkipConfigValidation {
+		current, err := database.Client.WorkflowNode.Get(ctx, id)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return nil, fmt.Errorf("workflow node not found")
+			}
+			return nil, err
+		}
+		if req.Name != "" && uniqueWorkflowNames {
+			if err := checkNodeName(ctx, database.Client, current.ApplicationID, req.Name, id); err != nil {
+				return nil, err
+			}
+		}
+		// 按修改后的完整节点校验配置，只提交部分字段时其余字段沿用当前值
+		if !req.SkipConfigValidation {
+			if err := validateNodeConfig(applyNodeUpdateRequest(current, req)); err != nil {
+				return nil, err
+			}
+		}
+	}
 	builder := database.Client.WorkflowNode.UpdateOneID(id)
 
 	if req.Name != "" {
@@ -524,6 +910,10 @@ func (WorkflowFuncs) UpdateWorkflowNode(ctx context.Context, id uint64, req *mod
 		builder = builder.SetColor(req.Color)
 	}
 
+	if req.Enabled != nil {
+		builder = builder.SetEnabled(*req.Enabled)
+	}
+
 	err := builder.Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -535,6 +925,51 @@ func (WorkflowFuncs) UpdateWorkflowNode(ctx context.Context, id uint64, req *mod
 	return WorkflowFuncs{}.GetWorkflowNodeByID(ctx, id)
 }
 
+// PatchWorkflowNodeConfig 以合并方式更新工作流节点的配置
+// patch 中的键覆盖原值、嵌套对象递归合并、值为 null 的键被删除，未出现的键保持不变；
+// 合并结果按节点类型的 Schema 校验，skipConfigValidation 为 true 时跳过
+func (WorkflowFuncs) PatchWorkflowNodeConfig(ctx context.Context, id uint64, patch map[string]interface{}, skipConfigValidation bool) (*models.WorkflowNodeResponse, error) {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	node, err := tx.WorkflowNode.Get(ctx, id)
+	if err != nil {
+		tx.Rollback()
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node not found")
+		}
+		return nil, err
+	}
+
+	config := utils.MergeJSON(node.Config, patch)
+	if err := validateNodeRetryPolicy(config); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if !skipConfigValidation {
+		if err := validateNodeConfig(applyNodeUpdateRequest(node, &models.UpdateWorkflowNodeRequest{Config: config})); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	err = tx.WorkflowNode.UpdateOneID(id).
+		SetConfig(config).
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return WorkflowFuncs{}.GetWorkflowNodeByID(ctx, id)
+}
+
 // DeleteWorkflowNode 删除工作流节点(软删除)
 func (WorkflowFuncs) DeleteWorkflowNode(ctx context.Context, id uint64) error {
 	err := database.Client.WorkflowNode.DeleteOneID(id).Exec(ctx)
@@ -574,9 +1009,11 @@ func (WorkflowFuncs) ConvertWorkflowNodeToResponse(node *ent.WorkflowNode) *mode
 		Async:                 node.Async,
 		Timeout:               node.Timeout,
 		RetryCount:            node.RetryCount,
+		RetryPolicy:           nodeRetryPolicy(node),
 		PositionX:             node.PositionX,
 		PositionY:             node.PositionY,
 		Color:                 node.Color,
+		Enabled:               node.Enabled,
 	}
 
 	return resp
@@ -584,96 +1021,7 @@ func (WorkflowFuncs) ConvertWorkflowNodeToResponse(node *ent.WorkflowNode) *mode
 
 // // ============ Workflow Graph Operations ============
 
-// // NodeConnectionRule 节点连接规则
-// type NodeConnectionRule struct {
-// 	CanHaveNextNode      bool // 是否可以有next_node_id
-// 	CanHaveBranches      bool // 是否可以有分支
-// 	CanBeParallel        bool // 是否可以作为并行节点的子节点
-// 	RequiresBranchName   bool // 连接时是否需要分支名称
-// 	MaxOutputConnections int  // 最大输出连接数 (-1表示无限制)
-// }
-
-// // getNodeConnectionRule 获取节点类型的连接规则
-// func getNodeConnectionRule(nodeType string) NodeConnectionRule {
-// 	rules := map[string]NodeConnectionRule{
-// 		"user_input": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        false,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"todo_task_generator": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"condition_checker": {
-// 			CanHaveNextNode:      false,
-// 			CanHaveBranches:      true,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   true,
-// 			MaxOutputConnections: -1, // 可以有多个分支
-// 		},
-// 		"api_caller": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"data_processor": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"while_loop": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        false,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"end_node": {
-// 			CanHaveNextNode:      false,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 0,
-// 		},
-// 		"parallel_executor": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        false,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 		"llm_caller": {
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		},
-// 	}
-
-// 	rule, exists := rules[nodeType]
-// 	if !exists {
-// 		// 默认规则
-// 		return NodeConnectionRule{
-// 			CanHaveNextNode:      true,
-// 			CanHaveBranches:      false,
-// 			CanBeParallel:        true,
-// 			RequiresBranchName:   false,
-// 			MaxOutputConnections: 1,
-// 		}
-// 	}
-// 	return rule
-// }
+// // NodeConnectionRule 和 getNodeConnectionRule 已移至 workflow_node_type_func.go 的节点类型注册表
 
 // // ConnectNodes 连接两个节点（普通连接，用于next_node_id）
 // func (WorkflowFuncs) ConnectNodes(ctx context.Context, fromNodeID, toNodeID uint64) error {
@@ -990,52 +1338,6 @@ func (WorkflowFuncs) ConvertWorkflowNodeToResponse(node *ent.WorkflowNode) *mode
 // 	return nil
 // }
 
-// // GetNodeConnections 获取节点的所有连接信息
-// func (WorkflowFuncs) GetNodeConnections(ctx context.Context, nodeID uint64) (map[string]interface{}, error) {
-// 	node, err := database.Client.WorkflowNode.Get(ctx, nodeID)
-// 	if err != nil {
-// 		if ent.IsNotFound(err) {
-// 			return nil, fmt.Errorf("node not found")
-// 		}
-// 		return nil, err
-// 	}
-
-// 	connections := make(map[string]interface{})
-
-// 	// Next node connection
-// 	if node.NextNodeID != 0 {
-// 		connections["next_node_id"] = utils.Uint64ToString(node.NextNodeID)
-// 	}
-
-// 	// Parent node (for parallel children)
-// 	if node.ParentNodeID != 0 {
-// 		connections["parent_node_id"] = utils.Uint64ToString(node.ParentNodeID)
-// 	}
-
-// 	// Branch connections
-// 	if len(node.BranchNodes) > 0 {
-// 		branches := make(map[string]string)
-// 		for branchName, targetID := range node.BranchNodes {
-// 			branches[branchName] = utils.Uint64ToString(targetID)
-// 		}
-// 		connections["branches"] = branches
-// 	}
-
-// 	// Parallel children (if this is a parallel executor)
-// 	if node.Type == "parallel_executor" {
-// 		children, err := WorkflowFuncs{}.GetParallelChildren(ctx, nodeID)
-// 		if err == nil && len(children) > 0 {
-// 			childIDs := make([]string, 0, len(children))
-// 			for _, child := range children {
-// 				childIDs = append(childIDs, child.ID)
-// 			}
-// 			connections["parallel_children"] = childIDs
-// 		}
-// 	}
-
-// 	return connections, nil
-// }
-
 // // UpdateNodePosition 更新节点位置
 // func (WorkflowFuncs) UpdateNodePosition(ctx context.Context, nodeID uint64, x, y float64) error {
 // 	err := database.Client.WorkflowNode.UpdateOneID(nodeID).
@@ -1082,30 +1384,15 @@ func (WorkflowFuncs) ConvertWorkflowNodeToResponse(node *ent.WorkflowNode) *mode
 // 	return tx.Commit()
 // }
 
-// // BatchDeleteNodes 批量删除节点
-// func (WorkflowFuncs) BatchDeleteNodes(ctx context.Context, nodeIDs []uint64) error {
-// 	tx, err := database.Client.Tx(ctx)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	for _, nodeID := range nodeIDs {
-// 		err = tx.WorkflowNode.DeleteOneID(nodeID).Exec(ctx)
-// 		if err != nil {
-// 			tx.Rollback()
-// 			return err
-// 		}
-// 	}
-
-// 	return tx.Commit()
-// }
-
-// CloneWorkflowApplication 克隆工作流应用（包括所有节点）
-func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, applicationID uint64, newName string) (*models.WorkflowApplicationResponse, error) {
+// CloneWorkflowApplication 克隆工作流应用（包括所有节点和边）
+// 节点的分支目标与边的端点都会映射到克隆出的新节点，克隆结果不会引用原应用的节点；克隆结果总是普通应用（不是模板）
+// 克隆结果归 ownerID 所有（为0时不设置所有者），不继承原应用的所有者和共享记录
+func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, ownerID uint64, applicationID uint64, newName string) (*models.WorkflowApplicationResponse, error) {
 	// 获取原应用
 	originalApp, err := database.Client.WorkflowApplication.Query().
 		Where(workflowapplication.ID(applicationID)).
 		WithNodes().
+		WithEdges().
 		Only(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
@@ -1114,6 +1401,12 @@ func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, applicationID
 		return nil, err
 	}
 
+	// 启用名称唯一性时，名称已被占用则添加副本后缀
+	newName, err = availableApplicationName(ctx, database.Client, newName)
+	if err != nil {
+		return nil, err
+	}
+
 	// 生成新的客户端密钥
 	clientSecret, err := generateClientSecret()
 	if err != nil {
@@ -1126,40 +1419,34 @@ func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, applicationID
 	}
 
 	// 创建新应用
-	newApp, err := tx.WorkflowApplication.Create().
+	appBuilder := tx.WorkflowApplication.Create().
 		SetName(newName).
 		SetDescription(originalApp.Description).
 		SetStartNodeID(originalApp.StartNodeID).
 		SetClientSecret(clientSecret).
 		SetVariables(originalApp.Variables).
-		SetStatus(workflowapplication.StatusDraft).
-		Save(ctx)
+		SetInputSchema(originalApp.InputSchema).
+		SetViewportConfig(originalApp.ViewportConfig).
+		SetStatus(workflowapplication.StatusDraft)
+	if ownerID != 0 {
+		appBuilder = appBuilder.SetOwnerID(ownerID)
+	}
+	newApp, err := appBuilder.Save(ctx)
 	if err != nil {
 		tx.Rollback()
 		return nil, err
 	}
 
-	// 克隆所有节点
+	// 克隆所有节点，分支目标需要等所有节点创建完成后再映射
+	// 启用名称唯一性时，原应用中已存在的重名节点在克隆结果中添加副本后缀
+	var nodeNames map[string]struct{}
+	if uniqueWorkflowNames {
+		nodeNames = make(map[string]struct{}, len(originalApp.Edges.Nodes))
+	}
 	nodeIDMap := make(map[uint64]uint64) // 旧ID -> 新ID
 	for _, oldNode := range originalApp.Edges.Nodes {
-		newNode, err := tx.WorkflowNode.Create().
-			SetName(oldNode.Name).
-			SetType(oldNode.Type).
-			SetDescription(oldNode.Description).
-			SetPrompt(oldNode.Prompt).
-			SetConfig(oldNode.Config).
-			SetApplicationID(newApp.ID).
-			SetProcessorLanguage(oldNode.ProcessorLanguage).
-			SetProcessorCode(oldNode.ProcessorCode).
-			SetBranchNodes(oldNode.BranchNodes).
-			SetParallelConfig(oldNode.ParallelConfig).
-			SetAPIConfig(oldNode.APIConfig).
-			SetAsync(oldNode.Async).
-			SetTimeout(oldNode.Timeout).
-			SetRetryCount(oldNode.RetryCount).
-			SetPositionX(oldNode.PositionX).
-			SetPositionY(oldNode.PositionY).
-			SetColor(oldNode.Color).
+		newNode, err := cloneWorkflowNodeCreate(tx, oldNode, newApp.ID).
+			SetName(claimNodeName(oldNode.Name, nodeNames)).
 			Save(ctx)
 		if err != nil {
 			tx.Rollback()
@@ -1168,6 +1455,34 @@ func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, applicationID
 		nodeIDMap[oldNode.ID] = newNode.ID
 	}
 
+	// 映射分支目标
+	for _, oldNode := range originalApp.Edges.Nodes {
+		if oldNode.BranchNodes == nil {
+			continue
+		}
+		err = tx.WorkflowNode.UpdateOneID(nodeIDMap[oldNode.ID]).
+			SetBranchNodes(remapBranchNodes(oldNode.BranchNodes, nodeIDMap)).
+			Exec(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to remap branch nodes: %w", err)
+		}
+	}
+
+	// 克隆所有边
+	for _, oldEdge := range originalApp.Edges.Edges {
+		sourceID, targetID, ok := remapEdgeEndpoints(oldEdge, nodeIDMap)
+		if !ok {
+			logging.Warn("Skip cloning workflow edge %d: endpoint not in application %d", oldEdge.ID, applicationID)
+			continue
+		}
+		_, err = cloneWorkflowEdgeCreate(tx, oldEdge, newApp.ID, sourceID, targetID).Save(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to clone edge: %w", err)
+		}
+	}
+
 	// 更新新应用的起始节点ID
 	if newStartNodeID, ok := nodeIDMap[originalApp.StartNodeID]; ok {
 		err = tx.WorkflowApplication.UpdateOneID(newApp.ID).
@@ -1186,26 +1501,144 @@ func (WorkflowFuncs) CloneWorkflowApplication(ctx context.Context, applicationID
 	return WorkflowFuncs{}.GetWorkflowApplicationByID(ctx, newApp.ID)
 }
 
+// cloneWorkflowNodeCreate 构建复制节点的创建器，复制除分支配置外的所有字段（分支目标需在所有节点创建后再映射）
+func cloneWorkflowNodeCreate(tx *ent.Tx, node *ent.WorkflowNode, applicationID uint64) *ent.WorkflowNodeCreate {
+	builder := tx.WorkflowNode.Create().
+		SetName(node.Name).
+		SetType(node.Type).
+		SetDescription(node.Description).
+		SetPrompt(node.Prompt).
+		SetConfig(node.Config).
+		SetApplicationID(applicationID).
+		SetProcessorLanguage(node.ProcessorLanguage).
+		SetProcessorCode(node.ProcessorCode).
+		SetParallelConfig(node.ParallelConfig).
+		SetAPIConfig(node.APIConfig).
+		SetAsync(node.Async).
+		SetTimeout(node.Timeout).
+		SetRetryCount(node.RetryCount).
+		SetPositionX(node.PositionX).
+		SetPositionY(node.PositionY).
+		SetColor(node.Color).
+		SetEnabled(node.Enabled)
+	if node.WorkflowApplicationID != 0 {
+		builder = builder.SetWorkflowApplicationID(node.WorkflowApplicationID)
+	}
+	return builder
+}
+
+// cloneWorkflowEdgeCreate 构建复制边的创建器，端点使用映射后的新节点ID
+func cloneWorkflowEdgeCreate(tx *ent.Tx, edge *ent.WorkflowEdge, applicationID, sourceID, targetID uint64) *ent.WorkflowEdgeCreate {
+	return tx.WorkflowEdge.Create().
+		SetApplicationID(applicationID).
+		SetSourceNodeID(sourceID).
+		SetTargetNodeID(targetID).
+		SetSourceHandle(edge.SourceHandle).
+		SetTargetHandle(edge.TargetHandle).
+		SetType(edge.Type).
+		SetLabel(edge.Label).
+		SetBranchName(edge.BranchName).
+		SetAnimated(edge.Animated).
+		SetStyle(edge.Style).
+		SetData(edge.Data)
+}
+
+// remapBranchNodes 复制分支配置并将各分支的 targetNodeId 映射为新节点ID
+// 目标不在映射表中（指向应用外或已删除的节点）时移除该目标，避免克隆结果指向原应用
+func remapBranchNodes(branchNodes map[string]interface{}, nodeIDMap map[uint64]uint64) map[string]interface{} {
+	result := make(map[string]interface{}, len(branchNodes))
+	for name, raw := range branchNodes {
+		branch, ok := raw.(map[string]interface{})
+		if !ok {
+			result[name] = raw
+			continue
+		}
+
+		copied := make(map[string]interface{}, len(branch))
+		for k, v := range branch {
+			copied[k] = v
+		}
+
+		if target, exists := copied["targetNodeId"]; exists && target != nil {
+			oldID, parsed := parseBranchTargetID(target)
+			newID, mapped := nodeIDMap[oldID]
+			switch {
+			case !parsed || !mapped:
+				delete(copied, "targetNodeId")
+			case isStringValue(target):
+				copied["targetNodeId"] = utils.Uint64ToString(newID)
+			default:
+				copied["targetNodeId"] = newID
+			}
+		}
+		result[name] = copied
+	}
+	return result
+}
+
+// remapEdgeEndpoints 将边的源节点和目标节点映射为新节点ID
+func remapEdgeEndpoints(edge *ent.WorkflowEdge, nodeIDMap map[uint64]uint64) (uint64, uint64, bool) {
+	sourceID, sourceOK := nodeIDMap[edge.SourceNodeID]
+	targetID, targetOK := nodeIDMap[edge.TargetNodeID]
+	return sourceID, targetID, sourceOK && targetOK
+}
+
+// parseBranchTargetID 解析分支目标节点ID，兼容字符串与数字两种存储格式
+func parseBranchTargetID(v interface{}) (uint64, bool) {
+	switch id := v.(type) {
+	case string:
+		parsed, err := strconv.ParseUint(id, 10, 64)
+		return parsed, err == nil
+	case float64:
+		return uint64(id), id >= 0
+	case uint64:
+		return id, true
+	case int64:
+		return uint64(id), id >= 0
+	case int:
+		return uint64(id), id >= 0
+	}
+	return 0, false
+}
+
+func isStringValue(v interface{}) bool {
+	_, ok := v.(string)
+	return ok
+}
+
 // // ============ WorkflowEdge CRUD ============
 
-// GetAllWorkflowEdges 获取所有工作流边
-func (WorkflowFuncs) GetAllWorkflowEdges(ctx context.Context) ([]*models.WorkflowEdgeResponse, error) {
-	edges, err := database.Client.WorkflowEdge.Query().
+// workflowEdgeListCap 不分页获取所有边时最多返回的数量，超出时应使用分页搜索
+const workflowEdgeListCap = 1000
+
+// GetAllWorkflowEdges 获取用户可见应用中的所有工作流边，按创建时间升序最多返回 workflowEdgeListCap 条，truncated 表示还有更多的边
+func (WorkflowFuncs) GetAllWorkflowEdges(ctx context.Context, scope WorkflowAccessScope) (edgeResponses []*models.WorkflowEdgeResponse, truncated bool, err error) {
+	query := database.Client.WorkflowEdge.Query()
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(workflowedge.HasApplicationWith(visible))
+	}
+	edges, err := query.
 		WithApplication().
 		WithSourceNode().
 		WithTargetNode().
+		Order(ent.Asc(workflowedge.FieldCreateTime), ent.Asc(workflowedge.FieldID)).
+		Limit(workflowEdgeListCap + 1).
 		All(ctx)
 	if err != nil {
-		return nil, err
+		return nil, false, err
+	}
+	if len(edges) > workflowEdgeListCap {
+		edges = edges[:workflowEdgeListCap]
+		truncated = true
 	}
 
 	// 转换为响应格式
-	edgeResponses := make([]*models.WorkflowEdgeResponse, 0, len(edges))
+	edgeResponses = make([]*models.WorkflowEdgeResponse, 0, len(edges))
 	for _, edge := range edges {
 		edgeResponses = append(edgeResponses, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
 	}
 
-	return edgeResponses, nil
+	return edgeResponses, truncated, nil
 }
 
 // GetWorkflowEdgeByID 根据ID获取工作流边
@@ -1246,11 +1679,202 @@ func (WorkflowFuncs) GetWorkflowEdgesByApplicationID(ctx context.Context, applic
 	return edgeResponses, nil
 }
 
+// SearchWorkflowEdges 在用户可见的应用中按应用、端点、类型、分支名称和创建时间范围分页搜索工作流边，默认按创建时间升序
+func (WorkflowFuncs) SearchWorkflowEdges(ctx context.Context, scope WorkflowAccessScope, req *models.PageWorkflowEdgeRequest) (*models.PageWorkflowEdgeResponse, error) {
+	query := database.Client.WorkflowEdge.Query().
+		WithApplication().
+		WithSourceNode().
+		WithTargetNode()
+
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(workflowedge.HasApplicationWith(visible))
+	}
+
+	// 添加搜索条件
+	if req.ApplicationID != "" {
+		applicationID, err := strconv.ParseUint(req.ApplicationID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application id: %s", req.ApplicationID)
+		}
+		query = query.Where(workflowedge.ApplicationIDEQ(applicationID))
+	}
+
+	if req.SourceNodeID != "" {
+		sourceNodeID, err := strconv.ParseUint(req.SourceNodeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source node id: %s", req.SourceNodeID)
+		}
+		query = query.Where(workflowedge.SourceNodeIDEQ(sourceNodeID))
+	}
+
+	if req.TargetNodeID != "" {
+		targetNodeID, err := strconv.ParseUint(req.TargetNodeID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target node id: %s", req.TargetNodeID)
+		}
+		query = query.Where(workflowedge.TargetNodeIDEQ(targetNodeID))
+	}
+
+	if req.Type != "" {
+		if err := workflowedge.TypeValidator(workflowedge.Type(req.Type)); err != nil {
+			return nil, fmt.Errorf("invalid edge type: %s", req.Type)
+		}
+		query = query.Where(workflowedge.TypeEQ(workflowedge.Type(req.Type)))
+	}
+
+	if req.BranchName != "" {
+		query = query.Where(workflowedge.BranchName(req.BranchName))
+	}
+
+	var beginTime, endTime time.Time
+	if req.BeginTime != "" {
+		var err error
+		if beginTime, err = time.Parse(time.RFC3339, req.BeginTime); err != nil {
+			return nil, fmt.Errorf("invalid begin time: %s", req.BeginTime)
+		}
+		query = query.Where(workflowedge.CreateTimeGTE(beginTime))
+	}
+
+	if req.EndTime != "" {
+		var err error
+		if endTime, err = time.Parse(time.RFC3339, req.EndTime); err != nil {
+			return nil, fmt.Errorf("invalid end time: %s", req.EndTime)
+		}
+		query = query.Where(workflowedge.CreateTimeLTE(endTime))
+	}
+
+	if !beginTime.IsZero() && !endTime.IsZero() && endTime.Before(beginTime) {
+		return nil, fmt.Errorf("invalid time window: end time is before begin time")
+	}
+
+	// 获取总数
+	total, err := query.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 计算分页
+	offset := (req.Page - 1) * req.PageSize
+	totalPages := int(math.Ceil(float64(total) / float64(req.PageSize)))
+
+	// 设置排序，最后按ID排序保证分页稳定
+	direction := ent.Asc
+	if req.Order == "desc" {
+		direction = ent.Desc
+	}
+	switch req.OrderBy {
+	case "type":
+		query = query.Order(direction(workflowedge.FieldType))
+	case "updateTime":
+		query = query.Order(direction(workflowedge.FieldUpdateTime))
+	default:
+		query = query.Order(direction(workflowedge.FieldCreateTime))
+	}
+	query = query.Order(direction(workflowedge.FieldID))
+
+	// 执行查询
+	edges, err := query.Offset(offset).Limit(req.PageSize).All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 转换为响应格式
+	edgeResponses := make([]*models.WorkflowEdgeResponse, 0, len(edges))
+	for _, edge := range edges {
+		edgeResponses = append(edgeResponses, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
+	}
+
+	return &models.PageWorkflowEdgeResponse{
+		Data: edgeResponses,
+		Pagination: models.Pagination{
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			Total:      int64(total),
+			TotalPages: totalPages,
+			HasNext:    req.Page < totalPages,
+			HasPrev:    req.Page > 1,
+		},
+	}, nil
+}
+
+// errInvalidEdgeEndpoints 边的端点不合法时的错误前缀
+const errInvalidEdgeEndpoints = "invalid edge endpoints"
+
+// errInvalidEdgeRequest 创建/更新边的请求字段不合法时的错误前缀
+const errInvalidEdgeRequest = "invalid edge request"
+
+// parseCreateWorkflowEdgeRequest 解析创建边请求中的应用ID和端点ID，并校验边类型
+func parseCreateWorkflowEdgeRequest(req *models.CreateWorkflowEdgeRequest) (applicationID, sourceNodeID, targetNodeID uint64, err error) {
+	ids := make([]uint64, 0, 3)
+	for _, field := range []struct{ name, value string }{
+		{"applicationId", req.ApplicationID},
+		{"source", req.SourceNodeID},
+		{"target", req.TargetNodeID},
+	} {
+		id, parseErr := strconv.ParseUint(field.value, 10, 64)
+		if parseErr != nil || id == 0 {
+			return 0, 0, 0, fmt.Errorf("%s: %s %q is not a valid ID", errInvalidEdgeRequest, field.name, field.value)
+		}
+		ids = append(ids, id)
+	}
+	if err := validateEdgeType(req.Type); err != nil {
+		return 0, 0, 0, err
+	}
+	if err := validateEdgeCondition(req.Type, req.Data); err != nil {
+		return 0, 0, 0, fmt.Errorf("%s: %w", errInvalidEdgeRequest, err)
+	}
+	return ids[0], ids[1], ids[2], nil
+}
+
+// validateEdgeType 校验边类型，空值表示使用默认类型
+func validateEdgeType(edgeType string) error {
+	if edgeType == "" {
+		return nil
+	}
+	if err := workflowedge.TypeValidator(workflowedge.Type(edgeType)); err != nil {
+		return fmt.Errorf("%s: unknown edge type %q", errInvalidEdgeRequest, edgeType)
+	}
+	return nil
+}
+
+// validateEdgeEndpoints 校验边的两个端点：必须都属于边所在的应用，且除 while_loop 外不允许连接到自身
+func validateEdgeEndpoints(applicationID uint64, source, target *ent.WorkflowNode) error {
+	if source.ApplicationID != applicationID || target.ApplicationID != applicationID {
+		return fmt.Errorf("%s: nodes %d and %d must both belong to workflow application %d",
+			errInvalidEdgeEndpoints, source.ID, target.ID, applicationID)
+	}
+	if source.ID == target.ID && source.Type != workflownode.TypeWhileLoop {
+		return fmt.Errorf("%s: node %d cannot connect to itself (only while_loop nodes may loop back)",
+			errInvalidEdgeEndpoints, source.ID)
+	}
+	return nil
+}
+
 // CreateWorkflowEdge 创建工作流边
 func (WorkflowFuncs) CreateWorkflowEdge(ctx context.Context, req *models.CreateWorkflowEdgeRequest) (*models.WorkflowEdgeResponse, error) {
-	applicationID := utils.StringToUint64(req.ApplicationID)
-	sourceNodeID := utils.StringToUint64(req.SourceNodeID)
-	targetNodeID := utils.StringToUint64(req.TargetNodeID)
+	applicationID, sourceNodeID, targetNodeID, err := parseCreateWorkflowEdgeRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// 加载两个端点，确认它们属于同一应用
+	sourceNode, err := database.Client.WorkflowNode.Get(ctx, sourceNodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%s: source node %s not found", errInvalidEdgeEndpoints, req.SourceNodeID)
+		}
+		return nil, err
+	}
+	targetNode, err := database.Client.WorkflowNode.Get(ctx, targetNodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("%s: target node %s not found", errInvalidEdgeEndpoints, req.TargetNodeID)
+		}
+		return nil, err
+	}
+	if err := validateEdgeEndpoints(applicationID, sourceNode, targetNode); err != nil {
+		return nil, err
+	}
 
 	builder := database.Client.WorkflowEdge.Create().
 		SetApplicationID(applicationID).
@@ -1297,6 +1921,29 @@ func (WorkflowFuncs) CreateWorkflowEdge(ctx context.Context, req *models.CreateW
 
 // UpdateWorkflowEdge 更新工作流边
 func (WorkflowFuncs) UpdateWorkflowEdge(ctx context.Context, id uint64, req *models.UpdateWorkflowEdgeRequest) (*models.WorkflowEdgeResponse, error) {
+	if err := validateEdgeType(req.Type); err != nil {
+		return nil, err
+	}
+
+	// 条件表达式按更新后的类型和数据校验
+	current, err := database.Client.WorkflowEdge.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow edge not found")
+		}
+		return nil, err
+	}
+	edgeType, data := current.Type.String(), current.Data
+	if req.Type != "" {
+		edgeType = req.Type
+	}
+	if req.Data != nil {
+		data = req.Data
+	}
+	if err := validateEdgeCondition(edgeType, data); err != nil {
+		return nil, fmt.Errorf("%s: %w", errInvalidEdgeRequest, err)
+	}
+
 	builder := database.Client.WorkflowEdge.UpdateOneID(id)
 
 	if req.SourceHandle != "" {
@@ -1331,7 +1978,7 @@ func (WorkflowFuncs) UpdateWorkflowEdge(ctx context.Context, id uint64, req *mod
 		builder = builder.SetData(req.Data)
 	}
 
-	err := builder.Exec(ctx)
+	err = builder.Exec(ctx)
 	if err != nil {
 		if ent.IsNotFound(err) {
 			return nil, fmt.Errorf("workflow edge not found")
@@ -1370,15 +2017,88 @@ func (WorkflowFuncs) BatchCreateWorkflowEdges(ctx context.Context, req *models.B
 }
 
 // BatchDeleteWorkflowEdges 批量删除工作流边
-func (WorkflowFuncs) BatchDeleteWorkflowEdges(ctx context.Context, req *models.BatchDeleteWorkflowEdgesRequest) error {
-	for _, edgeIDStr := range req.EdgeIDs {
-		edgeID := utils.StringToUint64(edgeIDStr)
-		err := WorkflowFuncs{}.DeleteWorkflowEdge(ctx, edgeID)
+// 默认在事务中执行，任意一项失败则全部回滚；ContinueOnError 为 true 时逐项删除并返回每一项的结果
+func (WorkflowFuncs) BatchDeleteWorkflowEdges(ctx context.Context, req *models.BatchDeleteWorkflowEdgesRequest) (*models.BatchOperationResult, error) {
+	return runBatchDelete(ctx, req.EdgeIDs, req.ContinueOnError, "edge",
+		func(ctx context.Context, client *ent.Client, id uint64) error {
+			err := client.WorkflowEdge.DeleteOneID(id).Exec(ctx)
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("workflow edge not found")
+			}
+			return err
+		})
+}
+
+// BatchDeleteWorkflowNodes 批量删除工作流节点
+// 默认在事务中执行，任意一项失败则全部回滚；ContinueOnError 为 true 时逐项删除并返回每一项的结果
+func (WorkflowFuncs) BatchDeleteWorkflowNodes(ctx context.Context, req *models.BatchDeleteWorkflowNodesRequest) (*models.BatchOperationResult, error) {
+	return runBatchDelete(ctx, req.NodeIDs, req.ContinueOnError, "node",
+		func(ctx context.Context, client *ent.Client, id uint64) error {
+			err := client.WorkflowNode.DeleteOneID(id).Exec(ctx)
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("workflow node not found")
+			}
+			return err
+		})
+}
+
+// runBatchDelete 批量删除的通用流程
+// continueOnError 为 false 时所有删除在同一事务中执行，遇到第一个错误即回滚并返回该错误；
+// 为 true 时每一项独立执行，失败项记录在结果中，不影响其他项
+func runBatchDelete(ctx context.Context, ids []string, continueOnError bool, kind string,
+	deleteFn func(ctx context.Context, client *ent.Client, id uint64) error) (*models.BatchOperationResult, error) {
+	result := &models.BatchOperationResult{
+		Total: len(ids),
+		Items: make([]models.BatchOperationItemResult, 0, len(ids)),
+	}
+
+	if continueOnError {
+		for _, idStr := range ids {
+			item := models.BatchOperationItemResult{ID: idStr}
+			id, err := strconv.ParseUint(idStr, 10, 64)
+			if err != nil {
+				err = fmt.Errorf("invalid %s id", kind)
+			} else {
+				err = deleteFn(ctx, database.Client, id)
+			}
+			if err != nil {
+				item.Error = err.Error()
+				result.Failed++
+			} else {
+				item.Success = true
+				result.Succeeded++
+			}
+			result.Items = append(result.Items, item)
+		}
+		return result, nil
+	}
+
+	parsedIDs := make([]uint64, 0, len(ids))
+	for _, idStr := range ids {
+		id, err := strconv.ParseUint(idStr, 10, 64)
 		if err != nil {
-			return fmt.Errorf("failed to delete edge %s: %w", edgeIDStr, err)
+			return nil, fmt.Errorf("invalid %s id %s", kind, idStr)
 		}
+		parsedIDs = append(parsedIDs, id)
 	}
-	return nil
+
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+	for i, id := range parsedIDs {
+		if err := deleteFn(ctx, tx.Client(), id); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to delete %s %s: %w", kind, ids[i], err)
+		}
+		result.Items = append(result.Items, models.BatchOperationItemResult{ID: ids[i], Success: true})
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	result.Succeeded = len(parsedIDs)
+	return result, nil
 }
 
 // ConvertWorkflowEdgeToResponse 将工作流边实体转换为响应格式
@@ -1421,35 +2141,63 @@ func (WorkflowFuncs) CreateWorkflowVersion(ctx context.Context, req *models.Crea
 		return nil, err
 	}
 
-	// 2. 查询当前应用的最大版本号
-	maxVersion, err := database.Client.WorkflowVersion.Query().
+	// 2. 生成节点和边的快照并创建版本记录
+	version, snapshot, err := createWorkflowVersionSnapshot(ctx, database.Client, applicationID, req.ChangeLog, req.Pinned)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 配置了版本保留数时清理旧版本，清理失败不影响本次创建
+	if configs.GetConfig().Workflow.MaxVersionsPerApplication > 0 {
+		if _, err := (WorkflowFuncs{}).PruneWorkflowVersions(ctx, applicationID); err != nil {
+			logging.Warn("Failed to prune workflow versions of application %d: %v", applicationID, err)
+		}
+	}
+
+	// 4. 返回响应
+	return &models.WorkflowVersionResponse{
+		ID:            utils.Uint64ToString(version.ID),
+		CreateTime:    utils.FormatDateTime(version.CreateTime),
+		UpdateTime:    utils.FormatDateTime(version.UpdateTime),
+		ApplicationID: utils.Uint64ToString(version.ApplicationID),
+		Version:       version.Version,
+		Snapshot:      snapshot,
+		ChangeLog:     version.ChangeLog,
+		Pinned:        version.Pinned,
+	}, nil
+}
+
+// createWorkflowVersionSnapshot 使用给定客户端（可以是事务客户端）为应用当前的节点和边创建版本快照
+func createWorkflowVersionSnapshot(ctx context.Context, client *ent.Client, applicationID uint64, changeLog string, pinned bool) (*ent.WorkflowVersion, models.WorkflowVersionSnapshot, error) {
+	// 1. 查询当前应用的最大版本号
+	maxVersion, err := client.WorkflowVersion.Query().
 		Where(workflowversion.ApplicationID(applicationID)).
 		Aggregate(ent.Max(workflowversion.FieldVersion)).
 		Int(ctx)
 	if err != nil && !ent.IsNotFound(err) {
-		return nil, err
+		return nil, models.WorkflowVersionSnapshot{}, err
 	}
 
 	// 新版本号 = 最大版本号 + 1
 	newVersion := uint(maxVersion + 1)
 
-	// 3. 查询所有节点
-	nodes, err := database.Client.WorkflowNode.Query().
+	// 2. 查询所有节点
+	nodes, err := client.WorkflowNode.Query().
 		Where(workflownode.ApplicationID(applicationID)).
 		All(ctx)
 	if err != nil {
-		return nil, err
+		return nil, models.WorkflowVersionSnapshot{}, err
 	}
 
-	// 4. 查询所有边
-	edges, err := database.Client.WorkflowEdge.Query().
+	// 3. 查询所有边
+	edges, err := client.WorkflowEdge.Query().
 		Where(workflowedge.ApplicationID(applicationID)).
 		All(ctx)
 	if err != nil {
-		return nil, err
+		return nil, models.WorkflowVersionSnapshot{}, err
 	}
 
-	// 5. 构建快照数据
+	// 4. 构建快照数据
 	nodeResponses := make([]*models.WorkflowNodeResponse, 0, len(nodes))
 	for _, node := range nodes {
 		nodeResponses = append(nodeResponses, WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node))
@@ -1465,33 +2213,68 @@ func (WorkflowFuncs) CreateWorkflowVersion(ctx context.Context, req *models.Crea
 		Edges: edgeResponses,
 	}
 
-	// 6. 将快照转换为 map[string]interface{} 以存储到数据库
+	// 5. 将快照转换为 map[string]interface{} 以存储到数据库
 	snapshotMap := map[string]interface{}{
 		"nodes": nodeResponses,
 		"edges": edgeResponses,
 	}
 
-	// 7. 创建版本记录
-	version, err := database.Client.WorkflowVersion.Create().
+	// 6. 创建版本记录
+	version, err := client.WorkflowVersion.Create().
 		SetApplicationID(applicationID).
 		SetVersion(newVersion).
 		SetSnapshot(snapshotMap).
-		SetNillableChangeLog(&req.ChangeLog).
+		SetNillableChangeLog(&changeLog).
+		SetPinned(pinned).
+		Save(ctx)
+	if err != nil {
+		return nil, models.WorkflowVersionSnapshot{}, err
+	}
+
+	return version, snapshot, nil
+}
+
+// PruneWorkflowVersions 按保留策略清理应用的历史版本，保留最近的N个未置顶版本及所有置顶版本，返回清理数量
+func (WorkflowFuncs) PruneWorkflowVersions(ctx context.Context, applicationID uint64) (int, error) {
+	return pruneWorkflowVersions(ctx, database.Client, applicationID, configs.GetConfig().Workflow.MaxVersionsPerApplication)
+}
+
+// pruneWorkflowVersions 删除应用中最近 maxVersions 个未置顶版本之外的未置顶版本，置顶版本不计入数量，maxVersions 不大于0时不清理
+func pruneWorkflowVersions(ctx context.Context, client *ent.Client, applicationID uint64, maxVersions int) (int, error) {
+	if maxVersions <= 0 {
+		return 0, nil
+	}
+
+	versionIDs, err := client.WorkflowVersion.Query().
+		Where(workflowversion.ApplicationID(applicationID), workflowversion.PinnedEQ(false)).
+		Order(ent.Desc(workflowversion.FieldVersion)).
+		IDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(versionIDs) <= maxVersions {
+		return 0, nil
+	}
+
+	return client.WorkflowVersion.Delete().
+		Where(workflowversion.IDIn(versionIDs[maxVersions:]...)).
+		Exec(ctx)
+}
+
+// SetWorkflowVersionPinned 设置版本置顶状态
+func (WorkflowFuncs) SetWorkflowVersionPinned(ctx context.Context, id uint64, pinned bool) (*models.WorkflowVersionResponse, error) {
+	version, err := database.Client.WorkflowVersion.UpdateOneID(id).
+		SetPinned(pinned).
 		Save(ctx)
 	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow version not found")
+		}
 		return nil, err
 	}
 
-	// 8. 返回响应
-	return &models.WorkflowVersionResponse{
-		ID:            utils.Uint64ToString(version.ID),
-		CreateTime:    utils.FormatDateTime(version.CreateTime),
-		UpdateTime:    utils.FormatDateTime(version.UpdateTime),
-		ApplicationID: utils.Uint64ToString(version.ApplicationID),
-		Version:       version.Version,
-		Snapshot:      snapshot,
-		ChangeLog:     version.ChangeLog,
-	}, nil
+	return WorkflowFuncs{}.ConvertWorkflowVersionToResponse(version), nil
 }
 
 // GetWorkflowVersionsByApplicationID 根据应用ID获取所有版本
@@ -1544,24 +2327,393 @@ func (WorkflowFuncs) ConvertWorkflowVersionToResponse(version *ent.WorkflowVersi
 		Version:       version.Version,
 		Snapshot:      snapshot,
 		ChangeLog:     version.ChangeLog,
+		Pinned:        version.Pinned,
 	}
 }
 
-// ============ Batch Save ============
+// ============ WorkflowExecution ============
 
-// BatchSaveWorkflow 批量保存工作流（节点和边的增删改）
-func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSaveWorkflowRequest) (*models.BatchSaveWorkflowData, error) {
+// CreateWorkflowExecution 创建工作流执行记录（pending 状态，等待执行器处理）
+// 应用配置了输入 Schema 时先校验输入，校验失败返回 *InputValidationError；创建时保存工作流图快照。
+// 执行上下文与应用变量合并为本次执行生效的变量（执行上下文优先）。
+// 执行队列启用时创建后立即入队，入队失败的执行标记为失败
+func (WorkflowFuncs) CreateWorkflowExecution(ctx context.Context, req *models.CreateWorkflowExecutionRequest) (*models.WorkflowExecutionResponse, error) {
+	execution, err := createWorkflowExecution(ctx, req, 0)
+	if err != nil {
+		return nil, err
+	}
+	return WorkflowFuncs{}.ConvertWorkflowExecutionToResponse(execution), nil
+}
+
+// createWorkflowExecution 创建执行记录并入队，parentExecutionID 非0时记录为子工作流节点启动的子执行
+func createWorkflowExecution(ctx context.Context, req *models.CreateWorkflowExecutionRequest, parentExecutionID uint64) (*ent.WorkflowExecution, error) {
 	applicationID := utils.StringToUint64(req.ApplicationID)
 
+	if err := checkExecutionQueueAccepting(); err != nil {
+		return nil, err
+	}
+
+	// 模板需要实例化后才能执行
+	if err := checkNotWorkflowTemplate(ctx, applicationID); err != nil {
+		return nil, err
+	}
+
+	// 校验输入（同时检查应用是否存在），不合法的输入在创建执行前被拒绝
+	err := WorkflowFuncs{}.ValidateExecutionInput(ctx, applicationID, req.Input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 拍摄图快照，执行期间按快照遍历，之后对工作流的编辑不影响本次执行
+	graphSnapshot, err := takeExecutionSnapshot(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 执行上下文覆盖应用变量，合并结果随执行保存
+	variables, err := resolveExecutionVariables(graphSnapshot, req.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := database.Client.WorkflowExecution.Create().
+		SetExecutionID(utils.UUIDString()).
+		SetApplicationID(applicationID).
+		SetStatus(workflowexecution.StatusPending).
+		SetGraphSnapshot(graphSnapshot)
+
+	if variables != nil {
+		builder = builder.SetVariables(variables)
+	}
+
+	if req.Input != nil {
+		builder = builder.SetInput(req.Input)
+	}
+	if req.Context != nil {
+		builder = builder.SetContext(req.Context)
+	}
+	if req.TriggeredBy != "" {
+		builder = builder.SetTriggeredBy(req.TriggeredBy)
+	}
+	if req.TriggerSource != "" {
+		builder = builder.SetTriggerSource(req.TriggerSource)
+	}
+	if parentExecutionID > 0 {
+		builder = builder.SetParentExecutionID(parentExecutionID)
+	}
+
+	execution, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := enqueueWorkflowExecution(ctx, execution.ID); err != nil {
+		if _, finishErr := (WorkflowFuncs{}).FinishWorkflowExecution(ctx, execution.ID, &models.UpdateWorkflowExecutionRequest{
+			Status:       string(workflowexecution.StatusFailed),
+			ErrorMessage: fmt.Sprintf("enqueue failed: %v", err),
+		}); finishErr != nil {
+			logging.Error("Failed to mark workflow execution %d as failed: %v", execution.ID, finishErr)
+		}
+		return nil, err
+	}
+
+	return execution, nil
+}
+
+// ConvertWorkflowExecutionToResponse 将工作流执行记录转换为响应格式
+func (WorkflowFuncs) ConvertWorkflowExecutionToResponse(execution *ent.WorkflowExecution) *models.WorkflowExecutionResponse {
+	resp := &models.WorkflowExecutionResponse{
+		ID:            utils.Uint64ToString(execution.ID),
+		CreateTime:    utils.FormatDateTime(execution.CreateTime),
+		UpdateTime:    utils.FormatDateTime(execution.UpdateTime),
+		ExecutionID:   execution.ExecutionID,
+		ApplicationID: utils.Uint64ToString(execution.ApplicationID),
+		Status:        string(execution.Status),
+		Input:         execution.Input,
+		Output:        execution.Output,
+		Context:       execution.Context,
+		Variables:     redactExecutionVariables(execution.Variables),
+		DurationMs:    execution.DurationMs,
+		TotalTokens:   execution.TotalTokens,
+		TotalCost:     execution.TotalCost,
+		ErrorMessage:  execution.ErrorMessage,
+		ErrorStack:    execution.ErrorStack,
+		TriggeredBy:   execution.TriggeredBy,
+		TriggerSource: execution.TriggerSource,
+	}
+
+	if execution.ParentExecutionID > 0 {
+		resp.ParentExecutionID = utils.Uint64ToString(execution.ParentExecutionID)
+	}
+	if !execution.StartedAt.IsZero() {
+		startedAt := execution.StartedAt
+		resp.StartedAt = &startedAt
+	}
+	if !execution.FinishedAt.IsZero() {
+		finishedAt := execution.FinishedAt
+		resp.FinishedAt = &finishedAt
+	}
+
+	return resp
+}
+
+// ConvertWorkflowExecutionLogToResponse 将执行日志转换为响应格式
+func (WorkflowFuncs) ConvertWorkflowExecutionLogToResponse(log *ent.WorkflowExecutionLog) *models.WorkflowExecutionLogResponse {
+	resp := &models.WorkflowExecutionLogResponse{
+		ID:          utils.Uint64ToString(log.ID),
+		CreateTime:  utils.FormatDateTime(log.CreateTime),
+		UpdateTime:  utils.FormatDateTime(log.UpdateTime),
+		ExecutionID: utils.Uint64ToString(log.ExecutionID),
+		Level:       string(log.Level),
+		Message:     log.Message,
+		Metadata:    log.Metadata,
+		LoggedAt:    log.LoggedAt,
+	}
+	if log.NodeExecutionID != 0 {
+		resp.NodeExecutionID = utils.Uint64ToString(log.NodeExecutionID)
+	}
+	return resp
+}
+
+// ConvertWorkflowNodeExecutionToResponse 将节点执行记录转换为响应格式
+func (WorkflowFuncs) ConvertWorkflowNodeExecutionToResponse(nodeExecution *ent.WorkflowNodeExecution) *models.WorkflowNodeExecutionResponse {
+	resp := &models.WorkflowNodeExecutionResponse{
+		ID:               utils.Uint64ToString(nodeExecution.ID),
+		CreateTime:       utils.FormatDateTime(nodeExecution.CreateTime),
+		UpdateTime:       utils.FormatDateTime(nodeExecution.UpdateTime),
+		ExecutionID:      utils.Uint64ToString(nodeExecution.ExecutionID),
+		NodeID:           utils.Uint64ToString(nodeExecution.NodeID),
+		NodeName:         nodeExecution.NodeName,
+		NodeType:         nodeExecution.NodeType,
+		Status:           string(nodeExecution.Status),
+		Input:            nodeExecution.Input,
+		Output:           nodeExecution.Output,
+		Extra:            nodeExecution.Extra,
+		DurationMs:       nodeExecution.DurationMs,
+		PromptTokens:     nodeExecution.PromptTokens,
+		CompletionTokens: nodeExecution.CompletionTokens,
+		TotalTokens:      nodeExecution.TotalTokens,
+		Cost:             nodeExecution.Cost,
+		Model:            nodeExecution.Model,
+		ErrorMessage:     nodeExecution.ErrorMessage,
+		ErrorStack:       nodeExecution.ErrorStack,
+		RetryCount:       nodeExecution.RetryCount,
+		IsAsync:          nodeExecution.IsAsync,
+	}
+
+	if nodeExecution.ParentExecutionID != 0 {
+		resp.ParentExecutionID = utils.Uint64ToString(nodeExecution.ParentExecutionID)
+	}
+	if !nodeExecution.StartedAt.IsZero() {
+		startedAt := nodeExecution.StartedAt
+		resp.StartedAt = &startedAt
+	}
+	if !nodeExecution.FinishedAt.IsZero() {
+		finishedAt := nodeExecution.FinishedAt
+		resp.FinishedAt = &finishedAt
+	}
+
+	return resp
+}
+
+// ============ Batch Save ============
+
+// errInvalidBatchSaveRequest 批量保存请求结构不合法时的错误前缀
+const errInvalidBatchSaveRequest = "invalid batch save request"
+
+// validateBatchSaveRequest 校验批量保存请求的临时ID与数组长度是否一致，
+// 以及边的源/目标节点是否为本次新建节点的临时ID或合法的数据库ID。
+// 返回边引用的已有节点ID（去重），由调用方确认其存在于应用中
+func validateBatchSaveRequest(req *models.BatchSaveWorkflowRequest) ([]uint64, error) {
+	if len(req.NodeTempIDs) != len(req.NodesToCreate) {
+		return nil, fmt.Errorf("%s: nodeTempIds length %d does not match nodesToCreate length %d",
+			errInvalidBatchSaveRequest, len(req.NodeTempIDs), len(req.NodesToCreate))
+	}
+	if len(req.EdgeTempIDs) != len(req.EdgesToCreate) {
+		return nil, fmt.Errorf("%s: edgeTempIds length %d does not match edgesToCreate length %d",
+			errInvalidBatchSaveRequest, len(req.EdgeTempIDs), len(req.EdgesToCreate))
+	}
+
+	tempIDs := make(map[string]int, len(req.NodeTempIDs))
+	for i, tempID := range req.NodeTempIDs {
+		if _, ok := tempIDs[tempID]; ok {
+			return nil, fmt.Errorf("%s: duplicate node temp ID %s", errInvalidBatchSaveRequest, tempID)
+		}
+		tempIDs[tempID] = i
+	}
+	for i := range req.NodesToCreate {
+		if err := validateNodeRetryPolicy(req.NodesToCreate[i].Config); err != nil {
+			return nil, fmt.Errorf("%s: node %s: %w", errInvalidBatchSaveRequest, req.NodeTempIDs[i], err)
+		}
+	}
+	for _, nodeUpdate := range req.NodesToUpdate {
+		if err := validateNodeRetryPolicy(nodeUpdate.Data.Config); err != nil {
+			return nil, fmt.Errorf("%s: node %s: %w", errInvalidBatchSaveRequest, nodeUpdate.ID, err)
+		}
+	}
+
+	deleted := make(map[uint64]struct{}, len(req.NodeIDsToDelete))
+	for _, idStr := range req.NodeIDsToDelete {
+		if nodeID, err := strconv.ParseUint(idStr, 10, 64); err == nil {
+			deleted[nodeID] = struct{}{}
+		}
+	}
+
+	seen := make(map[uint64]struct{})
+	existingNodeIDs := make([]uint64, 0)
+	for i, edgeReq := range req.EdgesToCreate {
+		if err := validateEdgeCondition(edgeReq.Type, edgeReq.Data); err != nil {
+			return nil, fmt.Errorf("%s: edge %d: %w", errInvalidBatchSaveRequest, i, err)
+		}
+		// 新建节点的自环可直接根据请求中的节点类型判断，已有节点的自环由调用方加载节点后校验
+		if index, ok := tempIDs[edgeReq.SourceNodeID]; ok && edgeReq.SourceNodeID == edgeReq.TargetNodeID &&
+			req.NodesToCreate[index].Type != string(workflownode.TypeWhileLoop) {
+			return nil, fmt.Errorf("%s: edge %d connects node %s to itself (only while_loop nodes may loop back)",
+				errInvalidBatchSaveRequest, i, edgeReq.SourceNodeID)
+		}
+		for _, ref := range []string{edgeReq.SourceNodeID, edgeReq.TargetNodeID} {
+			if _, ok := tempIDs[ref]; ok {
+				continue
+			}
+			nodeID, err := strconv.ParseUint(ref, 10, 64)
+			if err != nil || nodeID == 0 {
+				return nil, fmt.Errorf("%s: edge %d references unknown node %s (neither database ID nor temp ID)",
+					errInvalidBatchSaveRequest, i, ref)
+			}
+			if _, ok := deleted[nodeID]; ok {
+				return nil, fmt.Errorf("%s: edge %d references node %s which is being deleted",
+					errInvalidBatchSaveRequest, i, ref)
+			}
+			if _, ok := seen[nodeID]; !ok {
+				seen[nodeID] = struct{}{}
+				existingNodeIDs = append(existingNodeIDs, nodeID)
+			}
+		}
+	}
+
+	return existingNodeIDs, nil
+}
+
+// validateExistingEdgeSelfLoops 校验待创建的边在已有节点上的自环，nodes 为边引用的已有节点
+func validateExistingEdgeSelfLoops(applicationID uint64, edges []models.CreateWorkflowEdgeRequest, nodes []*ent.WorkflowNode) error {
+	nodeMap := make(map[uint64]*ent.WorkflowNode, len(nodes))
+	for _, node := range nodes {
+		nodeMap[node.ID] = node
+	}
+	for i, edgeReq := range edges {
+		if edgeReq.SourceNodeID != edgeReq.TargetNodeID {
+			continue
+		}
+		nodeID, err := strconv.ParseUint(edgeReq.SourceNodeID, 10, 64)
+		if err != nil {
+			continue
+		}
+		node, ok := nodeMap[nodeID]
+		if !ok {
+			continue
+		}
+		if err := validateEdgeEndpoints(applicationID, node, node); err != nil {
+			return fmt.Errorf("%s: edge %d: %w", errInvalidBatchSaveRequest, i, err)
+		}
+	}
+	return nil
+}
+
+// prepareBatchSave 在开启事务前校验批量保存请求：请求结构、应用存在性以及边引用的已有节点
+func prepareBatchSave(ctx context.Context, applicationID uint64, req *models.BatchSaveWorkflowRequest) error {
+	// 校验请求结构，避免临时ID错位导致映射错误
+	existingNodeIDs, err := validateBatchSaveRequest(req)
+	if err != nil {
+		return err
+	}
+
 	// 验证应用是否存在
 	exists, err := database.Client.WorkflowApplication.Query().
 		Where(workflowapplication.ID(applicationID)).
 		Exist(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check application existence: %w", err)
+		return fmt.Errorf("failed to check application existence: %w", err)
 	}
 	if !exists {
-		return nil, fmt.Errorf("workflow application not found")
+		return fmt.Errorf("workflow application not found")
+	}
+
+	// 新增和修改的节点配置必须符合节点类型的 Schema
+	if err := validateBatchSaveNodeConfigs(ctx, database.Client, applicationID, req); err != nil {
+		return err
+	}
+
+	// 边引用的已有节点必须属于该应用，且除 while_loop 外不允许自环
+	if len(existingNodeIDs) > 0 {
+		existingNodes, err := database.Client.WorkflowNode.Query().
+			Where(
+				workflownode.IDIn(existingNodeIDs...),
+				workflownode.ApplicationIDEQ(applicationID),
+			).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check edge nodes: %w", err)
+		}
+		if len(existingNodes) != len(existingNodeIDs) {
+			return fmt.Errorf("%s: edge references nodes that do not exist in the application", errInvalidBatchSaveRequest)
+		}
+		if err := validateExistingEdgeSelfLoops(applicationID, req.EdgesToCreate, existingNodes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BatchSaveWorkflow 批量保存工作流（节点和边的增删改）
+// 在开启事务前按配置校验批量规模并检查重复边；largeBatch 模式下按块拆分为多个事务依次提交
+func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSaveWorkflowRequest) (*models.BatchSaveWorkflowData, error) {
+	limits := configs.GetConfig().Workflow.BatchSave
+	nodeOps, edgeOps := batchSaveSize(req)
+	logging.Info("批量保存工作流 %s: 节点操作 %d 个，边操作 %d 个，大批量模式 %v",
+		req.ApplicationID, nodeOps, edgeOps, req.LargeBatch)
+
+	if err := checkBatchSaveLimits(req, limits); err != nil {
+		return nil, err
+	}
+
+	// 持有编辑锁直到保存结束，执行快照不会读到保存到一半的图
+	applicationID := utils.StringToUint64(req.ApplicationID)
+	if configs.GetConfig().Workflow.EditLock.BlockWhileRunning {
+		if err := checkNoRunningExecutions(ctx, applicationID); err != nil {
+			return nil, err
+		}
+	}
+	release, err := lockApplicationForEdit(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// 重复边检查在拆分前对整个请求进行，以便排除本次要删除的边
+	req, skipped, err := dedupeBatchSaveEdges(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *models.BatchSaveWorkflowData
+	if req.LargeBatch {
+		result, err = batchSaveWorkflowChunked(ctx, req, limits.LargeBatch.ChunkSize)
+	} else {
+		result, err = batchSaveWorkflowTx(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	applySkippedEdges(result, skipped)
+	return result, nil
+}
+
+// batchSaveWorkflowTx 在单个事务中执行批量保存
+func batchSaveWorkflowTx(ctx context.Context, req *models.BatchSaveWorkflowRequest) (*models.BatchSaveWorkflowData, error) {
+	applicationID := utils.StringToUint64(req.ApplicationID)
+
+	if err := prepareBatchSave(ctx, applicationID, req); err != nil {
+		return nil, err
 	}
 
 	// 使用事务确保所有操作要么全部成功，要么全部失败
@@ -1570,24 +2722,7 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		return nil, fmt.Errorf("failed to start transaction: %w", err)
 	}
 
-	result := &models.BatchSaveWorkflowData{
-		NodeIDMapping:  make(map[string]string),
-		EdgeIDMapping:  make(map[string]string),
-		CreatedNodes:   make([]*models.WorkflowNodeResponse, 0),
-		UpdatedNodes:   make([]*models.WorkflowNodeResponse, 0),
-		DeletedNodeIDs: make([]string, 0),
-		CreatedEdges:   make([]*models.WorkflowEdgeResponse, 0),
-		UpdatedEdges:   make([]*models.WorkflowEdgeResponse, 0),
-		DeletedEdgeIDs: make([]string, 0),
-		Stats: models.BatchSaveWorkflowStats{
-			NodesCreated: 0,
-			NodesUpdated: 0,
-			NodesDeleted: 0,
-			EdgesCreated: 0,
-			EdgesUpdated: 0,
-			EdgesDeleted: 0,
-		},
-	}
+	result := newBatchSaveWorkflowData()
 
 	// 临时ID到数据库ID的映射表（用于边的创建）
 	// 注意：我们需要从前端请求中获取临时ID，这里通过请求数组的顺序来建立映射
@@ -1638,6 +2773,9 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		if nodeReq.Color != "" {
 			builder = builder.SetColor(nodeReq.Color)
 		}
+		if nodeReq.Enabled != nil {
+			builder = builder.SetEnabled(*nodeReq.Enabled)
+		}
 		if nodeReq.PositionX != nil {
 			builder = builder.SetPositionX(*nodeReq.PositionX)
 		}
@@ -1652,12 +2790,9 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		}
 
 		// 记录临时ID到数据库ID的映射
-		if i < len(req.NodeTempIDs) {
-			tempID := req.NodeTempIDs[i]
-			dbID := utils.Uint64ToString(node.ID)
-			tempIDToDBID[tempID] = node.ID
-			result.NodeIDMapping[tempID] = dbID
-		}
+		tempID := req.NodeTempIDs[i]
+		tempIDToDBID[tempID] = node.ID
+		result.NodeIDMapping[tempID] = utils.Uint64ToString(node.ID)
 
 		result.CreatedNodes = append(result.CreatedNodes, WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node))
 		result.Stats.NodesCreated++
@@ -1713,6 +2848,9 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		if nodeReq.Color != "" {
 			builder = builder.SetColor(nodeReq.Color)
 		}
+		if nodeReq.Enabled != nil {
+			builder = builder.SetEnabled(*nodeReq.Enabled)
+		}
 		if nodeReq.PositionX != nil {
 			builder = builder.SetPositionX(*nodeReq.PositionX)
 		}
@@ -1744,6 +2882,12 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		result.Stats.NodesDeleted++
 	}
 
+	// 所有节点操作完成后检查重名，允许在同一批次中交换两个节点的名称
+	if err := checkApplicationNodeNames(ctx, tx.Client(), applicationID); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
 	// 4. 创建边
 	for i, edgeReq := range req.EdgesToCreate {
 		// 解析节点ID，优先从临时ID映射表查找，如果找不到再尝试解析为数据库ID
@@ -1809,11 +2953,7 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 		}
 
 		// 记录临时ID到数据库ID的映射
-		if i < len(req.EdgeTempIDs) {
-			tempID := req.EdgeTempIDs[i]
-			dbID := utils.Uint64ToString(edge.ID)
-			result.EdgeIDMapping[tempID] = dbID
-		}
+		result.EdgeIDMapping[req.EdgeTempIDs[i]] = utils.Uint64ToString(edge.ID)
 
 		result.CreatedEdges = append(result.CreatedEdges, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
 		result.Stats.EdgesCreated++
@@ -1856,6 +2996,11 @@ func (WorkflowFuncs) BatchSaveWorkflow(ctx context.Context, req *models.BatchSav
 			tx.Rollback()
 			return nil, fmt.Errorf("failed to update edge %s: %w", edgeUpdate.ID, err)
 		}
+		// 条件表达式按更新后的类型和数据校验
+		if err := validateEdgeCondition(edge.Type.String(), edge.Data); err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("%s: edge %s: %w", errInvalidBatchSaveRequest, edgeUpdate.ID, err)
+		}
 
 		result.UpdatedEdges = append(result.UpdatedEdges, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
 		result.Stats.EdgesUpdated++
@@ -0,0 +1,358 @@
+package funcs
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflowversion"
+	"go-backend/shared/models"
+)
+
+func TestValidateBatchSaveRequestNodeTempIDMismatch(t *testing.T) {
+	req := &models.BatchSaveWorkflowRequest{
+		NodeTempIDs: []string{"tmp-1"},
+		NodesToCreate: []models.CreateWorkflowNodeRequest{
+			{Name: "a"},
+			{Name: "b"},
+		},
+	}
+
+	_, err := validateBatchSaveRequest(req)
+	if err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected invalid batch save request error, got %v", err)
+	}
+}
+
+func TestValidateBatchSaveRequestEdgeTempIDMismatch(t *testing.T) {
+	req := &models.BatchSaveWorkflowRequest{
+		NodeTempIDs:   []string{"tmp-1", "tmp-2"},
+		NodesToCreate: []models.CreateWorkflowNodeRequest{{Name: "a"}, {Name: "b"}},
+		EdgeTempIDs:   []string{},
+		EdgesToCreate: []models.CreateWorkflowEdgeRequest{
+			{SourceNodeID: "tmp-1", TargetNodeID: "tmp-2"},
+		},
+	}
+
+	_, err := validateBatchSaveRequest(req)
+	if err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected invalid batch save request error, got %v", err)
+	}
+}
+
+func TestValidateBatchSaveRequestEdgeReferences(t *testing.T) {
+	req := &models.BatchSaveWorkflowRequest{
+		NodeTempIDs:     []string{"tmp-1"},
+		NodesToCreate:   []models.CreateWorkflowNodeRequest{{Name: "a"}},
+		NodeIDsToDelete: []string{"300"},
+		EdgeTempIDs:     []string{"edge-1", "edge-2"},
+		EdgesToCreate: []models.CreateWorkflowEdgeRequest{
+			{SourceNodeID: "tmp-1", TargetNodeID: "100"},
+			{SourceNodeID: "100", TargetNodeID: "200"},
+		},
+	}
+
+	existing, err := validateBatchSaveRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(existing) != 2 || existing[0] != 100 || existing[1] != 200 {
+		t.Fatalf("unexpected existing node IDs: %v", existing)
+	}
+
+	req.EdgesToCreate[1].TargetNodeID = "tmp-unknown"
+	if _, err := validateBatchSaveRequest(req); err == nil {
+		t.Fatal("expected error for unknown temp ID")
+	}
+
+	req.EdgesToCreate[1].TargetNodeID = "300"
+	if _, err := validateBatchSaveRequest(req); err == nil {
+		t.Fatal("expected error for edge referencing deleted node")
+	}
+}
+
+func TestResolveEnabledTargetsSkipsDisabledNodes(t *testing.T) {
+	// 1 -> 2(禁用) -> 3；1 -> 4(禁用条件节点) -default-> 5，-yes-> 6
+	nodes := []*ent.WorkflowNode{
+		{ID: 1, Enabled: true},
+		{ID: 2, Enabled: false},
+		{ID: 3, Enabled: true},
+		{ID: 4, Enabled: false, Type: workflownode.TypeConditionChecker},
+		{ID: 5, Enabled: true},
+		{ID: 6, Enabled: true},
+	}
+	edges := []*ent.WorkflowEdge{
+		{SourceNodeID: 1, TargetNodeID: 2},
+		{SourceNodeID: 2, TargetNodeID: 3},
+		{SourceNodeID: 1, TargetNodeID: 4},
+		{SourceNodeID: 4, TargetNodeID: 5, BranchName: ConditionBranchDefault},
+		{SourceNodeID: 4, TargetNodeID: 6, BranchName: "yes"},
+	}
+	graph := buildWorkflowGraph(&ent.WorkflowApplication{StartNodeID: 1}, nodes, edges)
+
+	targets, skipped := graph.resolveEnabledTargets(graph.outgoingEdges[1])
+	if !reflect.DeepEqual(targets, []uint64{3, 5}) {
+		t.Fatalf("unexpected targets: %v", targets)
+	}
+	if !reflect.DeepEqual(skipped, []uint64{2, 4}) {
+		t.Fatalf("unexpected skipped nodes: %v", skipped)
+	}
+
+	issues := graph.validationIssues()
+	if len(issues) != 1 || issues[0].NodeID != "4" || !strings.Contains(issues[0].Message, "yes") {
+		t.Fatalf("expected unreachable branch warning for node 4, got %+v", issues)
+	}
+}
+
+func TestCloneRemapsBranchTargetsAndEdges(t *testing.T) {
+	// 条件节点 1 有两个分支：yes -> 2，default -> 3；分支 ext 指向应用外的节点 99
+	branchNodes := map[string]interface{}{
+		"yes":     map[string]interface{}{"name": "yes", "condition": "x > 1", "targetNodeId": "2"},
+		"default": map[string]interface{}{"name": "default", "targetNodeId": float64(3)},
+		"ext":     map[string]interface{}{"name": "ext", "targetNodeId": "99"},
+	}
+	edges := []*ent.WorkflowEdge{
+		{SourceNodeID: 1, TargetNodeID: 2, BranchName: "yes"},
+		{SourceNodeID: 1, TargetNodeID: 3, BranchName: ConditionBranchDefault},
+	}
+	nodeIDMap := map[uint64]uint64{1: 101, 2: 102, 3: 103}
+
+	remapped := remapBranchNodes(branchNodes, nodeIDMap)
+	if got := remapped["yes"].(map[string]interface{})["targetNodeId"]; got != "102" {
+		t.Fatalf("expected yes branch to target 102, got %v", got)
+	}
+	if got := remapped["default"].(map[string]interface{})["targetNodeId"]; got != uint64(103) {
+		t.Fatalf("expected default branch to target 103, got %v", got)
+	}
+	if _, exists := remapped["ext"].(map[string]interface{})["targetNodeId"]; exists {
+		t.Fatal("target outside the application should be dropped")
+	}
+	if branchNodes["yes"].(map[string]interface{})["targetNodeId"] != "2" {
+		t.Fatal("original branch nodes must not be modified")
+	}
+
+	for i, edge := range edges {
+		source, target, ok := remapEdgeEndpoints(edge, nodeIDMap)
+		if !ok || source != 101 || target != nodeIDMap[edge.TargetNodeID] {
+			t.Fatalf("edge %d not remapped: %d -> %d", i, source, target)
+		}
+	}
+	if _, _, ok := remapEdgeEndpoints(&ent.WorkflowEdge{SourceNodeID: 1, TargetNodeID: 99}, nodeIDMap); ok {
+		t.Fatal("edge with endpoint outside the application should not be cloned")
+	}
+}
+
+func TestValidationIssuesReportNodeDiagnostics(t *testing.T) {
+	// 1(起始) -> 2(条件，分支 yes 指向不存在的节点 99) -default-> 3(API调用，缺少url)；4 孤立不可达
+	nodes := []*ent.WorkflowNode{
+		{ID: 1, Enabled: true},
+		{ID: 2, Enabled: true, Type: workflownode.TypeConditionChecker, BranchNodes: map[string]interface{}{
+			"yes": map[string]interface{}{"name": "yes", "targetNodeId": "99"},
+			"no":  map[string]interface{}{"name": "no", "targetNodeId": "3"},
+		}},
+		{ID: 3, Enabled: true, Type: workflownode.TypeAPICaller},
+		{ID: 4, Enabled: true},
+	}
+	edges := []*ent.WorkflowEdge{
+		{SourceNodeID: 1, TargetNodeID: 2},
+		{SourceNodeID: 2, TargetNodeID: 3, BranchName: ConditionBranchDefault},
+	}
+	graph := buildWorkflowGraph(&ent.WorkflowApplication{StartNodeID: 1}, nodes, edges)
+
+	diagnostics := groupIssuesByNode(graph.validationIssues())
+	codes := func(nodeID string) []string {
+		result := make([]string, 0)
+		for _, issue := range diagnostics[nodeID] {
+			result = append(result, issue.Code)
+		}
+		return result
+	}
+
+	if got := codes("1"); len(got) != 0 {
+		t.Fatalf("expected no diagnostics for start node, got %v", got)
+	}
+	if got := codes("2"); !reflect.DeepEqual(got, []string{models.ValidationCodeDanglingBranchTarget}) {
+		t.Fatalf("unexpected diagnostics for node 2: %v", got)
+	}
+	if got := codes("3"); !reflect.DeepEqual(got, []string{models.ValidationCodeMissingConfig}) {
+		t.Fatalf("unexpected diagnostics for node 3: %v", got)
+	}
+	if got := codes("4"); !reflect.DeepEqual(got, []string{models.ValidationCodeUnreachable}) {
+		t.Fatalf("unexpected diagnostics for node 4: %v", got)
+	}
+}
+
+func TestUniqueNodeIDs(t *testing.T) {
+	got := uniqueNodeIDs([]uint64{3, 1, 3, 0, 2, 1})
+	if want := []uint64{3, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestValidateViewportConfig(t *testing.T) {
+	if err := ValidateViewportConfig(map[string]interface{}{"x": 10.5, "y": -3.0, "zoom": 1.2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, invalid := range []map[string]interface{}{
+		nil,
+		{"x": 0.0, "y": 0.0},
+		{"x": "1", "y": 0.0, "zoom": 1.0},
+		{"x": 0.0, "y": 0.0, "zoom": 0.0},
+	} {
+		if err := ValidateViewportConfig(invalid); err == nil {
+			t.Fatalf("expected viewport %v to be rejected", invalid)
+		}
+	}
+}
+
+func TestValidateEdgeEndpointsRejectsCrossApplication(t *testing.T) {
+	source := &ent.WorkflowNode{ID: 1, ApplicationID: 10, Type: workflownode.TypeDataProcessor}
+	target := &ent.WorkflowNode{ID: 2, ApplicationID: 20, Type: workflownode.TypeEndNode}
+
+	err := validateEdgeEndpoints(10, source, target)
+	if err == nil || !strings.HasPrefix(err.Error(), errInvalidEdgeEndpoints) {
+		t.Fatalf("expected cross-application edge to be rejected, got %v", err)
+	}
+
+	target.ApplicationID = 10
+	if err := validateEdgeEndpoints(20, source, target); err == nil {
+		t.Fatal("expected edge in a different application than its nodes to be rejected")
+	}
+	if err := validateEdgeEndpoints(10, source, target); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateEdgeEndpointsSelfLoop(t *testing.T) {
+	node := &ent.WorkflowNode{ID: 1, ApplicationID: 10, Type: workflownode.TypeDataProcessor}
+	if err := validateEdgeEndpoints(10, node, node); err == nil {
+		t.Fatal("expected self-loop on data_processor to be rejected")
+	}
+
+	loop := &ent.WorkflowNode{ID: 2, ApplicationID: 10, Type: workflownode.TypeWhileLoop}
+	if err := validateEdgeEndpoints(10, loop, loop); err != nil {
+		t.Fatalf("while_loop self-loop should be allowed: %v", err)
+	}
+}
+
+func TestValidateBatchSaveRequestSelfLoops(t *testing.T) {
+	req := &models.BatchSaveWorkflowRequest{
+		NodeTempIDs: []string{"tmp-1", "tmp-2"},
+		NodesToCreate: []models.CreateWorkflowNodeRequest{
+			{Name: "a", Type: string(workflownode.TypeDataProcessor)},
+			{Name: "loop", Type: string(workflownode.TypeWhileLoop)},
+		},
+		EdgeTempIDs: []string{"edge-1"},
+		EdgesToCreate: []models.CreateWorkflowEdgeRequest{
+			{SourceNodeID: "tmp-2", TargetNodeID: "tmp-2"},
+		},
+	}
+	if _, err := validateBatchSaveRequest(req); err != nil {
+		t.Fatalf("while_loop self-loop should be allowed: %v", err)
+	}
+
+	req.EdgesToCreate[0] = models.CreateWorkflowEdgeRequest{SourceNodeID: "tmp-1", TargetNodeID: "tmp-1"}
+	if _, err := validateBatchSaveRequest(req); err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected self-loop on new node to be rejected, got %v", err)
+	}
+
+	existing := []*ent.WorkflowNode{
+		{ID: 100, ApplicationID: 10, Type: workflownode.TypeAPICaller},
+		{ID: 200, ApplicationID: 10, Type: workflownode.TypeWhileLoop},
+	}
+	edges := []models.CreateWorkflowEdgeRequest{{SourceNodeID: "200", TargetNodeID: "200"}}
+	if err := validateExistingEdgeSelfLoops(10, edges, existing); err != nil {
+		t.Fatalf("while_loop self-loop should be allowed: %v", err)
+	}
+	edges = append(edges, models.CreateWorkflowEdgeRequest{SourceNodeID: "100", TargetNodeID: "100"})
+	if err := validateExistingEdgeSelfLoops(10, edges, existing); err == nil || !strings.HasPrefix(err.Error(), errInvalidBatchSaveRequest) {
+		t.Fatalf("expected self-loop on existing node to be rejected, got %v", err)
+	}
+}
+
+func TestParseCreateWorkflowEdgeRequest(t *testing.T) {
+	req := &models.CreateWorkflowEdgeRequest{ApplicationID: "1", SourceNodeID: "2", TargetNodeID: "3", Type: "branch"}
+	appID, sourceID, targetID, err := parseCreateWorkflowEdgeRequest(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if appID != 1 || sourceID != 2 || targetID != 3 {
+		t.Fatalf("unexpected IDs: %d %d %d", appID, sourceID, targetID)
+	}
+
+	for _, invalid := range []models.CreateWorkflowEdgeRequest{
+		{ApplicationID: "abc", SourceNodeID: "2", TargetNodeID: "3"},
+		{ApplicationID: "1", SourceNodeID: "0", TargetNodeID: "3"},
+		{ApplicationID: "1", SourceNodeID: "2", TargetNodeID: "-3"},
+		{ApplicationID: "1", SourceNodeID: "2", TargetNodeID: "3", Type: "loop"},
+	} {
+		if _, _, _, err := parseCreateWorkflowEdgeRequest(&invalid); err == nil || !strings.HasPrefix(err.Error(), errInvalidEdgeRequest) {
+			t.Fatalf("expected %+v to be rejected, got %v", invalid, err)
+		}
+	}
+}
+
+func TestCheckWorkflowPublishable(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	// 应用1未设置开始节点（创建时关闭了默认开始节点）
+	if _, err := db.Exec("UPDATE `workflow_applications` SET `start_node_id` = 21 WHERE `id` = 2"); err != nil {
+		t.Fatalf("failed to set start node: %v", err)
+	}
+
+	if err := checkWorkflowPublishable(ctx, client, 1, 0); err == nil || !strings.HasPrefix(err.Error(), errWorkflowNotPublishable) {
+		t.Fatalf("application without start node should not be publishable, got %v", err)
+	}
+	if err := checkWorkflowPublishable(ctx, client, 1, 11); err != nil {
+		t.Fatalf("start node set in the same update should be accepted: %v", err)
+	}
+	if err := checkWorkflowPublishable(ctx, client, 1, 21); err == nil || !strings.HasPrefix(err.Error(), errWorkflowNotPublishable) {
+		t.Fatalf("start node of another application should be rejected, got %v", err)
+	}
+	if err := checkWorkflowPublishable(ctx, client, 2, 0); err != nil {
+		t.Fatalf("application with a start node should be publishable: %v", err)
+	}
+	if err := checkWorkflowPublishable(ctx, client, 99, 0); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestPruneWorkflowVersionsSkipsPinned(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+
+	// 应用1已有版本1（id 15），再添加版本2-6，其中版本5、6（最新的两个）和版本2置顶
+	pinned := map[int]bool{2: true, 5: true, 6: true}
+	for version := 2; version <= 6; version++ {
+		insertTestRow(t, db, "workflow_versions", map[string]any{"id": 100 + version, "application_id": 1, "version": version, "pinned": pinned[version]})
+	}
+
+	pruned, err := pruneWorkflowVersions(ctx, client, 1, 2)
+	if err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	// 未置顶的版本为 4、3、1，保留最近的两个（4、3），只删除版本1；置顶版本不占用保留名额
+	if pruned != 1 {
+		t.Fatalf("expected 1 pruned version, got %d", pruned)
+	}
+	remaining := client.WorkflowVersion.Query().Where(workflowversion.ApplicationID(1)).IDsX(ctx)
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i] < remaining[j] })
+	if want := []uint64{102, 103, 104, 105, 106}; !reflect.DeepEqual(remaining, want) {
+		t.Fatalf("remaining versions = %v, want %v", remaining, want)
+	}
+	if n := client.WorkflowVersion.Query().Where(workflowversion.ApplicationID(2)).CountX(ctx); n != 1 {
+		t.Fatalf("other application's versions should be untouched, got %d", n)
+	}
+
+	if pruned, _ := pruneWorkflowVersions(ctx, client, 1, 0); pruned != 0 {
+		t.Fatalf("unlimited retention should not prune, got %d", pruned)
+	}
+}
@@ -0,0 +1,551 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// workflowGraph 工作流的邻接表表示
+type workflowGraph struct {
+	application *ent.WorkflowApplication
+	nodes       map[uint64]*ent.WorkflowNode
+	outgoing    map[uint64][]uint64 // 节点ID -> 下游节点ID
+	incoming    map[uint64][]uint64 // 节点ID -> 上游节点ID
+
+	outgoingEdges map[uint64][]*ent.WorkflowEdge // 节点ID -> 出边
+	incomingEdges map[uint64][]*ent.WorkflowEdge // 节点ID -> 入边
+}
+
+// loadWorkflowGraph 加载应用的节点和边并构建邻接表，端点不属于该应用的边会被忽略
+func loadWorkflowGraph(ctx context.Context, applicationID uint64) (*workflowGraph, error) {
+	app, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow application not found")
+		}
+		return nil, err
+	}
+
+	nodes, err := database.Client.WorkflowNode.Query().
+		Where(workflownode.ApplicationID(applicationID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	edges, err := database.Client.WorkflowEdge.Query().
+		Where(workflowedge.ApplicationID(applicationID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildWorkflowGraph(app, nodes, edges), nil
+}
+
+// buildWorkflowGraph 根据节点和边构建邻接表
+func buildWorkflowGraph(app *ent.WorkflowApplication, nodes []*ent.WorkflowNode, edges []*ent.WorkflowEdge) *workflowGraph {
+	graph := &workflowGraph{
+		application: app,
+		nodes:       make(map[uint64]*ent.WorkflowNode, len(nodes)),
+		outgoing:    make(map[uint64][]uint64, len(nodes)),
+		incoming:    make(map[uint64][]uint64, len(nodes)),
+
+		outgoingEdges: make(map[uint64][]*ent.WorkflowEdge, len(nodes)),
+		incomingEdges: make(map[uint64][]*ent.WorkflowEdge, len(nodes)),
+	}
+
+	for _, node := range nodes {
+		graph.nodes[node.ID] = node
+	}
+
+	for _, edge := range edges {
+		if _, ok := graph.nodes[edge.SourceNodeID]; !ok {
+			continue
+		}
+		if _, ok := graph.nodes[edge.TargetNodeID]; !ok {
+			continue
+		}
+		graph.outgoing[edge.SourceNodeID] = append(graph.outgoing[edge.SourceNodeID], edge.TargetNodeID)
+		graph.incoming[edge.TargetNodeID] = append(graph.incoming[edge.TargetNodeID], edge.SourceNodeID)
+		graph.outgoingEdges[edge.SourceNodeID] = append(graph.outgoingEdges[edge.SourceNodeID], edge)
+		graph.incomingEdges[edge.TargetNodeID] = append(graph.incomingEdges[edge.TargetNodeID], edge)
+	}
+
+	return graph
+}
+
+// sortedNodeIDs 返回排序后的节点ID，起始节点优先，保证结果稳定
+func (g *workflowGraph) sortedNodeIDs(ids []uint64) []uint64 {
+	startNodeID := uint64(0)
+	if g.application != nil {
+		startNodeID = g.application.StartNodeID
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if (ids[i] == startNodeID) != (ids[j] == startNodeID) {
+			return ids[i] == startNodeID
+		}
+		return ids[i] < ids[j]
+	})
+	return ids
+}
+
+// executionLevels 使用Kahn算法对图进行拓扑排序并按层级分组，无法排序的节点（环路）单独返回
+func (g *workflowGraph) executionLevels() (levels [][]uint64, cyclic []uint64) {
+	inDegree := make(map[uint64]int, len(g.nodes))
+	current := make([]uint64, 0)
+	for id := range g.nodes {
+		inDegree[id] = len(g.incoming[id])
+		if inDegree[id] == 0 {
+			current = append(current, id)
+		}
+	}
+
+	visited := 0
+	for len(current) > 0 {
+		levels = append(levels, g.sortedNodeIDs(current))
+		visited += len(current)
+
+		next := make([]uint64, 0)
+		for _, id := range current {
+			for _, target := range g.outgoing[id] {
+				inDegree[target]--
+				if inDegree[target] == 0 {
+					next = append(next, target)
+				}
+			}
+		}
+		current = next
+	}
+
+	if visited < len(g.nodes) {
+		for id, degree := range inDegree {
+			if degree > 0 {
+				cyclic = append(cyclic, id)
+			}
+		}
+		cyclic = g.sortedNodeIDs(cyclic)
+	}
+
+	return levels, cyclic
+}
+
+// toExecutionOrderNodes 将节点ID转换为执行顺序节点
+func (g *workflowGraph) toExecutionOrderNodes(ids []uint64) []models.ExecutionOrderNode {
+	result := make([]models.ExecutionOrderNode, 0, len(ids))
+	for _, id := range ids {
+		node := g.nodes[id]
+		result = append(result, models.ExecutionOrderNode{
+			ID:   utils.Uint64ToString(node.ID),
+			Name: node.Name,
+			Type: string(node.Type),
+		})
+	}
+	return result
+}
+
+// GetExecutionOrder 计算工作流的拓扑执行顺序，按层级分组（同层级节点可并行执行）
+// 处于环路中（或只能经由环路到达）的节点无法排序，会放在最后一个Cyclic为true的分组中返回
+func (WorkflowFuncs) GetExecutionOrder(ctx context.Context, applicationID uint64) ([]models.ExecutionLevel, error) {
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	levels, cyclic := graph.executionLevels()
+
+	result := make([]models.ExecutionLevel, 0, len(levels)+1)
+	for i, level := range levels {
+		result = append(result, models.ExecutionLevel{
+			Level: i,
+			Nodes: graph.toExecutionOrderNodes(level),
+		})
+	}
+
+	if len(cyclic) > 0 {
+		result = append(result, models.ExecutionLevel{
+			Level:  len(levels),
+			Nodes:  graph.toExecutionOrderNodes(cyclic),
+			Cyclic: true,
+		})
+	}
+
+	return result, nil
+}
+
+// ============ Condition Branches ============
+
+// 条件节点的保留分支名称，当没有其他分支条件成立时执行引擎沿该分支继续
+const (
+	ConditionBranchDefault = "default"
+	ConditionBranchElse    = "else" // default 的别名
+)
+
+// IsDefaultConditionBranch 判断分支名称是否为默认（兜底）分支
+func IsDefaultConditionBranch(branchName string) bool {
+	name := strings.TrimSpace(branchName)
+	return strings.EqualFold(name, ConditionBranchDefault) || strings.EqualFold(name, ConditionBranchElse)
+}
+
+// SelectConditionBranchEdges 根据条件节点命中的分支选择要继续执行的出边
+// 命中的分支没有对应的边（或没有任何分支命中，branchName为空）时，回退到默认分支
+func SelectConditionBranchEdges(edges []*ent.WorkflowEdge, branchName string) ([]*ent.WorkflowEdge, error) {
+	var matched, fallback []*ent.WorkflowEdge
+	for _, edge := range edges {
+		switch {
+		case IsDefaultConditionBranch(edge.BranchName):
+			fallback = append(fallback, edge)
+		case branchName != "" && edge.BranchName == branchName:
+			matched = append(matched, edge)
+		}
+	}
+
+	if len(matched) > 0 {
+		return matched, nil
+	}
+	if len(fallback) > 0 {
+		return fallback, nil
+	}
+	if branchName == "" {
+		return nil, fmt.Errorf("no condition matched and no default branch is configured")
+	}
+	return nil, fmt.Errorf("no edge for branch %q and no default branch is configured", branchName)
+}
+
+// conditionBranches 按分支名称对条件节点的出边目标分组，默认分支单独返回
+func (g *workflowGraph) conditionBranches(nodeID uint64) (branches map[string][]string, defaultTargets []string) {
+	branches = make(map[string][]string)
+	for _, edge := range g.outgoingEdges[nodeID] {
+		target := utils.Uint64ToString(edge.TargetNodeID)
+		if IsDefaultConditionBranch(edge.BranchName) {
+			defaultTargets = append(defaultTargets, target)
+			continue
+		}
+		branches[edge.BranchName] = append(branches[edge.BranchName], target)
+	}
+	return branches, defaultTargets
+}
+
+// ============ Disabled Nodes ============
+
+// passThroughEdges 禁用节点透传时沿用的出边：条件节点只沿默认分支，其余节点沿所有出边
+func (g *workflowGraph) passThroughEdges(nodeID uint64) []*ent.WorkflowEdge {
+	node := g.nodes[nodeID]
+	if node == nil || node.Type != workflownode.TypeConditionChecker {
+		return g.outgoingEdges[nodeID]
+	}
+
+	edges := make([]*ent.WorkflowEdge, 0)
+	for _, edge := range g.outgoingEdges[nodeID] {
+		if IsDefaultConditionBranch(edge.BranchName) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// resolveEnabledTargets 根据出边解析下一批要执行的启用节点
+// 禁用的节点视为透传并沿 passThroughEdges 继续解析，经过的禁用节点按遇到的顺序返回，由执行引擎记录为 skipped
+func (g *workflowGraph) resolveEnabledTargets(edges []*ent.WorkflowEdge) (targets []uint64, skipped []uint64) {
+	visited := make(map[uint64]struct{})
+	queue := make([]uint64, 0, len(edges))
+	for _, edge := range edges {
+		queue = append(queue, edge.TargetNodeID)
+	}
+
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if _, ok := visited[id]; ok {
+			continue
+		}
+		visited[id] = struct{}{}
+
+		node, ok := g.nodes[id]
+		if !ok {
+			continue
+		}
+		if node.Enabled {
+			targets = append(targets, id)
+			continue
+		}
+
+		skipped = append(skipped, id)
+		for _, edge := range g.passThroughEdges(id) {
+			queue = append(queue, edge.TargetNodeID)
+		}
+	}
+	return targets, skipped
+}
+
+// ResolveNextNodes 解析从指定出边继续执行时应执行的节点，跳过（透传）禁用的节点
+// 返回要执行的启用节点以及途经的禁用节点，执行引擎应通过 SkipNodeExecution 为后者记录 skipped 执行
+func (WorkflowFuncs) ResolveNextNodes(ctx context.Context, applicationID uint64, edges []*ent.WorkflowEdge) (next []*ent.WorkflowNode, skipped []*ent.WorkflowNode, err error) {
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	targets, skippedIDs := graph.resolveEnabledTargets(edges)
+	for _, id := range targets {
+		next = append(next, graph.nodes[id])
+	}
+	for _, id := range skippedIDs {
+		skipped = append(skipped, graph.nodes[id])
+	}
+	return next, skipped, nil
+}
+
+// SetWorkflowNodeEnabled 启用或禁用工作流节点，禁用的节点及其边保持不变，仅在执行时被跳过
+func (WorkflowFuncs) SetWorkflowNodeEnabled(ctx context.Context, id uint64, enabled bool) (*models.WorkflowNodeResponse, error) {
+	err := database.Client.WorkflowNode.UpdateOneID(id).
+		SetEnabled(enabled).
+		Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node not found")
+		}
+		return nil, err
+	}
+	return WorkflowFuncs{}.GetWorkflowNodeByID(ctx, id)
+}
+
+// validationIssues 检查工作流图中的配置问题
+func (g *workflowGraph) validationIssues() []models.WorkflowValidationIssue {
+	ids := make([]uint64, 0, len(g.nodes))
+	for id := range g.nodes {
+		ids = append(ids, id)
+	}
+
+	reachable := g.reachableNodes()
+
+	issues := make([]models.WorkflowValidationIssue, 0)
+	for _, id := range g.sortedNodeIDs(ids) {
+		node := g.nodes[id]
+		if message := missingNodeConfig(node); message != "" {
+			issues = append(issues, models.WorkflowValidationIssue{
+				NodeID:   utils.Uint64ToString(id),
+				NodeName: node.Name,
+				Level:    models.ValidationLevelError,
+				Code:     models.ValidationCodeMissingConfig,
+				Message:  message,
+			})
+		}
+		if reachable != nil {
+			if _, ok := reachable[id]; !ok {
+				issues = append(issues, models.WorkflowValidationIssue{
+					NodeID:   utils.Uint64ToString(id),
+					NodeName: node.Name,
+					Level:    models.ValidationLevelWarning,
+					Code:     models.ValidationCodeUnreachable,
+					Message:  "节点无法从起始节点到达，执行时不会被运行",
+				})
+			}
+		}
+
+		for _, edge := range g.outgoingEdges[id] {
+			if err := validateEdgeCondition(edge.Type.String(), edge.Data); err != nil {
+				issues = append(issues, models.WorkflowValidationIssue{
+					NodeID:   utils.Uint64ToString(id),
+					NodeName: node.Name,
+					Level:    models.ValidationLevelError,
+					Code:     models.ValidationCodeInvalidEdgeCondition,
+					Message:  fmt.Sprintf("到节点 %d 的条件边表达式不合法: %v", edge.TargetNodeID, err),
+				})
+			}
+		}
+
+		if node.Type != workflownode.TypeConditionChecker {
+			continue
+		}
+		for _, name := range g.danglingBranchTargets(node) {
+			issues = append(issues, models.WorkflowValidationIssue{
+				NodeID:   utils.Uint64ToString(id),
+				NodeName: node.Name,
+				Level:    models.ValidationLevelError,
+				Code:     models.ValidationCodeDanglingBranchTarget,
+				Message:  fmt.Sprintf("分支 %s 的目标节点不存在或不属于当前应用", name),
+			})
+		}
+		branches, defaultTargets := g.conditionBranches(id)
+		if len(defaultTargets) == 0 {
+			issues = append(issues, models.WorkflowValidationIssue{
+				NodeID:   utils.Uint64ToString(id),
+				NodeName: node.Name,
+				Level:    models.ValidationLevelWarning,
+				Code:     models.ValidationCodeMissingDefaultBranch,
+				Message:  fmt.Sprintf("条件节点缺少默认分支（%s），没有条件成立时执行将无法继续", ConditionBranchDefault),
+			})
+		}
+
+		// 禁用的条件节点只沿默认分支透传，其余分支在执行时不可达
+		if !node.Enabled && len(branches) > 0 {
+			names := make([]string, 0, len(branches))
+			for name := range branches {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			issues = append(issues, models.WorkflowValidationIssue{
+				NodeID:   utils.Uint64ToString(id),
+				NodeName: node.Name,
+				Level:    models.ValidationLevelWarning,
+				Code:     models.ValidationCodeDisabledBranch,
+				Message:  fmt.Sprintf("条件节点已禁用，执行时只沿默认分支透传，分支 %s 将不可达", strings.Join(names, ", ")),
+			})
+		}
+	}
+	return issues
+}
+
+// reachableNodes 返回从起始节点出发沿边可达的节点集合，应用没有有效的起始节点时返回nil（不做可达性检查）
+func (g *workflowGraph) reachableNodes() map[uint64]struct{} {
+	if g.application == nil {
+		return nil
+	}
+	if _, ok := g.nodes[g.application.StartNodeID]; !ok {
+		return nil
+	}
+
+	reachable := map[uint64]struct{}{g.application.StartNodeID: {}}
+	queue := []uint64{g.application.StartNodeID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, target := range g.outgoing[id] {
+			if _, ok := reachable[target]; ok {
+				continue
+			}
+			reachable[target] = struct{}{}
+			queue = append(queue, target)
+		}
+	}
+	return reachable
+}
+
+// danglingBranchTargets 返回条件节点 branch_nodes 中目标节点不在当前图中的分支名称
+func (g *workflowGraph) danglingBranchTargets(node *ent.WorkflowNode) []string {
+	names := make([]string, 0)
+	for name, raw := range node.BranchNodes {
+		branch, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		target, exists := branch["targetNodeId"]
+		if !exists || target == nil || target == "" {
+			continue
+		}
+		targetID, parsed := parseBranchTargetID(target)
+		if _, ok := g.nodes[targetID]; parsed && ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// missingNodeConfig 检查节点类型要求的必填配置，返回问题描述，配置完整时返回空串
+func missingNodeConfig(node *ent.WorkflowNode) string {
+	switch node.Type {
+	case workflownode.TypeAPICaller:
+		if url, _ := node.APIConfig["url"].(string); strings.TrimSpace(url) == "" {
+			return "API调用节点缺少请求地址（apiConfig.url）"
+		}
+	case workflownode.TypeLlmCaller:
+		if strings.TrimSpace(node.Prompt) == "" {
+			return "LLM调用节点缺少提示词（prompt）"
+		}
+	case workflownode.TypeDataProcessor:
+		if strings.TrimSpace(node.ProcessorCode) == "" {
+			return "数据处理节点缺少处理代码（processorCode）"
+		}
+	case workflownode.TypeWorkflow:
+		if node.WorkflowApplicationID == 0 {
+			return "子工作流节点未指定引用的工作流应用（workflowApplicationId）"
+		}
+	}
+	return ""
+}
+
+// groupIssuesByNode 按节点ID分组校验问题，不关联节点的问题不包含在结果中
+func groupIssuesByNode(issues []models.WorkflowValidationIssue) map[string][]models.WorkflowValidationIssue {
+	result := make(map[string][]models.WorkflowValidationIssue)
+	for _, issue := range issues {
+		if issue.NodeID == "" {
+			continue
+		}
+		result[issue.NodeID] = append(result[issue.NodeID], issue)
+	}
+	return result
+}
+
+// GetWorkflowNodeDiagnostics 校验工作流图并按节点ID返回问题，供图查询时内联展示
+func (WorkflowFuncs) GetWorkflowNodeDiagnostics(ctx context.Context, applicationID uint64) (map[string][]models.WorkflowValidationIssue, error) {
+	issues, err := WorkflowFuncs{}.ValidateWorkflowApplication(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return groupIssuesByNode(issues), nil
+}
+
+// ValidateWorkflowApplication 校验工作流应用的图结构，返回发现的问题（不会阻止保存）
+func (WorkflowFuncs) ValidateWorkflowApplication(ctx context.Context, applicationID uint64) ([]models.WorkflowValidationIssue, error) {
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return graph.validationIssues(), nil
+}
+
+// GetNodeConnections 获取节点的所有连接信息，条件节点会按分支分组并单独返回默认分支
+func (WorkflowFuncs) GetNodeConnections(ctx context.Context, nodeID uint64) (*models.NodeConnectionsResponse, error) {
+	node, err := database.Client.WorkflowNode.Get(ctx, nodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node not found")
+		}
+		return nil, err
+	}
+
+	graph, err := loadWorkflowGraph(ctx, node.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.NodeConnectionsResponse{
+		NodeID:   utils.Uint64ToString(node.ID),
+		NodeType: string(node.Type),
+		Incoming: make([]*models.WorkflowEdgeResponse, 0, len(graph.incomingEdges[nodeID])),
+		Outgoing: make([]*models.WorkflowEdgeResponse, 0, len(graph.outgoingEdges[nodeID])),
+	}
+	for _, edge := range graph.incomingEdges[nodeID] {
+		resp.Incoming = append(resp.Incoming, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
+	}
+	for _, edge := range graph.outgoingEdges[nodeID] {
+		resp.Outgoing = append(resp.Outgoing, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
+	}
+
+	if node.Type == workflownode.TypeConditionChecker {
+		resp.Branches, resp.DefaultBranch = graph.conditionBranches(nodeID)
+		for _, issue := range graph.validationIssues() {
+			if issue.NodeID == resp.NodeID {
+				resp.Warnings = append(resp.Warnings, issue.Message)
+			}
+		}
+	}
+
+	return resp, nil
+}
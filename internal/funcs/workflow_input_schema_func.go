@@ -0,0 +1,351 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// 执行输入校验支持的 JSON Schema 子集：
+//   - type: object / array / string / number / integer / boolean / null
+//   - required: 对象的必填字段
+//   - properties: 对象字段的子 Schema（可嵌套）
+//   - items: 数组元素的 Schema
+//   - enum: 允许的取值
+//   - minLength: 字符串的最小长度（按字符计）
+//   - minimum / maximum: 数值的取值范围
+//   - minItems: 数组的最少元素数
+//   - minProperties: 对象的最少字段数
+//   - additionalProperties: 未在 properties 中声明的字段的 Schema（用于键名不固定的映射）
+// 未声明的字段与其他关键字不做校验
+
+// InputValidationError 执行输入校验失败，包含字段级错误
+type InputValidationError struct {
+	Errors []models.InputFieldError
+}
+
+func (e *InputValidationError) Error() string {
+	return fmt.Sprintf("invalid execution input: %d field error(s)", len(e.Errors))
+}
+
+var inputSchemaTypes = map[string]bool{
+	"object": true, "array": true, "string": true, "number": true,
+	"integer": true, "boolean": true, "null": true,
+}
+
+// ValidateInputSchema 检查 Schema 本身是否合法，在创建/更新应用时调用
+func ValidateInputSchema(schema map[string]interface{}) error {
+	return validateInputSchemaAt(schema, "")
+}
+
+func validateInputSchemaAt(schema map[string]interface{}, path string) error {
+	where := path
+	if where == "" {
+		where = "(root)"
+	}
+
+	if t, ok := schema["type"]; ok {
+		name, isString := t.(string)
+		if !isString || !inputSchemaTypes[name] {
+			return fmt.Errorf("invalid input schema at %s: unsupported type %v", where, t)
+		}
+	}
+	if required, ok := schema["required"]; ok {
+		list, isList := required.([]interface{})
+		if !isList {
+			return fmt.Errorf("invalid input schema at %s: required must be an array", where)
+		}
+		for _, item := range list {
+			if _, isString := item.(string); !isString {
+				return fmt.Errorf("invalid input schema at %s: required must contain field names", where)
+			}
+		}
+	}
+	if enum, ok := schema["enum"]; ok {
+		if list, isList := enum.([]interface{}); !isList || len(list) == 0 {
+			return fmt.Errorf("invalid input schema at %s: enum must be a non-empty array", where)
+		}
+	}
+	for _, keyword := range []string{"minLength", "minimum", "maximum", "minItems", "minProperties"} {
+		if value, ok := schema[keyword]; ok {
+			if _, isNumber := inputNumber(value); !isNumber {
+				return fmt.Errorf("invalid input schema at %s: %s must be a number", where, keyword)
+			}
+		}
+	}
+	if additional, ok := schema["additionalProperties"]; ok {
+		additionalSchema, isMap := additional.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("invalid input schema at %s: additionalProperties must be an object", where)
+		}
+		if err := validateInputSchemaAt(additionalSchema, joinInputPath(path, "*")); err != nil {
+			return err
+		}
+	}
+	if properties, ok := schema["properties"]; ok {
+		props, isMap := properties.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("invalid input schema at %s: properties must be an object", where)
+		}
+		for name, sub := range props {
+			subSchema, isMap := sub.(map[string]interface{})
+			if !isMap {
+				return fmt.Errorf("invalid input schema at %s: property schema must be an object", joinInputPath(path, name))
+			}
+			if err := validateInputSchemaAt(subSchema, joinInputPath(path, name)); err != nil {
+				return err
+			}
+		}
+	}
+	if items, ok := schema["items"]; ok {
+		itemSchema, isMap := items.(map[string]interface{})
+		if !isMap {
+			return fmt.Errorf("invalid input schema at %s: items must be an object", where)
+		}
+		if err := validateInputSchemaAt(itemSchema, path+"[]"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateExecutionInput 按应用配置的输入 Schema 校验执行输入
+// 应用未配置 Schema 时直接通过，校验失败返回 *InputValidationError
+func (WorkflowFuncs) ValidateExecutionInput(ctx context.Context, applicationID uint64, input map[string]interface{}) error {
+	app, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Select(workflowapplication.FieldInputSchema).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("workflow application not found")
+		}
+		return fmt.Errorf("failed to query application: %w", err)
+	}
+
+	return checkExecutionInput(app.InputSchema, input)
+}
+
+// checkExecutionInput 使用 Schema 校验输入，Schema 为空时不校验
+func checkExecutionInput(schema map[string]interface{}, input map[string]interface{}) error {
+	if len(schema) == 0 {
+		return nil
+	}
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+
+	errs := validateInputValue(schema, input, "")
+	if len(errs) > 0 {
+		return &InputValidationError{Errors: errs}
+	}
+	return nil
+}
+
+func validateInputValue(schema map[string]interface{}, value interface{}, path string) []models.InputFieldError {
+	var errs []models.InputFieldError
+	field := path
+	if field == "" {
+		field = "(root)"
+	}
+
+	if t, ok := schema["type"].(string); ok && !matchesInputType(t, value) {
+		return append(errs, models.InputFieldError{
+			Field:   field,
+			Message: fmt.Sprintf("expected %s, got %s", t, inputValueType(value)),
+		})
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok && !inputEnumContains(enum, value) {
+		errs = append(errs, models.InputFieldError{
+			Field:   field,
+			Message: fmt.Sprintf("must be one of %v", enum),
+		})
+	}
+
+	errs = append(errs, validateInputBounds(schema, value, field)...)
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, item := range required {
+				name, _ := item.(string)
+				if _, exists := v[name]; !exists {
+					errs = append(errs, models.InputFieldError{
+						Field:   joinInputPath(path, name),
+						Message: "is required",
+					})
+				}
+			}
+		}
+		if props, ok := schema["properties"].(map[string]interface{}); ok {
+			// 按字段名排序，保证错误顺序稳定
+			names := make([]string, 0, len(props))
+			for name := range props {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fieldValue, exists := v[name]
+				subSchema, isMap := props[name].(map[string]interface{})
+				if !exists || !isMap {
+					continue
+				}
+				errs = append(errs, validateInputValue(subSchema, fieldValue, joinInputPath(path, name))...)
+			}
+		}
+		if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok {
+			props, _ := schema["properties"].(map[string]interface{})
+			names := make([]string, 0, len(v))
+			for name := range v {
+				if _, declared := props[name]; !declared {
+					names = append(names, name)
+				}
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				errs = append(errs, validateInputValue(additional, v[name], joinInputPath(path, name))...)
+			}
+		}
+	case []interface{}:
+		if itemSchema, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				errs = append(errs, validateInputValue(itemSchema, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateInputBounds 校验长度、数量和数值范围关键字，类型不适用的关键字被忽略
+func validateInputBounds(schema map[string]interface{}, value interface{}, field string) []models.InputFieldError {
+	var errs []models.InputFieldError
+	bound := func(keyword string) (float64, bool) {
+		return inputNumber(schema[keyword])
+	}
+
+	switch v := value.(type) {
+	case string:
+		if minLength, ok := bound("minLength"); ok && float64(utf8.RuneCountInString(v)) < minLength {
+			if minLength <= 1 {
+				errs = append(errs, models.InputFieldError{Field: field, Message: "must not be empty"})
+			} else {
+				errs = append(errs, models.InputFieldError{Field: field, Message: fmt.Sprintf("must be at least %v characters", minLength)})
+			}
+		}
+	case []interface{}:
+		if minItems, ok := bound("minItems"); ok && float64(len(v)) < minItems {
+			errs = append(errs, models.InputFieldError{Field: field, Message: fmt.Sprintf("must contain at least %v item(s)", minItems)})
+		}
+	case map[string]interface{}:
+		if minProperties, ok := bound("minProperties"); ok && float64(len(v)) < minProperties {
+			errs = append(errs, models.InputFieldError{Field: field, Message: fmt.Sprintf("must contain at least %v entry(s)", minProperties)})
+		}
+	default:
+		n, isNumber := inputNumber(value)
+		if !isNumber {
+			break
+		}
+		if minimum, ok := bound("minimum"); ok && n < minimum {
+			errs = append(errs, models.InputFieldError{Field: field, Message: fmt.Sprintf("must be >= %v", minimum)})
+		}
+		if maximum, ok := bound("maximum"); ok && n > maximum {
+			errs = append(errs, models.InputFieldError{Field: field, Message: fmt.Sprintf("must be <= %v", maximum)})
+		}
+	}
+	return errs
+}
+
+func matchesInputType(t string, value interface{}) bool {
+	switch t {
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	case "number":
+		_, ok := inputNumber(value)
+		return ok
+	case "integer":
+		n, ok := inputNumber(value)
+		return ok && n == float64(int64(n))
+	}
+	return true
+}
+
+func inputNumber(value interface{}) (float64, bool) {
+	switch n := value.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func inputValueType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	}
+	if _, ok := inputNumber(value); ok {
+		return "number"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+func inputEnumContains(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		a, aNum := inputNumber(allowed)
+		v, vNum := inputNumber(value)
+		if aNum && vNum {
+			if a == v {
+				return true
+			}
+			continue
+		}
+		if reflect.DeepEqual(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinInputPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return strings.Join([]string{path, name}, ".")
+}
@@ -0,0 +1,70 @@
+package funcs
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func mustJSONMap(t *testing.T, s string) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal([]byte(s), &m); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	return m
+}
+
+func TestCheckExecutionInput(t *testing.T) {
+	schema := mustJSONMap(t, `{
+		"type": "object",
+		"required": ["query", "mode"],
+		"properties": {
+			"query": {"type": "string"},
+			"mode": {"type": "string", "enum": ["fast", "accurate"]},
+			"limit": {"type": "integer"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`)
+
+	if err := checkExecutionInput(schema, mustJSONMap(t, `{"query": "hi", "mode": "fast", "limit": 3, "tags": ["a"]}`)); err != nil {
+		t.Fatalf("expected valid input, got %v", err)
+	}
+
+	err := checkExecutionInput(schema, mustJSONMap(t, `{"mode": "slow", "limit": 1.5, "tags": ["a", 2]}`))
+	var inputErr *InputValidationError
+	if !errors.As(err, &inputErr) {
+		t.Fatalf("expected InputValidationError, got %v", err)
+	}
+	fields := make([]string, 0, len(inputErr.Errors))
+	for _, e := range inputErr.Errors {
+		fields = append(fields, e.Field)
+	}
+	if !reflect.DeepEqual(fields, []string{"query", "limit", "mode", "tags[1]"}) {
+		t.Fatalf("unexpected field errors: %+v", inputErr.Errors)
+	}
+
+	if err := checkExecutionInput(nil, nil); err != nil {
+		t.Fatalf("empty schema should accept any input, got %v", err)
+	}
+	if err := checkExecutionInput(schema, nil); err == nil {
+		t.Fatal("missing input should fail required fields")
+	}
+}
+
+func TestValidateInputSchema(t *testing.T) {
+	if err := ValidateInputSchema(mustJSONMap(t, `{"type": "object", "properties": {"a": {"type": "string"}}}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, invalid := range []string{
+		`{"type": "text"}`,
+		`{"required": "a"}`,
+		`{"enum": []}`,
+		`{"properties": {"a": {"type": 1}}}`,
+	} {
+		if err := ValidateInputSchema(mustJSONMap(t, invalid)); err == nil {
+			t.Fatalf("expected schema %s to be rejected", invalid)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// 自动布局方向
+const (
+	LayoutDirectionLR = "LR" // 从左到右，层级沿X轴排列
+	LayoutDirectionTB = "TB" // 从上到下，层级沿Y轴排列
+)
+
+// 自动布局的默认间距
+const (
+	defaultLayoutLevelGap = 300
+	defaultLayoutNodeGap  = 150
+)
+
+// errInvalidLayoutOptions 自动布局参数无效
+const errInvalidLayoutOptions = "invalid layout options"
+
+// IsInvalidLayoutOptions 判断错误是否为自动布局参数无效
+func IsInvalidLayoutOptions(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errInvalidLayoutOptions)
+}
+
+// normalizeLayoutOptions 校验布局参数并填充默认值
+func normalizeLayoutOptions(options models.LayoutOptions) (models.LayoutOptions, error) {
+	options.Direction = strings.ToUpper(strings.TrimSpace(options.Direction))
+	if options.Direction == "" {
+		options.Direction = LayoutDirectionLR
+	}
+	if options.Direction != LayoutDirectionLR && options.Direction != LayoutDirectionTB {
+		return options, fmt.Errorf("%s: direction must be %s or %s", errInvalidLayoutOptions, LayoutDirectionLR, LayoutDirectionTB)
+	}
+	if options.LevelGap < 0 || options.NodeGap < 0 {
+		return options, fmt.Errorf("%s: gaps must not be negative", errInvalidLayoutOptions)
+	}
+	if options.LevelGap == 0 {
+		options.LevelGap = defaultLayoutLevelGap
+	}
+	if options.NodeGap == 0 {
+		options.NodeGap = defaultLayoutNodeGap
+	}
+	return options, nil
+}
+
+// AutoLayoutWorkflow 按分层（Sugiyama）方式计算应用内所有节点的位置，options.Persist 为 true 时同时保存。
+// 返回 节点ID -> 位置
+func (WorkflowFuncs) AutoLayoutWorkflow(ctx context.Context, applicationID uint64, options models.LayoutOptions) (map[string]models.Position, error) {
+	options, err := normalizeLayoutOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	graph, err := loadWorkflowGraph(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	positions := graph.layeredLayout(options)
+
+	if options.Persist && len(positions) > 0 {
+		tx, err := database.Client.Tx(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start transaction: %w", err)
+		}
+		for _, id := range graph.sortedNodeIDs(layoutNodeIDs(positions)) {
+			position := positions[id]
+			if err := tx.WorkflowNode.UpdateOneID(id).
+				SetPositionX(position.X).
+				SetPositionY(position.Y).
+				Exec(ctx); err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("failed to update position of node %d: %w", id, err)
+			}
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+
+	result := make(map[string]models.Position, len(positions))
+	for id, position := range positions {
+		result[utils.Uint64ToString(id)] = position
+	}
+	return result, nil
+}
+
+// layeredLayout 计算分层布局：
+//  1. 从起始节点开始深度优先遍历，忽略回边（以及指向起始节点的边），把图变为无环图；
+//  2. 按最长路径分配层级，起始节点位于第0层，每个节点位于其所有上游节点之后；
+//  3. 逐层按上游节点的平均序号（重心）排序，减少连线交叉；
+//  4. 层级沿布局方向等距排列，同层节点等距排列并相对最宽的层级居中。
+func (g *workflowGraph) layeredLayout(options models.LayoutOptions) map[uint64]models.Position {
+	if len(g.nodes) == 0 {
+		return map[uint64]models.Position{}
+	}
+
+	predecessors := g.acyclicPredecessors()
+	ranks := g.layoutRanks(predecessors)
+
+	maxRank := 0
+	for _, rank := range ranks {
+		maxRank = max(maxRank, rank)
+	}
+	levels := make([][]uint64, maxRank+1)
+	for _, id := range g.sortedNodeIDs(layoutNodeIDs(g.nodes)) {
+		levels[ranks[id]] = append(levels[ranks[id]], id)
+	}
+
+	// 按重心排序：节点的重心为其上游节点在各自层级中序号的平均值，没有上游的节点保持原有顺序排在后面
+	order := make(map[uint64]float64, len(g.nodes))
+	widest := 0
+	for _, level := range levels {
+		barycenter := make(map[uint64]float64, len(level))
+		for i, id := range level {
+			barycenter[id] = float64(len(g.nodes) + i)
+			if len(predecessors[id]) == 0 {
+				continue
+			}
+			sum := 0.0
+			for _, source := range predecessors[id] {
+				sum += order[source]
+			}
+			barycenter[id] = sum / float64(len(predecessors[id]))
+		}
+		sort.SliceStable(level, func(i, j int) bool {
+			return barycenter[level[i]] < barycenter[level[j]]
+		})
+		for i, id := range level {
+			order[id] = float64(i)
+		}
+		widest = max(widest, len(level))
+	}
+
+	positions := make(map[uint64]models.Position, len(g.nodes))
+	for rank, level := range levels {
+		offset := float64(widest-len(level)) / 2 * options.NodeGap
+		for i, id := range level {
+			along := options.LevelGap * float64(rank)
+			across := offset + options.NodeGap*float64(i)
+			if options.Direction == LayoutDirectionTB {
+				positions[id] = models.Position{X: options.Origin.X + across, Y: options.Origin.Y + along}
+			} else {
+				positions[id] = models.Position{X: options.Origin.X + along, Y: options.Origin.Y + across}
+			}
+		}
+	}
+	return positions
+}
+
+// acyclicPredecessors 返回去掉回边后每个节点的上游节点。
+// 从起始节点开始深度优先遍历，其余未访问的节点按ID顺序作为遍历起点；指向遍历栈中节点的边（回边）和指向起始节点的边不参与布局
+func (g *workflowGraph) acyclicPredecessors() map[uint64][]uint64 {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	startNodeID := uint64(0)
+	if g.application != nil {
+		startNodeID = g.application.StartNodeID
+	}
+
+	state := make(map[uint64]int, len(g.nodes))
+	predecessors := make(map[uint64][]uint64, len(g.nodes))
+	var visit func(id uint64)
+	visit = func(id uint64) {
+		state[id] = visiting
+		for _, target := range g.sortedNodeIDs(append([]uint64(nil), g.outgoing[id]...)) {
+			if target == startNodeID || state[target] == visiting {
+				continue
+			}
+			predecessors[target] = appendUnique(predecessors[target], id)
+			if state[target] == unvisited {
+				visit(target)
+			}
+		}
+		state[id] = visited
+	}
+
+	for _, id := range g.sortedNodeIDs(layoutNodeIDs(g.nodes)) {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return predecessors
+}
+
+// layoutRanks 按最长路径分配层级：没有上游的节点位于第0层，其余节点位于所有上游节点的下一层
+func (g *workflowGraph) layoutRanks(predecessors map[uint64][]uint64) map[uint64]int {
+	ranks := make(map[uint64]int, len(g.nodes))
+	var rankOf func(id uint64) int
+	rankOf = func(id uint64) int {
+		if rank, ok := ranks[id]; ok {
+			return rank
+		}
+		rank := 0
+		for _, source := range predecessors[id] {
+			rank = max(rank, rankOf(source)+1)
+		}
+		ranks[id] = rank
+		return rank
+	}
+	for id := range g.nodes {
+		rankOf(id)
+	}
+	return ranks
+}
+
+// layoutNodeIDs 返回以节点ID为键的映射中的所有节点ID
+func layoutNodeIDs[V any](values map[uint64]V) []uint64 {
+	ids := make([]uint64, 0, len(values))
+	for id := range values {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// appendUnique 向切片追加不重复的节点ID（重复边只计算一次）
+func appendUnique(ids []uint64, id uint64) []uint64 {
+	for _, existing := range ids {
+		if existing == id {
+			return ids
+		}
+	}
+	return append(ids, id)
+}
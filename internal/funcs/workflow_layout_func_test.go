@@ -0,0 +1,110 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func layoutTestGraph(edges [][2]uint64) *workflowGraph {
+	nodes := make([]*ent.WorkflowNode, 0, 5)
+	for id := uint64(1); id <= 5; id++ {
+		nodes = append(nodes, &ent.WorkflowNode{ID: id, Type: workflownode.TypeLlmCaller})
+	}
+	workflowEdges := make([]*ent.WorkflowEdge, 0, len(edges))
+	for i, edge := range edges {
+		workflowEdges = append(workflowEdges, &ent.WorkflowEdge{ID: uint64(100 + i), SourceNodeID: edge[0], TargetNodeID: edge[1]})
+	}
+	return buildWorkflowGraph(&ent.WorkflowApplication{ID: 1, StartNodeID: 1}, nodes, workflowEdges)
+}
+
+func TestLayeredLayout(t *testing.T) {
+	// 1 -> 2 -> 4, 1 -> 3 -> 4 -> 5，4 -> 2 构成环路
+	graph := layoutTestGraph([][2]uint64{{1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 5}, {4, 2}})
+	options, err := normalizeLayoutOptions(models.LayoutOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	positions := graph.layeredLayout(options)
+
+	wantX := map[uint64]float64{1: 0, 2: 300, 3: 300, 4: 600, 5: 900}
+	for id, x := range wantX {
+		if positions[id].X != x {
+			t.Fatalf("node %d: expected x %v, got %+v", id, x, positions[id])
+		}
+	}
+	if positions[2].Y == positions[3].Y {
+		t.Fatalf("nodes in the same level should not overlap: %+v %+v", positions[2], positions[3])
+	}
+	// 单节点层级相对最宽的层级居中
+	if positions[1].Y != 75 || positions[4].Y != 75 {
+		t.Fatalf("single-node levels should be centered, got %+v %+v", positions[1], positions[4])
+	}
+
+	options.Direction = "tb"
+	options, err = normalizeLayoutOptions(options)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if position := graph.layeredLayout(options)[5]; position.Y != 900 || position.X != 75 {
+		t.Fatalf("expected top-to-bottom layout, got %+v", position)
+	}
+
+	if _, err := normalizeLayoutOptions(models.LayoutOptions{Direction: "RL"}); !IsInvalidLayoutOptions(err) {
+		t.Fatalf("expected invalid direction to be rejected, got %v", err)
+	}
+}
+
+func TestLayeredLayoutCycleThroughStart(t *testing.T) {
+	// 整个图是一个经过起始节点的环，另有一个孤立节点
+	graph := layoutTestGraph([][2]uint64{{1, 2}, {2, 3}, {3, 4}, {4, 1}})
+	options, _ := normalizeLayoutOptions(models.LayoutOptions{Origin: models.Position{X: 10, Y: 20}})
+	positions := graph.layeredLayout(options)
+	if len(positions) != 5 {
+		t.Fatalf("expected every node to be placed, got %d", len(positions))
+	}
+	for rank, id := range []uint64{1, 2, 3, 4} {
+		if positions[id].X != 10+float64(rank)*300 {
+			t.Fatalf("node %d: expected rank %d, got %+v", id, rank, positions[id])
+		}
+	}
+	if positions[5].X != 10 || positions[5].Y == positions[1].Y {
+		t.Fatalf("isolated node should share level 0 without overlapping, got %+v", positions[5])
+	}
+}
+
+func TestAutoLayoutWorkflowPersist(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+
+	original := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = original })
+
+	positions, err := WorkflowFuncs{}.AutoLayoutWorkflow(ctx, 1, models.LayoutOptions{})
+	if err != nil {
+		t.Fatalf("auto layout failed: %v", err)
+	}
+	if positions["12"].X != 300 {
+		t.Fatalf("unexpected positions: %v", positions)
+	}
+	if node := client.WorkflowNode.GetX(ctx, 12); node.PositionX != 0 {
+		t.Fatalf("positions should not be saved without persist, got %v", node.PositionX)
+	}
+
+	if _, err := (WorkflowFuncs{}).AutoLayoutWorkflow(ctx, 1, models.LayoutOptions{Persist: true, LevelGap: 200}); err != nil {
+		t.Fatalf("auto layout failed: %v", err)
+	}
+	if node := client.WorkflowNode.GetX(ctx, 12); node.PositionX != 200 {
+		t.Fatalf("expected persisted position, got %v", node.PositionX)
+	}
+
+	if _, err := (WorkflowFuncs{}).AutoLayoutWorkflow(ctx, 99, models.LayoutOptions{}); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
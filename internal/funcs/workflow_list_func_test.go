@@ -0,0 +1,124 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+func TestGetWorkflowNodesAndEdgesWithPagination(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	ctx := context.Background()
+
+	insertTestRow(t, db, "workflow_applications", map[string]any{"id": 1, "name": "app", "client_secret": "secret", "status": "draft"})
+	insertTestRow(t, db, "workflow_applications", map[string]any{"id": 2, "name": "other", "client_secret": "secret-2", "status": "draft"})
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 1; i <= 5; i++ {
+		insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 10 + i, "application_id": 1, "name": fmt.Sprintf("node-%d", i),
+			"node_key": fmt.Sprintf("n%d", i), "type": "llm_caller", "create_time": base.Add(time.Duration(i) * time.Minute)})
+	}
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 21, "application_id": 2, "name": "foreign", "node_key": "f", "type": "end_node"})
+	for i := 1; i <= 4; i++ {
+		insertTestRow(t, db, "workflow_edges", map[string]any{"id": 30 + i, "application_id": 1, "source_node_id": 10 + i, "target_node_id": 11 + i,
+			"edge_key": fmt.Sprintf("e%d", i), "type": "default", "create_time": base.Add(time.Duration(i) * time.Minute)})
+	}
+
+	nodes, err := WorkflowFuncs{}.GetWorkflowNodesWithPagination(ctx, WorkflowAccessScope{Admin: true}, &models.PageWorkflowNodeRequest{
+		PaginationRequest: models.PaginationRequest{Page: 2, PageSize: 2, OrderBy: "createTime", Order: "desc"},
+		ApplicationID:     "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if nodes.Pagination.Total != 5 || nodes.Pagination.TotalPages != 3 || !nodes.Pagination.HasNext || !nodes.Pagination.HasPrev {
+		t.Fatalf("unexpected pagination: %+v", nodes.Pagination)
+	}
+	if len(nodes.Data) != 2 || nodes.Data[0].ID != "13" || nodes.Data[1].ID != "12" {
+		t.Fatalf("expected nodes 13, 12 on page 2, got %+v", nodes.Data)
+	}
+
+	edges, err := WorkflowFuncs{}.SearchWorkflowEdges(ctx, WorkflowAccessScope{Admin: true}, &models.PageWorkflowEdgeRequest{
+		PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 3, OrderBy: "createTime", Order: "asc"},
+		ApplicationID:     "1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if edges.Pagination.Total != 4 || len(edges.Data) != 3 || edges.Data[0].ID != "31" || edges.Pagination.HasPrev {
+		t.Fatalf("unexpected edges page: %+v %+v", edges.Pagination, edges.Data)
+	}
+
+	if _, err := (WorkflowFuncs{}).SearchWorkflowEdges(ctx, WorkflowAccessScope{Admin: true}, &models.PageWorkflowEdgeRequest{
+		PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 3},
+		SourceNodeID:      "abc",
+	}); err == nil {
+		t.Fatal("expected invalid source node id error")
+	}
+}
+
+func TestSearchWorkflowEdgesFilters(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	ctx := context.Background()
+
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 101, "application_id": 1, "source_node_id": 11, "target_node_id": 12,
+		"edge_key": "b1", "type": "branch", "branch_name": "yes", "create_time": base})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 102, "application_id": 1, "source_node_id": 11, "target_node_id": 12,
+		"edge_key": "b2", "type": "branch", "branch_name": "no", "create_time": base.Add(time.Hour)})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 103, "application_id": 2, "source_node_id": 21, "target_node_id": 22,
+		"edge_key": "b3", "type": "branch", "branch_name": "yes", "create_time": base.Add(2 * time.Hour)})
+
+	search := func(req models.PageWorkflowEdgeRequest) []string {
+		t.Helper()
+		req.PaginationRequest = models.PaginationRequest{Page: 1, PageSize: 10}
+		page, err := WorkflowFuncs{}.SearchWorkflowEdges(ctx, WorkflowAccessScope{Admin: true}, &req)
+		if err != nil {
+			t.Fatalf("search %+v failed: %v", req, err)
+		}
+		ids := make([]string, 0, len(page.Data))
+		for _, edge := range page.Data {
+			ids = append(ids, edge.ID)
+		}
+		return ids
+	}
+
+	if ids := search(models.PageWorkflowEdgeRequest{Type: "branch", BranchName: "yes"}); fmt.Sprint(ids) != "[101 103]" {
+		t.Fatalf("unexpected branch search result: %v", ids)
+	}
+	if ids := search(models.PageWorkflowEdgeRequest{ApplicationID: "1", Type: "branch", BranchName: "yes"}); fmt.Sprint(ids) != "[101]" {
+		t.Fatalf("unexpected application search result: %v", ids)
+	}
+	window := models.PageWorkflowEdgeRequest{Type: "branch", BeginTime: base.Add(30 * time.Minute).Format(time.RFC3339), EndTime: base.Add(3 * time.Hour).Format(time.RFC3339)}
+	if ids := search(window); fmt.Sprint(ids) != "[102 103]" {
+		t.Fatalf("unexpected time window search result: %v", ids)
+	}
+
+	for _, req := range []models.PageWorkflowEdgeRequest{
+		{Type: "unknown"},
+		{BeginTime: "yesterday"},
+		{BeginTime: window.EndTime, EndTime: window.BeginTime},
+	} {
+		req.PaginationRequest = models.PaginationRequest{Page: 1, PageSize: 10}
+		if _, err := (WorkflowFuncs{}).SearchWorkflowEdges(ctx, WorkflowAccessScope{Admin: true}, &req); err == nil {
+			t.Fatalf("expected invalid search %+v to be rejected", req)
+		}
+	}
+
+	// 不分页获取时按创建时间升序返回，未超出上限时不截断
+	edges, truncated, err := WorkflowFuncs{}.GetAllWorkflowEdges(ctx, WorkflowAccessScope{Admin: true})
+	if err != nil || truncated || len(edges) != 5 {
+		t.Fatalf("unexpected edges: %d (truncated=%v, err=%v)", len(edges), truncated, err)
+	}
+}
@@ -0,0 +1,182 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflownode"
+)
+
+// 名称唯一性：配置 workflow.unique_names 为 true 时，应用名称全局唯一，节点名称在所属应用内唯一。
+// 创建和修改时重名返回冲突错误；克隆应用和复制节点时自动添加副本后缀（name (copy)、name (copy 2) ...）。
+// 名称按原样比较（区分大小写），已软删除的应用和节点不参与检查。
+
+// errWorkflowNameConflict 名称与已有的应用或节点重复
+const errWorkflowNameConflict = "workflow name conflict"
+
+// IsWorkflowNameConflict 判断错误是否为名称重复
+func IsWorkflowNameConflict(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errWorkflowNameConflict)
+}
+
+// uniqueWorkflowNames 是否启用名称唯一性，启动时由配置覆盖
+var uniqueWorkflowNames = false
+
+// InitWorkflowNameUniqueness 根据配置启用或关闭名称唯一性检查
+func InitWorkflowNameUniqueness(enabled bool) {
+	uniqueWorkflowNames = enabled
+}
+
+// copySuffixPattern 匹配名称末尾的副本后缀，用于在副本的基础上继续编号
+var copySuffixPattern = regexp.MustCompile(`^(.*) \(copy(?: (\d+))?\)$`)
+
+// copyName 返回不与 taken 重复的副本名称：name (copy)、name (copy 2)、name (copy 3) ...
+// name 本身已带副本后缀时在原名称的基础上继续编号
+func copyName(name string, taken map[string]struct{}) string {
+	base, next := name, 1
+	if match := copySuffixPattern.FindStringSubmatch(name); match != nil {
+		base, next = match[1], 2
+		if match[2] != "" {
+			n, _ := strconv.Atoi(match[2])
+			next = n + 1
+		}
+	}
+	for n := next; ; n++ {
+		candidate := base + " (copy)"
+		if n > 1 {
+			candidate = fmt.Sprintf("%s (copy %d)", base, n)
+		}
+		if _, ok := taken[candidate]; !ok {
+			return candidate
+		}
+	}
+}
+
+// checkApplicationName 检查应用名称是否可用，excludeID 为修改中的应用本身
+func checkApplicationName(ctx context.Context, client *ent.Client, name string, excludeID uint64) error {
+	if !uniqueWorkflowNames || name == "" {
+		return nil
+	}
+	query := client.WorkflowApplication.Query().Where(workflowapplication.NameEQ(name))
+	if excludeID != 0 {
+		query = query.Where(workflowapplication.IDNEQ(excludeID))
+	}
+	exists, err := query.Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check application name: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%s: workflow application %q already exists", errWorkflowNameConflict, name)
+	}
+	return nil
+}
+
+// checkNodeName 检查节点名称在应用内是否可用，excludeID 为修改中的节点本身
+func checkNodeName(ctx context.Context, client *ent.Client, applicationID uint64, name string, excludeID uint64) error {
+	if !uniqueWorkflowNames || name == "" {
+		return nil
+	}
+	query := client.WorkflowNode.Query().
+		Where(workflownode.ApplicationIDEQ(applicationID), workflownode.NameEQ(name))
+	if excludeID != 0 {
+		query = query.Where(workflownode.IDNEQ(excludeID))
+	}
+	exists, err := query.Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check node name: %w", err)
+	}
+	if exists {
+		return fmt.Errorf("%s: node %q already exists in workflow application %d", errWorkflowNameConflict, name, applicationID)
+	}
+	return nil
+}
+
+// checkApplicationNodeNames 检查应用内是否存在重名节点，用于批量保存在事务内完成所有节点操作之后检查
+func checkApplicationNodeNames(ctx context.Context, client *ent.Client, applicationID uint64) error {
+	if !uniqueWorkflowNames {
+		return nil
+	}
+	names, err := queryNodeNames(ctx, client, applicationID)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%s: node %q already exists in workflow application %d", errWorkflowNameConflict, name, applicationID)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
+
+// availableApplicationName 克隆应用时使用的名称：名称未被占用（或未启用唯一性）时原样返回，否则添加副本后缀
+func availableApplicationName(ctx context.Context, client *ent.Client, name string) (string, error) {
+	if !uniqueWorkflowNames {
+		return name, nil
+	}
+	base := name
+	if match := copySuffixPattern.FindStringSubmatch(name); match != nil {
+		base = match[1]
+	}
+	names, err := client.WorkflowApplication.Query().
+		Where(workflowapplication.NameHasPrefix(base)).
+		Select(workflowapplication.FieldName).
+		Strings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to check application name: %w", err)
+	}
+	taken := make(map[string]struct{}, len(names))
+	for _, existing := range names {
+		taken[existing] = struct{}{}
+	}
+	if _, ok := taken[name]; !ok {
+		return name, nil
+	}
+	return copyName(name, taken), nil
+}
+
+// applicationNodeNames 返回应用内已使用的节点名称，未启用唯一性时返回nil
+func applicationNodeNames(ctx context.Context, client *ent.Client, applicationID uint64) (map[string]struct{}, error) {
+	if !uniqueWorkflowNames {
+		return nil, nil
+	}
+	names, err := queryNodeNames(ctx, client, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	taken := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		taken[name] = struct{}{}
+	}
+	return taken, nil
+}
+
+// queryNodeNames 查询应用内所有节点的名称
+func queryNodeNames(ctx context.Context, client *ent.Client, applicationID uint64) ([]string, error) {
+	names, err := client.WorkflowNode.Query().
+		Where(workflownode.ApplicationIDEQ(applicationID)).
+		Select(workflownode.FieldName).
+		Strings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check node names: %w", err)
+	}
+	return names, nil
+}
+
+// claimNodeName 在 taken 中为节点分配名称，重名时添加副本后缀；taken 为nil（未启用唯一性）时原样返回
+func claimNodeName(name string, taken map[string]struct{}) string {
+	if taken == nil {
+		return name
+	}
+	if _, ok := taken[name]; ok {
+		name = copyName(name, taken)
+	}
+	taken[name] = struct{}{}
+	return name
+}
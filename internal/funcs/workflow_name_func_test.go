@@ -0,0 +1,156 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// enableUniqueWorkflowNames 在测试期间启用名称唯一性
+func enableUniqueWorkflowNames(t *testing.T) {
+	t.Helper()
+	previous := uniqueWorkflowNames
+	uniqueWorkflowNames = true
+	t.Cleanup(func() { uniqueWorkflowNames = previous })
+}
+
+func TestCopyName(t *testing.T) {
+	taken := map[string]struct{}{
+		"report":          {},
+		"report (copy)":   {},
+		"report (copy 2)": {},
+	}
+	cases := []struct {
+		name, want string
+	}{
+		{"report", "report (copy 3)"},
+		{"report (copy)", "report (copy 3)"},
+		{"report (copy 5)", "report (copy 6)"},
+		{"summary", "summary (copy)"},
+	}
+	for _, tc := range cases {
+		if got := copyName(tc.name, taken); got != tc.want {
+			t.Fatalf("copyName(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+
+	names := map[string]struct{}{}
+	if got := claimNodeName("llm", names); got != "llm" {
+		t.Fatalf("first claim should keep the name, got %q", got)
+	}
+	if got := claimNodeName("llm", names); got != "llm (copy)" {
+		t.Fatalf("second claim should be suffixed, got %q", got)
+	}
+	if got := claimNodeName("llm", nil); got != "llm" {
+		t.Fatalf("names should be kept when uniqueness is disabled, got %q", got)
+	}
+}
+
+func TestWorkflowNameConflict(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+
+	original := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = original })
+
+	// 未启用时允许重名
+	if err := checkApplicationName(ctx, client, "app-1", 2); err != nil {
+		t.Fatalf("names should not be checked when disabled, got %v", err)
+	}
+
+	enableUniqueWorkflowNames(t)
+
+	_, err := WorkflowFuncs{}.UpdateWorkflowApplication(ctx, 2, &models.UpdateWorkflowApplicationRequest{Name: "app-1"})
+	if !IsWorkflowNameConflict(err) {
+		t.Fatalf("expected application name conflict, got %v", err)
+	}
+	if err := checkApplicationName(ctx, client, "app-1", 1); err != nil {
+		t.Fatalf("keeping an application's own name should not conflict, got %v", err)
+	}
+
+	_, err = WorkflowFuncs{}.CreateWorkflowNode(ctx, &models.CreateWorkflowNodeRequest{ApplicationID: "1", Name: "start", Type: "llm_caller"})
+	if !IsWorkflowNameConflict(err) {
+		t.Fatalf("expected node name conflict, got %v", err)
+	}
+	_, err = WorkflowFuncs{}.UpdateWorkflowNode(ctx, 12, &models.UpdateWorkflowNodeRequest{Name: "start"})
+	if !IsWorkflowNameConflict(err) {
+		t.Fatalf("expected node rename conflict, got %v", err)
+	}
+	// 节点名称只在应用内唯一
+	if err := checkNodeName(ctx, client, 3, "start", 0); err != nil {
+		t.Fatalf("node names in other applications should not conflict, got %v", err)
+	}
+
+	// 批量保存在事务内检查重名
+	if err := checkApplicationNodeNames(ctx, client, 1); err != nil {
+		t.Fatalf("unexpected conflict: %v", err)
+	}
+	client.WorkflowNode.UpdateOneID(12).SetName("start").ExecX(ctx)
+	if err := checkApplicationNodeNames(ctx, client, 1); !IsWorkflowNameConflict(err) {
+		t.Fatalf("expected duplicate node names to be reported, got %v", err)
+	}
+}
+
+func TestCloneWorkflowApplicationAutoSuffix(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+
+	original := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = original })
+	enableUniqueWorkflowNames(t)
+
+	for _, want := range []string{"app-1 (copy)", "app-1 (copy 2)"} {
+		app, err := WorkflowFuncs{}.CloneWorkflowApplication(ctx, 0, 1, "app-1")
+		if err != nil {
+			t.Fatalf("clone failed: %v", err)
+		}
+		if app.Name != want {
+			t.Fatalf("expected clone to be named %q, got %q", want, app.Name)
+		}
+	}
+	app, err := WorkflowFuncs{}.CloneWorkflowApplication(ctx, 0, 1, "app-1 (copy)")
+	if err != nil {
+		t.Fatalf("clone failed: %v", err)
+	}
+	if app.Name != "app-1 (copy 3)" {
+		t.Fatalf("expected clone of a copy to continue numbering, got %q", app.Name)
+	}
+	app, err = WorkflowFuncs{}.CloneWorkflowApplication(ctx, 0, 1, "fresh")
+	if err != nil || app.Name != "fresh" {
+		t.Fatalf("unused clone names should be kept, got %v %v", app, err)
+	}
+	if n := client.WorkflowApplication.Query().Where(workflowapplication.NameEQ("app-1")).CountX(ctx); n != 1 {
+		t.Fatalf("expected a single application named app-1, got %d", n)
+	}
+
+	// 在同一应用内复制节点时副本名称自动添加后缀
+	result, err := WorkflowFuncs{}.DuplicateSubgraph(ctx, 1, []uint64{11, 12}, models.Position{X: 10, Y: 10})
+	if err != nil {
+		t.Fatalf("duplicate failed: %v", err)
+	}
+	if len(result.CreatedNodes) != 2 {
+		t.Fatalf("expected 2 duplicated nodes, got %d", len(result.CreatedNodes))
+	}
+	names := client.WorkflowNode.Query().
+		Where(workflownode.ApplicationIDEQ(1)).
+		Select(workflownode.FieldName).
+		StringsX(ctx)
+	want := map[string]bool{"start": true, "end": true, "start (copy)": true, "end (copy)": true}
+	if len(names) != len(want) {
+		t.Fatalf("unexpected node names: %v", names)
+	}
+	for _, name := range names {
+		if !want[name] {
+			t.Fatalf("unexpected node names: %v", names)
+		}
+	}
+}
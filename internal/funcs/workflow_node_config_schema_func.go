@@ -0,0 +1,325 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// 节点配置校验：每种节点类型在注册表中公布一份 JSON Schema（与执行输入校验使用相同的子集），
+// 创建、更新和批量保存节点时按类型校验节点配置，缺少必填项或类型不符时拒绝保存并返回字段级错误，
+// 避免配置问题到执行时才暴露。请求可通过 skipConfigValidation 跳过校验，用于迁移旧数据
+
+// NodeConfigValidationError 节点配置不符合节点类型的 Schema
+type NodeConfigValidationError struct {
+	Errors []models.InputFieldError
+}
+
+func (e *NodeConfigValidationError) Error() string {
+	return fmt.Sprintf("invalid node config: %d field error(s)", len(e.Errors))
+}
+
+// retryPolicySchema 重试策略的结构，取值范围由 validateNodeRetryPolicy 校验
+func retryPolicySchema() map[string]interface{} {
+	return map[string]interface{}{"type": "object"}
+}
+
+// nodeConfigSchema 返回节点类型的配置 Schema，每次调用返回新的对象；没有需要校验的配置时返回 nil
+func nodeConfigSchema(nodeType workflownode.Type) map[string]interface{} {
+	nonEmptyString := func() map[string]interface{} {
+		return map[string]interface{}{"type": "string", "minLength": 1}
+	}
+
+	switch nodeType {
+	case workflownode.TypeTodoTaskGenerator:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"prompt"},
+			"properties": map[string]interface{}{
+				"prompt": nonEmptyString(),
+				"config": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"model": map[string]interface{}{"type": "string"},
+					},
+				},
+			},
+		}
+	case workflownode.TypeConditionChecker:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"branchNodes"},
+			"properties": map[string]interface{}{
+				"branchNodes": map[string]interface{}{
+					"type":          "object",
+					"minProperties": 1,
+					"additionalProperties": map[string]interface{}{
+						"type": "object",
+						"properties": map[string]interface{}{
+							"name":      map[string]interface{}{"type": "string"},
+							"condition": map[string]interface{}{"type": "string"},
+							"handlerId": map[string]interface{}{"type": "string"},
+						},
+					},
+				},
+			},
+		}
+	case workflownode.TypeAPICaller:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"apiConfig"},
+			"properties": map[string]interface{}{
+				"apiConfig": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"url"},
+					"properties": map[string]interface{}{
+						"url":     nonEmptyString(),
+						"method":  map[string]interface{}{"type": "string", "enum": []interface{}{"GET", "POST", "PUT", "PATCH", "DELETE"}},
+						"headers": map[string]interface{}{"type": "object"},
+					},
+				},
+				"config": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"retry_policy": retryPolicySchema(),
+					},
+				},
+			},
+		}
+	case workflownode.TypeDataProcessor:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"processorLanguage", "processorCode"},
+			"properties": map[string]interface{}{
+				"processorLanguage": map[string]interface{}{"type": "string", "enum": []interface{}{"javascript", "python", "go", "java"}},
+				"processorCode":     nonEmptyString(),
+			},
+		}
+	case workflownode.TypeWhileLoop:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"config"},
+			"properties": map[string]interface{}{
+				"config": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"condition"},
+					"properties": map[string]interface{}{
+						"condition":      nonEmptyString(),
+						"max_iterations": map[string]interface{}{"type": "integer", "minimum": 1},
+					},
+				},
+			},
+		}
+	case workflownode.TypeParallelExecutor:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"parallelConfig"},
+			"properties": map[string]interface{}{
+				"parallelConfig": map[string]interface{}{
+					"type":     "object",
+					"required": []interface{}{"threads"},
+					"properties": map[string]interface{}{
+						"threads": map[string]interface{}{"type": "array", "minItems": 1, "items": map[string]interface{}{"type": "object"}},
+						"mode":    map[string]interface{}{"type": "string", "enum": []interface{}{"all", "any", "race"}},
+						"timeout": map[string]interface{}{"type": "integer", "minimum": 0},
+					},
+				},
+			},
+		}
+	case workflownode.TypeLlmCaller:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"prompt"},
+			"properties": map[string]interface{}{
+				"prompt": nonEmptyString(),
+				"config": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"model":         map[string]interface{}{"type": "string"},
+						"system_prompt": map[string]interface{}{"type": "string"},
+						"temperature":   map[string]interface{}{"type": "number", "minimum": 0, "maximum": 2},
+						"max_tokens":    map[string]interface{}{"type": "integer", "minimum": 1},
+						"retry_policy":  retryPolicySchema(),
+					},
+				},
+			},
+		}
+	case workflownode.TypeWorkflow:
+		return map[string]interface{}{
+			"type":     "object",
+			"required": []interface{}{"workflowApplicationId"},
+			"properties": map[string]interface{}{
+				"workflowApplicationId": nonEmptyString(),
+				"config": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"input_mapping":  map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+						"output_mapping": map[string]interface{}{"type": "object", "additionalProperties": map[string]interface{}{"type": "string"}},
+					},
+				},
+			},
+		}
+	}
+	return nil
+}
+
+// nodeConfigDocument 将节点的各项配置组装为按 Schema 校验的对象，空值视为未配置
+func nodeConfigDocument(node *ent.WorkflowNode) map[string]interface{} {
+	doc := make(map[string]interface{})
+	if node.Prompt != "" {
+		doc["prompt"] = node.Prompt
+	}
+	if node.ProcessorLanguage != "" {
+		doc["processorLanguage"] = node.ProcessorLanguage
+	}
+	if node.ProcessorCode != "" {
+		doc["processorCode"] = node.ProcessorCode
+	}
+	if node.WorkflowApplicationID != 0 {
+		doc["workflowApplicationId"] = utils.Uint64ToString(node.WorkflowApplicationID)
+	}
+	for key, value := range map[string]map[string]interface{}{
+		"config":         node.Config,
+		"branchNodes":    node.BranchNodes,
+		"parallelConfig": node.ParallelConfig,
+		"apiConfig":      node.APIConfig,
+	} {
+		if value != nil {
+			doc[key] = value
+		}
+	}
+	return doc
+}
+
+// validateNodeConfig 按节点类型的 Schema 校验节点配置，不符合时返回 *NodeConfigValidationError
+func validateNodeConfig(node *ent.WorkflowNode) error {
+	errs := nodeConfigErrors(node, "")
+	if len(errs) > 0 {
+		return &NodeConfigValidationError{Errors: errs}
+	}
+	return nil
+}
+
+// nodeConfigErrors 返回节点配置的字段级错误，prefix 非空时加在字段路径前（批量保存中用于区分节点）
+func nodeConfigErrors(node *ent.WorkflowNode, prefix string) []models.InputFieldError {
+	schema := nodeConfigSchema(node.Type)
+	if schema == nil {
+		return nil
+	}
+	errs := validateInputValue(schema, nodeConfigDocument(node), "")
+	if prefix != "" {
+		for i := range errs {
+			errs[i].Field = joinInputPath(prefix, errs[i].Field)
+		}
+	}
+	return errs
+}
+
+// nodeFromCreateRequest 按创建请求构造节点（未保存），用于保存前校验
+func nodeFromCreateRequest(req *models.CreateWorkflowNodeRequest) *ent.WorkflowNode {
+	node := &ent.WorkflowNode{
+		Type:              workflownode.Type(req.Type),
+		Prompt:            req.Prompt,
+		Config:            req.Config,
+		ProcessorLanguage: req.ProcessorLanguage,
+		ProcessorCode:     req.ProcessorCode,
+		BranchNodes:       req.BranchNodes,
+		ParallelConfig:    req.ParallelConfig,
+		APIConfig:         req.APIConfig,
+	}
+	if req.WorkflowApplicationID != "" {
+		node.WorkflowApplicationID = utils.StringToUint64(req.WorkflowApplicationID)
+	}
+	return node
+}
+
+// applyNodeUpdateRequest 返回按更新请求修改后的节点副本（与 UpdateWorkflowNode 只更新提交字段的规则一致）
+func applyNodeUpdateRequest(current *ent.WorkflowNode, req *models.UpdateWorkflowNodeRequest) *ent.WorkflowNode {
+	node := *current
+	if req.Type != "" {
+		node.Type = workflownode.Type(req.Type)
+	}
+	if req.Prompt != "" {
+		node.Prompt = req.Prompt
+	}
+	if req.Config != nil {
+		node.Config = req.Config
+	}
+	if req.ProcessorLanguage != "" {
+		node.ProcessorLanguage = req.ProcessorLanguage
+	}
+	if req.ProcessorCode != "" {
+		node.ProcessorCode = req.ProcessorCode
+	}
+	if req.BranchNodes != nil {
+		node.BranchNodes = req.BranchNodes
+	}
+	if req.ParallelConfig != nil {
+		node.ParallelConfig = req.ParallelConfig
+	}
+	if req.APIConfig != nil {
+		node.APIConfig = req.APIConfig
+	}
+	if req.WorkflowApplicationID != "" {
+		node.WorkflowApplicationID = utils.StringToUint64(req.WorkflowApplicationID)
+	}
+	return &node
+}
+
+// validateBatchSaveNodeConfigs 校验批量保存中新增和修改的节点配置，所有节点的错误一并返回；
+// 字段路径以节点的临时ID或数据库ID开头
+func validateBatchSaveNodeConfigs(ctx context.Context, client *ent.Client, applicationID uint64, req *models.BatchSaveWorkflowRequest) error {
+	if req.SkipConfigValidation {
+		return nil
+	}
+
+	var errs []models.InputFieldError
+	for i := range req.NodesToCreate {
+		nodeReq := &req.NodesToCreate[i]
+		if nodeReq.SkipConfigValidation {
+			continue
+		}
+		errs = append(errs, nodeConfigErrors(nodeFromCreateRequest(nodeReq), req.NodeTempIDs[i])...)
+	}
+
+	updates := make(map[uint64]*models.UpdateWorkflowNodeRequest, len(req.NodesToUpdate))
+	ids := make([]uint64, 0, len(req.NodesToUpdate))
+	for i := range req.NodesToUpdate {
+		nodeUpdate := &req.NodesToUpdate[i]
+		// 无效的ID由保存时的更新操作报告
+		id, err := strconv.ParseUint(nodeUpdate.ID, 10, 64)
+		if err != nil || nodeUpdate.Data.SkipConfigValidation {
+			continue
+		}
+		updates[id] = &nodeUpdate.Data
+		ids = append(ids, id)
+	}
+	if len(ids) > 0 {
+		nodes, err := client.WorkflowNode.Query().
+			Where(workflownode.IDIn(ids...), workflownode.ApplicationIDEQ(applicationID)).
+			All(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to query nodes: %w", err)
+		}
+		current := make(map[uint64]*ent.WorkflowNode, len(nodes))
+		for _, node := range nodes {
+			current[node.ID] = node
+		}
+		// 按请求中的顺序报告，不存在的节点由保存时的更新操作报告
+		for _, id := range ids {
+			if node, ok := current[id]; ok {
+				errs = append(errs, nodeConfigErrors(applyNodeUpdateRequest(node, updates[id]), utils.Uint64ToString(id))...)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return &NodeConfigValidationError{Errors: errs}
+	}
+	return nil
+}
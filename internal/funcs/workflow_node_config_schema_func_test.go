@@ -0,0 +1,165 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// nodeConfigFields 返回校验失败的字段路径，校验通过时返回 nil
+func nodeConfigFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var configErr *NodeConfigValidationError
+	if !errors.As(err, &configErr) {
+		t.Fatalf("expected node config validation error, got %v", err)
+	}
+	fields := make([]string, 0, len(configErr.Errors))
+	for _, fieldErr := range configErr.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	return fields
+}
+
+func TestValidateNodeConfigPerType(t *testing.T) {
+	cases := []struct {
+		name   string
+		req    models.CreateWorkflowNodeRequest
+		fields []string // 期望出错的字段，为空表示配置合法
+	}{
+		{name: "api ok", req: models.CreateWorkflowNodeRequest{Type: "api_caller", APIConfig: map[string]interface{}{"url": "https://example.com", "method": "POST"}}},
+		{name: "api missing config", req: models.CreateWorkflowNodeRequest{Type: "api_caller"}, fields: []string{"apiConfig"}},
+		{name: "api missing url", req: models.CreateWorkflowNodeRequest{Type: "api_caller", APIConfig: map[string]interface{}{"method": "GET"}}, fields: []string{"apiConfig.url"}},
+		{name: "api empty url and bad method", req: models.CreateWorkflowNodeRequest{Type: "api_caller", APIConfig: map[string]interface{}{"url": "", "method": "FETCH"}}, fields: []string{"apiConfig.method", "apiConfig.url"}},
+
+		{name: "condition ok", req: models.CreateWorkflowNodeRequest{Type: "condition_checker", BranchNodes: map[string]interface{}{
+			"yes": map[string]interface{}{"name": "yes", "condition": "{{score}} > 60"},
+		}}},
+		{name: "condition missing branches", req: models.CreateWorkflowNodeRequest{Type: "condition_checker"}, fields: []string{"branchNodes"}},
+		{name: "condition empty branches", req: models.CreateWorkflowNodeRequest{Type: "condition_checker", BranchNodes: map[string]interface{}{}}, fields: []string{"branchNodes"}},
+		{name: "condition bad expression type", req: models.CreateWorkflowNodeRequest{Type: "condition_checker", BranchNodes: map[string]interface{}{
+			"yes": map[string]interface{}{"condition": 1.0},
+		}}, fields: []string{"branchNodes.yes.condition"}},
+
+		{name: "llm ok", req: models.CreateWorkflowNodeRequest{Type: "llm_caller", Prompt: "hi", Config: map[string]interface{}{"temperature": 0.7, "max_tokens": 100.0}}},
+		{name: "llm missing prompt", req: models.CreateWorkflowNodeRequest{Type: "llm_caller", Config: map[string]interface{}{}}, fields: []string{"prompt"}},
+		{name: "llm out of range", req: models.CreateWorkflowNodeRequest{Type: "llm_caller", Prompt: "hi", Config: map[string]interface{}{"temperature": 3.0, "max_tokens": 1.5}}, fields: []string{"config.max_tokens", "config.temperature"}},
+
+		{name: "loop ok", req: models.CreateWorkflowNodeRequest{Type: "while_loop", Config: map[string]interface{}{"condition": "{{i}} < 3", "max_iterations": 5.0}}},
+		{name: "loop missing condition", req: models.CreateWorkflowNodeRequest{Type: "while_loop", Config: map[string]interface{}{"max_iterations": 0.0}}, fields: []string{"config.condition", "config.max_iterations"}},
+
+		{name: "processor ok", req: models.CreateWorkflowNodeRequest{Type: "data_processor", ProcessorLanguage: "javascript", ProcessorCode: "return input"}},
+		{name: "processor bad language and no code", req: models.CreateWorkflowNodeRequest{Type: "data_processor", ProcessorLanguage: "ruby"}, fields: []string{"processorCode", "processorLanguage"}},
+
+		{name: "parallel ok", req: models.CreateWorkflowNodeRequest{Type: "parallel_executor", ParallelConfig: map[string]interface{}{"threads": []interface{}{map[string]interface{}{"id": "a"}}}}},
+		{name: "parallel no threads", req: models.CreateWorkflowNodeRequest{Type: "parallel_executor", ParallelConfig: map[string]interface{}{"threads": []interface{}{}, "mode": "some"}}, fields: []string{"parallelConfig.mode", "parallelConfig.threads"}},
+
+		{name: "workflow ok", req: models.CreateWorkflowNodeRequest{Type: "workflow", WorkflowApplicationID: "2", Config: map[string]interface{}{"input_mapping": map[string]interface{}{"q": "input.q"}}}},
+		{name: "workflow missing target and bad mapping", req: models.CreateWorkflowNodeRequest{Type: "workflow", Config: map[string]interface{}{"output_mapping": map[string]interface{}{"answer": 1.0}}}, fields: []string{"workflowApplicationId", "config.output_mapping.answer"}},
+
+		{name: "task generator missing prompt", req: models.CreateWorkflowNodeRequest{Type: "todo_task_generator"}, fields: []string{"prompt"}},
+		{name: "user input has no schema", req: models.CreateWorkflowNodeRequest{Type: "user_input", Config: map[string]interface{}{"anything": true}}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fields := nodeConfigFields(t, validateNodeConfig(nodeFromCreateRequest(&tc.req)))
+			if strings.Join(fields, ",") != strings.Join(tc.fields, ",") {
+				t.Fatalf("expected errors on %v, got %v", tc.fields, fields)
+			}
+		})
+	}
+}
+
+func TestNodeConfigSchemaPublishedInCatalog(t *testing.T) {
+	catalog, err := WorkflowFuncs{}.GetNodeTypeCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, descriptor := range catalog {
+		schema := descriptor.ConfigSchema
+		switch workflownode.Type(descriptor.Type) {
+		case workflownode.TypeUserInput, workflownode.TypeEndNode:
+			if schema != nil {
+				t.Fatalf("%s should not publish a config schema", descriptor.Type)
+			}
+			continue
+		}
+		if schema == nil {
+			t.Fatalf("%s should publish a config schema", descriptor.Type)
+		}
+		// 公布的 Schema 本身必须合法，且修改返回值不影响后续校验
+		if err := ValidateInputSchema(schema); err != nil {
+			t.Fatalf("%s schema is invalid: %v", descriptor.Type, err)
+		}
+		schema["required"] = []interface{}{}
+	}
+	err = validateNodeConfig(nodeFromCreateRequest(&models.CreateWorkflowNodeRequest{Type: "api_caller"}))
+	if fields := nodeConfigFields(t, err); len(fields) != 1 {
+		t.Fatalf("catalog copies should not affect validation, got %v", fields)
+	}
+}
+
+func TestNodeConfigValidationOnSave(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	ctx := context.Background()
+
+	// 创建：默认严格校验，可按请求跳过
+	req := &models.CreateWorkflowNodeRequest{ApplicationID: "1", Name: "api", Type: "api_caller", Config: map[string]interface{}{}}
+	if fields := nodeConfigFields(t, func() error { _, err := (WorkflowFuncs{}).CreateWorkflowNode(ctx, req); return err }()); len(fields) != 1 || fields[0] != "apiConfig" {
+		t.Fatalf("create should reject missing apiConfig, got %v", fields)
+	}
+	req.SkipConfigValidation = true
+	created, err := WorkflowFuncs{}.CreateWorkflowNode(ctx, req)
+	if err != nil {
+		t.Fatalf("create with validation skipped failed: %v", err)
+	}
+	nodeID := created.ID
+
+	// 更新：按合并后的节点校验，只修改其他字段时同样报告已有的问题
+	_, err = WorkflowFuncs{}.UpdateWorkflowNode(ctx, 11, &models.UpdateWorkflowNodeRequest{Type: "llm_caller"})
+	if fields := nodeConfigFields(t, err); len(fields) != 1 || fields[0] != "prompt" {
+		t.Fatalf("changing type without a prompt should fail, got %v", fields)
+	}
+	if _, err := (WorkflowFuncs{}).UpdateWorkflowNode(ctx, 11, &models.UpdateWorkflowNodeRequest{Type: "llm_caller", Prompt: "hello"}); err != nil {
+		t.Fatalf("valid update failed: %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).UpdateWorkflowNode(ctx, 11, &models.UpdateWorkflowNodeRequest{Config: map[string]interface{}{"temperature": 0.2}}); err != nil {
+		t.Fatalf("partial update should keep the stored prompt, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).PatchWorkflowNodeConfig(ctx, 11, map[string]interface{}{"temperature": 5.0}, false); nodeConfigFields(t, err) == nil {
+		t.Fatal("patch should validate the merged config")
+	}
+
+	// 批量保存：所有节点的错误一并返回，字段路径以临时ID或节点ID开头
+	batch := &models.BatchSaveWorkflowRequest{
+		ApplicationID: "1",
+		NodeTempIDs:   []string{"tmp-1", "tmp-2"},
+		NodesToCreate: []models.CreateWorkflowNodeRequest{
+			{Name: "loop", Type: "while_loop", Config: map[string]interface{}{"condition": "{{i}} < 3"}},
+			{Name: "cond", Type: "condition_checker", Config: map[string]interface{}{}},
+		},
+		NodesToUpdate: []models.UpdateWorkflowNodeWithID{
+			{ID: nodeID, Data: models.UpdateWorkflowNodeRequest{Name: "api renamed"}},
+		},
+	}
+	err = prepareBatchSave(ctx, 1, batch)
+	if fields := nodeConfigFields(t, err); strings.Join(fields, ",") != "tmp-2.branchNodes,"+nodeID+".apiConfig" {
+		t.Fatalf("unexpected batch errors: %v", fields)
+	}
+	batch.SkipConfigValidation = true
+	if err := prepareBatchSave(ctx, 1, batch); err != nil {
+		t.Fatalf("batch with validation skipped should pass, got %v", err)
+	}
+}
@@ -0,0 +1,117 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// nodeExecutionNotExecuted 相连节点在本次执行中没有运行时的状态
+const nodeExecutionNotExecuted = "not_executed"
+
+// loadNodeExecutionContext 加载节点执行的直接上游和下游节点执行，输入输出经过与执行输入相同的脱敏
+func loadNodeExecutionContext(ctx context.Context, nodeExecution *ent.WorkflowNodeExecution) (upstream, downstream []*models.WorkflowNodeExecutionContext, err error) {
+	execution, err := database.Client.WorkflowExecution.Get(ctx, nodeExecution.ExecutionID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, nil, err
+	}
+
+	graph, err := loadExecutionGraph(ctx, execution)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	siblings, err := database.Client.WorkflowNodeExecution.Query().
+		Where(workflownodeexecution.ExecutionIDEQ(execution.ID)).
+		Order(ent.Asc(workflownodeexecution.FieldStartedAt), ent.Asc(workflownodeexecution.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to query node executions: %w", err)
+	}
+
+	redact := func(data map[string]interface{}) map[string]interface{} {
+		return WorkflowFuncs{}.RedactExecutionInput(ctx, execution.ApplicationID, data)
+	}
+	upstream, downstream = buildNodeExecutionContext(graph, nodeExecution, siblings, redact)
+	return upstream, downstream, nil
+}
+
+// buildNodeExecutionContext 根据执行使用的图和按开始时间排序的节点执行记录确定相连节点的执行。
+// 节点被多次执行（循环、重跑）时，上游取本节点开始前最近的一次执行，即为本节点提供输入的那一次；
+// 下游取本节点之后最早的一次执行
+func buildNodeExecutionContext(graph *workflowGraph, nodeExecution *ent.WorkflowNodeExecution, siblings []*ent.WorkflowNodeExecution, redact func(map[string]interface{}) map[string]interface{}) (upstream, downstream []*models.WorkflowNodeExecutionContext) {
+	position := len(siblings)
+	for i, sibling := range siblings {
+		if sibling.ID == nodeExecution.ID {
+			position = i
+			break
+		}
+	}
+
+	upstream = make([]*models.WorkflowNodeExecutionContext, 0, len(graph.incomingEdges[nodeExecution.NodeID]))
+	for _, edge := range graph.incomingEdges[nodeExecution.NodeID] {
+		var source *ent.WorkflowNodeExecution
+		for i := position - 1; i >= 0; i-- {
+			if siblings[i].NodeID == edge.SourceNodeID {
+				source = siblings[i]
+				break
+			}
+		}
+		entry := newNodeExecutionContext(graph, edge, edge.SourceNodeID, source)
+		if source != nil {
+			entry.Output = redact(source.Output)
+		}
+		upstream = append(upstream, entry)
+	}
+
+	downstream = make([]*models.WorkflowNodeExecutionContext, 0, len(graph.outgoingEdges[nodeExecution.NodeID]))
+	for _, edge := range graph.outgoingEdges[nodeExecution.NodeID] {
+		var target *ent.WorkflowNodeExecution
+		for i := position + 1; i < len(siblings); i++ {
+			if siblings[i].NodeID == edge.TargetNodeID {
+				target = siblings[i]
+				break
+			}
+		}
+		entry := newNodeExecutionContext(graph, edge, edge.TargetNodeID, target)
+		if target != nil {
+			entry.Input = redact(target.Input)
+		}
+		downstream = append(downstream, entry)
+	}
+
+	return upstream, downstream
+}
+
+// newNodeExecutionContext 构建相连节点的基本信息，不包含输入输出
+func newNodeExecutionContext(graph *workflowGraph, edge *ent.WorkflowEdge, nodeID uint64, nodeExecution *ent.WorkflowNodeExecution) *models.WorkflowNodeExecutionContext {
+	node := graph.nodes[nodeID]
+	entry := &models.WorkflowNodeExecutionContext{
+		EdgeID:     utils.Uint64ToString(edge.ID),
+		BranchName: edge.BranchName,
+		NodeID:     utils.Uint64ToString(nodeID),
+		NodeName:   node.Name,
+		NodeType:   node.Type.String(),
+		Status:     nodeExecutionNotExecuted,
+	}
+	if nodeExecution == nil {
+		return entry
+	}
+
+	entry.NodeExecutionID = utils.Uint64ToString(nodeExecution.ID)
+	entry.Status = string(nodeExecution.Status)
+	entry.ErrorMessage = nodeExecution.ErrorMessage
+	if !nodeExecution.FinishedAt.IsZero() {
+		finishedAt := nodeExecution.FinishedAt
+		entry.FinishedAt = &finishedAt
+	}
+	return entry
+}
@@ -0,0 +1,67 @@
+package funcs
+
+import (
+	"testing"
+
+	"go-backend/database/ent/workflownodeexecution"
+)
+
+func TestBuildNodeExecutionContext(t *testing.T) {
+	graph := executionPathTestGraph()
+	// 条件节点2执行了两次（重跑），节点3读取的是第二次的输出
+	executions := pathTestNodeExecutions(nil, 1, 2, 2, 3, 5)
+	executions[1].Output = map[string]interface{}{"branch": "else"}
+	executions[2].Output = map[string]interface{}{"branch": "high", "token": "plain-token", "note": "uses sk-secret"}
+	executions[3].Status = workflownodeexecution.StatusFailed
+	executions[3].ErrorMessage = "bad value"
+	executions[4].Input = map[string]interface{}{"password": "p@ss", "value": 1}
+
+	secrets := map[string]string{"API_KEY": "sk-secret"}
+	redact := func(data map[string]interface{}) map[string]interface{} {
+		return redactExecutionInput(data, []string{"token", "password"}, secrets)
+	}
+
+	upstream, downstream := buildNodeExecutionContext(graph, executions[3], executions, redact)
+	if len(upstream) != 1 || upstream[0].NodeID != "2" || upstream[0].EdgeID != "12" || upstream[0].BranchName != "high" {
+		t.Fatalf("unexpected upstream: %+v", upstream)
+	}
+	if upstream[0].NodeExecutionID != "1002" || upstream[0].Output["branch"] != "high" {
+		t.Fatalf("expected latest upstream execution, got %+v", upstream[0])
+	}
+	if upstream[0].Output["token"] == "plain-token" || upstream[0].Output["note"] != "uses "+secretMask {
+		t.Fatalf("upstream output not redacted: %v", upstream[0].Output)
+	}
+	if executions[2].Output["token"] != "plain-token" {
+		t.Fatal("redaction must not modify the stored output")
+	}
+
+	if len(downstream) != 1 || downstream[0].NodeID != "5" || downstream[0].NodeExecutionID != "1004" {
+		t.Fatalf("unexpected downstream: %+v", downstream)
+	}
+	if downstream[0].Input["password"] == "p@ss" || downstream[0].Input["value"] != 1 {
+		t.Fatalf("downstream input not redacted: %v", downstream[0].Input)
+	}
+
+	// 条件节点的两个分支中，未运行的节点4标记为 not_executed
+	upstream, downstream = buildNodeExecutionContext(graph, executions[2], executions, redact)
+	if len(upstream) != 1 || upstream[0].NodeExecutionID != "1000" {
+		t.Fatalf("unexpected upstream of condition: %+v", upstream)
+	}
+	if len(downstream) != 2 {
+		t.Fatalf("unexpected downstream of condition: %+v", downstream)
+	}
+	for _, entry := range downstream {
+		switch entry.NodeID {
+		case "3":
+			if entry.Status != string(workflownodeexecution.StatusFailed) || entry.ErrorMessage != "bad value" {
+				t.Fatalf("unexpected executed branch: %+v", entry)
+			}
+		case "4":
+			if entry.Status != nodeExecutionNotExecuted || entry.NodeExecutionID != "" || entry.Input != nil {
+				t.Fatalf("unexpected skipped branch: %+v", entry)
+			}
+		default:
+			t.Fatalf("unexpected downstream node: %+v", entry)
+		}
+	}
+}
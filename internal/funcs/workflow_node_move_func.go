@@ -0,0 +1,151 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// errWorkflowNodeNotMovable 节点不满足移动到其他应用的条件
+const errWorkflowNodeNotMovable = "workflow node cannot be moved"
+
+// IsWorkflowNodeNotMovable 判断错误是否为节点不能移动
+func IsWorkflowNodeNotMovable(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errWorkflowNodeNotMovable)
+}
+
+// MoveNodeToApplication 将节点移动到其他工作流应用（用于纠正建错应用的节点）。
+// 边必须在应用内部，移动后另一端不在目标应用中的边会被删除，另一端已在目标应用中的边随节点归属目标应用；
+// 节点是原应用的开始节点时拒绝移动，需先为原应用设置其他开始节点
+func (WorkflowFuncs) MoveNodeToApplication(ctx context.Context, nodeID, targetApplicationID uint64) (*models.MoveWorkflowNodeResponse, error) {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	removed, err := moveNodeToApplication(ctx, tx, nodeID, targetApplicationID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	node, err := WorkflowFuncs{}.GetWorkflowNodeByID(ctx, nodeID)
+	if err != nil {
+		return nil, err
+	}
+	removedIDs := make([]string, 0, len(removed))
+	for _, id := range removed {
+		removedIDs = append(removedIDs, utils.Uint64ToString(id))
+	}
+	return &models.MoveWorkflowNodeResponse{Node: node, RemovedEdgeIDs: removedIDs}, nil
+}
+
+// moveNodeToApplication 在事务内移动节点并清理跨应用的边，返回被删除的边ID
+func moveNodeToApplication(ctx context.Context, tx *ent.Tx, nodeID, targetApplicationID uint64) ([]uint64, error) {
+	node, err := tx.WorkflowNode.Get(ctx, nodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node not found")
+		}
+		return nil, err
+	}
+	if node.ApplicationID == targetApplicationID {
+		return nil, nil
+	}
+
+	exists, err := tx.WorkflowApplication.Query().
+		Where(workflowapplication.ID(targetApplicationID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%s: target application %d not found", errWorkflowNodeNotMovable, targetApplicationID)
+	}
+
+	isStart, err := tx.WorkflowApplication.Query().
+		Where(workflowapplication.ID(node.ApplicationID), workflowapplication.StartNodeIDEQ(nodeID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if isStart {
+		return nil, fmt.Errorf("%s: node %d is the start node of workflow application %d, set another start node first",
+			errWorkflowNodeNotMovable, nodeID, node.ApplicationID)
+	}
+
+	if err := checkNodeName(ctx, tx.Client(), targetApplicationID, node.Name, nodeID); err != nil {
+		return nil, err
+	}
+
+	edges, err := tx.WorkflowEdge.Query().
+		Where(workflowedge.Or(workflowedge.SourceNodeIDEQ(nodeID), workflowedge.TargetNodeIDEQ(nodeID))).
+		Order(ent.Asc(workflowedge.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node edges: %w", err)
+	}
+
+	// 另一端已在目标应用中的边保留并改为归属目标应用，其余边会跨应用，需要删除
+	otherIDs := make([]uint64, 0, len(edges))
+	for _, edge := range edges {
+		otherIDs = append(otherIDs, otherEdgeEndpoint(edge, nodeID))
+	}
+	inTarget, err := tx.WorkflowNode.Query().
+		Where(workflownode.IDIn(otherIDs...), workflownode.ApplicationIDEQ(targetApplicationID)).
+		IDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query edge endpoints: %w", err)
+	}
+	keep := make(map[uint64]bool, len(inTarget))
+	for _, id := range inTarget {
+		keep[id] = true
+	}
+
+	var removed, kept []uint64
+	for _, edge := range edges {
+		if keep[otherEdgeEndpoint(edge, nodeID)] {
+			kept = append(kept, edge.ID)
+		} else {
+			removed = append(removed, edge.ID)
+		}
+	}
+	if len(removed) > 0 {
+		if _, err := tx.WorkflowEdge.Delete().Where(workflowedge.IDIn(removed...)).Exec(ctx); err != nil {
+			return nil, fmt.Errorf("failed to delete cross-application edges: %w", err)
+		}
+	}
+	if len(kept) > 0 {
+		err := tx.WorkflowEdge.Update().
+			Where(workflowedge.IDIn(kept...)).
+			SetApplicationID(targetApplicationID).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to update edges: %w", err)
+		}
+	}
+
+	if err := tx.WorkflowNode.UpdateOneID(nodeID).SetApplicationID(targetApplicationID).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return removed, nil
+}
+
+// otherEdgeEndpoint 返回边上不是 nodeID 的一端
+func otherEdgeEndpoint(edge *ent.WorkflowEdge, nodeID uint64) uint64 {
+	if edge.SourceNodeID == nodeID {
+		return edge.TargetNodeID
+	}
+	return edge.SourceNodeID
+}
@@ -0,0 +1,72 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-backend/pkg/database"
+)
+
+func TestMoveNodeToApplication(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 14, "application_id": 1, "name": "process", "node_key": "process", "type": "data_processor"})
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 24, "application_id": 2, "name": "notify", "node_key": "notify", "type": "api_caller"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 15, "application_id": 1, "source_node_id": 11, "target_node_id": 14, "edge_key": "e15", "type": "default"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 16, "application_id": 1, "source_node_id": 14, "target_node_id": 12, "edge_key": "e16", "type": "default"})
+	// 历史数据中已经跨应用的边：移动后两端都在应用2中，应保留并归属应用2
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 17, "application_id": 1, "source_node_id": 14, "target_node_id": 24, "edge_key": "e17", "type": "default"})
+	client.WorkflowApplication.UpdateOneID(1).SetStartNodeID(11).ExecX(context.Background())
+	ctx := context.Background()
+
+	result, err := WorkflowFuncs{}.MoveNodeToApplication(ctx, 14, 2)
+	if err != nil {
+		t.Fatalf("move failed: %v", err)
+	}
+	if result.Node.ApplicationID != "2" {
+		t.Fatalf("node should belong to application 2, got %s", result.Node.ApplicationID)
+	}
+	if strings.Join(result.RemovedEdgeIDs, ",") != "15,16" {
+		t.Fatalf("edges to application 1 nodes should be removed, got %v", result.RemovedEdgeIDs)
+	}
+	if n := client.WorkflowEdge.Query().CountX(ctx); n != 3 {
+		t.Fatalf("expected 3 remaining edges, got %d", n)
+	}
+	if edge := client.WorkflowEdge.GetX(ctx, 17); edge.ApplicationID != 2 {
+		t.Fatalf("intra-application edge should follow the node, got application %d", edge.ApplicationID)
+	}
+	if edge := client.WorkflowEdge.GetX(ctx, 13); edge.ApplicationID != 1 {
+		t.Fatalf("unrelated edge should be untouched, got application %d", edge.ApplicationID)
+	}
+
+	// 移动到当前所在应用不做任何修改
+	result, err = WorkflowFuncs{}.MoveNodeToApplication(ctx, 14, 2)
+	if err != nil || len(result.RemovedEdgeIDs) != 0 {
+		t.Fatalf("moving to the same application should be a no-op: %+v, %v", result, err)
+	}
+
+	// 原应用的开始节点不能移动
+	_, err = WorkflowFuncs{}.MoveNodeToApplication(ctx, 11, 2)
+	if !IsWorkflowNodeNotMovable(err) || !strings.Contains(err.Error(), "start node") {
+		t.Fatalf("expected start node guard, got %v", err)
+	}
+	if node := client.WorkflowNode.GetX(ctx, 11); node.ApplicationID != 1 {
+		t.Fatalf("start node should stay in application 1, got %d", node.ApplicationID)
+	}
+	if n := client.WorkflowEdge.Query().CountX(ctx); n != 3 {
+		t.Fatalf("rejected move should not remove edges, got %d edges", n)
+	}
+
+	if _, err := (WorkflowFuncs{}).MoveNodeToApplication(ctx, 12, 99); !IsWorkflowNodeNotMovable(err) {
+		t.Fatalf("expected missing target error, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).MoveNodeToApplication(ctx, 999, 2); err == nil || err.Error() != "workflow node not found" {
+		t.Fatalf("expected node not found, got %v", err)
+	}
+}
@@ -0,0 +1,515 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/openai"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	goopenai "github.com/sashabaranov/go-openai"
+)
+
+// errNodeTestUnsupported 节点类型无法单独试运行
+const errNodeTestUnsupported = "node test unsupported"
+
+// 单节点试运行的结果状态
+const (
+	NodeTestStatusCompleted = "completed"
+	NodeTestStatusFailed    = "failed"
+	NodeTestStatusTimeout   = "timeout"
+)
+
+// nodeTestDefaultTimeout 节点未配置超时时间时的试运行超时
+const nodeTestDefaultTimeout = 30 * time.Second
+
+// nodeTestMaxResponseBytes API节点试运行时读取的响应体上限
+const nodeTestMaxResponseBytes = 1 << 20
+
+// nodeTestRun 单个节点的运行结果
+type nodeTestRun struct {
+	input    map[string]interface{} // 实际使用的输入（如解析后的提示词、请求）
+	output   map[string]interface{}
+	model    string
+	tokens   *models.NodeTestTokenUsage
+	attempts []models.NodeAttempt // 按重试策略重试时的失败尝试
+}
+
+// TestNode 使用手动提供的输入单独运行一个节点，返回输出、解析后的输入、耗时和 token/成本信息，不写入任何执行记录
+// 节点运行失败或超时不会返回 error，而是体现在结果的 Status/Error 中；节点类型不支持试运行时返回 error
+func (WorkflowFuncs) TestNode(ctx context.Context, nodeID uint64, input map[string]interface{}) (*models.NodeTestResult, error) {
+	node, err := database.Client.WorkflowNode.Get(ctx, nodeID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow node not found")
+		}
+		return nil, err
+	}
+	if err := checkNodeTestable(node); err != nil {
+		return nil, err
+	}
+
+	secrets, err := loadApplicationSecrets(ctx, node.ApplicationID)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := resolveNodeSecrets(node, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	config := configs.GetConfig()
+	redactedKeys := append(utils.SensitiveKeys(), config.Workflow.RedactedKeys...)
+	result := executeNodeTest(ctx, resolved, input, redactedKeys, &config.Workflow.CostEstimate)
+	maskNodeTestResult(result, secrets)
+	return result, nil
+}
+
+// resolveNodeSecrets 返回替换了 ${secrets.KEY} 引用的节点副本，原节点不会被修改
+func resolveNodeSecrets(node *ent.WorkflowNode, secrets map[string]string) (*ent.WorkflowNode, error) {
+	resolved := *node
+	prompt, err := resolveSecretReferences(node.Prompt, secrets)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Prompt = prompt.(string)
+	if node.Config != nil {
+		config, err := resolveSecretReferences(node.Config, secrets)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Config = config.(map[string]interface{})
+	}
+	if node.APIConfig != nil {
+		apiConfig, err := resolveSecretReferences(node.APIConfig, secrets)
+		if err != nil {
+			return nil, err
+		}
+		resolved.APIConfig = apiConfig.(map[string]interface{})
+	}
+	return &resolved, nil
+}
+
+// maskNodeTestResult 屏蔽试运行结果中出现的密钥明文
+func maskNodeTestResult(result *models.NodeTestResult, secrets map[string]string) {
+	if len(secrets) == 0 {
+		return
+	}
+	if input, ok := maskSecretValues(result.Input, secrets).(map[string]interface{}); ok {
+		result.Input = input
+	}
+	if output, ok := maskSecretValues(result.Output, secrets).(map[string]interface{}); ok {
+		result.Output = output
+	}
+	result.Error = maskSecretString(result.Error, secrets)
+	for i := range result.Attempts {
+		result.Attempts[i].Error = maskSecretString(result.Attempts[i].Error, secrets)
+	}
+}
+
+// checkNodeTestable 检查节点类型是否可以脱离工作流单独运行
+// 循环、并行和子工作流节点依赖图中的其他节点；处理器代码需要外部执行引擎的语言运行时
+func checkNodeTestable(node *ent.WorkflowNode) error {
+	switch node.Type {
+	case workflownode.TypeLlmCaller, workflownode.TypeAPICaller, workflownode.TypeConditionChecker,
+		workflownode.TypeUserInput, workflownode.TypeEndNode:
+		return nil
+	case workflownode.TypeDataProcessor:
+		return fmt.Errorf("%s: processor language %q is not supported by the in-process runtime", errNodeTestUnsupported, node.ProcessorLanguage)
+	}
+	return fmt.Errorf("%s: node type %s depends on other nodes and cannot run alone", errNodeTestUnsupported, node.Type)
+}
+
+// executeNodeTest 在节点超时时间内运行节点并组装试运行结果
+func executeNodeTest(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}, redactedKeys []string, costSettings *configs.CostEstimateConfig) *models.NodeTestResult {
+	if input == nil {
+		input = map[string]interface{}{}
+	}
+
+	timeout := time.Duration(node.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = nodeTestDefaultTimeout
+	}
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	run, err := runNodeTest(runCtx, node, input)
+	duration := time.Since(start)
+
+	result := &models.NodeTestResult{
+		NodeID:     utils.Uint64ToString(node.ID),
+		NodeName:   node.Name,
+		NodeType:   string(node.Type),
+		Status:     NodeTestStatusCompleted,
+		DurationMs: duration.Milliseconds(),
+		Currency:   costSettings.Currency,
+	}
+
+	resolvedInput := input
+	if run != nil {
+		resolvedInput = run.input
+		result.Output = run.output
+		result.Model = run.model
+		result.Tokens = run.tokens
+		result.Attempts = run.attempts
+		if run.tokens != nil {
+			result.Cost = nodeTestCost(run.model, run.tokens, costSettings)
+		}
+	}
+	if redacted, ok := utils.Redact(resolvedInput, redactedKeys).(map[string]interface{}); ok {
+		result.Input = redacted
+	}
+
+	if err != nil {
+		result.Status = NodeTestStatusFailed
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			result.Status = NodeTestStatusTimeout
+			err = fmt.Errorf("node timed out after %v: %w", timeout, err)
+		}
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// runNodeTest 按节点类型运行节点，LLM 和 API 节点按节点的重试策略重试
+func runNodeTest(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (*nodeTestRun, error) {
+	switch node.Type {
+	case workflownode.TypeLlmCaller:
+		return runNodeTestWithRetry(ctx, node, input, runLLMNodeTest)
+	case workflownode.TypeAPICaller:
+		return runNodeTestWithRetry(ctx, node, input, runAPINodeTest)
+	case workflownode.TypeConditionChecker:
+		return runConditionNodeTest(node, input)
+	case workflownode.TypeUserInput, workflownode.TypeEndNode:
+		// 输入和结束节点原样透传输入
+		return &nodeTestRun{input: input, output: input}, nil
+	}
+	return nil, checkNodeTestable(node)
+}
+
+// runNodeTestWithRetry 按重试策略运行节点，失败尝试记录在返回的运行结果中
+func runNodeTestWithRetry(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{},
+	runner func(context.Context, *ent.WorkflowNode, map[string]interface{}) (*nodeTestRun, error)) (*nodeTestRun, error) {
+	run, attempts, err := runWithRetry(ctx, nodeRetryPolicy(node), func(ctx context.Context) (*nodeTestRun, error) {
+		return runner(ctx, node, input)
+	})
+	if len(attempts) > 0 {
+		if run == nil {
+			run = &nodeTestRun{input: input}
+		}
+		run.attempts = attempts
+	}
+	return run, err
+}
+
+// runLLMNodeTest 解析提示词占位符后调用 LLM
+func runLLMNodeTest(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (*nodeTestRun, error) {
+	prompt := resolvePromptTemplate(node.Prompt, input)
+	systemPrompt, _ := node.Config["system_prompt"].(string)
+	systemPrompt = resolvePromptTemplate(systemPrompt, input)
+
+	req := openai.ChatRequest{}
+	req.Model, _ = node.Config["model"].(string)
+	if maxTokens, ok := configInt(node.Config, "max_tokens"); ok {
+		req.MaxTokens = maxTokens
+	}
+	if temperature, ok := node.Config["temperature"].(float64); ok {
+		req.Temperature = float32(temperature)
+	}
+	if systemPrompt != "" {
+		req.Messages = append(req.Messages, goopenai.ChatCompletionMessage{Role: goopenai.ChatMessageRoleSystem, Content: systemPrompt})
+	}
+	req.Messages = append(req.Messages, goopenai.ChatCompletionMessage{Role: goopenai.ChatMessageRoleUser, Content: prompt})
+
+	run := &nodeTestRun{
+		input: map[string]interface{}{"prompt": prompt, "systemPrompt": systemPrompt, "variables": input},
+		model: req.Model,
+	}
+	if run.model == "" {
+		if config := openai.GetConfig(); config != nil {
+			run.model = config.Model
+		}
+	}
+
+	resp, err := openai.CreateChatCompletion(ctx, req)
+	if err != nil {
+		return run, err
+	}
+	run.output = map[string]interface{}{"content": resp.Content}
+	run.tokens = &models.NodeTestTokenUsage{
+		PromptTokens:     resp.Usage.PromptTokens,
+		CompletionTokens: resp.Usage.CompletionTokens,
+		TotalTokens:      resp.Usage.TotalTokens,
+	}
+	return run, nil
+}
+
+// runAPINodeTest 按 api_config（url、method、headers、body）发起请求，字符串中的 {{key}} 占位符使用输入解析
+func runAPINodeTest(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (*nodeTestRun, error) {
+	apiConfig := node.APIConfig
+	url, _ := apiConfig["url"].(string)
+	url = resolvePromptTemplate(strings.TrimSpace(url), input)
+	method, _ := apiConfig["method"].(string)
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	headers := make(map[string]interface{})
+	if rawHeaders, ok := apiConfig["headers"].(map[string]interface{}); ok {
+		for key, value := range rawHeaders {
+			headers[key] = resolveTemplateValue(value, input)
+		}
+	}
+	body := resolveTemplateValue(apiConfig["body"], input)
+
+	run := &nodeTestRun{input: map[string]interface{}{
+		"url":     url,
+		"method":  method,
+		"headers": headers,
+		"body":    body,
+	}}
+	if url == "" {
+		return run, fmt.Errorf("api_config.url is required")
+	}
+
+	var reader io.Reader
+	if body != nil {
+		if s, ok := body.(string); ok {
+			reader = strings.NewReader(s)
+		} else {
+			encoded, err := json.Marshal(body)
+			if err != nil {
+				return run, fmt.Errorf("failed to encode request body: %w", err)
+			}
+			reader = bytes.NewReader(encoded)
+			if _, ok := headers["Content-Type"]; !ok {
+				headers["Content-Type"] = "application/json"
+			}
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return run, err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, fmt.Sprint(value))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return run, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, nodeTestMaxResponseBytes))
+	if err != nil {
+		return run, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	var responseBody interface{} = string(data)
+	var decoded interface{}
+	if json.Unmarshal(data, &decoded) == nil {
+		responseBody = decoded
+	}
+	run.output = map[string]interface{}{
+		"statusCode": resp.StatusCode,
+		"body":       responseBody,
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return run, &apiStatusError{StatusCode: resp.StatusCode}
+	}
+	return run, nil
+}
+
+// resolveTemplateValue 递归解析值中所有字符串的 {{key}} 占位符
+func resolveTemplateValue(value interface{}, vars map[string]interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return resolvePromptTemplate(v, vars)
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved[key] = resolveTemplateValue(item, vars)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			resolved[i] = resolveTemplateValue(item, vars)
+		}
+		return resolved
+	}
+	return value
+}
+
+// runConditionNodeTest 按分支名称顺序计算 branch_nodes 中各分支的条件，返回第一个成立的分支，都不成立时返回默认分支
+func runConditionNodeTest(node *ent.WorkflowNode, input map[string]interface{}) (*nodeTestRun, error) {
+	names := make([]string, 0, len(node.BranchNodes))
+	for name := range node.BranchNodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	evaluated := make(map[string]interface{}, len(names))
+	run := &nodeTestRun{input: input}
+	for _, name := range names {
+		branch, ok := node.BranchNodes[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		branchName := name
+		if n, ok := branch["name"].(string); ok && strings.TrimSpace(n) != "" {
+			branchName = n
+		}
+		condition, _ := branch["condition"].(string)
+		if IsDefaultConditionBranch(branchName) || strings.TrimSpace(condition) == "" {
+			continue
+		}
+
+		matched, err := evaluateTestCondition(condition, input)
+		if err != nil {
+			return run, fmt.Errorf("branch %s: %w", branchName, err)
+		}
+		evaluated[branchName] = matched
+		if matched {
+			run.output = map[string]interface{}{"branch": branchName, "evaluated": evaluated}
+			return run, nil
+		}
+	}
+
+	run.output = map[string]interface{}{"branch": ConditionBranchDefault, "evaluated": evaluated}
+	return run, nil
+}
+
+// conditionOperators 条件表达式支持的比较运算符，两字符运算符需要先于单字符匹配
+var conditionOperators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// evaluateTestCondition 计算形如 `input.score >= 60` 的简单比较表达式，左侧为变量路径，
+// 右侧为JSON字面量或变量路径；没有运算符时按变量值（或字面量）的真假判断
+func evaluateTestCondition(expr string, vars map[string]interface{}) (bool, error) {
+	expr = strings.TrimSpace(expr)
+	if left, op, right := splitCondition(expr); op != "" {
+		return compareConditionOperands(conditionOperand(left, vars), conditionOperand(right, vars), op)
+	}
+	if value, ok := lookupTemplateValue(vars, strings.Split(expr, ".")); ok {
+		return isTruthy(value), nil
+	}
+	var literal interface{}
+	if json.Unmarshal([]byte(expr), &literal) == nil {
+		return isTruthy(literal), nil
+	}
+	// 变量不存在时视为不成立
+	return false, nil
+}
+
+// splitCondition 按第一个匹配的运算符拆分条件表达式，没有运算符时 op 为空
+func splitCondition(expr string) (left, op, right string) {
+	for _, candidate := range conditionOperators {
+		if idx := strings.Index(expr, candidate); idx >= 0 {
+			return strings.TrimSpace(expr[:idx]), candidate, strings.TrimSpace(expr[idx+len(candidate):])
+		}
+	}
+	return expr, "", ""
+}
+
+// conditionOperand 解析操作数：先按JSON字面量解析，否则按变量路径查找，都失败时视为字符串
+func conditionOperand(token string, vars map[string]interface{}) interface{} {
+	var literal interface{}
+	if json.Unmarshal([]byte(token), &literal) == nil {
+		return literal
+	}
+	if value, ok := lookupTemplateValue(vars, strings.Split(token, ".")); ok {
+		return value
+	}
+	return token
+}
+
+// compareConditionOperands 比较两个操作数，两侧都能转为数字时按数值比较，否则 ==/!= 按值比较，其余运算符报错
+func compareConditionOperands(left, right interface{}, op string) (bool, error) {
+	l, lok := conditionNumber(left)
+	r, rok := conditionNumber(right)
+	if lok && rok {
+		switch op {
+		case "==":
+			return l == r, nil
+		case "!=":
+			return l != r, nil
+		case ">":
+			return l > r, nil
+		case ">=":
+			return l >= r, nil
+		case "<":
+			return l < r, nil
+		case "<=":
+			return l <= r, nil
+		}
+	}
+
+	switch op {
+	case "==":
+		return fmt.Sprint(left) == fmt.Sprint(right), nil
+	case "!=":
+		return fmt.Sprint(left) != fmt.Sprint(right), nil
+	}
+	return false, fmt.Errorf("operator %s requires numeric operands, got %v and %v", op, left, right)
+}
+
+func conditionNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case string:
+		n, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != "" && !strings.EqualFold(v, "false")
+	case []interface{}:
+		return len(v) > 0
+	case map[string]interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// nodeTestCost 按价格表计算实际 token 用量的成本，模型未配置价格时为0
+func nodeTestCost(model string, tokens *models.NodeTestTokenUsage, settings *configs.CostEstimateConfig) float64 {
+	if model == "" {
+		model = settings.DefaultModel
+	}
+	pricing, ok := settings.Pricing[strings.ToLower(model)]
+	if !ok {
+		return 0
+	}
+	return roundCost((float64(tokens.PromptTokens)*pricing.Input + float64(tokens.CompletionTokens)*pricing.Output) / 1000)
+}
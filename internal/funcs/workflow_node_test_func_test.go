@@ -0,0 +1,165 @@
+package funcs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+)
+
+func TestExecuteNodeTestAPICaller(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/users/42" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			t.Errorf("authorization header not resolved: %q", r.Header.Get("Authorization"))
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"echo": body["name"]})
+	}))
+	defer server.Close()
+
+	node := &ent.WorkflowNode{
+		ID:      1,
+		Name:    "call",
+		Type:    workflownode.TypeAPICaller,
+		Timeout: 5,
+		APIConfig: map[string]interface{}{
+			"url":     server.URL + "/users/{{input.id}}",
+			"method":  "post",
+			"headers": map[string]interface{}{"Authorization": "Bearer {{token}}"},
+			"body":    map[string]interface{}{"name": "{{name}}"},
+		},
+	}
+	input := map[string]interface{}{"id": 42, "name": "alice", "token": "s3cret"}
+
+	result := executeNodeTest(context.Background(), node, input, []string{"authorization"}, &configs.CostEstimateConfig{})
+	if result.Status != NodeTestStatusCompleted {
+		t.Fatalf("status = %s, error = %s", result.Status, result.Error)
+	}
+	if result.Output["statusCode"] != http.StatusOK {
+		t.Fatalf("unexpected output: %v", result.Output)
+	}
+	if body, _ := result.Output["body"].(map[string]interface{}); body["echo"] != "alice" {
+		t.Fatalf("unexpected response body: %v", result.Output["body"])
+	}
+
+	headers, _ := result.Input["headers"].(map[string]interface{})
+	if headers["Authorization"] != "******" {
+		t.Fatalf("echoed input should be redacted, got %v", result.Input)
+	}
+	if result.Input["url"] != server.URL+"/users/42" {
+		t.Fatalf("resolved url not echoed: %v", result.Input["url"])
+	}
+}
+
+func TestExecuteNodeTestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	node := &ent.WorkflowNode{
+		Type:      workflownode.TypeAPICaller,
+		Timeout:   1,
+		APIConfig: map[string]interface{}{"url": server.URL},
+	}
+
+	start := time.Now()
+	result := executeNodeTest(context.Background(), node, nil, nil, &configs.CostEstimateConfig{})
+	if result.Status != NodeTestStatusTimeout {
+		t.Fatalf("status = %s, error = %s", result.Status, result.Error)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("node timeout not respected, took %v", elapsed)
+	}
+}
+
+func TestExecuteNodeTestConditionChecker(t *testing.T) {
+	node := &ent.WorkflowNode{
+		Type: workflownode.TypeConditionChecker,
+		BranchNodes: map[string]interface{}{
+			"a_high":  map[string]interface{}{"name": "high", "condition": "input.score >= 80"},
+			"b_pass":  map[string]interface{}{"name": "pass", "condition": "score >= 60"},
+			"default": map[string]interface{}{"name": "default"},
+		},
+	}
+
+	cases := []struct {
+		score  float64
+		branch string
+	}{
+		{90, "high"},
+		{70, "pass"},
+		{10, ConditionBranchDefault},
+	}
+	for _, tc := range cases {
+		result := executeNodeTest(context.Background(), node, map[string]interface{}{"score": tc.score}, nil, &configs.CostEstimateConfig{})
+		if result.Status != NodeTestStatusCompleted || result.Output["branch"] != tc.branch {
+			t.Fatalf("score %v: got %v (%s), want branch %s", tc.score, result.Output, result.Error, tc.branch)
+		}
+	}
+}
+
+func TestEvaluateTestCondition(t *testing.T) {
+	vars := map[string]interface{}{
+		"status": "ok",
+		"count":  3.0,
+		"flag":   true,
+		"user":   map[string]interface{}{"role": "admin"},
+	}
+
+	cases := []struct {
+		expr string
+		want bool
+	}{
+		{`status == "ok"`, true},
+		{`status != "ok"`, false},
+		{`count > 2`, true},
+		{`count <= 2`, false},
+		{`user.role == "admin"`, true},
+		{`flag`, true},
+		{`missing`, false},
+		{`input.count == 3`, true},
+	}
+	for _, tc := range cases {
+		got, err := evaluateTestCondition(tc.expr, vars)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.expr, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+
+	if _, err := evaluateTestCondition(`status > 1`, vars); err == nil {
+		t.Fatal("expected an error for non-numeric ordering comparison")
+	}
+}
+
+func TestCheckNodeTestable(t *testing.T) {
+	for _, nodeType := range []workflownode.Type{workflownode.TypeWhileLoop, workflownode.TypeParallelExecutor, workflownode.TypeWorkflow, workflownode.TypeDataProcessor} {
+		err := checkNodeTestable(&ent.WorkflowNode{Type: nodeType})
+		if err == nil || !strings.HasPrefix(err.Error(), errNodeTestUnsupported) {
+			t.Fatalf("%s: expected unsupported error, got %v", nodeType, err)
+		}
+	}
+	if err := checkNodeTestable(&ent.WorkflowNode{Type: workflownode.TypeLlmCaller}); err != nil {
+		t.Fatalf("llm_caller should be testable: %v", err)
+	}
+}
@@ -0,0 +1,165 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+	"go-backend/shared/models"
+)
+
+// NodeTimeoutBranch 节点超时后继续执行的分支名称，节点存在该分支的出边时超时不会使整个执行失败
+const NodeTimeoutBranch = "timeout"
+
+// NodeRuntime 执行引擎中运行单个节点的函数。
+// ctx 在节点超时后被取消，运行时发起的HTTP/LLM请求必须使用该 ctx，超时后请求随之中断
+type NodeRuntime func(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error)
+
+// NodeRunResult 节点运行结果
+type NodeRunResult struct {
+	NodeExecution *ent.WorkflowNodeExecution // 节点执行记录
+	Output        map[string]interface{}     // 节点输出，失败或超时时为空
+	TimedOut      bool                       // 是否超时
+	// TimeoutEdges 超时后继续执行的 timeout 分支出边；超时且为空时整个执行已被标记为失败
+	TimeoutEdges []*ent.WorkflowEdge
+}
+
+// inProcessNodeRuntime 进程内运行节点，与单节点试运行使用相同的实现（LLM/API调用使用传入的 ctx）
+func inProcessNodeRuntime(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error) {
+	run, err := runNodeTest(ctx, node, input)
+	if err != nil {
+		return nil, err
+	}
+	return run.output, nil
+}
+
+// nodeTimeout 节点的超时时间：节点配置的超时时间，未配置时使用全局默认值
+func nodeTimeout(node *ent.WorkflowNode, defaultTimeout time.Duration) time.Duration {
+	if node.Timeout > 0 {
+		return time.Duration(node.Timeout) * time.Second
+	}
+	if defaultTimeout > 0 {
+		return defaultTimeout
+	}
+	return nodeTestDefaultTimeout
+}
+
+// RunNode 在节点的超时时间内运行节点并记录节点执行。
+// runtime 为空时使用进程内运行时；超时时节点执行标记为 timeout 并记录已耗费的时长，
+// 节点有 timeout 分支时返回该分支的出边由引擎继续执行，否则将整个执行标记为失败
+func (WorkflowFuncs) RunNode(ctx context.Context, executionID uint64, node *ent.WorkflowNode, input map[string]interface{}, runtime NodeRuntime) (*NodeRunResult, error) {
+	defaultTimeout := time.Duration(configs.GetConfig().Workflow.DefaultNodeTimeout) * time.Second
+	return runNodeExecution(ctx, executionID, node, input, runtime, nodeTimeout(node, defaultTimeout))
+}
+
+// runNodeExecution 按指定超时时间运行节点并记录结果
+func runNodeExecution(ctx context.Context, executionID uint64, node *ent.WorkflowNode, input map[string]interface{}, runtime NodeRuntime, timeout time.Duration) (*NodeRunResult, error) {
+	if runtime == nil {
+		runtime = inProcessNodeRuntime
+		if node.Type == workflownode.TypeWorkflow {
+			// 子工作流节点需要知道所属执行，用于关联子执行和检查调用链路
+			runtime = subWorkflowNodeRuntime(executionID)
+		}
+	}
+
+	nodeExecution, err := WorkflowFuncs{}.StartNodeExecution(ctx, executionID, node, input)
+	if err != nil {
+		return nil, err
+	}
+
+	output, timedOut, runErr := runWithNodeTimeout(ctx, runtime, node, input, timeout)
+	result := &NodeRunResult{TimedOut: timedOut}
+
+	req := &models.UpdateWorkflowNodeExecutionRequest{}
+	switch {
+	case timedOut:
+		req.Status = string(workflownodeexecution.StatusTimeout)
+		req.ErrorMessage = fmt.Sprintf("node timed out after %v", timeout)
+	case runErr != nil:
+		req.Status = string(workflownodeexecution.StatusFailed)
+		req.ErrorMessage = runErr.Error()
+	default:
+		req.Output = output
+		result.Output = output
+	}
+
+	result.NodeExecution, err = WorkflowFuncs{}.FinishNodeExecution(ctx, nodeExecution.ID, req)
+	if err != nil {
+		return nil, err
+	}
+	if !timedOut {
+		return result, nil
+	}
+
+	// 超时：存在 timeout 分支时沿该分支继续，否则整个执行失败
+	execution, err := getActiveWorkflowExecution(ctx, executionID)
+	if err != nil {
+		return nil, err
+	}
+	graph, err := loadExecutionGraph(ctx, execution)
+	if err != nil {
+		return nil, err
+	}
+	for _, edge := range graph.outgoingEdges[node.ID] {
+		if edge.BranchName == NodeTimeoutBranch {
+			result.TimeoutEdges = append(result.TimeoutEdges, edge)
+		}
+	}
+	if len(result.TimeoutEdges) > 0 {
+		return result, nil
+	}
+
+	if _, err := (WorkflowFuncs{}).FinishWorkflowExecution(ctx, executionID, &models.UpdateWorkflowExecutionRequest{
+		Status:       string(workflowexecution.StatusFailed),
+		ErrorMessage: fmt.Sprintf("node %s (%d) %s", node.Name, node.ID, req.ErrorMessage),
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// nodeTimeoutGracePeriod 超时后等待运行时响应取消并返回的最长时间，运行时可以在此期间完成清理（如结束子执行）
+var nodeTimeoutGracePeriod = 5 * time.Second
+
+// runWithNodeTimeout 在超时上下文中运行节点。
+// 超时后等待运行时返回再返回，运行时不会在调用方结束后继续写入；运行时不响应 ctx 取消时最多等待 nodeTimeoutGracePeriod，
+// 运行时的结果被丢弃
+func runWithNodeTimeout(ctx context.Context, runtime NodeRuntime, node *ent.WorkflowNode, input map[string]interface{}, timeout time.Duration) (map[string]interface{}, bool, error) {
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		output map[string]interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		output, err := runtime(runCtx, node, input)
+		done <- outcome{output: output, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		if result.err != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return nil, true, result.err
+		}
+		return result.output, false, result.err
+	case <-runCtx.Done():
+		select {
+		case <-done:
+		case <-time.After(nodeTimeoutGracePeriod):
+			logging.Warn("Node %s (%d) did not stop within %v after cancellation", node.Name, node.ID, nodeTimeoutGracePeriod)
+		}
+		if errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			return nil, true, runCtx.Err()
+		}
+		return nil, false, runCtx.Err()
+	}
+}
@@ -0,0 +1,114 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/database"
+)
+
+// slowNodeRuntime 一直阻塞到 ctx 被取消的运行时，记录是否观察到取消
+func slowNodeRuntime(cancelled chan<- error) NodeRuntime {
+	return func(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error) {
+		select {
+		case <-ctx.Done():
+			cancelled <- ctx.Err()
+			return nil, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return map[string]interface{}{"late": true}, nil
+		}
+	}
+}
+
+func TestRunNodeTimeoutFailsExecution(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running", "started_at": time.Now()})
+	ctx := context.Background()
+	node := client.WorkflowNode.GetX(ctx, 11)
+
+	cancelled := make(chan error, 1)
+	start := time.Now()
+	result, err := runNodeExecution(ctx, 100, node, map[string]interface{}{"q": "hi"}, slowNodeRuntime(cancelled), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("run node failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("run should return soon after the timeout, took %v", elapsed)
+	}
+	if !result.TimedOut || result.NodeExecution.Status != workflownodeexecution.StatusTimeout {
+		t.Fatalf("node should be marked timeout: %+v", result.NodeExecution)
+	}
+	if result.NodeExecution.DurationMs < 40 || result.NodeExecution.ErrorMessage == "" {
+		t.Fatalf("partial duration and error should be recorded: %+v", result.NodeExecution)
+	}
+	select {
+	case err := <-cancelled:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("runtime context should hit the deadline, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("runtime context should be cancelled on timeout")
+	}
+
+	execution := client.WorkflowExecution.GetX(ctx, 100)
+	if execution.Status != workflowexecution.StatusFailed || execution.ErrorMessage == "" {
+		t.Fatalf("execution should fail when no timeout branch exists: %+v", execution)
+	}
+}
+
+func TestRunNodeTimeoutFollowsTimeoutBranch(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_nodes", map[string]any{"id": 16, "application_id": 1, "name": "fallback", "node_key": "fallback", "type": "end_node"})
+	insertTestRow(t, db, "workflow_edges", map[string]any{"id": 17, "application_id": 1, "source_node_id": 11, "target_node_id": 16, "edge_key": "e2", "type": "default", "branch_name": NodeTimeoutBranch})
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running"})
+	ctx := context.Background()
+	node := client.WorkflowNode.GetX(ctx, 11)
+
+	result, err := runNodeExecution(ctx, 100, node, nil, slowNodeRuntime(make(chan error, 1)), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("run node failed: %v", err)
+	}
+	if !result.TimedOut || len(result.TimeoutEdges) != 1 || result.TimeoutEdges[0].TargetNodeID != 16 {
+		t.Fatalf("timeout branch should be returned: %+v", result)
+	}
+	if status := client.WorkflowExecution.GetX(ctx, 100).Status; status != workflowexecution.StatusRunning {
+		t.Fatalf("execution should keep running on the timeout branch, got %s", status)
+	}
+
+	// 在超时时间内完成的节点正常记录输出
+	fast := func(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error) {
+		return map[string]interface{}{"ok": true}, nil
+	}
+	result, err = runNodeExecution(ctx, 100, node, nil, fast, time.Second)
+	if err != nil {
+		t.Fatalf("run node failed: %v", err)
+	}
+	if result.TimedOut || result.NodeExecution.Status != workflownodeexecution.StatusCompleted || result.Output["ok"] != true {
+		t.Fatalf("fast node should complete: %+v", result)
+	}
+}
+
+func TestNodeTimeoutFallsBackToDefault(t *testing.T) {
+	if got := nodeTimeout(&ent.WorkflowNode{Timeout: 5}, time.Minute); got != 5*time.Second {
+		t.Fatalf("node timeout should win, got %v", got)
+	}
+	if got := nodeTimeout(&ent.WorkflowNode{}, time.Minute); got != time.Minute {
+		t.Fatalf("default timeout should be used, got %v", got)
+	}
+	if got := nodeTimeout(&ent.WorkflowNode{}, 0); got != nodeTestDefaultTimeout {
+		t.Fatalf("built-in default should be used, got %v", got)
+	}
+}
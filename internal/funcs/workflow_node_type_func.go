@@ -0,0 +1,150 @@
+package funcs
+
+import (
+	"context"
+
+	"go-backend/database/ent/workflownode"
+	"go-backend/shared/models"
+)
+
+// retryPolicyFieldDescriptor API 和 LLM 节点的重试策略字段
+var retryPolicyFieldDescriptor = models.NodeConfigFieldDescriptor{
+	Key:         "config.retry_policy",
+	Type:        "object",
+	Description: "重试策略：max_attempts（1-10）、backoff（fixed/exponential）、base_delay_ms、max_delay_ms、retry_on（5xx/429/timeout/network）",
+}
+
+// 节点类型注册表：编辑器通过 GET /workflow/node-types 获取，新增节点类型时只需在此登记，前端无需硬编码
+var nodeTypeRegistry = []models.NodeTypeDescriptor{
+	{
+		Type:         workflownode.TypeUserInput.String(),
+		DisplayName:  "用户输入",
+		Description:  "工作流入口，接收执行时提供的输入数据",
+		DefaultColor: "#67C23A",
+	},
+	{
+		Type:         workflownode.TypeTodoTaskGenerator.String(),
+		DisplayName:  "待办任务生成",
+		Description:  "根据提示词生成待办任务列表",
+		DefaultColor: "#409EFF",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "prompt", Type: "text", Required: true, Description: "任务生成提示词，支持 {{变量}} 替换"},
+			{Key: "config.model", Type: "string", Description: "使用的模型名称，为空时使用默认模型"},
+		},
+	},
+	{
+		Type:         workflownode.TypeConditionChecker.String(),
+		DisplayName:  "条件检查",
+		Description:  "按顺序计算各分支条件，流向第一个满足条件的分支",
+		DefaultColor: "#E6A23C",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "branchNodes", Type: "object", Required: true, Description: "分支配置映射，每个分支包含 name、condition、handlerId"},
+		},
+	},
+	{
+		Type:         workflownode.TypeAPICaller.String(),
+		DisplayName:  "API调用",
+		Description:  "调用外部 HTTP 接口",
+		DefaultColor: "#909399",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "apiConfig.url", Type: "string", Required: true, Description: "请求地址，支持 {{变量}} 替换"},
+			{Key: "apiConfig.method", Type: "string", Description: "请求方法", Enum: []string{"GET", "POST", "PUT", "PATCH", "DELETE"}, Default: "GET"},
+			{Key: "apiConfig.headers", Type: "object", Description: "请求头"},
+			{Key: "apiConfig.body", Type: "any", Description: "请求体，对象会编码为 JSON"},
+			retryPolicyFieldDescriptor,
+		},
+	},
+	{
+		Type:         workflownode.TypeDataProcessor.String(),
+		DisplayName:  "数据处理",
+		Description:  "执行自定义代码处理上游数据",
+		DefaultColor: "#9B59B6",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "processorLanguage", Type: "string", Required: true, Description: "处理器语言", Enum: []string{"javascript", "python", "go", "java"}, Default: "javascript"},
+			{Key: "processorCode", Type: "text", Required: true, Description: "处理器代码"},
+		},
+	},
+	{
+		Type:         workflownode.TypeWhileLoop.String(),
+		DisplayName:  "循环",
+		Description:  "在条件满足时重复执行循环体",
+		DefaultColor: "#F56C6C",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "config.condition", Type: "string", Required: true, Description: "循环条件表达式"},
+			{Key: "config.max_iterations", Type: "integer", Description: "最大迭代次数，防止死循环", Default: 100},
+		},
+	},
+	{
+		Type:         workflownode.TypeEndNode.String(),
+		DisplayName:  "结束",
+		Description:  "工作流出口，其输入即为执行结果",
+		DefaultColor: "#303133",
+	},
+	{
+		Type:         workflownode.TypeParallelExecutor.String(),
+		DisplayName:  "并行执行",
+		Description:  "同时执行多个并行任务后汇合",
+		DefaultColor: "#00BCD4",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "parallelConfig.threads", Type: "array", Required: true, Description: "并行任务列表，每个任务包含 id、name"},
+			{Key: "parallelConfig.mode", Type: "string", Description: "并行模式", Enum: []string{"all", "any", "race"}, Default: "all"},
+			{Key: "parallelConfig.timeout", Type: "integer", Description: "并行等待超时时间（秒）"},
+		},
+	},
+	{
+		Type:         workflownode.TypeLlmCaller.String(),
+		DisplayName:  "LLM调用",
+		Description:  "调用大语言模型生成内容",
+		DefaultColor: "#8E44AD",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "prompt", Type: "text", Required: true, Description: "提示词，支持 {{变量}} 替换"},
+			{Key: "config.model", Type: "string", Description: "模型名称，为空时使用默认模型"},
+			{Key: "config.system_prompt", Type: "text", Description: "系统提示词"},
+			{Key: "config.temperature", Type: "number", Description: "温度（0-2）"},
+			{Key: "config.max_tokens", Type: "integer", Description: "最大输出 Token 数"},
+			retryPolicyFieldDescriptor,
+		},
+	},
+	{
+		Type:         workflownode.TypeWorkflow.String(),
+		DisplayName:  "子工作流",
+		Description:  "调用另一个工作流应用",
+		DefaultColor: "#1ABC9C",
+		ConfigFields: []models.NodeConfigFieldDescriptor{
+			{Key: "workflowApplicationId", Type: "string", Required: true, Description: "被调用的工作流应用ID"},
+			{Key: "config.input_mapping", Type: "object", Description: "子执行输入映射（子执行输入键 -> 本节点输入中以点分隔的路径），为空时原样传入"},
+			{Key: "config.output_mapping", Type: "object", Description: "节点输出映射（输出键 -> 子执行输出中以点分隔的路径），为空时原样输出"},
+		},
+	},
+}
+
+// getNodeConnectionRule 获取节点类型的连接规则
+func getNodeConnectionRule(nodeType string) models.NodeConnectionRule {
+	switch workflownode.Type(nodeType) {
+	case workflownode.TypeUserInput:
+		return models.NodeConnectionRule{CanHaveNextNode: true, MaxOutputConnections: 1}
+	case workflownode.TypeConditionChecker:
+		return models.NodeConnectionRule{CanHaveBranches: true, CanBeParallel: true, RequiresBranchName: true, MaxOutputConnections: -1}
+	case workflownode.TypeWhileLoop, workflownode.TypeParallelExecutor:
+		return models.NodeConnectionRule{CanHaveNextNode: true, MaxOutputConnections: 1}
+	case workflownode.TypeEndNode:
+		return models.NodeConnectionRule{CanBeParallel: true, MaxOutputConnections: 0}
+	default:
+		// todo_task_generator、api_caller、data_processor、llm_caller 及未知类型
+		return models.NodeConnectionRule{CanHaveNextNode: true, CanBeParallel: true, MaxOutputConnections: 1}
+	}
+}
+
+// GetNodeTypeCatalog 获取所有节点类型的描述
+func (WorkflowFuncs) GetNodeTypeCatalog(ctx context.Context) ([]models.NodeTypeDescriptor, error) {
+	catalog := make([]models.NodeTypeDescriptor, 0, len(nodeTypeRegistry))
+	for _, descriptor := range nodeTypeRegistry {
+		descriptor.ConnectionRule = getNodeConnectionRule(descriptor.Type)
+		fields := make([]models.NodeConfigFieldDescriptor, len(descriptor.ConfigFields))
+		copy(fields, descriptor.ConfigFields)
+		descriptor.ConfigFields = fields
+		descriptor.ConfigSchema = nodeConfigSchema(workflownode.Type(descriptor.Type))
+		catalog = append(catalog, descriptor)
+	}
+	return catalog, nil
+}
@@ -0,0 +1,57 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/database/ent/workflownode"
+)
+
+func TestGetNodeTypeCatalog(t *testing.T) {
+	catalog, err := WorkflowFuncs{}.GetNodeTypeCatalog(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, descriptor := range catalog {
+		if err := workflownode.TypeValidator(workflownode.Type(descriptor.Type)); err != nil {
+			t.Fatalf("catalog contains unknown node type %q", descriptor.Type)
+		}
+		if seen[descriptor.Type] {
+			t.Fatalf("duplicate node type %q", descriptor.Type)
+		}
+		seen[descriptor.Type] = true
+		if descriptor.DisplayName == "" || descriptor.DefaultColor == "" {
+			t.Fatalf("%s: display name and default color are required", descriptor.Type)
+		}
+	}
+	if len(seen) != 10 {
+		t.Fatalf("expected every node type in the catalog, got %d", len(seen))
+	}
+
+	for _, descriptor := range catalog {
+		switch workflownode.Type(descriptor.Type) {
+		case workflownode.TypeConditionChecker:
+			rule := descriptor.ConnectionRule
+			if !rule.RequiresBranchName || !rule.CanHaveBranches || rule.MaxOutputConnections != -1 {
+				t.Fatalf("unexpected condition_checker rule: %+v", rule)
+			}
+		case workflownode.TypeEndNode:
+			if descriptor.ConnectionRule.MaxOutputConnections != 0 {
+				t.Fatalf("end_node should have no outputs: %+v", descriptor.ConnectionRule)
+			}
+		}
+	}
+
+	// 返回的是副本，修改不影响注册表
+	for i := range catalog {
+		if len(catalog[i].ConfigFields) > 0 {
+			catalog[i].ConfigFields[0].Key = "changed"
+			if nodeTypeRegistry[i].ConfigFields[0].Key == "changed" {
+				t.Fatal("catalog should not share config fields with the registry")
+			}
+			break
+		}
+	}
+}
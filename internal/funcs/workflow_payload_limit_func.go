@@ -0,0 +1,132 @@
+package funcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+)
+
+// 执行数据大小限制：节点输入、节点输出、执行上下文和最终输出按JSON序列化后的字节数检查。
+// 超出限制时按策略处理：
+//   - truncate 以截断标记（含原始大小和开头部分的预览）代替原数据写入，并输出告警日志
+//   - fail 不写入原数据，节点（或整个执行）标记为失败
+//
+// 节点的原始大小记录在节点执行的 Extra 中（inputOriginalSize / outputOriginalSize）。
+
+// 超出大小限制时的处理策略
+const (
+	PayloadPolicyTruncate = "truncate"
+	PayloadPolicyFail     = "fail"
+)
+
+// errPayloadTooLarge 数据超出大小限制
+const errPayloadTooLarge = "payload too large"
+
+// IsPayloadTooLarge 判断错误是否为数据超出大小限制
+func IsPayloadTooLarge(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errPayloadTooLarge)
+}
+
+// payloadPreviewBytes 截断标记中预览的最大字节数
+const payloadPreviewBytes = 1024
+
+// payloadLimitPolicy 执行数据大小限制策略，限制为0表示不限制
+type payloadLimitPolicy struct {
+	maxNodeInput        int
+	maxNodeOutput       int
+	maxExecutionContext int
+	fail                bool
+}
+
+// payloadLimits 全局执行数据大小限制，启动时由配置覆盖
+var payloadLimits = &payloadLimitPolicy{}
+
+// InitWorkflowPayloadLimits 根据配置初始化执行数据大小限制
+func InitWorkflowPayloadLimits(config *configs.PayloadLimitConfig) {
+	payloadLimits = newPayloadLimitPolicy(config)
+}
+
+// newPayloadLimitPolicy 根据配置创建大小限制策略，未知策略按 truncate 处理
+func newPayloadLimitPolicy(config *configs.PayloadLimitConfig) *payloadLimitPolicy {
+	return &payloadLimitPolicy{
+		maxNodeInput:        config.MaxNodeInput,
+		maxNodeOutput:       config.MaxNodeOutput,
+		maxExecutionContext: config.MaxExecutionContext,
+		fail:                strings.EqualFold(strings.TrimSpace(config.Policy), PayloadPolicyFail),
+	}
+}
+
+// payloadCheck 一次大小检查的结果
+type payloadCheck struct {
+	payload      map[string]interface{} // 允许写入的数据：未超出时为原数据，截断时为截断标记，失败策略下为nil
+	originalSize int                    // 序列化后的原始大小，未启用限制时为0
+	exceeded     bool                   // 是否超出限制
+	err          error                  // 失败策略下超出限制时的错误
+}
+
+// check 检查数据是否超出 limit，kind 用于错误信息和日志（如 "node output"）
+func (p *payloadLimitPolicy) check(kind string, payload map[string]interface{}, limit int) payloadCheck {
+	if payload == nil || limit <= 0 {
+		return payloadCheck{payload: payload}
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		// 无法序列化的数据写入时同样会失败，交由写入时报错
+		return payloadCheck{payload: payload}
+	}
+	if len(data) <= limit {
+		return payloadCheck{payload: payload, originalSize: len(data)}
+	}
+
+	result := payloadCheck{originalSize: len(data), exceeded: true}
+	if p.fail {
+		result.err = fmt.Errorf("%s: %s is %d bytes, exceeds the limit of %d bytes", errPayloadTooLarge, kind, len(data), limit)
+		return result
+	}
+	result.payload = truncatedPayload(data, limit)
+	return result
+}
+
+// truncatedPayload 生成截断标记，预览取序列化数据的开头部分且不超过限制的一半
+func truncatedPayload(data []byte, limit int) map[string]interface{} {
+	previewLen := payloadPreviewBytes
+	if half := limit / 2; half < previewLen {
+		previewLen = half
+	}
+	preview := data[:previewLen]
+	// 不在多字节字符中间截断
+	for len(preview) > 0 && !utf8.Valid(preview) {
+		preview = preview[:len(preview)-1]
+	}
+	return map[string]interface{}{
+		"_truncated":   true,
+		"originalSize": len(data),
+		"limit":        limit,
+		"preview":      string(preview),
+	}
+}
+
+// warnTruncated 截断时输出告警日志
+func (c payloadCheck) warnTruncated(kind string, id uint64) {
+	if c.exceeded && c.err == nil {
+		logging.Warn("Workflow %s of %d truncated: %d bytes exceeds the limit", kind, id, c.originalSize)
+	}
+}
+
+// recordPayloadSize 将超出限制的原始大小记录到 extra 的副本中，未超出时原样返回
+func recordPayloadSize(extra map[string]interface{}, prefix string, check payloadCheck) map[string]interface{} {
+	if !check.exceeded {
+		return extra
+	}
+	recorded := make(map[string]interface{}, len(extra)+2)
+	for key, value := range extra {
+		recorded[key] = value
+	}
+	recorded[prefix+"OriginalSize"] = check.originalSize
+	recorded[prefix+"Truncated"] = check.err == nil
+	return recorded
+}
@@ -0,0 +1,117 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/shared/models"
+)
+
+// setPayloadLimits 替换全局大小限制，测试结束后恢复
+func setPayloadLimits(t *testing.T, policy string) {
+	t.Helper()
+	previous := payloadLimits
+	payloadLimits = newPayloadLimitPolicy(&configs.PayloadLimitConfig{
+		MaxNodeInput: 64, MaxNodeOutput: 64, MaxExecutionContext: 64, Policy: policy,
+	})
+	t.Cleanup(func() { payloadLimits = previous })
+	logging.NewLogger(&configs.LoggingConfig{Level: "fatal"})
+}
+
+func oversizedPayload() map[string]interface{} {
+	return map[string]interface{}{"text": strings.Repeat("数据", 100)}
+}
+
+func TestPayloadLimitCheck(t *testing.T) {
+	truncate := newPayloadLimitPolicy(&configs.PayloadLimitConfig{Policy: "truncate"})
+	if check := truncate.check("node output", oversizedPayload(), 0); check.exceeded || check.payload == nil {
+		t.Fatalf("limit 0 should disable the check: %+v", check)
+	}
+	if check := truncate.check("node output", map[string]interface{}{"a": 1}, 64); check.exceeded || check.payload["a"] != 1 {
+		t.Fatalf("small payload should pass through: %+v", check)
+	}
+
+	check := truncate.check("node output", oversizedPayload(), 64)
+	if !check.exceeded || check.err != nil || check.payload["_truncated"] != true || check.payload["originalSize"] != check.originalSize {
+		t.Fatalf("oversized payload should be truncated: %+v", check)
+	}
+	if preview := check.payload["preview"].(string); len(preview) > 32 || !strings.HasPrefix(preview, `{"text":"`) {
+		t.Fatalf("preview should be bounded and valid: %q", preview)
+	}
+
+	fail := newPayloadLimitPolicy(&configs.PayloadLimitConfig{Policy: "FAIL"})
+	check = fail.check("node output", oversizedPayload(), 64)
+	if !check.exceeded || check.payload != nil || !IsPayloadTooLarge(check.err) {
+		t.Fatalf("fail policy should reject the payload: %+v", check)
+	}
+}
+
+func TestFinishNodeExecutionPayloadPolicies(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running"})
+	ctx := context.Background()
+	node := client.WorkflowNode.GetX(ctx, 11)
+
+	t.Run("truncate", func(t *testing.T) {
+		setPayloadLimits(t, PayloadPolicyTruncate)
+		started, err := WorkflowFuncs{}.StartNodeExecution(ctx, 100, node, oversizedPayload())
+		if err != nil {
+			t.Fatalf("start failed: %v", err)
+		}
+		if started.Input["_truncated"] != true || started.Extra["inputTruncated"] != true {
+			t.Fatalf("input should be truncated: %+v", started)
+		}
+
+		finished, err := WorkflowFuncs{}.FinishNodeExecution(ctx, started.ID, &models.UpdateWorkflowNodeExecutionRequest{Output: oversizedPayload()})
+		if err != nil {
+			t.Fatalf("finish failed: %v", err)
+		}
+		if finished.Status != workflownodeexecution.StatusCompleted || finished.Output["_truncated"] != true {
+			t.Fatalf("output should be truncated and node completed: %+v", finished)
+		}
+		if finished.Extra["outputOriginalSize"] == nil || finished.Extra["inputOriginalSize"] == nil {
+			t.Fatalf("original sizes should be recorded: %+v", finished.Extra)
+		}
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		setPayloadLimits(t, PayloadPolicyFail)
+		failed, err := WorkflowFuncs{}.StartNodeExecution(ctx, 100, node, oversizedPayload())
+		if !IsPayloadTooLarge(err) || failed == nil || failed.Status != workflownodeexecution.StatusFailed || failed.Input != nil {
+			t.Fatalf("oversized input should fail the node: %+v, %v", failed, err)
+		}
+
+		started, err := WorkflowFuncs{}.StartNodeExecution(ctx, 100, node, map[string]interface{}{"q": 1})
+		if err != nil {
+			t.Fatalf("start failed: %v", err)
+		}
+		finished, err := WorkflowFuncs{}.FinishNodeExecution(ctx, started.ID, &models.UpdateWorkflowNodeExecutionRequest{Output: oversizedPayload()})
+		if err != nil {
+			t.Fatalf("finish failed: %v", err)
+		}
+		if finished.Status != workflownodeexecution.StatusFailed || finished.Output != nil || !strings.HasPrefix(finished.ErrorMessage, errPayloadTooLarge) {
+			t.Fatalf("oversized output should fail the node: %+v", finished)
+		}
+		if finished.Extra["outputTruncated"] != false || finished.Extra["outputOriginalSize"] == nil {
+			t.Fatalf("original size should be recorded: %+v", finished.Extra)
+		}
+
+		execution, err := WorkflowFuncs{}.FinishWorkflowExecution(ctx, 100, &models.UpdateWorkflowExecutionRequest{Context: oversizedPayload()})
+		if err != nil {
+			t.Fatalf("finish execution failed: %v", err)
+		}
+		if execution.Status != workflowexecution.StatusFailed || execution.Context != nil || !strings.Contains(execution.ErrorMessage, "execution context") {
+			t.Fatalf("oversized context should fail the execution: %+v", execution)
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflowexecutionlog"
+	"go-backend/database/ent/workflownode"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// GetExecutionReport 获取单次执行的完整报告
+// 报告包含执行记录、按开始时间排序的节点执行、按记录时间排序的日志以及执行涉及的节点配置，
+// 其中命中 logging.redacted_keys 或 workflow.redacted_keys 的键（如 API Key）会被脱敏
+func (WorkflowFuncs) GetExecutionReport(ctx context.Context, executionID string) (*models.ExecutionReport, error) {
+	execution, err := database.Client.WorkflowExecution.Query().
+		Where(workflowexecution.ExecutionIDEQ(executionID)).
+		WithApplication().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow execution not found")
+		}
+		return nil, err
+	}
+
+	nodeExecutions, err := database.Client.WorkflowNodeExecution.Query().
+		Where(workflownodeexecution.ExecutionIDEQ(execution.ID)).
+		Order(ent.Asc(workflownodeexecution.FieldStartedAt), ent.Asc(workflownodeexecution.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query node executions: %w", err)
+	}
+
+	logs, err := database.Client.WorkflowExecutionLog.Query().
+		Where(workflowexecutionlog.ExecutionIDEQ(execution.ID)).
+		Order(ent.Asc(workflowexecutionlog.FieldLoggedAt), ent.Asc(workflowexecutionlog.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution logs: %w", err)
+	}
+
+	nodeIDs := make([]uint64, 0, len(nodeExecutions))
+	seen := make(map[uint64]struct{}, len(nodeExecutions))
+	for _, nodeExecution := range nodeExecutions {
+		if _, ok := seen[nodeExecution.NodeID]; ok {
+			continue
+		}
+		seen[nodeExecution.NodeID] = struct{}{}
+		nodeIDs = append(nodeIDs, nodeExecution.NodeID)
+	}
+	nodes, err := database.Client.WorkflowNode.Query().
+		Where(workflownode.IDIn(nodeIDs...)).
+		Order(ent.Asc(workflownode.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nodes: %w", err)
+	}
+
+	// 报告同时使用全局敏感键名和工作流专用的脱敏键名
+	redactedKeys := append(utils.SensitiveKeys(), configs.GetConfig().Workflow.RedactedKeys...)
+
+	executionResp := WorkflowFuncs{}.ConvertWorkflowExecutionToResponse(execution)
+	executionResp.Input = utils.RedactJSON(executionResp.Input, redactedKeys)
+	executionResp.Output = utils.RedactJSON(executionResp.Output, redactedKeys)
+	executionResp.Context = utils.RedactJSON(executionResp.Context, redactedKeys)
+	executionResp.Variables = utils.RedactJSON(execution.Variables, redactedKeys)
+
+	report := &models.ExecutionReport{
+		GeneratedAt:    utils.FormatDateTime(time.Now()),
+		ApplicationID:  utils.Uint64ToString(execution.ApplicationID),
+		Execution:      executionResp,
+		NodeExecutions: make([]*models.WorkflowNodeExecutionResponse, 0, len(nodeExecutions)),
+		Nodes:          make([]*models.WorkflowNodeResponse, 0, len(nodes)),
+		Logs:           make([]*models.WorkflowExecutionLogResponse, 0, len(logs)),
+	}
+	if execution.Edges.Application != nil {
+		report.ApplicationName = execution.Edges.Application.Name
+	}
+
+	for _, nodeExecution := range nodeExecutions {
+		resp := WorkflowFuncs{}.ConvertWorkflowNodeExecutionToResponse(nodeExecution)
+		resp.Input = utils.RedactJSON(resp.Input, redactedKeys)
+		resp.Output = utils.RedactJSON(resp.Output, redactedKeys)
+		resp.Extra = utils.RedactJSON(resp.Extra, redactedKeys)
+		report.NodeExecutions = append(report.NodeExecutions, resp)
+	}
+
+	for _, node := range nodes {
+		resp := WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node)
+		resp.Config = utils.RedactJSON(resp.Config, redactedKeys)
+		resp.APIConfig = utils.RedactJSON(resp.APIConfig, redactedKeys)
+		resp.ParallelConfig = utils.RedactJSON(resp.ParallelConfig, redactedKeys)
+		report.Nodes = append(report.Nodes, resp)
+	}
+
+	for _, log := range logs {
+		resp := WorkflowFuncs{}.ConvertWorkflowExecutionLogToResponse(log)
+		resp.Metadata = utils.RedactJSON(resp.Metadata, redactedKeys)
+		report.Logs = append(report.Logs, resp)
+	}
+
+	return report, nil
+}
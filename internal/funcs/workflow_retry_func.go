@@ -0,0 +1,312 @@
+package funcs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+
+	goopenai "github.com/sashabaranov/go-openai"
+)
+
+// errInvalidRetryPolicy 节点重试策略不合法时的错误前缀，处理器据此返回400
+const errInvalidRetryPolicy = "invalid retry policy"
+
+// retryPolicyConfigKey 重试策略在节点 config 中的键
+const retryPolicyConfigKey = "retry_policy"
+
+// 重试退避方式
+const (
+	RetryBackoffFixed       = "fixed"
+	RetryBackoffExponential = "exponential"
+)
+
+// 错误类别，重试策略的 retry_on 取前四种
+const (
+	RetryErrorServer     = "5xx"
+	RetryErrorRateLimit  = "429"
+	RetryErrorTimeout    = "timeout"
+	RetryErrorNetwork    = "network"
+	RetryErrorClient     = "4xx"
+	RetryErrorValidation = "validation"
+)
+
+// 重试策略取值范围
+const (
+	retryMaxAttemptsLimit = 10
+	retryMaxDelayLimit    = 5 * 60 * 1000
+	retryDefaultAttempts  = 3
+	retryDefaultDelayMs   = 1000
+)
+
+// defaultRetryOn 未配置 retry_on 时可重试的错误类别；4xx 和校验错误重试也不会成功
+var defaultRetryOn = []string{RetryErrorServer, RetryErrorTimeout, RetryErrorNetwork}
+
+// retrySleep 重试前等待，ctx 结束时提前返回；测试中可替换
+var retrySleep = func(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// retryPolicyConfig config.retry_policy 的结构
+type retryPolicyConfig struct {
+	MaxAttempts *int     `json:"max_attempts"`
+	Backoff     string   `json:"backoff"`
+	BaseDelayMs *int     `json:"base_delay_ms"`
+	MaxDelayMs  int      `json:"max_delay_ms"`
+	RetryOn     []string `json:"retry_on"`
+}
+
+// apiStatusError API节点收到错误状态码
+type apiStatusError struct {
+	StatusCode int
+}
+
+func (e *apiStatusError) Error() string {
+	return fmt.Sprintf("api responded with status %d", e.StatusCode)
+}
+
+// parseRetryPolicy 读取并校验节点 config 中的重试策略，未配置时返回 nil
+func parseRetryPolicy(config map[string]interface{}) (*models.RetryPolicy, error) {
+	raw, ok := config[retryPolicyConfigKey]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	if _, ok := raw.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("%s: %s must be an object", errInvalidRetryPolicy, retryPolicyConfigKey)
+	}
+
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", errInvalidRetryPolicy, err)
+	}
+	var parsed retryPolicyConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("%s: %v", errInvalidRetryPolicy, err)
+	}
+
+	policy := &models.RetryPolicy{
+		MaxAttempts: retryDefaultAttempts,
+		Backoff:     parsed.Backoff,
+		BaseDelayMs: retryDefaultDelayMs,
+		MaxDelayMs:  parsed.MaxDelayMs,
+		RetryOn:     parsed.RetryOn,
+	}
+	if parsed.MaxAttempts != nil {
+		policy.MaxAttempts = *parsed.MaxAttempts
+	}
+	if parsed.BaseDelayMs != nil {
+		policy.BaseDelayMs = *parsed.BaseDelayMs
+	}
+	if policy.Backoff == "" {
+		policy.Backoff = RetryBackoffFixed
+	}
+	if len(policy.RetryOn) == 0 {
+		policy.RetryOn = append([]string(nil), defaultRetryOn...)
+	}
+
+	if err := validateRetryPolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// validateRetryPolicy 校验重试策略的取值范围
+func validateRetryPolicy(policy *models.RetryPolicy) error {
+	if policy.MaxAttempts < 1 || policy.MaxAttempts > retryMaxAttemptsLimit {
+		return fmt.Errorf("%s: max_attempts must be between 1 and %d", errInvalidRetryPolicy, retryMaxAttemptsLimit)
+	}
+	if policy.Backoff != RetryBackoffFixed && policy.Backoff != RetryBackoffExponential {
+		return fmt.Errorf("%s: unknown backoff %q (expected %s or %s)", errInvalidRetryPolicy, policy.Backoff, RetryBackoffFixed, RetryBackoffExponential)
+	}
+	if policy.BaseDelayMs < 0 || policy.BaseDelayMs > retryMaxDelayLimit {
+		return fmt.Errorf("%s: base_delay_ms must be between 0 and %d", errInvalidRetryPolicy, retryMaxDelayLimit)
+	}
+	if policy.MaxDelayMs < 0 || policy.MaxDelayMs > retryMaxDelayLimit {
+		return fmt.Errorf("%s: max_delay_ms must be between 0 and %d", errInvalidRetryPolicy, retryMaxDelayLimit)
+	}
+	if policy.MaxDelayMs > 0 && policy.MaxDelayMs < policy.BaseDelayMs {
+		return fmt.Errorf("%s: max_delay_ms must not be less than base_delay_ms", errInvalidRetryPolicy)
+	}
+	for _, category := range policy.RetryOn {
+		switch category {
+		case RetryErrorServer, RetryErrorRateLimit, RetryErrorTimeout, RetryErrorNetwork:
+		default:
+			return fmt.Errorf("%s: unknown retry_on value %q", errInvalidRetryPolicy, category)
+		}
+	}
+	return nil
+}
+
+// validateNodeRetryPolicy 校验创建/更新节点时提交的 config 中的重试策略
+func validateNodeRetryPolicy(config map[string]interface{}) error {
+	_, err := parseRetryPolicy(config)
+	return err
+}
+
+// nodeRetryPolicy 获取节点生效的重试策略：优先使用 config.retry_policy，
+// 未配置时按节点的 retryCount 固定间隔重试；都没有时返回 nil，只尝试一次
+func nodeRetryPolicy(node *ent.WorkflowNode) *models.RetryPolicy {
+	policy, err := parseRetryPolicy(node.Config)
+	if err == nil && policy != nil {
+		return policy
+	}
+	if node.RetryCount <= 0 {
+		return nil
+	}
+	attempts := node.RetryCount + 1
+	if attempts > retryMaxAttemptsLimit {
+		attempts = retryMaxAttemptsLimit
+	}
+	return &models.RetryPolicy{
+		MaxAttempts: attempts,
+		Backoff:     RetryBackoffFixed,
+		BaseDelayMs: retryDefaultDelayMs,
+		RetryOn:     append([]string(nil), defaultRetryOn...),
+	}
+}
+
+// retryDelay 计算第 attempt 次尝试失败后的等待时间（attempt 从1开始）
+func retryDelay(policy *models.RetryPolicy, attempt int) time.Duration {
+	delay := time.Duration(policy.BaseDelayMs) * time.Millisecond
+	if policy.Backoff == RetryBackoffExponential {
+		for i := 1; i < attempt; i++ {
+			delay *= 2
+			if policy.MaxDelayMs > 0 && delay >= time.Duration(policy.MaxDelayMs)*time.Millisecond {
+				break
+			}
+		}
+	}
+	if policy.MaxDelayMs > 0 && delay > time.Duration(policy.MaxDelayMs)*time.Millisecond {
+		delay = time.Duration(policy.MaxDelayMs) * time.Millisecond
+	}
+	return delay
+}
+
+// classifyRetryError 判断错误类别，无法识别的错误视为校验错误，不重试
+func classifyRetryError(err error) string {
+	statusCode := 0
+	var statusErr *apiStatusError
+	var apiErr *goopenai.APIError
+	var requestErr *goopenai.RequestError
+	switch {
+	case errors.As(err, &statusErr):
+		statusCode = statusErr.StatusCode
+	case errors.As(err, &apiErr):
+		statusCode = apiErr.HTTPStatusCode
+	case errors.As(err, &requestErr):
+		statusCode = requestErr.HTTPStatusCode
+	}
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return RetryErrorRateLimit
+	case statusCode == http.StatusRequestTimeout:
+		return RetryErrorTimeout
+	case statusCode >= http.StatusInternalServerError:
+		return RetryErrorServer
+	case statusCode >= http.StatusBadRequest:
+		return RetryErrorClient
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return RetryErrorTimeout
+	}
+	if netErr != nil {
+		return RetryErrorNetwork
+	}
+	return RetryErrorValidation
+}
+
+// isRetryable 判断错误类别是否在策略的 retry_on 中
+func isRetryable(policy *models.RetryPolicy, category string) bool {
+	for _, retryOn := range policy.RetryOn {
+		if retryOn == category {
+			return true
+		}
+	}
+	return false
+}
+
+// runWithRetry 按重试策略运行节点，返回最后一次运行结果和每次失败尝试的记录。
+// 不可重试的错误、达到最大尝试次数或 ctx 结束（节点整体超时）时立即返回；policy 为 nil 时只运行一次
+func runWithRetry(ctx context.Context, policy *models.RetryPolicy, run func(context.Context) (*nodeTestRun, error)) (*nodeTestRun, []models.NodeAttempt, error) {
+	if policy == nil {
+		result, err := run(ctx)
+		return result, nil, err
+	}
+
+	var attempts []models.NodeAttempt
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		result, err := run(ctx)
+		if err == nil {
+			return result, attempts, nil
+		}
+
+		record := models.NodeAttempt{
+			Attempt:    attempt,
+			Error:      err.Error(),
+			ErrorType:  classifyRetryError(err),
+			DurationMs: time.Since(start).Milliseconds(),
+		}
+		record.Retryable = isRetryable(policy, record.ErrorType)
+		if !record.Retryable || attempt >= policy.MaxAttempts || ctx.Err() != nil {
+			attempts = append(attempts, record)
+			return result, attempts, err
+		}
+
+		delay := retryDelay(policy, attempt)
+		record.DelayMs = delay.Milliseconds()
+		attempts = append(attempts, record)
+		if sleepErr := retrySleep(ctx, delay); sleepErr != nil {
+			return result, attempts, err
+		}
+	}
+}
+
+// RecordNodeExecutionAttempt 记录节点执行的一次失败尝试：重试次数加一，尝试的错误追加到 extra.attempts
+func (WorkflowFuncs) RecordNodeExecutionAttempt(ctx context.Context, nodeExecutionID uint64, attempt models.NodeAttempt) error {
+	nodeExecution, err := database.Client.WorkflowNodeExecution.Get(ctx, nodeExecutionID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("workflow node execution not found")
+		}
+		return err
+	}
+
+	extra := make(map[string]interface{}, len(nodeExecution.Extra)+1)
+	for key, value := range nodeExecution.Extra {
+		extra[key] = value
+	}
+	attempts, _ := extra["attempts"].([]interface{})
+	extra["attempts"] = append(attempts, map[string]interface{}{
+		"attempt":    attempt.Attempt,
+		"error":      attempt.Error,
+		"errorType":  attempt.ErrorType,
+		"retryable":  attempt.Retryable,
+		"durationMs": attempt.DurationMs,
+		"delayMs":    attempt.DelayMs,
+	})
+
+	return nodeExecution.Update().
+		AddRetryCount(1).
+		SetExtra(extra).
+		Exec(ctx)
+}
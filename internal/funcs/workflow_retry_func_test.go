@@ -0,0 +1,227 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// stubRetrySleep 替换重试等待，记录每次等待时间而不真正等待
+func stubRetrySleep(t *testing.T) *[]time.Duration {
+	t.Helper()
+	delays := make([]time.Duration, 0)
+	previous := retrySleep
+	retrySleep = func(ctx context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	t.Cleanup(func() { retrySleep = previous })
+	return &delays
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	policy, err := parseRetryPolicy(map[string]interface{}{
+		"retry_policy": map[string]interface{}{"max_attempts": float64(4), "backoff": "exponential", "base_delay_ms": float64(100)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy.MaxAttempts != 4 || policy.Backoff != RetryBackoffExponential || policy.BaseDelayMs != 100 {
+		t.Fatalf("unexpected policy: %+v", policy)
+	}
+	if strings.Join(policy.RetryOn, ",") != "5xx,timeout,network" {
+		t.Fatalf("default retry_on not applied: %v", policy.RetryOn)
+	}
+
+	if policy, err := parseRetryPolicy(map[string]interface{}{"model": "gpt"}); policy != nil || err != nil {
+		t.Fatalf("config without retry_policy = %+v, %v", policy, err)
+	}
+
+	invalid := []map[string]interface{}{
+		{"max_attempts": float64(0)},
+		{"max_attempts": float64(11)},
+		{"backoff": "linear"},
+		{"base_delay_ms": float64(-1)},
+		{"base_delay_ms": float64(500), "max_delay_ms": float64(100)},
+		{"retry_on": []interface{}{"4xx"}},
+		{"max_attempt": float64(3)},
+		{"max_attempts": "three"},
+	}
+	for _, raw := range invalid {
+		if _, err := parseRetryPolicy(map[string]interface{}{"retry_policy": raw}); err == nil || !strings.HasPrefix(err.Error(), errInvalidRetryPolicy) {
+			t.Errorf("retry_policy %v: expected invalid retry policy error, got %v", raw, err)
+		}
+	}
+	if _, err := parseRetryPolicy(map[string]interface{}{"retry_policy": "always"}); err == nil {
+		t.Fatal("expected non-object retry_policy to be rejected")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	exponential := &models.RetryPolicy{Backoff: RetryBackoffExponential, BaseDelayMs: 100, MaxDelayMs: 500}
+	want := []time.Duration{100, 200, 400, 500, 500}
+	for i, expected := range want {
+		if delay := retryDelay(exponential, i+1); delay != expected*time.Millisecond {
+			t.Errorf("exponential attempt %d: delay = %v, want %v", i+1, delay, expected*time.Millisecond)
+		}
+	}
+
+	fixed := &models.RetryPolicy{Backoff: RetryBackoffFixed, BaseDelayMs: 100}
+	if delay := retryDelay(fixed, 5); delay != 100*time.Millisecond {
+		t.Fatalf("fixed delay = %v", delay)
+	}
+}
+
+func TestClassifyRetryError(t *testing.T) {
+	cases := []struct {
+		err  error
+		want string
+	}{
+		{&apiStatusError{StatusCode: 503}, RetryErrorServer},
+		{fmt.Errorf("wrapped: %w", &apiStatusError{StatusCode: 429}), RetryErrorRateLimit},
+		{&apiStatusError{StatusCode: 404}, RetryErrorClient},
+		{context.DeadlineExceeded, RetryErrorTimeout},
+		{errors.New("api_config.url is required"), RetryErrorValidation},
+	}
+	for _, c := range cases {
+		if got := classifyRetryError(c.err); got != c.want {
+			t.Errorf("classifyRetryError(%v) = %s, want %s", c.err, got, c.want)
+		}
+	}
+}
+
+func TestRunWithRetryExponentialBackoff(t *testing.T) {
+	delays := stubRetrySleep(t)
+	policy := &models.RetryPolicy{MaxAttempts: 4, Backoff: RetryBackoffExponential, BaseDelayMs: 100, RetryOn: defaultRetryOn}
+
+	calls := 0
+	run, attempts, err := runWithRetry(context.Background(), policy, func(ctx context.Context) (*nodeTestRun, error) {
+		calls++
+		if calls < 3 {
+			return &nodeTestRun{}, &apiStatusError{StatusCode: http.StatusBadGateway}
+		}
+		return &nodeTestRun{output: map[string]interface{}{"ok": true}}, nil
+	})
+	if err != nil || run.output["ok"] != true {
+		t.Fatalf("run = %+v, err = %v", run, err)
+	}
+	if calls != 3 || len(attempts) != 2 {
+		t.Fatalf("calls = %d, attempts = %+v", calls, attempts)
+	}
+	if len(*delays) != 2 || (*delays)[0] != 100*time.Millisecond || (*delays)[1] != 200*time.Millisecond {
+		t.Fatalf("unexpected backoff delays: %v", *delays)
+	}
+	if attempts[1].Attempt != 2 || attempts[1].ErrorType != RetryErrorServer || !attempts[1].Retryable || attempts[1].DelayMs != 200 {
+		t.Fatalf("unexpected attempt record: %+v", attempts[1])
+	}
+
+	// 达到最大尝试次数后返回最后一次的错误
+	calls = 0
+	_, attempts, err = runWithRetry(context.Background(), policy, func(ctx context.Context) (*nodeTestRun, error) {
+		calls++
+		return nil, &apiStatusError{StatusCode: http.StatusServiceUnavailable}
+	})
+	if err == nil || calls != 4 || len(attempts) != 4 || attempts[3].DelayMs != 0 {
+		t.Fatalf("calls = %d, attempts = %+v, err = %v", calls, attempts, err)
+	}
+}
+
+func TestRunWithRetryNonRetryableShortCircuit(t *testing.T) {
+	delays := stubRetrySleep(t)
+	policy := &models.RetryPolicy{MaxAttempts: 5, Backoff: RetryBackoffFixed, BaseDelayMs: 100, RetryOn: defaultRetryOn}
+
+	for _, failure := range []error{&apiStatusError{StatusCode: http.StatusBadRequest}, errors.New("api_config.url is required")} {
+		calls := 0
+		_, attempts, err := runWithRetry(context.Background(), policy, func(ctx context.Context) (*nodeTestRun, error) {
+			calls++
+			return nil, failure
+		})
+		if err != failure || calls != 1 {
+			t.Fatalf("%v: calls = %d, err = %v", failure, calls, err)
+		}
+		if len(attempts) != 1 || attempts[0].Retryable {
+			t.Fatalf("%v: unexpected attempts %+v", failure, attempts)
+		}
+	}
+	if len(*delays) != 0 {
+		t.Fatalf("non-retryable errors should not wait, got %v", *delays)
+	}
+}
+
+func TestExecuteNodeTestAPICallerRetries(t *testing.T) {
+	stubRetrySleep(t)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	node := &ent.WorkflowNode{
+		Type:      workflownode.TypeAPICaller,
+		Timeout:   5,
+		APIConfig: map[string]interface{}{"url": server.URL},
+		Config:    map[string]interface{}{"retry_policy": map[string]interface{}{"max_attempts": float64(3), "base_delay_ms": float64(10)}},
+	}
+
+	result := executeNodeTest(context.Background(), node, nil, nil, &configs.CostEstimateConfig{})
+	if result.Status != NodeTestStatusCompleted {
+		t.Fatalf("status = %s, error = %s", result.Status, result.Error)
+	}
+	if requests != 2 || len(result.Attempts) != 1 || result.Attempts[0].ErrorType != RetryErrorServer {
+		t.Fatalf("requests = %d, attempts = %+v", requests, result.Attempts)
+	}
+}
+
+func TestRecordNodeExecutionAttempt(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	seedWorkflowApplication(t, db, 1)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-1", "application_id": 1, "status": "running"})
+	insertTestRow(t, db, "workflow_node_executions", map[string]any{
+		"id": 200, "execution_id": 100, "node_id": 11, "node_name": "call", "node_type": "api_caller", "status": "running",
+		"extra": `{"trace":"abc"}`,
+	})
+
+	ctx := context.Background()
+	for i := 1; i <= 2; i++ {
+		attempt := models.NodeAttempt{Attempt: i, Error: fmt.Sprintf("failure %d", i), ErrorType: RetryErrorServer, Retryable: true}
+		if err := (WorkflowFuncs{}).RecordNodeExecutionAttempt(ctx, 200, attempt); err != nil {
+			t.Fatalf("record attempt %d: %v", i, err)
+		}
+	}
+
+	nodeExecution := client.WorkflowNodeExecution.GetX(ctx, 200)
+	if nodeExecution.RetryCount != 2 {
+		t.Fatalf("retry count = %d", nodeExecution.RetryCount)
+	}
+	attempts, _ := nodeExecution.Extra["attempts"].([]interface{})
+	if len(attempts) != 2 || nodeExecution.Extra["trace"] != "abc" {
+		t.Fatalf("unexpected extra: %v", nodeExecution.Extra)
+	}
+	if second, _ := attempts[1].(map[string]interface{}); second["error"] != "failure 2" {
+		t.Fatalf("unexpected attempt record: %v", attempts[1])
+	}
+
+	if err := (WorkflowFuncs{}).RecordNodeExecutionAttempt(ctx, 999, models.NodeAttempt{}); err == nil || err.Error() != "workflow node execution not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,460 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+
+	"github.com/robfig/cron/v3"
+)
+
+// 工作流触发源
+const (
+	WorkflowTriggerSourceSchedule = "schedule" // 定时调度触发
+	WorkflowTriggerSourceManual   = "manual"   // 通过接口手动触发
+)
+
+// workflowScheduleLockTTL 调度触发锁的有效期，需大于Cron的最小粒度（1分钟）
+const workflowScheduleLockTTL = 2 * time.Minute
+
+// workflowScheduleChangedChannel 调度变更的Redis发布/订阅频道，消息为调度ID，
+// 多实例部署时各实例收到后按数据库中的最新状态重新注册或移除该调度
+var workflowScheduleChangedChannel = caching.WorkflowKeys.Key("schedule", "changed")
+
+var (
+	// 调度器
+	workflowScheduler *cron.Cron
+	// 调度ID -> Cron条目ID
+	workflowScheduleEntries map[uint64]cron.EntryID
+	// 保护调度器和条目映射
+	workflowSchedulerLock sync.Mutex
+	// 停止订阅调度变更
+	stopWorkflowScheduleSubscription context.CancelFunc
+)
+
+// InitWorkflowScheduler 初始化工作流调度器，加载所有启用的调度
+func InitWorkflowScheduler() error {
+	if workflowScheduler != nil {
+		StopWorkflowScheduler()
+	}
+
+	workflowSchedulerLock.Lock()
+	workflowScheduler = cron.New()
+	workflowScheduleEntries = make(map[uint64]cron.EntryID)
+	workflowSchedulerLock.Unlock()
+
+	ctx := context.Background()
+	schedules, err := database.Client.WorkflowSchedule.Query().
+		Where(workflowschedule.EnabledEQ(true)).
+		All(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load workflow schedules: %w", err)
+	}
+
+	for _, schedule := range schedules {
+		if err := registerWorkflowSchedule(ctx, schedule); err != nil {
+			logging.Error("Failed to register workflow schedule %d: %v", schedule.ID, err)
+		}
+	}
+
+	workflowScheduler.Start()
+	stopWorkflowScheduleSubscription = subscribeWorkflowScheduleChanges()
+	logging.Info("Workflow scheduler started with %d schedules", len(schedules))
+	return nil
+}
+
+// StopWorkflowScheduler 停止工作流调度器
+func StopWorkflowScheduler() {
+	if stopWorkflowScheduleSubscription != nil {
+		stopWorkflowScheduleSubscription()
+		stopWorkflowScheduleSubscription = nil
+	}
+
+	workflowSchedulerLock.Lock()
+	defer workflowSchedulerLock.Unlock()
+
+	if workflowScheduler == nil {
+		return
+	}
+
+	<-workflowScheduler.Stop().Done()
+	workflowScheduler = nil
+	workflowScheduleEntries = nil
+	logging.Info("Workflow scheduler stopped")
+}
+
+// registerWorkflowSchedule 将调度注册到调度器，并根据Cron表达式刷新下次触发时间
+func registerWorkflowSchedule(ctx context.Context, schedule *ent.WorkflowSchedule) error {
+	spec, err := cron.ParseStandard(schedule.CronExpression)
+	if err != nil {
+		return fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	workflowSchedulerLock.Lock()
+	if workflowScheduler == nil {
+		workflowSchedulerLock.Unlock()
+		return nil
+	}
+
+	if entryID, ok := workflowScheduleEntries[schedule.ID]; ok {
+		workflowScheduler.Remove(entryID)
+	}
+
+	scheduleID, cronExpression := schedule.ID, schedule.CronExpression
+	workflowScheduleEntries[scheduleID] = workflowScheduler.Schedule(spec, cron.FuncJob(func() {
+		fireWorkflowSchedule(scheduleID, cronExpression)
+	}))
+	workflowSchedulerLock.Unlock()
+
+	return database.Client.WorkflowSchedule.UpdateOneID(scheduleID).
+		SetNextRunAt(spec.Next(time.Now())).
+		Exec(ctx)
+}
+
+// unregisterWorkflowSchedule 从调度器中移除调度
+func unregisterWorkflowSchedule(scheduleID uint64) {
+	workflowSchedulerLock.Lock()
+	defer workflowSchedulerLock.Unlock()
+
+	if workflowScheduler == nil {
+		return
+	}
+
+	if entryID, ok := workflowScheduleEntries[scheduleID]; ok {
+		workflowScheduler.Remove(entryID)
+		delete(workflowScheduleEntries, scheduleID)
+	}
+}
+
+// syncWorkflowSchedule 按数据库中的最新状态同步本实例的调度注册：启用的调度重新注册，已暂停或已删除的调度移除
+func syncWorkflowSchedule(ctx context.Context, scheduleID uint64) error {
+	schedule, err := database.Client.WorkflowSchedule.Get(ctx, scheduleID)
+	if err != nil && !ent.IsNotFound(err) {
+		return err
+	}
+	if err != nil || !schedule.Enabled {
+		unregisterWorkflowSchedule(scheduleID)
+		return nil
+	}
+	return registerWorkflowSchedule(ctx, schedule)
+}
+
+// syncApplicationWorkflowSchedules 同步应用下所有调度在本实例的注册并通知其他实例，用于批量修改调度之后，失败只记录日志
+func syncApplicationWorkflowSchedules(ctx context.Context, applicationIDs []uint64) {
+	if len(applicationIDs) == 0 {
+		return
+	}
+	scheduleIDs, err := database.Client.WorkflowSchedule.Query().
+		Where(workflowschedule.ApplicationIDIn(applicationIDs...)).
+		IDs(ctx)
+	if err != nil {
+		logging.Warn("Failed to load workflow schedules of applications %v: %v", applicationIDs, err)
+		return
+	}
+	for _, scheduleID := range scheduleIDs {
+		if err := syncWorkflowSchedule(ctx, scheduleID); err != nil {
+			logging.Warn("Failed to sync workflow schedule %d: %v", scheduleID, err)
+		}
+	}
+	publishWorkflowScheduleChanged(ctx, scheduleIDs...)
+}
+
+// publishWorkflowScheduleChanged 通知其他实例调度已被创建、修改、暂停/恢复或删除，Redis不可用时只有单实例，无需通知
+func publishWorkflowScheduleChanged(ctx context.Context, scheduleIDs ...uint64) {
+	if caching.Client == nil {
+		return
+	}
+	for _, scheduleID := range scheduleIDs {
+		if err := caching.Client.Publish(ctx, workflowScheduleChangedChannel, scheduleID).Err(); err != nil {
+			logging.Warn("Failed to publish change of workflow schedule %d: %v", scheduleID, err)
+		}
+	}
+}
+
+// subscribeWorkflowScheduleChanges 订阅其他实例发布的调度变更并同步本实例的注册，返回停止订阅的函数，Redis不可用时返回 nil
+func subscribeWorkflowScheduleChanges() context.CancelFunc {
+	client := caching.GetInstanceUnsafe()
+	if client == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pubsub := client.Subscribe(ctx, workflowScheduleChangedChannel)
+	// 等待订阅确认，启动之后发布的变更不会丢失
+	if _, err := pubsub.Receive(ctx); err != nil {
+		logging.Warn("Failed to subscribe to workflow schedule changes: %v", err)
+		pubsub.Close()
+		cancel()
+		return nil
+	}
+	go func() {
+		defer pubsub.Close()
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				handleWorkflowScheduleChanged(ctx, message.Payload)
+			}
+		}
+	}()
+	return cancel
+}
+
+// handleWorkflowScheduleChanged 处理一条调度变更通知，按数据库中的最新状态同步本实例的注册
+func handleWorkflowScheduleChanged(ctx context.Context, payload string) {
+	scheduleID, err := strconv.ParseUint(payload, 10, 64)
+	if err != nil {
+		return
+	}
+	if err := syncWorkflowSchedule(ctx, scheduleID); err != nil {
+		logging.Error("Failed to sync workflow schedule %d: %v", scheduleID, err)
+	}
+}
+
+// fireWorkflowSchedule 触发一次调度执行，多实例部署时通过分布式锁保证只有一个实例触发。
+// cronExpression 为注册时的Cron表达式：调度已在其他实例上被暂停、删除或修改了Cron表达式而本实例未收到通知时，
+// 不触发执行，只按数据库中的最新状态同步本实例的注册
+func fireWorkflowSchedule(scheduleID uint64, cronExpression string) {
+	ctx := context.Background()
+	now := time.Now()
+
+	schedule, err := database.Client.WorkflowSchedule.Get(ctx, scheduleID)
+	if err != nil && !ent.IsNotFound(err) {
+		logging.Error("Failed to load workflow schedule %d: %v", scheduleID, err)
+		return
+	}
+	if err != nil || !schedule.Enabled || schedule.CronExpression != cronExpression {
+		if err := syncWorkflowSchedule(ctx, scheduleID); err != nil {
+			logging.Error("Failed to sync workflow schedule %d: %v", scheduleID, err)
+		}
+		return
+	}
+
+	if caching.Client != nil {
+		lockKey := caching.WorkflowKeys.Key("schedule", scheduleID, now.Truncate(time.Minute).Unix())
+		acquired, err := RedisFuncs{}.SetNX(ctx, lockKey, 1, workflowScheduleLockTTL)
+		if err != nil {
+			logging.Error("Failed to acquire lock for workflow schedule %d: %v", scheduleID, err)
+			return
+		}
+		if !acquired {
+			return
+		}
+	}
+
+	execution, err := WorkflowFuncs{}.CreateWorkflowExecution(ctx, &models.CreateWorkflowExecutionRequest{
+		ApplicationID: utils.Uint64ToString(schedule.ApplicationID),
+		Input:         schedule.Input,
+		TriggeredBy:   fmt.Sprintf("schedule:%d", schedule.ID),
+		TriggerSource: WorkflowTriggerSourceSchedule,
+	})
+	if err != nil {
+		logging.Error("Failed to create execution for workflow schedule %d: %v", scheduleID, err)
+		return
+	}
+
+	builder := database.Client.WorkflowSchedule.UpdateOneID(scheduleID).
+		SetLastRunAt(now)
+	if spec, err := cron.ParseStandard(schedule.CronExpression); err == nil {
+		builder = builder.SetNextRunAt(spec.Next(now))
+	}
+	if err := builder.Exec(ctx); err != nil {
+		logging.Warn("Failed to update run time of workflow schedule %d: %v", scheduleID, err)
+	}
+
+	logging.Info("Workflow schedule %d fired execution %s", scheduleID, execution.ExecutionID)
+}
+
+// ============ WorkflowSchedule CRUD ============
+
+// GetWorkflowSchedulesByApplicationID 根据应用ID获取所有调度
+func (WorkflowFuncs) GetWorkflowSchedulesByApplicationID(ctx context.Context, applicationID uint64) ([]*models.WorkflowScheduleResponse, error) {
+	schedules, err := database.Client.WorkflowSchedule.Query().
+		Where(workflowschedule.ApplicationIDEQ(applicationID)).
+		Order(ent.Asc(workflowschedule.FieldCreateTime)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.WorkflowScheduleResponse, 0, len(schedules))
+	for _, schedule := range schedules {
+		responses = append(responses, WorkflowFuncs{}.ConvertWorkflowScheduleToResponse(schedule))
+	}
+
+	return responses, nil
+}
+
+// GetWorkflowScheduleByID 根据ID获取调度
+func (WorkflowFuncs) GetWorkflowScheduleByID(ctx context.Context, id uint64) (*models.WorkflowScheduleResponse, error) {
+	schedule, err := database.Client.WorkflowSchedule.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow schedule not found")
+		}
+		return nil, err
+	}
+	return WorkflowFuncs{}.ConvertWorkflowScheduleToResponse(schedule), nil
+}
+
+// CreateWorkflowSchedule 创建调度
+func (WorkflowFuncs) CreateWorkflowSchedule(ctx context.Context, applicationID uint64, req *models.CreateWorkflowScheduleRequest) (*models.WorkflowScheduleResponse, error) {
+	if _, err := cron.ParseStandard(req.CronExpression); err != nil {
+		return nil, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	exists, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check application existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("workflow application not found")
+	}
+
+	builder := database.Client.WorkflowSchedule.Create().
+		SetApplicationID(applicationID).
+		SetCronExpression(req.CronExpression)
+
+	if req.Name != "" {
+		builder = builder.SetName(req.Name)
+	}
+	if req.Input != nil {
+		builder = builder.SetInput(req.Input)
+	}
+	if req.Enabled != nil {
+		builder = builder.SetEnabled(*req.Enabled)
+	}
+
+	schedule, err := builder.Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if schedule.Enabled {
+		if err := registerWorkflowSchedule(ctx, schedule); err != nil {
+			return nil, err
+		}
+		publishWorkflowScheduleChanged(ctx, schedule.ID)
+	}
+
+	return WorkflowFuncs{}.GetWorkflowScheduleByID(ctx, schedule.ID)
+}
+
+// UpdateWorkflowSchedule 更新调度
+func (WorkflowFuncs) UpdateWorkflowSchedule(ctx context.Context, id uint64, req *models.UpdateWorkflowScheduleRequest) (*models.WorkflowScheduleResponse, error) {
+	builder := database.Client.WorkflowSchedule.UpdateOneID(id)
+
+	if req.Name != "" {
+		builder = builder.SetName(req.Name)
+	}
+	if req.CronExpression != "" {
+		if _, err := cron.ParseStandard(req.CronExpression); err != nil {
+			return nil, fmt.Errorf("invalid cron expression: %w", err)
+		}
+		builder = builder.SetCronExpression(req.CronExpression)
+	}
+	if req.Input != nil {
+		builder = builder.SetInput(req.Input)
+	}
+
+	schedule, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow schedule not found")
+		}
+		return nil, err
+	}
+
+	if schedule.Enabled {
+		if err := registerWorkflowSchedule(ctx, schedule); err != nil {
+			return nil, err
+		}
+		publishWorkflowScheduleChanged(ctx, id)
+	}
+
+	return WorkflowFuncs{}.GetWorkflowScheduleByID(ctx, id)
+}
+
+// SetWorkflowScheduleEnabled 暂停或恢复调度
+func (WorkflowFuncs) SetWorkflowScheduleEnabled(ctx context.Context, id uint64, enabled bool) (*models.WorkflowScheduleResponse, error) {
+	builder := database.Client.WorkflowSchedule.UpdateOneID(id).
+		SetEnabled(enabled)
+	if !enabled {
+		builder = builder.ClearNextRunAt()
+	}
+
+	schedule, err := builder.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow schedule not found")
+		}
+		return nil, err
+	}
+
+	if enabled {
+		if err := registerWorkflowSchedule(ctx, schedule); err != nil {
+			return nil, err
+		}
+	} else {
+		unregisterWorkflowSchedule(id)
+	}
+	publishWorkflowScheduleChanged(ctx, id)
+
+	return WorkflowFuncs{}.GetWorkflowScheduleByID(ctx, id)
+}
+
+// DeleteWorkflowSchedule 删除调度(软删除)
+func (WorkflowFuncs) DeleteWorkflowSchedule(ctx context.Context, id uint64) error {
+	err := database.Client.WorkflowSchedule.DeleteOneID(id).Exec(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return fmt.Errorf("workflow schedule not found")
+		}
+		return err
+	}
+
+	unregisterWorkflowSchedule(id)
+	publishWorkflowScheduleChanged(ctx, id)
+	return nil
+}
+
+// ConvertWorkflowScheduleToResponse 将调度实体转换为响应格式
+func (WorkflowFuncs) ConvertWorkflowScheduleToResponse(schedule *ent.WorkflowSchedule) *models.WorkflowScheduleResponse {
+	resp := &models.WorkflowScheduleResponse{
+		ID:             utils.Uint64ToString(schedule.ID),
+		CreateTime:     utils.FormatDateTime(schedule.CreateTime),
+		UpdateTime:     utils.FormatDateTime(schedule.UpdateTime),
+		ApplicationID:  utils.Uint64ToString(schedule.ApplicationID),
+		Name:           schedule.Name,
+		CronExpression: schedule.CronExpression,
+		Input:          schedule.Input,
+		Enabled:        schedule.Enabled,
+	}
+
+	if schedule.LastRunAt != nil {
+		resp.LastRunAt = utils.FormatDateTime(*schedule.LastRunAt)
+	}
+	if schedule.NextRunAt != nil {
+		resp.NextRunAt = utils.FormatDateTime(*schedule.NextRunAt)
+	}
+
+	return resp
+}
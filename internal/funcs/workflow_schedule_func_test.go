@@ -0,0 +1,93 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/database"
+)
+
+// registeredWorkflowSchedule 判断调度是否注册在本实例的调度器中
+func registeredWorkflowSchedule(scheduleID uint64) bool {
+	workflowSchedulerLock.Lock()
+	defer workflowSchedulerLock.Unlock()
+	_, ok := workflowScheduleEntries[scheduleID]
+	return ok
+}
+
+func TestWorkflowScheduleChangesPropagateAcrossInstances(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previousClient, previousCache := database.Client, caching.Client
+	database.Client, caching.Client = client, nil
+	t.Cleanup(func() { database.Client, caching.Client = previousClient, previousCache })
+	ctx := context.Background()
+
+	seedWorkflowApplication(t, db, 1)
+	client.WorkflowSchedule.UpdateOneID(14).SetCronExpression("0 0 * * *").SetEnabled(false).ExecX(ctx)
+	if err := InitWorkflowScheduler(); err != nil {
+		t.Fatalf("init scheduler failed: %v", err)
+	}
+	t.Cleanup(StopWorkflowScheduler)
+	if registeredWorkflowSchedule(14) {
+		t.Fatal("disabled schedule should not be registered")
+	}
+
+	// 其他实例恢复、暂停、删除调度后发布通知，本实例收到后按数据库中的状态同步
+	client.WorkflowSchedule.UpdateOneID(14).SetEnabled(true).ExecX(ctx)
+	handleWorkflowScheduleChanged(ctx, "14")
+	if !registeredWorkflowSchedule(14) {
+		t.Fatal("resumed schedule should be registered")
+	}
+
+	client.WorkflowSchedule.UpdateOneID(14).SetEnabled(false).ExecX(ctx)
+	handleWorkflowScheduleChanged(ctx, "14")
+	if registeredWorkflowSchedule(14) {
+		t.Fatal("paused schedule should be unregistered")
+	}
+
+	client.WorkflowSchedule.UpdateOneID(14).SetEnabled(true).ExecX(ctx)
+	handleWorkflowScheduleChanged(ctx, "14")
+	client.WorkflowSchedule.DeleteOneID(14).ExecX(ctx)
+	handleWorkflowScheduleChanged(ctx, "14")
+	if registeredWorkflowSchedule(14) {
+		t.Fatal("deleted schedule should be unregistered")
+	}
+}
+
+func TestFireWorkflowScheduleRechecksDatabase(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	previousClient, previousCache := database.Client, caching.Client
+	database.Client, caching.Client = client, nil
+	t.Cleanup(func() { database.Client, caching.Client = previousClient, previousCache })
+	ctx := context.Background()
+
+	seedWorkflowApplication(t, db, 1)
+	client.WorkflowSchedule.UpdateOneID(14).SetCronExpression("0 0 * * *").SetEnabled(true).ExecX(ctx)
+	if err := InitWorkflowScheduler(); err != nil {
+		t.Fatalf("init scheduler failed: %v", err)
+	}
+	t.Cleanup(StopWorkflowScheduler)
+
+	// 其他实例修改了Cron表达式但通知丢失：按旧表达式触发时不创建执行，改为按新表达式重新注册
+	client.WorkflowSchedule.UpdateOneID(14).SetCronExpression("*/5 * * * *").ExecX(ctx)
+	fireWorkflowSchedule(14, "0 0 * * *")
+	if n := client.WorkflowExecution.Query().CountX(ctx); n != 0 {
+		t.Fatalf("stale registration should not fire, got %d executions", n)
+	}
+	schedule := client.WorkflowSchedule.GetX(ctx, 14)
+	if !registeredWorkflowSchedule(14) || schedule.NextRunAt == nil || time.Until(*schedule.NextRunAt) > 5*time.Minute {
+		t.Fatalf("schedule should be re-registered with the new cron expression: %+v", schedule)
+	}
+
+	// 其他实例暂停了调度但通知丢失：触发时不创建执行并移除注册
+	client.WorkflowSchedule.UpdateOneID(14).SetEnabled(false).ExecX(ctx)
+	fireWorkflowSchedule(14, "*/5 * * * *")
+	if n := client.WorkflowExecution.Query().CountX(ctx); n != 0 {
+		t.Fatalf("paused schedule should not fire, got %d executions", n)
+	}
+	if registeredWorkflowSchedule(14) {
+		t.Fatal("paused schedule should be unregistered")
+	}
+}
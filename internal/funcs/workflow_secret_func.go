@@ -0,0 +1,280 @@
+package funcs
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowsecret"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// errSecretStoreNotConfigured 未配置主密钥时密钥存储不可用
+const errSecretStoreNotConfigured = "secret store not configured"
+
+// errInvalidSecretKey 密钥名称不合法
+const errInvalidSecretKey = "invalid secret key"
+
+// secretMask 试运行结果中替换密钥值的掩码
+const secretMask = "******"
+
+// secretKeyPattern 密钥名称：字母或下划线开头，仅包含字母、数字和下划线
+var secretKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// secretReferencePattern 节点配置中对密钥的引用，如 ${secrets.OPENAI_API_KEY}
+var secretReferencePattern = regexp.MustCompile(`\$\{secrets\.([A-Za-z0-9_]+)\}`)
+
+// secretReference 返回引用密钥的写法
+func secretReference(key string) string {
+	return "${secrets." + key + "}"
+}
+
+// secretEncryptionKey 由配置的主密钥派生 AES-256 密钥
+func secretEncryptionKey() ([]byte, error) {
+	masterKey := configs.GetConfig().Workflow.Secrets.EncryptionKey
+	if masterKey == "" {
+		return nil, fmt.Errorf(errSecretStoreNotConfigured)
+	}
+	sum := sha256.Sum256([]byte(masterKey))
+	return sum[:], nil
+}
+
+// encryptSecretValue 加密密钥值，返回 base64 编码的密文
+func encryptSecretValue(value string, key []byte) (string, error) {
+	ciphertext, err := utils.AESEncrypt([]byte(value), key)
+	if err != nil {
+		return "", err
+	}
+	return utils.EncodeBase64(ciphertext), nil
+}
+
+// decryptSecretValue 解密 encryptSecretValue 生成的密文
+func decryptSecretValue(encoded string, key []byte) (string, error) {
+	ciphertext, err := utils.DecodeBase64(encoded)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := utils.AESDecrypt(ciphertext, key)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// ConvertWorkflowSecretToResponse 转换应用密钥为响应格式，不包含密钥值
+func (WorkflowFuncs) ConvertWorkflowSecretToResponse(secret *ent.WorkflowSecret) *models.WorkflowSecretResponse {
+	return &models.WorkflowSecretResponse{
+		ID:            utils.Uint64ToString(secret.ID),
+		CreateTime:    utils.FormatDateTime(secret.CreateTime),
+		UpdateTime:    utils.FormatDateTime(secret.UpdateTime),
+		ApplicationID: utils.Uint64ToString(secret.ApplicationID),
+		Key:           secret.Key,
+		Reference:     secretReference(secret.Key),
+	}
+}
+
+// ListApplicationSecrets 获取应用的所有密钥名称
+func (WorkflowFuncs) ListApplicationSecrets(ctx context.Context, applicationID uint64) ([]*models.WorkflowSecretResponse, error) {
+	secrets, err := database.Client.WorkflowSecret.Query().
+		Where(workflowsecret.ApplicationIDEQ(applicationID)).
+		Order(ent.Asc(workflowsecret.FieldKey)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*models.WorkflowSecretResponse, 0, len(secrets))
+	for _, secret := range secrets {
+		responses = append(responses, WorkflowFuncs{}.ConvertWorkflowSecretToResponse(secret))
+	}
+	return responses, nil
+}
+
+// SetApplicationSecret 设置应用密钥，已存在同名密钥时覆盖其值
+func (WorkflowFuncs) SetApplicationSecret(ctx context.Context, applicationID uint64, key, value string) (*models.WorkflowSecretResponse, error) {
+	if !secretKeyPattern.MatchString(key) {
+		return nil, fmt.Errorf("%s: %q", errInvalidSecretKey, key)
+	}
+	encryptionKey, err := secretEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check application existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("workflow application not found")
+	}
+
+	encrypted, err := encryptSecretValue(value, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt secret: %w", err)
+	}
+
+	existing, err := database.Client.WorkflowSecret.Query().
+		Where(workflowsecret.ApplicationIDEQ(applicationID), workflowsecret.KeyEQ(key)).
+		Only(ctx)
+	if err != nil && !ent.IsNotFound(err) {
+		return nil, err
+	}
+
+	var secret *ent.WorkflowSecret
+	if existing != nil {
+		secret, err = existing.Update().SetValue(encrypted).Save(ctx)
+	} else {
+		secret, err = database.Client.WorkflowSecret.Create().
+			SetApplicationID(applicationID).
+			SetKey(key).
+			SetValue(encrypted).
+			Save(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return WorkflowFuncs{}.ConvertWorkflowSecretToResponse(secret), nil
+}
+
+// DeleteApplicationSecret 删除应用密钥
+func (WorkflowFuncs) DeleteApplicationSecret(ctx context.Context, applicationID uint64, key string) error {
+	deleted, err := database.Client.WorkflowSecret.Delete().
+		Where(workflowsecret.ApplicationIDEQ(applicationID), workflowsecret.KeyEQ(key)).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("workflow secret not found")
+	}
+	return nil
+}
+
+// loadApplicationSecrets 加载并解密应用的所有密钥
+func loadApplicationSecrets(ctx context.Context, applicationID uint64) (map[string]string, error) {
+	secrets, err := database.Client.WorkflowSecret.Query().
+		Where(workflowsecret.ApplicationIDEQ(applicationID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(secrets))
+	if len(secrets) == 0 {
+		return values, nil
+	}
+
+	encryptionKey, err := secretEncryptionKey()
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		value, err := decryptSecretValue(secret.Value, encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt secret %s: %w", secret.Key, err)
+		}
+		values[secret.Key] = value
+	}
+	return values, nil
+}
+
+// ResolveApplicationSecrets 将值中所有 ${secrets.KEY} 替换为应用密钥的明文，供执行节点前调用。
+// 返回新的值，原值不会被修改；引用了未定义的密钥时返回 error
+func (WorkflowFuncs) ResolveApplicationSecrets(ctx context.Context, applicationID uint64, value interface{}) (interface{}, error) {
+	secrets, err := loadApplicationSecrets(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	return resolveSecretReferences(value, secrets)
+}
+
+// resolveSecretReferences 递归替换字符串、map 和切片中的 ${secrets.KEY}
+func resolveSecretReferences(value interface{}, secrets map[string]string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		var missing []string
+		resolved := secretReferencePattern.ReplaceAllStringFunc(v, func(match string) string {
+			key := secretReferencePattern.FindStringSubmatch(match)[1]
+			secret, ok := secrets[key]
+			if !ok {
+				missing = append(missing, key)
+				return match
+			}
+			return secret
+		})
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("undefined secret: %s", strings.Join(missing, ", "))
+		}
+		return resolved, nil
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			r, err := resolveSecretReferences(item, secrets)
+			if err != nil {
+				return nil, err
+			}
+			resolved[key] = r
+		}
+		return resolved, nil
+	case []interface{}:
+		resolved := make([]interface{}, len(v))
+		for i, item := range v {
+			r, err := resolveSecretReferences(item, secrets)
+			if err != nil {
+				return nil, err
+			}
+			resolved[i] = r
+		}
+		return resolved, nil
+	}
+	return value, nil
+}
+
+// maskSecretValues 将值中出现的密钥明文替换为掩码，防止密钥通过输入、输出或错误信息泄露
+func maskSecretValues(value interface{}, secrets map[string]string) interface{} {
+	if len(secrets) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return maskSecretString(v, secrets)
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = maskSecretValues(item, secrets)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskSecretValues(item, secrets)
+		}
+		return masked
+	}
+	return value
+}
+
+// maskSecretString 替换字符串中的密钥明文，较长的值优先替换以免被其前缀部分匹配
+func maskSecretString(s string, secrets map[string]string) string {
+	values := make([]string, 0, len(secrets))
+	for _, value := range secrets {
+		if value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	for _, value := range values {
+		s = strings.ReplaceAll(s, value, secretMask)
+	}
+	return s
+}
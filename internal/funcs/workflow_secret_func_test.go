@@ -0,0 +1,93 @@
+package funcs
+
+import (
+	"crypto/sha256"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent"
+)
+
+func TestSecretValueRoundTrip(t *testing.T) {
+	key := sha256.Sum256([]byte("master"))
+	encrypted, err := encryptSecretValue("sk-123", key[:])
+	if err != nil {
+		t.Fatalf("encrypt failed: %v", err)
+	}
+	if strings.Contains(encrypted, "sk-123") {
+		t.Fatalf("ciphertext leaks plaintext: %s", encrypted)
+	}
+
+	decrypted, err := decryptSecretValue(encrypted, key[:])
+	if err != nil || decrypted != "sk-123" {
+		t.Fatalf("decrypt = %q, %v", decrypted, err)
+	}
+
+	otherKey := sha256.Sum256([]byte("other"))
+	if _, err := decryptSecretValue(encrypted, otherKey[:]); err == nil {
+		t.Fatal("expected decrypt with wrong key to fail")
+	}
+}
+
+func TestResolveSecretReferences(t *testing.T) {
+	secrets := map[string]string{"API_KEY": "sk-123", "HOST": "example.com"}
+	value := map[string]interface{}{
+		"url":     "https://${secrets.HOST}/v1",
+		"headers": map[string]interface{}{"Authorization": "Bearer ${secrets.API_KEY}"},
+		"list":    []interface{}{"${secrets.HOST}", 3},
+		"plain":   "{{input}}",
+	}
+
+	resolved, err := resolveSecretReferences(value, secrets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]interface{}{
+		"url":     "https://example.com/v1",
+		"headers": map[string]interface{}{"Authorization": "Bearer sk-123"},
+		"list":    []interface{}{"example.com", 3},
+		"plain":   "{{input}}",
+	}
+	if !reflect.DeepEqual(resolved, want) {
+		t.Fatalf("resolved = %#v", resolved)
+	}
+	if value["url"] != "https://${secrets.HOST}/v1" {
+		t.Fatal("original value was modified")
+	}
+
+	if _, err := resolveSecretReferences("${secrets.MISSING}", secrets); err == nil || !strings.Contains(err.Error(), "MISSING") {
+		t.Fatalf("expected undefined secret error, got %v", err)
+	}
+}
+
+func TestResolveNodeSecretsAndMask(t *testing.T) {
+	secrets := map[string]string{"API_KEY": "sk-123"}
+	node := &ent.WorkflowNode{
+		Prompt:    "key ${secrets.API_KEY}",
+		APIConfig: map[string]interface{}{"headers": map[string]interface{}{"X-Key": "${secrets.API_KEY}"}},
+	}
+
+	resolved, err := resolveNodeSecrets(node, secrets)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resolved.Prompt != "key sk-123" || node.Prompt != "key ${secrets.API_KEY}" {
+		t.Fatalf("prompt resolved = %q, original = %q", resolved.Prompt, node.Prompt)
+	}
+	if resolved.Config != nil {
+		t.Fatalf("nil config should stay nil, got %#v", resolved.Config)
+	}
+
+	masked := maskSecretValues(map[string]interface{}{
+		"prompt": resolved.Prompt,
+		"nested": []interface{}{"Bearer sk-123"},
+	}, secrets)
+	want := map[string]interface{}{
+		"prompt": "key " + secretMask,
+		"nested": []interface{}{"Bearer " + secretMask},
+	}
+	if !reflect.DeepEqual(masked, want) {
+		t.Fatalf("masked = %#v", masked)
+	}
+}
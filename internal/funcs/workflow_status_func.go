@@ -0,0 +1,225 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// errInvalidStatusTransition 应用状态机不允许的状态转换
+const errInvalidStatusTransition = "invalid status transition"
+
+// errInvalidTargetStatus 目标状态不是合法的应用状态
+const errInvalidTargetStatus = "invalid target status"
+
+// publishVersionChangeLog 发布时自动创建的版本快照的变更说明
+const publishVersionChangeLog = "发布"
+
+// workflowStatusTransitions 应用状态机：当前状态 -> 允许转换到的状态。
+// 已归档的应用需要先恢复为草稿才能重新发布
+var workflowStatusTransitions = map[workflowapplication.Status][]workflowapplication.Status{
+	workflowapplication.StatusDraft:     {workflowapplication.StatusPublished, workflowapplication.StatusArchived},
+	workflowapplication.StatusPublished: {workflowapplication.StatusDraft, workflowapplication.StatusArchived},
+	workflowapplication.StatusArchived:  {workflowapplication.StatusDraft},
+}
+
+// IsStatusTransitionRejected 判断错误是否为状态转换被拒绝（非法转换、目标状态无效或应用不满足发布条件）
+func IsStatusTransitionRejected(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, errInvalidStatusTransition) ||
+		strings.Contains(message, errInvalidTargetStatus) ||
+		strings.Contains(message, errWorkflowNotPublishable)
+}
+
+// checkStatusTransition 检查状态机是否允许从 from 转换到 to
+func checkStatusTransition(from, to workflowapplication.Status) error {
+	for _, allowed := range workflowStatusTransitions[from] {
+		if allowed == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %s -> %s", errInvalidStatusTransition, from, to)
+}
+
+// transitionApplicationStatus 按状态机转换单个应用的状态，并执行转换附带的操作：
+// 发布时检查开始节点并创建版本快照，归档时停用应用的所有定时调度。返回转换前的状态和发布时创建的版本
+func transitionApplicationStatus(ctx context.Context, client *ent.Client, id uint64, target workflowapplication.Status) (workflowapplication.Status, *ent.WorkflowVersion, error) {
+	app, err := client.WorkflowApplication.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil, fmt.Errorf("workflow application not found")
+		}
+		return "", nil, err
+	}
+	if err := checkStatusTransition(app.Status, target); err != nil {
+		return app.Status, nil, err
+	}
+	if target == workflowapplication.StatusPublished {
+		if err := checkWorkflowPublishable(ctx, client, id, 0); err != nil {
+			return app.Status, nil, err
+		}
+	}
+
+	if err := client.WorkflowApplication.UpdateOneID(id).SetStatus(target).Exec(ctx); err != nil {
+		return app.Status, nil, err
+	}
+
+	var version *ent.WorkflowVersion
+	switch target {
+	case workflowapplication.StatusPublished:
+		version, _, err = createWorkflowVersionSnapshot(ctx, client, id, publishVersionChangeLog, false)
+		if err != nil {
+			return app.Status, nil, fmt.Errorf("failed to snapshot version: %w", err)
+		}
+	case workflowapplication.StatusArchived:
+		err = client.WorkflowSchedule.Update().
+			Where(workflowschedule.ApplicationIDEQ(id), workflowschedule.EnabledEQ(true)).
+			SetEnabled(false).
+			Exec(ctx)
+		if err != nil {
+			return app.Status, nil, fmt.Errorf("failed to disable schedules: %w", err)
+		}
+	}
+	return app.Status, version, nil
+}
+
+// BatchTransitionStatus 批量将应用转换到目标状态，每个应用的转换遵循状态机规则。
+// 默认每个应用在独立事务中转换，非法转换等失败只记录在对应项中，不影响其他应用；
+// 配置 workflow.batch_status_atomic 为 true 时所有转换在同一事务中执行，任意一项失败即全部回滚并返回该错误
+func (WorkflowFuncs) BatchTransitionStatus(ctx context.Context, applicationIDs []uint64, targetStatus string) (*models.BatchStatusResult, error) {
+	workflowConfig := configs.GetConfig().Workflow
+	result, err := batchTransitionStatus(ctx, applicationIDs, targetStatus, workflowConfig.BatchStatusAtomic)
+	if err != nil {
+		return nil, err
+	}
+
+	// 归档停用了调度，同步本实例的注册并通知其他实例
+	if result.Status == string(workflowapplication.StatusArchived) {
+		archived := make([]uint64, 0, len(result.Items))
+		for _, item := range result.Items {
+			if item.Success {
+				archived = append(archived, utils.StringToUint64(item.ID))
+			}
+		}
+		syncApplicationWorkflowSchedules(ctx, archived)
+	}
+
+	// 发布产生了新版本时按保留策略清理旧版本，清理失败不影响转换结果
+	if result.Status == string(workflowapplication.StatusPublished) && workflowConfig.MaxVersionsPerApplication > 0 {
+		for _, item := range result.Items {
+			if !item.Success {
+				continue
+			}
+			if _, err := (WorkflowFuncs{}).PruneWorkflowVersions(ctx, utils.StringToUint64(item.ID)); err != nil {
+				logging.Warn("Failed to prune workflow versions of application %s: %v", item.ID, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// batchTransitionStatus 校验目标状态后按 atomic 选择整体事务或逐个事务执行转换
+func batchTransitionStatus(ctx context.Context, applicationIDs []uint64, targetStatus string, atomic bool) (*models.BatchStatusResult, error) {
+	target := workflowapplication.Status(targetStatus)
+	if err := workflowapplication.StatusValidator(target); err != nil {
+		return nil, fmt.Errorf("%s %q", errInvalidTargetStatus, targetStatus)
+	}
+
+	result := &models.BatchStatusResult{
+		Status: targetStatus,
+		Total:  len(applicationIDs),
+		Items:  make([]models.BatchStatusItemResult, 0, len(applicationIDs)),
+	}
+	if atomic {
+		if err := batchTransitionAtomic(ctx, applicationIDs, target, result); err != nil {
+			return nil, err
+		}
+	} else {
+		batchTransitionEach(ctx, applicationIDs, target, result)
+	}
+	return result, nil
+}
+
+// batchTransitionEach 每个应用在独立事务中转换，收集每一项的结果
+func batchTransitionEach(ctx context.Context, applicationIDs []uint64, target workflowapplication.Status, result *models.BatchStatusResult) {
+	for _, id := range applicationIDs {
+		item := models.BatchStatusItemResult{ID: utils.Uint64ToString(id)}
+
+		var (
+			from    workflowapplication.Status
+			version *ent.WorkflowVersion
+		)
+		err := withWorkflowTx(ctx, func(tx *ent.Tx) error {
+			var err error
+			from, version, err = transitionApplicationStatus(ctx, tx.Client(), id, target)
+			return err
+		})
+
+		item.PreviousStatus = string(from)
+		if err != nil {
+			item.Error = err.Error()
+			result.Failed++
+		} else {
+			item.Success = true
+			if version != nil {
+				item.VersionID = utils.Uint64ToString(version.ID)
+			}
+			result.Succeeded++
+		}
+		result.Items = append(result.Items, item)
+	}
+}
+
+// batchTransitionAtomic 所有应用在同一事务中转换，遇到第一个错误即回滚
+func batchTransitionAtomic(ctx context.Context, applicationIDs []uint64, target workflowapplication.Status, result *models.BatchStatusResult) error {
+	items := make([]models.BatchStatusItemResult, 0, len(applicationIDs))
+	err := withWorkflowTx(ctx, func(tx *ent.Tx) error {
+		for _, id := range applicationIDs {
+			from, version, err := transitionApplicationStatus(ctx, tx.Client(), id, target)
+			if err != nil {
+				return fmt.Errorf("failed to transition application %d: %w", id, err)
+			}
+			item := models.BatchStatusItemResult{ID: utils.Uint64ToString(id), PreviousStatus: string(from), Success: true}
+			if version != nil {
+				item.VersionID = utils.Uint64ToString(version.ID)
+			}
+			items = append(items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	result.Items = items
+	result.Succeeded = len(items)
+	return nil
+}
+
+// withWorkflowTx 在事务中执行 fn，fn 返回错误时回滚
+func withWorkflowTx(ctx context.Context, fn func(tx *ent.Tx) error) error {
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package funcs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowschedule"
+	"go-backend/pkg/database"
+)
+
+func TestCheckStatusTransition(t *testing.T) {
+	allowed := [][2]workflowapplication.Status{
+		{workflowapplication.StatusDraft, workflowapplication.StatusPublished},
+		{workflowapplication.StatusDraft, workflowapplication.StatusArchived},
+		{workflowapplication.StatusPublished, workflowapplication.StatusDraft},
+		{workflowapplication.StatusPublished, workflowapplication.StatusArchived},
+		{workflowapplication.StatusArchived, workflowapplication.StatusDraft},
+	}
+	for _, pair := range allowed {
+		if err := checkStatusTransition(pair[0], pair[1]); err != nil {
+			t.Errorf("%s -> %s should be allowed: %v", pair[0], pair[1], err)
+		}
+	}
+
+	rejected := [][2]workflowapplication.Status{
+		{workflowapplication.StatusArchived, workflowapplication.StatusPublished},
+		{workflowapplication.StatusArchived, workflowapplication.StatusArchived},
+		{workflowapplication.StatusDraft, workflowapplication.StatusDraft},
+	}
+	for _, pair := range rejected {
+		if err := checkStatusTransition(pair[0], pair[1]); !IsStatusTransitionRejected(err) {
+			t.Errorf("%s -> %s should be rejected, got %v", pair[0], pair[1], err)
+		}
+	}
+}
+
+// setWorkflowStatusTestClient 替换全局数据库客户端并写入指定状态的应用
+func setWorkflowStatusTestClient(t *testing.T, statuses map[uint64]string) {
+	t.Helper()
+	client, db := newWorkflowDeleteTestClient(t)
+	previous := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = previous })
+
+	for id, status := range statuses {
+		seedWorkflowApplication(t, db, id)
+		if _, err := db.Exec("UPDATE workflow_applications SET status = ? WHERE id = ?", status, id); err != nil {
+			t.Fatalf("failed to set status: %v", err)
+		}
+	}
+}
+
+func TestBatchTransitionStatusMixedSet(t *testing.T) {
+	setWorkflowStatusTestClient(t, map[uint64]string{1: "draft", 2: "archived", 3: "published"})
+	ctx := context.Background()
+
+	result, err := batchTransitionStatus(ctx, []uint64{1, 2, 3, 99}, "archived", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Total != 4 || result.Succeeded != 2 || result.Failed != 2 {
+		t.Fatalf("unexpected summary: %+v", result)
+	}
+
+	items := result.Items
+	if !items[0].Success || items[0].PreviousStatus != "draft" || !items[2].Success || items[2].PreviousStatus != "published" {
+		t.Fatalf("valid transitions should succeed: %+v", items)
+	}
+	if items[1].Success || items[1].PreviousStatus != "archived" || !strings.HasPrefix(items[1].Error, errInvalidStatusTransition) {
+		t.Fatalf("archived -> archived should be rejected: %+v", items[1])
+	}
+	if items[3].Success || items[3].Error != "workflow application not found" {
+		t.Fatalf("missing application should fail: %+v", items[3])
+	}
+
+	for _, id := range []uint64{1, 2, 3} {
+		if app := database.Client.WorkflowApplication.GetX(ctx, id); app.Status != workflowapplication.StatusArchived {
+			t.Fatalf("application %d status = %s", id, app.Status)
+		}
+	}
+	// 归档时停用应用的定时调度
+	enabled := database.Client.WorkflowSchedule.Query().Where(workflowschedule.EnabledEQ(true)).CountX(ctx)
+	if enabled != 1 {
+		t.Fatalf("expected only the schedule of the already archived application to stay enabled, got %d", enabled)
+	}
+}
+
+func TestBatchTransitionStatusRejectsUnpublishable(t *testing.T) {
+	setWorkflowStatusTestClient(t, map[uint64]string{1: "draft"})
+	ctx := context.Background()
+
+	// 应用未设置开始节点，不满足发布条件
+	result, err := batchTransitionStatus(ctx, []uint64{1}, "published", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Failed != 1 || !strings.HasPrefix(result.Items[0].Error, errWorkflowNotPublishable) {
+		t.Fatalf("expected publish check failure: %+v", result.Items)
+	}
+	if app := database.Client.WorkflowApplication.GetX(ctx, 1); app.Status != workflowapplication.StatusDraft {
+		t.Fatalf("status should stay draft, got %s", app.Status)
+	}
+
+	if _, err := batchTransitionStatus(ctx, []uint64{1}, "deleted", false); !IsStatusTransitionRejected(err) {
+		t.Fatalf("expected invalid target status error, got %v", err)
+	}
+}
+
+func TestBatchTransitionStatusAtomicRollsBack(t *testing.T) {
+	setWorkflowStatusTestClient(t, map[uint64]string{1: "draft", 2: "archived"})
+	ctx := context.Background()
+
+	_, err := batchTransitionStatus(ctx, []uint64{1, 2}, "archived", true)
+	if !IsStatusTransitionRejected(err) {
+		t.Fatalf("expected rejected transition, got %v", err)
+	}
+	if app := database.Client.WorkflowApplication.GetX(ctx, 1); app.Status != workflowapplication.StatusDraft {
+		t.Fatalf("transition of application 1 should be rolled back, status = %s", app.Status)
+	}
+	if enabled := database.Client.WorkflowSchedule.Query().Where(workflowschedule.EnabledEQ(true)).CountX(ctx); enabled != 2 {
+		t.Fatalf("schedule changes should be rolled back, enabled = %d", enabled)
+	}
+
+	result, err := batchTransitionStatus(ctx, []uint64{1, 2}, "draft", true)
+	if !IsStatusTransitionRejected(err) || result != nil {
+		t.Fatalf("draft -> draft should abort the atomic batch, got %+v, %v", result, err)
+	}
+}
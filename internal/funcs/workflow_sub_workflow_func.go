@@ -0,0 +1,218 @@
+package funcs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/database"
+	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// 子工作流节点（workflow 类型）：以被引用的应用启动一个子执行，子执行记录父执行ID，
+// 节点在自身的超时时间内等待子执行结束，子执行的输出经映射后作为节点输出。
+// 启动前沿父执行链路检查嵌套深度和循环调用（链路中已出现被引用的应用），并检查父应用的所有者能够读取被引用的应用
+
+// SubWorkflowTriggerSource 子工作流节点启动的执行的触发来源
+const SubWorkflowTriggerSource = "sub_workflow"
+
+var (
+	// errSubWorkflowCycle 被引用的应用已在调用链路中
+	errSubWorkflowCycle = errors.New("sub-workflow cycle detected")
+	// errSubWorkflowDepthExceeded 嵌套深度超出限制
+	errSubWorkflowDepthExceeded = errors.New("sub-workflow depth limit exceeded")
+)
+
+// IsSubWorkflowRecursion 判断错误是否为子工作流循环调用或嵌套过深
+func IsSubWorkflowRecursion(err error) bool {
+	return errors.Is(err, errSubWorkflowCycle) || errors.Is(err, errSubWorkflowDepthExceeded)
+}
+
+// subWorkflowMaxDepth 子工作流的最大嵌套深度，启动时由配置覆盖
+var subWorkflowMaxDepth = 5
+
+// subWorkflowPollInterval 等待子执行结束时重新读取状态的间隔
+var subWorkflowPollInterval = executionWaitPollInterval
+
+// subWorkflowStopTimeout 父节点超时后结束子执行的写入时限，应小于 nodeTimeoutGracePeriod
+const subWorkflowStopTimeout = 2 * time.Second
+
+// startSubWorkflowExecution 创建并入队子执行，测试中可替换
+var startSubWorkflowExecution = createWorkflowExecution
+
+// InitSubWorkflowPolicy 根据配置设置子工作流的最大嵌套深度
+func InitSubWorkflowPolicy(config *configs.SubWorkflowConfig) {
+	if config.MaxDepth > 0 {
+		subWorkflowMaxDepth = config.MaxDepth
+	}
+}
+
+// checkSubWorkflowAccess 检查父应用的所有者对被引用的应用拥有读取权限。节点保存时已检查编辑者的权限，
+// 运行时再按父应用的所有者检查，覆盖克隆、移动节点等未经检查复制引用的途径；没有所有者的父应用只能引用没有所有者的应用
+func checkSubWorkflowAccess(ctx context.Context, parentAppID, targetAppID uint64) error {
+	parentApp, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(parentAppID)).
+		Select(workflowapplication.FieldOwnerID).
+		Only(ctx)
+	if err != nil {
+		return err
+	}
+
+	scope := WorkflowAccessScope{UserID: parentApp.OwnerID}
+	if parentApp.OwnerID != 0 {
+		scope, err = WorkflowFuncs{}.ResolveWorkflowAccessScope(ctx, parentApp.OwnerID)
+		if err != nil {
+			return err
+		}
+	}
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scope, targetAppID, WorkflowAccessRead); err != nil {
+		return fmt.Errorf("%w: sub-workflow application %d is not readable by the owner of application %d",
+			errWorkflowAccessDenied, targetAppID, parentAppID)
+	}
+	return nil
+}
+
+// checkSubWorkflowChain 沿父执行链路检查是否可以从 parentExecutionID 启动 targetAppID 的子执行：
+// 链路中任一执行属于 targetAppID 时为循环调用，子执行的深度超过上限时拒绝
+func checkSubWorkflowChain(ctx context.Context, parentExecutionID, targetAppID uint64) error {
+	visited := make(map[uint64]bool)
+	path := make([]string, 0)
+	depth := 0
+	for id := parentExecutionID; id > 0; {
+		if visited[id] {
+			// 数据异常形成的环，同样视为循环调用
+			return fmt.Errorf("%w: execution %d appears twice in the chain", errSubWorkflowCycle, id)
+		}
+		visited[id] = true
+
+		execution, err := database.Client.WorkflowExecution.Get(ctx, id)
+		if err != nil {
+			if ent.IsNotFound(err) {
+				return fmt.Errorf("workflow execution not found")
+			}
+			return err
+		}
+		path = append(path, utils.Uint64ToString(execution.ApplicationID))
+		if execution.ApplicationID == targetAppID {
+			return fmt.Errorf("%w: application %d is already running in the chain %s",
+				errSubWorkflowCycle, targetAppID, strings.Join(reverseStrings(path), " -> "))
+		}
+		if execution.ParentExecutionID > 0 {
+			depth++
+		}
+		id = execution.ParentExecutionID
+	}
+
+	// 父执行的深度为 depth，子执行为 depth+1
+	if depth+1 > subWorkflowMaxDepth {
+		return fmt.Errorf("%w: depth %d exceeds the limit %d", errSubWorkflowDepthExceeded, depth+1, subWorkflowMaxDepth)
+	}
+	return nil
+}
+
+// reverseStrings 返回倒序的副本，用于按调用顺序展示链路
+func reverseStrings(items []string) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[len(items)-1-i] = item
+	}
+	return result
+}
+
+// applySubWorkflowMapping 按映射（目标键 -> 以点分隔的源路径）从 source 中取值，
+// 未配置映射时原样使用 source；源路径不存在的键被跳过
+func applySubWorkflowMapping(source map[string]interface{}, mapping interface{}) map[string]interface{} {
+	entries, ok := mapping.(map[string]interface{})
+	if !ok || len(entries) == 0 {
+		return source
+	}
+
+	result := make(map[string]interface{}, len(entries))
+	for key, rawPath := range entries {
+		path, ok := rawPath.(string)
+		if !ok || strings.TrimSpace(path) == "" {
+			continue
+		}
+		if value, found := lookupPath(source, strings.Split(strings.TrimSpace(path), ".")); found {
+			result[key] = value
+		}
+	}
+	return result
+}
+
+// subWorkflowNodeRuntime 返回在 executionID 中运行子工作流节点的运行时
+func subWorkflowNodeRuntime(executionID uint64) NodeRuntime {
+	return func(ctx context.Context, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error) {
+		return runSubWorkflow(ctx, executionID, node, input)
+	}
+}
+
+// runSubWorkflow 启动子执行并等待其结束，ctx 的截止时间即节点的超时时间。
+// 超时后子执行被标记为 timeout，子执行未成功完成时节点失败
+func runSubWorkflow(ctx context.Context, parentExecutionID uint64, node *ent.WorkflowNode, input map[string]interface{}) (map[string]interface{}, error) {
+	if node.Type != workflownode.TypeWorkflow || node.WorkflowApplicationID == 0 {
+		return nil, fmt.Errorf("node %s (%d) does not reference a workflow application", node.Name, node.ID)
+	}
+	if err := checkSubWorkflowChain(ctx, parentExecutionID, node.WorkflowApplicationID); err != nil {
+		return nil, err
+	}
+
+	parent, err := database.Client.WorkflowExecution.Get(ctx, parentExecutionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSubWorkflowAccess(ctx, parent.ApplicationID, node.WorkflowApplicationID); err != nil {
+		return nil, err
+	}
+
+	child, err := startSubWorkflowExecution(ctx, &models.CreateWorkflowExecutionRequest{
+		ApplicationID: utils.Uint64ToString(node.WorkflowApplicationID),
+		Input:         applySubWorkflowMapping(input, node.Config["input_mapping"]),
+		TriggeredBy:   parent.ExecutionID,
+		TriggerSource: SubWorkflowTriggerSource,
+	}, parentExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sub-workflow: %w", err)
+	}
+
+	events, unsubscribe := subscribeExecutionEvents(ctx, child.ExecutionID)
+	defer unsubscribe()
+
+	finished, err := waitForExecution(ctx, events, subWorkflowPollInterval, func(ctx context.Context) (*ent.WorkflowExecution, error) {
+		return database.Client.WorkflowExecution.Get(ctx, child.ID)
+	}, nil)
+	if err != nil {
+		if ctx.Err() != nil {
+			// 节点已超时或被取消，子执行不再有人等待，将其结束后再返回。ctx 已取消，使用不随其取消且有时限的上下文，
+			// 调用方（runWithNodeTimeout）会等待本函数返回，写入不会在调用方结束后发生
+			stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), subWorkflowStopTimeout)
+			defer cancel()
+			if _, finishErr := (WorkflowFuncs{}).FinishWorkflowExecution(stopCtx, child.ID, &models.UpdateWorkflowExecutionRequest{
+				Status:       string(workflowexecution.StatusTimeout),
+				ErrorMessage: "parent node timed out",
+			}); finishErr != nil {
+				logging.Warn("Failed to stop sub-workflow execution %d: %v", child.ID, finishErr)
+			}
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+
+	if finished.Status != workflowexecution.StatusCompleted {
+		message := finished.ErrorMessage
+		if message == "" {
+			message = string(finished.Status)
+		}
+		return nil, fmt.Errorf("sub-workflow execution %s %s: %s", finished.ExecutionID, finished.Status, message)
+	}
+	return applySubWorkflowMapping(finished.Output, node.Config["output_mapping"]), nil
+}
@@ -0,0 +1,272 @@
+package funcs
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowexecution"
+	"go-backend/database/ent/workflownodeexecution"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// setupSubWorkflowTest 准备测试数据库，并将子执行的创建替换为直接插入记录（不经过配置和队列），
+// 每个子执行创建后交给 engine 在后台运行；清理时等待所有 engine 结束后才恢复 database.Client 和关闭数据库
+func setupSubWorkflowTest(t *testing.T, engine func(ctx context.Context, execution *ent.WorkflowExecution)) (*ent.Client, *stdsql.DB) {
+	t.Helper()
+	client, db := newWorkflowDeleteTestClient(t)
+	// 子执行在后台与父节点并发读写，共享缓存的内存数据库遇到并发写入时直接返回 table is locked 而不是等待，
+	// 因此只使用一个连接，让各个 goroutine 的数据库访问排队进行
+	db.SetMaxOpenConns(1)
+	previousClient, previousStart := database.Client, startSubWorkflowExecution
+	previousInterval, previousDepth := subWorkflowPollInterval, subWorkflowMaxDepth
+	database.Client = client
+	subWorkflowPollInterval = 10 * time.Millisecond
+	var engines sync.WaitGroup
+	t.Cleanup(func() {
+		engines.Wait()
+		database.Client, startSubWorkflowExecution = previousClient, previousStart
+		subWorkflowPollInterval, subWorkflowMaxDepth = previousInterval, previousDepth
+	})
+
+	var mu sync.Mutex
+	nextID := uint64(500)
+	startSubWorkflowExecution = func(ctx context.Context, req *models.CreateWorkflowExecutionRequest, parentExecutionID uint64) (*ent.WorkflowExecution, error) {
+		applicationID := utils.StringToUint64(req.ApplicationID)
+		snapshot, err := snapshotWorkflowGraph(ctx, applicationID)
+		if err != nil {
+			return nil, err
+		}
+		mu.Lock()
+		nextID++
+		id := nextID
+		mu.Unlock()
+		execution, err := client.WorkflowExecution.Create().
+			SetID(id).
+			SetExecutionID(utils.Uint64ToString(id)).
+			SetApplicationID(applicationID).
+			SetStatus(workflowexecution.StatusPending).
+			SetGraphSnapshot(snapshot).
+			SetInput(req.Input).
+			SetTriggeredBy(req.TriggeredBy).
+			SetTriggerSource(req.TriggerSource).
+			SetParentExecutionID(parentExecutionID).
+			Save(ctx)
+		if err != nil {
+			return nil, err
+		}
+		engines.Add(1)
+		go func() {
+			defer engines.Done()
+			engine(context.Background(), execution)
+		}()
+		return execution, nil
+	}
+	return client, db
+}
+
+// insertSubWorkflowNode 在应用中插入引用 targetAppID 的子工作流节点
+func insertSubWorkflowNode(t *testing.T, client *ent.Client, db *stdsql.DB, id, appID, targetAppID uint64, config map[string]interface{}) *ent.WorkflowNode {
+	t.Helper()
+	insertTestRow(t, db, "workflow_nodes", map[string]any{
+		"id": id, "application_id": appID, "name": fmt.Sprintf("sub-%d", id), "node_key": fmt.Sprintf("sub-%d", id), "type": "workflow", "workflow_application_id": targetAppID,
+	})
+	if config != nil {
+		client.WorkflowNode.UpdateOneID(id).SetConfig(config).ExecX(context.Background())
+	}
+	return client.WorkflowNode.GetX(context.Background(), id)
+}
+
+func TestSubWorkflowTwoLevelComposition(t *testing.T) {
+	var client *ent.Client
+	client, db := setupSubWorkflowTest(t, func(ctx context.Context, execution *ent.WorkflowExecution) {
+		if _, err := (WorkflowFuncs{}).StartWorkflowExecution(ctx, execution.ID); err != nil {
+			t.Errorf("start child failed: %v", err)
+			return
+		}
+		req := &models.UpdateWorkflowExecutionRequest{}
+		switch execution.ApplicationID {
+		case 2:
+			// 中间层应用自身又通过子工作流节点调用应用3
+			node := client.WorkflowNode.GetX(ctx, 26)
+			result, err := runNodeExecution(ctx, execution.ID, node, execution.Input, nil, 5*time.Second)
+			if err != nil || result.NodeExecution.Status != workflownodeexecution.StatusCompleted {
+				t.Errorf("nested sub-workflow node failed: %v %+v", err, result)
+				return
+			}
+			req.Output = result.Output
+		case 3:
+			question, _ := execution.Input["question"].(string)
+			req.Output = map[string]interface{}{"answer": question + "!", "tokens": 3}
+		}
+		if _, err := (WorkflowFuncs{}).FinishWorkflowExecution(ctx, execution.ID, req); err != nil {
+			t.Errorf("finish child failed: %v", err)
+		}
+	})
+	for _, appID := range []uint64{1, 2, 3} {
+		seedWorkflowApplication(t, db, appID)
+	}
+	node := insertSubWorkflowNode(t, client, db, 16, 1, 2, map[string]interface{}{
+		"input_mapping":  map[string]interface{}{"question": "form.q"},
+		"output_mapping": map[string]interface{}{"result": "answer"},
+	})
+	insertSubWorkflowNode(t, client, db, 26, 2, 3, nil)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-root", "application_id": 1, "status": "running"})
+	ctx := context.Background()
+
+	result, err := runNodeExecution(ctx, 100, node, map[string]interface{}{"form": map[string]interface{}{"q": "hi"}}, nil, 5*time.Second)
+	if err != nil {
+		t.Fatalf("run sub-workflow node failed: %v", err)
+	}
+	if result.NodeExecution.Status != workflownodeexecution.StatusCompleted {
+		t.Fatalf("sub-workflow node should complete: %+v", result.NodeExecution)
+	}
+	if len(result.Output) != 1 || result.Output["result"] != "hi!" {
+		t.Fatalf("output should be mapped from the grandchild, got %v", result.Output)
+	}
+
+	child := client.WorkflowExecution.Query().Where(workflowexecution.ParentExecutionIDEQ(100)).OnlyX(ctx)
+	if child.ApplicationID != 2 || child.TriggerSource != SubWorkflowTriggerSource || child.TriggeredBy != "exec-root" {
+		t.Fatalf("child should be linked to the caller: %+v", child)
+	}
+	if child.Status != workflowexecution.StatusCompleted || child.Input["question"] != "hi" {
+		t.Fatalf("child should receive the mapped input and complete: %+v", child)
+	}
+	grandchild := client.WorkflowExecution.Query().Where(workflowexecution.ParentExecutionIDEQ(child.ID)).OnlyX(ctx)
+	if grandchild.ApplicationID != 3 || grandchild.Status != workflowexecution.StatusCompleted {
+		t.Fatalf("grandchild should run application 3: %+v", grandchild)
+	}
+	if response := (WorkflowFuncs{}).ConvertWorkflowExecutionToResponse(grandchild); response.ParentExecutionID != utils.Uint64ToString(child.ID) {
+		t.Fatalf("response should expose the parent execution id, got %q", response.ParentExecutionID)
+	}
+}
+
+func TestSubWorkflowRecursionGuard(t *testing.T) {
+	client, db := setupSubWorkflowTest(t, func(ctx context.Context, execution *ent.WorkflowExecution) {
+		t.Errorf("no child execution should be started, got %+v", execution)
+	})
+	for _, appID := range []uint64{1, 2, 3} {
+		seedWorkflowApplication(t, db, appID)
+	}
+	// 执行链路：应用1（200） -> 应用2（201）
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 200, "execution_id": "exec-a", "application_id": 1, "status": "running"})
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 201, "execution_id": "exec-b", "application_id": 2, "status": "running", "parent_execution_id": 200})
+	ctx := context.Background()
+
+	// 应用2再调用应用1，形成 A -> B -> A
+	back := insertSubWorkflowNode(t, client, db, 26, 2, 1, nil)
+	result, err := runNodeExecution(ctx, 201, back, map[string]interface{}{"q": "hi"}, nil, 5*time.Second)
+	if err != nil {
+		t.Fatalf("run node failed: %v", err)
+	}
+	if result.NodeExecution.Status != workflownodeexecution.StatusFailed || !strings.Contains(result.NodeExecution.ErrorMessage, "cycle") {
+		t.Fatalf("cyclic sub-workflow should fail the node: %+v", result.NodeExecution)
+	}
+	if _, err := runSubWorkflow(ctx, 201, back, nil); !IsSubWorkflowRecursion(err) {
+		t.Fatalf("expected recursion error, got %v", err)
+	}
+
+	// 应用调用自身
+	self := insertSubWorkflowNode(t, client, db, 16, 1, 1, nil)
+	if _, err := runSubWorkflow(ctx, 200, self, nil); !IsSubWorkflowRecursion(err) {
+		t.Fatalf("self reference should be rejected, got %v", err)
+	}
+
+	// 不成环但超出嵌套深度：链路已有1层子执行，再调用应用3的深度为2
+	subWorkflowMaxDepth = 1
+	deep := insertSubWorkflowNode(t, client, db, 27, 2, 3, nil)
+	if _, err := runSubWorkflow(ctx, 201, deep, nil); !IsSubWorkflowRecursion(err) || !strings.Contains(err.Error(), "depth") {
+		t.Fatalf("expected depth limit error, got %v", err)
+	}
+
+	if count := client.WorkflowExecution.Query().CountX(ctx); count != 2 {
+		t.Fatalf("guard should not create child executions, got %d executions", count)
+	}
+}
+
+func TestSubWorkflowTimeoutStopsChildBeforeReturning(t *testing.T) {
+	release := make(chan struct{})
+	client, db := setupSubWorkflowTest(t, func(ctx context.Context, execution *ent.WorkflowExecution) {
+		if _, err := (WorkflowFuncs{}).StartWorkflowExecution(ctx, execution.ID); err != nil {
+			t.Errorf("start child failed: %v", err)
+		}
+		// 子执行一直不结束，直到测试结束
+		<-release
+	})
+	t.Cleanup(func() { close(release) })
+	for _, appID := range []uint64{1, 2} {
+		seedWorkflowApplication(t, db, appID)
+	}
+	node := insertSubWorkflowNode(t, client, db, 16, 1, 2, nil)
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-root", "application_id": 1, "status": "running"})
+	ctx := context.Background()
+
+	result, err := runNodeExecution(ctx, 100, node, nil, nil, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("run sub-workflow node failed: %v", err)
+	}
+	if !result.TimedOut || result.NodeExecution.Status != workflownodeexecution.StatusTimeout {
+		t.Fatalf("sub-workflow node should time out: %+v", result.NodeExecution)
+	}
+
+	// 节点返回时子执行已被结束，不依赖后台写入
+	child := client.WorkflowExecution.Query().Where(workflowexecution.ParentExecutionIDEQ(100)).OnlyX(ctx)
+	if child.Status != workflowexecution.StatusTimeout || child.ErrorMessage != "parent node timed out" {
+		t.Fatalf("child should be stopped when the parent node times out: %+v", child)
+	}
+}
+
+func TestSubWorkflowRequiresOwnerReadAccess(t *testing.T) {
+	client, db := setupSubWorkflowTest(t, func(ctx context.Context, execution *ent.WorkflowExecution) {
+		t.Errorf("no child execution should be started, got %+v", execution)
+	})
+	for _, userID := range []int{1, 2} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	// 应用1属于用户1，应用2、3属于用户2且应用3共享给用户1只读，应用4、5没有所有者
+	for appID, owner := range map[uint64]any{1: 1, 2: 2, 3: 2, 4: nil, 5: nil} {
+		seedWorkflowApplication(t, db, appID)
+		if owner != nil {
+			if _, err := db.Exec("UPDATE workflow_applications SET owner_id = ? WHERE id = ?", owner, appID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	insertTestRow(t, db, "workflow_application_shares", map[string]any{"id": 900, "application_id": 3, "user_id": 1, "access": "read"})
+	insertTestRow(t, db, "workflow_executions", map[string]any{"id": 100, "execution_id": "exec-root", "application_id": 1, "status": "running"})
+	ctx := context.Background()
+
+	// 克隆等途径可能复制出引用其他用户私有应用的节点，运行时按父应用的所有者拒绝
+	private := insertSubWorkflowNode(t, client, db, 16, 1, 2, nil)
+	if _, err := runSubWorkflow(ctx, 100, private, nil); !IsWorkflowAccessDenied(err) {
+		t.Fatalf("expected access denied for another user's private application, got %v", err)
+	}
+	if count := client.WorkflowExecution.Query().CountX(ctx); count != 1 {
+		t.Fatalf("no child execution should be created, got %d executions", count)
+	}
+
+	for _, tc := range []struct {
+		parent, target uint64
+		allowed        bool
+	}{
+		{parent: 1, target: 3, allowed: true},  // 共享给父应用所有者
+		{parent: 1, target: 4, allowed: true},  // 没有所有者的应用对所有人可见
+		{parent: 4, target: 5, allowed: true},  // 没有所有者的应用之间
+		{parent: 4, target: 2, allowed: false}, // 没有所有者的应用不能引用私有应用
+	} {
+		err := checkSubWorkflowAccess(ctx, tc.parent, tc.target)
+		if tc.allowed && err != nil {
+			t.Fatalf("application %d should be able to call %d: %v", tc.parent, tc.target, err)
+		}
+		if !tc.allowed && !IsWorkflowAccessDenied(err) {
+			t.Fatalf("application %d should not be able to call %d, got %v", tc.parent, tc.target, err)
+		}
+	}
+}
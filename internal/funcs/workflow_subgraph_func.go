@@ -0,0 +1,191 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+// errInvalidSubgraphSelection 复制子图时选中的节点无效
+const errInvalidSubgraphSelection = "invalid subgraph selection"
+
+// DuplicateSubgraph 在应用内复制选中的节点及其内部连线
+// 只复制两端都在选区内的边，跨越选区边界的边会被丢弃；分支目标在选区内的映射为副本，指向选区外的分支目标会被移除
+// 返回结果中 NodeIDMapping/EdgeIDMapping 为 原ID -> 副本ID，便于前端选中新复制的节点
+func (WorkflowFuncs) DuplicateSubgraph(ctx context.Context, applicationID uint64, nodeIDs []uint64, offset models.Position) (*models.BatchSaveWorkflowData, error) {
+	selected := uniqueNodeIDs(nodeIDs)
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("%s: no nodes selected", errInvalidSubgraphSelection)
+	}
+
+	exists, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID)).
+		Exist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check application existence: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("workflow application not found")
+	}
+
+	nodes, err := database.Client.WorkflowNode.Query().
+		Where(
+			workflownode.IDIn(selected...),
+			workflownode.ApplicationIDEQ(applicationID),
+		).
+		Order(ent.Asc(workflownode.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) != len(selected) {
+		return nil, fmt.Errorf("%s: some nodes do not exist in the application", errInvalidSubgraphSelection)
+	}
+
+	edges, err := database.Client.WorkflowEdge.Query().
+		Where(
+			workflowedge.ApplicationIDEQ(applicationID),
+			workflowedge.SourceNodeIDIn(selected...),
+			workflowedge.TargetNodeIDIn(selected...),
+		).
+		Order(ent.Asc(workflowedge.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 启用名称唯一性时副本名称添加副本后缀
+	nodeNames, err := applicationNodeNames(ctx, database.Client, applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := database.Client.Tx(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	nodeIDMap := make(map[uint64]uint64, len(nodes)) // 原ID -> 副本ID
+	for _, node := range nodes {
+		newNode, err := cloneWorkflowNodeCreate(tx, node, applicationID).
+			SetName(claimNodeName(node.Name, nodeNames)).
+			SetPositionX(node.PositionX + offset.X).
+			SetPositionY(node.PositionY + offset.Y).
+			Save(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to duplicate node %d: %w", node.ID, err)
+		}
+		nodeIDMap[node.ID] = newNode.ID
+	}
+
+	for _, node := range nodes {
+		if node.BranchNodes == nil {
+			continue
+		}
+		err = tx.WorkflowNode.UpdateOneID(nodeIDMap[node.ID]).
+			SetBranchNodes(remapBranchNodes(node.BranchNodes, nodeIDMap)).
+			Exec(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to remap branch nodes: %w", err)
+		}
+	}
+
+	edgeIDMap := make(map[uint64]uint64, len(edges))
+	for _, edge := range edges {
+		sourceID, targetID, ok := remapEdgeEndpoints(edge, nodeIDMap)
+		if !ok {
+			continue
+		}
+		newEdge, err := cloneWorkflowEdgeCreate(tx, edge, applicationID, sourceID, targetID).Save(ctx)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("failed to duplicate edge %d: %w", edge.ID, err)
+		}
+		edgeIDMap[edge.ID] = newEdge.ID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return buildDuplicateSubgraphData(ctx, nodeIDMap, edgeIDMap)
+}
+
+// buildDuplicateSubgraphData 查询复制出的节点和边并组装返回数据
+func buildDuplicateSubgraphData(ctx context.Context, nodeIDMap, edgeIDMap map[uint64]uint64) (*models.BatchSaveWorkflowData, error) {
+	result := &models.BatchSaveWorkflowData{
+		NodeIDMapping:  make(map[string]string, len(nodeIDMap)),
+		EdgeIDMapping:  make(map[string]string, len(edgeIDMap)),
+		CreatedNodes:   make([]*models.WorkflowNodeResponse, 0, len(nodeIDMap)),
+		UpdatedNodes:   make([]*models.WorkflowNodeResponse, 0),
+		DeletedNodeIDs: make([]string, 0),
+		CreatedEdges:   make([]*models.WorkflowEdgeResponse, 0, len(edgeIDMap)),
+		UpdatedEdges:   make([]*models.WorkflowEdgeResponse, 0),
+		DeletedEdgeIDs: make([]string, 0),
+	}
+
+	newNodeIDs := make([]uint64, 0, len(nodeIDMap))
+	for oldID, newID := range nodeIDMap {
+		result.NodeIDMapping[utils.Uint64ToString(oldID)] = utils.Uint64ToString(newID)
+		newNodeIDs = append(newNodeIDs, newID)
+	}
+	newEdgeIDs := make([]uint64, 0, len(edgeIDMap))
+	for oldID, newID := range edgeIDMap {
+		result.EdgeIDMapping[utils.Uint64ToString(oldID)] = utils.Uint64ToString(newID)
+		newEdgeIDs = append(newEdgeIDs, newID)
+	}
+
+	nodes, err := database.Client.WorkflowNode.Query().
+		Where(workflownode.IDIn(newNodeIDs...)).
+		Order(ent.Asc(workflownode.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, node := range nodes {
+		result.CreatedNodes = append(result.CreatedNodes, WorkflowFuncs{}.ConvertWorkflowNodeToResponse(node))
+	}
+
+	if len(newEdgeIDs) > 0 {
+		edges, err := database.Client.WorkflowEdge.Query().
+			Where(workflowedge.IDIn(newEdgeIDs...)).
+			Order(ent.Asc(workflowedge.FieldID)).
+			All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range edges {
+			result.CreatedEdges = append(result.CreatedEdges, WorkflowFuncs{}.ConvertWorkflowEdgeToResponse(edge))
+		}
+	}
+
+	result.Stats.NodesCreated = len(result.CreatedNodes)
+	result.Stats.EdgesCreated = len(result.CreatedEdges)
+	return result, nil
+}
+
+// uniqueNodeIDs 去除重复和为0的节点ID，保持原有顺序
+func uniqueNodeIDs(ids []uint64) []uint64 {
+	seen := make(map[uint64]struct{}, len(ids))
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
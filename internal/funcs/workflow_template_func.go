@@ -0,0 +1,94 @@
+package funcs
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-backend/database/ent"
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/pkg/database"
+	"go-backend/shared/models"
+)
+
+// 模板：标记为模板的应用作为起始工作流展示在模板列表中，不能直接执行；
+// 实例化时复制为普通的草稿应用（创建人为当前用户），之后像普通应用一样编辑和执行。
+// 模板与普通应用一样受所有权和共享限制，用户只能看到和实例化自己可以读取的模板
+
+// errWorkflowTemplateNotExecutable 模板不能直接执行
+const errWorkflowTemplateNotExecutable = "workflow template cannot be executed"
+
+// errNotWorkflowTemplate 要实例化的应用不是模板
+const errNotWorkflowTemplate = "workflow application is not a template"
+
+// IsWorkflowTemplateNotExecutable 判断错误是否为尝试直接执行模板
+func IsWorkflowTemplateNotExecutable(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errWorkflowTemplateNotExecutable)
+}
+
+// IsNotWorkflowTemplate 判断错误是否为要实例化的应用不是模板
+func IsNotWorkflowTemplate(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), errNotWorkflowTemplate)
+}
+
+// ListTemplates 获取用户可见的模板，按名称排序
+func (WorkflowFuncs) ListTemplates(ctx context.Context, scope WorkflowAccessScope) ([]*models.WorkflowApplicationResponse, error) {
+	query := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.IsTemplate(true))
+	if visible := scope.visibleApplications(); visible != nil {
+		query = query.Where(visible)
+	}
+	templates, err := query.
+		Order(ent.Asc(workflowapplication.FieldName), ent.Asc(workflowapplication.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.WorkflowApplicationResponse, 0, len(templates))
+	for _, template := range templates {
+		result = append(result, WorkflowFuncs{}.ConvertWorkflowApplicationToResponse(template))
+	}
+	return result, nil
+}
+
+// InstantiateTemplate 从模板创建普通的草稿应用，节点、边和分支目标的映射与克隆应用相同。
+// name 为空时使用模板名称（启用名称唯一性时自动添加副本后缀）；用户需要能读取模板，创建的应用归该用户所有
+func (WorkflowFuncs) InstantiateTemplate(ctx context.Context, scope WorkflowAccessScope, templateID uint64, name string) (*models.WorkflowApplicationResponse, error) {
+	if err := (WorkflowFuncs{}).CheckWorkflowApplicationAccess(ctx, scope, templateID, WorkflowAccessRead); err != nil {
+		return nil, err
+	}
+	template, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(templateID)).
+		Select(workflowapplication.FieldName, workflowapplication.FieldIsTemplate).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("workflow application not found")
+		}
+		return nil, err
+	}
+	if !template.IsTemplate {
+		return nil, fmt.Errorf("%s: %d", errNotWorkflowTemplate, templateID)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = template.Name
+	}
+	return WorkflowFuncs{}.CloneWorkflowApplication(ctx, scope.UserID, templateID, name)
+}
+
+// checkNotWorkflowTemplate 创建执行前检查应用不是模板，应用不存在时交由后续的检查报告
+func checkNotWorkflowTemplate(ctx context.Context, applicationID uint64) error {
+	isTemplate, err := database.Client.WorkflowApplication.Query().
+		Where(workflowapplication.ID(applicationID), workflowapplication.IsTemplate(true)).
+		Exist(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query application: %w", err)
+	}
+	if isTemplate {
+		return fmt.Errorf("%s: instantiate template %d first", errWorkflowTemplateNotExecutable, applicationID)
+	}
+	return nil
+}
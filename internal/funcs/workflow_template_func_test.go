@@ -0,0 +1,169 @@
+package funcs
+
+import (
+	"context"
+	"testing"
+
+	"go-backend/database/ent/workflowapplication"
+	"go-backend/database/ent/workflowedge"
+	"go-backend/database/ent/workflownode"
+	"go-backend/pkg/database"
+	"go-backend/pkg/utils"
+	"go-backend/shared/models"
+)
+
+func TestInstantiateTemplateRemapsGraph(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	ctx := context.Background()
+	seedWorkflowApplication(t, db, 1)
+	seedWorkflowApplication(t, db, 2)
+
+	original := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = original })
+
+	// 模板：start 为条件节点，分支指向 end
+	client.WorkflowApplication.UpdateOneID(1).SetIsTemplate(true).SetStartNodeID(11).ExecX(ctx)
+	client.WorkflowNode.UpdateOneID(11).
+		SetBranchNodes(map[string]interface{}{"yes": map[string]interface{}{"name": "yes", "targetNodeId": "12"}}).
+		ExecX(ctx)
+
+	templates, err := WorkflowFuncs{}.ListTemplates(ctx, WorkflowAccessScope{Admin: true})
+	if err != nil {
+		t.Fatalf("list templates failed: %v", err)
+	}
+	if len(templates) != 1 || templates[0].ID != "1" || !templates[0].IsTemplate {
+		t.Fatalf("unexpected templates: %+v", templates)
+	}
+
+	app, err := WorkflowFuncs{}.InstantiateTemplate(ctx, WorkflowAccessScope{Admin: true}, 1, "")
+	if err != nil {
+		t.Fatalf("instantiate failed: %v", err)
+	}
+	if app.Name != "app-1" || app.IsTemplate || app.Status != string(workflowapplication.StatusDraft) {
+		t.Fatalf("expected a draft application named after the template, got %+v", app)
+	}
+
+	newID := utils.StringToUint64(app.ID)
+	nodes := client.WorkflowNode.Query().Where(workflownode.ApplicationIDEQ(newID)).AllX(ctx)
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(nodes))
+	}
+	ids := map[string]uint64{}
+	for _, node := range nodes {
+		if node.ID == 11 || node.ID == 12 {
+			t.Fatalf("instantiated nodes must be new rows, got %d", node.ID)
+		}
+		ids[node.Name] = node.ID
+	}
+	if app.StartNodeID != utils.Uint64ToString(ids["start"]) {
+		t.Fatalf("start node should be remapped, got %s", app.StartNodeID)
+	}
+	start := client.WorkflowNode.GetX(ctx, ids["start"])
+	branch := start.BranchNodes["yes"].(map[string]interface{})
+	if branch["targetNodeId"] != utils.Uint64ToString(ids["end"]) {
+		t.Fatalf("branch target should be remapped, got %v", branch["targetNodeId"])
+	}
+	edge := client.WorkflowEdge.Query().Where(workflowedge.ApplicationIDEQ(newID)).OnlyX(ctx)
+	if edge.SourceNodeID != ids["start"] || edge.TargetNodeID != ids["end"] {
+		t.Fatalf("edge endpoints should be remapped, got %d -> %d", edge.SourceNodeID, edge.TargetNodeID)
+	}
+
+	// 模板本身不变，也不能直接执行；实例化出的应用可以执行
+	if n := client.WorkflowNode.Query().Where(workflownode.ApplicationIDEQ(1)).CountX(ctx); n != 2 {
+		t.Fatalf("template nodes should be untouched, got %d", n)
+	}
+	if _, err := (WorkflowFuncs{}).CreateWorkflowExecution(ctx, &models.CreateWorkflowExecutionRequest{ApplicationID: "1"}); !IsWorkflowTemplateNotExecutable(err) {
+		t.Fatalf("expected template execution to be rejected, got %v", err)
+	}
+	if err := checkNotWorkflowTemplate(ctx, newID); err != nil {
+		t.Fatalf("instantiated application should be executable, got %v", err)
+	}
+
+	named, err := WorkflowFuncs{}.InstantiateTemplate(ctx, WorkflowAccessScope{Admin: true}, 1, "  my flow ")
+	if err != nil || named.Name != "my flow" {
+		t.Fatalf("expected the given name to be used, got %+v %v", named, err)
+	}
+	if _, err := (WorkflowFuncs{}).InstantiateTemplate(ctx, WorkflowAccessScope{Admin: true}, 2, ""); !IsNotWorkflowTemplate(err) {
+		t.Fatalf("expected non-template to be rejected, got %v", err)
+	}
+	if _, err := (WorkflowFuncs{}).InstantiateTemplate(ctx, WorkflowAccessScope{Admin: true}, 99, ""); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	// 分页接口按是否为模板过滤
+	isTemplate := false
+	page, err := WorkflowFuncs{}.GetWorkflowApplicationsWithPagination(ctx, WorkflowAccessScope{Admin: true}, &models.PageWorkflowApplicationRequest{
+		PaginationRequest: models.PaginationRequest{Page: 1, PageSize: 10},
+		IsTemplate:        &isTemplate,
+	})
+	if err != nil {
+		t.Fatalf("page failed: %v", err)
+	}
+	for _, item := range page.Data {
+		if item.IsTemplate {
+			t.Fatalf("templates should be filtered out: %+v", item)
+		}
+	}
+	if len(page.Data) != 3 {
+		t.Fatalf("expected 3 non-template applications, got %d", len(page.Data))
+	}
+}
+
+func TestWorkflowTemplatesRespectOwnership(t *testing.T) {
+	client, db := newWorkflowDeleteTestClient(t)
+	original := database.Client
+	database.Client = client
+	t.Cleanup(func() { database.Client = original })
+
+	for _, userID := range []int{1, 2} {
+		insertTestRow(t, db, "sys_users", map[string]any{"id": userID, "name": "user", "status": "active"})
+	}
+	// 模板1属于用户1，模板2属于用户2且共享给用户1只读，模板3没有所有者
+	for appID, owner := range map[uint64]any{1: 1, 2: 2, 3: nil} {
+		seedWorkflowApplication(t, db, appID)
+		if _, err := db.Exec("UPDATE workflow_applications SET is_template = 1, owner_id = ? WHERE id = ?", owner, appID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	insertTestRow(t, db, "workflow_application_shares", map[string]any{"id": 900, "application_id": 2, "user_id": 1, "access": "read"})
+	ctx := context.Background()
+	user1 := WorkflowAccessScope{UserID: 1}
+	user2 := WorkflowAccessScope{UserID: 2}
+
+	templateIDs := func(scope WorkflowAccessScope) []string {
+		t.Helper()
+		templates, err := WorkflowFuncs{}.ListTemplates(ctx, scope)
+		if err != nil {
+			t.Fatalf("list templates failed: %v", err)
+		}
+		ids := make([]string, 0, len(templates))
+		for _, template := range templates {
+			ids = append(ids, template.ID)
+		}
+		return ids
+	}
+	if ids := templateIDs(user1); len(ids) != 3 {
+		t.Fatalf("user 1 should see own, shared and unowned templates, got %v", ids)
+	}
+	if ids := templateIDs(user2); len(ids) != 2 || ids[0] != "2" || ids[1] != "3" {
+		t.Fatalf("user 2 should not see user 1's private template, got %v", ids)
+	}
+
+	// 不能读取的模板与不存在的模板一样返回 not found，不复制任何节点
+	before := client.WorkflowNode.Query().CountX(ctx)
+	if _, err := (WorkflowFuncs{}).InstantiateTemplate(ctx, user2, 1, ""); err == nil || err.Error() != "workflow application not found" {
+		t.Fatalf("expected not found for a private template, got %v", err)
+	}
+	if after := client.WorkflowNode.Query().CountX(ctx); after != before {
+		t.Fatalf("no nodes should be cloned, got %d -> %d", before, after)
+	}
+
+	app, err := WorkflowFuncs{}.InstantiateTemplate(ctx, user1, 2, "from shared")
+	if err != nil {
+		t.Fatalf("instantiate shared template failed: %v", err)
+	}
+	if app.OwnerID != "1" {
+		t.Fatalf("instantiated application should belong to user 1, got %+v", app)
+	}
+}
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"go-backend/database/ent"
 	"go-backend/internal/funcs"
 	"go-backend/internal/middleware"
 	"go-backend/pkg/logging"
 	"go-backend/shared/models"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -60,6 +64,7 @@ func (h *AuthHandler) SendVerifyCode(c *gin.Context) {
 // @Param        request body models.VerifyCodeRequest true "验证验证码请求"
 // @Success      200 {object} models.VerifyCodeResponse
 // @Failure      400 {object} object{success=bool,message=string}
+// @Failure      429 {object} object{success=bool,message=string}
 // @Failure      500 {object} object{success=bool,message=string}
 // @Router       /auth/verify-code [post]
 func (h *AuthHandler) VerifyCode(c *gin.Context) {
@@ -70,6 +75,10 @@ func (h *AuthHandler) VerifyCode(c *gin.Context) {
 	}
 
 	err := funcs.VerifyCodeFuncs{}.VerifyCode(middleware.GetRequestContext(c), req.SenderType, req.Purpose, req.Identifier, req.Code)
+	if errors.Is(err, funcs.ErrVerifyCodeTooManyAttempts) {
+		middleware.ThrowError(c, middleware.TooManyRequestsError("验证码验证失败", err.Error()))
+		return
+	}
 	if err != nil {
 		middleware.ThrowError(c, middleware.BusinessError("验证码验证失败", err.Error()))
 		return
@@ -93,6 +102,7 @@ func (h *AuthHandler) VerifyCode(c *gin.Context) {
 // @Success      200 {object} models.LoginResponse
 // @Failure      400 {object} object{success=bool,message=string}
 // @Failure      401 {object} object{success=bool,message=string}
+// @Failure      429 {object} object{success=bool,message=string}
 // @Failure      500 {object} object{success=bool,message=string}
 // @Router       /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -122,21 +132,301 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		req.ClientCode,
 	)
 	if err != nil {
-		middleware.ThrowError(c, middleware.UnauthorizedError("登录失败", err.Error()))
+		throwLoginError(c, err)
+		return
+	}
+
+	h.writeLoginResponse(c, user, req.RememberMe)
+}
+
+// FlexibleLogin 灵活登录
+// @Summary      灵活登录
+// @Description  使用邮箱、手机号或用户名登录，服务端按配置的顺序识别标识符类型，提供验证码时走验证码登录，否则校验密码
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.FlexibleLoginRequest true "灵活登录请求"
+// @Success      200 {object} models.LoginResponse
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      429 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/login/flexible [post]
+func (h *AuthHandler) FlexibleLogin(c *gin.Context) {
+	var req models.FlexibleLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	if req.Secret == "" && req.VerifyCode == "" {
+		middleware.ThrowError(c, middleware.ValidationError("必须提供密码或验证码", ""))
+		return
+	}
+
+	user, err := funcs.AuthFuncs{}.UserLoginFlexible(
+		middleware.GetRequestContext(c),
+		c,
+		req.Identifier,
+		req.Secret,
+		req.VerifyCode,
+		req.ClientCode,
+	)
+	if err != nil {
+		throwLoginError(c, err)
+		return
+	}
+
+	h.writeLoginResponse(c, user, req.RememberMe)
+}
+
+// BeginWebAuthnRegistration 开始注册通行密钥
+// @Summary      开始注册通行密钥
+// @Description  为当前用户生成注册通行密钥（WebAuthn）的参数，前端将其传给 navigator.credentials.create
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} object{success=bool,data=object} "data 为 PublicKeyCredentialCreationOptions"
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/webauthn/register/begin [post]
+func (h *AuthHandler) BeginWebAuthnRegistration(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
 		return
 	}
 
+	options, err := funcs.AuthFuncs{}.BeginWebAuthnRegistration(middleware.GetRequestContext(c), userID)
+	if err != nil {
+		throwWebAuthnError(c, "生成通行密钥注册参数失败", err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    options,
+	})
+}
+
+// FinishWebAuthnRegistration 完成注册通行密钥
+// @Summary      完成注册通行密钥
+// @Description  校验认证器创建的凭据并保存为当前用户的通行密钥
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request body models.WebAuthnRegistrationRequest true "注册通行密钥请求"
+// @Success      200 {object} object{success=bool,data=models.WebAuthnCredentialResponse,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/webauthn/register/finish [post]
+func (h *AuthHandler) FinishWebAuthnRegistration(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req models.WebAuthnRegistrationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	result, err := funcs.AuthFuncs{}.FinishWebAuthnRegistration(middleware.GetRequestContext(c), userID, &req)
+	if err != nil {
+		throwWebAuthnError(c, "注册通行密钥失败", err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "通行密钥注册成功",
+	})
+}
+
+// BeginWebAuthnLogin 开始通行密钥登录
+// @Summary      开始通行密钥登录
+// @Description  生成通行密钥登录的参数，前端将其传给 navigator.credentials.get；提供标识符时只允许该用户的通行密钥
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.WebAuthnLoginBeginRequest false "开始通行密钥登录请求"
+// @Success      200 {object} object{success=bool,data=object} "data 为 PublicKeyCredentialRequestOptions"
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/webauthn/login/begin [post]
+func (h *AuthHandler) BeginWebAuthnLogin(c *gin.Context) {
+	var req models.WebAuthnLoginBeginRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+			return
+		}
+	}
+
+	options, err := funcs.AuthFuncs{}.BeginWebAuthnLogin(middleware.GetRequestContext(c), req.Identifier)
+	if err != nil {
+		throwWebAuthnError(c, "生成通行密钥登录参数失败", err)
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    options,
+	})
+}
+
+// FinishWebAuthnLogin 完成通行密钥登录
+// @Summary      完成通行密钥登录
+// @Description  校验认证器的签名并登录，与其他登录方式一样校验终端角色并记录登录日志
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.WebAuthnLoginRequest true "通行密钥登录请求"
+// @Success      200 {object} models.LoginResponse
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      429 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/webauthn/login/finish [post]
+func (h *AuthHandler) FinishWebAuthnLogin(c *gin.Context) {
+	var req models.WebAuthnLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	user, err := funcs.AuthFuncs{}.FinishWebAuthnLogin(middleware.GetRequestContext(c), c, &req)
+	if err != nil {
+		if funcs.IsWebAuthnDisabled(err) {
+			middleware.ThrowError(c, middleware.ServiceUnavailableError(err.Error(), nil))
+			return
+		}
+		throwLoginError(c, err)
+		return
+	}
+
+	h.writeLoginResponse(c, user, req.RememberMe)
+}
+
+// RequestMagicLink 发送魔法链接
+// @Summary      发送魔法链接
+// @Description  向已绑定的邮箱发送一次性登录链接，链接短时有效且只能在请求的终端上使用一次；邮箱未绑定用户时同样返回成功
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.MagicLinkRequest true "发送魔法链接请求"
+// @Success      200 {object} object{success=bool,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/magic-link/request [post]
+func (h *AuthHandler) RequestMagicLink(c *gin.Context) {
+	var req models.MagicLinkRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	err := funcs.AuthFuncs{}.RequestMagicLink(middleware.GetRequestContext(c), req.Email, req.ClientCode)
+	if err != nil {
+		if funcs.IsMagicLinkDisabled(err) {
+			middleware.ThrowError(c, middleware.ServiceUnavailableError(err.Error(), nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.BusinessError("发送登录链接失败", err.Error()))
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "如果该邮箱已绑定账号，登录链接已发送",
+	})
+}
+
+// ConsumeMagicLink 魔法链接登录
+// @Summary      魔法链接登录
+// @Description  使用登录链接中的一次性令牌登录，与其他登录方式一样校验终端角色并记录登录日志
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.MagicLinkLoginRequest true "魔法链接登录请求"
+// @Success      200 {object} models.LoginResponse
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      429 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /auth/magic-link/login [post]
+func (h *AuthHandler) ConsumeMagicLink(c *gin.Context) {
+	var req models.MagicLinkLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	user, err := funcs.AuthFuncs{}.ConsumeMagicLinkWithContext(middleware.GetRequestContext(c), c, req.Token, req.ClientCode)
+	if err != nil {
+		if funcs.IsMagicLinkDisabled(err) {
+			middleware.ThrowError(c, middleware.ServiceUnavailableError(err.Error(), nil))
+			return
+		}
+		throwLoginError(c, err)
+		return
+	}
+
+	h.writeLoginResponse(c, user, req.RememberMe)
+}
+
+// throwWebAuthnError 返回通行密钥操作失败错误，未启用时返回503
+func throwWebAuthnError(c *gin.Context, message string, err error) {
+	if funcs.IsWebAuthnDisabled(err) {
+		middleware.ThrowError(c, middleware.ServiceUnavailableError(err.Error(), nil))
+		return
+	}
+	middleware.ThrowError(c, middleware.BusinessError(message, err.Error()))
+}
+
+// IntrospectToken 访问令牌自省
+// @Summary      访问令牌自省
+// @Description  校验请求头中的访问令牌，返回是否有效以及用户ID、终端ID、过期时间和记住我标记；令牌缺失、无效、已过期或会话已被撤销时返回 active=false
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} object{success=bool,data=models.TokenIntrospectionResponse}
+// @Router       /auth/token/introspect [get]
+func (h *AuthHandler) IntrospectToken(c *gin.Context) {
+	tokenString := strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer "))
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    funcs.AuthFuncs{}.IntrospectToken(c.Request.Context(), tokenString),
+	})
+}
+
+// throwLoginError 返回登录失败错误，来源IP被临时封禁时返回429
+func throwLoginError(c *gin.Context, err error) {
+	if errors.Is(err, funcs.ErrLoginIPBlocked) {
+		middleware.ThrowError(c, middleware.TooManyRequestsError("登录尝试过于频繁", err.Error()))
+		return
+	}
+	middleware.ThrowError(c, middleware.UnauthorizedError("登录失败", err.Error()))
+}
+
+// writeLoginResponse 登录成功后构建用户信息和Token并返回
+func (h *AuthHandler) writeLoginResponse(c *gin.Context, user *ent.User, rememberMeFlag *bool) {
 	clientIdAny, ex := c.Get("client_device_id")
 	if !ex {
 		middleware.ThrowError(c, middleware.InternalServerError("找不到终端", fmt.Errorf("cannot find client_device_id in gin context")))
+		return
 	}
 
 	var clientId uint64 = clientIdAny.(uint64)
 
 	// 构建用户信息和Token
 	var rememberMe bool = false
-	if req.RememberMe != nil {
-		rememberMe = *req.RememberMe
+	if rememberMeFlag != nil {
+		rememberMe = *rememberMeFlag
 	}
 	userInfo, token, err := funcs.AuthFuncs{}.BuildUserInfoWithToken(middleware.GetRequestContext(c), user, &clientId, rememberMe)
 	if err != nil {
@@ -267,6 +557,257 @@ func (h *AuthHandler) ResetPassword(c *gin.Context) {
 	})
 }
 
+// SetInitialPassword 首次设置密码
+// @Summary      首次设置密码
+// @Description  为没有密码的用户（如通过OAuth、手机号注册）设置密码，验证码需以 set_password 用途发送到已验证的邮箱或手机号
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.SetInitialPasswordRequest true "设置密码请求"
+// @Success      200 {object} object{success=bool,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      409 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/password/set [post]
+func (h *AuthHandler) SetInitialPassword(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetInitialPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	err := funcs.AuthFuncs{}.SetInitialPassword(middleware.GetRequestContext(c), userID, req.NewPassword, req.VerifyCode)
+	if err != nil {
+		switch {
+		case err.Error() == "已设置密码":
+			middleware.ThrowError(c, middleware.ConflictError("已设置密码，请使用重置密码", nil))
+		case err.Error() == "用户不存在":
+			middleware.ThrowError(c, middleware.UserNotFoundError(err.Error()))
+		case strings.HasPrefix(err.Error(), "密码不符合安全策略"):
+			middleware.ThrowError(c, middleware.ValidationError(err.Error(), nil))
+		default:
+			middleware.ThrowError(c, middleware.BusinessError("设置密码失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "密码设置成功",
+	})
+}
+
+// ChangePassword 修改密码
+// @Summary      修改密码
+// @Description  登录状态下校验原密码后修改密码。开启撤销其他会话时，该用户其他会话的令牌全部失效，提供refreshToken的当前会话换发新令牌
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ChangePasswordRequest true "修改密码请求"
+// @Success      200 {object} object{success=bool,data=models.ChangePasswordResponse}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/password/change [post]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req models.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	token, err := funcs.AuthFuncs{}.ChangePassword(middleware.GetRequestContext(c), userID, req.OldPassword, req.NewPassword, req.RefreshToken)
+	if err != nil {
+		switch {
+		case err.Error() == "原密码错误":
+			middleware.ThrowError(c, middleware.UnauthorizedError("原密码错误", err.Error()))
+		case err.Error() == "当前会话的refreshToken无效", errors.Is(err, funcs.ErrSessionRevoked), errors.Is(err, funcs.ErrSessionDisplaced):
+			middleware.ThrowError(c, middleware.UnauthorizedError(err.Error(), nil))
+		case strings.HasPrefix(err.Error(), "密码不符合安全策略"):
+			middleware.ThrowError(c, middleware.ValidationError(err.Error(), nil))
+		default:
+			middleware.ThrowError(c, middleware.BusinessError("修改密码失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data": models.ChangePasswordResponse{
+			Token:   token,
+			Message: "密码修改成功",
+		},
+	})
+}
+
+// GetCredentials 获取当前用户的认证方式
+// @Summary      获取认证方式
+// @Description  获取当前用户绑定的所有认证方式（密码、邮箱、手机号、OAuth、TOTP、通行密钥），邮箱和手机号已脱敏，不返回密钥
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} object{success=bool,data=[]models.CredentialResponse}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/credentials [get]
+func (h *AuthHandler) GetCredentials(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	credentials, err := funcs.AuthFuncs{}.GetUserCredentials(middleware.GetRequestContext(c), userID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取认证方式失败", err.Error()))
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    credentials,
+	})
+}
+
+// UnlinkCredential 解绑认证方式
+// @Summary      解绑认证方式
+// @Description  删除当前用户的一个认证方式；删除后没有其他可用登录方式（已设置的密码、已验证的邮箱或手机号、OAuth、通行密钥）时拒绝
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id path string true "认证方式ID"
+// @Success      200 {object} object{success=bool,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      404 {object} object{success=bool,message=string}
+// @Failure      409 {object} object{success=bool,message=string}
+// @Router       /auth/credentials/{id} [delete]
+func (h *AuthHandler) UnlinkCredential(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	credentialID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("认证方式ID格式无效", map[string]any{
+			"provided_id": c.Param("id"),
+		}))
+		return
+	}
+
+	if err := (funcs.AuthFuncs{}).UnlinkCredential(middleware.GetRequestContext(c), userID, credentialID); err != nil {
+		switch {
+		case errors.Is(err, funcs.ErrCredentialNotFound):
+			middleware.ThrowError(c, middleware.NotFoundError(err.Error(), nil))
+		case errors.Is(err, funcs.ErrLastLoginCredential):
+			middleware.ThrowError(c, middleware.ConflictError(err.Error(), nil))
+		default:
+			middleware.ThrowError(c, middleware.BusinessError("解绑认证方式失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "认证方式已解绑",
+	})
+}
+
+// RequestContactChange 申请更换邮箱/手机号
+// @Summary      申请更换邮箱/手机号
+// @Description  向新的邮箱/手机号发送验证码，确认前原认证方式保持可用
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.RequestContactChangeRequest true "申请更换请求"
+// @Success      200 {object} object{success=bool,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      409 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/contact-change/request [post]
+func (h *AuthHandler) RequestContactChange(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req models.RequestContactChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	err := funcs.AuthFuncs{}.RequestContactChange(middleware.GetRequestContext(c), userID, req.CredentialType, req.NewIdentifier)
+	if err != nil {
+		if err.Error() == "该标识已被使用" {
+			middleware.ThrowError(c, middleware.ConflictError("该标识已被使用", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.BusinessError("发送验证码失败", err.Error()))
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "验证码发送成功",
+	})
+}
+
+// ConfirmContactChange 确认更换邮箱/手机号
+// @Summary      确认更换邮箱/手机号
+// @Description  校验发送到新标识符的验证码并完成更换
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        request body models.ConfirmContactChangeRequest true "确认更换请求"
+// @Success      200 {object} object{success=bool,message=string}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      409 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /auth/contact-change/confirm [post]
+func (h *AuthHandler) ConfirmContactChange(c *gin.Context) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
+	var req models.ConfirmContactChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求参数格式错误", err.Error()))
+		return
+	}
+
+	err := funcs.AuthFuncs{}.ConfirmContactChange(middleware.GetRequestContext(c), userID, req.CredentialType, req.NewIdentifier, req.VerifyCode)
+	if err != nil {
+		if err.Error() == "该标识已被使用" {
+			middleware.ThrowError(c, middleware.ConflictError("该标识已被使用", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.BusinessError("更换失败", err.Error()))
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "更换成功",
+	})
+}
+
 // RefreshToken 刷新Token
 // @Summary      刷新Token
 // @Description  刷新JWT Token
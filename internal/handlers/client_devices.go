@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"strconv"
+	"strings"
 
 	"go-backend/internal/funcs"
 	"go-backend/internal/middleware"
@@ -187,7 +188,7 @@ func (h *ClientDeviceHandler) GetClientDeviceByCode(c *gin.Context) {
 
 // CreateClientDevice 创建客户端设备
 // @Summary      创建客户端设备
-// @Description  创建新的客户端设备
+// @Description  创建新的客户端设备，可配置该终端签发令牌的受众（audience）和授权范围（scopes），授权范围必须是已知的范围
 // @Tags         client-devices
 // @Accept       json
 // @Produce      json
@@ -211,19 +212,17 @@ func (h *ClientDeviceHandler) CreateClientDevice(c *gin.Context) {
 		return
 	}
 
-	if req.AccessTokenExpiry < 1000 {
-		middleware.ThrowError(c, middleware.BadRequestError("AccessToken超时时间不能小于1000毫秒", nil))
-		return
-	}
-
-	if req.RefreshTokenExpiry < 1000 {
-		middleware.ThrowError(c, middleware.BadRequestError("RefreshToken超时时间不能小于1000毫秒", nil))
-		return
-	}
-
 	ctx := middleware.GetRequestContext(c)
 	device, err := funcs.ClientDeviceFuncs{}.CreateClientDevice(ctx, &req)
 	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid token expiry") {
+			middleware.ThrowError(c, middleware.BadRequestError("令牌有效期不在允许范围内", err.Error()))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid token scope") {
+			middleware.ThrowError(c, middleware.BadRequestError("令牌授权范围无效", err.Error()))
+			return
+		}
 		middleware.ThrowError(c, middleware.DatabaseError("创建客户端设备失败", err.Error()))
 		return
 	}
@@ -237,7 +236,7 @@ func (h *ClientDeviceHandler) CreateClientDevice(c *gin.Context) {
 
 // UpdateClientDevice 更新客户端设备
 // @Summary      更新客户端设备
-// @Description  根据ID更新客户端设备信息
+// @Description  根据ID更新客户端设备信息，未传 audience/scopes 时保持不变，scopes 传空数组表示不限制授权范围
 // @Tags         client-devices
 // @Accept       json
 // @Produce      json
@@ -271,16 +270,6 @@ func (h *ClientDeviceHandler) UpdateClientDevice(c *gin.Context) {
 		return
 	}
 
-	if req.AccessTokenExpiry != nil && *req.AccessTokenExpiry < 1000 {
-		middleware.ThrowError(c, middleware.BadRequestError("AccessToken超时时间不能小于1000毫秒", nil))
-		return
-	}
-
-	if req.RefreshTokenExpiry != nil && *req.RefreshTokenExpiry < 1000 {
-		middleware.ThrowError(c, middleware.BadRequestError("RefreshToken超时时间不能小于1000毫秒", nil))
-		return
-	}
-
 	ctx := middleware.GetRequestContext(c)
 	device, err := funcs.ClientDeviceFuncs{}.UpdateClientDevice(ctx, id, &req)
 	if err != nil {
@@ -289,6 +278,10 @@ func (h *ClientDeviceHandler) UpdateClientDevice(c *gin.Context) {
 			middleware.ThrowError(c, middleware.NotFoundError("客户端设备未找到", map[string]any{
 				"id": id,
 			}))
+		} else if strings.HasPrefix(err.Error(), "invalid token expiry") {
+			middleware.ThrowError(c, middleware.BadRequestError("令牌有效期不在允许范围内", err.Error()))
+		} else if strings.HasPrefix(err.Error(), "invalid token scope") {
+			middleware.ThrowError(c, middleware.BadRequestError("令牌授权范围无效", err.Error()))
 		} else {
 			middleware.ThrowError(c, middleware.DatabaseError("更新客户端设备失败", err.Error()))
 		}
@@ -345,6 +338,49 @@ func (h *ClientDeviceHandler) DeleteClientDevice(c *gin.Context) {
 	})
 }
 
+// RegenerateClientDeviceCode 重新生成客户端设备标识
+// @Summary      重新生成客户端设备标识
+// @Description  为客户端设备生成新的code，旧code立即失效，使用旧code的客户端需要更新配置
+// @Tags         client-devices
+// @Accept       json
+// @Produce      json
+// @Param        id   path      int  true  "客户端设备ID"
+// @Success      200  {object}  object{success=bool,data=models.ClientDeviceResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /client-devices/{id}/regenerate-code [post]
+func (h *ClientDeviceHandler) RegenerateClientDeviceCode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("客户端设备ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	device, err := funcs.ClientDeviceFuncs{}.RegenerateClientDeviceCode(ctx, id)
+	if err != nil {
+		if err.Error() == "client device with id "+strconv.FormatUint(id, 10)+" not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("客户端设备未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("重新生成客户端设备标识失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"data":    device,
+		"message": "客户端设备标识已重新生成",
+	})
+}
+
 // CheckClientAccess 检查客户端访问权限
 // @Summary      检查客户端访问权限
 // @Description  检查用户是否能使用指定客户端登录
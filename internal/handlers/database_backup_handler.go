@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+
+	"go-backend/internal/funcs"
+	"go-backend/internal/middleware"
+	"go-backend/shared/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseBackupHandler 数据库备份处理器
+type DatabaseBackupHandler struct {
+}
+
+// NewDatabaseBackupHandler 创建新的数据库备份处理器
+func NewDatabaseBackupHandler() *DatabaseBackupHandler {
+	return &DatabaseBackupHandler{}
+}
+
+// BackupDatabase 触发数据库备份
+// @Summary      触发数据库备份
+// @Description  导出所有表为 tar.gz 归档并上传到S3，记录备份清单，返回归档的键和预签名下载链接（需要 system:backup 权限）。同一时间只允许一个备份运行
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200 {object} object{success=bool,data=models.DatabaseBackupResponse,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      403 {object} object{success=bool,message=string}
+// @Failure      409 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Failure      503 {object} object{success=bool,message=string}
+// @Router       /admin/backup [post]
+func (h *DatabaseBackupHandler) BackupDatabase(c *gin.Context) {
+	userID, ok := requireDatabaseBackupPermission(c)
+	if !ok {
+		return
+	}
+
+	backup, err := funcs.DatabaseBackupFuncs{}.BackupDatabase(middleware.GetRequestContext(c), userID)
+	if err != nil {
+		switch {
+		case funcs.IsDatabaseBackupInProgress(err):
+			middleware.ThrowError(c, middleware.ConflictError("已有数据库备份正在进行", err.Error()))
+		case funcs.IsDatabaseBackupStorageUnavailable(err):
+			middleware.ThrowError(c, middleware.ServiceUnavailableError("未配置备份存储", err.Error()))
+		default:
+			middleware.ThrowError(c, middleware.InternalServerError("数据库备份失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    backup,
+		"message": "数据库备份完成",
+	})
+}
+
+// ListDatabaseBackups 获取数据库备份清单
+// @Summary      获取数据库备份清单
+// @Description  按备份时间倒序分页获取备份清单，包括归档的键、大小和各实体的记录数（需要 system:backup 权限）
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        page      query    int false "页码" default(1)
+// @Param        pageSize  query    int false "每页数量" default(20)
+// @Success      200 {object} object{success=bool,data=[]models.DatabaseBackupResponse,pagination=models.Pagination}
+// @Failure      400 {object} object{success=bool,message=string}
+// @Failure      401 {object} object{success=bool,message=string}
+// @Failure      403 {object} object{success=bool,message=string}
+// @Failure      500 {object} object{success=bool,message=string}
+// @Router       /admin/backups [get]
+func (h *DatabaseBackupHandler) ListDatabaseBackups(c *gin.Context) {
+	if _, ok := requireDatabaseBackupPermission(c); !ok {
+		return
+	}
+
+	var req models.DatabaseBackupListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("请求参数错误", err.Error()))
+		return
+	}
+
+	result, err := funcs.DatabaseBackupFuncs{}.ListDatabaseBackups(middleware.GetRequestContext(c), &req)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取备份清单失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result.Data,
+		"pagination": result.Pagination,
+	})
+}
+
+// requireDatabaseBackupPermission 确认当前用户拥有数据库备份权限
+func requireDatabaseBackupPermission(c *gin.Context) (uint64, bool) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return 0, false
+	}
+
+	allowed, err := funcs.HasAnyPermissionsOptimized(middleware.GetRequestContext(c), userID, []string{funcs.DatabaseBackupPermission})
+	if err != nil {
+		middleware.ThrowError(c, middleware.InternalServerError("权限检查失败", err.Error()))
+		return 0, false
+	}
+	if !allowed {
+		middleware.ThrowError(c, middleware.ForbiddenError("没有数据库备份权限", nil))
+		return 0, false
+	}
+	return userID, true
+}
@@ -3,8 +3,10 @@ package handlers
 import (
 	"net/http"
 
+	"go-backend/internal/funcs"
 	"go-backend/pkg/caching"
 	"go-backend/pkg/database"
+	"go-backend/pkg/messaging"
 	"go-backend/pkg/s3"
 	"go-backend/shared/models"
 
@@ -21,11 +23,12 @@ func NewHealthHandler() *HealthHandler {
 
 // Health 健康检查端点
 // @Summary      健康检查
-// @Description  检查服务健康状态
+// @Description  检查服务健康状态，messaging 组件在缓存不可用或消费协程退出时为 Dead。执行队列正在关闭（不再接受新的执行）时返回503，负载均衡应停止转发请求
 // @Tags         health
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  models.HealthResponse
+// @Failure      503  {object}  models.HealthResponse
 // @Router       /health [get]
 func (h *HealthHandler) Health(c *gin.Context) {
 	var dbStatus string
@@ -49,14 +52,41 @@ func (h *HealthHandler) Health(c *gin.Context) {
 		s3Status = "Dead"
 	}
 
+	queue := funcs.WorkflowFuncs{}.GetExecutionQueueStats(c.Request.Context())
+	var queueStatus string
+	switch {
+	case !queue.Enabled:
+		queueStatus = "Disabled"
+	case queue.Accepting:
+		queueStatus = "Alive"
+	default:
+		queueStatus = "Draining"
+	}
+
+	messagingStats := messaging.Stats()
+	messagingStatus := "Alive"
+	if !messagingStats.Healthy {
+		messagingStatus = "Dead"
+	}
+
 	response := &models.HealthResponse{
 		Status:  "ok",
 		Message: "Server is running",
 		Components: map[string]string{
-			"database": dbStatus,
-			"cache":    cacheStatus,
-			"s3":       s3Status,
+			"database":      dbStatus,
+			"cache":         cacheStatus,
+			"s3":            s3Status,
+			"workflowQueue": queueStatus,
+			"messaging":     messagingStatus,
 		},
+		WorkflowQueue: queue,
+		Messaging:     messagingStats,
+	}
+	if queueStatus == "Draining" {
+		response.Status = "draining"
+		response.Message = "Server is shutting down"
+		c.JSON(http.StatusServiceUnavailable, response)
+		return
 	}
 	c.JSON(http.StatusOK, response)
 }
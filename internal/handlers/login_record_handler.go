@@ -2,12 +2,10 @@ package handlers
 
 import (
 	"strconv"
+	"strings"
 
-	"go-backend/database/ent"
-	"go-backend/database/ent/loginrecord"
 	"go-backend/internal/funcs"
 	"go-backend/internal/middleware"
-	"go-backend/pkg/database"
 	"go-backend/shared/models"
 
 	"github.com/gin-gonic/gin"
@@ -24,134 +22,56 @@ func NewLoginRecordHandler() *LoginRecordHandler {
 
 // GetLoginRecords 获取登录记录列表
 // @Summary      获取登录记录列表
-// @Description  获取系统登录记录列表（管理员权限）
+// @Description  获取系统登录记录列表（管理员权限），支持按用户、标识符、状态、登录方式、设备、IP和时间范围过滤，按登录时间倒序
 // @Tags         admin
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        page     query    int    false "页码" default(1)
-// @Param        limit    query    int    false "每页数量" default(20)
-// @Param        user_id  query    string false "用户ID"
-// @Param        status   query    string false "登录状态" Enums(success,failed,locked)
-// @Success      200 {object} object{success=bool,data=object{records=[]models.LoginRecordResponse,total=int}}
+// @Param        page            query    int    false "页码" default(1)
+// @Param        pageSize        query    int    false "每页数量" default(20)
+// @Param        userId          query    string false "用户ID"
+// @Param        identifier      query    string false "登录标识符（模糊匹配）"
+// @Param        status          query    string false "登录状态" Enums(success,failed,locked)
+// @Param        credentialType  query    string false "登录方式" Enums(password,email,oauth,phone,totp,webauthn)
+// @Param        clientId        query    string false "登录设备ID"
+// @Param        device          query    string false "设备信息（模糊匹配）"
+// @Param        ip              query    string false "登录IP"
+// @Param        beginTime       query    string false "开始时间（RFC3339）"
+// @Param        endTime         query    string false "结束时间（RFC3339）"
+// @Success      200 {object} object{success=bool,data=object{records=[]models.LoginRecordResponse,total=int,page=int,limit=int}}
 // @Failure      400 {object} object{success=bool,message=string}
 // @Failure      401 {object} object{success=bool,message=string}
 // @Failure      403 {object} object{success=bool,message=string}
 // @Failure      500 {object} object{success=bool,message=string}
 // @Router       /admin/login-records [get]
 func (h *LoginRecordHandler) GetLoginRecords(c *gin.Context) {
-	// 获取查询参数
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "20")
-	userIDStr := c.Query("user_id")
-	status := c.Query("status")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 20
-	}
-
-	offset := (page - 1) * limit
-
-	var records []*ent.LoginRecord
-	var total int
-
-	if userIDStr != "" {
-		// 查询指定用户的登录记录
-		userID, err := strconv.ParseUint(userIDStr, 10, 64)
-		if err != nil {
-			middleware.ThrowError(c, middleware.ValidationError("用户ID格式错误", ""))
-			return
-		}
-
-		records, err = funcs.LoginRecordFuncs{}.GetUserLoginRecords(middleware.GetRequestContext(c), userID, limit, offset)
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询用户登录记录失败", err.Error()))
-			return
-		}
-
-		// 获取总数
-		totalCount, err := database.Client.LoginRecord.Query().
-			Where(loginrecord.UserIDEQ(userID)).
-			Count(middleware.GetRequestContext(c))
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询记录总数失败", err.Error()))
-			return
-		}
-		total = totalCount
-
-	} else if status != "" {
-		// 根据状态查询登录记录
-		records, err = funcs.LoginRecordFuncs{}.GetLoginRecordsByStatus(middleware.GetRequestContext(c), status, limit, offset)
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询登录记录失败", err.Error()))
-			return
-		}
-
-		// 获取总数
-		totalCount, err := database.Client.LoginRecord.Query().
-			Where(loginrecord.StatusEQ(loginrecord.Status(status))).
-			Count(middleware.GetRequestContext(c))
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询记录总数失败", err.Error()))
-			return
-		}
-		total = totalCount
-
-	} else {
-		// 查询所有登录记录
-		records, err = database.Client.LoginRecord.Query().
-			Order(ent.Desc(loginrecord.FieldCreateTime)).
-			Limit(limit).
-			Offset(offset).
-			All(middleware.GetRequestContext(c))
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询登录记录失败", err.Error()))
-			return
-		}
-
-		// 获取总数
-		totalCount, err := database.Client.LoginRecord.Query().
-			Count(middleware.GetRequestContext(c))
-		if err != nil {
-			middleware.ThrowError(c, middleware.InternalServerError("查询记录总数失败", err.Error()))
-			return
-		}
-		total = totalCount
-	}
-
-	// 转换为响应格式
-	recordResponses := make([]*models.LoginRecordResponse, len(records))
-	for i, record := range records {
-		recordResponses[i] = convertLoginRecordToResponse(record)
+	req, ok := bindLoginRecordsRequest(c, 20)
+	if !ok {
+		return
 	}
 
-	c.JSON(200, gin.H{
-		"success": true,
-		"data": gin.H{
-			"records": recordResponses,
-			"total":   total,
-			"page":    page,
-			"limit":   limit,
-		},
-	})
+	h.respondLoginRecords(c, req)
 }
 
-// GetUserLoginRecords 获取当前用户的登录记录
-// @Summary      获取当前用户的登录记录
-// @Description  获取当前登录用户的登录记录
+// GetUserLoginRecords 获取登录记录
+// @Summary      获取登录记录
+// @Description  默认返回当前用户的登录记录；拥有 login_record.audit 权限的用户可查询所有用户的登录记录，并按用户、标识符、状态、登录方式、设备、IP和时间范围过滤
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
-// @Param        page  query    int false "页码" default(1)
-// @Param        limit query    int false "每页数量" default(10)
-// @Success      200 {object} object{success=bool,data=object{records=[]models.LoginRecordResponse,total=int}}
+// @Param        page            query    int    false "页码" default(1)
+// @Param        pageSize        query    int    false "每页数量" default(10)
+// @Param        userId          query    string false "用户ID（仅审计权限）"
+// @Param        identifier      query    string false "登录标识符（模糊匹配）"
+// @Param        status          query    string false "登录状态" Enums(success,failed,locked)
+// @Param        credentialType  query    string false "登录方式" Enums(password,email,oauth,phone,totp,webauthn)
+// @Param        clientId        query    string false "登录设备ID"
+// @Param        device          query    string false "设备信息（模糊匹配）"
+// @Param        ip              query    string false "登录IP"
+// @Param        beginTime       query    string false "开始时间（RFC3339）"
+// @Param        endTime         query    string false "结束时间（RFC3339）"
+// @Success      200 {object} object{success=bool,data=object{records=[]models.LoginRecordResponse,total=int,page=int,limit=int}}
 // @Failure      400 {object} object{success=bool,message=string}
 // @Failure      401 {object} object{success=bool,message=string}
 // @Failure      500 {object} object{success=bool,message=string}
@@ -163,91 +83,66 @@ func (h *LoginRecordHandler) GetUserLoginRecords(c *gin.Context) {
 		return
 	}
 
-	// 获取查询参数
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
-
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
-	}
-
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 50 {
-		limit = 10
-	}
-
-	offset := (page - 1) * limit
-
-	// 查询用户的登录记录
-	records, err := funcs.LoginRecordFuncs{}.GetUserLoginRecords(middleware.GetRequestContext(c), userID, limit, offset)
-	if err != nil {
-		middleware.ThrowError(c, middleware.InternalServerError("查询登录记录失败", err.Error()))
+	req, ok := bindLoginRecordsRequest(c, 10)
+	if !ok {
 		return
 	}
 
-	// 获取总数
-	total, err := database.Client.LoginRecord.Query().
-		Where(loginrecord.UserIDEQ(userID)).
-		Count(middleware.GetRequestContext(c))
+	// 没有审计权限时只能查看自己的登录记录
+	canAudit, err := funcs.HasAnyPermissionsOptimized(middleware.GetRequestContext(c), userID, []string{funcs.LoginRecordAuditPermission})
 	if err != nil {
-		middleware.ThrowError(c, middleware.InternalServerError("查询记录总数失败", err.Error()))
+		middleware.ThrowError(c, middleware.InternalServerError("权限检查失败", err.Error()))
 		return
 	}
-
-	// 转换为响应格式
-	recordResponses := make([]*models.LoginRecordResponse, len(records))
-	for i, record := range records {
-		recordResponses[i] = convertLoginRecordToResponse(record)
+	if !canAudit {
+		req.UserID = strconv.FormatUint(userID, 10)
 	}
 
-	c.JSON(200, gin.H{
-		"success": true,
-		"data": gin.H{
-			"records": recordResponses,
-			"total":   total,
-			"page":    page,
-			"limit":   limit,
-		},
-	})
+	h.respondLoginRecords(c, req)
 }
 
-// convertLoginRecordToResponse 转换登录记录为响应格式
-func convertLoginRecordToResponse(record *ent.LoginRecord) *models.LoginRecordResponse {
-	response := &models.LoginRecordResponse{
-		ID:             record.ID,
-		UserID:         record.UserID,
-		Identifier:     record.Identifier,
-		CredentialType: string(record.CredentialType),
-		IPAddress:      record.IPAddress,
-		Status:         string(record.Status),
-		LoginTime:      record.CreateTime.Format("2006-01-02 15:04:05"),
-	}
+// bindLoginRecordsRequest 绑定登录记录查询参数，兼容旧的 limit 参数
+func bindLoginRecordsRequest(c *gin.Context, defaultPageSize int) (*models.GetLoginRecordsRequest, bool) {
+	req := &models.GetLoginRecordsRequest{}
+	req.Page = 1
+	req.PageSize = defaultPageSize
+	req.Order = "desc"
 
-	if record.UserAgent != "" {
-		response.UserAgent = record.UserAgent
-	}
-	if record.DeviceInfo != "" {
-		response.DeviceInfo = record.DeviceInfo
+	if err := c.ShouldBindQuery(req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("查询参数格式错误", err.Error()))
+		return nil, false
 	}
-	if record.Location != "" {
-		response.Location = record.Location
-	}
-	if record.FailureReason != "" {
-		response.FailureReason = record.FailureReason
-	}
-	if record.SessionID != "" {
-		response.SessionID = record.SessionID
-	}
-	if record.LogoutTime != nil {
-		response.LogoutTime = record.LogoutTime.Format("2006-01-02 15:04:05")
+	if c.Query("pageSize") == "" {
+		if limit, err := strconv.Atoi(c.Query("limit")); err == nil && limit > 0 {
+			req.PageSize = limit
+		}
 	}
-	if record.Duration != 0 {
-		response.Duration = record.Duration
+	// 兼容旧的 user_id 参数
+	if req.UserID == "" {
+		req.UserID = c.Query("user_id")
 	}
-	if record.Metadata != nil {
-		response.Metadata = record.Metadata
+	return req, true
+}
+
+func (h *LoginRecordHandler) respondLoginRecords(c *gin.Context, req *models.GetLoginRecordsRequest) {
+	result, err := funcs.LoginRecordFuncs{}.GetLoginRecordsWithPagination(middleware.GetRequestContext(c), req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid ") {
+			middleware.ThrowError(c, middleware.ValidationError("查询参数格式错误", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.InternalServerError("查询登录记录失败", err.Error()))
+		}
+		return
 	}
 
-	return response
+	c.JSON(200, gin.H{
+		"success": true,
+		"data": gin.H{
+			"records":    result.Data,
+			"total":      result.Pagination.Total,
+			"page":       result.Pagination.Page,
+			"limit":      result.Pagination.PageSize,
+			"pagination": result.Pagination,
+		},
+	})
 }
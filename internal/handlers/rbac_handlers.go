@@ -1,7 +1,9 @@
 package handlers
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
 
 	"go-backend/internal/funcs"
 	"go-backend/internal/middleware"
@@ -241,14 +243,16 @@ func (h *RoleHandler) UpdateRole(c *gin.Context) {
 
 // DeleteRole 删除角色
 // @Summary      删除角色
-// @Description  根据ID删除角色
+// @Description  根据ID删除角色。仍有用户分配或子角色继承时默认拒绝删除并返回依赖信息；force=true 时移除用户关联、将子角色改为继承其父角色后删除
 // @Tags         rbac-roles
 // @Accept       json
 // @Produce      json
-// @Param        id   path      int  true  "角色ID"
+// @Param        id     path      int   true   "角色ID"
+// @Param        force  query     bool  false  "是否强制删除"
 // @Success      200  {object}  object{success=bool,message=string}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      409  {object}  object{success=bool,message=string,data=models.RoleDeleteBlockers}
 // @Failure      500  {object}  object{success=bool,message=string}
 // @Router       /rbac/roles/{id} [delete]
 func (h *RoleHandler) DeleteRole(c *gin.Context) {
@@ -262,12 +266,19 @@ func (h *RoleHandler) DeleteRole(c *gin.Context) {
 		return
 	}
 
-	err = funcs.RoleFuncs{}.DeleteRole(middleware.GetRequestContext(c), id)
+	force := c.Query("force") == "true"
+
+	blockers, err := funcs.RoleFuncs{}.DeleteRole(middleware.GetRequestContext(c), id, force)
 	if err != nil {
 		if err.Error() == "role not found" {
 			middleware.ThrowError(c, middleware.NotFoundError("角色不存在", map[string]any{
 				"id": id,
 			}))
+		} else if err.Error() == "role in use" {
+			middleware.ThrowError(c, middleware.ConflictError(
+				fmt.Sprintf("角色仍被 %d 个用户和 %d 个子角色使用，无法删除", blockers.UserCount, blockers.ChildRoleCount),
+				blockers,
+			))
 		} else {
 			middleware.ThrowError(c, middleware.DatabaseError("删除角色失败", err.Error()))
 		}
@@ -321,6 +332,69 @@ func (h *RoleHandler) AssignRolePermissions(c *gin.Context) {
 	})
 }
 
+// SetRolePermissions 设置角色权限
+// @Summary      设置角色权限
+// @Description  将角色的直接权限整体替换为给定列表：在一个事务中新增缺少的、移除多余的权限，继承自父角色的权限不受影响
+// @Tags         rbac-roles
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                               true  "角色ID"
+// @Param        permissions body      models.SetRolePermissionsRequest  true  "完整的权限ID列表"
+// @Success      200         {object}  object{success=bool,message=string}
+// @Failure      400         {object}  object{success=bool,message=string}
+// @Failure      404         {object}  object{success=bool,message=string}
+// @Failure      500         {object}  object{success=bool,message=string}
+// @Router       /rbac/roles/{id}/permissions [put]
+func (h *RoleHandler) SetRolePermissions(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("角色ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	permissionIDs := make([]uint64, 0, len(req.PermissionIds))
+	for _, permissionIDStr := range req.PermissionIds {
+		permissionID, err := strconv.ParseUint(permissionIDStr, 10, 64)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("权限ID格式无效", map[string]any{
+				"provided_permission_id": permissionIDStr,
+			}))
+			return
+		}
+		permissionIDs = append(permissionIDs, permissionID)
+	}
+
+	err = funcs.RoleFuncs{}.SetRolePermissions(middleware.GetRequestContext(c), id, permissionIDs)
+	if err != nil {
+		switch {
+		case err.Error() == "role not found":
+			middleware.ThrowError(c, middleware.NotFoundError("角色不存在", map[string]any{
+				"id": id,
+			}))
+		case strings.HasPrefix(err.Error(), "permission not found"):
+			middleware.ThrowError(c, middleware.BadRequestError("部分权限不存在", err.Error()))
+		default:
+			middleware.ThrowError(c, middleware.DatabaseError("设置角色权限失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "角色权限设置成功",
+	})
+}
+
 // RevokeRolePermission 撤销角色权限
 // @Summary      撤销角色权限
 // @Description  撤销角色的指定权限
@@ -981,7 +1055,7 @@ func (h *RoleHandler) GetRoleUsersWithPagination(c *gin.Context) {
 
 // BatchAssignUsersToRole 批量分配用户到角色
 // @Summary      批量分配用户到角色
-// @Description  批量将用户分配到指定角色
+// @Description  批量将用户分配到指定角色，可指定过期时间（过期后不再授予该角色的权限），已分配的用户按本次请求更新过期时间
 // @Tags         rbac-roles
 // @Accept       json
 // @Produce      json
@@ -1020,6 +1094,8 @@ func (h *RoleHandler) BatchAssignUsersToRole(c *gin.Context) {
 			middleware.ThrowError(c, middleware.NotFoundError("角色不存在", map[string]any{
 				"id": id,
 			}))
+		} else if funcs.IsUserRoleExpiryInPast(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("过期时间必须晚于当前时间", err.Error()))
 		} else {
 			middleware.ThrowError(c, middleware.DatabaseError("批量分配用户失败", err.Error()))
 		}
@@ -229,6 +229,79 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	})
 }
 
+// SetUserStatus 修改用户账号状态
+// @Summary      修改用户账号状态
+// @Description  停用（inactive）或封禁（banned）用户时撤销其在所有终端上的会话并断开WebSocket连接，启用（active）后用户需要重新登录；需要 user.status.manage 权限
+// @Tags         rbac
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      int                          true  "用户ID"
+// @Param        request  body      models.SetUserStatusRequest  true  "目标状态和原因"
+// @Success      200      {object}  object{success=bool,message=string}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      401      {object}  object{success=bool,message=string}
+// @Failure      403      {object}  object{success=bool,message=string}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /rbac/users/{id}/status [patch]
+func (h *UserHandler) SetUserStatus(c *gin.Context) {
+	currentUserID, exists := middleware.GetCurrentUserID(c)
+	if !exists {
+		middleware.ThrowError(c, middleware.UnauthorizedError("未找到用户信息", ""))
+		return
+	}
+
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("用户ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.SetUserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	allowed, err := funcs.HasAnyPermissionsOptimized(ctx, currentUserID, []string{funcs.UserStatusManagePermission})
+	if err != nil {
+		middleware.ThrowError(c, middleware.InternalServerError("权限检查失败", err.Error()))
+		return
+	}
+	if !allowed {
+		middleware.ThrowError(c, middleware.ForbiddenError("没有修改用户状态的权限", nil))
+		return
+	}
+	if id == currentUserID && req.Status != "active" {
+		middleware.ThrowError(c, middleware.BadRequestError("不能停用自己的账号", nil))
+		return
+	}
+
+	if err := (funcs.UserFuncs{}).SetUserStatus(ctx, id, req.Status, req.Reason); err != nil {
+		switch {
+		case err.Error() == "user not found":
+			middleware.ThrowError(c, middleware.UserNotFoundError(map[string]any{
+				"id": id,
+			}))
+		case funcs.IsInvalidUserStatus(err):
+			middleware.ThrowError(c, middleware.ValidationError("用户状态无效", err.Error()))
+		default:
+			middleware.ThrowError(c, middleware.DatabaseError("修改用户状态失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"success": true,
+		"message": "用户状态修改成功",
+	})
+}
+
 // DeleteUser 删除用户
 // @Summary      删除用户
 // @Description  根据ID删除用户
@@ -65,7 +65,7 @@ func (h *UserRoleHandler) GetUserRolesWithPagination(c *gin.Context) {
 
 // AssignRole 为用户分配角色
 // @Summary      为用户分配角色
-// @Description  为指定用户分配角色
+// @Description  为指定用户分配角色，可指定过期时间（过期后不再授予该角色的权限）；已过期的分配重新分配时续期
 // @Tags         rbac-user-roles
 // @Accept       json
 // @Produce      json
@@ -108,6 +108,8 @@ func (h *UserRoleHandler) AssignRole(c *gin.Context) {
 			middleware.ThrowError(c, middleware.NotFoundError("角色不存在", map[string]any{
 				"role_id": req.RoleID,
 			}))
+		} else if funcs.IsUserRoleExpiryInPast(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("过期时间必须晚于当前时间", err.Error()))
 		} else {
 			middleware.ThrowError(c, middleware.DatabaseError("分配角色失败", err.Error()))
 		}
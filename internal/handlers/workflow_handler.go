@@ -1,11 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"go-backend/internal/funcs"
 	"go-backend/internal/middleware"
+	"go-backend/pkg/configs"
 	"go-backend/shared/models"
 
 	"github.com/gin-gonic/gin"
@@ -23,7 +29,7 @@ func NewWorkflowHandler() *WorkflowHandler {
 
 // GetWorkflowApplications 获取所有工作流应用
 // @Summary      获取所有工作流应用
-// @Description  获取系统中所有工作流应用的列表
+// @Description  获取当前用户拥有、被共享以及没有所有者的工作流应用列表，拥有 workflow:admin 权限时返回所有应用
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
@@ -32,7 +38,11 @@ func NewWorkflowHandler() *WorkflowHandler {
 // @Router       /workflow/applications [get]
 func (h *WorkflowHandler) GetWorkflowApplications(c *gin.Context) {
 	ctx := middleware.GetRequestContext(c)
-	apps, err := funcs.WorkflowFuncs{}.GetAllWorkflowApplications(ctx)
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+	apps, err := funcs.WorkflowFuncs{}.GetAllWorkflowApplications(ctx, scope)
 	if err != nil {
 		middleware.ThrowError(c, middleware.DatabaseError("获取工作流应用列表失败", err.Error()))
 		return
@@ -47,7 +57,7 @@ func (h *WorkflowHandler) GetWorkflowApplications(c *gin.Context) {
 
 // GetWorkflowApplicationsWithPagination 分页获取工作流应用列表
 // @Summary      分页获取工作流应用列表
-// @Description  根据分页参数获取工作流应用列表
+// @Description  根据分页参数获取当前用户可见的工作流应用列表，拥有 workflow:admin 权限时不限制所有者
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
@@ -76,9 +86,14 @@ func (h *WorkflowHandler) GetWorkflowApplicationsWithPagination(c *gin.Context)
 		return
 	}
 
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
 	// 调用服务层方法
 	ctx := middleware.GetRequestContext(c)
-	result, err := funcs.WorkflowFuncs{}.GetWorkflowApplicationsWithPagination(ctx, &req)
+	result, err := funcs.WorkflowFuncs{}.GetWorkflowApplicationsWithPagination(ctx, scope, &req)
 	if err != nil {
 		middleware.ThrowError(c, middleware.DatabaseError("获取工作流应用列表失败", err.Error()))
 		return
@@ -93,11 +108,12 @@ func (h *WorkflowHandler) GetWorkflowApplicationsWithPagination(c *gin.Context)
 
 // GetWorkflowApplication 根据ID获取工作流应用
 // @Summary      根据ID获取工作流应用
-// @Description  根据工作流应用ID获取详细信息
+// @Description  根据工作流应用ID获取详细信息，validate=true 时在 diagnostics 中按节点ID附带图校验问题
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "工作流应用ID"
+// @Param        id        path      string  true   "工作流应用ID"
+// @Param        validate  query     bool    false  "是否附带节点校验诊断"
 // @Success      200  {object}  object{success=bool,data=models.WorkflowApplicationResponse}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
@@ -119,6 +135,21 @@ func (h *WorkflowHandler) GetWorkflowApplication(c *gin.Context) {
 		return
 	}
 
+	validate := false
+	if raw := c.Query("validate"); raw != "" {
+		validate, err = strconv.ParseBool(raw)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("validate 参数格式无效", map[string]any{
+				"provided_validate": raw,
+			}))
+			return
+		}
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
 	app, err := funcs.WorkflowFuncs{}.GetWorkflowApplicationByID(ctx, id)
 	if err != nil {
@@ -132,6 +163,15 @@ func (h *WorkflowHandler) GetWorkflowApplication(c *gin.Context) {
 		return
 	}
 
+	if validate {
+		diagnostics, err := funcs.WorkflowFuncs{}.GetWorkflowNodeDiagnostics(ctx, id)
+		if err != nil {
+			middleware.ThrowError(c, middleware.DatabaseError("校验工作流失败", err.Error()))
+			return
+		}
+		app.Diagnostics = diagnostics
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
 		"data":    app,
@@ -140,13 +180,14 @@ func (h *WorkflowHandler) GetWorkflowApplication(c *gin.Context) {
 
 // CreateWorkflowApplication 创建工作流应用
 // @Summary      创建工作流应用
-// @Description  创建新的工作流应用
+// @Description  创建新的工作流应用，所有者为当前用户；未提供 startNodeId 时默认自动创建开始节点，createDefaultStartNode=false 可关闭
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
 // @Param        application  body      models.CreateWorkflowApplicationRequest  true  "工作流应用信息"
 // @Success      201   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
 // @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
 // @Failure      500   {object}  object{success=bool,message=string}
 // @Router       /workflow/applications [post]
 func (h *WorkflowHandler) CreateWorkflowApplication(c *gin.Context) {
@@ -162,10 +203,23 @@ func (h *WorkflowHandler) CreateWorkflowApplication(c *gin.Context) {
 		return
 	}
 
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	app, err := funcs.WorkflowFuncs{}.CreateWorkflowApplication(ctx, &req)
+	app, err := funcs.WorkflowFuncs{}.CreateWorkflowApplication(ctx, userID, &req)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("创建工作流应用失败", err.Error()))
+		if strings.HasPrefix(err.Error(), "invalid input schema") {
+			middleware.ThrowError(c, middleware.BadRequestError("输入Schema无效", err.Error()))
+		} else if strings.HasPrefix(err.Error(), "workflow application cannot be published") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用缺少开始节点，无法发布", err.Error()))
+		} else if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("工作流应用名称已存在", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("创建工作流应用失败", err.Error()))
+		}
 		return
 	}
 
@@ -178,7 +232,7 @@ func (h *WorkflowHandler) CreateWorkflowApplication(c *gin.Context) {
 
 // UpdateWorkflowApplication 更新工作流应用
 // @Summary      更新工作流应用
-// @Description  根据ID更新工作流应用信息
+// @Description  根据ID更新工作流应用信息，发布（status=published）时要求应用存在开始节点
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
@@ -187,6 +241,7 @@ func (h *WorkflowHandler) CreateWorkflowApplication(c *gin.Context) {
 // @Success      200   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
 // @Failure      400   {object}  object{success=bool,message=string}
 // @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
 // @Failure      500   {object}  object{success=bool,message=string}
 // @Router       /workflow/applications/{id} [put]
 func (h *WorkflowHandler) UpdateWorkflowApplication(c *gin.Context) {
@@ -206,6 +261,10 @@ func (h *WorkflowHandler) UpdateWorkflowApplication(c *gin.Context) {
 		return
 	}
 
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
 	app, err := funcs.WorkflowFuncs{}.UpdateWorkflowApplication(ctx, id, &req)
 	if err != nil {
@@ -213,6 +272,14 @@ func (h *WorkflowHandler) UpdateWorkflowApplication(c *gin.Context) {
 			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
 				"id": id,
 			}))
+		} else if strings.HasPrefix(err.Error(), "invalid input schema") {
+			middleware.ThrowError(c, middleware.BadRequestError("输入Schema无效", err.Error()))
+		} else if strings.HasPrefix(err.Error(), "invalid viewport") {
+			middleware.ThrowError(c, middleware.BadRequestError("视口配置无效", err.Error()))
+		} else if strings.HasPrefix(err.Error(), "workflow application cannot be published") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用缺少开始节点，无法发布", err.Error()))
+		} else if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("工作流应用名称已存在", err.Error()))
 		} else {
 			middleware.ThrowError(c, middleware.DatabaseError("更新工作流应用失败", err.Error()))
 		}
@@ -226,19 +293,21 @@ func (h *WorkflowHandler) UpdateWorkflowApplication(c *gin.Context) {
 	})
 }
 
-// DeleteWorkflowApplication 删除工作流应用
-// @Summary      删除工作流应用
-// @Description  根据ID删除工作流应用
+// PatchWorkflowApplicationVariables 合并更新工作流应用变量
+// @Summary      合并更新工作流应用变量
+// @Description  将请求体合并到应用现有变量上：同名键覆盖、嵌套对象递归合并、null 删除键，未提供的键保持不变。需要整体替换请使用 PUT
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "工作流应用ID"
-// @Success      200  {object}  object{success=bool,message=string}
-// @Failure      400  {object}  object{success=bool,message=string}
-// @Failure      404  {object}  object{success=bool,message=string}
-// @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/applications/{id} [delete]
-func (h *WorkflowHandler) DeleteWorkflowApplication(c *gin.Context) {
+// @Param        id     path      string  true  "工作流应用ID"
+// @Param        patch  body      object  true  "要合并的键值，值为 null 表示删除该键"
+// @Param        skipConfigValidation  query  bool  false  "跳过按节点类型的配置校验（仅用于迁移旧数据）"
+// @Success      200   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/variables [patch]
+func (h *WorkflowHandler) PatchWorkflowApplicationVariables(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.ParseUint(idStr, 10, 64)
@@ -249,39 +318,50 @@ func (h *WorkflowHandler) DeleteWorkflowApplication(c *gin.Context) {
 		return
 	}
 
+	var patch map[string]interface{}
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	err = funcs.WorkflowFuncs{}.DeleteWorkflowApplication(ctx, id)
+	result, err := funcs.WorkflowFuncs{}.PatchWorkflowApplicationVariables(ctx, id, patch)
 	if err != nil {
 		if err.Error() == "workflow application not found" {
 			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
 				"id": id,
 			}))
 		} else {
-			middleware.ThrowError(c, middleware.DatabaseError("删除工作流应用失败", err.Error()))
+			middleware.ThrowError(c, middleware.DatabaseError("更新工作流应用变量失败", err.Error()))
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "工作流应用删除成功",
+		"data":    result,
+		"message": "工作流应用变量更新成功",
 	})
 }
 
-// CloneWorkflowApplication 克隆工作流应用
-// @Summary      克隆工作流应用
-// @Description  克隆一个工作流应用及其所有节点
+// UpdateWorkflowApplicationViewport 更新工作流应用画布视口
+// @Summary      更新工作流应用画布视口
+// @Description  只更新应用的 viewportConfig（x、y、zoom），不影响其他字段，适用于前端高频保存平移/缩放
 // @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string  true  "工作流应用ID"
-// @Param        body  body      object{name=string}  true  "新应用名称"
-// @Success      201   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      404   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/applications/{id}/clone [post]
-func (h *WorkflowHandler) CloneWorkflowApplication(c *gin.Context) {
+// @Param        id        path      string                          true  "工作流应用ID"
+// @Param        viewport  body      object{x=number,y=number,zoom=number}  true  "视口配置"
+// @Success      200       {object}  object{success=bool,message=string}
+// @Failure      400       {object}  object{success=bool,message=string}
+// @Failure      404       {object}  object{success=bool,message=string}
+// @Failure      500       {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/viewport [patch]
+func (h *WorkflowHandler) UpdateWorkflowApplicationViewport(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.ParseUint(idStr, 10, 64)
@@ -292,1215 +372,3741 @@ func (h *WorkflowHandler) CloneWorkflowApplication(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Name string `json:"name" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var viewport map[string]interface{}
+	if err := c.ShouldBindJSON(&viewport); err != nil {
 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
 		return
 	}
 
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	app, err := funcs.WorkflowFuncs{}.CloneWorkflowApplication(ctx, id, req.Name)
-	if err != nil {
+	if err := (funcs.WorkflowFuncs{}).UpdateViewportConfig(ctx, id, viewport); err != nil {
 		if err.Error() == "workflow application not found" {
 			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
 				"id": id,
 			}))
+		} else if strings.HasPrefix(err.Error(), "invalid viewport") {
+			middleware.ThrowError(c, middleware.BadRequestError("视口配置无效", err.Error()))
 		} else {
-			middleware.ThrowError(c, middleware.DatabaseError("克隆工作流应用失败", err.Error()))
+			middleware.ThrowError(c, middleware.DatabaseError("更新视口配置失败", err.Error()))
 		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    app,
-		"message": "工作流应用克隆成功",
+		"message": "视口配置更新成功",
 	})
 }
 
-// ============ WorkflowNode Handlers ============
-
-// GetWorkflowNodes 获取所有工作流节点
-// @Summary      获取所有工作流节点
-// @Description  获取系统中所有工作流节点的列表
-// @Tags         workflow-nodes
+// ExecuteWorkflowApplication 执行工作流应用
+// @Summary      执行工作流应用
+// @Description  按应用配置的输入Schema校验输入后创建待执行的执行记录，输入不合法时返回字段级错误。
+// @Description  默认立即返回执行记录（异步，客户端轮询）；wait=true 时等待执行结束并直接返回包含输出的执行结果；
+// @Description  请求头 Accept: text/event-stream 时以 SSE 逐个推送执行事件，最后推送 result 事件。等待超时后返回执行ID（同步为202，SSE 为 timeout 事件）
+// @Description  请求体 fromExecutionId 指定同一应用之前的执行时，以其输入为模板（input 中的顶层键覆盖模板），响应中的输入会脱敏
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse,count=int}
-// @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes [get]
-func (h *WorkflowHandler) GetWorkflowNodes(c *gin.Context) {
+// @Produce      text/event-stream
+// @Param        id       path      string                                   true   "工作流应用ID"
+// @Param        wait     query     bool                                     false  "是否等待执行结束"
+// @Param        timeout  query     int                                      false  "等待时间（秒），默认和上限见配置 workflow.wait"
+// @Param        request  body      models.ExecuteWorkflowApplicationRequest  true   "执行输入"
+// @Success      200      {object}  object{success=bool,data=models.WorkflowExecutionResponse}
+// @Success      201      {object}  object{success=bool,data=models.WorkflowExecutionResponse}
+// @Success      202      {object}  object{success=bool,data=models.WorkflowExecutionResponse}
+// @Failure      400      {object}  object{success=bool,message=string,data=[]models.InputFieldError}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      409      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Failure      503      {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/execute [post]
+func (h *WorkflowHandler) ExecuteWorkflowApplication(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.ExecuteWorkflowApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
+
+	stream := strings.Contains(c.GetHeader("Accept"), "text/event-stream")
+	wait := stream
+	if waitStr := c.Query("wait"); waitStr != "" {
+		if wait, err = strconv.ParseBool(waitStr); err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("wait参数无效", map[string]any{
+				"provided_wait": waitStr,
+			}))
+			return
+		}
+		wait = wait || stream
+	}
+	waitTimeout, err := executionWaitTimeout(c.Query("timeout"))
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("timeout参数无效", err.Error()))
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	nodes, err := funcs.WorkflowFuncs{}.GetAllWorkflowNodes(ctx)
+
+	// 复制之前执行的输入时，响应中的输入需要脱敏，避免回显密钥派生的值
+	input := req.Input
+	present := func(*models.WorkflowExecutionResponse) {}
+	if req.FromExecutionID != "" {
+		input, err = funcs.WorkflowFuncs{}.CopyExecutionInput(ctx, id, req.FromExecutionID, req.Input)
+		if err != nil {
+			switch {
+			case err.Error() == "workflow execution not found":
+				middleware.ThrowError(c, middleware.NotFoundError("模板执行记录未找到", map[string]any{
+					"fromExecutionId": req.FromExecutionID,
+				}))
+			case funcs.IsSourceExecutionMismatch(err):
+				middleware.ThrowError(c, middleware.BadRequestError("模板执行不属于该工作流应用", map[string]any{
+					"fromExecutionId": req.FromExecutionID,
+				}))
+			default:
+				middleware.ThrowError(c, middleware.DatabaseError("加载模板执行输入失败", err.Error()))
+			}
+			return
+		}
+		present = func(resp *models.WorkflowExecutionResponse) {
+			resp.Input = funcs.WorkflowFuncs{}.RedactExecutionInput(ctx, id, resp.Input)
+		}
+	}
+
+	createReq := &models.CreateWorkflowExecutionRequest{
+		ApplicationID: idStr,
+		Input:         input,
+		Context:       req.Context,
+		TriggerSource: funcs.WorkflowTriggerSourceManual,
+	}
+	if userID, ok := middleware.GetCurrentUserID(c); ok {
+		createReq.TriggeredBy = fmt.Sprintf("user:%d", userID)
+	}
+
+	execution, err := funcs.WorkflowFuncs{}.CreateWorkflowExecution(ctx, createReq)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("获取工作流节点列表失败", err.Error()))
+		var inputErr *funcs.InputValidationError
+		if errors.As(err, &inputErr) {
+			middleware.ThrowError(c, middleware.ValidationError("执行输入校验失败", inputErr.Errors))
+		} else if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+				"id": id,
+			}))
+		} else if funcs.IsWorkflowEditConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("工作流正在保存，请稍后重试", err.Error()))
+		} else if funcs.IsWorkflowTemplateNotExecutable(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("模板不能直接执行，请先实例化", err.Error()))
+		} else if funcs.IsExecutionQueueUnavailable(err) {
+			middleware.ThrowError(c, middleware.ServiceUnavailableError("执行队列繁忙或正在关闭，请稍后重试", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("创建执行记录失败", err.Error()))
+		}
+		return
+	}
+	present(execution)
+
+	if !wait {
+		c.JSON(http.StatusCreated, gin.H{
+			"success": true,
+			"data":    execution,
+			"message": "工作流执行已创建",
+		})
+		return
+	}
+
+	if stream {
+		streamWorkflowExecution(c, execution, waitTimeout, present)
+		return
+	}
+
+	result, err := funcs.WorkflowFuncs{}.WaitWorkflowExecution(ctx, execution.ExecutionID, waitTimeout, nil)
+	if err != nil {
+		if funcs.IsExecutionWaitTimeout(err) {
+			c.JSON(http.StatusAccepted, gin.H{
+				"success": true,
+				"data":    execution,
+				"message": "等待执行结果超时，请使用执行ID轮询",
+			})
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("获取执行结果失败", err.Error()))
+		}
 		return
 	}
+	present(result)
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    nodes,
-		"count":   len(nodes),
+		"data":    result,
+		"message": "工作流执行已结束",
 	})
 }
 
-// GetWorkflowNodesByApplicationID 根据应用ID获取工作流节点
-// @Summary      根据应用ID获取工作流节点
-// @Description  获取指定工作流应用的所有节点
-// @Tags         workflow-nodes
-// @Accept       json
-// @Produce      json
-// @Param        applicationId  query     string  true  "工作流应用ID"
-// @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse,count=int}
-// @Failure      400  {object}  object{success=bool,message=string}
-// @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes/by-application [get]
-func (h *WorkflowHandler) GetWorkflowNodesByApplicationID(c *gin.Context) {
-	applicationIDStr := c.Query("applicationId")
+// executionWaitTimeout 解析等待执行结果的超时时间，未指定时使用配置的默认值，超过上限时取上限
+func executionWaitTimeout(timeoutStr string) (time.Duration, error) {
+	config := configs.GetConfig().Workflow.Wait
+	seconds := config.DefaultTimeout
+	if timeoutStr != "" {
+		value, err := strconv.Atoi(timeoutStr)
+		if err != nil || value <= 0 {
+			return 0, fmt.Errorf("timeout must be a positive number of seconds: %q", timeoutStr)
+		}
+		seconds = value
+	}
+	if config.MaxTimeout > 0 && seconds > config.MaxTimeout {
+		seconds = config.MaxTimeout
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
 
-	if applicationIDStr == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID不能为空", nil))
-		return
+// streamWorkflowExecution 以 SSE 推送执行进度：created 事件携带执行记录，之后为各执行事件（事件名即事件类型），
+// 执行结束时推送 result 事件（最终执行结果，推送前经过 present 处理），等待超时推送 timeout 事件，客户端据其中的执行ID改为轮询
+func streamWorkflowExecution(c *gin.Context, execution *models.WorkflowExecutionResponse, timeout time.Duration, present func(*models.WorkflowExecutionResponse)) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	c.SSEvent("created", execution)
+	c.Writer.Flush()
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.WaitWorkflowExecution(ctx, execution.ExecutionID, timeout, func(event *models.WorkflowExecutionEvent) {
+		c.SSEvent(event.Event, event)
+		c.Writer.Flush()
+	})
+	switch {
+	case err == nil:
+		present(result)
+		c.SSEvent("result", result)
+	case funcs.IsExecutionWaitTimeout(err):
+		c.SSEvent("timeout", gin.H{
+			"executionId": execution.ExecutionID,
+			"message":     "等待执行结果超时，请使用执行ID轮询",
+		})
+	default:
+		c.SSEvent("error", gin.H{
+			"executionId": execution.ExecutionID,
+			"message":     err.Error(),
+		})
 	}
+	c.Writer.Flush()
+}
 
-	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
+// EstimateWorkflowCost 预估工作流执行成本
+// @Summary      预估工作流执行成本
+// @Description  按最坏路径（条件节点取成本最高的分支，循环按上限计次）预估 LLM 节点的 token 用量和成本，价格表来自配置
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                              true   "工作流应用ID"
+// @Param        request  body      models.EstimateWorkflowCostRequest  false  "用于解析提示词占位符的执行输入"
+// @Success      200      {object}  object{success=bool,data=models.CostEstimate}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/estimate [post]
+func (h *WorkflowHandler) EstimateWorkflowCost(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
 		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
-			"provided_id": applicationIDStr,
+			"provided_id": idStr,
 		}))
 		return
 	}
 
+	var req models.EstimateWorkflowCostRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+			return
+		}
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	nodes, err := funcs.WorkflowFuncs{}.GetWorkflowNodesByApplicationID(ctx, applicationID)
+	estimate, err := funcs.WorkflowFuncs{}.EstimateWorkflowCost(ctx, id, req.Input)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("获取工作流节点列表失败", err.Error()))
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("预估执行成本失败", err.Error()))
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    nodes,
-		"count":   len(nodes),
+		"data":    estimate,
 	})
 }
 
-// GetWorkflowNode 根据ID获取工作流节点
-// @Summary      根据ID获取工作流节点
-// @Description  根据工作流节点ID获取详细信息
-// @Tags         workflow-nodes
+// DeleteWorkflowApplication 删除工作流应用
+// @Summary      删除工作流应用
+// @Description  根据ID删除工作流应用，按配置级联软删除其节点、边和调度，或在应用非空时拒绝删除；版本和执行记录保留用于审计
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "工作流节点ID"
-// @Success      200  {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Param        id   path      string  true  "工作流应用ID"
+// @Success      200  {object}  object{success=bool,message=string}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      409  {object}  object{success=bool,message=string}
 // @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes/{id} [get]
-func (h *WorkflowHandler) GetWorkflowNode(c *gin.Context) {
+// @Router       /workflow/applications/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflowApplication(c *gin.Context) {
 	idStr := c.Param("id")
 
-	if idStr == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID不能为空", nil))
-		return
-	}
-
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
 			"provided_id": idStr,
 		}))
 		return
 	}
 
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessOwner) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	node, err := funcs.WorkflowFuncs{}.GetWorkflowNodeByID(ctx, id)
+	err = funcs.WorkflowFuncs{}.DeleteWorkflowApplication(ctx, id)
 	if err != nil {
-		if err.Error() == "workflow node not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
 				"id": id,
 			}))
+		} else if strings.HasPrefix(err.Error(), "workflow application is not empty") {
+			middleware.ThrowError(c, middleware.ConflictError("应用下仍有节点或边，请先删除后再删除应用", err.Error()))
 		} else {
-			middleware.ThrowError(c, middleware.DatabaseError("查询工作流节点失败", err.Error()))
+			middleware.ThrowError(c, middleware.DatabaseError("删除工作流应用失败", err.Error()))
 		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    node,
+		"message": "工作流应用删除成功",
 	})
 }
 
-// CreateWorkflowNode 创建工作流节点
-// @Summary      创建工作流节点
-// @Description  创建新的工作流节点
-// @Tags         workflow-nodes
+// BatchTransitionWorkflowApplicationStatus 批量修改工作流应用状态
+// @Summary      批量修改工作流应用状态
+// @Description  按状态机规则将多个应用转换到目标状态（draft 可发布或归档，published 可退回草稿或归档，archived 只能恢复为草稿）。发布时创建版本快照，归档时停用应用的定时调度。默认逐个应用转换并返回每一项的结果，配置为原子模式时任意一项失败则全部回滚
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        node  body      models.CreateWorkflowNodeRequest  true  "工作流节点信息"
-// @Success      201   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Param        body  body      models.BatchStatusRequest  true  "应用ID列表和目标状态"
+// @Success      200   {object}  object{success=bool,data=models.BatchStatusResult,message=string}
 // @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
 // @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes [post]
-func (h *WorkflowHandler) CreateWorkflowNode(c *gin.Context) {
-	var req models.CreateWorkflowNodeRequest
+// @Router       /workflow/applications/batch-status [post]
+func (h *WorkflowHandler) BatchTransitionWorkflowApplicationStatus(c *gin.Context) {
+	var req models.BatchStatusRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
 		return
 	}
 
-	if req.Name == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流节点名称不能为空", nil))
-		return
+	ids := make([]uint64, 0, len(req.ApplicationIDs))
+	for _, idStr := range req.ApplicationIDs {
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+				"provided_id": idStr,
+			}))
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+			return
+		}
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	node, err := funcs.WorkflowFuncs{}.CreateWorkflowNode(ctx, &req)
+	result, err := funcs.WorkflowFuncs{}.BatchTransitionStatus(ctx, ids, req.Status)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("创建工作流节点失败", err.Error()))
+		if funcs.IsStatusTransitionRejected(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("应用状态转换不允许", err.Error()))
+		} else if strings.HasSuffix(err.Error(), "workflow application not found") {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("批量修改工作流应用状态失败", err.Error()))
+		}
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    node,
-		"message": "工作流节点创建成功",
+		"data":    result,
+		"message": "工作流应用状态批量修改完成",
 	})
 }
 
-// UpdateWorkflowNode 更新工作流节点
-// @Summary      更新工作流节点
-// @Description  根据ID更新工作流节点信息
-// @Tags         workflow-nodes
+// CloneWorkflowApplication 克隆工作流应用
+// @Summary      克隆工作流应用
+// @Description  克隆一个工作流应用及其所有节点
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string                            true  "工作流节点ID"
-// @Param        node  body      models.UpdateWorkflowNodeRequest  true  "工作流节点信息"
-// @Success      200   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Param        id    path      string  true  "工作流应用ID"
+// @Param        body  body      object{name=string}  true  "新应用名称"
+// @Success      201   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
 // @Failure      400   {object}  object{success=bool,message=string}
 // @Failure      404   {object}  object{success=bool,message=string}
 // @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes/{id} [put]
-func (h *WorkflowHandler) UpdateWorkflowNode(c *gin.Context) {
+// @Router       /workflow/applications/{id}/clone [post]
+func (h *WorkflowHandler) CloneWorkflowApplication(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
 			"provided_id": idStr,
 		}))
 		return
 	}
 
-	var req models.UpdateWorkflowNodeRequest
+	var req struct {
+		Name string `json:"name" binding:"required"`
+	}
 	if err := c.ShouldBindJSON(&req); err != nil {
 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
 		return
 	}
 
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+	userID, _ := middleware.GetCurrentUserID(c)
+
 	ctx := middleware.GetRequestContext(c)
-	node, err := funcs.WorkflowFuncs{}.UpdateWorkflowNode(ctx, id, &req)
+	app, err := funcs.WorkflowFuncs{}.CloneWorkflowApplication(ctx, userID, id, req.Name)
 	if err != nil {
-		if err.Error() == "workflow node not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
 				"id": id,
 			}))
 		} else {
-			middleware.ThrowError(c, middleware.DatabaseError("更新工作流节点失败", err.Error()))
+			middleware.ThrowError(c, middleware.DatabaseError("克隆工作流应用失败", err.Error()))
 		}
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    node,
-		"message": "工作流节点更新成功",
+		"data":    app,
+		"message": "工作流应用克隆成功",
 	})
 }
 
-// DeleteWorkflowNode 删除工作流节点
-// @Summary      删除工作流节点
-// @Description  根据ID删除工作流节点
-// @Tags         workflow-nodes
+// ShareWorkflowApplication 共享工作流应用
+// @Summary      共享工作流应用
+// @Description  将应用的只读（read）或编辑（edit）权限共享给其他用户，已共享时更新权限；只有所有者或拥有 workflow:admin 权限的用户可以操作。返回应用当前的共享列表
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "工作流节点ID"
-// @Success      200  {object}  object{success=bool,message=string}
-// @Failure      400  {object}  object{success=bool,message=string}
-// @Failure      404  {object}  object{success=bool,message=string}
-// @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/nodes/{id} [delete]
-func (h *WorkflowHandler) DeleteWorkflowNode(c *gin.Context) {
+// @Param        id    path      string                                  true  "工作流应用ID"
+// @Param        body  body      models.ShareWorkflowApplicationRequest  true  "共享对象和权限"
+// @Success      200   {object}  object{success=bool,data=[]models.WorkflowApplicationShareResponse,message=string}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      403   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/share [post]
+func (h *WorkflowHandler) ShareWorkflowApplication(c *gin.Context) {
 	idStr := c.Param("id")
 
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
 			"provided_id": idStr,
 		}))
 		return
 	}
 
+	var req models.ShareWorkflowApplicationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	userID, err := strconv.ParseUint(req.UserID, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("用户ID格式无效", map[string]any{
+			"provided_user_id": req.UserID,
+		}))
+		return
+	}
+
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	err = funcs.WorkflowFuncs{}.DeleteWorkflowNode(ctx, id)
+	shares, err := funcs.WorkflowFuncs{}.ShareWorkflowApplication(ctx, scope, id, userID, req.Access)
 	if err != nil {
-		if err.Error() == "workflow node not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
-				"id": id,
-			}))
-		} else {
-			middleware.ThrowError(c, middleware.DatabaseError("删除工作流节点失败", err.Error()))
-		}
+		throwWorkflowShareError(c, id, "共享工作流应用失败", err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "工作流节点删除成功",
+		"data":    shares,
+		"message": "工作流应用共享成功",
 	})
 }
 
-// ============ Workflow Graph Operations Handlers ============
-
-// // ConnectNodes 连接两个节点（普通next_node_id连接）
-// // @Summary      连接两个节点
-// // @Description  在两个工作流节点之间创建普通连接（next_node_id）
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{fromNodeId=string,toNodeId=string}  true  "节点连接信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      404   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/connect [post]
-// func (h *WorkflowHandler) ConnectNodes(c *gin.Context) {
-// 	var req struct {
-// 		FromNodeID string `json:"fromNodeId" binding:"required"`
-// 		ToNodeID   string `json:"toNodeId" binding:"required"`
-// 	}
+// UnshareWorkflowApplication 取消共享工作流应用
+// @Summary      取消共享工作流应用
+// @Description  取消用户对应用的共享；只有所有者或拥有 workflow:admin 权限的用户可以操作。返回应用当前的共享列表
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id      path      string  true  "工作流应用ID"
+// @Param        userId  path      string  true  "用户ID"
+// @Success      200   {object}  object{success=bool,data=[]models.WorkflowApplicationShareResponse,message=string}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      403   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/share/{userId} [delete]
+func (h *WorkflowHandler) UnshareWorkflowApplication(c *gin.Context) {
+	idStr := c.Param("id")
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
 
-// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
-// 			"provided_id": req.FromNodeID,
-// 		}))
-// 		return
-// 	}
+	userIDStr := c.Param("userId")
+	userID, err := strconv.ParseUint(userIDStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("用户ID格式无效", map[string]any{
+			"provided_user_id": userIDStr,
+		}))
+		return
+	}
 
-// 	toNodeID, err := strconv.ParseUint(req.ToNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("目标节点ID格式无效", map[string]any{
-// 			"provided_id": req.ToNodeID,
-// 		}))
-// 		return
-// 	}
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.ConnectNodes(ctx, fromNodeID, toNodeID)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("连接节点失败", err.Error()))
-// 		return
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	shares, err := funcs.WorkflowFuncs{}.UnshareWorkflowApplication(ctx, scope, id, userID)
+	if err != nil {
+		throwWorkflowShareError(c, id, "取消共享工作流应用失败", err)
+		return
+	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点连接成功",
-// 	})
-// }
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    shares,
+		"message": "已取消共享工作流应用",
+	})
+}
 
-// // ConnectBranch 为分支节点添加分支连接
-// // @Summary      添加分支连接
-// // @Description  为分支节点（如condition_checker）添加分支连接
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{fromNodeId=string,toNodeId=string,branchName=string}  true  "分支连接信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/connect-branch [post]
-// func (h *WorkflowHandler) ConnectBranch(c *gin.Context) {
-// 	var req struct {
-// 		FromNodeID string `json:"fromNodeId" binding:"required"`
-// 		ToNodeID   string `json:"toNodeId" binding:"required"`
-// 		BranchName string `json:"branchName" binding:"required"`
-// 	}
+// resolveWorkflowAccessScope 获取当前用户的工作流应用可见范围，失败时已写入错误响应
+func resolveWorkflowAccessScope(c *gin.Context) (funcs.WorkflowAccessScope, bool) {
+	userID, ok := middleware.RequireAuth(c)
+	if !ok {
+		return funcs.WorkflowAccessScope{}, false
+	}
+	scope, err := funcs.WorkflowFuncs{}.ResolveWorkflowAccessScope(middleware.GetRequestContext(c), userID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取用户权限失败", err.Error()))
+		return funcs.WorkflowAccessScope{}, false
+	}
+	return scope, true
+}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+// checkWorkflowApplicationAccess 检查当前用户对应用拥有 required 级别的访问权限，失败时已写入错误响应
+func checkWorkflowApplicationAccess(c *gin.Context, id uint64, required string) bool {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return false
+	}
+	err := funcs.WorkflowFuncs{}.CheckWorkflowApplicationAccess(middleware.GetRequestContext(c), scope, id, required)
+	if err != nil {
+		throwWorkflowShareError(c, id, "检查工作流应用权限失败", err)
+		return false
+	}
+	return true
+}
 
-// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
-// 			"provided_id": req.FromNodeID,
-// 		}))
-// 		return
-// 	}
+// checkWorkflowApplicationIDsAccess 检查当前用户对请求体中给出的应用都拥有 required 级别的访问权限，失败时已写入错误响应
+func checkWorkflowApplicationIDsAccess(c *gin.Context, required string, idStrs ...string) bool {
+	for _, idStr := range idStrs {
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+				"provided_id": idStr,
+			}))
+			return false
+		}
+		if !checkWorkflowApplicationAccess(c, id, required) {
+			return false
+		}
+	}
+	return true
+}
 
-// 	toNodeID, err := strconv.ParseUint(req.ToNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("目标节点ID格式无效", map[string]any{
-// 			"provided_id": req.ToNodeID,
-// 		}))
-// 		return
-// 	}
+// workflowResourceNotFoundMessages 资源不存在（或所属应用不可见）时的提示
+var workflowResourceNotFoundMessages = map[string]string{
+	funcs.WorkflowResourceNode:          "工作流节点未找到",
+	funcs.WorkflowResourceEdge:          "工作流边未找到",
+	funcs.WorkflowResourceVersion:       "工作流版本未找到",
+	funcs.WorkflowResourceSchedule:      "定时调度未找到",
+	funcs.WorkflowResourceNodeExecution: "节点执行记录未找到",
+}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.ConnectBranch(ctx, fromNodeID, toNodeID, req.BranchName)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("添加分支连接失败", err.Error()))
-// 		return
-// 	}
+// checkWorkflowResourceAccess 检查当前用户对节点、边等资源所属的应用拥有 required 级别的访问权限，失败时已写入错误响应
+func checkWorkflowResourceAccess(c *gin.Context, resource string, required string, ids ...uint64) bool {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return false
+	}
+	err := funcs.WorkflowFuncs{}.CheckWorkflowResourceAccess(middleware.GetRequestContext(c), scope, resource, required, ids...)
+	if err != nil {
+		if err.Error() == "workflow "+resource+" not found" {
+			middleware.ThrowError(c, middleware.NotFoundError(workflowResourceNotFoundMessages[resource], map[string]any{
+				"ids": ids,
+			}))
+		} else if funcs.IsWorkflowAccessDenied(err) {
+			middleware.ThrowError(c, middleware.ForbiddenError("没有该工作流应用的访问权限", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("检查工作流应用权限失败", err.Error()))
+		}
+		return false
+	}
+	return true
+}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "分支连接添加成功",
-// 	})
-// }
+// checkWorkflowResourceIDsAccess 同 checkWorkflowResourceAccess，ID为字符串形式；格式无效的ID跳过，由后续处理报告
+func checkWorkflowResourceIDsAccess(c *gin.Context, resource string, required string, idStrs []string) bool {
+	ids := make([]uint64, 0, len(idStrs))
+	for _, idStr := range idStrs {
+		if id, err := strconv.ParseUint(idStr, 10, 64); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return checkWorkflowResourceAccess(c, resource, required, ids...)
+}
 
-// // DisconnectBranch 删除分支连接
-// // @Summary      删除分支连接
-// // @Description  删除分支节点的某个分支连接
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{fromNodeId=string,branchName=string}  true  "分支信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/disconnect-branch [post]
-// func (h *WorkflowHandler) DisconnectBranch(c *gin.Context) {
-// 	var req struct {
-// 		FromNodeID string `json:"fromNodeId" binding:"required"`
-// 		BranchName string `json:"branchName" binding:"required"`
-// 	}
+// checkWorkflowExecutionAccess 检查当前用户对执行所属的应用拥有 required 级别的访问权限，失败时已写入错误响应
+func checkWorkflowExecutionAccess(c *gin.Context, executionID string, required string) bool {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return false
+	}
+	err := funcs.WorkflowFuncs{}.CheckWorkflowExecutionAccess(middleware.GetRequestContext(c), scope, executionID, required)
+	if err != nil {
+		if err.Error() == "workflow execution not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流执行未找到", map[string]any{
+				"executionId": executionID,
+			}))
+		} else if funcs.IsWorkflowAccessDenied(err) {
+			middleware.ThrowError(c, middleware.ForbiddenError("没有该工作流应用的访问权限", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("检查工作流应用权限失败", err.Error()))
+		}
+		return false
+	}
+	return true
+}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+// throwWorkflowShareError 将应用访问控制相关的错误转换为响应
+func throwWorkflowShareError(c *gin.Context, id uint64, message string, err error) {
+	if err.Error() == "workflow application not found" {
+		middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+			"id": id,
+		}))
+	} else if funcs.IsWorkflowAccessDenied(err) {
+		middleware.ThrowError(c, middleware.ForbiddenError("没有该工作流应用的访问权限", err.Error()))
+	} else if strings.HasPrefix(err.Error(), "invalid workflow share") {
+		middleware.ThrowError(c, middleware.BadRequestError("共享请求无效", err.Error()))
+	} else {
+		middleware.ThrowError(c, middleware.DatabaseError(message, err.Error()))
+	}
+}
 
-// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
-// 			"provided_id": req.FromNodeID,
-// 		}))
-// 		return
-// 	}
-
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.DisconnectBranch(ctx, fromNodeID, req.BranchName)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("删除分支连接失败", err.Error()))
-// 		return
-// 	}
-
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "分支连接已删除",
-// 	})
-// }
+// GetWorkflowTemplates 获取模板列表
+// @Summary      获取模板列表
+// @Description  获取当前用户可见的标记为模板的工作流应用，按名称排序
+// @Tags         workflow-templates
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowApplicationResponse,count=int}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/templates [get]
+func (h *WorkflowHandler) GetWorkflowTemplates(c *gin.Context) {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
 
-// // AddNodeToParallel 将节点添加到并行执行节点
-// // @Summary      添加节点到并行执行
-// // @Description  将节点添加到并行执行节点作为子节点
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{parallelNodeId=string,childNodeId=string}  true  "并行节点信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/add-to-parallel [post]
-// func (h *WorkflowHandler) AddNodeToParallel(c *gin.Context) {
-// 	var req struct {
-// 		ParallelNodeID string `json:"parallelNodeId" binding:"required"`
-// 		ChildNodeID    string `json:"childNodeId" binding:"required"`
-// 	}
+	templates, err := funcs.WorkflowFuncs{}.ListTemplates(middleware.GetRequestContext(c), scope)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取模板列表失败", err.Error()))
+		return
+	}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    templates,
+		"count":   len(templates),
+	})
+}
 
-// 	parallelNodeID, err := strconv.ParseUint(req.ParallelNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID格式无效", map[string]any{
-// 			"provided_id": req.ParallelNodeID,
-// 		}))
-// 		return
-// 	}
+// InstantiateWorkflowTemplate 实例化模板
+// @Summary      实例化模板
+// @Description  从模板创建普通的草稿应用（复制所有节点和边并重写分支目标），创建人为当前用户；名称为空时使用模板名称。当前用户不能读取的模板返回404
+// @Tags         workflow-templates
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                     true   "模板ID"
+// @Param        body  body      models.InstantiateWorkflowTemplateRequest  false  "新应用名称"
+// @Success      201   {object}  object{success=bool,data=models.WorkflowApplicationResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/templates/{id}/instantiate [post]
+func (h *WorkflowHandler) InstantiateWorkflowTemplate(c *gin.Context) {
+	idStr := c.Param("id")
 
-// 	childNodeID, err := strconv.ParseUint(req.ChildNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("子节点ID格式无效", map[string]any{
-// 			"provided_id": req.ChildNodeID,
-// 		}))
-// 		return
-// 	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("模板ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.AddNodeToParallel(ctx, parallelNodeID, childNodeID)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("添加节点到并行执行失败", err.Error()))
-// 		return
-// 	}
+	// 请求体可以省略，使用模板名称
+	var req models.InstantiateWorkflowTemplateRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+			return
+		}
+	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点已添加到并行执行",
-// 	})
-// }
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
 
-// // RemoveNodeFromParallel 从并行执行节点中移除子节点
-// // @Summary      从并行执行中移除节点
-// // @Description  从并行执行节点中移除子节点
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{childNodeId=string}  true  "子节点ID"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/remove-from-parallel [post]
-// func (h *WorkflowHandler) RemoveNodeFromParallel(c *gin.Context) {
-// 	var req struct {
-// 		ChildNodeID string `json:"childNodeId" binding:"required"`
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	app, err := funcs.WorkflowFuncs{}.InstantiateTemplate(ctx, scope, id, req.Name)
+	if err != nil {
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("模板未找到", map[string]any{
+				"id": id,
+			}))
+		} else if funcs.IsNotWorkflowTemplate(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("该应用不是模板", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("实例化模板失败", err.Error()))
+		}
+		return
+	}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    app,
+		"message": "模板实例化成功",
+	})
+}
 
-// 	childNodeID, err := strconv.ParseUint(req.ChildNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("子节点ID格式无效", map[string]any{
-// 			"provided_id": req.ChildNodeID,
-// 		}))
-// 		return
-// 	}
+// DuplicateSubgraph 复制节点子图
+// @Summary      复制节点子图
+// @Description  在应用内复制选中的节点及两端都在选区内的边，分支目标映射为副本，副本位置按 offset 偏移；返回 原ID -> 副本ID 的映射
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                           true  "工作流应用ID"
+// @Param        body  body      models.DuplicateSubgraphRequest  true  "要复制的节点及位置偏移"
+// @Success      201   {object}  object{success=bool,data=models.BatchSaveWorkflowData}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/duplicate-subgraph [post]
+func (h *WorkflowHandler) DuplicateSubgraph(c *gin.Context) {
+	idStr := c.Param("id")
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.RemoveNodeFromParallel(ctx, childNodeID)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("从并行执行中移除节点失败", err.Error()))
-// 		return
-// 	}
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点已从并行执行中移除",
-// 	})
-// }
+	var req models.DuplicateSubgraphRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
 
-// // GetParallelChildren 获取并行节点的所有子节点
-// // @Summary      获取并行节点的子节点
-// // @Description  获取并行执行节点的所有子节点列表
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        parallelNodeId  query     string  true  "并行节点ID"
-// // @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse}
-// // @Failure      400  {object}  object{success=bool,message=string}
-// // @Failure      500  {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/parallel-children [get]
-// func (h *WorkflowHandler) GetParallelChildren(c *gin.Context) {
-// 	parallelNodeIDStr := c.Query("parallelNodeId")
+	nodeIDs := make([]uint64, 0, len(req.NodeIDs))
+	for _, nodeIDStr := range req.NodeIDs {
+		nodeID, err := strconv.ParseUint(nodeIDStr, 10, 64)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", map[string]any{
+				"provided_id": nodeIDStr,
+			}))
+			return
+		}
+		nodeIDs = append(nodeIDs, nodeID)
+	}
 
-// 	if parallelNodeIDStr == "" {
-// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID不能为空", nil))
-// 		return
-// 	}
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
 
-// 	parallelNodeID, err := strconv.ParseUint(parallelNodeIDStr, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID格式无效", map[string]any{
-// 			"provided_id": parallelNodeIDStr,
-// 		}))
-// 		return
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.DuplicateSubgraph(ctx, id, nodeIDs, req.Offset)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid subgraph selection") {
+			middleware.ThrowError(c, middleware.BadRequestError("选中的节点无效", err.Error()))
+			return
+		}
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+				"id": id,
+			}))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("复制节点失败", err.Error()))
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	children, err := funcs.WorkflowFuncs{}.GetParallelChildren(ctx, parallelNodeID)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("获取并行子节点失败", err.Error()))
-// 		return
-// 	}
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "节点复制成功",
+	})
+}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"data":    children,
-// 		"count":   len(children),
-// 	})
-// }
+// AutoLayoutWorkflow 自动布局工作流
+// @Summary      自动布局工作流
+// @Description  按分层方式从起始节点开始计算所有节点的位置，环路中的回边在布局时忽略；persist=true 时保存计算出的位置
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                true   "工作流应用ID"
+// @Param        body  body      models.LayoutOptions  false  "布局方向、间距及是否保存"
+// @Success      200   {object}  object{success=bool,data=models.AutoLayoutWorkflowResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/auto-layout [post]
+func (h *WorkflowHandler) AutoLayoutWorkflow(c *gin.Context) {
+	idStr := c.Param("id")
 
-// // GetNodeConnections 获取节点的所有连接信息
-// // @Summary      获取节点连接信息
-// // @Description  获取节点的所有连接信息（包括next、branch、parallel等）
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        nodeId  query     string  true  "节点ID"
-// // @Success      200  {object}  object{success=bool,data=object}
-// // @Failure      400  {object}  object{success=bool,message=string}
-// // @Failure      500  {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/connections [get]
-// func (h *WorkflowHandler) GetNodeConnections(c *gin.Context) {
-// 	nodeIDStr := c.Query("nodeId")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
 
-// 	if nodeIDStr == "" {
-// 		middleware.ThrowError(c, middleware.BadRequestError("节点ID不能为空", nil))
-// 		return
-// 	}
+	// 请求体可以省略，全部使用默认参数
+	var req models.LayoutOptions
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+			return
+		}
+	}
 
-// 	nodeID, err := strconv.ParseUint(nodeIDStr, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", map[string]any{
-// 			"provided_id": nodeIDStr,
-// 		}))
-// 		return
-// 	}
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessEdit) {
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	connections, err := funcs.WorkflowFuncs{}.GetNodeConnections(ctx, nodeID)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("获取节点连接信息失败", err.Error()))
+	ctx := middleware.GetRequestContext(c)
+	positions, err := funcs.WorkflowFuncs{}.AutoLayoutWorkflow(ctx, id, req)
+	if err != nil {
+		if funcs.IsInvalidLayoutOptions(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("布局参数无效", err.Error()))
+			return
+		}
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+				"id": id,
+			}))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("自动布局失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": models.AutoLayoutWorkflowResponse{
+			ApplicationID: idStr,
+			Positions:     positions,
+			Persisted:     req.Persist,
+		},
+		"message": "自动布局完成",
+	})
+}
+
+// DiffWorkflowApplications 对比两个工作流应用
+// @Summary      对比两个工作流应用
+// @Description  按名称和类型匹配两个应用的节点，返回节点配置、提示词、代码的差异，边的差异以及只存在于一侧的节点，用于检测模板与派生应用之间的偏离
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        left   query     string  true  "左侧工作流应用ID"
+// @Param        right  query     string  true  "右侧工作流应用ID"
+// @Success      200    {object}  object{success=bool,data=models.WorkflowApplicationDiff}
+// @Failure      400    {object}  object{success=bool,message=string}
+// @Failure      404    {object}  object{success=bool,message=string}
+// @Failure      500    {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/diff [get]
+func (h *WorkflowHandler) DiffWorkflowApplications(c *gin.Context) {
+	ids := make([]uint64, 0, 2)
+	for _, param := range []string{"left", "right"} {
+		idStr := c.Query(param)
+		if idStr == "" {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID不能为空", map[string]any{
+				"param": param,
+			}))
+			return
+		}
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+				"param":       param,
+				"provided_id": idStr,
+			}))
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+			return
+		}
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.DiffWorkflowApplications(ctx, ids[0], ids[1])
+	if err != nil {
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用未找到", map[string]any{
+				"left":  ids[0],
+				"right": ids[1],
+			}))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("对比工作流应用失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "对比完成",
+	})
+}
+
+// GetWorkflowExecutionOrder 获取工作流的拓扑执行顺序
+// @Summary      获取工作流的拓扑执行顺序
+// @Description  按层级返回节点的执行顺序，同一层级的节点可并行执行；处于环路中的节点在cyclic分组中单独返回
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=[]models.ExecutionLevel}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/execution-order [get]
+func (h *WorkflowHandler) GetWorkflowExecutionOrder(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	levels, err := funcs.WorkflowFuncs{}.GetExecutionOrder(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("计算执行顺序失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    levels,
+	})
+}
+
+// GetWorkflowDiagram 导出工作流图
+// @Summary      导出工作流图
+// @Description  将应用的节点和边导出为 Mermaid flowchart 或 Graphviz DOT 文本，用于粘贴到文档中。download=true 时以附件形式下载
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Produce      plain
+// @Param        id        path      string  true   "应用ID"
+// @Param        format    query     string  false  "导出格式：mermaid（默认）或 dot"
+// @Param        download  query     bool    false  "是否以附件形式下载"
+// @Success      200       {object}  object{success=bool,data=object{format=string,content=string}}
+// @Failure      400       {object}  object{success=bool,message=string}
+// @Failure      404       {object}  object{success=bool,message=string}
+// @Failure      500       {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/diagram [get]
+func (h *WorkflowHandler) GetWorkflowDiagram(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format")))
+	if format == "" {
+		format = funcs.DiagramFormatMermaid
+	}
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	content, err := funcs.WorkflowFuncs{}.RenderWorkflowDiagram(ctx, id, format)
+	if err != nil {
+		if funcs.IsUnsupportedDiagramFormat(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("不支持的导出格式，可选 mermaid 或 dot", map[string]any{
+				"provided_format": format,
+			}))
+			return
+		}
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("导出工作流图失败", err.Error()))
+		return
+	}
+
+	if c.Query("download") == "true" {
+		extension := "mmd"
+		if format == funcs.DiagramFormatDOT {
+			extension = "dot"
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=workflow-%d.%s", id, extension))
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(content))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"format":  format,
+			"content": content,
+		},
+	})
+}
+
+// ValidateWorkflowApplication 校验工作流图结构
+// @Summary      校验工作流图结构
+// @Description  检查工作流图中的配置问题（如条件节点缺少默认分支），返回问题列表，不会修改数据
+// @Tags         workflow-applications
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowValidationIssue}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/validate [get]
+func (h *WorkflowHandler) ValidateWorkflowApplication(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, id, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	issues, err := funcs.WorkflowFuncs{}.ValidateWorkflowApplication(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("校验工作流失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    issues,
+	})
+}
+
+// ============ WorkflowNode Handlers ============
+
+// GetWorkflowNodes 获取所有工作流节点
+// @Summary      获取所有工作流节点
+// @Description  获取系统中所有工作流节点的列表
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse,count=int}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes [get]
+func (h *WorkflowHandler) GetWorkflowNodes(c *gin.Context) {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	nodes, err := funcs.WorkflowFuncs{}.GetAllWorkflowNodes(ctx, scope)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流节点列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    nodes,
+		"count":   len(nodes),
+	})
+}
+
+// GetWorkflowNodesByApplicationID 根据应用ID获取工作流节点
+// @Summary      根据应用ID获取工作流节点
+// @Description  获取指定工作流应用的所有节点；提供任一分页或排序参数时改为分页返回，响应中附带 pagination
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        applicationId  query     string  true   "工作流应用ID"
+// @Param        page           query     int     false  "页码"
+// @Param        pageSize       query     int     false  "每页数量"
+// @Param        order          query     string  false  "排序方式"  Enums(asc, desc)
+// @Param        orderBy        query     string  false  "排序字段"  Enums(createTime, updateTime, name, type)
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse,count=int,pagination=models.Pagination}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/by-application [get]
+func (h *WorkflowHandler) GetWorkflowNodesByApplicationID(c *gin.Context) {
+	applicationIDStr := c.Query("applicationId")
+
+	if applicationIDStr == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID不能为空", nil))
+		return
+	}
+
+	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", map[string]any{
+			"provided_id": applicationIDStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	// 带分页参数时按分页返回，否则保持返回全部节点
+	if hasPaginationQuery(c) {
+		h.GetWorkflowNodesWithPagination(c)
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	nodes, err := funcs.WorkflowFuncs{}.GetWorkflowNodesByApplicationID(ctx, applicationID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流节点列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    nodes,
+		"count":   len(nodes),
+	})
+}
+
+// GetWorkflowNodesWithPagination 分页获取工作流节点列表
+// @Summary      分页获取工作流节点列表
+// @Description  根据分页参数和过滤条件获取工作流节点列表，默认按创建时间升序
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        page           query     int     false  "页码"      default(1)
+// @Param        pageSize       query     int     false  "每页数量"  default(10)
+// @Param        order          query     string  false  "排序方式"  default(asc)
+// @Param        orderBy        query     string  false  "排序字段"  default(createTime)
+// @Param        applicationId  query     string  false  "工作流应用ID"
+// @Param        name           query     string  false  "节点名称"
+// @Param        type           query     string  false  "节点类型"
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse,pagination=models.Pagination}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/page [get]
+func (h *WorkflowHandler) GetWorkflowNodesWithPagination(c *gin.Context) {
+	var req models.PageWorkflowNodeRequest
+
+	// 设置默认值
+	req.Page = 1
+	req.PageSize = 10
+	req.Order = "asc"
+	req.OrderBy = "createTime"
+
+	// 绑定查询参数
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("查询参数格式错误", err.Error()))
+		return
+	}
+
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.GetWorkflowNodesWithPagination(ctx, scope, &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid application id") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流应用ID格式无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流节点列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result.Data,
+		"count":      len(result.Data),
+		"pagination": result.Pagination,
+	})
+}
+
+// hasPaginationQuery 请求是否携带了分页或排序参数
+func hasPaginationQuery(c *gin.Context) bool {
+	for _, key := range []string{"page", "pageSize", "order", "orderBy"} {
+		if _, ok := c.GetQuery(key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNodeTypeCatalog 获取节点类型目录
+// @Summary      获取节点类型目录
+// @Description  获取所有工作流节点类型的显示名称、默认颜色、连接规则和配置字段，供编辑器动态渲染
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  object{success=bool,data=[]models.NodeTypeDescriptor,count=int}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/node-types [get]
+func (h *WorkflowHandler) GetNodeTypeCatalog(c *gin.Context) {
+	ctx := middleware.GetRequestContext(c)
+	catalog, err := funcs.WorkflowFuncs{}.GetNodeTypeCatalog(ctx)
+	if err != nil {
+		middleware.ThrowError(c, middleware.InternalServerError("获取节点类型目录失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    catalog,
+		"count":   len(catalog),
+	})
+}
+
+// GetWorkflowNode 根据ID获取工作流节点
+// @Summary      根据ID获取工作流节点
+// @Description  根据工作流节点ID获取详细信息
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "工作流节点ID"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id} [get]
+func (h *WorkflowHandler) GetWorkflowNode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	if idStr == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID不能为空", nil))
+		return
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessRead, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	node, err := funcs.WorkflowFuncs{}.GetWorkflowNodeByID(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("查询工作流节点失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    node,
+	})
+}
+
+// GetNodeConnections 获取节点的所有连接信息
+// @Summary      获取节点连接信息
+// @Description  获取节点的入边和出边；条件节点会按分支分组，并单独返回默认分支（default/else）
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "工作流节点ID"
+// @Success      200  {object}  object{success=bool,data=models.NodeConnectionsResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id}/connections [get]
+func (h *WorkflowHandler) GetNodeConnections(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessRead, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	connections, err := funcs.WorkflowFuncs{}.GetNodeConnections(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("获取节点连接信息失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    connections,
+	})
+}
+
+// CreateWorkflowNode 创建工作流节点
+// @Summary      创建工作流节点
+// @Description  创建新的工作流节点，节点配置按节点类型的 Schema（见 GET /workflow/node-types 的 configSchema）校验，不符合时返回字段级错误；skipConfigValidation=true 跳过校验（仅用于迁移旧数据）
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        node  body      models.CreateWorkflowNodeRequest  true  "工作流节点信息"
+// @Success      201   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes [post]
+func (h *WorkflowHandler) CreateWorkflowNode(c *gin.Context) {
+	var req models.CreateWorkflowNodeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if req.Name == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点名称不能为空", nil))
+		return
+	}
+
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessEdit, req.ApplicationID) {
+		return
+	}
+	// 引用其他应用作为子工作流时需要能读取被引用的应用
+	if req.WorkflowApplicationID != "" && !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessRead, req.WorkflowApplicationID) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	node, err := funcs.WorkflowFuncs{}.CreateWorkflowNode(ctx, &req)
+	if err != nil {
+		var configErr *funcs.NodeConfigValidationError
+		if errors.As(err, &configErr) {
+			middleware.ThrowError(c, middleware.ValidationError("节点配置校验失败", configErr.Errors))
+		} else if strings.HasPrefix(err.Error(), "invalid retry policy") {
+			middleware.ThrowError(c, middleware.BadRequestError("重试策略配置无效", err.Error()))
+		} else if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("节点名称在应用内已存在", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("创建工作流节点失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    node,
+		"message": "工作流节点创建成功",
+	})
+}
+
+// UpdateWorkflowNode 更新工作流节点
+// @Summary      更新工作流节点
+// @Description  根据ID更新工作流节点信息，修改后的节点配置按节点类型的 Schema 校验，不符合时返回字段级错误；skipConfigValidation=true 跳过校验
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                            true  "工作流节点ID"
+// @Param        node  body      models.UpdateWorkflowNodeRequest  true  "工作流节点信息"
+// @Success      200   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id} [put]
+func (h *WorkflowHandler) UpdateWorkflowNode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.UpdateWorkflowNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+	// 引用其他应用作为子工作流时需要能读取被引用的应用
+	if req.WorkflowApplicationID != "" && !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessRead, req.WorkflowApplicationID) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	node, err := funcs.WorkflowFuncs{}.UpdateWorkflowNode(ctx, id, &req)
+	if err != nil {
+		var configErr *funcs.NodeConfigValidationError
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else if errors.As(err, &configErr) {
+			middleware.ThrowError(c, middleware.ValidationError("节点配置校验失败", configErr.Errors))
+		} else if strings.HasPrefix(err.Error(), "invalid retry policy") {
+			middleware.ThrowError(c, middleware.BadRequestError("重试策略配置无效", err.Error()))
+		} else if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("节点名称在应用内已存在", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("更新工作流节点失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    node,
+		"message": "工作流节点更新成功",
+	})
+}
+
+// PatchWorkflowNodeConfig 合并更新工作流节点配置
+// @Summary      合并更新工作流节点配置
+// @Description  将请求体合并到节点现有配置上：同名键覆盖、嵌套对象递归合并、null 删除键，未提供的键保持不变。需要整体替换请使用 PUT
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id     path      string  true  "工作流节点ID"
+// @Param        patch  body      object  true  "要合并的键值，值为 null 表示删除该键"
+// @Success      200   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id}/config [patch]
+func (h *WorkflowHandler) PatchWorkflowNodeConfig(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	skipConfigValidation, err := strconv.ParseBool(c.DefaultQuery("skipConfigValidation", "false"))
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("skipConfigValidation参数格式无效", map[string]any{
+			"provided": c.Query("skipConfigValidation"),
+		}))
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.PatchWorkflowNodeConfig(ctx, id, patch, skipConfigValidation)
+	if err != nil {
+		var configErr *funcs.NodeConfigValidationError
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else if errors.As(err, &configErr) {
+			middleware.ThrowError(c, middleware.ValidationError("节点配置校验失败", configErr.Errors))
+		} else if strings.HasPrefix(err.Error(), "invalid retry policy") {
+			middleware.ThrowError(c, middleware.BadRequestError("重试策略配置无效", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("更新工作流节点配置失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "工作流节点配置更新成功",
+	})
+}
+
+// SetWorkflowNodeEnabled 启用/禁用工作流节点
+// @Summary      启用/禁用工作流节点
+// @Description  临时禁用节点而不删除节点及其连线，禁用的节点在执行时被跳过并透传到下游节点
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                                true  "工作流节点ID"
+// @Param        body  body      models.SetWorkflowNodeEnabledRequest  true  "启用状态"
+// @Success      200   {object}  object{success=bool,data=models.WorkflowNodeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id}/enabled [patch]
+func (h *WorkflowHandler) SetWorkflowNodeEnabled(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.SetWorkflowNodeEnabledRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	node, err := funcs.WorkflowFuncs{}.SetWorkflowNodeEnabled(ctx, id, *req.Enabled)
+	if err != nil {
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("更新工作流节点状态失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    node,
+		"message": "工作流节点状态更新成功",
+	})
+}
+
+// MoveWorkflowNode 将节点移动到其他工作流应用
+// @Summary      将节点移动到其他工作流应用
+// @Description  修改节点所属的应用，用于纠正建错应用的节点。另一端不在目标应用中的连线会被删除并在结果中返回；原应用的开始节点不能移动
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                          true  "工作流节点ID"
+// @Param        body  body      models.MoveWorkflowNodeRequest  true  "目标应用"
+// @Success      200   {object}  object{success=bool,data=models.MoveWorkflowNodeResponse,message=string}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id}/application [patch]
+func (h *WorkflowHandler) MoveWorkflowNode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.MoveWorkflowNodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+	applicationID, err := strconv.ParseUint(req.ApplicationID, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("目标应用ID格式无效", map[string]any{
+			"provided_id": req.ApplicationID,
+		}))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.MoveNodeToApplication(ctx, id, applicationID)
+	if err != nil {
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else if funcs.IsWorkflowNodeNotMovable(err) {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流节点无法移动", err.Error()))
+		} else if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("节点名称在目标应用内已存在", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("移动工作流节点失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "工作流节点移动成功",
+	})
+}
+
+// TestWorkflowNode 单节点试运行
+// @Summary      单节点试运行
+// @Description  使用手动提供的输入只运行该节点（支持 LLM、API、条件、输入和结束节点），返回输出、解析后的输入（敏感字段已脱敏）、耗时和 token/成本信息，遵循节点超时时间且不写入执行记录
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                          true   "工作流节点ID"
+// @Param        request  body      models.TestWorkflowNodeRequest  false  "节点输入"
+// @Success      200      {object}  object{success=bool,data=models.NodeTestResult}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id}/test [post]
+func (h *WorkflowHandler) TestWorkflowNode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	var req models.TestWorkflowNodeRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+			return
+		}
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.TestNode(ctx, id, req.Input)
+	if err != nil {
+		switch {
+		case err.Error() == "workflow node not found":
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		case strings.HasPrefix(err.Error(), "node test unsupported"):
+			middleware.ThrowError(c, middleware.BadRequestError("该节点类型不支持单独试运行", err.Error()))
+		case strings.HasPrefix(err.Error(), "undefined secret"):
+			middleware.ThrowError(c, middleware.BadRequestError("节点引用了未定义的应用密钥", err.Error()))
+		case err.Error() == "secret store not configured":
+			middleware.ThrowError(c, middleware.BadRequestError("未配置应用密钥存储的主密钥", nil))
+		default:
+			middleware.ThrowError(c, middleware.DatabaseError("节点试运行失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+	})
+}
+
+// DeleteWorkflowNode 删除工作流节点
+// @Summary      删除工作流节点
+// @Description  根据ID删除工作流节点
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "工作流节点ID"
+// @Success      200  {object}  object{success=bool,message=string}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflowNode(c *gin.Context) {
+	idStr := c.Param("id")
+
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("工作流节点ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	err = funcs.WorkflowFuncs{}.DeleteWorkflowNode(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow node not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流节点未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("删除工作流节点失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "工作流节点删除成功",
+	})
+}
+
+// BatchDeleteWorkflowNodes 批量删除工作流节点
+// @Summary      批量删除工作流节点
+// @Description  批量删除多个工作流节点。默认在事务中执行，任意一项失败则全部回滚；continueOnError 为 true 时逐项删除并返回每一项的结果
+// @Tags         workflow-nodes
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BatchDeleteWorkflowNodesRequest  true  "节点ID列表"
+// @Success      200   {object}  object{success=bool,data=models.BatchOperationResult,message=string}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/nodes/batch-delete [post]
+func (h *WorkflowHandler) BatchDeleteWorkflowNodes(c *gin.Context) {
+	var req models.BatchDeleteWorkflowNodesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowResourceIDsAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, req.NodeIDs) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.BatchDeleteWorkflowNodes(ctx, &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid node id") {
+			middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("批量删除工作流节点失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "工作流节点批量删除完成",
+	})
+}
+
+// ============ Workflow Graph Operations Handlers ============
+
+// // ConnectNodes 连接两个节点（普通next_node_id连接）
+// // @Summary      连接两个节点
+// // @Description  在两个工作流节点之间创建普通连接（next_node_id）
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{fromNodeId=string,toNodeId=string}  true  "节点连接信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      404   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/connect [post]
+// func (h *WorkflowHandler) ConnectNodes(c *gin.Context) {
+// 	var req struct {
+// 		FromNodeID string `json:"fromNodeId" binding:"required"`
+// 		ToNodeID   string `json:"toNodeId" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
+// 			"provided_id": req.FromNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	toNodeID, err := strconv.ParseUint(req.ToNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("目标节点ID格式无效", map[string]any{
+// 			"provided_id": req.ToNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.ConnectNodes(ctx, fromNodeID, toNodeID)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("连接节点失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点连接成功",
+// 	})
+// }
+
+// // ConnectBranch 为分支节点添加分支连接
+// // @Summary      添加分支连接
+// // @Description  为分支节点（如condition_checker）添加分支连接
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{fromNodeId=string,toNodeId=string,branchName=string}  true  "分支连接信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/connect-branch [post]
+// func (h *WorkflowHandler) ConnectBranch(c *gin.Context) {
+// 	var req struct {
+// 		FromNodeID string `json:"fromNodeId" binding:"required"`
+// 		ToNodeID   string `json:"toNodeId" binding:"required"`
+// 		BranchName string `json:"branchName" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
+// 			"provided_id": req.FromNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	toNodeID, err := strconv.ParseUint(req.ToNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("目标节点ID格式无效", map[string]any{
+// 			"provided_id": req.ToNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.ConnectBranch(ctx, fromNodeID, toNodeID, req.BranchName)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("添加分支连接失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "分支连接添加成功",
+// 	})
+// }
+
+// // DisconnectBranch 删除分支连接
+// // @Summary      删除分支连接
+// // @Description  删除分支节点的某个分支连接
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{fromNodeId=string,branchName=string}  true  "分支信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/disconnect-branch [post]
+// func (h *WorkflowHandler) DisconnectBranch(c *gin.Context) {
+// 	var req struct {
+// 		FromNodeID string `json:"fromNodeId" binding:"required"`
+// 		BranchName string `json:"branchName" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
+// 			"provided_id": req.FromNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.DisconnectBranch(ctx, fromNodeID, req.BranchName)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("删除分支连接失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "分支连接已删除",
+// 	})
+// }
+
+// // AddNodeToParallel 将节点添加到并行执行节点
+// // @Summary      添加节点到并行执行
+// // @Description  将节点添加到并行执行节点作为子节点
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{parallelNodeId=string,childNodeId=string}  true  "并行节点信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/add-to-parallel [post]
+// func (h *WorkflowHandler) AddNodeToParallel(c *gin.Context) {
+// 	var req struct {
+// 		ParallelNodeID string `json:"parallelNodeId" binding:"required"`
+// 		ChildNodeID    string `json:"childNodeId" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	parallelNodeID, err := strconv.ParseUint(req.ParallelNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID格式无效", map[string]any{
+// 			"provided_id": req.ParallelNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	childNodeID, err := strconv.ParseUint(req.ChildNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("子节点ID格式无效", map[string]any{
+// 			"provided_id": req.ChildNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.AddNodeToParallel(ctx, parallelNodeID, childNodeID)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("添加节点到并行执行失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点已添加到并行执行",
+// 	})
+// }
+
+// // RemoveNodeFromParallel 从并行执行节点中移除子节点
+// // @Summary      从并行执行中移除节点
+// // @Description  从并行执行节点中移除子节点
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{childNodeId=string}  true  "子节点ID"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/remove-from-parallel [post]
+// func (h *WorkflowHandler) RemoveNodeFromParallel(c *gin.Context) {
+// 	var req struct {
+// 		ChildNodeID string `json:"childNodeId" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	childNodeID, err := strconv.ParseUint(req.ChildNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("子节点ID格式无效", map[string]any{
+// 			"provided_id": req.ChildNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.RemoveNodeFromParallel(ctx, childNodeID)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("从并行执行中移除节点失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点已从并行执行中移除",
+// 	})
+// }
+
+// // GetParallelChildren 获取并行节点的所有子节点
+// // @Summary      获取并行节点的子节点
+// // @Description  获取并行执行节点的所有子节点列表
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        parallelNodeId  query     string  true  "并行节点ID"
+// // @Success      200  {object}  object{success=bool,data=[]models.WorkflowNodeResponse}
+// // @Failure      400  {object}  object{success=bool,message=string}
+// // @Failure      500  {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/parallel-children [get]
+// func (h *WorkflowHandler) GetParallelChildren(c *gin.Context) {
+// 	parallelNodeIDStr := c.Query("parallelNodeId")
+
+// 	if parallelNodeIDStr == "" {
+// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID不能为空", nil))
+// 		return
+// 	}
+
+// 	parallelNodeID, err := strconv.ParseUint(parallelNodeIDStr, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("并行节点ID格式无效", map[string]any{
+// 			"provided_id": parallelNodeIDStr,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	children, err := funcs.WorkflowFuncs{}.GetParallelChildren(ctx, parallelNodeID)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("获取并行子节点失败", err.Error()))
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"data":    children,
+// 		"count":   len(children),
+// 	})
+// }
+
+// // DisconnectNodes 断开节点连接
+// // @Summary      断开节点连接
+// // @Description  断开工作流节点的连接（删除边）
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{fromNodeId=string}  true  "源节点ID"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      404   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/disconnect [post]
+// func (h *WorkflowHandler) DisconnectNodes(c *gin.Context) {
+// 	var req struct {
+// 		FromNodeID string `json:"fromNodeId" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
+// 			"provided_id": req.FromNodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.DisconnectNodes(ctx, fromNodeID)
+// 	if err != nil {
+// 		if err.Error() == "source node not found" {
+// 			middleware.ThrowError(c, middleware.NotFoundError("源节点未找到", map[string]any{
+// 				"fromNodeId": fromNodeID,
+// 			}))
+// 		} else {
+// 			middleware.ThrowError(c, middleware.DatabaseError("断开节点连接失败", err.Error()))
+// 		}
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点连接已断开",
+// 	})
+// }
+
+// // UpdateNodePosition 更新节点位置
+// // @Summary      更新节点位置
+// // @Description  更新工作流节点在画布上的位置
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{nodeId=string,x=number,y=number}  true  "节点位置信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      404   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/position [put]
+// func (h *WorkflowHandler) UpdateNodePosition(c *gin.Context) {
+// 	var req struct {
+// 		NodeID string  `json:"nodeId" binding:"required"`
+// 		X      float64 `json:"x" binding:"required"`
+// 		Y      float64 `json:"y" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	nodeID, err := strconv.ParseUint(req.NodeID, 10, 64)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", map[string]any{
+// 			"provided_id": req.NodeID,
+// 		}))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err = funcs.WorkflowFuncs{}.UpdateNodePosition(ctx, nodeID, req.X, req.Y)
+// 	if err != nil {
+// 		if err.Error() == "node not found" {
+// 			middleware.ThrowError(c, middleware.NotFoundError("节点未找到", map[string]any{
+// 				"nodeId": nodeID,
+// 			}))
+// 		} else {
+// 			middleware.ThrowError(c, middleware.DatabaseError("更新节点位置失败", err.Error()))
+// 		}
+// 		return
+// 	}
+
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点位置更新成功",
+// 	})
+// }
+
+// // BatchUpdateNodePositions 批量更新节点位置
+// // @Summary      批量更新节点位置
+// // @Description  批量更新多个工作流节点在画布上的位置
+// // @Tags         workflow-graph
+// // @Accept       json
+// // @Produce      json
+// // @Param        body  body      object{positions=object}  true  "节点位置信息"
+// // @Success      200   {object}  object{success=bool,message=string}
+// // @Failure      400   {object}  object{success=bool,message=string}
+// // @Failure      500   {object}  object{success=bool,message=string}
+// // @Router       /workflow/graph/positions [put]
+// func (h *WorkflowHandler) BatchUpdateNodePositions(c *gin.Context) {
+// 	var req struct {
+// 		Positions map[string]map[string]float64 `json:"positions" binding:"required"`
+// 	}
+
+// 	if err := c.ShouldBindJSON(&req); err != nil {
+// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// 		return
+// 	}
+
+// 	ctx := middleware.GetRequestContext(c)
+// 	err := funcs.WorkflowFuncs{}.BatchUpdateNodePositions(ctx, req.Positions)
+// 	if err != nil {
+// 		middleware.ThrowError(c, middleware.DatabaseError("批量更新节点位置失败", err.Error()))
 // 		return
 // 	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"data":    connections,
-// 	})
-// }
+// 	c.JSON(http.StatusOK, gin.H{
+// 		"success": true,
+// 		"message": "节点位置批量更新成功",
+// 	})
+// }
+
+// // ============ WorkflowEdge Handlers ============
+
+// GetAllWorkflowEdges 获取所有工作流边
+// @Summary      获取所有工作流边
+// @Description  按创建时间升序获取工作流边列表，最多返回1000条；truncated=true 表示还有更多的边，请使用 /workflow/edges/search 分页搜索
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowEdgeResponse,count=int,truncated=bool}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/edges [get]
+func (h *WorkflowHandler) GetAllWorkflowEdges(c *gin.Context) {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edges, truncated, err := funcs.WorkflowFuncs{}.GetAllWorkflowEdges(ctx, scope)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   true,
+		"data":      edges,
+		"count":     len(edges),
+		"truncated": truncated,
+	})
+}
+
+// GetWorkflowEdge 获取单个工作流边
+// @Summary      获取工作流边详情
+// @Description  根据ID获取工作流边详细信息
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "边ID"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowEdgeResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/{id} [get]
+func (h *WorkflowHandler) GetWorkflowEdge(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessRead, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edge, err := funcs.WorkflowFuncs{}.GetWorkflowEdgeByID(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow edge not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    edge,
+	})
+}
+
+// GetWorkflowEdgesByApplicationID 根据应用ID获取所有边
+// @Summary      根据应用ID获取边列表
+// @Description  获取指定工作流应用的所有边；提供任一分页或排序参数时改为分页返回，响应中附带 pagination
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        applicationId  query     string  true   "应用ID"
+// @Param        page           query     int     false  "页码"
+// @Param        pageSize       query     int     false  "每页数量"
+// @Param        order          query     string  false  "排序方式"  Enums(asc, desc)
+// @Param        orderBy        query     string  false  "排序字段"  Enums(createTime, updateTime, type)
+// @Success      200            {object}  object{success=bool,data=[]models.WorkflowEdgeResponse,pagination=models.Pagination}
+// @Failure      400            {object}  object{success=bool,message=string}
+// @Failure      500            {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/by-application [get]
+func (h *WorkflowHandler) GetWorkflowEdgesByApplicationID(c *gin.Context) {
+	applicationIDStr := c.Query("applicationId")
+	if applicationIDStr == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+		return
+	}
+
+	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	// 带分页参数时按分页返回，否则保持返回全部边
+	if hasPaginationQuery(c) {
+		h.SearchWorkflowEdges(c)
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edges, err := funcs.WorkflowFuncs{}.GetWorkflowEdgesByApplicationID(ctx, applicationID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    edges,
+	})
+}
+
+// SearchWorkflowEdges 分页搜索工作流边
+// @Summary      分页搜索工作流边
+// @Description  按应用、端点、类型、分支名称和创建时间范围分页搜索工作流边，默认按创建时间升序
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        page           query     int     false  "页码"      default(1)
+// @Param        pageSize       query     int     false  "每页数量"  default(10)
+// @Param        order          query     string  false  "排序方式"  default(asc)
+// @Param        orderBy        query     string  false  "排序字段"  default(createTime)
+// @Param        applicationId  query     string  false  "应用ID"
+// @Param        sourceNodeId   query     string  false  "源节点ID"
+// @Param        targetNodeId   query     string  false  "目标节点ID"
+// @Param        type           query     string  false  "边类型"
+// @Param        branchName     query     string  false  "分支名称"
+// @Param        beginTime      query     string  false  "创建时间下限（RFC3339）"
+// @Param        endTime        query     string  false  "创建时间上限（RFC3339）"
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowEdgeResponse,pagination=models.Pagination}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/search [get]
+func (h *WorkflowHandler) SearchWorkflowEdges(c *gin.Context) {
+	var req models.PageWorkflowEdgeRequest
+
+	// 设置默认值
+	req.Page = 1
+	req.PageSize = 10
+	req.Order = "asc"
+	req.OrderBy = "createTime"
+
+	// 绑定查询参数
+	if err := c.ShouldBindQuery(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("查询参数格式错误", err.Error()))
+		return
+	}
+
+	h.pageWorkflowEdges(c, &req)
+}
+
+// GetWorkflowEdgesWithPagination 分页获取工作流边列表
+// @Summary      分页获取工作流边列表
+// @Description  只按分页参数获取工作流边列表，默认按创建时间升序；按条件过滤请使用 /workflow/edges/search
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        page      query     int     false  "页码"      default(1)
+// @Param        pageSize  query     int     false  "每页数量"  default(10)
+// @Param        order     query     string  false  "排序方式"  default(asc)
+// @Param        orderBy   query     string  false  "排序字段"  default(createTime)
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowEdgeResponse,pagination=models.Pagination}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/page [get]
+func (h *WorkflowHandler) GetWorkflowEdgesWithPagination(c *gin.Context) {
+	var req models.PageWorkflowEdgeRequest
+
+	// 设置默认值
+	req.Page = 1
+	req.PageSize = 10
+	req.Order = "asc"
+	req.OrderBy = "createTime"
+
+	// 只绑定分页参数，忽略过滤条件
+	if err := c.ShouldBindQuery(&req.PaginationRequest); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("查询参数格式错误", err.Error()))
+		return
+	}
+
+	h.pageWorkflowEdges(c, &req)
+}
+
+// pageWorkflowEdges 在当前用户可访问的范围内分页查询工作流边并返回
+func (h *WorkflowHandler) pageWorkflowEdges(c *gin.Context, req *models.PageWorkflowEdgeRequest) {
+	scope, ok := resolveWorkflowAccessScope(c)
+	if !ok {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.SearchWorkflowEdges(ctx, scope, req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid ") {
+			middleware.ThrowError(c, middleware.BadRequestError("搜索条件无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       result.Data,
+		"pagination": result.Pagination,
+	})
+}
+
+// CreateWorkflowEdge 创建工作流边
+// @Summary      创建工作流边
+// @Description  创建新的工作流边
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        edge  body      models.CreateWorkflowEdgeRequest  true  "工作流边信息"
+// @Success      201   {object}  object{success=bool,data=models.WorkflowEdgeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/edges [post]
+func (h *WorkflowHandler) CreateWorkflowEdge(c *gin.Context) {
+	var req models.CreateWorkflowEdgeRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessEdit, req.ApplicationID) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edge, err := funcs.WorkflowFuncs{}.CreateWorkflowEdge(ctx, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid edge") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流边参数无效", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("创建工作流边失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    edge,
+		"message": "工作流边创建成功",
+	})
+}
+
+// UpdateWorkflowEdge 更新工作流边
+// @Summary      更新工作流边
+// @Description  更新工作流边信息
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        id    path      string                            true  "边ID"
+// @Param        edge  body      models.UpdateWorkflowEdgeRequest  true  "工作流边信息"
+// @Success      200   {object}  object{success=bool,data=models.WorkflowEdgeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/{id} [put]
+func (h *WorkflowHandler) UpdateWorkflowEdge(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		return
+	}
+
+	var req models.UpdateWorkflowEdgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edge, err := funcs.WorkflowFuncs{}.UpdateWorkflowEdge(ctx, id, &req)
+	if err != nil {
+		if err.Error() == "workflow edge not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid edge request") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流边参数无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("更新工作流边失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    edge,
+		"message": "工作流边更新成功",
+	})
+}
+
+// ReconnectWorkflowEdge 移动工作流边端点
+// @Summary      移动工作流边端点
+// @Description  将边的源端和/或目标端移动到同一应用的其他节点，保留边ID、样式和数据；移动后的连接按创建边的规则重新校验
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                               true  "边ID"
+// @Param        request  body      models.ReconnectWorkflowEdgeRequest  true  "新的端点"
+// @Success      200      {object}  object{success=bool,data=models.WorkflowEdgeResponse,message=string}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/{id}/reconnect [patch]
+func (h *WorkflowHandler) ReconnectWorkflowEdge(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		return
+	}
+
+	var req models.ReconnectWorkflowEdgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	sourceNodeID, ok := parseOptionalNodeID(req.SourceNodeID)
+	if !ok {
+		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", nil))
+		return
+	}
+	targetNodeID, ok := parseOptionalNodeID(req.TargetNodeID)
+	if !ok {
+		middleware.ThrowError(c, middleware.BadRequestError("目标节点ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	edge, err := funcs.WorkflowFuncs{}.ReconnectEdge(middleware.GetRequestContext(c), id, sourceNodeID, targetNodeID)
+	if err != nil {
+		if err.Error() == "workflow edge not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+			return
+		}
+		if strings.Contains(err.Error(), "invalid edge") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流边连接无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("移动工作流边失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    edge,
+		"message": "工作流边移动成功",
+	})
+}
+
+// parseOptionalNodeID 解析可选的节点ID，未提供时返回 nil
+func parseOptionalNodeID(value *string) (*uint64, bool) {
+	if value == nil {
+		return nil, true
+	}
+	id, err := strconv.ParseUint(*value, 10, 64)
+	if err != nil || id == 0 {
+		return nil, false
+	}
+	return &id, true
+}
+
+// DeleteWorkflowEdge 删除工作流边
+// @Summary      删除工作流边
+// @Description  删除指定的工作流边
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "边ID"
+// @Success      200  {object}  object{success=bool,message=string}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflowEdge(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	err = funcs.WorkflowFuncs{}.DeleteWorkflowEdge(ctx, id)
+	if err != nil {
+		if err.Error() == "workflow edge not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("删除工作流边失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "工作流边删除成功",
+	})
+}
+
+// BatchCreateWorkflowEdges 批量创建工作流边
+// @Summary      批量创建工作流边
+// @Description  批量创建多个工作流边
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BatchCreateWorkflowEdgesRequest  true  "边列表"
+// @Success      201   {object}  object{success=bool,data=[]models.WorkflowEdgeResponse}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/batch-create [post]
+func (h *WorkflowHandler) BatchCreateWorkflowEdges(c *gin.Context) {
+	var req models.BatchCreateWorkflowEdgesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	applicationIDs := make([]string, 0, len(req.Edges))
+	for _, edge := range req.Edges {
+		applicationIDs = append(applicationIDs, edge.ApplicationID)
+	}
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessEdit, applicationIDs...) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	edges, err := funcs.WorkflowFuncs{}.BatchCreateWorkflowEdges(ctx, &req)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid edge") {
+			middleware.ThrowError(c, middleware.BadRequestError("工作流边参数无效", err.Error()))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("批量创建工作流边失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"success": true,
+		"data":    edges,
+		"message": "工作流边批量创建成功",
+	})
+}
+
+// BatchDeleteWorkflowEdges 批量删除工作流边
+// @Summary      批量删除工作流边
+// @Description  批量删除多个工作流边。默认在事务中执行，任意一项失败则全部回滚；continueOnError 为 true 时逐项删除并返回每一项的结果
+// @Tags         workflow-edges
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BatchDeleteWorkflowEdgesRequest  true  "边ID列表"
+// @Success      200   {object}  object{success=bool,data=models.BatchOperationResult,message=string}
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/edges/batch-delete [post]
+func (h *WorkflowHandler) BatchDeleteWorkflowEdges(c *gin.Context) {
+	var req models.BatchDeleteWorkflowEdgesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
 
-// // DisconnectNodes 断开节点连接
-// // @Summary      断开节点连接
-// // @Description  断开工作流节点的连接（删除边）
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{fromNodeId=string}  true  "源节点ID"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      404   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/disconnect [post]
-// func (h *WorkflowHandler) DisconnectNodes(c *gin.Context) {
-// 	var req struct {
-// 		FromNodeID string `json:"fromNodeId" binding:"required"`
-// 	}
+	if !checkWorkflowResourceIDsAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessEdit, req.EdgeIDs) {
+		return
+	}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.BatchDeleteWorkflowEdges(ctx, &req)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "invalid edge id") {
+			middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("批量删除工作流边失败", err.Error()))
+		return
+	}
 
-// 	fromNodeID, err := strconv.ParseUint(req.FromNodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("源节点ID格式无效", map[string]any{
-// 			"provided_id": req.FromNodeID,
-// 		}))
-// 		return
-// 	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    result,
+		"message": "工作流边批量删除完成",
+	})
+}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.DisconnectNodes(ctx, fromNodeID)
-// 	if err != nil {
-// 		if err.Error() == "source node not found" {
-// 			middleware.ThrowError(c, middleware.NotFoundError("源节点未找到", map[string]any{
-// 				"fromNodeId": fromNodeID,
-// 			}))
-// 		} else {
-// 			middleware.ThrowError(c, middleware.DatabaseError("断开节点连接失败", err.Error()))
-// 		}
-// 		return
-// 	}
+// GetExecutionQueueStats 获取执行队列状态
+// @Summary      获取执行队列状态
+// @Description  获取执行队列的排队深度、工作协程数和正在运行的执行数，未启用队列时 enabled 为 false
+// @Tags         workflow-executions
+// @Accept       json
+// @Produce      json
+// @Success      200  {object}  object{success=bool,data=models.WorkflowExecutionQueueStats}
+// @Router       /workflow/executions/queue [get]
+func (h *WorkflowHandler) GetExecutionQueueStats(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    funcs.WorkflowFuncs{}.GetExecutionQueueStats(middleware.GetRequestContext(c)),
+	})
+}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点连接已断开",
-// 	})
-// }
+// GetExecutionPath 获取执行路径
+// @Summary      获取执行路径
+// @Description  根据节点执行记录重建单次执行经过的节点顺序、各条件节点选择的分支以及未执行到的节点
+// @Tags         workflow-executions
+// @Accept       json
+// @Produce      json
+// @Param        executionId  path      string  true  "执行ID"
+// @Success      200          {object}  object{success=bool,data=models.ExecutionPath}
+// @Failure      404          {object}  object{success=bool,message=string}
+// @Failure      500          {object}  object{success=bool,message=string}
+// @Router       /workflow/executions/{executionId}/path [get]
+func (h *WorkflowHandler) GetExecutionPath(c *gin.Context) {
+	executionID := c.Param("executionId")
+
+	if !checkWorkflowExecutionAccess(c, executionID, funcs.WorkflowAccessRead) {
+		return
+	}
 
-// // UpdateNodePosition 更新节点位置
-// // @Summary      更新节点位置
-// // @Description  更新工作流节点在画布上的位置
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{nodeId=string,x=number,y=number}  true  "节点位置信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      404   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/position [put]
-// func (h *WorkflowHandler) UpdateNodePosition(c *gin.Context) {
-// 	var req struct {
-// 		NodeID string  `json:"nodeId" binding:"required"`
-// 		X      float64 `json:"x" binding:"required"`
-// 		Y      float64 `json:"y" binding:"required"`
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	path, err := funcs.WorkflowFuncs{}.GetExecutionPath(ctx, executionID)
+	if err != nil {
+		switch err.Error() {
+		case "workflow execution not found":
+			middleware.ThrowError(c, middleware.NotFoundError("执行记录未找到", map[string]any{
+				"executionId": executionID,
+			}))
+		case "workflow application not found":
+			middleware.ThrowError(c, middleware.NotFoundError("执行所属的工作流应用不存在", map[string]any{
+				"executionId": executionID,
+			}))
+		default:
+			middleware.ThrowError(c, middleware.DatabaseError("获取执行路径失败", err.Error()))
+		}
+		return
+	}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    path,
+	})
+}
+
+// GetExecutionInput 获取执行输入
+// @Summary      获取执行输入
+// @Description  获取单次执行的输入，用于预填新的执行；敏感字段和应用密钥的值已脱敏。执行时可通过 fromExecutionId 直接复用原始输入
+// @Tags         workflow-executions
+// @Accept       json
+// @Produce      json
+// @Param        executionId  path      string  true  "执行ID"
+// @Success      200          {object}  object{success=bool,data=map[string]interface{}}
+// @Failure      404          {object}  object{success=bool,message=string}
+// @Failure      500          {object}  object{success=bool,message=string}
+// @Router       /workflow/executions/{executionId}/input [get]
+func (h *WorkflowHandler) GetExecutionInput(c *gin.Context) {
+	executionID := c.Param("executionId")
+
+	if !checkWorkflowExecutionAccess(c, executionID, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	input, err := funcs.WorkflowFuncs{}.GetExecutionInput(ctx, executionID)
+	if err != nil {
+		if err.Error() == "workflow execution not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("执行记录未找到", map[string]any{
+				"executionId": executionID,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("获取执行输入失败", err.Error()))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    input,
+	})
+}
+
+// GetExecutionReport 获取执行报告
+// @Summary      获取执行报告
+// @Description  获取单次执行的完整报告（执行记录、节点执行、日志、相关节点配置），敏感字段已脱敏。download=true 时以附件形式下载
+// @Tags         workflow-executions
+// @Accept       json
+// @Produce      json
+// @Param        executionId  path      string  true   "执行ID"
+// @Param        download     query     bool    false  "是否以附件形式下载"
+// @Success      200          {object}  object{success=bool,data=models.ExecutionReport}
+// @Failure      404          {object}  object{success=bool,message=string}
+// @Failure      500          {object}  object{success=bool,message=string}
+// @Router       /workflow/executions/{executionId}/report [get]
+func (h *WorkflowHandler) GetExecutionReport(c *gin.Context) {
+	executionID := c.Param("executionId")
+
+	if !checkWorkflowExecutionAccess(c, executionID, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	report, err := funcs.WorkflowFuncs{}.GetExecutionReport(ctx, executionID)
+	if err != nil {
+		if err.Error() == "workflow execution not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("执行记录未找到", map[string]any{
+				"executionId": executionID,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("获取执行报告失败", err.Error()))
+		}
+		return
+	}
+
+	if c.Query("download") == "true" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			middleware.ThrowError(c, middleware.InternalServerError("生成执行报告失败", err.Error()))
+			return
+		}
+
+		filename := fmt.Sprintf("execution-report-%s.json", executionID)
+		c.Header("Content-Disposition", "attachment; filename="+filename)
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    report,
+	})
+}
+
+// ============ WorkflowVersion Handlers ============
+
+// CreateWorkflowVersion 创建工作流版本快照
+// @Summary      创建工作流版本快照
+// @Description  为指定应用创建版本快照，自动递增版本号
+// @Tags         workflow-versions
+// @Accept       json
+// @Produce      json
+// @Param        request  body      models.CreateWorkflowVersionRequest  true  "创建版本请求"
+// @Success      200      {object}  object{success=bool,data=models.WorkflowVersionResponse}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /workflow/versions [post]
+func (h *WorkflowHandler) CreateWorkflowVersion(c *gin.Context) {
+	var req models.CreateWorkflowVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("请求参数错误", map[string]any{
+			"error": err.Error(),
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessEdit, req.ApplicationID) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	version, err := funcs.WorkflowFuncs{}.CreateWorkflowVersion(ctx, &req)
+	if err != nil {
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("创建工作流版本失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    version,
+		"message": "工作流版本创建成功",
+	})
+}
+
+// GetWorkflowVersionsByApplicationID 根据应用ID获取版本列表
+// @Summary      根据应用ID获取版本列表
+// @Description  获取指定应用的所有版本快照，按版本号降序排列
+// @Tags         workflow-versions
+// @Accept       json
+// @Produce      json
+// @Param        applicationId  query     string  true  "应用ID"
+// @Success      200            {object}  object{success=bool,data=[]models.WorkflowVersionResponse}
+// @Failure      400            {object}  object{success=bool,message=string}
+// @Failure      500            {object}  object{success=bool,message=string}
+// @Router       /workflow/versions/by-application [get]
+func (h *WorkflowHandler) GetWorkflowVersionsByApplicationID(c *gin.Context) {
+	applicationIDStr := c.Query("applicationId")
+	if applicationIDStr == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+		return
+	}
+
+	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
+			"provided_id": applicationIDStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessRead) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	versions, err := funcs.WorkflowFuncs{}.GetWorkflowVersionsByApplicationID(ctx, applicationID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("获取版本列表失败", err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    versions,
+		"count":   len(versions),
+	})
+}
+
+// PruneWorkflowVersions 清理工作流历史版本
+// @Summary      清理工作流历史版本
+// @Description  按配置的版本保留数清理应用的历史版本，置顶版本不会被清理
+// @Tags         workflow-versions
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=models.PruneWorkflowVersionsResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/versions/prune [post]
+func (h *WorkflowHandler) PruneWorkflowVersions(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
+		return
+	}
+
+	ctx := middleware.GetRequestContext(c)
+	pruned, err := funcs.WorkflowFuncs{}.PruneWorkflowVersions(ctx, applicationID)
+	if err != nil {
+		middleware.ThrowError(c, middleware.DatabaseError("清理工作流版本失败", err.Error()))
+		return
+	}
 
-// 	nodeID, err := strconv.ParseUint(req.NodeID, 10, 64)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", map[string]any{
-// 			"provided_id": req.NodeID,
-// 		}))
-// 		return
-// 	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    models.PruneWorkflowVersionsResponse{Pruned: pruned},
+		"message": "工作流版本清理完成",
+	})
+}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err = funcs.WorkflowFuncs{}.UpdateNodePosition(ctx, nodeID, req.X, req.Y)
-// 	if err != nil {
-// 		if err.Error() == "node not found" {
-// 			middleware.ThrowError(c, middleware.NotFoundError("节点未找到", map[string]any{
-// 				"nodeId": nodeID,
-// 			}))
-// 		} else {
-// 			middleware.ThrowError(c, middleware.DatabaseError("更新节点位置失败", err.Error()))
-// 		}
-// 		return
-// 	}
+// SetWorkflowVersionPinned 设置版本置顶状态
+// @Summary      设置版本置顶状态
+// @Description  置顶或取消置顶工作流版本，置顶的版本不会被自动清理
+// @Tags         workflow-versions
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                                  true  "版本ID"
+// @Param        request  body      models.SetWorkflowVersionPinnedRequest  true  "置顶状态"
+// @Success      200      {object}  object{success=bool,data=models.WorkflowVersionResponse}
+// @Failure      400      {object}  object{success=bool,message=string}
+// @Failure      404      {object}  object{success=bool,message=string}
+// @Failure      500      {object}  object{success=bool,message=string}
+// @Router       /workflow/versions/{id}/pinned [put]
+func (h *WorkflowHandler) SetWorkflowVersionPinned(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("版本ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点位置更新成功",
-// 	})
-// }
+	var req models.SetWorkflowVersionPinnedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
 
-// // BatchUpdateNodePositions 批量更新节点位置
-// // @Summary      批量更新节点位置
-// // @Description  批量更新多个工作流节点在画布上的位置
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{positions=object}  true  "节点位置信息"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/positions [put]
-// func (h *WorkflowHandler) BatchUpdateNodePositions(c *gin.Context) {
-// 	var req struct {
-// 		Positions map[string]map[string]float64 `json:"positions" binding:"required"`
-// 	}
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceVersion, funcs.WorkflowAccessEdit, id) {
+		return
+	}
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	ctx := middleware.GetRequestContext(c)
+	version, err := funcs.WorkflowFuncs{}.SetWorkflowVersionPinned(ctx, id, req.Pinned)
+	if err != nil {
+		if err.Error() == "workflow version not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流版本不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("设置版本置顶状态失败", err.Error()))
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err := funcs.WorkflowFuncs{}.BatchUpdateNodePositions(ctx, req.Positions)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("批量更新节点位置失败", err.Error()))
-// 		return
-// 	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    version,
+	})
+}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点位置批量更新成功",
-// 	})
-// }
+// ============ Batch Save Handler ============
 
-// // BatchDeleteNodes 批量删除节点
-// // @Summary      批量删除节点
-// // @Description  批量删除多个工作流节点
-// // @Tags         workflow-graph
-// // @Accept       json
-// // @Produce      json
-// // @Param        body  body      object{nodeIds=[]string}  true  "节点ID列表"
-// // @Success      200   {object}  object{success=bool,message=string}
-// // @Failure      400   {object}  object{success=bool,message=string}
-// // @Failure      500   {object}  object{success=bool,message=string}
-// // @Router       /workflow/graph/batch-delete [post]
-// func (h *WorkflowHandler) BatchDeleteNodes(c *gin.Context) {
-// 	var req struct {
-// 		NodeIDs []string `json:"nodeIds" binding:"required"`
-// 	}
+// BatchSaveWorkflow 批量保存工作流
+// @Summary      批量保存工作流
+// @Description  批量保存工作流的节点和边（增删改），节点/边操作数超过配置上限时返回400；largeBatch=true 时按块分多个事务提交；新增和修改的节点配置按节点类型的 Schema 校验，不符合时返回字段级错误（字段路径以节点临时ID或ID开头），skipConfigValidation=true 跳过校验
+// @Tags         workflow-batch
+// @Accept       json
+// @Produce      json
+// @Param        body  body      models.BatchSaveWorkflowRequest  true  "批量保存请求"
+// @Success      200   {object}  models.BatchSaveWorkflowResponse
+// @Failure      400   {object}  object{success=bool,message=string}
+// @Failure      404   {object}  object{success=bool,message=string}
+// @Failure      409   {object}  object{success=bool,message=string}
+// @Failure      500   {object}  object{success=bool,message=string}
+// @Router       /workflow/batch-save [post]
+func (h *WorkflowHandler) BatchSaveWorkflow(c *gin.Context) {
+	var req models.BatchSaveWorkflowRequest
 
-// 	if err := c.ShouldBindJSON(&req); err != nil {
-// 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
-// 		return
-// 	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
 
-// 	nodeIDs := make([]uint64, 0, len(req.NodeIDs))
-// 	for _, idStr := range req.NodeIDs {
-// 		id, err := strconv.ParseUint(idStr, 10, 64)
-// 		if err != nil {
-// 			middleware.ThrowError(c, middleware.BadRequestError("节点ID格式无效", map[string]any{
-// 				"provided_id": idStr,
-// 			}))
-// 			return
-// 		}
-// 		nodeIDs = append(nodeIDs, id)
-// 	}
+	if req.ApplicationID == "" {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+		return
+	}
 
-// 	ctx := middleware.GetRequestContext(c)
-// 	err := funcs.WorkflowFuncs{}.BatchDeleteNodes(ctx, nodeIDs)
-// 	if err != nil {
-// 		middleware.ThrowError(c, middleware.DatabaseError("批量删除节点失败", err.Error()))
-// 		return
-// 	}
+	// 修改和删除的节点、边也必须属于有编辑权限的应用
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessEdit, req.ApplicationID) {
+		return
+	}
+	nodeIDs := append([]string{}, req.NodeIDsToDelete...)
+	for _, node := range req.NodesToUpdate {
+		nodeIDs = append(nodeIDs, node.ID)
+	}
+	if !checkWorkflowResourceIDsAccess(c, funcs.WorkflowResourceNode, funcs.WorkflowAccessEdit, nodeIDs) {
+		return
+	}
+	edgeIDs := append([]string{}, req.EdgeIDsToDelete...)
+	for _, edge := range req.EdgesToUpdate {
+		edgeIDs = append(edgeIDs, edge.ID)
+	}
+	if !checkWorkflowResourceIDsAccess(c, funcs.WorkflowResourceEdge, funcs.WorkflowAccessEdit, edgeIDs) {
+		return
+	}
+	// 子工作流节点引用的应用需要能读取
+	var subWorkflowAppIDs []string
+	for _, node := range req.NodesToCreate {
+		if node.WorkflowApplicationID != "" {
+			subWorkflowAppIDs = append(subWorkflowAppIDs, node.WorkflowApplicationID)
+		}
+	}
+	for _, node := range req.NodesToUpdate {
+		if node.Data.WorkflowApplicationID != "" {
+			subWorkflowAppIDs = append(subWorkflowAppIDs, node.Data.WorkflowApplicationID)
+		}
+	}
+	if !checkWorkflowApplicationIDsAccess(c, funcs.WorkflowAccessRead, subWorkflowAppIDs...) {
+		return
+	}
 
-// 	c.JSON(http.StatusOK, gin.H{
-// 		"success": true,
-// 		"message": "节点批量删除成功",
-// 	})
-// }
+	ctx := middleware.GetRequestContext(c)
+	result, err := funcs.WorkflowFuncs{}.BatchSaveWorkflow(ctx, &req)
+	if err != nil {
+		var configErr *funcs.NodeConfigValidationError
+		if errors.As(err, &configErr) {
+			middleware.ThrowError(c, middleware.ValidationError("节点配置校验失败", configErr.Errors))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid batch save request") {
+			middleware.ThrowError(c, middleware.BadRequestError("批量保存请求不合法", err.Error()))
+			return
+		}
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		if funcs.IsWorkflowEditConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("工作流正在编辑或执行中，请稍后重试", err.Error()))
+			return
+		}
+		if funcs.IsWorkflowNameConflict(err) {
+			middleware.ThrowError(c, middleware.ConflictError("节点名称在应用内已存在", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("批量保存工作流失败", err.Error()))
+		return
+	}
 
-// // ============ WorkflowEdge Handlers ============
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "批量保存成功",
+		"data":    result,
+	})
+}
 
-// GetAllWorkflowEdges 获取所有工作流边
-// @Summary      获取所有工作流边
-// @Description  获取所有工作流边列表
+// DedupeWorkflowEdges 清理应用中的重复边
+// @Summary      清理重复边
+// @Description  删除应用中源节点、目标节点、连接点和分支均相同的重复边，每组保留最早创建的一条
 // @Tags         workflow-edges
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  object{success=bool,data=[]models.WorkflowEdgeResponse}
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=models.DedupeWorkflowEdgesResponse,message=string}
+// @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/edges [get]
-func (h *WorkflowHandler) GetAllWorkflowEdges(c *gin.Context) {
+// @Router       /workflow/applications/{id}/edges/dedupe [post]
+func (h *WorkflowHandler) DedupeWorkflowEdges(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	edges, err := funcs.WorkflowFuncs{}.GetAllWorkflowEdges(ctx)
+	removed, err := funcs.WorkflowFuncs{}.DedupeApplicationEdges(ctx, applicationID)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边列表失败", err.Error()))
+		middleware.ThrowError(c, middleware.DatabaseError("清理重复边失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    edges,
+		"data": models.DedupeWorkflowEdgesResponse{
+			ApplicationID: idStr,
+			Removed:       removed,
+		},
+		"message": fmt.Sprintf("已清理 %d 条重复边", removed),
 	})
 }
 
-// GetWorkflowEdge 获取单个工作流边
-// @Summary      获取工作流边详情
-// @Description  根据ID获取工作流边详细信息
-// @Tags         workflow-edges
+// GetWorkflowVersion 获取单个版本
+// @Summary      获取单个版本
+// @Description  根据版本ID获取版本详情
+// @Tags         workflow-versions
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "边ID"
-// @Success      200  {object}  object{success=bool,data=models.WorkflowEdgeResponse}
+// @Param        id   path      string  true  "版本ID"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowVersionResponse}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
 // @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/{id} [get]
-func (h *WorkflowHandler) GetWorkflowEdge(c *gin.Context) {
+// @Router       /workflow/versions/{id} [get]
+func (h *WorkflowHandler) GetWorkflowVersion(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		middleware.ThrowError(c, middleware.BadRequestError("版本ID格式无效", map[string]any{
+			"provided_id": idStr,
+		}))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceVersion, funcs.WorkflowAccessRead, id) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	edge, err := funcs.WorkflowFuncs{}.GetWorkflowEdgeByID(ctx, id)
+	version, err := funcs.WorkflowFuncs{}.GetWorkflowVersionByID(ctx, id)
 	if err != nil {
-		if err.Error() == "workflow edge not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+		if err.Error() == "workflow version not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流版本不存在", nil))
 			return
 		}
-		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边失败", err.Error()))
+		middleware.ThrowError(c, middleware.DatabaseError("获取版本失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    edge,
+		"data":    version,
 	})
 }
 
-// GetWorkflowEdgesByApplicationID 根据应用ID获取所有边
-// @Summary      根据应用ID获取边列表
-// @Description  获取指定工作流应用的所有边
-// @Tags         workflow-edges
+// ============ WorkflowSchedule Handlers ============
+
+// CreateWorkflowSchedule 创建定时调度
+// @Summary      创建定时调度
+// @Description  为工作流应用创建Cron定时调度
+// @Tags         workflow-schedules
 // @Accept       json
 // @Produce      json
-// @Param        applicationId  query     string  true  "应用ID"
-// @Success      200            {object}  object{success=bool,data=[]models.WorkflowEdgeResponse}
-// @Failure      400            {object}  object{success=bool,message=string}
-// @Failure      500            {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/by-application [get]
-func (h *WorkflowHandler) GetWorkflowEdgesByApplicationID(c *gin.Context) {
-	applicationIDStr := c.Query("applicationId")
-	if applicationIDStr == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+// @Param        id        path      string                               true  "应用ID"
+// @Param        schedule  body      models.CreateWorkflowScheduleRequest  true  "调度信息"
+// @Success      201       {object}  object{success=bool,data=models.WorkflowScheduleResponse}
+// @Failure      400       {object}  object{success=bool,message=string}
+// @Failure      404       {object}  object{success=bool,message=string}
+// @Failure      500       {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/schedules [post]
+func (h *WorkflowHandler) CreateWorkflowSchedule(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
 		return
 	}
 
-	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
-	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
+	var req models.CreateWorkflowScheduleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+		return
+	}
+
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	edges, err := funcs.WorkflowFuncs{}.GetWorkflowEdgesByApplicationID(ctx, applicationID)
+	schedule, err := funcs.WorkflowFuncs{}.CreateWorkflowSchedule(ctx, applicationID, &req)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("获取工作流边列表失败", err.Error()))
+		if err.Error() == "workflow application not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+			return
+		}
+		if strings.HasPrefix(err.Error(), "invalid cron expression") {
+			middleware.ThrowError(c, middleware.BadRequestError("Cron表达式无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("创建定时调度失败", err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	c.JSON(http.StatusCreated, gin.H{
 		"success": true,
-		"data":    edges,
+		"data":    schedule,
+		"message": "定时调度创建成功",
 	})
 }
 
-// CreateWorkflowEdge 创建工作流边
-// @Summary      创建工作流边
-// @Description  创建新的工作流边
-// @Tags         workflow-edges
+// GetWorkflowSchedules 获取应用的定时调度列表
+// @Summary      获取应用的定时调度列表
+// @Description  获取指定工作流应用的所有定时调度
+// @Tags         workflow-schedules
 // @Accept       json
 // @Produce      json
-// @Param        edge  body      models.CreateWorkflowEdgeRequest  true  "工作流边信息"
-// @Success      201   {object}  object{success=bool,data=models.WorkflowEdgeResponse}
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/edges [post]
-func (h *WorkflowHandler) CreateWorkflowEdge(c *gin.Context) {
-	var req models.CreateWorkflowEdgeRequest
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowScheduleResponse,count=int}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/schedules [get]
+func (h *WorkflowHandler) GetWorkflowSchedules(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
+		return
+	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessRead) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	edge, err := funcs.WorkflowFuncs{}.CreateWorkflowEdge(ctx, &req)
+	schedules, err := funcs.WorkflowFuncs{}.GetWorkflowSchedulesByApplicationID(ctx, applicationID)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("创建工作流边失败", err.Error()))
+		middleware.ThrowError(c, middleware.DatabaseError("获取定时调度列表失败", err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    edge,
-		"message": "工作流边创建成功",
+		"data":    schedules,
+		"count":   len(schedules),
 	})
 }
 
-// UpdateWorkflowEdge 更新工作流边
-// @Summary      更新工作流边
-// @Description  更新工作流边信息
-// @Tags         workflow-edges
+// UpdateWorkflowSchedule 更新定时调度
+// @Summary      更新定时调度
+// @Description  更新定时调度的名称、Cron表达式或输入参数
+// @Tags         workflow-schedules
 // @Accept       json
 // @Produce      json
-// @Param        id    path      string                            true  "边ID"
-// @Param        edge  body      models.UpdateWorkflowEdgeRequest  true  "工作流边信息"
-// @Success      200   {object}  object{success=bool,data=models.WorkflowEdgeResponse}
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      404   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/{id} [put]
-func (h *WorkflowHandler) UpdateWorkflowEdge(c *gin.Context) {
+// @Param        id        path      string                               true  "调度ID"
+// @Param        schedule  body      models.UpdateWorkflowScheduleRequest  true  "调度信息"
+// @Success      200       {object}  object{success=bool,data=models.WorkflowScheduleResponse}
+// @Failure      400       {object}  object{success=bool,message=string}
+// @Failure      404       {object}  object{success=bool,message=string}
+// @Failure      500       {object}  object{success=bool,message=string}
+// @Router       /workflow/schedules/{id} [put]
+func (h *WorkflowHandler) UpdateWorkflowSchedule(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		middleware.ThrowError(c, middleware.BadRequestError("调度ID格式无效", nil))
 		return
 	}
 
-	var req models.UpdateWorkflowEdgeRequest
+	var req models.UpdateWorkflowScheduleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
 		return
 	}
 
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceSchedule, funcs.WorkflowAccessEdit, id) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	edge, err := funcs.WorkflowFuncs{}.UpdateWorkflowEdge(ctx, id, &req)
+	schedule, err := funcs.WorkflowFuncs{}.UpdateWorkflowSchedule(ctx, id, &req)
 	if err != nil {
-		if err.Error() == "workflow edge not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+		if err.Error() == "workflow schedule not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("定时调度不存在", nil))
 			return
 		}
-		middleware.ThrowError(c, middleware.DatabaseError("更新工作流边失败", err.Error()))
+		if strings.HasPrefix(err.Error(), "invalid cron expression") {
+			middleware.ThrowError(c, middleware.BadRequestError("Cron表达式无效", err.Error()))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("更新定时调度失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    edge,
-		"message": "工作流边更新成功",
+		"data":    schedule,
+		"message": "定时调度更新成功",
 	})
 }
 
-// DeleteWorkflowEdge 删除工作流边
-// @Summary      删除工作流边
-// @Description  删除指定的工作流边
-// @Tags         workflow-edges
+// PauseWorkflowSchedule 暂停定时调度
+// @Summary      暂停定时调度
+// @Description  暂停定时调度，暂停期间不会触发执行
+// @Tags         workflow-schedules
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "边ID"
-// @Success      200  {object}  object{success=bool,message=string}
+// @Param        id   path      string  true  "调度ID"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowScheduleResponse}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
 // @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/{id} [delete]
-func (h *WorkflowHandler) DeleteWorkflowEdge(c *gin.Context) {
+// @Router       /workflow/schedules/{id}/pause [post]
+func (h *WorkflowHandler) PauseWorkflowSchedule(c *gin.Context) {
+	h.setWorkflowScheduleEnabled(c, false)
+}
+
+// ResumeWorkflowSchedule 恢复定时调度
+// @Summary      恢复定时调度
+// @Description  恢复已暂停的定时调度
+// @Tags         workflow-schedules
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "调度ID"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowScheduleResponse}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/schedules/{id}/resume [post]
+func (h *WorkflowHandler) ResumeWorkflowSchedule(c *gin.Context) {
+	h.setWorkflowScheduleEnabled(c, true)
+}
+
+// setWorkflowScheduleEnabled 暂停/恢复定时调度的公共处理
+func (h *WorkflowHandler) setWorkflowScheduleEnabled(c *gin.Context, enabled bool) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("边ID格式无效", nil))
+		middleware.ThrowError(c, middleware.BadRequestError("调度ID格式无效", nil))
+		return
+	}
+
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceSchedule, funcs.WorkflowAccessEdit, id) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	err = funcs.WorkflowFuncs{}.DeleteWorkflowEdge(ctx, id)
+	schedule, err := funcs.WorkflowFuncs{}.SetWorkflowScheduleEnabled(ctx, id, enabled)
 	if err != nil {
-		if err.Error() == "workflow edge not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流边不存在", nil))
+		if err.Error() == "workflow schedule not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("定时调度不存在", nil))
 			return
 		}
-		middleware.ThrowError(c, middleware.DatabaseError("删除工作流边失败", err.Error()))
+		middleware.ThrowError(c, middleware.DatabaseError("更新定时调度状态失败", err.Error()))
 		return
 	}
 
+	message := "定时调度已暂停"
+	if enabled {
+		message = "定时调度已恢复"
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "工作流边删除成功",
+		"data":    schedule,
+		"message": message,
 	})
 }
 
-// BatchCreateWorkflowEdges 批量创建工作流边
-// @Summary      批量创建工作流边
-// @Description  批量创建多个工作流边
-// @Tags         workflow-edges
+// DeleteWorkflowSchedule 删除定时调度
+// @Summary      删除定时调度
+// @Description  删除指定的定时调度
+// @Tags         workflow-schedules
 // @Accept       json
 // @Produce      json
-// @Param        body  body      models.BatchCreateWorkflowEdgesRequest  true  "边列表"
-// @Success      201   {object}  object{success=bool,data=[]models.WorkflowEdgeResponse}
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/batch-create [post]
-func (h *WorkflowHandler) BatchCreateWorkflowEdges(c *gin.Context) {
-	var req models.BatchCreateWorkflowEdgesRequest
+// @Param        id   path      string  true  "调度ID"
+// @Success      200  {object}  object{success=bool,message=string}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/schedules/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflowSchedule(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("调度ID格式无效", nil))
+		return
+	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+	if !checkWorkflowResourceAccess(c, funcs.WorkflowResourceSchedule, funcs.WorkflowAccessEdit, id) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	edges, err := funcs.WorkflowFuncs{}.BatchCreateWorkflowEdges(ctx, &req)
+	err = funcs.WorkflowFuncs{}.DeleteWorkflowSchedule(ctx, id)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("批量创建工作流边失败", err.Error()))
+		if err.Error() == "workflow schedule not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("定时调度不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("删除定时调度失败", err.Error()))
 		return
 	}
 
-	c.JSON(http.StatusCreated, gin.H{
+	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    edges,
-		"message": "工作流边批量创建成功",
+		"message": "定时调度删除成功",
 	})
 }
 
-// BatchDeleteWorkflowEdges 批量删除工作流边
-// @Summary      批量删除工作流边
-// @Description  批量删除多个工作流边
-// @Tags         workflow-edges
+// GetWorkflowSecrets 获取应用的密钥列表
+// @Summary      获取应用的密钥列表
+// @Description  获取指定工作流应用的所有密钥名称及引用写法，不返回密钥值
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        body  body      models.BatchDeleteWorkflowEdgesRequest  true  "边ID列表"
-// @Success      200   {object}  object{success=bool,message=string}
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/edges/batch-delete [post]
-func (h *WorkflowHandler) BatchDeleteWorkflowEdges(c *gin.Context) {
-	var req models.BatchDeleteWorkflowEdgesRequest
+// @Param        id   path      string  true  "应用ID"
+// @Success      200  {object}  object{success=bool,data=[]models.WorkflowSecretResponse,count=int}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/secrets [get]
+func (h *WorkflowHandler) GetWorkflowSecrets(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
+		return
+	}
 
-	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessRead) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	err := funcs.WorkflowFuncs{}.BatchDeleteWorkflowEdges(ctx, &req)
+	secrets, err := funcs.WorkflowFuncs{}.ListApplicationSecrets(ctx, applicationID)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("批量删除工作流边失败", err.Error()))
+		middleware.ThrowError(c, middleware.DatabaseError("获取应用密钥列表失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "工作流边批量删除成功",
+		"data":    secrets,
+		"count":   len(secrets),
 	})
 }
 
-// ============ WorkflowVersion Handlers ============
-
-// CreateWorkflowVersion 创建工作流版本快照
-// @Summary      创建工作流版本快照
-// @Description  为指定应用创建版本快照，自动递增版本号
-// @Tags         workflow-versions
+// SetWorkflowSecret 设置应用密钥
+// @Summary      设置应用密钥
+// @Description  创建或覆盖应用密钥，密钥值加密保存且之后不会再返回；节点配置中通过 ${secrets.KEY} 引用
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        request  body      models.CreateWorkflowVersionRequest  true  "创建版本请求"
-// @Success      200      {object}  object{success=bool,data=models.WorkflowVersionResponse}
-// @Failure      400      {object}  object{success=bool,message=string}
-// @Failure      500      {object}  object{success=bool,message=string}
-// @Router       /workflow/versions [post]
-func (h *WorkflowHandler) CreateWorkflowVersion(c *gin.Context) {
-	var req models.CreateWorkflowVersionRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("请求参数错误", map[string]any{
-			"error": err.Error(),
-		}))
-		return
-	}
-
-	ctx := middleware.GetRequestContext(c)
-	version, err := funcs.WorkflowFuncs{}.CreateWorkflowVersion(ctx, &req)
+// @Param        id      path      string                          true  "应用ID"
+// @Param        key     path      string                          true  "密钥名称"
+// @Param        secret  body      models.SetWorkflowSecretRequest  true  "密钥值"
+// @Success      200     {object}  object{success=bool,data=models.WorkflowSecretResponse,message=string}
+// @Failure      400     {object}  object{success=bool,message=string}
+// @Failure      404     {object}  object{success=bool,message=string}
+// @Failure      500     {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/secrets/{key} [put]
+func (h *WorkflowHandler) SetWorkflowSecret(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
 	if err != nil {
-		if err.Error() == "workflow application not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
-			return
-		}
-		middleware.ThrowError(c, middleware.DatabaseError("创建工作流版本失败", err.Error()))
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    version,
-		"message": "工作流版本创建成功",
-	})
-}
-
-// GetWorkflowVersionsByApplicationID 根据应用ID获取版本列表
-// @Summary      根据应用ID获取版本列表
-// @Description  获取指定应用的所有版本快照，按版本号降序排列
-// @Tags         workflow-versions
-// @Accept       json
-// @Produce      json
-// @Param        applicationId  query     string  true  "应用ID"
-// @Success      200            {object}  object{success=bool,data=[]models.WorkflowVersionResponse}
-// @Failure      400            {object}  object{success=bool,message=string}
-// @Failure      500            {object}  object{success=bool,message=string}
-// @Router       /workflow/versions/by-application [get]
-func (h *WorkflowHandler) GetWorkflowVersionsByApplicationID(c *gin.Context) {
-	applicationIDStr := c.Query("applicationId")
-	if applicationIDStr == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+	var req models.SetWorkflowSecretRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
 		return
 	}
 
-	applicationID, err := strconv.ParseUint(applicationIDStr, 10, 64)
-	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", map[string]any{
-			"provided_id": applicationIDStr,
-		}))
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	versions, err := funcs.WorkflowFuncs{}.GetWorkflowVersionsByApplicationID(ctx, applicationID)
+	secret, err := funcs.WorkflowFuncs{}.SetApplicationSecret(ctx, applicationID, c.Param("key"), req.Value)
 	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("获取版本列表失败", err.Error()))
+		switch {
+		case err.Error() == "workflow application not found":
+			middleware.ThrowError(c, middleware.NotFoundError("工作流应用不存在", nil))
+		case strings.HasPrefix(err.Error(), "invalid secret key"):
+			middleware.ThrowError(c, middleware.BadRequestError("密钥名称只能包含字母、数字和下划线，且不能以数字开头", err.Error()))
+		case err.Error() == "secret store not configured":
+			middleware.ThrowError(c, middleware.BadRequestError("未配置应用密钥存储的主密钥", nil))
+		default:
+			middleware.ThrowError(c, middleware.DatabaseError("设置应用密钥失败", err.Error()))
+		}
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    versions,
-		"count":   len(versions),
+		"data":    secret,
+		"message": "应用密钥设置成功",
 	})
 }
 
-// ============ Batch Save Handler ============
-
-// BatchSaveWorkflow 批量保存工作流
-// @Summary      批量保存工作流
-// @Description  批量保存工作流的节点和边（增删改）
-// @Tags         workflow-batch
+// DeleteWorkflowSecret 删除应用密钥
+// @Summary      删除应用密钥
+// @Description  删除应用密钥，之后引用该密钥的节点将无法执行
+// @Tags         workflow-applications
 // @Accept       json
 // @Produce      json
-// @Param        body  body      models.BatchSaveWorkflowRequest  true  "批量保存请求"
-// @Success      200   {object}  models.BatchSaveWorkflowResponse
-// @Failure      400   {object}  object{success=bool,message=string}
-// @Failure      500   {object}  object{success=bool,message=string}
-// @Router       /workflow/batch-save [post]
-func (h *WorkflowHandler) BatchSaveWorkflow(c *gin.Context) {
-	var req models.BatchSaveWorkflowRequest
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		middleware.ThrowError(c, middleware.ValidationError("请求数据格式错误", err.Error()))
+// @Param        id   path      string  true  "应用ID"
+// @Param        key  path      string  true  "密钥名称"
+// @Success      200  {object}  object{success=bool,message=string}
+// @Failure      400  {object}  object{success=bool,message=string}
+// @Failure      404  {object}  object{success=bool,message=string}
+// @Failure      500  {object}  object{success=bool,message=string}
+// @Router       /workflow/applications/{id}/secrets/{key} [delete]
+func (h *WorkflowHandler) DeleteWorkflowSecret(c *gin.Context) {
+	idStr := c.Param("id")
+	applicationID, err := strconv.ParseUint(idStr, 10, 64)
+	if err != nil {
+		middleware.ThrowError(c, middleware.BadRequestError("应用ID格式无效", nil))
 		return
 	}
 
-	if req.ApplicationID == "" {
-		middleware.ThrowError(c, middleware.BadRequestError("应用ID不能为空", nil))
+	if !checkWorkflowApplicationAccess(c, applicationID, funcs.WorkflowAccessEdit) {
 		return
 	}
 
 	ctx := middleware.GetRequestContext(c)
-	result, err := funcs.WorkflowFuncs{}.BatchSaveWorkflow(ctx, &req)
-	if err != nil {
-		middleware.ThrowError(c, middleware.DatabaseError("批量保存工作流失败", err.Error()))
+	if err := (funcs.WorkflowFuncs{}).DeleteApplicationSecret(ctx, applicationID, c.Param("key")); err != nil {
+		if err.Error() == "workflow secret not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("应用密钥不存在", nil))
+			return
+		}
+		middleware.ThrowError(c, middleware.DatabaseError("删除应用密钥失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"message": "批量保存成功",
-		"data":    result,
+		"message": "应用密钥删除成功",
 	})
 }
 
-// GetWorkflowVersion 获取单个版本
-// @Summary      获取单个版本
-// @Description  根据版本ID获取版本详情
-// @Tags         workflow-versions
+// GetNodeExecution 获取节点执行详情
+// @Summary      获取节点执行详情
+// @Description  获取单个节点执行的输入、输出、附加信息、错误以及关联的执行日志；withContext=true 时同时返回直接上游节点的输出和直接下游节点的输入（已脱敏），用于追踪错误值来自哪个上游节点
+// @Tags         workflow-executions
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "版本ID"
-// @Success      200  {object}  object{success=bool,data=models.WorkflowVersionResponse}
+// @Param        id           path      string  true   "节点执行ID"
+// @Param        withContext  query     bool    false  "是否包含上下游节点的执行"
+// @Success      200  {object}  object{success=bool,data=models.WorkflowNodeExecutionResponse}
 // @Failure      400  {object}  object{success=bool,message=string}
 // @Failure      404  {object}  object{success=bool,message=string}
 // @Failure      500  {object}  object{success=bool,message=string}
-// @Router       /workflow/versions/{id} [get]
-func (h *WorkflowHandler) GetWorkflowVersion(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseUint(idStr, 10, 64)
+// @Router       /workflow/node-executions/{id} [get]
+func (h *WorkflowHandler) GetNodeExecution(c *gin.Context) {
+	id := c.Param("id")
+	withContext, err := strconv.ParseBool(c.DefaultQuery("withContext", "false"))
 	if err != nil {
-		middleware.ThrowError(c, middleware.BadRequestError("版本ID格式无效", map[string]any{
-			"provided_id": idStr,
+		middleware.ThrowError(c, middleware.BadRequestError("withContext参数格式无效", map[string]any{
+			"provided": c.Query("withContext"),
 		}))
 		return
 	}
 
+	if !checkWorkflowResourceIDsAccess(c, funcs.WorkflowResourceNodeExecution, funcs.WorkflowAccessRead, []string{id}) {
+		return
+	}
+
 	ctx := middleware.GetRequestContext(c)
-	version, err := funcs.WorkflowFuncs{}.GetWorkflowVersionByID(ctx, id)
+	result, err := funcs.WorkflowFuncs{}.GetNodeExecution(ctx, id, withContext)
 	if err != nil {
-		if err.Error() == "workflow version not found" {
-			middleware.ThrowError(c, middleware.NotFoundError("工作流版本不存在", nil))
-			return
+		if strings.HasPrefix(err.Error(), "invalid node execution id") {
+			middleware.ThrowError(c, middleware.BadRequestError("节点执行ID格式无效", map[string]any{
+				"provided_id": id,
+			}))
+		} else if err.Error() == "workflow node execution not found" {
+			middleware.ThrowError(c, middleware.NotFoundError("节点执行记录未找到", map[string]any{
+				"id": id,
+			}))
+		} else {
+			middleware.ThrowError(c, middleware.DatabaseError("获取节点执行详情失败", err.Error()))
 		}
-		middleware.ThrowError(c, middleware.DatabaseError("获取版本失败", err.Error()))
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    version,
+		"data":    result,
 	})
 }
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	cfgmiddleware "go-backend/pkg/configs/middleware"
+	"go-backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+// accessLogSample 判断成功请求是否被采样记录，测试中可替换
+var accessLogSample = func(rate float64) bool {
+	return rand.Float64() < rate
+}
+
+// AccessLog 访问日志中间件：每个请求结束后记录一条日志，包含方法、路径、路由、状态码、耗时、
+// 响应字节数、请求ID和已认证的用户ID。应在其他中间件之前注册，以便记录被提前中止的请求和最终的状态码
+func AccessLog(config cfgmiddleware.AccessLogConfig) gin.HandlerFunc {
+	logger := logging.WithName("access_log")
+
+	return func(c *gin.Context) {
+		// 在处理前确定请求ID并写入响应头，错误响应和后续日志使用同一个ID
+		requestID := GetRequestID(c)
+		c.Header(RequestIDHeader, requestID)
+
+		start := time.Now()
+		path := c.Request.URL.Path
+		c.Next()
+
+		if accessLogExcluded(config.ExcludePaths, path, c.FullPath()) {
+			return
+		}
+		status := c.Writer.Status()
+		if status < http.StatusInternalServerError && !accessLogSample(config.SampleRate) {
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "-"
+		}
+		userID := "-"
+		if uid, ok := GetCurrentUserID(c); ok {
+			userID = strconv.FormatUint(uid, 10)
+		}
+
+		format := "method=%s path=%s route=%s status=%d latency_ms=%.3f bytes=%d request_id=%s user_id=%s client_ip=%s"
+		args := []any{
+			c.Request.Method, path, route, status, float64(time.Since(start).Microseconds()) / 1000,
+			max(c.Writer.Size(), 0), requestID, userID, c.ClientIP(),
+		}
+		switch {
+		case status >= http.StatusInternalServerError:
+			logger.Error(format, args...)
+		case status >= http.StatusBadRequest:
+			logger.Warn(format, args...)
+		default:
+			logger.Info(format, args...)
+		}
+	}
+}
+
+// accessLogExcluded 判断请求路径或路由模板是否在排除列表中，以 * 结尾的规则按前缀匹配
+func accessLogExcluded(patterns []string, path, route string) bool {
+	for _, pattern := range patterns {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(path, prefix) || (route != "" && strings.HasPrefix(route, prefix)) {
+				return true
+			}
+			continue
+		}
+		if pattern == path || pattern == route {
+			return true
+		}
+	}
+	return false
+}
@@ -25,9 +25,8 @@ func APIAuthMiddleware(engine *gin.Engine) gin.HandlerFunc {
 		// 首先查找匹配的路由模式
 		routePattern := findMatchingRoutePattern(engine, method, requestPath)
 		if routePattern == "" {
-			// 路由不存在，直接返回404
-			ThrowError(c, NotFoundError("请求的API路由不存在", nil))
-			c.Abort()
+			// 路由不存在时返回404，路径存在但方法不匹配时返回405
+			throwRouteNotMatched(c, engine)
 			return
 		}
 
@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"context"
+	"reflect"
+	"runtime"
 	"strings"
 
 	"go-backend/internal/funcs"
@@ -109,6 +111,31 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// 同一终端的新登录已使该会话失效
+		if err := funcs.CheckSessionRevoked(c.Request.Context(), claims); err != nil {
+			if apiAuthRecord.IsPublic {
+				c.Next()
+				return
+			}
+
+			ThrowError(c, UnauthorizedError("认证令牌已失效", err.Error()))
+			c.Abort()
+			return
+		}
+
+		// 限定了授权范围的令牌只能访问声明了授权范围的路由，未声明范围的路由默认拒绝
+		if claims.Scoped() && !routeDeclaresScopes(c) {
+			if apiAuthRecord.IsPublic {
+				// 公开接口不采用该令牌的身份，按未认证请求处理
+				c.Next()
+				return
+			}
+
+			ThrowError(c, ForbiddenError("令牌的授权范围不允许访问此接口", nil))
+			c.Abort()
+			return
+		}
+
 		// 将用户ID存储到上下文中
 		c.Set("user_id", claims.UserID)
 		c.Set("client_device_id", claims.ClientDeviceId)
@@ -188,6 +215,48 @@ func RequireAuth(c *gin.Context) (uint64, bool) {
 	return userID, true
 }
 
+// RequireScopes 要求令牌包含所有指定的授权范围
+// 只检查限定了授权范围的令牌，未限定范围的令牌和未携带令牌的请求由 JWTAuthMiddleware 的鉴权结果决定；
+// 处理链中没有 RequireScopes 的路由不接受限定了授权范围的令牌
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := GetJWTClaims(c)
+		if !exists || !claims.Scoped() {
+			c.Next()
+			return
+		}
+
+		missing := make([]string, 0)
+		for _, scope := range scopes {
+			if !claims.HasScope(scope) {
+				missing = append(missing, scope)
+			}
+		}
+		if len(missing) > 0 {
+			ThrowError(c, ForbiddenError("令牌的授权范围不允许访问此接口", map[string]any{
+				"missing_scopes": missing,
+			}))
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireScopesHandlerPrefix RequireScopes 返回的处理函数的名称前缀
+var requireScopesHandlerPrefix = runtime.FuncForPC(reflect.ValueOf(RequireScopes).Pointer()).Name() + "."
+
+// routeDeclaresScopes 判断当前路由的处理链中是否注册了 RequireScopes
+func routeDeclaresScopes(c *gin.Context) bool {
+	for _, name := range c.HandlerNames() {
+		if strings.HasPrefix(name, requireScopesHandlerPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetRequestContext 从gin.Context获取带有用户信息的context.Context
 // 这是统一处理context传递的核心函数
 func GetRequestContext(c *gin.Context) context.Context {
@@ -2,20 +2,82 @@ package middleware
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"go-backend/internal/funcs"
 	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
 	"go-backend/shared/models"
+	"net"
 	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-// ErrorHandlerMiddleware 错误处理中间件
+const (
+	// RequestIDHeader 请求关联ID的请求/响应头
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey 请求关联ID在gin.Context中的键
+	RequestIDKey = "request_id"
+)
+
+// ErrorHandlerMiddleware 错误处理中间件：将panic恢复为统一的错误响应。
+// http.ErrAbortHandler 是处理函数主动中止响应，继续向上panic交给 net/http 静默断开连接；
+// 客户端已断开（broken pipe、connection reset）时无法写入响应，只记录一条告警并中止请求
 func ErrorHandlerMiddleware() gin.HandlerFunc {
-	return gin.CustomRecoveryWithWriter(gin.DefaultWriter, func(c *gin.Context, recovered any) {
-		handleError(c, recovered)
-	})
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+			if isBrokenPipe(recovered) {
+				logging.Warn("[Recovery][%s] %s %s connection closed by client: %v",
+					GetRequestID(c), c.Request.Method, c.Request.URL.Path, recovered)
+				c.Abort()
+				return
+			}
+			handleError(c, recovered, debug.Stack())
+		}()
+
+		c.Next()
+	}
+}
+
+// isBrokenPipe 判断panic是否由写入已断开的客户端连接引起
+func isBrokenPipe(recovered any) bool {
+	var opErr *net.OpError
+	err, ok := recovered.(error)
+	if !ok || !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if !errors.As(opErr, &syscallErr) {
+		return false
+	}
+	message := strings.ToLower(syscallErr.Error())
+	return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+}
+
+// GetRequestID 获取当前请求的关联ID，优先使用客户端传入的X-Request-ID，没有则生成
+func GetRequestID(c *gin.Context) string {
+	if requestID := c.GetString(RequestIDKey); requestID != "" {
+		return requestID
+	}
+
+	requestID := c.GetHeader(RequestIDHeader)
+	if requestID == "" {
+		requestID = utils.UUIDString()
+	}
+	c.Set(RequestIDKey, requestID)
+	return requestID
 }
 
 // ErrorHandler 错误处理中间件（用于处理手动抛出的错误）
@@ -32,23 +94,28 @@ func ErrorHandler() gin.HandlerFunc {
 }
 
 // handleError 处理panic恢复的错误
-func handleError(c *gin.Context, recovered any) {
+func handleError(c *gin.Context, recovered any, stack []byte) {
 	var customErr *CustomError
 	var ok bool
 
 	// 尝试转换为自定义错误
 	if customErr, ok = recovered.(*CustomError); !ok {
-		// 如果不是自定义错误，创建一个内部服务器错误
-		customErr = InternalServerError(
-			"服务器内部错误",
-			map[string]any{
-				"error": recovered,
-			},
-		)
+		// 如果不是自定义错误，创建一个内部服务器错误，原始panic内容仅在调试模式下返回给客户端
+		var data any
+		if gin.Mode() == gin.DebugMode {
+			data = map[string]any{
+				"error": fmt.Sprint(recovered),
+			}
+		}
+		customErr = InternalServerError("服务器内部错误", data)
 	}
 
-	// 记录错误日志
-	logError(c, customErr, true)
+	if customErr.Stack == "" {
+		customErr.Stack = string(stack)
+	}
+
+	// 记录错误日志（包含panic内容、请求ID和堆栈），每个panic只记录一次
+	logError(c, customErr, recovered)
 
 	// 响应错误
 	respondWithError(c, customErr)
@@ -72,7 +139,7 @@ func handleGinError(c *gin.Context, err error) {
 	}
 
 	// 记录错误日志
-	logError(c, customErr, false)
+	logError(c, customErr, nil)
 
 	// 响应错误
 	respondWithError(c, customErr)
@@ -80,6 +147,7 @@ func handleGinError(c *gin.Context, err error) {
 
 // respondWithError 统一错误响应
 func respondWithError(c *gin.Context, customErr *CustomError) {
+	requestID := GetRequestID(c)
 	response := ErrorResponse{
 		Success:   false,
 		Code:      customErr.Code,
@@ -87,6 +155,7 @@ func respondWithError(c *gin.Context, customErr *CustomError) {
 		Data:      customErr.Data,
 		Timestamp: time.Now().Format(time.RFC3339),
 		Path:      c.Request.URL.Path,
+		RequestID: requestID,
 	}
 
 	// 在开发环境中包含堆栈信息
@@ -97,11 +166,13 @@ func respondWithError(c *gin.Context, customErr *CustomError) {
 	// 根据错误代码设置HTTP状态码
 	httpCode := getHTTPStatusCode(customErr.Code)
 
+	c.Header(RequestIDHeader, requestID)
 	c.JSON(httpCode, response)
 }
 
-// logError 记录错误日志
-func logError(c *gin.Context, customErr *CustomError, isPanic bool) {
+// logError 记录错误日志，recovered 为恢复的panic内容，不是panic时为 nil
+func logError(c *gin.Context, customErr *CustomError, recovered any) {
+	isPanic := recovered != nil
 	errorType := "Error"
 	if isPanic {
 		errorType = "Panic"
@@ -116,20 +187,28 @@ func logError(c *gin.Context, customErr *CustomError, isPanic bool) {
 		"query":      c.Request.URL.RawQuery,
 		"user_agent": c.Request.UserAgent(),
 		"ip":         c.ClientIP(),
+		"request_id": GetRequestID(c),
 		"timestamp":  time.Now().Format(time.RFC3339),
 	}
 
+	if isPanic {
+		logData["panic"] = fmt.Sprint(recovered)
+	}
 	if customErr.Data != nil {
-		logData["data"] = customErr.Data
+		logData["data"] = utils.Redact(customErr.Data, nil)
 	}
 
 	if customErr.Stack != "" {
 		logData["stack"] = customErr.Stack
 	}
 
-	// 序列化日志数据
+	// 序列化日志数据，panic按错误级别记录
 	logJSON, _ := json.Marshal(logData)
-	logging.Warn("[%s] %s", errorType, string(logJSON))
+	if isPanic {
+		logging.Error("[%s] %s", errorType, string(logJSON))
+	} else {
+		logging.Warn("[%s] %s", errorType, string(logJSON))
+	}
 
 	level := "warn"
 	if errorType == "Panic" {
@@ -49,6 +49,7 @@ type ErrorResponse struct {
 	Data      any              `json:"data,omitempty"`
 	Timestamp string           `json:"timestamp"`
 	Path      string           `json:"path"`
+	RequestID string           `json:"requestId,omitempty"`
 	Stack     string           `json:"stack,omitempty"`
 }
 
@@ -61,7 +62,10 @@ const (
 	ErrCodeUnauthorized models.ErrorCode = 401
 	ErrCodeForbidden    models.ErrorCode = 403
 	ErrCodeNotFound     models.ErrorCode = 404
+	ErrCodeNotAllowed   models.ErrorCode = 405
 	ErrCodeConflict     models.ErrorCode = 409
+	ErrCodeTooMany      models.ErrorCode = 429
+	ErrCodeUnavailable  models.ErrorCode = 503
 
 	// 业务错误
 	ErrCodeUserNotFound    models.ErrorCode = 1001
@@ -78,7 +82,10 @@ var ErrorMessages = map[models.ErrorCode]string{
 	ErrCodeUnauthorized:    "未授权",
 	ErrCodeForbidden:       "禁止访问",
 	ErrCodeNotFound:        "资源未找到",
+	ErrCodeNotAllowed:      "请求方法不允许",
 	ErrCodeConflict:        "资源冲突",
+	ErrCodeTooMany:         "请求过于频繁",
+	ErrCodeUnavailable:     "服务暂不可用",
 	ErrCodeUserNotFound:    "用户不存在",
 	ErrCodeUserExists:      "用户已存在",
 	ErrCodeInvalidUserData: "用户数据无效",
@@ -109,6 +116,13 @@ func NotFoundError(message string, data any) *CustomError {
 	return NewCustomError(ErrCodeNotFound, message, data)
 }
 
+func MethodNotAllowedError(message string, data any) *CustomError {
+	if message == "" {
+		message = GetErrorMessage(ErrCodeNotAllowed)
+	}
+	return NewCustomError(ErrCodeNotAllowed, message, data)
+}
+
 func UnauthorizedError(message string, data any) *CustomError {
 	if message == "" {
 		message = GetErrorMessage(ErrCodeUnauthorized)
@@ -152,9 +166,30 @@ func UserExistsError(data any) *CustomError {
 	return NewCustomError(ErrCodeUserExists, GetErrorMessage(ErrCodeUserExists), data)
 }
 
+func ConflictError(message string, data any) *CustomError {
+	if message == "" {
+		message = GetErrorMessage(ErrCodeConflict)
+	}
+	return NewCustomError(ErrCodeConflict, message, data)
+}
+
 func ForbiddenError(message string, data any) *CustomError {
 	if message == "" {
 		message = GetErrorMessage(ErrCodeForbidden)
 	}
 	return NewCustomError(ErrCodeForbidden, message, data)
 }
+
+func TooManyRequestsError(message string, data any) *CustomError {
+	if message == "" {
+		message = GetErrorMessage(ErrCodeTooMany)
+	}
+	return NewCustomError(ErrCodeTooMany, message, data)
+}
+
+func ServiceUnavailableError(message string, data any) *CustomError {
+	if message == "" {
+		message = GetErrorMessage(ErrCodeUnavailable)
+	}
+	return NewCustomError(ErrCodeUnavailable, message, data)
+}
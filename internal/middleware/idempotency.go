@@ -0,0 +1,198 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// IdempotencyKeyHeader 客户端传入的幂等键请求头
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader 响应为重放结果时设置的响应头
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// idempotencyKeys 幂等记录的缓存键构建器
+var idempotencyKeys = caching.NewKeyBuilder("idempotency")
+
+// 幂等记录状态
+const (
+	idempotencyStatePending = "pending" // 首次请求处理中
+	idempotencyStateDone    = "done"    // 已缓存首次响应
+)
+
+// idempotencyRecord 缓存在Redis中的幂等记录
+type idempotencyRecord struct {
+	State       string `json:"state"`
+	BodyHash    string `json:"bodyHash"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// idempotencyWriter 记录响应体，便于处理完成后缓存
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency 幂等键中间件，由需要防止重复执行的写接口按路由启用
+// 携带 Idempotency-Key 的 POST/PUT/PATCH/DELETE 请求首次处理成功后缓存响应（状态码+响应体），
+// 在 server.middleware.idempotency.ttl 内使用相同键和相同请求体的重复请求直接重放该响应；
+// 相同键但请求体不同、或首次请求仍在处理中时返回409。Redis不可用时直接放行
+func Idempotency() gin.HandlerFunc {
+	ttl := configs.GetConfig().Server.Middleware.Idempotency.TTL
+	return newIdempotencyMiddleware(func() redis.Cmdable {
+		if client := caching.GetInstanceUnsafe(); client != nil && caching.Healthy() {
+			return client
+		}
+		return nil
+	}, ttl)
+}
+
+// newIdempotencyMiddleware 使用指定的Redis客户端获取函数创建幂等键中间件
+func newIdempotencyMiddleware(getClient func() redis.Cmdable, ttl time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		client := getClient()
+		if client == nil {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			ThrowError(c, BadRequestError("读取请求体失败", err.Error()))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		sum := sha256.Sum256(body)
+		bodyHash := hex.EncodeToString(sum[:])
+		cacheKey := idempotencyCacheKey(c, idempotencyKey)
+		ctx := c.Request.Context()
+
+		pending, _ := json.Marshal(idempotencyRecord{State: idempotencyStatePending, BodyHash: bodyHash})
+		reserved, err := client.SetNX(ctx, cacheKey, pending, ttl).Result()
+		if err != nil {
+			// 缓存故障时不阻塞请求
+			logging.Warn("幂等键检查失败，跳过幂等处理: %v", err)
+			c.Next()
+			return
+		}
+
+		if !reserved {
+			replayIdempotentResponse(c, client, cacheKey, bodyHash)
+			return
+		}
+
+		writer := &idempotencyWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		// 处理失败（抛出错误、未写响应或服务端错误）时释放幂等键，允许客户端重试
+		if len(c.Errors) > 0 || !writer.Written() || writer.Status() >= http.StatusInternalServerError {
+			client.Del(context.WithoutCancel(ctx), cacheKey)
+			return
+		}
+
+		record, _ := json.Marshal(idempotencyRecord{
+			State:       idempotencyStateDone,
+			BodyHash:    bodyHash,
+			Status:      writer.Status(),
+			ContentType: writer.Header().Get("Content-Type"),
+			Body:        writer.body.Bytes(),
+		})
+		if err := client.Set(context.WithoutCancel(ctx), cacheKey, record, ttl).Err(); err != nil {
+			logging.Warn("缓存幂等响应失败: %v", err)
+		}
+	}
+}
+
+// replayIdempotentResponse 处理重复请求：请求体一致且首次请求已完成时重放响应，否则返回409
+func replayIdempotentResponse(c *gin.Context, client redis.Cmdable, cacheKey, bodyHash string) {
+	data, err := client.Get(c.Request.Context(), cacheKey).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// 首次请求刚好失败并释放了键，提示客户端重试
+			ThrowError(c, ConflictError("请求正在处理中，请稍后重试", nil))
+			c.Abort()
+			return
+		}
+		logging.Warn("读取幂等记录失败，跳过幂等处理: %v", err)
+		c.Next()
+		return
+	}
+
+	var record idempotencyRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		ThrowError(c, InternalServerError("幂等记录格式错误", err.Error()))
+		c.Abort()
+		return
+	}
+
+	if record.BodyHash != bodyHash {
+		ThrowError(c, ConflictError("Idempotency-Key 已被用于不同的请求", map[string]any{
+			"idempotency_key": c.GetHeader(IdempotencyKeyHeader),
+		}))
+		c.Abort()
+		return
+	}
+	if record.State != idempotencyStateDone {
+		ThrowError(c, ConflictError("请求正在处理中，请稍后重试", nil))
+		c.Abort()
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Data(record.Status, record.ContentType, record.Body)
+	c.Abort()
+}
+
+// idempotencyCacheKey 幂等记录的缓存键，按用户、方法和请求路径隔离
+func idempotencyCacheKey(c *gin.Context, idempotencyKey string) string {
+	userID, _ := GetCurrentUserID(c)
+	return idempotencyKeys.Key(userID, c.Request.Method, c.Request.URL.Path, idempotencyKey)
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+func newIdempotencyTestRouter(t *testing.T) (*gin.Engine, *int) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start fake redis: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	calls := 0
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.POST("/items", newIdempotencyMiddleware(func() redis.Cmdable { return client }, time.Minute), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"success": true, "call": calls})
+	})
+	return router, &calls
+}
+
+func doIdempotentRequest(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysFirstResponse(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(t)
+
+	first := doIdempotentRequest(router, "key-1", `{"name":"a"}`)
+	second := doIdempotentRequest(router, "key-1", `{"name":"a"}`)
+
+	if *calls != 1 {
+		t.Fatalf("handler should run once, ran %d times", *calls)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("expected replayed response %d %s, got %d %s", first.Code, first.Body.String(), second.Code, second.Body.String())
+	}
+	if second.Header().Get(IdempotentReplayedHeader) != "true" {
+		t.Fatal("replayed response should be marked")
+	}
+
+	// 不带幂等键的请求不受影响
+	doIdempotentRequest(router, "", `{"name":"a"}`)
+	if *calls != 2 {
+		t.Fatalf("request without key should reach handler, calls=%d", *calls)
+	}
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	router, calls := newIdempotencyTestRouter(t)
+
+	doIdempotentRequest(router, "key-1", `{"name":"a"}`)
+	conflict := doIdempotentRequest(router, "key-1", `{"name":"b"}`)
+
+	if conflict.Code != http.StatusConflict {
+		t.Fatalf("expected 409 for reused key with different body, got %d %s", conflict.Code, conflict.Body.String())
+	}
+	if *calls != 1 {
+		t.Fatalf("conflicting request must not reach handler, calls=%d", *calls)
+	}
+}
@@ -2,15 +2,29 @@ package middleware
 
 import (
 	"encoding/json"
+	"go-backend/pkg/configs"
+	cfgmiddleware "go-backend/pkg/configs/middleware"
+	"go-backend/pkg/jwt"
+	"go-backend/pkg/logging"
 	"go-backend/shared/models"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+func TestMain(m *testing.M) {
+	// 错误处理会写日志，测试中使用不输出的默认logger
+	logging.NewLogger(&configs.LoggingConfig{Level: "fatal"})
+	os.Exit(m.Run())
+}
+
 func TestErrorHandlerMiddleware(t *testing.T) {
 	// 设置测试模式
 	gin.SetMode(gin.TestMode)
@@ -154,6 +168,7 @@ func TestGetHTTPStatusCode(t *testing.T) {
 		{"UserExists", ErrCodeUserExists, 409},
 		{"DatabaseError", ErrCodeDatabaseError, 500},
 		{"ValidationError", ErrCodeValidationError, 400},
+		{"TooManyRequests", ErrCodeTooMany, 429},
 		{"UnknownError", 9999, 500},
 	}
 
@@ -166,3 +181,322 @@ func TestGetHTTPStatusCode(t *testing.T) {
 		})
 	}
 }
+
+func TestRecoveryResponse(t *testing.T) {
+	router := gin.New()
+	router.Use(ErrorHandlerMiddleware())
+	router.GET("/panic", func(c *gin.Context) {
+		panic("unexpected")
+	})
+
+	tests := []struct {
+		name      string
+		mode      string
+		requestID string
+		wantStack bool
+	}{
+		{name: "release模式不返回堆栈", mode: gin.ReleaseMode, wantStack: false},
+		{name: "debug模式返回堆栈", mode: gin.DebugMode, wantStack: true},
+		{name: "沿用客户端关联ID", mode: gin.TestMode, requestID: "req-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(tt.mode)
+			defer gin.SetMode(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/panic", nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusInternalServerError {
+				t.Errorf("Expected status code 500, got %d", w.Code)
+			}
+
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse error response: %v", err)
+			}
+
+			if response.Success || response.Code != ErrCodeInternal {
+				t.Errorf("Unexpected response: %+v", response)
+			}
+			if response.RequestID == "" || w.Header().Get(RequestIDHeader) != response.RequestID {
+				t.Errorf("Expected request id in body and header, got '%s' and '%s'", response.RequestID, w.Header().Get(RequestIDHeader))
+			}
+			if tt.requestID != "" && response.RequestID != tt.requestID {
+				t.Errorf("Expected request id '%s', got '%s'", tt.requestID, response.RequestID)
+			}
+			if tt.mode != gin.TestMode && (response.Stack != "") != tt.wantStack {
+				t.Errorf("Expected stack present=%v, got '%s'", tt.wantStack, response.Stack)
+			}
+		})
+	}
+}
+
+func TestRecoveryConnectionErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ErrorHandlerMiddleware())
+	router.GET("/broken-pipe", func(c *gin.Context) {
+		panic(&net.OpError{Op: "write", Net: "tcp", Err: os.NewSyscallError("write", syscall.EPIPE)})
+	})
+	router.GET("/abort", func(c *gin.Context) {
+		panic(http.ErrAbortHandler)
+	})
+
+	// 客户端已断开时不写入错误响应
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/broken-pipe", nil)
+	router.ServeHTTP(w, req)
+	if w.Body.Len() != 0 {
+		t.Errorf("Expected no response body for a broken pipe, got '%s'", w.Body.String())
+	}
+
+	// http.ErrAbortHandler 继续向上panic，由 net/http 中止响应
+	func() {
+		defer func() {
+			if recovered := recover(); recovered != http.ErrAbortHandler {
+				t.Errorf("Expected http.ErrAbortHandler to be re-panicked, got %v", recovered)
+			}
+		}()
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/abort", nil)
+		router.ServeHTTP(w, req)
+	}()
+}
+
+func TestNoRouteAndNoMethodResponses(t *testing.T) {
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.HandleMethodNotAllowed = true
+	router.NoRoute(NoRouteHandler())
+	router.NoMethod(NoMethodHandler(router))
+	router.GET("/items/:id", func(c *gin.Context) {})
+	router.DELETE("/items/:id", func(c *gin.Context) {})
+
+	tests := []struct {
+		name       string
+		mode       string
+		method     string
+		path       string
+		wantCode   int
+		wantAllow  string
+		wantDetail bool
+	}{
+		{name: "路由不存在", mode: gin.ReleaseMode, method: "GET", path: "/missing", wantCode: http.StatusNotFound},
+		{name: "方法不允许", mode: gin.ReleaseMode, method: "POST", path: "/items/1", wantCode: http.StatusMethodNotAllowed, wantAllow: "DELETE, GET"},
+		{name: "debug模式返回请求详情", mode: gin.DebugMode, method: "PUT", path: "/items/1", wantCode: http.StatusMethodNotAllowed, wantAllow: "DELETE, GET", wantDetail: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(tt.mode)
+			defer gin.SetMode(gin.TestMode)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status code %d, got %d", tt.wantCode, w.Code)
+			}
+			if w.Header().Get("Allow") != tt.wantAllow {
+				t.Errorf("Expected Allow header '%s', got '%s'", tt.wantAllow, w.Header().Get("Allow"))
+			}
+
+			var response ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse error response: %v", err)
+			}
+			if response.Success || int(response.Code) != tt.wantCode || response.Message == "" {
+				t.Errorf("Unexpected response: %+v", response)
+			}
+
+			detail, _ := response.Data.(map[string]any)
+			if (detail != nil) != tt.wantDetail {
+				t.Fatalf("Expected detail present=%v, got %v", tt.wantDetail, response.Data)
+			}
+			if tt.wantDetail && (detail["method"] != tt.method || detail["path"] != tt.path) {
+				t.Errorf("Unexpected detail: %v", detail)
+			}
+		})
+	}
+}
+
+func TestRequireScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(func(c *gin.Context) {
+		// 模拟 JWTAuthMiddleware 写入的令牌声明
+		if scopes := c.GetHeader("X-Test-Scopes"); scopes != "" {
+			claims := &jwt.Claims{UserID: 1}
+			if scopes != "-" {
+				claims.Scopes = strings.Split(scopes, ",")
+			}
+			c.Set("jwt_claims", claims)
+		}
+		c.Next()
+	})
+	router.GET("/workflow", RequireScopes("workflow"), func(c *gin.Context) {
+		c.JSON(200, gin.H{"success": true})
+	})
+	router.POST("/workflow/execute", RequireScopes("workflow", "workflow:execute"), func(c *gin.Context) {
+		c.JSON(200, gin.H{"success": true})
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		scopes   string
+		wantCode int
+	}{
+		{name: "未携带令牌", method: "GET", path: "/workflow", wantCode: http.StatusOK},
+		{name: "未限定范围的令牌", method: "GET", path: "/workflow", scopes: "-", wantCode: http.StatusOK},
+		{name: "包含所需范围", method: "GET", path: "/workflow", scopes: "attachment,workflow", wantCode: http.StatusOK},
+		{name: "缺少所需范围", method: "GET", path: "/workflow", scopes: "attachment", wantCode: http.StatusForbidden},
+		{name: "缺少部分范围", method: "POST", path: "/workflow/execute", scopes: "workflow", wantCode: http.StatusForbidden},
+		{name: "包含全部范围", method: "POST", path: "/workflow/execute", scopes: "workflow,workflow:execute", wantCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.scopes != "" {
+				req.Header.Set("X-Test-Scopes", tt.scopes)
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode == http.StatusForbidden && !strings.Contains(w.Body.String(), "missing_scopes") {
+				t.Errorf("Expected missing scopes in response, got %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestJWTAuthRejectsScopedTokenOnUngatedRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	if err := jwt.InitializeService(&configs.JWTConfig{SecretKey: "test-secret", Issuer: "test", Audience: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	scoped, err := jwt.GenerateEnrichedAccessToken(1, 2, time.Hour, &jwt.AccessClaims{Scopes: []string{"workflow"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.Use(ErrorHandler())
+	router.Use(func(c *gin.Context) {
+		// 模拟 APIAuthMiddleware 写入的认证记录，公开接口跳过数据库权限检查
+		c.Set(string(ApiAuthRecord), &APIAuthRecord{IsPublic: c.GetHeader("X-Test-Public") == "1"})
+		c.Next()
+	})
+	router.Use(JWTAuthMiddleware())
+	respond := func(c *gin.Context) {
+		userID, _ := GetCurrentUserID(c)
+		c.JSON(200, gin.H{"success": true, "data": userID})
+	}
+	router.GET("/workflow", RequireScopes("workflow"), respond)
+	router.GET("/users", respond)
+
+	tests := []struct {
+		name     string
+		path     string
+		public   bool
+		wantCode int
+		wantUser string
+	}{
+		{name: "声明了范围的路由", path: "/workflow", public: true, wantCode: http.StatusOK, wantUser: `"data":1`},
+		{name: "未声明范围的路由", path: "/users", wantCode: http.StatusForbidden},
+		{name: "未声明范围的公开路由", path: "/users", public: true, wantCode: http.StatusOK, wantUser: `"data":0`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+scoped)
+			if tt.public {
+				req.Header.Set("X-Test-Public", "1")
+			}
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected status code %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantUser != "" && !strings.Contains(w.Body.String(), tt.wantUser) {
+				t.Errorf("Expected %s in response, got %s", tt.wantUser, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// 记录采样判断的调用，被排除的请求和 5xx 响应不经过采样
+	var sampled []string
+	previous := accessLogSample
+	accessLogSample = func(rate float64) bool {
+		sampled = append(sampled, "sampled")
+		return rate >= 1
+	}
+	defer func() { accessLogSample = previous }()
+
+	router := gin.New()
+	router.Use(AccessLog(cfgmiddleware.AccessLogConfig{Enabled: true, SampleRate: 1, ExcludePaths: []string{"/health", "/swagger/*"}}))
+	router.Use(ErrorHandler())
+	router.GET("/health", func(c *gin.Context) { c.String(200, "ok") })
+	router.GET("/swagger/*any", func(c *gin.Context) { c.String(200, "doc") })
+	router.GET("/users/:id", func(c *gin.Context) { c.String(200, "user") })
+	router.GET("/fail", func(c *gin.Context) { ThrowError(c, InternalServerError("测试错误", nil)) })
+
+	tests := []struct {
+		name        string
+		path        string
+		requestID   string
+		wantSampled int
+	}{
+		{name: "健康检查被排除", path: "/health", wantSampled: 0},
+		{name: "前缀规则排除", path: "/swagger/index.html", wantSampled: 0},
+		{name: "普通请求参与采样", path: "/users/1", requestID: "req-from-client", wantSampled: 1},
+		{name: "服务端错误始终记录", path: "/fail", wantSampled: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sampled = nil
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			router.ServeHTTP(w, req)
+
+			if len(sampled) != tt.wantSampled {
+				t.Errorf("Expected %d sampling decisions, got %d", tt.wantSampled, len(sampled))
+			}
+			requestID := w.Header().Get(RequestIDHeader)
+			if requestID == "" || (tt.requestID != "" && requestID != tt.requestID) {
+				t.Errorf("Expected request id header %q, got %q", tt.requestID, requestID)
+			}
+		})
+	}
+
+	if !accessLogExcluded([]string{"/api/v1/users/:id"}, "/api/v1/users/7", "/api/v1/users/:id") {
+		t.Error("Expected route template to match exclude rule")
+	}
+	if accessLogExcluded([]string{"/health"}, "/healthz", "") {
+		t.Error("Expected exact rule not to match longer path")
+	}
+}
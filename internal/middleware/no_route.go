@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NoRouteHandler 未匹配到路由时返回统一格式的404错误
+func NoRouteHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ThrowError(c, routeNotFoundError(c))
+	}
+}
+
+// NoMethodHandler 路径存在但请求方法不匹配时返回统一格式的405错误，并在Allow头中列出支持的方法
+func NoMethodHandler(engine *gin.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed := allowedRouteMethods(engine, c.Request.URL.Path)
+		if len(allowed) == 0 {
+			ThrowError(c, routeNotFoundError(c))
+			return
+		}
+		ThrowError(c, methodNotAllowedError(c, allowed))
+	}
+}
+
+// throwRouteNotMatched 请求没有匹配的路由时中止请求，路径存在但方法不匹配时返回405，否则返回404
+func throwRouteNotMatched(c *gin.Context, engine *gin.Engine) {
+	if allowed := allowedRouteMethods(engine, c.Request.URL.Path); len(allowed) > 0 {
+		ThrowError(c, methodNotAllowedError(c, allowed))
+	} else {
+		ThrowError(c, routeNotFoundError(c))
+	}
+	c.Abort()
+}
+
+// routeNotFoundError 构建路由不存在的错误，调试模式下附带请求的方法和路径
+func routeNotFoundError(c *gin.Context) *CustomError {
+	return NotFoundError("请求的API路由不存在", routeErrorDetail(c, nil))
+}
+
+// methodNotAllowedError 构建请求方法不允许的错误并设置Allow响应头
+func methodNotAllowedError(c *gin.Context, allowed []string) *CustomError {
+	c.Header("Allow", strings.Join(allowed, ", "))
+	return MethodNotAllowedError("请求的API路由不支持该请求方法", routeErrorDetail(c, allowed))
+}
+
+// routeErrorDetail 仅在调试模式下返回请求的方法和路径，避免在生产环境中回显探测请求
+func routeErrorDetail(c *gin.Context, allowed []string) map[string]any {
+	if gin.Mode() != gin.DebugMode {
+		return nil
+	}
+	detail := map[string]any{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+	}
+	if len(allowed) > 0 {
+		detail["allowed_methods"] = allowed
+	}
+	return detail
+}
+
+// allowedRouteMethods 返回注册了该路径的所有请求方法
+func allowedRouteMethods(engine *gin.Engine, requestPath string) []string {
+	seen := make(map[string]bool)
+	var allowed []string
+	for _, route := range engine.Routes() {
+		if seen[route.Method] {
+			continue
+		}
+		if route.Path == requestPath || isPathMatch(route.Path, requestPath) {
+			seen[route.Method] = true
+			allowed = append(allowed, route.Method)
+		}
+	}
+	sort.Strings(allowed)
+	return allowed
+}
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"go-backend/internal/funcs"
 	"go-backend/internal/handlers"
+	"go-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,7 +13,7 @@ func (r *Router) setupAttachmentRoutes(rg *gin.RouterGroup) {
 
 	attachmentHandler := handlers.NewAttachmentHandler()
 
-	attachments := rg.Group("/attachments")
+	attachments := rg.Group("/attachments", middleware.RequireScopes(funcs.TokenScopeAttachment))
 	// 全部需要auth中间件保护
 	attachments.GET("", attachmentHandler.GetAttachments)
 	attachments.GET("/page", attachmentHandler.GetAttachmentsWithPagination) // 分页查询路由
@@ -16,13 +16,27 @@ func (r *Router) setupAuthRoutes(rg *gin.RouterGroup) {
 		auth.POST("/send-verify-code", authHandler.SendVerifyCode)
 		auth.POST("/verify-code", authHandler.VerifyCode)
 		auth.POST("/login", authHandler.Login)
+		auth.POST("/login/flexible", authHandler.FlexibleLogin)
 		auth.POST("/register", authHandler.Register)
 		auth.POST("/reset-password", authHandler.ResetPassword)
+		auth.GET("/token/introspect", authHandler.IntrospectToken)
+		auth.POST("/webauthn/login/begin", authHandler.BeginWebAuthnLogin)
+		auth.POST("/webauthn/login/finish", authHandler.FinishWebAuthnLogin)
+		auth.POST("/magic-link/request", authHandler.RequestMagicLink)
+		auth.POST("/magic-link/login", authHandler.ConsumeMagicLink)
 
 		// 需要认证（配置为非public）的路由
 		auth.POST("/refresh-token", authHandler.RefreshToken)
 		auth.POST("/logout", authHandler.Logout)
 		auth.GET("/user-info", authHandler.GetUserInfo)
 		auth.GET("/user-menu-tree", authHandler.GetUserMenuTree)
+		auth.POST("/contact-change/request", authHandler.RequestContactChange)
+		auth.POST("/contact-change/confirm", authHandler.ConfirmContactChange)
+		auth.POST("/password/set", authHandler.SetInitialPassword)
+		auth.POST("/password/change", authHandler.ChangePassword)
+		auth.POST("/webauthn/register/begin", authHandler.BeginWebAuthnRegistration)
+		auth.POST("/webauthn/register/finish", authHandler.FinishWebAuthnRegistration)
+		auth.GET("/credentials", authHandler.GetCredentials)
+		auth.DELETE("/credentials/:id", authHandler.UnlinkCredential)
 	}
 }
@@ -11,8 +11,8 @@ func (r *Router) setupClientDeviceRoutes(rg *gin.RouterGroup) {
 
 	clientDeviceHandler := handlers.NewClientDeviceHandler()
 
-	clientDevices := rg.Group("/client-devices")
-	{
+	// 设备管理同时挂载在 /client-devices 和 /auth/devices 下
+	for _, clientDevices := range []*gin.RouterGroup{rg.Group("/client-devices"), rg.Group("/auth/devices")} {
 		// 基础CRUD操作
 		clientDevices.GET("", clientDeviceHandler.GetClientDevices)
 		clientDevices.GET("/page", clientDeviceHandler.GetClientDevicesWithPagination)
@@ -21,6 +21,7 @@ func (r *Router) setupClientDeviceRoutes(rg *gin.RouterGroup) {
 		clientDevices.POST("", clientDeviceHandler.CreateClientDevice)
 		clientDevices.PUT("/:id", clientDeviceHandler.UpdateClientDevice)
 		clientDevices.DELETE("/:id", clientDeviceHandler.DeleteClientDevice)
+		clientDevices.POST("/:id/regenerate-code", clientDeviceHandler.RegenerateClientDeviceCode)
 
 		// 根据code获取设备信息
 		clientDevices.GET("/code/:code", clientDeviceHandler.GetClientDeviceByCode)
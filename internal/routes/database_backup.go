@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"go-backend/internal/handlers"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setupDatabaseBackupRoutes 设置数据库备份相关路由
+func (r *Router) setupDatabaseBackupRoutes(rg *gin.RouterGroup) {
+	backupHandler := handlers.NewDatabaseBackupHandler()
+
+	admin := rg.Group("/admin")
+	admin.POST("/backup", backupHandler.BackupDatabase)
+	admin.GET("/backups", backupHandler.ListDatabaseBackups)
+}
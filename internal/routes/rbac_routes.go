@@ -13,6 +13,7 @@ func (r *Router) setupRBACRoutes(rg *gin.RouterGroup) {
 	permissionHandler := handlers.NewPermissionHandler()
 	scopeHandler := handlers.NewScopeHandler()
 	userRoleHandler := handlers.NewUserRoleHandler()
+	userHandler := handlers.NewUserHandler()
 
 	// RBAC API组
 	rbacGroup := rg.Group("/rbac")
@@ -29,6 +30,7 @@ func (r *Router) setupRBACRoutes(rg *gin.RouterGroup) {
 		roleGroup.PUT("/:id", roleHandler.UpdateRole)                                        // 更新角色
 		roleGroup.DELETE("/:id", roleHandler.DeleteRole)                                     // 删除角色
 		roleGroup.POST("/:id/permissions", roleHandler.AssignRolePermissions)                // 分配角色权限
+		roleGroup.PUT("/:id/permissions", roleHandler.SetRolePermissions)                    // 设置角色权限（整体替换）
 		roleGroup.DELETE("/:id/permissions/:permissionId", roleHandler.RevokeRolePermission) // 撤销角色权限
 		roleGroup.GET("/:id/assignable-permissions", roleHandler.GetAssignablePermissions)   // 获取可分配的权限
 
@@ -77,4 +79,10 @@ func (r *Router) setupRBACRoutes(rg *gin.RouterGroup) {
 		userRoleGroup.GET("/users/:userID/permissions", userRoleHandler.GetUserPermissions)                      // 获取用户的所有权限
 		userRoleGroup.GET("/users/:userID/permissions/:permissionID/check", userRoleHandler.CheckUserPermission) // 检查用户权限
 	}
+
+	// 用户账号状态路由
+	userGroup := rbacGroup.Group("/users")
+	{
+		userGroup.PATCH("/:id/status", userHandler.SetUserStatus) // 停用/启用用户账号
+	}
 }
@@ -33,6 +33,11 @@ func (r *Router) SetupRoutes(config *configs.AppConfig, engine *gin.Engine) {
 
 	middleware.RegisterConfigMiddlewares(engine)
 
+	// 未匹配的路由和请求方法使用统一的错误响应格式
+	engine.HandleMethodNotAllowed = true
+	engine.NoRoute(middleware.NoRouteHandler())
+	engine.NoMethod(middleware.NoMethodHandler(engine))
+
 	// Swagger文档路由
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 
@@ -55,6 +60,7 @@ func (r *Router) SetupRoutes(config *configs.AppConfig, engine *gin.Engine) {
 		r.setupDemoRoutes(api)
 		r.setupRBACRoutes(api)
 		r.setupLoginRecordRoutes(api)
+		r.setupDatabaseBackupRoutes(api)
 		r.setupAPIAuthRoutes(api)
 		r.setupLoggingRoutes(api)
 		r.setupClientDeviceRoutes(api)
@@ -1,7 +1,9 @@
 package routes
 
 import (
+	"go-backend/internal/funcs"
 	"go-backend/internal/handlers"
+	"go-backend/internal/middleware"
 
 	"github.com/gin-gonic/gin"
 )
@@ -11,21 +13,53 @@ func (r *Router) setupWorkflowRoutes(rg *gin.RouterGroup) {
 
 	workflowHandler := handlers.NewWorkflowHandler()
 
-	workflow := rg.Group("/workflow")
+	// 限定了授权范围的令牌需要 workflow 范围，触发执行还需要 workflow:execute 范围
+	workflow := rg.Group("/workflow", middleware.RequireScopes(funcs.TokenScopeWorkflow))
 	{
 		// WorkflowApplication 路由
 		applications := workflow.Group("/applications")
 		{
 			// 基本CRUD操作
-			applications.GET("", workflowHandler.GetWorkflowApplications)                    // 获取所有工作流应用
-			applications.GET("/page", workflowHandler.GetWorkflowApplicationsWithPagination) // 分页获取工作流应用列表
-			applications.GET("/:id", workflowHandler.GetWorkflowApplication)                 // 根据ID获取工作流应用
-			applications.POST("", workflowHandler.CreateWorkflowApplication)                 // 创建工作流应用
-			applications.PUT("/:id", workflowHandler.UpdateWorkflowApplication)              // 更新工作流应用
-			applications.DELETE("/:id", workflowHandler.DeleteWorkflowApplication)           // 删除工作流应用
+			applications.GET("", workflowHandler.GetWorkflowApplications)                                // 获取所有工作流应用
+			applications.GET("/page", workflowHandler.GetWorkflowApplicationsWithPagination)             // 分页获取工作流应用列表
+			applications.GET("/diff", workflowHandler.DiffWorkflowApplications)                          // 对比两个工作流应用
+			applications.GET("/:id", workflowHandler.GetWorkflowApplication)                             // 根据ID获取工作流应用
+			applications.POST("/batch-status", workflowHandler.BatchTransitionWorkflowApplicationStatus) // 批量修改应用状态
+			applications.POST("", middleware.Idempotency(), workflowHandler.CreateWorkflowApplication)   // 创建工作流应用
+			applications.PUT("/:id", workflowHandler.UpdateWorkflowApplication)                          // 更新工作流应用
+			applications.PATCH("/:id/variables", workflowHandler.PatchWorkflowApplicationVariables)      // 合并更新应用变量
+			applications.PATCH("/:id/viewport", workflowHandler.UpdateWorkflowApplicationViewport)       // 只更新画布视口
+			applications.DELETE("/:id", workflowHandler.DeleteWorkflowApplication)                       // 删除工作流应用
 
 			// 特殊操作
-			applications.POST("/:id/clone", workflowHandler.CloneWorkflowApplication) // 克隆工作流应用
+			applications.POST("/:id/clone", workflowHandler.CloneWorkflowApplication)                                                                                          // 克隆工作流应用
+			applications.POST("/:id/share", workflowHandler.ShareWorkflowApplication)                                                                                          // 共享工作流应用
+			applications.DELETE("/:id/share/:userId", workflowHandler.UnshareWorkflowApplication)                                                                              // 取消共享
+			applications.POST("/:id/duplicate-subgraph", workflowHandler.DuplicateSubgraph)                                                                                    // 复制节点子图
+			applications.POST("/:id/auto-layout", workflowHandler.AutoLayoutWorkflow)                                                                                          // 自动布局节点
+			applications.POST("/:id/versions/prune", workflowHandler.PruneWorkflowVersions)                                                                                    // 清理历史版本
+			applications.GET("/:id/execution-order", workflowHandler.GetWorkflowExecutionOrder)                                                                                // 获取拓扑执行顺序
+			applications.POST("/:id/execute", middleware.RequireScopes(funcs.TokenScopeWorkflowExecute), middleware.Idempotency(), workflowHandler.ExecuteWorkflowApplication) // 校验输入并创建执行
+			applications.POST("/:id/estimate", workflowHandler.EstimateWorkflowCost)                                                                                           // 预估执行成本
+			applications.GET("/:id/validate", workflowHandler.ValidateWorkflowApplication)                                                                                     // 校验工作流图结构
+			applications.GET("/:id/diagram", workflowHandler.GetWorkflowDiagram)                                                                                               // 导出 Mermaid/DOT 图
+			applications.POST("/:id/edges/dedupe", workflowHandler.DedupeWorkflowEdges)                                                                                        // 清理重复边
+
+			// 定时调度
+			applications.POST("/:id/schedules", workflowHandler.CreateWorkflowSchedule) // 创建定时调度
+			applications.GET("/:id/schedules", workflowHandler.GetWorkflowSchedules)    // 获取应用的定时调度列表
+
+			// 应用密钥
+			applications.GET("/:id/secrets", workflowHandler.GetWorkflowSecrets)           // 获取应用密钥列表（不含值）
+			applications.PUT("/:id/secrets/:key", workflowHandler.SetWorkflowSecret)       // 设置应用密钥
+			applications.DELETE("/:id/secrets/:key", workflowHandler.DeleteWorkflowSecret) // 删除应用密钥
+		}
+
+		// 模板路由
+		templates := workflow.Group("/templates")
+		{
+			templates.GET("", workflowHandler.GetWorkflowTemplates)                         // 获取模板列表
+			templates.POST("/:id/instantiate", workflowHandler.InstantiateWorkflowTemplate) // 从模板创建应用
 		}
 
 		// WorkflowNode 路由
@@ -33,11 +67,20 @@ func (r *Router) setupWorkflowRoutes(rg *gin.RouterGroup) {
 		{
 			// 基本CRUD操作
 			nodes.GET("", workflowHandler.GetWorkflowNodes)                               // 获取所有工作流节点
+			nodes.GET("/page", workflowHandler.GetWorkflowNodesWithPagination)            // 分页获取工作流节点列表
 			nodes.GET("/by-application", workflowHandler.GetWorkflowNodesByApplicationID) // 根据应用ID获取节点
 			nodes.GET("/:id", workflowHandler.GetWorkflowNode)                            // 根据ID获取工作流节点
 			nodes.POST("", workflowHandler.CreateWorkflowNode)                            // 创建工作流节点
 			nodes.PUT("/:id", workflowHandler.UpdateWorkflowNode)                         // 更新工作流节点
+			nodes.PATCH("/:id/config", workflowHandler.PatchWorkflowNodeConfig)           // 合并更新节点配置
+			nodes.PATCH("/:id/enabled", workflowHandler.SetWorkflowNodeEnabled)           // 启用/禁用节点
+			nodes.PATCH("/:id/application", workflowHandler.MoveWorkflowNode)             // 将节点移动到其他应用
 			nodes.DELETE("/:id", workflowHandler.DeleteWorkflowNode)                      // 删除工作流节点
+			nodes.GET("/:id/connections", workflowHandler.GetNodeConnections)             // 获取节点的所有连接信息
+			nodes.POST("/:id/test", workflowHandler.TestWorkflowNode)                     // 单节点试运行
+
+			// 批量操作
+			nodes.POST("/batch-delete", workflowHandler.BatchDeleteWorkflowNodes) // 批量删除工作流节点
 		}
 
 		// WorkflowEdge 路由
@@ -45,10 +88,13 @@ func (r *Router) setupWorkflowRoutes(rg *gin.RouterGroup) {
 		{
 			// 基本CRUD操作
 			edges.GET("", workflowHandler.GetAllWorkflowEdges)                            // 获取所有工作流边
+			edges.GET("/page", workflowHandler.GetWorkflowEdgesWithPagination)            // 分页获取工作流边列表
+			edges.GET("/search", workflowHandler.SearchWorkflowEdges)                     // 分页搜索工作流边
 			edges.GET("/by-application", workflowHandler.GetWorkflowEdgesByApplicationID) // 根据应用ID获取边
 			edges.GET("/:id", workflowHandler.GetWorkflowEdge)                            // 根据ID获取工作流边
 			edges.POST("", workflowHandler.CreateWorkflowEdge)                            // 创建工作流边
 			edges.PUT("/:id", workflowHandler.UpdateWorkflowEdge)                         // 更新工作流边
+			edges.PATCH("/:id/reconnect", workflowHandler.ReconnectWorkflowEdge)          // 移动工作流边端点
 			edges.DELETE("/:id", workflowHandler.DeleteWorkflowEdge)                      // 删除工作流边
 
 			// 批量操作
@@ -62,10 +108,38 @@ func (r *Router) setupWorkflowRoutes(rg *gin.RouterGroup) {
 			versions.POST("", workflowHandler.CreateWorkflowVersion)                            // 创建版本快照
 			versions.GET("/by-application", workflowHandler.GetWorkflowVersionsByApplicationID) // 根据应用ID获取版本列表
 			versions.GET("/:id", workflowHandler.GetWorkflowVersion)                            // 获取单个版本
+			versions.PUT("/:id/pinned", workflowHandler.SetWorkflowVersionPinned)               // 设置版本置顶状态
+		}
+
+		// WorkflowExecution 路由
+		executions := workflow.Group("/executions")
+		{
+			executions.GET("/queue", workflowHandler.GetExecutionQueueStats)           // 获取执行队列状态
+			executions.GET("/:executionId/report", workflowHandler.GetExecutionReport) // 导出执行报告
+			executions.GET("/:executionId/path", workflowHandler.GetExecutionPath)     // 获取执行路径
+			executions.GET("/:executionId/input", workflowHandler.GetExecutionInput)   // 获取执行输入（脱敏，用于预填）
 		}
 
+		// WorkflowNodeExecution 路由
+		nodeExecutions := workflow.Group("/node-executions")
+		{
+			nodeExecutions.GET("/:id", workflowHandler.GetNodeExecution) // 获取节点执行详情（含日志）
+		}
+
+		// WorkflowSchedule 路由
+		schedules := workflow.Group("/schedules")
+		{
+			schedules.PUT("/:id", workflowHandler.UpdateWorkflowSchedule)         // 更新定时调度
+			schedules.DELETE("/:id", workflowHandler.DeleteWorkflowSchedule)      // 删除定时调度
+			schedules.POST("/:id/pause", workflowHandler.PauseWorkflowSchedule)   // 暂停定时调度
+			schedules.POST("/:id/resume", workflowHandler.ResumeWorkflowSchedule) // 恢复定时调度
+		}
+
+		// 节点类型目录
+		workflow.GET("/node-types", workflowHandler.GetNodeTypeCatalog) // 获取节点类型目录
+
 		// 批量保存路由
-		workflow.POST("/batch-save", workflowHandler.BatchSaveWorkflow) // 批量保存工作流
+		workflow.POST("/batch-save", middleware.Idempotency(), workflowHandler.BatchSaveWorkflow) // 批量保存工作流
 
 		// // Workflow Graph 操作路由
 		// graph := workflow.Group("/graph")
@@ -83,13 +157,9 @@ func (r *Router) setupWorkflowRoutes(rg *gin.RouterGroup) {
 		// 	graph.POST("/remove-from-parallel", workflowHandler.RemoveNodeFromParallel) // 从并行执行中移除节点
 		// 	graph.GET("/parallel-children", workflowHandler.GetParallelChildren)        // 获取并行节点的子节点
 
-		// 	// 节点信息查询
-		// 	graph.GET("/connections", workflowHandler.GetNodeConnections) // 获取节点的所有连接信息
-
 		// 	// 位置和批量操作
 		// 	graph.PUT("/position", workflowHandler.UpdateNodePosition)        // 更新节点位置
 		// 	graph.PUT("/positions", workflowHandler.BatchUpdateNodePositions) // 批量更新节点位置
-		// 	graph.POST("/batch-delete", workflowHandler.BatchDeleteNodes)     // 批量删除节点
 		// }
 	}
 }
@@ -0,0 +1,223 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCircuitOpen 熔断器处于打开状态，命令未发送到Redis
+var ErrCircuitOpen = errors.New("redis circuit breaker is open")
+
+// 熔断器默认参数
+const (
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerCooldown         = 30 * time.Second
+)
+
+// BreakerState 熔断器状态
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 正常放行
+	BreakerOpen                         // 熔断中，所有命令直接失败
+	BreakerHalfOpen                     // 冷却结束，放行一个探测命令
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker Redis熔断器
+// 连续失败达到阈值后打开，冷却期内所有命令直接返回 ErrCircuitOpen，调用方应回退到数据源（数据库）；
+// 冷却期结束后放行一个探测命令，成功则关闭，失败则重新打开
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	state     BreakerState
+	failures  int
+	threshold int
+	cooldown  time.Duration
+	openedAt  time.Time
+	probing   bool
+	now       func() time.Time
+}
+
+// NewCircuitBreaker 创建熔断器，参数无效时使用默认值
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	b := &CircuitBreaker{now: time.Now}
+	b.Configure(threshold, cooldown)
+	return b
+}
+
+// Configure 更新熔断参数，参数无效时使用默认值
+func (b *CircuitBreaker) Configure(threshold int, cooldown time.Duration) {
+	if threshold <= 0 {
+		threshold = defaultBreakerFailureThreshold
+	}
+	if cooldown <= 0 {
+		cooldown = defaultBreakerCooldown
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.threshold = threshold
+	b.cooldown = cooldown
+}
+
+// State 返回熔断器当前状态
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Healthy 熔断器未打开时返回true
+func (b *CircuitBreaker) Healthy() bool {
+	return b.State() != BreakerOpen
+}
+
+// Allow 判断是否放行命令，熔断中返回 ErrCircuitOpen
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		// 探测命令尚未返回时，其余命令继续失败
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	}
+	return nil
+}
+
+// Record 记录命令结果，redis.Nil、服务端返回的错误（如WRONGTYPE）和主动取消不视为故障
+func (b *CircuitBreaker) Record(err error) {
+	failed := isBreakerFailure(err)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.state = BreakerClosed
+			logBreakerf(false, "Redis circuit breaker closed, cache re-enabled")
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+	if b.state == BreakerClosed {
+		b.failures++
+		if b.failures >= b.threshold {
+			b.open()
+		}
+	}
+}
+
+// open 打开熔断器，调用方需持有锁
+func (b *CircuitBreaker) open() {
+	b.state = BreakerOpen
+	b.openedAt = b.now()
+	b.failures = 0
+	logBreakerf(true, "Redis circuit breaker opened, cache disabled for %s", b.cooldown)
+}
+
+// isBreakerFailure 判断错误是否表示Redis不可用
+func isBreakerFailure(err error) bool {
+	if err == nil || errors.Is(err, redis.Nil) || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var redisErr redis.Error
+	return !errors.As(err, &redisErr)
+}
+
+func logBreakerf(isError bool, format string, args ...any) {
+	if logger == nil {
+		return
+	}
+	if isError {
+		logger.Error(format, args...)
+	} else {
+		logger.Info(format, args...)
+	}
+}
+
+// Hook 返回在命令执行前后经过熔断器的 go-redis 钩子
+func (b *CircuitBreaker) Hook() redis.Hook {
+	return breakerHook{breaker: b}
+}
+
+// breakerHook go-redis 钩子实现
+type breakerHook struct {
+	breaker *CircuitBreaker
+}
+
+func (h breakerHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (h breakerHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		err := next(ctx, cmd)
+		h.breaker.Record(err)
+		return err
+	}
+}
+
+func (h breakerHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if err := h.breaker.Allow(); err != nil {
+			for _, cmd := range cmds {
+				cmd.SetErr(err)
+			}
+			return err
+		}
+		err := next(ctx, cmds)
+		h.breaker.Record(err)
+		return err
+	}
+}
+
+// breaker 全局Redis客户端使用的熔断器
+var breaker = NewCircuitBreaker(defaultBreakerFailureThreshold, defaultBreakerCooldown)
+
+// Healthy Redis客户端已初始化且熔断器未打开时返回true，为false时调用方应直接使用数据源
+func Healthy() bool {
+	return GetInstanceUnsafe() != nil && breaker.Healthy()
+}
+
+// IsUnavailable 判断错误是否表示缓存不可用（熔断或连接故障），调用方应按未命中处理
+func IsUnavailable(err error) bool {
+	return errors.Is(err, ErrCircuitOpen) || isBreakerFailure(err)
+}
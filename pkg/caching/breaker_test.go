@@ -0,0 +1,108 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCircuitBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	b := NewCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	failure := errors.New("dial tcp: connection refused")
+	b.Record(failure)
+	if !b.Healthy() {
+		t.Fatal("breaker should stay closed below threshold")
+	}
+	b.Record(failure)
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen during cooldown, got %v", err)
+	}
+
+	// 冷却结束后只放行一个探测命令，探测失败重新打开
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected concurrent commands to be rejected while probing, got %v", err)
+	}
+	b.Record(failure)
+	if b.State() != BreakerOpen {
+		t.Fatalf("failed probe should reopen breaker, got %s", b.State())
+	}
+
+	// 再次冷却后探测成功，熔断器关闭
+	now = now.Add(time.Minute)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("expected probe to be allowed, got %v", err)
+	}
+	b.Record(nil)
+	if b.State() != BreakerClosed {
+		t.Fatalf("successful probe should close breaker, got %s", b.State())
+	}
+}
+
+func TestCircuitBreakerIgnoresNonFailures(t *testing.T) {
+	b := NewCircuitBreaker(1, time.Minute)
+	b.Record(redis.Nil)
+	b.Record(context.Canceled)
+	if !b.Healthy() {
+		t.Fatal("redis.Nil and cancellation must not open the breaker")
+	}
+}
+
+func TestGetOrSetDegradesWhenRedisFails(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	b := NewCircuitBreaker(1, time.Hour)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	client.AddHook(b.Hook())
+	defer client.Close()
+
+	ctx := context.Background()
+	loads := 0
+	load := func(ctx context.Context) ([]uint64, error) {
+		loads++
+		return []uint64{1, 2}, nil
+	}
+
+	// 正常情况：第一次加载并写回，第二次命中缓存
+	for i := 0; i < 2; i++ {
+		got, err := getOrSetWithClient(ctx, client, nil, "perms:1", time.Minute, load)
+		if err != nil || len(got) != 2 {
+			t.Fatalf("unexpected result: %v, %v", got, err)
+		}
+	}
+	if loads != 1 {
+		t.Fatalf("expected one load before failure, got %d", loads)
+	}
+
+	// Redis宕机：熔断打开后按未命中处理，直接回退到数据源
+	mr.Close()
+	for i := 0; i < 3; i++ {
+		got, err := getOrSetWithClient(ctx, client, nil, "perms:1", time.Minute, load)
+		if err != nil || len(got) != 2 {
+			t.Fatalf("expected fallback to source, got %v, %v", got, err)
+		}
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected breaker to open after failures, got %s", b.State())
+	}
+	if loads != 4 {
+		t.Fatalf("expected every call to hit the source while redis is down, got %d loads", loads)
+	}
+}
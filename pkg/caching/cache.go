@@ -2,12 +2,14 @@ package caching
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"sync"
 	"time"
 
 	"go-backend/pkg/configs"
 	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -59,11 +61,19 @@ func NewClient(config *configs.RedisConfig) (*redis.Client, error) {
 		ConnMaxIdleTime: time.Duration(config.IdleTimeout) * time.Second,
 	})
 
-	// 测试连接
-	ctx := context.Background()
-	_, err := rdb.Ping(ctx).Result()
+	// 测试连接，Redis 尚未就绪时按配置的退避策略重试
+	retry := config.ConnectRetry
+	attempts := max(retry.Attempts, 1)
+	err := utils.RetryWithBackoff(context.Background(), attempts, retry.Interval, retry.MaxInterval,
+		func(ctx context.Context) error {
+			return rdb.Ping(ctx).Err()
+		},
+		func(attempt int, wait time.Duration, err error) {
+			logging.Warn("Redis is not reachable (attempt %d/%d): %v, retrying in %s", attempt, attempts, err, wait)
+		})
 	if err != nil {
-		return nil, err
+		rdb.Close()
+		return nil, fmt.Errorf("failed to connect to Redis at %s after %d attempts: %w", config.Addr, attempts, err)
 	}
 
 	if logger != nil {
@@ -91,7 +101,14 @@ func MustNewClient(config *configs.RedisConfig) *redis.Client {
 // InitInstance 初始化Redis客户端单例实例（只执行一次）
 func InitInstance(config *configs.RedisConfig) *redis.Client {
 	once.Do(func() {
+		SetKeyPrefix(config.KeyPrefix)
+		configureCodec(config.Codec)
+		breaker.Configure(config.CircuitBreaker.FailureThreshold, time.Duration(config.CircuitBreaker.Cooldown)*time.Millisecond)
 		Client = MustNewClient(config)
+		if Client != nil {
+			Client.AddHook(breaker.Hook())
+		}
+		configureL1(&config.L1, Client)
 	})
 	return Client
 }
@@ -110,6 +127,7 @@ func CloseInstance() error {
 	defer mu.Unlock()
 
 	if Client != nil {
+		configureL1(&configs.RedisL1CacheConfig{}, nil)
 		err := Client.Close()
 		Client = nil
 		// 重置 once，允许重新初始化
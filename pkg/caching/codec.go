@@ -0,0 +1,94 @@
+package caching
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// 类型化缓存的序列化格式：JSON 兼容性最好，msgpack 更紧凑，适合权限集合、图快照等较大的热点值。
+// msgpack 编码的值以 msgpackMarker 开头，JSON 值不带标记（与启用该选项前写入的缓存保持一致），
+// 读取时按标记选择解码方式，因此切换格式或多实例配置不一致时不会误解码
+
+// Codec 缓存值的序列化格式
+type Codec string
+
+const (
+	CodecJSON    Codec = "json"    // JSON（默认）
+	CodecMsgpack Codec = "msgpack" // MessagePack
+)
+
+// msgpackMarker msgpack 编码值的前缀，JSON 文本不会以该字节序列开头
+var msgpackMarker = []byte("mp:")
+
+// codec 当前写入使用的格式
+var codec atomic.Value
+
+func init() {
+	codec.Store(CodecJSON)
+}
+
+// ParseCodec 解析配置中的格式名称，空字符串视为 json
+func ParseCodec(name string) (Codec, error) {
+	switch Codec(strings.ToLower(strings.TrimSpace(name))) {
+	case "", CodecJSON:
+		return CodecJSON, nil
+	case CodecMsgpack:
+		return CodecMsgpack, nil
+	}
+	return "", fmt.Errorf("unsupported cache codec %q", name)
+}
+
+// SetCodec 设置写入缓存时使用的格式
+func SetCodec(c Codec) {
+	codec.Store(c)
+}
+
+// GetCodec 获取写入缓存时使用的格式
+func GetCodec() Codec {
+	return codec.Load().(Codec)
+}
+
+// configureCodec 根据配置设置写入格式，配置无效时使用 json
+func configureCodec(name string) {
+	c, err := ParseCodec(name)
+	if err != nil {
+		if logger != nil {
+			logger.Error("%v, falling back to %s", err, CodecJSON)
+		}
+		c = CodecJSON
+	}
+	SetCodec(c)
+}
+
+// encodeValue 按指定格式编码缓存值
+func encodeValue(c Codec, value any) ([]byte, error) {
+	if c != CodecMsgpack {
+		return json.Marshal(value)
+	}
+
+	var buf bytes.Buffer
+	buf.Write(msgpackMarker)
+	enc := msgpack.NewEncoder(&buf)
+	// 沿用 json 标签，保证两种格式的字段名和 omitempty 行为一致
+	enc.SetCustomStructTag("json")
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue 按值的格式标记解码，不带标记的值按 JSON 解码
+func decodeValue(data []byte, dest any) error {
+	if !bytes.HasPrefix(data, msgpackMarker) {
+		return json.Unmarshal(data, dest)
+	}
+
+	dec := msgpack.NewDecoder(bytes.NewReader(data[len(msgpackMarker):]))
+	dec.SetCustomStructTag("json")
+	return dec.Decode(dest)
+}
@@ -0,0 +1,161 @@
+package caching
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+)
+
+// benchmarkNode 与工作流图快照中节点结构相近的测试数据
+type benchmarkNode struct {
+	ID       uint64                 `json:"id"`
+	Name     string                 `json:"name"`
+	NodeKey  string                 `json:"nodeKey"`
+	Type     string                 `json:"type"`
+	Prompt   string                 `json:"prompt,omitempty"`
+	Config   map[string]interface{} `json:"config,omitempty"`
+	PosX     float64                `json:"positionX"`
+	PosY     float64                `json:"positionY"`
+	Parallel bool                   `json:"parallel"`
+}
+
+// benchmarkPayload 代表性的大缓存值：用户权限集合加一个中等规模的工作流图
+type benchmarkPayload struct {
+	Permissions []string         `json:"permissions"`
+	RoleIDs     []uint64         `json:"roleIds"`
+	Nodes       []*benchmarkNode `json:"nodes"`
+}
+
+func newBenchmarkPayload() *benchmarkPayload {
+	payload := &benchmarkPayload{}
+	for i := 0; i < 300; i++ {
+		payload.Permissions = append(payload.Permissions, fmt.Sprintf("module%d:resource%d:action%d", i%12, i%40, i%5))
+	}
+	for i := 0; i < 20; i++ {
+		payload.RoleIDs = append(payload.RoleIDs, uint64(1000000000000+i))
+	}
+	for i := 0; i < 100; i++ {
+		payload.Nodes = append(payload.Nodes, &benchmarkNode{
+			ID:      uint64(2000000000000 + i),
+			Name:    fmt.Sprintf("node-%d", i),
+			NodeKey: fmt.Sprintf("node_%d", i),
+			Type:    "llm_caller",
+			Prompt:  "Summarize the following input: {{input.text}}",
+			Config:  map[string]interface{}{"model": "gpt-4o", "temperature": 0.7, "max_tokens": 1024},
+			PosX:    float64(i * 120),
+			PosY:    float64(i * 80),
+		})
+	}
+	return payload
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	want := newBenchmarkPayload()
+	for _, c := range []Codec{CodecJSON, CodecMsgpack} {
+		data, err := encodeValue(c, want)
+		if err != nil {
+			t.Fatalf("%s encode failed: %v", c, err)
+		}
+		var got benchmarkPayload
+		if err := decodeValue(data, &got); err != nil {
+			t.Fatalf("%s decode failed: %v", c, err)
+		}
+		if !reflect.DeepEqual(got.Permissions, want.Permissions) || !reflect.DeepEqual(got.RoleIDs, want.RoleIDs) ||
+			len(got.Nodes) != len(want.Nodes) || got.Nodes[5].NodeKey != want.Nodes[5].NodeKey || got.Nodes[5].PosX != want.Nodes[5].PosX {
+			t.Fatalf("%s round trip mismatch", c)
+		}
+	}
+
+	jsonData, _ := encodeValue(CodecJSON, want)
+	msgpackData, _ := encodeValue(CodecMsgpack, want)
+	if len(msgpackData) >= len(jsonData) {
+		t.Fatalf("msgpack should be more compact: %d >= %d bytes", len(msgpackData), len(jsonData))
+	}
+
+	if _, err := ParseCodec("xml"); err == nil {
+		t.Fatal("unknown codec should be rejected")
+	}
+	if c, err := ParseCodec(" MsgPack "); err != nil || c != CodecMsgpack {
+		t.Fatalf("codec names should be case-insensitive, got %q, %v", c, err)
+	}
+}
+
+func TestGetOrSetReadsMixedCodecs(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+	previous := GetCodec()
+	t.Cleanup(func() { SetCodec(previous) })
+
+	ctx := context.Background()
+	loads := 0
+	load := func(ctx context.Context) ([]string, error) {
+		loads++
+		return []string{"user:read", "user:write"}, nil
+	}
+
+	// 以 json 写入，切换为 msgpack 后仍能读取；反之亦然
+	SetCodec(CodecJSON)
+	if _, err := getOrSetWithClient(ctx, client, nil, "perms:json", time.Minute, load); err != nil {
+		t.Fatalf("json write failed: %v", err)
+	}
+	SetCodec(CodecMsgpack)
+	if _, err := getOrSetWithClient(ctx, client, newLocalCache(10, time.Minute), "perms:msgpack", time.Minute, load); err != nil {
+		t.Fatalf("msgpack write failed: %v", err)
+	}
+	if raw, _ := mr.Get("perms:msgpack"); raw[:len(msgpackMarker)] != string(msgpackMarker) {
+		t.Fatalf("msgpack value should carry the codec marker, got %q", raw)
+	}
+
+	for _, c := range []Codec{CodecMsgpack, CodecJSON} {
+		SetCodec(c)
+		for _, key := range []string{"perms:json", "perms:msgpack"} {
+			got, err := getOrSetWithClient(ctx, client, nil, key, time.Minute, load)
+			if err != nil || len(got) != 2 || got[1] != "user:write" {
+				t.Fatalf("reading %s with codec %s failed: %v, %v", key, c, got, err)
+			}
+		}
+	}
+	if loads != 2 {
+		t.Fatalf("cached values should be read regardless of codec, got %d loads", loads)
+	}
+}
+
+func BenchmarkCodec(b *testing.B) {
+	payload := newBenchmarkPayload()
+	for _, c := range []Codec{CodecJSON, CodecMsgpack} {
+		data, err := encodeValue(c, payload)
+		if err != nil {
+			b.Fatalf("%s encode failed: %v", c, err)
+		}
+
+		b.Run(string(c)+"/encode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(len(data)), "bytes/value")
+			for i := 0; i < b.N; i++ {
+				if _, err := encodeValue(c, payload); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(string(c)+"/decode", func(b *testing.B) {
+			b.ReportAllocs()
+			b.ReportMetric(float64(len(data)), "bytes/value")
+			for i := 0; i < b.N; i++ {
+				var decoded benchmarkPayload
+				if err := decodeValue(data, &decoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package caching
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// GetOrSet 读取缓存，未命中时调用 load 从数据源加载并按配置的格式（json/msgpack）写回缓存
+// 启用一级缓存时先查本地，Redis命中或写回成功后同时写入本地；本地条目的有效期不超过一级缓存TTL。
+// Redis未初始化、熔断器打开或读写失败时都按未命中处理，直接返回 load 的结果，不会因缓存故障返回错误
+func GetOrSet[T any](ctx context.Context, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	client := GetInstanceUnsafe()
+	if client == nil {
+		return load(ctx)
+	}
+	return getOrSetWithClient(ctx, client, l1.Load(), key, ttl, load)
+}
+
+// getOrSetWithClient 使用指定客户端和一级缓存执行 GetOrSet，local 为 nil 表示不使用一级缓存
+func getOrSetWithClient[T any](ctx context.Context, client redis.Cmdable, local *localCache, key string, ttl time.Duration, load func(ctx context.Context) (T, error)) (T, error) {
+	if data, ok := local.get(key); ok {
+		var cached T
+		if err := decodeValue(data, &cached); err == nil {
+			return cached, nil
+		}
+	}
+
+	data, getErr := client.Get(ctx, key).Bytes()
+	if getErr == nil {
+		var cached T
+		if err := decodeValue(data, &cached); err == nil {
+			local.set(key, data, ttl)
+			return cached, nil
+		}
+	}
+
+	value, err := load(ctx)
+	if err != nil {
+		return value, err
+	}
+
+	// 熔断中不再尝试写回，避免无意义的等待；此时也不写一级缓存，因为失效通知同样无法送达
+	if errors.Is(getErr, ErrCircuitOpen) {
+		return value, nil
+	}
+
+	encoded, err := encodeValue(GetCodec(), value)
+	if err != nil {
+		return value, nil
+	}
+	if err := client.Set(ctx, key, encoded, ttl).Err(); err != nil {
+		if logger != nil && !IsUnavailable(err) {
+			logger.Error("failed to write cache %s: %v", key, err)
+		}
+		return value, nil
+	}
+	local.set(key, encoded, ttl)
+	return value, nil
+}
@@ -0,0 +1,135 @@
+package caching
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// KeySeparator 缓存键各段之间的分隔符
+const KeySeparator = ":"
+
+// invalidateScanCount 每次SCAN建议返回的键数量，同时也是每批删除的规模
+const invalidateScanCount = 500
+
+// 默认的应用前缀
+const defaultKeyPrefix = "qc"
+
+// keyPrefix 应用前缀，由配置的 redis.key_prefix 决定
+var keyPrefix atomic.Value
+
+func init() {
+	keyPrefix.Store(defaultKeyPrefix)
+}
+
+// SetKeyPrefix 设置缓存键的应用前缀，为空时使用默认前缀
+func SetKeyPrefix(prefix string) {
+	prefix = strings.Trim(prefix, KeySeparator)
+	if prefix == "" {
+		prefix = defaultKeyPrefix
+	}
+	keyPrefix.Store(prefix)
+}
+
+// GetKeyPrefix 获取缓存键的应用前缀
+func GetKeyPrefix() string {
+	return keyPrefix.Load().(string)
+}
+
+// 各组件的键构建器
+var (
+	RBACKeys     = NewKeyBuilder("rbac")
+	WorkflowKeys = NewKeyBuilder("workflow")
+)
+
+// KeyBuilder 带命名空间的缓存键构建器
+// 生成的键格式为 {应用前缀}:{组件}:{段1}:{段2}...，例如 qc:rbac:perms:1
+type KeyBuilder struct {
+	component string
+}
+
+// NewKeyBuilder 创建指定组件的键构建器
+func NewKeyBuilder(component string) KeyBuilder {
+	return KeyBuilder{component: strings.Trim(component, KeySeparator)}
+}
+
+// Key 构建完整的缓存键
+func (b KeyBuilder) Key(parts ...any) string {
+	segments := make([]string, 0, len(parts)+2)
+	segments = append(segments, GetKeyPrefix(), b.component)
+	for _, part := range parts {
+		segments = append(segments, fmt.Sprint(part))
+	}
+	return strings.Join(segments, KeySeparator)
+}
+
+// Prefix 构建键族的前缀（以分隔符结尾），用于 InvalidatePrefix
+// 例如 RBACKeys.Prefix("perms") 返回 qc:rbac:perms:
+func (b KeyBuilder) Prefix(parts ...any) string {
+	return b.Key(parts...) + KeySeparator
+}
+
+// InvalidatePrefix 删除所有以 prefix 开头的键，同时删除本实例及其他实例一级缓存中的条目
+func InvalidatePrefix(ctx context.Context, prefix string) error {
+	if prefix != "" {
+		l1.Load().deletePrefix(prefix)
+	}
+	if Client == nil {
+		return nil
+	}
+	_, err := InvalidatePrefixWithClient(ctx, Client, prefix)
+	if prefix != "" {
+		publishInvalidation(ctx, Client, invalidationMessage{Prefix: prefix})
+	}
+	return err
+}
+
+// InvalidatePrefixWithClient 使用指定的客户端删除所有以 prefix 开头的键，返回删除的数量
+// 使用 SCAN 而非 KEYS 遍历键空间：KEYS 会一次性阻塞 Redis 直到遍历完所有键，
+// 在生产环境的大键空间下可能导致服务停顿；SCAN 按游标分批返回，每批删除后再继续
+func InvalidatePrefixWithClient(ctx context.Context, client redis.Cmdable, prefix string) (int64, error) {
+	if prefix == "" {
+		return 0, fmt.Errorf("prefix must not be empty")
+	}
+
+	pattern := escapeKeyPattern(prefix) + "*"
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, next, err := client.Scan(ctx, cursor, pattern, invalidateScanCount).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan keys with prefix %s: %w", prefix, err)
+		}
+
+		if len(keys) > 0 {
+			n, err := client.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, fmt.Errorf("failed to delete keys with prefix %s: %w", prefix, err)
+			}
+			deleted += n
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// escapeKeyPattern 转义 SCAN MATCH 中的通配字符，使前缀按字面匹配
+func escapeKeyPattern(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,64 @@
+package caching
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestKeyBuilder(t *testing.T) {
+	defer SetKeyPrefix("")
+
+	if got := RBACKeys.Key("perms", 1); got != "qc:rbac:perms:1" {
+		t.Fatalf("unexpected key: %s", got)
+	}
+
+	SetKeyPrefix("app:")
+	if got := WorkflowKeys.Prefix("schedule"); got != "app:workflow:schedule:" {
+		t.Fatalf("unexpected prefix: %s", got)
+	}
+}
+
+func TestInvalidatePrefix(t *testing.T) {
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start fake redis: %v", err)
+	}
+	defer server.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	keys := NewKeyBuilder("test")
+	for i := 0; i < 1200; i++ {
+		if err := client.Set(ctx, keys.Key("perms", i), i, 0).Err(); err != nil {
+			t.Fatalf("failed to set key: %v", err)
+		}
+	}
+	other := []string{keys.Key("roles", 1), keys.Key("permsx", 1), "qc:test:perms*"}
+	for _, key := range other {
+		if err := client.Set(ctx, key, 1, 0).Err(); err != nil {
+			t.Fatalf("failed to set key: %v", err)
+		}
+	}
+
+	deleted, err := InvalidatePrefixWithClient(ctx, client, keys.Prefix("perms"))
+	if err != nil {
+		t.Fatalf("InvalidatePrefixWithClient failed: %v", err)
+	}
+	if deleted != 1200 {
+		t.Fatalf("expected 1200 deleted keys, got %d", deleted)
+	}
+
+	for _, key := range other {
+		if !server.Exists(key) {
+			t.Fatalf("key %s should not be deleted", key)
+		}
+	}
+	if server.Exists(keys.Key("perms", 0)) {
+		t.Fatalf("key %s should be deleted", keys.Key("perms", 0))
+	}
+}
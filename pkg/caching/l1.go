@@ -0,0 +1,262 @@
+package caching
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-backend/pkg/configs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 进程内一级缓存（L1）：GetOrSet 先查本地再查Redis，热点键（如RBAC权限）无需每次访问Redis。
+// 键通过 Invalidate/InvalidatePrefix 变更时，先删除本地条目，再通过Redis发布/订阅通知其他实例删除；
+// 通知丢失（订阅连接断开、Redis熔断）时其他实例最多在 L1 TTL 内读到旧值，这是一级缓存的一致性窗口。
+
+// 一级缓存默认参数
+const (
+	defaultL1Size = 1000
+	defaultL1TTL  = 2 * time.Second
+)
+
+// invalidationChannelSuffix 失效通知频道名称（前缀之后的部分）
+const invalidationChannelSuffix = "cache:invalidate"
+
+// localCache 带过期时间的LRU缓存，保存编码后的值（与Redis中的格式相同），避免调用方共享可变对象
+type localCache struct {
+	mu    sync.Mutex
+	size  int
+	ttl   time.Duration
+	items map[string]*list.Element
+	order *list.List // 表头为最近使用的条目
+	now   func() time.Time
+}
+
+// localEntry 一级缓存条目
+type localEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+// newLocalCache 创建一级缓存，参数无效时使用默认值
+func newLocalCache(size int, ttl time.Duration) *localCache {
+	if size <= 0 {
+		size = defaultL1Size
+	}
+	if ttl <= 0 {
+		ttl = defaultL1TTL
+	}
+	return &localCache{
+		size:  size,
+		ttl:   ttl,
+		items: make(map[string]*list.Element),
+		order: list.New(),
+		now:   time.Now,
+	}
+}
+
+// get 读取未过期的条目，c 为 nil 时视为未命中
+func (c *localCache) get(key string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*localEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return entry.data, true
+}
+
+// set 写入条目，有效期取一级缓存TTL与 ttl 中较小者
+func (c *localCache) set(key string, data []byte, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+	if ttl <= 0 || ttl > c.ttl {
+		ttl = c.ttl
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, ok := c.items[key]; ok {
+		entry := element.Value.(*localEntry)
+		entry.data = data
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&localEntry{key: key, data: data, expiresAt: expiresAt})
+	for c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+	}
+}
+
+// delete 删除指定的键
+func (c *localCache) delete(keys ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		if element, ok := c.items[key]; ok {
+			c.removeElement(element)
+		}
+	}
+}
+
+// deletePrefix 删除所有以 prefix 开头的键
+func (c *localCache) deletePrefix(prefix string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, element := range c.items {
+		if strings.HasPrefix(key, prefix) {
+			c.removeElement(element)
+		}
+	}
+}
+
+// len 返回当前条目数量（包含尚未清理的过期条目）
+func (c *localCache) len() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// removeElement 删除条目，调用方需持有锁
+func (c *localCache) removeElement(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.items, element.Value.(*localEntry).key)
+}
+
+// invalidationMessage 失效通知，Keys 和 Prefix 至少有一个非空
+type invalidationMessage struct {
+	Keys   []string `json:"keys,omitempty"`
+	Prefix string   `json:"prefix,omitempty"`
+}
+
+// apply 按失效通知删除本地条目
+func (c *localCache) apply(payload string) {
+	var message invalidationMessage
+	if err := json.Unmarshal([]byte(payload), &message); err != nil {
+		if logger != nil {
+			logger.Error("invalid cache invalidation message %q: %v", payload, err)
+		}
+		return
+	}
+	c.delete(message.Keys...)
+	if message.Prefix != "" {
+		c.deletePrefix(message.Prefix)
+	}
+}
+
+// l1 全局一级缓存，为 nil 表示未启用
+var l1 atomic.Pointer[localCache]
+
+// l1Cancel 停止失效通知订阅
+var l1Cancel context.CancelFunc
+
+// invalidationChannel 失效通知使用的频道，按应用前缀隔离
+func invalidationChannel() string {
+	return GetKeyPrefix() + KeySeparator + invalidationChannelSuffix
+}
+
+// configureL1 根据配置启用或关闭一级缓存，启用且Redis可用时订阅失效通知
+func configureL1(config *configs.RedisL1CacheConfig, client *redis.Client) {
+	stopL1()
+	if !config.Enable || client == nil {
+		l1.Store(nil)
+		return
+	}
+
+	cache := newLocalCache(config.Size, time.Duration(config.TTL)*time.Millisecond)
+	l1.Store(cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l1Cancel = cancel
+	pubsub := client.Subscribe(ctx, invalidationChannel())
+	go func() {
+		defer pubsub.Close()
+		consumeInvalidations(ctx, pubsub.Channel(), cache)
+	}()
+
+	if logger != nil {
+		logger.Info("L1 cache enabled: size=%d, ttl=%s", cache.size, cache.ttl)
+	}
+}
+
+// stopL1 停止失效通知订阅
+func stopL1() {
+	if l1Cancel != nil {
+		l1Cancel()
+		l1Cancel = nil
+	}
+}
+
+// consumeInvalidations 处理失效通知直到 ctx 取消或频道关闭
+func consumeInvalidations(ctx context.Context, messages <-chan *redis.Message, cache *localCache) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-messages:
+			if !ok {
+				return
+			}
+			cache.apply(message.Payload)
+		}
+	}
+}
+
+// publishInvalidation 通知其他实例删除本地条目，发送失败只记录日志，由 L1 TTL 兜底
+func publishInvalidation(ctx context.Context, client redis.Cmdable, message invalidationMessage) {
+	if l1.Load() == nil || client == nil {
+		return
+	}
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	if err := client.Publish(ctx, invalidationChannel(), payload).Err(); err != nil && logger != nil && !IsUnavailable(err) {
+		logger.Error("failed to publish cache invalidation: %v", err)
+	}
+}
+
+// Invalidate 删除缓存键，同时删除本实例及其他实例一级缓存中的条目
+func Invalidate(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	l1.Load().delete(keys...)
+
+	client := GetInstanceUnsafe()
+	if client == nil {
+		return nil
+	}
+	err := client.Del(ctx, keys...).Err()
+	publishInvalidation(ctx, client, invalidationMessage{Keys: keys})
+	return err
+}
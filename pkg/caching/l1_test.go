@@ -0,0 +1,91 @@
+package caching
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLocalCacheEvictsAndExpires(t *testing.T) {
+	now := time.Unix(0, 0)
+	cache := newLocalCache(2, time.Second)
+	cache.now = func() time.Time { return now }
+
+	cache.set("a", []byte("1"), time.Minute)
+	cache.set("b", []byte("2"), time.Minute)
+	cache.get("a") // a 最近使用，b 将被淘汰
+	cache.set("c", []byte("3"), time.Minute)
+	if _, ok := cache.get("b"); ok {
+		t.Fatal("least recently used entry should be evicted")
+	}
+	if _, ok := cache.get("a"); !ok {
+		t.Fatal("recently used entry should be kept")
+	}
+
+	// 条目有效期不超过一级缓存TTL
+	now = now.Add(time.Second)
+	if _, ok := cache.get("a"); ok {
+		t.Fatal("entry should expire after the L1 TTL")
+	}
+}
+
+func TestInvalidationMessageClearsL1Entry(t *testing.T) {
+	cache := newLocalCache(10, time.Minute)
+	cache.set("qc:rbac:perms:1", []byte("[1]"), 0)
+	cache.set("qc:rbac:perms:2", []byte("[2]"), 0)
+	cache.set("qc:workflow:x", []byte("{}"), 0)
+
+	messages := make(chan *redis.Message, 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		consumeInvalidations(ctx, messages, cache)
+		close(done)
+	}()
+
+	messages <- &redis.Message{Channel: invalidationChannel(), Payload: `{"keys":["qc:workflow:x"]}`}
+	messages <- &redis.Message{Channel: invalidationChannel(), Payload: `{"prefix":"qc:rbac:perms:"}`}
+	close(messages)
+	<-done
+	cancel()
+
+	if cache.len() != 0 {
+		t.Fatalf("expected all entries to be invalidated, %d left", cache.len())
+	}
+}
+
+func TestGetOrSetReadsFromL1BeforeRedis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer mr.Close()
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	local := newLocalCache(10, time.Minute)
+	loads := 0
+	load := func(ctx context.Context) ([]uint64, error) {
+		loads++
+		return []uint64{1, 2}, nil
+	}
+
+	if _, err := getOrSetWithClient(ctx, client, local, "perms:1", time.Minute, load); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 删除Redis中的值后仍从一级缓存命中
+	mr.FlushAll()
+	got, err := getOrSetWithClient(ctx, client, local, "perms:1", time.Minute, load)
+	if err != nil || len(got) != 2 || loads != 1 {
+		t.Fatalf("expected L1 hit, got %v, %v after %d loads", got, err, loads)
+	}
+
+	local.delete("perms:1")
+	if _, err := getOrSetWithClient(ctx, client, local, "perms:1", time.Minute, load); err != nil || loads != 2 {
+		t.Fatalf("expected reload after invalidation, got %d loads, %v", loads, err)
+	}
+}
@@ -0,0 +1,201 @@
+package configs
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// AuthConfig 认证配置
+type AuthConfig struct {
+	Argon2 Argon2Config `mapstructure:"argon2"` // 密码哈希参数
+	Device DeviceConfig `mapstructure:"device"` // 客户端设备令牌时长限制
+	Login  LoginConfig  `mapstructure:"login"`  // 登录行为配置
+	// Session 登录会话策略
+	Session SessionConfig `mapstructure:"session"`
+	// PasswordPolicy 设置、重置密码和注册时新密码需要满足的规则
+	PasswordPolicy PasswordPolicyConfig `mapstructure:"password_policy"`
+	// TokenClaims accessToken声明扩展
+	TokenClaims TokenClaimsConfig `mapstructure:"token_claims"`
+	// VerifyCode 验证码校验限制
+	VerifyCode VerifyCodeConfig `mapstructure:"verify_code"`
+	// PasswordChange 登录状态下修改密码的会话处理
+	PasswordChange PasswordChangeConfig `mapstructure:"password_change"`
+	// RoleExpiry 限时角色分配的清理
+	RoleExpiry RoleExpiryConfig `mapstructure:"role_expiry"`
+	// WebAuthn 通行密钥（WebAuthn）登录
+	WebAuthn WebAuthnConfig `mapstructure:"webauthn"`
+	// MagicLink 邮件魔法链接登录
+	MagicLink MagicLinkConfig `mapstructure:"magic_link"`
+}
+
+// MagicLinkConfig 魔法链接登录配置，URL 为空时不启用
+type MagicLinkConfig struct {
+	URL     string `mapstructure:"url"`     // 前端处理魔法链接的页面地址，令牌以 token 查询参数附加在后面
+	TTL     int    `mapstructure:"ttl"`     // 链接的有效期（秒）
+	Subject string `mapstructure:"subject"` // 邮件主题
+}
+
+// WebAuthnConfig 通行密钥配置，RPID 为空时不启用
+type WebAuthnConfig struct {
+	RPID             string   `mapstructure:"rp_id"`             // 依赖方ID，一般为前端站点的域名（不含协议和端口）
+	RPName           string   `mapstructure:"rp_name"`           // 依赖方名称，显示在浏览器的通行密钥提示中
+	Origins          []string `mapstructure:"origins"`           // 允许发起认证的前端来源，如 https://admin.example.com
+	ChallengeTTL     int      `mapstructure:"challenge_ttl"`     // 挑战的有效期（秒）
+	Timeout          int      `mapstructure:"timeout"`           // 提示浏览器等待用户操作的时长（毫秒）
+	UserVerification string   `mapstructure:"user_verification"` // 用户验证要求：required、preferred 或 discouraged
+}
+
+// RoleExpiryConfig 限时角色分配配置。过期的分配立即不再授予权限，清理任务只负责删除过期已久的记录
+type RoleExpiryConfig struct {
+	SweepInterval int `mapstructure:"sweep_interval"` // 清理任务的执行间隔（秒），0表示不清理
+	RetentionDays int `mapstructure:"retention_days"` // 过期后保留的天数，便于审计和续期
+}
+
+// PasswordChangeConfig 修改密码配置
+type PasswordChangeConfig struct {
+	// RevokeOtherSessions 修改密码后是否撤销该用户除当前会话外的所有会话
+	RevokeOtherSessions bool `mapstructure:"revoke_other_sessions"`
+}
+
+// VerifyCodeConfig 验证码校验配置
+type VerifyCodeConfig struct {
+	MaxAttempts int `mapstructure:"max_attempts"` // 同一验证码允许的错误次数，达到后验证码作废
+}
+
+// TokenClaimsConfig accessToken声明扩展配置，开启后生效终端签发的accessToken携带角色名称（和权限哈希）。
+// 声明在accessToken过期前不会随权限变更而更新，最多滞后 MaxAccessTokenExpiry
+type TokenClaimsConfig struct {
+	Enabled            bool     `mapstructure:"enabled"`             // 是否启用
+	Devices            []string `mapstructure:"devices"`             // 生效的终端（名称或编码），为空表示所有终端
+	IncludePermissions bool     `mapstructure:"include_permissions"` // 是否同时携带权限哈希
+	MaxRoles           int      `mapstructure:"max_roles"`           // 角色声明数量上限
+	MaxPermissions     int      `mapstructure:"max_permissions"`     // 权限哈希数量上限
+	// MaxAccessTokenExpiry 携带声明的accessToken有效期上限（毫秒），取该值与终端配置中的较小者，0表示不限制
+	MaxAccessTokenExpiry uint64 `mapstructure:"max_access_token_expiry"`
+}
+
+// PasswordPolicyConfig 密码策略配置
+type PasswordPolicyConfig struct {
+	MinLength     int  `mapstructure:"min_length"`     // 最小长度（字符数）
+	MaxLength     int  `mapstructure:"max_length"`     // 最大长度（字符数），0表示不限制
+	RequireLetter bool `mapstructure:"require_letter"` // 是否必须包含字母
+	RequireDigit  bool `mapstructure:"require_digit"`  // 是否必须包含数字
+	RequireSymbol bool `mapstructure:"require_symbol"` // 是否必须包含字母和数字以外的符号
+}
+
+// LoginConfig 登录行为配置
+type LoginConfig struct {
+	// IdentifierPriority 灵活登录时标识符可能匹配多种类型的判定顺序，可选值 email、phone、username
+	IdentifierPriority []string `mapstructure:"identifier_priority"`
+	// IPGuard 按来源IP的登录防护，与按凭据的锁定同时生效
+	IPGuard LoginIPGuardConfig `mapstructure:"ip_guard"`
+}
+
+// SessionConfig 登录会话策略配置
+type SessionConfig struct {
+	// SinglePerDevice 同一用户在同一终端只保留一个有效会话
+	SinglePerDevice SinglePerDeviceConfig `mapstructure:"single_per_device"`
+}
+
+// SinglePerDeviceConfig 单终端单会话策略，启用后新登录会使该用户在同一终端的旧会话失效
+type SinglePerDeviceConfig struct {
+	Enabled bool     `mapstructure:"enabled"` // 是否启用
+	Devices []string `mapstructure:"devices"` // 生效的终端（名称或编码），为空表示所有终端
+}
+
+// LoginIPGuardConfig 按来源IP的登录防护配置
+type LoginIPGuardConfig struct {
+	Enabled                bool          `mapstructure:"enabled"`                  // 是否启用
+	MaxDistinctIdentifiers int           `mapstructure:"max_distinct_identifiers"` // 窗口内同一IP登录失败涉及的不同标识符数量上限
+	Window                 time.Duration `mapstructure:"window"`                   // 失败统计窗口
+	BlockDuration          time.Duration `mapstructure:"block_duration"`           // 达到上限后的封禁时长
+	Allowlist              []string      `mapstructure:"allowlist"`                // 豁免的IP或CIDR网段（如办公网出口）
+}
+
+// DeviceConfig 客户端设备令牌有效期的允许范围（毫秒）
+type DeviceConfig struct {
+	MinAccessTokenExpiry  uint64 `mapstructure:"min_access_token_expiry"`  // accessToken最短有效期
+	MaxAccessTokenExpiry  uint64 `mapstructure:"max_access_token_expiry"`  // accessToken最长有效期
+	MinRefreshTokenExpiry uint64 `mapstructure:"min_refresh_token_expiry"` // refreshToken最短有效期
+	MaxRefreshTokenExpiry uint64 `mapstructure:"max_refresh_token_expiry"` // refreshToken最长有效期
+	// 未勾选"记住我"时refreshToken的绝对有效期上限，实际有效期取该值与设备配置中的较小者
+	SessionRefreshTokenExpiry uint64 `mapstructure:"session_refresh_token_expiry"`
+}
+
+// Argon2Config Argon2id 密码哈希参数
+type Argon2Config struct {
+	Time             uint32 `mapstructure:"time"`               // 迭代次数
+	Memory           uint32 `mapstructure:"memory"`             // 内存开销（KiB）
+	Threads          uint8  `mapstructure:"threads"`            // 并行度
+	KeyLen           uint32 `mapstructure:"key_len"`            // 输出哈希长度（字节）
+	SaltLen          uint32 `mapstructure:"salt_len"`           // 盐值长度（字节）
+	BenchmarkOnStart bool   `mapstructure:"benchmark_on_start"` // 启动时是否进行哈希耗时自检
+	MinDuration      int64  `mapstructure:"min_duration"`       // 单次哈希的最低期望耗时（毫秒），低于该值时告警
+}
+
+func setAuthConfigDefaults() {
+	// Argon2 默认参数（与 OWASP 推荐的最低配置一致）
+	viper.SetDefault("auth.argon2.time", 1)
+	viper.SetDefault("auth.argon2.memory", 64*1024) // 64MB
+	viper.SetDefault("auth.argon2.threads", 4)
+	viper.SetDefault("auth.argon2.key_len", 32)
+	viper.SetDefault("auth.argon2.salt_len", 16)
+	viper.SetDefault("auth.argon2.benchmark_on_start", true)
+	viper.SetDefault("auth.argon2.min_duration", 100) // 100毫秒
+
+	// 客户端设备令牌有效期范围
+	viper.SetDefault("auth.device.min_access_token_expiry", 1000)              // 1秒
+	viper.SetDefault("auth.device.max_access_token_expiry", 7*24*3600*1000)    // 7天
+	viper.SetDefault("auth.device.min_refresh_token_expiry", 1000)             // 1秒
+	viper.SetDefault("auth.device.max_refresh_token_expiry", 365*24*3600*1000) // 365天
+	viper.SetDefault("auth.device.session_refresh_token_expiry", 12*3600*1000) // 未勾选"记住我"的会话12小时后过期
+
+	// 灵活登录标识符判定顺序
+	viper.SetDefault("auth.login.identifier_priority", []string{"email", "phone", "username"})
+
+	// 按来源IP的登录防护
+	viper.SetDefault("auth.login.ip_guard.enabled", true)
+	viper.SetDefault("auth.login.ip_guard.max_distinct_identifiers", 10)
+	viper.SetDefault("auth.login.ip_guard.window", 15*time.Minute)
+	viper.SetDefault("auth.login.ip_guard.block_duration", 30*time.Minute)
+	viper.SetDefault("auth.login.ip_guard.allowlist", []string{})
+
+	// 单终端单会话策略
+	viper.SetDefault("auth.session.single_per_device.enabled", false)
+	viper.SetDefault("auth.session.single_per_device.devices", []string{})
+
+	// accessToken声明扩展
+	viper.SetDefault("auth.token_claims.enabled", false)
+	viper.SetDefault("auth.token_claims.devices", []string{})
+	viper.SetDefault("auth.token_claims.include_permissions", false)
+	viper.SetDefault("auth.token_claims.max_roles", 16)
+	viper.SetDefault("auth.token_claims.max_permissions", 64)
+	viper.SetDefault("auth.token_claims.max_access_token_expiry", 5*60*1000) // 5分钟
+
+	// 验证码校验限制
+	viper.SetDefault("auth.verify_code.max_attempts", 5)
+	viper.SetDefault("auth.password_change.revoke_other_sessions", true)
+	viper.SetDefault("auth.role_expiry.sweep_interval", 3600)
+	viper.SetDefault("auth.role_expiry.retention_days", 30)
+
+	// 通行密钥
+	viper.SetDefault("auth.webauthn.rp_id", "")
+	viper.SetDefault("auth.webauthn.rp_name", "QC Admin")
+	viper.SetDefault("auth.webauthn.origins", []string{})
+	viper.SetDefault("auth.webauthn.challenge_ttl", 300)
+	viper.SetDefault("auth.webauthn.timeout", 60000)
+	viper.SetDefault("auth.webauthn.user_verification", "preferred")
+
+	// 魔法链接
+	viper.SetDefault("auth.magic_link.url", "")
+	viper.SetDefault("auth.magic_link.ttl", 600)
+	viper.SetDefault("auth.magic_link.subject", "登录链接")
+
+	// 密码策略
+	viper.SetDefault("auth.password_policy.min_length", 8)
+	viper.SetDefault("auth.password_policy.max_length", 128)
+	viper.SetDefault("auth.password_policy.require_letter", true)
+	viper.SetDefault("auth.password_policy.require_digit", true)
+	viper.SetDefault("auth.password_policy.require_symbol", false)
+}
@@ -3,14 +3,30 @@ package components
 import "github.com/spf13/viper"
 
 type MessagingConfig struct {
-	Enabled     bool          `mapstructure:"enabled"`      // 是否启用消息处理器
-	GroupName   string        `mapstructure:"group_name"`   // 消费者组名称
-	StreamKey   string        `mapstructure:"stream_key"`   // Redis 流键名
-	MaxRetries  int64         `mapstructure:"max_retries"`  // 最大重试次数
-	ReadTimeout int64         `mapstructure:"read_timeout"` // 读取消息的阻塞超时时间（毫秒）
-	ReadCount   int64         `mapstructure:"read_count"`   // 每次读取的消息数量
-	IdleTimeout int64         `mapstructure:"idle_timeout"` // 消息空闲超时时间（毫秒）
-	Cleanup     CleanupConfig `mapstructure:"cleanup"`      // 清理配置
+	Enabled     bool           `mapstructure:"enabled"`      // 是否启用消息处理器
+	GroupName   string         `mapstructure:"group_name"`   // 消费者组名称
+	StreamKey   string         `mapstructure:"stream_key"`   // Redis 流键名
+	MaxRetries  int64          `mapstructure:"max_retries"`  // 最大重试次数
+	ReadTimeout int64          `mapstructure:"read_timeout"` // 读取消息的阻塞超时时间（毫秒）
+	ReadCount   int64          `mapstructure:"read_count"`   // 每次读取的消息数量
+	IdleTimeout int64          `mapstructure:"idle_timeout"` // 消息空闲超时时间（毫秒）
+	DedupTTL    int64          `mapstructure:"dedup_ttl"`    // 开启去重的消费者保留已处理消息记录的时间（秒）
+	Cleanup     CleanupConfig  `mapstructure:"cleanup"`      // 清理配置
+	Delayed     DelayedConfig  `mapstructure:"delayed"`      // 延迟消息配置
+	Bridges     []BridgeConfig `mapstructure:"bridges"`      // Redis 频道到 WebSocket 主题的桥接
+}
+
+// BridgeConfig Redis Pub/Sub 频道到 WebSocket 主题的桥接配置
+type BridgeConfig struct {
+	Channel string `mapstructure:"channel"` // Redis 频道，包含 * 时为模式订阅
+	Topic   string `mapstructure:"topic"`   // WebSocket 主题模板，支持 {channel} 和 {match} 占位符
+}
+
+type DelayedConfig struct {
+	Enabled      bool  `mapstructure:"enabled"`       // 是否启动延迟消息投递器
+	PollInterval int64 `mapstructure:"poll_interval"` // 轮询到期消息的间隔（毫秒）
+	BatchSize    int64 `mapstructure:"batch_size"`    // 每次最多投递的到期消息数量
+	LockTTL      int64 `mapstructure:"lock_ttl"`      // 投递器主节点锁的有效期（毫秒），主节点失联超过该时间后由其他实例接管
 }
 
 type CleanupConfig struct {
@@ -30,6 +46,7 @@ func setMessagingConfigDefaults() {
 	viper.SetDefault("server.components.messaging.read_timeout", 2000) // 2s
 	viper.SetDefault("server.components.messaging.read_count", 1)
 	viper.SetDefault("server.components.messaging.idle_timeout", 60000) // 60s
+	viper.SetDefault("server.components.messaging.dedup_ttl", 86400)    // 24小时
 
 	// 清理配置默认值
 	viper.SetDefault("server.components.messaging.cleanup.enabled", true)
@@ -37,4 +54,10 @@ func setMessagingConfigDefaults() {
 	viper.SetDefault("server.components.messaging.cleanup.max_len", 0)                   // 0表示不使用长度清理
 	viper.SetDefault("server.components.messaging.cleanup.max_age", 0)                   // 0表示不使用时间清理
 	viper.SetDefault("server.components.messaging.cleanup.dead_letter_max_age", 2592000) // 30天
+
+	// 延迟消息默认值
+	viper.SetDefault("server.components.messaging.delayed.enabled", true)
+	viper.SetDefault("server.components.messaging.delayed.poll_interval", 1000) // 1s
+	viper.SetDefault("server.components.messaging.delayed.batch_size", 100)
+	viper.SetDefault("server.components.messaging.delayed.lock_ttl", 10000) // 10s
 }
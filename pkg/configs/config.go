@@ -22,6 +22,8 @@ type AppConfig struct {
 	JWT      JWTConfig      `mapstructure:"jwt"`
 	OpenAI   OpenAIConfig   `mapstructure:"openai"`
 	Socket   SocketConfig   `mapstructure:"socket"`
+	Auth     AuthConfig     `mapstructure:"auth"`
+	Workflow WorkflowConfig `mapstructure:"workflow"`
 }
 
 var config *AppConfig
@@ -57,6 +59,11 @@ func LoadConfig(configPath string) (*AppConfig, error) {
 		return nil, fmt.Errorf("unable to decode config: %w", err)
 	}
 
+	// 启动前校验配置，避免错误配置在初始化深处才以难以理解的方式失败
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -197,6 +204,10 @@ func setDefaults() {
 
 	// SocketIO默认配置
 	setSocketConfigDefaults()
+
+	// 认证默认配置
+	setAuthConfigDefaults()
+	setWorkflowConfigDefaults()
 }
 
 // ResolveConfigPath 解析配置文件路径，支持相对路径和绝对路径
@@ -0,0 +1,22 @@
+package configs
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ConnectRetryConfig 启动时连接依赖服务的重试配置。
+// 每次失败后等待 Interval，之后每次等待时间翻倍，不超过 MaxInterval
+type ConnectRetryConfig struct {
+	Attempts    int           `mapstructure:"attempts"`     // 最多尝试次数（包括第一次），小于1时按1处理
+	Interval    time.Duration `mapstructure:"interval"`     // 第一次重试前的等待时间
+	MaxInterval time.Duration `mapstructure:"max_interval"` // 重试等待时间上限
+}
+
+// setConnectRetryDefaults 设置 prefix 下连接重试配置的默认值
+func setConnectRetryDefaults(prefix string) {
+	viper.SetDefault(prefix+".attempts", 5)
+	viper.SetDefault(prefix+".interval", time.Second)
+	viper.SetDefault(prefix+".max_interval", 10*time.Second)
+}
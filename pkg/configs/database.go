@@ -16,7 +16,19 @@ type DatabaseConfig struct {
 	MaxIdleConns            int           `mapstructure:"max_idle_conns"`            // 最大空闲连接数
 	MaxOpenConns            int           `mapstructure:"max_open_conns"`            // 最大打开连接数
 	ConnMaxLifetime         time.Duration `mapstructure:"conn_max_lifetime"`         // 连接最大生命周期
+	ConnMaxIdleTime         time.Duration `mapstructure:"conn_max_idle_time"`        // 连接最大空闲时间，超过后被关闭
 	ConnectionCheckInterval time.Duration `mapstructure:"connection_check_interval"` // 连接检查间隔
+
+	IDGenerator  IDGeneratorConfig  `mapstructure:"id_generator"`  // 实体ID生成器配置
+	ConnectRetry ConnectRetryConfig `mapstructure:"connect_retry"` // 启动时连接数据库的重试配置
+}
+
+// IDGeneratorConfig 雪花ID生成器配置
+type IDGeneratorConfig struct {
+	// NodeID 节点ID（0-1023），多实例部署时每个实例必须不同；小于0时根据本机内网IP推导
+	NodeID int64 `mapstructure:"node_id"`
+	// Epoch 纪元（2006-01-02 或 RFC3339），已有数据后不能再修改，否则新生成的ID可能与已有ID重复
+	Epoch string `mapstructure:"epoch"`
 }
 
 func setDatabaseConfigDefaults() {
@@ -29,5 +41,9 @@ func setDatabaseConfigDefaults() {
 	viper.SetDefault("database.max_idle_conns", 10)  // 默认最大空闲连接数
 	viper.SetDefault("database.max_open_conns", 100)
 	viper.SetDefault("database.conn_max_lifetime", time.Hour)              // 默认连接最大生命周期为1小时
+	viper.SetDefault("database.conn_max_idle_time", 10*time.Minute)        // 默认空闲连接10分钟后关闭
 	viper.SetDefault("database.connection_check_interval", 30*time.Minute) // 默认连接检查间隔为1分钟
+	viper.SetDefault("database.id_generator.node_id", -1)                  // 默认根据内网IP推导节点ID
+	viper.SetDefault("database.id_generator.epoch", "2020-01-01")
+	setConnectRetryDefaults("database.connect_retry")
 }
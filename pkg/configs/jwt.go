@@ -7,11 +7,14 @@ import (
 // JWTConfig JWT配置
 type JWTConfig struct {
 	SecretKey string `mapstructure:"secret_key"` // JWT密钥
-	Issuer    string `mapstructure:"issuer"`     // 签发者
+	Issuer    string `mapstructure:"issuer"`     // 签发者，非空时验证Token的iss
+	Audience  string `mapstructure:"audience"`   // 受众，非空时验证Token的aud
 }
 
 // setJWTConfigDefaults 设置JWT默认配置
 func setJWTConfigDefaults() {
 	viper.SetDefault("jwt.secret_key", "your-super-secret-jwt-key-change-in-production")
 	viper.SetDefault("jwt.issuer", "go-backend")
+	// 受众默认不启用：升级前签发的Token不带 aud，启用后会全部失效
+	viper.SetDefault("jwt.audience", "")
 }
@@ -8,6 +8,8 @@ type LoggingConfig struct {
 	Prefix  string               `mapstructure:"prefix"`  // 日志前缀
 	Console LoggingConsoleConfig `mapstructure:"console"` // 控制台日志配置
 	File    LoggingFileConfig    `mapstructure:"file"`    // 文件日志配置
+	// RedactedKeys 写入日志和导出报告前需要脱敏的键名（忽略大小写、下划线和连字符，按后缀匹配）
+	RedactedKeys []string `mapstructure:"redacted_keys"`
 }
 
 type LoggingConsoleConfig struct {
@@ -38,6 +40,7 @@ func setLoggingConfigDefaults() {
 	// 日志默认配置
 	viper.SetDefault("logging.level", "info")
 	viper.SetDefault("logging.prefix", "APP")
+	viper.SetDefault("logging.redacted_keys", []string{"clientSecret", "password", "secret", "authorization", "apiKey"})
 	viper.SetDefault("logging.console.enabled", true)
 	viper.SetDefault("logging.file.enabled", false)
 	viper.SetDefault("logging.file.path", "./logs")
@@ -0,0 +1,16 @@
+package middleware
+
+import "github.com/spf13/viper"
+
+// AccessLogConfig 访问日志中间件配置
+type AccessLogConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`       // 是否记录访问日志
+	SampleRate   float64  `mapstructure:"sample_rate"`   // 成功请求的采样比例（0~1），5xx 响应始终记录
+	ExcludePaths []string `mapstructure:"exclude_paths"` // 不记录的路径，支持以 * 结尾的前缀匹配，可填写请求路径或路由模板
+}
+
+func setAccessLogConfigDefaults() {
+	viper.SetDefault("server.middleware.access_log.enabled", true)                      // 默认记录访问日志
+	viper.SetDefault("server.middleware.access_log.sample_rate", 1.0)                   // 默认全部记录
+	viper.SetDefault("server.middleware.access_log.exclude_paths", []string{"/health"}) // 默认不记录健康检查
+}
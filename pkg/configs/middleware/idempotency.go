@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// IdempotencyConfig 幂等键中间件配置
+type IdempotencyConfig struct {
+	TTL time.Duration `mapstructure:"ttl"` // 首次响应的缓存时长，期间相同 Idempotency-Key 的请求直接重放
+}
+
+func setIdempotencyConfigDefaults() {
+	viper.SetDefault("server.middleware.idempotency.ttl", 24*time.Hour) // 默认缓存24小时
+}
@@ -1,9 +1,13 @@
 package middleware
 
 type MiddlewareConfig struct {
-	Delay DelayConfig `mapstructure:"delay"` // 延迟中间件配置
+	Delay       DelayConfig       `mapstructure:"delay"`       // 延迟中间件配置
+	Idempotency IdempotencyConfig `mapstructure:"idempotency"` // 幂等键中间件配置
+	AccessLog   AccessLogConfig   `mapstructure:"access_log"`  // 访问日志中间件配置
 }
 
 func SetMiddlewareConfigDefaults() {
 	setDelayConfigDefaults()
+	setIdempotencyConfigDefaults()
+	setAccessLogConfigDefaults()
 }
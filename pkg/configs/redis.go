@@ -13,6 +13,29 @@ type RedisConfig struct {
 	ReadTimeout  int    `mapstructure:"read_timeout"`
 	WriteTimeout int    `mapstructure:"write_timeout"`
 	IdleTimeout  int    `mapstructure:"idle_timeout"`
+	KeyPrefix    string `mapstructure:"key_prefix"` // 缓存键的应用前缀，用于隔离不同应用/环境的键
+	// Codec GetOrSet 等类型化缓存写入时使用的序列化格式：json（默认）或 msgpack。
+	// 读取时按值的格式标记解码，切换格式后已有的缓存仍可读取
+	Codec string `mapstructure:"codec"`
+
+	CircuitBreaker RedisCircuitBreakerConfig `mapstructure:"circuit_breaker"` // Redis熔断配置
+	L1             RedisL1CacheConfig        `mapstructure:"l1"`              // 进程内一级缓存配置
+	ConnectRetry   ConnectRetryConfig        `mapstructure:"connect_retry"`   // 启动时连接Redis的重试配置
+}
+
+// RedisL1CacheConfig 进程内一级缓存配置
+// 一级缓存位于Redis之前，键变更时通过Redis发布/订阅通知其他实例删除本地条目；
+// 通知丢失（如订阅连接断开）时其他实例最多在 TTL 内读到旧值
+type RedisL1CacheConfig struct {
+	Enable bool `mapstructure:"enable"` // 是否启用一级缓存
+	Size   int  `mapstructure:"size"`   // 最多缓存的键数量，超出时淘汰最久未使用的键
+	TTL    int  `mapstructure:"ttl"`    // 条目有效期（毫秒），即实例间数据不一致的最长时间
+}
+
+// RedisCircuitBreakerConfig Redis熔断配置
+type RedisCircuitBreakerConfig struct {
+	FailureThreshold int `mapstructure:"failure_threshold"` // 连续失败多少次后熔断
+	Cooldown         int `mapstructure:"cooldown"`          // 熔断冷却时间（毫秒），期间缓存视为不可用
 }
 
 func setRedisConfigDefaults() {
@@ -26,4 +49,12 @@ func setRedisConfigDefaults() {
 	viper.SetDefault("redis.read_timeout", 3)
 	viper.SetDefault("redis.write_timeout", 3)
 	viper.SetDefault("redis.idle_timeout", 300)
+	viper.SetDefault("redis.key_prefix", "qc")
+	viper.SetDefault("redis.codec", "json")
+	viper.SetDefault("redis.circuit_breaker.failure_threshold", 5)
+	viper.SetDefault("redis.circuit_breaker.cooldown", 30000) // 30秒
+	viper.SetDefault("redis.l1.enable", false)
+	viper.SetDefault("redis.l1.size", 1000)
+	viper.SetDefault("redis.l1.ttl", 2000) // 2秒
+	setConnectRetryDefaults("redis.connect_retry")
 }
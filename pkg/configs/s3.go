@@ -4,18 +4,26 @@ import "github.com/spf13/viper"
 
 // S3Config S3配置
 type S3Config struct {
-	Endpoint        string `mapstructure:"endpoint"`          // S3端点URL
-	PublicEndpoint  string `mapstructure:"public_endpoint"`   // 公共访问端点URL
-	Region          string `mapstructure:"region"`            // AWS区域
-	AccessKeyID     string `mapstructure:"access_key_id"`     // AWS访问密钥ID
-	SecretAccessKey string `mapstructure:"secret_access_key"` // AWS访问密钥
-	SessionToken    string `mapstructure:"session_token"`     // AWS会话令牌（可选）
-	Bucket          string `mapstructure:"bucket"`            // 默认存储桶
-	UseSSL          bool   `mapstructure:"use_ssl"`           // 是否使用HTTPS
-	ForcePathStyle  bool   `mapstructure:"force_path_style"`  // 是否强制使用路径样式URL
-	DisableSSL      bool   `mapstructure:"disable_ssl"`       // 是否禁用SSL
-	Timeout         int    `mapstructure:"timeout"`           // 超时时间（秒）
-	MaxRetries      int    `mapstructure:"max_retries"`       // 最大重试次数
+	Endpoint        string      `mapstructure:"endpoint"`          // S3端点URL
+	PublicEndpoint  string      `mapstructure:"public_endpoint"`   // 公共访问端点URL
+	Region          string      `mapstructure:"region"`            // AWS区域
+	AccessKeyID     string      `mapstructure:"access_key_id"`     // AWS访问密钥ID
+	SecretAccessKey string      `mapstructure:"secret_access_key"` // AWS访问密钥
+	SessionToken    string      `mapstructure:"session_token"`     // AWS会话令牌（可选）
+	Bucket          string      `mapstructure:"bucket"`            // 默认存储桶
+	UseSSL          bool        `mapstructure:"use_ssl"`           // 是否使用HTTPS
+	ForcePathStyle  bool        `mapstructure:"force_path_style"`  // 是否强制使用路径样式URL
+	DisableSSL      bool        `mapstructure:"disable_ssl"`       // 是否禁用SSL
+	Timeout         int         `mapstructure:"timeout"`           // 超时时间（秒）
+	MaxRetries      int         `mapstructure:"max_retries"`       // 最大重试次数
+	SSE             S3SSEConfig `mapstructure:"sse"`               // 服务端加密配置
+}
+
+// S3SSEConfig S3服务端加密配置
+type S3SSEConfig struct {
+	Enabled   bool   `mapstructure:"enabled"`    // 是否默认对上传的对象启用服务端加密
+	Algorithm string `mapstructure:"algorithm"`  // 加密算法: AES256(SSE-S3) 或 aws:kms(SSE-KMS)
+	KMSKeyID  string `mapstructure:"kms_key_id"` // SSE-KMS使用的密钥ID，为空时使用默认KMS密钥
 }
 
 // setS3ConfigDefaults 设置S3默认配置
@@ -32,4 +40,7 @@ func setS3ConfigDefaults() {
 	viper.SetDefault("s3.disable_ssl", false)
 	viper.SetDefault("s3.timeout", 30)
 	viper.SetDefault("s3.max_retries", 3)
+	viper.SetDefault("s3.sse.enabled", false)
+	viper.SetDefault("s3.sse.algorithm", "AES256")
+	viper.SetDefault("s3.sse.kms_key_id", "")
 }
@@ -9,14 +9,16 @@ import (
 
 // ServerConfig 服务器配置
 type ServerConfig struct {
-	Port       string                      `mapstructure:"port"`
-	Mode       string                      `mapstructure:"mode"`   // gin模式: debug, release, test
-	Static     StaticConfig                `mapstructure:"static"` // 静态文件服务配置
-	Debug      bool                        `mapstructure:"debug"`  // 是否启用调试模式
-	CORS       CORSConfig                  `mapstructure:"cors"`   // 跨域配置
-	Prefix     string                      `mapstructure:"prefix"` // API前缀
-	Middleware middleware.MiddlewareConfig `mapstructure:"middleware"`
-	Components components.ComponentConfig  `mapstructure:"components"`
+	Port           string                      `mapstructure:"port"`
+	Mode           string                      `mapstructure:"mode"`            // gin模式: debug, release, test
+	Static         StaticConfig                `mapstructure:"static"`          // 静态文件服务配置
+	Debug          bool                        `mapstructure:"debug"`           // 是否启用调试模式
+	CORS           CORSConfig                  `mapstructure:"cors"`            // 跨域配置
+	Prefix         string                      `mapstructure:"prefix"`          // API前缀
+	TLS            TLSConfig                   `mapstructure:"tls"`             // HTTPS配置
+	TrustedProxies []string                    `mapstructure:"trusted_proxies"` // 受信任的反向代理（IP或CIDR），为空表示不信任任何代理，客户端IP取连接的对端地址
+	Middleware     middleware.MiddlewareConfig `mapstructure:"middleware"`
+	Components     components.ComponentConfig  `mapstructure:"components"`
 }
 
 type StaticConfig struct {
@@ -25,6 +27,26 @@ type StaticConfig struct {
 	Path    string `mapstructure:"path"`    // 静态文件访问路径
 }
 
+// TLSConfig HTTPS配置，启用后自动支持 HTTP/2
+type TLSConfig struct {
+	Enabled      bool           `mapstructure:"enabled"`       // 是否启用HTTPS，默认仅提供HTTP
+	CertFile     string         `mapstructure:"cert_file"`     // 证书文件路径
+	KeyFile      string         `mapstructure:"key_file"`      // 私钥文件路径
+	HTTP2        bool           `mapstructure:"http2"`         // 是否启用HTTP/2
+	Redirect     bool           `mapstructure:"redirect"`      // 是否启动HTTP到HTTPS的重定向监听
+	RedirectPort string         `mapstructure:"redirect_port"` // 重定向监听地址
+	AutoCert     AutoCertConfig `mapstructure:"auto_cert"`     // ACME自动证书配置
+}
+
+// AutoCertConfig ACME（如 Let's Encrypt）自动签发证书配置，启用后忽略 cert_file / key_file
+type AutoCertConfig struct {
+	Enabled      bool     `mapstructure:"enabled"`       // 是否启用自动证书
+	Domains      []string `mapstructure:"domains"`       // 允许签发证书的域名
+	Email        string   `mapstructure:"email"`         // ACME账户邮箱，用于接收证书过期通知
+	CacheDir     string   `mapstructure:"cache_dir"`     // 证书缓存目录
+	DirectoryURL string   `mapstructure:"directory_url"` // ACME服务地址，为空时使用 Let's Encrypt 正式环境
+}
+
 type CORSConfig struct {
 	Enabled          bool     `mapstructure:"enabled"`           // 是否启用CORS
 	AllowAllOrigins  bool     `mapstructure:"allow_all_origins"` // 是否允许所有来源
@@ -45,6 +67,20 @@ func setServerConfigDefaults() {
 	viper.SetDefault("server.static.root", "../public")
 	viper.SetDefault("server.static.path", "/static")
 	viper.SetDefault("server.api_prefix", "/api")
+	viper.SetDefault("server.trusted_proxies", []string{})
+
+	// TLS默认配置
+	viper.SetDefault("server.tls.enabled", false)
+	viper.SetDefault("server.tls.cert_file", "")
+	viper.SetDefault("server.tls.key_file", "")
+	viper.SetDefault("server.tls.http2", true)
+	viper.SetDefault("server.tls.redirect", false)
+	viper.SetDefault("server.tls.redirect_port", ":80")
+	viper.SetDefault("server.tls.auto_cert.enabled", false)
+	viper.SetDefault("server.tls.auto_cert.domains", []string{})
+	viper.SetDefault("server.tls.auto_cert.email", "")
+	viper.SetDefault("server.tls.auto_cert.cache_dir", "./certs")
+	viper.SetDefault("server.tls.auto_cert.directory_url", "")
 
 	// CORS默认配置
 	viper.SetDefault("server.cors.enabled", true)
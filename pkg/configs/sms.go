@@ -10,6 +10,9 @@ type SMSConfig struct {
 	HTTP     HTTPSMSConfig          `mapstructure:"http"`     // HTTP短信配置
 	Mock     MockSMSConfig          `mapstructure:"mock"`     // Mock短信配置
 	Extra    map[string]interface{} `mapstructure:"extra"`    // 额外配置
+
+	// VerifyCodeTemplates 验证码用途到模板ID的映射 (register, login, reset_password...)，未配置的用途使用提供商默认模板
+	VerifyCodeTemplates map[string]string `mapstructure:"verify_code_templates"`
 }
 
 // AliyunSMSConfig 阿里云短信配置
@@ -57,6 +60,7 @@ type MockSMSConfig struct {
 // setSMSConfigDefaults 设置短信默认配置
 func setSMSConfigDefaults() {
 	viper.SetDefault("sms.provider", "aliyun")
+	viper.SetDefault("sms.verify_code_templates", map[string]string{})
 
 	// 阿里云默认配置
 	viper.SetDefault("sms.aliyun.access_key_id", "")
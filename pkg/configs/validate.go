@@ -0,0 +1,159 @@
+package configs
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// MinJWTSecretLength JWT密钥的最小长度（字节），HS256 要求密钥不短于签名长度
+const MinJWTSecretLength = 32
+
+// ValidationError 配置校验错误，一次性列出所有问题
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("配置校验失败，共 %d 个问题:", len(e.Problems)))
+	for _, problem := range e.Problems {
+		sb.WriteString("\n  - ")
+		sb.WriteString(problem)
+	}
+	return sb.String()
+}
+
+// configValidator 收集校验问题
+type configValidator struct {
+	problems []string
+}
+
+func (v *configValidator) addf(format string, args ...any) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+// Validate 按子系统检查必填项和格式，返回列出所有问题的 *ValidationError，没有问题时返回 nil
+func (c *AppConfig) Validate() error {
+	v := &configValidator{}
+	c.validateServer(v)
+	c.validateDatabase(v)
+	c.validateRedis(v)
+	c.validateJWT(v)
+
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (c *AppConfig) validateServer(v *configValidator) {
+	server := c.Server
+	if err := validateListenAddr(server.Port); err != nil {
+		v.addf("server.port %q 无效: %v，应为 \"host:port\" 或 \":port\" 格式，如 \":8080\"", server.Port, err)
+	}
+
+	switch server.Mode {
+	case "debug", "release", "test":
+	default:
+		v.addf("server.mode %q 无效，可选值为 debug、release、test", server.Mode)
+	}
+
+	if server.TLS.Enabled {
+		if server.TLS.AutoCert.Enabled {
+			if len(server.TLS.AutoCert.Domains) == 0 {
+				v.addf("server.tls.auto_cert.domains 不能为空：启用自动证书时必须指定允许签发证书的域名")
+			}
+		} else if server.TLS.CertFile == "" || server.TLS.KeyFile == "" {
+			v.addf("server.tls.cert_file 和 server.tls.key_file 不能为空：启用HTTPS时需要提供证书，或启用 server.tls.auto_cert")
+		}
+		if server.TLS.Redirect {
+			if err := validateListenAddr(server.TLS.RedirectPort); err != nil {
+				v.addf("server.tls.redirect_port %q 无效: %v", server.TLS.RedirectPort, err)
+			}
+		}
+	}
+
+	c.validateCORS(v)
+}
+
+// validateCORS 检查非 allow_all_origins 时来源列表的一致性，调试模式下CORS允许所有来源，不使用该配置
+func (c *AppConfig) validateCORS(v *configValidator) {
+	cors := c.Server.CORS
+	if !cors.Enabled || cors.AllowAllOrigins {
+		return
+	}
+
+	if len(cors.AllowOrigins) == 0 {
+		v.addf("server.cors.allow_origins 不能为空：未启用 allow_all_origins 时必须列出允许的来源")
+	}
+	for _, origin := range cors.AllowOrigins {
+		if origin == "*" {
+			v.addf("server.cors.allow_origins 包含 \"*\"：允许所有来源请改为设置 server.cors.allow_all_origins: true")
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			v.addf("server.cors.allow_origins 中的 %q 无效: %v", origin, err)
+		}
+	}
+	if cors.MaxAge < 0 {
+		v.addf("server.cors.max_age 不能为负数")
+	}
+}
+
+func (c *AppConfig) validateDatabase(v *configValidator) {
+	if strings.TrimSpace(c.Database.Driver) == "" {
+		v.addf("database.driver 不能为空，如 sqlite3、mysql、postgres")
+	}
+	if strings.TrimSpace(c.Database.DSN) == "" {
+		v.addf("database.dsn 不能为空：请配置数据库连接串")
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		v.addf("database.max_idle_conns (%d) 不能大于 database.max_open_conns (%d)", c.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+}
+
+func (c *AppConfig) validateRedis(v *configValidator) {
+	if !c.Redis.Enable {
+		return
+	}
+	if _, _, err := net.SplitHostPort(c.Redis.Addr); err != nil {
+		v.addf("redis.addr %q 无效: %v，应为 \"host:port\" 格式", c.Redis.Addr, err)
+	}
+}
+
+func (c *AppConfig) validateJWT(v *configValidator) {
+	if len(c.JWT.SecretKey) < MinJWTSecretLength {
+		v.addf("jwt.secret_key 长度为 %d，至少需要 %d 个字符", len(c.JWT.SecretKey), MinJWTSecretLength)
+	}
+}
+
+// validateListenAddr 检查监听地址是否为 host:port 格式且端口在 1-65535 之间
+func validateListenAddr(addr string) error {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	n, err := strconv.Atoi(port)
+	if err != nil || n < 1 || n > 65535 {
+		return fmt.Errorf("端口必须是 1-65535 之间的数字")
+	}
+	return nil
+}
+
+// validateOrigin 检查CORS来源是否为 scheme://host[:port] 格式
+func validateOrigin(origin string) error {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("应为 scheme://host[:port] 格式，如 https://example.com")
+	}
+	if u.Path != "" && u.Path != "/" {
+		return fmt.Errorf("来源不能包含路径")
+	}
+	return nil
+}
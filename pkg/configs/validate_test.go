@@ -0,0 +1,92 @@
+package configs
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validTestConfig() *AppConfig {
+	return &AppConfig{
+		Server: ServerConfig{
+			Port: "localhost:8080",
+			Mode: "release",
+			CORS: CORSConfig{
+				Enabled:      true,
+				AllowOrigins: []string{"http://localhost:3000", "https://example.com"},
+			},
+		},
+		Database: DatabaseConfig{Driver: "sqlite3", DSN: "file:ent.db", MaxIdleConns: 10, MaxOpenConns: 100},
+		Redis:    RedisConfig{Enable: true, Addr: "localhost:6379"},
+		JWT:      JWTConfig{SecretKey: strings.Repeat("k", MinJWTSecretLength)},
+	}
+}
+
+func TestValidateAcceptsValidConfig(t *testing.T) {
+	if err := validTestConfig().Validate(); err != nil {
+		t.Fatalf("expected valid config, got %v", err)
+	}
+}
+
+func TestValidateListsEveryProblem(t *testing.T) {
+	config := validTestConfig()
+	config.Server.Port = "8080"
+	config.Server.Mode = "prod"
+	config.Server.CORS.AllowOrigins = []string{"*", "example.com"}
+	config.Database.DSN = ""
+	config.Redis.Addr = "localhost"
+	config.JWT.SecretKey = "short"
+
+	err := config.Validate()
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("expected *ValidationError, got %v", err)
+	}
+
+	wantFields := []string{"server.port", "server.mode", `包含 "*"`, `"example.com"`, "database.dsn", "redis.addr", "jwt.secret_key"}
+	if len(validationErr.Problems) != len(wantFields) {
+		t.Fatalf("expected %d problems, got %d:\n%v", len(wantFields), len(validationErr.Problems), err)
+	}
+	for i, field := range wantFields {
+		if !strings.Contains(validationErr.Problems[i], field) {
+			t.Fatalf("problem %d = %q, want mention of %s", i, validationErr.Problems[i], field)
+		}
+	}
+}
+
+func TestValidateSubsystemRules(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(c *AppConfig)
+		want   string
+	}{
+		{"port out of range", func(c *AppConfig) { c.Server.Port = ":70000" }, "server.port"},
+		{"tls without certificate", func(c *AppConfig) { c.Server.TLS.Enabled = true }, "server.tls.cert_file"},
+		{"auto cert without domains", func(c *AppConfig) {
+			c.Server.TLS.Enabled = true
+			c.Server.TLS.AutoCert.Enabled = true
+		}, "server.tls.auto_cert.domains"},
+		{"empty origins", func(c *AppConfig) { c.Server.CORS.AllowOrigins = nil }, "server.cors.allow_origins"},
+		{"origin with path", func(c *AppConfig) { c.Server.CORS.AllowOrigins = []string{"https://example.com/app"} }, "路径"},
+		{"idle above open conns", func(c *AppConfig) { c.Database.MaxIdleConns = 200 }, "database.max_idle_conns"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := validTestConfig()
+			tc.mutate(config)
+			err := config.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error mentioning %s, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateSkipsDisabledSubsystems(t *testing.T) {
+	config := validTestConfig()
+	config.Redis = RedisConfig{Enable: false}
+	config.Server.CORS = CORSConfig{Enabled: true, AllowAllOrigins: true}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("disabled subsystems should not be validated, got %v", err)
+	}
+}
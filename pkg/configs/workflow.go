@@ -0,0 +1,156 @@
+package configs
+
+import "github.com/spf13/viper"
+
+// WorkflowConfig 工作流配置
+type WorkflowConfig struct {
+	MaxVersionsPerApplication int      `mapstructure:"max_versions_per_application"` // 每个应用保留的最大版本数（不含置顶版本），0表示不限制
+	RedactedKeys              []string `mapstructure:"redacted_keys"`                // 导出执行报告时需要脱敏的键名（忽略大小写和下划线，按后缀匹配）
+	// ApplicationDeleteMode 删除应用时对其节点、边和调度的处理方式：cascade 一并软删除，block 存在节点或边时拒绝删除
+	ApplicationDeleteMode string `mapstructure:"application_delete_mode"`
+	// BatchStatusAtomic 批量修改应用状态时是否整体原子执行：true 时任意一个应用转换失败则全部回滚，
+	// false 时每个应用在独立事务中转换，失败项记录在结果中不影响其他应用
+	BatchStatusAtomic bool `mapstructure:"batch_status_atomic"`
+	// DefaultNodeTimeout 节点未配置超时时间（timeout<=0）时执行引擎使用的超时时间，单位秒
+	DefaultNodeTimeout int `mapstructure:"default_node_timeout"`
+	// UniqueNames 为 true 时应用名称全局唯一、节点名称在所属应用内唯一，创建/修改时重名返回冲突错误，克隆时自动添加副本后缀
+	UniqueNames bool `mapstructure:"unique_names"`
+
+	CostEstimate CostEstimateConfig  `mapstructure:"cost_estimate"` // 执行成本预估配置
+	BatchSave    BatchSaveConfig     `mapstructure:"batch_save"`    // 批量保存限制
+	Secrets      SecretsConfig       `mapstructure:"secrets"`       // 应用密钥存储配置
+	Wait         ExecutionWaitConfig `mapstructure:"wait"`          // 执行接口同步等待结果的配置
+	EditLock     EditLockConfig      `mapstructure:"edit_lock"`     // 编辑与执行之间的隔离配置
+	// PayloadLimits 节点输入输出和执行上下文的大小限制
+	PayloadLimits PayloadLimitConfig `mapstructure:"payload_limits"`
+	// Queue 执行队列和工作池
+	Queue ExecutionQueueConfig `mapstructure:"queue"`
+	// SubWorkflow 子工作流节点的嵌套限制
+	SubWorkflow SubWorkflowConfig `mapstructure:"sub_workflow"`
+}
+
+// SubWorkflowConfig 子工作流节点配置。子工作流节点启动的执行通过父执行ID关联调用方，
+// 沿该链路检查嵌套深度和应用之间的循环调用
+type SubWorkflowConfig struct {
+	MaxDepth int `mapstructure:"max_depth"` // 最大嵌套深度（顶层执行为0），超出时子工作流节点失败
+}
+
+// ExecutionQueueConfig 执行队列配置。启用时执行接口只创建执行记录并入队，由工作池中的协程运行执行引擎；
+// Redis 可用时队列保存在 Redis 中，服务重启或实例崩溃后未开始和运行中断的执行会重新入队，否则使用进程内队列
+type ExecutionQueueConfig struct {
+	Enabled      bool `mapstructure:"enabled"`       // 是否启用
+	Workers      int  `mapstructure:"workers"`       // 工作协程数，即同时运行的执行数上限
+	Capacity     int  `mapstructure:"capacity"`      // 排队中的执行数上限，超出时拒绝新的执行；0表示Redis队列不限制，进程内队列使用1000
+	DrainTimeout int  `mapstructure:"drain_timeout"` // 关闭服务时等待进行中的执行完成的最长时间，单位秒
+}
+
+// PayloadLimitConfig 执行数据的大小限制，按JSON序列化后的字节数计算，0表示不限制
+type PayloadLimitConfig struct {
+	MaxNodeInput        int `mapstructure:"max_node_input"`        // 节点输入上限
+	MaxNodeOutput       int `mapstructure:"max_node_output"`       // 节点输出上限
+	MaxExecutionContext int `mapstructure:"max_execution_context"` // 执行上下文和最终输出的上限
+	// Policy 超出限制时的处理方式：truncate 以截断标记代替原数据并告警，fail 将节点或执行标记为失败
+	Policy string `mapstructure:"policy"`
+}
+
+// EditLockConfig 应用编辑锁配置。批量保存和创建执行时拍摄图快照都需要持有该锁，单位秒
+type EditLockConfig struct {
+	TTL         int `mapstructure:"ttl"`          // 锁的过期时间，持有者异常退出后锁在此之后自动释放
+	WaitTimeout int `mapstructure:"wait_timeout"` // 锁被占用时的最长等待时间，超时返回409
+	// BlockWhileRunning 为 true 时应用存在待执行或运行中的执行则拒绝批量保存；
+	// 为 false 时允许编辑，执行中的流程按开始时的快照继续运行
+	BlockWhileRunning bool `mapstructure:"block_while_running"`
+}
+
+// ExecutionWaitConfig 执行接口同步等待（wait=true）或 SSE 推送进度时的等待时间，单位秒
+type ExecutionWaitConfig struct {
+	DefaultTimeout int `mapstructure:"default_timeout"` // 请求未指定 timeout 时的等待时间
+	MaxTimeout     int `mapstructure:"max_timeout"`     // 请求可指定的最长等待时间
+}
+
+// SecretsConfig 应用密钥存储配置
+type SecretsConfig struct {
+	// EncryptionKey 加密密钥值的主密钥，使用其 SHA-256 摘要作为 AES-256-GCM 密钥；为空时密钥存储不可用。
+	// 修改后已保存的密钥将无法解密，需要重新设置
+	EncryptionKey string `mapstructure:"encryption_key"`
+}
+
+// BatchSaveConfig 批量保存的规模限制，节点/边的数量分别统计新增、更新和删除的总数
+type BatchSaveConfig struct {
+	MaxNodes int `mapstructure:"max_nodes"` // 单次批量保存允许的节点操作数上限
+	MaxEdges int `mapstructure:"max_edges"` // 单次批量保存允许的边操作数上限
+
+	LargeBatch LargeBatchSaveConfig `mapstructure:"large_batch"` // 大批量模式（分块提交）
+}
+
+// LargeBatchSaveConfig 大批量保存模式配置，该模式下按块拆分为多个事务依次提交，不再保证整体原子性
+type LargeBatchSaveConfig struct {
+	MaxNodes  int `mapstructure:"max_nodes"`  // 大批量模式的节点操作数上限
+	MaxEdges  int `mapstructure:"max_edges"`  // 大批量模式的边操作数上限
+	ChunkSize int `mapstructure:"chunk_size"` // 每个事务处理的最大操作数
+}
+
+// 删除应用的处理方式
+const (
+	ApplicationDeleteModeCascade = "cascade" // 一并软删除应用拥有的节点、边和调度
+	ApplicationDeleteModeBlock   = "block"   // 应用下仍有节点或边时拒绝删除
+)
+
+// CostEstimateConfig 执行成本预估配置
+type CostEstimateConfig struct {
+	Currency              string                `mapstructure:"currency"`                // 价格币种
+	DefaultModel          string                `mapstructure:"default_model"`           // 节点未配置模型时使用的模型
+	DefaultOutputTokens   int                   `mapstructure:"default_output_tokens"`   // 节点未配置 max_tokens 时预估的输出token数
+	DefaultLoopIterations int                   `mapstructure:"default_loop_iterations"` // 循环未配置 max_iterations 时预估的迭代次数
+	Pricing               map[string]LLMPricing `mapstructure:"pricing"`                 // 模型名称（小写） -> 价格
+}
+
+// LLMPricing 模型价格（每1000个token）
+type LLMPricing struct {
+	Input  float64 `mapstructure:"input"`  // 输入token单价
+	Output float64 `mapstructure:"output"` // 输出token单价
+}
+
+func setWorkflowConfigDefaults() {
+	viper.SetDefault("workflow.max_versions_per_application", 0)
+	viper.SetDefault("workflow.application_delete_mode", ApplicationDeleteModeCascade)
+	viper.SetDefault("workflow.batch_status_atomic", false)
+	viper.SetDefault("workflow.default_node_timeout", 30)
+	viper.SetDefault("workflow.unique_names", false)
+	viper.SetDefault("workflow.redacted_keys", []string{
+		"api_key", "secret", "secret_key", "access_key", "private_key",
+		"password", "token", "authorization", "cookie",
+	})
+
+	viper.SetDefault("workflow.secrets.encryption_key", "")
+
+	viper.SetDefault("workflow.wait.default_timeout", 30)
+	viper.SetDefault("workflow.wait.max_timeout", 120)
+
+	viper.SetDefault("workflow.edit_lock.ttl", 120)
+	viper.SetDefault("workflow.edit_lock.wait_timeout", 10)
+	viper.SetDefault("workflow.edit_lock.block_while_running", false)
+
+	viper.SetDefault("workflow.queue.enabled", true)
+	viper.SetDefault("workflow.queue.workers", 4)
+	viper.SetDefault("workflow.queue.capacity", 1000)
+	viper.SetDefault("workflow.queue.drain_timeout", 30)
+
+	viper.SetDefault("workflow.sub_workflow.max_depth", 5)
+
+	viper.SetDefault("workflow.payload_limits.max_node_input", 256*1024)
+	viper.SetDefault("workflow.payload_limits.max_node_output", 1024*1024)
+	viper.SetDefault("workflow.payload_limits.max_execution_context", 1024*1024)
+	viper.SetDefault("workflow.payload_limits.policy", "truncate")
+
+	viper.SetDefault("workflow.batch_save.max_nodes", 500)
+	viper.SetDefault("workflow.batch_save.max_edges", 1000)
+	viper.SetDefault("workflow.batch_save.large_batch.max_nodes", 5000)
+	viper.SetDefault("workflow.batch_save.large_batch.max_edges", 10000)
+	viper.SetDefault("workflow.batch_save.large_batch.chunk_size", 200)
+
+	viper.SetDefault("workflow.cost_estimate.currency", "USD")
+	viper.SetDefault("workflow.cost_estimate.default_model", "gpt-3.5-turbo")
+	viper.SetDefault("workflow.cost_estimate.default_output_tokens", 1000)
+	viper.SetDefault("workflow.cost_estimate.default_loop_iterations", 10)
+}
@@ -1,8 +1,8 @@
 package database
 
 import (
-	"bytes"
 	"context"
+	stdsql "database/sql"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -16,6 +16,7 @@ import (
 	"go-backend/pkg/configs"
 	"go-backend/pkg/database/drivers"
 	"go-backend/pkg/logging"
+	"go-backend/pkg/utils"
 
 	"entgo.io/ent/dialect/sql"
 )
@@ -61,15 +62,21 @@ func NewClient(config *configs.DatabaseConfig) (*database.Client, error) {
 		return nil, fmt.Errorf("unsupported database driver: %s. Supported drivers: %v", config.Driver, supportedDrivers)
 	}
 
+	if err := initIDGenerator(&config.IDGenerator); err != nil {
+		return nil, err
+	}
+
 	drv, err := sql.Open(config.Driver, config.DSN)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	db := drv.DB()
-	db.SetMaxIdleConns(config.MaxIdleConns)
-	db.SetMaxOpenConns(config.MaxOpenConns)
-	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	applyPoolSettings(drv.DB(), config)
+
+	if err := pingWithRetry(drv.DB(), config); err != nil {
+		drv.Close()
+		return nil, err
+	}
 
 	var client *database.Client
 	if config.Debug {
@@ -88,13 +95,15 @@ func NewClient(config *configs.DatabaseConfig) (*database.Client, error) {
 			}
 		} else {
 			// 如果没有配置自动迁移，则检查是否需要迁移
-			need, err := checkMigrationNeeded(client)
+			pending, err := checkMigrationNeeded(client)
 			if err != nil {
-				logger.Error("Migration check failed: %v", err)
-				panic("Migration check failed")
+				client.Close()
+				return nil, fmt.Errorf("migration check failed: %w", err)
 			}
-			if need != nil && *need {
-				panic("Database schema is not up to date, please run migrations, set `auto_migrate` to true or set `skip_migrate` to true in config")
+			if pending > 0 {
+				client.Close()
+				return nil, fmt.Errorf("database schema is not up to date (%d pending statements): review them with `db status`, "+
+					"apply with `db migrate` or start with `--migrate auto`; use `--migrate skip` to bypass the check", pending)
 			}
 		}
 	} else {
@@ -111,6 +120,74 @@ func NewClient(config *configs.DatabaseConfig) (*database.Client, error) {
 	return client, nil
 }
 
+// initIDGenerator 按配置初始化实体ID生成器，未配置纪元时使用默认纪元
+func initIDGenerator(config *configs.IDGeneratorConfig) error {
+	epoch := utils.DefaultIDEpoch
+	if config.Epoch != "" {
+		parsed, err := parseIDEpoch(config.Epoch)
+		if err != nil {
+			return err
+		}
+		epoch = parsed
+	}
+
+	generator, err := utils.InitIDGenerator(config.NodeID, epoch)
+	if err != nil {
+		return fmt.Errorf("invalid id generator config: %w", err)
+	}
+	if logger != nil {
+		logger.Info("ID generator initialized: node_id=%d, epoch=%s", generator.NodeID(), epoch.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// parseIDEpoch 解析ID生成器的纪元，支持 2006-01-02 和 RFC3339，日期按UTC解析
+func parseIDEpoch(value string) (time.Time, error) {
+	if epoch, err := time.Parse(time.RFC3339, value); err == nil {
+		return epoch, nil
+	}
+	epoch, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid id generator epoch %q: expected 2006-01-02 or RFC3339", value)
+	}
+	return epoch, nil
+}
+
+// applyPoolSettings 将连接池配置应用到ent底层的 *sql.DB 并输出生效的配置
+func applyPoolSettings(db *stdsql.DB, config *configs.DatabaseConfig) {
+	maxIdleConns := config.MaxIdleConns
+	// 最大空闲连接数不能超过最大打开连接数（database/sql 也会做同样的修正）
+	if config.MaxOpenConns > 0 && maxIdleConns > config.MaxOpenConns {
+		maxIdleConns = config.MaxOpenConns
+	}
+
+	db.SetMaxOpenConns(config.MaxOpenConns)
+	db.SetMaxIdleConns(maxIdleConns)
+	db.SetConnMaxLifetime(config.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(config.ConnMaxIdleTime)
+
+	if logger != nil {
+		logger.Info("Database pool settings: max_open_conns=%d, max_idle_conns=%d, conn_max_lifetime=%s, conn_max_idle_time=%s",
+			config.MaxOpenConns, maxIdleConns, config.ConnMaxLifetime, config.ConnMaxIdleTime)
+	}
+}
+
+// pingWithRetry 连接数据库，数据库尚未就绪时按配置的退避策略重试
+func pingWithRetry(db *stdsql.DB, config *configs.DatabaseConfig) error {
+	retry := config.ConnectRetry
+	attempts := max(retry.Attempts, 1)
+	err := utils.RetryWithBackoff(context.Background(), attempts, retry.Interval, retry.MaxInterval, db.PingContext,
+		func(attempt int, wait time.Duration, err error) {
+			if logger != nil {
+				logger.Warn("Database is not reachable (attempt %d/%d): %v, retrying in %s", attempt, attempts, err, wait)
+			}
+		})
+	if err != nil {
+		return fmt.Errorf("failed to connect to database after %d attempts: %w", attempts, err)
+	}
+	return nil
+}
+
 // MustNewClient 创建数据库客户端，失败时panic
 func MustNewClient(config *configs.DatabaseConfig) *database.Client {
 	client, err := NewClient(config)
@@ -206,50 +283,42 @@ func ValidateDriver(driverName string) error {
 	return nil
 }
 
-// checkMigrationNeeded 检查数据库是否需要迁移
-// 如果需要迁移，将输出迁移SQL语句到文件
-func checkMigrationNeeded(client *database.Client) (*bool, error) {
-	ctx := context.Background()
-
-	var needMigration bool
-	// 使用WriteTo方法检查是否有待执行的迁移
-	var buf bytes.Buffer
-	if err := client.Schema.WriteTo(ctx, &buf); err != nil {
-		return nil, fmt.Errorf("failed to check migration status: %w", err)
+// checkMigrationNeeded 检查是否有待执行的迁移，返回待执行的语句数量
+// 有待执行的迁移时将SQL保存到 migration 目录，便于运维人员审阅
+func checkMigrationNeeded(client *database.Client) (int, error) {
+	statements, err := migrationDiff(context.Background(), client)
+	if err != nil {
+		return 0, err
 	}
 
-	migrationSQL := buf.String()
-	if migrationSQL != "" {
-		needMigration = true
-
-		// 创建migration目录
-		migrationDir := "migration"
-		if err := os.MkdirAll(migrationDir, 0755); err != nil {
-			return nil, fmt.Errorf("failed to create migration directory: %w", err)
-		}
-
-		// 生成文件名，格式：migration_YYYYMMDD_HHMMSS.sql
-		timestamp := time.Now().Format("20060102_150405")
-		fileName := fmt.Sprintf("migration_%s.sql", timestamp)
-		filePath := filepath.Join(migrationDir, fileName)
-
-		// 写入SQL文件
-		if err := os.WriteFile(filePath, []byte(migrationSQL), 0644); err != nil {
-			return nil, fmt.Errorf("failed to write migration file: %w", err)
-		}
-
-		if logger != nil {
-			logger.Error("Database migration needed. Migration SQL saved to: %s", filePath)
-		}
-	} else {
-		needMigration = false
+	if len(statements) == 0 {
 		// 如果没有迁移SQL，说明数据库是最新的
 		if logger != nil {
 			logger.Info("Database schema is up to date, no migration needed")
 		}
+		return 0, nil
 	}
 
-	return &needMigration, nil
+	// 创建migration目录
+	migrationDir := "migration"
+	if err := os.MkdirAll(migrationDir, 0755); err != nil {
+		return 0, fmt.Errorf("failed to create migration directory: %w", err)
+	}
+
+	// 生成文件名，格式：migration_YYYYMMDD_HHMMSS.sql
+	timestamp := time.Now().Format("20060102_150405")
+	fileName := fmt.Sprintf("migration_%s.sql", timestamp)
+	filePath := filepath.Join(migrationDir, fileName)
+
+	// 写入SQL文件
+	if err := os.WriteFile(filePath, []byte(strings.Join(statements, "\n")+"\n"), 0644); err != nil {
+		return 0, fmt.Errorf("failed to write migration file: %w", err)
+	}
+
+	if logger != nil {
+		logger.Error("Database migration needed. Migration SQL saved to: %s", filePath)
+	}
+	return len(statements), nil
 }
 
 // startConnectionCheck 启动数据库连接检查协程
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/pkg/configs"
+)
+
+// fakeDriver 无需真实数据库即可打开连接的测试驱动
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func init() {
+	stdsql.Register("fake-pool-test", fakeDriver{})
+}
+
+func TestApplyPoolSettings(t *testing.T) {
+	db, err := stdsql.Open("fake-pool-test", "")
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	defer db.Close()
+
+	applyPoolSettings(db, &configs.DatabaseConfig{
+		MaxOpenConns:    5,
+		MaxIdleConns:    2,
+		ConnMaxLifetime: time.Hour,
+		ConnMaxIdleTime: time.Minute,
+	})
+
+	if got := db.Stats().MaxOpenConnections; got != 5 {
+		t.Fatalf("expected MaxOpenConnections 5, got %d", got)
+	}
+
+	// 打开多个连接后全部归还，空闲连接数应被限制为 MaxIdleConns
+	ctx := context.Background()
+	conns := make([]*stdsql.Conn, 0, 4)
+	for i := 0; i < 4; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatalf("failed to get connection: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	for _, conn := range conns {
+		conn.Close()
+	}
+
+	stats := db.Stats()
+	if stats.Idle != 2 {
+		t.Fatalf("expected 2 idle connections, got %d", stats.Idle)
+	}
+	if stats.MaxIdleClosed != 2 {
+		t.Fatalf("expected 2 connections closed by idle limit, got %d", stats.MaxIdleClosed)
+	}
+}
+
+func TestApplyPoolSettingsClampsIdleConns(t *testing.T) {
+	db, err := stdsql.Open("fake-pool-test", "")
+	if err != nil {
+		t.Fatalf("failed to open fake database: %v", err)
+	}
+	defer db.Close()
+
+	applyPoolSettings(db, &configs.DatabaseConfig{MaxOpenConns: 1, MaxIdleConns: 10})
+
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection: %v", err)
+	}
+	conn.Close()
+
+	if got := db.Stats().Idle; got != 1 {
+		t.Fatalf("expected 1 idle connection, got %d", got)
+	}
+}
@@ -26,6 +26,9 @@ type ExportConfig struct {
 	IncludeEntities []string
 	// ExcludeFields 排除的字段名称列表（支持公共字段如id, create_time等）
 	ExcludeFields []string
+	// Filters 按实体名称（不区分大小写）配置的过滤条件，同一实体的多个条件为 AND 关系，
+	// 如 {"WorkflowApplication": {{Field: "status", Value: "published"}}} 只导出已发布的应用
+	Filters map[string][]ExportFilter
 }
 
 // EntityExportResult 单个实体导出结果
@@ -93,7 +96,22 @@ func ExportAllTables(client *database.Client, config *ExportConfig) (*ExportResu
 			if len(config.ExcludeFields) > 0 {
 				logger.Info("排除字段: %v", config.ExcludeFields)
 			}
+			if len(config.Filters) > 0 {
+				logger.Info("过滤条件: %v", config.Filters)
+			}
+		}
+	}
+
+	// 使用反射获取client的所有字段
+	clientValue := reflect.ValueOf(client).Elem()
+	clientType := clientValue.Type()
+
+	// 在写入任何文件之前校验过滤条件，未知的实体或字段直接报错
+	if err := validateExportFilters(clientValue, config.Filters); err != nil {
+		if logger != nil {
+			logger.Error("导出过滤条件无效: %v", err)
 		}
+		return nil, err
 	}
 
 	// 确保输出目录存在
@@ -109,10 +127,6 @@ func ExportAllTables(client *database.Client, config *ExportConfig) (*ExportResu
 		Results:         make([]EntityExportResult, 0),
 	}
 
-	// 使用反射获取client的所有字段
-	clientValue := reflect.ValueOf(client).Elem()
-	clientType := clientValue.Type()
-
 	for i := 0; i < clientValue.NumField(); i++ {
 		field := clientValue.Field(i)
 		fieldType := clientType.Field(i)
@@ -196,7 +210,11 @@ func exportSingleEntity(clientInterface interface{}, entityName string, config *
 		return result
 	}
 
-	queryValue := queryResults[0]
+	queryValue, err := applyExportFilters(queryResults[0], entityName, entityFilters(config.Filters, entityName))
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
 
 	// 调用All方法获取所有记录
 	allMethod := queryValue.MethodByName("All")
@@ -240,7 +258,6 @@ func exportSingleEntity(clientInterface interface{}, entityName string, config *
 
 	// 序列化为JSON
 	var jsonData []byte
-	var err error
 
 	if config.PrettyFormat {
 		jsonData, err = json.MarshalIndent(records, "", "  ")
@@ -0,0 +1,116 @@
+package database
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	database "go-backend/database/ent"
+)
+
+// ArchiveManifestName 归档中记录导出结果的清单文件名
+const ArchiveManifestName = "manifest.json"
+
+// ExportToArchive 导出所有表并以 tar.gz 格式写入 w，每个实体一个 JSON 文件，另附 manifest.json 记录导出结果。
+// 导出先写入临时目录，config.OutputDir 被忽略；返回结果中的 FilePath 为归档内的文件名。
+// 部分实体导出失败时仍然写出归档，由调用方根据 FailedCount 决定如何处理
+func ExportToArchive(client *database.Client, config *ExportConfig, w io.Writer) (*ExportResult, error) {
+	if w == nil {
+		return nil, fmt.Errorf("archive writer is nil")
+	}
+
+	tempDir, err := os.MkdirTemp("", "db-export-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	archiveConfig := getDefaultExportConfig()
+	if config != nil {
+		copied := *config
+		archiveConfig = &copied
+	}
+	if archiveConfig.Context == nil {
+		archiveConfig.Context = context.Background()
+	}
+	archiveConfig.OutputDir = tempDir
+
+	result, err := ExportAllTables(client, archiveConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := time.Now()
+
+	for i := range result.Results {
+		entity := &result.Results[i]
+		if !entity.Success {
+			continue
+		}
+		name := filepath.Base(entity.FilePath)
+		if err := addFileToArchive(tarWriter, entity.FilePath, name, modTime); err != nil {
+			return nil, fmt.Errorf("failed to archive entity %s: %w", entity.EntityName, err)
+		}
+		entity.FilePath = name
+	}
+	result.OutputDirectory = ""
+
+	manifest, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal archive manifest: %w", err)
+	}
+	if err := addBytesToArchive(tarWriter, ArchiveManifestName, manifest, modTime); err != nil {
+		return nil, fmt.Errorf("failed to archive manifest: %w", err)
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize tar archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize gzip archive: %w", err)
+	}
+
+	if logger != nil {
+		logger.Info("导出归档完成: 成功=%d个实体, 失败=%d个", result.SuccessCount, result.FailedCount)
+	}
+
+	return result, nil
+}
+
+// addFileToArchive 将文件以 name 为名写入归档
+func addFileToArchive(tw *tar.Writer, path, name string, modTime time.Time) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: modTime}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, file)
+	return err
+}
+
+// addBytesToArchive 将内存中的数据以 name 为名写入归档
+func addBytesToArchive(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: modTime}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
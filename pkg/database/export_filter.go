@@ -0,0 +1,339 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// 导出过滤操作符
+const (
+	FilterOpEq       = "eq"
+	FilterOpNeq      = "neq"
+	FilterOpGt       = "gt"
+	FilterOpGte      = "gte"
+	FilterOpLt       = "lt"
+	FilterOpLte      = "lte"
+	FilterOpIn       = "in"
+	FilterOpContains = "contains"
+)
+
+// ExportFilter 导出过滤条件，按 字段 操作符 值 筛选实体的记录
+type ExportFilter struct {
+	// Field 字段名，使用数据库列名（如 status、create_time），也可以使用实体结构体字段名
+	Field string
+	// Op 操作符：eq, neq, gt, gte, lt, lte, in, contains，为空时为 eq
+	Op string
+	// Value 比较值，按字段类型转换；时间字段支持 RFC3339、2006-01-02 和 2006-01-02 15:04:05，
+	// in 操作的值为切片或逗号分隔的字符串
+	Value interface{}
+}
+
+// filterTimeLayouts 时间字段过滤值支持的格式
+var filterTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// filterSymbolOps CLI 过滤表达式中的符号操作符，较长的符号在前以免被前缀匹配
+var filterSymbolOps = []struct {
+	symbol string
+	op     string
+}{
+	{">=", FilterOpGte},
+	{"<=", FilterOpLte},
+	{"!=", FilterOpNeq},
+	{"~=", FilterOpContains},
+	{"=", FilterOpEq},
+	{">", FilterOpGt},
+	{"<", FilterOpLt},
+}
+
+// ParseExportFilter 解析命令行过滤表达式，格式为 实体.字段<操作符>值，
+// 如 WorkflowApplication.status=published、WorkflowApplication.create_time>=2024-01-01。
+// 支持的操作符：= != > >= < <= ~=（包含）
+func ParseExportFilter(expr string) (string, ExportFilter, error) {
+	dot := strings.Index(expr, ".")
+	if dot <= 0 {
+		return "", ExportFilter{}, fmt.Errorf("invalid export filter %q: expected Entity.field<op>value", expr)
+	}
+	entityName := strings.TrimSpace(expr[:dot])
+	rest := expr[dot+1:]
+
+	for _, candidate := range filterSymbolOps {
+		index := strings.Index(rest, candidate.symbol)
+		if index <= 0 {
+			continue
+		}
+		// 字段名中不含操作符字符，取最早出现的操作符位置，避免值中的符号被误判
+		if strings.ContainsAny(rest[:index], "=!<>~") {
+			continue
+		}
+		return entityName, ExportFilter{
+			Field: strings.TrimSpace(rest[:index]),
+			Op:    candidate.op,
+			Value: strings.TrimSpace(rest[index+len(candidate.symbol):]),
+		}, nil
+	}
+	return "", ExportFilter{}, fmt.Errorf("invalid export filter %q: missing operator", expr)
+}
+
+// findEntityClient 按名称（不区分大小写）查找实体客户端
+func findEntityClient(clientValue reflect.Value, entityName string) (reflect.Value, bool) {
+	clientType := clientValue.Type()
+	for i := 0; i < clientValue.NumField(); i++ {
+		field := clientValue.Field(i)
+		if !field.CanInterface() || field.Kind() != reflect.Ptr {
+			continue
+		}
+		typeName := clientType.Field(i).Type.Elem().Name()
+		if !strings.HasSuffix(typeName, "Client") {
+			continue
+		}
+		if strings.EqualFold(strings.TrimSuffix(typeName, "Client"), entityName) {
+			return field, true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// validateExportFilters 导出前校验过滤条件引用的实体和字段都存在
+func validateExportFilters(clientValue reflect.Value, filters map[string][]ExportFilter) error {
+	for entityName, entityFilters := range filters {
+		entityClient, ok := findEntityClient(clientValue, entityName)
+		if !ok {
+			return fmt.Errorf("export filter references unknown entity %q", entityName)
+		}
+		queryValue := entityClient.MethodByName("Query").Call(nil)[0]
+		if _, err := buildExportPredicates(queryValue, entityName, entityFilters); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// entityFilters 获取实体的过滤条件，实体名称不区分大小写
+func entityFilters(filters map[string][]ExportFilter, entityName string) []ExportFilter {
+	var result []ExportFilter
+	for name, entityFilters := range filters {
+		if strings.EqualFold(name, entityName) {
+			result = append(result, entityFilters...)
+		}
+	}
+	return result
+}
+
+// applyExportFilters 通过反射调用查询构建器的 Where 方法应用过滤条件，返回过滤后的查询
+func applyExportFilters(queryValue reflect.Value, entityName string, filters []ExportFilter) (reflect.Value, error) {
+	if len(filters) == 0 {
+		return queryValue, nil
+	}
+	predicates, err := buildExportPredicates(queryValue, entityName, filters)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return queryValue.MethodByName("Where").CallSlice([]reflect.Value{predicates})[0], nil
+}
+
+// buildExportPredicates 将过滤条件转换为查询构建器 Where 方法接受的谓词切片
+func buildExportPredicates(queryValue reflect.Value, entityName string, filters []ExportFilter) (reflect.Value, error) {
+	whereMethod := queryValue.MethodByName("Where")
+	if !whereMethod.IsValid() {
+		return reflect.Value{}, fmt.Errorf("entity %s: Where method not found", entityName)
+	}
+	sliceType := whereMethod.Type().In(0)
+	predicateType := sliceType.Elem()
+	if !reflect.TypeOf(func(*sql.Selector) {}).ConvertibleTo(predicateType) {
+		return reflect.Value{}, fmt.Errorf("entity %s: unsupported predicate type %s", entityName, predicateType)
+	}
+
+	fields := entityFieldTypes(queryValue)
+	predicates := reflect.MakeSlice(sliceType, 0, len(filters))
+	for _, filter := range filters {
+		column, fieldType, ok := lookupEntityField(fields, filter.Field)
+		if !ok {
+			return reflect.Value{}, fmt.Errorf("entity %s: unknown filter field %q", entityName, filter.Field)
+		}
+		predicate, err := buildExportPredicate(column, fieldType, filter)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("entity %s: %w", entityName, err)
+		}
+		predicates = reflect.Append(predicates, reflect.ValueOf(predicate).Convert(predicateType))
+	}
+	return predicates, nil
+}
+
+// entityFieldTypes 根据 All 方法返回的实体结构体获取列名到字段类型的映射
+func entityFieldTypes(queryValue reflect.Value) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	allMethod := queryValue.MethodByName("All")
+	if !allMethod.IsValid() || allMethod.Type().NumOut() == 0 {
+		return fields
+	}
+	entityType := allMethod.Type().Out(0)
+	for entityType.Kind() == reflect.Slice || entityType.Kind() == reflect.Ptr {
+		entityType = entityType.Elem()
+	}
+	if entityType.Kind() != reflect.Struct {
+		return fields
+	}
+
+	for i := 0; i < entityType.NumField(); i++ {
+		field := entityType.Field(i)
+		if !field.IsExported() || field.Anonymous || field.Name == "Edges" {
+			continue
+		}
+		column := strings.Split(field.Tag.Get("json"), ",")[0]
+		if column == "" || column == "-" {
+			continue
+		}
+		fields[column] = field
+	}
+	return fields
+}
+
+// lookupEntityField 按列名或结构体字段名（不区分大小写）查找字段
+func lookupEntityField(fields map[string]reflect.StructField, name string) (string, reflect.Type, bool) {
+	if field, ok := fields[name]; ok {
+		return name, field.Type, true
+	}
+	for column, field := range fields {
+		if strings.EqualFold(column, name) || strings.EqualFold(field.Name, name) {
+			return column, field.Type, true
+		}
+	}
+	return "", nil, false
+}
+
+// buildExportPredicate 构建单个过滤条件的谓词
+func buildExportPredicate(column string, fieldType reflect.Type, filter ExportFilter) (func(*sql.Selector), error) {
+	op := strings.ToLower(strings.TrimSpace(filter.Op))
+	if op == "" {
+		op = FilterOpEq
+	}
+
+	if op == FilterOpIn {
+		values, err := filterInValues(filter.Value, fieldType)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", column, err)
+		}
+		return func(s *sql.Selector) { s.Where(sql.In(s.C(column), values...)) }, nil
+	}
+
+	if op == FilterOpContains {
+		if fieldType.Kind() != reflect.String {
+			return nil, fmt.Errorf("field %s: contains requires a string field", column)
+		}
+		substr := fmt.Sprint(filter.Value)
+		return func(s *sql.Selector) { s.Where(sql.Contains(s.C(column), substr)) }, nil
+	}
+
+	value, err := convertFilterValue(filter.Value, fieldType)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", column, err)
+	}
+
+	var compare func(string, interface{}) *sql.Predicate
+	switch op {
+	case FilterOpEq:
+		compare = sql.EQ
+	case FilterOpNeq:
+		compare = sql.NEQ
+	case FilterOpGt:
+		compare = sql.GT
+	case FilterOpGte:
+		compare = sql.GTE
+	case FilterOpLt:
+		compare = sql.LT
+	case FilterOpLte:
+		compare = sql.LTE
+	default:
+		return nil, fmt.Errorf("field %s: unknown filter operator %q", column, filter.Op)
+	}
+	return func(s *sql.Selector) { s.Where(compare(s.C(column), value)) }, nil
+}
+
+// filterInValues 转换 in 操作的值列表
+func filterInValues(value interface{}, fieldType reflect.Type) ([]interface{}, error) {
+	var raw []interface{}
+	switch v := value.(type) {
+	case string:
+		for _, item := range strings.Split(v, ",") {
+			raw = append(raw, strings.TrimSpace(item))
+		}
+	case []interface{}:
+		raw = v
+	case []string:
+		for _, item := range v {
+			raw = append(raw, item)
+		}
+	default:
+		raw = []interface{}{value}
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("in requires at least one value")
+	}
+
+	values := make([]interface{}, 0, len(raw))
+	for _, item := range raw {
+		converted, err := convertFilterValue(item, fieldType)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, converted)
+	}
+	return values, nil
+}
+
+// convertFilterValue 将过滤值转换为字段类型对应的数据库参数
+func convertFilterValue(value interface{}, fieldType reflect.Type) (interface{}, error) {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		switch v := value.(type) {
+		case time.Time:
+			return v, nil
+		case string:
+			for _, layout := range filterTimeLayouts {
+				if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), time.Local); err == nil {
+					return t, nil
+				}
+			}
+		}
+		return nil, fmt.Errorf("invalid time value %v", value)
+	}
+
+	text := strings.TrimSpace(fmt.Sprint(value))
+	switch fieldType.Kind() {
+	case reflect.String:
+		return fmt.Sprint(value), nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool value %v", value)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid integer value %v", value)
+		}
+		return n, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid unsigned integer value %v", value)
+		}
+		return n, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number value %v", value)
+		}
+		return f, nil
+	}
+	return nil, fmt.Errorf("field type %s does not support filtering", fieldType)
+}
@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go-backend/database/ent/enttest"
+	_ "go-backend/database/ent/runtime"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestExportAllTablesWithFilters(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	defer client.Close()
+
+	rows := []struct {
+		id      int
+		status  string
+		created time.Time
+	}{
+		{1, "published", time.Date(2024, 1, 10, 0, 0, 0, 0, time.Local)},
+		{2, "published", time.Date(2024, 3, 10, 0, 0, 0, 0, time.Local)},
+		{3, "draft", time.Date(2024, 3, 12, 0, 0, 0, 0, time.Local)},
+		{4, "published", time.Date(2024, 5, 1, 0, 0, 0, 0, time.Local)},
+	}
+	for _, row := range rows {
+		_, err := client.ExecContext(ctx,
+			"INSERT INTO workflow_applications (id, create_time, update_time, name, client_secret, status, version) VALUES (?, ?, ?, ?, ?, ?, 1)",
+			row.id, row.created, row.created, fmt.Sprintf("app-%d", row.id), fmt.Sprintf("secret-%d", row.id), row.status)
+		if err != nil {
+			t.Fatalf("insert application %d: %v", row.id, err)
+		}
+	}
+
+	outputDir := t.TempDir()
+	result, err := ExportAllTables(client, &ExportConfig{
+		OutputDir:       outputDir,
+		Context:         ctx,
+		IncludeEntities: []string{"WorkflowApplication"},
+		Filters: map[string][]ExportFilter{
+			"workflowapplication": {
+				{Field: "status", Value: "published"},
+				{Field: "create_time", Op: FilterOpGte, Value: "2024-02-01"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+	if result.SuccessCount != 1 || result.Results[0].RecordCount != 2 {
+		t.Fatalf("unexpected export result: %+v", result)
+	}
+
+	data, err := os.ReadFile(result.Results[0].FilePath)
+	if err != nil {
+		t.Fatalf("read export file: %v", err)
+	}
+	var exported []map[string]interface{}
+	if err := json.Unmarshal(data, &exported); err != nil {
+		t.Fatalf("decode export file: %v", err)
+	}
+	if len(exported) != 2 || exported[0]["status"] != "published" || exported[1]["status"] != "published" {
+		t.Fatalf("unexpected exported records: %v", exported)
+	}
+
+	_, err = ExportAllTables(client, &ExportConfig{
+		OutputDir: outputDir,
+		Context:   ctx,
+		Filters:   map[string][]ExportFilter{"WorkflowApplication": {{Field: "state", Value: "published"}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), `unknown filter field "state"`) {
+		t.Fatalf("expected unknown field error, got %v", err)
+	}
+
+	_, err = ExportAllTables(client, &ExportConfig{
+		OutputDir: outputDir,
+		Context:   ctx,
+		Filters:   map[string][]ExportFilter{"Nope": {{Field: "id", Value: 1}}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "unknown entity") {
+		t.Fatalf("expected unknown entity error, got %v", err)
+	}
+}
+
+func TestParseExportFilter(t *testing.T) {
+	cases := []struct {
+		expr   string
+		entity string
+		filter ExportFilter
+	}{
+		{"WorkflowApplication.status=published", "WorkflowApplication", ExportFilter{Field: "status", Op: FilterOpEq, Value: "published"}},
+		{"User.create_time >= 2024-01-01", "User", ExportFilter{Field: "create_time", Op: FilterOpGte, Value: "2024-01-01"}},
+		{"User.name~=a>b", "User", ExportFilter{Field: "name", Op: FilterOpContains, Value: "a>b"}},
+		{"User.age<30", "User", ExportFilter{Field: "age", Op: FilterOpLt, Value: "30"}},
+	}
+	for _, c := range cases {
+		entity, filter, err := ParseExportFilter(c.expr)
+		if err != nil || entity != c.entity || filter != c.filter {
+			t.Errorf("ParseExportFilter(%q) = %q, %+v, %v", c.expr, entity, filter, err)
+		}
+	}
+
+	for _, expr := range []string{"status=published", "User.status"} {
+		if _, _, err := ParseExportFilter(expr); err == nil {
+			t.Errorf("ParseExportFilter(%q) expected error", expr)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	database "go-backend/database/ent"
+	"go-backend/pkg/configs"
 )
 
 // ImportConfig 导入配置
@@ -29,6 +30,10 @@ type ImportConfig struct {
 	SkipExisting bool
 	// ClearBeforeImport 导入前是否清空表
 	ClearBeforeImport bool
+	// Driver 数据库驱动名（postgres、mysql、sqlite3），用于导入后调整自增序列，为空时不调整
+	Driver string
+	// SkipSequenceReset 导入后是否跳过自增序列调整
+	SkipSequenceReset bool
 }
 
 // EntityImportResult 单个实体导入结果
@@ -53,6 +58,8 @@ type ImportResult struct {
 	ImportedRecords int                  `json:"imported_records"`
 	SkippedRecords  int                  `json:"skipped_records"`
 	Results         []EntityImportResult `json:"results"`
+	// SequenceAdjustments 导入后调整过的自增序列
+	SequenceAdjustments []SequenceAdjustment `json:"sequence_adjustments,omitempty"`
 }
 
 // getDefaultImportConfig 获取默认导入配置
@@ -183,6 +190,7 @@ func ImportAllTables(client *database.Client, config *ImportConfig) (*ImportResu
 	}
 
 	// 处理每个JSON文件
+	importedEntities := make([]string, 0, len(files))
 	for _, filePath := range files {
 		fileName := filepath.Base(filePath)
 		entityName := strings.TrimSuffix(fileName, ".json")
@@ -209,6 +217,9 @@ func ImportAllTables(client *database.Client, config *ImportConfig) (*ImportResu
 
 		if entityResult.Success {
 			result.SuccessCount++
+			if entityResult.SuccessCount > 0 {
+				importedEntities = append(importedEntities, entityName)
+			}
 			if logger != nil {
 				logger.Info("实体 %s 导入成功: 总计=%d条, 成功=%d条, 跳过=%d条, 失败=%d条",
 					entityName, entityResult.RecordCount, entityResult.SuccessCount,
@@ -239,6 +250,18 @@ func ImportAllTables(client *database.Client, config *ImportConfig) (*ImportResu
 		return result, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	// 调整自增序列，MySQL 的 ALTER TABLE 会隐式提交事务，因此在提交之后执行
+	if !config.SkipSequenceReset && config.Driver != "" && len(importedEntities) > 0 {
+		adjustments, seqErr := resetImportedSequences(config.Context, client, config.Driver, importedEntities, entityClients)
+		result.SequenceAdjustments = adjustments
+		if seqErr != nil {
+			if logger != nil {
+				logger.Error("导入后调整自增序列失败: %v", seqErr)
+			}
+			return result, fmt.Errorf("data imported but sequence reset failed: %w", seqErr)
+		}
+	}
+
 	// 记录导入统计信息
 	if logger != nil {
 		logger.Info("导入完成统计: 总计=%d个实体, 成功=%d个, 失败=%d个, 总记录=%d条, 导入记录=%d条, 跳过记录=%d条",
@@ -699,6 +722,7 @@ func ImportAllTablesGlobal(inputDir string) (*ImportResult, error) {
 		BatchSize:         100,
 		SkipExisting:      false,
 		ClearBeforeImport: false,
+		Driver:            configs.GetConfig().Database.Driver,
 	}
 
 	if config.InputDir == "" {
@@ -714,5 +738,17 @@ func ImportSpecificTablesGlobal(entityNames []string, inputDir string) (*ImportR
 		return nil, fmt.Errorf("database client is not initialized, call InitInstance first")
 	}
 
-	return ImportSpecificTables(Client, entityNames, inputDir)
+	config := &ImportConfig{
+		InputDir:        inputDir,
+		Context:         context.Background(),
+		BatchSize:       100,
+		IncludeEntities: entityNames,
+		Driver:          configs.GetConfig().Database.Driver,
+	}
+
+	if config.InputDir == "" {
+		config.InputDir = "./exports"
+	}
+
+	return ImportAllTables(Client, config)
 }
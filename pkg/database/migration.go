@@ -0,0 +1,77 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	database "go-backend/database/ent"
+)
+
+// MigrationStatusReport 数据库结构迁移状态
+type MigrationStatusReport struct {
+	UpToDate   bool     `json:"upToDate"`   // 数据库结构是否为最新
+	Statements []string `json:"statements"` // 待执行的DDL语句
+}
+
+// MigrationDiff 返回将数据库结构更新到最新所需执行的DDL语句（不会执行）
+func MigrationDiff(ctx context.Context) ([]string, error) {
+	client := GetInstanceUnsafe()
+	if client == nil {
+		return nil, fmt.Errorf("database client not initialized")
+	}
+	return migrationDiff(ctx, client)
+}
+
+// MigrationStatus 报告数据库结构是否为最新，以及待执行的DDL语句
+func MigrationStatus(ctx context.Context) (*MigrationStatusReport, error) {
+	statements, err := MigrationDiff(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &MigrationStatusReport{
+		UpToDate:   len(statements) == 0,
+		Statements: statements,
+	}, nil
+}
+
+// migrationDiff 通过 ent 的 WriteTo 生成迁移SQL并拆分为语句
+func migrationDiff(ctx context.Context, client *database.Client) ([]string, error) {
+	var buf bytes.Buffer
+	if err := client.Schema.WriteTo(ctx, &buf); err != nil {
+		return nil, fmt.Errorf("failed to compute migration diff: %w", err)
+	}
+	return parseMigrationStatements(buf.String()), nil
+}
+
+// parseMigrationStatements 将迁移SQL拆分为独立语句，忽略事务包装与注释
+func parseMigrationStatements(migrationSQL string) []string {
+	statements := make([]string, 0)
+	var current strings.Builder
+	for _, line := range strings.Split(migrationSQL, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "--") {
+			continue
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(line)
+		if !strings.HasSuffix(line, ";") {
+			continue
+		}
+
+		stmt := current.String()
+		current.Reset()
+		switch strings.ToUpper(strings.TrimSuffix(stmt, ";")) {
+		case "BEGIN", "COMMIT":
+			continue
+		}
+		statements = append(statements, stmt)
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	database "go-backend/database/ent"
+
+	"entgo.io/ent/dialect/sql"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestParseMigrationStatements(t *testing.T) {
+	input := "BEGIN;\n-- create \"users\" table\nCREATE TABLE `users` (\n  `id` integer\n);\nALTER TABLE `users` ADD COLUMN `name` text;\nCOMMIT;\n"
+	want := []string{
+		"CREATE TABLE `users` ( `id` integer );",
+		"ALTER TABLE `users` ADD COLUMN `name` text;",
+	}
+	if got := parseMigrationStatements(input); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected statements: %q", got)
+	}
+	if got := parseMigrationStatements(""); len(got) != 0 {
+		t.Fatalf("expected no statements, got %q", got)
+	}
+}
+
+func TestMigrationDiff(t *testing.T) {
+	drv, err := sql.Open("sqlite3", "file:migration_diff?mode=memory&cache=shared&_fk=1")
+	if err != nil {
+		t.Fatalf("failed to open sqlite: %v", err)
+	}
+	client := database.NewClient(database.Driver(drv))
+	defer client.Close()
+	ctx := context.Background()
+
+	statements, err := migrationDiff(ctx, client)
+	if err != nil {
+		t.Fatalf("migrationDiff failed: %v", err)
+	}
+	if len(statements) == 0 {
+		t.Fatal("expected pending statements for empty database")
+	}
+
+	if err := client.Schema.Create(ctx); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	statements, err = migrationDiff(ctx, client)
+	if err != nil {
+		t.Fatalf("migrationDiff failed: %v", err)
+	}
+	if len(statements) != 0 {
+		t.Fatalf("expected no pending statements after migration, got %d", len(statements))
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+
+	"go-backend/shared/models"
+)
+
+// PageQuery 可分页的 ent 查询，所有生成的 *ent.XxxQuery 均实现
+type PageQuery[T any, Q any] interface {
+	Count(ctx context.Context) (int, error)
+	Offset(offset int) Q
+	Limit(limit int) Q
+	All(ctx context.Context) ([]T, error)
+}
+
+// Paginate 统计总数后按页码查询一页数据，并构建分页信息。
+// 排序和过滤条件需要在调用前设置到 query 上；page 小于1时按第1页处理，pageSize 小于1时按每页10条处理
+func Paginate[T any, Q PageQuery[T, Q]](ctx context.Context, query Q, page, pageSize int) ([]T, models.Pagination, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+
+	total, err := query.Count(ctx)
+	if err != nil {
+		return nil, models.Pagination{}, err
+	}
+
+	items, err := query.Offset((page - 1) * pageSize).Limit(pageSize).All(ctx)
+	if err != nil {
+		return nil, models.Pagination{}, err
+	}
+	return items, NewPagination(page, pageSize, total), nil
+}
+
+// NewPagination 根据页码、每页数量和总数构建分页信息
+func NewPagination(page, pageSize, total int) models.Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return models.Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      int64(total),
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
+}
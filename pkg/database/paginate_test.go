@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	database "go-backend/database/ent"
+	"go-backend/database/ent/enttest"
+	"go-backend/database/ent/permission"
+	_ "go-backend/database/ent/runtime"
+	"go-backend/shared/models"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestPaginate(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	defer client.Close()
+
+	for i := 1; i <= 5; i++ {
+		if _, err := client.ExecContext(ctx, "INSERT INTO sys_permissions (id, name, action, create_time, update_time, is_public) VALUES (?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP, false)",
+			i, fmt.Sprintf("perm-%d", i), fmt.Sprintf("perm:%d", i)); err != nil {
+			t.Fatalf("insert failed: %v", err)
+		}
+	}
+
+	cases := []struct {
+		page, pageSize int
+		wantIDs        []uint64
+		want           models.Pagination
+	}{
+		{1, 2, []uint64{1, 2}, models.Pagination{Page: 1, PageSize: 2, Total: 5, TotalPages: 3, HasNext: true}},
+		{3, 2, []uint64{5}, models.Pagination{Page: 3, PageSize: 2, Total: 5, TotalPages: 3, HasPrev: true}},
+		{4, 2, nil, models.Pagination{Page: 4, PageSize: 2, Total: 5, TotalPages: 3, HasPrev: true}},
+		{0, 0, []uint64{1, 2, 3, 4, 5}, models.Pagination{Page: 1, PageSize: 10, Total: 5, TotalPages: 1}},
+	}
+	for _, c := range cases {
+		query := client.Permission.Query().Order(database.Asc(permission.FieldID))
+		items, pagination, err := Paginate(ctx, query, c.page, c.pageSize)
+		if err != nil {
+			t.Fatalf("paginate(%d, %d) failed: %v", c.page, c.pageSize, err)
+		}
+		if pagination != c.want {
+			t.Errorf("paginate(%d, %d) pagination = %+v, want %+v", c.page, c.pageSize, pagination, c.want)
+		}
+		if len(items) != len(c.wantIDs) {
+			t.Fatalf("paginate(%d, %d) returned %d items, want %d", c.page, c.pageSize, len(items), len(c.wantIDs))
+		}
+		for i, item := range items {
+			if item.ID != c.wantIDs[i] {
+				t.Errorf("paginate(%d, %d) item %d = %d, want %d", c.page, c.pageSize, i, item.ID, c.wantIDs[i])
+			}
+		}
+	}
+
+	// 过滤条件同时作用于总数和数据
+	query := client.Permission.Query().Where(permission.IDGT(3))
+	if _, pagination, err := Paginate(ctx, query, 1, 10); err != nil || pagination.Total != 2 {
+		t.Fatalf("filtered pagination = %+v, err = %v", pagination, err)
+	}
+}
+
+func TestNewPagination(t *testing.T) {
+	if got := NewPagination(1, 10, 0); got.TotalPages != 0 || got.HasNext || got.HasPrev {
+		t.Fatalf("empty result should have no pages: %+v", got)
+	}
+	if got := NewPagination(2, 10, 20); got.TotalPages != 2 || got.HasNext || !got.HasPrev {
+		t.Fatalf("last page should have no next page: %+v", got)
+	}
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"context"
+	stdsql "database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+
+	database "go-backend/database/ent"
+	"go-backend/database/mixins"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// 导入带显式ID的数据后，数据库自增序列不会随之更新，后续由数据库生成ID的插入会与已导入的记录冲突。
+// 导入完成后按方言检测各表的自增序列，并将其重置为 max(id)+1。
+// 使用应用生成ID（BaseMixin 的 IDHook）的实体不依赖序列，跳过。
+
+// SequenceAdjustment 一次自增序列调整
+type SequenceAdjustment struct {
+	Table     string `json:"table"`
+	Sequence  string `json:"sequence"`
+	NextValue int64  `json:"next_value"`
+}
+
+// sequenceQuerier 执行序列调整所需的查询接口，*ent.Client 和 *ent.Tx 均实现
+type sequenceQuerier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (stdsql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*stdsql.Rows, error)
+}
+
+// applicationIDHook IDHook 返回的闭包共享同一份代码，可按代码指针识别实体是否使用应用生成ID
+var applicationIDHook = reflect.ValueOf(mixins.IDHook()).Pointer()
+
+// sequenceDialect 将驱动名归一为序列调整支持的方言，不支持时返回空字符串
+func sequenceDialect(driverName string) string {
+	switch strings.ToLower(driverName) {
+	case "postgres", "postgresql", "pgx":
+		return "postgres"
+	case "mysql":
+		return "mysql"
+	case "sqlite3", "sqlite":
+		return "sqlite3"
+	default:
+		return ""
+	}
+}
+
+// resetImportedSequences 重置已导入实体所在表的自增序列
+func resetImportedSequences(ctx context.Context, client *database.Client, driverName string, entityNames []string, entityClients map[string]reflect.Value) ([]SequenceAdjustment, error) {
+	dialect := sequenceDialect(driverName)
+	if dialect == "" {
+		if logger != nil {
+			logger.Info("数据库驱动 %s 不支持自增序列调整，跳过", driverName)
+		}
+		return nil, nil
+	}
+
+	adjustments := make([]SequenceAdjustment, 0)
+	for _, entityName := range entityNames {
+		clientField, ok := entityClients[entityName]
+		if !ok {
+			continue
+		}
+		if entityUsesApplicationIDs(clientField) {
+			continue
+		}
+
+		table, err := entityTableName(ctx, clientField)
+		if err != nil {
+			return adjustments, fmt.Errorf("failed to resolve table for entity %s: %w", entityName, err)
+		}
+
+		adjustment, err := resetTableSequence(ctx, client, dialect, table)
+		if err != nil {
+			return adjustments, fmt.Errorf("failed to reset sequence for table %s: %w", table, err)
+		}
+		if adjustment == nil {
+			continue
+		}
+		adjustments = append(adjustments, *adjustment)
+		if logger != nil {
+			logger.Info("已调整表 %s 的自增序列 %s，下一个ID为 %d", adjustment.Table, adjustment.Sequence, adjustment.NextValue)
+		}
+	}
+	return adjustments, nil
+}
+
+// entityUsesApplicationIDs 检查实体是否注册了 IDHook，即ID由应用生成而非数据库序列
+func entityUsesApplicationIDs(clientField reflect.Value) bool {
+	hooksMethod := clientField.MethodByName("Hooks")
+	if !hooksMethod.IsValid() {
+		return false
+	}
+	results := hooksMethod.Call(nil)
+	if len(results) != 1 || results[0].Kind() != reflect.Slice {
+		return false
+	}
+	hooks := results[0]
+	for i := 0; i < hooks.Len(); i++ {
+		hook := hooks.Index(i)
+		if hook.Kind() == reflect.Func && !hook.IsNil() && hook.Pointer() == applicationIDHook {
+			return true
+		}
+	}
+	return false
+}
+
+// entityTableName 通过一次不返回数据的查询获取实体对应的表名
+func entityTableName(ctx context.Context, clientField reflect.Value) (string, error) {
+	queryMethod := clientField.MethodByName("Query")
+	if !queryMethod.IsValid() {
+		return "", fmt.Errorf("query method not found")
+	}
+	query := queryMethod.Call(nil)[0]
+
+	whereMethod := query.MethodByName("Where")
+	if !whereMethod.IsValid() || whereMethod.Type().NumIn() != 1 {
+		return "", fmt.Errorf("where method not found")
+	}
+
+	var table string
+	capture := func(s *sql.Selector) {
+		table = s.TableName()
+		s.Where(sql.False())
+	}
+	predicateType := whereMethod.Type().In(0).Elem()
+	whereMethod.Call([]reflect.Value{reflect.ValueOf(capture).Convert(predicateType)})
+
+	existResults := query.MethodByName("Exist").Call([]reflect.Value{reflect.ValueOf(ctx)})
+	if err, _ := existResults[1].Interface().(error); err != nil {
+		return "", err
+	}
+	if table == "" {
+		return "", fmt.Errorf("table name not captured")
+	}
+	return table, nil
+}
+
+// resetTableSequence 按方言检测表的自增序列并重置为 max(id)+1，表没有自增序列时返回 nil
+func resetTableSequence(ctx context.Context, q sequenceQuerier, dialect, table string) (*SequenceAdjustment, error) {
+	var sequence string
+	switch dialect {
+	case "postgres":
+		var name stdsql.NullString
+		if err := queryRow(ctx, q, &name, "SELECT pg_get_serial_sequence($1, 'id')", table); err != nil {
+			return nil, err
+		}
+		if !name.Valid {
+			return nil, nil
+		}
+		sequence = name.String
+	case "mysql":
+		var count int
+		if err := queryRow(ctx, q, &count, "SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() "+
+			"AND TABLE_NAME = ? AND COLUMN_NAME = 'id' AND EXTRA LIKE '%auto_increment%'", table); err != nil {
+			return nil, err
+		}
+		if count == 0 {
+			return nil, nil
+		}
+		sequence = table + ".AUTO_INCREMENT"
+	case "sqlite3":
+		// 只有声明为 AUTOINCREMENT 的表才使用 sqlite_sequence
+		var ddl stdsql.NullString
+		if err := queryRow(ctx, q, &ddl, "SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table); err != nil {
+			return nil, err
+		}
+		if !strings.Contains(strings.ToUpper(ddl.String), "AUTOINCREMENT") {
+			return nil, nil
+		}
+		sequence = "sqlite_sequence." + table
+	default:
+		return nil, nil
+	}
+
+	var next int64
+	if err := queryRow(ctx, q, &next, fmt.Sprintf("SELECT COALESCE(MAX(id), 0) + 1 FROM %s", quoteIdentifier(dialect, table))); err != nil {
+		return nil, err
+	}
+
+	var err error
+	switch dialect {
+	case "postgres":
+		// is_called=false 表示下一次 nextval 直接返回 next
+		_, err = q.ExecContext(ctx, "SELECT setval($1, $2, false)", sequence, next)
+	case "mysql":
+		_, err = q.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s AUTO_INCREMENT = %d", quoteIdentifier(dialect, table), next))
+	case "sqlite3":
+		if _, err = q.ExecContext(ctx, "DELETE FROM sqlite_sequence WHERE name = ?", table); err == nil {
+			_, err = q.ExecContext(ctx, "INSERT INTO sqlite_sequence (name, seq) VALUES (?, ?)", table, next-1)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &SequenceAdjustment{Table: table, Sequence: sequence, NextValue: next}, nil
+}
+
+// queryRow 执行返回单行单列的查询
+func queryRow(ctx context.Context, q sequenceQuerier, dest any, query string, args ...any) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return stdsql.ErrNoRows
+	}
+	if err := rows.Scan(dest); err != nil {
+		return err
+	}
+	return rows.Err()
+}
+
+// quoteIdentifier 按方言转义表名
+func quoteIdentifier(dialect, name string) string {
+	if dialect == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
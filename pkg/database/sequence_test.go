@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"go-backend/database/ent/enttest"
+	_ "go-backend/database/ent/runtime"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestResetTableSequenceAutoIncrement(t *testing.T) {
+	ctx := context.Background()
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	defer client.Close()
+
+	mustExec := func(query string, args ...any) {
+		t.Helper()
+		if _, err := client.ExecContext(ctx, query, args...); err != nil {
+			t.Fatalf("exec %q: %v", query, err)
+		}
+	}
+
+	// 模拟恢复：带显式ID导入后序列仍停留在旧值
+	mustExec("CREATE TABLE counters (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)")
+	mustExec("INSERT INTO counters (id, name) VALUES (41, 'a'), (42, 'b')")
+	mustExec("UPDATE sqlite_sequence SET seq = 1 WHERE name = 'counters'")
+	mustExec("CREATE TABLE plain (id INTEGER PRIMARY KEY, name TEXT)")
+
+	adjustment, err := resetTableSequence(ctx, client, "sqlite3", "counters")
+	if err != nil {
+		t.Fatalf("reset failed: %v", err)
+	}
+	if adjustment == nil || adjustment.NextValue != 43 {
+		t.Fatalf("unexpected adjustment: %+v", adjustment)
+	}
+
+	mustExec("INSERT INTO counters (name) VALUES ('c')")
+	var id int64
+	if err := queryRow(ctx, client, &id, "SELECT id FROM counters WHERE name = 'c'"); err != nil {
+		t.Fatalf("query failed: %v", err)
+	}
+	if id != 43 {
+		t.Fatalf("next generated id = %d, want 43", id)
+	}
+
+	if adjustment, err := resetTableSequence(ctx, client, "sqlite3", "plain"); err != nil || adjustment != nil {
+		t.Fatalf("tables without a sequence should be skipped, got %+v, %v", adjustment, err)
+	}
+}
+
+func TestEntitySequenceDetection(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	defer client.Close()
+
+	userClient := reflect.ValueOf(client.User)
+	if !entityUsesApplicationIDs(userClient) {
+		t.Fatal("entities with BaseMixin should be detected as using application IDs")
+	}
+
+	table, err := entityTableName(context.Background(), userClient)
+	if err != nil || table != "sys_users" {
+		t.Fatalf("entityTableName = %q, %v", table, err)
+	}
+
+	if sequenceDialect("pgx") != "postgres" || sequenceDialect("oracle") != "" {
+		t.Fatal("unexpected dialect mapping")
+	}
+}
@@ -0,0 +1,107 @@
+package jwt
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+)
+
+// 声明扩展：开启后accessToken携带用户的角色名称和权限短哈希，下游服务无需查询数据库即可做粗粒度的权限判断。
+//
+// 声明是签发时的快照，角色或权限变更后，已签发的accessToken在过期前仍携带旧的声明，
+// 因此声明最多滞后一个accessToken有效期；开启声明扩展的终端应使用较短的accessToken有效期。
+// 刷新accessToken时重新查询角色和权限，不沿用旧令牌中的声明。
+// 敏感操作仍应以数据库中的权限为准。
+
+// permissionHashLen 权限哈希的长度（十六进制字符数）
+const permissionHashLen = 12
+
+// AccessClaims accessToken中携带的角色和权限声明
+type AccessClaims struct {
+	Roles      []string // 角色名称
+	PermHashes []string // 权限短哈希
+	Truncated  bool     // 是否因超出数量上限被截断
+	Audience   string   // 终端配置的受众，追加在服务受众之后
+	Scopes     []string // 终端配置的授权范围，为空表示不限制
+}
+
+// PermissionHash 计算权限的短哈希（sha256的前12个十六进制字符），用于压缩令牌体积且不直接暴露权限名称
+func PermissionHash(permission string) string {
+	sum := sha256.Sum256([]byte(permission))
+	return hex.EncodeToString(sum[:])[:permissionHashLen]
+}
+
+// NewAccessClaims 构建有数量上限的声明：角色和权限去重排序后截取前 maxRoles / maxPermissions 个，
+// 上限小于等于0表示不携带该类声明（不视为截断）
+func NewAccessClaims(roles, permissions []string, maxRoles, maxPermissions int) *AccessClaims {
+	claims := &AccessClaims{}
+	claims.Roles, claims.Truncated = boundedClaimValues(roles, maxRoles, nil)
+	var truncated bool
+	claims.PermHashes, truncated = boundedClaimValues(permissions, maxPermissions, PermissionHash)
+	claims.Truncated = claims.Truncated || truncated
+	return claims
+}
+
+// boundedClaimValues 去重、排序并截断声明值，transform 非空时对每个值做转换
+func boundedClaimValues(values []string, limit int, transform func(string) string) ([]string, bool) {
+	if limit <= 0 {
+		return nil, false
+	}
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if transform != nil {
+			value = transform(value)
+		}
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	if len(result) > limit {
+		return result[:limit], true
+	}
+	return result, false
+}
+
+// HasRole 判断令牌是否携带指定角色声明
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission 判断令牌是否携带指定权限的哈希声明。
+// 返回false时可能是声明被截断或未开启权限声明，调用方应结合 ClaimsTruncated 回退到数据库检查
+func (c *Claims) HasPermission(permission string) bool {
+	hash := PermissionHash(permission)
+	for _, h := range c.PermHashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Scoped 判断令牌是否限定了授权范围，未限定的令牌（终端未配置授权范围）不受范围检查约束
+func (c *Claims) Scoped() bool {
+	return len(c.Scopes) > 0
+}
+
+// HasScope 判断令牌是否包含指定的授权范围
+func (c *Claims) HasScope(scope string) bool {
+	for _, s := range c.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
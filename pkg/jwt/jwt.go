@@ -16,24 +16,41 @@ type Claims struct {
 	IsRefresh      bool   `json:"isRefresh"`
 	Expiry         uint64 `json:"expity"`
 	RememberMe     bool   `json:"rememberMe"`
+	// IssuedAtMs 毫秒精度的签发时间，用于判断令牌是否签发于会话被撤销之前（iat 只有秒级精度）
+	IssuedAtMs uint64 `json:"iatMs"`
+	// Roles 签发时用户的角色名称（仅开启声明扩展的终端的accessToken携带）
+	Roles []string `json:"roles,omitempty"`
+	// PermHashes 签发时用户权限的短哈希，见 PermissionHash
+	PermHashes []string `json:"perms,omitempty"`
+	// ClaimsTruncated 角色或权限超出数量上限被截断，此时声明不完整，应回退到数据库检查
+	ClaimsTruncated bool `json:"claimsTruncated,omitempty"`
+	// Scopes 终端配置的授权范围，为空表示不限制
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // JWTService JWT服务
 type JWTService struct {
 	secretKey []byte
 	issuer    string
+	audience  string
 }
 
-// NewJWTService 创建JWT服务
-func NewJWTService(secretKey, issuer string) *JWTService {
+// NewJWTService 创建JWT服务，issuer/audience 非空时写入签发的 Token 并在验证时强制校验
+func NewJWTService(secretKey, issuer, audience string) *JWTService {
 	return &JWTService{
 		secretKey: []byte(secretKey),
 		issuer:    issuer,
+		audience:  audience,
 	}
 }
 
 // GenerateToken 生成JWT Token
 func (j *JWTService) GenerateToken(userID uint64, clientId uint64, expiry time.Duration, isRefresh bool, rememberMe bool) (string, error) {
+	return j.GenerateTokenWithClaims(userID, clientId, expiry, isRefresh, rememberMe, nil)
+}
+
+// GenerateTokenWithClaims 生成JWT Token，extra 非空时写入角色和权限哈希声明以及终端的受众和授权范围
+func (j *JWTService) GenerateTokenWithClaims(userID uint64, clientId uint64, expiry time.Duration, isRefresh bool, rememberMe bool, extra *AccessClaims) (string, error) {
 	now := time.Now()
 	claims := Claims{
 		UserID:         userID,
@@ -45,22 +62,46 @@ func (j *JWTService) GenerateToken(userID uint64, clientId uint64, expiry time.D
 			ExpiresAt: jwt.NewNumericDate(now.Add(expiry)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
-		IsRefresh: isRefresh,
-		Expiry:    uint64(time.Now().Add(time.Duration(expiry)).UnixMilli()),
+		IsRefresh:  isRefresh,
+		Expiry:     uint64(now.Add(expiry).UnixMilli()),
+		RememberMe: rememberMe,
+		IssuedAtMs: uint64(now.UnixMilli()),
+	}
+
+	if j.audience != "" {
+		claims.Audience = jwt.ClaimStrings{j.audience}
+	}
+	if extra != nil {
+		claims.Roles = extra.Roles
+		claims.PermHashes = extra.PermHashes
+		claims.ClaimsTruncated = extra.Truncated
+		claims.Scopes = extra.Scopes
+		// 保留服务受众，验证时仍按服务受众校验
+		if extra.Audience != "" && extra.Audience != j.audience {
+			claims.Audience = append(claims.Audience, extra.Audience)
+		}
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(j.secretKey)
 }
 
-// ValidateToken 验证JWT Token
+// ValidateToken 验证JWT Token，配置了签发者/受众时拒绝不匹配的 Token（如同密钥的其他服务签发的 Token）
 func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
+	var options []jwt.ParserOption
+	if j.issuer != "" {
+		options = append(options, jwt.WithIssuer(j.issuer))
+	}
+	if j.audience != "" {
+		options = append(options, jwt.WithAudience(j.audience))
+	}
+
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 		return j.secretKey, nil
-	})
+	}, options...)
 
 	if err != nil {
 		return nil, err
@@ -75,6 +116,11 @@ func (j *JWTService) ValidateToken(tokenString string) (*Claims, error) {
 
 // RefreshToken 刷新Token
 func (j *JWTService) RefreshToken(tokenString string, clientId uint64, expiry time.Duration) (string, error) {
+	return j.RefreshTokenWithClaims(tokenString, clientId, expiry, nil)
+}
+
+// RefreshTokenWithClaims 刷新Token，新的accessToken携带 extra 中的声明（由调用方重新查询，不沿用旧令牌中的声明）
+func (j *JWTService) RefreshTokenWithClaims(tokenString string, clientId uint64, expiry time.Duration, extra *AccessClaims) (string, error) {
 	claims, err := j.ValidateToken(tokenString)
 	if err != nil {
 		return "", err
@@ -85,5 +131,5 @@ func (j *JWTService) RefreshToken(tokenString string, clientId uint64, expiry ti
 		return "", fmt.Errorf("不允许在不同终端刷新同一token")
 	}
 
-	return j.GenerateToken(claims.UserID, clientId, expiry, false, claims.RememberMe)
+	return j.GenerateTokenWithClaims(claims.UserID, clientId, expiry, false, claims.RememberMe, extra)
 }
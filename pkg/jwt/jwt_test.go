@@ -0,0 +1,200 @@
+package jwt
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestValidateTokenAcceptsMatchingIssuerAndAudience(t *testing.T) {
+	service := NewJWTService("secret", "go-backend", "admin-api")
+	token, err := service.GenerateToken(1, 2, time.Minute, false, false)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.Issuer != "go-backend" || len(claims.Audience) != 1 || claims.Audience[0] != "admin-api" {
+		t.Fatalf("unexpected iss/aud: %q %v", claims.Issuer, claims.Audience)
+	}
+}
+
+func TestValidateTokenRejectsWrongIssuerOrAudience(t *testing.T) {
+	service := NewJWTService("secret", "go-backend", "admin-api")
+	cases := []struct {
+		name   string
+		signer *JWTService
+		want   error
+	}{
+		{"wrong issuer", NewJWTService("secret", "other-service", "admin-api"), jwt.ErrTokenInvalidIssuer},
+		{"wrong audience", NewJWTService("secret", "go-backend", "other-api"), jwt.ErrTokenInvalidAudience},
+		{"missing audience", NewJWTService("secret", "go-backend", ""), jwt.ErrTokenRequiredClaimMissing},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := tc.signer.GenerateToken(1, 2, time.Minute, false, false)
+			if err != nil {
+				t.Fatalf("generate failed: %v", err)
+			}
+			if _, err := service.ValidateToken(token); !errors.Is(err, tc.want) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+}
+
+func TestValidateTokenAcceptsTokenWithoutAudienceByDefault(t *testing.T) {
+	// 按升级前的方式签发：只有签发者，没有 aud 和毫秒签发时间
+	now := time.Now()
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		UserID:         1,
+		ClientDeviceId: 2,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "go-backend",
+			Subject:   "1@2",
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			NotBefore: jwt.NewNumericDate(now),
+		},
+		Expiry: uint64(now.Add(time.Minute).UnixMilli()),
+	})
+	token, err := legacy.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatalf("sign failed: %v", err)
+	}
+
+	// 默认配置不设置受众
+	claims, err := NewJWTService("secret", "go-backend", "").ValidateToken(token)
+	if err != nil {
+		t.Fatalf("legacy token should stay valid without a configured audience: %v", err)
+	}
+	if claims.UserID != 1 || len(claims.Audience) != 0 {
+		t.Fatalf("unexpected claims: %+v", claims)
+	}
+
+	// 显式启用受众后旧Token被拒绝
+	if _, err := NewJWTService("secret", "go-backend", "go-backend").ValidateToken(token); !errors.Is(err, jwt.ErrTokenRequiredClaimMissing) {
+		t.Fatalf("expected missing audience error, got %v", err)
+	}
+}
+
+func TestAccessClaimsRoundTrip(t *testing.T) {
+	service := NewJWTService("secret", "go-backend", "admin-api")
+	extra := NewAccessClaims(
+		[]string{"editor", "admin", "editor", " "},
+		[]string{"workflow:delete", "workflow:read", "workflow:read"},
+		8, 8,
+	)
+	token, err := service.GenerateTokenWithClaims(1, 2, time.Minute, false, false, extra)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if len(claims.Roles) != 2 || claims.Roles[0] != "admin" || claims.Roles[1] != "editor" {
+		t.Fatalf("roles should be deduplicated and sorted, got %v", claims.Roles)
+	}
+	if !claims.HasRole("admin") || claims.HasRole("guest") {
+		t.Fatalf("unexpected role check result: %v", claims.Roles)
+	}
+	if len(claims.PermHashes) != 2 || !claims.HasPermission("workflow:delete") || claims.HasPermission("workflow:write") {
+		t.Fatalf("unexpected permission hashes: %v", claims.PermHashes)
+	}
+	if claims.ClaimsTruncated {
+		t.Fatal("claims within limits should not be marked truncated")
+	}
+
+	// 刷新时携带调用方重新提供的声明，不沿用旧令牌中的声明
+	refresh, err := service.GenerateToken(1, 2, time.Hour, true, false)
+	if err != nil {
+		t.Fatalf("generate refresh failed: %v", err)
+	}
+	refreshed, err := service.RefreshTokenWithClaims(refresh, 2, time.Minute, NewAccessClaims([]string{"viewer"}, nil, 8, 8))
+	if err != nil {
+		t.Fatalf("refresh failed: %v", err)
+	}
+	claims, err = service.ValidateToken(refreshed)
+	if err != nil {
+		t.Fatalf("validate refreshed failed: %v", err)
+	}
+	if len(claims.Roles) != 1 || claims.Roles[0] != "viewer" || len(claims.PermHashes) != 0 {
+		t.Fatalf("unexpected refreshed claims: %+v", claims)
+	}
+}
+
+func TestAccessClaimsBoundedAndOmittedByDefault(t *testing.T) {
+	extra := NewAccessClaims([]string{"c", "a", "b"}, []string{"x:read", "y:read"}, 2, 0)
+	if len(extra.Roles) != 2 || extra.Roles[0] != "a" || extra.Roles[1] != "b" || !extra.Truncated {
+		t.Fatalf("roles should be truncated to the limit: %+v", extra)
+	}
+	if extra.PermHashes != nil {
+		t.Fatalf("permissions should be omitted when the limit is 0: %v", extra.PermHashes)
+	}
+	if hash := PermissionHash("workflow:delete"); len(hash) != permissionHashLen {
+		t.Fatalf("unexpected hash length: %q", hash)
+	}
+
+	// 未携带声明的令牌中不出现 roles/perms 字段
+	service := NewJWTService("secret", "", "")
+	token, err := service.GenerateToken(1, 2, time.Minute, false, false)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("parse failed: %v", err)
+	}
+	mapClaims := parsed.Claims.(jwt.MapClaims)
+	if _, ok := mapClaims["roles"]; ok {
+		t.Fatal("plain token should not carry roles")
+	}
+	if _, ok := mapClaims["perms"]; ok {
+		t.Fatal("plain token should not carry permission hashes")
+	}
+}
+
+func TestDeviceAudienceAndScopes(t *testing.T) {
+	service := NewJWTService("secret", "go-backend", "admin-api")
+	token, err := service.GenerateTokenWithClaims(1, 2, time.Minute, false, false, &AccessClaims{
+		Audience: "integration-api",
+		Scopes:   []string{"workflow", "workflow:execute"},
+	})
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+
+	// 终端受众追加在服务受众之后，服务仍能按自身受众验证
+	claims, err := service.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if len(claims.Audience) != 2 || claims.Audience[0] != "admin-api" || claims.Audience[1] != "integration-api" {
+		t.Fatalf("unexpected audience: %v", claims.Audience)
+	}
+	if !claims.Scoped() || !claims.HasScope("workflow:execute") || claims.HasScope("attachment") {
+		t.Fatalf("unexpected scopes: %v", claims.Scopes)
+	}
+	if _, err := NewJWTService("secret", "go-backend", "integration-api").ValidateToken(token); err != nil {
+		t.Fatalf("device audience should also validate: %v", err)
+	}
+
+	plain, err := service.GenerateToken(1, 2, time.Minute, false, false)
+	if err != nil {
+		t.Fatalf("generate failed: %v", err)
+	}
+	claims, err = service.ValidateToken(plain)
+	if err != nil {
+		t.Fatalf("validate failed: %v", err)
+	}
+	if claims.Scoped() || len(claims.Audience) != 1 {
+		t.Fatalf("plain token should not be scoped: %+v", claims)
+	}
+}
@@ -23,7 +23,7 @@ var (
 func InitializeService(config *configs.JWTConfig) error {
 	var err error
 	once.Do(func() {
-		service = NewJWTService(config.SecretKey, config.Issuer)
+		service = NewJWTService(config.SecretKey, config.Issuer, config.Audience)
 	})
 	return err
 }
@@ -43,6 +43,14 @@ func GenerateAccessToken(userID, clientId uint64, expiry time.Duration) (string,
 	return service.GenerateToken(userID, clientId, expiry, false, false)
 }
 
+// GenerateEnrichedAccessToken 生成携带角色和权限哈希声明的accessToken (全局函数)
+func GenerateEnrichedAccessToken(userID, clientId uint64, expiry time.Duration, extra *AccessClaims) (string, error) {
+	if service == nil {
+		return "", ErrServiceNotInitialized
+	}
+	return service.GenerateTokenWithClaims(userID, clientId, expiry, false, false, extra)
+}
+
 // GenerateRefreshToken 生成Token (全局函数)
 func GenerateRefreshToken(userID, clientId uint64, expiry time.Duration, rememberMe bool) (string, error) {
 	if service == nil {
@@ -51,6 +59,14 @@ func GenerateRefreshToken(userID, clientId uint64, expiry time.Duration, remembe
 	return service.GenerateToken(userID, clientId, expiry, true, rememberMe)
 }
 
+// GenerateRefreshTokenWithClaims 生成携带终端受众和授权范围的refreshToken (全局函数)
+func GenerateRefreshTokenWithClaims(userID, clientId uint64, expiry time.Duration, rememberMe bool, extra *AccessClaims) (string, error) {
+	if service == nil {
+		return "", ErrServiceNotInitialized
+	}
+	return service.GenerateTokenWithClaims(userID, clientId, expiry, true, rememberMe, extra)
+}
+
 // ValidateToken 验证Token (全局函数)
 func ValidateToken(tokenString string) (*Claims, error) {
 	if service == nil {
@@ -66,3 +82,11 @@ func RefreshToken(tokenString string, clientId uint64, expiry time.Duration) (st
 	}
 	return service.RefreshToken(tokenString, clientId, expiry)
 }
+
+// RefreshTokenWithClaims 刷新Token并携带声明 (全局函数)
+func RefreshTokenWithClaims(tokenString string, clientId uint64, expiry time.Duration, extra *AccessClaims) (string, error) {
+	if service == nil {
+		return "", ErrServiceNotInitialized
+	}
+	return service.RefreshTokenWithClaims(tokenString, clientId, expiry, extra)
+}
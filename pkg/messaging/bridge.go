@@ -0,0 +1,143 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// WebSocket 桥接：订阅 Redis Pub/Sub 频道，把收到的每条消息转为 ServerToUserSocket 消息发布到对应的 WebSocket 主题，
+// 后端组件只需向 Redis 频道 PUBLISH 即可通知客户端，无需了解 WebSocket 服务。
+// 注意 Pub/Sub 会投递给每个订阅者，多实例部署时同一个桥接只应在一个实例上启动，否则客户端会收到重复消息。
+
+// BridgeMessage 桥接收到的一条消息
+type BridgeMessage struct {
+	Channel string // 实际收到消息的 Redis 频道
+	Match   string // 模式订阅时通配符 * 匹配到的部分，普通订阅时与 Channel 相同
+	Topic   string // 按主题模板计算出的默认 WebSocket 主题
+	Data    any    // 消息内容，JSON 会被解码为 map/切片等，否则为原始字符串
+}
+
+// BridgeTransform 转发前转换消息的结构和主题，返回 false 时丢弃该消息
+type BridgeTransform func(msg BridgeMessage) (SocketMessagePayload, bool)
+
+// BridgeToWebSocket 订阅 Redis 频道并把消息原样转发到 WebSocket 主题，ctx 取消后停止
+// redisChannel 包含 * 时使用模式订阅；wsTopicPattern 中的 {channel} 和 {match} 会被替换为实际频道和通配符匹配的部分，
+// 例如将 events:workflow:* 桥接到 workflow/execution/{match}
+func BridgeToWebSocket(ctx context.Context, redisChannel, wsTopicPattern string) error {
+	return BridgeToWebSocketWithTransform(ctx, redisChannel, wsTopicPattern, nil)
+}
+
+// BridgeToWebSocketWithTransform 与 BridgeToWebSocket 相同，但转发前使用 transform 转换消息，transform 为 nil 时原样转发
+func BridgeToWebSocketWithTransform(ctx context.Context, redisChannel, wsTopicPattern string, transform BridgeTransform) error {
+	client := caching.GetInstanceUnsafe()
+	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
+	return startBridge(ctx, client, redisChannel, wsTopicPattern, transform, func(task MessageStruct) error {
+		_, err := publishToStream(ctx, client, streamKey, task)
+		return err
+	})
+}
+
+// startBridge 建立订阅并启动转发协程，订阅确认后才返回，保证返回之后发布的消息不会丢失
+func startBridge(ctx context.Context, client *redis.Client, redisChannel, wsTopicPattern string, transform BridgeTransform, publish func(MessageStruct) error) error {
+	if strings.TrimSpace(redisChannel) == "" || strings.TrimSpace(wsTopicPattern) == "" {
+		return fmt.Errorf("redis channel and websocket topic pattern are required")
+	}
+
+	var pubsub *redis.PubSub
+	if strings.Contains(redisChannel, "*") {
+		pubsub = client.PSubscribe(ctx, redisChannel)
+	} else {
+		pubsub = client.Subscribe(ctx, redisChannel)
+	}
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return fmt.Errorf("订阅频道 %s 失败: %w", redisChannel, err)
+	}
+
+	go func() {
+		defer metrics.trackSubscriber()()
+		defer pubsub.Close()
+		logger.Info("WebSocket 桥接已启动: %s -> %s", redisChannel, wsTopicPattern)
+		runBridge(ctx, pubsub.Channel(), redisChannel, wsTopicPattern, transform, publish)
+		logger.Info("WebSocket 桥接已停止: %s", redisChannel)
+	}()
+	return nil
+}
+
+// runBridge 持续转发消息，直到 ctx 取消或消息通道关闭
+func runBridge(ctx context.Context, messages <-chan *redis.Message, redisChannel, wsTopicPattern string, transform BridgeTransform, publish func(MessageStruct) error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+			forwardBridgeMessage(msg, redisChannel, wsTopicPattern, transform, publish)
+		}
+	}
+}
+
+// forwardBridgeMessage 转换并发布一条消息，失败只记录日志，不影响后续消息
+func forwardBridgeMessage(msg *redis.Message, redisChannel, wsTopicPattern string, transform BridgeTransform, publish func(MessageStruct) error) {
+	// 按订阅的频道（模式）统计，避免模式订阅下每个实际频道各占一项
+	counters := metrics.channel(redisChannel)
+	counters.consumed.Add(1)
+
+	bridgeMsg := newBridgeMessage(msg.Channel, msg.Payload, redisChannel, wsTopicPattern)
+
+	payload := SocketMessagePayload{Topic: bridgeMsg.Topic, Data: bridgeMsg.Data}
+	if transform != nil {
+		var ok bool
+		payload, ok = transform(bridgeMsg)
+		if !ok {
+			return
+		}
+	}
+	if payload.Topic == "" {
+		logger.Warn("WebSocket 桥接丢弃消息：频道 %s 的消息没有目标主题", msg.Channel)
+		return
+	}
+
+	if err := publish(MessageStruct{Type: ServerToUserSocket, Payload: payload}); err != nil {
+		counters.handlerErrors.Add(1)
+		logger.Error("WebSocket 桥接转发频道 %s 的消息失败: %v", msg.Channel, err)
+	}
+}
+
+// newBridgeMessage 解析消息内容并按主题模板计算默认主题
+func newBridgeMessage(channel, payload, redisChannel, wsTopicPattern string) BridgeMessage {
+	match := bridgeWildcardMatch(redisChannel, channel)
+
+	var data any = payload
+	var decoded any
+	if json.Unmarshal([]byte(payload), &decoded) == nil {
+		data = decoded
+	}
+
+	topic := strings.NewReplacer("{channel}", channel, "{match}", match).Replace(wsTopicPattern)
+	return BridgeMessage{Channel: channel, Match: match, Topic: topic, Data: data}
+}
+
+// bridgeWildcardMatch 计算模式中第一个 * 匹配到的部分，模式不含 * 或与频道不匹配时返回完整频道名
+func bridgeWildcardMatch(pattern, channel string) string {
+	idx := strings.Index(pattern, "*")
+	if idx < 0 {
+		return channel
+	}
+	prefix := pattern[:idx]
+	suffix := strings.TrimPrefix(pattern[idx+1:], "*")
+	if strings.Contains(suffix, "*") || !strings.HasPrefix(channel, prefix) || !strings.HasSuffix(channel, suffix) ||
+		len(channel) < len(prefix)+len(suffix) {
+		return channel
+	}
+	return channel[len(prefix) : len(channel)-len(suffix)]
+}
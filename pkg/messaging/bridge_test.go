@@ -0,0 +1,125 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNewBridgeMessage(t *testing.T) {
+	msg := newBridgeMessage("events:workflow:42", `{"status":"running"}`, "events:workflow:*", "workflow/execution/{match}")
+	if msg.Match != "42" || msg.Topic != "workflow/execution/42" {
+		t.Fatalf("unexpected topic resolution: %+v", msg)
+	}
+	data, ok := msg.Data.(map[string]interface{})
+	if !ok || data["status"] != "running" {
+		t.Fatalf("json payload should be decoded, got %#v", msg.Data)
+	}
+
+	msg = newBridgeMessage("events:rbac", "roles changed", "events:rbac", "rbac/{channel}")
+	if msg.Topic != "rbac/events:rbac" || msg.Data != "roles changed" {
+		t.Fatalf("unexpected plain message: %+v", msg)
+	}
+}
+
+func TestBridgeWildcardMatch(t *testing.T) {
+	cases := []struct {
+		pattern, channel, want string
+	}{
+		{"events:*", "events:a:b", "a:b"},
+		{"events:*:done", "events:42:done", "42"},
+		{"events:*:done", "other:42", "other:42"},
+		{"events", "events", "events"},
+	}
+	for _, tc := range cases {
+		if got := bridgeWildcardMatch(tc.pattern, tc.channel); got != tc.want {
+			t.Fatalf("bridgeWildcardMatch(%q, %q) = %q, want %q", tc.pattern, tc.channel, got, tc.want)
+		}
+	}
+}
+
+func TestRunBridgeForwardsAndStops(t *testing.T) {
+	setupDelayedTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	published := make(chan MessageStruct, 4)
+	publish := func(task MessageStruct) error {
+		published <- task
+		return nil
+	}
+	userID := uint64(7)
+	transform := func(msg BridgeMessage) (SocketMessagePayload, bool) {
+		if msg.Match == "ignored" {
+			return SocketMessagePayload{}, false
+		}
+		return SocketMessagePayload{UserId: &userID, Topic: msg.Topic, Data: map[string]interface{}{"event": msg.Data}}, true
+	}
+
+	messages := make(chan *redis.Message)
+	done := make(chan struct{})
+	go func() {
+		runBridge(ctx, messages, "events:*", "notify/{match}", transform, publish)
+		close(done)
+	}()
+
+	messages <- &redis.Message{Channel: "events:ignored", Payload: "x"}
+	messages <- &redis.Message{Channel: "events:rbac", Payload: `{"role":"admin"}`}
+
+	select {
+	case task := <-published:
+		payload, ok := task.Payload.(SocketMessagePayload)
+		if task.Type != ServerToUserSocket || !ok {
+			t.Fatalf("unexpected task: %+v", task)
+		}
+		if payload.Topic != "notify/rbac" || payload.UserId == nil || *payload.UserId != userID {
+			t.Fatalf("unexpected payload: %+v", payload)
+		}
+		event := payload.Data.(map[string]interface{})["event"].(map[string]interface{})
+		if event["role"] != "admin" {
+			t.Fatalf("unexpected data: %#v", payload.Data)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("message was not forwarded")
+	}
+	if len(published) != 0 {
+		t.Fatalf("filtered message should not be forwarded, got %d extra", len(published))
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("bridge should stop after context cancel")
+	}
+}
+
+func TestRunBridgeDropsMessagesWithoutTopic(t *testing.T) {
+	setupDelayedTest(t)
+	messages := make(chan *redis.Message, 1)
+	messages <- &redis.Message{Channel: "events", Payload: "x"}
+	close(messages)
+
+	forwarded := 0
+	runBridge(context.Background(), messages, "events", "topic", func(BridgeMessage) (SocketMessagePayload, bool) {
+		return SocketMessagePayload{}, true
+	}, func(MessageStruct) error {
+		forwarded++
+		return nil
+	})
+	if forwarded != 0 {
+		t.Fatal("messages without a topic should be dropped")
+	}
+}
+
+func TestStartBridgeRequiresChannelAndTopic(t *testing.T) {
+	_, client := setupDelayedTest(t)
+	if err := startBridge(context.Background(), client, "", "topic", nil, nil); err == nil {
+		t.Fatal("expected error for empty channel")
+	}
+	if err := startBridge(context.Background(), client, "events", " ", nil, nil); err == nil {
+		t.Fatal("expected error for empty topic pattern")
+	}
+}
@@ -0,0 +1,57 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 消费去重（Dedup）：
+//
+// Stream 的 ACK 机制保证消息至少被处理一次，处理成功但 ACK 失败、或处理超时被其他消费者认领时，处理器会再次执行。
+// 开启消费者的 Dedup 后，每条消息处理成功时在 Redis 中记录其去重ID（有效期 dedup_ttl），
+// 再次投递时若记录仍存在则直接 ACK 跳过，不再调用处理器。
+//
+// 去重ID优先使用发布方设置的 MessageStruct.DedupKey（可用于合并重复发布的同一业务消息），否则使用 Stream 条目ID。
+// 这只在有效期内提供“效果上的恰好一次”，并不是真正的恰好一次：
+//   - 处理器执行成功后、写入记录前进程崩溃，消息仍会被再次处理
+//   - 同一条消息被两个消费者并发处理（处理时间超过 idle_timeout 被认领）时两者都可能执行
+//   - 记录过期后重新投递的消息会再次处理
+// 因此处理器仍应尽量保持幂等。
+
+// processedKey 已处理消息的去重记录键名
+func processedKey(streamKey, messageType, dedupID string) string {
+	return fmt.Sprintf("%s:%s:processed:%s", streamKey, messageType, dedupID)
+}
+
+// dedupID 返回消息的去重ID：发布方指定的 DedupKey 优先，否则使用 Stream 条目ID
+func (m MessageStruct) dedupID(entryID string) string {
+	if m.DedupKey != "" {
+		return m.DedupKey
+	}
+	return entryID
+}
+
+// handleDeduplicated 去重记录存在时跳过处理器并返回 skipped=true；处理成功后写入去重记录。
+// 处理器失败时不写记录，消息按原有机制重试
+func handleDeduplicated(ctx context.Context, client redis.Cmdable, key string, ttl time.Duration, message MessageStruct, handler func(MessageStruct) error) (skipped bool, err error) {
+	processed, err := client.Exists(ctx, key).Result()
+	if err != nil {
+		return false, fmt.Errorf("检查去重记录失败: %w", err)
+	}
+	if processed > 0 {
+		return true, nil
+	}
+
+	if err := handler(message); err != nil {
+		return false, err
+	}
+
+	if err := client.Set(ctx, key, 1, ttl).Err(); err != nil {
+		// 记录失败不影响本次处理结果，只是失去对下一次重复投递的保护
+		logger.Warn("写入消息去重记录 %s 失败: %v", key, err)
+	}
+	return false, nil
+}
@@ -0,0 +1,92 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go-backend/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// newStreamEntry 构造与 publishToStream 写入格式一致的 Stream 条目
+func newStreamEntry(t *testing.T, id string, message MessageStruct) redis.XMessage {
+	t.Helper()
+	data, err := msgpack.Marshal(message)
+	if err != nil {
+		t.Fatalf("failed to marshal message: %v", err)
+	}
+	return redis.XMessage{ID: id, Values: map[string]interface{}{"data": utils.ByteToString(data)}}
+}
+
+func TestDedupConsumerSkipsRedeliveredMessage(t *testing.T) {
+	server, client := setupDelayedTest(t)
+	ctx := context.Background()
+
+	calls := 0
+	handler := func(MessageStruct) error {
+		calls++
+		return nil
+	}
+	consumer := &MessageCunsumer{mType: []MessageType{ServerToWorker}, consumerName: "test", Dedup: true}
+	entry := newStreamEntry(t, "1-0", MessageStruct{Type: ServerToWorker, Payload: "job"})
+
+	// 第一次投递正常处理，再次投递（例如 ACK 丢失后被认领）时跳过
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, entry, string(ServerToWorker), handler)
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, entry, string(ServerToWorker), handler)
+	if calls != 1 {
+		t.Fatalf("redelivered message should be processed once, got %d", calls)
+	}
+
+	// 其他消息不受影响；去重记录过期后重新投递会再次处理
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, newStreamEntry(t, "2-0", MessageStruct{Type: ServerToWorker}), string(ServerToWorker), handler)
+	if calls != 2 {
+		t.Fatalf("a different message should be processed, got %d calls", calls)
+	}
+	server.FastForward(time.Hour + time.Second)
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, entry, string(ServerToWorker), handler)
+	if calls != 3 {
+		t.Fatalf("message should be processed again after the dedup ttl, got %d calls", calls)
+	}
+
+	// 未开启去重的消费者每次投递都会处理
+	plain := &MessageCunsumer{mType: []MessageType{ServerToWorker}, consumerName: "plain"}
+	plain.processStreamMessage(ctx, client, "stream", "group", time.Hour, entry, string(ServerToWorker), handler)
+	if calls != 4 {
+		t.Fatalf("consumer without dedup should process every delivery, got %d calls", calls)
+	}
+}
+
+func TestDedupUsesPublisherKeyAndRetriesFailures(t *testing.T) {
+	_, client := setupDelayedTest(t)
+	ctx := context.Background()
+	consumer := &MessageCunsumer{mType: []MessageType{ServerToWorker}, consumerName: "test", Dedup: true}
+
+	failing := true
+	calls := 0
+	handler := func(MessageStruct) error {
+		calls++
+		if failing {
+			return errors.New("temporary failure")
+		}
+		return nil
+	}
+
+	message := MessageStruct{Type: ServerToWorker, DedupKey: "order-42"}
+	first := newStreamEntry(t, "1-0", message)
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, first, string(ServerToWorker), handler)
+	failing = false
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, first, string(ServerToWorker), handler)
+	if calls != 2 {
+		t.Fatalf("failed processing should not be recorded, got %d calls", calls)
+	}
+
+	// 同一业务消息被重复发布（不同的 Stream 条目ID）时按 DedupKey 去重
+	consumer.processStreamMessage(ctx, client, "stream", "group", time.Hour, newStreamEntry(t, "5-0", message), string(ServerToWorker), handler)
+	if calls != 2 {
+		t.Fatalf("republished message with the same dedup key should be skipped, got %d calls", calls)
+	}
+}
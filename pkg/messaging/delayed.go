@@ -0,0 +1,175 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// 延迟消息存放在有序集合 {stream_key}:delayed 中，score 为投递时间（毫秒时间戳）。
+// 投递器定时将到期的消息移入对应类型的 Stream，多实例部署时通过锁选出唯一的投递实例。
+
+// popDueScript 原子地取出并删除到期的消息，避免同一条消息被重复投递
+var popDueScript = redis.NewScript(`
+local items = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[2])
+if #items > 0 then
+	redis.call('ZREM', KEYS[1], unpack(items))
+end
+return items
+`)
+
+// leaderScript 获取或续期投递器主节点锁：锁属于自己时续期，锁不存在时抢占
+var leaderScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 1
+end
+return 0
+`)
+
+// delayedKey 延迟消息有序集合的键名
+func delayedKey(streamKey string) string {
+	return streamKey + ":delayed"
+}
+
+// delayedLockKey 延迟消息投递器主节点锁的键名
+func delayedLockKey(streamKey string) string {
+	return streamKey + ":delayed:lock"
+}
+
+// PublishDelayed 发布延迟消息，消息在 delay 之后才会进入 Stream 被消费
+// 返回延迟消息的ID
+func PublishDelayed(ctx context.Context, task MessageStruct, delay time.Duration) (string, error) {
+	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
+	return publishDelayed(ctx, caching.GetInstanceUnsafe(), streamKey, task, time.Now().Add(delay))
+}
+
+func publishDelayed(ctx context.Context, client redis.Cmdable, streamKey string, task MessageStruct, deliverAt time.Time) (string, error) {
+	if err := prehandleTask(ctx, &task); err != nil {
+		return "", fmt.Errorf("预处理任务失败: %w", err)
+	}
+
+	// id 不参与序列化，包装一层保证有序集合中的成员唯一
+	data, err := msgpack.Marshal(delayedEnvelope{ID: task.id, Task: task})
+	if err != nil {
+		return "", fmt.Errorf("msgpack 序列化失败: %w", err)
+	}
+
+	err = client.ZAdd(ctx, delayedKey(streamKey), redis.Z{
+		Score:  float64(deliverAt.UnixMilli()),
+		Member: utils.ByteToString(data),
+	}).Err()
+	if err != nil {
+		return "", fmt.Errorf("写入延迟队列失败: %w", err)
+	}
+	return task.id, nil
+}
+
+// delayedEnvelope 延迟队列中存储的消息
+type delayedEnvelope struct {
+	ID   string        `msgpack:"id"`
+	Task MessageStruct `msgpack:"task"`
+}
+
+// dispatchDueMessages 取出到期的延迟消息并通过 publish 投递，返回投递的数量
+// 投递时已过期的消息会被丢弃
+func dispatchDueMessages(ctx context.Context, client redis.Cmdable, streamKey string, now time.Time, batchSize int64, publish func(MessageStruct) error) (int, error) {
+	items, err := popDueScript.Run(ctx, client, []string{delayedKey(streamKey)}, now.UnixMilli(), batchSize).StringSlice()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("获取到期消息失败: %w", err)
+	}
+
+	dispatched := 0
+	for _, item := range items {
+		var envelope delayedEnvelope
+		if err := msgpack.Unmarshal(utils.StringToByte(item), &envelope); err != nil {
+			logger.Error("延迟消息反序列化失败: %v", err)
+			continue
+		}
+		if envelope.Task.Expired(now) {
+			logger.Warn("延迟消息 %s 在投递前已过期，丢弃", envelope.ID)
+			continue
+		}
+		if err := publish(envelope.Task); err != nil {
+			// 投递失败时放回延迟队列，下一轮重试
+			logger.Error("投递延迟消息 %s 失败: %v", envelope.ID, err)
+			client.ZAdd(ctx, delayedKey(streamKey), redis.Z{Score: float64(now.UnixMilli()), Member: item})
+			continue
+		}
+		dispatched++
+	}
+	return dispatched, nil
+}
+
+// acquireDelayedLeadership 获取或续期投递器主节点锁
+func acquireDelayedLeadership(ctx context.Context, client redis.Cmdable, streamKey, owner string, ttl time.Duration) (bool, error) {
+	result, err := leaderScript.Run(ctx, client, []string{delayedLockKey(streamKey)}, owner, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+// StartDelayedDispatcher 启动延迟消息投递器
+// 每个实例都可以启动，只有持有主节点锁的实例会实际投递，主节点失联后由其他实例接管
+func StartDelayedDispatcher(ctx context.Context) {
+	config := configs.GetConfig().Server.Components.Messaging
+	if !config.Delayed.Enabled {
+		logger.Info("延迟消息投递器未启用")
+		return
+	}
+
+	interval := time.Duration(config.Delayed.PollInterval) * time.Millisecond
+	lockTTL := time.Duration(config.Delayed.LockTTL) * time.Millisecond
+	owner := generateUniqueID()
+	client := caching.GetInstanceUnsafe()
+	publish := func(task MessageStruct) error {
+		_, err := publishToStream(ctx, client, config.StreamKey, task)
+		return err
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		logger.Info("延迟消息投递器已启动，轮询间隔: %v", interval)
+		leader := false
+		for {
+			select {
+			case <-ctx.Done():
+				logger.Info("延迟消息投递器已停止")
+				return
+			case now := <-ticker.C:
+				acquired, err := acquireDelayedLeadership(ctx, client, config.StreamKey, owner, lockTTL)
+				if err != nil {
+					logger.Error("获取延迟消息投递器锁失败: %v", err)
+					continue
+				}
+				if acquired != leader {
+					leader = acquired
+					logger.Info("延迟消息投递器主节点状态变更: leader=%v", leader)
+				}
+				if !leader {
+					continue
+				}
+
+				if _, err := dispatchDueMessages(ctx, client, config.StreamKey, now, config.Delayed.BatchSize, publish); err != nil {
+					logger.Error("投递延迟消息失败: %v", err)
+				}
+			}
+		}
+	}()
+}
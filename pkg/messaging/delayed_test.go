@@ -0,0 +1,135 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis"
+	"github.com/redis/go-redis/v9"
+)
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+func (nopLogger) Error(string, ...any) {}
+func (nopLogger) Fatal(string, ...any) {}
+
+func setupDelayedTest(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	if logger == nil {
+		SetLogger(nopLogger{})
+	}
+
+	server, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start fake redis: %v", err)
+	}
+	t.Cleanup(server.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return server, client
+}
+
+func TestMessageExpired(t *testing.T) {
+	now := time.Now()
+	if (MessageStruct{}).Expired(now) {
+		t.Fatal("message without ttl should never expire")
+	}
+	msg := MessageStruct{ExpiresAt: now.Add(time.Second).UnixMilli()}
+	if msg.Expired(now) {
+		t.Fatal("message should not be expired before its ttl")
+	}
+	if !msg.Expired(now.Add(2 * time.Second)) {
+		t.Fatal("message should be expired after its ttl")
+	}
+}
+
+func TestDelayedMessageArrivesAfterDelay(t *testing.T) {
+	_, client := setupDelayedTest(t)
+	ctx := context.Background()
+	var delivered []MessageStruct
+	publish := func(task MessageStruct) error {
+		delivered = append(delivered, task)
+		return nil
+	}
+
+	now := time.Now()
+	task := MessageStruct{Type: ChannelOpenCheck, Payload: map[string]any{"k": "v"}}
+	if _, err := publishDelayed(ctx, client, "test", task, now.Add(time.Minute)); err != nil {
+		t.Fatalf("publishDelayed failed: %v", err)
+	}
+
+	n, err := dispatchDueMessages(ctx, client, "test", now, 10, publish)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if n != 0 || len(delivered) != 0 {
+		t.Fatal("message should not be delivered before its delay")
+	}
+
+	n, err = dispatchDueMessages(ctx, client, "test", now.Add(time.Minute+time.Millisecond), 10, publish)
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if n != 1 || len(delivered) != 1 || delivered[0].Type != ChannelOpenCheck {
+		t.Fatalf("expected delayed message to be delivered, got %+v", delivered)
+	}
+	if client.ZCard(ctx, delayedKey("test")).Val() != 0 {
+		t.Fatal("delivered message should be removed from delayed set")
+	}
+}
+
+func TestDelayedMessageExpiredBeforeDelivery(t *testing.T) {
+	_, client := setupDelayedTest(t)
+	ctx := context.Background()
+
+	now := time.Now()
+	task := MessageStruct{
+		Type:      ChannelOpenCheck,
+		Payload:   map[string]any{"k": "v"},
+		ExpiresAt: now.Add(time.Second).UnixMilli(),
+	}
+	if _, err := publishDelayed(ctx, client, "test", task, now.Add(time.Minute)); err != nil {
+		t.Fatalf("publishDelayed failed: %v", err)
+	}
+
+	delivered := 0
+	n, err := dispatchDueMessages(ctx, client, "test", now.Add(2*time.Minute), 10, func(MessageStruct) error {
+		delivered++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("dispatch failed: %v", err)
+	}
+	if n != 0 || delivered != 0 {
+		t.Fatal("expired message should be dropped")
+	}
+	if client.ZCard(ctx, delayedKey("test")).Val() != 0 {
+		t.Fatal("expired message should be removed from delayed set")
+	}
+}
+
+func TestDelayedDispatcherLeadership(t *testing.T) {
+	server, client := setupDelayedTest(t)
+	ctx := context.Background()
+
+	ok, err := acquireDelayedLeadership(ctx, client, "test", "a", time.Second)
+	if err != nil || !ok {
+		t.Fatalf("first instance should become leader: %v", err)
+	}
+	if ok, _ := acquireDelayedLeadership(ctx, client, "test", "b", time.Second); ok {
+		t.Fatal("second instance should not become leader while lock is held")
+	}
+	if ok, _ := acquireDelayedLeadership(ctx, client, "test", "a", time.Second); !ok {
+		t.Fatal("leader should be able to renew its lock")
+	}
+
+	server.FastForward(2 * time.Second)
+	if ok, _ := acquireDelayedLeadership(ctx, client, "test", "b", time.Second); !ok {
+		t.Fatal("second instance should take over after lock expires")
+	}
+}
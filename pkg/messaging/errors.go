@@ -17,8 +17,11 @@ func NewError(id, msg string) error {
 	}
 }
 
+const errRPCRemoteID = "ERR_RPC_REMOTE"
+
 var (
 	ErrNotSupported = NewError("ERR_NOT_SUPPORTED", "The requested operation is not supported")
+	ErrRPCTimeout   = NewError("ERR_RPC_TIMEOUT", "The RPC call timed out waiting for a reply")
 )
 
 func IsNotSupportedError(err error) bool {
@@ -30,3 +33,19 @@ func IsNotSupportedError(err error) bool {
 	}
 	return false
 }
+
+// IsRPCTimeoutError 判断错误是否为 RPC 调用超时
+func IsRPCTimeoutError(err error) bool {
+	if me, ok := err.(*MessagingError); ok {
+		return me.ID == "ERR_RPC_TIMEOUT"
+	}
+	return false
+}
+
+// IsRPCRemoteError 判断错误是否为 RPC 服务端处理失败，错误信息为服务端返回的错误
+func IsRPCRemoteError(err error) bool {
+	if me, ok := err.(*MessagingError); ok {
+		return me.ID == errRPCRemoteID
+	}
+	return false
+}
@@ -2,10 +2,12 @@ package messaging
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"go-backend/pkg/caching"
 	"go-backend/pkg/configs"
 	"go-backend/pkg/utils"
+	"runtime/debug"
 	"strconv"
 	"strings"
 	"sync"
@@ -87,6 +89,10 @@ func GetRedisVersion(ctx context.Context) (*RedisVersion, error) {
 type MessageCunsumer struct {
 	mType        []MessageType
 	consumerName string
+
+	// Dedup 是否跳过已处理过的消息（按去重ID记录在 Redis 中，有效期为 dedup_ttl），
+	// 提供有效期内效果上的恰好一次处理，见 dedup.go
+	Dedup bool
 }
 
 func NewMessageConsumer(consumerName string, mType ...MessageType) *MessageCunsumer {
@@ -132,7 +138,7 @@ func messageDispatcher(message MessageStruct) error {
 	}
 	anySuccess := false
 	for _, handler := range handlers {
-		if err := handler(message); err != nil {
+		if err := safeHandle(handler, message); err != nil {
 			logger.Error("处理消息 %s 失败: %v", message.id, err)
 		} else {
 			anySuccess = true
@@ -144,9 +150,23 @@ func messageDispatcher(message MessageStruct) error {
 	return nil
 }
 
+// safeHandle 调用消息处理器，并将处理器中的panic转换为错误，避免消费协程崩溃
+func safeHandle(handler MessageHandler, message MessageStruct) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.Error("处理消息 %s 时发生panic: %v\n%s", message.id, recovered, debug.Stack())
+			err = fmt.Errorf("处理器panic: %v", recovered)
+		}
+	}()
+	return handler(message)
+}
+
 // Consume 开始消费消息
 func (c *MessageCunsumer) Consume(ctx context.Context) {
+	metrics.consumers.Add(1)
+
 	go func() {
+		defer metrics.trackSubscriber()()
 		for {
 			select {
 			case <-ctx.Done():
@@ -158,6 +178,10 @@ func (c *MessageCunsumer) Consume(ctx context.Context) {
 					if err == context.Canceled {
 						return
 					}
+					if errors.Is(err, caching.ErrCircuitOpen) {
+						waitForRedisRecovery(ctx)
+						continue
+					}
 					logger.Error("[%s] 读取新消息错误: %v", c.consumerName, err)
 				}
 			}
@@ -165,6 +189,7 @@ func (c *MessageCunsumer) Consume(ctx context.Context) {
 	}()
 
 	go func() {
+		defer metrics.trackSubscriber()()
 		for {
 			select {
 			case <-ctx.Done():
@@ -176,12 +201,56 @@ func (c *MessageCunsumer) Consume(ctx context.Context) {
 					if err == context.Canceled {
 						return
 					}
+					if errors.Is(err, caching.ErrCircuitOpen) {
+						waitForRedisRecovery(ctx)
+						continue
+					}
 
 					logger.Error("[%s] 处理待处理消息错误: %v", c.consumerName, err)
 				}
 			}
 		}
 	}()
+
+	go c.sampleLag(ctx)
+}
+
+// sampleLag 定期采样各 Stream 的消费积压，供 Stats 读取；ctx 取消时注销消费者
+func (c *MessageCunsumer) sampleLag(ctx context.Context) {
+	defer metrics.consumers.Add(-1)
+
+	ticker := time.NewTicker(lagSampleInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !caching.Healthy() {
+				continue
+			}
+			config := configs.GetConfig().Server.Components.Messaging
+			for _, mType := range c.mType {
+				stream := fmt.Sprintf("%s:%s", config.StreamKey, mType)
+				if err := sampleStreamLag(ctx, caching.GetInstanceUnsafe(), stream, config.GroupName); err != nil && ctx.Err() == nil {
+					logger.Warn("[%s] 采样消费积压失败: %v", c.consumerName, err)
+				}
+			}
+		}
+	}
+}
+
+// circuitOpenRetryInterval Redis熔断期间消费者的重试间隔
+const circuitOpenRetryInterval = time.Second
+
+// waitForRedisRecovery Redis熔断期间暂停消费，避免空转
+func waitForRedisRecovery(ctx context.Context) {
+	timer := time.NewTimer(circuitOpenRetryInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
 }
 
 // readNewMessages 读取新消息
@@ -325,10 +394,14 @@ func (c *MessageCunsumer) processPendingMessages(ctx context.Context, handler fu
 
 // processMessage 处理单条消息
 func (c *MessageCunsumer) processMessage(ctx context.Context, message redis.XMessage, messageType string, handler func(MessageStruct) error) {
-	groupName := configs.GetConfig().Server.Components.Messaging.GroupName
-	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
-	client := caching.GetInstanceUnsafe()
+	config := configs.GetConfig().Server.Components.Messaging
+	c.processStreamMessage(ctx, caching.GetInstanceUnsafe(), config.StreamKey, config.GroupName,
+		time.Duration(config.DedupTTL)*time.Second, message, messageType, handler)
+}
 
+// processStreamMessage 解码并处理单条消息，处理成功（或开启去重时已处理过）后 ACK
+func (c *MessageCunsumer) processStreamMessage(ctx context.Context, client redis.Cmdable, streamKey, groupName string, dedupTTL time.Duration,
+	message redis.XMessage, messageType string, handler func(MessageStruct) error) {
 	// 解码消息
 	data, ok := message.Values["data"].(string)
 	if !ok {
@@ -345,9 +418,28 @@ func (c *MessageCunsumer) processMessage(ctx context.Context, message redis.XMes
 		return
 	}
 
+	// 已过期的消息不再处理，直接确认丢弃
+	if messageStruct.Expired(time.Now()) {
+		logger.Warn("[%s] 消息 %s 已过期，丢弃", c.consumerName, message.ID)
+		client.XAck(ctx, fmt.Sprintf("%s:%s", streamKey, messageType), groupName, message.ID)
+		return
+	}
+
 	// 执行业务处理
-	err := handler(messageStruct)
+	var err error
+	if c.Dedup {
+		var skipped bool
+		key := processedKey(streamKey, messageType, messageStruct.dedupID(message.ID))
+		skipped, err = handleDeduplicated(ctx, client, key, dedupTTL, messageStruct, handler)
+		if skipped {
+			logger.Warn("[%s] 消息 %s 已处理过，跳过", c.consumerName, message.ID)
+		}
+	} else {
+		err = handler(messageStruct)
+	}
+	counters := metrics.channel(fmt.Sprintf("%s:%s", streamKey, messageType))
 	if err != nil {
+		counters.handlerErrors.Add(1)
 		logger.Error("[%s] 处理消息失败 %s: %v", c.consumerName, message.ID, err)
 		// 不 ACK，让消息进入 pending 状态，等待重试
 		return
@@ -358,6 +450,7 @@ func (c *MessageCunsumer) processMessage(ctx context.Context, message redis.XMes
 		logger.Error("[%s] ACK 失败: %v", c.consumerName, err)
 		return
 	}
+	counters.consumed.Add(1)
 }
 
 // moveToDeadLetter 将消息移至死信队列
@@ -380,6 +473,7 @@ func (c *MessageCunsumer) moveToDeadLetter(ctx context.Context, messageID string
 		Stream: deadLetterKey,
 		Values: messages[0].Values,
 	})
+	metrics.channel(fmt.Sprintf("%s:%s", streamKey, messageType)).deadLettered.Add(1)
 
 	// ACK 原消息
 	client.XAck(ctx, fmt.Sprintf("%s:%s", streamKey, messageType), groupName, messageID)
@@ -0,0 +1,218 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-backend/pkg/caching"
+	"go-backend/pkg/configs"
+	"go-backend/pkg/utils"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// 基于 Redis 列表的请求/响应调用，用于实例之间的简单服务调用（例如查询某个 WebSocket 会话属于哪个实例）。
+// 请求写入服务的请求队列 {stream_key}:rpc:{service}，由任意一个提供该服务的实例取出处理；
+// 响应写入以关联ID命名的响应队列 {stream_key}:rpc:reply:{id}，调用方等待响应后删除该队列。
+// 请求和响应都使用 msgpack 编码，请求携带截止时间，服务端不处理已超时的请求，超时后才到达的响应随队列过期清理。
+// 阻塞读取的超时以秒为单位，超时时间的精度为1秒。
+
+// DefaultRPCTimeout ctx 没有截止时间时调用的默认超时时间
+const DefaultRPCTimeout = 5 * time.Second
+
+// rpcPollInterval 阻塞读取请求/响应的单次等待时间，每轮之间检查 ctx 是否已取消
+const rpcPollInterval = time.Second
+
+// rpcReplyGrace 响应队列在请求截止时间之后保留的时间，超过后由 Redis 过期删除
+const rpcReplyGrace = 10 * time.Second
+
+// rpcRequestKey 服务请求队列的键名
+func rpcRequestKey(streamKey, service string) string {
+	return streamKey + ":rpc:" + service
+}
+
+// rpcReplyKey 响应队列的键名
+func rpcReplyKey(streamKey, correlationID string) string {
+	return streamKey + ":rpc:reply:" + correlationID
+}
+
+// rpcRequest 请求队列中的请求
+type rpcRequest struct {
+	ID       string `msgpack:"id"`       // 关联ID
+	ReplyTo  string `msgpack:"replyTo"`  // 响应队列
+	Deadline int64  `msgpack:"deadline"` // 截止时间（毫秒时间戳）
+	Payload  []byte `msgpack:"payload"`  // msgpack 编码的请求参数
+}
+
+// rpcReply 响应队列中的响应
+type rpcReply struct {
+	ID      string `msgpack:"id"`
+	Error   string `msgpack:"error,omitempty"` // 服务端处理失败时的错误信息
+	Payload []byte `msgpack:"payload"`         // msgpack 编码的响应结果
+}
+
+// Call 调用 service 服务并等待响应，超时时间取 ctx 的截止时间，没有截止时间时使用 DefaultRPCTimeout。
+// 超时返回 ErrRPCTimeout，服务端处理失败时返回 ERR_RPC_REMOTE 错误
+func Call[Req, Resp any](ctx context.Context, service string, req Req) (Resp, error) {
+	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
+	return call[Req, Resp](ctx, caching.GetInstanceUnsafe(), streamKey, service, req)
+}
+
+func call[Req, Resp any](ctx context.Context, client redis.Cmdable, streamKey, service string, req Req) (Resp, error) {
+	var resp Resp
+	if strings.TrimSpace(service) == "" {
+		return resp, fmt.Errorf("rpc service is required")
+	}
+
+	payload, err := msgpack.Marshal(req)
+	if err != nil {
+		return resp, fmt.Errorf("msgpack 序列化失败: %w", err)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(DefaultRPCTimeout)
+	}
+	request := rpcRequest{
+		ID:       generateUniqueID(),
+		Deadline: deadline.UnixMilli(),
+		Payload:  payload,
+	}
+	request.ReplyTo = rpcReplyKey(streamKey, request.ID)
+	data, err := msgpack.Marshal(request)
+	if err != nil {
+		return resp, fmt.Errorf("msgpack 序列化失败: %w", err)
+	}
+
+	// 无论成功与否都删除响应队列，避免超时后才到达的响应残留
+	defer client.Del(context.WithoutCancel(ctx), request.ReplyTo)
+
+	// 请求队列的过期时间随每次调用顺延，没有服务端消费时积压的请求最终会被清理
+	requestKey := rpcRequestKey(streamKey, service)
+	pipe := client.TxPipeline()
+	pipe.LPush(ctx, requestKey, utils.ByteToString(data))
+	pipe.PExpire(ctx, requestKey, time.Until(deadline)+rpcReplyGrace)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return resp, fmt.Errorf("发送 RPC 请求失败: %w", err)
+	}
+
+	for {
+		if !time.Now().Before(deadline) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return resp, ErrRPCTimeout
+		}
+		if err := ctx.Err(); err != nil {
+			return resp, err
+		}
+
+		result, err := client.BRPop(ctx, rpcPollInterval, request.ReplyTo).Result()
+		if err == redis.Nil {
+			continue
+		}
+		if err != nil {
+			return resp, fmt.Errorf("等待 RPC 响应失败: %w", err)
+		}
+
+		var reply rpcReply
+		if err := msgpack.Unmarshal(utils.StringToByte(result[1]), &reply); err != nil {
+			return resp, fmt.Errorf("msgpack 反序列化失败: %w", err)
+		}
+		if reply.Error != "" {
+			return resp, NewError(errRPCRemoteID, reply.Error)
+		}
+		if err := msgpack.Unmarshal(reply.Payload, &resp); err != nil {
+			return resp, fmt.Errorf("msgpack 反序列化失败: %w", err)
+		}
+		return resp, nil
+	}
+}
+
+// Serve 启动 service 服务的请求处理协程，ctx 取消后停止。
+// 每个请求在独立的协程中处理，处理函数的 ctx 带有调用方的截止时间；
+// 同一服务可以在多个实例上启动，每个请求只会被其中一个实例处理
+func Serve[Req, Resp any](ctx context.Context, service string, handler func(ctx context.Context, req Req) (Resp, error)) error {
+	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
+	_, err := serve(ctx, caching.GetInstanceUnsafe(), streamKey, service, handler)
+	return err
+}
+
+// serve 启动请求处理协程，返回的通道在协程退出（不再读取请求队列）后关闭
+func serve[Req, Resp any](ctx context.Context, client redis.Cmdable, streamKey, service string, handler func(ctx context.Context, req Req) (Resp, error)) (<-chan struct{}, error) {
+	if strings.TrimSpace(service) == "" {
+		return nil, fmt.Errorf("rpc service is required")
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("rpc handler is required")
+	}
+
+	requestKey := rpcRequestKey(streamKey, service)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		logger.Info("RPC 服务已启动: %s", service)
+		for ctx.Err() == nil {
+			result, err := client.BRPop(ctx, rpcPollInterval, requestKey).Result()
+			if err == redis.Nil {
+				continue
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				logger.Error("读取 RPC 服务 %s 的请求失败: %v", service, err)
+				select {
+				case <-ctx.Done():
+				case <-time.After(rpcPollInterval):
+				}
+				continue
+			}
+			go handleRPCRequest(ctx, client, service, result[1], handler)
+		}
+		logger.Info("RPC 服务已停止: %s", service)
+	}()
+	return done, nil
+}
+
+// handleRPCRequest 处理一个请求并写入响应，已超时的请求直接丢弃
+func handleRPCRequest[Req, Resp any](ctx context.Context, client redis.Cmdable, service, item string, handler func(ctx context.Context, req Req) (Resp, error)) {
+	var request rpcRequest
+	if err := msgpack.Unmarshal(utils.StringToByte(item), &request); err != nil {
+		logger.Error("RPC 服务 %s 的请求反序列化失败: %v", service, err)
+		return
+	}
+	deadline := time.UnixMilli(request.Deadline)
+	if !time.Now().Before(deadline) {
+		logger.Warn("RPC 服务 %s 的请求 %s 在处理前已超时，丢弃", service, request.ID)
+		return
+	}
+
+	reply := rpcReply{ID: request.ID}
+	var req Req
+	if err := msgpack.Unmarshal(request.Payload, &req); err != nil {
+		reply.Error = fmt.Sprintf("invalid request: %v", err)
+	} else {
+		handlerCtx, cancel := context.WithDeadline(ctx, deadline)
+		resp, err := handler(handlerCtx, req)
+		cancel()
+		if err != nil {
+			reply.Error = err.Error()
+		} else if reply.Payload, err = msgpack.Marshal(resp); err != nil {
+			reply.Error = fmt.Sprintf("invalid response: %v", err)
+		}
+	}
+
+	data, err := msgpack.Marshal(reply)
+	if err != nil {
+		logger.Error("RPC 服务 %s 的响应序列化失败: %v", service, err)
+		return
+	}
+	pipe := client.TxPipeline()
+	pipe.LPush(ctx, request.ReplyTo, utils.ByteToString(data))
+	pipe.PExpire(ctx, request.ReplyTo, time.Until(deadline)+rpcReplyGrace)
+	if _, err := pipe.Exec(ctx); err != nil {
+		logger.Error("写入 RPC 服务 %s 的响应失败: %v", service, err)
+	}
+}
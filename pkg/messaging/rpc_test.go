@@ -0,0 +1,106 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type sessionOwnerRequest struct {
+	SessionID string `msgpack:"sessionId"`
+}
+
+type sessionOwnerResponse struct {
+	InstanceID string `msgpack:"instanceId"`
+	Clients    int    `msgpack:"clients"`
+}
+
+func TestRPCRoundTrip(t *testing.T) {
+	server, client := setupDelayedTest(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handler := func(_ context.Context, req sessionOwnerRequest) (sessionOwnerResponse, error) {
+		if req.SessionID == "missing" {
+			return sessionOwnerResponse{}, fmt.Errorf("session %s not found", req.SessionID)
+		}
+		return sessionOwnerResponse{InstanceID: "instance-" + req.SessionID, Clients: 3}, nil
+	}
+	done, err := serve(ctx, client, "test", "socket.owner", handler)
+	if err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	// 关闭内存 Redis 前等待服务协程退出，避免关闭时仍有阻塞中的读取
+	defer func() { <-done }()
+	defer cancel()
+
+	callCtx, callCancel := context.WithTimeout(ctx, 3*time.Second)
+	defer callCancel()
+	resp, err := call[sessionOwnerRequest, sessionOwnerResponse](callCtx, client, "test", "socket.owner", sessionOwnerRequest{SessionID: "42"})
+	if err != nil {
+		t.Fatalf("call failed: %v", err)
+	}
+	if resp.InstanceID != "instance-42" || resp.Clients != 3 {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+
+	_, err = call[sessionOwnerRequest, sessionOwnerResponse](callCtx, client, "test", "socket.owner", sessionOwnerRequest{SessionID: "missing"})
+	if !IsRPCRemoteError(err) || err.(*MessagingError).Msg != "session missing not found" {
+		t.Fatalf("expected remote error, got %v", err)
+	}
+
+	// 响应队列在调用结束后被删除
+	for _, key := range server.Keys() {
+		if strings.HasPrefix(key, "test:rpc:reply:") {
+			t.Fatalf("reply queue %s was not cleaned up", key)
+		}
+	}
+}
+
+func TestRPCCallTimeout(t *testing.T) {
+	server, client := setupDelayedTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, err := call[sessionOwnerRequest, sessionOwnerResponse](ctx, client, "test", "nobody", sessionOwnerRequest{SessionID: "1"})
+	if !IsRPCTimeoutError(err) {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Fatalf("call should give up shortly after its deadline, took %v", elapsed)
+	}
+
+	// 没有服务端处理的请求留在队列中，服务端启动后会因已超时而丢弃
+	if items, err := server.List("test:rpc:nobody"); err != nil || len(items) != 1 {
+		t.Fatalf("expected the unanswered request to stay queued, got %v %v", items, err)
+	}
+	var handled atomic.Bool
+	serveCtx, serveCancel := context.WithCancel(context.Background())
+	defer serveCancel()
+	done, err := serve(serveCtx, client, "test", "nobody", func(_ context.Context, req sessionOwnerRequest) (sessionOwnerResponse, error) {
+		handled.Store(true)
+		return sessionOwnerResponse{}, nil
+	})
+	if err != nil {
+		t.Fatalf("serve failed: %v", err)
+	}
+	defer func() { <-done }()
+	defer serveCancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if !server.Exists("test:rpc:nobody") {
+			break
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	if server.Exists("test:rpc:nobody") {
+		t.Fatal("expired request should be consumed")
+	}
+	if handled.Load() {
+		t.Fatal("expired request should not reach the handler")
+	}
+}
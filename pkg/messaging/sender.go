@@ -14,14 +14,27 @@ import (
 
 // Publish 发布消息（使用 msgpack 序列化）
 func Publish(ctx context.Context, task MessageStruct) (string, error) {
+	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
+	return publishToStream(ctx, caching.GetInstanceUnsafe(), streamKey, task)
+}
+
+// PublishWithTTL 发布带有效期的消息，超过 ttl 仍未被处理的消息会被消费者直接丢弃
+func PublishWithTTL(ctx context.Context, task MessageStruct, ttl time.Duration) (string, error) {
+	if ttl <= 0 {
+		return "", fmt.Errorf("ttl must be positive")
+	}
+	task.ExpiresAt = time.Now().Add(ttl).UnixMilli()
+	return Publish(ctx, task)
+}
+
+// publishToStream 将消息写入对应类型的 Stream
+func publishToStream(ctx context.Context, client redis.Cmdable, streamKey string, task MessageStruct) (string, error) {
 	err := prehandleTask(ctx, &task)
 
 	if err != nil {
 		return "", fmt.Errorf("预处理任务失败: %w", err)
 	}
 
-	streamKey := configs.GetConfig().Server.Components.Messaging.StreamKey
-
 	// 使用 msgpack 序列化
 	data, err := msgpack.Marshal(task)
 	if err != nil {
@@ -29,8 +42,9 @@ func Publish(ctx context.Context, task MessageStruct) (string, error) {
 	}
 
 	// 添加到 Stream
-	result, err := caching.GetInstanceUnsafe().XAdd(ctx, &redis.XAddArgs{
-		Stream: fmt.Sprintf("%s:%s", streamKey, task.Type), // 使用不同的 Stream 存储不同类型的消息
+	stream := fmt.Sprintf("%s:%s", streamKey, task.Type) // 使用不同的 Stream 存储不同类型的消息
+	result, err := client.XAdd(ctx, &redis.XAddArgs{
+		Stream: stream,
 		Values: map[string]any{
 			"data": utils.ByteToString(data),
 		},
@@ -39,6 +53,7 @@ func Publish(ctx context.Context, task MessageStruct) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("发布到 Stream 失败: %w", err)
 	}
+	metrics.channel(stream).published.Add(1)
 
 	return result, nil
 }
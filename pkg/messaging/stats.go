@@ -0,0 +1,159 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-backend/pkg/caching"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// 消息层指标：按频道（Stream 名称或 Pub/Sub 频道）统计发布、消费、处理失败和转入死信的消息数，
+// 以及正在运行的订阅协程数。计数使用原子操作，Stats 只读取内存中的数据，可以在健康检查中频繁调用。
+// Streams 的消费积压由消费者在后台通过 XPENDING 定期采样，Stats 返回最近一次采样的结果。
+
+// lagSampleInterval 消费积压的采样间隔
+const lagSampleInterval = 10 * time.Second
+
+// ChannelStats 单个频道的统计
+type ChannelStats struct {
+	Channel       string `json:"channel"`
+	Published     int64  `json:"published"`
+	Consumed      int64  `json:"consumed"`
+	HandlerErrors int64  `json:"handlerErrors"`
+	DeadLettered  int64  `json:"deadLettered"`
+	// 以下为 Streams 消费者组的积压，最近一次采样的结果
+	Pending       int64      `json:"pending"`                // 已投递但未确认的消息数
+	OldestPending int64      `json:"oldestPendingMs"`        // 最早一条未确认消息的空闲时长（毫秒）
+	LagSampledAt  *time.Time `json:"lagSampledAt,omitempty"` // 采样时间，未采样时为空
+}
+
+// StatsSnapshot 消息层统计快照
+type StatsSnapshot struct {
+	Healthy     bool           `json:"healthy"`
+	Consumers   int            `json:"consumers"`   // 已启动的 Streams 消费者数
+	Subscribers int64          `json:"subscribers"` // 正在运行的订阅协程数（消费循环和 Pub/Sub 桥接）
+	Channels    []ChannelStats `json:"channels"`
+}
+
+// channelCounters 单个频道的计数器
+type channelCounters struct {
+	published     atomic.Int64
+	consumed      atomic.Int64
+	handlerErrors atomic.Int64
+	deadLettered  atomic.Int64
+
+	lagMu         sync.Mutex
+	pending       int64
+	oldestPending time.Duration
+	lagSampledAt  time.Time
+}
+
+// messagingMetrics 进程内的消息层指标
+type messagingMetrics struct {
+	channels    sync.Map // 频道名称 -> *channelCounters
+	subscribers atomic.Int64
+	// consumers 已启动的消费者数，每个消费者运行两个订阅协程
+	consumers atomic.Int64
+}
+
+var metrics = &messagingMetrics{}
+
+// channel 返回频道的计数器，不存在时创建
+func (m *messagingMetrics) channel(name string) *channelCounters {
+	if counters, ok := m.channels.Load(name); ok {
+		return counters.(*channelCounters)
+	}
+	counters, _ := m.channels.LoadOrStore(name, &channelCounters{})
+	return counters.(*channelCounters)
+}
+
+// trackSubscriber 登记一个订阅协程，返回的函数在协程退出时调用
+func (m *messagingMetrics) trackSubscriber() func() {
+	m.subscribers.Add(1)
+	return func() { m.subscribers.Add(-1) }
+}
+
+// recordLag 保存一次积压采样
+func (c *channelCounters) recordLag(pending int64, oldest time.Duration, at time.Time) {
+	c.lagMu.Lock()
+	defer c.lagMu.Unlock()
+	c.pending = pending
+	c.oldestPending = oldest
+	c.lagSampledAt = at
+}
+
+// snapshot 读取频道统计
+func (c *channelCounters) snapshot(name string) ChannelStats {
+	stats := ChannelStats{
+		Channel:       name,
+		Published:     c.published.Load(),
+		Consumed:      c.consumed.Load(),
+		HandlerErrors: c.handlerErrors.Load(),
+		DeadLettered:  c.deadLettered.Load(),
+	}
+	c.lagMu.Lock()
+	defer c.lagMu.Unlock()
+	if !c.lagSampledAt.IsZero() {
+		sampledAt := c.lagSampledAt
+		stats.Pending = c.pending
+		stats.OldestPending = c.oldestPending.Milliseconds()
+		stats.LagSampledAt = &sampledAt
+	}
+	return stats
+}
+
+// Stats 返回消息层的统计快照，并发安全且不访问 Redis
+// 已启动消费者时，要求缓存服务可用且每个消费者的两个消费协程都在运行才视为健康
+func Stats() StatsSnapshot {
+	return metrics.stats(caching.Healthy())
+}
+
+func (m *messagingMetrics) stats(redisHealthy bool) StatsSnapshot {
+	stats := StatsSnapshot{
+		Consumers:   int(m.consumers.Load()),
+		Subscribers: m.subscribers.Load(),
+		Channels:    make([]ChannelStats, 0),
+	}
+	m.channels.Range(func(key, value any) bool {
+		stats.Channels = append(stats.Channels, value.(*channelCounters).snapshot(key.(string)))
+		return true
+	})
+	sort.Slice(stats.Channels, func(i, j int) bool { return stats.Channels[i].Channel < stats.Channels[j].Channel })
+
+	stats.Healthy = redisHealthy && stats.Subscribers >= int64(stats.Consumers)*2
+	return stats
+}
+
+// sampleStreamLag 通过 XPENDING 采样一个 Stream 在消费者组中的积压
+func sampleStreamLag(ctx context.Context, client redis.Cmdable, stream, groupName string) error {
+	summary, err := client.XPending(ctx, stream, groupName).Result()
+	if err != nil {
+		return fmt.Errorf("查询 %s 的待确认消息失败: %w", stream, err)
+	}
+
+	var oldest time.Duration
+	if summary.Count > 0 {
+		pending, err := client.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: stream,
+			Group:  groupName,
+			Start:  summary.Lower,
+			End:    summary.Lower,
+			Count:  1,
+		}).Result()
+		if err != nil {
+			return fmt.Errorf("查询 %s 最早的待确认消息失败: %w", stream, err)
+		}
+		if len(pending) > 0 {
+			oldest = pending[0].Idle
+		}
+	}
+
+	metrics.channel(stream).recordLag(summary.Count, oldest, time.Now())
+	return nil
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// channelStats 从统计快照中找到指定频道
+func channelStats(t *testing.T, stats StatsSnapshot, channel string) ChannelStats {
+	t.Helper()
+	for _, item := range stats.Channels {
+		if item.Channel == channel {
+			return item
+		}
+	}
+	t.Fatalf("channel %s not found in stats", channel)
+	return ChannelStats{}
+}
+
+func TestStatsCountsPerChannel(t *testing.T) {
+	_, client := setupDelayedTest(t)
+	ctx := context.Background()
+
+	// 处理失败的 Stream 消息计入处理错误，不计入已消费
+	consumer := &MessageCunsumer{mType: []MessageType{ServerToWorker}, consumerName: "stats"}
+	failing := func(MessageStruct) error { return errors.New("boom") }
+	consumer.processStreamMessage(ctx, client, "stats-stream", "group", time.Hour,
+		newStreamEntry(t, "1-0", MessageStruct{Type: ServerToWorker}), string(ServerToWorker), failing)
+
+	stream := channelStats(t, metrics.stats(true), "stats-stream:worker")
+	if stream.HandlerErrors != 1 || stream.Consumed != 0 || stream.LagSampledAt != nil {
+		t.Fatalf("unexpected stream stats: %+v", stream)
+	}
+
+	// 桥接按订阅的频道模式统计，转发失败计入处理错误
+	messages := make(chan *redis.Message, 2)
+	messages <- &redis.Message{Channel: "stats-events:1", Payload: "a"}
+	messages <- &redis.Message{Channel: "stats-events:2", Payload: "b"}
+	close(messages)
+	calls := 0
+	runBridge(ctx, messages, "stats-events:*", "topic/{match}", nil, func(MessageStruct) error {
+		calls++
+		if calls == 2 {
+			return errors.New("publish failed")
+		}
+		return nil
+	})
+
+	bridge := channelStats(t, metrics.stats(true), "stats-events:*")
+	if bridge.Consumed != 2 || bridge.HandlerErrors != 1 {
+		t.Fatalf("unexpected bridge stats: %+v", bridge)
+	}
+}
+
+func TestStatsHealth(t *testing.T) {
+	m := &messagingMetrics{}
+	if stats := m.stats(true); !stats.Healthy || len(stats.Channels) != 0 {
+		t.Fatalf("no consumers should be healthy, got %+v", stats)
+	}
+
+	m.consumers.Add(1)
+	stopRead := m.trackSubscriber()
+	m.trackSubscriber()
+	if stats := m.stats(true); !stats.Healthy || stats.Subscribers != 2 {
+		t.Fatalf("running consumer should be healthy, got %+v", stats)
+	}
+	if m.stats(false).Healthy {
+		t.Fatal("messaging should be unhealthy when redis is down")
+	}
+
+	// 消费协程退出后视为不健康
+	stopRead()
+	if stats := m.stats(true); stats.Healthy || stats.Subscribers != 1 {
+		t.Fatalf("stopped consumer goroutine should be unhealthy, got %+v", stats)
+	}
+}
+
+func TestStatsConcurrentAccess(t *testing.T) {
+	m := &messagingMetrics{}
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				m.channel("concurrent").published.Add(1)
+				m.channel("concurrent").recordLag(int64(j), time.Millisecond, time.Now())
+				m.stats(true)
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := channelStats(t, m.stats(true), "concurrent")
+	if stats.Published != 800 || stats.LagSampledAt == nil || stats.OldestPending != 1 {
+		t.Fatalf("unexpected concurrent stats: %+v", stats)
+	}
+}
@@ -26,6 +26,14 @@ type MessageStruct struct {
 	Type     MessageType  `msgpack:"type"`     // 消息类型
 	Payload  TopicPayload `msgpack:"payload"`  // 根据消息的类型不同,这里会是不同的Payload结构体
 	Priority int          `msgpack:"priority"` // 优先级，数字越大优先级越高
+
+	ExpiresAt int64  `msgpack:"expires_at"` // 过期时间（毫秒时间戳），超过后未被处理的消息直接丢弃，0表示永不过期
+	DedupKey  string `msgpack:"dedup_key"`  // 去重ID，开启去重的消费者据此跳过已处理的消息，为空时使用 Stream 条目ID
+}
+
+// Expired 判断消息在指定时间是否已经过期
+func (m MessageStruct) Expired(now time.Time) bool {
+	return m.ExpiresAt > 0 && now.UnixMilli() > m.ExpiresAt
 }
 
 type ChannelOpenCheckPayload struct {
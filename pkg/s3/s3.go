@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,6 +34,32 @@ type S3Client struct {
 	config   *configs.S3Config
 }
 
+// 服务端加密算法
+const (
+	SSEAlgorithmS3  = "AES256"  // SSE-S3
+	SSEAlgorithmKMS = "aws:kms" // SSE-KMS
+)
+
+// SSEOptions 服务端加密选项
+type SSEOptions struct {
+	Algorithm string // 加密算法: SSEAlgorithmS3 或 SSEAlgorithmKMS
+	KMSKeyID  string // SSE-KMS使用的密钥ID，为空时使用默认KMS密钥
+}
+
+// UploadOptions 上传选项
+type UploadOptions struct {
+	ContentType        string
+	ContentDisposition string
+	Metadata           map[string]string // 用户自定义元数据（x-amz-meta-*）
+	SSE                *SSEOptions       // 服务端加密，为nil时使用配置中的默认值
+}
+
+// PresignedUpload 预签名上传信息
+type PresignedUpload struct {
+	URL     string
+	Headers map[string]string // 上传时必须携带的已签名请求头（如加密、元数据）
+}
+
 // 单例相关变量
 var (
 	Client *S3Client
@@ -150,6 +178,11 @@ func (c *S3Client) TestConnection() error {
 
 // UploadFile 上传文件到S3
 func (c *S3Client) UploadFile(bucket, key string, body io.Reader, contentType string) (*manager.UploadOutput, error) {
+	return c.UploadFileWithOptions(bucket, key, body, &UploadOptions{ContentType: contentType})
+}
+
+// UploadFileWithOptions 使用指定的元数据和加密选项上传文件到S3
+func (c *S3Client) UploadFileWithOptions(bucket, key string, body io.Reader, opts *UploadOptions) (*manager.UploadOutput, error) {
 	if bucket == "" {
 		bucket = c.config.Bucket
 	}
@@ -160,12 +193,46 @@ func (c *S3Client) UploadFile(bucket, key string, body io.Reader, contentType st
 		Key:    aws.String(key),
 		Body:   body,
 	}
+	c.applyUploadOptions(input, opts)
 
-	if contentType != "" {
-		input.ContentType = aws.String(contentType)
+	return c.uploader.Upload(ctx, input)
+}
+
+// applyUploadOptions 将上传选项应用到PutObject请求，未指定加密时使用配置中的默认加密
+func (c *S3Client) applyUploadOptions(input *s3.PutObjectInput, opts *UploadOptions) {
+	if opts == nil {
+		opts = &UploadOptions{}
 	}
 
-	return c.uploader.Upload(ctx, input)
+	if opts.ContentType != "" {
+		input.ContentType = aws.String(opts.ContentType)
+	}
+	if opts.ContentDisposition != "" {
+		input.ContentDisposition = aws.String(opts.ContentDisposition)
+	}
+	if len(opts.Metadata) > 0 {
+		input.Metadata = opts.Metadata
+	}
+
+	sse := opts.SSE
+	if sse == nil && c.config.SSE.Enabled {
+		sse = &SSEOptions{
+			Algorithm: c.config.SSE.Algorithm,
+			KMSKeyID:  c.config.SSE.KMSKeyID,
+		}
+	}
+	if sse == nil {
+		return
+	}
+
+	algorithm := sse.Algorithm
+	if algorithm == "" {
+		algorithm = SSEAlgorithmS3
+	}
+	input.ServerSideEncryption = types.ServerSideEncryption(algorithm)
+	if algorithm == SSEAlgorithmKMS && sse.KMSKeyID != "" {
+		input.SSEKMSKeyId = aws.String(sse.KMSKeyID)
+	}
 }
 
 // DownloadFile 从S3下载文件
@@ -223,6 +290,16 @@ func (c *S3Client) GetFileURL(bucket, key string, expiration time.Duration) (str
 
 // GetPresignedPutURL 获取文件上传的预签名URL
 func (c *S3Client) GetPresignedPutURL(bucket, key string, expiration time.Duration, contentType string) (string, error) {
+	upload, err := c.PresignPutObject(bucket, key, expiration, &UploadOptions{ContentType: contentType})
+	if err != nil {
+		return "", err
+	}
+
+	return upload.URL, nil
+}
+
+// PresignPutObject 生成带元数据和加密选项的预签名上传信息，客户端上传时需携带返回的请求头
+func (c *S3Client) PresignPutObject(bucket, key string, expiration time.Duration, opts *UploadOptions) (*PresignedUpload, error) {
 	if bucket == "" {
 		bucket = c.config.Bucket
 	}
@@ -234,20 +311,32 @@ func (c *S3Client) GetPresignedPutURL(bucket, key string, expiration time.Durati
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	}
-
-	if contentType != "" {
-		input.ContentType = aws.String(contentType)
-	}
+	c.applyUploadOptions(input, opts)
 
 	req, err := presignClient.PresignPutObject(ctx, input, func(opts *s3.PresignOptions) {
 		opts.Expires = expiration
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return req.URL, nil
+	return &PresignedUpload{
+		URL:     req.URL,
+		Headers: signedUploadHeaders(req.SignedHeader),
+	}, nil
+}
+
+// signedUploadHeaders 提取客户端上传时需要携带的已签名请求头（Host由客户端自动设置）
+func signedUploadHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for name, values := range header {
+		if strings.EqualFold(name, "Host") || len(values) == 0 {
+			continue
+		}
+		headers[name] = strings.Join(values, ",")
+	}
+	return headers
 }
 
 // FileExists 检查文件是否存在
@@ -177,17 +177,21 @@ func (p *AliyunProvider) SendVerificationCode(ctx context.Context, req *Verifica
 		purposeText = "验证"
 	}
 
-	// 构建发送请求
-	sendReq := &SendMessageRequest{
-		PhoneNumber: req.PhoneNumber,
-		TemplateParam: map[string]string{
-			"code":    req.Code,
-			"purpose": purposeText,
-		},
-		Timeout: req.Timeout,
+	params := map[string]string{
+		"code":    req.Code,
+		"purpose": purposeText,
 	}
+	return sendTemplateResponse(ctx, p, req.PhoneNumber, req.TemplateID, params, req.Timeout)
+}
+
+// Send 阿里云仅支持模板短信
+func (p *AliyunProvider) Send(ctx context.Context, to, body string) error {
+	return ErrTemplateRequired
+}
 
-	return p.SendMessage(ctx, sendReq)
+// SendTemplate 使用阿里云短信模板发送
+func (p *AliyunProvider) SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error {
+	return sendResultError(sendTemplateResponse(ctx, p, to, templateID, params, 0))
 }
 
 // Close 关闭客户端连接
@@ -280,13 +280,20 @@ func (p *HTTPProvider) SendVerificationCode(ctx context.Context, req *Verificati
 		"purpose": req.Purpose,
 	}
 
-	sendReq := &SendMessageRequest{
-		PhoneNumber:   req.PhoneNumber,
-		TemplateParam: templateParam,
-		Timeout:       req.Timeout,
-	}
+	return sendTemplateResponse(ctx, p, req.PhoneNumber, req.TemplateID, templateParam, req.Timeout)
+}
+
+// Send 发送自由文本短信，内容通过 content 字段传给下游接口
+func (p *HTTPProvider) Send(ctx context.Context, to, body string) error {
+	return sendResultError(p.SendMessage(ctx, &SendMessageRequest{
+		PhoneNumber: to,
+		Content:     body,
+	}))
+}
 
-	return p.SendMessage(ctx, sendReq)
+// SendTemplate 使用模板发送短信
+func (p *HTTPProvider) SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error {
+	return sendResultError(sendTemplateResponse(ctx, p, to, templateID, params, 0))
 }
 
 // Close 关闭客户端连接
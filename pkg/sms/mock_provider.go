@@ -89,6 +89,19 @@ func (p *MockProvider) SendVerificationCode(ctx context.Context, req *Verificati
 	}, nil
 }
 
+// Send 打印自由文本短信
+func (p *MockProvider) Send(ctx context.Context, to, body string) error {
+	return sendResultError(p.SendMessage(ctx, &SendMessageRequest{
+		PhoneNumber: to,
+		Content:     body,
+	}))
+}
+
+// SendTemplate 打印模板短信
+func (p *MockProvider) SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error {
+	return sendResultError(sendTemplateResponse(ctx, p, to, templateID, params, 0))
+}
+
 // ValidateConfig 验证配置是否有效
 func (p *MockProvider) ValidateConfig() error {
 	// Mock provider 不需要特殊配置验证
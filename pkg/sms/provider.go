@@ -2,9 +2,16 @@ package sms
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
 	"time"
 )
 
+// ErrTemplateRequired 提供商只支持模板短信（国内运营商要求），不能发送自由文本
+var ErrTemplateRequired = errors.New("该短信提供商仅支持模板短信")
+
 // SMSProvider 短信提供商接口
 type SMSProvider interface {
 	// Name 返回提供商名称
@@ -13,7 +20,13 @@ type SMSProvider interface {
 	// SendMessage 发送短信消息
 	SendMessage(ctx context.Context, req *SendMessageRequest) (*SendMessageResponse, error)
 
-	// SendVerificationCode 发送验证码短信
+	// Send 发送自由文本短信，仅支持模板的提供商返回 ErrTemplateRequired
+	Send(ctx context.Context, to, body string) error
+
+	// SendTemplate 使用提供商侧的模板发送短信，templateID 为空时使用配置的默认模板
+	SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error
+
+	// SendVerificationCode 发送验证码短信（通过 SendTemplate 发送）
 	SendVerificationCode(ctx context.Context, req *VerificationCodeRequest) (*SendMessageResponse, error)
 
 	// ValidateConfig 验证配置是否有效
@@ -38,6 +51,7 @@ type VerificationCodeRequest struct {
 	PhoneNumber string        `json:"phone_number"` // 手机号码
 	Code        string        `json:"code"`         // 验证码
 	Purpose     string        `json:"purpose"`      // 用途 (register, login, reset_password)
+	TemplateID  string        `json:"template_id"`  // 模板ID，为空时使用提供商配置的默认模板
 	Timeout     time.Duration `json:"timeout"`      // 超时时间
 }
 
@@ -50,6 +64,62 @@ type SendMessageResponse struct {
 	Message   string `json:"message"`    // 响应消息
 }
 
+// sendResultError 将发送结果转换为错误
+func sendResultError(resp *SendMessageResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp == nil || !resp.Success {
+		message := ""
+		if resp != nil {
+			message = resp.Message
+		}
+		return fmt.Errorf("短信发送失败: %s", message)
+	}
+	return nil
+}
+
+// sendTemplateResponse 以模板方式发送并返回原始响应，供 SendVerificationCode 复用
+func sendTemplateResponse(ctx context.Context, provider SMSProvider, to, templateID string, params map[string]string, timeout time.Duration) (*SendMessageResponse, error) {
+	return provider.SendMessage(ctx, &SendMessageRequest{
+		PhoneNumber:   to,
+		TemplateCode:  templateID,
+		TemplateParam: params,
+		Timeout:       timeout,
+	})
+}
+
+// orderedTemplateParams 按位置顺序排列模板参数，用于按序号传参的提供商（如腾讯云）
+// 参数名全部为数字时按数字排序（1, 2, 3...）；否则 code 排在最前，其余按名称排序，保证顺序稳定
+func orderedTemplateParams(params map[string]string) []string {
+	keys := make([]string, 0, len(params))
+	numeric := true
+	for key := range params {
+		keys = append(keys, key)
+		if _, err := strconv.Atoi(key); err != nil {
+			numeric = false
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if numeric {
+			a, _ := strconv.Atoi(keys[i])
+			b, _ := strconv.Atoi(keys[j])
+			return a < b
+		}
+		if keys[i] == "code" || keys[j] == "code" {
+			return keys[i] == "code"
+		}
+		return keys[i] < keys[j]
+	})
+
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, params[key])
+	}
+	return values
+}
+
 // ProviderType 提供商类型
 type ProviderType string
 
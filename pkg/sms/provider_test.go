@@ -0,0 +1,51 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-backend/pkg/configs"
+)
+
+func TestOrderedTemplateParams(t *testing.T) {
+	got := orderedTemplateParams(map[string]string{"2": "b", "10": "c", "1": "a"})
+	if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("numeric keys: got %v, want %v", got, want)
+	}
+
+	got = orderedTemplateParams(map[string]string{"minutes": "5", "code": "123456", "app": "qc"})
+	if want := []string{"123456", "qc", "5"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("named keys: got %v, want %v", got, want)
+	}
+}
+
+func TestSendResultError(t *testing.T) {
+	if err := sendResultError(&SendMessageResponse{Success: true}, nil); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := sendResultError(&SendMessageResponse{Success: false, Message: "quota"}, nil); err == nil {
+		t.Fatal("expected error for unsuccessful response")
+	}
+	if err := sendResultError(nil, ErrTemplateRequired); !errors.Is(err, ErrTemplateRequired) {
+		t.Fatalf("expected ErrTemplateRequired, got %v", err)
+	}
+}
+
+func TestClientUsesPurposeTemplate(t *testing.T) {
+	client := &SMSClient{
+		config: &configs.SMSConfig{VerifyCodeTemplates: map[string]string{"login": "TPL_LOGIN"}},
+	}
+	if got := client.verifyCodeTemplate("login"); got != "TPL_LOGIN" {
+		t.Fatalf("expected TPL_LOGIN, got %q", got)
+	}
+	if got := client.verifyCodeTemplate("register"); got != "" {
+		t.Fatalf("expected default template, got %q", got)
+	}
+
+	client.provider = &MockProvider{}
+	if err := client.SendTemplate(context.Background(), "13800000000", "TPL_LOGIN", map[string]string{"code": "1234"}); err != nil {
+		t.Fatalf("mock SendTemplate: %v", err)
+	}
+}
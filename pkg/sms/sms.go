@@ -173,6 +173,7 @@ func (c *SMSClient) SendVerificationCodeWithContext(ctx context.Context, phoneNu
 		PhoneNumber: phoneNumber,
 		Code:        code,
 		Purpose:     purpose,
+		TemplateID:  c.verifyCodeTemplate(purpose),
 		Timeout:     30 * time.Second,
 	}
 
@@ -188,6 +189,30 @@ func (c *SMSClient) SendVerificationCodeWithContext(ctx context.Context, phoneNu
 	return nil
 }
 
+// verifyCodeTemplate 返回用途对应的验证码模板ID，未配置时返回空串（使用提供商默认模板）
+func (c *SMSClient) verifyCodeTemplate(purpose string) string {
+	if c.config == nil || purpose == "" {
+		return ""
+	}
+	return c.config.VerifyCodeTemplates[purpose]
+}
+
+// Send 发送自由文本短信
+func (c *SMSClient) Send(ctx context.Context, to, body string) error {
+	if c.provider == nil {
+		return fmt.Errorf("短信提供商未初始化")
+	}
+	return c.provider.Send(ctx, to, body)
+}
+
+// SendTemplate 使用模板发送短信
+func (c *SMSClient) SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error {
+	if c.provider == nil {
+		return fmt.Errorf("短信提供商未初始化")
+	}
+	return c.provider.SendTemplate(ctx, to, templateID, params)
+}
+
 // SendSimpleVerificationCode 发送简单验证码短信 (只包含验证码)
 func (c *SMSClient) SendSimpleVerificationCode(phoneNumber, code string) error {
 	return c.SendVerificationCode(phoneNumber, code, "")
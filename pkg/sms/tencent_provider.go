@@ -109,16 +109,7 @@ func (p *TencentProvider) SendMessage(ctx context.Context, req *SendMessageReque
 	// 准备模板参数数组 (腾讯云按数字索引顺序传递参数)
 	var templateParamSet []*string
 	if len(req.TemplateParam) > 0 {
-		// 腾讯云模板参数需要按顺序传递，这里按照常见的参数顺序
-		if code, exists := req.TemplateParam["code"]; exists {
-			templateParamSet = append(templateParamSet, common.StringPtr(code))
-		}
-		// 可以根据需要添加更多参数
-		for key, value := range req.TemplateParam {
-			if key != "code" { // 避免重复添加code
-				templateParamSet = append(templateParamSet, common.StringPtr(value))
-			}
-		}
+		templateParamSet = common.StringPtrs(orderedTemplateParams(req.TemplateParam))
 	}
 
 	// 创建发送请求
@@ -180,15 +171,20 @@ func (p *TencentProvider) SendMessage(ctx context.Context, req *SendMessageReque
 // SendVerificationCode 发送验证码短信
 func (p *TencentProvider) SendVerificationCode(ctx context.Context, req *VerificationCodeRequest) (*SendMessageResponse, error) {
 	// 腾讯云通常只需要验证码参数
-	sendReq := &SendMessageRequest{
-		PhoneNumber: req.PhoneNumber,
-		TemplateParam: map[string]string{
-			"code": req.Code,
-		},
-		Timeout: req.Timeout,
+	params := map[string]string{
+		"code": req.Code,
 	}
+	return sendTemplateResponse(ctx, p, req.PhoneNumber, req.TemplateID, params, req.Timeout)
+}
+
+// Send 腾讯云仅支持模板短信
+func (p *TencentProvider) Send(ctx context.Context, to, body string) error {
+	return ErrTemplateRequired
+}
 
-	return p.SendMessage(ctx, sendReq)
+// SendTemplate 使用腾讯云短信模板发送，参数按 orderedTemplateParams 的顺序传递
+func (p *TencentProvider) SendTemplate(ctx context.Context, to, templateID string, params map[string]string) error {
+	return sendResultError(sendTemplateResponse(ctx, p, to, templateID, params, 0))
 }
 
 // Close 关闭客户端连接
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/go-viper/mapstructure/v2"
 )
@@ -137,3 +138,150 @@ func MapToString(m map[string]interface{}) string {
 	}
 	return string(data)
 }
+
+// MergeJSON 将 patch 深度合并到 base 上，返回新的 map，不修改入参
+// 合并规则与 JSON Merge Patch (RFC 7396) 一致：
+//   - patch 中的键覆盖 base 中的同名键
+//   - 两边都是对象时递归合并
+//   - patch 中值为 null 的键会从结果中删除
+func MergeJSON(base, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(base)+len(patch))
+	for key, value := range base {
+		result[key] = value
+	}
+
+	for key, patchValue := range patch {
+		if patchValue == nil {
+			delete(result, key)
+			continue
+		}
+
+		patchMap, ok := patchValue.(map[string]interface{})
+		if !ok {
+			result[key] = patchValue
+			continue
+		}
+
+		// 原值不是对象时，相当于合并到空对象上（同时会去掉 patch 中的 null）
+		baseMap, _ := result[key].(map[string]interface{})
+		result[key] = MergeJSON(baseMap, patchMap)
+	}
+
+	return result
+}
+
+// RedactedValue 脱敏后的占位值
+const RedactedValue = "******"
+
+// RedactJSON 返回 data 的深拷贝，键名命中 sensitiveKeys 的值被替换为 RedactedValue
+// 键名比较时忽略大小写以及 "_"、"-"，并按后缀匹配，例如 api_key 同时匹配 apiKey 和 openai_api_key
+func RedactJSON(data map[string]interface{}, sensitiveKeys []string) map[string]interface{} {
+	if data == nil {
+		return nil
+	}
+
+	patterns := make([]string, 0, len(sensitiveKeys))
+	for _, key := range sensitiveKeys {
+		if normalized := normalizeJSONKey(key); normalized != "" {
+			patterns = append(patterns, normalized)
+		}
+	}
+	return redactMap(data, patterns)
+}
+
+// DefaultSensitiveKeys 默认需要脱敏的键名，可通过 SetSensitiveKeys 按配置覆盖
+var DefaultSensitiveKeys = []string{"clientSecret", "password", "secret", "authorization", "apiKey"}
+
+// sensitiveKeys 当前生效的敏感键名，Redact 未指定键名时使用
+var sensitiveKeys = DefaultSensitiveKeys
+
+// SetSensitiveKeys 设置 Redact 默认使用的敏感键名，为空时恢复为 DefaultSensitiveKeys
+func SetSensitiveKeys(keys []string) {
+	if len(keys) == 0 {
+		sensitiveKeys = DefaultSensitiveKeys
+		return
+	}
+	sensitiveKeys = append([]string(nil), keys...)
+}
+
+// SensitiveKeys 返回当前生效的敏感键名
+func SensitiveKeys() []string {
+	return append([]string(nil), sensitiveKeys...)
+}
+
+// Redact 深度遍历 map、切片或结构体，返回敏感键已被替换为 RedactedValue 的副本，可安全地写入日志或返回给客户端
+// fields 为空时使用 SetSensitiveKeys 配置的键名；匹配规则与 RedactJSON 相同。
+// 结构体按其 JSON 序列化结果处理（键名为 json 标签名），因此返回值可能是 map 而不是原类型；无法序列化时整体脱敏
+func Redact(v any, fields []string) any {
+	if v == nil {
+		return nil
+	}
+	if len(fields) == 0 {
+		fields = sensitiveKeys
+	}
+
+	patterns := make([]string, 0, len(fields))
+	for _, key := range fields {
+		if normalized := normalizeJSONKey(key); normalized != "" {
+			patterns = append(patterns, normalized)
+		}
+	}
+
+	switch v.(type) {
+	case map[string]interface{}, []interface{}, string, bool, float64, int, int64, uint64:
+		return redactValue(v, patterns)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return RedactedValue
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return RedactedValue
+	}
+	return redactValue(generic, patterns)
+}
+
+func redactMap(data map[string]interface{}, patterns []string) map[string]interface{} {
+	result := make(map[string]interface{}, len(data))
+	for key, value := range data {
+		if isSensitiveJSONKey(key, patterns) && value != nil {
+			result[key] = RedactedValue
+			continue
+		}
+		result[key] = redactValue(value, patterns)
+	}
+	return result
+}
+
+func redactValue(value interface{}, patterns []string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return redactMap(v, patterns)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = redactValue(item, patterns)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+func isSensitiveJSONKey(key string, patterns []string) bool {
+	normalized := normalizeJSONKey(key)
+	for _, pattern := range patterns {
+		if strings.HasSuffix(normalized, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeJSONKey 统一键名格式：小写并去掉 "_"、"-"
+func normalizeJSONKey(key string) string {
+	key = strings.ToLower(key)
+	return strings.NewReplacer("_", "", "-", "").Replace(key)
+}
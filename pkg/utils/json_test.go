@@ -0,0 +1,193 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeJSONNested(t *testing.T) {
+	base := map[string]interface{}{
+		"name": "demo",
+		"llm": map[string]interface{}{
+			"model":       "gpt-4o",
+			"temperature": 0.7,
+		},
+		"tags": []interface{}{"a", "b"},
+	}
+	patch := map[string]interface{}{
+		"llm": map[string]interface{}{
+			"temperature": 0.2,
+			"options": map[string]interface{}{
+				"stream": true,
+			},
+		},
+		"tags": []interface{}{"c"},
+	}
+
+	got := MergeJSON(base, patch)
+	want := map[string]interface{}{
+		"name": "demo",
+		"llm": map[string]interface{}{
+			"model":       "gpt-4o",
+			"temperature": 0.2,
+			"options": map[string]interface{}{
+				"stream": true,
+			},
+		},
+		"tags": []interface{}{"c"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected merge result:\n got: %v\nwant: %v", got, want)
+	}
+
+	// 不修改入参
+	if base["llm"].(map[string]interface{})["temperature"] != 0.7 {
+		t.Fatalf("base was modified: %v", base)
+	}
+}
+
+func TestMergeJSONDeleteKeys(t *testing.T) {
+	base := map[string]interface{}{
+		"keep":   1,
+		"remove": 2,
+		"nested": map[string]interface{}{
+			"a": 1,
+			"b": 2,
+		},
+		"scalar": "x",
+	}
+	patch := map[string]interface{}{
+		"remove":  nil,
+		"missing": nil,
+		"nested": map[string]interface{}{
+			"b": nil,
+		},
+		// 原值不是对象时，patch 对象中的 null 也会被去掉
+		"scalar": map[string]interface{}{
+			"c": 3,
+			"d": nil,
+		},
+	}
+
+	got := MergeJSON(base, patch)
+	want := map[string]interface{}{
+		"keep": 1,
+		"nested": map[string]interface{}{
+			"a": 1,
+		},
+		"scalar": map[string]interface{}{
+			"c": 3,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected merge result:\n got: %v\nwant: %v", got, want)
+	}
+}
+
+func TestMergeJSONNilBase(t *testing.T) {
+	got := MergeJSON(nil, map[string]interface{}{"a": 1, "b": nil})
+	if !reflect.DeepEqual(got, map[string]interface{}{"a": 1}) {
+		t.Fatalf("unexpected merge result: %v", got)
+	}
+}
+
+func TestRedactJSON(t *testing.T) {
+	data := map[string]interface{}{
+		"url":            "https://api.example.com",
+		"openai_api_key": "sk-123",
+		"headers": map[string]interface{}{
+			"Authorization": "Bearer abc",
+			"Accept":        "application/json",
+		},
+		"steps": []interface{}{
+			map[string]interface{}{"password": "p", "name": "step"},
+		},
+		"total_tokens": 42,
+	}
+
+	got := RedactJSON(data, []string{"apiKey", "authorization", "password", "token"})
+	want := map[string]interface{}{
+		"url":            "https://api.example.com",
+		"openai_api_key": RedactedValue,
+		"headers": map[string]interface{}{
+			"Authorization": RedactedValue,
+			"Accept":        "application/json",
+		},
+		"steps": []interface{}{
+			map[string]interface{}{"password": RedactedValue, "name": "step"},
+		},
+		"total_tokens": 42,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected redact result:\n got: %v\nwant: %v", got, want)
+	}
+	if data["openai_api_key"] != "sk-123" {
+		t.Fatalf("input was modified: %v", data)
+	}
+}
+
+func TestRedactNestedStructsAndSlices(t *testing.T) {
+	type node struct {
+		Name      string                 `json:"name"`
+		APIConfig map[string]interface{} `json:"apiConfig"`
+	}
+	type app struct {
+		ClientSecret string `json:"clientSecret"`
+		Nodes        []node `json:"nodes"`
+	}
+
+	input := app{
+		ClientSecret: "cs-1",
+		Nodes: []node{{
+			Name: "call",
+			APIConfig: map[string]interface{}{
+				"url":     "https://api.example.com",
+				"headers": map[string]interface{}{"Authorization": "Bearer abc"},
+				"auth":    []interface{}{map[string]interface{}{"apiKey": "k", "region": "cn"}},
+			},
+		}},
+	}
+
+	got := Redact(input, nil)
+	want := map[string]interface{}{
+		"clientSecret": RedactedValue,
+		"nodes": []interface{}{
+			map[string]interface{}{
+				"name": "call",
+				"apiConfig": map[string]interface{}{
+					"url":     "https://api.example.com",
+					"headers": map[string]interface{}{"Authorization": RedactedValue},
+					"auth":    []interface{}{map[string]interface{}{"apiKey": RedactedValue, "region": "cn"}},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected redact result:\n got: %v\nwant: %v", got, want)
+	}
+	if input.ClientSecret != "cs-1" || input.Nodes[0].APIConfig["headers"].(map[string]interface{})["Authorization"] != "Bearer abc" {
+		t.Fatal("input was modified")
+	}
+}
+
+func TestRedactCustomFieldsAndScalars(t *testing.T) {
+	data := []interface{}{map[string]interface{}{"password": "p", "pin": "1234"}}
+	got := Redact(data, []string{"pin"})
+	want := []interface{}{map[string]interface{}{"password": "p", "pin": RedactedValue}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected redact result: %v", got)
+	}
+
+	if got := Redact("plain", nil); got != "plain" {
+		t.Fatalf("scalars should pass through, got %v", got)
+	}
+	if got := Redact(make(chan int), nil); got != RedactedValue {
+		t.Fatalf("unserializable values should be fully redacted, got %v", got)
+	}
+
+	SetSensitiveKeys([]string{"pin"})
+	defer SetSensitiveKeys(nil)
+	if got := Redact(data, nil); !reflect.DeepEqual(got, want) {
+		t.Fatalf("configured sensitive keys should be used by default, got %v", got)
+	}
+}
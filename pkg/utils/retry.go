@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"context"
+	"time"
+)
+
+// RetryWithBackoff 调用 fn 直到成功、达到 attempts 次或 ctx 取消，返回最后一次的错误。
+// 每次失败后等待 interval，之后等待时间翻倍且不超过 maxInterval（maxInterval 小于等于0时不限制）；
+// 每次等待前调用 onRetry（可为nil），attempt 为刚失败的尝试序号（从1开始）
+func RetryWithBackoff(ctx context.Context, attempts int, interval, maxInterval time.Duration, fn func(ctx context.Context) error, onRetry func(attempt int, wait time.Duration, err error)) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	wait := interval
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(ctx); err == nil {
+			return nil
+		}
+		if attempt >= attempts {
+			return err
+		}
+
+		if maxInterval > 0 && wait > maxInterval {
+			wait = maxInterval
+		}
+		if onRetry != nil {
+			onRetry(attempt, wait, err)
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		wait *= 2
+	}
+}
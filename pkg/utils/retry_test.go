@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryWithBackoff(t *testing.T) {
+	calls := 0
+	var waits []time.Duration
+	err := RetryWithBackoff(context.Background(), 5, time.Millisecond, 3*time.Millisecond, func(context.Context) error {
+		calls++
+		if calls < 4 {
+			return errors.New("not ready")
+		}
+		return nil
+	}, func(attempt int, wait time.Duration, err error) {
+		waits = append(waits, wait)
+	})
+	if err != nil || calls != 4 {
+		t.Fatalf("expected success on the 4th attempt, got %v after %d calls", err, calls)
+	}
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond}
+	if len(waits) != len(want) {
+		t.Fatalf("expected waits %v, got %v", want, waits)
+	}
+	for i := range want {
+		if waits[i] != want[i] {
+			t.Fatalf("expected waits %v, got %v", want, waits)
+		}
+	}
+
+	calls = 0
+	final := errors.New("connection refused")
+	err = RetryWithBackoff(context.Background(), 3, time.Millisecond, 0, func(context.Context) error {
+		calls++
+		return final
+	}, nil)
+	if !errors.Is(err, final) || calls != 3 {
+		t.Fatalf("expected the last error after 3 attempts, got %v after %d calls", err, calls)
+	}
+
+	// ctx 取消后不再等待
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	start := time.Now()
+	err = RetryWithBackoff(ctx, 3, time.Hour, 0, func(context.Context) error {
+		calls++
+		return final
+	}, nil)
+	if !errors.Is(err, final) || calls != 1 || time.Since(start) > time.Second {
+		t.Fatalf("expected to stop after cancellation, got %v after %d calls", err, calls)
+	}
+}
@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// ID 的位布局（共64位，最高位固定为0，保证转为 int64 时仍为正数）：
+//
+//	| 1 位保留 | 41 位时间戳（距纪元的毫秒数） | 10 位节点ID | 12 位序列号 |
+//
+// 41 位毫秒时间戳可使用约 69 年；每个节点每毫秒最多生成 4096 个ID。
+// 不同实例必须配置不同的节点ID，否则同一毫秒内生成的ID可能重复
+const (
+	IDTimestampBits = 41
+	IDNodeBits      = 10
+	IDSequenceBits  = 12
+
+	MaxIDNodeID   = 1<<IDNodeBits - 1
+	maxIDSequence = 1<<IDSequenceBits - 1
+
+	idNodeShift      = IDSequenceBits
+	idTimestampShift = IDSequenceBits + IDNodeBits
+)
+
+// DefaultIDEpoch 默认纪元。早期数据使用 sonyflake 生成ID，以该纪元生成的ID均大于已有ID，按ID排序仍与创建顺序一致
+var DefaultIDEpoch = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// IDComponents ID的组成部分，用于排查问题
+type IDComponents struct {
+	Timestamp time.Time `json:"timestamp"` // 生成时间（毫秒精度）
+	NodeID    uint64    `json:"nodeId"`    // 生成该ID的节点
+	Sequence  uint64    `json:"sequence"`  // 同一毫秒内的序列号
+}
+
+// IDGenerator 雪花算法ID生成器，并发安全
+type IDGenerator struct {
+	mu       sync.Mutex
+	epoch    int64 // 纪元（Unix毫秒）
+	nodeID   uint64
+	lastTime int64 // 上一个ID使用的时间戳（距纪元的毫秒数）
+	sequence uint64
+	now      func() time.Time
+}
+
+// NewIDGenerator 使用默认纪元创建ID生成器，nodeID 取值范围为 0-1023
+func NewIDGenerator(nodeID int64) (*IDGenerator, error) {
+	return NewIDGeneratorWithEpoch(nodeID, DefaultIDEpoch)
+}
+
+// NewIDGeneratorWithEpoch 使用指定纪元创建ID生成器，纪元不能晚于当前时间
+func NewIDGeneratorWithEpoch(nodeID int64, epoch time.Time) (*IDGenerator, error) {
+	if nodeID < 0 || nodeID > MaxIDNodeID {
+		return nil, fmt.Errorf("node id must be between 0 and %d, got %d", MaxIDNodeID, nodeID)
+	}
+	if epoch.After(time.Now()) {
+		return nil, fmt.Errorf("id epoch %s is in the future", epoch.Format(time.RFC3339))
+	}
+	return &IDGenerator{
+		epoch:  epoch.UnixMilli(),
+		nodeID: uint64(nodeID),
+		now:    time.Now,
+	}, nil
+}
+
+// NodeID 返回生成器的节点ID
+func (g *IDGenerator) NodeID() uint64 {
+	return g.nodeID
+}
+
+// GenerateID 生成下一个ID，同一生成器生成的ID严格递增。
+// 时钟回拨或同一毫秒内序列号用尽时沿用并推进上一个时间戳，不阻塞等待，时钟追上后恢复使用当前时间
+func (g *IDGenerator) GenerateID() uint64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now().UnixMilli() - g.epoch
+	if now > g.lastTime {
+		g.lastTime = now
+		g.sequence = 0
+	} else {
+		g.sequence = (g.sequence + 1) & maxIDSequence
+		if g.sequence == 0 {
+			g.lastTime++
+		}
+	}
+
+	return uint64(g.lastTime)<<idTimestampShift | g.nodeID<<idNodeShift | g.sequence
+}
+
+// Decompose 按生成器的纪元拆解ID
+func (g *IDGenerator) Decompose(id uint64) IDComponents {
+	return decomposeID(id, g.epoch)
+}
+
+// decomposeID 拆解ID的时间戳、节点和序列号
+func decomposeID(id uint64, epoch int64) IDComponents {
+	return IDComponents{
+		Timestamp: time.UnixMilli(int64(id>>idTimestampShift) + epoch),
+		NodeID:    (id >> idNodeShift) & MaxIDNodeID,
+		Sequence:  id & maxIDSequence,
+	}
+}
+
+var (
+	idGeneratorMu      sync.RWMutex
+	defaultIDGenerator *IDGenerator
+)
+
+// InitIDGenerator 初始化全局ID生成器。nodeID 小于0时根据本机内网IPv4地址的低10位推导，
+// 无法获取时使用0；多实例部署时应显式配置互不相同的节点ID
+func InitIDGenerator(nodeID int64, epoch time.Time) (*IDGenerator, error) {
+	if nodeID < 0 {
+		nodeID = defaultIDNodeID()
+	}
+	generator, err := NewIDGeneratorWithEpoch(nodeID, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	idGeneratorMu.Lock()
+	defaultIDGenerator = generator
+	idGeneratorMu.Unlock()
+	return generator, nil
+}
+
+// getIDGenerator 获取全局ID生成器，未初始化时使用推导的节点ID和默认纪元
+func getIDGenerator() *IDGenerator {
+	idGeneratorMu.RLock()
+	generator := defaultIDGenerator
+	idGeneratorMu.RUnlock()
+	if generator != nil {
+		return generator
+	}
+
+	idGeneratorMu.Lock()
+	defer idGeneratorMu.Unlock()
+	if defaultIDGenerator == nil {
+		defaultIDGenerator, _ = NewIDGeneratorWithEpoch(defaultIDNodeID(), DefaultIDEpoch)
+	}
+	return defaultIDGenerator
+}
+
+// GenerateID 使用全局ID生成器生成ID
+func GenerateID() uint64 {
+	return getIDGenerator().GenerateID()
+}
+
+// DecomposeID 按全局ID生成器的纪元拆解ID
+func DecomposeID(id uint64) IDComponents {
+	return getIDGenerator().Decompose(id)
+}
+
+// defaultIDNodeID 使用本机第一个内网IPv4地址的低10位作为节点ID，获取失败时返回0
+func defaultIDNodeID() int64 {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return 0
+	}
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		ip := ipNet.IP.To4()
+		if ip == nil || !ip.IsPrivate() {
+			continue
+		}
+		return int64(uint16(ip[2])<<8|uint16(ip[3])) & MaxIDNodeID
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+package utils
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIDGeneratorMonotonicWithinWorker(t *testing.T) {
+	generator, err := NewIDGenerator(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	previous := generator.GenerateID()
+	for i := 0; i < 20000; i++ {
+		id := generator.GenerateID()
+		if id <= previous {
+			t.Fatalf("id %d is not greater than previous %d", id, previous)
+		}
+		previous = id
+	}
+}
+
+func TestIDGeneratorClockMovesBackwards(t *testing.T) {
+	generator, err := NewIDGenerator(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	current := time.Now()
+	generator.now = func() time.Time { return current }
+
+	first := generator.GenerateID()
+	current = current.Add(-time.Second)
+	second := generator.GenerateID()
+	if second <= first {
+		t.Fatalf("id after clock rollback %d should be greater than %d", second, first)
+	}
+
+	// 同一毫秒内序列号用尽后推进时间戳而不是重复
+	seen := map[uint64]bool{first: true, second: true}
+	previous := second
+	for i := 0; i < maxIDSequence+10; i++ {
+		id := generator.GenerateID()
+		if id <= previous || seen[id] {
+			t.Fatalf("id %d is not unique and increasing (previous %d)", id, previous)
+		}
+		seen[id] = true
+		previous = id
+	}
+}
+
+func TestIDGeneratorUniqueAcrossGoroutines(t *testing.T) {
+	const goroutines, perGoroutine = 8, 5000
+	generators := make([]*IDGenerator, 2)
+	for i := range generators {
+		generator, err := NewIDGenerator(int64(i))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		generators[i] = generator
+	}
+
+	var mu sync.Mutex
+	seen := make(map[uint64]bool, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(generator *IDGenerator) {
+			defer wg.Done()
+			ids := make([]uint64, perGoroutine)
+			for i := range ids {
+				ids[i] = generator.GenerateID()
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, id := range ids {
+				if seen[id] {
+					t.Errorf("duplicate id %d", id)
+				}
+				seen[id] = true
+			}
+		}(generators[g%len(generators)])
+	}
+	wg.Wait()
+
+	if len(seen) != goroutines*perGoroutine {
+		t.Fatalf("expected %d unique ids, got %d", goroutines*perGoroutine, len(seen))
+	}
+}
+
+func TestIDGeneratorDecompose(t *testing.T) {
+	epoch := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	generator, err := NewIDGeneratorWithEpoch(MaxIDNodeID, epoch)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	at := time.Date(2025, 6, 1, 12, 0, 0, 123*int(time.Millisecond), time.UTC)
+	generator.now = func() time.Time { return at }
+
+	generator.GenerateID()
+	id := generator.GenerateID()
+	components := generator.Decompose(id)
+	if !components.Timestamp.Equal(at) || components.NodeID != MaxIDNodeID || components.Sequence != 1 {
+		t.Fatalf("unexpected components: %+v", components)
+	}
+	if id>>63 != 0 {
+		t.Fatalf("highest bit should be reserved: %d", id)
+	}
+}
+
+func TestNewIDGeneratorValidation(t *testing.T) {
+	for _, nodeID := range []int64{-1, MaxIDNodeID + 1} {
+		if _, err := NewIDGenerator(nodeID); err == nil {
+			t.Errorf("node id %d should be rejected", nodeID)
+		}
+	}
+	if _, err := NewIDGeneratorWithEpoch(0, time.Now().Add(time.Hour)); err == nil {
+		t.Error("future epoch should be rejected")
+	}
+}
@@ -3,9 +3,11 @@ package client
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math"
+	"net"
 	"net/url"
 	"sync"
 	"time"
@@ -157,6 +159,7 @@ func (c *SocketClient) Connect(token ...string) (<-chan struct{}, error) {
 		return nil, fmt.Errorf("failed to connect to WebSocket: %w", err)
 	}
 
+	c.applyReadLimits(conn)
 	c.conn = conn
 	// 注意：这里不立即设置为Connected，而是等待服务器的confirmed消息
 	// c.setState(Connected) // 移除这行
@@ -593,8 +596,11 @@ func (c *SocketClient) handleMessages() {
 
 			_, messageData, err := c.conn.ReadMessage()
 			if err != nil {
+				var netErr net.Error
 				if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 					c.log("WebSocket closed normally")
+				} else if errors.As(err, &netErr) && netErr.Timeout() {
+					c.log(fmt.Sprintf("WebSocket read timeout after %v, treating connection as dead", c.options.ReadTimeout))
 				} else {
 					c.log(fmt.Sprintf("WebSocket read error: %v", err))
 				}
@@ -603,11 +609,33 @@ func (c *SocketClient) handleMessages() {
 				return
 			}
 
+			c.extendReadDeadline(c.conn)
 			c.handleMessage(messageData)
 		}
 	}
 }
 
+// 设置单条消息大小上限和读取超时，收到pong时同样延长读取期限
+func (c *SocketClient) applyReadLimits(conn *websocket.Conn) {
+	if c.options.ReadLimit > 0 {
+		conn.SetReadLimit(c.options.ReadLimit)
+	}
+	if c.options.ReadTimeout > 0 {
+		c.extendReadDeadline(conn)
+		conn.SetPongHandler(func(string) error {
+			c.extendReadDeadline(conn)
+			return nil
+		})
+	}
+}
+
+// 将读取期限顺延一个读取超时周期
+func (c *SocketClient) extendReadDeadline(conn *websocket.Conn) {
+	if c.options.ReadTimeout > 0 && conn != nil {
+		conn.SetReadDeadline(time.Now().Add(c.options.ReadTimeout))
+	}
+}
+
 // 处理单个消息
 func (c *SocketClient) handleMessage(data []byte) {
 	// 首先解析为通用的map来检查消息类型
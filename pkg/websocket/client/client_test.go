@@ -0,0 +1,104 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// newSilentServer 启动一个接受连接后不发送任何消息的服务器
+func newSilentServer(t *testing.T) *httptest.Server {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestReadTimeoutTriggersReconnect(t *testing.T) {
+	server := newSilentServer(t)
+
+	client := NewSocketClient(SocketOptions{
+		URL:         "ws" + strings.TrimPrefix(server.URL, "http"),
+		Token:       "test",
+		ReadTimeout: 100 * time.Millisecond,
+	})
+	reconnecting := make(chan struct{}, 1)
+	client.OnStateChange(func(state WebSocketState) {
+		if state == Reconnecting {
+			select {
+			case reconnecting <- struct{}{}:
+			default:
+			}
+		}
+	})
+
+	if _, err := client.Connect(); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Disconnect()
+
+	select {
+	case <-reconnecting:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected read timeout to schedule a reconnect")
+	}
+}
+
+func TestReadLimitAppliedToConnection(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteMessage(websocket.TextMessage, []byte(`{"topic":"big","data":"`+strings.Repeat("x", 1024)+`"}`))
+		time.Sleep(time.Second)
+	}))
+	defer server.Close()
+
+	client := NewSocketClient(SocketOptions{
+		URL:       "ws" + strings.TrimPrefix(server.URL, "http"),
+		Token:     "test",
+		ReadLimit: 128,
+	})
+	received := make(chan struct{}, 1)
+	client.Subscribe("big", func(data interface{}, topic string) { received <- struct{}{} })
+	disconnected := make(chan struct{}, 1)
+	client.OnStateChange(func(state WebSocketState) {
+		if state == Reconnecting {
+			select {
+			case disconnected <- struct{}{}:
+			default:
+			}
+		}
+	})
+
+	if _, err := client.Connect(); err != nil {
+		t.Fatalf("connect failed: %v", err)
+	}
+	defer client.Disconnect()
+
+	select {
+	case <-received:
+		t.Fatal("oversized message should not be delivered")
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected oversized message to drop the connection")
+	}
+}
@@ -75,6 +75,8 @@ type SocketOptions struct {
 	Debug             bool                 // 是否开启调试日志
 	RefreshToken      RefreshTokenFunction // token刷新函数
 	ErrorHandler      ErrorHandler         // 错误处理函数
+	ReadLimit         int64                // 单条消息的最大字节数，超过时断开并重连，0表示不限制
+	ReadTimeout       time.Duration        // 读取超时，超过该时间未收到任何消息或pong视为连接失效并重连，0表示不超时；应大于心跳间隔
 }
 
 // SubscriptionRecord 内部订阅记录
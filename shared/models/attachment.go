@@ -78,22 +78,25 @@ type AttachmentsListResponse struct {
 
 // PrepareUploadRequest 准备上传请求结构
 type PrepareUploadRequest struct {
-	Filename    string `json:"filename" binding:"required"`
-	ContentType string `json:"contentType" binding:"required"`
-	Size        int64  `json:"size" binding:"required,min=1"`
-	Bucket      string `json:"bucket,omitempty"`
-	Tag1        string `json:"tag1,omitempty"`
-	Tag2        string `json:"tag2,omitempty"`
-	Tag3        string `json:"tag3,omitempty"`
+	Filename           string            `json:"filename" binding:"required"`
+	ContentType        string            `json:"contentType" binding:"required"`
+	Size               int64             `json:"size" binding:"required,min=1"`
+	Bucket             string            `json:"bucket,omitempty"`
+	Tag1               string            `json:"tag1,omitempty"`
+	Tag2               string            `json:"tag2,omitempty"`
+	Tag3               string            `json:"tag3,omitempty"`
+	ContentDisposition string            `json:"contentDisposition,omitempty"` // 下载时的Content-Disposition
+	Metadata           map[string]string `json:"metadata,omitempty"`           // 对象的用户自定义元数据
 }
 
 // PrepareUploadResponse 准备上传响应结构
 type PrepareUploadResponse struct {
-	UploadURL       string         `json:"uploadUrl"`
-	UploadSessionID string         `json:"uploadSessionId"`
-	Fields          map[string]any `json:"fields,omitempty"` // 用于表单上传的额外字段
-	ExpiresAt       int64          `json:"expiresAt"`
-	AttachmentID    string         `json:"attachmentId"`
+	UploadURL       string            `json:"uploadUrl"`
+	UploadSessionID string            `json:"uploadSessionId"`
+	Fields          map[string]any    `json:"fields,omitempty"`  // 用于表单上传的额外字段
+	Headers         map[string]string `json:"headers,omitempty"` // 上传时必须携带的请求头（加密、元数据等）
+	ExpiresAt       int64             `json:"expiresAt"`
+	AttachmentID    string            `json:"attachmentId"`
 }
 
 // ConfirmUploadRequest 确认上传请求结构
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // SendVerifyCodeRequest 发送验证码请求
 type SendVerifyCodeRequest struct {
 	SenderType string `json:"senderType" binding:"required,oneof=email phone sms"` // 发送方式
@@ -36,6 +38,15 @@ type LoginRequest struct {
 	RememberMe     *bool  `json:"rememberMe"` // 记住我
 }
 
+// FlexibleLoginRequest 灵活登录请求，由服务端判断标识符是邮箱、手机号还是用户名
+type FlexibleLoginRequest struct {
+	Identifier string `json:"identifier" binding:"required"` // 邮箱、手机号或用户名
+	Secret     string `json:"secret,omitempty"`              // 密码（与验证码二选一）
+	VerifyCode string `json:"verifyCode,omitempty"`          // 验证码（仅邮箱、手机号可用）
+	ClientCode string `json:"clientCode,omitempty" binding:"required"`
+	RememberMe *bool  `json:"rememberMe"` // 记住我
+}
+
 // LoginResponse 登录响应
 type LoginResponse struct {
 	User    UserInfo  `json:"user"`
@@ -75,6 +86,120 @@ type ResetPasswordResponse struct {
 	Message string `json:"message"`
 }
 
+// SetInitialPasswordRequest 首次设置密码请求
+type SetInitialPasswordRequest struct {
+	NewPassword string `json:"newPassword" binding:"required"` // 新密码
+	VerifyCode  string `json:"verifyCode" binding:"required"`  // 以 set_password 用途发送到已验证邮箱/手机号的验证码
+}
+
+// ChangePasswordRequest 登录状态下修改密码请求
+type ChangePasswordRequest struct {
+	OldPassword string `json:"oldPassword" binding:"required"` // 原密码
+	NewPassword string `json:"newPassword" binding:"required"` // 新密码
+	// RefreshToken 当前会话的refreshToken，撤销其他会话时用于为当前会话换发新令牌；不提供时当前会话同样被撤销，需要重新登录
+	RefreshToken string `json:"refreshToken,omitempty"`
+}
+
+// ChangePasswordResponse 修改密码响应
+type ChangePasswordResponse struct {
+	// Token 当前会话换发的新令牌，未撤销会话或未提供refreshToken时为空
+	Token   *TokenInfo `json:"token,omitempty"`
+	Message string     `json:"message"`
+}
+
+// ============ WebAuthn（通行密钥） ============
+// 以下结构与浏览器 navigator.credentials 的返回值（PublicKeyCredential.toJSON）对应，二进制字段均为 base64url 编码；
+// Begin 接口返回的参数直接使用 go-webauthn 的 PublicKeyCredentialCreationOptions 和 PublicKeyCredentialRequestOptions
+
+// WebAuthnAttestationResponse 认证器创建凭据的响应
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" binding:"required"`
+	AttestationObject string   `json:"attestationObject" binding:"required"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// WebAuthnRegistrationRequest 完成通行密钥注册请求
+type WebAuthnRegistrationRequest struct {
+	ID       string                      `json:"id" binding:"required"` // 凭据ID
+	RawID    string                      `json:"rawId,omitempty"`
+	Type     string                      `json:"type" binding:"required"` // 固定为 public-key
+	Response WebAuthnAttestationResponse `json:"response" binding:"required"`
+	Name     string                      `json:"name,omitempty"` // 通行密钥名称，便于用户区分多个设备
+}
+
+// WebAuthnLoginBeginRequest 开始通行密钥登录请求
+type WebAuthnLoginBeginRequest struct {
+	// Identifier 邮箱、手机号或用户名，提供时只允许该用户的通行密钥；为空时使用可发现凭据登录
+	Identifier string `json:"identifier,omitempty"`
+}
+
+// WebAuthnAssertionResponse 认证器的签名响应
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON" binding:"required"`
+	AuthenticatorData string `json:"authenticatorData" binding:"required"`
+	Signature         string `json:"signature" binding:"required"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnLoginRequest 完成通行密钥登录请求
+type WebAuthnLoginRequest struct {
+	ID         string                    `json:"id" binding:"required"` // 凭据ID
+	RawID      string                    `json:"rawId,omitempty"`
+	Type       string                    `json:"type" binding:"required"` // 固定为 public-key
+	Response   WebAuthnAssertionResponse `json:"response" binding:"required"`
+	ClientCode string                    `json:"clientCode" binding:"required"`
+	RememberMe *bool                     `json:"rememberMe"` // 记住我
+}
+
+// MagicLinkRequest 发送魔法链接请求
+type MagicLinkRequest struct {
+	Email      string `json:"email" binding:"required"`      // 已绑定的邮箱
+	ClientCode string `json:"clientCode" binding:"required"` // 终端编码，链接只能在该终端上使用
+}
+
+// MagicLinkLoginRequest 魔法链接登录请求
+type MagicLinkLoginRequest struct {
+	Token      string `json:"token" binding:"required"` // 链接中的一次性令牌
+	ClientCode string `json:"clientCode" binding:"required"`
+	RememberMe *bool  `json:"rememberMe"` // 记住我
+}
+
+// WebAuthnCredentialResponse 已注册的通行密钥
+type WebAuthnCredentialResponse struct {
+	ID           string `json:"id"`
+	CredentialID string `json:"credentialId"`
+	Name         string `json:"name,omitempty"`
+	CreateTime   string `json:"createTime"`
+}
+
+// CredentialResponse 用户的认证方式，不包含密钥
+type CredentialResponse struct {
+	ID             string  `json:"id"`
+	CredentialType string  `json:"credentialType"`
+	Identifier     string  `json:"identifier"` // 邮箱和手机号已脱敏
+	Provider       string  `json:"provider,omitempty"`
+	Name           string  `json:"name,omitempty"` // 通行密钥名称
+	IsVerified     bool    `json:"isVerified"`
+	VerifiedAt     *string `json:"verifiedAt,omitempty"`
+	LastUsedAt     *string `json:"lastUsedAt,omitempty"`
+	ExpiresAt      *string `json:"expiresAt,omitempty"`
+	LoginMethod    bool    `json:"loginMethod"` // 是否可以单独用于登录（TOTP 只能作为第二因素）
+	CreateTime     string  `json:"createTime"`
+}
+
+// RequestContactChangeRequest 申请更换邮箱/手机号请求
+type RequestContactChangeRequest struct {
+	CredentialType string `json:"credentialType" binding:"required,oneof=email phone"` // 认证类型
+	NewIdentifier  string `json:"newIdentifier" binding:"required"`                    // 新的邮箱/手机号
+}
+
+// ConfirmContactChangeRequest 确认更换邮箱/手机号请求
+type ConfirmContactChangeRequest struct {
+	CredentialType string `json:"credentialType" binding:"required,oneof=email phone"` // 认证类型
+	NewIdentifier  string `json:"newIdentifier" binding:"required"`                    // 新的邮箱/手机号
+	VerifyCode     string `json:"verifyCode" binding:"required"`                       // 发送到新标识符的验证码
+}
+
 type TokenInfo struct {
 	AccessToken      string `json:"accessToken"`
 	RefreshToken     string `json:"refreshToken"`
@@ -82,6 +207,15 @@ type TokenInfo struct {
 	RefreshExpiredIn uint64 `json:"refreshExpiredIn"`
 }
 
+// TokenIntrospectionResponse 访问令牌自省结果，令牌无效或已过期时仅返回 active=false
+type TokenIntrospectionResponse struct {
+	Active         bool       `json:"active"`
+	UserID         string     `json:"userId,omitempty"`
+	ClientDeviceID string     `json:"clientDeviceId,omitempty"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	RememberMe     *bool      `json:"rememberMe,omitempty"`
+}
+
 // RefreshTokenRequest 刷新Token请求结构体
 type RefreshTokenRequest struct {
 	RefreshToken string `json:"refreshToken" binding:"required"`
@@ -118,4 +252,25 @@ type LoginRecordResponse struct {
 	LogoutTime     string                 `json:"logoutTime,omitempty"`
 	Duration       int                    `json:"duration,omitempty"` // 会话持续时间(秒)
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
+	ClientID       uint64                 `json:"clientId,omitempty"` // 登录设备ID
+}
+
+// GetLoginRecordsRequest 登录记录审计查询请求
+type GetLoginRecordsRequest struct {
+	PaginationRequest
+	UserID         string `form:"userId" json:"userId"`                 // 用户ID
+	Identifier     string `form:"identifier" json:"identifier"`         // 登录标识符，模糊匹配
+	Status         string `form:"status" json:"status"`                 // 登录状态：success / failed / locked
+	CredentialType string `form:"credentialType" json:"credentialType"` // 登录方式
+	ClientID       string `form:"clientId" json:"clientId"`             // 登录设备ID
+	Device         string `form:"device" json:"device"`                 // 设备信息，模糊匹配
+	IPAddress      string `form:"ip" json:"ip"`                         // 登录IP
+	BeginTime      string `form:"beginTime" json:"beginTime"`           // 开始时间（RFC3339）
+	EndTime        string `form:"endTime" json:"endTime"`               // 结束时间（RFC3339）
+}
+
+// LoginRecordsListResponse 登录记录分页响应
+type LoginRecordsListResponse struct {
+	Data       []*LoginRecordResponse `json:"data"`
+	Pagination Pagination             `json:"pagination"`
 }
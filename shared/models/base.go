@@ -16,6 +16,10 @@ type HealthResponse struct {
 	Status     string            `json:"status"`
 	Message    string            `json:"message"`
 	Components map[string]string `json:"components,omitempty"`
+	// WorkflowQueue 工作流执行队列状态
+	WorkflowQueue *WorkflowExecutionQueueStats `json:"workflowQueue,omitempty"`
+	// Messaging 消息层统计：各频道的发布/消费/失败计数、订阅协程数和 Streams 消费积压（messaging.StatsSnapshot）
+	Messaging any `json:"messaging,omitempty"`
 }
 
 // PaginationRequest 分页请求结构
@@ -19,7 +19,9 @@ type ClientDeviceResponse struct {
 	AccessTokenExpiry  uint64     `json:"accessTokenExpiry"`
 	RefreshTokenExpiry uint64     `json:"refreshTokenExpiry"`
 	Anonymous          bool       `json:"anonymous"`
-	Roles              []RoleInfo `json:"roles,omitempty"` // 关联的角色列表
+	Audience           string     `json:"audience,omitempty"` // 令牌受众
+	Scopes             []string   `json:"scopes,omitempty"`   // 令牌授权范围，为空表示不限制
+	Roles              []RoleInfo `json:"roles,omitempty"`    // 关联的角色列表
 }
 
 // CreateClientDeviceRequest 创建客户端设备请求
@@ -30,6 +32,8 @@ type CreateClientDeviceRequest struct {
 	AccessTokenExpiry  uint64   `json:"accessTokenExpiry" binding:"required"`  // accessToken超时时间(ms)
 	RefreshTokenExpiry uint64   `json:"refreshTokenExpiry" binding:"required"` // refreshToken超时时间(ms)
 	Anonymous          *bool    `json:"anonymous"`                             // 允许所有角色登录，可选，默认true
+	Audience           string   `json:"audience" binding:"max=255"`            // 令牌受众，可选
+	Scopes             []string `json:"scopes,omitempty"`                      // 令牌授权范围，可选，为空表示不限制
 	RoleIds            []string `json:"roleIds,omitempty"`                     // 关联的角色ID列表
 }
 
@@ -41,6 +45,8 @@ type UpdateClientDeviceRequest struct {
 	AccessTokenExpiry  *uint64  `json:"accessTokenExpiry" binding:"required"`  // accessToken超时时间(ms)
 	RefreshTokenExpiry *uint64  `json:"refreshTokenExpiry" binding:"required"` // refreshToken超时时间(ms)
 	Anonymous          *bool    `json:"anonymous" binding:"required"`          // 允许所有角色登录
+	Audience           *string  `json:"audience" binding:"omitempty,max=255"`  // 令牌受众，不传时保持不变
+	Scopes             []string `json:"scopes"`                                // 令牌授权范围，不传时保持不变，传空数组表示不限制
 	RoleIds            []string `json:"roleIds,omitempty"`                     // 关联的角色ID列表
 }
 
@@ -0,0 +1,21 @@
+package models
+
+// DatabaseBackupResponse 数据库备份清单响应结构
+type DatabaseBackupResponse struct {
+	ID             string         `json:"id"`
+	Bucket         string         `json:"bucket"`
+	Key            string         `json:"key"`
+	Size           int64          `json:"size"`                     // 归档大小(字节)
+	TotalRecords   int            `json:"totalRecords"`             // 导出的记录总数
+	EntityCounts   map[string]int `json:"entityCounts"`             // 各实体导出的记录数
+	FailedEntities []string       `json:"failedEntities,omitempty"` // 导出失败的实体
+	BackupTime     string         `json:"backupTime"`
+	CreateBy       string         `json:"createBy,omitempty"`
+	DownloadURL    string         `json:"downloadUrl,omitempty"` // 预签名下载链接，仅在触发备份时返回
+}
+
+// DatabaseBackupListRequest 备份清单查询请求
+type DatabaseBackupListRequest struct {
+	Page     int `form:"page" json:"page" binding:"omitempty,min=1"`                 // 页码，默认1
+	PageSize int `form:"pageSize" json:"pageSize" binding:"omitempty,min=1,max=100"` // 每页数量，默认20
+}
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 // CreateRoleRequest 创建角色请求结构
 type CreateRoleRequest struct {
 	Name         string   `json:"name" binding:"required"`
@@ -39,15 +41,41 @@ type RolesListResponse struct {
 	Pagination Pagination      `json:"pagination"`
 }
 
+// RoleDeleteBlocker 阻止删除角色的关联对象
+type RoleDeleteBlocker struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// RoleDeleteBlockers 阻止删除角色的依赖信息，列表只返回部分样例，数量以 Count 字段为准
+type RoleDeleteBlockers struct {
+	UserCount      int                 `json:"userCount"`      // 仍分配了该角色的用户数
+	Users          []RoleDeleteBlocker `json:"users"`          // 部分用户
+	ChildRoleCount int                 `json:"childRoleCount"` // 继承该角色的子角色数
+	ChildRoles     []RoleDeleteBlocker `json:"childRoles"`     // 部分子角色
+}
+
+// HasBlockers 是否存在阻止删除的依赖
+func (b *RoleDeleteBlockers) HasBlockers() bool {
+	return b.UserCount > 0 || b.ChildRoleCount > 0
+}
+
 // AssignRolePermissionsRequest 分配角色权限请求结构
 type AssignRolePermissionsRequest struct {
 	PermissionIds []string `json:"permissionIds" binding:"required"` // 权限ID列表
 }
 
+// SetRolePermissionsRequest 设置角色权限请求结构（整体替换角色的直接权限）
+type SetRolePermissionsRequest struct {
+	PermissionIds []string `json:"permissionIds"` // 完整的权限ID列表，空列表表示清空角色的直接权限
+}
+
 // AssignUserRoleRequest 分配用户角色请求结构
 type AssignUserRoleRequest struct {
 	UserID string `json:"userId" binding:"required"`
 	RoleID string `json:"roleId" binding:"required"`
+	// ExpiresAt 过期时间，为空表示永久有效；过期后不再授予该角色的权限
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // CreatePermissionRequest 创建权限请求结构
@@ -106,6 +134,8 @@ type UserRoleResponse struct {
 	RoleID     string        `json:"roleId"`
 	User       *UserResponse `json:"user,omitempty"`
 	Role       *RoleResponse `json:"role,omitempty"`
+	ExpiresAt  string        `json:"expiresAt,omitempty"` // 过期时间，为空表示永久有效
+	Expired    bool          `json:"expired,omitempty"`   // 是否已过期
 	CreateTime string        `json:"createTime"`
 	UpdateTime string        `json:"updateTime"`
 }
@@ -219,6 +249,8 @@ type RoleUsersListResponse struct {
 // BatchAssignUsersToRoleRequest 批量分配用户到角色请求结构
 type BatchAssignUsersToRoleRequest struct {
 	UserIds []string `json:"userIds" binding:"required"` // 用户ID列表
+	// ExpiresAt 过期时间，为空表示永久有效；已分配的用户按本次请求更新过期时间
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // BatchRemoveUsersFromRoleRequest 批量从角色移除用户请求结构
@@ -19,6 +19,12 @@ type UpdateUserRequest struct {
 	AvatarId string `json:"avatarId,omitempty"` // 头像ID
 }
 
+// SetUserStatusRequest 修改用户账号状态请求结构
+type SetUserStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=active inactive banned"` // 目标状态：active 启用，inactive 停用，banned 封禁
+	Reason string `json:"reason" binding:"max=500"`                               // 修改原因，记录到审计日志
+}
+
 // UserResponse 用户响应结构
 type UserResponse struct {
 	ID         string   `json:"id"`
@@ -43,6 +49,8 @@ type UserFromRoleResponse struct {
 	Avatar     string         `json:"avatar,omitempty"`     // 头像
 	Roles      []string       `json:"roles,omitempty"`      // 角色列表
 	OtherRoles []RoleResponse `json:"otherRoles,omitempty"` // 角色列表
+	ExpiresAt  string         `json:"expiresAt,omitempty"`  // 该角色分配的过期时间，为空表示永久有效
+	Expired    bool           `json:"expired,omitempty"`    // 该角色分配是否已过期
 }
 
 // GetUsersRequest 获取用户列表请求结构
@@ -20,7 +20,13 @@ type WorkflowApplicationResponse struct {
 	Status         string                  `json:"status"`                   // draft, published, archived
 	GraphData      string                  `json:"graphData,omitempty"`      // 新架构：完整的工作流图JSON
 	ViewportConfig map[string]interface{}  `json:"viewportConfig,omitempty"` // 画布视口配置
+	InputSchema    map[string]interface{}  `json:"inputSchema,omitempty"`    // 执行输入的JSON Schema
+	IsTemplate     bool                    `json:"isTemplate"`               // 是否为模板，模板需要实例化后才能执行
+	OwnerID        string                  `json:"ownerId,omitempty"`        // 所有者用户ID，为空表示未归属任何用户
 	Nodes          []*WorkflowNodeResponse `json:"nodes,omitempty"`          // 旧架构，保留兼容
+
+	// Diagnostics 节点ID -> 校验问题，仅在请求 validate=true 时返回
+	Diagnostics map[string][]WorkflowValidationIssue `json:"diagnostics,omitempty"`
 }
 
 // CreateWorkflowApplicationRequest 创建工作流应用请求结构
@@ -29,7 +35,12 @@ type CreateWorkflowApplicationRequest struct {
 	Description string                 `json:"description,omitempty"`
 	StartNodeID string                 `json:"startNodeId,omitempty"` // 可选，如果不提供则自动创建默认开始节点
 	Variables   map[string]interface{} `json:"variables,omitempty"`
-	Status      string                 `json:"status,omitempty"` // draft, published, archived
+	Status      string                 `json:"status,omitempty"`      // draft, published, archived
+	InputSchema map[string]interface{} `json:"inputSchema,omitempty"` // 执行输入的JSON Schema，支持 required、type、enum
+	IsTemplate  bool                   `json:"isTemplate,omitempty"`  // 是否作为模板创建
+	// CreateDefaultStartNode 未提供 startNodeId 时是否自动创建默认开始节点，默认 true；
+	// 导入或以编程方式构建图时可设为 false，之后通过更新或批量保存设置开始节点
+	CreateDefaultStartNode *bool `json:"createDefaultStartNode,omitempty"`
 }
 
 // UpdateWorkflowApplicationRequest 更新工作流应用请求结构
@@ -42,6 +53,8 @@ type UpdateWorkflowApplicationRequest struct {
 	Status         string                 `json:"status,omitempty"`         // draft, published, archived
 	GraphData      string                 `json:"graphData,omitempty"`      // 新架构：完整的工作流图JSON
 	ViewportConfig map[string]interface{} `json:"viewportConfig,omitempty"` // 画布视口配置
+	InputSchema    map[string]interface{} `json:"inputSchema,omitempty"`    // 执行输入的JSON Schema，传空对象表示取消校验
+	IsTemplate     *bool                  `json:"isTemplate,omitempty"`     // 标记或取消标记为模板
 }
 
 // PageWorkflowApplicationRequest 分页查询工作流应用请求结构
@@ -51,6 +64,8 @@ type PageWorkflowApplicationRequest struct {
 	Status    string `form:"status" json:"status"`       // 按状态过滤
 	BeginTime string `form:"beginTime" json:"beginTime"` // 开始时间
 	EndTime   string `form:"endTime" json:"endTime"`     // 结束时间
+	// IsTemplate 按是否为模板过滤，不传时返回全部
+	IsTemplate *bool `form:"isTemplate" json:"isTemplate"`
 }
 
 // PageWorkflowApplicationResponse 分页查询工作流应用响应结构
@@ -71,7 +86,7 @@ type WorkflowEdgeResponse struct {
 	TargetNodeID  string                 `json:"target"` // 目标节点数据库ID
 	SourceHandle  string                 `json:"sourceHandle,omitempty"`
 	TargetHandle  string                 `json:"targetHandle,omitempty"`
-	Type          string                 `json:"type"` // default, branch, parallel
+	Type          string                 `json:"type"` // default, branch, parallel, conditional（data.condition 为条件表达式）
 	Label         string                 `json:"label,omitempty"`
 	BranchName    string                 `json:"branchName,omitempty"`
 	Animated      bool                   `json:"animated"`
@@ -86,7 +101,7 @@ type CreateWorkflowEdgeRequest struct {
 	TargetNodeID  string                 `json:"target" binding:"required"` // 目标节点数据库ID
 	SourceHandle  string                 `json:"sourceHandle,omitempty"`
 	TargetHandle  string                 `json:"targetHandle,omitempty"`
-	Type          string                 `json:"type,omitempty"` // default, branch, parallel
+	Type          string                 `json:"type,omitempty"` // default, branch, parallel, conditional（data.condition 为条件表达式）
 	Label         string                 `json:"label,omitempty"`
 	BranchName    string                 `json:"branchName,omitempty"`
 	Animated      bool                   `json:"animated,omitempty"`
@@ -106,6 +121,12 @@ type UpdateWorkflowEdgeRequest struct {
 	Data         map[string]interface{} `json:"data,omitempty"`
 }
 
+// ReconnectWorkflowEdgeRequest 移动工作流边端点请求结构，未提供的一端保持不变
+type ReconnectWorkflowEdgeRequest struct {
+	SourceNodeID *string `json:"source,omitempty"` // 新的源节点数据库ID
+	TargetNodeID *string `json:"target,omitempty"` // 新的目标节点数据库ID
+}
+
 // PageWorkflowEdgeRequest 分页查询工作流边请求结构
 type PageWorkflowEdgeRequest struct {
 	PaginationRequest
@@ -113,8 +134,9 @@ type PageWorkflowEdgeRequest struct {
 	SourceNodeID  string `json:"sourceNodeId,omitempty" form:"sourceNodeId"`
 	TargetNodeID  string `json:"targetNodeId,omitempty" form:"targetNodeId"`
 	Type          string `json:"type,omitempty" form:"type"`
-	BeginTime     string `json:"beginTime,omitempty" form:"beginTime"`
-	EndTime       string `json:"endTime,omitempty" form:"endTime"`
+	BranchName    string `json:"branchName,omitempty" form:"branchName"` // 按分支名称精确匹配
+	BeginTime     string `json:"beginTime,omitempty" form:"beginTime"`   // 创建时间下限（RFC3339）
+	EndTime       string `json:"endTime,omitempty" form:"endTime"`       // 创建时间上限（RFC3339）
 }
 
 // PageWorkflowEdgeResponse 分页查询工作流边响应结构
@@ -130,7 +152,53 @@ type BatchCreateWorkflowEdgesRequest struct {
 
 // BatchDeleteWorkflowEdgesRequest 批量删除工作流边请求结构
 type BatchDeleteWorkflowEdgesRequest struct {
-	EdgeIDs []string `json:"edgeIds" binding:"required"`
+	EdgeIDs         []string `json:"edgeIds" binding:"required"`
+	ContinueOnError bool     `json:"continueOnError"` // 为 true 时逐项删除并收集结果，否则在事务中全部成功或全部回滚
+}
+
+// BatchDeleteWorkflowNodesRequest 批量删除工作流节点请求结构
+type BatchDeleteWorkflowNodesRequest struct {
+	NodeIDs         []string `json:"nodeIds" binding:"required"`
+	ContinueOnError bool     `json:"continueOnError"` // 为 true 时逐项删除并收集结果，否则在事务中全部成功或全部回滚
+}
+
+// BatchOperationItemResult 批量操作中单项的执行结果
+type BatchOperationItemResult struct {
+	ID      string `json:"id"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+// BatchOperationResult 批量操作结果汇总
+type BatchOperationResult struct {
+	Total     int                        `json:"total"`
+	Succeeded int                        `json:"succeeded"`
+	Failed    int                        `json:"failed"`
+	Items     []BatchOperationItemResult `json:"items"`
+}
+
+// BatchStatusRequest 批量修改工作流应用状态请求结构
+type BatchStatusRequest struct {
+	ApplicationIDs []string `json:"applicationIds" binding:"required,min=1"`
+	Status         string   `json:"status" binding:"required"` // 目标状态：draft, published, archived
+}
+
+// BatchStatusItemResult 批量修改状态中单个应用的结果
+type BatchStatusItemResult struct {
+	ID             string `json:"id"`
+	PreviousStatus string `json:"previousStatus,omitempty"` // 转换前的状态
+	Success        bool   `json:"success"`
+	Error          string `json:"error,omitempty"`
+	VersionID      string `json:"versionId,omitempty"` // 发布时创建的版本快照ID
+}
+
+// BatchStatusResult 批量修改工作流应用状态的结果汇总
+type BatchStatusResult struct {
+	Status    string                  `json:"status"` // 目标状态
+	Total     int                     `json:"total"`
+	Succeeded int                     `json:"succeeded"`
+	Failed    int                     `json:"failed"`
+	Items     []BatchStatusItemResult `json:"items"`
 }
 
 // ============ WorkflowNode Models ============
@@ -155,9 +223,30 @@ type WorkflowNodeResponse struct {
 	Async                 bool                   `json:"async"`
 	Timeout               int                    `json:"timeout"`
 	RetryCount            int                    `json:"retryCount"`
+	RetryPolicy           *RetryPolicy           `json:"retryPolicy,omitempty"` // 生效的重试策略，来自 config.retry_policy 或 retryCount
 	PositionX             float64                `json:"positionX"`
 	PositionY             float64                `json:"positionY"`
 	Color                 string                 `json:"color,omitempty"`
+	Enabled               bool                   `json:"enabled"` // 禁用的节点执行时被跳过
+}
+
+// RetryPolicy 节点重试策略，在节点 config.retry_policy 中配置（max_attempts、backoff、base_delay_ms、max_delay_ms、retry_on）
+type RetryPolicy struct {
+	MaxAttempts int      `json:"maxAttempts"`          // 最大尝试次数（含首次）
+	Backoff     string   `json:"backoff"`              // fixed, exponential
+	BaseDelayMs int      `json:"baseDelayMs"`          // 首次重试前的等待时间
+	MaxDelayMs  int      `json:"maxDelayMs,omitempty"` // 指数退避的等待上限，0 表示不限制
+	RetryOn     []string `json:"retryOn"`              // 可重试的错误类别：5xx, 429, timeout, network
+}
+
+// NodeAttempt 节点的一次失败尝试
+type NodeAttempt struct {
+	Attempt    int    `json:"attempt"`   // 第几次尝试，从1开始
+	Error      string `json:"error"`     // 尝试的错误信息
+	ErrorType  string `json:"errorType"` // 错误类别：5xx, 429, 4xx, timeout, network, validation
+	Retryable  bool   `json:"retryable"` // 按重试策略该错误是否可重试
+	DurationMs int64  `json:"durationMs"`
+	DelayMs    int64  `json:"delayMs,omitempty"` // 下一次尝试前的等待时间
 }
 
 // CreateWorkflowNodeRequest 创建工作流节点请求结构
@@ -180,6 +269,9 @@ type CreateWorkflowNodeRequest struct {
 	PositionX             *float64               `json:"positionX,omitempty"`
 	PositionY             *float64               `json:"positionY,omitempty"`
 	Color                 string                 `json:"color,omitempty"`
+	Enabled               *bool                  `json:"enabled,omitempty"` // 默认启用
+	// SkipConfigValidation 跳过按节点类型的配置校验，仅用于迁移旧数据
+	SkipConfigValidation bool `json:"skipConfigValidation,omitempty"`
 }
 
 // UpdateWorkflowNodeRequest 更新工作流节点请求结构
@@ -202,6 +294,25 @@ type UpdateWorkflowNodeRequest struct {
 	PositionX             *float64               `json:"positionX,omitempty"`
 	PositionY             *float64               `json:"positionY,omitempty"`
 	Color                 string                 `json:"color,omitempty"`
+	Enabled               *bool                  `json:"enabled,omitempty"` // 默认启用
+	// SkipConfigValidation 跳过按节点类型的配置校验，仅用于迁移旧数据
+	SkipConfigValidation bool `json:"skipConfigValidation,omitempty"`
+}
+
+// SetWorkflowNodeEnabledRequest 启用/禁用工作流节点请求结构
+type SetWorkflowNodeEnabledRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// MoveWorkflowNodeRequest 将节点移动到其他工作流应用请求结构
+type MoveWorkflowNodeRequest struct {
+	ApplicationID string `json:"applicationId" binding:"required"` // 目标应用ID
+}
+
+// MoveWorkflowNodeResponse 节点移动结果
+type MoveWorkflowNodeResponse struct {
+	Node           *WorkflowNodeResponse `json:"node"`
+	RemovedEdgeIDs []string              `json:"removedEdgeIds"` // 因跨应用被删除的边ID
 }
 
 // PageWorkflowNodeRequest 分页查询工作流节点请求结构
@@ -224,36 +335,143 @@ type PageWorkflowNodeResponse struct {
 
 // WorkflowExecutionResponse 工作流执行记录响应结构
 type WorkflowExecutionResponse struct {
-	ID             string                           `json:"id"`
-	CreateTime     string                           `json:"createTime"`
-	UpdateTime     string                           `json:"updateTime"`
-	ExecutionID    string                           `json:"executionId"`
-	ApplicationID  string                           `json:"applicationId"`
-	Status         string                           `json:"status"` // pending, running, completed, failed, cancelled, timeout
-	Input          map[string]interface{}           `json:"input,omitempty"`
-	Output         map[string]interface{}           `json:"output,omitempty"`
-	Context        map[string]interface{}           `json:"context,omitempty"`
-	StartedAt      *time.Time                       `json:"startedAt,omitempty"`
-	FinishedAt     *time.Time                       `json:"finishedAt,omitempty"`
-	DurationMs     int                              `json:"durationMs"`
-	TotalTokens    int                              `json:"totalTokens"`
-	TotalCost      float64                          `json:"totalCost"`
-	ErrorMessage   string                           `json:"errorMessage,omitempty"`
-	ErrorStack     string                           `json:"errorStack,omitempty"`
-	TriggeredBy    string                           `json:"triggeredBy,omitempty"`
-	TriggerSource  string                           `json:"triggerSource,omitempty"`
-	NodeExecutions []*WorkflowNodeExecutionResponse `json:"nodeExecutions,omitempty"`
+	ID            string                 `json:"id"`
+	CreateTime    string                 `json:"createTime"`
+	UpdateTime    string                 `json:"updateTime"`
+	ExecutionID   string                 `json:"executionId"`
+	ApplicationID string                 `json:"applicationId"`
+	Status        string                 `json:"status"` // pending, running, completed, failed, cancelled, timeout
+	Input         map[string]interface{} `json:"input,omitempty"`
+	Output        map[string]interface{} `json:"output,omitempty"`
+	Context       map[string]interface{} `json:"context,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"` // 本次执行生效的变量（应用变量合并执行上下文，执行上下文优先），敏感键已脱敏
+	StartedAt     *time.Time             `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time             `json:"finishedAt,omitempty"`
+	DurationMs    int                    `json:"durationMs"`
+	TotalTokens   int                    `json:"totalTokens"`
+	TotalCost     float64                `json:"totalCost"`
+	ErrorMessage  string                 `json:"errorMessage,omitempty"`
+	ErrorStack    string                 `json:"errorStack,omitempty"`
+	TriggeredBy   string                 `json:"triggeredBy,omitempty"`
+	TriggerSource string                 `json:"triggerSource,omitempty"`
+	// ParentExecutionID 由子工作流节点启动时为调用方执行的ID
+	ParentExecutionID string                           `json:"parentExecutionId,omitempty"`
+	NodeExecutions    []*WorkflowNodeExecutionResponse `json:"nodeExecutions,omitempty"`
 }
 
 // CreateWorkflowExecutionRequest 创建工作流执行请求结构
 type CreateWorkflowExecutionRequest struct {
 	ApplicationID string                 `json:"applicationId" binding:"required"`
 	Input         map[string]interface{} `json:"input,omitempty"`
-	Context       map[string]interface{} `json:"context,omitempty"`
+	Context       map[string]interface{} `json:"context,omitempty"` // 覆盖应用变量，同名键以此为准，值为 null 表示不使用该变量
 	TriggeredBy   string                 `json:"triggeredBy,omitempty"`
 	TriggerSource string                 `json:"triggerSource,omitempty"`
 }
 
+// ExecuteWorkflowApplicationRequest 执行工作流应用请求结构
+type ExecuteWorkflowApplicationRequest struct {
+	Input   map[string]interface{} `json:"input,omitempty"`
+	Context map[string]interface{} `json:"context,omitempty"` // 覆盖应用变量，同名键以此为准，值为 null 表示不使用该变量
+	// FromExecutionID 以该应用之前某次执行（执行ID）的输入为模板，Input 中的顶层键覆盖模板中的同名键
+	FromExecutionID string `json:"fromExecutionId,omitempty"`
+}
+
+// EstimateWorkflowCostRequest 执行成本预估请求结构
+type EstimateWorkflowCostRequest struct {
+	Input map[string]interface{} `json:"input,omitempty"` // 用于解析提示词中 {{key}} 占位符的执行输入
+}
+
+// CostEstimate 工作流执行成本预估（按最坏路径计算）
+type CostEstimate struct {
+	ApplicationID string              `json:"applicationId"`
+	Currency      string              `json:"currency"`
+	InputTokens   int                 `json:"inputTokens"`
+	OutputTokens  int                 `json:"outputTokens"`
+	TotalTokens   int                 `json:"totalTokens"`
+	EstimatedCost float64             `json:"estimatedCost"`
+	Nodes         []*NodeCostEstimate `json:"nodes"`              // 最坏路径上的 LLM 节点
+	Warnings      []string            `json:"warnings,omitempty"` // 如模型缺少价格配置
+}
+
+// NodeCostEstimate 单个 LLM 节点的成本预估
+type NodeCostEstimate struct {
+	NodeID        string  `json:"nodeId"`
+	NodeName      string  `json:"nodeName"`
+	Model         string  `json:"model"`
+	Iterations    int     `json:"iterations"`    // 预估执行次数（处于循环中时大于1）
+	InputTokens   int     `json:"inputTokens"`   // 所有迭代的输入token合计
+	OutputTokens  int     `json:"outputTokens"`  // 所有迭代的输出token合计
+	EstimatedCost float64 `json:"estimatedCost"` // 所有迭代的成本合计
+	PricingFound  bool    `json:"pricingFound"`  // 为false时模型未配置价格，成本按0计算
+}
+
+// NodeTypeDescriptor 节点类型描述，供编辑器动态渲染节点面板和配置表单
+type NodeTypeDescriptor struct {
+	Type           string                      `json:"type"`         // 节点类型标识，如 llm_caller
+	DisplayName    string                      `json:"displayName"`  // 显示名称
+	Description    string                      `json:"description"`  // 类型说明
+	DefaultColor   string                      `json:"defaultColor"` // 新建节点的默认颜色
+	ConnectionRule NodeConnectionRule          `json:"connectionRule"`
+	ConfigFields   []NodeConfigFieldDescriptor `json:"configFields"` // 该类型使用的配置字段
+	// ConfigSchema 节点配置的 JSON Schema，校验对象包含 prompt、config、apiConfig、branchNodes、parallelConfig、
+	// processorLanguage、processorCode、workflowApplicationId 等字段；为空表示该类型没有需要校验的配置
+	ConfigSchema map[string]interface{} `json:"configSchema,omitempty"`
+}
+
+// NodeConnectionRule 节点类型的连接规则
+type NodeConnectionRule struct {
+	CanHaveNextNode      bool `json:"canHaveNextNode"`      // 是否可以有普通出边
+	CanHaveBranches      bool `json:"canHaveBranches"`      // 是否可以有分支出边
+	CanBeParallel        bool `json:"canBeParallel"`        // 是否可以作为并行节点的子节点
+	RequiresBranchName   bool `json:"requiresBranchName"`   // 连接时是否需要分支名称
+	MaxOutputConnections int  `json:"maxOutputConnections"` // 最大输出连接数，-1表示无限制
+}
+
+// NodeConfigFieldDescriptor 节点配置字段描述
+type NodeConfigFieldDescriptor struct {
+	Key         string      `json:"key"`               // 字段路径，如 prompt、config.model、apiConfig.url
+	Type        string      `json:"type"`              // string, text, integer, number, boolean, object, array, any
+	Required    bool        `json:"required"`          // 是否必填
+	Description string      `json:"description"`       // 字段说明
+	Enum        []string    `json:"enum,omitempty"`    // 可选值
+	Default     interface{} `json:"default,omitempty"` // 默认值
+}
+
+// TestWorkflowNodeRequest 单节点试运行请求结构
+type TestWorkflowNodeRequest struct {
+	Input map[string]interface{} `json:"input,omitempty"` // 手动提供的节点输入
+}
+
+// NodeTestResult 单节点试运行结果，不产生执行记录
+type NodeTestResult struct {
+	NodeID     string                 `json:"nodeId"`
+	NodeName   string                 `json:"nodeName"`
+	NodeType   string                 `json:"nodeType"`
+	Status     string                 `json:"status"` // completed, failed, timeout
+	Input      map[string]interface{} `json:"input"`  // 解析后的实际输入，敏感字段已脱敏
+	Output     map[string]interface{} `json:"output,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	DurationMs int64                  `json:"durationMs"`
+	Model      string                 `json:"model,omitempty"` // LLM 节点实际使用的模型
+	Tokens     *NodeTestTokenUsage    `json:"tokens,omitempty"`
+	Cost       float64                `json:"cost"` // 按配置的价格表计算，模型未配置价格时为0
+	Currency   string                 `json:"currency,omitempty"`
+	Attempts   []NodeAttempt          `json:"attempts,omitempty"` // 按重试策略重试时每次失败尝试的记录
+}
+
+// NodeTestTokenUsage 单节点试运行的 token 用量
+type NodeTestTokenUsage struct {
+	PromptTokens     int `json:"promptTokens"`
+	CompletionTokens int `json:"completionTokens"`
+	TotalTokens      int `json:"totalTokens"`
+}
+
+// InputFieldError 执行输入的字段级校验错误
+type InputFieldError struct {
+	Field   string `json:"field"`   // 字段路径，如 user.name、items[0]
+	Message string `json:"message"` // 错误描述
+}
+
 // UpdateWorkflowExecutionRequest 更新工作流执行请求结构
 type UpdateWorkflowExecutionRequest struct {
 	Status       string                 `json:"status,omitempty"` // pending, running, completed, failed, cancelled, timeout
@@ -308,6 +526,28 @@ type WorkflowNodeExecutionResponse struct {
 	RetryCount        int                    `json:"retryCount"`
 	IsAsync           bool                   `json:"isAsync"`
 	ParentExecutionID string                 `json:"parentExecutionId,omitempty"`
+
+	Logs []*WorkflowExecutionLogResponse `json:"logs,omitempty"` // 节点执行关联的日志，仅单独查询节点执行时返回
+
+	// 直接上游和下游节点的执行，仅在查询节点执行详情时指定 withContext 返回
+	Upstream   []*WorkflowNodeExecutionContext `json:"upstream,omitempty"`
+	Downstream []*WorkflowNodeExecutionContext `json:"downstream,omitempty"`
+}
+
+// WorkflowNodeExecutionContext 与节点执行直接相连的另一个节点的执行，用于追踪数据的来源和去向。
+// 上游节点返回其输出（即本节点输入的来源），下游节点返回其输入；敏感值已脱敏
+type WorkflowNodeExecutionContext struct {
+	EdgeID          string                 `json:"edgeId"`
+	BranchName      string                 `json:"branchName,omitempty"`
+	NodeID          string                 `json:"nodeId"`
+	NodeName        string                 `json:"nodeName"`
+	NodeType        string                 `json:"nodeType"`
+	NodeExecutionID string                 `json:"nodeExecutionId,omitempty"` // 节点在本次执行中未运行时为空
+	Status          string                 `json:"status"`                    // 节点执行状态，未运行时为 not_executed
+	Input           map[string]interface{} `json:"input,omitempty"`
+	Output          map[string]interface{} `json:"output,omitempty"`
+	ErrorMessage    string                 `json:"errorMessage,omitempty"`
+	FinishedAt      *time.Time             `json:"finishedAt,omitempty"`
 }
 
 // CreateWorkflowNodeExecutionRequest 创建节点执行请求结构
@@ -354,6 +594,115 @@ type PageWorkflowNodeExecutionResponse struct {
 	Pagination Pagination                       `json:"pagination"`
 }
 
+// ============ Execution Report Models ============
+
+// ExecutionReport 单次执行的完整报告，包含执行记录、节点执行、日志以及相关节点的配置
+type ExecutionReport struct {
+	GeneratedAt     string                           `json:"generatedAt"`
+	ApplicationID   string                           `json:"applicationId"`
+	ApplicationName string                           `json:"applicationName"`
+	Execution       *WorkflowExecutionResponse       `json:"execution"`
+	NodeExecutions  []*WorkflowNodeExecutionResponse `json:"nodeExecutions"` // 按开始时间排序
+	Nodes           []*WorkflowNodeResponse          `json:"nodes"`          // 执行涉及的节点（敏感配置已脱敏）
+	Logs            []*WorkflowExecutionLogResponse  `json:"logs"`           // 按记录时间排序
+}
+
+// ExecutionPath 单次执行经过的路径，用于调试执行走向和统计分支覆盖
+type ExecutionPath struct {
+	ExecutionID     string                     `json:"executionId"`
+	ApplicationID   string                     `json:"applicationId"`
+	Status          string                     `json:"status"`
+	Steps           []*ExecutionPathStep       `json:"steps"`           // 按开始时间排序的节点执行
+	Branches        []*ExecutionBranchDecision `json:"branches"`        // 各条件节点选择的分支
+	UnmetConditions []*ExecutionUnmetCondition `json:"unmetConditions"` // 条件未成立（未沿其继续）的条件边
+	UnreachedNodes  []*ExecutionPathNode       `json:"unreachedNodes"`  // 没有任何执行记录的节点
+	VisitedCount    int                        `json:"visitedCount"`    // 有执行记录的节点数
+	TotalNodes      int                        `json:"totalNodes"`      // 工作流当前的节点总数
+	Coverage        float64                    `json:"coverage"`        // 节点覆盖率（0-1）
+}
+
+// ExecutionPathStep 路径中的一次节点执行
+type ExecutionPathStep struct {
+	NodeExecutionID string `json:"nodeExecutionId"`
+	NodeID          string `json:"nodeId"`
+	NodeName        string `json:"nodeName"`
+	NodeType        string `json:"nodeType"`
+	Status          string `json:"status"` // skipped 表示节点被禁用而跳过
+	StartedAt       string `json:"startedAt,omitempty"`
+	FinishedAt      string `json:"finishedAt,omitempty"`
+	ErrorMessage    string `json:"errorMessage,omitempty"`
+}
+
+// ExecutionBranchDecision 条件节点一次执行选择的分支
+type ExecutionBranchDecision struct {
+	NodeExecutionID  string   `json:"nodeExecutionId"`
+	NodeID           string   `json:"nodeId"`
+	NodeName         string   `json:"nodeName"`
+	BranchName       string   `json:"branchName"`       // 选择的分支，无法确定时为空
+	Inferred         bool     `json:"inferred"`         // 为true时分支由下游节点的执行记录推断，而非节点输出记录
+	NotTakenBranches []string `json:"notTakenBranches"` // 未选择的分支
+}
+
+// ExecutionUnmetCondition 节点执行后条件未成立的条件边
+type ExecutionUnmetCondition struct {
+	NodeExecutionID string `json:"nodeExecutionId"` // 源节点的执行记录
+	EdgeID          string `json:"edgeId"`
+	SourceNodeID    string `json:"sourceNodeId"`
+	TargetNodeID    string `json:"targetNodeId"`
+	Condition       string `json:"condition"`
+	Error           string `json:"error,omitempty"` // 表达式求值出错时的错误信息（视为不成立）
+	Inferred        bool   `json:"inferred"`        // 为true时由目标节点没有后续执行记录推断，而非引擎记录的求值结果
+}
+
+// ExecutionPathNode 路径统计中的节点
+type ExecutionPathNode struct {
+	NodeID   string `json:"nodeId"`
+	NodeName string `json:"nodeName"`
+	NodeType string `json:"nodeType"`
+}
+
+// ============ Execution Event Models ============
+
+// 执行事件类型
+const (
+	WorkflowEventExecutionStarted  = "execution_started"
+	WorkflowEventExecutionFinished = "execution_finished" // 执行进入终态，之后不会再有该执行的事件
+	WorkflowEventNodeStarted       = "node_started"
+	WorkflowEventNodeFinished      = "node_finished"
+	WorkflowEventNodeFailed        = "node_failed"
+)
+
+// WorkflowExecutionQueueStats 执行队列状态，未启用队列时 Enabled 为 false
+type WorkflowExecutionQueueStats struct {
+	Enabled       bool   `json:"enabled"`
+	Accepting     bool   `json:"accepting"`         // 是否接受新的执行，关闭服务期间为 false
+	Backend       string `json:"backend,omitempty"` // 队列存储：redis 或 local
+	Workers       int    `json:"workers"`           // 工作协程数
+	ActiveWorkers int64  `json:"activeWorkers"`     // 正在运行执行的工作协程数
+	Depth         int64  `json:"depth"`             // 排队中的执行数
+	Error         string `json:"error,omitempty"`   // 读取队列深度失败时的错误
+}
+
+// WorkflowExecutionEvent 通过WebSocket推送到 workflow/execution/{executionId} 的执行事件
+type WorkflowExecutionEvent struct {
+	Event            string     `json:"event"`
+	ExecutionID      string     `json:"executionId"`
+	ApplicationID    string     `json:"applicationId"`
+	NodeExecutionID  string     `json:"nodeExecutionId,omitempty"`
+	NodeID           string     `json:"nodeId,omitempty"`
+	NodeName         string     `json:"nodeName,omitempty"`
+	NodeType         string     `json:"nodeType,omitempty"`
+	Status           string     `json:"status"`
+	StartedAt        *time.Time `json:"startedAt,omitempty"`
+	FinishedAt       *time.Time `json:"finishedAt,omitempty"`
+	DurationMs       int        `json:"durationMs"`
+	PromptTokens     int        `json:"promptTokens"`
+	CompletionTokens int        `json:"completionTokens"`
+	TotalTokens      int        `json:"totalTokens"`
+	ErrorMessage     string     `json:"errorMessage,omitempty"`
+	Timestamp        int64      `json:"timestamp"`
+}
+
 // ============ WorkflowVersion Models ============
 
 // WorkflowVersionSnapshot 版本快照数据结构
@@ -371,12 +720,24 @@ type WorkflowVersionResponse struct {
 	Version       uint                    `json:"version"`
 	Snapshot      WorkflowVersionSnapshot `json:"snapshot"`
 	ChangeLog     string                  `json:"changeLog,omitempty"`
+	Pinned        bool                    `json:"pinned"`
 }
 
 // CreateWorkflowVersionRequest 创建工作流版本请求结构
 type CreateWorkflowVersionRequest struct {
 	ApplicationID string `json:"applicationId" binding:"required"`
 	ChangeLog     string `json:"changeLog,omitempty"` // 可选的变更日志
+	Pinned        bool   `json:"pinned,omitempty"`    // 是否置顶，置顶的版本不会被自动清理
+}
+
+// SetWorkflowVersionPinnedRequest 设置版本置顶状态请求结构
+type SetWorkflowVersionPinnedRequest struct {
+	Pinned bool `json:"pinned"`
+}
+
+// PruneWorkflowVersionsResponse 清理工作流版本响应结构
+type PruneWorkflowVersionsResponse struct {
+	Pruned int `json:"pruned"` // 被清理的版本数
 }
 
 // PageWorkflowVersionRequest 分页查询工作流版本请求结构
@@ -448,6 +809,91 @@ type PageWorkflowExecutionLogResponse struct {
 	Pagination Pagination                      `json:"pagination"`
 }
 
+// ============ WorkflowSchedule Models ============
+
+// WorkflowScheduleResponse 工作流定时调度响应结构
+type WorkflowScheduleResponse struct {
+	ID             string                 `json:"id"`
+	CreateTime     string                 `json:"createTime"`
+	UpdateTime     string                 `json:"updateTime"`
+	ApplicationID  string                 `json:"applicationId"`
+	Name           string                 `json:"name,omitempty"`
+	CronExpression string                 `json:"cronExpression"`
+	Input          map[string]interface{} `json:"input,omitempty"`
+	Enabled        bool                   `json:"enabled"`
+	LastRunAt      string                 `json:"lastRunAt,omitempty"`
+	NextRunAt      string                 `json:"nextRunAt,omitempty"`
+}
+
+// CreateWorkflowScheduleRequest 创建工作流定时调度请求结构
+type CreateWorkflowScheduleRequest struct {
+	Name           string                 `json:"name,omitempty"`
+	CronExpression string                 `json:"cronExpression" binding:"required"` // 标准5段Cron表达式，如 "0 */2 * * *"
+	Input          map[string]interface{} `json:"input,omitempty"`
+	Enabled        *bool                  `json:"enabled,omitempty"` // 默认启用
+}
+
+// UpdateWorkflowScheduleRequest 更新工作流定时调度请求结构
+type UpdateWorkflowScheduleRequest struct {
+	Name           string                 `json:"name,omitempty"`
+	CronExpression string                 `json:"cronExpression,omitempty"`
+	Input          map[string]interface{} `json:"input,omitempty"`
+}
+
+// ============ Execution Order Models ============
+
+// ExecutionOrderNode 执行顺序中的节点
+type ExecutionOrderNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// ExecutionLevel 执行层级，同一层级内的节点互不依赖，可以并行执行
+type ExecutionLevel struct {
+	Level  int                  `json:"level"`
+	Nodes  []ExecutionOrderNode `json:"nodes"`
+	Cyclic bool                 `json:"cyclic,omitempty"` // 为true时表示该组节点处于环路中（或只能经由环路到达），无法确定执行顺序
+}
+
+// ============ Graph Validation Models ============
+
+// 校验问题级别
+const (
+	ValidationLevelError   = "error"
+	ValidationLevelWarning = "warning"
+)
+
+// 校验问题代码，便于前端按类型展示
+const (
+	ValidationCodeMissingDefaultBranch = "missing_default_branch"
+	ValidationCodeDisabledBranch       = "disabled_branch"
+	ValidationCodeMissingConfig        = "missing_config"
+	ValidationCodeUnreachable          = "unreachable"
+	ValidationCodeDanglingBranchTarget = "dangling_branch_target"
+	ValidationCodeInvalidEdgeCondition = "invalid_edge_condition"
+)
+
+// WorkflowValidationIssue 工作流图校验发现的问题
+type WorkflowValidationIssue struct {
+	NodeID   string `json:"nodeId,omitempty"`
+	NodeName string `json:"nodeName,omitempty"`
+	Level    string `json:"level"` // error, warning
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message"`
+}
+
+// NodeConnectionsResponse 节点连接信息响应
+type NodeConnectionsResponse struct {
+	NodeID        string                  `json:"nodeId"`
+	NodeType      string                  `json:"nodeType"`
+	Incoming      []*WorkflowEdgeResponse `json:"incoming"`
+	Outgoing      []*WorkflowEdgeResponse `json:"outgoing"`
+	Branches      map[string][]string     `json:"branches,omitempty"`      // 分支名称 -> 目标节点ID（仅condition_checker）
+	DefaultBranch []string                `json:"defaultBranch,omitempty"` // 默认分支（default/else）的目标节点ID，没有条件成立时执行
+	Warnings      []string                `json:"warnings,omitempty"`
+}
+
 // ============ Batch Save Models ============
 
 // BatchSaveWorkflowRequest 批量保存工作流请求结构
@@ -461,6 +907,13 @@ type BatchSaveWorkflowRequest struct {
 	EdgesToCreate   []CreateWorkflowEdgeRequest `json:"edgesToCreate"`
 	EdgesToUpdate   []UpdateWorkflowEdgeWithID  `json:"edgesToUpdate"`
 	EdgeIDsToDelete []string                    `json:"edgeIdsToDelete"`
+	// LargeBatch 大批量模式：按块拆分为多个事务依次提交，允许更大的规模，但中途失败时已提交的块不会回滚
+	LargeBatch bool `json:"largeBatch,omitempty"`
+	// SkipDuplicateEdges 新增的边与已有边（或本次请求中的另一条边）的源节点、目标节点、连接点和分支完全相同时跳过而不是拒绝整个请求，
+	// 被跳过的边的临时ID映射到已存在的边
+	SkipDuplicateEdges bool `json:"skipDuplicateEdges,omitempty"`
+	// SkipConfigValidation 跳过新增和修改节点的配置校验，仅用于迁移旧数据
+	SkipConfigValidation bool `json:"skipConfigValidation,omitempty"`
 }
 
 // UpdateWorkflowNodeWithID 带ID的节点更新请求
@@ -483,19 +936,139 @@ type BatchSaveWorkflowStats struct {
 	EdgesCreated int `json:"edgesCreated"`
 	EdgesUpdated int `json:"edgesUpdated"`
 	EdgesDeleted int `json:"edgesDeleted"`
+	EdgesSkipped int `json:"edgesSkipped"` // 因重复而跳过的边
 }
 
 // BatchSaveWorkflowData 批量保存返回数据
 type BatchSaveWorkflowData struct {
-	NodeIDMapping  map[string]string       `json:"nodeIdMapping"` // 临时ID -> 数据库ID 映射
-	EdgeIDMapping  map[string]string       `json:"edgeIdMapping"` // 临时ID -> 数据库ID 映射
-	CreatedNodes   []*WorkflowNodeResponse `json:"createdNodes"`
-	UpdatedNodes   []*WorkflowNodeResponse `json:"updatedNodes"`
-	DeletedNodeIDs []string                `json:"deletedNodeIds"`
-	CreatedEdges   []*WorkflowEdgeResponse `json:"createdEdges"`
-	UpdatedEdges   []*WorkflowEdgeResponse `json:"updatedEdges"`
-	DeletedEdgeIDs []string                `json:"deletedEdgeIds"`
-	Stats          BatchSaveWorkflowStats  `json:"stats"`
+	NodeIDMapping      map[string]string       `json:"nodeIdMapping"` // 临时ID -> 数据库ID 映射
+	EdgeIDMapping      map[string]string       `json:"edgeIdMapping"` // 临时ID -> 数据库ID 映射
+	CreatedNodes       []*WorkflowNodeResponse `json:"createdNodes"`
+	UpdatedNodes       []*WorkflowNodeResponse `json:"updatedNodes"`
+	DeletedNodeIDs     []string                `json:"deletedNodeIds"`
+	CreatedEdges       []*WorkflowEdgeResponse `json:"createdEdges"`
+	UpdatedEdges       []*WorkflowEdgeResponse `json:"updatedEdges"`
+	DeletedEdgeIDs     []string                `json:"deletedEdgeIds"`
+	SkippedEdgeTempIDs []string                `json:"skippedEdgeTempIds"` // 因重复而跳过的边的临时ID
+	Stats              BatchSaveWorkflowStats  `json:"stats"`
+}
+
+// DedupeWorkflowEdgesResponse 清理重复边的结果
+type DedupeWorkflowEdgesResponse struct {
+	ApplicationID string `json:"applicationId"`
+	Removed       int    `json:"removed"`
+}
+
+// ============ Subgraph Duplicate Models ============
+
+// Position 画布坐标
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// DuplicateSubgraphRequest 复制节点子图请求结构
+type DuplicateSubgraphRequest struct {
+	NodeIDs []string `json:"nodeIds" binding:"required,min=1"` // 要复制的节点ID
+	Offset  Position `json:"offset"`                           // 复制出的节点相对原节点的位置偏移
+}
+
+// InstantiateWorkflowTemplateRequest 实例化模板请求结构
+type InstantiateWorkflowTemplateRequest struct {
+	Name string `json:"name"` // 新应用名称，为空时使用模板名称
+}
+
+// ============ Application Share Models ============
+
+// ShareWorkflowApplicationRequest 共享工作流应用请求结构
+type ShareWorkflowApplicationRequest struct {
+	UserID string `json:"userId" binding:"required"`
+	Access string `json:"access" binding:"required,oneof=read edit"` // read 只读，edit 可编辑
+}
+
+// WorkflowApplicationShareResponse 工作流应用共享记录
+type WorkflowApplicationShareResponse struct {
+	UserID     string `json:"userId"`
+	Access     string `json:"access"`
+	UpdateTime string `json:"updateTime"`
+}
+
+// ============ Auto Layout Models ============
+
+// LayoutOptions 自动布局参数，未填写的间距使用默认值
+type LayoutOptions struct {
+	Direction string   `json:"direction"` // 布局方向：LR（从左到右，默认）或 TB（从上到下）
+	LevelGap  float64  `json:"levelGap"`  // 相邻层级之间的距离
+	NodeGap   float64  `json:"nodeGap"`   // 同一层级内相邻节点之间的距离
+	Origin    Position `json:"origin"`    // 布局左上角的坐标
+	Persist   bool     `json:"persist"`   // 是否保存计算出的节点位置
+}
+
+// AutoLayoutWorkflowResponse 自动布局响应结构
+type AutoLayoutWorkflowResponse struct {
+	ApplicationID string              `json:"applicationId"`
+	Positions     map[string]Position `json:"positions"` // 节点ID -> 计算出的位置
+	Persisted     bool                `json:"persisted"` // 位置是否已保存
+}
+
+// ============ Application Diff Models ============
+
+// 应用对比中边的差异状态
+const (
+	WorkflowDiffLeftOnly  = "left_only"
+	WorkflowDiffRightOnly = "right_only"
+	WorkflowDiffChanged   = "changed"
+)
+
+// WorkflowApplicationDiff 两个工作流应用的对比结果
+type WorkflowApplicationDiff struct {
+	Left           WorkflowDiffApplication `json:"left"`
+	Right          WorkflowDiffApplication `json:"right"`
+	Identical      bool                    `json:"identical"`      // 节点和边均没有差异
+	Nodes          []WorkflowNodeDiff      `json:"nodes"`          // 两侧都存在但内容不同的节点
+	UnmatchedNodes []WorkflowUnmatchedNode `json:"unmatchedNodes"` // 只存在于一侧的节点
+	Edges          []WorkflowEdgeDiff      `json:"edges"`          // 只存在于一侧或内容不同的边
+}
+
+// WorkflowDiffApplication 参与对比的应用
+type WorkflowDiffApplication struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WorkflowFieldChange 字段差异，Left/Right 为 nil 表示该侧没有此字段
+type WorkflowFieldChange struct {
+	Field string `json:"field"` // 字段名，JSON配置按层级展开，如 config.model
+	Left  any    `json:"left"`
+	Right any    `json:"right"`
+}
+
+// WorkflowNodeDiff 按名称和类型匹配上的节点之间的差异
+type WorkflowNodeDiff struct {
+	Name        string                `json:"name"`
+	Type        string                `json:"type"`
+	LeftNodeID  string                `json:"leftNodeId"`
+	RightNodeID string                `json:"rightNodeId"`
+	Changes     []WorkflowFieldChange `json:"changes"`
+}
+
+// WorkflowUnmatchedNode 只存在于一侧的节点
+type WorkflowUnmatchedNode struct {
+	Side   string `json:"side"` // left 或 right
+	NodeID string `json:"nodeId"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+}
+
+// WorkflowEdgeDiff 边的差异，边按两端匹配后的节点和分支名称对应
+type WorkflowEdgeDiff struct {
+	Status      string                `json:"status"`     // left_only、right_only 或 changed
+	SourceName  string                `json:"sourceName"` // 源节点名称
+	TargetName  string                `json:"targetName"` // 目标节点名称
+	BranchName  string                `json:"branchName,omitempty"`
+	LeftEdgeID  string                `json:"leftEdgeId,omitempty"`
+	RightEdgeID string                `json:"rightEdgeId,omitempty"`
+	Changes     []WorkflowFieldChange `json:"changes,omitempty"`
 }
 
 // BatchSaveWorkflowResponse 批量保存工作流响应结构
@@ -504,3 +1077,20 @@ type BatchSaveWorkflowResponse struct {
 	Message string                 `json:"message"`
 	Data    *BatchSaveWorkflowData `json:"data"`
 }
+
+// ============ WorkflowSecret Models ============
+
+// WorkflowSecretResponse 应用密钥响应结构，不包含密钥值
+type WorkflowSecretResponse struct {
+	ID            string `json:"id"`
+	CreateTime    string `json:"createTime"`
+	UpdateTime    string `json:"updateTime"`
+	ApplicationID string `json:"applicationId"`
+	Key           string `json:"key"`
+	Reference     string `json:"reference"` // 在节点配置中引用该密钥的写法，如 ${secrets.OPENAI_API_KEY}
+}
+
+// SetWorkflowSecretRequest 设置应用密钥请求结构
+type SetWorkflowSecretRequest struct {
+	Value string `json:"value" binding:"required"` // 密钥值，保存时加密，之后不会再返回
+}